@@ -48,6 +48,7 @@ func NewIAMServiceBundle(cfg *config.Config, opts IAMServiceOptions) (*IAMServic
 	}
 	enforcer.EnableAutoSave(opts.EnableAutoSave)
 
+	auditLogRepo := repository.NewBunAuditLogRepository(db)
 	deps := iam.IAMServiceDependencies{
 		Users:           repository.NewBunUserRepository(db),
 		ServiceAccounts: repository.NewBunServiceAccountRepository(db),
@@ -57,6 +58,14 @@ func NewIAMServiceBundle(cfg *config.Config, opts IAMServiceOptions) (*IAMServic
 		Roles:           repository.NewBunRoleRepository(db),
 		RevokedJTIs:     repository.NewBunRevokedJTIRepository(db),
 		Enforcer:        enforcer,
+		States:          repository.NewBunStateRepository(db),
+		RevocationEpoch: auth.NewRevocationEpochCache(repository.NewBunRevocationEpochRepository(db), cfg.RevocationEpochTime()),
+		AuditLogger:     iam.NewAuditLogger(auditLogRepo),
+		AuditLogs:       auditLogRepo,
+		UserGroups:      repository.NewBunUserGroupRepository(db),
+	}
+	if cfg.OIDC.IsInternalIdPMode() {
+		deps.GroupSizes = iam.NewUserGroupSizeEstimator(deps.UserGroups)
 	}
 
 	iamService, err := iam.NewIAMService(deps, iam.IAMServiceConfig{Config: cfg})
@@ -2,8 +2,8 @@ package iam
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
@@ -17,13 +17,17 @@ var (
 // IamCmd is the parent command for iam operations
 var IamCmd = &cobra.Command{
 	Use:   "iam",
-	Short: "Manage External IdP group claims to roles",
+	Short: "Manage External IdP group claims to roles and review role assignments",
 	Long:  `Commands for managing iam mappings for External IdP mode.`,
 }
 
 func init() {
 	IamCmd.AddCommand(bootstrapCmd)
 	bootstrapCmd.Flags().StringSliceVar(&rolesInput, "role", []string{}, "Role(s) to assign to the group claim")
+
+	IamCmd.AddCommand(accessReviewCmd)
+	accessReviewCmd.Flags().StringVar(&accessReviewFormat, "format", "json", "Output format: json or csv")
+	accessReviewCmd.Flags().StringVarP(&accessReviewOutput, "output", "o", "", "Write to this file instead of stdout")
 }
 
 func assignRolesToGroup(ctx context.Context, groupName string, roles []models.Role, iamService iam.Service) error {
@@ -31,9 +35,9 @@ func assignRolesToGroup(ctx context.Context, groupName string, roles []models.Ro
 
 	for _, role := range roles {
 		// Use IAM service to assign role (handles DB write, Casbin sync, and cache refresh)
-		if err := iamService.AssignGroupRole(ctx, groupName, role.ID); err != nil {
+		if err := iamService.AssignGroupRole(ctx, groupName, role.ID, ""); err != nil {
 			// Check if it's a duplicate assignment (not a fatal error)
-			if strings.Contains(err.Error(), "role already assigned to group") {
+			if errors.Is(err, iam.ErrDuplicateAssignment) {
 				fmt.Printf("  Role '%s' already assigned to group '%s', skipping\n", role.Name, groupName)
 				continue
 			}
@@ -0,0 +1,56 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/cmd/cmdutil"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+)
+
+// reconcileCmd repairs Casbin role assignments that diverged from the database
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Repair Casbin role assignments that diverged from the database",
+	Long: `Compare the user_roles and group_roles tables with Casbin's grouping rules
+and repair differences: assignments missing from Casbin are added and Casbin
+assignments without a database row are removed. The server also does this on
+startup.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		bundle, err := cmdutil.NewIAMServiceBundle(cfg, cmdutil.IAMServiceOptions{
+			EnableAutoSave: true,
+		})
+		if err != nil {
+			return err
+		}
+		defer bundle.Close()
+
+		result, err := bundle.Service.ReconcileRoleAssignments(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile role assignments: %w", err)
+		}
+
+		for _, c := range result.Corrections {
+			if c.Added {
+				fmt.Printf("+ %s → %s\n", c.Principal, c.Role)
+			} else {
+				fmt.Printf("- %s → %s\n", c.Principal, c.Role)
+			}
+		}
+		if result.Skipped > 0 {
+			fmt.Printf("Skipped %d assignment(s) of missing principals\n", result.Skipped)
+		}
+		fmt.Printf("✓ %d correction(s) applied\n", len(result.Corrections))
+		return nil
+	},
+}
@@ -0,0 +1,78 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/cmd/cmdutil"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+)
+
+var reconcileRolesRepair bool
+
+// reconcileRolesCmd diffs role policies against the roles table
+var reconcileRolesCmd = &cobra.Command{
+	Use:   "reconcile-roles",
+	Short: "Show (and optionally repair) role policies that drifted from the roles table",
+	Long: `Compare each role's Casbin policies with the actions and label scope stored
+on the role, e.g. after a role update failed halfway through. Drift is printed
+for review; --repair rewrites the drifted roles' policies and removes policies
+of deleted roles. Roles created before actions were recorded are listed as
+unverified and left alone.
+
+Example:
+  gridapi iam reconcile-roles
+  gridapi iam reconcile-roles --repair
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		bundle, err := cmdutil.NewIAMServiceBundle(cfg, cmdutil.IAMServiceOptions{
+			EnableAutoSave: reconcileRolesRepair,
+		})
+		if err != nil {
+			return err
+		}
+		defer bundle.Close()
+
+		result, err := bundle.Service.ReconcileRolePolicies(ctx, reconcileRolesRepair)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile role policies: %w", err)
+		}
+
+		for _, drift := range result.Drift {
+			if drift.Deleted {
+				fmt.Printf("Role '%s' (deleted):\n", drift.Role)
+			} else {
+				fmt.Printf("Role '%s':\n", drift.Role)
+			}
+			for _, a := range drift.Missing {
+				fmt.Printf("  + %s [%s]\n", a.Action, a.ScopeExpr)
+			}
+			for _, a := range drift.Unexpected {
+				fmt.Printf("  - %s [%s]\n", a.Action, a.ScopeExpr)
+			}
+		}
+		if len(result.Unverified) > 0 {
+			fmt.Printf("Unverified (no stored actions): %s\n", strings.Join(result.Unverified, ", "))
+		}
+
+		switch {
+		case len(result.Drift) == 0:
+			fmt.Println("✓ Role policies match the roles table")
+		case result.Repaired:
+			fmt.Printf("✓ Repaired %d role(s)\n", len(result.Drift))
+		default:
+			fmt.Printf("%d role(s) drifted; re-run with --repair to fix\n", len(result.Drift))
+		}
+		return nil
+	},
+}
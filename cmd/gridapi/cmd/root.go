@@ -49,6 +49,7 @@ func init() {
 	rootCmd.PersistentFlags().String("db-url", "", "Database connection URL (GRID_DATABASE_URL)")
 	rootCmd.PersistentFlags().String("server-addr", "", "Server bind address (GRID_SERVER_ADDR)")
 	rootCmd.PersistentFlags().String("server-url", "", "Server base URL for backend config (GRID_SERVER_URL)")
+	rootCmd.PersistentFlags().String("backend-url", "", "External base URL for Terraform backend config, defaults to server URL (GRID_BACKEND_URL)")
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug logging (GRID_DEBUG)")
 	rootCmd.PersistentFlags().Int("max-db-connections", 0, "Max DB connections (GRID_MAX_DB_CONNECTIONS)")
 
@@ -56,6 +57,7 @@ func init() {
 	viper.BindPFlag("database_url", rootCmd.PersistentFlags().Lookup("db-url"))
 	viper.BindPFlag("server_addr", rootCmd.PersistentFlags().Lookup("server-addr"))
 	viper.BindPFlag("server_url", rootCmd.PersistentFlags().Lookup("server-url"))
+	viper.BindPFlag("backend_url", rootCmd.PersistentFlags().Lookup("backend-url"))
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
 	viper.BindPFlag("max_db_connections", rootCmd.PersistentFlags().Lookup("max-db-connections"))
 
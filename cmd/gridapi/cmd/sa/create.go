@@ -47,33 +47,18 @@ var createCmd = &cobra.Command{
 		ctx := context.Background()
 		iamService := bundle.Service
 
-		roles, invalidRoles, validRoleNames, err := iamService.GetRolesByName(ctx, rolesInput)
-		if err != nil {
-			return fmt.Errorf("failed to fetch roles: %w", err)
-		}
-		if len(invalidRoles) > 0 {
-			return fmt.Errorf("invalid role(s): %s\nValid roles are: %s",
-				strings.Join(invalidRoles, ", "),
-				strings.Join(validRoleNames, ", "))
-		}
-
-		sa, clientSecret, err := iamService.CreateServiceAccount(ctx, name, auth.SystemUserID)
+		// Roles are assigned in the same operation; an invalid role or failed
+		// assignment leaves no account behind
+		sa, clientSecret, roles, err := iamService.CreateServiceAccount(ctx, name, auth.SystemUserID, rolesInput)
 		if err != nil {
 			return fmt.Errorf("failed to create service account: %w", err)
 		}
 
-		roleIDs := make([]string, len(roles))
-		for i, role := range roles {
-			roleIDs[i] = role.ID
-		}
-		if err := iamService.AssignRolesToServiceAccount(ctx, sa.ID, roleIDs); err != nil {
-			return fmt.Errorf("failed to assign roles: %w", err)
-		}
-
 		fmt.Println("Service Account created successfully!")
 		fmt.Println("----------------------------------------")
 		fmt.Printf("Client ID: %s\n", sa.ClientID)
 		fmt.Printf("Client Secret: %s\n", clientSecret)
+		fmt.Printf("Roles: %s\n", strings.Join(roles, ", "))
 		fmt.Println("----------------------------------------")
 		fmt.Println("Save the client secret securely. It will not be shown again.")
 
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/spf13/cobra"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/bunx"
 	gridmiddleware "github.com/terraconstructs/grid/cmd/gridapi/internal/middleware"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/migrations"
@@ -26,6 +29,7 @@ import (
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/inference"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/validation"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/telemetry"
 	"github.com/uptrace/bun/migrate"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
@@ -36,6 +40,23 @@ var serveCmd = &cobra.Command{
 	Short: "Start the Grid API server",
 	Long:  `Starts the HTTP server with Connect RPC and Terraform HTTP Backend endpoints.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Install the OpenTelemetry SDK before anything is instrumented
+		shutdownTelemetry, err := telemetry.Setup(cmd.Context(), telemetry.Options{
+			Exporter:       cfg.Telemetry.Exporter,
+			ServiceName:    cfg.Telemetry.ServiceName,
+			MetricInterval: cfg.Telemetry.MetricInterval,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set up telemetry: %w", err)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTelemetry(ctx); err != nil {
+				log.Printf("WARNING: telemetry shutdown failed: %v", err)
+			}
+		}()
+
 		// Connect to database
 		db, err := bunx.NewDB(cfg.DatabaseURL)
 		if err != nil {
@@ -75,42 +96,70 @@ var serveCmd = &cobra.Command{
 		stateRepo := repository.NewBunStateRepository(db)
 		edgeRepo := repository.NewBunEdgeRepository(db)
 		outputRepo := repository.NewBunStateOutputRepository(db)
+		schemaRegistryRepo := repository.NewBunSchemaRegistryRepository(db)
 		labelPolicyRepo := repository.NewBunLabelPolicyRepository(db)
 		userRepo := repository.NewBunUserRepository(db)
 		userRoleRepo := repository.NewBunUserRoleRepository(db)
+		userGroupRepo := repository.NewBunUserGroupRepository(db)
 		serviceAccountRepo := repository.NewBunServiceAccountRepository(db)
 		sessionRepo := repository.NewBunSessionRepository(db)
 		roleRepo := repository.NewBunRoleRepository(db)
 		groupRoleRepo := repository.NewBunGroupRoleRepository(db)
 		revokedJTIRepo := repository.NewBunRevokedJTIRepository(db)
+		// Shared by the OIDC provider and the IAM service so an epoch bump
+		// applies to both at once
+		revocationEpoch := auth.NewRevocationEpochCache(repository.NewBunRevocationEpochRepository(db), cfg.RevocationEpochTime())
+		auditLogRepo := repository.NewBunAuditLogRepository(db)
+		idempotencyKeyRepo := repository.NewBunStateIdempotencyKeyRepository(db)
+		deviceAuthorizationRepo := repository.NewBunDeviceAuthorizationRepository(db)
 
 		// Initialize inference service
 		inferrer := inference.NewInferrer()
 
+		// Compile logic_id naming rule (validated by config.Load)
+		var logicIDPattern *regexp.Regexp
+		if cfg.StateNaming.LogicIDPattern != "" {
+			logicIDPattern = regexp.MustCompile(cfg.StateNaming.LogicIDPattern)
+		}
+
 		// Initialize services
-		svc := state.NewService(stateRepo, cfg.ServerURL).
+		svc := state.NewService(stateRepo, cfg.BackendBaseURL()).
 			WithOutputRepository(outputRepo).
 			WithEdgeRepository(edgeRepo).
 			WithPolicyRepository(labelPolicyRepo).
-			WithInferrer(inferrer)
-		depService := dependency.NewService(edgeRepo, stateRepo).
-			WithOutputRepository(outputRepo)
-		edgeUpdater := server.NewEdgeUpdateJob(edgeRepo, stateRepo)
+			WithSchemaRegistry(schemaRegistryRepo).
+			WithInferrer(inferrer).
+			WithLogicIDRules(logicIDPattern, cfg.StateNaming.LogicIDMaxLength).
+			WithMaxOutputsPerState(cfg.MaxOutputsPerState).
+			WithIdempotencyKeys(idempotencyKeyRepo, cfg.IdempotencyKeyTTL)
+
+		// Delete expired idempotency keys in the background
+		purgeCtx, cancelPurge := context.WithCancel(cmd.Context())
+		defer cancelPurge()
+		go svc.PurgeExpiredIdempotencyKeys(purgeCtx)
 
 		// Create validation service and job
 		validator, err := validation.NewSchemaValidator(1000) // LRU cache with 1000 entries
 		if err != nil {
 			return fmt.Errorf("create schema validator: %w", err)
 		}
+		depService := dependency.NewService(edgeRepo, stateRepo).
+			WithOutputRepository(outputRepo).
+			WithValidator(validator)
+		edgeUpdater := server.NewEdgeUpdateJob(edgeRepo, stateRepo)
 		validationJob := server.NewSchemaValidationJob(outputRepo, validator, 0) // 0 = use default 30s timeout
 
 		policyService := state.NewPolicyService(labelPolicyRepo, state.NewPolicyValidator())
 
+		// WatchStateChanges: the polling source only runs while a stream is open
+		stateWatchHub := server.NewStateWatchHub(server.NewPollingStateChangeSource(stateRepo, edgeRepo, cfg.StateWatchPollInterval))
+
 		var chiMiddleware []func(http.Handler) http.Handler
 		var connectInterceptors []connect.Interceptor
 		var oidcRouter chi.Router
 		var relyingParty *auth.RelyingParty
 		var provider *auth.Provider
+		var passwordPolicy *auth.PasswordPolicy
 
 		// Phase 6 Note: AuthnDependencies still used by auth handlers
 		// (HandleInternalLogin, HandleSSOCallback, HandleWhoAmI, HandleLogout)
@@ -126,8 +175,45 @@ var serveCmd = &cobra.Command{
 			Enforcer:        nil, // Set below if OIDC enabled
 		}
 
+		// Calls to the external IdP share one transport so retries, the circuit
+		// breaker and cached JWKS cover both SSO and bearer token validation.
+		var idpTransport *auth.IdPTransport
+		var idpHTTPClient *http.Client
+		var jwksCaches map[string]*auth.JWKSCache
 		if cfg.OIDC.ExternalIdP != nil {
-			rp, err := auth.NewRelyingParty(cmd.Context(), cfg.OIDC.ExternalIdP)
+			idpTransport = auth.NewIdPTransport(nil)
+			idpHTTPClient = idpTransport.Client()
+
+			// Bearer tokens are checked against keys held in memory, refreshed
+			// in the background and on an unknown kid
+			jwksURI, err := auth.DiscoverJWKSURI(cmd.Context(), idpHTTPClient, cfg.OIDC.ExternalIdP.Issuer)
+			if err != nil {
+				return fmt.Errorf("discover external idp jwks: %w", err)
+			}
+			jwksCache := auth.NewJWKSCache(idpTransport, jwksURI)
+			if err := jwksCache.Refresh(cmd.Context()); err != nil {
+				return fmt.Errorf("load external idp jwks: %w", err)
+			}
+			jwksCaches = map[string]*auth.JWKSCache{cfg.OIDC.ExternalIdP.Issuer: jwksCache}
+
+			// Trusted issuers only accept bearer tokens, so one being down
+			// at startup is not fatal; its keys load on first use. Each gets
+			// its own transport so its failures don't open the SSO circuit.
+			for _, trusted := range cfg.OIDC.ExternalIdP.TrustedIssuers {
+				cache := auth.NewJWKSCache(auth.NewIdPTransport(nil).WithJWKSURI(trusted.JWKSURI), trusted.JWKSURI)
+				if err := cache.Refresh(cmd.Context()); err != nil {
+					log.Printf("WARNING: load jwks for trusted issuer %s: %v", trusted.Issuer, err)
+				}
+				jwksCaches[trusted.Issuer] = cache
+			}
+
+			jwksCtx, cancelJWKS := context.WithCancel(cmd.Context())
+			defer cancelJWKS()
+			for _, cache := range jwksCaches {
+				go cache.Run(jwksCtx, cfg.OIDC.ExternalIdP.JWKSRefreshInterval)
+			}
+
+			rp, err := auth.NewRelyingParty(cmd.Context(), cfg.OIDC.ExternalIdP, idpHTTPClient)
 			if err != nil {
 				return fmt.Errorf("failed to create relying party: %w", err)
 			}
@@ -136,9 +222,16 @@ var serveCmd = &cobra.Command{
 
 		if cfg.OIDC.Issuer != "" {
 			provider, err = auth.NewOIDCProvider(cmd.Context(), cfg.OIDC, auth.ProviderDependencies{
-				Users:           userRepo,
-				ServiceAccounts: serviceAccountRepo,
-				Sessions:        sessionRepo,
+				Users:                userRepo,
+				ServiceAccounts:      serviceAccountRepo,
+				Sessions:             sessionRepo,
+				DeviceAuthorizations: deviceAuthorizationRepo,
+				UserRoles:            userRoleRepo,
+				Roles:                roleRepo,
+				UserGroups:           userGroupRepo,
+				AuditLogs:            auditLogRepo,
+				RevokedJTIs:          revokedJTIRepo,
+				RevocationEpoch:      revocationEpoch,
 			})
 			if err != nil && !errors.Is(err, auth.ErrOIDCDisabled) {
 				return fmt.Errorf("configure oidc provider: %w", err)
@@ -147,6 +240,11 @@ var serveCmd = &cobra.Command{
 				oidcRouter = provider.Router
 				log.Printf("OIDC router created")
 			}
+
+			passwordPolicy, err = auth.NewPasswordPolicy(cfg.PasswordPolicy)
+			if err != nil {
+				return fmt.Errorf("configure password policy: %w", err)
+			}
 		}
 
 		oidcEnabled := cfg.OIDC.ExternalIdP != nil || cfg.OIDC.Issuer != ""
@@ -159,25 +257,40 @@ var serveCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("configure casbin enforcer: %w", err)
 			}
-			// Phase 4: Disable AutoSave - we no longer mutate Casbin state
-			// Authorization is now read-only (uses Principal.Roles, no AddGroupingPolicy)
-			enforcer.EnableAutoSave(false)
 			authnDeps.Enforcer = enforcer
 
+			iamDeps := iam.IAMServiceDependencies{
+				Users:           userRepo,
+				ServiceAccounts: serviceAccountRepo,
+				Sessions:        sessionRepo,
+				UserRoles:       userRoleRepo,
+				GroupRoles:      groupRoleRepo,
+				Roles:           roleRepo,
+				RevokedJTIs:     revokedJTIRepo,
+				Enforcer:        enforcer,
+				States:          stateRepo,
+				RevocationEpoch: revocationEpoch,
+				AuditLogger:     iam.NewAuditLogger(auditLogRepo),
+				AuditLogs:       auditLogRepo,
+				UserGroups:      userGroupRepo,
+			}
+			if provider != nil {
+				iamDeps.RefreshTokens = provider
+			}
+			if cfg.OIDC.IsInternalIdPMode() {
+				// Grid records internal IdP group memberships, so capped roles
+				// can be mapped to groups; external IdP groups cannot be sized
+				iamDeps.GroupSizes = iam.NewUserGroupSizeEstimator(userGroupRepo)
+			}
+
 			// Phase 3: Create IAM service (replaces scattered auth logic)
 			iamService, err = iam.NewIAMService(
-				iam.IAMServiceDependencies{
-					Users:           userRepo,
-					ServiceAccounts: serviceAccountRepo,
-					Sessions:        sessionRepo,
-					UserRoles:       userRoleRepo,
-					GroupRoles:      groupRoleRepo,
-					Roles:           roleRepo,
-					RevokedJTIs:     revokedJTIRepo,
-					Enforcer:        enforcer,
-				},
+				iamDeps,
 				iam.IAMServiceConfig{
-					Config: cfg,
+					Config:         cfg,
+					IdPHTTPClient:  idpHTTPClient,
+					JWKSCaches:     jwksCaches,
+					PasswordPolicy: passwordPolicy,
 				},
 			)
 			if err != nil {
@@ -185,6 +298,51 @@ var serveCmd = &cobra.Command{
 			}
 			log.Printf("IAM service initialized with authenticators")
 
+			// The internal IdP's roles claim includes group-mapped roles.
+			// Without token claims only unconditional group mappings apply.
+			if provider != nil {
+				provider.WithRoleResolver(func(ctx context.Context, userID string, groups []string) ([]string, error) {
+					return iamService.ResolveRoles(ctx, userID, groups, nil, true)
+				})
+			}
+
+			// Rewrite roles still relying on an empty scope meaning "all states".
+			// Runs while AutoSave is on so the rewritten policies are persisted,
+			// as does the role assignment reconciliation below.
+			migratedRoles, err := iamService.MigrateEmptyRoleScopes(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("migrate empty role scopes: %w", err)
+			}
+			if migratedRoles > 0 {
+				log.Printf("Migrated %d role(s) with an empty label scope (empty_role_scope=%s)", migratedRoles, cfg.EmptyRoleScope)
+			}
+
+			// Repair Casbin groupings that diverged from user_roles/group_roles
+			// (e.g. a crash between an assignment's DB write and Casbin sync).
+			reconciliation, err := iamService.ReconcileRoleAssignments(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("reconcile role assignments: %w", err)
+			}
+			if n := len(reconciliation.Corrections); n > 0 {
+				log.Printf("Reconciled %d Casbin role assignment(s) with the database", n)
+			}
+
+			if cfg.RolePolicyReconcile != config.RolePolicyReconcileOff {
+				repair := cfg.RolePolicyReconcile == config.RolePolicyReconcileRepair
+				policyReconciliation, err := iamService.ReconcileRolePolicies(cmd.Context(), repair)
+				if err != nil {
+					return fmt.Errorf("reconcile role policies: %w", err)
+				}
+				if n := len(policyReconciliation.Drift); n > 0 {
+					log.Printf("Found %d role(s) whose Casbin policies drifted (repaired=%t); run 'gridapi iam reconcile-roles' to review",
+						n, policyReconciliation.Repaired)
+				}
+			}
+
+			// Phase 4: Disable AutoSave - we no longer mutate Casbin state
+			// Authorization is now read-only (uses Principal.Roles, no AddGroupingPolicy)
+			enforcer.EnableAutoSave(false)
+
 			// Phase 7: Start background cache refresh goroutine
 			// Refreshes group→role cache periodically to pick up changes
 			// Default interval: 5 minutes (configurable via GRID_CACHE_REFRESH_INTERVAL)
@@ -266,12 +424,43 @@ var serveCmd = &cobra.Command{
 				IAMService:   iamService,
 			})
 			connectInterceptors = append(connectInterceptors, authzInterceptor)
+
+			// Streaming RPCs bypass the unary interceptors above
+			connectInterceptors = append(connectInterceptors, gridmiddleware.NewStreamAuthInterceptor(iamService, cfg.StateWatchReauthInterval))
+		} else {
+			// No OIDC: handlers that require a principal let requests through
+			chiMiddleware = append(chiMiddleware, auth.AuthDisabledMiddleware)
 		}
 
 		healthHandler := func(w http.ResponseWriter, r *http.Request) {
+			health := map[string]any{"status": "ok", "oidc_enabled": oidcEnabled}
+			if idpTransport != nil {
+				// An unreachable IdP degrades auth but the server keeps serving
+				// cached keys, so this stays a 200.
+				idpHealth := idpTransport.Health()
+				health["idp"] = idpHealth
+				if !idpHealth.Reachable {
+					health["status"] = "degraded"
+				}
+			}
+			code := http.StatusOK
+			if iamService != nil {
+				// Without a loaded group→role cache or a working enforcer no
+				// request can be authorized: fail readiness so the node is
+				// taken out of rotation.
+				iamHealth := iamService.Health(r.Context())
+				health["iam"] = iamHealth
+				switch {
+				case !iamHealth.Ready():
+					health["status"] = iam.HealthStatusUnavailable
+					code = http.StatusServiceUnavailable
+				case iamHealth.Status == iam.HealthStatusDegraded:
+					health["status"] = "degraded"
+				}
+			}
 			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			fmt.Fprintf(w, `{"status":"ok","oidc_enabled":%t}`, oidcEnabled)
+			w.WriteHeader(code)
+			_ = json.NewEncoder(w).Encode(health)
 		}
 
 		// Assemble the shared router with the production-specific middleware.
@@ -280,8 +469,10 @@ var serveCmd = &cobra.Command{
 			DependencyService:   depService,
 			EdgeUpdater:         edgeUpdater,
 			ValidationJob:       validationJob,
+			StateWatchHub:       stateWatchHub,
 			PolicyService:       policyService,
 			Provider:            provider,
+			PasswordPolicy:      passwordPolicy,
 			OIDCRouter:          oidcRouter,
 			RelyingParty:        relyingParty,
 			IAMService:          iamService,
@@ -310,6 +501,9 @@ var serveCmd = &cobra.Command{
 		go func() {
 			log.Printf("Starting server on %s", cfg.ServerAddr)
 			log.Printf("Server URL: %s", cfg.ServerURL)
+			if cfg.BackendURL != "" {
+				log.Printf("Backend URL: %s", cfg.BackendURL)
+			}
 			serverErrors <- srv.ListenAndServe()
 		}()
 
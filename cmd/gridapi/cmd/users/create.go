@@ -24,6 +24,7 @@ var (
 	usernameFlag string
 	passwordFlag string
 	rolesInput   []string
+	groupsInput  []string
 	stdinFlag    bool
 )
 
@@ -79,6 +80,15 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("local users require OIDC internal IdP to be enabled (GRID_OIDC_ISSUER must be set)")
 		}
 
+		// Enforce the password policy on the raw password
+		policy, err := auth.NewPasswordPolicy(cfg.PasswordPolicy)
+		if err != nil {
+			return fmt.Errorf("failed to load password policy: %w", err)
+		}
+		if err := policy.Validate(password); err != nil {
+			return err
+		}
+
 		db, err := bunx.NewDB(cfg.DatabaseURL)
 		if err != nil {
 			return fmt.Errorf("failed to connect to database: %w", err)
@@ -90,6 +100,7 @@ var createCmd = &cobra.Command{
 		serviceAccountRepo := repository.NewBunServiceAccountRepository(db)
 		sessionRepo := repository.NewBunSessionRepository(db)
 		userRoleRepo := repository.NewBunUserRoleRepository(db)
+		userGroupRepo := repository.NewBunUserGroupRepository(db)
 		groupRoleRepo := repository.NewBunGroupRoleRepository(db)
 		roleRepo := repository.NewBunRoleRepository(db)
 		revokedJTIRepo := repository.NewBunRevokedJTIRepository(db)
@@ -109,6 +120,7 @@ var createCmd = &cobra.Command{
 				Roles:           roleRepo,
 				RevokedJTIs:     revokedJTIRepo,
 				Enforcer:        enforcer,
+				AuditLogger:     iam.NewAuditLogger(repository.NewBunAuditLogRepository(db)),
 			},
 			iam.IAMServiceConfig{Config: cfg},
 		)
@@ -159,6 +171,12 @@ var createCmd = &cobra.Command{
 			return err
 		}
 
+		for _, group := range groupsInput {
+			if err := userGroupRepo.Add(ctx, user.ID, group); err != nil {
+				return fmt.Errorf("failed to add user to group '%s': %w", group, err)
+			}
+		}
+
 		fmt.Println("User created successfully!")
 		fmt.Println("----------------------------------------")
 		fmt.Printf("User ID: %s\n", user.ID)
@@ -171,6 +189,9 @@ var createCmd = &cobra.Command{
 			}
 			fmt.Printf("Roles: %s\n", strings.Join(roleNames, ", "))
 		}
+		if len(groupsInput) > 0 {
+			fmt.Printf("Groups: %s\n", strings.Join(groupsInput, ", "))
+		}
 		fmt.Println("----------------------------------------")
 
 		return nil
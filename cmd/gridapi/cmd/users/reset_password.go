@@ -0,0 +1,90 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/bunx"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+)
+
+var resetEmailFlag string
+
+var resetPasswordCmd = &cobra.Command{
+	Use:   "reset-password",
+	Short: "Reset an internal IdP user's password to a temporary one",
+	Long: `Generates a temporary password for an internal IdP user and signs them out
+everywhere. The user must choose a new password at their next login.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if resetEmailFlag == "" {
+			return fmt.Errorf("--email flag is required")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if cfg.OIDC.Issuer == "" {
+			return fmt.Errorf("local users require OIDC internal IdP to be enabled (GRID_OIDC_ISSUER must be set)")
+		}
+
+		db, err := bunx.NewDB(cfg.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer bunx.Close(db)
+
+		ctx := context.Background()
+		userRepo := repository.NewBunUserRepository(db)
+		enforcer, err := auth.InitEnforcer(db)
+		if err != nil {
+			return fmt.Errorf("failed to initialize casbin enforcer: %w", err)
+		}
+
+		iamService, err := iam.NewIAMService(
+			iam.IAMServiceDependencies{
+				Users:           userRepo,
+				ServiceAccounts: repository.NewBunServiceAccountRepository(db),
+				Sessions:        repository.NewBunSessionRepository(db),
+				UserRoles:       repository.NewBunUserRoleRepository(db),
+				GroupRoles:      repository.NewBunGroupRoleRepository(db),
+				Roles:           repository.NewBunRoleRepository(db),
+				RevokedJTIs:     repository.NewBunRevokedJTIRepository(db),
+				Enforcer:        enforcer,
+				AuditLogger:     iam.NewAuditLogger(repository.NewBunAuditLogRepository(db)),
+			},
+			iam.IAMServiceConfig{Config: cfg},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to initialize IAM service: %w", err)
+		}
+
+		user, err := userRepo.GetByEmail(ctx, resetEmailFlag)
+		if err != nil {
+			return fmt.Errorf("failed to find user %q: %w", resetEmailFlag, err)
+		}
+
+		tempPassword, err := iamService.AdminResetPassword(ctx, user.ID)
+		if errors.Is(err, iam.ErrNoLocalPassword) {
+			return fmt.Errorf("user %q signs in through an external identity provider and has no Grid password", resetEmailFlag)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reset password: %w", err)
+		}
+
+		fmt.Println("Password reset successfully!")
+		fmt.Println("----------------------------------------")
+		fmt.Printf("Email: %s\n", user.Email)
+		fmt.Printf("Temporary password: %s\n", tempPassword)
+		fmt.Println("----------------------------------------")
+		fmt.Println("All sessions were revoked. The user must choose a new password at next login.")
+
+		return nil
+	},
+}
@@ -14,7 +14,11 @@ func init() {
 	createCmd.Flags().StringVar(&usernameFlag, "username", "", "Username/display name of the user")
 	createCmd.Flags().StringVar(&passwordFlag, "password", "", "Password for the user (use --stdin to avoid shell history)")
 	createCmd.Flags().StringSliceVar(&rolesInput, "role", []string{}, "Role(s) to assign to the user (required)")
+	createCmd.Flags().StringSliceVar(&groupsInput, "group", []string{}, "Group(s) to add the user to, issued in the groups claim of internal IdP tokens")
 	createCmd.Flags().BoolVar(&stdinFlag, "stdin", false, "Read password from stdin instead of --password flag")
 
+	resetPasswordCmd.Flags().StringVar(&resetEmailFlag, "email", "", "Email address of the user")
+
 	UsersCmd.AddCommand(createCmd)
+	UsersCmd.AddCommand(resetPasswordCmd)
 }
@@ -21,6 +21,11 @@ const (
 
 	// StateDelete allows deleting states
 	StateDelete = "state:delete"
+
+	// StateTransferOwnership allows transferring states the principal does not own.
+	// The "state:*" and "*:*" wildcards include it, so roles holding either
+	// (such as platform-engineer) can transfer any state in their scope.
+	StateTransferOwnership = "state:transfer-ownership"
 )
 
 // Data Plane Actions (Terraform HTTP backend)
@@ -64,6 +69,9 @@ const (
 	// StateOutputRead allows reading specific output values from a state
 	StateOutputRead = "state-output:read"
 
+	// StateOutputReadSensitive allows reading the values of outputs marked sensitive
+	StateOutputReadSensitive = "state-output:read-sensitive"
+
 	// StateOutputSchemaWrite allows setting/updating JSON Schema for a state output
 	StateOutputSchemaWrite = "state-output:schema-write"
 
@@ -97,8 +105,26 @@ const (
 	// AdminSessionRevoke allows revoking sessions
 	AdminSessionRevoke = "admin:session-revoke"
 
+	// AdminSessionList allows listing sessions across all users
+	AdminSessionList = "admin:session-list"
+
 	// AdminCacheRefresh allows manually refreshing the group→role cache
 	AdminCacheRefresh = "admin:cache-refresh"
+
+	// AdminTokenIntrospect allows introspecting arbitrary bearer tokens
+	AdminTokenIntrospect = "admin:token-introspect"
+
+	// AdminSigningKeyRotate allows rotating the internal IdP token signing key
+	AdminSigningKeyRotate = "admin:signing-key-rotate"
+
+	// AdminAuditRead allows querying the IAM audit log
+	AdminAuditRead = "admin:audit-read"
+
+	// AdminAuthzPreview allows dry-run authorization checks for arbitrary roles and groups
+	AdminAuthzPreview = "admin:authz-preview"
+
+	// AdminAccessReview allows exporting every principal's role assignments
+	AdminAccessReview = "admin:access-review"
 )
 
 // Ownership Actions (self-service access)
@@ -153,11 +179,12 @@ const (
 func ValidateAction(action string) bool {
 	validActions := map[string]bool{
 		// Control Plane
-		StateCreate:       true,
-		StateRead:         true,
-		StateList:         true,
-		StateUpdateLabels: true,
-		StateDelete:       true,
+		StateCreate:            true,
+		StateRead:              true,
+		StateList:              true,
+		StateUpdateLabels:      true,
+		StateDelete:            true,
+		StateTransferOwnership: true,
 		// Data Plane
 		TfstateRead:   true,
 		TfstateWrite:  true,
@@ -170,10 +197,11 @@ func ValidateAction(action string) bool {
 		DependencyListAll: true,
 		DependencyDelete:  true,
 		// State Outputs
-		StateOutputList:        true,
-		StateOutputRead:        true,
-		StateOutputSchemaWrite: true,
-		StateOutputSchemaRead:  true,
+		StateOutputList:          true,
+		StateOutputRead:          true,
+		StateOutputReadSensitive: true,
+		StateOutputSchemaWrite:   true,
+		StateOutputSchemaRead:    true,
 		// Policy
 		PolicyRead:  true,
 		PolicyWrite: true,
@@ -183,7 +211,13 @@ func ValidateAction(action string) bool {
 		AdminGroupAssign:          true,
 		AdminServiceAccountManage: true,
 		AdminSessionRevoke:        true,
+		AdminSessionList:          true,
 		AdminCacheRefresh:         true,
+		AdminTokenIntrospect:      true,
+		AdminSigningKeyRotate:     true,
+		AdminAuditRead:            true,
+		AdminAuthzPreview:         true,
+		AdminAccessReview:         true,
 		// Ownership
 		ReadSelf: true,
 		// Wildcards
@@ -200,21 +234,21 @@ func ValidateAction(action string) bool {
 }
 
 // ExpandWildcard expands wildcard actions to their concrete actions
-// Example: "state:*" → ["state:create", "state:read", "state:list", "state:update-labels", "state:delete"]
+// Example: "state:*" → ["state:create", "state:read", "state:list", "state:update-labels", "state:delete", "state:transfer-ownership"]
 func ExpandWildcard(action string) []string {
 	switch action {
 	case StateWildcard:
-		return []string{StateCreate, StateRead, StateList, StateUpdateLabels, StateDelete}
+		return []string{StateCreate, StateRead, StateList, StateUpdateLabels, StateDelete, StateTransferOwnership}
 	case TfstateWildcard:
 		return []string{TfstateRead, TfstateWrite, TfstateLock, TfstateUnlock}
 	case DependencyWildcard:
 		return []string{DependencyCreate, DependencyRead, DependencyList, DependencyListAll, DependencyDelete}
 	case StateOutputWildcard:
-		return []string{StateOutputList, StateOutputRead, StateOutputSchemaWrite, StateOutputSchemaRead}
+		return []string{StateOutputList, StateOutputRead, StateOutputReadSensitive, StateOutputSchemaWrite, StateOutputSchemaRead}
 	case PolicyWildcard:
 		return []string{PolicyRead, PolicyWrite}
 	case AdminWildcard:
-		return []string{AdminRoleManage, AdminUserAssign, AdminGroupAssign, AdminServiceAccountManage, AdminSessionRevoke, AdminCacheRefresh}
+		return []string{AdminRoleManage, AdminUserAssign, AdminGroupAssign, AdminServiceAccountManage, AdminSessionRevoke, AdminSessionList, AdminCacheRefresh, AdminTokenIntrospect, AdminSigningKeyRotate, AdminAuditRead, AdminAuthzPreview, AdminAccessReview}
 	case AllWildcard:
 		// Return all concrete actions
 		var all []string
@@ -4,14 +4,19 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"sync"
-
-	"github.com/hashicorp/go-bexpr"
 )
 
-// bexprCache stores compiled go-bexpr evaluators for performance
-// Key: scope expression string, Value: *bexpr.Evaluator
-var bexprCache = &sync.Map{}
+// Scope expression sentinels. These are not go-bexpr syntax; EvaluateBexpr
+// recognizes them directly.
+//
+// An empty scope expression is not a sentinel: it matches nothing, so an
+// unscoped grant always has to be spelled out as ScopeAll.
+const (
+	// ScopeAll explicitly matches every resource.
+	ScopeAll = "*"
+	// ScopeNone matches no resource.
+	ScopeNone = "!*"
+)
 
 // BexprMatchFunction returns the bexprMatch function for Casbin
 // This function evaluates go-bexpr expressions against resource labels
@@ -39,38 +44,29 @@ func BexprMatchFunction() func(args ...any) (any, error) {
 }
 
 // EvaluateBexpr evaluates a go-bexpr expression against resource labels
-// Empty scopeExpr returns true (no constraint)
-// Caches compiled evaluators for performance
+// ScopeAll returns true (no constraint); ScopeNone and an empty scopeExpr return false
+// Compiled evaluators are cached (see bexprEvaluatorCache), so each distinct
+// expression is compiled once while it stays in the cache
 //
 // Reference: research.md §1 (lines 443-482)
 func EvaluateBexpr(scopeExpr string, labels map[string]any) bool {
-	// Empty expression means no constraint (allow all)
-	if strings.TrimSpace(scopeExpr) == "" {
+	switch strings.TrimSpace(scopeExpr) {
+	case ScopeAll:
 		return true
+	case "", ScopeNone:
+		// Empty is treated as misconfigured rather than unscoped - deny access
+		return false
 	}
 
-	// Check cache for compiled evaluator
-	if cached, ok := bexprCache.Load(scopeExpr); ok {
-		evaluator := cached.(*bexpr.Evaluator)
-		matches, err := evaluator.Evaluate(labels)
-		if err != nil {
-			// Invalid evaluation (e.g., missing label key) - deny access
-			return false
-		}
-		return matches
-	}
-
-	// Compile and cache evaluator
-	evaluator, err := bexpr.CreateEvaluator(scopeExpr)
-	if err != nil {
+	evaluator := bexprCache.get(scopeExpr)
+	if evaluator == nil {
 		// Invalid expression syntax - deny access
 		return false
 	}
-	bexprCache.Store(scopeExpr, evaluator)
 
-	// Evaluate
 	matches, err := evaluator.Evaluate(labels)
 	if err != nil {
+		// Invalid evaluation (e.g., missing label key) - deny access
 		return false
 	}
 	return matches
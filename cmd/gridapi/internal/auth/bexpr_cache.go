@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"sync/atomic"
+
+	"github.com/hashicorp/go-bexpr"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// DefaultBexprCacheSize bounds the number of compiled scope expressions kept
+// in memory. Scope expressions come from role definitions and change rarely,
+// so a few thousand entries hold every live expression with room to spare.
+const DefaultBexprCacheSize = 4096
+
+// bexprCache holds the compiled evaluators used by EvaluateBexpr.
+var bexprCache = newBexprEvaluatorCache(DefaultBexprCacheSize)
+
+// BexprCacheStats reports the compiled scope expression cache's counters
+// since process start.
+type BexprCacheStats struct {
+	Hits      uint64 // Lookups served by a cached evaluator
+	Misses    uint64 // Lookups that had to compile
+	Compiles  uint64 // Compilations, including invalid expressions
+	Evictions uint64 // Entries dropped to stay within Capacity
+	Size      int    // Entries currently cached
+	Capacity  int
+}
+
+// GetBexprCacheStats returns the compiled scope expression cache's counters.
+func GetBexprCacheStats() BexprCacheStats {
+	return bexprCache.stats()
+}
+
+// bexprEvaluatorCache is a bounded LRU of compiled go-bexpr evaluators keyed
+// by expression string. Invalid expressions are cached as nil so they are not
+// recompiled on every check either. Safe for concurrent use; two goroutines
+// missing on the same expression at once may both compile it, which only
+// costs the duplicate work.
+type bexprEvaluatorCache struct {
+	evaluators *lru.Cache[string, *bexpr.Evaluator]
+	capacity   int
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	compiles  atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func newBexprEvaluatorCache(size int) *bexprEvaluatorCache {
+	c := &bexprEvaluatorCache{capacity: size}
+	evaluators, err := lru.NewWithEvict(size, func(string, *bexpr.Evaluator) {
+		c.evictions.Add(1)
+	})
+	if err != nil {
+		panic(err) // Only for a non-positive size
+	}
+	c.evaluators = evaluators
+	return c
+}
+
+// get returns the compiled evaluator for expr, compiling and caching it on a
+// miss. A nil evaluator means expr does not compile.
+func (c *bexprEvaluatorCache) get(expr string) *bexpr.Evaluator {
+	if evaluator, ok := c.evaluators.Get(expr); ok {
+		c.hits.Add(1)
+		return evaluator
+	}
+	c.misses.Add(1)
+
+	c.compiles.Add(1)
+	evaluator, err := bexpr.CreateEvaluator(expr)
+	if err != nil {
+		evaluator = nil
+	}
+	c.evaluators.Add(expr, evaluator)
+	return evaluator
+}
+
+func (c *bexprEvaluatorCache) stats() BexprCacheStats {
+	return BexprCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Compiles:  c.compiles.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      c.evaluators.Len(),
+		Capacity:  c.capacity,
+	}
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBexprEvaluatorCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("compiles each expression once", func(t *testing.T) {
+		cache := newBexprEvaluatorCache(8)
+
+		first := cache.get(`env == "prod"`)
+		require.NotNil(t, first)
+		for range 10 {
+			assert.Same(t, first, cache.get(`env == "prod"`))
+		}
+
+		stats := cache.stats()
+		assert.Equal(t, uint64(1), stats.Compiles)
+		assert.Equal(t, uint64(1), stats.Misses)
+		assert.Equal(t, uint64(10), stats.Hits)
+		assert.Equal(t, 1, stats.Size)
+		assert.Equal(t, 8, stats.Capacity)
+	})
+
+	t.Run("invalid expressions are cached too", func(t *testing.T) {
+		cache := newBexprEvaluatorCache(8)
+
+		assert.Nil(t, cache.get(`env ==`))
+		assert.Nil(t, cache.get(`env ==`))
+		assert.Equal(t, uint64(1), cache.stats().Compiles)
+	})
+
+	t.Run("bounded by capacity", func(t *testing.T) {
+		cache := newBexprEvaluatorCache(2)
+
+		for i := range 5 {
+			cache.get(fmt.Sprintf(`tier == "%d"`, i))
+		}
+		stats := cache.stats()
+		assert.Equal(t, 2, stats.Size)
+		assert.Equal(t, uint64(3), stats.Evictions)
+
+		// The most recent expressions are still cached; the oldest recompiles
+		cache.get(`tier == "4"`)
+		assert.Equal(t, uint64(5), cache.stats().Compiles)
+		cache.get(`tier == "0"`)
+		assert.Equal(t, uint64(6), cache.stats().Compiles)
+	})
+
+	t.Run("concurrent lookups", func(t *testing.T) {
+		cache := newBexprEvaluatorCache(4)
+
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 100 {
+					assert.NotNil(t, cache.get(fmt.Sprintf(`team == "%d"`, (i+j)%6)))
+				}
+			}()
+		}
+		wg.Wait()
+
+		stats := cache.stats()
+		assert.Equal(t, uint64(16*100), stats.Hits+stats.Misses)
+		assert.LessOrEqual(t, stats.Size, 4)
+	})
+}
+
+// Not parallel: it reads the process-wide cache's counters.
+func TestEvaluateBexprUsesCache(t *testing.T) {
+	const expr = `env == "cache-test" and team == "platform"`
+	labels := map[string]any{"env": "cache-test", "team": "platform"}
+
+	before := GetBexprCacheStats()
+	for range 5 {
+		assert.True(t, EvaluateBexpr(expr, labels))
+	}
+	assert.False(t, EvaluateBexpr(expr, map[string]any{"env": "dev", "team": "platform"}))
+	after := GetBexprCacheStats()
+
+	assert.Equal(t, uint64(1), after.Compiles-before.Compiles)
+	assert.Equal(t, uint64(5), after.Hits-before.Hits)
+	assert.Equal(t, DefaultBexprCacheSize, after.Capacity)
+}
+
+func BenchmarkEvaluateBexpr(b *testing.B) {
+	const expr = `env == "prod" and team != "sandbox"`
+	labels := map[string]any{"env": "prod", "team": "platform"}
+
+	b.Run("cached", func(b *testing.B) {
+		for b.Loop() {
+			EvaluateBexpr(expr, labels)
+		}
+	})
+
+	b.Run("compile per call", func(b *testing.B) {
+		for b.Loop() {
+			evaluator, err := bexpr.CreateEvaluator(expr)
+			if err != nil {
+				b.Fatal(err)
+			}
+			_, _ = evaluator.Evaluate(labels)
+		}
+	})
+}
@@ -217,9 +217,20 @@ func (a *Adapter) UpdatePolicies(_ string, ptype string, oldRules, newRules [][]
 	}
 
 	for i, line := range oldLines {
-		q := tx.NewUpdate().Model(newLines[i])
+		// Every column is part of the primary key, which bun leaves out of a
+		// model's SET clause, so the new values are set explicitly
+		newLine := newLines[i]
+		q := tx.NewUpdate().
+			Model((*CasbinRule)(nil)).
+			Set("ptype = ?", newLine.Ptype).
+			Set("v0 = ?", newLine.V0).
+			Set("v1 = ?", newLine.V1).
+			Set("v2 = ?", newLine.V2).
+			Set("v3 = ?", newLine.V3).
+			Set("v4 = ?", newLine.V4).
+			Set("v5 = ?", newLine.V5)
 		qb := q.QueryBuilder()
-		line.QueryWhereGroup(qb)
+		line.queryWhereExact(qb)
 		_, err = q.Exec(context.Background())
 		if err != nil {
 			_ = tx.Rollback()
@@ -267,7 +278,7 @@ func (a *Adapter) UpdateFilteredPolicies(_ string, ptype string, newRules [][]st
 		for i := range newP {
 			q := tx.NewDelete().Model(&oldP)
 			qb := q.QueryBuilder()
-			line.QueryWhereGroup(qb)
+			line.queryWhereExact(qb)
 			_, err := q.Returning("*").Exec(ctx)
 			if err != nil {
 				return err
@@ -485,6 +496,19 @@ func (r *CasbinRule) QueryWhereGroup(q bun.QueryBuilder) bun.QueryBuilder {
 	return q
 }
 
+// queryWhereExact matches exactly this rule. Unlike QueryWhereGroup, empty
+// fields must be empty too, so updating a rule with an empty scope (v3) does
+// not also rewrite the otherwise identical rules that have one.
+func (r *CasbinRule) queryWhereExact(q bun.QueryBuilder) bun.QueryBuilder {
+	return q.Where("ptype = ?", r.Ptype).
+		Where("v0 = ?", r.V0).
+		Where("v1 = ?", r.V1).
+		Where("v2 = ?", r.V2).
+		Where("v3 = ?", r.V3).
+		Where("v4 = ?", r.V4).
+		Where("v5 = ?", r.V5)
+}
+
 func (r *CasbinRule) toValueSlice() ([]string, int) {
 	values := []string{r.V0, r.V1, r.V2, r.V3, r.V4, r.V5}
 	lastNonEmpty := -1
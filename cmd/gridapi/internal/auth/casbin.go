@@ -13,6 +13,12 @@ import (
 //go:embed model.conf
 var casbinModelContent string
 
+// Policy effects (p.eft). A matching deny overrides any matching allow.
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
 // InitEnforcer creates and initializes a Casbin enforcer with embedded model and database adapter
 // Uses msales/casbin-bun-adapter to share the existing *bun.DB connection pool
 //
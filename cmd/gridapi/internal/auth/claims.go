@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -9,16 +12,31 @@ import (
 // ExtractGroups handles both flat and nested group claims from JWT tokens
 // Supports:
 //   - Flat arrays: ["dev-team", "contractors"]
+//   - A single string: "dev-team"
 //   - Nested objects: [{"name": "dev-team", "type": "team"}] with claimPath="name"
 //
+// claimField and claimPath may use dot notation to reach into nested claims,
+// e.g. claimField="realm_access.roles" or "resource_access.gridapi.roles". A
+// top-level claim whose name contains dots is matched first.
+//
+// Group names are returned as sent apart from dropping empty entries; they are
+// normalized (NormalizeGroupName) where they are matched against mappings.
+//
 // Reference: research.md §9 (lines 629-787), CLARIFICATIONS.md §1 (JWT claim config)
 func ExtractGroups(claims map[string]interface{}, claimField string, claimPath string) ([]string, error) {
-	rawValue, ok := claims[claimField]
+	rawValue, ok := lookupClaim(claims, claimField)
 	if !ok {
 		// Groups claim not present - return empty list (not an error, user may have no groups)
 		return []string{}, nil
 	}
 
+	switch v := rawValue.(type) {
+	case string:
+		return nonEmptyGroupNames([]string{v}), nil
+	case []string:
+		return nonEmptyGroupNames(v), nil
+	}
+
 	// Try flat string array first: ["dev-team", "contractors"]
 	if groups, ok := rawValue.([]interface{}); ok {
 		result := make([]string, 0, len(groups))
@@ -28,40 +46,69 @@ func ExtractGroups(claims map[string]interface{}, claimField string, claimPath s
 			}
 		}
 		if len(result) > 0 {
-			return result, nil
+			return nonEmptyGroupNames(result), nil
 		}
 	}
 
 	// Try nested extraction if path provided: [{"name": "dev-team"}]
 	if claimPath != "" {
-		return extractNestedGroups(rawValue, claimPath)
+		groups, err := extractNestedGroups(rawValue, claimPath)
+		if err != nil {
+			return nil, err
+		}
+		return nonEmptyGroupNames(groups), nil
 	}
 
 	return nil, fmt.Errorf("groups claim invalid format (expected []string or []object with path)")
 }
 
-// extractNestedGroups uses mapstructure to extract from nested objects
-// Supports simple single-level paths like "name", "value", "id"
-func extractNestedGroups(rawValue interface{}, path string) ([]string, error) {
-	// For simple nested objects: [{"name": "dev-team"}] with path="name"
-	// Only support single-level paths (YAGNI for complex nested structures)
-	if path == "name" || path == "value" || path == "id" {
-		var objects []map[string]interface{}
-		if err := mapstructure.Decode(rawValue, &objects); err != nil {
-			return nil, fmt.Errorf("failed to decode nested groups: %w", err)
+func nonEmptyGroupNames(groups []string) []string {
+	result := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if strings.TrimSpace(g) != "" {
+			result = append(result, g)
 		}
+	}
+	return result
+}
 
-		result := make([]string, 0, len(objects))
-		for _, obj := range objects {
-			if val, ok := obj[path].(string); ok {
-				result = append(result, val)
-			}
+// lookupClaim returns the value at a dot-notation path in claims.
+func lookupClaim(claims map[string]interface{}, path string) (interface{}, bool) {
+	if value, ok := claims[path]; ok {
+		return value, true
+	}
+
+	var current interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[key]; !ok {
+			return nil, false
 		}
-		return result, nil
 	}
+	return current, true
+}
 
-	// For complex paths (future): consider gjson if demand arises
-	return nil, fmt.Errorf("complex nested paths not yet supported (path: %s)", path)
+// extractNestedGroups uses mapstructure to extract from nested objects:
+// [{"name": "dev-team"}] with path="name", or a dot path such as
+// "attributes.slug" for deeper objects.
+func extractNestedGroups(rawValue interface{}, path string) ([]string, error) {
+	var objects []map[string]interface{}
+	if err := mapstructure.Decode(rawValue, &objects); err != nil {
+		return nil, fmt.Errorf("failed to decode nested groups: %w", err)
+	}
+
+	result := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		if val, ok := lookupClaim(obj, path); ok {
+			if str, ok := val.(string); ok {
+				result = append(result, str)
+			}
+		}
+	}
+	return result, nil
 }
 
 // ExtractClaimString extracts a string claim from JWT claims
@@ -111,3 +158,26 @@ func ExtractNameFromClaims(claims map[string]interface{}) string {
 
 	return name
 }
+
+// ClaimTime converts a NumericDate claim (seconds since epoch) to time.Time.
+// Returns the zero time if the claim is absent or malformed.
+func ClaimTime(claims map[string]any, key string) time.Time {
+	switch v := claims[key].(type) {
+	case float64:
+		return time.Unix(int64(v), 0)
+	case int64:
+		return time.Unix(v, 0)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return time.Unix(n, 0)
+		}
+	}
+	return time.Time{}
+}
+
+// IssuedBeforeEpoch reports whether a token's iat claim predates the
+// revocation epoch. With an epoch in force, a token without iat cannot prove
+// its age and is rejected.
+func IssuedBeforeEpoch(claims map[string]any, epoch time.Time) bool {
+	return !epoch.IsZero() && ClaimTime(claims, "iat").Before(epoch)
+}
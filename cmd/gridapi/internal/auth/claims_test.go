@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     map[string]any
+		claimField string
+		claimPath  string
+		want       []string
+	}{
+		{
+			name:       "flat array",
+			claims:     map[string]any{"groups": []any{"platform-engineers", "dev-team"}},
+			claimField: "groups",
+			want:       []string{"platform-engineers", "dev-team"},
+		},
+		{
+			name:       "typed string slice",
+			claims:     map[string]any{"groups": []string{"platform-engineers"}},
+			claimField: "groups",
+			want:       []string{"platform-engineers"},
+		},
+		{
+			name:       "single string",
+			claims:     map[string]any{"groups": "platform-engineers"},
+			claimField: "groups",
+			want:       []string{"platform-engineers"},
+		},
+		{
+			name:       "array of objects",
+			claims:     map[string]any{"groups": []any{map[string]any{"name": "dev-team"}, map[string]any{"name": "qa"}}},
+			claimField: "groups",
+			claimPath:  "name",
+			want:       []string{"dev-team", "qa"},
+		},
+		{
+			name:       "typed array of maps",
+			claims:     map[string]any{"groups": []map[string]any{{"id": "dev-team"}}},
+			claimField: "groups",
+			claimPath:  "id",
+			want:       []string{"dev-team"},
+		},
+		{
+			name:       "array of objects with nested path",
+			claims:     map[string]any{"groups": []any{map[string]any{"attributes": map[string]any{"slug": "dev-team"}}}},
+			claimField: "groups",
+			claimPath:  "attributes.slug",
+			want:       []string{"dev-team"},
+		},
+		{
+			name:       "keycloak realm roles",
+			claims:     map[string]any{"realm_access": map[string]any{"roles": []any{"platform-engineers"}}},
+			claimField: "realm_access.roles",
+			want:       []string{"platform-engineers"},
+		},
+		{
+			name: "keycloak client roles",
+			claims: map[string]any{"resource_access": map[string]any{
+				"gridapi": map[string]any{"roles": []any{"dev-team"}},
+				"account": map[string]any{"roles": []any{"manage-account"}},
+			}},
+			claimField: "resource_access.gridapi.roles",
+			want:       []string{"dev-team"},
+		},
+		{
+			name:       "dotted top-level claim name wins",
+			claims:     map[string]any{"https://grid.example.com/groups": []any{"dev-team"}},
+			claimField: "https://grid.example.com/groups",
+			want:       []string{"dev-team"},
+		},
+		{
+			name:       "names are returned as sent without empty entries",
+			claims:     map[string]any{"groups": []any{"/product-engineers", "", "  ", "Dev-Team"}},
+			claimField: "groups",
+			want:       []string{"/product-engineers", "Dev-Team"},
+		},
+		{
+			name:       "missing claim",
+			claims:     map[string]any{"sub": "alice"},
+			claimField: "realm_access.roles",
+			want:       []string{},
+		},
+		{
+			name:       "path through a non-object",
+			claims:     map[string]any{"realm_access": "roles"},
+			claimField: "realm_access.roles",
+			want:       []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractGroups(tt.claims, tt.claimField, tt.claimPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtractGroups_ObjectsWithoutPath(t *testing.T) {
+	claims := map[string]any{"groups": []any{map[string]any{"name": "dev-team"}}}
+
+	_, err := ExtractGroups(claims, "groups", "")
+	assert.Error(t, err)
+}
+
+func TestIssuedBeforeEpoch(t *testing.T) {
+	t.Parallel()
+	epoch := time.Now().Truncate(time.Second)
+
+	preEpoch := map[string]any{"iat": float64(epoch.Add(-time.Second).Unix())}
+	fresh := map[string]any{"iat": float64(epoch.Unix())}
+
+	assert.True(t, IssuedBeforeEpoch(preEpoch, epoch), "token issued before the epoch must fail")
+	assert.False(t, IssuedBeforeEpoch(fresh, epoch), "token issued in the epoch second must pass")
+	assert.True(t, IssuedBeforeEpoch(map[string]any{}, epoch), "token without iat must fail once an epoch is set")
+	assert.False(t, IssuedBeforeEpoch(preEpoch, time.Time{}), "no epoch rejects nothing")
+}
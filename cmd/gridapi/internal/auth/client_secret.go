@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// ErrInvalidClientSecret is returned when a secret matches neither the
+// service account's current secret nor a previous one still in overlap.
+var ErrInvalidClientSecret = errors.New("invalid client secret")
+
+// VerifyClientSecret checks secret against the service account's current
+// secret and, until PreviousSecretExpiresAt, the secret an overlapping
+// rotation replaced. usedPrevious reports a match on the previous secret.
+func VerifyClientSecret(sa *models.ServiceAccount, secret string, now time.Time) (usedPrevious bool, err error) {
+	if bcrypt.CompareHashAndPassword([]byte(sa.ClientSecretHash), []byte(secret)) == nil {
+		return false, nil
+	}
+	if sa.PreviousSecretHash == nil || PreviousSecretExpired(sa, now) {
+		return false, ErrInvalidClientSecret
+	}
+	if bcrypt.CompareHashAndPassword([]byte(*sa.PreviousSecretHash), []byte(secret)) == nil {
+		return true, nil
+	}
+	return false, ErrInvalidClientSecret
+}
+
+// PreviousSecretExpired reports whether the service account still holds a
+// previous secret whose overlap window has ended, so it can be cleared.
+func PreviousSecretExpired(sa *models.ServiceAccount, now time.Time) bool {
+	return sa.PreviousSecretHash != nil &&
+		(sa.PreviousSecretExpiresAt == nil || !now.Before(*sa.PreviousSecretExpiresAt))
+}
@@ -1,6 +1,9 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"net/http"
+)
 
 // PrincipalType describes the type of authenticated principal.
 type PrincipalType string
@@ -24,10 +27,16 @@ type AuthenticatedPrincipal struct {
 	Email string
 	// Name is optional display name for human users.
 	Name string
+	// DisplayName is a human-friendly label for logs: the user's name, email
+	// or subject, or the service account name.
+	DisplayName string
 	// SessionID references the active session row when available.
 	SessionID string
 	// Roles lists effective Casbin role identifiers resolved during authentication.
 	Roles []string
+	// TokenScopes lists the permission scopes the credential was limited to
+	// (see PermissionScopes). Empty means limited only by Roles.
+	TokenScopes []string
 	// Type differentiates users and service accounts.
 	Type PrincipalType
 }
@@ -61,3 +70,27 @@ func GetGroupsFromContext(ctx context.Context) []string {
 	}
 	return append([]string(nil), groups...)
 }
+
+type authDisabledContextKey struct{}
+
+// SetAuthDisabledContext marks the request as served with authentication
+// disabled (no OIDC configured), so a missing principal is expected rather
+// than a sign of a skipped authentication step.
+func SetAuthDisabledContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authDisabledContextKey{}, true)
+}
+
+// IsAuthDisabled reports whether the request was marked by
+// SetAuthDisabledContext.
+func IsAuthDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(authDisabledContextKey{}).(bool)
+	return disabled
+}
+
+// AuthDisabledMiddleware marks every request with SetAuthDisabledContext. It
+// is installed only when the server runs without OIDC.
+func AuthDisabledMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(SetAuthDisabledContext(r.Context())))
+	})
+}
@@ -1,5 +1,10 @@
 package auth
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Phase 4 Note: ApplyDynamicGroupings, clearUserGroupings, GetEffectiveRoles,
 // and ResolveGroupRoles were removed.
 //
@@ -13,3 +18,58 @@ package auth
 //   - Pre-resolved roles in Principal.Roles at authentication time
 //   - Read-only Casbin authorization (no state mutation)
 //   - IAM Service handles all role resolution via ResolveRoles method
+
+// Group name normalization strategies (GRID_OIDC_GROUP_NAME_NORMALIZATION).
+const (
+	GroupNameStripLeadingSlash = "strip-leading-slash"
+	GroupNameLowercase         = "lowercase"
+	GroupNameNoNormalization   = "none"
+)
+
+// GroupNameNormalization decides how group names from token claims and from
+// group-role assignments are made comparable. The same value must be used on
+// the write path (AssignGroupRole) and the read path (group→role lookup).
+type GroupNameNormalization struct {
+	// StripLeadingSlash turns Keycloak group paths ("/product-engineers")
+	// into bare names. Nested paths keep their inner slashes.
+	StripLeadingSlash bool
+	// Lowercase makes group names case-insensitive.
+	Lowercase bool
+}
+
+// DefaultGroupNameNormalization strips leading slashes only.
+var DefaultGroupNameNormalization = GroupNameNormalization{StripLeadingSlash: true}
+
+// ParseGroupNameNormalization builds a normalization from configured strategy
+// names. An empty list yields DefaultGroupNameNormalization.
+func ParseGroupNameNormalization(strategies []string) (GroupNameNormalization, error) {
+	if len(strategies) == 0 {
+		return DefaultGroupNameNormalization, nil
+	}
+	var norm GroupNameNormalization
+	for _, strategy := range strategies {
+		switch strategy {
+		case GroupNameStripLeadingSlash:
+			norm.StripLeadingSlash = true
+		case GroupNameLowercase:
+			norm.Lowercase = true
+		case GroupNameNoNormalization:
+		default:
+			return GroupNameNormalization{}, fmt.Errorf("unknown group name normalization %q", strategy)
+		}
+	}
+	return norm, nil
+}
+
+// NormalizeGroupName applies norm to name. Surrounding whitespace is always
+// trimmed.
+func NormalizeGroupName(name string, norm GroupNameNormalization) string {
+	name = strings.TrimSpace(name)
+	if norm.StripLeadingSlash {
+		name = strings.TrimLeft(name, "/")
+	}
+	if norm.Lowercase {
+		name = strings.ToLower(name)
+	}
+	return name
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeGroupName(t *testing.T) {
+	tests := []struct {
+		name string
+		norm GroupNameNormalization
+		in   string
+		want string
+	}{
+		{name: "default strips leading slash", norm: DefaultGroupNameNormalization, in: "/product-engineers", want: "product-engineers"},
+		{name: "default keeps bare name", norm: DefaultGroupNameNormalization, in: "product-engineers", want: "product-engineers"},
+		{name: "default keeps inner slashes", norm: DefaultGroupNameNormalization, in: "/platform/sre", want: "platform/sre"},
+		{name: "default keeps case", norm: DefaultGroupNameNormalization, in: "/Product-Engineers", want: "Product-Engineers"},
+		{name: "lowercase", norm: GroupNameNormalization{Lowercase: true}, in: "/Product-Engineers", want: "/product-engineers"},
+		{name: "both", norm: GroupNameNormalization{StripLeadingSlash: true, Lowercase: true}, in: "/Product-Engineers", want: "product-engineers"},
+		{name: "none trims whitespace only", norm: GroupNameNormalization{}, in: " /dev-team ", want: "/dev-team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeGroupName(tt.in, tt.norm))
+		})
+	}
+}
+
+func TestParseGroupNameNormalization(t *testing.T) {
+	norm, err := ParseGroupNameNormalization(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultGroupNameNormalization, norm)
+
+	norm, err = ParseGroupNameNormalization([]string{GroupNameStripLeadingSlash, GroupNameLowercase})
+	require.NoError(t, err)
+	assert.Equal(t, GroupNameNormalization{StripLeadingSlash: true, Lowercase: true}, norm)
+
+	norm, err = ParseGroupNameNormalization([]string{GroupNameNoNormalization})
+	require.NoError(t, err)
+	assert.Equal(t, GroupNameNormalization{}, norm)
+
+	_, err = ParseGroupNameNormalization([]string{"uppercase"})
+	assert.Error(t, err)
+}
@@ -16,83 +16,173 @@ const (
 	PrefixRole           = "role:"
 )
 
+// CasbinKind is the type of a Casbin identifier, named by its prefix.
+type CasbinKind int
+
+const (
+	CasbinUser CasbinKind = iota + 1
+	CasbinGroup
+	CasbinServiceAccount
+	CasbinRole
+)
+
+// casbinKinds lists every kind with its prefix; parsing tries them in order.
+var casbinKinds = []struct {
+	kind   CasbinKind
+	prefix string
+	name   string
+}{
+	{CasbinUser, PrefixUser, "user"},
+	{CasbinGroup, PrefixGroup, "group"},
+	{CasbinServiceAccount, PrefixServiceAccount, "service_account"},
+	{CasbinRole, PrefixRole, "role"},
+}
+
+// String returns the kind's name: "user", "group", "service_account" or "role".
+func (k CasbinKind) String() string {
+	for _, c := range casbinKinds {
+		if c.kind == k {
+			return c.name
+		}
+	}
+	return fmt.Sprintf("CasbinKind(%d)", int(k))
+}
+
+// Prefix returns the identifier prefix of the kind, e.g. "sa:".
+func (k CasbinKind) Prefix() string {
+	for _, c := range casbinKinds {
+		if c.kind == k {
+			return c.prefix
+		}
+	}
+	return ""
+}
+
+// CasbinID is a Casbin subject or role identifier: a kind and the name it
+// prefixes. It is the only place prefixes are added (String) or stripped
+// (ParseCasbinID); the helpers below are shorthands for it.
+type CasbinID struct {
+	Kind CasbinKind
+	Name string // Without prefix: user subject, group name, SA client ID or role name
+}
+
+// NewCasbinID returns the identifier of name as a kind.
+func NewCasbinID(kind CasbinKind, name string) CasbinID {
+	return CasbinID{Kind: kind, Name: name}
+}
+
+// String returns the prefixed identifier as stored in Casbin rules.
+func (id CasbinID) String() string {
+	return id.Kind.Prefix() + id.Name
+}
+
+// ParseCasbinID splits a prefixed Casbin identifier into its kind and name.
+// It fails for identifiers without a known prefix.
+func ParseCasbinID(id string) (CasbinID, error) {
+	for _, c := range casbinKinds {
+		if name, ok := strings.CutPrefix(id, c.prefix); ok {
+			return CasbinID{Kind: c.kind, Name: name}, nil
+		}
+	}
+	return CasbinID{}, fmt.Errorf("invalid casbin identifier: %s (no known prefix)", id)
+}
+
+// parseCasbinIDOf parses id and requires it to be of kind.
+func parseCasbinIDOf(id string, kind CasbinKind) (string, error) {
+	parsed, err := ParseCasbinID(id)
+	if err != nil || parsed.Kind != kind {
+		return "", fmt.Errorf("invalid %s principal: %s (expected prefix %s)", strings.ReplaceAll(kind.String(), "_", " "), id, kind.Prefix())
+	}
+	return parsed.Name, nil
+}
+
 // UserID creates a Casbin user identifier with the standard prefix
 // Example: UserID("alice@example.com") → "user:alice@example.com"
 func UserID(id string) string {
-	return PrefixUser + id
+	return NewCasbinID(CasbinUser, id).String()
 }
 
 // GroupID creates a Casbin group identifier with the standard prefix
 // Example: GroupID("dev-team") → "group:dev-team"
 func GroupID(name string) string {
-	return PrefixGroup + name
+	return NewCasbinID(CasbinGroup, name).String()
 }
 
 // ServiceAccountID creates a Casbin service account identifier with the standard prefix
 // Example: ServiceAccountID("550e8400-e29b-41d4-a716-446655440000") → "sa:550e8400-e29b-41d4-a716-446655440000"
 func ServiceAccountID(id string) string {
-	return PrefixServiceAccount + id
+	return NewCasbinID(CasbinServiceAccount, id).String()
 }
 
 // RoleID creates a Casbin role identifier with the standard prefix
 // Example: RoleID("product-engineer") → "role:product-engineer"
 func RoleID(name string) string {
-	return PrefixRole + name
+	return NewCasbinID(CasbinRole, name).String()
 }
 
 // ExtractUserID extracts the user ID from a Casbin principal identifier
 // Returns the ID without prefix, or error if prefix mismatch
 // Example: ExtractUserID("user:alice@example.com") → "alice@example.com", nil
 func ExtractUserID(principal string) (string, error) {
-	if !strings.HasPrefix(principal, PrefixUser) {
-		return "", fmt.Errorf("invalid user principal: %s (expected prefix %s)", principal, PrefixUser)
-	}
-	return strings.TrimPrefix(principal, PrefixUser), nil
+	return parseCasbinIDOf(principal, CasbinUser)
 }
 
 // ExtractGroupID extracts the group name from a Casbin principal identifier
 // Returns the name without prefix, or error if prefix mismatch
 // Example: ExtractGroupID("group:dev-team") → "dev-team", nil
 func ExtractGroupID(principal string) (string, error) {
-	if !strings.HasPrefix(principal, PrefixGroup) {
-		return "", fmt.Errorf("invalid group principal: %s (expected prefix %s)", principal, PrefixGroup)
-	}
-	return strings.TrimPrefix(principal, PrefixGroup), nil
+	return parseCasbinIDOf(principal, CasbinGroup)
 }
 
 // ExtractServiceAccountID extracts the service account ID from a Casbin principal identifier
 // Returns the ID without prefix, or error if prefix mismatch
 // Example: ExtractServiceAccountID("sa:550e8400-e29b-41d4-a716-446655440000") → "550e8400-e29b-41d4-a716-446655440000", nil
 func ExtractServiceAccountID(principal string) (string, error) {
-	if !strings.HasPrefix(principal, PrefixServiceAccount) {
-		return "", fmt.Errorf("invalid service account principal: %s (expected prefix %s)", principal, PrefixServiceAccount)
-	}
-	return strings.TrimPrefix(principal, PrefixServiceAccount), nil
+	return parseCasbinIDOf(principal, CasbinServiceAccount)
 }
 
 // ExtractRoleID extracts the role name from a Casbin principal identifier
 // Returns the name without prefix, or error if prefix mismatch
 // Example: ExtractRoleID("role:product-engineer") → "product-engineer", nil
 func ExtractRoleID(principal string) (string, error) {
-	if !strings.HasPrefix(principal, PrefixRole) {
-		return "", fmt.Errorf("invalid role principal: %s (expected prefix %s)", principal, PrefixRole)
+	return parseCasbinIDOf(principal, CasbinRole)
+}
+
+// ParseRoleName returns the role name of a role reference in either of the
+// two forms in use:
+//   - bare role names ("product-engineer"), carried in Principal.Roles. Role
+//     resolution (iam.Service.ResolveRoles) produces these for every principal,
+//     whether authenticated by an external IdP (Mode 1) or by Grid's internal
+//     IdP (Mode 2)
+//   - Casbin role identifiers ("role:product-engineer"), found in grouping
+//     rules and returned by iam.Service.GetPrincipalRoles
+//
+// Anything else is rejected rather than guessed at: an empty reference, a
+// "role:" prefix without a name, or another principal type's prefix
+// ("user:", "group:", "sa:").
+func ParseRoleName(role string) (string, error) {
+	if role == "" {
+		return "", fmt.Errorf("invalid role: empty role name")
 	}
-	return strings.TrimPrefix(principal, PrefixRole), nil
+	id, err := ParseCasbinID(role)
+	if err != nil {
+		return role, nil // No prefix: a role name
+	}
+	switch {
+	case id.Kind != CasbinRole:
+		return "", fmt.Errorf("invalid role %q: %s identifier, not a role", role, id.Kind)
+	case id.Name == "":
+		return "", fmt.Errorf("invalid role %q: empty role name", role)
+	}
+	return id.Name, nil
 }
 
 // GetPrincipalType returns the type of a Casbin principal (user, group, sa, role)
 // Returns empty string if prefix not recognized
 func GetPrincipalType(principal string) string {
-	switch {
-	case strings.HasPrefix(principal, PrefixUser):
-		return "user"
-	case strings.HasPrefix(principal, PrefixGroup):
-		return "group"
-	case strings.HasPrefix(principal, PrefixServiceAccount):
-		return "service_account"
-	case strings.HasPrefix(principal, PrefixRole):
-		return "role"
-	default:
+	id, err := ParseCasbinID(principal)
+	if err != nil {
 		return ""
 	}
+	return id.Kind.String()
 }
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoleName(t *testing.T) {
+	tests := []struct {
+		role    string
+		want    string
+		wantErr string
+	}{
+		{role: "product-engineer", want: "product-engineer"},      // Principal.Roles
+		{role: "role:product-engineer", want: "product-engineer"}, // Casbin grouping rules
+		{role: "role:", wantErr: "empty role name"},
+		{role: "", wantErr: "empty role name"},
+		{role: "user:alice@example.com", wantErr: "user identifier"},
+		{role: "group:platform", wantErr: "group identifier"},
+		{role: "sa:0192f4a1", wantErr: "service_account identifier"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			got, err := ParseRoleName(tt.role)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCasbinIDRoundTrip(t *testing.T) {
+	tests := []struct {
+		kind    CasbinKind
+		name    string
+		want    string
+		helper  func(string) string
+		extract func(string) (string, error)
+	}{
+		{kind: CasbinUser, name: "alice@example.com", want: "user:alice@example.com", helper: UserID, extract: ExtractUserID},
+		{kind: CasbinGroup, name: "platform/dev", want: "group:platform/dev", helper: GroupID, extract: ExtractGroupID},
+		{kind: CasbinServiceAccount, name: "0192f4a1-7c3e-7000-8000-000000000001", want: "sa:0192f4a1-7c3e-7000-8000-000000000001", helper: ServiceAccountID, extract: ExtractServiceAccountID},
+		{kind: CasbinRole, name: "product-engineer", want: "role:product-engineer", helper: RoleID, extract: ExtractRoleID},
+		{kind: CasbinUser, name: "role:admin", want: "user:role:admin", helper: UserID, extract: ExtractUserID}, // Only the leading prefix counts
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			id := NewCasbinID(tt.kind, tt.name)
+			assert.Equal(t, tt.want, id.String())
+			assert.Equal(t, tt.want, tt.helper(tt.name))
+
+			parsed, err := ParseCasbinID(id.String())
+			require.NoError(t, err)
+			assert.Equal(t, id, parsed)
+			assert.Equal(t, tt.kind.String(), GetPrincipalType(id.String()))
+
+			name, err := tt.extract(id.String())
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+
+	t.Run("kind mismatch", func(t *testing.T) {
+		_, err := ExtractServiceAccountID(UserID("alice"))
+		assert.EqualError(t, err, "invalid service account principal: user:alice (expected prefix sa:)")
+		_, err = ExtractRoleID(GroupID("dev"))
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown prefix", func(t *testing.T) {
+		_, err := ParseCasbinID("service_account:ci")
+		assert.Error(t, err)
+		assert.Empty(t, GetPrincipalType("service_account:ci"))
+	})
+}
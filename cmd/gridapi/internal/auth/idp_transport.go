@@ -0,0 +1,352 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// External IdP resilience (Mode 1). Discovery and JWKS fetches retry transient
+// failures with exponential backoff. After idpFailureThreshold failed fetches in a
+// row the circuit opens and the IdP is left alone for idpCircuitCooldown.
+//
+// Only the discovery document and the JWKS are cached, and only 200 responses.
+// The response's Cache-Control (no-store, no-cache, max-age) or Expires header
+// decides whether it is stored and how long it is served without asking the
+// IdP. While the IdP is unreachable the last good response keeps being served,
+// fresh or not, until it is older than idpMaxStaleness.
+const (
+	idpMaxAttempts      = 3
+	idpInitialBackoff   = 200 * time.Millisecond
+	idpMaxBackoff       = 2 * time.Second
+	idpFailureThreshold = 5
+	idpCircuitCooldown  = 30 * time.Second
+	idpMaxStaleness     = 24 * time.Hour
+)
+
+var errIdPCircuitOpen = errors.New("external idp circuit open")
+
+// IdPHealth reports external IdP reachability for the health endpoint.
+type IdPHealth struct {
+	Reachable           bool       `json:"reachable"`
+	CircuitOpen         bool       `json:"circuit_open"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+// discoveryPath is the OpenID Connect discovery document's well-known path.
+const discoveryPath = "/.well-known/openid-configuration"
+
+// IdPTransport is the http.RoundTripper used for calls to the external IdP.
+// GET requests (discovery, JWKS, userinfo) are retried; other methods such as
+// the token exchange pass straight through. JWKS URIs are learned from cached
+// discovery documents or registered with WithJWKSURI.
+type IdPTransport struct {
+	base  http.RoundTripper
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu          sync.Mutex
+	failures    int
+	openUntil   time.Time
+	lastSuccess time.Time
+	lastErr     error
+	jwksURIs    map[string]bool
+	cache       map[string]cachedIdPResponse
+}
+
+type cachedIdPResponse struct {
+	header     http.Header
+	body       []byte
+	fetchedAt  time.Time
+	freshUntil time.Time
+}
+
+// NewIdPTransport wraps base (http.DefaultTransport when nil).
+func NewIdPTransport(base http.RoundTripper) *IdPTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &IdPTransport{
+		base:     base,
+		now:      time.Now,
+		sleep:    sleepContext,
+		jwksURIs: make(map[string]bool),
+		cache:    make(map[string]cachedIdPResponse),
+	}
+}
+
+// WithJWKSURI marks jwksURI as a key set to cache, for issuers whose JWKS is
+// configured rather than discovered.
+func (t *IdPTransport) WithJWKSURI(jwksURI string) *IdPTransport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.jwksURIs[jwksURI] = true
+	return t
+}
+
+// Client returns an http.Client using the transport.
+func (t *IdPTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *IdPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cacheable := t.cacheable(req)
+	if cacheable && !requestsRevalidation(req) {
+		if resp, ok := t.fresh(req, key); ok {
+			return resp, nil
+		}
+	}
+
+	if t.circuitOpen() {
+		return t.fromCache(req, key, cacheable, errIdPCircuitOpen)
+	}
+
+	resp, body, err := t.fetch(req)
+	if err != nil {
+		t.recordFailure(err)
+		return t.fromCache(req, key, cacheable, err)
+	}
+
+	t.recordSuccess()
+	if cacheable {
+		t.store(key, req, resp, body)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// Health returns a snapshot of the IdP's reachability.
+func (t *IdPTransport) Health() IdPHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health := IdPHealth{
+		Reachable:           t.failures == 0,
+		CircuitOpen:         t.failures >= idpFailureThreshold && t.now().Before(t.openUntil),
+		ConsecutiveFailures: t.failures,
+	}
+	if !t.lastSuccess.IsZero() {
+		lastSuccess := t.lastSuccess
+		health.LastSuccess = &lastSuccess
+	}
+	if t.lastErr != nil {
+		health.LastError = t.lastErr.Error()
+	}
+	return health
+}
+
+// fetch performs the request, retrying network errors, 429 and 5xx responses.
+func (t *IdPTransport) fetch(req *http.Request) (*http.Response, []byte, error) {
+	backoff := idpInitialBackoff
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req.Clone(req.Context()))
+		if err == nil {
+			body, readErr := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			switch {
+			case readErr != nil:
+				err = fmt.Errorf("read %s: %w", req.URL, readErr)
+			case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+				err = fmt.Errorf("%s returned %s", req.URL, resp.Status)
+			default:
+				return resp, body, nil
+			}
+		}
+		lastErr = err
+
+		if attempt == idpMaxAttempts {
+			return nil, nil, lastErr
+		}
+		if err := t.sleep(req.Context(), backoff); err != nil {
+			return nil, nil, lastErr
+		}
+		backoff = min(backoff*2, idpMaxBackoff)
+	}
+}
+
+func (t *IdPTransport) circuitOpen() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// Once the cooldown has passed the next request is let through; a failure
+	// reopens the circuit straight away.
+	return t.failures >= idpFailureThreshold && t.now().Before(t.openUntil)
+}
+
+func (t *IdPTransport) recordFailure(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failures++
+	t.lastErr = err
+	if t.failures >= idpFailureThreshold {
+		t.openUntil = t.now().Add(idpCircuitCooldown)
+	}
+}
+
+func (t *IdPTransport) recordSuccess() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failures = 0
+	t.lastErr = nil
+	t.lastSuccess = t.now()
+}
+
+// cacheable reports whether req is for a discovery document or a known JWKS.
+// Requests carrying credentials are never cached since the cache is keyed by
+// URL alone.
+func (t *IdPTransport) cacheable(req *http.Request) bool {
+	if req.Header.Get("Authorization") != "" {
+		return false
+	}
+	if strings.HasSuffix(req.URL.Path, discoveryPath) {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.jwksURIs[req.URL.String()]
+}
+
+// store caches a 200 response unless its headers forbid it. A cached discovery
+// document also marks its jwks_uri as cacheable.
+func (t *IdPTransport) store(key string, req *http.Request, resp *http.Response, body []byte) {
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+	directives := cacheControl(resp.Header)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, noStore := directives["no-store"]; noStore {
+		delete(t.cache, key)
+		return
+	}
+
+	now := t.now()
+	t.cache[key] = cachedIdPResponse{
+		header:     resp.Header.Clone(),
+		body:       body,
+		fetchedAt:  now,
+		freshUntil: freshUntil(resp.Header, directives, now),
+	}
+
+	if strings.HasSuffix(req.URL.Path, discoveryPath) {
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if json.Unmarshal(body, &discovery) == nil && discovery.JWKSURI != "" {
+			t.jwksURIs[discovery.JWKSURI] = true
+		}
+	}
+}
+
+// fresh serves the cached response for key while its headers say it is fresh.
+func (t *IdPTransport) fresh(req *http.Request, key string) (*http.Response, bool) {
+	t.mu.Lock()
+	cached, ok := t.cache[key]
+	fresh := ok && t.now().Before(cached.freshUntil)
+	t.mu.Unlock()
+
+	if !fresh {
+		return nil, false
+	}
+	return cached.response(req), true
+}
+
+// fromCache serves the last good response for a cacheable key, fresh or not,
+// unless it is older than idpMaxStaleness; otherwise it returns cause.
+func (t *IdPTransport) fromCache(req *http.Request, key string, cacheable bool, cause error) (*http.Response, error) {
+	if !cacheable {
+		return nil, fmt.Errorf("external idp unavailable: %w", cause)
+	}
+	t.mu.Lock()
+	cached, ok := t.cache[key]
+	stale := ok && t.now().Sub(cached.fetchedAt) > idpMaxStaleness
+	t.mu.Unlock()
+
+	if !ok || stale {
+		return nil, fmt.Errorf("external idp unavailable: %w", cause)
+	}
+	return cached.response(req), nil
+}
+
+func (cached cachedIdPResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.body)),
+		ContentLength: int64(len(cached.body)),
+		Request:       req,
+	}
+}
+
+// requestsRevalidation reports whether the caller asked to bypass fresh
+// cached responses with Cache-Control: no-cache.
+func requestsRevalidation(req *http.Request) bool {
+	_, noCache := cacheControl(req.Header)["no-cache"]
+	return noCache
+}
+
+// cacheControl parses the Cache-Control directives of h, lower-cased.
+func cacheControl(h http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, value := range h.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name != "" {
+				directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+			}
+		}
+	}
+	return directives
+}
+
+// freshUntil returns when a response fetched at now stops being fresh: from
+// max-age, else Expires. no-cache, or neither header, means it is never served
+// without asking the IdP first.
+func freshUntil(h http.Header, directives map[string]string, now time.Time) time.Time {
+	if _, noCache := directives["no-cache"]; noCache {
+		return now
+	}
+	if maxAge, ok := directives["max-age"]; ok {
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil || seconds <= 0 {
+			return now
+		}
+		age, _ := strconv.Atoi(h.Get("Age"))
+		return now.Add(time.Duration(seconds-max(age, 0)) * time.Second)
+	}
+	if expires, err := http.ParseTime(h.Get("Expires")); err == nil {
+		return expires
+	}
+	return now
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
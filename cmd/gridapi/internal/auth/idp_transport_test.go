@@ -0,0 +1,310 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xenitab/go-oidc-middleware/oidctoken"
+	"github.com/xenitab/go-oidc-middleware/options"
+)
+
+// fakeIdP serves discovery, JWKS and userinfo documents, with cacheControl
+// as their Cache-Control header. Requests fail with 503 while failNext is
+// positive (transient) or down is set (outage).
+type fakeIdP struct {
+	server   *httptest.Server
+	failNext atomic.Int32
+	down     atomic.Bool
+	requests atomic.Int32
+
+	mu           sync.Mutex
+	key          *rsa.PrivateKey // current signing key
+	keyID        string
+	published    []jose.JSONWebKey
+	cacheControl string
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	idp := &fakeIdP{}
+	idp.rotateKey(t, "idp-key")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		idp.writeCacheControl(w)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   idp.server.URL,
+			"jwks_uri": idp.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		idp.writeCacheControl(w)
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: idp.published})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, _ *http.Request) {
+		idp.writeCacheControl(w)
+		_ = json.NewEncoder(w).Encode(map[string]string{"sub": "alice"})
+	})
+	idp.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idp.requests.Add(1)
+		if idp.down.Load() || idp.failNext.Add(-1) >= 0 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *fakeIdP) setCacheControl(value string) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.cacheControl = value
+}
+
+func (idp *fakeIdP) writeCacheControl(w http.ResponseWriter) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	if idp.cacheControl != "" {
+		w.Header().Set("Cache-Control", idp.cacheControl)
+	}
+}
+
+// rotateKey publishes a new signing key alongside the old ones and signs
+// subsequent tokens with it.
+func (idp *fakeIdP) rotateKey(t *testing.T, keyID string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.key, idp.keyID = key, keyID
+	idp.published = append(idp.published, jose.JSONWebKey{
+		Key: &key.PublicKey, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig",
+	})
+}
+
+func (idp *fakeIdP) token(t *testing.T) string {
+	t.Helper()
+	idp.mu.Lock()
+	key, keyID := idp.key, idp.keyID
+	idp.mu.Unlock()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: keyID}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	require.NoError(t, err)
+	now := time.Now()
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   idp.server.URL,
+		Subject:  "alice",
+		Audience: jwt.Audience{"grid-api"},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func newTestIdPTransport() *IdPTransport {
+	transport := NewIdPTransport(nil)
+	transport.sleep = func(context.Context, time.Duration) error { return nil }
+	return transport
+}
+
+func TestIdPTransport_TransientFailures(t *testing.T) {
+	idp := newFakeIdP(t)
+	transport := newTestIdPTransport()
+
+	// Discovery and JWKS are fetched at startup; both ride out a blip.
+	idp.failNext.Store(idpMaxAttempts - 1)
+	handler, err := oidctoken.New[map[string]any](nil,
+		options.WithIssuer(idp.server.URL),
+		options.WithHttpClient(transport.Client()),
+	)
+	require.NoError(t, err)
+
+	claims, err := handler.ParseToken(context.Background(), idp.token(t))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims["sub"])
+
+	health := transport.Health()
+	assert.True(t, health.Reachable)
+	assert.False(t, health.CircuitOpen)
+	require.NotNil(t, health.LastSuccess)
+}
+
+func TestIdPTransport_ServesCachedKeysDuringOutage(t *testing.T) {
+	idp := newFakeIdP(t)
+	jwksURL := idp.server.URL + "/keys"
+	transport := newTestIdPTransport().WithJWKSURI(jwksURL)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	client := transport.Client()
+
+	fetchKeys := func() (*jose.JSONWebKeySet, error) {
+		resp, err := client.Get(jwksURL)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		var keys jose.JSONWebKeySet
+		return &keys, json.Unmarshal(body, &keys)
+	}
+
+	_, err := fetchKeys()
+	require.NoError(t, err)
+
+	idp.down.Store(true)
+	for i := 0; i < idpFailureThreshold; i++ {
+		keys, err := fetchKeys()
+		require.NoError(t, err, "cached keys should be served while the IdP is down")
+		require.Len(t, keys.Key("idp-key"), 1)
+	}
+
+	health := transport.Health()
+	assert.False(t, health.Reachable)
+	assert.True(t, health.CircuitOpen)
+	assert.Equal(t, idpFailureThreshold, health.ConsecutiveFailures)
+	assert.Contains(t, health.LastError, "503")
+
+	// With the circuit open the IdP is not called at all.
+	requests := idp.requests.Load()
+	_, err = fetchKeys()
+	require.NoError(t, err)
+	assert.Equal(t, requests, idp.requests.Load())
+
+	// Past the staleness bound the outage surfaces to callers.
+	now = now.Add(idpMaxStaleness + time.Minute)
+	_, err = fetchKeys()
+	assert.ErrorContains(t, err, "external idp unavailable")
+
+	// Recovery closes the circuit.
+	idp.down.Store(false)
+	now = now.Add(idpCircuitCooldown)
+	_, err = fetchKeys()
+	require.NoError(t, err)
+	health = transport.Health()
+	assert.True(t, health.Reachable)
+	assert.False(t, health.CircuitOpen)
+}
+
+func TestIdPTransport_CachesOnlyDiscoveryAndJWKS(t *testing.T) {
+	idp := newFakeIdP(t)
+	transport := newTestIdPTransport()
+	client := transport.Client()
+
+	get := func(url string, header http.Header) (int, error) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	// The discovery document names the JWKS, so both are cached
+	for _, path := range []string{"/.well-known/openid-configuration", "/keys", "/userinfo", "/missing"} {
+		_, err := get(idp.server.URL+path, nil)
+		require.NoError(t, err)
+	}
+	_, err := get(idp.server.URL+"/keys", http.Header{"Authorization": {"Bearer token"}})
+	require.NoError(t, err)
+
+	idp.down.Store(true)
+	for _, path := range []string{"/.well-known/openid-configuration", "/keys"} {
+		status, err := get(idp.server.URL+path, nil)
+		require.NoError(t, err, path)
+		assert.Equal(t, http.StatusOK, status, path)
+	}
+	for _, path := range []string{"/userinfo", "/missing"} {
+		_, err := get(idp.server.URL+path, nil)
+		assert.ErrorContains(t, err, "external idp unavailable", path)
+	}
+	_, err = get(idp.server.URL+"/keys", http.Header{"Authorization": {"Bearer token"}})
+	assert.ErrorContains(t, err, "external idp unavailable")
+}
+
+func TestIdPTransport_HonorsCacheControl(t *testing.T) {
+	idp := newFakeIdP(t)
+	jwksURL := idp.server.URL + "/keys"
+	transport := newTestIdPTransport().WithJWKSURI(jwksURL)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	client := transport.Client()
+
+	get := func(header http.Header) error {
+		req, err := http.NewRequest(http.MethodGet, jwksURL, nil)
+		require.NoError(t, err)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	t.Run("max-age is served without asking the idp", func(t *testing.T) {
+		idp.setCacheControl("public, max-age=300")
+		require.NoError(t, get(nil))
+		requests := idp.requests.Load()
+
+		now = now.Add(time.Minute)
+		require.NoError(t, get(nil))
+		assert.Equal(t, requests, idp.requests.Load())
+
+		// A no-cache request revalidates
+		require.NoError(t, get(http.Header{"Cache-Control": {"no-cache"}}))
+		assert.Equal(t, requests+1, idp.requests.Load())
+
+		// Once max-age passes the idp is asked again
+		now = now.Add(5 * time.Minute)
+		require.NoError(t, get(nil))
+		assert.Equal(t, requests+2, idp.requests.Load())
+	})
+
+	t.Run("no-cache is stored but always revalidated", func(t *testing.T) {
+		idp.setCacheControl("no-cache")
+		require.NoError(t, get(http.Header{"Cache-Control": {"no-cache"}}))
+		requests := idp.requests.Load()
+		require.NoError(t, get(nil))
+		assert.Equal(t, requests+1, idp.requests.Load())
+	})
+
+	t.Run("no-store is never served from cache", func(t *testing.T) {
+		idp.setCacheControl("no-store")
+		require.NoError(t, get(http.Header{"Cache-Control": {"no-cache"}}))
+
+		idp.down.Store(true)
+		defer idp.down.Store(false)
+		assert.ErrorContains(t, get(nil), "external idp unavailable")
+	})
+}
@@ -0,0 +1,214 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jwksUnknownKeyRefreshInterval rate-limits on-demand JWKS fetches. The token
+// handler only asks for keys when it sees an unknown kid, so a flood of tokens
+// signed with a bogus kid reaches the IdP at most once per interval.
+const jwksUnknownKeyRefreshInterval = 10 * time.Second
+
+// JWKSCache holds the external IdP's key set (Mode 1) in memory so validating
+// a bearer token never waits on the IdP. The key set is refreshed in the
+// background by Run; a token with an unknown kid triggers one rate-limited
+// refresh. When a refresh fails the last-known-good key set keeps being served.
+//
+// The cache is an http.RoundTripper: requests for the JWKS URI are answered
+// from memory and everything else goes to base.
+type JWKSCache struct {
+	base    http.RoundTripper
+	jwksURI string
+	now     func() time.Time
+
+	// refreshMu serializes fetches so concurrent unknown-kid lookups share one
+	refreshMu sync.Mutex
+
+	mu          sync.Mutex
+	body        []byte
+	header      http.Header
+	fetchedAt   time.Time // last successful fetch
+	lastAttempt time.Time // last fetch, successful or not
+}
+
+// NewJWKSCache caches the key set at jwksURI, fetched through base
+// (http.DefaultTransport when nil).
+func NewJWKSCache(base http.RoundTripper, jwksURI string) *JWKSCache {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &JWKSCache{base: base, jwksURI: jwksURI, now: time.Now}
+}
+
+// DiscoverJWKSURI reads jwks_uri from the issuer's discovery document.
+func DiscoverJWKSURI(ctx context.Context, client *http.Client, issuer string) (string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("build discovery request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch discovery document: %s returned %s", discoveryURL, resp.Status)
+	}
+
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return "", fmt.Errorf("decode discovery document: %w", err)
+	}
+	if discovery.JWKSURI == "" {
+		return "", fmt.Errorf("discovery document at %s has no jwks_uri", discoveryURL)
+	}
+	return discovery.JWKSURI, nil
+}
+
+// URI returns the cached JWKS URI.
+func (c *JWKSCache) URI() string {
+	return c.jwksURI
+}
+
+// Client returns an http.Client using the cache as its transport.
+func (c *JWKSCache) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// FetchedAt returns when the key set was last fetched successfully (zero if
+// never).
+func (c *JWKSCache) FetchedAt() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetchedAt
+}
+
+// Run refreshes the key set every interval until ctx is done.
+func (c *JWKSCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				log.Printf("WARNING: JWKS refresh failed, serving last-known-good keys: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh fetches the key set from the IdP. On failure the cached key set is
+// left untouched.
+func (c *JWKSCache) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	return c.refresh(ctx, false)
+}
+
+// refresh fetches the key set. revalidate asks base to skip any HTTP cache,
+// as an unknown kid may mean the IdP rotated keys within the key set's max-age.
+func (c *JWKSCache) refresh(ctx context.Context, revalidate bool) error {
+	c.mu.Lock()
+	c.lastAttempt = c.now()
+	c.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.jwksURI, nil)
+	if err != nil {
+		return fmt.Errorf("build jwks request: %w", err)
+	}
+	if revalidate {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
+	resp, err := c.base.RoundTrip(req)
+	if err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read jwks: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch jwks: %s returned %s", c.jwksURI, resp.Status)
+	}
+
+	var keySet struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &keySet); err != nil {
+		return fmt.Errorf("decode jwks: %w", err)
+	}
+	if len(keySet.Keys) == 0 {
+		// An empty set would reject every token; keep the previous keys instead
+		return fmt.Errorf("jwks at %s contains no keys", c.jwksURI)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.body = body
+	c.header = resp.Header.Clone()
+	c.fetchedAt = c.now()
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper. The token handler requests the key
+// set at startup and whenever a token names an unknown kid; either way the IdP
+// is asked at most once per jwksUnknownKeyRefreshInterval.
+func (c *JWKSCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.URL.String() != c.jwksURI {
+		return c.base.RoundTrip(req)
+	}
+
+	c.refreshMu.Lock()
+	c.mu.Lock()
+	due := c.now().Sub(c.lastAttempt) >= jwksUnknownKeyRefreshInterval
+	c.mu.Unlock()
+	var refreshErr error
+	if due {
+		refreshErr = c.refresh(req.Context(), true)
+	}
+	c.refreshMu.Unlock()
+
+	c.mu.Lock()
+	body, header := c.body, c.header
+	c.mu.Unlock()
+
+	if body == nil {
+		if refreshErr == nil {
+			refreshErr = fmt.Errorf("no key set fetched yet")
+		}
+		return nil, fmt.Errorf("jwks unavailable: %w", refreshErr)
+	}
+	if refreshErr != nil {
+		log.Printf("WARNING: JWKS refresh failed, serving last-known-good keys: %v", refreshErr)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
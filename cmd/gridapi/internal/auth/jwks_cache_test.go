@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xenitab/go-oidc-middleware/oidctoken"
+	"github.com/xenitab/go-oidc-middleware/options"
+)
+
+// newTestJWKSCache returns a loaded cache for idp whose clock is advanced by
+// the returned function.
+func newTestJWKSCache(t *testing.T, idp *fakeIdP) (*JWKSCache, func(time.Duration)) {
+	t.Helper()
+	now := time.Now()
+	cache := NewJWKSCache(nil, idp.server.URL+"/keys")
+	cache.now = func() time.Time { return now }
+	require.NoError(t, cache.Refresh(context.Background()))
+	return cache, func(d time.Duration) { now = now.Add(d) }
+}
+
+func newCachedTokenHandler(t *testing.T, idp *fakeIdP, cache *JWKSCache) *oidctoken.TokenHandler[map[string]any] {
+	t.Helper()
+	handler, err := oidctoken.New[map[string]any](nil,
+		options.WithIssuer(idp.server.URL),
+		options.WithJwksUri(cache.URI()),
+		options.WithHttpClient(cache.Client()),
+	)
+	require.NoError(t, err)
+	return handler
+}
+
+func TestJWKSCache_KeyRotation(t *testing.T) {
+	idp := newFakeIdP(t)
+	cache, advance := newTestJWKSCache(t, idp)
+	handler := newCachedTokenHandler(t, idp, cache)
+	ctx := context.Background()
+
+	// Known keys are served from memory; the IdP is not asked per request
+	requests := idp.requests.Load()
+	for i := 0; i < 3; i++ {
+		_, err := handler.ParseToken(ctx, idp.token(t))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, requests, idp.requests.Load())
+
+	// An unknown kid triggers one refresh that picks up the rotated key
+	idp.rotateKey(t, "idp-key-2")
+	advance(jwksUnknownKeyRefreshInterval)
+	claims, err := handler.ParseToken(ctx, idp.token(t))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims["sub"])
+	assert.Equal(t, requests+1, idp.requests.Load())
+
+	// Unknown kids inside the rate limit window fail without reaching the IdP
+	idp.rotateKey(t, "idp-key-3")
+	requests = idp.requests.Load()
+	_, err = handler.ParseToken(ctx, idp.token(t))
+	require.ErrorContains(t, err, "idp-key-3")
+	assert.Equal(t, requests, idp.requests.Load())
+}
+
+func TestJWKSCache_IdPDowntime(t *testing.T) {
+	idp := newFakeIdP(t)
+	cache, advance := newTestJWKSCache(t, idp)
+	handler := newCachedTokenHandler(t, idp, cache)
+	ctx := context.Background()
+
+	// A background refresh sees the rotated key before the IdP goes down
+	idp.rotateKey(t, "idp-key-2")
+	advance(time.Minute)
+	require.NoError(t, cache.Refresh(ctx))
+	fetchedAt := cache.FetchedAt()
+
+	idp.down.Store(true)
+	advance(time.Minute)
+	assert.Error(t, cache.Refresh(ctx))
+	assert.Equal(t, fetchedAt, cache.FetchedAt(), "a failed refresh keeps the last-known-good keys")
+
+	// The unknown-kid refresh fails too, but the last-known-good set has the key
+	advance(jwksUnknownKeyRefreshInterval)
+	claims, err := handler.ParseToken(ctx, idp.token(t))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims["sub"])
+}
+
+func TestJWKSCache_KeepsKeysOnBadResponse(t *testing.T) {
+	idp := newFakeIdP(t)
+	cache, advance := newTestJWKSCache(t, idp)
+	fetchedAt := cache.FetchedAt()
+
+	idp.mu.Lock()
+	idp.published = nil
+	idp.mu.Unlock()
+
+	advance(time.Minute)
+	require.ErrorContains(t, cache.Refresh(context.Background()), "no keys")
+	assert.Equal(t, fetchedAt, cache.FetchedAt())
+
+	resp, err := cache.Client().Get(cache.URI())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestJWKSCache_Run(t *testing.T) {
+	idp := newFakeIdP(t)
+	cache := NewJWKSCache(nil, idp.server.URL+"/keys")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		cache.Run(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return !cache.FetchedAt().IsZero() }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+}
+
+func TestDiscoverJWKSURI(t *testing.T) {
+	idp := newFakeIdP(t)
+
+	uri, err := DiscoverJWKSURI(context.Background(), nil, idp.server.URL+"/")
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/keys", uri)
+
+	idp.down.Store(true)
+	_, err = DiscoverJWKSURI(context.Background(), nil, idp.server.URL)
+	assert.ErrorContains(t, err, "503")
+}
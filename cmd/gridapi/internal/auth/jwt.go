@@ -271,6 +271,21 @@ func SetTokenHashContext(ctx context.Context, hash string) context.Context {
 	return context.WithValue(ctx, defaultTokenHashContextKey, hash)
 }
 
+// ExtractClaimsFromIDToken returns all claims from a stored ID token.
+// The token is parsed without verification; it was validated when the session
+// was created. Returns nil claims for an empty token.
+func ExtractClaimsFromIDToken(idToken string) (map[string]any, error) {
+	if idToken == "" {
+		return nil, nil
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(idToken, claims); err != nil {
+		return nil, fmt.Errorf("parse ID token: %w", err)
+	}
+	return claims, nil
+}
+
 // ExtractGroupsFromIDToken parses a JWT ID token and extracts the "groups" claim.
 // The token is parsed without verification since it's already validated and stored in the database.
 // Returns an empty slice if no groups claim is present.
@@ -281,15 +296,9 @@ func SetTokenHashContext(ctx context.Context, hash string) context.Context {
 //
 // Related: grid-80ad (External IdP group-to-role mapping fix)
 func ExtractGroupsFromIDToken(idToken string) ([]string, error) {
-	if idToken == "" {
-		return nil, nil
-	}
-
-	// Parse without verification (token is already validated and stored in DB)
-	claims := jwt.MapClaims{}
-	_, _, err := new(jwt.Parser).ParseUnverified(idToken, claims)
-	if err != nil {
-		return nil, fmt.Errorf("parse ID token: %w", err)
+	claims, err := ExtractClaimsFromIDToken(idToken)
+	if err != nil || claims == nil {
+		return nil, err
 	}
 
 	// Extract "groups" claim (may be string or []interface{})
@@ -8,8 +8,12 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,7 +22,6 @@ import (
 	jose "github.com/go-jose/go-jose/v4"
 	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
@@ -39,6 +42,35 @@ const (
 	defaultAccessTokenTTL  = 120 * time.Minute
 	defaultRefreshTokenTTL = 24 * time.Hour
 	defaultIDTokenTTL      = 15 * time.Minute
+
+	// refreshTokenReuseWindow is how long after a rotation the token it
+	// replaced may be presented again, as long as its successor is unused: a
+	// client that lost the rotation response retries rather than being
+	// treated as a token thief.
+	refreshTokenReuseWindow = 10 * time.Second
+
+	// signingKeyGracePeriod is how long a rotated-out key stays in the JWKS.
+	// It must cover the longest-lived JWT signed by the key (refresh tokens are opaque).
+	signingKeyGracePeriod = defaultAccessTokenTTL
+
+	// Device authorization flow (RFC 8628). Users approve codes at deviceUserFormPath.
+	deviceCodeLifetime = 10 * time.Minute
+	devicePollInterval = 5 * time.Second
+	deviceUserFormPath = "/auth/device"
+
+	// ScopeRoles requests the user's effective Grid roles, assigned directly or
+	// through groups, in the "roles" userinfo claim.
+	ScopeRoles = "roles"
+	rolesClaim = "roles"
+
+	// ScopeGroups requests the user's group memberships in access tokens and
+	// userinfo, so internal IdP groups map to roles the same way external ones do.
+	ScopeGroups = "groups"
+
+	// AuditActionRefreshTokenReuse is recorded when a rotated-out refresh token
+	// is presented again and its token family is revoked.
+	AuditActionRefreshTokenReuse  = "refresh_token.reuse"
+	AuditTargetRefreshTokenFamily = "refresh_token_family"
 )
 
 // ProviderDependencies holds the repositories required by the OIDC storage adapter.
@@ -46,12 +78,46 @@ type ProviderDependencies struct {
 	Users           repository.UserRepository
 	ServiceAccounts repository.ServiceAccountRepository
 	Sessions        repository.SessionRepository
-}
+	// DeviceAuthorizations persists device flow codes so polling survives restarts
+	// and works across replicas.
+	DeviceAuthorizations repository.DeviceAuthorizationRepository
+	// UserRoles and Roles resolve the "roles" userinfo claim until a
+	// RoleResolver is set (see Provider.WithRoleResolver), which also counts
+	// group-mapped roles. Optional; the claim is omitted when either is nil.
+	UserRoles repository.UserRoleRepository
+	Roles     repository.RoleRepository
+	// UserGroups resolves the groups claim. Optional; the claim is omitted when nil.
+	UserGroups repository.UserGroupRepository
+	// AuditLogs records refresh token reuse. Optional; reuse is only logged to
+	// stderr when nil.
+	AuditLogs repository.AuditLogRepository
+	// RevokedJTIs and RevocationEpoch let token exchange refuse subject
+	// tokens the bearer authenticator would reject; the refresh grant refuses
+	// refresh tokens issued before the epoch. Optional; the checks are skipped
+	// when nil. Share RevocationEpoch with the IAM service.
+	RevokedJTIs     repository.RevokedJTIRepository
+	RevocationEpoch *RevocationEpochCache
+}
+
+// RoleResolver returns the names of a user's effective roles: those assigned
+// directly and those mapped from groups.
+type RoleResolver func(ctx context.Context, userID string, groups []string) ([]string, error)
 
 // Provider exposes the server-side OIDC endpoints wired through zitadel/oidc.
 type Provider struct {
 	Router  chi.Router
 	Storage op.Storage
+
+	storage *providerStorage
+}
+
+// SigningKeyRotation describes the outcome of RotateSigningKey.
+type SigningKeyRotation struct {
+	// KeyID is the kid of the key that now signs new tokens.
+	KeyID string
+	// PreviousKeyID is the kid of the replaced key, still published in the JWKS until RetireAt.
+	PreviousKeyID string
+	RetireAt      time.Time
 }
 
 // loadOrGenerateSigningKey loads an RSA private key and its ID from disk, or generates and saves them if they don't exist.
@@ -105,30 +171,115 @@ func loadOrGenerateSigningKey(keyPath string) (*rsa.PrivateKey, string, error) {
 	// Generate a stable key ID
 	keyID := uuid.NewString()
 
+	if err := saveSigningKey(keyPath, privateKey, keyID); err != nil {
+		return nil, "", err
+	}
+
+	return privateKey, keyID, nil
+}
+
+// saveSigningKey writes the private key to keyPath and its ID to keyPath.kid.
+// Each file is replaced atomically so a crash never leaves a truncated key behind.
+func saveSigningKey(keyPath string, privateKey *rsa.PrivateKey, keyID string) error {
 	// Create parent directory if it doesn't exist
 	if dir := filepath.Dir(keyPath); dir != "." && dir != "" {
 		if err := os.MkdirAll(dir, 0755); err != nil {
-			return nil, "", fmt.Errorf("create key directory: %w", err)
+			return fmt.Errorf("create key directory: %w", err)
 		}
 	}
 
-	// Save key to disk
-	keyBytes := x509.MarshalPKCS1PrivateKey(privateKey)
 	keyPEM := pem.EncodeToMemory(&pem.Block{
 		Type:  "RSA PRIVATE KEY",
-		Bytes: keyBytes,
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
 	})
+	if err := writeFileAtomic(keyPath, keyPEM, 0600); err != nil {
+		return fmt.Errorf("save signing key to disk: %w", err)
+	}
 
-	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
-		return nil, "", fmt.Errorf("save signing key to disk: %w", err)
+	if err := writeFileAtomic(keyPath+".kid", []byte(keyID), 0600); err != nil {
+		return fmt.Errorf("save key ID to disk: %w", err)
 	}
+	return nil
+}
 
-	// Save key ID to disk
-	if err := os.WriteFile(keyIDPath, []byte(keyID), 0600); err != nil {
-		return nil, "", fmt.Errorf("save key ID to disk: %w", err)
+// loadRetiringKeys reads the public keys kept in the JWKS after a rotation from
+// keyPath.previous. A missing file means no rotation has happened yet.
+func loadRetiringKeys(keyPath string) ([]retiringKey, error) {
+	data, err := os.ReadFile(keyPath + ".previous")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read previous signing keys: %w", err)
 	}
 
-	return privateKey, keyID, nil
+	var keys []retiringKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "RSA PUBLIC KEY" {
+			return nil, fmt.Errorf("invalid PEM block in previous signing keys")
+		}
+		publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse previous signing key: %w", err)
+		}
+		retireAt, err := time.Parse(time.RFC3339, block.Headers["Retire-At"])
+		if err != nil {
+			return nil, fmt.Errorf("parse previous signing key retire time: %w", err)
+		}
+		if block.Headers["Kid"] == "" {
+			return nil, fmt.Errorf("previous signing key is missing its key ID")
+		}
+		keys = append(keys, retiringKey{
+			rsaPublicKey: rsaPublicKey{id: block.Headers["Kid"], algorithm: jose.RS256, key: publicKey},
+			retireAt:     retireAt,
+		})
+	}
+	return keys, nil
+}
+
+// saveRetiringKeys replaces keyPath.previous with the given public keys.
+func saveRetiringKeys(keyPath string, keys []retiringKey) error {
+	var buf []byte
+	for _, k := range keys {
+		buf = append(buf, pem.EncodeToMemory(&pem.Block{
+			Type: "RSA PUBLIC KEY",
+			Headers: map[string]string{
+				"Kid":       k.id,
+				"Retire-At": k.retireAt.UTC().Format(time.RFC3339),
+			},
+			Bytes: x509.MarshalPKCS1PublicKey(k.key),
+		})...)
+	}
+	if err := writeFileAtomic(keyPath+".previous", buf, 0600); err != nil {
+		return fmt.Errorf("save previous signing keys: %w", err)
+	}
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 // NewOIDCProvider builds an OpenID provider instance when OIDC is enabled.
@@ -139,7 +290,7 @@ func NewOIDCProvider(ctx context.Context, cfg config.OIDCConfig, deps ProviderDe
 	if cfg.ClientID == "" {
 		return nil, fmt.Errorf("oidc client_id is required for internal IdP mode")
 	}
-	if deps.Users == nil || deps.ServiceAccounts == nil || deps.Sessions == nil {
+	if deps.Users == nil || deps.ServiceAccounts == nil || deps.Sessions == nil || deps.DeviceAuthorizations == nil {
 		return nil, fmt.Errorf("oidc storage dependencies incomplete")
 	}
 
@@ -147,14 +298,25 @@ func NewOIDCProvider(ctx context.Context, cfg config.OIDCConfig, deps ProviderDe
 	if err != nil {
 		return nil, fmt.Errorf("initialise oidc storage: %w", err)
 	}
+	// Issue groups under the claim the JWT authenticator reads them from.
+	if cfg.GroupsClaimField != "" {
+		storage.groupsClaim = cfg.GroupsClaimField
+	}
+	storage.tokenExchangeClients = cfg.TokenExchangeClients
 
 	opConfig := &op.Config{
 		CodeMethodS256:           true,
 		AuthMethodPost:           true,
 		GrantTypeRefreshToken:    true,
-		SupportedScopes:          []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
-		SupportedClaims:          op.DefaultSupportedClaims,
+		SupportedScopes:          []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess, ScopeRoles, ScopeGroups},
+		SupportedClaims:          append(slices.Clone(op.DefaultSupportedClaims), rolesClaim, storage.groupsClaim),
 		DefaultLogoutRedirectURI: "",
+		DeviceAuthorization: op.DeviceAuthorizationConfig{
+			Lifetime:     deviceCodeLifetime,
+			PollInterval: devicePollInterval,
+			UserFormPath: deviceUserFormPath,
+			UserCode:     op.UserCodeBase20,
+		},
 	}
 
 	provider, err := op.NewProvider(opConfig, storage, op.StaticIssuer(cfg.Issuer), op.WithAllowInsecure())
@@ -170,30 +332,86 @@ func NewOIDCProvider(ctx context.Context, cfg config.OIDCConfig, deps ProviderDe
 	return &Provider{
 		Router:  op.CreateRouter(provider),
 		Storage: storage,
+		storage: storage,
 	}, nil
 }
 
+// WithRoleResolver resolves the "roles" claim through resolve, so roles
+// inherited from groups are included. Set it before serving requests.
+func (p *Provider) WithRoleResolver(resolve RoleResolver) *Provider {
+	p.storage.resolveRoles = resolve
+	return p
+}
+
+// CompleteDeviceAuthorization approves or denies the pending device authorization
+// identified by userCode on behalf of the user with the given subject. Returns a
+// "not found" error when the code is unknown, expired or already decided.
+func (p *Provider) CompleteDeviceAuthorization(ctx context.Context, userCode, subject string, approved bool) error {
+	return p.storage.completeDeviceAuthorization(ctx, userCode, subject, approved)
+}
+
+// RevokeRefreshTokens drops the given refresh tokens so they can no longer be
+// exchanged for access tokens. Unknown tokens are ignored. Returns how many
+// were revoked.
+func (p *Provider) RevokeRefreshTokens(ctx context.Context, tokens []string) int {
+	p.storage.mu.Lock()
+	defer p.storage.mu.Unlock()
+
+	revoked := 0
+	for _, token := range tokens {
+		if _, ok := p.storage.refreshTokens[token]; ok {
+			delete(p.storage.refreshTokens, token)
+			revoked++
+		}
+	}
+	return revoked
+}
+
 // Handler exposes the chi.Router handling the OIDC endpoints.
 func (p *Provider) Handler() chi.Router {
 	return p.Router
 }
 
+// RotateSigningKey generates and persists a new token signing key. The previous
+// public key stays in the JWKS until every token it signed has expired, so
+// rotation does not invalidate outstanding tokens.
+func (p *Provider) RotateSigningKey(ctx context.Context) (*SigningKeyRotation, error) {
+	return p.storage.rotateSigningKey()
+}
+
 type providerStorage struct {
-	users           repository.UserRepository
-	serviceAccounts repository.ServiceAccountRepository
-	sessions        repository.SessionRepository
+	users                repository.UserRepository
+	serviceAccounts      repository.ServiceAccountRepository
+	sessions             repository.SessionRepository
+	deviceAuthorizations repository.DeviceAuthorizationRepository
+	userRoles            repository.UserRoleRepository
+	roles                repository.RoleRepository
+	userGroups           repository.UserGroupRepository
+	resolveRoles         RoleResolver // Effective roles for the roles claim; nil counts direct assignments only
+	auditLogs            repository.AuditLogRepository
+	revokedJTIs          repository.RevokedJTIRepository
+	revocationEpoch      *RevocationEpochCache
+	groupsClaim          string
+	// Client IDs allowed to use the token exchange grant (see token_exchange.go)
+	tokenExchangeClients []string
 
 	mu            sync.Mutex
 	authRequests  map[string]*authRequest
 	authCodes     map[string]string
 	refreshTokens map[string]*refreshToken
-	deviceCodes   map[string]deviceAuthorizationEntry
-	userCodes     map[string]string
+	// retiredRefreshTokens holds tokens replaced by rotation until they would
+	// have expired, so presenting one again can be recognised as reuse.
+	retiredRefreshTokens map[string]*refreshToken
+
+	keyPath      string
+	keysMu       sync.RWMutex
+	signingKey   *rsaSigningKey
+	retiringKeys []retiringKey // previous public keys still served in the JWKS
+	now          func() time.Time
 
-	signingKey *rsaSigningKey
-	issuer     string
-	audience   string // Audience claim for issued tokens (matches OIDC ClientID)
-	signer     op.Crypto
+	issuer   string
+	audience string // Audience claim for issued tokens (matches OIDC ClientID)
+	signer   op.Crypto
 }
 
 func (s *providerStorage) setSigner(signer op.Crypto) {
@@ -215,20 +433,92 @@ func newProviderStorage(deps ProviderDependencies, keyPath string) (*providerSto
 		return nil, fmt.Errorf("load or generate signing key: %w", err)
 	}
 
+	var retiring []retiringKey
+	if keyPath != "" {
+		if retiring, err = loadRetiringKeys(keyPath); err != nil {
+			return nil, err
+		}
+	}
+
 	return &providerStorage{
-		users:           deps.Users,
-		serviceAccounts: deps.ServiceAccounts,
-		sessions:        deps.Sessions,
-		authRequests:    make(map[string]*authRequest),
-		authCodes:       make(map[string]string),
-		refreshTokens:   make(map[string]*refreshToken),
-		deviceCodes:     make(map[string]deviceAuthorizationEntry),
-		userCodes:       make(map[string]string),
+		users:                deps.Users,
+		serviceAccounts:      deps.ServiceAccounts,
+		sessions:             deps.Sessions,
+		deviceAuthorizations: deps.DeviceAuthorizations,
+		userRoles:            deps.UserRoles,
+		roles:                deps.Roles,
+		userGroups:           deps.UserGroups,
+		auditLogs:            deps.AuditLogs,
+		revokedJTIs:          deps.RevokedJTIs,
+		revocationEpoch:      deps.RevocationEpoch,
+		groupsClaim:          ScopeGroups,
+		authRequests:         make(map[string]*authRequest),
+		authCodes:            make(map[string]string),
+		refreshTokens:        make(map[string]*refreshToken),
+		retiredRefreshTokens: make(map[string]*refreshToken),
+		keyPath:              keyPath,
 		signingKey: &rsaSigningKey{
 			id:        keyID, // Use the persisted/loaded key ID
 			algorithm: jose.RS256,
 			key:       privateKey,
 		},
+		retiringKeys: retiring,
+		now:          time.Now,
+	}, nil
+}
+
+// currentSigningKey returns the key used to sign new tokens.
+func (s *providerStorage) currentSigningKey() *rsaSigningKey {
+	s.keysMu.RLock()
+	defer s.keysMu.RUnlock()
+	return s.signingKey
+}
+
+// rotateSigningKey swaps in a freshly generated signing key. The replaced key's
+// public half is retired after signingKeyGracePeriod, the longest lifetime of
+// any token it can have signed.
+func (s *providerStorage) rotateSigningKey() (*SigningKeyRotation, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("generate signing key: %w", err)
+	}
+	next := &rsaSigningKey{id: uuid.NewString(), algorithm: jose.RS256, key: privateKey}
+
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+
+	now := s.now()
+	previous := s.signingKey
+	retireAt := now.Add(signingKeyGracePeriod)
+	retiring := make([]retiringKey, 0, len(s.retiringKeys)+1)
+	for _, k := range s.retiringKeys {
+		if now.Before(k.retireAt) {
+			retiring = append(retiring, k)
+		}
+	}
+	retiring = append(retiring, retiringKey{
+		rsaPublicKey: rsaPublicKey{id: previous.id, algorithm: previous.algorithm, key: &previous.key.PublicKey},
+		retireAt:     retireAt,
+	})
+
+	// Publish the outgoing key as retiring before replacing it, so a failure
+	// between the two writes still leaves every issued token verifiable.
+	if s.keyPath != "" {
+		if err := saveRetiringKeys(s.keyPath, retiring); err != nil {
+			return nil, err
+		}
+		if err := saveSigningKey(s.keyPath, privateKey, next.id); err != nil {
+			return nil, err
+		}
+	}
+
+	s.signingKey = next
+	s.retiringKeys = retiring
+
+	return &SigningKeyRotation{
+		KeyID:         next.id,
+		PreviousKeyID: previous.id,
+		RetireAt:      retireAt,
 	}, nil
 }
 
@@ -296,7 +586,7 @@ func (s *providerStorage) DeleteAuthRequest(ctx context.Context, id string) erro
 	return nil
 }
 
-func (s *providerStorage) createJWT(request op.TokenRequest, exp time.Time) (string, string, error) {
+func (s *providerStorage) createJWT(ctx context.Context, request op.TokenRequest, exp time.Time) (string, string, error) {
 	jti := uuid.NewString()
 	now := time.Now()
 
@@ -316,8 +606,23 @@ func (s *providerStorage) createJWT(request op.TokenRequest, exp time.Time) (str
 		Claims: make(map[string]any),
 	}
 
-	key := &jose.JSONWebKey{Key: s.signingKey.key, Algorithm: string(s.signingKey.algorithm), KeyID: s.signingKey.id}
-	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: s.signingKey.algorithm, Key: key}, nil)
+	privateClaims, err := s.GetPrivateClaimsFromScopes(ctx, request.GetSubject(), getClientID(request), request.GetScopes())
+	if err != nil {
+		return "", "", err
+	}
+	maps.Copy(claims.Claims, privateClaims)
+	if exchange, ok := request.(op.TokenExchangeRequest); ok {
+		claims.Claims[actorClaim] = delegationActor(exchange)
+	}
+	// Permission scopes down-scope the token (see PermissionScopes), so the
+	// authenticator must see them
+	if scopes := request.GetScopes(); len(scopes) > 0 {
+		claims.Claims["scope"] = strings.Join(scopes, " ")
+	}
+
+	signingKey := s.currentSigningKey()
+	key := &jose.JSONWebKey{Key: signingKey.key, Algorithm: string(signingKey.algorithm), KeyID: signingKey.id}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: signingKey.algorithm, Key: key}, nil)
 	if err != nil {
 		return "", "", err
 	}
@@ -330,7 +635,10 @@ func (s *providerStorage) createJWT(request op.TokenRequest, exp time.Time) (str
 
 func (s *providerStorage) CreateAccessToken(ctx context.Context, request op.TokenRequest) (string, time.Time, error) {
 	exp := time.Now().Add(defaultAccessTokenTTL)
-	token, jti, err := s.createJWT(request, exp)
+	if exchange, ok := request.(op.TokenExchangeRequest); ok {
+		exp = exchangedTokenExpiry(exchange, exp)
+	}
+	token, jti, err := s.createJWT(ctx, request, exp)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -343,19 +651,8 @@ func (s *providerStorage) CreateAccessToken(ctx context.Context, request op.Toke
 }
 
 func (s *providerStorage) CreateAccessAndRefreshTokens(ctx context.Context, request op.TokenRequest, currentRefreshToken string) (string, string, time.Time, error) {
-	if currentRefreshToken != "" {
-		s.mu.Lock()
-		if rt, ok := s.refreshTokens[currentRefreshToken]; ok {
-			delete(s.refreshTokens, currentRefreshToken)
-			go func() {
-				_ = s.revokeSessionByJTI(context.Background(), rt.AccessToken) // rt.AccessToken holds the JTI
-			}()
-		}
-		s.mu.Unlock()
-	}
-
 	exp := time.Now().Add(defaultAccessTokenTTL)
-	accessToken, jti, err := s.createJWT(request, exp)
+	accessToken, jti, err := s.createJWT(ctx, request, exp)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
@@ -364,7 +661,9 @@ func (s *providerStorage) CreateAccessAndRefreshTokens(ctx context.Context, requ
 	rt := &refreshToken{
 		ID:            refreshID,
 		Token:         refreshID,
+		FamilyID:      refreshID, // First token of a new grant starts its family
 		AuthTime:      time.Now(),
+		IssuedAt:      time.Now(),
 		AMR:           getAMR(request),
 		Audience:      request.GetAudience(),
 		UserID:        request.GetSubject(),
@@ -374,7 +673,25 @@ func (s *providerStorage) CreateAccessAndRefreshTokens(ctx context.Context, requ
 		AccessToken:   jti, // Store JTI instead of opaque token
 	}
 
+	// The token this rotation retires is swapped for its successor in one
+	// step, so a retry never sees it retired without a replacement
 	s.mu.Lock()
+	if currentRefreshToken != "" {
+		replaced := s.refreshTokens[currentRefreshToken]
+		if previous, ok := s.retiredRefreshTokens[currentRefreshToken]; ok && s.inReuseWindowLocked(previous) {
+			// Retry with the previous token: its unused successor is replaced
+			replaced = s.refreshTokens[previous.ReplacedBy]
+		}
+		if replaced != nil {
+			delete(s.refreshTokens, replaced.Token)
+			replaced.ReplacedBy, replaced.RetiredAt = rt.Token, s.now()
+			s.retireRefreshTokenLocked(replaced)
+			rt.FamilyID = replaced.FamilyID
+			go func() {
+				_ = s.revokeSessionByJTI(context.Background(), replaced.AccessToken) // AccessToken holds the JTI
+			}()
+		}
+	}
 	s.refreshTokens[rt.ID] = rt
 	s.mu.Unlock()
 
@@ -390,15 +707,121 @@ func (s *providerStorage) CreateAccessAndRefreshTokens(ctx context.Context, requ
 
 func (s *providerStorage) TokenRequestByRefreshToken(ctx context.Context, token string) (op.RefreshTokenRequest, error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	rt, ok := s.refreshTokens[token]
 	if !ok {
+		retired, reused := s.retiredRefreshTokens[token]
+		if !reused || !s.inReuseWindowLocked(retired) {
+			s.mu.Unlock()
+			if reused {
+				s.revokeRefreshTokenFamily(ctx, retired)
+			}
+			return nil, op.ErrInvalidRefreshToken
+		}
+		rt = retired // A retry within the reuse window
+	}
+	s.mu.Unlock()
+
+	// A refresh token issued before the revocation epoch is as dead as the
+	// access token issued with it
+	epoch, err := s.revocationEpoch.Epoch(ctx)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err).WithDescription("get revocation epoch")
+	}
+	if rt.IssuedAt.Before(epoch) {
+		s.mu.Lock()
+		delete(s.refreshTokens, token)
+		s.mu.Unlock()
 		return nil, op.ErrInvalidRefreshToken
 	}
 	return refreshTokenRequestFromRefreshToken(rt), nil
 }
 
+// inReuseWindowLocked reports whether retired may still be presented: it was
+// rotated out less than refreshTokenReuseWindow ago and the token that
+// replaced it has not been used yet. Callers must hold s.mu.
+func (s *providerStorage) inReuseWindowLocked(retired *refreshToken) bool {
+	if retired.ReplacedBy == "" || s.now().Sub(retired.RetiredAt) > refreshTokenReuseWindow {
+		return false
+	}
+	_, unused := s.refreshTokens[retired.ReplacedBy]
+	return unused
+}
+
+// retireRefreshTokenLocked remembers a token replaced by rotation and forgets
+// retired tokens past their expiry. Callers must hold s.mu.
+func (s *providerStorage) retireRefreshTokenLocked(rt *refreshToken) {
+	now := s.now()
+	for token, retired := range s.retiredRefreshTokens {
+		if now.After(retired.Expiration) {
+			delete(s.retiredRefreshTokens, token)
+		}
+	}
+	s.retiredRefreshTokens[rt.Token] = rt
+}
+
+// revokeRefreshTokenFamily responds to a rotated-out refresh token being
+// presented again. Either the client or an attacker replayed a stolen token and
+// it is impossible to tell which, so every token rotated from the same grant is
+// revoked along with the sessions and access tokens they issued, and the reuse
+// is audited.
+func (s *providerStorage) revokeRefreshTokenFamily(ctx context.Context, reused *refreshToken) {
+	ctx = context.WithoutCancel(ctx)
+
+	s.mu.Lock()
+	var jtis []string
+	revokedTokens := 0
+	for token, rt := range s.refreshTokens {
+		if rt.FamilyID == reused.FamilyID {
+			delete(s.refreshTokens, token)
+			jtis = append(jtis, rt.AccessToken)
+			revokedTokens++
+		}
+	}
+	for _, rt := range s.retiredRefreshTokens {
+		if rt.FamilyID == reused.FamilyID {
+			jtis = append(jtis, rt.AccessToken)
+		}
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, jti := range jtis {
+		if err := s.revokeAccessToken(ctx, jti, reused.UserID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	revokeErr := errors.Join(errs...)
+
+	log.Printf("WARNING: refresh token reuse detected for subject %s (client %s); revoked %d refresh tokens in family %s",
+		reused.UserID, reused.ApplicationID, revokedTokens, reused.FamilyID)
+	if s.auditLogs == nil {
+		return
+	}
+	entry := &models.AuditLogEntry{
+		OccurredAt: s.now(),
+		Actor:      "system",
+		Action:     AuditActionRefreshTokenReuse,
+		TargetType: AuditTargetRefreshTokenFamily,
+		TargetID:   reused.FamilyID,
+		After: map[string]any{
+			"subject":                reused.UserID,
+			"client_id":              reused.ApplicationID,
+			"revoked_refresh_tokens": revokedTokens,
+			"revoked_sessions":       len(jtis),
+		},
+		Outcome: "success",
+	}
+	if revokeErr != nil {
+		entry.Outcome = "failure"
+		msg := revokeErr.Error()
+		entry.Error = &msg
+	}
+	if err := s.auditLogs.Create(ctx, entry); err != nil {
+		log.Printf("ERROR: audit log write failed (action=%s, target=%s:%s): %v",
+			entry.Action, entry.TargetType, entry.TargetID, err)
+	}
+}
+
 func (s *providerStorage) TerminateSession(ctx context.Context, userID string, clientID string) error {
 	// TODO: Implement full session termination in the database for the user/client.
 	return nil
@@ -441,15 +864,29 @@ func (s *providerStorage) GetRefreshTokenInfo(ctx context.Context, clientID stri
 }
 
 func (s *providerStorage) SigningKey(ctx context.Context) (op.SigningKey, error) {
-	return s.signingKey, nil
+	return s.currentSigningKey(), nil
 }
 
 func (s *providerStorage) SignatureAlgorithms(context.Context) ([]jose.SignatureAlgorithm, error) {
-	return []jose.SignatureAlgorithm{s.signingKey.algorithm}, nil
+	return []jose.SignatureAlgorithm{s.currentSigningKey().algorithm}, nil
 }
 
+// KeySet returns the current public key followed by any rotated-out keys whose
+// grace window has not yet elapsed.
 func (s *providerStorage) KeySet(ctx context.Context) ([]op.Key, error) {
-	return []op.Key{&rsaPublicKey{signingKey: s.signingKey}}, nil
+	s.keysMu.RLock()
+	defer s.keysMu.RUnlock()
+
+	current := s.signingKey
+	keys := []op.Key{&rsaPublicKey{id: current.id, algorithm: current.algorithm, key: &current.key.PublicKey}}
+	now := s.now()
+	for i := range s.retiringKeys {
+		k := &s.retiringKeys[i]
+		if k.id != current.id && now.Before(k.retireAt) {
+			keys = append(keys, &k.rsaPublicKey)
+		}
+	}
+	return keys, nil
 }
 
 func (s *providerStorage) GetClientByClientID(ctx context.Context, clientID string) (op.Client, error) {
@@ -457,7 +894,9 @@ func (s *providerStorage) GetClientByClientID(ctx context.Context, clientID stri
 	if err != nil {
 		return nil, err
 	}
-	return newServiceAccountClient(sa), nil
+	client := newServiceAccountClient(sa)
+	client.tokenExchange = s.canExchangeTokens(sa.ClientID)
+	return client, nil
 }
 
 func (s *providerStorage) AuthorizeClientIDSecret(ctx context.Context, clientID, clientSecret string) error {
@@ -465,14 +904,25 @@ func (s *providerStorage) AuthorizeClientIDSecret(ctx context.Context, clientID,
 	if err != nil {
 		return err
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(sa.ClientSecretHash), []byte(clientSecret)); err != nil {
-		return fmt.Errorf("invalid client secret")
+	now := s.now()
+	if PreviousSecretExpired(sa, now) {
+		// The overlap of the last rotation has ended; drop the old hash
+		if err := s.serviceAccounts.ClearPreviousSecret(ctx, sa.ID, now); err != nil {
+			log.Printf("clear expired previous secret of service account %s: %v", sa.ClientID, err)
+		}
+	}
+	usedPrevious, err := VerifyClientSecret(sa, clientSecret, now)
+	if err != nil {
+		return err
+	}
+	if usedPrevious {
+		log.Printf("service account %s authenticated with its previous secret (valid until %s)", sa.ClientID, sa.PreviousSecretExpiresAt.Format(time.RFC3339))
 	}
 	return nil
 }
 
-func (s *providerStorage) SetUserinfoFromScopes(context.Context, *oidc.UserInfo, string, string, []string) error {
-	return nil
+func (s *providerStorage) SetUserinfoFromScopes(ctx context.Context, userInfo *oidc.UserInfo, userID, _ string, scopes []string) error {
+	return s.populateUserInfo(ctx, userInfo, userID, scopes)
 }
 
 func (s *providerStorage) SetUserinfoFromRequest(ctx context.Context, userInfo *oidc.UserInfo, token op.IDTokenRequest, scopes []string) error {
@@ -514,8 +964,25 @@ func (s *providerStorage) SetIntrospectionFromToken(ctx context.Context, resp *o
 	return nil
 }
 
-func (s *providerStorage) GetPrivateClaimsFromScopes(context.Context, string, string, []string) (map[string]any, error) {
-	return nil, nil
+// GetPrivateClaimsFromScopes adds the groups claim for users when the groups
+// scope was granted. It is used for access tokens, ID tokens and introspection.
+func (s *providerStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, _ string, scopes []string) (map[string]any, error) {
+	if s.userGroups == nil || !slices.Contains(scopes, ScopeGroups) {
+		return nil, nil
+	}
+	user, err := s.users.GetBySubject(ctx, userID)
+	if err != nil {
+		// Service accounts (client credentials) have no group memberships.
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	groups, err := s.userGroups.ListGroupNames(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{s.groupsClaim: groups}, nil
 }
 
 func (s *providerStorage) GetKeyByIDAndClientID(context.Context, string, string) (*jose.JSONWebKey, error) {
@@ -531,36 +998,81 @@ func (s *providerStorage) StoreDeviceAuthorization(ctx context.Context, clientID
 		return err
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	// User codes are short, so purge long-expired codes to keep collisions rare.
+	// Recently expired ones are kept so polls still get expired_token.
+	if err := s.deviceAuthorizations.DeleteExpired(ctx, s.now().Add(-deviceCodeLifetime)); err != nil {
+		return err
+	}
 
-	if _, exists := s.userCodes[userCode]; exists {
+	if _, err := s.deviceAuthorizations.GetByUserCode(ctx, userCode); err == nil {
 		return op.ErrDuplicateUserCode
+	} else if !isNotFoundError(err) {
+		return err
 	}
 
-	entry := deviceAuthorizationEntry{
-		deviceCode: deviceCode,
-		userCode:   userCode,
-		state: &op.DeviceAuthorizationState{
-			ClientID: clientID,
-			Scopes:   scopes,
-			Expires:  expires,
-		},
-	}
-	s.deviceCodes[deviceCode] = entry
-	s.userCodes[userCode] = deviceCode
-	return nil
+	return s.deviceAuthorizations.Create(ctx, &models.DeviceAuthorization{
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		ClientID:   clientID,
+		Scopes:     strings.Join(scopes, " "),
+		ExpiresAt:  expires,
+		CreatedAt:  s.now(),
+	})
 }
 
+// GetDeviceAuthorizatonState is polled by the token endpoint. op turns the
+// returned state into the response: pending (authorization_pending), Done
+// (token issued), Denied (access_denied) or expired (expired_token). Polling
+// faster than devicePollInterval yields context.DeadlineExceeded (slow_down).
 func (s *providerStorage) GetDeviceAuthorizatonState(ctx context.Context, clientID, deviceCode string) (*op.DeviceAuthorizationState, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entry, ok := s.deviceCodes[deviceCode]
-	if !ok || entry.state.ClientID != clientID {
+	record, err := s.deviceAuthorizations.GetByDeviceCode(ctx, deviceCode)
+	if err != nil || record.ClientID != clientID {
 		return nil, fmt.Errorf("device code not found")
 	}
-	return entry.state, nil
+
+	state := &op.DeviceAuthorizationState{
+		ClientID: record.ClientID,
+		Scopes:   strings.Fields(record.Scopes),
+		Expires:  record.ExpiresAt,
+		Denied:   record.Denied,
+	}
+
+	now := s.now()
+	if record.Denied || !now.Before(record.ExpiresAt) {
+		return state, nil
+	}
+
+	if record.Approved {
+		// Device codes are single use: the token is issued from this state,
+		// only by the poll whose delete consumed the code.
+		if err := s.deviceAuthorizations.Delete(ctx, deviceCode); err != nil {
+			return nil, fmt.Errorf("device code not found")
+		}
+		state.Done = true
+		state.Subject = record.Subject
+		if record.AuthTime != nil {
+			state.AuthTime = *record.AuthTime
+		}
+		return state, nil
+	}
+
+	lastPolled := record.LastPolledAt
+	if err := s.deviceAuthorizations.MarkPolled(ctx, deviceCode, now); err != nil {
+		return nil, err
+	}
+	if lastPolled != nil && now.Sub(*lastPolled) < devicePollInterval {
+		return nil, fmt.Errorf("device code polled more often than every %s: %w", devicePollInterval, context.DeadlineExceeded)
+	}
+	return state, nil
+}
+
+// completeDeviceAuthorization records the user's decision for a pending user code.
+func (s *providerStorage) completeDeviceAuthorization(ctx context.Context, userCode, subject string, approved bool) error {
+	userCode = strings.ToUpper(strings.TrimSpace(userCode))
+	if userCode == "" {
+		return fmt.Errorf("user code is required")
+	}
+	return s.deviceAuthorizations.Complete(ctx, userCode, subject, approved, s.now())
 }
 
 func (s *providerStorage) ClientCredentials(ctx context.Context, clientID, clientSecret string) (op.Client, error) {
@@ -698,9 +1210,12 @@ func (a *authRequest) GetRedirectURI() string {
 }
 
 type refreshToken struct {
-	ID            string
-	Token         string
+	ID    string
+	Token string
+	// FamilyID is shared by every token rotated from the same grant.
+	FamilyID      string
 	AuthTime      time.Time
+	IssuedAt      time.Time // checked against the revocation epoch
 	AMR           []string
 	Audience      []string
 	UserID        string
@@ -708,12 +1223,10 @@ type refreshToken struct {
 	Expiration    time.Time
 	Scopes        []string
 	AccessToken   string
-}
 
-type deviceAuthorizationEntry struct {
-	deviceCode string
-	userCode   string
-	state      *op.DeviceAuthorizationState
+	// ReplacedBy and RetiredAt are set when rotation retires the token.
+	ReplacedBy string
+	RetiredAt  time.Time
 }
 
 func (s *providerStorage) populateUserInfo(ctx context.Context, info *oidc.UserInfo, userID string, scopes []string) error {
@@ -731,11 +1244,69 @@ func (s *providerStorage) populateUserInfo(ctx context.Context, info *oidc.UserI
 		case oidc.ScopeProfile:
 			info.PreferredUsername = user.Email
 			info.Name = user.Name
+		case ScopeRoles:
+			if s.resolveRoles == nil && (s.userRoles == nil || s.roles == nil) {
+				continue
+			}
+			roles, err := s.effectiveRoleNames(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+			info.AppendClaims(rolesClaim, roles)
+		case ScopeGroups:
+			if s.userGroups == nil {
+				continue
+			}
+			groups, err := s.userGroups.ListGroupNames(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+			info.AppendClaims(s.groupsClaim, groups)
 		}
 	}
 	return nil
 }
 
+// effectiveRoleNames returns the sorted names of the user's effective roles,
+// resolved from the user's group memberships and direct assignments. Without
+// a role resolver only direct assignments are counted.
+func (s *providerStorage) effectiveRoleNames(ctx context.Context, userID string) ([]string, error) {
+	if s.resolveRoles == nil {
+		return s.userRoleNames(ctx, userID)
+	}
+	var groups []string
+	if s.userGroups != nil {
+		var err error
+		if groups, err = s.userGroups.ListGroupNames(ctx, userID); err != nil {
+			return nil, fmt.Errorf("get user groups: %w", err)
+		}
+	}
+	roles, err := s.resolveRoles(ctx, userID, groups)
+	if err != nil {
+		return nil, fmt.Errorf("resolve roles: %w", err)
+	}
+	sort.Strings(roles)
+	return roles, nil
+}
+
+// userRoleNames returns the sorted names of the roles assigned directly to the user.
+func (s *providerStorage) userRoleNames(ctx context.Context, userID string) ([]string, error) {
+	assignments, err := s.userRoles.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user roles: %w", err)
+	}
+	names := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		role, err := s.roles.GetByID(ctx, assignment.RoleID)
+		if err != nil {
+			return nil, fmt.Errorf("get role %s: %w", assignment.RoleID, err)
+		}
+		names = append(names, role.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 type rsaSigningKey struct {
 	id        string
 	algorithm jose.SignatureAlgorithm
@@ -755,15 +1326,17 @@ func (k *rsaSigningKey) ID() string {
 }
 
 type rsaPublicKey struct {
-	signingKey *rsaSigningKey
+	id        string
+	algorithm jose.SignatureAlgorithm
+	key       *rsa.PublicKey
 }
 
 func (k *rsaPublicKey) ID() string {
-	return k.signingKey.id
+	return k.id
 }
 
 func (k *rsaPublicKey) Algorithm() jose.SignatureAlgorithm {
-	return k.signingKey.algorithm
+	return k.algorithm
 }
 
 func (k *rsaPublicKey) Use() string {
@@ -771,14 +1344,21 @@ func (k *rsaPublicKey) Use() string {
 }
 
 func (k *rsaPublicKey) Key() any {
-	return &k.signingKey.key.PublicKey
+	return k.key
+}
+
+// retiringKey is a rotated-out public key kept in the JWKS until retireAt.
+type retiringKey struct {
+	rsaPublicKey
+	retireAt time.Time
 }
 
 type serviceAccountClient struct {
-	id string
+	id            string
+	tokenExchange bool // Listed in OIDCConfig.TokenExchangeClients
 }
 
-func newServiceAccountClient(sa *models.ServiceAccount) op.Client {
+func newServiceAccountClient(sa *models.ServiceAccount) *serviceAccountClient {
 	return &serviceAccountClient{id: sa.ClientID}
 }
 
@@ -807,11 +1387,15 @@ func (c *serviceAccountClient) ResponseTypes() []oidc.ResponseType {
 }
 
 func (c *serviceAccountClient) GrantTypes() []oidc.GrantType {
-	return []oidc.GrantType{
+	grantTypes := []oidc.GrantType{
 		oidc.GrantTypeCode,
 		oidc.GrantTypeRefreshToken,
 		oidc.GrantTypeClientCredentials,
 	}
+	if c.tokenExchange {
+		grantTypes = append(grantTypes, oidc.GrantTypeTokenExchange)
+	}
+	return grantTypes
 }
 
 func (c *serviceAccountClient) LoginURL(requestID string) string {
@@ -838,8 +1422,10 @@ func (c *serviceAccountClient) RestrictAdditionalAccessTokenScopes() func(scopes
 	return func(scopes []string) []string { return scopes }
 }
 
-func (c *serviceAccountClient) IsScopeAllowed(string) bool {
-	return true
+// IsScopeAllowed admits Grid's own scopes beyond the standard OIDC ones:
+// roles, groups, and permission scopes that down-scope the issued token.
+func (c *serviceAccountClient) IsScopeAllowed(scope string) bool {
+	return scope == ScopeRoles || scope == ScopeGroups || IsPermissionScope(scope)
 }
 
 func (c *serviceAccountClient) IDTokenUserinfoClaimsAssertion() bool {
@@ -911,6 +1497,9 @@ func getClientID(request op.TokenRequest) string {
 	if rtReq, ok := request.(*refreshTokenRequest); ok {
 		return rtReq.token.ApplicationID
 	}
+	if teReq, ok := request.(op.TokenExchangeRequest); ok {
+		return teReq.GetClientID()
+	}
 	return ""
 }
 
@@ -924,7 +1513,9 @@ func (s *providerStorage) persistSession(ctx context.Context, request op.TokenRe
 
 	session := &models.Session{
 		TokenHash:    tokenHash,
+		JTI:          accessToken,
 		RefreshToken: refreshToken,
+		Scopes:       PermissionScopes(request.GetScopes()),
 		ExpiresAt:    expiresAt,
 		CreatedAt:    now,
 		LastUsedAt:   now,
@@ -946,6 +1537,12 @@ func (s *providerStorage) persistSession(ctx context.Context, request op.TokenRe
 		if cr, ok := request.(*clientCredentialsTokenRequest); ok {
 			clientID = cr.clientID
 		}
+		if _, ok := request.(op.TokenExchangeRequest); ok {
+			// An exchanged token's subject is the service account it acts for
+			if id, err := ParseCasbinID(subject); err == nil && id.Kind == CasbinServiceAccount {
+				clientID = id.Name
+			}
+		}
 		if clientID == "" {
 			if authReq, ok := request.(op.AuthRequest); ok {
 				clientID = strings.TrimSpace(authReq.GetClientID())
@@ -987,6 +1584,24 @@ func (s *providerStorage) revokeSessionByJTI(ctx context.Context, jti string) er
 	return s.sessions.Revoke(ctx, session.ID)
 }
 
+// revokeAccessToken revokes the session of the access token jti and adds jti
+// to the denylist, so the token stops authenticating before it expires.
+func (s *providerStorage) revokeAccessToken(ctx context.Context, jti, subject string) error {
+	if err := s.revokeSessionByJTI(ctx, jti); err != nil {
+		return err
+	}
+	if s.revokedJTIs == nil {
+		return nil
+	}
+	now := s.now()
+	return s.revokedJTIs.Create(ctx, &models.RevokedJTI{
+		JTI:       jti,
+		Subject:   subject,
+		Exp:       now.Add(defaultAccessTokenTTL), // outlives any access token issued so far
+		RevokedAt: now,
+	})
+}
+
 func isNotFoundError(err error) bool {
 	if err == nil {
 		return false
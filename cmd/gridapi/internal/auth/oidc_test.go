@@ -0,0 +1,605 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/bunx"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type testTokenRequest struct{}
+
+func (testTokenRequest) GetSubject() string    { return "user:test" }
+func (testTokenRequest) GetAudience() []string { return nil }
+func (testTokenRequest) GetScopes() []string   { return nil }
+
+func keyIDs(t *testing.T, keys []op.Key) []string {
+	t.Helper()
+	ids := make([]string, 0, len(keys))
+	for _, k := range keys {
+		ids = append(ids, k.ID())
+	}
+	return ids
+}
+
+// verifyWithKeySet checks the token signature against the key matching its kid
+// and returns that kid.
+func verifyWithKeySet(t *testing.T, token string, keys []op.Key) string {
+	t.Helper()
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+	require.NoError(t, err)
+	require.Len(t, parsed.Headers, 1)
+	kid := parsed.Headers[0].KeyID
+	for _, k := range keys {
+		if k.ID() == kid {
+			var claims jwt.Claims
+			require.NoError(t, parsed.Claims(k.Key(), &claims))
+			return kid
+		}
+	}
+	t.Fatalf("kid %q not in key set", kid)
+	return ""
+}
+
+func TestProviderStorage_RotateSigningKey(t *testing.T) {
+	ctx := context.Background()
+	keyPath := filepath.Join(t.TempDir(), "signing.pem")
+
+	storage, err := newProviderStorage(ProviderDependencies{}, keyPath)
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	storage.now = func() time.Time { return now }
+
+	oldKey, err := storage.SigningKey(ctx)
+	require.NoError(t, err)
+	oldToken, _, err := storage.createJWT(context.Background(), testTokenRequest{}, now.Add(defaultAccessTokenTTL))
+	require.NoError(t, err)
+
+	rotation, err := storage.rotateSigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ID(), rotation.PreviousKeyID)
+	assert.NotEqual(t, oldKey.ID(), rotation.KeyID)
+	assert.Equal(t, now.Add(signingKeyGracePeriod), rotation.RetireAt)
+
+	// New tokens are signed with the new key.
+	newToken, _, err := storage.createJWT(context.Background(), testTokenRequest{}, now.Add(defaultAccessTokenTTL))
+	require.NoError(t, err)
+
+	keys, err := storage.KeySet(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{rotation.KeyID, oldKey.ID()}, keyIDs(t, keys))
+
+	// Tokens signed before the rotation still verify against the published keys.
+	assert.Equal(t, oldKey.ID(), verifyWithKeySet(t, oldToken, keys))
+	assert.Equal(t, rotation.KeyID, verifyWithKeySet(t, newToken, keys))
+
+	// A restart picks up both the new key and the retiring one.
+	reloaded, err := newProviderStorage(ProviderDependencies{}, keyPath)
+	require.NoError(t, err)
+	reloaded.now = storage.now
+	reloadedKeys, err := reloaded.KeySet(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{rotation.KeyID, oldKey.ID()}, keyIDs(t, reloadedKeys))
+
+	// Once the grace window has passed the old key is dropped.
+	now = now.Add(signingKeyGracePeriod)
+	keys, err = storage.KeySet(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{rotation.KeyID}, keyIDs(t, keys))
+}
+
+// storageExchanger lets op.CheckDeviceAuthorizationState run against providerStorage.
+type storageExchanger struct {
+	op.Exchanger
+	storage op.Storage
+}
+
+func (e storageExchanger) Storage() op.Storage { return e.storage }
+
+func TestProviderStorage_DeviceAuthorizationPolling(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	serviceAccounts := repository.NewBunServiceAccountRepository(db)
+	require.NoError(t, serviceAccounts.Create(ctx, &models.ServiceAccount{
+		ClientID: "gridctl", ClientSecretHash: "unused", Name: "gridctl", CreatedBy: SystemUserID,
+	}))
+	deviceAuthorizations := repository.NewBunDeviceAuthorizationRepository(db)
+
+	storage, err := newProviderStorage(ProviderDependencies{
+		ServiceAccounts:      serviceAccounts,
+		DeviceAuthorizations: deviceAuthorizations,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+	var offset time.Duration
+	storage.now = func() time.Time { return time.Now().Add(offset) }
+	exchanger := storageExchanger{storage: storage}
+
+	poll := func(deviceCode string) (*op.DeviceAuthorizationState, error) {
+		return op.CheckDeviceAuthorizationState(ctx, "gridctl", deviceCode, exchanger)
+	}
+
+	require.NoError(t, storage.StoreDeviceAuthorization(ctx, "gridctl", "device-1", "BCDF-GHJK", time.Now().Add(deviceCodeLifetime), []string{"openid"}))
+	assert.ErrorIs(t, storage.StoreDeviceAuthorization(ctx, "gridctl", "device-2", "BCDF-GHJK", time.Now().Add(deviceCodeLifetime), nil), op.ErrDuplicateUserCode)
+
+	_, err = poll("device-1")
+	assert.ErrorIs(t, err, oidc.ErrAuthorizationPending())
+
+	// Polling again inside the interval asks the client to back off.
+	_, err = poll("device-1")
+	assert.ErrorIs(t, err, oidc.ErrSlowDown())
+
+	offset += devicePollInterval
+	require.NoError(t, storage.completeDeviceAuthorization(ctx, " bcdf-ghjk", "alice", true))
+
+	state, err := poll("device-1")
+	require.NoError(t, err)
+	assert.True(t, state.Done)
+	assert.Equal(t, "alice", state.Subject)
+	assert.Equal(t, []string{"openid"}, state.Scopes)
+
+	// Device codes are single use.
+	_, err = poll("device-1")
+	assert.ErrorIs(t, err, oidc.ErrAccessDenied())
+
+	t.Run("expired code", func(t *testing.T) {
+		require.NoError(t, storage.StoreDeviceAuthorization(ctx, "gridctl", "device-3", "LMNP-QRST", time.Now().Add(deviceCodeLifetime), nil))
+		offset += devicePollInterval
+		_, err := poll("device-3")
+		assert.ErrorIs(t, err, oidc.ErrAuthorizationPending())
+
+		_, err = db.NewUpdate().Model((*models.DeviceAuthorization)(nil)).
+			Set("expires_at = ?", time.Now().Add(-time.Second)).
+			Where("device_code = ?", "device-3").
+			Exec(ctx)
+		require.NoError(t, err)
+
+		offset += devicePollInterval
+		_, err = poll("device-3")
+		assert.ErrorIs(t, err, oidc.ErrExpiredDeviceCode())
+
+		// Approving after expiry is rejected.
+		assert.ErrorContains(t, storage.completeDeviceAuthorization(ctx, "LMNP-QRST", "alice", true), "not found")
+	})
+
+	t.Run("concurrent polls issue one token", func(t *testing.T) {
+		require.NoError(t, storage.StoreDeviceAuthorization(ctx, "gridctl", "device-5", "CDFG-HJKL", time.Now().Add(deviceCodeLifetime), nil))
+		require.NoError(t, storage.completeDeviceAuthorization(ctx, "CDFG-HJKL", "alice", true))
+
+		var wg sync.WaitGroup
+		var issued atomic.Int32
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if state, err := poll("device-5"); err == nil && state.Done {
+					issued.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.EqualValues(t, 1, issued.Load())
+		assert.ErrorContains(t, deviceAuthorizations.Delete(ctx, "device-5"), "not found")
+	})
+
+	t.Run("denied code", func(t *testing.T) {
+		require.NoError(t, storage.StoreDeviceAuthorization(ctx, "gridctl", "device-4", "VWXZ-BCDF", time.Now().Add(deviceCodeLifetime), nil))
+		require.NoError(t, storage.completeDeviceAuthorization(ctx, "VWXZ-BCDF", "alice", false))
+		_, err := poll("device-4")
+		assert.ErrorIs(t, err, oidc.ErrAccessDenied())
+	})
+}
+
+// roleLookup serves roles by ID; SQLite can't scan the roles jsonb columns.
+type roleLookup struct {
+	repository.RoleRepository
+	roles map[string]*models.Role
+}
+
+func (r roleLookup) GetByID(_ context.Context, id string) (*models.Role, error) {
+	if role, ok := r.roles[id]; ok {
+		return role, nil
+	}
+	return nil, errors.New("role not found")
+}
+
+func TestProviderStorage_SetUserinfoFromScopes(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	users := repository.NewBunUserRepository(db)
+	roles := roleLookup{roles: make(map[string]*models.Role)}
+	userRoles := repository.NewBunUserRoleRepository(db)
+
+	subject := "alice"
+	user := &models.User{Subject: &subject, Email: "alice@example.com", Name: "Alice"}
+	require.NoError(t, users.Create(ctx, user))
+	roleRepo := repository.NewBunRoleRepository(db)
+	for _, name := range []string{"release-engineer", "auditor"} {
+		role := &models.Role{ID: bunx.NewUUIDv7(), Name: name, ScopeExpr: "*"}
+		require.NoError(t, roleRepo.Create(ctx, role))
+		roles.roles[role.ID] = role
+		require.NoError(t, userRoles.Create(ctx, &models.UserRole{UserID: &user.ID, RoleID: role.ID, AssignedBy: user.ID}))
+	}
+
+	storage, err := newProviderStorage(ProviderDependencies{
+		Users:     users,
+		UserRoles: userRoles,
+		Roles:     roles,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+
+	info := &oidc.UserInfo{}
+	require.NoError(t, storage.SetUserinfoFromScopes(ctx, info, subject, "gridctl", []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopeProfile}))
+	assert.Equal(t, user.ID, info.Subject)
+	assert.Equal(t, "alice@example.com", info.Email)
+	assert.Equal(t, "alice@example.com", info.PreferredUsername)
+	assert.Equal(t, "Alice", info.Name)
+	assert.NotContains(t, info.Claims, rolesClaim, "roles are only included for the roles scope")
+
+	info = &oidc.UserInfo{}
+	require.NoError(t, storage.SetUserinfoFromScopes(ctx, info, subject, "gridctl", []string{oidc.ScopeOpenID, ScopeRoles}))
+	assert.Equal(t, []string{"auditor", "release-engineer"}, info.Claims[rolesClaim])
+	assert.Empty(t, info.Email)
+
+	// With a role resolver, roles mapped from the user's groups are included
+	userGroups := repository.NewBunUserGroupRepository(db)
+	require.NoError(t, userGroups.Add(ctx, user.ID, "platform"))
+	storage.userGroups = userGroups
+	storage.resolveRoles = func(ctx context.Context, userID string, groups []string) ([]string, error) {
+		direct, err := storage.userRoleNames(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(groups, "platform") {
+			direct = append(direct, "platform-engineer")
+		}
+		return direct, nil
+	}
+	info = &oidc.UserInfo{}
+	require.NoError(t, storage.SetUserinfoFromScopes(ctx, info, subject, "gridctl", []string{oidc.ScopeOpenID, ScopeRoles}))
+	assert.Equal(t, []string{"auditor", "platform-engineer", "release-engineer"}, info.Claims[rolesClaim])
+
+	assert.Error(t, storage.SetUserinfoFromScopes(ctx, &oidc.UserInfo{}, "unknown", "gridctl", []string{oidc.ScopeOpenID}))
+}
+
+type scopedTokenRequest struct {
+	subject string
+	scopes  []string
+}
+
+func (r scopedTokenRequest) GetSubject() string    { return r.subject }
+func (r scopedTokenRequest) GetAudience() []string { return nil }
+func (r scopedTokenRequest) GetScopes() []string   { return r.scopes }
+
+func TestProviderStorage_GroupsClaim(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	users := repository.NewBunUserRepository(db)
+	userGroups := repository.NewBunUserGroupRepository(db)
+
+	user := &models.User{Email: "alice@example.com", Name: "Alice"}
+	require.NoError(t, users.Create(ctx, user))
+	for _, group := range []string{"platform", "auditors", "platform"} {
+		require.NoError(t, userGroups.Add(ctx, user.ID, group))
+	}
+
+	storage, err := newProviderStorage(ProviderDependencies{
+		Users:      users,
+		UserGroups: userGroups,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+
+	tokenClaims := func(request op.TokenRequest) map[string]any {
+		t.Helper()
+		token, _, err := storage.createJWT(ctx, request, time.Now().Add(defaultAccessTokenTTL))
+		require.NoError(t, err)
+		parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+		require.NoError(t, err)
+		claims := make(map[string]any)
+		require.NoError(t, parsed.Claims(&storage.currentSigningKey().key.PublicKey, &claims))
+		return claims
+	}
+
+	claims := tokenClaims(scopedTokenRequest{subject: user.ID, scopes: []string{oidc.ScopeOpenID, ScopeGroups}})
+	assert.Equal(t, []any{"auditors", "platform"}, claims["groups"])
+
+	claims = tokenClaims(scopedTokenRequest{subject: user.ID, scopes: []string{oidc.ScopeOpenID}})
+	assert.NotContains(t, claims, "groups", "groups are only included for the groups scope")
+
+	// Service accounts have no memberships; the scope is ignored for them.
+	claims = tokenClaims(scopedTokenRequest{subject: "ci-bot", scopes: []string{ScopeGroups}})
+	assert.NotContains(t, claims, "groups")
+
+	info := &oidc.UserInfo{}
+	require.NoError(t, storage.SetUserinfoFromScopes(ctx, info, user.ID, "gridctl", []string{oidc.ScopeOpenID, ScopeGroups}))
+	assert.Equal(t, []string{"auditors", "platform"}, info.Claims["groups"])
+
+	require.NoError(t, userGroups.Remove(ctx, user.ID, "platform"))
+	claims = tokenClaims(scopedTokenRequest{subject: user.ID, scopes: []string{ScopeGroups}})
+	assert.Equal(t, []any{"auditors"}, claims["groups"])
+}
+
+// auditRecorder keeps audit entries in memory; SQLite can't scan jsonb columns.
+type auditRecorder struct {
+	repository.AuditLogRepository
+	entries []*models.AuditLogEntry
+}
+
+func (r *auditRecorder) Create(_ context.Context, entry *models.AuditLogEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func TestProviderStorage_RefreshTokenReuse(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	users := repository.NewBunUserRepository(db)
+	sessions := repository.NewBunSessionRepository(db)
+	subject := "alice"
+	user := &models.User{Subject: &subject, Email: "alice@example.com", Name: "Alice"}
+	require.NoError(t, users.Create(ctx, user))
+
+	audit := &auditRecorder{}
+	revokedJTIs := repository.NewBunRevokedJTIRepository(db)
+	storage, err := newProviderStorage(ProviderDependencies{
+		Users:       users,
+		Sessions:    sessions,
+		AuditLogs:   audit,
+		RevokedJTIs: revokedJTIs,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+	request := scopedTokenRequest{subject: subject, scopes: []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess}}
+
+	// Two rotations of one grant, plus an unrelated grant for the same user
+	_, stolen, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, "")
+	require.NoError(t, err)
+	_, rotated, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, stolen)
+	require.NoError(t, err)
+	_, current, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, rotated)
+	require.NoError(t, err)
+	_, otherGrant, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, "")
+	require.NoError(t, err)
+
+	_, err = storage.TokenRequestByRefreshToken(ctx, current)
+	require.NoError(t, err)
+	assert.Empty(t, audit.entries, "rotation alone is not reuse")
+
+	// Replaying the rotated-out token revokes the whole family
+	_, err = storage.TokenRequestByRefreshToken(ctx, stolen)
+	assert.ErrorIs(t, err, op.ErrInvalidRefreshToken)
+
+	_, err = storage.TokenRequestByRefreshToken(ctx, current)
+	assert.ErrorIs(t, err, op.ErrInvalidRefreshToken)
+	_, err = storage.TokenRequestByRefreshToken(ctx, otherGrant)
+	assert.NoError(t, err, "other grants are unaffected")
+
+	userSessions, err := sessions.GetByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 4)
+	for _, session := range userSessions {
+		inFamily := session.RefreshToken != otherGrant
+		assert.Equal(t, inFamily, session.Revoked, "session for refresh token %s", session.RefreshToken)
+		revoked, err := revokedJTIs.IsRevoked(ctx, session.JTI)
+		require.NoError(t, err)
+		assert.Equal(t, inFamily, revoked, "access token of refresh token %s", session.RefreshToken)
+	}
+
+	require.Len(t, audit.entries, 1)
+	entry := audit.entries[0]
+	assert.Equal(t, AuditActionRefreshTokenReuse, entry.Action)
+	assert.Equal(t, AuditTargetRefreshTokenFamily, entry.TargetType)
+	assert.Equal(t, stolen, entry.TargetID, "the family is named after its first token")
+	assert.Equal(t, "success", entry.Outcome)
+	assert.Equal(t, subject, entry.After["subject"])
+	assert.Equal(t, 1, entry.After["revoked_refresh_tokens"])
+}
+
+func TestProviderStorage_RefreshTokenReuseWindow(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	users := repository.NewBunUserRepository(db)
+	subject := "alice"
+	require.NoError(t, users.Create(ctx, &models.User{Subject: &subject, Email: "alice@example.com", Name: "Alice"}))
+
+	audit := &auditRecorder{}
+	storage, err := newProviderStorage(ProviderDependencies{
+		Users:     users,
+		Sessions:  repository.NewBunSessionRepository(db),
+		AuditLogs: audit,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+	now := time.Now()
+	storage.now = func() time.Time { return now }
+	request := scopedTokenRequest{subject: subject, scopes: []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess}}
+
+	_, previous, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, "")
+	require.NoError(t, err)
+	_, lost, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, previous)
+	require.NoError(t, err)
+
+	// The client never saw lost and retries with previous
+	_, err = storage.TokenRequestByRefreshToken(ctx, previous)
+	require.NoError(t, err, "the previous token is accepted while its successor is unused")
+	_, retried, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, previous)
+	require.NoError(t, err)
+	assert.Empty(t, audit.entries)
+	_, err = storage.TokenRequestByRefreshToken(ctx, retried)
+	require.NoError(t, err)
+
+	// Presenting previous again is reuse: its successor was replaced
+	_, err = storage.TokenRequestByRefreshToken(ctx, previous)
+	assert.ErrorIs(t, err, op.ErrInvalidRefreshToken)
+	require.Len(t, audit.entries, 1)
+	_, err = storage.TokenRequestByRefreshToken(ctx, retried)
+	assert.ErrorIs(t, err, op.ErrInvalidRefreshToken, "the family is revoked")
+	_, err = storage.TokenRequestByRefreshToken(ctx, lost)
+	assert.ErrorIs(t, err, op.ErrInvalidRefreshToken)
+
+	t.Run("window elapsed", func(t *testing.T) {
+		_, previous, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, "")
+		require.NoError(t, err)
+		_, next, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, previous)
+		require.NoError(t, err)
+
+		now = now.Add(refreshTokenReuseWindow + time.Second)
+		_, err = storage.TokenRequestByRefreshToken(ctx, previous)
+		assert.ErrorIs(t, err, op.ErrInvalidRefreshToken)
+		_, err = storage.TokenRequestByRefreshToken(ctx, next)
+		assert.ErrorIs(t, err, op.ErrInvalidRefreshToken, "late reuse revokes the family")
+	})
+
+	t.Run("retry during rotation", func(t *testing.T) {
+		now = time.Now()
+		_, previous, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, "")
+		require.NoError(t, err)
+
+		// The retry lands while the successor's access token is being signed
+		var retryErr error
+		storage.userGroups = hookedUserGroups{
+			UserGroupRepository: repository.NewBunUserGroupRepository(db),
+			onList: func() {
+				_, retryErr = storage.TokenRequestByRefreshToken(ctx, previous)
+			},
+		}
+		defer func() { storage.userGroups = nil }()
+		groupsRequest := scopedTokenRequest{subject: subject, scopes: []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess, ScopeGroups}}
+		_, next, _, err := storage.CreateAccessAndRefreshTokens(ctx, groupsRequest, previous)
+		require.NoError(t, err)
+		assert.NoError(t, retryErr)
+
+		_, err = storage.TokenRequestByRefreshToken(ctx, next)
+		assert.NoError(t, err, "the retry did not revoke the family")
+	})
+}
+
+// hookedUserGroups runs onList whenever group names are listed.
+type hookedUserGroups struct {
+	repository.UserGroupRepository
+	onList func()
+}
+
+func (h hookedUserGroups) ListGroupNames(ctx context.Context, userID string) ([]string, error) {
+	h.onList()
+	return h.UserGroupRepository.ListGroupNames(ctx, userID)
+}
+
+func TestProviderStorage_RefreshTokenRevocationEpoch(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	users := repository.NewBunUserRepository(db)
+	subject := "alice"
+	require.NoError(t, users.Create(ctx, &models.User{Subject: &subject, Email: "alice@example.com", Name: "Alice"}))
+
+	epoch := NewRevocationEpochCache(&countingEpochStore{}, time.Time{})
+	storage, err := newProviderStorage(ProviderDependencies{
+		Users:           users,
+		Sessions:        repository.NewBunSessionRepository(db),
+		RevocationEpoch: epoch,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+	request := scopedTokenRequest{subject: subject, scopes: []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess}}
+
+	_, refresh, _, err := storage.CreateAccessAndRefreshTokens(ctx, request, "")
+	require.NoError(t, err)
+	_, err = storage.TokenRequestByRefreshToken(ctx, refresh)
+	require.NoError(t, err)
+
+	require.NoError(t, epoch.Set(ctx, time.Now().Add(time.Second), "user:admin"))
+	_, err = storage.TokenRequestByRefreshToken(ctx, refresh)
+	assert.ErrorIs(t, err, op.ErrInvalidRefreshToken, "refresh tokens issued before the epoch are refused")
+}
+
+func TestProviderStorage_PermissionScopes(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	users := repository.NewBunUserRepository(db)
+	sessions := repository.NewBunSessionRepository(db)
+	subject := "alice"
+	user := &models.User{Subject: &subject, Email: "alice@example.com", Name: "Alice"}
+	require.NoError(t, users.Create(ctx, user))
+
+	storage, err := newProviderStorage(ProviderDependencies{
+		Users:    users,
+		Sessions: sessions,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+
+	token, _, err := storage.CreateAccessToken(ctx, scopedTokenRequest{
+		subject: subject,
+		scopes:  []string{oidc.ScopeOpenID, StateRead},
+	})
+	require.NoError(t, err)
+
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+	require.NoError(t, err)
+	claims := make(map[string]any)
+	require.NoError(t, parsed.Claims(&storage.currentSigningKey().key.PublicKey, &claims))
+	assert.Equal(t, "openid state:read", claims["scope"])
+
+	userSessions, err := sessions.GetByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+	assert.Equal(t, []string{StateRead}, userSessions[0].Scopes)
+
+	client := &serviceAccountClient{}
+	assert.True(t, client.IsScopeAllowed(StateRead))
+	assert.True(t, client.IsScopeAllowed("tfstate:*"))
+	assert.False(t, client.IsScopeAllowed("state:launch"))
+}
+
+func TestProviderStorage_AuthorizeClientIDSecretOverlap(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	hash := func(secret string) string {
+		h, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+		require.NoError(t, err)
+		return string(h)
+	}
+	serviceAccounts := repository.NewBunServiceAccountRepository(db)
+	sa := &models.ServiceAccount{ClientID: "ci", ClientSecretHash: hash("old"), Name: "ci", CreatedBy: SystemUserID}
+	require.NoError(t, serviceAccounts.Create(ctx, sa))
+
+	storage, err := newProviderStorage(ProviderDependencies{ServiceAccounts: serviceAccounts}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+	var offset time.Duration
+	storage.now = func() time.Time { return time.Now().Add(offset) }
+
+	require.NoError(t, serviceAccounts.UpdateSecretHashWithOverlap(ctx, sa.ID, hash("new"), time.Now().Add(time.Hour)))
+
+	assert.NoError(t, storage.AuthorizeClientIDSecret(ctx, "ci", "new"))
+	assert.NoError(t, storage.AuthorizeClientIDSecret(ctx, "ci", "old"), "previous secret during overlap")
+	assert.ErrorIs(t, storage.AuthorizeClientIDSecret(ctx, "ci", "other"), ErrInvalidClientSecret)
+
+	// After the overlap the old secret fails and its hash is cleared
+	offset = 2 * time.Hour
+	assert.ErrorIs(t, storage.AuthorizeClientIDSecret(ctx, "ci", "old"), ErrInvalidClientSecret)
+	assert.NoError(t, storage.AuthorizeClientIDSecret(ctx, "ci", "new"))
+
+	stored, err := serviceAccounts.GetByID(ctx, sa.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.PreviousSecretHash)
+	assert.Nil(t, stored.PreviousSecretExpiresAt)
+}
@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+)
+
+// Password policy requirement names, reported in PasswordPolicyError and the
+// policy endpoint so clients can map failures to the rules they display.
+const (
+	PasswordRequirementMinLength = "min_length"
+	PasswordRequirementUppercase = "uppercase"
+	PasswordRequirementLowercase = "lowercase"
+	PasswordRequirementDigit     = "digit"
+	PasswordRequirementSymbol    = "symbol"
+	PasswordRequirementBreached  = "not_breached"
+	PasswordRequirementChanged   = "differs_from_current"
+)
+
+// PasswordPolicy validates raw passwords for internal IdP users before they
+// are hashed. External IdP users have no Grid password and are never checked.
+type PasswordPolicy struct {
+	minLength        int
+	requireUppercase bool
+	requireLowercase bool
+	requireDigit     bool
+	requireSymbol    bool
+	breached         map[string]struct{}
+}
+
+// PasswordPolicyDescription is the client-facing view of the active policy.
+type PasswordPolicyDescription struct {
+	MinLength        int  `json:"min_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSymbol    bool `json:"require_symbol"`
+	BreachCheck      bool `json:"breach_check"`
+}
+
+// PasswordPolicyError reports the first requirement a password failed.
+type PasswordPolicyError struct {
+	Requirement string
+	Message     string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("invalid password: %s", e.Message)
+}
+
+// NewPasswordPolicy builds a policy from configuration, loading the breach list
+// when one is configured.
+func NewPasswordPolicy(cfg config.PasswordPolicyConfig) (*PasswordPolicy, error) {
+	policy := &PasswordPolicy{
+		minLength:        cfg.MinLength,
+		requireUppercase: cfg.RequireUppercase,
+		requireLowercase: cfg.RequireLowercase,
+		requireDigit:     cfg.RequireDigit,
+		requireSymbol:    cfg.RequireSymbol,
+	}
+	if cfg.BreachListPath != "" {
+		breached, err := loadBreachList(cfg.BreachListPath)
+		if err != nil {
+			return nil, err
+		}
+		policy.breached = breached
+	}
+	return policy, nil
+}
+
+// Validate returns a *PasswordPolicyError naming the first unmet requirement,
+// or nil if the password satisfies the policy.
+func (p *PasswordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.minLength {
+		return &PasswordPolicyError{
+			Requirement: PasswordRequirementMinLength,
+			Message:     fmt.Sprintf("must be at least %d characters", p.minLength),
+		}
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	switch {
+	case p.requireUppercase && !hasUpper:
+		return &PasswordPolicyError{Requirement: PasswordRequirementUppercase, Message: "must contain an uppercase letter"}
+	case p.requireLowercase && !hasLower:
+		return &PasswordPolicyError{Requirement: PasswordRequirementLowercase, Message: "must contain a lowercase letter"}
+	case p.requireDigit && !hasDigit:
+		return &PasswordPolicyError{Requirement: PasswordRequirementDigit, Message: "must contain a digit"}
+	case p.requireSymbol && !hasSymbol:
+		return &PasswordPolicyError{Requirement: PasswordRequirementSymbol, Message: "must contain a symbol"}
+	}
+
+	if _, ok := p.breached[password]; ok {
+		return &PasswordPolicyError{
+			Requirement: PasswordRequirementBreached,
+			Message:     "appears in a list of breached passwords",
+		}
+	}
+	return nil
+}
+
+// Describe returns the policy for display to clients.
+func (p *PasswordPolicy) Describe() PasswordPolicyDescription {
+	return PasswordPolicyDescription{
+		MinLength:        p.minLength,
+		RequireUppercase: p.requireUppercase,
+		RequireLowercase: p.requireLowercase,
+		RequireDigit:     p.requireDigit,
+		RequireSymbol:    p.requireSymbol,
+		BreachCheck:      p.breached != nil,
+	}
+}
+
+// Character sets for generated passwords. Look-alike characters (0/O, 1/l/I)
+// are left out since temporary passwords are read off a screen and typed.
+const (
+	generatedUppercase = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	generatedLowercase = "abcdefghijkmnopqrstuvwxyz"
+	generatedDigits    = "23456789"
+	generatedSymbols   = "!#%+-=?@_"
+
+	// minGeneratedLength is used when the policy's minimum is shorter.
+	minGeneratedLength = 20
+)
+
+// Generate returns a random password that satisfies the policy. It contains
+// every character class, so it passes regardless of which classes are required.
+func (p *PasswordPolicy) Generate() (string, error) {
+	classes := []string{generatedUppercase, generatedLowercase, generatedDigits, generatedSymbols}
+	all := strings.Join(classes, "")
+	length := max(p.minLength, minGeneratedLength)
+
+	for attempt := 0; attempt < 3; attempt++ {
+		password := make([]byte, length)
+		for i := range password {
+			// The first characters cover each class once; the rest draw from all.
+			charset := all
+			if i < len(classes) {
+				charset = classes[i]
+			}
+			c, err := randomChar(charset)
+			if err != nil {
+				return "", err
+			}
+			password[i] = c
+		}
+		if err := shuffle(password); err != nil {
+			return "", err
+		}
+		if p.Validate(string(password)) == nil {
+			return string(password), nil
+		}
+	}
+	return "", fmt.Errorf("generate password: no candidate satisfied the policy")
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, fmt.Errorf("generate password: %w", err)
+	}
+	return charset[n.Int64()], nil
+}
+
+// shuffle is a Fisher-Yates shuffle using crypto/rand.
+func shuffle(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return fmt.Errorf("generate password: %w", err)
+		}
+		b[i], b[j.Int64()] = b[j.Int64()], b[i]
+	}
+	return nil
+}
+
+func loadBreachList(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open password breach list: %w", err)
+	}
+	defer f.Close()
+
+	breached := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			breached[line] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read password breach list: %w", err)
+	}
+	return breached, nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	breachList := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(breachList, []byte("Password1!\r\nLetMeIn99?\n"), 0o600))
+
+	strict := config.PasswordPolicyConfig{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		BreachListPath:   breachList,
+	}
+
+	tests := []struct {
+		name        string
+		cfg         config.PasswordPolicyConfig
+		password    string
+		requirement string
+	}{
+		{name: "default accepts plain password", cfg: config.PasswordPolicyConfig{MinLength: 8}, password: "password123"},
+		{name: "too short", cfg: strict, password: "Ab1!", requirement: PasswordRequirementMinLength},
+		{name: "length counts characters not bytes", cfg: config.PasswordPolicyConfig{MinLength: 4}, password: "ééé", requirement: PasswordRequirementMinLength},
+		{name: "missing uppercase", cfg: strict, password: "lowercase1!", requirement: PasswordRequirementUppercase},
+		{name: "missing lowercase", cfg: strict, password: "UPPERCASE1!", requirement: PasswordRequirementLowercase},
+		{name: "missing digit", cfg: strict, password: "NoDigitsHere!", requirement: PasswordRequirementDigit},
+		{name: "missing symbol", cfg: strict, password: "NoSymbols123", requirement: PasswordRequirementSymbol},
+		{name: "breached", cfg: strict, password: "LetMeIn99?", requirement: PasswordRequirementBreached},
+		{name: "breach list trims CRLF", cfg: config.PasswordPolicyConfig{MinLength: 8, BreachListPath: breachList}, password: "Password1!", requirement: PasswordRequirementBreached},
+		{name: "satisfies strict policy", cfg: strict, password: "Correct-Horse-9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewPasswordPolicy(tt.cfg)
+			require.NoError(t, err)
+
+			err = policy.Validate(tt.password)
+			if tt.requirement == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var policyErr *PasswordPolicyError
+			require.True(t, errors.As(err, &policyErr), "expected PasswordPolicyError, got %v", err)
+			assert.Equal(t, tt.requirement, policyErr.Requirement)
+		})
+	}
+}
+
+func TestPasswordPolicy_Describe(t *testing.T) {
+	breachList := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(breachList, []byte("hunter2\n"), 0o600))
+
+	policy, err := NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 12, RequireDigit: true, BreachListPath: breachList})
+	require.NoError(t, err)
+	assert.Equal(t, PasswordPolicyDescription{MinLength: 12, RequireDigit: true, BreachCheck: true}, policy.Describe())
+
+	_, err = NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 8, BreachListPath: filepath.Join(t.TempDir(), "missing.txt")})
+	assert.ErrorContains(t, err, "password breach list")
+}
+
+func TestPasswordPolicy_Generate(t *testing.T) {
+	policy, err := NewPasswordPolicy(config.PasswordPolicyConfig{
+		MinLength:        32,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	})
+	require.NoError(t, err)
+
+	first, err := policy.Generate()
+	require.NoError(t, err)
+	assert.Len(t, first, 32)
+	assert.NoError(t, policy.Validate(first))
+
+	second, err := policy.Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
@@ -28,7 +28,8 @@ func (r *RelyingParty) RP() rp.RelyingParty {
 }
 
 // NewRelyingParty creates a new RelyingParty for external IdP authentication.
-func NewRelyingParty(ctx context.Context, cfg *config.ExternalIdPConfig) (*RelyingParty, error) {
+// httpClient is used for calls to the IdP; nil uses http.DefaultClient.
+func NewRelyingParty(ctx context.Context, cfg *config.ExternalIdPConfig, httpClient *http.Client) (*RelyingParty, error) {
 	// The hash and crypto keys should be sourced from a secure configuration in production.
 	// For local development, we generate random keys on startup.
 	hashKey, err := generateRandomBytes(32)
@@ -63,6 +64,9 @@ func NewRelyingParty(ctx context.Context, cfg *config.ExternalIdPConfig) (*Relyi
 		rp.WithPKCE(cookieHandler), // Use the same cookie handler for PKCE
 		rp.WithUnauthorizedHandler(unauthorizedHandler),
 	}
+	if httpClient != nil {
+		options = append(options, rp.WithHTTPClient(httpClient))
+	}
 
 	// Use configured scopes (defaults to [openid, profile, email] set in config.go)
 	// Group memberships are obtained via JWT claim mapper, not via scope request
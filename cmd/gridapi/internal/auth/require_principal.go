@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+)
+
+// RequirePrincipal returns the principal on ctx, or an unauthenticated
+// connect error when there is none. Only when authentication is explicitly
+// disabled (see SetAuthDisabledContext) is a missing principal accepted; the
+// zero principal is returned then.
+func RequirePrincipal(ctx context.Context) (AuthenticatedPrincipal, error) {
+	principal, ok := GetUserFromContext(ctx)
+	if !ok && !IsAuthDisabled(ctx) {
+		return AuthenticatedPrincipal{}, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
+	}
+	return principal, nil
+}
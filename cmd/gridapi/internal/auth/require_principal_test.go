@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequirePrincipal(t *testing.T) {
+	ctx := SetUserContext(context.Background(), AuthenticatedPrincipal{PrincipalID: "sa:ci"})
+
+	principal, err := RequirePrincipal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "sa:ci", principal.PrincipalID)
+}
+
+func TestRequirePrincipal_NoAuthMode(t *testing.T) {
+	// No principal on the context, and authentication is disabled
+	ctx := SetAuthDisabledContext(context.Background())
+
+	principal, err := RequirePrincipal(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, principal.PrincipalID)
+}
+
+func TestRequirePrincipal_MissingPrincipal(t *testing.T) {
+	// No principal although authentication is enabled: fail closed
+	_, err := RequirePrincipal(context.Background())
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// revocationEpochCacheTTL bounds how long a read epoch is served from
+// memory. A bump through this cache applies immediately; a bump made by
+// another replica is picked up within the TTL.
+const revocationEpochCacheTTL = 5 * time.Second
+
+// ErrRevocationEpochNotStored is returned by RevocationEpochCache.Set when no
+// epoch repository is configured.
+var ErrRevocationEpochNotStored = errors.New("revocation epoch storage is not configured")
+
+// RevocationEpochCache serves the effective revocation epoch: the later of the
+// configured floor (GRID_REVOCATION_EPOCH) and the epoch stored in the
+// database. Every authenticator checks the epoch, so the stored row is read at
+// most once per TTL instead of once per request.
+//
+// A nil *RevocationEpochCache serves the zero epoch (nothing is revoked).
+type RevocationEpochCache struct {
+	store repository.RevocationEpochRepository
+	floor time.Time
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu        sync.Mutex
+	epoch     time.Time
+	fetchedAt time.Time // zero when the cached epoch must be re-read
+}
+
+// NewRevocationEpochCache caches the epoch stored in store, never serving one
+// earlier than floor. store may be nil; then only floor applies.
+func NewRevocationEpochCache(store repository.RevocationEpochRepository, floor time.Time) *RevocationEpochCache {
+	return &RevocationEpochCache{store: store, floor: floor, ttl: revocationEpochCacheTTL, now: time.Now}
+}
+
+// Epoch returns the effective revocation epoch. The zero time means no
+// credential is rejected by epoch.
+func (c *RevocationEpochCache) Epoch(ctx context.Context) (time.Time, error) {
+	if c == nil {
+		return time.Time{}, nil
+	}
+	if c.store == nil {
+		return c.floor, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.fetchedAt.IsZero() && c.now().Sub(c.fetchedAt) < c.ttl {
+		return c.epoch, nil
+	}
+
+	stored, err := c.store.Get(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	epoch := c.floor
+	if stored != nil && stored.Epoch.After(epoch) {
+		epoch = stored.Epoch
+	}
+	c.epoch, c.fetchedAt = epoch, c.now()
+	return epoch, nil
+}
+
+// Set stores epoch and invalidates the cached value, so this replica rejects
+// older credentials from the next request on.
+func (c *RevocationEpochCache) Set(ctx context.Context, epoch time.Time, setBy string) error {
+	if c == nil || c.store == nil {
+		return ErrRevocationEpochNotStored
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetchedAt = time.Time{}
+	return c.store.Set(ctx, epoch, setBy)
+}
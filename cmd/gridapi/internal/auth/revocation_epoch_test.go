@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// countingEpochStore keeps the epoch in memory and counts reads.
+type countingEpochStore struct {
+	epoch *models.RevocationEpoch
+	gets  int
+}
+
+func (s *countingEpochStore) Get(context.Context) (*models.RevocationEpoch, error) {
+	s.gets++
+	return s.epoch, nil
+}
+
+func (s *countingEpochStore) Set(_ context.Context, epoch time.Time, setBy string) error {
+	s.epoch = &models.RevocationEpoch{ID: 1, Epoch: epoch, SetBy: setBy}
+	return nil
+}
+
+func TestRevocationEpochCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	now := time.Now()
+	floor := now.Add(-24 * time.Hour).Truncate(time.Second)
+	store := &countingEpochStore{}
+	cache := NewRevocationEpochCache(store, floor)
+	cache.now = func() time.Time { return now }
+
+	epoch, err := cache.Epoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, floor, epoch, "the configured floor applies until a bump")
+
+	// A bump through the cache applies on the next read
+	bumped := now.Add(-time.Minute).Truncate(time.Second)
+	require.NoError(t, cache.Set(ctx, bumped, "user:admin"))
+	epoch, err = cache.Epoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, bumped, epoch)
+	assert.Equal(t, 2, store.gets)
+
+	// Within the TTL the stored epoch is not re-read
+	_, err = cache.Epoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.gets)
+
+	// A bump by another replica is seen once the TTL has passed
+	require.NoError(t, store.Set(ctx, now.Truncate(time.Second), "user:other-admin"))
+	epoch, err = cache.Epoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, bumped, epoch)
+	now = now.Add(revocationEpochCacheTTL)
+	epoch, err = cache.Epoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, store.epoch.Epoch, epoch)
+
+	// Without storage only the floor applies and the epoch can't be bumped
+	floorOnly := NewRevocationEpochCache(nil, floor)
+	epoch, err = floorOnly.Epoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, floor, epoch)
+	assert.ErrorIs(t, floorOnly.Set(ctx, now, "user:admin"), ErrRevocationEpochNotStored)
+
+	var unset *RevocationEpochCache
+	epoch, err = unset.Epoch(ctx)
+	require.NoError(t, err)
+	assert.True(t, epoch.IsZero())
+}
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+const (
+	// actorClaim names who acts on the subject's behalf (RFC 8693 §4.1).
+	actorClaim = "act"
+
+	// AuditActionTokenExchange is recorded when a client exchanges a
+	// subject's token for one acting on its behalf.
+	AuditActionTokenExchange = "token.exchange"
+	AuditTargetTokenSubject  = "token_subject"
+)
+
+// The internal IdP implements the token exchange grant (RFC 8693) so a
+// trusted client, such as a CI runner, can trade a user's access token for a
+// short-lived token that runs on the user's behalf with fewer permissions.
+//
+// The issued token keeps the subject's "sub", so it is authorized with the
+// subject's roles, and names the exchanging client in its "act" claim. Its
+// permission scopes must be covered by the subject token's: authorization
+// then grants the intersection of the subject's roles and the requested
+// scopes (see TokenScopesAllow).
+
+// canExchangeTokens reports whether clientID is listed in
+// OIDCConfig.TokenExchangeClients.
+func (s *providerStorage) canExchangeTokens(clientID string) bool {
+	return slices.Contains(s.tokenExchangeClients, clientID)
+}
+
+// ValidateTokenExchangeRequest admits exchanges by listed clients of one of
+// our access tokens for an access token with Grid's audience and at least one
+// permission scope, every one of them covered by the subject token.
+func (s *providerStorage) ValidateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) error {
+	clientID := request.GetClientID()
+	if !s.canExchangeTokens(clientID) {
+		return oidc.ErrUnauthorizedClient().WithDescription("client %s is not allowed to exchange tokens", clientID)
+	}
+	if request.GetExchangeSubjectTokenType() != oidc.AccessTokenType {
+		return oidc.ErrInvalidRequest().WithDescription("subject_token_type must be %s", oidc.AccessTokenType)
+	}
+	if request.GetExchangeActorTokenIDOrToken() != "" {
+		return oidc.ErrInvalidRequest().WithDescription("actor_token is not supported: the exchanging client is the actor")
+	}
+
+	switch request.GetRequestedTokenType() {
+	case "":
+		request.SetRequestedTokenType(oidc.AccessTokenType)
+	case oidc.AccessTokenType:
+	default:
+		return oidc.ErrInvalidRequest().WithDescription("requested_token_type must be %s", oidc.AccessTokenType)
+	}
+
+	audience := request.GetAudience()
+	if len(audience) == 0 || slices.ContainsFunc(audience, func(aud string) bool { return aud != s.audience }) {
+		return oidc.ErrInvalidTarget().WithDescription("audience must be %s", s.audience)
+	}
+
+	if err := s.checkSubjectToken(ctx, request); err != nil {
+		return err
+	}
+
+	requested := request.GetScopes()
+	if len(PermissionScopes(requested)) == 0 {
+		return oidc.ErrInvalidScope().WithDescription("at least one permission scope is required to down-scope the exchanged token")
+	}
+	subjectScopes := tokenScopes(request.GetExchangeSubjectTokenClaims())
+	if excess := scopesNotCoveredBy(requested, subjectScopes); len(excess) > 0 {
+		return oidc.ErrInvalidScope().WithDescription("requested scopes exceed the subject token: %s", strings.Join(excess, " "))
+	}
+	request.SetCurrentScopes(requested)
+	return nil
+}
+
+// checkSubjectToken refuses a subject token the bearer authenticator would
+// no longer accept: its jti was revoked, it was issued before the revocation
+// epoch, or its subject has been disabled since.
+func (s *providerStorage) checkSubjectToken(ctx context.Context, request op.TokenExchangeRequest) error {
+	if s.revokedJTIs != nil {
+		revoked, err := s.revokedJTIs.IsRevoked(ctx, request.GetExchangeSubjectTokenIDOrToken())
+		if err != nil {
+			return oidc.ErrServerError().WithParent(err).WithDescription("check subject_token revocation")
+		}
+		if revoked {
+			return oidc.ErrInvalidGrant().WithDescription("subject_token has been revoked")
+		}
+	}
+
+	epoch, err := s.revocationEpoch.Epoch(ctx)
+	if err != nil {
+		return oidc.ErrServerError().WithParent(err).WithDescription("get revocation epoch")
+	}
+	if IssuedBeforeEpoch(request.GetExchangeSubjectTokenClaims(), epoch) {
+		return oidc.ErrInvalidGrant().WithDescription("subject_token was issued before the revocation epoch")
+	}
+
+	disabled, err := s.subjectDisabled(ctx, request.GetSubject())
+	if err != nil {
+		return oidc.ErrServerError().WithParent(err).WithDescription("look up token subject")
+	}
+	if disabled {
+		return oidc.ErrInvalidGrant().WithDescription("subject %s is disabled", request.GetSubject())
+	}
+	return nil
+}
+
+// subjectDisabled reports whether the user or service account a token was
+// issued to has been disabled. Unknown subjects are left to session creation
+// to reject.
+func (s *providerStorage) subjectDisabled(ctx context.Context, subject string) (bool, error) {
+	if id, err := ParseCasbinID(subject); err == nil && id.Kind == CasbinServiceAccount {
+		if s.serviceAccounts == nil {
+			return false, nil
+		}
+		sa, err := s.serviceAccounts.GetByClientID(ctx, id.Name)
+		if err != nil {
+			if isNotFoundError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return sa.Disabled, nil
+	}
+
+	user, err := s.users.GetBySubject(ctx, subject)
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.DisabledAt != nil, nil
+}
+
+// exchangedTokenExpiry caps exp at the subject token's expiry, so exchanging
+// a token, or chaining exchanges, never extends how long the subject's
+// authority lasts.
+func exchangedTokenExpiry(request op.TokenExchangeRequest, exp time.Time) time.Time {
+	subjectExp := ClaimTime(request.GetExchangeSubjectTokenClaims(), "exp")
+	if !subjectExp.IsZero() && subjectExp.Before(exp) {
+		return subjectExp
+	}
+	return exp
+}
+
+// CreateTokenExchangeRequest audits the exchange; the request itself is not
+// needed again.
+func (s *providerStorage) CreateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) error {
+	actor := ServiceAccountID(request.GetClientID())
+	log.Printf("token exchange: %s acting for %s with scopes %v", actor, request.GetSubject(), request.GetScopes())
+	if s.auditLogs == nil {
+		return nil
+	}
+	entry := &models.AuditLogEntry{
+		OccurredAt: s.now(),
+		Actor:      actor,
+		Action:     AuditActionTokenExchange,
+		TargetType: AuditTargetTokenSubject,
+		TargetID:   request.GetSubject(),
+		After: map[string]any{
+			"client_id": request.GetClientID(),
+			"scopes":    request.GetScopes(),
+		},
+		Outcome: "success",
+	}
+	if err := s.auditLogs.Create(ctx, entry); err != nil {
+		log.Printf("ERROR: audit log write failed (action=%s, target=%s:%s): %v",
+			entry.Action, entry.TargetType, entry.TargetID, err)
+	}
+	return nil
+}
+
+// GetPrivateClaimsFromTokenExchangeRequest adds the claims of a regular
+// access token plus the granted scopes and the delegation's "act" claim.
+func (s *providerStorage) GetPrivateClaimsFromTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) (map[string]any, error) {
+	claims, err := s.GetPrivateClaimsFromScopes(ctx, request.GetSubject(), request.GetClientID(), request.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		claims = make(map[string]any)
+	}
+	claims["scope"] = strings.Join(request.GetScopes(), " ")
+	claims[actorClaim] = delegationActor(request)
+	return claims, nil
+}
+
+// SetUserinfoFromTokenExchangeRequest fills ID token claims; only access
+// tokens are issued by exchange, so this is unused in practice.
+func (s *providerStorage) SetUserinfoFromTokenExchangeRequest(ctx context.Context, userInfo *oidc.UserInfo, request op.TokenExchangeRequest) error {
+	return s.populateUserInfo(ctx, userInfo, request.GetSubject(), request.GetScopes())
+}
+
+// delegationActor returns the "act" claim of an exchanged token: the
+// exchanging service account, nesting the subject token's own actor when it
+// was itself exchanged.
+func delegationActor(request op.TokenExchangeRequest) map[string]any {
+	actor := map[string]any{"sub": ServiceAccountID(request.GetClientID())}
+	if prior, ok := request.GetExchangeSubjectTokenClaims()[actorClaim]; ok {
+		actor[actorClaim] = prior
+	}
+	return actor
+}
+
+// tokenScopes returns the scopes in a token's space-delimited "scope" claim.
+func tokenScopes(claims map[string]any) []string {
+	scope, _ := claims["scope"].(string)
+	return strings.Fields(scope)
+}
+
+// scopesNotCoveredBy returns the requested scopes a token with subjectScopes
+// could not pass on. A permission scope is covered when every action it
+// expands to is allowed by the subject's permission scopes, which is always
+// the case for a subject that is not down-scoped; any other scope must have
+// been granted to the subject.
+func scopesNotCoveredBy(requested, subjectScopes []string) []string {
+	subjectPermissions := PermissionScopes(subjectScopes)
+	var excess []string
+	for _, scope := range requested {
+		covered := slices.Contains(subjectScopes, scope)
+		if IsPermissionScope(scope) {
+			covered = true
+			for _, action := range ExpandWildcard(scope) {
+				if !TokenScopesAllow(subjectPermissions, action) {
+					covered = false
+					break
+				}
+			}
+		}
+		if !covered {
+			excess = append(excess, scope)
+		}
+	}
+	return excess
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+)
+
+// exchangeRequest is a parsed token exchange request, as op hands it to storage.
+type exchangeRequest struct {
+	clientID      string
+	subject       string
+	subjectJTI    string
+	subjectClaims map[string]any
+	audience      []string
+	scopes        []string
+	requestedType oidc.TokenType
+}
+
+var _ op.TokenExchangeRequest = (*exchangeRequest)(nil)
+
+func (r *exchangeRequest) GetAMR() []string                              { return nil }
+func (r *exchangeRequest) GetAudience() []string                         { return r.audience }
+func (r *exchangeRequest) GetResourses() []string                        { return nil }
+func (r *exchangeRequest) GetAuthTime() time.Time                        { return time.Now() }
+func (r *exchangeRequest) GetClientID() string                           { return r.clientID }
+func (r *exchangeRequest) GetScopes() []string                           { return r.scopes }
+func (r *exchangeRequest) GetSubject() string                            { return r.subject }
+func (r *exchangeRequest) GetRequestedTokenType() oidc.TokenType         { return r.requestedType }
+func (r *exchangeRequest) GetExchangeSubject() string                    { return r.subject }
+func (r *exchangeRequest) GetExchangeSubjectTokenType() oidc.TokenType   { return oidc.AccessTokenType }
+func (r *exchangeRequest) GetExchangeSubjectTokenIDOrToken() string      { return r.subjectJTI }
+func (r *exchangeRequest) GetExchangeSubjectTokenClaims() map[string]any { return r.subjectClaims }
+func (r *exchangeRequest) GetExchangeActor() string                      { return "" }
+func (r *exchangeRequest) GetExchangeActorTokenType() oidc.TokenType     { return "" }
+func (r *exchangeRequest) GetExchangeActorTokenIDOrToken() string        { return "" }
+func (r *exchangeRequest) GetExchangeActorTokenClaims() map[string]any   { return nil }
+func (r *exchangeRequest) SetCurrentScopes(scopes []string)              { r.scopes = scopes }
+func (r *exchangeRequest) SetRequestedTokenType(tt oidc.TokenType)       { r.requestedType = tt }
+func (r *exchangeRequest) SetSubject(subject string)                     { r.subject = subject }
+
+func TestProviderStorage_TokenExchange(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewSQLite(t)
+
+	users := repository.NewBunUserRepository(db)
+	sessions := repository.NewBunSessionRepository(db)
+	subject := "alice"
+	user := &models.User{Subject: &subject, Email: "alice@example.com", Name: "Alice"}
+	require.NoError(t, users.Create(ctx, user))
+
+	revokedJTIs := repository.NewBunRevokedJTIRepository(db)
+	epochs := NewRevocationEpochCache(repository.NewBunRevocationEpochRepository(db), time.Time{})
+	storage, err := newProviderStorage(ProviderDependencies{
+		Users:           users,
+		Sessions:        sessions,
+		RevokedJTIs:     revokedJTIs,
+		RevocationEpoch: epochs,
+	}, filepath.Join(t.TempDir(), "signing.pem"))
+	require.NoError(t, err)
+	storage.setAudience("grid-api")
+	storage.tokenExchangeClients = []string{"ci"}
+
+	request := func(clientID string, subjectScope string, scopes ...string) *exchangeRequest {
+		return &exchangeRequest{
+			clientID:      clientID,
+			subject:       subject,
+			subjectJTI:    "subject-jti",
+			subjectClaims: map[string]any{"scope": subjectScope, "iat": float64(time.Now().Unix())},
+			audience:      []string{"grid-api"},
+			scopes:        scopes,
+		}
+	}
+
+	t.Run("down-scoped exchange", func(t *testing.T) {
+		req := request("ci", "openid state:* tfstate:*", StateRead, TfstateRead)
+		require.NoError(t, storage.ValidateTokenExchangeRequest(ctx, req))
+		assert.Equal(t, oidc.AccessTokenType, req.GetRequestedTokenType(), "defaults to an access token")
+		require.NoError(t, storage.CreateTokenExchangeRequest(ctx, req))
+
+		token, _, err := storage.CreateAccessToken(ctx, req)
+		require.NoError(t, err)
+		parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+		require.NoError(t, err)
+		claims := make(map[string]any)
+		require.NoError(t, parsed.Claims(&storage.currentSigningKey().key.PublicKey, &claims))
+		assert.Equal(t, subject, claims["sub"], "the token still speaks for the subject")
+		assert.Equal(t, map[string]any{"sub": ServiceAccountID("ci")}, claims[actorClaim])
+		assert.Equal(t, "state:read tfstate:read", claims["scope"])
+
+		private, err := storage.GetPrivateClaimsFromTokenExchangeRequest(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "state:read tfstate:read", private["scope"])
+		assert.Equal(t, map[string]any{"sub": ServiceAccountID("ci")}, private[actorClaim])
+
+		userSessions, err := sessions.GetByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, userSessions, 1)
+		assert.Equal(t, []string{StateRead, TfstateRead}, userSessions[0].Scopes)
+	})
+
+	t.Run("unrestricted subject", func(t *testing.T) {
+		assert.NoError(t, storage.ValidateTokenExchangeRequest(ctx, request("ci", "openid", "state:*")))
+	})
+
+	t.Run("nested actor", func(t *testing.T) {
+		req := request("ci", "state:read", StateRead)
+		req.subjectClaims[actorClaim] = map[string]any{"sub": ServiceAccountID("deployer")}
+		assert.Equal(t, map[string]any{
+			"sub":      ServiceAccountID("ci"),
+			actorClaim: map[string]any{"sub": ServiceAccountID("deployer")},
+		}, delegationActor(req))
+	})
+
+	t.Run("chained exchange never outlives the subject token", func(t *testing.T) {
+		subjectExp := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+		req := request("ci", "state:* tfstate:*", StateRead, TfstateRead)
+		req.subjectClaims["exp"] = float64(subjectExp.Unix())
+		require.NoError(t, storage.ValidateTokenExchangeRequest(ctx, req))
+		token, exp, err := storage.CreateAccessToken(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, subjectExp, exp, "capped at the subject token's exp")
+
+		// Exchange the exchanged token again
+		parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+		require.NoError(t, err)
+		claims := make(map[string]any)
+		require.NoError(t, parsed.Claims(&storage.currentSigningKey().key.PublicKey, &claims))
+		chained := request("ci", "", StateRead)
+		chained.subjectJTI, _ = claims["jti"].(string)
+		chained.subjectClaims = claims
+		require.NoError(t, storage.ValidateTokenExchangeRequest(ctx, chained))
+		_, chainedExp, err := storage.CreateAccessToken(ctx, chained)
+		require.NoError(t, err)
+		assert.Equal(t, subjectExp, chainedExp)
+	})
+
+	t.Run("revoked subject token", func(t *testing.T) {
+		req := request("ci", "state:read", StateRead)
+		req.subjectJTI = "revoked-jti"
+		require.NoError(t, revokedJTIs.Create(ctx, &models.RevokedJTI{JTI: "revoked-jti", Subject: subject, Exp: time.Now().Add(time.Hour)}))
+		err := storage.ValidateTokenExchangeRequest(ctx, req)
+		var oidcErr *oidc.Error
+		require.ErrorAs(t, err, &oidcErr)
+		assert.Equal(t, oidc.InvalidGrant, oidcErr.ErrorType)
+		assert.Contains(t, oidcErr.Description, "revoked")
+	})
+
+	t.Run("subject token issued before the revocation epoch", func(t *testing.T) {
+		req := request("ci", "state:read", StateRead)
+		req.subjectClaims["iat"] = float64(time.Now().Add(-time.Hour).Unix())
+		require.NoError(t, epochs.Set(ctx, time.Now().Add(-time.Minute).Truncate(time.Second), "user:admin"))
+		defer func() { require.NoError(t, epochs.Set(ctx, time.Time{}, "user:admin")) }()
+		err := storage.ValidateTokenExchangeRequest(ctx, req)
+		var oidcErr *oidc.Error
+		require.ErrorAs(t, err, &oidcErr)
+		assert.Equal(t, oidc.InvalidGrant, oidcErr.ErrorType)
+		assert.Contains(t, oidcErr.Description, "revocation epoch")
+	})
+
+	t.Run("disabled subject", func(t *testing.T) {
+		disabledSubject := "mallory"
+		disabledAt := time.Now()
+		require.NoError(t, users.Create(ctx, &models.User{Subject: &disabledSubject, Email: "mallory@example.com", DisabledAt: &disabledAt}))
+		req := request("ci", "state:read", StateRead)
+		req.subject = disabledSubject
+		err := storage.ValidateTokenExchangeRequest(ctx, req)
+		var oidcErr *oidc.Error
+		require.ErrorAs(t, err, &oidcErr)
+		assert.Equal(t, oidc.InvalidGrant, oidcErr.ErrorType)
+		assert.Contains(t, oidcErr.Description, "disabled")
+	})
+
+	rejected := []struct {
+		name string
+		req  *exchangeRequest
+		want func() *oidc.Error
+		desc string
+	}{
+		{name: "over-broad scopes", req: request("ci", "openid state:read", "state:*"), want: oidc.ErrInvalidScope, desc: "exceed the subject token: state:*"},
+		{name: "scope the subject lacks", req: request("ci", "state:read", StateRead, ScopeGroups), want: oidc.ErrInvalidScope, desc: "exceed the subject token: groups"},
+		{name: "no permission scope", req: request("ci", "state:read", oidc.ScopeOpenID), want: oidc.ErrInvalidScope, desc: "at least one permission scope"},
+		{name: "client not allowed", req: request("deployer", "state:read", StateRead), want: oidc.ErrUnauthorizedClient, desc: "deployer is not allowed"},
+		{name: "refresh token requested", req: func() *exchangeRequest {
+			req := request("ci", "state:read", StateRead)
+			req.requestedType = oidc.RefreshTokenType
+			return req
+		}(), want: oidc.ErrInvalidRequest, desc: "requested_token_type"},
+		{name: "foreign audience", req: func() *exchangeRequest {
+			req := request("ci", "state:read", StateRead)
+			req.audience = []string{"grid-api", "other-api"}
+			return req
+		}(), want: oidc.ErrInvalidTarget, desc: "audience must be grid-api"},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			err := storage.ValidateTokenExchangeRequest(ctx, tt.req)
+			var oidcErr *oidc.Error
+			require.ErrorAs(t, err, &oidcErr)
+			assert.Equal(t, tt.want().ErrorType, oidcErr.ErrorType)
+			assert.Contains(t, oidcErr.Description, tt.desc)
+		})
+	}
+}
+
+func TestServiceAccountClient_TokenExchangeGrant(t *testing.T) {
+	assert.NotContains(t, (&serviceAccountClient{id: "deployer"}).GrantTypes(), oidc.GrantTypeTokenExchange)
+	assert.Contains(t, (&serviceAccountClient{id: "ci", tokenExchange: true}).GrantTypes(), oidc.GrantTypeTokenExchange)
+}
@@ -0,0 +1,36 @@
+package auth
+
+import "slices"
+
+// IsPermissionScope reports whether an OAuth scope names a Grid action or
+// action wildcard (e.g. "state:read", "tfstate:*"). Such scopes limit a token
+// to the actions they cover; other scopes (openid, roles, ...) grant nothing.
+func IsPermissionScope(scope string) bool {
+	return ValidateAction(scope)
+}
+
+// PermissionScopes returns the permission scopes among the granted OAuth
+// scopes, or nil when there are none (the token is not down-scoped).
+func PermissionScopes(scopes []string) []string {
+	var permission []string
+	for _, scope := range scopes {
+		if IsPermissionScope(scope) && !slices.Contains(permission, scope) {
+			permission = append(permission, scope)
+		}
+	}
+	return permission
+}
+
+// TokenScopesAllow reports whether a token limited to tokenScopes may perform
+// act. A token without permission scopes is limited only by its roles.
+func TokenScopesAllow(tokenScopes []string, act string) bool {
+	if len(tokenScopes) == 0 {
+		return true
+	}
+	for _, scope := range tokenScopes {
+		if slices.Contains(ExpandWildcard(scope), act) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
@@ -9,6 +12,22 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Policies for roles created or updated with an empty label scope expression.
+// The same policy decides how roles stored with an empty scope are migrated at startup.
+const (
+	EmptyRoleScopeAllow  = "allow"  // Empty scope is stored as "*" (matches every state)
+	EmptyRoleScopeReject = "reject" // Empty scope is an error; existing empty scopes become deny-all
+	EmptyRoleScopeDeny   = "deny"   // Empty scope is stored as a deny-all scope
+)
+
+// What the server does at startup about roles whose Casbin policies drifted
+// from the actions and scope stored on the role.
+const (
+	RolePolicyReconcileOff    = "off"    // Don't check
+	RolePolicyReconcileReport = "report" // Log the drift for review
+	RolePolicyReconcileRepair = "repair" // Log the drift and rewrite the policies
+)
+
 // Config holds the application configuration
 type Config struct {
 	// Database connection string (DSN)
@@ -20,6 +39,11 @@ type Config struct {
 	// Base URL for backend config generation
 	ServerURL string `mapstructure:"server_url"`
 
+	// Externally reachable base URL handed to Terraform in backend configs
+	// (address/lock_address/unlock_address). Set this when clients reach Grid
+	// through a gateway whose URL differs from ServerURL. Defaults to ServerURL.
+	BackendURL string `mapstructure:"backend_url"`
+
 	// Maximum database connection pool size
 	MaxDBConnections int `mapstructure:"max_db_connections"`
 
@@ -29,8 +53,132 @@ type Config struct {
 	// IAM cache refresh interval (default: 5m)
 	CacheRefreshInterval time.Duration `mapstructure:"cache_refresh_interval"`
 
+	// When a login carries groups the group→role cache has no mapping for and
+	// the cache is older than this, refresh it once before resolving roles
+	// (default: 0, disabled)
+	CacheMissRefreshAfter time.Duration `mapstructure:"cache_miss_refresh_after"`
+
+	// Window after session expiry during which whoami reports "session expired"
+	// instead of a generic 401 (default: 15m, 0 disables)
+	SessionExpiryGrace time.Duration `mapstructure:"session_expiry_grace"`
+
+	// Request header session cookies are bound to, typically one a trusted
+	// proxy sets (e.g. a client certificate fingerprint). Sessions record a
+	// hash of its value at login and are rejected when a request presents a
+	// different value or none; logins without the header are refused. Empty
+	// (default) disables binding.
+	SessionBindingHeader string `mapstructure:"session_binding_header"`
+
+	// Credentials (JWT iat, session creation) issued before this RFC 3339
+	// timestamp are rejected. Acts as a floor under the epoch admins bump at
+	// runtime; empty means no floor.
+	RevocationEpoch string `mapstructure:"revocation_epoch"`
+
+	// How roles created or updated without a label scope are treated:
+	// "allow" (default, matches all states), "reject", or "deny" (matches none)
+	EmptyRoleScope string `mapstructure:"empty_role_scope"`
+
+	// Startup check of role policies against the roles table: "off" (default),
+	// "report" or "repair"
+	RolePolicyReconcile string `mapstructure:"role_policy_reconcile"`
+
+	// Object types ("state", "policy", "admin") for which a principal with several
+	// roles is only granted access when every role permits it (intersection).
+	// Object types not listed grant access when any role permits it (union).
+	ScopeIntersectionObjectTypes []string `mapstructure:"scope_intersection_object_types"`
+
+	// Deny scoped requests from a principal carrying a role that is neither a
+	// role name nor a "role:" identifier, instead of logging a warning and
+	// looking the raw value up as a role name (default: false)
+	StrictRoleParsing bool `mapstructure:"strict_role_parsing"`
+
 	// OIDC authentication configuration
 	OIDC OIDCConfig `mapstructure:"oidc"`
+
+	// State naming rules
+	StateNaming StateNamingConfig `mapstructure:"state_naming"`
+
+	// Maximum number of outputs accepted per state upload (default: 10000, 0 disables)
+	MaxOutputsPerState int `mapstructure:"max_outputs_per_state"`
+
+	// How long CreateState idempotency keys are remembered (default: 24h)
+	IdempotencyKeyTTL time.Duration `mapstructure:"idempotency_key_ttl"`
+
+	// How often WatchStateChanges polls for state changes while anyone is
+	// watching (default: 2s)
+	StateWatchPollInterval time.Duration `mapstructure:"state_watch_poll_interval"`
+
+	// How often open streams re-authenticate and re-authorize their caller,
+	// closing the stream once the credential expires or is revoked
+	// (default: 30s)
+	StateWatchReauthInterval time.Duration `mapstructure:"state_watch_reauth_interval"`
+
+	// Password rules for internal IdP users
+	PasswordPolicy PasswordPolicyConfig `mapstructure:"password_policy"`
+
+	// OpenTelemetry trace and metric export
+	Telemetry TelemetryConfig `mapstructure:"telemetry"`
+}
+
+// TelemetryConfig selects where OpenTelemetry traces and metrics are sent.
+// The OTLP endpoint, headers and protocol options come from the standard
+// OTEL_EXPORTER_OTLP_* environment variables.
+type TelemetryConfig struct {
+	// Exporter is "none" (default; instrumentation is a no-op) or "otlp"
+	// (OTLP over HTTP).
+	Exporter string `mapstructure:"exporter"`
+
+	// ServiceName is reported as service.name (default: gridapi).
+	ServiceName string `mapstructure:"service_name"`
+
+	// MetricInterval is how often metrics are exported (default: 60s).
+	MetricInterval time.Duration `mapstructure:"metric_interval"`
+}
+
+// PasswordPolicyConfig controls which passwords are accepted for internal IdP
+// users. It is checked where the raw password is hashed; external IdP users
+// have no Grid password and are not affected.
+type PasswordPolicyConfig struct {
+	// MinLength is the minimum password length in characters (default: 8).
+	MinLength int `mapstructure:"min_length"`
+
+	// Character classes a password must contain (all default to false).
+	RequireUppercase bool `mapstructure:"require_uppercase"`
+	RequireLowercase bool `mapstructure:"require_lowercase"`
+	RequireDigit     bool `mapstructure:"require_digit"`
+	RequireSymbol    bool `mapstructure:"require_symbol"`
+
+	// BreachListPath is an optional file of known-breached passwords, one per
+	// line. Passwords found in it are rejected. Empty disables the check.
+	BreachListPath string `mapstructure:"breach_list_path"`
+}
+
+// StateNamingConfig controls which logic_ids are accepted when creating states.
+// Rules apply to new states only; existing states keep working regardless.
+type StateNamingConfig struct {
+	// LogicIDPattern is a regular expression new logic_ids must match.
+	// Empty uses the built-in default (alphanumerics, '.', '_', '-').
+	// Slashes, whitespace and control characters are always rejected.
+	LogicIDPattern string `mapstructure:"logic_id_pattern"`
+
+	// LogicIDMaxLength caps logic_id length (default: 128, the column width).
+	LogicIDMaxLength int `mapstructure:"logic_id_max_length"`
+}
+
+// RevocationEpochTime returns the configured revocation epoch, or the zero
+// time if none is set. validate has already checked the format.
+func (c *Config) RevocationEpochTime() time.Time {
+	epoch, _ := time.Parse(time.RFC3339, c.RevocationEpoch)
+	return epoch
+}
+
+// BackendBaseURL returns the base URL used to build Terraform backend configs,
+// falling back to ServerURL when no external BackendURL is configured.
+func (c *Config) BackendBaseURL() string {
+	if c.BackendURL != "" {
+		return c.BackendURL
+	}
+	return c.ServerURL
 }
 
 // OIDCConfig holds OIDC configuration for Grid's authentication.
@@ -62,16 +210,32 @@ type OIDCConfig struct {
 	// Key is persisted to disk to ensure tokens remain valid across server restarts
 	SigningKeyPath string `mapstructure:"signing_key_path"`
 
+	// Audiences lists additional aud values accepted in access tokens (both modes).
+	// The mode's ClientID is always accepted; use this when one issuer fronts
+	// several logical APIs. A token must carry at least one accepted audience.
+	Audiences []string `mapstructure:"audiences"`
+
+	// TokenExchangeClients lists the service account client IDs allowed to use
+	// the token exchange grant (RFC 8693) to trade a subject's access token for
+	// a narrower one issued on its behalf (Mode 2 only). Empty disables exchange.
+	TokenExchangeClients []string `mapstructure:"token_exchange_clients"`
+
 	// External IdP Configuration (Mode 1)
 	// When configured, Grid acts as Resource Server validating external tokens
 	// Leave nil for Mode 2 (Internal IdP Only)
 	ExternalIdP *ExternalIdPConfig `mapstructure:"external_idp"`
 
 	// JWT claim extraction configuration (applies to both modes)
-	GroupsClaimField string `mapstructure:"groups_claim_field"` // Default: "groups"
+	GroupsClaimField string `mapstructure:"groups_claim_field"` // Default: "groups"; dot notation reaches nested claims (e.g., "realm_access.roles")
 	GroupsClaimPath  string `mapstructure:"groups_claim_path"`  // Optional: for nested extraction (e.g., "name" for [{name:"dev"}])
 	UserIDClaimField string `mapstructure:"user_id_claim_field"` // Default: "sub"
 	EmailClaimField  string `mapstructure:"email_claim_field"`  // Default: "email"
+
+	// GroupNameNormalization lists how group names are normalized before they
+	// are stored in group-role mappings and before claims are looked up:
+	// "strip-leading-slash" and/or "lowercase", or "none". Default:
+	// ["strip-leading-slash"].
+	GroupNameNormalization []string `mapstructure:"group_name_normalization"`
 }
 
 // IsInternalIdPMode returns true if Grid is configured as an Internal IdP (Mode 2)
@@ -93,6 +257,35 @@ type ExternalIdPConfig struct {
 	ClientSecret string   `mapstructure:"client_secret"` // Grid's client secret with external IdP (for confidential client only)
 	RedirectURI  string   `mapstructure:"redirect_uri"`  // Grid's SSO callback URL (e.g., "https://grid.example.com/auth/sso/callback")
 	Scopes       []string `mapstructure:"scopes"`       // Optional: Additional OIDC scopes beyond default ["openid", "profile", "email"]
+
+	// JWKSRefreshInterval is how often the IdP's signing keys are refetched in
+	// the background. Tokens with an unknown kid also trigger a refetch.
+	JWKSRefreshInterval time.Duration `mapstructure:"jwks_refresh_interval"`
+
+	// TrustedIssuers lists further issuers (e.g. other Keycloak realms) whose
+	// bearer tokens are accepted alongside Issuer's. Tokens are routed by their
+	// iss claim; any issuer not listed here or above is rejected. SSO still
+	// goes through Issuer only.
+	TrustedIssuers []TrustedIssuerConfig `mapstructure:"trusted_issuers"`
+}
+
+// TrustedIssuerConfig is an additional issuer accepted in External IdP mode.
+// From the environment, GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS takes a JSON
+// array of these objects.
+type TrustedIssuerConfig struct {
+	Issuer    string   `mapstructure:"issuer" json:"issuer"`
+	JWKSURI   string   `mapstructure:"jwks_uri" json:"jwks_uri"`
+	Audiences []string `mapstructure:"audiences" json:"audiences"` // At least one aud value must match
+
+	// Group claim mapping; empty fields fall back to OIDCConfig's
+	GroupsClaimField string `mapstructure:"groups_claim_field" json:"groups_claim_field"`
+	GroupsClaimPath  string `mapstructure:"groups_claim_path" json:"groups_claim_path"`
+
+	// AllowedGroups, when set, keeps only these groups from the issuer's
+	// tokens; GroupPrefix is then prepended to each kept group (e.g. "realm-b/"),
+	// so group-role mappings never match another issuer's group by name.
+	AllowedGroups []string `mapstructure:"allowed_groups" json:"allowed_groups"`
+	GroupPrefix   string   `mapstructure:"group_prefix" json:"group_prefix"`
 }
 
 // Load reads configuration from Viper with support for:
@@ -121,6 +314,7 @@ func Load() (*Config, error) {
 	decoderConfig := &mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
+			jsonStringToTrustedIssuersHookFunc(),
 			mapstructure.StringToSliceHookFunc(","),
 		),
 		Result:           cfg,
@@ -165,20 +359,54 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server_addr", "localhost:8080")
 	v.SetDefault("server_url", "")   // Register key for Env var lookup, but force explicit value
 	v.SetDefault("max_db_connections", 25)
+	v.SetDefault("backend_url", "") // Optional: falls back to server_url
 	v.SetDefault("debug", false)
 	v.SetDefault("cache_refresh_interval", "5m")
+	v.SetDefault("cache_miss_refresh_after", "0")
+	v.SetDefault("session_expiry_grace", "15m")
+	v.SetDefault("session_binding_header", "")
+	v.SetDefault("revocation_epoch", "")
+	v.SetDefault("empty_role_scope", EmptyRoleScopeAllow)
+	v.SetDefault("role_policy_reconcile", RolePolicyReconcileOff)
+	v.SetDefault("scope_intersection_object_types", []string{})
+	v.SetDefault("strict_role_parsing", false)
+
+	// State naming defaults (empty pattern = built-in default)
+	v.SetDefault("state_naming.logic_id_pattern", "")
+	v.SetDefault("state_naming.logic_id_max_length", 128)
+
+	v.SetDefault("max_outputs_per_state", 10000)
+	v.SetDefault("idempotency_key_ttl", "24h")
+	v.SetDefault("state_watch_poll_interval", "2s")
+	v.SetDefault("state_watch_reauth_interval", "30s")
+
+	// Password policy defaults (length only; character classes are opt-in)
+	v.SetDefault("password_policy.min_length", 8)
+	v.SetDefault("password_policy.require_uppercase", false)
+	v.SetDefault("password_policy.require_lowercase", false)
+	v.SetDefault("password_policy.require_digit", false)
+	v.SetDefault("password_policy.require_symbol", false)
+	v.SetDefault("password_policy.breach_list_path", "")
+
+	// Telemetry defaults (export disabled)
+	v.SetDefault("telemetry.exporter", "none")
+	v.SetDefault("telemetry.service_name", "gridapi")
+	v.SetDefault("telemetry.metric_interval", "60s")
 
 	// OIDC defaults
 	v.SetDefault("oidc.groups_claim_field", "groups")
 	v.SetDefault("oidc.groups_claim_path", "")
 	v.SetDefault("oidc.user_id_claim_field", "sub")
 	v.SetDefault("oidc.email_claim_field", "email")
+	v.SetDefault("oidc.group_name_normalization", []string{"strip-leading-slash"})
 
 	// Explicitly set defaults for nested OIDC keys so Viper knows they exist
 	// and can populate them from environment variables during Unmarshal.
 	v.SetDefault("oidc.issuer", "")
 	v.SetDefault("oidc.client_id", "")
 	v.SetDefault("oidc.signing_key_path", "")
+	v.SetDefault("oidc.audiences", []string{})
+	v.SetDefault("oidc.token_exchange_clients", []string{})
 	v.SetDefault("oidc.external_idp.issuer", "")
 	v.SetDefault("oidc.external_idp.client_id", "")
 	v.SetDefault("oidc.external_idp.client_secret", "")
@@ -190,6 +418,8 @@ func setDefaults(v *viper.Viper) {
 	// Default OIDC scopes for external IdP (matches pre-Viper behavior)
 	// Without "openid" scope, IdP will treat request as OAuth2-only and won't return id_token
 	v.SetDefault("oidc.external_idp.scopes", []string{"openid", "profile", "email"})
+	v.SetDefault("oidc.external_idp.jwks_refresh_interval", "15m")
+	v.SetDefault("oidc.external_idp.trusted_issuers", []TrustedIssuerConfig{})
 }
 
 // validate performs configuration validation
@@ -226,10 +456,143 @@ func validate(cfg *Config) error {
 		if cfg.OIDC.ExternalIdP.RedirectURI == "" {
 			return fmt.Errorf("GRID_OIDC_EXTERNAL_IDP_REDIRECT_URI is required for External IdP mode")
 		}
+		if cfg.OIDC.ExternalIdP.JWKSRefreshInterval <= 0 {
+			return fmt.Errorf("GRID_OIDC_EXTERNAL_IDP_JWKS_REFRESH_INTERVAL must be positive, got %s", cfg.OIDC.ExternalIdP.JWKSRefreshInterval)
+		}
+		if err := validateTrustedIssuers(cfg.OIDC.ExternalIdP); err != nil {
+			return err
+		}
 	}
 
 	// Mode 2: Internal IdP Only - no additional validation needed here
 	// Provider initialization in oidc.go will validate Issuer is set
 
+	// State naming validation
+	if cfg.StateNaming.LogicIDPattern != "" {
+		if _, err := regexp.Compile(cfg.StateNaming.LogicIDPattern); err != nil {
+			return fmt.Errorf("invalid GRID_STATE_NAMING_LOGIC_ID_PATTERN: %w", err)
+		}
+	}
+	if cfg.StateNaming.LogicIDMaxLength < 1 || cfg.StateNaming.LogicIDMaxLength > 128 {
+		return fmt.Errorf("GRID_STATE_NAMING_LOGIC_ID_MAX_LENGTH must be between 1 and 128, got %d", cfg.StateNaming.LogicIDMaxLength)
+	}
+
+	if cfg.MaxOutputsPerState < 0 {
+		return fmt.Errorf("GRID_MAX_OUTPUTS_PER_STATE must not be negative, got %d", cfg.MaxOutputsPerState)
+	}
+
+	if cfg.IdempotencyKeyTTL <= 0 {
+		return fmt.Errorf("GRID_IDEMPOTENCY_KEY_TTL must be positive, got %s", cfg.IdempotencyKeyTTL)
+	}
+
+	if cfg.StateWatchPollInterval <= 0 {
+		return fmt.Errorf("GRID_STATE_WATCH_POLL_INTERVAL must be positive, got %s", cfg.StateWatchPollInterval)
+	}
+
+	if cfg.StateWatchReauthInterval <= 0 {
+		return fmt.Errorf("GRID_STATE_WATCH_REAUTH_INTERVAL must be positive, got %s", cfg.StateWatchReauthInterval)
+	}
+
+	if cfg.Telemetry.Exporter != "none" && cfg.Telemetry.Exporter != "otlp" {
+		return fmt.Errorf("GRID_TELEMETRY_EXPORTER must be none or otlp, got %q", cfg.Telemetry.Exporter)
+	}
+	if cfg.Telemetry.MetricInterval <= 0 {
+		return fmt.Errorf("GRID_TELEMETRY_METRIC_INTERVAL must be positive, got %s", cfg.Telemetry.MetricInterval)
+	}
+
+	if cfg.PasswordPolicy.MinLength < 1 {
+		return fmt.Errorf("GRID_PASSWORD_POLICY_MIN_LENGTH must be at least 1, got %d", cfg.PasswordPolicy.MinLength)
+	}
+
+	if cfg.RevocationEpoch != "" {
+		if _, err := time.Parse(time.RFC3339, cfg.RevocationEpoch); err != nil {
+			return fmt.Errorf("invalid GRID_REVOCATION_EPOCH (want RFC 3339, e.g. 2026-10-15T12:00:00Z): %w", err)
+		}
+	}
+
+	if cfg.CacheMissRefreshAfter < 0 {
+		return fmt.Errorf("GRID_CACHE_MISS_REFRESH_AFTER must not be negative, got %s", cfg.CacheMissRefreshAfter)
+	}
+
+	if cfg.SessionExpiryGrace < 0 {
+		return fmt.Errorf("GRID_SESSION_EXPIRY_GRACE must not be negative, got %s", cfg.SessionExpiryGrace)
+	}
+
+	switch cfg.EmptyRoleScope {
+	case EmptyRoleScopeAllow, EmptyRoleScopeReject, EmptyRoleScopeDeny:
+	default:
+		return fmt.Errorf("GRID_EMPTY_ROLE_SCOPE must be one of %q, %q or %q, got %q",
+			EmptyRoleScopeAllow, EmptyRoleScopeReject, EmptyRoleScopeDeny, cfg.EmptyRoleScope)
+	}
+
+	switch cfg.RolePolicyReconcile {
+	case RolePolicyReconcileOff, RolePolicyReconcileReport, RolePolicyReconcileRepair:
+	default:
+		return fmt.Errorf("GRID_ROLE_POLICY_RECONCILE must be one of %q, %q or %q, got %q",
+			RolePolicyReconcileOff, RolePolicyReconcileReport, RolePolicyReconcileRepair, cfg.RolePolicyReconcile)
+	}
+
+	// Mirrors the strategies in auth/groups.go
+	for _, strategy := range cfg.OIDC.GroupNameNormalization {
+		switch strategy {
+		case "strip-leading-slash", "lowercase":
+		case "none":
+			if len(cfg.OIDC.GroupNameNormalization) > 1 {
+				return fmt.Errorf("GRID_OIDC_GROUP_NAME_NORMALIZATION \"none\" cannot be combined with other strategies")
+			}
+		default:
+			return fmt.Errorf("GRID_OIDC_GROUP_NAME_NORMALIZATION entries must be \"strip-leading-slash\", \"lowercase\" or \"none\", got %q", strategy)
+		}
+	}
+
+	// Mirrors the object types in auth/actions.go (auth imports config, not the reverse)
+	for _, objType := range cfg.ScopeIntersectionObjectTypes {
+		switch objType {
+		case "state", "policy", "admin":
+		default:
+			return fmt.Errorf("GRID_SCOPE_INTERSECTION_OBJECT_TYPES entries must be \"state\", \"policy\" or \"admin\", got %q", objType)
+		}
+	}
+
+	return nil
+}
+
+// validateTrustedIssuers requires every trusted issuer to be complete and
+// distinct from the others and from the primary issuer.
+func validateTrustedIssuers(ext *ExternalIdPConfig) error {
+	seen := map[string]bool{ext.Issuer: true}
+	for i, trusted := range ext.TrustedIssuers {
+		switch {
+		case trusted.Issuer == "":
+			return fmt.Errorf("GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS entry %d: issuer is required", i)
+		case seen[trusted.Issuer]:
+			return fmt.Errorf("GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS entry %d: issuer %q is listed twice", i, trusted.Issuer)
+		case trusted.JWKSURI == "":
+			return fmt.Errorf("GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS entry %d: jwks_uri is required for %q", i, trusted.Issuer)
+		case len(trusted.Audiences) == 0:
+			return fmt.Errorf("GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS entry %d: at least one audience is required for %q", i, trusted.Issuer)
+		}
+		seen[trusted.Issuer] = true
+	}
 	return nil
 }
+
+// jsonStringToTrustedIssuersHookFunc decodes trusted issuers given as a JSON
+// array in an environment variable.
+func jsonStringToTrustedIssuersHookFunc() mapstructure.DecodeHookFuncType {
+	target := reflect.TypeOf([]TrustedIssuerConfig{})
+	return func(from, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to != target {
+			return data, nil
+		}
+		raw := strings.TrimSpace(data.(string))
+		if raw == "" {
+			return []TrustedIssuerConfig{}, nil
+		}
+		var issuers []TrustedIssuerConfig
+		if err := json.Unmarshal([]byte(raw), &issuers); err != nil {
+			return nil, fmt.Errorf("invalid GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS (want a JSON array): %w", err)
+		}
+		return issuers, nil
+	}
+}
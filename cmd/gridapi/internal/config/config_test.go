@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -151,8 +152,9 @@ func TestLoad_WithOIDCExternalIdP(t *testing.T) {
 	assert.Equal(t, "client-id", cfg.OIDC.ExternalIdP.ClientID)
 	assert.Equal(t, "secret", cfg.OIDC.ExternalIdP.ClientSecret)
 	assert.Equal(t, "http://callback", cfg.OIDC.ExternalIdP.RedirectURI)
-	assert.Equal(t, "gridctl", cfg.OIDC.ExternalIdP.CLIClientID) // Default
-	assert.Empty(t, cfg.OIDC.Issuer)                             // Internal IdP not set
+	assert.Equal(t, "gridctl", cfg.OIDC.ExternalIdP.CLIClientID)              // Default
+	assert.Equal(t, 15*time.Minute, cfg.OIDC.ExternalIdP.JWKSRefreshInterval) // Default
+	assert.Empty(t, cfg.OIDC.Issuer)                                          // Internal IdP not set
 }
 
 // TestLoad_WithInternalIdP tests Internal IdP configuration via Env Vars
@@ -470,4 +472,353 @@ func TestLoad_InternalIdPFromEnvVars_ViperWorkaround(t *testing.T) {
 
 	// Verify External IdP is not set
 	assert.Nil(t, cfg.OIDC.ExternalIdP, "ExternalIdP should be nil")
-}
\ No newline at end of file
+}
+
+// TestLoad_StateNaming verifies logic_id naming defaults and pattern validation
+func TestLoad_StateNaming(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_STATE_NAMING_LOGIC_ID_PATTERN")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.StateNaming.LogicIDPattern)
+	assert.Equal(t, 128, cfg.StateNaming.LogicIDMaxLength)
+
+	viper.Reset()
+	os.Setenv("GRID_STATE_NAMING_LOGIC_ID_PATTERN", "^[a-z")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_STATE_NAMING_LOGIC_ID_PATTERN")
+}
+
+// TestLoad_BackendURL verifies the backend config base URL and its fallback to server_url
+func TestLoad_BackendURL(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_BACKEND_URL")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://gridapi.internal:8080")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.BackendURL)
+	assert.Equal(t, "http://gridapi.internal:8080", cfg.BackendBaseURL())
+
+	viper.Reset()
+	os.Setenv("GRID_BACKEND_URL", "https://grid.example.com")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "https://grid.example.com", cfg.BackendBaseURL())
+	assert.Equal(t, "http://gridapi.internal:8080", cfg.ServerURL)
+}
+
+// TestLoad_ScopeIntersectionObjectTypes verifies the comma-separated env var and its validation
+func TestLoad_ScopeIntersectionObjectTypes(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_SCOPE_INTERSECTION_OBJECT_TYPES")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.ScopeIntersectionObjectTypes)
+
+	viper.Reset()
+	os.Setenv("GRID_SCOPE_INTERSECTION_OBJECT_TYPES", "state,admin")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"state", "admin"}, cfg.ScopeIntersectionObjectTypes)
+
+	viper.Reset()
+	os.Setenv("GRID_SCOPE_INTERSECTION_OBJECT_TYPES", "states")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_SCOPE_INTERSECTION_OBJECT_TYPES")
+}
+
+// TestLoad_PasswordPolicy verifies password policy defaults and env overrides
+func TestLoad_PasswordPolicy(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_PASSWORD_POLICY_MIN_LENGTH")
+		os.Unsetenv("GRID_PASSWORD_POLICY_REQUIRE_DIGIT")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 8, cfg.PasswordPolicy.MinLength)
+	assert.False(t, cfg.PasswordPolicy.RequireDigit)
+	assert.Empty(t, cfg.PasswordPolicy.BreachListPath)
+
+	viper.Reset()
+	os.Setenv("GRID_PASSWORD_POLICY_MIN_LENGTH", "12")
+	os.Setenv("GRID_PASSWORD_POLICY_REQUIRE_DIGIT", "true")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 12, cfg.PasswordPolicy.MinLength)
+	assert.True(t, cfg.PasswordPolicy.RequireDigit)
+
+	viper.Reset()
+	os.Setenv("GRID_PASSWORD_POLICY_MIN_LENGTH", "0")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_PASSWORD_POLICY_MIN_LENGTH")
+}
+
+func TestLoad_RevocationEpoch(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_REVOCATION_EPOCH")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.RevocationEpochTime().IsZero())
+
+	viper.Reset()
+	os.Setenv("GRID_REVOCATION_EPOCH", "2026-10-15T12:00:00Z")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC), cfg.RevocationEpochTime())
+
+	viper.Reset()
+	os.Setenv("GRID_REVOCATION_EPOCH", "yesterday")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_REVOCATION_EPOCH")
+}
+
+func TestLoad_TrustedIssuers(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_OIDC_EXTERNAL_IDP_ISSUER")
+		os.Unsetenv("GRID_OIDC_EXTERNAL_IDP_CLIENT_ID")
+		os.Unsetenv("GRID_OIDC_EXTERNAL_IDP_CLIENT_SECRET")
+		os.Unsetenv("GRID_OIDC_EXTERNAL_IDP_REDIRECT_URI")
+		os.Unsetenv("GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+	os.Setenv("GRID_OIDC_EXTERNAL_IDP_ISSUER", "https://kc.example.com/realms/main")
+	os.Setenv("GRID_OIDC_EXTERNAL_IDP_CLIENT_ID", "client-id")
+	os.Setenv("GRID_OIDC_EXTERNAL_IDP_CLIENT_SECRET", "secret")
+	os.Setenv("GRID_OIDC_EXTERNAL_IDP_REDIRECT_URI", "http://callback")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.OIDC.ExternalIdP.TrustedIssuers)
+
+	viper.Reset()
+	os.Setenv("GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS", `[{
+		"issuer": "https://kc.example.com/realms/team-b",
+		"jwks_uri": "https://kc.example.com/realms/team-b/protocol/openid-connect/certs",
+		"audiences": ["grid-api"],
+		"groups_claim_field": "roles",
+		"allowed_groups": ["ops"],
+		"group_prefix": "team-b/"
+	}]`)
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []TrustedIssuerConfig{{
+		Issuer:           "https://kc.example.com/realms/team-b",
+		JWKSURI:          "https://kc.example.com/realms/team-b/protocol/openid-connect/certs",
+		Audiences:        []string{"grid-api"},
+		GroupsClaimField: "roles",
+		AllowedGroups:    []string{"ops"},
+		GroupPrefix:      "team-b/",
+	}}, cfg.OIDC.ExternalIdP.TrustedIssuers)
+
+	for env, want := range map[string]string{
+		`[{"issuer": "https://kc.example.com/realms/team-b", "jwks_uri": "https://kc.example.com/certs"}]`:                          "at least one audience",
+		`[{"issuer": "https://kc.example.com/realms/main", "jwks_uri": "https://kc.example.com/certs", "audiences": ["grid-api"]}]`: "listed twice",
+		`[{"jwks_uri": "https://kc.example.com/certs", "audiences": ["grid-api"]}]`:                                                 "issuer is required",
+		`not json`: "JSON array",
+	} {
+		viper.Reset()
+		os.Setenv("GRID_OIDC_EXTERNAL_IDP_TRUSTED_ISSUERS", env)
+
+		_, err = Load()
+		require.Error(t, err, env)
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+// TestLoad_GroupNameNormalization verifies the default and validation of
+// GRID_OIDC_GROUP_NAME_NORMALIZATION
+func TestLoad_GroupNameNormalization(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_OIDC_GROUP_NAME_NORMALIZATION")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"strip-leading-slash"}, cfg.OIDC.GroupNameNormalization)
+
+	viper.Reset()
+	os.Setenv("GRID_OIDC_GROUP_NAME_NORMALIZATION", "strip-leading-slash,lowercase")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"strip-leading-slash", "lowercase"}, cfg.OIDC.GroupNameNormalization)
+
+	for _, invalid := range []string{"uppercase", "none,lowercase"} {
+		viper.Reset()
+		os.Setenv("GRID_OIDC_GROUP_NAME_NORMALIZATION", invalid)
+
+		_, err = Load()
+		require.Error(t, err, invalid)
+		assert.Contains(t, err.Error(), "GRID_OIDC_GROUP_NAME_NORMALIZATION")
+	}
+}
+
+func TestLoad_RolePolicyReconcile(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_ROLE_POLICY_RECONCILE")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, RolePolicyReconcileOff, cfg.RolePolicyReconcile)
+
+	viper.Reset()
+	os.Setenv("GRID_ROLE_POLICY_RECONCILE", "repair")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, RolePolicyReconcileRepair, cfg.RolePolicyReconcile)
+
+	viper.Reset()
+	os.Setenv("GRID_ROLE_POLICY_RECONCILE", "fix")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_ROLE_POLICY_RECONCILE")
+}
+
+func TestLoad_StateWatchPollInterval(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_STATE_WATCH_POLL_INTERVAL")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, cfg.StateWatchPollInterval)
+
+	viper.Reset()
+	os.Setenv("GRID_STATE_WATCH_POLL_INTERVAL", "0s")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_STATE_WATCH_POLL_INTERVAL")
+}
+
+func TestLoad_StateWatchReauthInterval(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_STATE_WATCH_REAUTH_INTERVAL")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.StateWatchReauthInterval)
+
+	viper.Reset()
+	os.Setenv("GRID_STATE_WATCH_REAUTH_INTERVAL", "0s")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_STATE_WATCH_REAUTH_INTERVAL")
+}
+
+func TestLoad_Telemetry(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GRID_DATABASE_URL")
+		os.Unsetenv("GRID_SERVER_URL")
+		os.Unsetenv("GRID_TELEMETRY_EXPORTER")
+	}()
+
+	viper.Reset()
+	os.Setenv("GRID_DATABASE_URL", "postgres://test/test")
+	os.Setenv("GRID_SERVER_URL", "http://test")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "none", cfg.Telemetry.Exporter)
+	assert.Equal(t, "gridapi", cfg.Telemetry.ServiceName)
+	assert.Equal(t, 60*time.Second, cfg.Telemetry.MetricInterval)
+
+	viper.Reset()
+	os.Setenv("GRID_TELEMETRY_EXPORTER", "otlp")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "otlp", cfg.Telemetry.Exporter)
+
+	viper.Reset()
+	os.Setenv("GRID_TELEMETRY_EXPORTER", "jaeger")
+
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRID_TELEMETRY_EXPORTER")
+}
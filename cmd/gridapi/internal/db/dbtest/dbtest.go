@@ -0,0 +1,35 @@
+// Package dbtest provides databases for tests.
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/bunx"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/migrations"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+// NewSQLite returns an in-memory SQLite database with every migration
+// applied, so tests run against the same schema as a deployed server. The
+// database is closed when the test ends.
+func NewSQLite(t testing.TB) *bun.DB {
+	t.Helper()
+
+	db, err := bunx.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	migrator := migrate.NewMigrator(db, migrations.Migrations)
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatalf("init migrations: %v", err)
+	}
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+	return db
+}
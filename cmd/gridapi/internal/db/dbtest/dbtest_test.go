@@ -0,0 +1,12 @@
+package dbtest
+
+import "testing"
+
+func TestNewSQLite(t *testing.T) {
+	db := NewSQLite(t)
+	for _, table := range []string{"states", "edges", "state_outputs", "output_schema_versions", "users", "service_accounts", "casbin_rules"} {
+		if _, err := db.NewSelect().Table(table).Limit(1).Exec(t.Context()); err != nil {
+			t.Errorf("table %s: %v", table, err)
+		}
+	}
+}
@@ -15,15 +15,17 @@ import (
 type User struct {
 	bun.BaseModel `bun:"table:users,alias:u"`
 
-	ID           string     `bun:"id,pk,type:uuid"`
-	Subject      *string    `bun:"subject,unique"` // Optional OIDC subject (e.g., "keycloak|123")
-	Email        string     `bun:"email,notnull,unique"`
-	Name         string     `bun:"name"`
-	PasswordHash *string    `bun:"password_hash"` // bcrypt hash (internal IdP mode)
-	CreatedAt    time.Time  `bun:"created_at,notnull,default:current_timestamp"`
-	UpdatedAt    time.Time  `bun:"updated_at,notnull,default:current_timestamp"`
-	LastLoginAt  *time.Time `bun:"last_login_at"`
-	DisabledAt   *time.Time `bun:"disabled_at"`
+	ID                 string     `bun:"id,pk,type:uuid"`
+	Subject            *string    `bun:"subject,unique"` // Optional OIDC subject (e.g., "keycloak|123")
+	Email              string     `bun:"email,notnull,unique"`
+	Name               string     `bun:"name"`
+	PasswordHash       *string    `bun:"password_hash"`                              // bcrypt hash (internal IdP mode)
+	MustChangePassword bool       `bun:"must_change_password,notnull,default:false"` // Set by an admin password reset
+	CreatedAt          time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt          time.Time  `bun:"updated_at,notnull,default:current_timestamp"`
+	LastLoginAt        *time.Time `bun:"last_login_at"`
+	DisabledAt         *time.Time `bun:"disabled_at"`
+	TokensValidAfter   *time.Time `bun:"tokens_valid_after"` // Per-user revocation epoch, set by an admin password reset
 }
 
 // PrincipalSubject returns the stable identifier used for Casbin bindings.
@@ -54,6 +56,10 @@ type ServiceAccount struct {
 	SecretRotatedAt  time.Time `bun:"secret_rotated_at"`
 	Disabled         bool      `bun:"disabled,notnull,default:false"`
 
+	// Secret replaced by an overlapping rotation, accepted until it expires
+	PreviousSecretHash      *string    `bun:"previous_secret_hash"`
+	PreviousSecretExpiresAt *time.Time `bun:"previous_secret_expires_at"`
+
 	// Relationships
 	Creator *User `bun:"rel:belongs-to,join:created_by=id"`
 }
@@ -71,10 +77,17 @@ func (cc *CreateConstraints) Scan(value any) error {
 		*cc = make(CreateConstraints)
 		return nil
 	}
-	bytes, ok := value.([]byte)
-	if !ok {
-		return fmt.Errorf("failed to scan CreateConstraints: expected []byte, got %T", value)
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("failed to scan CreateConstraints: expected []byte or string, got %T", value)
 	}
+
 	return json.Unmarshal(bytes, cc)
 }
 
@@ -100,6 +113,10 @@ type Role struct {
 	ScopeExpr         string            `bun:"scope_expr"` // go-bexpr expression string
 	CreateConstraints CreateConstraints `bun:"create_constraints,type:jsonb"`
 	ImmutableKeys     []string          `bun:"immutable_keys,type:text[],array"`
+	MaxAssignments    *int              `bun:"max_assignments"`                      // Optional cap on principals holding the role (nil = unlimited)
+	OwnerActions      []string          `bun:"owner_actions,type:text[],array"`      // Actions allowed on states the principal created, regardless of ScopeExpr
+	Actions           []string          `bun:"actions,type:text[],array"`            // Actions as given to CreateRole/UpdateRole; nil for roles stored before they were recorded
+	DefaultLabels     bool              `bun:"default_labels,notnull,default:false"` // Fill in CreateState labels pinned by CreateConstraints instead of rejecting
 	CreatedAt         time.Time         `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt         time.Time         `bun:"updated_at,notnull,default:current_timestamp"`
 	Version           int               `bun:"version,notnull,default:1"`
@@ -133,12 +150,24 @@ type GroupRole struct {
 	RoleID     string    `bun:"role_id,notnull,type:uuid"` // FK to roles(id)
 	AssignedAt time.Time `bun:"assigned_at,notnull,default:current_timestamp"`
 	AssignedBy string    `bun:"assigned_by,notnull,type:uuid"` // FK to users(id)
+	Condition  *string   `bun:"condition"`                     // Optional bexpr over token claims; nil = unconditional
 
 	// Relationships
 	Role     *Role `bun:"rel:belongs-to,join:role_id=id"`
 	Assigner *User `bun:"rel:belongs-to,join:assigned_by=id"`
 }
 
+// UserGroup records an internal IdP user's membership of a group. Groups are
+// issued in the groups claim of internal IdP tokens and mapped to roles through
+// GroupRole, exactly as external IdP groups are.
+type UserGroup struct {
+	bun.BaseModel `bun:"table:user_groups,alias:ug"`
+
+	UserID    string    `bun:"user_id,pk,type:uuid"` // FK to users(id)
+	GroupName string    `bun:"group_name,pk"`
+	AddedAt   time.Time `bun:"added_at,notnull,default:current_timestamp"`
+}
+
 // Session tracks active sessions for human users and service accounts
 type Session struct {
 	bun.BaseModel `bun:"table:sessions,alias:sess"`
@@ -147,13 +176,16 @@ type Session struct {
 	UserID           *string   `bun:"user_id,type:uuid"`            // FK to users(id), nullable
 	ServiceAccountID *string   `bun:"service_account_id,type:uuid"` // FK to service_accounts(id), nullable
 	TokenHash        string    `bun:"token_hash,notnull,unique"`    // SHA256 hash of bearer token
+	JTI              string    `bun:"jti"`                          // Access token jti (internal IdP tokens); revoked with the session
 	IDToken          string    `bun:"id_token,type:text"`           // OIDC ID token (JWT) for human sessions
 	RefreshToken     string    `bun:"refresh_token,type:text"`      // OIDC refresh token
+	Scopes           []string  `bun:"scopes,type:text[],array"`     // Permission scopes the token was limited to; empty = full role permissions
 	ExpiresAt        time.Time `bun:"expires_at,notnull"`
 	CreatedAt        time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	LastUsedAt       time.Time `bun:"last_used_at,notnull,default:current_timestamp"`
 	UserAgent        *string   `bun:"user_agent"`           // Nullable for service account sessions
 	IPAddress        *string   `bun:"ip_address,type:inet"` // Nullable for service account sessions
+	Fingerprint      *string   `bun:"fingerprint"`          // SHA256 of the client binding header at login; nil = not bound
 	Revoked          bool      `bun:"revoked,notnull,default:false"`
 
 	// Relationships
@@ -171,3 +203,49 @@ type RevokedJTI struct {
 	RevokedAt time.Time `bun:"revoked_at,notnull,default:current_timestamp"` // When the token was revoked
 	RevokedBy *string   `bun:"revoked_by"`                                   // Optional: who revoked it (user ID)
 }
+
+// RevocationEpoch is the single-row kill switch: credentials issued before
+// Epoch (JWT iat, session created_at) are rejected.
+type RevocationEpoch struct {
+	bun.BaseModel `bun:"table:revocation_epoch,alias:repoch"`
+
+	ID        int       `bun:"id,pk"`                                        // Always 1
+	Epoch     time.Time `bun:"epoch,notnull"`                                // Credentials issued before this are invalid
+	SetBy     string    `bun:"set_by,notnull"`                               // Principal ID of the admin who set it
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"` // When the epoch was last bumped
+}
+
+// AuditLogEntry records one out-of-band IAM mutation (roles, role
+// assignments, service accounts, users, sessions) and its outcome.
+type AuditLogEntry struct {
+	bun.BaseModel `bun:"table:audit_log,alias:al"`
+
+	ID         string         `bun:"id,pk,type:uuid"`
+	OccurredAt time.Time      `bun:"occurred_at,notnull,default:current_timestamp"`
+	Actor      string         `bun:"actor,notnull"`       // Principal ID (user:..., sa:...) or "system" for CLI operations
+	Action     string         `bun:"action,notnull"`      // e.g. role.update, group_role.assign
+	TargetType string         `bun:"target_type,notnull"` // role, user, group, service_account, session
+	TargetID   string         `bun:"target_id,notnull"`   // Name or ID of the target, depending on TargetType
+	Before     map[string]any `bun:"before,type:jsonb"`   // Target state before the mutation, when relevant
+	After      map[string]any `bun:"after,type:jsonb"`    // Target state after the mutation, when relevant
+	Outcome    string         `bun:"outcome,notnull"`     // success or failure
+	Error      *string        `bun:"error"`               // Set when Outcome is failure
+}
+
+// DeviceAuthorization is an OAuth 2.0 device authorization (RFC 8628) issued by
+// the internal IdP. Persisting it lets a CLI keep polling across restarts and replicas.
+type DeviceAuthorization struct {
+	bun.BaseModel `bun:"table:device_authorizations,alias:da"`
+
+	DeviceCode   string     `bun:"device_code,pk"`           // Secret code polled by the device
+	UserCode     string     `bun:"user_code,notnull,unique"` // Short code the user enters to approve
+	ClientID     string     `bun:"client_id,notnull"`        // Requesting OAuth client
+	Scopes       string     `bun:"scopes,notnull"`           // Space-separated, as in the OAuth scope parameter
+	ExpiresAt    time.Time  `bun:"expires_at,notnull"`       // Polls after this return expired_token
+	Approved     bool       `bun:"approved,notnull,default:false"`
+	Denied       bool       `bun:"denied,notnull,default:false"`
+	Subject      string     `bun:"subject,notnull"` // Approving user's subject, set with Approved
+	AuthTime     *time.Time `bun:"auth_time"`       // When the user approved or denied
+	LastPolledAt *time.Time `bun:"last_polled_at"`  // For slow_down enforcement
+	CreatedAt    time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+}
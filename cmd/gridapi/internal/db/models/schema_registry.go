@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// SchemaRegistryEntry is a JSON Schema shared across state outputs under a
+// named version such as "aws/vpc_id@v1". Outputs reference it by Key instead
+// of carrying their own copy of the schema.
+type SchemaRegistryEntry struct {
+	bun.BaseModel `bun:"table:schema_registry,alias:sr"`
+
+	Key         string    `bun:"key,pk,type:text"`
+	SchemaJSON  string    `bun:"schema_json,type:text,notnull"`
+	Description string    `bun:"description,type:text,notnull,default:''"`
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
@@ -61,6 +61,10 @@ type State struct {
 	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt    time.Time `bun:"updated_at,notnull,default:current_timestamp"`
 
+	// CreatedBy is the principal ID (e.g. user:alice@example.com) that created the
+	// state. Empty for states created with auth disabled or before it was recorded.
+	CreatedBy string `bun:"created_by,nullzero"`
+
 	// Labels stores typed label key/value pairs
 	Labels LabelMap `bun:"labels,type:jsonb,notnull,default:'{}'"`
 
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// StateIdempotencyKey records a CreateState call made with an idempotency key,
+// so a retried call returns the state created by the first call instead of
+// creating another one. Keys stop matching once ExpiresAt has passed.
+type StateIdempotencyKey struct {
+	bun.BaseModel `bun:"table:state_idempotency_keys,alias:sik"`
+
+	Key string `bun:"key,pk,type:text"`
+
+	// RequestHash fingerprints the logic_id and labels of the original request.
+	// Reusing the key with a different payload is rejected.
+	RequestHash string `bun:"request_hash,notnull"`
+
+	StateGUID string    `bun:"state_guid,type:uuid,notnull"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
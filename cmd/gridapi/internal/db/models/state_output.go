@@ -15,7 +15,7 @@ type StateOutput struct {
 
 	StateGUID   string `bun:"state_guid,pk,type:uuid,notnull"`
 	OutputKey   string `bun:"output_key,pk,type:text,notnull"`
-	Sensitive   bool   `bun:"sensitive,notnull,default:false"`
+	Sensitive   bool   `bun:"sensitive,notnull"` // No default tag: bun would omit false values, and with them the column, from multi-row inserts
 	StateSerial int64  `bun:"state_serial,notnull"`
 
 	// SchemaJSON stores an optional JSON Schema definition for this output.
@@ -28,6 +28,15 @@ type StateOutput struct {
 	// NULL when no schema exists.
 	SchemaSource *string `bun:"schema_source,type:text,nullzero"`
 
+	// SchemaRef is the schema registry key (e.g. "aws/vpc_id@v1") the schema was
+	// set from. SchemaJSON holds a copy of the registered schema and is rewritten
+	// when the registry entry changes. NULL for inline and inferred schemas.
+	SchemaRef *string `bun:"schema_ref,type:text,nullzero"`
+
+	// SchemaVersion is the OutputSchemaVersion holding the current schema.
+	// NULL when no schema exists.
+	SchemaVersion *int `bun:"schema_version,nullzero"`
+
 	// ValidationStatus indicates the result of JSON Schema validation.
 	// Values: "valid" (passed validation), "invalid" (failed validation), "error" (validation error)
 	// NULL when no schema exists or validation hasn't run.
@@ -41,9 +50,30 @@ type StateOutput struct {
 	// NULL when validation hasn't run.
 	ValidatedAt *time.Time `bun:"validated_at,type:timestamptz,nullzero"`
 
+	// ValidatedSchemaVersion is the schema version the last validation ran against.
+	// NULL when validation hasn't run.
+	ValidatedSchemaVersion *int `bun:"validated_schema_version,nullzero"`
+
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
 
 	// Relationships for eager loading (populated only when using Relation())
 	State *State `bun:"rel:belongs-to,join:state_guid=guid"`
 }
+
+// OutputSchemaVersion is one schema an output has had. Every schema write
+// appends a version, so validation results can be traced to the schema they
+// were produced under.
+type OutputSchemaVersion struct {
+	bun.BaseModel `bun:"table:output_schema_versions,alias:osv"`
+
+	StateGUID string `bun:"state_guid,pk,type:uuid,notnull"`
+	OutputKey string `bun:"output_key,pk,type:text,notnull"`
+	Version   int    `bun:"version,pk,notnull"`
+
+	SchemaJSON   string  `bun:"schema_json,type:text,notnull"`
+	SchemaSource string  `bun:"schema_source,type:text,notnull"`
+	SchemaRef    *string `bun:"schema_ref,type:text,nullzero"`
+
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
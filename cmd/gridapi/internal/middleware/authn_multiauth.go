@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
@@ -42,6 +44,10 @@ func MultiAuthMiddleware(iamService iam.Service) func(http.Handler) http.Handler
 			if err != nil {
 				// Authentication failed (invalid credentials)
 				log.Printf("authentication failed for %s %s: %v", r.Method, r.URL.Path, err)
+				if errors.Is(err, iam.ErrSessionRecentlyExpired) {
+					writeSessionExpired(w)
+					return
+				}
 				http.Error(w, "authentication failed", http.StatusUnauthorized)
 				return
 			}
@@ -55,8 +61,10 @@ func MultiAuthMiddleware(iamService iam.Service) func(http.Handler) http.Handler
 					InternalID:  principal.InternalID,
 					Email:       principal.Email,
 					Name:        principal.Name,
+					DisplayName: principal.DisplayName,
 					SessionID:   principal.SessionID,
 					Roles:       principal.Roles,
+					TokenScopes: principal.TokenScopes,
 					Type:        auth.PrincipalType(principal.Type),
 				}
 
@@ -71,3 +79,14 @@ func MultiAuthMiddleware(iamService iam.Service) func(http.Handler) http.Handler
 		})
 	}
 }
+
+// writeSessionExpired responds 401 with a machine-readable body so clients can
+// prompt the user to log in again rather than treating them as anonymous.
+func writeSessionExpired(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":   "session_expired",
+		"message": "your session expired, please log in again",
+	})
+}
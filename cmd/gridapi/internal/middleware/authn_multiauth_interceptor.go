@@ -51,20 +51,7 @@ func NewMultiAuthInterceptor(iamService iam.Service) connect.UnaryInterceptorFun
 
 			// Step 3: Set Principal and Groups in context
 			if principal != nil {
-				// Convert iam.Principal to auth.AuthenticatedPrincipal for legacy compatibility
-				legacyPrincipal := auth.AuthenticatedPrincipal{
-					Subject:     principal.Subject,
-					PrincipalID: principal.PrincipalID,
-					InternalID:  principal.InternalID,
-					Email:       principal.Email,
-					Name:        principal.Name,
-					SessionID:   principal.SessionID,
-					Roles:       principal.Roles,
-					Type:        auth.PrincipalType(principal.Type),
-				}
-
-				ctx = auth.SetUserContext(ctx, legacyPrincipal)
-				ctx = auth.SetGroupsContext(ctx, principal.Groups)
+				ctx = withPrincipal(ctx, principal)
 			}
 
 			// Step 4: Continue to next handler/interceptor
@@ -74,3 +61,21 @@ func NewMultiAuthInterceptor(iamService iam.Service) connect.UnaryInterceptorFun
 		})
 	})
 }
+
+// withPrincipal sets the principal and its groups in the context, converting
+// iam.Principal to auth.AuthenticatedPrincipal for legacy compatibility.
+func withPrincipal(ctx context.Context, principal *iam.Principal) context.Context {
+	ctx = auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{
+		Subject:     principal.Subject,
+		PrincipalID: principal.PrincipalID,
+		InternalID:  principal.InternalID,
+		Email:       principal.Email,
+		Name:        principal.Name,
+		DisplayName: principal.DisplayName,
+		SessionID:   principal.SessionID,
+		Roles:       principal.Roles,
+		TokenScopes: principal.TokenScopes,
+		Type:        auth.PrincipalType(principal.Type),
+	})
+	return auth.SetGroupsContext(ctx, principal.Groups)
+}
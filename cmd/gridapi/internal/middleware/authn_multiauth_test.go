@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+)
+
+// failingAuthIAMService fails every authentication with a fixed error.
+// Only AuthenticateRequest is implemented; other methods panic via the nil embed.
+type failingAuthIAMService struct {
+	iam.Service
+	err error
+}
+
+func (s *failingAuthIAMService) AuthenticateRequest(ctx context.Context, req iam.AuthRequest) (*iam.Principal, error) {
+	return nil, s.err
+}
+
+func TestMultiAuthMiddleware_RecentlyExpiredSession(t *testing.T) {
+	t.Parallel()
+
+	reached := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { reached = true })
+	handler := MultiAuthMiddleware(&failingAuthIAMService{err: iam.ErrSessionRecentlyExpired})(next)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/whoami", nil))
+
+	require.False(t, reached)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "session_expired", body["error"])
+}
@@ -63,7 +63,7 @@ func NewAuthzMiddleware(deps AuthzDependencies) (func(http.Handler) http.Handler
 				return
 			}
 
-			labels, lockInfo, err := loadStateLabels(r.Context(), deps.StateService, guid)
+			labels, state, err := loadStateLabels(r.Context(), deps.StateService, guid)
 			if err != nil {
 				if errors.Is(err, errStateNotFound) {
 					http.NotFound(w, r)
@@ -73,26 +73,21 @@ func NewAuthzMiddleware(deps AuthzDependencies) (func(http.Handler) http.Handler
 				return
 			}
 
-			if bypassWriteForLockHolder(tfstateAction, principal, lockInfo) {
+			if bypassWriteForLockHolder(tfstateAction, principal, state.LockInfo) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
 			// Phase 4: Use IAM service for read-only authorization (no Casbin mutation)
-			// Convert legacy auth.AuthenticatedPrincipal to iam.Principal for authorization
-			iamPrincipal := &iam.Principal{
-				Roles: principal.Roles,
-			}
-
-			allowed, err := deps.IAMService.Authorize(r.Context(), iamPrincipal, auth.ObjectTypeState, tfstateAction, labels)
+			allowed, err := AuthorizeWithOwner(r.Context(), deps.IAMService, principal, auth.ObjectTypeState, tfstateAction, labels, state.CreatedBy)
 			if err != nil {
 				log.Printf("authorization error for %s: %v", principal.PrincipalID, err)
 				http.Error(w, "authorization error", http.StatusInternalServerError)
 				return
 			}
 			if !allowed {
-				log.Printf("authorization denied: principal %s (roles=%v) for %s on state (labels=%v)",
-					principal.PrincipalID, principal.Roles, tfstateAction, labels)
+				log.Printf("authorization denied: principal %s (%s, roles=%v) for %s on state (labels=%v)",
+					principal.PrincipalID, principal.DisplayName, principal.Roles, tfstateAction, labels)
 				http.Error(w, "forbidden", http.StatusForbidden)
 				return
 			}
@@ -147,7 +142,7 @@ func classifyTerraformRequest(r *http.Request) (action string, guid string, matc
 
 var errStateNotFound = errors.New("state not found")
 
-func loadStateLabels(ctx context.Context, service *statepkg.Service, guid string) (map[string]any, *models.LockInfo, error) {
+func loadStateLabels(ctx context.Context, service *statepkg.Service, guid string) (map[string]any, *models.State, error) {
 	state, err := service.GetStateByGUID(ctx, guid)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -159,7 +154,32 @@ func loadStateLabels(ctx context.Context, service *statepkg.Service, guid string
 	labels := make(map[string]any, len(state.Labels))
 	maps.Copy(labels, state.Labels)
 
-	return labels, state.LockInfo, nil
+	return labels, state, nil
+}
+
+// OwnerAuthorizer is the part of iam.Service AuthorizeWithOwner needs.
+type OwnerAuthorizer interface {
+	AuthorizeDecision(ctx context.Context, principal *iam.Principal, obj, act string, labels map[string]interface{}) (iam.Decision, error)
+	AuthorizeOwner(ctx context.Context, principal *iam.Principal, act string) (bool, error)
+}
+
+// AuthorizeWithOwner checks action on obj for the principal. If the roles'
+// label scopes deny it, the principal is still allowed when it created the
+// state (owner) and one of its roles opts the action into owner override.
+// An explicit deny rule is final and is never overridden by ownership.
+func AuthorizeWithOwner(ctx context.Context, iamService OwnerAuthorizer, principal auth.AuthenticatedPrincipal, obj, action string, labels map[string]any, owner string) (bool, error) {
+	iamPrincipal := &iam.Principal{Roles: principal.Roles, TokenScopes: principal.TokenScopes}
+	decision, err := iamService.AuthorizeDecision(ctx, iamPrincipal, obj, action, labels)
+	if err != nil || decision.Allowed {
+		return decision.Allowed, err
+	}
+	if decision.ExplicitDeny {
+		return false, nil
+	}
+	if obj != auth.ObjectTypeState || owner == "" || owner != principal.PrincipalID {
+		return false, nil
+	}
+	return iamService.AuthorizeOwner(ctx, iamPrincipal, action)
 }
 
 func bypassWriteForLockHolder(action string, principal auth.AuthenticatedPrincipal, lockInfo *models.LockInfo) bool {
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"maps"
@@ -28,15 +29,17 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 			}
 
 			// Phase 4: Convert to iam.Principal for authorization
-			// Only roles are needed for authorization checks
+			// Only roles and token scopes are needed for authorization checks
 			iamPrincipal := &iam.Principal{
-				Roles: principal.Roles,
+				Roles:       principal.Roles,
+				TokenScopes: principal.TokenScopes,
 			}
 
 			procedure := req.Spec().Procedure
 			obj := "" // Can be objType (like "state") or specific resource ID (for ownership checks)
 			action := ""
 			var labels map[string]any
+			var stateOwner string // Creator of the state being accessed, for owner override
 
 			//nolint:gocritic
 			switch procedure {
@@ -44,16 +47,42 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 			case statev1connect.StateServiceListStatesProcedure:
 				obj = auth.ObjectTypeState
 				action = auth.StateList
-			case statev1connect.StateServiceListAllEdgesProcedure:
+			case statev1connect.StateServiceListAllEdgesProcedure, statev1connect.StateServiceSearchByOutputProcedure:
+				// Edges are filtered by role scopes in the handler
 				obj = auth.ObjectTypeState
 				action = auth.DependencyListAll
+			case statev1connect.StateServiceListStateOutputsBatchProcedure:
+				// The handler authorizes each requested state against its own
+				// labels and drops the states the caller may not read
+				return next(ctx, req)
 			case statev1connect.StateServiceGetLabelPolicyProcedure:
 				obj = auth.ObjectTypePolicy
 				action = auth.PolicyRead
 			case statev1connect.StateServiceSetLabelPolicyProcedure:
 				obj = auth.ObjectTypePolicy
 				action = auth.PolicyWrite
-			case statev1connect.StateServiceCreateServiceAccountProcedure, statev1connect.StateServiceListServiceAccountsProcedure, statev1connect.StateServiceRevokeServiceAccountProcedure, statev1connect.StateServiceRotateServiceAccountProcedure:
+			// Shared output schemas are global like the label policy
+			case statev1connect.StateServiceGetRegisteredSchemaProcedure, statev1connect.StateServiceListRegisteredSchemasProcedure:
+				obj = auth.ObjectTypePolicy
+				action = auth.PolicyRead
+			case statev1connect.StateServiceCreateRegisteredSchemaProcedure, statev1connect.StateServiceUpdateRegisteredSchemaProcedure, statev1connect.StateServiceDeleteRegisteredSchemaProcedure:
+				obj = auth.ObjectTypePolicy
+				action = auth.PolicyWrite
+			case statev1connect.StateServiceCreateServiceAccountProcedure:
+				obj = auth.ObjectTypeAdmin
+				action = auth.AdminServiceAccountManage
+				// Roles granted at creation need the same permission as AssignRole
+				if len(req.Any().(*statev1.CreateServiceAccountRequest).GetRoleNames()) > 0 && deps.IAMService != nil {
+					allowed, err := deps.IAMService.Authorize(ctx, iamPrincipal, auth.ObjectTypeAdmin, auth.AdminUserAssign, nil)
+					if err != nil {
+						log.Printf("error enforcing role assignment auth for %s: %v", principal.PrincipalID, err)
+						return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("authorization error: %w", err))
+					}
+					if !allowed {
+						return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied for action '%s' on object '%s': required to assign role_names", auth.AdminUserAssign, auth.ObjectTypeAdmin))
+					}
+				}
+			case statev1connect.StateServiceListServiceAccountsProcedure, statev1connect.StateServiceRevokeServiceAccountProcedure, statev1connect.StateServiceRotateServiceAccountProcedure:
 				obj = auth.ObjectTypeAdmin
 				action = auth.AdminServiceAccountManage
 			case statev1connect.StateServiceCreateRoleProcedure, statev1connect.StateServiceListRolesProcedure, statev1connect.StateServiceUpdateRoleProcedure, statev1connect.StateServiceDeleteRoleProcedure:
@@ -106,6 +135,18 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 			case statev1connect.StateServiceRevokeSessionProcedure:
 				obj = auth.ObjectTypeAdmin
 				action = auth.AdminSessionRevoke
+			case statev1connect.StateServiceRevokeSessionsProcedure:
+				obj = auth.ObjectTypeAdmin
+				action = auth.AdminSessionRevoke
+			case statev1connect.StateServiceListAllSessionsProcedure:
+				obj = auth.ObjectTypeAdmin
+				action = auth.AdminSessionList
+			case statev1connect.StateServiceIntrospectTokenProcedure:
+				obj = auth.ObjectTypeAdmin
+				action = auth.AdminTokenIntrospect
+			case statev1connect.StateServicePreviewAuthorizationProcedure:
+				obj = auth.ObjectTypeAdmin
+				action = auth.AdminAuthzPreview
 
 			// --- Dynamic Permission Checks (resource-specific data required) ---
 			case statev1connect.StateServiceCreateStateProcedure:
@@ -149,6 +190,7 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 			case statev1connect.StateServiceGetStateInfoProcedure:
 				obj = auth.ObjectTypeState
 				action = auth.StateRead
@@ -177,6 +219,39 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+			case statev1connect.StateServiceTransferStateOwnershipProcedure:
+				obj = auth.ObjectTypeState
+				action = auth.StateTransferOwnership
+				var stateID string
+				r := req.Any().(*statev1.TransferStateOwnershipRequest)
+
+				// Handle oneof state (logic_id or guid)
+				switch state := r.State.(type) {
+				case *statev1.TransferStateOwnershipRequest_LogicId:
+					guid, _, err := deps.StateService.GetStateConfig(ctx, state.LogicId)
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				case *statev1.TransferStateOwnershipRequest_Guid:
+					stateID = state.Guid
+				default:
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (logic_id or guid)"))
+				}
+
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				// The current owner may always hand their state over; everyone
+				// else needs state:transfer-ownership within their label scope.
+				if state.CreatedBy != "" && state.CreatedBy == principal.PrincipalID {
+					return next(ctx, req)
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+
 			case statev1connect.StateServiceListStateOutputsProcedure:
 				obj = auth.ObjectTypeState
 				action = auth.StateOutputList
@@ -205,6 +280,34 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+
+			case statev1connect.StateServiceGetStateOutputValuesProcedure:
+				// Reading sensitive values needs state-output:read-sensitive, checked by the handler
+				obj = auth.ObjectTypeState
+				action = auth.StateOutputRead
+				ref := req.Any().(*statev1.GetStateOutputValuesRequest).GetState()
+
+				stateID := ref.GetGuid()
+				if stateID == "" {
+					if ref.GetLogicId() == "" {
+						return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (logic_id or guid)"))
+					}
+					guid, _, err := deps.StateService.GetStateConfig(ctx, ref.GetLogicId())
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				}
+
+				// Load state to get labels for authorization
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 
 			// --- Dependency Authorization (two-check model) ---
 			case statev1connect.StateServiceAddDependencyProcedure:
@@ -252,7 +355,7 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				maps.Copy(fromLabels, fromState.Labels)
 				log.Printf("enforcing principal %s for action '%s' on object '%s' with labels %v", principal.PrincipalID, auth.StateOutputRead, auth.ObjectTypeState, fromLabels)
 				// Phase 4: Use IAM service for read-only authorization
-				allowed, err := deps.IAMService.Authorize(ctx, iamPrincipal, auth.ObjectTypeState, auth.StateOutputRead, fromLabels)
+				allowed, err := AuthorizeWithOwner(ctx, deps.IAMService, principal, auth.ObjectTypeState, auth.StateOutputRead, fromLabels, fromState.CreatedBy)
 				if err != nil {
 					log.Printf("error enforcing from state auth for %s: %v", principal.PrincipalID, err)
 					return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("authorization error: %w", err))
@@ -271,7 +374,7 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				maps.Copy(toLabels, toState.Labels)
 				log.Printf("enforcing principal %s for action '%s' on object '%s' with labels %v", principal.PrincipalID, auth.DependencyCreate, auth.ObjectTypeState, toLabels)
 				// Phase 4: Use IAM service for read-only authorization
-				allowed, err = deps.IAMService.Authorize(ctx, iamPrincipal, auth.ObjectTypeState, auth.DependencyCreate, toLabels)
+				allowed, err = AuthorizeWithOwner(ctx, deps.IAMService, principal, auth.ObjectTypeState, auth.DependencyCreate, toLabels, toState.CreatedBy)
 				if err != nil {
 					log.Printf("error enforcing to state auth for %s: %v", principal.PrincipalID, err)
 					return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("authorization error: %w", err))
@@ -309,6 +412,7 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 
 			case statev1connect.StateServiceListDependentsProcedure:
 				obj = auth.ObjectTypeState
@@ -336,6 +440,7 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 
 			case statev1connect.StateServiceGetDependencyGraphProcedure:
 				obj = auth.ObjectTypeState
@@ -363,6 +468,68 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+
+			case statev1connect.StateServiceGetTopologicalOrderProcedure, statev1connect.StateServiceGetStateStatusProcedure:
+				// The handler checks the target against the caller's role scopes
+				// and omits neighbouring states outside them.
+				obj = auth.ObjectTypeState
+				action = auth.DependencyList
+				var logicID, stateID string
+				switch r := req.Any().(type) {
+				case *statev1.GetTopologicalOrderRequest:
+					logicID, stateID = r.GetLogicId(), r.GetGuid()
+				case *statev1.GetStateStatusRequest:
+					logicID, stateID = r.GetLogicId(), r.GetGuid()
+				}
+				switch {
+				case logicID != "":
+					guid, _, err := deps.StateService.GetStateConfig(ctx, logicID)
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				case stateID == "":
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required"))
+				}
+
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+
+			case statev1connect.StateServiceRecomputeDependencyStatusProcedure:
+				// Recompute rewrites edge rows, so it needs the same permission as
+				// creating them, on the producer; the handler checks both endpoints
+				// of each edge against the caller's role scopes.
+				obj = auth.ObjectTypeState
+				action = auth.DependencyCreate
+				var stateID string
+				r := req.Any().(*statev1.RecomputeDependencyStatusRequest)
+
+				switch state := r.State.(type) {
+				case *statev1.RecomputeDependencyStatusRequest_LogicId:
+					guid, _, err := deps.StateService.GetStateConfig(ctx, state.LogicId)
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				case *statev1.RecomputeDependencyStatusRequest_Guid:
+					stateID = state.Guid
+				default:
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required"))
+				}
+
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 
 			case statev1connect.StateServiceRemoveDependencyProcedure:
 				obj = auth.ObjectTypeState
@@ -383,6 +550,7 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 
 			// --- Output Schema Management ---
 			case statev1connect.StateServiceSetOutputSchemaProcedure:
@@ -413,6 +581,69 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+
+			case statev1connect.StateServiceSetOutputSchemasProcedure:
+				obj = auth.ObjectTypeState
+				action = auth.StateOutputSchemaWrite
+				var stateID string
+				r := req.Any().(*statev1.SetOutputSchemasRequest)
+
+				// Handle oneof state (logic_id or guid)
+				switch state := r.State.(type) {
+				case *statev1.SetOutputSchemasRequest_StateLogicId:
+					// Resolve logic_id to GUID
+					guid, _, err := deps.StateService.GetStateConfig(ctx, state.StateLogicId)
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				case *statev1.SetOutputSchemasRequest_StateGuid:
+					stateID = state.StateGuid
+				default:
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+				}
+
+				// Load state to get labels for authorization
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+
+			case statev1connect.StateServiceRevalidateOutputsForSchemaProcedure:
+				// Re-validation rewrites statuses for every output sharing the schema,
+				// so it requires schema-write on the output that selects the schema
+				obj = auth.ObjectTypeState
+				action = auth.StateOutputSchemaWrite
+				var stateID string
+				r := req.Any().(*statev1.RevalidateOutputsForSchemaRequest)
+
+				// Handle oneof state (logic_id or guid)
+				switch state := r.State.(type) {
+				case *statev1.RevalidateOutputsForSchemaRequest_StateLogicId:
+					// Resolve logic_id to GUID
+					guid, _, err := deps.StateService.GetStateConfig(ctx, state.StateLogicId)
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				case *statev1.RevalidateOutputsForSchemaRequest_StateGuid:
+					stateID = state.StateGuid
+				default:
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+				}
+
+				// Load state to get labels for authorization
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 
 			case statev1connect.StateServiceGetOutputSchemaProcedure:
 				obj = auth.ObjectTypeState
@@ -442,6 +673,66 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				}
 				labels = make(map[string]any, len(state.Labels))
 				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+
+			case statev1connect.StateServiceListOutputSchemaHistoryProcedure:
+				obj = auth.ObjectTypeState
+				action = auth.StateOutputSchemaRead
+				var stateID string
+				r := req.Any().(*statev1.ListOutputSchemaHistoryRequest)
+
+				// Handle oneof state (logic_id or guid)
+				switch state := r.State.(type) {
+				case *statev1.ListOutputSchemaHistoryRequest_StateLogicId:
+					// Resolve logic_id to GUID
+					guid, _, err := deps.StateService.GetStateConfig(ctx, state.StateLogicId)
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				case *statev1.ListOutputSchemaHistoryRequest_StateGuid:
+					stateID = state.StateGuid
+				default:
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+				}
+
+				// Load state to get labels for authorization
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
+			case statev1connect.StateServiceGetStateValidationSummaryProcedure:
+				obj = auth.ObjectTypeState
+				action = auth.StateOutputSchemaRead
+				var stateID string
+				r := req.Any().(*statev1.GetStateValidationSummaryRequest)
+
+				// Handle oneof state (logic_id or guid)
+				switch state := r.State.(type) {
+				case *statev1.GetStateValidationSummaryRequest_StateLogicId:
+					// Resolve logic_id to GUID
+					guid, _, err := deps.StateService.GetStateConfig(ctx, state.StateLogicId)
+					if err != nil {
+						return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found: %w", err))
+					}
+					stateID = guid
+				case *statev1.GetStateValidationSummaryRequest_StateGuid:
+					stateID = state.StateGuid
+				default:
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+				}
+
+				// Load state to get labels for authorization
+				state, err := deps.StateService.GetStateByGUID(ctx, stateID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("state not found for authz: %w", err))
+				}
+				labels = make(map[string]any, len(state.Labels))
+				maps.Copy(labels, state.Labels)
+				stateOwner = state.CreatedBy
 
 			default:
 				// Deny any RPC that is not explicitly listed.
@@ -462,20 +753,111 @@ func NewAuthzInterceptor(deps AuthzDependencies) connect.UnaryInterceptorFunc {
 				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not initialized"))
 			}
 
-			allowed, err := deps.IAMService.Authorize(ctx, iamPrincipal, obj, action, labels)
+			if r, ok := req.Any().(*statev1.CreateStateRequest); ok {
+				if err := applyDefaultCreateLabels(ctx, deps.IAMService, iamPrincipal, r, labels); err != nil {
+					return nil, err
+				}
+			}
+
+			allowed, err := AuthorizeWithOwner(ctx, deps.IAMService, principal, obj, action, labels, stateOwner)
 			if err != nil {
 				log.Printf("error enforce query for %s: %v", principal.PrincipalID, err)
 				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("authorization enforcement error: %w", err))
 			}
 
 			if !allowed {
-				log.Printf("authorization denied: principal %s (roles=%v) for %s on %s (labels=%v)",
-					principal.PrincipalID, principal.Roles, action, obj, labels)
+				log.Printf("authorization denied: principal %s (%s, roles=%v) for %s on %s (labels=%v)",
+					principal.PrincipalID, principal.DisplayName, principal.Roles, action, obj, labels)
 				return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied for action '%s' on object '%s'", action, obj))
 			}
 
+			// Role label constraints are checked after Casbin so a denial can name
+			// the offending label key instead of returning a generic forbidden.
+			if err := enforceLabelConstraints(ctx, deps.IAMService, iamPrincipal, req.Any(), labels); err != nil {
+				log.Printf("label constraint denied: principal %s (%s, roles=%v) for %s on %s: %v",
+					principal.PrincipalID, principal.DisplayName, principal.Roles, action, obj, err)
+				return nil, err
+			}
+
 			// If authorized, proceed with the request.
 			return next(ctx, req)
 		})
 	})
 }
+
+// applyDefaultCreateLabels adds the labels a DefaultLabels role supplies for
+// a CreateState to both the request, so the handler persists them, and the
+// labels being authorized.
+func applyDefaultCreateLabels(ctx context.Context, iamService iam.Service, principal *iam.Principal, r *statev1.CreateStateRequest, labels map[string]any) error {
+	defaults, err := iamService.DefaultCreateLabels(ctx, principal, labels)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("default label resolution error: %w", err))
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	if r.Labels == nil {
+		r.Labels = make(map[string]string, len(defaults))
+	}
+	for key, value := range defaults {
+		r.Labels[key] = value
+		labels[key] = value
+	}
+	log.Printf("defaulted labels %v from role create constraints for state %s", defaults, r.LogicId)
+	return nil
+}
+
+// enforceLabelConstraints applies role CreateConstraints to CreateState and
+// role ImmutableKeys to UpdateStateLabels. labels holds the requested labels
+// for CreateState and the state's current labels for UpdateStateLabels.
+// Violations are returned as PermissionDenied with a LabelConstraintViolation detail.
+func enforceLabelConstraints(ctx context.Context, iamService iam.Service, principal *iam.Principal, msg any, labels map[string]any) error {
+	var err error
+	switch r := msg.(type) {
+	case *statev1.CreateStateRequest:
+		err = iamService.CheckCreateConstraints(ctx, principal, labels)
+	case *statev1.UpdateStateLabelsRequest:
+		adds := make(map[string]any, len(r.Adds))
+		for k, v := range r.Adds {
+			adds[k] = labelValueToAny(v)
+		}
+		err = iamService.CheckImmutableKeys(ctx, principal, labels, adds, r.Removals)
+	default:
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+
+	var violation *iam.LabelConstraintError
+	if !errors.As(err, &violation) {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("label constraint check error: %w", err))
+	}
+
+	connectErr := connect.NewError(connect.CodePermissionDenied, violation)
+	detail, detailErr := connect.NewErrorDetail(&statev1.LabelConstraintViolation{
+		Role:       violation.Role,
+		LabelKey:   violation.Key,
+		Constraint: violation.Constraint,
+		Message:    violation.Message,
+	})
+	if detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// labelValueToAny unwraps a typed proto label value.
+func labelValueToAny(v *statev1.LabelValue) any {
+	switch val := v.GetValue().(type) {
+	case *statev1.LabelValue_StringValue:
+		return val.StringValue
+	case *statev1.LabelValue_NumberValue:
+		return val.NumberValue
+	case *statev1.LabelValue_BoolValue:
+		return val.BoolValue
+	default:
+		return nil
+	}
+}
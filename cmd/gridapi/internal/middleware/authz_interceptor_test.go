@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+	statepkg "github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+)
+
+// immutableEnvIAMService treats "env" as immutable for every principal.
+// Only CheckImmutableKeys is implemented; other methods panic via the nil embed.
+type immutableEnvIAMService struct {
+	iam.Service
+}
+
+func (s *immutableEnvIAMService) CheckImmutableKeys(ctx context.Context, principal *iam.Principal, current, adds map[string]any, removals []string) error {
+	if v, ok := adds["env"]; ok && fmt.Sprint(v) != fmt.Sprint(current["env"]) {
+		return &iam.LabelConstraintError{Role: "ops", Key: "env", Constraint: iam.ConstraintImmutable, Message: "label \"env\" cannot be changed once set"}
+	}
+	return nil
+}
+
+func stringLabel(v string) *statev1.LabelValue {
+	return &statev1.LabelValue{Value: &statev1.LabelValue_StringValue{StringValue: v}}
+}
+
+func TestEnforceLabelConstraints_UpdateStateLabels(t *testing.T) {
+	t.Parallel()
+
+	svc := &immutableEnvIAMService{}
+	principal := &iam.Principal{Roles: []string{"ops"}}
+	current := map[string]any{"env": "dev", "team": "core"}
+
+	t.Run("changing team succeeds", func(t *testing.T) {
+		req := &statev1.UpdateStateLabelsRequest{
+			StateId: "state-1",
+			Adds:    map[string]*statev1.LabelValue{"team": stringLabel("platform")},
+		}
+		require.NoError(t, enforceLabelConstraints(context.Background(), svc, principal, req, current))
+	})
+
+	t.Run("changing env is denied with detail", func(t *testing.T) {
+		req := &statev1.UpdateStateLabelsRequest{
+			StateId: "state-1",
+			Adds:    map[string]*statev1.LabelValue{"env": stringLabel("prod")},
+		}
+		err := enforceLabelConstraints(context.Background(), svc, principal, req, current)
+
+		var connectErr *connect.Error
+		require.True(t, errors.As(err, &connectErr))
+		require.Equal(t, connect.CodePermissionDenied, connectErr.Code())
+		require.Contains(t, connectErr.Message(), `"env"`)
+
+		require.Len(t, connectErr.Details(), 1)
+		detail, err := connectErr.Details()[0].Value()
+		require.NoError(t, err)
+		violation, ok := detail.(*statev1.LabelConstraintViolation)
+		require.True(t, ok)
+		require.Equal(t, "env", violation.GetLabelKey())
+		require.Equal(t, iam.ConstraintImmutable, violation.GetConstraint())
+	})
+
+	t.Run("other procedures are not checked", func(t *testing.T) {
+		require.NoError(t, enforceLabelConstraints(context.Background(), svc, principal, &statev1.ListStatesRequest{}, nil))
+	})
+}
+
+// outOfScopeIAMService denies every action by label scope, or by a deny rule
+// when explicitDeny is set; ownerOverride controls whether the principal's
+// roles opt actions into owner override.
+type outOfScopeIAMService struct {
+	iam.Service
+	explicitDeny  bool
+	ownerOverride bool
+	ownerChecks   int
+}
+
+func (s *outOfScopeIAMService) AuthorizeDecision(ctx context.Context, principal *iam.Principal, obj, act string, labels map[string]any) (iam.Decision, error) {
+	return iam.Decision{ExplicitDeny: s.explicitDeny}, nil
+}
+
+func (s *outOfScopeIAMService) AuthorizeOwner(ctx context.Context, principal *iam.Principal, act string) (bool, error) {
+	s.ownerChecks++
+	return s.ownerOverride, nil
+}
+
+func TestAuthorizeWithOwner(t *testing.T) {
+	t.Parallel()
+
+	creator := auth.AuthenticatedPrincipal{PrincipalID: "user:alice@example.com", Roles: []string{"dev"}}
+	other := auth.AuthenticatedPrincipal{PrincipalID: "user:bob@example.com", Roles: []string{"dev"}}
+	labels := map[string]any{"env": "prod"}
+
+	for _, tc := range []struct {
+		name          string
+		principal     auth.AuthenticatedPrincipal
+		obj           string
+		ownerOverride bool
+		allowed       bool
+	}{
+		{name: "creator with owner override", principal: creator, obj: auth.ObjectTypeState, ownerOverride: true, allowed: true},
+		{name: "creator without owner override", principal: creator, obj: auth.ObjectTypeState, ownerOverride: false, allowed: false},
+		{name: "non-creator", principal: other, obj: auth.ObjectTypeState, ownerOverride: true, allowed: false},
+		{name: "non-state object", principal: creator, obj: auth.ObjectTypePolicy, ownerOverride: true, allowed: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &outOfScopeIAMService{ownerOverride: tc.ownerOverride}
+			allowed, err := AuthorizeWithOwner(context.Background(), svc, tc.principal, tc.obj, auth.StateUpdateLabels, labels, creator.PrincipalID)
+			require.NoError(t, err)
+			require.Equal(t, tc.allowed, allowed)
+		})
+	}
+
+	t.Run("explicit deny is not overridden by ownership", func(t *testing.T) {
+		svc := &outOfScopeIAMService{explicitDeny: true, ownerOverride: true}
+		allowed, err := AuthorizeWithOwner(context.Background(), svc, creator, auth.ObjectTypeState, auth.TfstateWrite, labels, creator.PrincipalID)
+		require.NoError(t, err)
+		require.False(t, allowed)
+		require.Zero(t, svc.ownerChecks)
+	})
+
+	t.Run("states without a recorded creator", func(t *testing.T) {
+		svc := &outOfScopeIAMService{ownerOverride: true}
+		allowed, err := AuthorizeWithOwner(context.Background(), svc, creator, auth.ObjectTypeState, auth.TfstateWrite, labels, "")
+		require.NoError(t, err)
+		require.False(t, allowed)
+		require.Zero(t, svc.ownerChecks)
+	})
+}
+
+func TestTransferOwnership_MovesOwnerOverride(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	alice := auth.AuthenticatedPrincipal{PrincipalID: "user:alice@example.com", Roles: []string{"dev"}}
+	bob := auth.AuthenticatedPrincipal{PrincipalID: "user:bob@example.com", Roles: []string{"dev"}}
+	db := dbtest.NewSQLite(t)
+
+	repo := repository.NewBunStateRepository(db)
+	state := &models.State{
+		GUID:      "01890000-0000-7000-8000-000000000001",
+		LogicID:   "payments-prod",
+		Labels:    models.LabelMap{"env": "prod"},
+		CreatedBy: alice.PrincipalID,
+	}
+	require.NoError(t, repo.Create(ctx, state))
+	states := statepkg.NewService(repo, "http://localhost:8080")
+	// Neither principal's roles reach env=prod by label scope.
+	svc := &outOfScopeIAMService{ownerOverride: true}
+
+	canWrite := func(principal auth.AuthenticatedPrincipal) bool {
+		t.Helper()
+		current, err := states.GetStateByGUID(ctx, state.GUID)
+		require.NoError(t, err)
+		labels := map[string]any{"env": current.Labels["env"]}
+		allowed, err := AuthorizeWithOwner(ctx, svc, principal, auth.ObjectTypeState, auth.TfstateWrite, labels, current.CreatedBy)
+		require.NoError(t, err)
+		return allowed
+	}
+
+	require.True(t, canWrite(alice))
+	require.False(t, canWrite(bob))
+
+	previous, err := states.TransferOwnership(ctx, state.GUID, bob.PrincipalID)
+	require.NoError(t, err)
+	require.Equal(t, alice.PrincipalID, previous)
+
+	require.True(t, canWrite(bob), "new owner gains owner override")
+	require.False(t, canWrite(alice), "previous owner loses owner override")
+
+	stored, err := repo.GetByGUID(ctx, state.GUID)
+	require.NoError(t, err)
+	require.Equal(t, models.LabelMap{"env": "prod"}, stored.Labels, "only the owner is written")
+
+	// A transfer based on a stale owner must not overwrite a newer one
+	err = repo.TransferOwner(ctx, state.GUID, alice.PrincipalID, "user:mallory@example.com")
+	require.ErrorContains(t, err, "ownership conflict")
+	require.True(t, canWrite(bob))
+
+	err = repo.TransferOwner(ctx, "01890000-0000-7000-8000-00000000ffff", "", bob.PrincipalID)
+	require.ErrorContains(t, err, "not found")
+
+	_, err = states.TransferOwnership(ctx, state.GUID, "")
+	require.ErrorContains(t, err, "invalid new owner")
+}
+
+// defaultEnvIAMService defaults "env" to "dev" when a CreateState omits it.
+type defaultEnvIAMService struct {
+	iam.Service
+}
+
+func (s *defaultEnvIAMService) DefaultCreateLabels(ctx context.Context, principal *iam.Principal, labels map[string]any) (map[string]string, error) {
+	if _, ok := labels["env"]; ok {
+		return nil, nil
+	}
+	return map[string]string{"env": "dev"}, nil
+}
+
+func TestApplyDefaultCreateLabels(t *testing.T) {
+	t.Parallel()
+
+	principal := &iam.Principal{Roles: []string{"product-engineer"}}
+
+	t.Run("missing env is added to request and authz labels", func(t *testing.T) {
+		req := &statev1.CreateStateRequest{LogicId: "payments", Labels: map[string]string{"team": "core"}}
+		labels := map[string]any{"team": "core"}
+
+		require.NoError(t, applyDefaultCreateLabels(context.Background(), &defaultEnvIAMService{}, principal, req, labels))
+		require.Equal(t, map[string]string{"team": "core", "env": "dev"}, req.Labels)
+		require.Equal(t, "dev", labels["env"])
+	})
+
+	t.Run("request without labels", func(t *testing.T) {
+		req := &statev1.CreateStateRequest{LogicId: "payments"}
+		labels := map[string]any{}
+
+		require.NoError(t, applyDefaultCreateLabels(context.Background(), &defaultEnvIAMService{}, principal, req, labels))
+		require.Equal(t, map[string]string{"env": "dev"}, req.Labels)
+	})
+
+	t.Run("supplied env is kept", func(t *testing.T) {
+		req := &statev1.CreateStateRequest{LogicId: "payments", Labels: map[string]string{"env": "prod"}}
+		labels := map[string]any{"env": "prod"}
+
+		require.NoError(t, applyDefaultCreateLabels(context.Background(), &defaultEnvIAMService{}, principal, req, labels))
+		require.Equal(t, map[string]string{"env": "prod"}, req.Labels)
+	})
+}
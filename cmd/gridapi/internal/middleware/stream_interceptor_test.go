@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+	"github.com/terraconstructs/grid/pkg/api/state/v1/statev1connect"
+)
+
+// revocableIAMService authenticates until revoked is set and authorizes until
+// denied is set. Other methods panic via the nil embed.
+type revocableIAMService struct {
+	iam.Service
+	revoked atomic.Bool
+	denied  atomic.Bool
+}
+
+func (s *revocableIAMService) AuthenticateRequest(ctx context.Context, req iam.AuthRequest) (*iam.Principal, error) {
+	if s.revoked.Load() {
+		return nil, errors.New("session revoked")
+	}
+	return &iam.Principal{PrincipalID: "user:alice@example.com", Roles: []string{"viewer"}}, nil
+}
+
+func (s *revocableIAMService) Authorize(ctx context.Context, principal *iam.Principal, obj, act string, labels map[string]interface{}) (bool, error) {
+	return !s.denied.Load(), nil
+}
+
+// watchStreamConn is a WatchStateChanges handler conn; only Spec and
+// RequestHeader are implemented.
+type watchStreamConn struct {
+	connect.StreamingHandlerConn
+}
+
+func (watchStreamConn) Spec() connect.Spec {
+	return connect.Spec{Procedure: statev1connect.StateServiceWatchStateChangesProcedure, StreamType: connect.StreamTypeServer}
+}
+
+func (watchStreamConn) RequestHeader() http.Header {
+	return http.Header{}
+}
+
+func TestStreamAuthInterceptor_Recheck(t *testing.T) {
+	t.Parallel()
+
+	// streamUntilDone stands in for a handler that streams until its context ends
+	streamUntilDone := func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	for _, tc := range []struct {
+		name   string
+		revoke func(*revocableIAMService)
+		code   connect.Code
+	}{
+		{name: "revoked credential", revoke: func(s *revocableIAMService) { s.revoked.Store(true) }, code: connect.CodeUnauthenticated},
+		{name: "lost permission", revoke: func(s *revocableIAMService) { s.denied.Store(true) }, code: connect.CodePermissionDenied},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			svc := &revocableIAMService{}
+			handler := NewStreamAuthInterceptor(svc, 10*time.Millisecond).WrapStreamingHandler(streamUntilDone)
+
+			done := make(chan error, 1)
+			go func() { done <- handler(context.Background(), watchStreamConn{}) }()
+
+			select {
+			case err := <-done:
+				t.Fatalf("stream closed before revocation: %v", err)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			tc.revoke(svc)
+			select {
+			case err := <-done:
+				require.Equal(t, tc.code, connect.CodeOf(err))
+			case <-time.After(time.Second):
+				t.Fatal("stream stayed open after revocation")
+			}
+		})
+	}
+
+	t.Run("client disconnect is not an error", func(t *testing.T) {
+		t.Parallel()
+		handler := NewStreamAuthInterceptor(&revocableIAMService{}, time.Hour).WrapStreamingHandler(streamUntilDone)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.NoError(t, handler(ctx, watchStreamConn{}))
+	})
+
+	t.Run("unauthenticated stream never opens", func(t *testing.T) {
+		t.Parallel()
+		svc := &revocableIAMService{}
+		svc.revoked.Store(true)
+		handler := NewStreamAuthInterceptor(svc, time.Hour).WrapStreamingHandler(streamUntilDone)
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(handler(context.Background(), watchStreamConn{})))
+	})
+}
@@ -5,12 +5,15 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	casbinbunadapter "github.com/terraconstructs/grid/cmd/gridapi/internal/auth/bunadapter"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 	"github.com/uptrace/bun"
 )
 
+// systemUserID is auth.SystemUserID. Migrations don't import internal/auth so
+// that its tests can run against a migrated database.
+const systemUserID = "00000000-0000-0000-0000-000000000000"
+
 func init() {
 	Migrations.MustRegister(up_20251203000000, down_20251203000000)
 }
@@ -347,7 +350,7 @@ func up_20251203000000(ctx context.Context, db *bun.DB) error {
 
 	// Seed System User
 	sysSub := "system"
-	sysUser := models.User{ID: auth.SystemUserID, Subject: &sysSub, Email: "system@grid.internal", Name: "System"}
+	sysUser := models.User{ID: systemUserID, Subject: &sysSub, Email: "system@grid.internal", Name: "System"}
 	if _, err := db.NewInsert().Model(&sysUser).On("CONFLICT (id) DO NOTHING").Exec(ctx); err != nil {
 		return fmt.Errorf("seed system user: %w", err)
 	}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015000000, down_20261015000000)
+}
+
+// up_20261015000000 adds the optional per-role assignment cap.
+func up_20261015000000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding roles.max_assignments...")
+
+	if err := addColumnIfMissing(ctx, db, "roles", "max_assignments", "INTEGER"); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015000000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping roles.max_assignments...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE roles DROP COLUMN max_assignments`); err != nil {
+		return fmt.Errorf("failed to drop max_assignments column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015010000, down_20261015010000)
+}
+
+// up_20261015010000 adds the optional claim condition to group-role mappings.
+func up_20261015010000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding group_roles.condition...")
+
+	if err := addColumnIfMissing(ctx, db, "group_roles", "condition", "TEXT"); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015010000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping group_roles.condition...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE group_roles DROP COLUMN condition`); err != nil {
+		return fmt.Errorf("failed to drop condition column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
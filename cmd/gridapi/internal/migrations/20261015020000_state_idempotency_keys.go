@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015020000, down_20261015020000)
+}
+
+// up_20261015020000 creates the table backing CreateState idempotency keys.
+func up_20261015020000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] creating state_idempotency_keys table...")
+
+	_, err := db.NewCreateTable().
+		Model((*models.StateIdempotencyKey)(nil)).
+		IfNotExists().
+		ForeignKey(`(state_guid) REFERENCES states(guid) ON DELETE CASCADE`).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create state_idempotency_keys table: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_state_idempotency_keys_expires_at ON state_idempotency_keys(expires_at)`); err != nil {
+		return fmt.Errorf("failed to create index on state_idempotency_keys.expires_at: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015020000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping state_idempotency_keys table...")
+
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS state_idempotency_keys`); err != nil {
+		return fmt.Errorf("failed to drop state_idempotency_keys table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015030000, down_20261015030000)
+}
+
+// up_20261015030000 creates the table backing internal IdP device authorizations,
+// previously held in memory.
+func up_20261015030000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] creating device_authorizations table...")
+
+	_, err := db.NewCreateTable().
+		Model((*models.DeviceAuthorization)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create device_authorizations table: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_device_authorizations_expires_at ON device_authorizations(expires_at)`); err != nil {
+		return fmt.Errorf("failed to create index on device_authorizations.expires_at: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015030000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping device_authorizations table...")
+
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS device_authorizations`); err != nil {
+		return fmt.Errorf("failed to drop device_authorizations table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015040000, down_20261015040000)
+}
+
+// up_20261015040000 records state creators and the per-role owner override
+// actions.
+func up_20261015040000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding states.created_by and roles.owner_actions...")
+
+	if err := addColumnIfMissing(ctx, db, "states", "created_by", "TEXT"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(ctx, db, "roles", "owner_actions", columnType(db, "TEXT[]", "VARCHAR")); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015040000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping states.created_by and roles.owner_actions...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE roles DROP COLUMN owner_actions`); err != nil {
+		return fmt.Errorf("failed to drop owner_actions column: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `ALTER TABLE states DROP COLUMN created_by`); err != nil {
+		return fmt.Errorf("failed to drop created_by column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
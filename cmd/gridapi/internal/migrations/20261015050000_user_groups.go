@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015050000, down_20261015050000)
+}
+
+// up_20261015050000 creates the table of internal IdP group memberships, issued
+// as the groups claim of internal IdP tokens.
+func up_20261015050000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] creating user_groups table...")
+
+	_, err := db.NewCreateTable().
+		Model((*models.UserGroup)(nil)).
+		IfNotExists().
+		ForeignKey(`(user_id) REFERENCES users(id) ON DELETE CASCADE`).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create user_groups table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015050000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping user_groups table...")
+
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS user_groups`); err != nil {
+		return fmt.Errorf("failed to drop user_groups table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
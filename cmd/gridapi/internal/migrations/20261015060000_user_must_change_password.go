@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015060000, down_20261015060000)
+}
+
+// up_20261015060000 adds the flag set by an admin password reset.
+func up_20261015060000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding users.must_change_password...")
+
+	if err := addColumnIfMissing(ctx, db, "users", "must_change_password", "BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015060000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping users.must_change_password...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE users DROP COLUMN must_change_password`); err != nil {
+		return fmt.Errorf("failed to drop must_change_password column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015070000, down_20261015070000)
+}
+
+// up_20261015070000 creates the single-row table holding the global
+// revocation epoch (the credential kill switch).
+func up_20261015070000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] creating revocation_epoch table...")
+
+	_, err := db.NewCreateTable().
+		Model((*models.RevocationEpoch)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create revocation_epoch table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015070000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping revocation_epoch table...")
+
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS revocation_epoch`); err != nil {
+		return fmt.Errorf("failed to drop revocation_epoch table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015080000, down_20261015080000)
+}
+
+// up_20261015080000 creates the audit_log table recording out-of-band IAM
+// mutations, indexed for the admin query filters.
+func up_20261015080000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] creating audit_log table...")
+
+	_, err := db.NewCreateTable().
+		Model((*models.AuditLogEntry)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create audit_log table: %w", err)
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log (occurred_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, occurred_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log (target_type, target_id, occurred_at)`,
+	}
+	for _, stmt := range indexes {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create audit_log index: %w", err)
+		}
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015080000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping audit_log table...")
+
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS audit_log`); err != nil {
+		return fmt.Errorf("failed to drop audit_log table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015090000, down_20261015090000)
+}
+
+// up_20261015090000 records each role's actions next to the role so its Casbin
+// policies can be checked against them. Existing roles keep NULL (unknown).
+func up_20261015090000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding roles.actions...")
+
+	if err := addColumnIfMissing(ctx, db, "roles", "actions", columnType(db, "TEXT[]", "VARCHAR")); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015090000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping roles.actions...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE roles DROP COLUMN actions`); err != nil {
+		return fmt.Errorf("failed to drop actions column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015100000, down_20261015100000)
+}
+
+// up_20261015100000 records the permission scopes a session's token was
+// limited to. Existing sessions keep NULL (not down-scoped).
+func up_20261015100000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding sessions.scopes...")
+
+	if err := addColumnIfMissing(ctx, db, "sessions", "scopes", columnType(db, "TEXT[]", "VARCHAR")); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015100000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping sessions.scopes...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE sessions DROP COLUMN scopes`); err != nil {
+		return fmt.Errorf("failed to drop scopes column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
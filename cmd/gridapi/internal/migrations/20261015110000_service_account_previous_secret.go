@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015110000, down_20261015110000)
+}
+
+// up_20261015110000 adds the previous secret kept during an overlapping
+// service account secret rotation.
+func up_20261015110000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding service_accounts.previous_secret_hash, previous_secret_expires_at...")
+
+	if err := addColumnIfMissing(ctx, db, "service_accounts", "previous_secret_hash", columnType(db, "TEXT", "VARCHAR")); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(ctx, db, "service_accounts", "previous_secret_expires_at", columnType(db, "TIMESTAMPTZ", "TIMESTAMP")); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015110000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping service_accounts.previous_secret_hash, previous_secret_expires_at...")
+
+	for _, column := range []string{"previous_secret_expires_at", "previous_secret_hash"} {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE service_accounts DROP COLUMN %s`, column)); err != nil {
+			return fmt.Errorf("failed to drop %s column: %w", column, err)
+		}
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
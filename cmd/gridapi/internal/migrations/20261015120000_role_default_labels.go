@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015120000, down_20261015120000)
+}
+
+// up_20261015120000 adds the per-role opt-in for defaulting CreateState labels
+// from create constraints. Existing roles keep rejecting missing labels.
+func up_20261015120000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding roles.default_labels...")
+
+	if err := addColumnIfMissing(ctx, db, "roles", "default_labels", "BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015120000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping roles.default_labels...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE roles DROP COLUMN default_labels`); err != nil {
+		return fmt.Errorf("failed to drop default_labels column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015130000, down_20261015130000)
+}
+
+// up_20261015130000 creates the schema_registry table of shared output schemas
+// and adds state_outputs.schema_ref linking an output to the registry entry its
+// schema was set from.
+func up_20261015130000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] creating schema_registry table...")
+
+	_, err := db.NewCreateTable().
+		Model((*models.SchemaRegistryEntry)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_registry table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	fmt.Print(" [up] adding state_outputs.schema_ref...")
+
+	if err := addColumnIfMissing(ctx, db, "state_outputs", "schema_ref", "TEXT"); err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_state_outputs_schema_ref ON state_outputs(schema_ref) WHERE schema_ref IS NOT NULL`); err != nil {
+		return fmt.Errorf("failed to create schema_ref index: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015130000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping state_outputs.schema_ref and schema_registry table...")
+
+	if _, err := db.ExecContext(ctx, `DROP INDEX IF EXISTS idx_state_outputs_schema_ref`); err != nil {
+		return fmt.Errorf("failed to drop schema_ref index: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `ALTER TABLE state_outputs DROP COLUMN schema_ref`); err != nil {
+		return fmt.Errorf("failed to drop schema_ref column: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS schema_registry`); err != nil {
+		return fmt.Errorf("failed to drop schema_registry table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
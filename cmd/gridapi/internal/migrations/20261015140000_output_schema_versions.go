@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015140000, down_20261015140000)
+}
+
+// up_20261015140000 creates the output_schema_versions history table and adds
+// the active and last-validated schema versions to state_outputs. Existing
+// schemas are recorded as version 1.
+func up_20261015140000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] creating output_schema_versions table...")
+
+	_, err := db.NewCreateTable().
+		Model((*models.OutputSchemaVersion)(nil)).
+		IfNotExists().
+		ForeignKey(`(state_guid) REFERENCES states(guid) ON DELETE CASCADE`).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create output_schema_versions table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	fmt.Print(" [up] adding state_outputs schema version columns...")
+
+	for _, column := range []string{"schema_version", "validated_schema_version"} {
+		if err := addColumnIfMissing(ctx, db, "state_outputs", column, "INTEGER"); err != nil {
+			return err
+		}
+	}
+
+	// Backfill: existing schemas become version 1
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO output_schema_versions (state_guid, output_key, version, schema_json, schema_source, schema_ref, created_at)
+		SELECT state_guid, output_key, 1, schema_json, COALESCE(schema_source, 'manual'), schema_ref, updated_at
+		FROM state_outputs
+		WHERE schema_json IS NOT NULL AND schema_version IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill output_schema_versions: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE state_outputs SET schema_version = 1 WHERE schema_json IS NOT NULL AND schema_version IS NULL`); err != nil {
+		return fmt.Errorf("failed to backfill schema_version: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015140000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping output schema versions...")
+
+	for _, column := range []string{"validated_schema_version", "schema_version"} {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE state_outputs DROP COLUMN %s`, column)); err != nil {
+			return fmt.Errorf("failed to drop %s column: %w", column, err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS output_schema_versions`); err != nil {
+		return fmt.Errorf("failed to drop output_schema_versions table: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015150000, down_20261015150000)
+}
+
+// up_20261015150000 records the client fingerprint a session is bound to.
+// Existing sessions keep NULL (not bound).
+func up_20261015150000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding sessions.fingerprint...")
+
+	if err := addColumnIfMissing(ctx, db, "sessions", "fingerprint", "VARCHAR"); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015150000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping sessions.fingerprint...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE sessions DROP COLUMN fingerprint`); err != nil {
+		return fmt.Errorf("failed to drop fingerprint column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
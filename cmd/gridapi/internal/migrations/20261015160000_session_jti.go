@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015160000, down_20261015160000)
+}
+
+// up_20261015160000 records the access token jti of internal IdP sessions, so
+// revoking a session can denylist the token. Existing sessions keep NULL and
+// are only revoked server-side.
+func up_20261015160000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding sessions.jti...")
+
+	if err := addColumnIfMissing(ctx, db, "sessions", "jti", "VARCHAR"); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015160000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping sessions.jti...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE sessions DROP COLUMN jti`); err != nil {
+		return fmt.Errorf("failed to drop jti column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
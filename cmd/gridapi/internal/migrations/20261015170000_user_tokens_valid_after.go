@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015170000, down_20261015170000)
+}
+
+// up_20261015170000 adds the per-user revocation epoch an admin password reset
+// sets.
+func up_20261015170000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding users.tokens_valid_after...")
+
+	if err := addColumnIfMissing(ctx, db, "users", "tokens_valid_after", columnType(db, "TIMESTAMPTZ", "TIMESTAMP")); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015170000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping users.tokens_valid_after...")
+
+	if _, err := db.ExecContext(ctx, `ALTER TABLE users DROP COLUMN tokens_valid_after`); err != nil {
+		return fmt.Errorf("failed to drop tokens_valid_after column: %w", err)
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
@@ -0,0 +1,92 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015180000, down_20261015180000)
+}
+
+// seededUnscopedRoles are the roles the init migration seeds with an empty
+// scope, which meant "every state" at the time.
+var seededUnscopedRoles = []string{"service-account", "platform-engineer"}
+
+// seededUnscopedPolicies are the (role, object, action) of the policies the
+// init migration seeds with an empty scope.
+var seededUnscopedPolicies = [][3]string{
+	{"role:service-account", "state", "tfstate:read"},
+	{"role:service-account", "state", "tfstate:write"},
+	{"role:service-account", "state", "tfstate:lock"},
+	{"role:service-account", "state", "tfstate:unlock"},
+	{"role:platform-engineer", "*", "*"},
+	{"role:product-engineer", "state", "state:list"},
+	{"role:product-engineer", "state", "dependency:list-all"},
+	{"role:product-engineer", "policy", "policy:read"},
+}
+
+// up_20261015180000 gives the seeded unscoped roles and policies the explicit
+// "*" scope now that an empty scope no longer means "every state". Only rows
+// still carrying the seeded empty scope are touched; roles an admin created
+// with an empty scope are left to the startup rewrite, which follows
+// empty_role_scope.
+func up_20261015180000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] setting explicit scopes on seeded roles...")
+
+	if _, err := db.NewUpdate().
+		Table("roles").
+		Set("scope_expr = ?", "*").
+		Where("name IN (?)", bun.In(seededUnscopedRoles)).
+		Where("scope_expr = ''").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update seeded role scopes: %w", err)
+	}
+
+	for _, policy := range seededUnscopedPolicies {
+		if _, err := db.NewUpdate().
+			Table("casbin_rules").
+			Set("v3 = ?", "*").
+			Where("ptype = 'p'").
+			Where("v0 = ? AND v1 = ? AND v2 = ?", policy[0], policy[1], policy[2]).
+			Where("v3 = ''").
+			Where("v4 = 'allow'").
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to update seeded policy scope: %w", err)
+		}
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015180000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] restoring empty scopes on seeded roles...")
+
+	if _, err := db.NewUpdate().
+		Table("roles").
+		Set("scope_expr = ''").
+		Where("name IN (?)", bun.In(seededUnscopedRoles)).
+		Where("scope_expr = ?", "*").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to restore seeded role scopes: %w", err)
+	}
+
+	for _, policy := range seededUnscopedPolicies {
+		if _, err := db.NewUpdate().
+			Table("casbin_rules").
+			Set("v3 = ''").
+			Where("ptype = 'p'").
+			Where("v0 = ? AND v1 = ? AND v2 = ?", policy[0], policy[1], policy[2]).
+			Where("v3 = ?", "*").
+			Where("v4 = 'allow'").
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to restore seeded policy scope: %w", err)
+		}
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
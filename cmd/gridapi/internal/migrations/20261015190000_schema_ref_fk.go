@@ -0,0 +1,118 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(up_20261015190000, down_20261015190000)
+}
+
+// up_20261015190000 backs state_outputs.schema_ref with a foreign key to
+// schema_registry(key) that restricts deletes, so a registry entry cannot be
+// removed while an output references it. References to entries that no longer
+// exist are cleared first.
+//
+// SQLite cannot add a constraint to an existing table, so state_outputs is
+// rebuilt there with the constraint and its indexes are recreated.
+func up_20261015190000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [up] adding schema_ref foreign key...")
+
+	if _, err := db.ExecContext(ctx, `
+		UPDATE state_outputs SET schema_ref = NULL
+		WHERE schema_ref IS NOT NULL AND schema_ref NOT IN (SELECT key FROM schema_registry)
+	`); err != nil {
+		return fmt.Errorf("failed to clear dangling schema_ref values: %w", err)
+	}
+
+	if IsPostgreSQL(db) {
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE state_outputs
+			ADD CONSTRAINT fk_state_outputs_schema_ref
+			FOREIGN KEY (schema_ref) REFERENCES schema_registry(key) ON DELETE RESTRICT
+		`); err != nil {
+			return fmt.Errorf("failed to add FK constraint on schema_ref: %w", err)
+		}
+		fmt.Println(" OK")
+		return nil
+	}
+
+	if err := rebuildStateOutputs(ctx, db, true); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+func down_20261015190000(ctx context.Context, db *bun.DB) error {
+	fmt.Print(" [down] dropping schema_ref foreign key...")
+
+	if IsPostgreSQL(db) {
+		if _, err := db.ExecContext(ctx, `ALTER TABLE state_outputs DROP CONSTRAINT IF EXISTS fk_state_outputs_schema_ref`); err != nil {
+			return fmt.Errorf("failed to drop FK constraint on schema_ref: %w", err)
+		}
+		fmt.Println(" OK")
+		return nil
+	}
+
+	if err := rebuildStateOutputs(ctx, db, false); err != nil {
+		return err
+	}
+
+	fmt.Println(" OK")
+	return nil
+}
+
+// rebuildStateOutputs recreates the SQLite state_outputs table from the
+// StateOutput model, with or without the schema_ref foreign key, copying its
+// rows and indexes across.
+func rebuildStateOutputs(ctx context.Context, db *bun.DB, schemaRefFK bool) error {
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var indexes []string
+		if err := tx.NewSelect().
+			Table("sqlite_master").
+			Column("sql").
+			Where("type = 'index' AND tbl_name = 'state_outputs' AND sql IS NOT NULL").
+			Scan(ctx, &indexes); err != nil {
+			return fmt.Errorf("failed to list state_outputs indexes: %w", err)
+		}
+
+		q := tx.NewCreateTable().
+			Model((*models.StateOutput)(nil)).
+			ModelTableExpr("state_outputs_rebuild").
+			ForeignKey(`(state_guid) REFERENCES states(guid) ON DELETE CASCADE`)
+		if schemaRefFK {
+			q = q.ForeignKey(`(schema_ref) REFERENCES schema_registry(key) ON DELETE RESTRICT`)
+		}
+		if _, err := q.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create state_outputs_rebuild table: %w", err)
+		}
+
+		var columns []string
+		if err := tx.NewSelect().
+			TableExpr("pragma_table_info('state_outputs')").
+			Column("name").
+			Scan(ctx, &columns); err != nil {
+			return fmt.Errorf("failed to inspect state_outputs columns: %w", err)
+		}
+		columnList := strings.Join(columns, ", ")
+
+		steps := []string{
+			fmt.Sprintf(`INSERT INTO state_outputs_rebuild (%s) SELECT %s FROM state_outputs`, columnList, columnList),
+			`DROP TABLE state_outputs`,
+			`ALTER TABLE state_outputs_rebuild RENAME TO state_outputs`,
+		}
+		for _, step := range append(steps, indexes...) {
+			if _, err := tx.ExecContext(ctx, step); err != nil {
+				return fmt.Errorf("failed to rebuild state_outputs: %w", err)
+			}
+		}
+		return nil
+	})
+}
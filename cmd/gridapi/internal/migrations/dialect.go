@@ -1,6 +1,9 @@
 package migrations
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
 )
@@ -14,3 +17,38 @@ func IsSQLite(db *bun.DB) bool {
 func IsPostgreSQL(db *bun.DB) bool {
 	return db.Dialect().Name() == dialect.PG
 }
+
+// columnType returns pgType on PostgreSQL and sqliteType otherwise, for
+// columns whose type differs between the dialects (e.g. arrays).
+func columnType(db *bun.DB, pgType, sqliteType string) string {
+	if IsPostgreSQL(db) {
+		return pgType
+	}
+	return sqliteType
+}
+
+// addColumnIfMissing adds column to table, ddl being the column type and any
+// constraints. Fresh databases already get columns added by later migrations
+// from the models in the init migration, so the add is skipped when the column
+// exists. SQLite has no ADD COLUMN IF NOT EXISTS, so the column is looked up
+// there first.
+func addColumnIfMissing(ctx context.Context, db *bun.DB, table, column, ddl string) error {
+	if IsPostgreSQL(db) {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s`, table, column, ddl)); err != nil {
+			return fmt.Errorf("failed to add %s.%s column: %w", table, column, err)
+		}
+		return nil
+	}
+
+	var count int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count); err != nil {
+		return fmt.Errorf("failed to inspect %s columns: %w", table, err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, ddl)); err != nil {
+		return fmt.Errorf("failed to add %s.%s column: %w", table, column, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/bunx"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+// BunAuditLogRepository implements AuditLogRepository using Bun ORM
+type BunAuditLogRepository struct {
+	db *bun.DB
+}
+
+// NewBunAuditLogRepository creates a new Bun-based audit log repository
+func NewBunAuditLogRepository(db *bun.DB) AuditLogRepository {
+	return &BunAuditLogRepository{db: db}
+}
+
+// Create appends an entry to the audit log
+func (r *BunAuditLogRepository) Create(ctx context.Context, entry *models.AuditLogEntry) error {
+	if entry.ID == "" {
+		entry.ID = bunx.NewUUIDv7()
+	}
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(entry).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("create audit log entry: %w", err)
+	}
+	return nil
+}
+
+// List returns entries matching filter, newest first
+func (r *BunAuditLogRepository) List(ctx context.Context, filter AuditLogFilter) ([]models.AuditLogEntry, error) {
+	var entries []models.AuditLogEntry
+	q := r.db.NewSelect().
+		Model(&entries).
+		Order("occurred_at DESC", "id DESC")
+
+	if filter.Actor != "" {
+		q = q.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		q = q.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		q = q.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		q = q.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.Outcome != "" {
+		q = q.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.Since != nil {
+		q = q.Where("occurred_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		q = q.Where("occurred_at < ?", *filter.Until)
+	}
+	if filter.PageSize > 0 {
+		q = q.Limit(filter.PageSize)
+	}
+	if filter.Offset > 0 {
+		q = q.Offset(filter.Offset)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("list audit log: %w", err)
+	}
+	return entries, nil
+}
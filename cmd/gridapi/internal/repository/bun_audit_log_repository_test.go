@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestBunAuditLogRepository(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	repo := NewBunAuditLogRepository(db)
+	start := time.Now().Add(-time.Hour)
+	failure := "role already assigned to group"
+	entries := []*models.AuditLogEntry{
+		{OccurredAt: start, Actor: "user:alice", Action: "role.create", TargetType: "role", TargetID: "viewer",
+			After: map[string]any{"name": "viewer", "version": 1}, Outcome: "success"},
+		{OccurredAt: start.Add(time.Minute), Actor: "system", Action: "group_role.assign", TargetType: "group", TargetID: "ops",
+			Outcome: "failure", Error: &failure},
+		{OccurredAt: start.Add(2 * time.Minute), Actor: "user:alice", Action: "role.delete", TargetType: "role", TargetID: "viewer",
+			Before: map[string]any{"name": "viewer"}, Outcome: "success"},
+	}
+	for _, entry := range entries {
+		require.NoError(t, repo.Create(ctx, entry))
+		require.NotEmpty(t, entry.ID)
+	}
+
+	t.Run("newest first with before and after", func(t *testing.T) {
+		got, err := repo.List(ctx, AuditLogFilter{})
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, "role.delete", got[0].Action)
+		assert.Equal(t, "viewer", got[0].Before["name"])
+		assert.Equal(t, float64(1), got[2].After["version"])
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		got, err := repo.List(ctx, AuditLogFilter{Actor: "user:alice", TargetType: "role", TargetID: "viewer"})
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+
+		got, err = repo.List(ctx, AuditLogFilter{Outcome: "failure"})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.NotNil(t, got[0].Error)
+		assert.Equal(t, failure, *got[0].Error)
+
+		since := start.Add(30 * time.Second)
+		got, err = repo.List(ctx, AuditLogFilter{Since: &since, PageSize: 1})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "role.delete", got[0].Action)
+	})
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+// BunDeviceAuthorizationRepository implements DeviceAuthorizationRepository using Bun ORM
+type BunDeviceAuthorizationRepository struct {
+	db *bun.DB
+}
+
+// NewBunDeviceAuthorizationRepository creates a new Bun-based device authorization repository
+func NewBunDeviceAuthorizationRepository(db *bun.DB) DeviceAuthorizationRepository {
+	return &BunDeviceAuthorizationRepository{db: db}
+}
+
+// Create stores a new device authorization
+func (r *BunDeviceAuthorizationRepository) Create(ctx context.Context, record *models.DeviceAuthorization) error {
+	if _, err := r.db.NewInsert().Model(record).Exec(ctx); err != nil {
+		return fmt.Errorf("create device authorization: %w", err)
+	}
+	return nil
+}
+
+// GetByDeviceCode looks up an authorization by the code the device polls with
+func (r *BunDeviceAuthorizationRepository) GetByDeviceCode(ctx context.Context, deviceCode string) (*models.DeviceAuthorization, error) {
+	return r.get(ctx, "device_code = ?", deviceCode)
+}
+
+// GetByUserCode looks up an authorization by the code the user enters
+func (r *BunDeviceAuthorizationRepository) GetByUserCode(ctx context.Context, userCode string) (*models.DeviceAuthorization, error) {
+	return r.get(ctx, "user_code = ?", userCode)
+}
+
+func (r *BunDeviceAuthorizationRepository) get(ctx context.Context, where string, arg string) (*models.DeviceAuthorization, error) {
+	record := new(models.DeviceAuthorization)
+	err := r.db.NewSelect().Model(record).Where(where, arg).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("device authorization not found")
+		}
+		return nil, fmt.Errorf("get device authorization: %w", err)
+	}
+	return record, nil
+}
+
+// MarkPolled records the time of the latest token-endpoint poll
+func (r *BunDeviceAuthorizationRepository) MarkPolled(ctx context.Context, deviceCode string, at time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.DeviceAuthorization)(nil)).
+		Set("last_polled_at = ?", at).
+		Where("device_code = ?", deviceCode).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("mark device authorization polled: %w", err)
+	}
+	return nil
+}
+
+// Complete approves or denies an authorization that is still pending and unexpired
+func (r *BunDeviceAuthorizationRepository) Complete(ctx context.Context, userCode, subject string, approved bool, at time.Time) error {
+	res, err := r.db.NewUpdate().
+		Model((*models.DeviceAuthorization)(nil)).
+		Set("approved = ?", approved).
+		Set("denied = ?", !approved).
+		Set("subject = ?", subject).
+		Set("auth_time = ?", at).
+		Where("user_code = ?", userCode).
+		Where("approved = ?", false).
+		Where("denied = ?", false).
+		Where("expires_at > ?", at).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("complete device authorization: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("device authorization not found")
+	}
+	return nil
+}
+
+// Delete removes an authorization once its token has been issued. Only the
+// caller whose delete removed the row may issue the token.
+func (r *BunDeviceAuthorizationRepository) Delete(ctx context.Context, deviceCode string) error {
+	res, err := r.db.NewDelete().
+		Model((*models.DeviceAuthorization)(nil)).
+		Where("device_code = ?", deviceCode).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete device authorization: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if n != 1 {
+		return fmt.Errorf("device authorization not found")
+	}
+	return nil
+}
+
+// DeleteExpired removes authorizations that expired before the given time
+func (r *BunDeviceAuthorizationRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	_, err := r.db.NewDelete().
+		Model((*models.DeviceAuthorization)(nil)).
+		Where("expires_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete expired device authorizations: %w", err)
+	}
+	return nil
+}
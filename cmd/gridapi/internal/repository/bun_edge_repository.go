@@ -134,6 +134,26 @@ func (r *BunEdgeRepository) GetIncomingEdges(ctx context.Context, toStateGUID st
 	return edges, nil
 }
 
+// GetIncomingEdgesForStates fetches incoming edges for all of toStateGUIDs.
+func (r *BunEdgeRepository) GetIncomingEdgesForStates(ctx context.Context, toStateGUIDs []string) ([]models.Edge, error) {
+	if len(toStateGUIDs) == 0 {
+		return []models.Edge{}, nil
+	}
+
+	var edges []models.Edge
+	err := r.db.NewSelect().
+		Model(&edges).
+		Where("to_state IN (?)", bun.In(toStateGUIDs)).
+		Order("created_at ASC", "id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("query incoming edges: %w", err)
+	}
+
+	return edges, nil
+}
+
 // GetAllEdges fetches all edges in the system, ordered by ID (insertion order).
 func (r *BunEdgeRepository) GetAllEdges(ctx context.Context) ([]models.Edge, error) {
 	var edges []models.Edge
@@ -149,6 +169,23 @@ func (r *BunEdgeRepository) GetAllEdges(ctx context.Context) ([]models.Edge, err
 	return edges, nil
 }
 
+// ListAfter returns up to limit edges with ID greater than afterID.
+func (r *BunEdgeRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]models.Edge, error) {
+	var edges []models.Edge
+	err := r.db.NewSelect().
+		Model(&edges).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("query edges page: %w", err)
+	}
+
+	return edges, nil
+}
+
 // FindByOutput finds all edges that reference a specific output key.
 func (r *BunEdgeRepository) FindByOutput(ctx context.Context, outputKey string) ([]models.Edge, error) {
 	var edges []models.Edge
@@ -118,6 +118,11 @@ func TestBunEdgeRepository_QueryMethods(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, incoming, 1)
 		assert.Equal(t, edge.ID, incoming[0].ID)
+
+		batch, err := edgeRepo.GetIncomingEdgesForStates(ctx, []string{consumer.GUID, producer.GUID})
+		require.NoError(t, err)
+		require.Len(t, batch, 1)
+		assert.Equal(t, edge.ID, batch[0].ID)
 	})
 
 	t.Run("find by output", func(t *testing.T) {
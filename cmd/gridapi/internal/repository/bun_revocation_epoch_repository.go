@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+// revocationEpochID is the primary key of the single revocation_epoch row
+const revocationEpochID = 1
+
+// BunRevocationEpochRepository implements RevocationEpochRepository using Bun ORM
+type BunRevocationEpochRepository struct {
+	db *bun.DB
+}
+
+// NewBunRevocationEpochRepository creates a new Bun-based revocation epoch repository
+func NewBunRevocationEpochRepository(db *bun.DB) RevocationEpochRepository {
+	return &BunRevocationEpochRepository{db: db}
+}
+
+// Get returns the stored epoch, or nil if none was ever set
+func (r *BunRevocationEpochRepository) Get(ctx context.Context) (*models.RevocationEpoch, error) {
+	epoch := new(models.RevocationEpoch)
+	err := r.db.NewSelect().
+		Model(epoch).
+		Where("id = ?", revocationEpochID).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get revocation epoch: %w", err)
+	}
+	return epoch, nil
+}
+
+// Set stores epoch, replacing any previous value
+func (r *BunRevocationEpochRepository) Set(ctx context.Context, epoch time.Time, setBy string) error {
+	_, err := r.db.NewInsert().
+		Model(&models.RevocationEpoch{ID: revocationEpochID, Epoch: epoch, SetBy: setBy, UpdatedAt: time.Now()}).
+		On("CONFLICT (id) DO UPDATE").
+		Set("epoch = EXCLUDED.epoch").
+		Set("set_by = EXCLUDED.set_by").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("set revocation epoch: %w", err)
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ func NewBunRevokedJTIRepository(db *bun.DB) RevokedJTIRepository {
 func (r *BunRevokedJTIRepository) Create(ctx context.Context, revokedJTI *models.RevokedJTI) error {
 	_, err := r.db.NewInsert().
 		Model(revokedJTI).
+		On("CONFLICT (jti) DO NOTHING"). // Revoking a revoked token is a no-op
 		Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("create revoked jti: %w", err)
@@ -35,6 +35,9 @@ func (r *BunRoleRepository) Create(ctx context.Context, role *models.Role) error
 		Model(role).
 		Exec(ctx)
 	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("create role: %w", ErrAlreadyExists)
+		}
 		return fmt.Errorf("create role: %w", err)
 	}
 	return nil
@@ -49,7 +52,7 @@ func (r *BunRoleRepository) GetByID(ctx context.Context, id string) (*models.Rol
 		Scan(ctx)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("role not found: %s", id)
+			return nil, fmt.Errorf("role %w: %s", ErrNotFound, id)
 		}
 		return nil, fmt.Errorf("get role: %w", err)
 	}
@@ -65,7 +68,7 @@ func (r *BunRoleRepository) GetByName(ctx context.Context, name string) (*models
 		Scan(ctx)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("role not found: %s", name)
+			return nil, fmt.Errorf("role %w: %s", ErrNotFound, name)
 		}
 		return nil, fmt.Errorf("get role by name: %w", err)
 	}
@@ -90,7 +93,7 @@ func (r *BunRoleRepository) Update(ctx context.Context, role *models.Role) error
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("role not found: %s", role.ID)
+		return fmt.Errorf("role %w: %s", ErrNotFound, role.ID)
 	}
 
 	return nil
@@ -112,7 +115,7 @@ func (r *BunRoleRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("role not found: %s", id)
+		return fmt.Errorf("role %w: %s", ErrNotFound, id)
 	}
 
 	return nil
@@ -147,6 +150,10 @@ func NewBunUserRoleRepository(db *bun.DB) UserRoleRepository {
 
 // Create inserts a new user-role assignment
 func (r *BunUserRoleRepository) Create(ctx context.Context, ur *models.UserRole) error {
+	return r.create(ctx, r.db, ur)
+}
+
+func (r *BunUserRoleRepository) create(ctx context.Context, db bun.IDB, ur *models.UserRole) error {
 	if ur.ID == "" {
 		ur.ID = bunx.NewUUIDv7()
 	}
@@ -156,15 +163,29 @@ func (r *BunUserRoleRepository) Create(ctx context.Context, ur *models.UserRole)
 		return fmt.Errorf("exactly one of user_id or service_account_id must be set")
 	}
 
-	_, err := r.db.NewInsert().
+	_, err := db.NewInsert().
 		Model(ur).
 		Exec(ctx)
 	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("create user role: %w", ErrAlreadyExists)
+		}
 		return fmt.Errorf("create user role: %w", err)
 	}
 	return nil
 }
 
+// CreateChecked inserts a new user-role assignment if check accepts the role's
+// current assignments
+func (r *BunUserRoleRepository) CreateChecked(ctx context.Context, ur *models.UserRole, check AssignmentCheck) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := checkAssignment(ctx, tx, ur.RoleID, check); err != nil {
+			return err
+		}
+		return r.create(ctx, tx, ur)
+	})
+}
+
 // GetByID retrieves a user-role assignment by ID
 func (r *BunUserRoleRepository) GetByID(ctx context.Context, id string) (*models.UserRole, error) {
 	ur := new(models.UserRole)
@@ -327,14 +348,32 @@ func NewBunGroupRoleRepository(db *bun.DB) GroupRoleRepository {
 
 // Create inserts a new group-role mapping
 func (r *BunGroupRoleRepository) Create(ctx context.Context, gr *models.GroupRole) error {
+	return r.create(ctx, r.db, gr)
+}
+
+// CreateChecked inserts a new group-role mapping if check accepts the role's
+// current assignments
+func (r *BunGroupRoleRepository) CreateChecked(ctx context.Context, gr *models.GroupRole, check AssignmentCheck) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := checkAssignment(ctx, tx, gr.RoleID, check); err != nil {
+			return err
+		}
+		return r.create(ctx, tx, gr)
+	})
+}
+
+func (r *BunGroupRoleRepository) create(ctx context.Context, db bun.IDB, gr *models.GroupRole) error {
 	if gr.ID == "" {
 		gr.ID = bunx.NewUUIDv7()
 	}
 
-	_, err := r.db.NewInsert().
+	_, err := db.NewInsert().
 		Model(gr).
 		Exec(ctx)
 	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("create group role: %w", ErrAlreadyExists)
+		}
 		return fmt.Errorf("create group role: %w", err)
 	}
 	return nil
@@ -428,3 +467,40 @@ func (r *BunGroupRoleRepository) List(ctx context.Context) ([]models.GroupRole,
 	}
 	return groupRoles, nil
 }
+
+// checkAssignment locks the role and runs check against its current
+// assignments. The lock is a no-op update, which takes a row lock on
+// PostgreSQL and the database write lock on SQLite, so concurrent
+// assignments of the same role are checked one after another.
+func checkAssignment(ctx context.Context, tx bun.Tx, roleID string, check AssignmentCheck) error {
+	if check == nil {
+		return nil
+	}
+
+	res, err := tx.NewUpdate().
+		Model((*models.Role)(nil)).
+		Set("id = id").
+		Where("id = ?", roleID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("lock role: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("role %w: %s", ErrNotFound, roleID)
+	}
+
+	role := new(models.Role)
+	if err := tx.NewSelect().Model(role).Where("id = ?", roleID).Scan(ctx); err != nil {
+		return fmt.Errorf("get role: %w", err)
+	}
+	var userRoles []models.UserRole
+	if err := tx.NewSelect().Model(&userRoles).Where("role_id = ?", roleID).Scan(ctx); err != nil {
+		return fmt.Errorf("list user roles: %w", err)
+	}
+	var groupRoles []models.GroupRole
+	if err := tx.NewSelect().Model(&groupRoles).Where("role_id = ?", roleID).Scan(ctx); err != nil {
+		return fmt.Errorf("list group roles: %w", err)
+	}
+
+	return check(ctx, role, userRoles, groupRoles)
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestBunRoleRepository_Errors(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	roles := NewBunRoleRepository(db)
+	role := &models.Role{Name: "viewer", Version: 1}
+	require.NoError(t, roles.Create(ctx, role))
+
+	t.Run("missing role", func(t *testing.T) {
+		_, err := roles.GetByName(ctx, "ghost")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = roles.GetByID(ctx, "ghost")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, roles.Update(ctx, &models.Role{ID: "ghost", Name: "ghost"}), ErrNotFound)
+		assert.ErrorIs(t, roles.Delete(ctx, "ghost"), ErrNotFound)
+	})
+
+	t.Run("duplicate role", func(t *testing.T) {
+		assert.ErrorIs(t, roles.Create(ctx, &models.Role{Name: "viewer", Version: 1}), ErrAlreadyExists)
+	})
+
+	t.Run("duplicate assignment", func(t *testing.T) {
+		userRoles := NewBunUserRoleRepository(db)
+		userID := createTestUser(t, db, "alice@example.com")
+		require.NoError(t, userRoles.Create(ctx, &models.UserRole{UserID: &userID, RoleID: role.ID, AssignedBy: testSystemUserID}))
+		err := userRoles.Create(ctx, &models.UserRole{UserID: &userID, RoleID: role.ID, AssignedBy: testSystemUserID})
+		assert.ErrorIs(t, err, ErrAlreadyExists)
+	})
+}
+
+func TestBunUserRoleRepository_CreateChecked(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	maxAssignments := 2
+	role := &models.Role{Name: "org-admin", Version: 1, MaxAssignments: &maxAssignments}
+	require.NoError(t, NewBunRoleRepository(db).Create(ctx, role))
+
+	errCapped := errors.New("capped")
+	withinCap := func(ctx context.Context, role *models.Role, userRoles []models.UserRole, groupRoles []models.GroupRole) error {
+		if len(userRoles)+len(groupRoles) >= *role.MaxAssignments {
+			return errCapped
+		}
+		return nil
+	}
+
+	t.Run("concurrent assignments respect the cap", func(t *testing.T) {
+		userRoles := NewBunUserRoleRepository(db)
+		userIDs := make([]string, 5)
+		for i := range userIDs {
+			userIDs[i] = createTestUser(t, db, fmt.Sprintf("user-%d@example.com", i))
+		}
+		var wg sync.WaitGroup
+		errs := make([]error, len(userIDs))
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = userRoles.CreateChecked(ctx, &models.UserRole{UserID: &userIDs[i], RoleID: role.ID, AssignedBy: testSystemUserID}, withinCap)
+			}()
+		}
+		wg.Wait()
+
+		rejected := 0
+		for _, err := range errs {
+			if err != nil {
+				assert.ErrorIs(t, err, errCapped)
+				rejected++
+			}
+		}
+		assert.Equal(t, 3, rejected)
+		assigned, err := userRoles.GetByRoleID(ctx, role.ID)
+		require.NoError(t, err)
+		assert.Len(t, assigned, 2)
+	})
+
+	t.Run("group mappings count toward the cap", func(t *testing.T) {
+		err := NewBunGroupRoleRepository(db).CreateChecked(ctx, &models.GroupRole{GroupName: "admins", RoleID: role.ID, AssignedBy: testSystemUserID}, withinCap)
+		assert.ErrorIs(t, err, errCapped)
+		mapped, err := NewBunGroupRoleRepository(db).GetByRoleID(ctx, role.ID)
+		require.NoError(t, err)
+		assert.Empty(t, mapped)
+	})
+
+	t.Run("missing role", func(t *testing.T) {
+		userID := createTestUser(t, db, "user-x@example.com")
+		err := NewBunUserRoleRepository(db).CreateChecked(ctx, &models.UserRole{UserID: &userID, RoleID: "ghost", AssignedBy: testSystemUserID}, withinCap)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+// BunSchemaRegistryRepository persists shared output schemas using Bun ORM.
+type BunSchemaRegistryRepository struct {
+	db *bun.DB
+}
+
+// NewBunSchemaRegistryRepository constructs a repository backed by Bun.
+func NewBunSchemaRegistryRepository(db *bun.DB) SchemaRegistryRepository {
+	return &BunSchemaRegistryRepository{db: db}
+}
+
+// Create inserts a new registry entry.
+func (r *BunSchemaRegistryRepository) Create(ctx context.Context, entry *models.SchemaRegistryEntry) error {
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	if _, err := r.db.NewInsert().Model(entry).Exec(ctx); err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("schema %s already exists", entry.Key)
+		}
+		return fmt.Errorf("insert schema %s: %w", entry.Key, err)
+	}
+	return nil
+}
+
+// Get fetches a registry entry by key.
+func (r *BunSchemaRegistryRepository) Get(ctx context.Context, key string) (*models.SchemaRegistryEntry, error) {
+	return getRegisteredSchema(ctx, r.db, key)
+}
+
+// List returns all registry entries ordered by key.
+func (r *BunSchemaRegistryRepository) List(ctx context.Context) ([]models.SchemaRegistryEntry, error) {
+	var entries []models.SchemaRegistryEntry
+	err := r.db.NewSelect().
+		Model(&entries).
+		Order("key ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list schemas: %w", err)
+	}
+	return entries, nil
+}
+
+// Update replaces an entry's schema, and its description when one is given,
+// and rewrites the schema copy, adding a schema version, on every output
+// referencing the entry in the same transaction.
+func (r *BunSchemaRegistryRepository) Update(ctx context.Context, key, schemaJSON string, description *string) (*models.SchemaRegistryEntry, error) {
+	var updated *models.SchemaRegistryEntry
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		entry, err := getRegisteredSchema(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		entry.SchemaJSON = schemaJSON
+		if description != nil {
+			entry.Description = *description
+		}
+		entry.UpdatedAt = now
+
+		_, err = tx.NewUpdate().
+			Model(entry).
+			Column("schema_json", "description", "updated_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("update schema %s: %w", key, err)
+		}
+
+		var outputs []models.StateOutput
+		err = tx.NewUpdate().
+			Model(&outputs).
+			Set("schema_json = ?", schemaJSON).
+			Set("updated_at = ?", now).
+			Where("schema_ref = ?", key).
+			Returning("state_guid, output_key").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("update outputs referencing schema %s: %w", key, err)
+		}
+		for _, output := range outputs {
+			if err := recordSchemaVersion(ctx, tx, output.StateGUID, output.OutputKey, now); err != nil {
+				return fmt.Errorf("record schema version for output %s in state %s: %w", output.OutputKey, output.StateGUID, err)
+			}
+		}
+
+		updated = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete removes a registry entry. Entries still referenced by an output
+// cannot be deleted; the schema_ref foreign key enforces this against
+// references added concurrently.
+func (r *BunSchemaRegistryRepository) Delete(ctx context.Context, key string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		refs, err := tx.NewSelect().
+			Model((*models.StateOutput)(nil)).
+			Where("schema_ref = ?", key).
+			Count(ctx)
+		if err != nil {
+			return fmt.Errorf("count references to schema %s: %w", key, err)
+		}
+		if refs > 0 {
+			return fmt.Errorf("%w: schema %s is referenced by %d outputs", ErrSchemaInUse, key, refs)
+		}
+
+		result, err := tx.NewDelete().
+			Model((*models.SchemaRegistryEntry)(nil)).
+			Where("key = ?", key).
+			Exec(ctx)
+		if isForeignKeyError(err) {
+			// An output started referencing the entry after the count
+			return fmt.Errorf("%w: schema %s is referenced by an output", ErrSchemaInUse, key)
+		}
+		if err != nil {
+			return fmt.Errorf("delete schema %s: %w", key, err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return fmt.Errorf("schema %s %w", key, ErrNotFound)
+		}
+		return nil
+	})
+}
+
+func getRegisteredSchema(ctx context.Context, db bun.IDB, key string) (*models.SchemaRegistryEntry, error) {
+	entry := new(models.SchemaRegistryEntry)
+	err := db.NewSelect().
+		Model(entry).
+		Where("key = ?", key).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("schema %s %w", key, ErrNotFound)
+		}
+		return nil, fmt.Errorf("get schema %s: %w", key, err)
+	}
+	return entry, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/bunx"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
@@ -36,6 +37,40 @@ func (r *BunServiceAccountRepository) Create(ctx context.Context, sa *models.Ser
 	return nil
 }
 
+// CreateWithRoles inserts a new service account together with its role
+// assignments in a single transaction, so a failed assignment leaves no
+// account behind. The ServiceAccountID of each assignment is set to the new
+// account's ID. check, when non-nil, vets each assignment as CreateChecked does.
+func (r *BunServiceAccountRepository) CreateWithRoles(ctx context.Context, sa *models.ServiceAccount, roles []models.UserRole, check AssignmentCheck) error {
+	if sa.ID == "" {
+		sa.ID = bunx.NewUUIDv7()
+	}
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(sa).Exec(ctx); err != nil {
+			return fmt.Errorf("create service account: %w", err)
+		}
+
+		for i := range roles {
+			ur := &roles[i]
+			if ur.ID == "" {
+				ur.ID = bunx.NewUUIDv7()
+			}
+			ur.UserID, ur.ServiceAccountID = nil, &sa.ID
+			if err := checkAssignment(ctx, tx, ur.RoleID, check); err != nil {
+				return err
+			}
+			if _, err := tx.NewInsert().Model(ur).Exec(ctx); err != nil {
+				if isDuplicateKeyError(err) {
+					return fmt.Errorf("create user role: %w", ErrAlreadyExists)
+				}
+				return fmt.Errorf("create user role: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
 // GetByID retrieves a service account by ID
 func (r *BunServiceAccountRepository) GetByID(ctx context.Context, id string) (*models.ServiceAccount, error) {
 	sa := new(models.ServiceAccount)
@@ -119,12 +154,15 @@ func (r *BunServiceAccountRepository) UpdateLastUsed(ctx context.Context, id str
 	return nil
 }
 
-// UpdateSecretHash updates the client secret hash (for rotation)
+// UpdateSecretHash updates the client secret hash (for rotation). The old
+// secret, and any previous secret still in overlap, stop working at once.
 func (r *BunServiceAccountRepository) UpdateSecretHash(ctx context.Context, id string, secretHash string) error {
 	_, err := r.db.NewUpdate().
 		Model((*models.ServiceAccount)(nil)).
 		Set("client_secret_hash = ?", secretHash).
 		Set("secret_rotated_at = ?", time.Now()).
+		Set("previous_secret_hash = NULL").
+		Set("previous_secret_expires_at = NULL").
 		Where("id = ?", id).
 		Exec(ctx)
 	if err != nil {
@@ -133,6 +171,40 @@ func (r *BunServiceAccountRepository) UpdateSecretHash(ctx context.Context, id s
 	return nil
 }
 
+// UpdateSecretHashWithOverlap updates the client secret hash and keeps the
+// current one as the previous secret, valid until previousExpiresAt
+func (r *BunServiceAccountRepository) UpdateSecretHashWithOverlap(ctx context.Context, id string, secretHash string, previousExpiresAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.ServiceAccount)(nil)).
+		Set("previous_secret_hash = client_secret_hash").
+		Set("previous_secret_expires_at = ?", previousExpiresAt).
+		Set("client_secret_hash = ?", secretHash).
+		Set("secret_rotated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("update secret hash with overlap: %w", err)
+	}
+	return nil
+}
+
+// ClearPreviousSecret drops the previous secret once its overlap has ended.
+// The expiry is re-checked in the UPDATE so a previous secret set by a
+// rotation after the caller read the account is left alone.
+func (r *BunServiceAccountRepository) ClearPreviousSecret(ctx context.Context, id string, now time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.ServiceAccount)(nil)).
+		Set("previous_secret_hash = NULL").
+		Set("previous_secret_expires_at = NULL").
+		Where("id = ?", id).
+		Where("previous_secret_expires_at <= ?", now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("clear previous secret: %w", err)
+	}
+	return nil
+}
+
 // List retrieves all service accounts
 func (r *BunServiceAccountRepository) List(ctx context.Context) ([]models.ServiceAccount, error) {
 	var accounts []models.ServiceAccount
@@ -160,6 +232,42 @@ func (r *BunServiceAccountRepository) ListByCreator(ctx context.Context, created
 	return accounts, nil
 }
 
+// ListFiltered retrieves service accounts matching filter, ordered by ID
+// (UUIDv7, so creation order) for cursor pagination
+func (r *BunServiceAccountRepository) ListFiltered(ctx context.Context, filter ServiceAccountFilter) ([]models.ServiceAccount, error) {
+	var accounts []models.ServiceAccount
+	q := r.db.NewSelect().
+		Model(&accounts).
+		Order("id ASC")
+
+	if filter.NamePrefix != "" {
+		// substr rather than LIKE: no wildcard escaping, case-sensitive on both dialects
+		q = q.Where("substr(name, 1, ?) = ?", utf8.RuneCountInString(filter.NamePrefix), filter.NamePrefix)
+	}
+	if filter.Disabled != nil {
+		q = q.Where("disabled = ?", *filter.Disabled)
+	}
+	if filter.CreatedBy != "" {
+		q = q.Where("created_by = ?", filter.CreatedBy)
+	}
+	if filter.LastUsedBefore != nil {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("last_used_at IS NULL").WhereOr("last_used_at < ?", *filter.LastUsedBefore)
+		})
+	}
+	if filter.AfterID != "" {
+		q = q.Where("id > ?", filter.AfterID)
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("list filtered service accounts: %w", err)
+	}
+	return accounts, nil
+}
+
 // SetDisabled updates the disabled status of a service account
 func (r *BunServiceAccountRepository) SetDisabled(ctx context.Context, id string, disabled bool) error {
 	_, err := r.db.NewUpdate().
@@ -172,3 +280,25 @@ func (r *BunServiceAccountRepository) SetDisabled(ctx context.Context, id string
 	}
 	return nil
 }
+
+// Delete removes a service account
+func (r *BunServiceAccountRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.NewDelete().
+		Model((*models.ServiceAccount)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete service account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("service account not found: %s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestBunServiceAccountRepository_ListFiltered(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	repo := NewBunServiceAccountRepository(db)
+	alice, bob := createTestUser(t, db, "alice@example.com"), createTestUser(t, db, "bob@example.com")
+	now := time.Now()
+	accounts := []*models.ServiceAccount{
+		{Name: "ci-deploy", CreatedBy: alice, LastUsedAt: now},
+		{Name: "ci-plan", CreatedBy: alice, LastUsedAt: now.Add(-90 * 24 * time.Hour)},
+		{Name: "ci_%", CreatedBy: bob, Disabled: true},
+		{Name: "CI-upper", CreatedBy: bob, LastUsedAt: now.Add(-time.Hour)},
+		{Name: "nightly-backup", CreatedBy: bob, LastUsedAt: now.Add(-30 * 24 * time.Hour), Disabled: true},
+	}
+	for _, sa := range accounts {
+		sa.ClientID = sa.Name + "-client"
+		sa.ClientSecretHash = "hash"
+		require.NoError(t, repo.Create(ctx, sa))
+	}
+
+	names := func(filter ServiceAccountFilter) []string {
+		t.Helper()
+		got, err := repo.ListFiltered(ctx, filter)
+		require.NoError(t, err)
+		out := make([]string, len(got))
+		for i, sa := range got {
+			out[i] = sa.Name
+		}
+		return out
+	}
+	disabled, enabled := true, false
+	cutoff := now.Add(-7 * 24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		filter ServiceAccountFilter
+		want   []string
+	}{
+		{name: "no filter", filter: ServiceAccountFilter{}, want: []string{"ci-deploy", "ci-plan", "ci_%", "CI-upper", "nightly-backup"}},
+		{name: "name prefix", filter: ServiceAccountFilter{NamePrefix: "ci-"}, want: []string{"ci-deploy", "ci-plan"}},
+		{name: "prefix wildcards are literal", filter: ServiceAccountFilter{NamePrefix: "ci_"}, want: []string{"ci_%"}},
+		{name: "prefix is case-sensitive", filter: ServiceAccountFilter{NamePrefix: "CI"}, want: []string{"CI-upper"}},
+		{name: "disabled", filter: ServiceAccountFilter{Disabled: &disabled}, want: []string{"ci_%", "nightly-backup"}},
+		{name: "enabled", filter: ServiceAccountFilter{Disabled: &enabled}, want: []string{"ci-deploy", "ci-plan", "CI-upper"}},
+		{name: "created by", filter: ServiceAccountFilter{CreatedBy: alice}, want: []string{"ci-deploy", "ci-plan"}},
+		{name: "last used before includes never used", filter: ServiceAccountFilter{LastUsedBefore: &cutoff}, want: []string{"ci-plan", "ci_%", "nightly-backup"}},
+		{name: "combined", filter: ServiceAccountFilter{CreatedBy: bob, Disabled: &disabled, LastUsedBefore: &cutoff}, want: []string{"ci_%", "nightly-backup"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(tt.filter))
+		})
+	}
+
+	t.Run("pages by ID", func(t *testing.T) {
+		var pages [][]string
+		after := ""
+		for {
+			page, err := repo.ListFiltered(ctx, ServiceAccountFilter{CreatedBy: bob, AfterID: after, Limit: 2})
+			require.NoError(t, err)
+			if len(page) == 0 {
+				break
+			}
+			var pageNames []string
+			for _, sa := range page {
+				pageNames = append(pageNames, sa.Name)
+			}
+			pages = append(pages, pageNames)
+			after = page[len(page)-1].ID
+		}
+		assert.Equal(t, [][]string{{"ci_%", "CI-upper"}, {"nightly-backup"}}, pages)
+	})
+}
+
+func TestBunServiceAccountRepository_CreateWithRoles(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+	repo := NewBunServiceAccountRepository(db)
+	for _, id := range []string{"role-ci", "role-plan"} {
+		require.NoError(t, NewBunRoleRepository(db).Create(ctx, &models.Role{ID: id, Name: id, Version: 1}))
+	}
+
+	t.Run("account and assignments are inserted together", func(t *testing.T) {
+		sa := &models.ServiceAccount{Name: "ci-deploy", ClientID: "ci-deploy-client", ClientSecretHash: "hash", CreatedBy: testSystemUserID}
+		roles := []models.UserRole{{RoleID: "role-ci", AssignedBy: testSystemUserID}, {RoleID: "role-plan", AssignedBy: testSystemUserID}}
+		require.NoError(t, repo.CreateWithRoles(ctx, sa, roles, nil))
+
+		var assigned []models.UserRole
+		require.NoError(t, db.NewSelect().Model(&assigned).Where("service_account_id = ?", sa.ID).Order("role_id").Scan(ctx))
+		require.Len(t, assigned, 2)
+		assert.Equal(t, "role-ci", assigned[0].RoleID)
+		assert.Equal(t, "role-plan", assigned[1].RoleID)
+	})
+
+	t.Run("failed assignment leaves no account", func(t *testing.T) {
+		sa := &models.ServiceAccount{Name: "ci-broken", ClientID: "ci-broken-client", ClientSecretHash: "hash", CreatedBy: testSystemUserID}
+		roles := []models.UserRole{{ID: "ur-dup", RoleID: "role-ci", AssignedBy: testSystemUserID}, {ID: "ur-dup", RoleID: "role-plan", AssignedBy: testSystemUserID}}
+		require.Error(t, repo.CreateWithRoles(ctx, sa, roles, nil))
+
+		count, err := db.NewSelect().Model((*models.ServiceAccount)(nil)).Where("name = ?", "ci-broken").Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count, "the account insert is rolled back")
+		count, err = db.NewSelect().Model((*models.UserRole)(nil)).Where("id = ?", "ur-dup").Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestBunServiceAccountRepository_ClearPreviousSecret(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+	repo := NewBunServiceAccountRepository(db)
+
+	sa := &models.ServiceAccount{Name: "ci-rotate", ClientID: "ci-rotate-client", ClientSecretHash: "hash-1", CreatedBy: testSystemUserID}
+	require.NoError(t, repo.Create(ctx, sa))
+	now := time.Now()
+
+	// A rotation after the caller saw the expired overlap starts a new one
+	require.NoError(t, repo.UpdateSecretHashWithOverlap(ctx, sa.ID, "hash-2", now.Add(time.Hour)))
+	require.NoError(t, repo.ClearPreviousSecret(ctx, sa.ID, now))
+
+	got, err := repo.GetByID(ctx, sa.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.PreviousSecretHash, "the fresh overlap survives")
+	assert.Equal(t, "hash-1", *got.PreviousSecretHash)
+
+	require.NoError(t, repo.ClearPreviousSecret(ctx, sa.ID, now.Add(2*time.Hour)))
+	got, err = repo.GetByID(ctx, sa.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.PreviousSecretHash)
+	assert.Nil(t, got.PreviousSecretExpiresAt)
+}
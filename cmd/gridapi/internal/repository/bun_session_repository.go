@@ -50,7 +50,7 @@ func (r *BunSessionRepository) GetByID(ctx context.Context, id string) (*models.
 		Scan(ctx)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("session not found: %s", id)
+			return nil, fmt.Errorf("session %w: %s", ErrNotFound, id)
 		}
 		return nil, fmt.Errorf("get session: %w", err)
 	}
@@ -67,7 +67,7 @@ func (r *BunSessionRepository) GetByTokenHash(ctx context.Context, tokenHash str
 		Scan(ctx)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("session not found")
+			return nil, fmt.Errorf("session %w", ErrNotFound)
 		}
 		return nil, fmt.Errorf("get session by token: %w", err)
 	}
@@ -181,3 +181,83 @@ func (r *BunSessionRepository) List(ctx context.Context) ([]models.Session, erro
 	}
 	return sessions, nil
 }
+
+// ListFiltered retrieves sessions matching filter, newest first (admin operation)
+func (r *BunSessionRepository) ListFiltered(ctx context.Context, filter SessionFilter) ([]models.Session, error) {
+	var sessions []models.Session
+	q := r.db.NewSelect().
+		Model(&sessions).
+		Order("created_at DESC", "id ASC")
+	q = applySessionFilter(q, filter)
+
+	if filter.PageSize > 0 {
+		q = q.Limit(filter.PageSize)
+	}
+	if filter.Offset > 0 {
+		q = q.Offset(filter.Offset)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("list filtered sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// RevokeFiltered revokes sessions matching filter (incident response).
+// Each UPDATE touches at most batchSize rows so large revocations don't hold
+// long row locks; batches repeat until no matching unrevoked sessions remain.
+func (r *BunSessionRepository) RevokeFiltered(ctx context.Context, filter SessionFilter, batchSize int) (int, error) {
+	total := 0
+	for {
+		batch := r.db.NewSelect().
+			Model((*models.Session)(nil)).
+			Column("id").
+			Where("revoked = ?", false).
+			Limit(batchSize)
+		batch = applySessionFilter(batch, filter)
+
+		result, err := r.db.NewUpdate().
+			Model((*models.Session)(nil)).
+			Set("revoked = ?", true).
+			Where("id IN (?)", batch).
+			Exec(ctx)
+		if err != nil {
+			return total, fmt.Errorf("revoke filtered sessions: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("revoke filtered sessions: %w", err)
+		}
+		total += int(rows)
+		if rows < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// applySessionFilter adds the filter's match conditions (not paging) to q.
+func applySessionFilter(q *bun.SelectQuery, filter SessionFilter) *bun.SelectQuery {
+	if filter.UserID != "" {
+		q = q.Where("user_id = ?", filter.UserID)
+	}
+	if filter.ServiceAccountID != "" {
+		q = q.Where("service_account_id = ?", filter.ServiceAccountID)
+	}
+	if filter.ActiveOnly {
+		q = q.Where("revoked = ?", false).Where("expires_at > ?", time.Now())
+	}
+	if filter.RevokedOnly {
+		q = q.Where("revoked = ?", true)
+	}
+	if filter.CreatedAfter != nil {
+		q = q.Where("created_at > ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		q = q.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.ExcludeID != "" {
+		q = q.Where("id <> ?", filter.ExcludeID)
+	}
+	return q
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+// BunStateIdempotencyKeyRepository implements StateIdempotencyKeyRepository using Bun ORM
+type BunStateIdempotencyKeyRepository struct {
+	db *bun.DB
+}
+
+// NewBunStateIdempotencyKeyRepository creates a new Bun-based idempotency key repository
+func NewBunStateIdempotencyKeyRepository(db *bun.DB) StateIdempotencyKeyRepository {
+	return &BunStateIdempotencyKeyRepository{db: db}
+}
+
+// Get returns the unexpired record for key.
+func (r *BunStateIdempotencyKeyRepository) Get(ctx context.Context, key string) (*models.StateIdempotencyKey, error) {
+	record := new(models.StateIdempotencyKey)
+	err := r.db.NewSelect().
+		Model(record).
+		Where("key = ?", key).
+		Where("expires_at > ?", time.Now()).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("idempotency key not found")
+		}
+		return nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+	return record, nil
+}
+
+// Put stores a key, replacing any expired record with the same key. An
+// unexpired record is left untouched, so the first caller's state wins.
+func (r *BunStateIdempotencyKeyRepository) Put(ctx context.Context, record *models.StateIdempotencyKey) error {
+	_, err := r.db.NewInsert().
+		Model(record).
+		On("CONFLICT (key) DO UPDATE").
+		Set("request_hash = EXCLUDED.request_hash").
+		Set("state_guid = EXCLUDED.state_guid").
+		Set("expires_at = EXCLUDED.expires_at").
+		Set("created_at = EXCLUDED.created_at").
+		Where("sik.expires_at <= ?", time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("put idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes keys whose TTL has passed.
+func (r *BunStateIdempotencyKeyRepository) DeleteExpired(ctx context.Context) error {
+	_, err := r.db.NewDelete().
+		Model((*models.StateIdempotencyKey)(nil)).
+		Where("expires_at <= ?", time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return nil
+}
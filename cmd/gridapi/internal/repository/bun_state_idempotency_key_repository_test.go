@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestBunStateIdempotencyKeyRepository(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := NewBunStateRepository(db)
+	repo := NewBunStateIdempotencyKeyRepository(db)
+
+	newState := func(logicID string) string {
+		guid := uuid.Must(uuid.NewV7()).String()
+		require.NoError(t, stateRepo.Create(ctx, &models.State{GUID: guid, LogicID: logicID}))
+		return guid
+	}
+	first := newState("test-idempotency-first")
+	second := newState("test-idempotency-second")
+
+	t.Run("unknown key is not found", func(t *testing.T) {
+		_, err := repo.Get(ctx, "missing")
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("unexpired key is kept", func(t *testing.T) {
+		require.NoError(t, repo.Put(ctx, &models.StateIdempotencyKey{
+			Key: "live", RequestHash: "hash-1", StateGUID: first, ExpiresAt: time.Now().Add(time.Hour),
+		}))
+		// A second writer must not steal a live key
+		require.NoError(t, repo.Put(ctx, &models.StateIdempotencyKey{
+			Key: "live", RequestHash: "hash-2", StateGUID: second, ExpiresAt: time.Now().Add(time.Hour),
+		}))
+
+		got, err := repo.Get(ctx, "live")
+		require.NoError(t, err)
+		assert.Equal(t, first, got.StateGUID)
+		assert.Equal(t, "hash-1", got.RequestHash)
+	})
+
+	t.Run("expired key is ignored and replaceable", func(t *testing.T) {
+		require.NoError(t, repo.Put(ctx, &models.StateIdempotencyKey{
+			Key: "stale", RequestHash: "hash-1", StateGUID: first, ExpiresAt: time.Now().Add(-time.Minute),
+		}))
+		_, err := repo.Get(ctx, "stale")
+		assert.ErrorContains(t, err, "not found")
+
+		require.NoError(t, repo.Put(ctx, &models.StateIdempotencyKey{
+			Key: "stale", RequestHash: "hash-2", StateGUID: second, ExpiresAt: time.Now().Add(time.Hour),
+		}))
+		got, err := repo.Get(ctx, "stale")
+		require.NoError(t, err)
+		assert.Equal(t, second, got.StateGUID)
+	})
+
+	t.Run("DeleteExpired removes only expired keys", func(t *testing.T) {
+		require.NoError(t, repo.Put(ctx, &models.StateIdempotencyKey{
+			Key: "old", RequestHash: "hash-1", StateGUID: first, ExpiresAt: time.Now().Add(-time.Minute),
+		}))
+		require.NoError(t, repo.DeleteExpired(ctx))
+
+		count, err := db.NewSelect().Model((*models.StateIdempotencyKey)(nil)).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count) // "live" and "stale"
+	})
+}
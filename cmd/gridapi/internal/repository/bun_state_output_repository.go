@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
@@ -86,9 +89,12 @@ func (r *BunStateOutputRepository) UpsertOutputs(ctx context.Context, stateGUID
 			if existing, ok := existingByKey[out.Key]; ok {
 				model.SchemaJSON = existing.SchemaJSON
 				model.SchemaSource = existing.SchemaSource
+				model.SchemaRef = existing.SchemaRef
+				model.SchemaVersion = existing.SchemaVersion
 				model.ValidationStatus = existing.ValidationStatus
 				model.ValidationError = existing.ValidationError
 				model.ValidatedAt = existing.ValidatedAt
+				model.ValidatedSchemaVersion = existing.ValidatedSchemaVersion
 			}
 			outputModels = append(outputModels, model)
 		}
@@ -103,9 +109,12 @@ func (r *BunStateOutputRepository) UpsertOutputs(ctx context.Context, stateGUID
 			Set("updated_at = EXCLUDED.updated_at").
 			Set("schema_json = EXCLUDED.schema_json").
 			Set("schema_source = EXCLUDED.schema_source").
+			Set("schema_ref = EXCLUDED.schema_ref").
+			Set("schema_version = EXCLUDED.schema_version").
 			Set("validation_status = EXCLUDED.validation_status").
 			Set("validation_error = EXCLUDED.validation_error").
 			Set("validated_at = EXCLUDED.validated_at").
+			Set("validated_schema_version = EXCLUDED.validated_schema_version").
 			Exec(ctx)
 		if err != nil {
 			return fmt.Errorf("upsert outputs: %w", err)
@@ -131,20 +140,91 @@ func (r *BunStateOutputRepository) GetOutputsByState(ctx context.Context, stateG
 	outputs := make([]OutputKey, len(dbOutputs))
 	for i, dbOut := range dbOutputs {
 		outputs[i] = OutputKey{
-			Key:              dbOut.OutputKey,
-			Sensitive:        dbOut.Sensitive,
-			StateSerial:      dbOut.StateSerial,
-			SchemaJSON:       dbOut.SchemaJSON,
-			SchemaSource:     dbOut.SchemaSource,
-			ValidationStatus: dbOut.ValidationStatus,
-			ValidationError:  dbOut.ValidationError,
-			ValidatedAt:      dbOut.ValidatedAt,
+			Key:                    dbOut.OutputKey,
+			Sensitive:              dbOut.Sensitive,
+			StateSerial:            dbOut.StateSerial,
+			SchemaJSON:             dbOut.SchemaJSON,
+			SchemaSource:           dbOut.SchemaSource,
+			SchemaRef:              dbOut.SchemaRef,
+			SchemaVersion:          dbOut.SchemaVersion,
+			ValidationStatus:       dbOut.ValidationStatus,
+			ValidationError:        dbOut.ValidationError,
+			ValidatedAt:            dbOut.ValidatedAt,
+			ValidatedSchemaVersion: dbOut.ValidatedSchemaVersion,
 		}
 	}
 
 	return outputs, nil
 }
 
+// GetOutputsByStates returns cached outputs for several states in one query.
+func (r *BunStateOutputRepository) GetOutputsByStates(ctx context.Context, stateGUIDs []string) (map[string][]OutputKey, error) {
+	result := make(map[string][]OutputKey, len(stateGUIDs))
+	if len(stateGUIDs) == 0 {
+		return result, nil
+	}
+
+	var dbOutputs []models.StateOutput
+	err := r.db.NewSelect().
+		Model(&dbOutputs).
+		Where("state_guid IN (?)", bun.In(stateGUIDs)).
+		Order("state_guid ASC", "output_key ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query outputs for %d states: %w", len(stateGUIDs), err)
+	}
+
+	for _, dbOut := range dbOutputs {
+		result[dbOut.StateGUID] = append(result[dbOut.StateGUID], OutputKey{
+			Key:                    dbOut.OutputKey,
+			Sensitive:              dbOut.Sensitive,
+			StateSerial:            dbOut.StateSerial,
+			SchemaJSON:             dbOut.SchemaJSON,
+			SchemaSource:           dbOut.SchemaSource,
+			SchemaRef:              dbOut.SchemaRef,
+			SchemaVersion:          dbOut.SchemaVersion,
+			ValidationStatus:       dbOut.ValidationStatus,
+			ValidationError:        dbOut.ValidationError,
+			ValidatedAt:            dbOut.ValidatedAt,
+			ValidatedSchemaVersion: dbOut.ValidatedSchemaVersion,
+		})
+	}
+
+	return result, nil
+}
+
+// GetOutputsBySchema returns outputs whose schema_json equals schemaJSON, keyed by state GUID.
+func (r *BunStateOutputRepository) GetOutputsBySchema(ctx context.Context, schemaJSON string) (map[string][]OutputKey, error) {
+	var dbOutputs []models.StateOutput
+	err := r.db.NewSelect().
+		Model(&dbOutputs).
+		Where("schema_json = ?", schemaJSON).
+		Order("state_guid ASC", "output_key ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query outputs by schema: %w", err)
+	}
+
+	result := make(map[string][]OutputKey)
+	for _, dbOut := range dbOutputs {
+		result[dbOut.StateGUID] = append(result[dbOut.StateGUID], OutputKey{
+			Key:                    dbOut.OutputKey,
+			Sensitive:              dbOut.Sensitive,
+			StateSerial:            dbOut.StateSerial,
+			SchemaJSON:             dbOut.SchemaJSON,
+			SchemaSource:           dbOut.SchemaSource,
+			SchemaRef:              dbOut.SchemaRef,
+			SchemaVersion:          dbOut.SchemaVersion,
+			ValidationStatus:       dbOut.ValidationStatus,
+			ValidationError:        dbOut.ValidationError,
+			ValidatedAt:            dbOut.ValidatedAt,
+			ValidatedSchemaVersion: dbOut.ValidatedSchemaVersion,
+		})
+	}
+
+	return result, nil
+}
+
 // SearchOutputsByKey finds all states with output matching key (exact match).
 func (r *BunStateOutputRepository) SearchOutputsByKey(ctx context.Context, outputKey string) ([]StateOutputRef, error) {
 	var dbOutputs []models.StateOutput
@@ -253,6 +333,7 @@ func (r *BunStateOutputRepository) GetOutputSchema(ctx context.Context, stateGUI
 // SetOutputSchemaWithSource sets or updates the JSON Schema with source tracking.
 // source must be "manual" or "inferred".
 // Creates the output record if it doesn't exist (with state_serial=0, sensitive=false).
+// An "inferred" write is a silent no-op when the output already has a manual schema.
 // expectedSerial: For inferred schemas, verifies output still exists at this serial before writing.
 //
 //	Use -1 for manual schemas to skip serial check (always write).
@@ -277,7 +358,82 @@ func (r *BunStateOutputRepository) SetOutputSchemaWithSource(ctx context.Context
 		}
 	}
 
-	// Use INSERT ... ON CONFLICT to upsert the schema with source
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return upsertOutputSchema(ctx, tx, stateGUID, outputKey, schemaJSON, source, nil, now)
+	})
+	if err != nil {
+		return fmt.Errorf("set schema with source %s for output %s in state %s: %w", source, outputKey, stateGUID, err)
+	}
+
+	return nil
+}
+
+// SetOutputSchemaRef sets a manual schema from the schema registry entry key.
+// The registered schema is copied onto the output alongside the key, so
+// validation and schema reads see it like any other manual schema.
+func (r *BunStateOutputRepository) SetOutputSchemaRef(ctx context.Context, stateGUID, outputKey, schemaRef string) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		entry, err := getRegisteredSchema(ctx, tx, schemaRef)
+		if err != nil {
+			return err
+		}
+		return upsertOutputSchema(ctx, tx, stateGUID, outputKey, entry.SchemaJSON, "manual", &schemaRef, time.Now())
+	})
+	if err != nil {
+		return fmt.Errorf("set schema ref %s for output %s in state %s: %w", schemaRef, outputKey, stateGUID, err)
+	}
+	return nil
+}
+
+// SetOutputSchemas sets manual schemas for several outputs in a single transaction.
+// Creates output records that don't exist yet (with state_serial=0, sensitive=false).
+// Returns the set of keys that had no schema before the call; the rest were replaced.
+func (r *BunStateOutputRepository) SetOutputSchemas(ctx context.Context, stateGUID string, schemas map[string]string) (map[string]bool, error) {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	created := make(map[string]bool, len(keys))
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var existing []models.StateOutput
+		err := tx.NewSelect().
+			Model(&existing).
+			Where("state_guid = ?", stateGUID).
+			Where("output_key IN (?)", bun.In(keys)).
+			Where("schema_json IS NOT NULL").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("load existing schemas: %w", err)
+		}
+		hasSchema := make(map[string]bool, len(existing))
+		for _, out := range existing {
+			hasSchema[out.OutputKey] = true
+		}
+
+		now := time.Now()
+		for _, key := range keys {
+			if err := upsertOutputSchema(ctx, tx, stateGUID, key, schemas[key], "manual", nil, now); err != nil {
+				return fmt.Errorf("set schema for output %s: %w", key, err)
+			}
+			created[key] = !hasSchema[key]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set schemas for state %s: %w", stateGUID, err)
+	}
+
+	return created, nil
+}
+
+// upsertOutputSchema writes a schema, its source and registry key (nil for
+// inline schemas) using INSERT ... ON CONFLICT, then records it in the
+// output's schema history. Run it in a transaction so the two stay in step.
+// Inferred schemas never replace a manual one: the conflict update only
+// applies when the existing row has no schema source or is itself inferred.
+func upsertOutputSchema(ctx context.Context, db bun.IDB, stateGUID, outputKey, schemaJSON, source string, schemaRef *string, now time.Time) error {
 	output := models.StateOutput{
 		StateGUID:    stateGUID,
 		OutputKey:    outputKey,
@@ -285,24 +441,151 @@ func (r *BunStateOutputRepository) SetOutputSchemaWithSource(ctx context.Context
 		StateSerial:  0,     // Default serial for outputs that don't exist in state yet
 		SchemaJSON:   &schemaJSON,
 		SchemaSource: &source,
+		SchemaRef:    schemaRef,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
 
-	_, err := r.db.NewInsert().
+	q := db.NewInsert().
 		Model(&output).
 		On("CONFLICT (state_guid, output_key) DO UPDATE").
 		Set("schema_json = EXCLUDED.schema_json").
 		Set("schema_source = EXCLUDED.schema_source").
-		Set("updated_at = EXCLUDED.updated_at").
-		Exec(ctx)
+		Set("schema_ref = EXCLUDED.schema_ref").
+		Set("updated_at = EXCLUDED.updated_at")
+	if source == "inferred" {
+		// Closes the race between GetOutputsWithoutSchema and this write
+		q = q.Where("so.schema_source IS NULL OR so.schema_source = ?", "inferred")
+	}
+
+	if _, err := q.Exec(ctx); err != nil {
+		return err
+	}
+	return recordSchemaVersion(ctx, db, stateGUID, outputKey, now)
+}
+
+// recordSchemaVersion appends the output's current schema to its history and
+// makes it the active version. Nothing is recorded when the output has no
+// schema or the schema, source and registry key match the active version, so
+// re-setting a schema (or a skipped inferred write) does not add a version.
+func recordSchemaVersion(ctx context.Context, db bun.IDB, stateGUID, outputKey string, now time.Time) error {
+	var output models.StateOutput
+	err := db.NewSelect().
+		Model(&output).
+		Where("state_guid = ?", stateGUID).
+		Where("output_key = ?", outputKey).
+		Scan(ctx)
 	if err != nil {
-		return fmt.Errorf("set schema with source %s for output %s in state %s: %w", source, outputKey, stateGUID, err)
+		return fmt.Errorf("load output for schema history: %w", err)
+	}
+	if output.SchemaJSON == nil {
+		return nil
+	}
+	source := "manual"
+	if output.SchemaSource != nil {
+		source = *output.SchemaSource
+	}
+
+	if output.SchemaVersion != nil {
+		var active models.OutputSchemaVersion
+		err := db.NewSelect().
+			Model(&active).
+			Where("state_guid = ?", stateGUID).
+			Where("output_key = ?", outputKey).
+			Where("version = ?", *output.SchemaVersion).
+			Scan(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("load active schema version: %w", err)
+		}
+		if err == nil && active.SchemaJSON == *output.SchemaJSON && active.SchemaSource == source && equalStringPtr(active.SchemaRef, output.SchemaRef) {
+			return nil
+		}
+	}
+
+	version := models.OutputSchemaVersion{
+		StateGUID:    stateGUID,
+		OutputKey:    outputKey,
+		SchemaJSON:   *output.SchemaJSON,
+		SchemaSource: source,
+		SchemaRef:    output.SchemaRef,
+		CreatedAt:    now,
+	}
+	if err := insertSchemaVersion(ctx, db, &version); err != nil {
+		return err
 	}
 
+	_, err = db.NewUpdate().
+		Model((*models.StateOutput)(nil)).
+		Set("schema_version = ?", version.Version).
+		Where("state_guid = ?", stateGUID).
+		Where("output_key = ?", outputKey).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("set active schema version: %w", err)
+	}
 	return nil
 }
 
+// schemaVersionAttempts bounds how often insertSchemaVersion retries after
+// losing a version number to a concurrent writer.
+const schemaVersionAttempts = 5
+
+// insertSchemaVersion appends version as the output's next version number.
+// The (state_guid, output_key, version) primary key arbitrates concurrent
+// writers: a loser's insert is skipped by ON CONFLICT DO NOTHING, which keeps
+// an enclosing Postgres transaction usable, and it retries with a fresh MAX.
+func insertSchemaVersion(ctx context.Context, db bun.IDB, version *models.OutputSchemaVersion) error {
+	for attempt := 0; attempt < schemaVersionAttempts; attempt++ {
+		var latest int
+		err := db.NewSelect().
+			Model((*models.OutputSchemaVersion)(nil)).
+			ColumnExpr("COALESCE(MAX(version), 0)").
+			Where("state_guid = ?", version.StateGUID).
+			Where("output_key = ?", version.OutputKey).
+			Scan(ctx, &latest)
+		if err != nil {
+			return fmt.Errorf("load latest schema version: %w", err)
+		}
+
+		version.Version = latest + 1
+		res, err := db.NewInsert().
+			Model(version).
+			On("CONFLICT (state_guid, output_key, version) DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("insert schema version: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("insert schema version: %w", err)
+		} else if n == 1 {
+			return nil
+		}
+	}
+	return fmt.Errorf("insert schema version: version %d of output %s in state %s taken concurrently", version.Version, version.OutputKey, version.StateGUID)
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// ListSchemaVersions returns an output's schema history, oldest first.
+func (r *BunStateOutputRepository) ListSchemaVersions(ctx context.Context, stateGUID, outputKey string) ([]models.OutputSchemaVersion, error) {
+	var versions []models.OutputSchemaVersion
+	err := r.db.NewSelect().
+		Model(&versions).
+		Where("state_guid = ?", stateGUID).
+		Where("output_key = ?", outputKey).
+		Order("version ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list schema versions for output %s in state %s: %w", outputKey, stateGUID, err)
+	}
+	return versions, nil
+}
+
 // GetOutputsWithoutSchema returns output keys that don't have a schema set.
 // Used by inference service to determine which outputs need schema generation.
 // Returns empty slice if all outputs have schemas (not an error).
@@ -329,13 +612,13 @@ func (r *BunStateOutputRepository) GetOutputsWithoutSchema(ctx context.Context,
 }
 
 // GetSchemasForState returns all output schemas for a state (for validation).
-// Returns map of outputKey -> schemaJSON for outputs that have schemas.
+// Returns map of outputKey -> schema and version for outputs that have schemas.
 // Outputs without schemas are not included in the map.
-func (r *BunStateOutputRepository) GetSchemasForState(ctx context.Context, stateGUID string) (map[string]string, error) {
+func (r *BunStateOutputRepository) GetSchemasForState(ctx context.Context, stateGUID string) (map[string]VersionedSchema, error) {
 	var outputs []models.StateOutput
 	err := r.db.NewSelect().
 		Model(&outputs).
-		Column("output_key", "schema_json").
+		Column("output_key", "schema_json", "schema_version").
 		Where("state_guid = ?", stateGUID).
 		Where("schema_json IS NOT NULL").
 		Scan(ctx)
@@ -344,11 +627,11 @@ func (r *BunStateOutputRepository) GetSchemasForState(ctx context.Context, state
 		return nil, fmt.Errorf("get schemas for state %s: %w", stateGUID, err)
 	}
 
-	// Build map of output key -> schema JSON
-	schemas := make(map[string]string, len(outputs))
+	// Build map of output key -> schema
+	schemas := make(map[string]VersionedSchema, len(outputs))
 	for _, output := range outputs {
 		if output.SchemaJSON != nil {
-			schemas[output.OutputKey] = *output.SchemaJSON
+			schemas[output.OutputKey] = VersionedSchema{JSON: *output.SchemaJSON, Version: output.SchemaVersion}
 		}
 	}
 
@@ -356,14 +639,17 @@ func (r *BunStateOutputRepository) GetSchemasForState(ctx context.Context, state
 }
 
 // UpdateValidationStatus updates the validation status for a specific output.
-// Sets validation_status, validation_error, and validated_at columns.
+// Sets validation_status, validation_error, validated_at and
+// validated_schema_version columns. The schema version comes from the caller
+// because the active version may have moved on since the schema was read.
 // validationError can be nil for "valid" or "not_validated" statuses.
-func (r *BunStateOutputRepository) UpdateValidationStatus(ctx context.Context, stateGUID, outputKey, status string, validationError *string, validatedAt time.Time) error {
+func (r *BunStateOutputRepository) UpdateValidationStatus(ctx context.Context, stateGUID, outputKey, status string, validationError *string, schemaVersion *int, validatedAt time.Time) error {
 	_, err := r.db.NewUpdate().
 		Model((*models.StateOutput)(nil)).
 		Set("validation_status = ?", status).
 		Set("validation_error = ?", validationError).
 		Set("validated_at = ?", validatedAt).
+		Set("validated_schema_version = ?", schemaVersion).
 		Set("updated_at = ?", validatedAt).
 		Where("state_guid = ?", stateGUID).
 		Where("output_key = ?", outputKey).
@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 	"github.com/uptrace/bun"
 )
@@ -343,3 +345,89 @@ func TestBunStateOutputRepository_CascadeDelete(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, retrieved, 0)
 }
+
+func TestBunStateOutputRepository_InferredNeverReplacesManual(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+	defer cleanupTestOutputs(t, db)
+
+	repo := NewBunStateOutputRepository(db)
+	stateRepo := NewBunStateRepository(db)
+	ctx := context.Background()
+
+	testState := &models.State{
+		GUID:    uuid.NewString(),
+		LogicID: "test-schema-source-" + uuid.NewString()[:8],
+	}
+	require.NoError(t, stateRepo.Create(ctx, testState))
+
+	manualSchema := `{"type":"string","pattern":"^vpc-"}`
+	inferredSchema := `{"type":"string"}`
+
+	t.Run("inferred write skips manual schema", func(t *testing.T) {
+		require.NoError(t, repo.SetOutputSchema(ctx, testState.GUID, "vpc_id", manualSchema))
+		require.NoError(t, repo.SetOutputSchemaWithSource(ctx, testState.GUID, "vpc_id", inferredSchema, "inferred", -1))
+
+		var out models.StateOutput
+		err := db.NewSelect().Model(&out).
+			Where("state_guid = ?", testState.GUID).
+			Where("output_key = ?", "vpc_id").
+			Scan(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, out.SchemaSource)
+		assert.Equal(t, "manual", *out.SchemaSource)
+		assert.JSONEq(t, manualSchema, *out.SchemaJSON)
+	})
+
+	t.Run("inferred write replaces inferred schema", func(t *testing.T) {
+		require.NoError(t, repo.SetOutputSchemaWithSource(ctx, testState.GUID, "region", `{"type":"number"}`, "inferred", -1))
+		require.NoError(t, repo.SetOutputSchemaWithSource(ctx, testState.GUID, "region", inferredSchema, "inferred", -1))
+
+		schema, err := repo.GetOutputSchema(ctx, testState.GUID, "region")
+		require.NoError(t, err)
+		assert.JSONEq(t, inferredSchema, schema)
+	})
+
+	t.Run("manual write replaces inferred schema", func(t *testing.T) {
+		require.NoError(t, repo.SetOutputSchema(ctx, testState.GUID, "region", manualSchema))
+
+		schema, err := repo.GetOutputSchema(ctx, testState.GUID, "region")
+		require.NoError(t, err)
+		assert.JSONEq(t, manualSchema, schema)
+	})
+}
+
+func TestBunStateOutputRepository_ValidatedSchemaVersion(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	repo := NewBunStateOutputRepository(db)
+	stateRepo := NewBunStateRepository(db)
+	testState := &models.State{GUID: uuid.NewString(), LogicID: "test-validated-version"}
+	require.NoError(t, stateRepo.Create(ctx, testState))
+
+	require.NoError(t, repo.SetOutputSchema(ctx, testState.GUID, "vpc_id", `{"type":"string"}`))
+	schemas, err := repo.GetSchemasForState(ctx, testState.GUID)
+	require.NoError(t, err)
+	require.NotNil(t, schemas["vpc_id"].Version)
+	assert.Equal(t, 1, *schemas["vpc_id"].Version)
+
+	// The schema moves on while the value is validated against version 1
+	require.NoError(t, repo.SetOutputSchema(ctx, testState.GUID, "vpc_id", `{"type":"string","pattern":"^vpc-"}`))
+	require.NoError(t, repo.UpdateValidationStatus(ctx, testState.GUID, "vpc_id", "valid", nil, schemas["vpc_id"].Version, time.Now()))
+
+	outputs, err := repo.GetOutputsByState(ctx, testState.GUID)
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.NotNil(t, outputs[0].SchemaVersion)
+	require.NotNil(t, outputs[0].ValidatedSchemaVersion)
+	assert.Equal(t, 2, *outputs[0].SchemaVersion)
+	assert.Equal(t, 1, *outputs[0].ValidatedSchemaVersion)
+
+	versions, err := repo.ListSchemaVersions(ctx, testState.GUID, "vpc_id")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[1].Version)
+}
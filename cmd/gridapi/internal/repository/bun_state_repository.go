@@ -80,7 +80,7 @@ func (r *BunStateRepository) Update(ctx context.Context, state *models.State) er
 
 	result, err := r.db.NewUpdate().
 		Model(state).
-		Column("state_content", "locked", "lock_info", "labels", "updated_at").
+		Column("state_content", "locked", "lock_info", "labels", "created_by", "updated_at").
 		WherePK().
 		Exec(ctx)
 	if err != nil {
@@ -95,6 +95,34 @@ func (r *BunStateRepository) Update(ctx context.Context, state *models.State) er
 	return nil
 }
 
+// TransferOwner sets created_by to newOwner only while it still equals
+// expectedOwner, so a concurrent transfer or update cannot be overwritten.
+func (r *BunStateRepository) TransferOwner(ctx context.Context, guid, expectedOwner, newOwner string) error {
+	result, err := r.db.NewUpdate().
+		Model((*models.State)(nil)).
+		Set("created_by = ?", newOwner).
+		Set("updated_at = ?", time.Now()).
+		Where("guid = ?", guid).
+		Where("created_by = ?", expectedOwner).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("transfer state owner: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 1 {
+		return nil
+	}
+	exists, err := r.db.NewSelect().Model((*models.State)(nil)).Where("guid = ?", guid).Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("transfer state owner: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("state with guid '%s' not found", guid)
+	}
+	return fmt.Errorf("ownership conflict: owner of state '%s' changed concurrently", guid)
+}
+
 // UpdateContentAndUpsertOutputs atomically updates state content and output cache in one transaction.
 // This ensures 003-ux-improvements-for/FR-027 compliance: cache and state are always consistent.
 func (r *BunStateRepository) UpdateContentAndUpsertOutputs(ctx context.Context, guid string, content []byte, lockID string, serial int64, outputs []OutputKey) error {
@@ -192,9 +220,12 @@ func (r *BunStateRepository) UpdateContentAndUpsertOutputs(ctx context.Context,
 				if existing, ok := existingByKey[out.Key]; ok {
 					model.SchemaJSON = existing.SchemaJSON
 					model.SchemaSource = existing.SchemaSource
+					model.SchemaRef = existing.SchemaRef
+					model.SchemaVersion = existing.SchemaVersion
 					model.ValidationStatus = existing.ValidationStatus
 					model.ValidationError = existing.ValidationError
 					model.ValidatedAt = existing.ValidatedAt
+					model.ValidatedSchemaVersion = existing.ValidatedSchemaVersion
 				}
 				outputModels = append(outputModels, model)
 			}
@@ -209,9 +240,12 @@ func (r *BunStateRepository) UpdateContentAndUpsertOutputs(ctx context.Context,
 				Set("updated_at = EXCLUDED.updated_at").
 				Set("schema_json = EXCLUDED.schema_json").
 				Set("schema_source = EXCLUDED.schema_source").
+				Set("schema_ref = EXCLUDED.schema_ref").
+				Set("schema_version = EXCLUDED.schema_version").
 				Set("validation_status = EXCLUDED.validation_status").
 				Set("validation_error = EXCLUDED.validation_error").
 				Set("validated_at = EXCLUDED.validated_at").
+				Set("validated_schema_version = EXCLUDED.validated_schema_version").
 				Exec(ctx)
 			if err != nil {
 				return fmt.Errorf("upsert outputs: %w", err)
@@ -225,19 +259,21 @@ func (r *BunStateRepository) UpdateContentAndUpsertOutputs(ctx context.Context,
 // List returns all states ordered from newest to oldest with relationship counts.
 // Uses efficient COUNT subqueries to populate dependencies_count, dependents_count, outputs_count
 // without fetching full relationship data (eliminates N+1 pattern for StateInfo rendering).
-func (r *BunStateRepository) List(ctx context.Context) ([]models.State, error) {
+// A non-nil scope excludes states the caller's role scopes cannot match.
+func (r *BunStateRepository) List(ctx context.Context, scope *ScopeFilter) ([]models.State, error) {
 	var states []models.State
-	if err := r.db.NewSelect().
+	q := r.db.NewSelect().
 		Model(&states).
 		ModelTableExpr("states AS s").
-		Column("s.guid", "s.logic_id", "s.locked", "s.created_at", "s.updated_at", "s.labels").
+		Column("s.guid", "s.logic_id", "s.locked", "s.created_at", "s.updated_at", "s.labels", "s.created_by").
 		ColumnExpr("length(s.state_content) AS size_bytes").
 		// Efficient COUNT subqueries using correlated subqueries
 		ColumnExpr("(SELECT COUNT(*) FROM edges WHERE to_state = s.guid) AS dependencies_count").
 		ColumnExpr("(SELECT COUNT(*) FROM edges WHERE from_state = s.guid) AS dependents_count").
 		ColumnExpr("(SELECT COUNT(*) FROM state_outputs WHERE state_guid = s.guid) AS outputs_count").
-		Order("s.created_at DESC").
-		Scan(ctx); err != nil {
+		Order("s.created_at DESC")
+	q = scope.apply(q, r.db.Dialect().Name())
+	if err := q.Scan(ctx); err != nil {
 		return nil, fmt.Errorf("list states: %w", err)
 	}
 
@@ -312,7 +348,8 @@ func (r *BunStateRepository) Unlock(ctx context.Context, guid string, lockID str
 // ListWithFilter returns states matching bexpr filter with deterministic label ordering and counts.
 // T026: Implements in-memory bexpr filtering per data-model.md lines 360-411.
 // Includes efficient COUNT subqueries for relationship counts.
-func (r *BunStateRepository) ListWithFilter(ctx context.Context, filter string, pageSize int, offset int) ([]models.State, error) {
+// A non-nil scope is applied in SQL before the over-fetch limit.
+func (r *BunStateRepository) ListWithFilter(ctx context.Context, filter string, scope *ScopeFilter, pageSize int, offset int) ([]models.State, error) {
 	// 1. Fetch states from DB (over-fetch for in-memory filtering)
 	var states []models.State
 	fetchSize := pageSize * 3 // heuristic: 3x over-fetch
@@ -320,9 +357,9 @@ func (r *BunStateRepository) ListWithFilter(ctx context.Context, filter string,
 		fetchSize = 100
 	}
 
-	err := r.db.NewSelect().
+	q := r.db.NewSelect().
 		Model(&states).
-		Column("guid", "logic_id", "locked", "created_at", "updated_at", "labels").
+		Column("guid", "logic_id", "locked", "created_at", "updated_at", "labels", "created_by").
 		ColumnExpr("length(state_content) AS size_bytes").
 		// Efficient COUNT subqueries using correlated subqueries
 		ColumnExpr("(SELECT COUNT(*) FROM edges WHERE to_state = s.guid) AS dependencies_count").
@@ -330,9 +367,9 @@ func (r *BunStateRepository) ListWithFilter(ctx context.Context, filter string,
 		ColumnExpr("(SELECT COUNT(*) FROM state_outputs WHERE state_guid = s.guid) AS outputs_count").
 		Order("updated_at DESC").
 		Limit(fetchSize).
-		Offset(offset).
-		Scan(ctx)
-	if err != nil {
+		Offset(offset)
+	q = scope.apply(q, r.db.Dialect().Name())
+	if err := q.Scan(ctx); err != nil {
 		return nil, fmt.Errorf("list states: %w", err)
 	}
 
@@ -376,6 +413,76 @@ func (r *BunStateRepository) ListWithFilter(ctx context.Context, filter string,
 	return filtered, nil
 }
 
+// CountMatching counts states whose labels match a bexpr filter.
+// An empty filter is a plain COUNT(*). Otherwise only the labels column is
+// fetched and the filter is evaluated in memory, as in ListWithFilter.
+func (r *BunStateRepository) CountMatching(ctx context.Context, filter string) (int, error) {
+	if filter == "" {
+		count, err := r.db.NewSelect().Model((*models.State)(nil)).Count(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("count states: %w", err)
+		}
+		return count, nil
+	}
+
+	evaluator, err := bexpr.CreateEvaluator(filter)
+	if err != nil {
+		return 0, fmt.Errorf("invalid filter expression: %w", err)
+	}
+
+	var states []models.State
+	if err := r.db.NewSelect().Model(&states).Column("guid", "labels").Scan(ctx); err != nil {
+		return 0, fmt.Errorf("list state labels: %w", err)
+	}
+
+	count := 0
+	for _, state := range states {
+		labels := state.Labels
+		if labels == nil {
+			labels = make(models.LabelMap)
+		}
+		// Evaluation errors (e.g. missing label key) count as no match
+		if match, err := evaluator.Evaluate(map[string]any(labels)); err == nil && match {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ListAfter returns up to limit states sorting after the after cursor in
+// (created_at, guid) order that match selector. The selector and a non-nil
+// scope are evaluated in SQL.
+func (r *BunStateRepository) ListAfter(ctx context.Context, selector LabelSelector, scope *ScopeFilter, after *StateCursor, limit int) ([]models.State, error) {
+	var states []models.State
+	q := r.db.NewSelect().
+		Model(&states).
+		ModelTableExpr("states AS s").
+		Column("s.guid", "s.logic_id", "s.locked", "s.created_at", "s.updated_at", "s.labels", "s.created_by").
+		ColumnExpr("length(s.state_content) AS size_bytes").
+		ColumnExpr("(SELECT COUNT(*) FROM edges WHERE to_state = s.guid) AS dependencies_count").
+		ColumnExpr("(SELECT COUNT(*) FROM edges WHERE from_state = s.guid) AS dependents_count").
+		ColumnExpr("(SELECT COUNT(*) FROM state_outputs WHERE state_guid = s.guid) AS outputs_count").
+		Order("s.created_at ASC", "s.guid ASC").
+		Limit(limit)
+	if after != nil {
+		q = q.Where("(s.created_at > ? OR (s.created_at = ? AND s.guid > ?))", after.CreatedAt, after.CreatedAt, after.GUID)
+	}
+	q = selector.apply(q, r.db.Dialect().Name())
+	q = scope.apply(q, r.db.Dialect().Name())
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("list states page: %w", err)
+	}
+
+	for i := range states {
+		sortLabels(&states[i])
+	}
+	if states == nil {
+		states = []models.State{}
+	}
+	return states, nil
+}
+
 // sortLabels sorts label keys alphabetically for deterministic output (FR-007).
 func sortLabels(state *models.State) {
 	if len(state.Labels) == 0 {
@@ -420,6 +527,30 @@ func (r *BunStateRepository) GetByGUIDs(ctx context.Context, guids []string) (ma
 	return result, nil
 }
 
+// GetByLogicIDs fetches multiple states by logic ID in a single query (batch operation).
+// Returns a map of logic ID -> State. Missing logic IDs are omitted from result.
+func (r *BunStateRepository) GetByLogicIDs(ctx context.Context, logicIDs []string) (map[string]*models.State, error) {
+	if len(logicIDs) == 0 {
+		return make(map[string]*models.State), nil
+	}
+
+	var states []*models.State
+	err := r.db.NewSelect().
+		Model(&states).
+		Where("logic_id IN (?)", bun.In(logicIDs)).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("batch fetch states by logic ID: %w", err)
+	}
+
+	result := make(map[string]*models.State, len(states))
+	for _, state := range states {
+		result[state.LogicID] = state
+	}
+
+	return result, nil
+}
+
 // GetByGUIDWithRelations fetches a state with specified relations preloaded.
 // Relations can be: "Outputs", "IncomingEdges", "OutgoingEdges"
 // This allows flexible eager loading based on what data is needed.
@@ -450,7 +581,7 @@ func (r *BunStateRepository) ListStatesWithOutputs(ctx context.Context) ([]*mode
 	err := r.db.NewSelect().
 		Model(&states).
 		Relation("Outputs").
-		Column("guid", "logic_id", "locked", "created_at", "updated_at", "labels").
+		Column("guid", "logic_id", "locked", "created_at", "updated_at", "labels", "created_by").
 		ColumnExpr("length(state_content) AS size_bytes").
 		Order("created_at DESC").
 		Scan(ctx)
@@ -470,3 +601,12 @@ func isDuplicateKeyError(err error) bool {
 	msg := err.Error()
 	return strings.Contains(msg, "duplicate key value") || strings.Contains(msg, "unique constraint") || strings.Contains(msg, "UNIQUE constraint") || strings.Contains(msg, "23505")
 }
+
+func isForeignKeyError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "violates foreign key constraint") || strings.Contains(msg, "FOREIGN KEY constraint") || strings.Contains(msg, "23503")
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
@@ -236,7 +238,7 @@ func TestBunStateRepository_List(t *testing.T) {
 			time.Sleep(10 * time.Millisecond) // Ensure different timestamps
 		}
 
-		states, err := repo.List(ctx)
+		states, err := repo.List(ctx, nil)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(states), 3, "Should have at least 3 states")
 
@@ -252,7 +254,7 @@ func TestBunStateRepository_List(t *testing.T) {
 		// Clean all test data
 		cleanupTestData(t, db)
 
-		states, err := repo.List(ctx)
+		states, err := repo.List(ctx, nil)
 		require.NoError(t, err)
 		assert.NotNil(t, states, "Should return empty slice, not nil")
 	})
@@ -800,7 +802,7 @@ func TestBunStateRepository_ListWithFilter(t *testing.T) {
 
 	t.Run("filter by equality", func(t *testing.T) {
 		// Filter: env == "staging"
-		results, err := repo.ListWithFilter(ctx, `env == "staging"`, 10, 0)
+		results, err := repo.ListWithFilter(ctx, `env == "staging"`, nil, 10, 0)
 		require.NoError(t, err)
 		assert.Len(t, results, 2, "Should return 2 staging states")
 		for _, r := range results {
@@ -810,7 +812,7 @@ func TestBunStateRepository_ListWithFilter(t *testing.T) {
 
 	t.Run("filter by AND expression", func(t *testing.T) {
 		// Filter: env == "prod" and team == "platform"
-		results, err := repo.ListWithFilter(ctx, `env == "prod" and team == "platform"`, 10, 0)
+		results, err := repo.ListWithFilter(ctx, `env == "prod" and team == "platform"`, nil, 10, 0)
 		require.NoError(t, err)
 		assert.Len(t, results, 1, "Should return 1 prod+platform state")
 		assert.Equal(t, "test-filter-4", results[0].LogicID)
@@ -818,7 +820,7 @@ func TestBunStateRepository_ListWithFilter(t *testing.T) {
 
 	t.Run("filter by OR expression", func(t *testing.T) {
 		// Filter: team == "core" or region == "us-west"
-		results, err := repo.ListWithFilter(ctx, `team == "core" or region == "us-west"`, 10, 0)
+		results, err := repo.ListWithFilter(ctx, `team == "core" or region == "us-west"`, nil, 10, 0)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(results), 3, "Should return at least 3 states")
 	})
@@ -826,30 +828,90 @@ func TestBunStateRepository_ListWithFilter(t *testing.T) {
 	t.Run("filter by in expression", func(t *testing.T) {
 		// Filter: env == "staging" or env == "prod"
 		// Note: bexpr doesn't support "field in [array]" syntax, use OR instead
-		results, err := repo.ListWithFilter(ctx, `env == "staging" or env == "prod"`, 10, 0)
+		results, err := repo.ListWithFilter(ctx, `env == "staging" or env == "prod"`, nil, 10, 0)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(results), 4, "Should return all 4 test states")
 	})
 
 	t.Run("empty filter returns all", func(t *testing.T) {
-		results, err := repo.ListWithFilter(ctx, "", 10, 0)
+		results, err := repo.ListWithFilter(ctx, "", nil, 10, 0)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(results), 4, "Should return all states")
 	})
 
 	t.Run("invalid bexpr returns error", func(t *testing.T) {
-		_, err := repo.ListWithFilter(ctx, `env = "invalid syntax"`, 10, 0)
+		_, err := repo.ListWithFilter(ctx, `env = "invalid syntax"`, nil, 10, 0)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid filter expression")
 	})
 
 	t.Run("pagination limits results", func(t *testing.T) {
-		results, err := repo.ListWithFilter(ctx, "", 2, 0)
+		results, err := repo.ListWithFilter(ctx, "", nil, 2, 0)
 		require.NoError(t, err)
 		assert.LessOrEqual(t, len(results), 2, "Should respect page_size limit")
 	})
 }
 
+func TestBunStateRepository_CountMatching(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	repo := NewBunStateRepository(db)
+	ctx := context.Background()
+
+	// A unique team keeps counts independent of states left by other tests
+	team := "count-" + uuid.NewString()[:8]
+	labelSets := []models.LabelMap{
+		{"env": "dev", "team": team},
+		{"env": "dev", "team": team, "region": "us-west"},
+		{"env": "prod", "team": team},
+		{"env": "prod", "team": "other"},
+		{"team": team},
+	}
+	for i, labels := range labelSets {
+		err := repo.Create(ctx, &models.State{
+			GUID:    uuid.NewString(),
+			LogicID: fmt.Sprintf("test-count-%s-%d", team, i),
+			Labels:  labels,
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		filter string
+		want   int
+	}{
+		{filter: fmt.Sprintf(`team == %q`, team), want: 4},
+		{filter: fmt.Sprintf(`team == %q and env == "dev"`, team), want: 2},
+		{filter: fmt.Sprintf(`team == %q and env == "prod"`, team), want: 1},
+		{filter: fmt.Sprintf(`team == %q and region == "us-west"`, team), want: 1},
+		{filter: fmt.Sprintf(`team == %q and env == "staging"`, team), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			count, err := repo.CountMatching(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+
+			listed, err := repo.ListWithFilter(ctx, tt.filter, nil, 100, 0)
+			require.NoError(t, err)
+			assert.Len(t, listed, count, "count should agree with ListWithFilter")
+		})
+	}
+
+	t.Run("empty filter counts all states", func(t *testing.T) {
+		count, err := repo.CountMatching(ctx, "")
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, count, len(labelSets))
+	})
+
+	t.Run("invalid bexpr returns error", func(t *testing.T) {
+		_, err := repo.CountMatching(ctx, `env = "invalid syntax"`)
+		assert.ErrorContains(t, err, "invalid filter expression")
+	})
+}
+
 // T011: Test deterministic label ordering
 func TestBunStateRepository_DeterministicLabelOrdering(t *testing.T) {
 	db := setupTestDB(t)
@@ -890,3 +952,94 @@ func TestBunStateRepository_DeterministicLabelOrdering(t *testing.T) {
 		assert.Contains(t, keys, "zebra")
 	})
 }
+
+func TestBunStateRepository_ListAfter(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	repo := NewBunStateRepository(db)
+	ctx := context.Background()
+
+	created := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		guid := uuid.Must(uuid.NewV7()).String()
+		err := repo.Create(ctx, &models.State{
+			GUID:    guid,
+			LogicID: fmt.Sprintf("test-page-%s", guid[len(guid)-12:]),
+		})
+		require.NoError(t, err)
+		created[guid] = true
+	}
+
+	// Walk every state two at a time; each created state appears exactly once
+	seen := make(map[string]int)
+	var after *StateCursor
+	for {
+		page, err := repo.ListAfter(ctx, nil, nil, after, 2)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		for _, state := range page {
+			after = &StateCursor{CreatedAt: state.CreatedAt, GUID: state.GUID}
+			seen[state.GUID]++
+		}
+	}
+
+	for guid := range created {
+		assert.Equal(t, 1, seen[guid], "state %s should be listed once", guid)
+	}
+}
+
+func TestBunStateRepository_ListAfterKeyset(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+	repo := NewBunStateRepository(db)
+
+	// Client-supplied GUIDs in reverse creation order, two sharing a timestamp
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	insert := func(guid string, createdAt time.Time) {
+		_, err := db.NewInsert().Model(&models.State{
+			GUID:      guid,
+			LogicID:   "keyset-" + guid[:4],
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}).Exec(ctx)
+		require.NoError(t, err)
+	}
+	insert("ffff0000-0000-4000-8000-000000000000", base)
+	insert("cccc0000-0000-4000-8000-000000000000", base.Add(time.Second))
+	insert("dddd0000-0000-4000-8000-000000000000", base.Add(time.Second))
+	insert("aaaa0000-0000-4000-8000-000000000000", base.Add(2*time.Second))
+
+	page, err := repo.ListAfter(ctx, nil, nil, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "ffff0000-0000-4000-8000-000000000000", page[0].GUID)
+	assert.Equal(t, "cccc0000-0000-4000-8000-000000000000", page[1].GUID)
+
+	// A state created mid-walk sorts after the cursor even though its GUID is the smallest
+	insert("00000000-0000-4000-8000-000000000000", base.Add(3*time.Second))
+
+	got := []string{}
+	after := &StateCursor{CreatedAt: page[1].CreatedAt, GUID: page[1].GUID}
+	for {
+		page, err := repo.ListAfter(ctx, nil, nil, after, 2)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		for _, state := range page {
+			got = append(got, state.GUID)
+		}
+		last := page[len(page)-1]
+		after = &StateCursor{CreatedAt: last.CreatedAt, GUID: last.GUID}
+	}
+	assert.Equal(t, []string{
+		"dddd0000-0000-4000-8000-000000000000",
+		"aaaa0000-0000-4000-8000-000000000000",
+		"00000000-0000-4000-8000-000000000000",
+	}, got)
+}
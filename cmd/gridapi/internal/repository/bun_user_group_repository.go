@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+// BunUserGroupRepository implements UserGroupRepository using Bun ORM
+type BunUserGroupRepository struct {
+	db *bun.DB
+}
+
+// NewBunUserGroupRepository creates a new Bun-based user group repository
+func NewBunUserGroupRepository(db *bun.DB) UserGroupRepository {
+	return &BunUserGroupRepository{db: db}
+}
+
+// Add records the user's membership of a group
+func (r *BunUserGroupRepository) Add(ctx context.Context, userID, groupName string) error {
+	_, err := r.db.NewInsert().
+		Model(&models.UserGroup{UserID: userID, GroupName: groupName}).
+		On("CONFLICT (user_id, group_name) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("add user group: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the user's membership of a group
+func (r *BunUserGroupRepository) Remove(ctx context.Context, userID, groupName string) error {
+	_, err := r.db.NewDelete().
+		Model((*models.UserGroup)(nil)).
+		Where("user_id = ?", userID).
+		Where("group_name = ?", groupName).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("remove user group: %w", err)
+	}
+	return nil
+}
+
+// ListGroupNames returns the names of the groups the user belongs to
+func (r *BunUserGroupRepository) ListGroupNames(ctx context.Context, userID string) ([]string, error) {
+	var names []string
+	err := r.db.NewSelect().
+		Model((*models.UserGroup)(nil)).
+		Column("group_name").
+		Where("user_id = ?", userID).
+		Order("group_name ASC").
+		Scan(ctx, &names)
+	if err != nil {
+		return nil, fmt.Errorf("list user groups: %w", err)
+	}
+	return names, nil
+}
+
+// CountMembers returns the number of users recorded as members of the group
+func (r *BunUserGroupRepository) CountMembers(ctx context.Context, groupName string) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*models.UserGroup)(nil)).
+		Where("group_name = ?", groupName).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("count group members: %w", err)
+	}
+	return count, nil
+}
@@ -135,10 +135,12 @@ func (r *BunUserRepository) UpdateLastLogin(ctx context.Context, id string) erro
 }
 
 // SetPasswordHash updates the stored bcrypt hash for a user's local credentials.
-func (r *BunUserRepository) SetPasswordHash(ctx context.Context, id string, passwordHash string) error {
+// mustChange marks the password as temporary, to be replaced at next login.
+func (r *BunUserRepository) SetPasswordHash(ctx context.Context, id string, passwordHash string, mustChange bool) error {
 	_, err := r.db.NewUpdate().
 		Model((*models.User)(nil)).
 		Set("password_hash = ?", passwordHash).
+		Set("must_change_password = ?", mustChange).
 		Set("updated_at = ?", time.Now()).
 		Where("id = ?", id).
 		Exec(ctx)
@@ -148,6 +150,20 @@ func (r *BunUserRepository) SetPasswordHash(ctx context.Context, id string, pass
 	return nil
 }
 
+// SetTokensValidAfter sets the user's revocation epoch.
+func (r *BunUserRepository) SetTokensValidAfter(ctx context.Context, id string, epoch time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("tokens_valid_after = ?", epoch).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("set tokens valid after: %w", err)
+	}
+	return nil
+}
+
 // List retrieves all users
 func (r *BunUserRepository) List(ctx context.Context) ([]models.User, error) {
 	var users []models.User
@@ -160,3 +176,123 @@ func (r *BunUserRepository) List(ctx context.Context) ([]models.User, error) {
 	}
 	return users, nil
 }
+
+// Merge folds duplicateID into primaryID in a single transaction: role
+// assignments (skipping roles the primary already holds), group memberships
+// (skipping groups the primary is already in), sessions, and
+// assigned_by/created_by/revoked_by references move to the primary, states
+// owned by duplicatePrincipal are re-owned by primaryPrincipal, then the
+// duplicate user row is deleted.
+func (r *BunUserRepository) Merge(ctx context.Context, primaryID, duplicateID, primaryPrincipal, duplicatePrincipal string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Drop duplicate assignments that would collide with the primary's
+		primaryRoles := tx.NewSelect().
+			TableExpr("user_roles").
+			Column("role_id").
+			Where("user_id = ?", primaryID)
+		if _, err := tx.NewDelete().
+			Model((*models.UserRole)(nil)).
+			Where("user_id = ?", duplicateID).
+			Where("role_id IN (?)", primaryRoles).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete overlapping user roles: %w", err)
+		}
+
+		if _, err := tx.NewUpdate().
+			Model((*models.UserRole)(nil)).
+			Set("user_id = ?", primaryID).
+			Where("user_id = ?", duplicateID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("reassign user roles: %w", err)
+		}
+
+		// Copy memberships to the primary, keeping those it already has
+		var memberships []models.UserGroup
+		if err := tx.NewSelect().
+			Model(&memberships).
+			Where("user_id = ?", duplicateID).
+			Scan(ctx); err != nil {
+			return fmt.Errorf("get group memberships: %w", err)
+		}
+		if len(memberships) > 0 {
+			for i := range memberships {
+				memberships[i].UserID = primaryID
+			}
+			if _, err := tx.NewInsert().
+				Model(&memberships).
+				On("CONFLICT (user_id, group_name) DO NOTHING").
+				Exec(ctx); err != nil {
+				return fmt.Errorf("reassign group memberships: %w", err)
+			}
+		}
+		if _, err := tx.NewDelete().
+			Model((*models.UserGroup)(nil)).
+			Where("user_id = ?", duplicateID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete duplicate group memberships: %w", err)
+		}
+
+		if _, err := tx.NewUpdate().
+			Model((*models.Session)(nil)).
+			Set("user_id = ?", primaryID).
+			Where("user_id = ?", duplicateID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("reassign sessions: %w", err)
+		}
+
+		// Ownership references
+		if _, err := tx.NewUpdate().
+			Model((*models.UserRole)(nil)).
+			Set("assigned_by = ?", primaryID).
+			Where("assigned_by = ?", duplicateID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("reassign user role assigners: %w", err)
+		}
+		if _, err := tx.NewUpdate().
+			Model((*models.GroupRole)(nil)).
+			Set("assigned_by = ?", primaryID).
+			Where("assigned_by = ?", duplicateID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("reassign group role assigners: %w", err)
+		}
+		if _, err := tx.NewUpdate().
+			Model((*models.ServiceAccount)(nil)).
+			Set("created_by = ?", primaryID).
+			Where("created_by = ?", duplicateID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("reassign service account creators: %w", err)
+		}
+		if _, err := tx.NewUpdate().
+			Model((*models.RevokedJTI)(nil)).
+			Set("revoked_by = ?", primaryID).
+			Where("revoked_by = ?", duplicateID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("reassign token revokers: %w", err)
+		}
+
+		if _, err := tx.NewUpdate().
+			Model((*models.State)(nil)).
+			Set("created_by = ?", primaryPrincipal).
+			Where("created_by = ?", duplicatePrincipal).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("reassign state owners: %w", err)
+		}
+
+		result, err := tx.NewDelete().
+			Model((*models.User)(nil)).
+			Where("id = ?", duplicateID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete duplicate user: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("user not found: %s", duplicateID)
+		}
+
+		return nil
+	})
+}
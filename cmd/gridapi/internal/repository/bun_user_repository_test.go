@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+)
+
+// testSystemUserID is the system user seeded by the init migration. Rows
+// whose creator does not matter to a test are attributed to it.
+const testSystemUserID = "00000000-0000-0000-0000-000000000000"
+
+// createTestUser stores a user for rows that must reference one and returns
+// its ID.
+func createTestUser(t *testing.T, db *bun.DB, email string) string {
+	t.Helper()
+	user := &models.User{Email: email, Name: email}
+	require.NoError(t, NewBunUserRepository(db).Create(context.Background(), user))
+	return user.ID
+}
+
+func TestBunUserRepository_Merge(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	users := NewBunUserRepository(db)
+	primary := &models.User{Email: "alice@example.com", Name: "Alice"}
+	duplicate := &models.User{Email: "alice@idp.example.com", Name: "Alice"}
+	require.NoError(t, users.Create(ctx, primary))
+	require.NoError(t, users.Create(ctx, duplicate))
+
+	groups := NewBunUserGroupRepository(db)
+	require.NoError(t, groups.Add(ctx, primary.ID, "platform"))
+	require.NoError(t, groups.Add(ctx, duplicate.ID, "platform"))
+	require.NoError(t, groups.Add(ctx, duplicate.ID, "payments"))
+
+	states := NewBunStateRepository(db)
+	owned := &models.State{GUID: "01890000-0000-7000-8000-000000000001", LogicID: "payments-prod", CreatedBy: "user:ext|123"}
+	other := &models.State{GUID: "01890000-0000-7000-8000-000000000002", LogicID: "search-prod", CreatedBy: "user:bob@example.com"}
+	require.NoError(t, states.Create(ctx, owned))
+	require.NoError(t, states.Create(ctx, other))
+
+	require.NoError(t, users.Merge(ctx, primary.ID, duplicate.ID, "user:alice@example.com", "user:ext|123"))
+
+	memberships, err := groups.ListGroupNames(ctx, primary.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"platform", "payments"}, memberships)
+	memberships, err = groups.ListGroupNames(ctx, duplicate.ID)
+	require.NoError(t, err)
+	require.Empty(t, memberships)
+
+	got, err := states.GetByGUID(ctx, owned.GUID)
+	require.NoError(t, err)
+	require.Equal(t, "user:alice@example.com", got.CreatedBy)
+	got, err = states.GetByGUID(ctx, other.GUID)
+	require.NoError(t, err)
+	require.Equal(t, "user:bob@example.com", got.CreatedBy)
+
+	_, err = users.GetByID(ctx, duplicate.ID)
+	require.Error(t, err)
+}
@@ -2,18 +2,35 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 )
 
+// ErrNotFound is wrapped by lookups that find no matching row, so callers can
+// use errors.Is instead of matching messages.
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists is wrapped by inserts that violate a unique constraint.
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrSchemaInUse is returned when deleting a schema registry entry that state
+// outputs still reference.
+var ErrSchemaInUse = errors.New("schema in use")
+
 // StateRepository exposes persistence operations for Terraform states.
 type StateRepository interface {
 	Create(ctx context.Context, state *models.State) error
 	GetByGUID(ctx context.Context, guid string) (*models.State, error)
 	GetByLogicID(ctx context.Context, logicID string) (*models.State, error)
 	Update(ctx context.Context, state *models.State) error
-	List(ctx context.Context) ([]models.State, error)
+	// TransferOwner sets created_by to newOwner if it is still expectedOwner.
+	// A state whose owner changed in the meantime yields a conflict error.
+	TransferOwner(ctx context.Context, guid, expectedOwner, newOwner string) error
+	// List returns all states; a non-nil scope excludes states outside the
+	// caller's role scopes in SQL.
+	List(ctx context.Context, scope *ScopeFilter) ([]models.State, error)
 	Lock(ctx context.Context, guid string, lockInfo *models.LockInfo) error
 	Unlock(ctx context.Context, guid string, lockID string) error
 
@@ -23,12 +40,17 @@ type StateRepository interface {
 
 	// ListWithFilter returns states matching bexpr filter with pagination.
 	// T029: Added for label filtering support.
-	ListWithFilter(ctx context.Context, filter string, pageSize int, offset int) ([]models.State, error)
+	// A non-nil scope is applied in SQL before filter.
+	ListWithFilter(ctx context.Context, filter string, scope *ScopeFilter, pageSize int, offset int) ([]models.State, error)
 
 	// GetByGUIDs fetches multiple states by GUIDs in a single query (batch operation).
 	// Returns a map of GUID -> State for efficient lookup. Missing GUIDs are omitted from result.
 	GetByGUIDs(ctx context.Context, guids []string) (map[string]*models.State, error)
 
+	// GetByLogicIDs fetches multiple states by logic ID in a single query.
+	// Returns a map of logic ID -> State. Missing logic IDs are omitted from result.
+	GetByLogicIDs(ctx context.Context, logicIDs []string) (map[string]*models.State, error)
+
 	// GetByGUIDWithRelations fetches a state with specified relations preloaded.
 	// Relations can be: "Outputs", "IncomingEdges", "OutgoingEdges"
 	// Example: GetByGUIDWithRelations(ctx, guid, "Outputs", "IncomingEdges")
@@ -36,6 +58,26 @@ type StateRepository interface {
 
 	// ListStatesWithOutputs returns all states with their outputs preloaded (avoids N+1).
 	ListStatesWithOutputs(ctx context.Context) ([]*models.State, error)
+
+	// CountMatching counts states whose labels match a bexpr filter.
+	// An empty filter counts every state.
+	CountMatching(ctx context.Context, filter string) (int, error)
+
+	// ListAfter returns up to limit states ordered by (created_at, guid),
+	// starting after the after cursor (nil = from the beginning). Used for
+	// cursor pagination. Only states matching selector are returned; an empty
+	// selector matches all. A non-nil scope further excludes states outside
+	// the caller's role scopes.
+	ListAfter(ctx context.Context, selector LabelSelector, scope *ScopeFilter, after *StateCursor, limit int) ([]models.State, error)
+}
+
+// StateCursor is the keyset position of a state in ListAfter order. GUIDs are
+// client-supplied and need not be time ordered, so created_at leads the key:
+// states created while a listing is paged through sort after its cursor
+// instead of being skipped.
+type StateCursor struct {
+	CreatedAt time.Time
+	GUID      string
 }
 
 // EdgeWithValidation wraps an Edge with its producer output's validation status.
@@ -57,7 +99,14 @@ type EdgeRepository interface {
 	// Query operations
 	GetOutgoingEdges(ctx context.Context, fromStateGUID string) ([]models.Edge, error)
 	GetIncomingEdges(ctx context.Context, toStateGUID string) ([]models.Edge, error)
+	// GetIncomingEdgesForStates fetches the incoming edges of every state in
+	// toStateGUIDs in one query.
+	GetIncomingEdgesForStates(ctx context.Context, toStateGUIDs []string) ([]models.Edge, error)
 	GetAllEdges(ctx context.Context) ([]models.Edge, error)
+
+	// ListAfter returns up to limit edges ordered by ID, starting after
+	// afterID (0 = from the beginning). Used for cursor pagination.
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]models.Edge, error)
 	FindByOutput(ctx context.Context, outputKey string) ([]models.Edge, error)
 
 	// Eager loading operations (avoid N+1 queries)
@@ -81,14 +130,24 @@ type EdgeRepository interface {
 
 // OutputKey represents a Terraform output name and metadata.
 type OutputKey struct {
-	Key              string
-	Sensitive        bool
-	StateSerial      int64      // Serial of state this output came from (0 = pre-declared schema, >0 = from Terraform state)
-	SchemaJSON       *string    // Optional JSON Schema definition for this output
-	SchemaSource     *string    // Schema source: "manual" or "inferred"
-	ValidationStatus *string    // Validation status: "valid", "invalid", or "error"
-	ValidationError  *string    // Validation error message (if validation failed)
-	ValidatedAt      *time.Time // Last validation timestamp
+	Key                    string
+	Sensitive              bool
+	StateSerial            int64      // Serial of state this output came from (0 = pre-declared schema, >0 = from Terraform state)
+	SchemaJSON             *string    // Optional JSON Schema definition for this output
+	SchemaSource           *string    // Schema source: "manual" or "inferred"
+	SchemaRef              *string    // Schema registry key the schema was set from (manual schemas only)
+	SchemaVersion          *int       // Version of the current schema in the output's schema history
+	ValidationStatus       *string    // Validation status: "valid", "invalid", or "error"
+	ValidationError        *string    // Validation error message (if validation failed)
+	ValidatedAt            *time.Time // Last validation timestamp
+	ValidatedSchemaVersion *int       // Schema version the last validation ran against
+}
+
+// VersionedSchema is an output's schema together with its version in the
+// output's schema history.
+type VersionedSchema struct {
+	JSON    string
+	Version *int
 }
 
 // ========================================
@@ -103,22 +162,48 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
 	UpdateLastLogin(ctx context.Context, id string) error
-	SetPasswordHash(ctx context.Context, id string, passwordHash string) error
+	SetPasswordHash(ctx context.Context, id string, passwordHash string, mustChange bool) error
+	// SetTokensValidAfter sets the user's revocation epoch: credentials issued
+	// before it are rejected.
+	SetTokensValidAfter(ctx context.Context, id string, epoch time.Time) error
 	List(ctx context.Context) ([]models.User, error)
+	// Merge folds user duplicateID into primaryID and deletes it. States owned
+	// by duplicatePrincipal (its "user:" principal ID) move to primaryPrincipal.
+	Merge(ctx context.Context, primaryID, duplicateID, primaryPrincipal, duplicatePrincipal string) error
+}
+
+// ServiceAccountFilter narrows ServiceAccountRepository.ListFiltered.
+// Zero-valued fields don't filter.
+type ServiceAccountFilter struct {
+	NamePrefix     string     // Only accounts whose name starts with this (case-sensitive)
+	Disabled       *bool      // Only disabled (true) or enabled (false) accounts
+	CreatedBy      string     // Only accounts created by this user ID
+	LastUsedBefore *time.Time // Only accounts not used since this time, including never-used ones
+	AfterID        string     // Resume after this ID; accounts are ordered by ID
+	Limit          int        // Max accounts returned (0 = no limit)
 }
 
 // ServiceAccountRepository exposes persistence operations for service accounts
 type ServiceAccountRepository interface {
 	Create(ctx context.Context, sa *models.ServiceAccount) error
+	// CreateWithRoles runs check (when non-nil) for each role, in the
+	// transaction that creates the account
+	CreateWithRoles(ctx context.Context, sa *models.ServiceAccount, roles []models.UserRole, check AssignmentCheck) error
 	GetByID(ctx context.Context, id string) (*models.ServiceAccount, error)
 	GetByName(ctx context.Context, name string) (*models.ServiceAccount, error)
 	GetByClientID(ctx context.Context, clientID string) (*models.ServiceAccount, error)
 	Update(ctx context.Context, sa *models.ServiceAccount) error
 	UpdateLastUsed(ctx context.Context, id string) error
 	UpdateSecretHash(ctx context.Context, id string, secretHash string) error
+	UpdateSecretHashWithOverlap(ctx context.Context, id string, secretHash string, previousExpiresAt time.Time) error
+	// ClearPreviousSecret drops the previous secret only if it expired at or
+	// before now, so a concurrent rotation's fresh overlap survives.
+	ClearPreviousSecret(ctx context.Context, id string, now time.Time) error
 	SetDisabled(ctx context.Context, id string, disabled bool) error
+	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]models.ServiceAccount, error)
 	ListByCreator(ctx context.Context, createdBy string) ([]models.ServiceAccount, error)
+	ListFiltered(ctx context.Context, filter ServiceAccountFilter) ([]models.ServiceAccount, error)
 }
 
 // RoleRepository exposes persistence operations for roles
@@ -134,6 +219,8 @@ type RoleRepository interface {
 // UserRoleRepository exposes persistence operations for user-role assignments
 type UserRoleRepository interface {
 	Create(ctx context.Context, ur *models.UserRole) error
+	// CreateChecked inserts ur once check accepts the role's current assignments
+	CreateChecked(ctx context.Context, ur *models.UserRole, check AssignmentCheck) error
 	GetByID(ctx context.Context, id string) (*models.UserRole, error)
 	GetByUserID(ctx context.Context, userID string) ([]models.UserRole, error)
 	GetByUserAndRoleID(ctx context.Context, userID string, roleID string) (*models.UserRole, error)
@@ -146,9 +233,17 @@ type UserRoleRepository interface {
 	List(ctx context.Context) ([]models.UserRole, error)
 }
 
+// AssignmentCheck vets a new assignment of role against the role's current
+// user, service account and group assignments. It runs inside the inserting
+// transaction with the role locked, so concurrent assignments of one role are
+// checked one after another rather than against the same stale count.
+type AssignmentCheck func(ctx context.Context, role *models.Role, userRoles []models.UserRole, groupRoles []models.GroupRole) error
+
 // GroupRoleRepository exposes persistence operations for group-role mappings
 type GroupRoleRepository interface {
 	Create(ctx context.Context, gr *models.GroupRole) error
+	// CreateChecked inserts gr once check accepts the role's current assignments
+	CreateChecked(ctx context.Context, gr *models.GroupRole, check AssignmentCheck) error
 	GetByID(ctx context.Context, id string) (*models.GroupRole, error)
 	GetByGroupName(ctx context.Context, groupName string) ([]models.GroupRole, error)
 	GetByRoleID(ctx context.Context, roleID string) ([]models.GroupRole, error)
@@ -157,6 +252,59 @@ type GroupRoleRepository interface {
 	List(ctx context.Context) ([]models.GroupRole, error)
 }
 
+// RevocationEpochRepository persists the global revocation epoch
+type RevocationEpochRepository interface {
+	// Get returns the stored epoch, or nil if none was ever set
+	Get(ctx context.Context) (*models.RevocationEpoch, error)
+	// Set stores epoch, replacing any previous value
+	Set(ctx context.Context, epoch time.Time, setBy string) error
+}
+
+// AuditLogFilter narrows audit log queries. Zero-valued fields match everything.
+type AuditLogFilter struct {
+	Actor      string     // Only entries by this principal ID
+	Action     string     // Only entries with this action
+	TargetType string     // Only entries about this kind of target
+	TargetID   string     // Only entries about this target
+	Outcome    string     // Only successes or only failures
+	Since      *time.Time // Only entries at or after this time
+	Until      *time.Time // Only entries before this time
+	PageSize   int        // Max entries returned (0 = no limit)
+	Offset     int        // Entries to skip, for paging
+}
+
+// AuditLogRepository persists the IAM audit trail
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *models.AuditLogEntry) error
+	// List returns entries matching filter, newest first.
+	List(ctx context.Context, filter AuditLogFilter) ([]models.AuditLogEntry, error)
+}
+
+// UserGroupRepository exposes persistence operations for internal IdP group memberships
+type UserGroupRepository interface {
+	// Add is idempotent: adding an existing membership is not an error.
+	Add(ctx context.Context, userID, groupName string) error
+	Remove(ctx context.Context, userID, groupName string) error
+	// ListGroupNames returns the user's groups sorted by name.
+	ListGroupNames(ctx context.Context, userID string) ([]string, error)
+	// CountMembers returns the number of users in the group.
+	CountMembers(ctx context.Context, groupName string) (int, error)
+}
+
+// SessionFilter narrows admin session queries. Zero values match everything.
+// Paging fields only apply to listing.
+type SessionFilter struct {
+	UserID           string     // Only sessions belonging to this user
+	ServiceAccountID string     // Only sessions belonging to this service account
+	ActiveOnly       bool       // Exclude revoked and expired sessions
+	RevokedOnly      bool       // Only revoked sessions
+	CreatedAfter     *time.Time // Only sessions created after this time
+	CreatedBefore    *time.Time // Only sessions created before this time
+	ExcludeID        string     // Skip this session (e.g. the caller's own)
+	PageSize         int        // Max sessions returned (0 = no limit)
+	Offset           int        // Sessions to skip, for paging
+}
+
 // SessionRepository exposes persistence operations for sessions
 type SessionRepository interface {
 	Create(ctx context.Context, session *models.Session) error
@@ -170,11 +318,17 @@ type SessionRepository interface {
 	RevokeByServiceAccountID(ctx context.Context, serviceAccountID string) error
 	DeleteExpired(ctx context.Context) error
 	List(ctx context.Context) ([]models.Session, error)
+	// ListFiltered returns sessions matching filter, newest first.
+	ListFiltered(ctx context.Context, filter SessionFilter) ([]models.Session, error)
+	// RevokeFiltered revokes unrevoked sessions matching filter in batches of
+	// batchSize, returning how many were revoked.
+	RevokeFiltered(ctx context.Context, filter SessionFilter, batchSize int) (int, error)
 }
 
 // RevokedJTIRepository exposes persistence operations for revoked JWT IDs
 type RevokedJTIRepository interface {
-	// Create adds a JTI to the revocation denylist
+	// Create adds a JTI to the revocation denylist; an already revoked JTI
+	// is left as is
 	Create(ctx context.Context, revokedJTI *models.RevokedJTI) error
 
 	// IsRevoked checks if a JTI exists in the revocation table
@@ -188,6 +342,43 @@ type RevokedJTIRepository interface {
 	GetByJTI(ctx context.Context, jti string) (*models.RevokedJTI, error)
 }
 
+// DeviceAuthorizationRepository persists internal IdP device authorizations.
+type DeviceAuthorizationRepository interface {
+	Create(ctx context.Context, record *models.DeviceAuthorization) error
+
+	// GetByDeviceCode and GetByUserCode return a "not found" error for unknown codes.
+	GetByDeviceCode(ctx context.Context, deviceCode string) (*models.DeviceAuthorization, error)
+	GetByUserCode(ctx context.Context, userCode string) (*models.DeviceAuthorization, error)
+
+	// MarkPolled records the time of the latest token-endpoint poll.
+	MarkPolled(ctx context.Context, deviceCode string, at time.Time) error
+
+	// Complete approves (as subject) or denies an unexpired, undecided authorization.
+	// Returns a "not found" error if no such authorization exists.
+	Complete(ctx context.Context, userCode, subject string, approved bool, at time.Time) error
+
+	// Delete removes an authorization once its token has been issued.
+	// Returns a "not found" error if the row was already removed, e.g. by a
+	// concurrent poll that issued the token.
+	Delete(ctx context.Context, deviceCode string) error
+
+	// DeleteExpired removes authorizations that expired before the given time.
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
+
+// StateIdempotencyKeyRepository persists CreateState idempotency keys.
+type StateIdempotencyKeyRepository interface {
+	// Get returns the unexpired record for key.
+	// Returns a "not found" error when the key is unknown or has expired.
+	Get(ctx context.Context, key string) (*models.StateIdempotencyKey, error)
+
+	// Put stores a key, replacing any expired record with the same key.
+	Put(ctx context.Context, record *models.StateIdempotencyKey) error
+
+	// DeleteExpired removes keys whose TTL has passed.
+	DeleteExpired(ctx context.Context) error
+}
+
 // StateOutputRef represents a state reference with an output key.
 type StateOutputRef struct {
 	StateGUID    string
@@ -206,6 +397,10 @@ type StateOutputRepository interface {
 	// Returns empty slice if no outputs exist (not an error)
 	GetOutputsByState(ctx context.Context, stateGUID string) ([]OutputKey, error)
 
+	// GetOutputsByStates returns cached outputs for several states in one query,
+	// keyed by state GUID. States without cached outputs are omitted from the map.
+	GetOutputsByStates(ctx context.Context, stateGUIDs []string) (map[string][]OutputKey, error)
+
 	// SearchOutputsByKey finds all states with output matching key (exact match)
 	// Used for cross-state dependency discovery
 	SearchOutputsByKey(ctx context.Context, outputKey string) ([]StateOutputRef, error)
@@ -219,6 +414,16 @@ type StateOutputRepository interface {
 	// This allows declaring expected outputs before they exist in the Terraform state.
 	SetOutputSchema(ctx context.Context, stateGUID string, outputKey string, schemaJSON string) error
 
+	// SetOutputSchemaRef sets a manual schema by schema registry key. The
+	// registered schema is copied onto the output and kept in sync when the
+	// entry is updated. Returns an ErrNotFound error if the key is not registered.
+	SetOutputSchemaRef(ctx context.Context, stateGUID, outputKey, schemaRef string) error
+
+	// SetOutputSchemas sets manual schemas for several outputs atomically.
+	// Returns the keys that had no schema before the call (created); all
+	// other keys had an existing schema replaced.
+	SetOutputSchemas(ctx context.Context, stateGUID string, schemas map[string]string) (map[string]bool, error)
+
 	// GetOutputSchema retrieves the JSON Schema for a specific state output.
 	// Returns empty string if no schema has been set (not an error).
 	// Returns error only for actual database failures.
@@ -227,6 +432,7 @@ type StateOutputRepository interface {
 	// SetOutputSchemaWithSource sets or updates the JSON Schema with source tracking.
 	// source must be "manual" or "inferred".
 	// Creates the output record if it doesn't exist (with state_serial=0, sensitive=false).
+	// Inferred schemas never replace a manual schema (the write is skipped).
 	// expectedSerial: For inferred schemas, verifies output still exists at this serial before writing.
 	//                 Use -1 for manual schemas to skip serial check (always write).
 	SetOutputSchemaWithSource(ctx context.Context, stateGUID, outputKey, schemaJSON, source string, expectedSerial int64) error
@@ -237,14 +443,46 @@ type StateOutputRepository interface {
 	GetOutputsWithoutSchema(ctx context.Context, stateGUID string) ([]string, error)
 
 	// GetSchemasForState returns all output schemas for a state (for validation).
-	// Returns map of outputKey -> schemaJSON for outputs that have schemas.
-	// Outputs without schemas are not included in the map.
-	GetSchemasForState(ctx context.Context, stateGUID string) (map[string]string, error)
+	// Returns map of outputKey -> schema and its version for outputs that have
+	// schemas. Outputs without schemas are not included in the map.
+	GetSchemasForState(ctx context.Context, stateGUID string) (map[string]VersionedSchema, error)
+
+	// GetOutputsBySchema returns the outputs, across all states, whose schema is
+	// exactly schemaJSON, keyed by state GUID. Used to re-validate every output
+	// sharing a schema after it changes.
+	GetOutputsBySchema(ctx context.Context, schemaJSON string) (map[string][]OutputKey, error)
+
+	// ListSchemaVersions returns every schema the output has had, oldest first.
+	// Each manual, inferred or registry-driven schema change adds a version.
+	ListSchemaVersions(ctx context.Context, stateGUID, outputKey string) ([]models.OutputSchemaVersion, error)
 
 	// UpdateValidationStatus updates the validation status for a specific output.
-	// Sets validation_status, validation_error, and validated_at columns.
+	// Sets validation_status, validation_error, validated_at and
+	// validated_schema_version columns. schemaVersion is the version the value
+	// was validated against, nil when no schema applied.
 	// validationError can be nil for "valid" or "not_validated" statuses.
-	UpdateValidationStatus(ctx context.Context, stateGUID, outputKey, status string, validationError *string, validatedAt time.Time) error
+	UpdateValidationStatus(ctx context.Context, stateGUID, outputKey, status string, validationError *string, schemaVersion *int, validatedAt time.Time) error
+}
+
+// SchemaRegistryRepository exposes persistence operations for output schemas
+// shared under a named version (e.g. "aws/vpc_id@v1").
+type SchemaRegistryRepository interface {
+	// Create inserts a new entry. Fails if the key is already registered.
+	Create(ctx context.Context, entry *models.SchemaRegistryEntry) error
+
+	// Get returns the entry for key, or an ErrNotFound error.
+	Get(ctx context.Context, key string) (*models.SchemaRegistryEntry, error)
+
+	// List returns all entries ordered by key.
+	List(ctx context.Context) ([]models.SchemaRegistryEntry, error)
+
+	// Update replaces the entry's schema (and description when non-nil) and
+	// rewrites the schema of every output referencing it, atomically.
+	Update(ctx context.Context, key, schemaJSON string, description *string) (*models.SchemaRegistryEntry, error)
+
+	// Delete removes the entry. Returns an ErrSchemaInUse error while outputs
+	// still reference it.
+	Delete(ctx context.Context, key string) error
 }
 
 // LabelPolicyRepository exposes persistence operations for label validation policy.
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// SelectorOperator is the comparison in a label selector requirement.
+type SelectorOperator string
+
+const (
+	SelectorEquals       SelectorOperator = "="
+	SelectorNotEquals    SelectorOperator = "!="
+	SelectorIn           SelectorOperator = "in"
+	SelectorNotIn        SelectorOperator = "notin"
+	SelectorExists       SelectorOperator = "exists"
+	SelectorDoesNotExist SelectorOperator = "!"
+)
+
+// selectorKeyRE mirrors the label key format enforced by the state label validator.
+var selectorKeyRE = regexp.MustCompile(`^[a-z][a-z0-9_/]{0,31}$`)
+
+// LabelRequirement is one comma-separated clause of a label selector.
+type LabelRequirement struct {
+	Key      string
+	Operator SelectorOperator
+	Values   []string // One value for = and !=, one or more for in/notin, none otherwise
+}
+
+// LabelSelector is a parsed Kubernetes-style label selector. A state matches
+// when every requirement holds; an empty selector matches every state.
+//
+// Label values are compared by their string form, so env=dev, replicas=3 and
+// enabled=true all work against string, number and boolean labels.
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector parses Kubernetes label selector syntax:
+//
+//	env=dev,team==platform     equality
+//	env!=prod                  inequality (also matches states without env)
+//	env in (dev,staging)       set membership
+//	env notin (prod)           set exclusion (also matches states without env)
+//	team                       key exists
+//	!deprecated                key does not exist
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	clauses, err := splitSelectorClauses(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make(LabelSelector, 0, len(clauses))
+	for _, clause := range clauses {
+		req, err := parseRequirement(clause)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, req)
+	}
+	return parsed, nil
+}
+
+// splitSelectorClauses splits on commas outside parentheses.
+func splitSelectorClauses(selector string) ([]string, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+
+	var clauses []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("invalid label selector %q: nested parentheses", selector)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", selector)
+			}
+		case ',':
+			if depth == 0 {
+				clauses = append(clauses, strings.TrimSpace(selector[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", selector)
+	}
+	clauses = append(clauses, strings.TrimSpace(selector[start:]))
+
+	for _, clause := range clauses {
+		if clause == "" {
+			return nil, fmt.Errorf("invalid label selector %q: empty requirement", selector)
+		}
+	}
+	return clauses, nil
+}
+
+func parseRequirement(clause string) (LabelRequirement, error) {
+	if key, ok := strings.CutPrefix(clause, "!"); ok {
+		return newRequirement(strings.TrimSpace(key), SelectorDoesNotExist, nil)
+	}
+
+	if key, values, ok := cutSetOperator(clause, " notin "); ok {
+		return newSetRequirement(key, SelectorNotIn, values)
+	}
+	if key, values, ok := cutSetOperator(clause, " in "); ok {
+		return newSetRequirement(key, SelectorIn, values)
+	}
+
+	for _, op := range []struct {
+		token    string
+		operator SelectorOperator
+	}{
+		{"!=", SelectorNotEquals},
+		{"==", SelectorEquals},
+		{"=", SelectorEquals},
+	} {
+		if key, value, ok := strings.Cut(clause, op.token); ok {
+			return newRequirement(strings.TrimSpace(key), op.operator, []string{strings.TrimSpace(value)})
+		}
+	}
+
+	return newRequirement(clause, SelectorExists, nil)
+}
+
+// cutSetOperator splits "key in (a,b)" into the key and the parenthesised list.
+func cutSetOperator(clause, operator string) (string, string, bool) {
+	key, rest, ok := strings.Cut(clause, operator)
+	if !ok {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(rest), true
+}
+
+func newSetRequirement(key string, operator SelectorOperator, list string) (LabelRequirement, error) {
+	inner, ok := strings.CutPrefix(list, "(")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, ")")
+	}
+	if !ok {
+		return LabelRequirement{}, fmt.Errorf("invalid label selector: %s values for %q must be parenthesised", operator, key)
+	}
+
+	var values []string
+	for _, value := range strings.Split(inner, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return LabelRequirement{}, fmt.Errorf("invalid label selector: empty value in %s list for %q", operator, key)
+		}
+		values = append(values, value)
+	}
+	return newRequirement(key, operator, values)
+}
+
+func newRequirement(key string, operator SelectorOperator, values []string) (LabelRequirement, error) {
+	if !selectorKeyRE.MatchString(key) {
+		return LabelRequirement{}, fmt.Errorf("invalid label selector: %q is not a valid label key", key)
+	}
+	for _, value := range values {
+		if strings.ContainsAny(value, "=!() ") {
+			return LabelRequirement{}, fmt.Errorf("invalid label selector: value %q for %q contains reserved characters", value, key)
+		}
+	}
+	return LabelRequirement{Key: key, Operator: operator, Values: values}, nil
+}
+
+// Matches reports whether labels satisfy every requirement. It applies the
+// same semantics as the SQL generated by apply.
+func (s LabelSelector) Matches(labels models.LabelMap) bool {
+	for _, req := range s {
+		raw, present := labels[req.Key]
+		value := labelValueString(raw)
+		switch req.Operator {
+		case SelectorExists:
+			if !present {
+				return false
+			}
+		case SelectorDoesNotExist:
+			if present {
+				return false
+			}
+		case SelectorEquals, SelectorIn:
+			if !present || !containsString(req.Values, value) {
+				return false
+			}
+		case SelectorNotEquals, SelectorNotIn:
+			if present && containsString(req.Values, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// apply adds one WHERE clause per requirement to a query over "states AS s".
+func (s LabelSelector) apply(q *bun.SelectQuery, d dialect.Name) *bun.SelectQuery {
+	for _, req := range s {
+		valueExpr, valueArgs := labelValueExpr(req.Key, d)
+		existsExpr, existsArgs := labelExistsExpr(req.Key, d)
+
+		switch req.Operator {
+		case SelectorExists:
+			q = q.Where(existsExpr, existsArgs...)
+		case SelectorDoesNotExist:
+			q = q.Where("NOT "+existsExpr, existsArgs...)
+		case SelectorEquals, SelectorIn:
+			q = q.Where(valueExpr+" IN (?)", append(valueArgs, bun.In(req.Values))...)
+		case SelectorNotEquals, SelectorNotIn:
+			args := append(append([]any{}, existsArgs...), valueArgs...)
+			q = q.Where("(NOT "+existsExpr+" OR "+valueExpr+" NOT IN (?))", append(args, bun.In(req.Values))...)
+		}
+	}
+	return q
+}
+
+// labelValueExpr returns SQL yielding a label's string form, matching labelValueString.
+func labelValueExpr(key string, d dialect.Name) (string, []any) {
+	if d == dialect.SQLite {
+		// json_extract returns 1/0 for booleans; render them as PostgreSQL's ->> does
+		path := sqliteLabelPath(key)
+		return "(CASE json_type(s.labels, ?) WHEN 'true' THEN 'true' WHEN 'false' THEN 'false' ELSE CAST(json_extract(s.labels, ?) AS TEXT) END)", []any{path, path}
+	}
+	return "(s.labels->>?)", []any{key}
+}
+
+func labelExistsExpr(key string, d dialect.Name) (string, []any) {
+	if d == dialect.SQLite {
+		return "(json_type(s.labels, ?) IS NOT NULL)", []any{sqliteLabelPath(key)}
+	}
+	return "(s.labels->? IS NOT NULL)", []any{key}
+}
+
+// sqliteLabelPath quotes the key so '/' in label keys is not read as a path separator.
+func sqliteLabelPath(key string) string {
+	return `$."` + key + `"`
+}
+
+// labelValueString renders a label value the way PostgreSQL's ->> operator does.
+func labelValueString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
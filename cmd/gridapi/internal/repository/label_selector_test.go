@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     LabelSelector
+	}{
+		{selector: "", want: LabelSelector{}},
+		{selector: "env=dev", want: LabelSelector{{Key: "env", Operator: SelectorEquals, Values: []string{"dev"}}}},
+		{selector: "env==dev", want: LabelSelector{{Key: "env", Operator: SelectorEquals, Values: []string{"dev"}}}},
+		{selector: "env != prod", want: LabelSelector{{Key: "env", Operator: SelectorNotEquals, Values: []string{"prod"}}}},
+		{
+			selector: "env=dev, team in (platform, data),!deprecated,owner",
+			want: LabelSelector{
+				{Key: "env", Operator: SelectorEquals, Values: []string{"dev"}},
+				{Key: "team", Operator: SelectorIn, Values: []string{"platform", "data"}},
+				{Key: "deprecated", Operator: SelectorDoesNotExist},
+				{Key: "owner", Operator: SelectorExists},
+			},
+		},
+		{selector: "region notin (us-east,eu-west)", want: LabelSelector{{Key: "region", Operator: SelectorNotIn, Values: []string{"us-east", "eu-west"}}}},
+		{selector: "app/tier=web", want: LabelSelector{{Key: "app/tier", Operator: SelectorEquals, Values: []string{"web"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := ParseLabelSelector(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLabelSelector_Invalid(t *testing.T) {
+	for _, selector := range []string{
+		"env=dev,",
+		",env=dev",
+		"Env=dev",
+		"env in dev",
+		"env in (dev",
+		"env in (dev,)",
+		"env in ((dev))",
+		"env=dev)",
+		"=dev",
+		"!",
+		"env=a b",
+	} {
+		t.Run(selector, func(t *testing.T) {
+			_, err := ParseLabelSelector(selector)
+			assert.ErrorContains(t, err, "invalid label selector")
+		})
+	}
+}
+
+func TestLabelSelector_Matches(t *testing.T) {
+	labels := models.LabelMap{"env": "dev", "team": "platform", "replicas": float64(3), "public": true}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "", want: true},
+		{selector: "env=dev", want: true},
+		{selector: "env=prod", want: false},
+		{selector: "env!=prod", want: true},
+		{selector: "region!=us", want: true},
+		{selector: "env in (dev,staging)", want: true},
+		{selector: "env notin (dev,staging)", want: false},
+		{selector: "region notin (us)", want: true},
+		{selector: "region in (us)", want: false},
+		{selector: "team", want: true},
+		{selector: "region", want: false},
+		{selector: "!region", want: true},
+		{selector: "!team", want: false},
+		{selector: "replicas=3", want: true},
+		{selector: "public=true", want: true},
+		{selector: "env=dev,team=data", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseLabelSelector(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, selector.Matches(labels))
+		})
+	}
+}
+
+// TestBunStateRepository_ListAfterSelector checks that the SQL generated for a
+// selector agrees with LabelSelector.Matches. It runs against in-memory SQLite
+// so it does not need the PostgreSQL test database.
+func TestBunStateRepository_ListAfterSelector(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	repo := NewBunStateRepository(db)
+	labelSets := []models.LabelMap{
+		{"env": "dev", "team": "platform"},
+		{"env": "dev", "team": "data", "public": true},
+		{"env": "staging", "team": "platform", "replicas": float64(3)},
+		{"env": "prod", "team": "platform", "deprecated": "yes"},
+		{"team": "data", "app/tier": "web"},
+	}
+	for i, labels := range labelSets {
+		err := repo.Create(ctx, &models.State{
+			GUID:    uuid.Must(uuid.NewV7()).String(),
+			LogicID: fmt.Sprintf("test-selector-%d", i),
+			Labels:  labels,
+		})
+		require.NoError(t, err)
+	}
+
+	for _, raw := range []string{
+		"env=dev",
+		"env!=dev",
+		"env in (dev,staging)",
+		"env notin (prod)",
+		"team=platform,!deprecated",
+		"env",
+		"!env",
+		"public=true",
+		"replicas=3",
+		"app/tier=web",
+		"team=data,env=dev",
+	} {
+		t.Run(raw, func(t *testing.T) {
+			selector, err := ParseLabelSelector(raw)
+			require.NoError(t, err)
+
+			want := []string{}
+			for i, labels := range labelSets {
+				if selector.Matches(labels) {
+					want = append(want, fmt.Sprintf("test-selector-%d", i))
+				}
+			}
+
+			states, err := repo.ListAfter(ctx, selector, nil, nil, 100)
+			require.NoError(t, err)
+			got := []string{}
+			for _, state := range states {
+				got = append(got, state.LogicID)
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr/grammar"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// scopeAll and scopeNone mirror auth.ScopeAll and auth.ScopeNone (auth
+// imports this package, so the sentinels cannot be shared).
+const (
+	scopeAll  = "*"
+	scopeNone = "!*"
+)
+
+// maxScopeFilterTerms bounds the label comparisons one scope expression may
+// compile to. Nested and/or/not expand as they are translated, so unusually
+// deep expressions are left to in-memory filtering instead.
+const maxScopeFilterTerms = 64
+
+// ScopeFilter is a SQL predicate over state labels compiled from role scope
+// expressions, so listings of narrowly scoped callers only fetch the states
+// those scopes can match.
+//
+// Supported expressions are translated exactly, including go-bexpr's rule
+// that an evaluation error (a missing label, or a value that cannot be
+// compared with the label's type) denies access: each sub-expression is
+// compiled to one predicate for "evaluates to true" and one for "evaluates
+// to false", and an error satisfies neither. The filter never rejects a state
+// that auth.EvaluateBexpr accepts, so callers keep their in-memory check.
+type ScopeFilter struct {
+	predicate *scopePredicate
+}
+
+// CompileScopeFilter compiles role scope expressions combined as a union
+// (any scope matches) or, with intersect, an intersection (every scope
+// matches). The supported subset is ==, !=, in, not in, contains and
+// not contains on a single label, joined with and, or, not and parentheses,
+// plus the auth.ScopeAll and auth.ScopeNone sentinels. == and != against a
+// numeric literal are not supported, since they compare numerically with
+// number labels.
+//
+// It returns nil when no rows can be excluded in SQL: the scopes match every
+// state, or a union contains an unsupported expression. Unsupported
+// expressions in an intersection are skipped, leaving the in-memory check to
+// apply them.
+func CompileScopeFilter(scopeExprs []string, intersect bool) *ScopeFilter {
+	var predicates []*scopePredicate
+	for _, expr := range scopeExprs {
+		predicate, ok := compileScope(expr)
+		if !ok {
+			if intersect {
+				continue
+			}
+			return nil
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	var combined *scopePredicate
+	switch {
+	case len(scopeExprs) == 0:
+		// No role scopes: nothing is visible, whichever way they combine
+		combined = scopeConst(false)
+	case intersect:
+		combined = scopeAnd(predicates...)
+	default:
+		combined = scopeOr(predicates...)
+	}
+	if combined.kind == scopeTrue {
+		return nil
+	}
+	return &ScopeFilter{predicate: combined}
+}
+
+// apply adds the filter to a query over "states AS s". A nil filter leaves
+// the query unchanged.
+func (f *ScopeFilter) apply(q *bun.SelectQuery, d dialect.Name) *bun.SelectQuery {
+	if f == nil {
+		return q
+	}
+	var args []any
+	query := f.predicate.render(d, &args)
+	return q.Where(query, args...)
+}
+
+type scopePredicateKind int
+
+const (
+	scopeFalse scopePredicateKind = iota
+	scopeTrue
+	scopeConjunction
+	scopeDisjunction
+	scopeStringEquals      // label is a string equal to value
+	scopeStringNotEquals   // label is a string not equal to value
+	scopeStringContains    // label is a string containing value
+	scopeStringNotContains // label is a string not containing value
+	scopeBoolEquals        // label is the boolean value ("true" or "false")
+)
+
+// scopePredicate is a dialect-independent condition on one state's labels.
+// It has no negation: "not" is compiled by swapping the true and false
+// predicates, so SQL NULLs from missing labels never turn into matches.
+type scopePredicate struct {
+	kind     scopePredicateKind
+	key      string
+	value    string
+	operands []*scopePredicate
+}
+
+// compileScope compiles one scope expression to the predicate for "evaluates
+// to true". ok is false when expr is outside the supported subset.
+func compileScope(expr string) (*scopePredicate, bool) {
+	switch strings.TrimSpace(expr) {
+	case scopeAll:
+		return scopeConst(true), true
+	case "", scopeNone:
+		return scopeConst(false), true
+	}
+
+	ast, err := grammar.Parse("", []byte(expr))
+	if err != nil {
+		// auth.EvaluateBexpr denies on invalid syntax
+		return scopeConst(false), true
+	}
+	whenTrue, _, ok := compileScopeNode(ast.(grammar.Expression))
+	if !ok || whenTrue.terms() > maxScopeFilterTerms {
+		return nil, false
+	}
+	return whenTrue, true
+}
+
+// compileScopeNode returns the predicates under which node evaluates to true
+// and to false without error. go-bexpr stops at the first error and
+// short-circuits and/or from the left, which the combinations below follow.
+func compileScopeNode(node grammar.Expression) (whenTrue, whenFalse *scopePredicate, ok bool) {
+	switch n := node.(type) {
+	case *grammar.UnaryExpression:
+		if n.Operator != grammar.UnaryOpNot {
+			return nil, nil, false
+		}
+		operandTrue, operandFalse, ok := compileScopeNode(n.Operand)
+		return operandFalse, operandTrue, ok
+
+	case *grammar.BinaryExpression:
+		leftTrue, leftFalse, ok := compileScopeNode(n.Left)
+		if !ok {
+			return nil, nil, false
+		}
+		rightTrue, rightFalse, ok := compileScopeNode(n.Right)
+		if !ok {
+			return nil, nil, false
+		}
+		switch n.Operator {
+		case grammar.BinaryOpAnd:
+			return scopeAnd(leftTrue, rightTrue), scopeOr(leftFalse, scopeAnd(leftTrue, rightFalse)), true
+		case grammar.BinaryOpOr:
+			return scopeOr(leftTrue, scopeAnd(leftFalse, rightTrue)), scopeAnd(leftFalse, rightFalse), true
+		}
+		return nil, nil, false
+
+	case *grammar.MatchExpression:
+		return compileScopeMatch(n)
+	}
+	// Collection expressions (any/all) are not supported
+	return nil, nil, false
+}
+
+func compileScopeMatch(match *grammar.MatchExpression) (whenTrue, whenFalse *scopePredicate, ok bool) {
+	// Labels are flat, and a missing key is an error only at the top level
+	if len(match.Selector.Path) != 1 || match.Value == nil {
+		return nil, nil, false
+	}
+	key, raw := match.Selector.Path[0], match.Value.Raw
+
+	switch match.Operator {
+	case grammar.MatchEqual, grammar.MatchNotEqual:
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return nil, nil, false
+		}
+		equal := &scopePredicate{kind: scopeStringEquals, key: key, value: raw}
+		notEqual := &scopePredicate{kind: scopeStringNotEquals, key: key, value: raw}
+		// The literal is coerced to the label's type: a boolean label
+		// compares with literals strconv.ParseBool accepts and errors otherwise
+		if b, err := strconv.ParseBool(raw); err == nil {
+			equal = scopeOr(equal, &scopePredicate{kind: scopeBoolEquals, key: key, value: strconv.FormatBool(b)})
+			notEqual = scopeOr(notEqual, &scopePredicate{kind: scopeBoolEquals, key: key, value: strconv.FormatBool(!b)})
+		}
+		if match.Operator == grammar.MatchNotEqual {
+			return notEqual, equal, true
+		}
+		return equal, notEqual, true
+
+	case grammar.MatchIn, grammar.MatchNotIn:
+		// Substring match; any label other than a string is an error
+		contains := &scopePredicate{kind: scopeStringContains, key: key, value: raw}
+		notContains := &scopePredicate{kind: scopeStringNotContains, key: key, value: raw}
+		if match.Operator == grammar.MatchNotIn {
+			return notContains, contains, true
+		}
+		return contains, notContains, true
+	}
+	// is empty, is not empty, matches and not matches are not supported
+	return nil, nil, false
+}
+
+func scopeConst(value bool) *scopePredicate {
+	if value {
+		return &scopePredicate{kind: scopeTrue}
+	}
+	return &scopePredicate{kind: scopeFalse}
+}
+
+// scopeAnd returns the conjunction of operands, folding constants.
+func scopeAnd(operands ...*scopePredicate) *scopePredicate {
+	return scopeJoin(scopeConjunction, scopeTrue, scopeFalse, operands)
+}
+
+// scopeOr returns the disjunction of operands, folding constants.
+func scopeOr(operands ...*scopePredicate) *scopePredicate {
+	return scopeJoin(scopeDisjunction, scopeFalse, scopeTrue, operands)
+}
+
+// scopeJoin combines operands with kind, dropping identity operands and
+// collapsing to the absorbing constant when one is present.
+func scopeJoin(kind, identity, absorbing scopePredicateKind, operands []*scopePredicate) *scopePredicate {
+	var kept []*scopePredicate
+	for _, operand := range operands {
+		switch operand.kind {
+		case identity:
+			continue
+		case absorbing:
+			return &scopePredicate{kind: absorbing}
+		case kind:
+			kept = append(kept, operand.operands...)
+		default:
+			kept = append(kept, operand)
+		}
+	}
+	switch len(kept) {
+	case 0:
+		return &scopePredicate{kind: identity}
+	case 1:
+		return kept[0]
+	}
+	return &scopePredicate{kind: kind, operands: kept}
+}
+
+// terms counts the label comparisons in p.
+func (p *scopePredicate) terms() int {
+	if p.kind != scopeConjunction && p.kind != scopeDisjunction {
+		return 1
+	}
+	n := 0
+	for _, operand := range p.operands {
+		n += operand.terms()
+	}
+	return n
+}
+
+// render returns SQL for p over "states AS s", appending its arguments to args.
+func (p *scopePredicate) render(d dialect.Name, args *[]any) string {
+	switch p.kind {
+	case scopeFalse:
+		return "(1 = 0)"
+	case scopeTrue:
+		return "(1 = 1)"
+	case scopeConjunction, scopeDisjunction:
+		sep := " AND "
+		if p.kind == scopeDisjunction {
+			sep = " OR "
+		}
+		parts := make([]string, len(p.operands))
+		for i, operand := range p.operands {
+			parts[i] = operand.render(d, args)
+		}
+		return "(" + strings.Join(parts, sep) + ")"
+	case scopeBoolEquals:
+		if d == dialect.SQLite {
+			// json_type reports booleans as 'true' or 'false'
+			*args = append(*args, sqliteLabelPath(p.key), p.value)
+			return "(json_type(s.labels, ?) = ?)"
+		}
+		*args = append(*args, p.key, p.key, p.value)
+		return "(jsonb_typeof(s.labels->?) = 'boolean' AND s.labels->>? = ?)"
+	}
+
+	isString, value, valueArgs := labelStringExprs(p.key, d)
+	var cond string
+	switch p.kind {
+	case scopeStringEquals:
+		cond = value + " = ?"
+	case scopeStringNotEquals:
+		cond = value + " <> ?"
+	case scopeStringContains:
+		cond = stringPositionExpr(value, d) + " > 0"
+	case scopeStringNotContains:
+		cond = stringPositionExpr(value, d) + " = 0"
+	}
+	*args = append(*args, valueArgs...)
+	*args = append(*args, p.value)
+	return "(" + isString + " AND " + cond + ")"
+}
+
+// labelStringExprs returns SQL testing that a label is a JSON string and SQL
+// yielding its value, with the arguments for both in order.
+func labelStringExprs(key string, d dialect.Name) (isString, value string, args []any) {
+	if d == dialect.SQLite {
+		path := sqliteLabelPath(key)
+		return "json_type(s.labels, ?) = 'text'", "json_extract(s.labels, ?)", []any{path, path}
+	}
+	return "jsonb_typeof(s.labels->?) = 'string'", "s.labels->>?", []any{key, key}
+}
+
+// stringPositionExpr returns SQL for the 1-based position of the next
+// argument in value, 0 when absent (an empty argument is found at 1).
+func stringPositionExpr(value string, d dialect.Name) string {
+	if d == dialect.SQLite {
+		return "instr(" + value + ", ?)"
+	}
+	return "strpos(" + value + ", ?)"
+}
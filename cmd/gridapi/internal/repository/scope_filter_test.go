@@ -0,0 +1,184 @@
+// External test package: auth imports repository, and these tests compare
+// the SQL filter with auth.EvaluateBexpr.
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+var scopeLabelSets = []models.LabelMap{
+	{"env": "dev", "team": "platform"},
+	{"env": "dev", "team": "data", "public": true},
+	{"env": "staging", "team": "platform", "replicas": float64(3)},
+	{"env": "prod", "team": "platform", "public": false, "region": "us"},
+	{"team": "data", "app/tier": "web", "flag": "true"},
+	{"env": "", "region": "eu"},
+	{"env": float64(1), "team": "ops"},
+	{},
+}
+
+// setupScopeRepo stores one state per label set in in-memory SQLite, named
+// after its index in scopeLabelSets.
+func setupScopeRepo(t *testing.T) repository.StateRepository {
+	t.Helper()
+
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	repo := repository.NewBunStateRepository(db)
+	for i, labels := range scopeLabelSets {
+		err := repo.Create(ctx, &models.State{
+			GUID:    uuid.Must(uuid.NewV7()).String(),
+			LogicID: fmt.Sprintf("scope-%d", i),
+			Labels:  labels,
+		})
+		require.NoError(t, err)
+	}
+	return repo
+}
+
+// scopedLogicIDs lists the states filter lets through.
+func scopedLogicIDs(t *testing.T, repo repository.StateRepository, filter *repository.ScopeFilter) []string {
+	t.Helper()
+
+	states, err := repo.ListAfter(context.Background(), nil, filter, nil, 100)
+	require.NoError(t, err)
+	got := []string{}
+	for _, state := range states {
+		got = append(got, state.LogicID)
+	}
+	return got
+}
+
+// evaluatedLogicIDs lists the states auth.EvaluateBexpr admits for scopes,
+// combined the way the state handlers combine role scopes.
+func evaluatedLogicIDs(scopes []string, intersect bool) []string {
+	want := []string{}
+	for i, labels := range scopeLabelSets {
+		matched := intersect && len(scopes) > 0
+		for _, scope := range scopes {
+			if intersect {
+				matched = matched && auth.EvaluateBexpr(scope, labels)
+			} else {
+				matched = matched || auth.EvaluateBexpr(scope, labels)
+			}
+		}
+		if matched {
+			want = append(want, fmt.Sprintf("scope-%d", i))
+		}
+	}
+	return want
+}
+
+func TestScopeFilter_AgreesWithEvaluateBexpr(t *testing.T) {
+	repo := setupScopeRepo(t)
+
+	for _, expr := range []string{
+		`env == "dev"`,
+		`env != "dev"`,
+		`env == dev`,
+		`env == ""`,
+		`"/env" == "dev"`,
+		`app/tier == "web"`,
+		`"de" in env`,
+		`"" in env`,
+		`env contains "ev"`,
+		`env not contains "ev"`,
+		`"d" not in env`,
+		`replicas contains "3"`,
+		`public == true`,
+		`public != false`,
+		`public == "True"`,
+		`flag == true`,
+		`flag != "TRUE"`,
+		`not env == "dev"`,
+		`not (env == "dev")`,
+		`env == "dev" and team == "platform"`,
+		`env == "dev" or team == "data"`,
+		`env == "dev" or region == "us"`,
+		`region == "us" or env == "dev"`,
+		`env == "prod" and region == "us"`,
+		`region == "us" and env == "prod"`,
+		`not (env == "dev" and region == "us")`,
+		`not (region == "us" or env == "dev")`,
+		`(env == "dev" or env == "staging") and not (team == "data")`,
+		`team == "data" and (public == true or flag == true)`,
+		`env = "invalid syntax"`,
+		auth.ScopeNone,
+		"",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			filter := repository.CompileScopeFilter([]string{expr}, false)
+			require.NotNil(t, filter, "expression should compile to SQL")
+			assert.Equal(t, evaluatedLogicIDs([]string{expr}, false), scopedLogicIDs(t, repo, filter))
+		})
+	}
+}
+
+func TestScopeFilter_CombinesRoleScopes(t *testing.T) {
+	repo := setupScopeRepo(t)
+
+	tests := []struct {
+		name      string
+		scopes    []string
+		intersect bool
+	}{
+		{name: "union", scopes: []string{`env == "dev"`, `team == "platform"`}},
+		{name: "intersection", scopes: []string{`env == "dev"`, `team == "platform"`}, intersect: true},
+		{name: "union with an erroring scope", scopes: []string{`region == "us"`, `team == "data"`}},
+		{name: "intersection with none", scopes: []string{`team == "platform"`, auth.ScopeNone}, intersect: true},
+		{name: "intersection with all", scopes: []string{`team == "platform"`, auth.ScopeAll}, intersect: true},
+		{name: "no scopes", scopes: nil},
+		{name: "no scopes intersected", scopes: nil, intersect: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := repository.CompileScopeFilter(tt.scopes, tt.intersect)
+			require.NotNil(t, filter)
+			assert.Equal(t, evaluatedLogicIDs(tt.scopes, tt.intersect), scopedLogicIDs(t, repo, filter))
+		})
+	}
+}
+
+func TestCompileScopeFilter_FallsBackForUnsupportedExpressions(t *testing.T) {
+	repo := setupScopeRepo(t)
+
+	unsupported := []string{
+		`replicas == 3`,
+		`env == "1"`,
+		`env is empty`,
+		`env matches "^d"`,
+		`meta.owner == "x"`,
+		`any tags as tag { tag == "x" }`,
+	}
+	for _, expr := range unsupported {
+		t.Run(expr, func(t *testing.T) {
+			// In a union an unsupported scope could admit any state
+			assert.Nil(t, repository.CompileScopeFilter([]string{`env == "dev"`, expr}, false))
+			assert.Nil(t, repository.CompileScopeFilter([]string{expr}, true))
+
+			// In an intersection the supported scopes still narrow the query
+			scopes := []string{`team == "platform"`, expr}
+			filter := repository.CompileScopeFilter(scopes, true)
+			require.NotNil(t, filter)
+			assert.Subset(t, scopedLogicIDs(t, repo, filter), evaluatedLogicIDs(scopes, true))
+		})
+	}
+
+	t.Run("matching every state", func(t *testing.T) {
+		assert.Nil(t, repository.CompileScopeFilter([]string{auth.ScopeAll}, false))
+		assert.Nil(t, repository.CompileScopeFilter([]string{`env == "dev"`, auth.ScopeAll}, false))
+	})
+}
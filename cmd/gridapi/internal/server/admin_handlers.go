@@ -1,9 +1,14 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
@@ -19,15 +24,16 @@ func HandleCacheRefresh(iamService iamAdminService) http.HandlerFunc {
 		ctx := r.Context()
 
 		// Get authenticated principal from context (set by auth middleware)
-		principal, ok := auth.GetUserFromContext(ctx)
-		if !ok {
+		principal, err := auth.RequirePrincipal(ctx)
+		if err != nil {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		// Convert to iam.Principal for authorization (only roles needed)
 		iamPrincipal := &iam.Principal{
-			Roles: principal.Roles,
+			Roles:       principal.Roles,
+			TokenScopes: principal.TokenScopes,
 		}
 
 		// Check admin:cache-refresh permission
@@ -65,3 +71,403 @@ func HandleCacheRefresh(iamService iamAdminService) http.HandlerFunc {
 			principal.PrincipalID, snapshot.Version, len(snapshot.Mappings))
 	}
 }
+
+// CredentialTestRequest represents the body of POST /admin/credentials/test
+type CredentialTestRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// CredentialCheckResponse represents a single authorization probe result
+type CredentialCheckResponse struct {
+	Object  string `json:"object"`
+	Action  string `json:"action"`
+	Allowed bool   `json:"allowed"`
+}
+
+// CredentialTestResponse represents the response from POST /admin/credentials/test
+type CredentialTestResponse struct {
+	ClientID            string                    `json:"client_id"`
+	ServiceAccountID    string                    `json:"service_account_id,omitempty"`
+	ServiceAccountName  string                    `json:"service_account_name,omitempty"`
+	Authenticated       bool                      `json:"authenticated"`
+	AuthenticationError string                    `json:"authentication_error,omitempty"`
+	Roles               []string                  `json:"roles"`
+	Checks              []CredentialCheckResponse `json:"checks"`
+}
+
+// HandleCredentialTest handles POST /admin/credentials/test
+// Runs the client-credentials flow for a service account without issuing a
+// token and reports authentication and authorization outcomes.
+//
+// Authorization: Requires admin:service-account-manage permission
+// Response: JSON CredentialTestResponse (200 even when the credentials are rejected)
+func HandleCredentialTest(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		principal, err := auth.RequirePrincipal(ctx)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := iamService.Authorize(ctx, &iam.Principal{Roles: principal.Roles, TokenScopes: principal.TokenScopes}, auth.ObjectTypeAdmin, auth.AdminServiceAccountManage, nil)
+		if err != nil {
+			log.Printf("Authorization check failed: %v", err)
+			http.Error(w, "Authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, "Forbidden: requires admin:service-account-manage permission", http.StatusForbidden)
+			return
+		}
+
+		var req CredentialTestRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.ClientID == "" || req.ClientSecret == "" {
+			http.Error(w, "Missing client_id or client_secret", http.StatusBadRequest)
+			return
+		}
+
+		result, err := iamService.TestCredentials(ctx, req.ClientID, req.ClientSecret)
+		if err != nil {
+			log.Printf("ERROR: Credential test failed: %v", err)
+			http.Error(w, "Credential test failed", http.StatusInternalServerError)
+			return
+		}
+
+		resp := CredentialTestResponse{
+			ClientID:            result.ClientID,
+			ServiceAccountID:    result.ServiceAccountID,
+			ServiceAccountName:  result.ServiceAccountName,
+			Authenticated:       result.Authenticated,
+			AuthenticationError: result.AuthenticationError,
+			Roles:               result.Roles,
+			Checks:              make([]CredentialCheckResponse, 0, len(result.Checks)),
+		}
+		if resp.Roles == nil {
+			resp.Roles = []string{}
+		}
+		for _, check := range result.Checks {
+			resp.Checks = append(resp.Checks, CredentialCheckResponse{
+				Object:  check.Object,
+				Action:  check.Action,
+				Allowed: check.Allowed,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+
+		log.Printf("INFO: Credential test for client %s by %s (authenticated=%t)",
+			req.ClientID, principal.PrincipalID, result.Authenticated)
+	}
+}
+
+// signingKeyRotator is implemented by *auth.Provider.
+type signingKeyRotator interface {
+	RotateSigningKey(ctx context.Context) (*auth.SigningKeyRotation, error)
+}
+
+// HandleSigningKeyRotate handles POST /admin/signing-key/rotate
+// Replaces the internal IdP token signing key. The previous key remains in the
+// JWKS until its tokens have expired.
+//
+// Authorization: Requires admin:signing-key-rotate permission
+// Response: JSON with the new kid, the previous kid and its retirement time
+func HandleSigningKeyRotate(rotator signingKeyRotator, iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		principal, err := auth.RequirePrincipal(ctx)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := iamService.Authorize(ctx, &iam.Principal{Roles: principal.Roles, TokenScopes: principal.TokenScopes}, auth.ObjectTypeAdmin, auth.AdminSigningKeyRotate, nil)
+		if err != nil {
+			log.Printf("Authorization check failed: %v", err)
+			http.Error(w, "Authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, "Forbidden: requires admin:signing-key-rotate permission", http.StatusForbidden)
+			return
+		}
+
+		rotation, err := rotator.RotateSigningKey(ctx)
+		if err != nil {
+			log.Printf("ERROR: Signing key rotation failed: %v", err)
+			http.Error(w, "Signing key rotation failed", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":             "success",
+			"kid":                rotation.KeyID,
+			"previous_kid":       rotation.PreviousKeyID,
+			"previous_retire_at": rotation.RetireAt.UTC().Format(time.RFC3339),
+		})
+
+		log.Printf("INFO: Signing key rotated by %s (kid=%s, previous=%s, retire_at=%s)",
+			principal.PrincipalID, rotation.KeyID, rotation.PreviousKeyID, rotation.RetireAt.Format(time.RFC3339))
+	}
+}
+
+// RevocationEpochRequest represents the body of POST /admin/revocation-epoch
+type RevocationEpochRequest struct {
+	// Epoch is an RFC 3339 timestamp; empty means now
+	Epoch string `json:"epoch,omitempty"`
+}
+
+// HandleRevocationEpochBump handles POST /admin/revocation-epoch
+// Advances the global revocation epoch. Every JWT and session issued before
+// the epoch, including the caller's, stops authenticating immediately.
+//
+// Authorization: Requires admin:session-revoke permission
+// Response: JSON with the new epoch
+func HandleRevocationEpochBump(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		principal, err := auth.RequirePrincipal(ctx)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := iamService.Authorize(ctx, &iam.Principal{Roles: principal.Roles, TokenScopes: principal.TokenScopes}, auth.ObjectTypeAdmin, auth.AdminSessionRevoke, nil)
+		if err != nil {
+			log.Printf("Authorization check failed: %v", err)
+			http.Error(w, "Authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, "Forbidden: requires admin:session-revoke permission", http.StatusForbidden)
+			return
+		}
+
+		var req RevocationEpochRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+		}
+		var epoch time.Time
+		if req.Epoch != "" {
+			epoch, err = time.Parse(time.RFC3339, req.Epoch)
+			if err != nil {
+				http.Error(w, "Invalid epoch: want an RFC 3339 timestamp", http.StatusBadRequest)
+				return
+			}
+		}
+
+		epoch, err = iamService.BumpRevocationEpoch(ctx, epoch, principal.PrincipalID)
+		if err != nil {
+			if errors.Is(err, iam.ErrInvalidRevocationEpoch) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			log.Printf("ERROR: Revocation epoch bump failed: %v", err)
+			http.Error(w, "Revocation epoch bump failed", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"epoch":  epoch.Format(time.RFC3339),
+		})
+
+		log.Printf("INFO: Revocation epoch bumped to %s by %s", epoch.Format(time.RFC3339), principal.PrincipalID)
+	}
+}
+
+// maxAuditLogPageSize caps GET /admin/audit-log responses
+const maxAuditLogPageSize = 500
+
+// AuditLogEntryResponse is one entry of GET /admin/audit-log
+type AuditLogEntryResponse struct {
+	ID         string         `json:"id"`
+	OccurredAt string         `json:"occurred_at"`
+	Actor      string         `json:"actor"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type"`
+	TargetID   string         `json:"target_id"`
+	Before     map[string]any `json:"before,omitempty"`
+	After      map[string]any `json:"after,omitempty"`
+	Outcome    string         `json:"outcome"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// HandleAuditLog handles GET /admin/audit-log
+// Lists IAM audit entries, newest first. Query parameters (all optional):
+// actor, action, target_type, target_id, outcome, since and until (RFC 3339),
+// page_size (default and max 500) and offset.
+//
+// Authorization: Requires admin:audit-read permission
+// Response: JSON with an entries array
+func HandleAuditLog(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		principal, err := auth.RequirePrincipal(ctx)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := iamService.Authorize(ctx, &iam.Principal{Roles: principal.Roles, TokenScopes: principal.TokenScopes}, auth.ObjectTypeAdmin, auth.AdminAuditRead, nil)
+		if err != nil {
+			log.Printf("Authorization check failed: %v", err)
+			http.Error(w, "Authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, "Forbidden: requires admin:audit-read permission", http.StatusForbidden)
+			return
+		}
+
+		filter, err := parseAuditLogFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		entries, err := iamService.ListAuditLog(ctx, filter)
+		if err != nil {
+			log.Printf("ERROR: Audit log query failed: %v", err)
+			http.Error(w, "Audit log query failed", http.StatusInternalServerError)
+			return
+		}
+
+		resp := make([]AuditLogEntryResponse, 0, len(entries))
+		for _, entry := range entries {
+			item := AuditLogEntryResponse{
+				ID:         entry.ID,
+				OccurredAt: entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+				Actor:      entry.Actor,
+				Action:     entry.Action,
+				TargetType: entry.TargetType,
+				TargetID:   entry.TargetID,
+				Before:     entry.Before,
+				After:      entry.After,
+				Outcome:    entry.Outcome,
+			}
+			if entry.Error != nil {
+				item.Error = *entry.Error
+			}
+			resp = append(resp, item)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"entries": resp}); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// parseAuditLogFilter reads the GET /admin/audit-log query parameters
+func parseAuditLogFilter(r *http.Request) (iam.AuditLogFilter, error) {
+	query := r.URL.Query()
+	filter := iam.AuditLogFilter{
+		Actor:      query.Get("actor"),
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		TargetID:   query.Get("target_id"),
+		Outcome:    query.Get("outcome"),
+		PageSize:   maxAuditLogPageSize,
+	}
+
+	for name, dst := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if raw := query.Get(name); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: want an RFC 3339 timestamp", name)
+			}
+			*dst = &t
+		}
+	}
+	if raw := query.Get("page_size"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxAuditLogPageSize {
+			return filter, fmt.Errorf("invalid page_size: want 1-%d", maxAuditLogPageSize)
+		}
+		filter.PageSize = n
+	}
+	if raw := query.Get("offset"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("invalid offset: want a non-negative integer")
+		}
+		filter.Offset = n
+	}
+	return filter, nil
+}
+
+// HandleAccessReview handles GET /admin/access-review
+// Exports every user, service account and group mapping with the roles they
+// grant. format=csv returns one row per principal and role instead of JSON.
+//
+// Authorization: Requires admin:access-review permission
+// Response: JSON iam.AccessReview, or text/csv
+func HandleAccessReview(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		principal, err := auth.RequirePrincipal(ctx)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := iamService.Authorize(ctx, &iam.Principal{Roles: principal.Roles, TokenScopes: principal.TokenScopes}, auth.ObjectTypeAdmin, auth.AdminAccessReview, nil)
+		if err != nil {
+			log.Printf("Authorization check failed: %v", err)
+			http.Error(w, "Authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, "Forbidden: requires admin:access-review permission", http.StatusForbidden)
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			http.Error(w, "Invalid format: want json or csv", http.StatusBadRequest)
+			return
+		}
+
+		review, err := iamService.ExportAccessReview(ctx)
+		if err != nil {
+			log.Printf("ERROR: Access review export failed: %v", err)
+			http.Error(w, "Access review export failed", http.StatusInternalServerError)
+			return
+		}
+
+		if format == "csv" {
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="access-review.csv"`)
+			if err := review.WriteCSV(w); err != nil {
+				log.Printf("ERROR: Access review CSV encoding failed: %v", err)
+			}
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(review); err != nil {
+				http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			}
+		}
+
+		log.Printf("INFO: Access review exported by %s (users=%d, service_accounts=%d, group_mappings=%d)",
+			principal.PrincipalID, len(review.Users), len(review.ServiceAccounts), len(review.GroupMappings))
+	}
+}
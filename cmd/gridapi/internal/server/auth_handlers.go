@@ -1,9 +1,12 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -64,7 +68,12 @@ func HandleSSOCallback(rpAuth *auth.RelyingParty, iamService iamAdminService) ht
 			}
 		}
 		// Create session via IAM service
-		_, token, err := iamService.CreateSession(ctx, user.ID, rawIDToken, tokens.Expiry)
+		_, token, err := iamService.CreateSession(ctx, user.ID, rawIDToken, tokens.Expiry, r.Header)
+		if errors.Is(err, iam.ErrSessionBindingHeaderMissing) {
+			log.Printf("SSO callback: refused session without binding header (user_id=%s)", user.ID)
+			http.Error(w, "Session binding header required", http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			log.Printf("SSO callback: failed to create session (user_id=%s): %v", user.ID, err)
 			http.Error(w, "Failed to create session", http.StatusInternalServerError)
@@ -115,22 +124,59 @@ func HandleLogout(iamService iamAdminService) http.HandlerFunc {
 		}
 
 		// Clear the session cookie
-		cookie := &http.Cookie{
-			Name:     auth.SessionCookieName,
-			Value:    "",
-			Path:     "/",
-			Expires:  time.Unix(0, 0),
-			HttpOnly: true,
-			Secure:   r.URL.Scheme == "https",
-			SameSite: http.SameSiteLaxMode,
-		}
-		http.SetCookie(w, cookie)
+		clearSessionCookie(w, r)
 
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("Logged out"))
 	}
 }
 
+// deviceAuthorizationCompleter is implemented by *auth.Provider.
+type deviceAuthorizationCompleter interface {
+	CompleteDeviceAuthorization(ctx context.Context, userCode, subject string, approved bool) error
+}
+
+// deviceVerifyRequest is the body of POST /auth/device/verify.
+type deviceVerifyRequest struct {
+	UserCode string `json:"user_code"`
+	Approve  bool   `json:"approve"`
+}
+
+// HandleDeviceVerify lets a signed-in user approve or deny a device flow user code.
+// The polling client receives its token (or access_denied) on its next poll.
+func HandleDeviceVerify(completer deviceAuthorizationCompleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.GetUserFromContext(r.Context())
+		if !ok {
+			http.Error(w, "No active session", http.StatusUnauthorized)
+			return
+		}
+
+		var req deviceVerifyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.UserCode) == "" {
+			http.Error(w, "user_code is required", http.StatusBadRequest)
+			return
+		}
+
+		if err := completer.CompleteDeviceAuthorization(r.Context(), req.UserCode, principal.Subject, req.Approve); err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				http.Error(w, "Unknown or expired user code", http.StatusNotFound)
+				return
+			}
+			log.Printf("ERROR: Device authorization failed: %v", err)
+			http.Error(w, "Failed to complete device authorization", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if req.Approve {
+			_, _ = w.Write([]byte("Device approved"))
+		} else {
+			_, _ = w.Write([]byte("Device denied"))
+		}
+	}
+}
+
 // AuthConfigResponse tells SDK clients how to authenticate
 type AuthConfigResponse struct {
 	Mode               string  `json:"mode"`                 // "external-idp" or "internal-idp"
@@ -180,20 +226,120 @@ func HandleAuthConfig(cfg *config.Config) http.HandlerFunc {
 	}
 }
 
-// InternalLoginRequest represents credentials for internal IdP authentication
+// HandlePasswordPolicy returns the internal IdP password policy so clients can
+// display the requirements before a password is submitted.
+func HandlePasswordPolicy(policy *auth.PasswordPolicy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(policy.Describe()); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// ChangePasswordRequest is the body of POST /auth/password.
+type ChangePasswordRequest struct {
+	OldPassword         string `json:"old_password"`
+	NewPassword         string `json:"new_password"`
+	RevokeOtherSessions bool   `json:"revoke_other_sessions"`
+}
+
+// PasswordPolicyErrorResponse names the policy requirement a new password failed.
+type PasswordPolicyErrorResponse struct {
+	Error       string `json:"error"`
+	Requirement string `json:"requirement"`
+}
+
+// HandleChangePassword lets a signed-in internal IdP user change their own
+// password. The caller's current session is kept when other sessions are revoked.
+func HandleChangePassword(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.GetUserFromContext(r.Context())
+		if !ok {
+			http.Error(w, "No active session", http.StatusUnauthorized)
+			return
+		}
+		if principal.Type != auth.PrincipalTypeUser {
+			http.Error(w, "Only users can change passwords", http.StatusForbidden)
+			return
+		}
+
+		var req ChangePasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.OldPassword == "" || req.NewPassword == "" {
+			http.Error(w, "Missing old or new password", http.StatusBadRequest)
+			return
+		}
+
+		err := iamService.ChangePassword(r.Context(), principal.InternalID, req.OldPassword, req.NewPassword, iam.ChangePasswordOptions{
+			RevokeOtherSessions: req.RevokeOtherSessions,
+			KeepSessionID:       principal.SessionID,
+		})
+		var policyErr *auth.PasswordPolicyError
+		switch {
+		case err == nil:
+		case errors.Is(err, iam.ErrIncorrectPassword):
+			http.Error(w, "Incorrect password", http.StatusForbidden)
+			return
+		case errors.Is(err, iam.ErrNoLocalPassword):
+			http.Error(w, "Password is managed by the identity provider", http.StatusConflict)
+			return
+		case errors.As(err, &policyErr):
+			writePasswordPolicyError(w, policyErr)
+			return
+		default:
+			log.Printf("ERROR: Password change failed (user_id=%s): %v", principal.InternalID, err)
+			http.Error(w, "Failed to change password", http.StatusInternalServerError)
+			return
+		}
+
+		log.Printf("INFO: Password changed by %s (revoke_other_sessions=%t)", principal.PrincipalID, req.RevokeOtherSessions)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// writePasswordPolicyError responds 400 with the unmet policy requirement.
+func writePasswordPolicyError(w http.ResponseWriter, policyErr *auth.PasswordPolicyError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(PasswordPolicyErrorResponse{
+		Error:       policyErr.Error(),
+		Requirement: policyErr.Requirement,
+	})
+}
+
+// writePasswordChangeRequired responds 403 with a machine-readable body so the
+// webapp can ask for a new password and retry the login with new_password set.
+func writePasswordChangeRequired(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":   "password_change_required",
+		"message": "your password was reset, choose a new password to continue",
+	})
+}
+
+// InternalLoginRequest represents credentials for internal IdP authentication.
+// NewPassword is only used when the account must change its password (after
+// an admin reset); it replaces Password before the session is issued.
 type InternalLoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	NewPassword string `json:"new_password,omitempty"`
 }
 
 // UserResponse represents user data in API responses
 type UserResponse struct {
-	ID       string   `json:"id"`
-	Username string   `json:"username"`
-	Email    string   `json:"email"`
-	AuthType string   `json:"auth_type"`
-	Roles    []string `json:"roles"`
-	Groups   []string `json:"groups,omitempty"` // Group memberships (external IdP only)
+	ID          string   `json:"id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"display_name,omitempty"` // Set by whoami, from the authenticated principal
+	AuthType    string   `json:"auth_type"`
+	Roles       []string `json:"roles"`
+	Groups      []string `json:"groups,omitempty"` // Group memberships (external IdP only)
 }
 
 // LoginResponse represents the response from POST /auth/login
@@ -212,8 +358,14 @@ type SessionResponse struct {
 type WhoamiResponse struct {
 	User    UserResponse    `json:"user"`
 	Session SessionResponse `json:"session"`
+	// Permissions is only set for ?include=permissions
+	Permissions []iam.EffectivePermission `json:"permissions,omitempty"`
 }
 
+// whoamiIncludePermissions is the ?include= value that adds the caller's
+// effective permissions to the whoami response.
+const whoamiIncludePermissions = "permissions"
+
 // HandleInternalLogin authenticates users with username/password for internal IdP mode
 func HandleInternalLogin(iamService iamAdminService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -232,15 +384,12 @@ func HandleInternalLogin(iamService iamAdminService) http.HandlerFunc {
 			return
 		}
 
-		// Lookup user by email (via IAM service)
+		// Lookup user by email (via IAM service). Unknown users and users
+		// without a password still pay for a bcrypt comparison so response
+		// timing does not reveal which accounts exist.
 		user, err := iamService.GetUserByEmail(ctx, req.Username)
-		if err != nil {
-			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
-			return
-		}
-
-		// Verify password hash
-		if user.PasswordHash == nil || *user.PasswordHash == "" {
+		if err != nil || user.PasswordHash == nil || *user.PasswordHash == "" {
+			_ = verifyPasswordHash(dummyPasswordHash, req.Password)
 			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 			return
 		}
@@ -256,16 +405,42 @@ func HandleInternalLogin(iamService iamAdminService) http.HandlerFunc {
 			return
 		}
 
+		// A temporary password from an admin reset only buys a password change
+		if user.MustChangePassword {
+			if req.NewPassword == "" {
+				writePasswordChangeRequired(w)
+				return
+			}
+			err := iamService.ChangePassword(ctx, user.ID, req.Password, req.NewPassword, iam.ChangePasswordOptions{})
+			var policyErr *auth.PasswordPolicyError
+			switch {
+			case err == nil:
+				log.Printf("INFO: Temporary password replaced at login by user:%s", user.PrincipalSubject())
+			case errors.As(err, &policyErr):
+				writePasswordPolicyError(w, policyErr)
+				return
+			default:
+				log.Printf("ERROR: Forced password change failed (user_id=%s): %v", user.ID, err)
+				http.Error(w, "Failed to change password", http.StatusInternalServerError)
+				return
+			}
+		}
+
 		// Create session via IAM service
 		expiresAt := time.Now().Add(2 * time.Hour)
-		session, token, err := iamService.CreateSession(ctx, user.ID, "", expiresAt)
+		session, token, err := iamService.CreateSession(ctx, user.ID, "", expiresAt, r.Header)
+		if errors.Is(err, iam.ErrSessionBindingHeaderMissing) {
+			log.Printf("internal login: refused session without binding header (user_id=%s)", user.ID)
+			http.Error(w, "Session binding header required", http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			http.Error(w, "Failed to create session", http.StatusInternalServerError)
 			return
 		}
 
 		// Resolve roles for internal IdP (no groups, isUser=true)
-		roles, err := iamService.ResolveRoles(ctx, user.ID, []string{}, true)
+		roles, err := iamService.ResolveRoles(ctx, user.ID, []string{}, nil, true)
 		if err != nil {
 			roles = []string{}
 		}
@@ -304,7 +479,9 @@ func HandleInternalLogin(iamService iamAdminService) http.HandlerFunc {
 	}
 }
 
-// HandleWhoAmI returns the authenticated user's information and session metadata
+// HandleWhoAmI returns the authenticated user's information and session metadata.
+// With ?include=permissions it also lists what the user's roles grant and deny,
+// scope expressions included: users may always see their own grants.
 func HandleWhoAmI(iamService iamAdminService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -345,29 +522,43 @@ func HandleWhoAmI(iamService iamAdminService) http.HandlerFunc {
 			// This is not a fatal error - user may have no groups
 			groups = []string{}
 		}
+		claims, _ := auth.ExtractClaimsFromIDToken(session.IDToken)
 
 		// Resolve roles via IAM service with groups
 		// For external IdP users, this uses the group→role cache to map groups to roles
-		roles, err := iamService.ResolveRoles(ctx, user.ID, groups, true /* isUser */)
+		roles, err := iamService.ResolveRoles(ctx, user.ID, groups, claims, true /* isUser */)
 		if err != nil {
 			roles = []string{}
 		}
 
+		// Effective permissions are opt-in to keep the default response unchanged
+		var permissions []iam.EffectivePermission
+		if r.URL.Query().Get("include") == whoamiIncludePermissions {
+			permissions, err = iamService.GetEffectivePermissions(ctx, roles)
+			if err != nil {
+				log.Printf("ERROR: Failed to resolve effective permissions (user_id=%s): %v", user.ID, err)
+				http.Error(w, "Failed to resolve permissions", http.StatusInternalServerError)
+				return
+			}
+		}
+
 		// Build response
 		w.Header().Set("Content-Type", "application/json")
 		resp := WhoamiResponse{
 			User: UserResponse{
-				ID:       user.ID,
-				Username: user.Name,
-				Email:    user.Email,
-				AuthType: authType,
-				Roles:    roles,
-				Groups:   groups,
+				ID:          user.ID,
+				Username:    user.Name,
+				Email:       user.Email,
+				DisplayName: principal.DisplayName,
+				AuthType:    authType,
+				Roles:       roles,
+				Groups:      groups,
 			},
 			Session: SessionResponse{
 				ID:        session.ID,
 				ExpiresAt: session.ExpiresAt.UnixMilli(),
 			},
+			Permissions: permissions,
 		}
 		if err := json.NewEncoder(w).Encode(resp); err != nil {
 			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
@@ -375,6 +566,11 @@ func HandleWhoAmI(iamService iamAdminService) http.HandlerFunc {
 	}
 }
 
+// dummyPasswordHash is compared against when login finds no usable password
+// hash. It uses the cost `gridapi users create` hashes with, so failed logins
+// take comparable time whether or not the user exists.
+const dummyPasswordHash = "$2a$12$RWy1KklAKPaCJOtNVIHn1Otpq1f3k8nB0dZqn063od/T4TBRlrGNa"
+
 // verifyPasswordHash checks if the provided password matches the bcrypt hash
 func verifyPasswordHash(hash, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// loginIAMService knows a single user. Only the methods the login handler
+// calls are implemented; other methods panic via the nil embed.
+type loginIAMService struct {
+	iamAdminService
+	user *models.User
+}
+
+func (s *loginIAMService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if s.user == nil || email != s.user.Email {
+		return nil, errors.New("user not found")
+	}
+	return s.user, nil
+}
+
+func (s *loginIAMService) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string, opts iam.ChangePasswordOptions) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(*s.user.PasswordHash), []byte(oldPassword)); err != nil {
+		return iam.ErrIncorrectPassword
+	}
+	if len(newPassword) < 12 {
+		return &auth.PasswordPolicyError{Requirement: auth.PasswordRequirementMinLength, Message: "must be at least 12 characters"}
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.MinCost)
+	if err != nil {
+		return err
+	}
+	passwordHash := string(hash)
+	s.user.PasswordHash = &passwordHash
+	s.user.MustChangePassword = false
+	return nil
+}
+
+func (s *loginIAMService) CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time, headers http.Header) (*models.Session, string, error) {
+	return &models.Session{ID: "sess-1", UserID: &userID, ExpiresAt: expiresAt}, "session-token", nil
+}
+
+func (s *loginIAMService) ResolveRoles(ctx context.Context, principalID string, groups []string, claims map[string]any, isUser bool) ([]string, error) {
+	return nil, nil
+}
+
+func TestHandleInternalLogin_InvalidCredentialsAreIndistinguishable(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	passwordHash := string(hash)
+	handler := HandleInternalLogin(&loginIAMService{user: &models.User{
+		ID:           "user-alice",
+		Email:        "alice@example.com",
+		PasswordHash: &passwordHash,
+	}})
+
+	login := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body)))
+		return rec
+	}
+
+	unknownUser := login(`{"username":"nobody@example.com","password":"correct-password"}`)
+	wrongPassword := login(`{"username":"alice@example.com","password":"wrong-password"}`)
+
+	assert.Equal(t, http.StatusUnauthorized, unknownUser.Code)
+	assert.Equal(t, http.StatusUnauthorized, wrongPassword.Code)
+	assert.Equal(t, wrongPassword.Body.String(), unknownUser.Body.String())
+	assert.Equal(t, wrongPassword.Header(), unknownUser.Header())
+	assert.Equal(t, "Invalid credentials\n", unknownUser.Body.String())
+}
+
+func TestHandleInternalLogin_ForcedPasswordChange(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("temp-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	passwordHash := string(hash)
+	iamService := &loginIAMService{user: &models.User{
+		ID:                 "user-alice",
+		Email:              "alice@example.com",
+		PasswordHash:       &passwordHash,
+		MustChangePassword: true,
+	}}
+	handler := HandleInternalLogin(iamService)
+
+	login := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body)))
+		return rec
+	}
+
+	// The temporary password alone gets no session
+	rec := login(`{"username":"alice@example.com","password":"temp-password"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"error":"password_change_required"`)
+	assert.Empty(t, rec.Result().Cookies())
+
+	// A new password failing the policy names the requirement
+	rec = login(`{"username":"alice@example.com","password":"temp-password","new_password":"short"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"requirement":"min_length"`)
+	assert.Empty(t, rec.Result().Cookies())
+
+	// Supplying a compliant new password completes the login
+	rec = login(`{"username":"alice@example.com","password":"temp-password","new_password":"chosen-password-42"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, rec.Result().Cookies(), 1)
+	assert.False(t, iamService.user.MustChangePassword)
+
+	// From then on the new password logs in normally
+	rec = login(`{"username":"alice@example.com","password":"chosen-password-42"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestDummyPasswordHash(t *testing.T) {
+	t.Parallel()
+
+	// The dummy comparison only costs as much as a real one if the hash parses
+	// and uses the cost `gridapi users create` hashes passwords with.
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	require.NoError(t, err)
+	assert.Equal(t, 12, cost)
+}
+
+func TestHandlePasswordPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := auth.NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 12, RequireSymbol: true})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	HandlePasswordPolicy(policy)(rec, httptest.NewRequest(http.MethodGet, "/auth/password-policy", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"min_length": 12,
+		"require_uppercase": false,
+		"require_lowercase": false,
+		"require_digit": false,
+		"require_symbol": true,
+		"breach_check": false
+	}`, rec.Body.String())
+}
+
+// whoamiIAMService serves a single session for alice, who holds the "ops" role.
+type whoamiIAMService struct {
+	iamAdminService
+}
+
+func (s *whoamiIAMService) GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error) {
+	return &models.Session{ID: sessionID, ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+func (s *whoamiIAMService) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	return &models.User{ID: userID, Name: "alice", Email: "alice@example.com"}, nil
+}
+
+func (s *whoamiIAMService) ResolveRoles(ctx context.Context, principalID string, groups []string, claims map[string]any, isUser bool) ([]string, error) {
+	return []string{"ops"}, nil
+}
+
+func (s *whoamiIAMService) GetEffectivePermissions(ctx context.Context, roles []string) ([]iam.EffectivePermission, error) {
+	return []iam.EffectivePermission{
+		{Role: "ops", RoleAction: iam.RoleAction{Action: "state:tfstate:read", ScopeExpr: `env == "prod"`, Effect: auth.EffectAllow}},
+		{Role: "ops", RoleAction: iam.RoleAction{Action: "!state:tfstate:write", ScopeExpr: `env == "prod"`, Effect: auth.EffectDeny}},
+	}, nil
+}
+
+func TestHandleWhoAmI_IncludePermissions(t *testing.T) {
+	t.Parallel()
+
+	whoami := func(target string) map[string]any {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req = req.WithContext(auth.SetUserContext(req.Context(), auth.AuthenticatedPrincipal{
+			InternalID:  "user-alice",
+			SessionID:   "sess-1",
+			DisplayName: "Alice Liddell",
+		}))
+		rec := httptest.NewRecorder()
+		HandleWhoAmI(&whoamiIAMService{})(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body
+	}
+
+	// The default response shape is unchanged
+	body := whoami("/api/auth/whoami")
+	assert.NotContains(t, body, "permissions")
+	assert.Equal(t, "Alice Liddell", body["user"].(map[string]any)["display_name"])
+
+	body = whoami("/api/auth/whoami?include=permissions")
+	assert.Equal(t, []any{
+		map[string]any{"role": "ops", "action": "state:tfstate:read", "scope_expr": `env == "prod"`, "effect": "allow"},
+		map[string]any{"role": "ops", "action": "!state:tfstate:write", "scope_expr": `env == "prod"`, "effect": "deny"},
+	}, body["permissions"])
+}
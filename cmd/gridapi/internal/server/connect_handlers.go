@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"maps"
 	"sort"
 	"strings"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/go-bexpr"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 	gridmiddleware "github.com/terraconstructs/grid/cmd/gridapi/internal/middleware"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/dependency"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
 	statepkg "github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
 	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
 	"github.com/terraconstructs/grid/pkg/api/state/v1/statev1connect"
@@ -29,6 +33,7 @@ type StateServiceHandler struct {
 	authnDeps     *gridmiddleware.AuthnDependencies
 	cfg           *config.Config
 	validationJob *SchemaValidationJob // Optional dependency for schema validation
+	watchHub      *StateWatchHub       // Optional dependency for WatchStateChanges
 }
 
 // NewStateServiceHandler constructs a handler backed by the provided service.
@@ -69,7 +74,13 @@ func (h *StateServiceHandler) CreateState(
 		}
 	}
 
-	summary, config, err := h.service.CreateState(ctx, req.Msg.Guid, req.Msg.LogicId, labels)
+	// Record the creator so roles can grant owner override on the state
+	var createdBy string
+	if principal, ok := auth.GetUserFromContext(ctx); ok {
+		createdBy = principal.PrincipalID
+	}
+
+	summary, config, err := h.service.CreateStateIdempotent(ctx, req.Msg.IdempotencyKey, req.Msg.Guid, req.Msg.LogicId, labels, createdBy)
 	if err != nil {
 		return nil, mapServiceError(err)
 	}
@@ -113,24 +124,46 @@ func (h *StateServiceHandler) ListStates(
 		includeStatus = *req.Msg.IncludeStatus
 	}
 
-	// Get states - use ListWithFilter if filter provided
-	var summaries []statepkg.StateSummary
-	var err error
-
-	if filter != "" {
-		summaries, err = h.service.ListStatesWithFilter(ctx, filter, 1000, 0)
-	} else {
-		summaries, err = h.service.ListStates(ctx)
+	if req.Msg.PageSize < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page_size %d", req.Msg.PageSize))
 	}
+
+	// Narrow the query to the caller's role scopes up front; the in-memory
+	// check below still decides visibility
+	scope, err := h.stateScopeFilter(ctx)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, err
 	}
 
-	// Filter states based on user's role label scopes
-	// This ensures users only see states they have access to
-	filteredSummaries, err := h.filterStatesByRoleScopes(ctx, summaries)
-	if err != nil {
-		return nil, mapServiceError(err)
+	var filteredSummaries []statepkg.StateSummary
+	var nextPageToken string
+	if req.Msg.PageSize > 0 || req.Msg.LabelSelector != "" {
+		pageSize := int(req.Msg.PageSize)
+		if pageSize == 0 {
+			pageSize = MaxListPageSize
+		}
+		filteredSummaries, nextPageToken, err = h.listStatesPage(ctx, filter, req.Msg.LabelSelector, scope, pageSize, req.Msg.PageToken)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Get states - use ListWithFilter if filter provided
+		var summaries []statepkg.StateSummary
+		if filter != "" {
+			summaries, err = h.service.ListStatesWithFilter(ctx, filter, scope, 1000, 0)
+		} else {
+			summaries, err = h.service.ListStates(ctx, scope)
+		}
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+
+		// Filter states based on user's role label scopes
+		// This ensures users only see states they have access to
+		filteredSummaries, err = h.filterStatesByRoleScopes(ctx, summaries)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
 	}
 
 	infos := make([]*statev1.StateInfo, 0, len(filteredSummaries))
@@ -178,10 +211,69 @@ func (h *StateServiceHandler) ListStates(
 		infos = append(infos, info)
 	}
 
-	resp := &statev1.ListStatesResponse{States: infos}
+	resp := &statev1.ListStatesResponse{States: infos, NextPageToken: nextPageToken}
 	return connect.NewResponse(resp), nil
 }
 
+// listStatesPage returns one page of states visible to the caller, ordered by
+// creation time then GUID. The label selector narrows the database query; the bexpr filter and
+// role scopes are applied to each fetched batch, so a page only comes back
+// short when the listing is exhausted.
+func (h *StateServiceHandler) listStatesPage(ctx context.Context, filter, selector string, scope *repository.ScopeFilter, pageSize int, pageToken string) ([]statepkg.StateSummary, string, error) {
+	after, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := parseStateCursor(after); err != nil {
+		return nil, "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	labelSelector, err := repository.ParseLabelSelector(selector)
+	if err != nil {
+		return nil, "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var evaluator *bexpr.Evaluator
+	if filter != "" {
+		evaluator, err = bexpr.CreateEvaluator(filter)
+		if err != nil {
+			return nil, "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid filter expression: %w", err))
+		}
+	}
+
+	page, last, err := collectPage(min(pageSize, MaxListPageSize), after,
+		func(after string, limit int) ([]statepkg.StateSummary, error) {
+			cursor, err := parseStateCursor(after)
+			if err != nil {
+				return nil, err
+			}
+			return h.service.ListStatesAfter(ctx, labelSelector, scope, cursor, limit)
+		},
+		func(batch []statepkg.StateSummary) ([]statepkg.StateSummary, error) {
+			if evaluator != nil {
+				matched := make([]statepkg.StateSummary, 0, len(batch))
+				for _, summary := range batch {
+					labels := summary.Labels
+					if labels == nil {
+						labels = make(models.LabelMap)
+					}
+					// Evaluation errors (e.g. missing label key) count as no match
+					if ok, err := evaluator.Evaluate(map[string]any(labels)); err == nil && ok {
+						matched = append(matched, summary)
+					}
+				}
+				batch = matched
+			}
+			return h.filterStatesByRoleScopes(ctx, batch)
+		},
+		func(summary statepkg.StateSummary) string { return stateCursorKey(summary.CreatedAt, summary.GUID) },
+	)
+	if err != nil {
+		return nil, "", mapServiceError(err)
+	}
+
+	return page, encodePageToken(last), nil
+}
+
 // GetStateConfig retrieves backend configuration for an existing state by logic_id.
 func (h *StateServiceHandler) GetStateConfig(
 	ctx context.Context,
@@ -245,6 +337,9 @@ func summaryToProto(summary statepkg.StateSummary) *statev1.StateInfo {
 		DependentsCount:   &dependentsCount,
 		OutputsCount:      &outputsCount,
 	}
+	if summary.CreatedBy != "" {
+		info.CreatedBy = &summary.CreatedBy
+	}
 	if !summary.CreatedAt.IsZero() {
 		info.CreatedAt = timestamppb.New(summary.CreatedAt)
 	}
@@ -342,6 +437,62 @@ func (h *StateServiceHandler) UpdateStateLabels(
 	return connect.NewResponse(resp), nil
 }
 
+// TransferStateOwnership records a new owner for a state.
+// The interceptor admits the current owner or principals with state:transfer-ownership.
+func (h *StateServiceHandler) TransferStateOwnership(
+	ctx context.Context,
+	req *connect.Request[statev1.TransferStateOwnershipRequest],
+) (*connect.Response[statev1.TransferStateOwnershipResponse], error) {
+	if h.iamService == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
+	}
+
+	var guid string
+	switch state := req.Msg.State.(type) {
+	case *statev1.TransferStateOwnershipRequest_LogicId:
+		resolved, _, err := h.service.GetStateConfig(ctx, state.LogicId)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		guid = resolved
+	case *statev1.TransferStateOwnershipRequest_Guid:
+		guid = state.Guid
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (logic_id or guid)"))
+	}
+
+	// Owners are recorded in the principal ID format the authenticators produce
+	var newOwner string
+	switch req.Msg.NewOwnerType {
+	case "user":
+		user, err := h.iamService.GetUserBySubject(ctx, req.Msg.NewOwnerId)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		newOwner = auth.UserID(user.PrincipalSubject())
+	case "service_account":
+		sa, err := h.iamService.GetServiceAccountByClientID(ctx, req.Msg.NewOwnerId)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		newOwner = auth.ServiceAccountID(sa.ClientID)
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid new owner type: %s", req.Msg.NewOwnerType))
+	}
+
+	previousOwner, err := h.service.TransferOwnership(ctx, guid, newOwner)
+	h.iamService.RecordOwnershipTransfer(ctx, guid, previousOwner, newOwner, err)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	return connect.NewResponse(&statev1.TransferStateOwnershipResponse{
+		Guid:          guid,
+		PreviousOwner: previousOwner,
+		NewOwner:      newOwner,
+	}), nil
+}
+
 // GetLabelPolicy retrieves the current label validation policy.
 // T036: Implements GetLabelPolicy RPC handler.
 func (h *StateServiceHandler) GetLabelPolicy(
@@ -411,75 +562,239 @@ func (h *StateServiceHandler) SetLabelPolicy(
 // Helper functions for state filtering and label value conversion
 
 // filterStatesByRoleScopes filters states based on the user's role label scopes.
-// For each state, it checks if the state's labels match ANY of the user's role scopes.
-// Platform engineers (with no label scope constraints) see all states.
+// For each state, it checks if the state's labels match ANY of the user's role scopes
+// (or ALL of them when states are configured for intersection) and no deny scope,
+// see matchesRoleScopes.
+// Platform engineers (scoped to auth.ScopeAll) see all states.
 // Product engineers (with env=="dev" scope) only see states with env=dev labels.
 // In no-auth mode (no principal), all states are returned.
 func (h *StateServiceHandler) filterStatesByRoleScopes(ctx context.Context, summaries []statepkg.StateSummary) ([]statepkg.StateSummary, error) {
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !scoped {
+		return summaries, nil
+	}
+
+	// If no role allows reading states, return empty list
+	// Be restrictive: if we can't determine permissions, deny access
+	if roleScopes.empty() {
+		return []statepkg.StateSummary{}, nil
+	}
+
+	// Filter states based on role scopes
+	filtered := make([]statepkg.StateSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		if h.matchesRoleScopes(roleScopes, summary.Labels) {
+			filtered = append(filtered, summary)
+		}
+	}
+
+	return filtered, nil
+}
+
+// roleScopes are the label scopes under which the caller's roles allow or
+// deny reading states. Only roles with a state read allow rule contribute to
+// allow; deny rules for state read from any role are collected in deny and
+// override every allow, as deny rules do in the authorization model.
+type roleScopes struct {
+	allow [][]string // Per role allowing state read, the scopes of its allow rules
+	deny  []string
+}
+
+// empty reports whether no role allows reading any state.
+func (s roleScopes) empty() bool {
+	return len(s.allow) == 0
+}
+
+// callerRoleScopes returns the state read scopes of the caller's roles, read
+// from each role's policies ([role, obj, act, scopeExpr, eft]) with the
+// matcher's rules: the object is "state" or "*" and the action state read or
+// "*". scoped is false when results should not be filtered at all: in no-auth
+// mode (no principal) or when the IAM service is unavailable. Roles without
+// policies (e.g. unknown roles) contribute no scope.
+//
+// A role that auth.ParseRoleName rejects is looked up by its raw value with a
+// warning, unless strict role parsing is configured: then the request fails
+// closed with a permission-denied error.
+func (h *StateServiceHandler) callerRoleScopes(ctx context.Context) (scopes roleScopes, scoped bool, err error) {
 	// Get principal from context
 	principal, ok := auth.GetUserFromContext(ctx)
 	if !ok {
 		// No principal - this means auth is disabled (no-auth mode)
-		// Return all states without filtering
-		return summaries, nil
+		return roleScopes{}, false, nil
 	}
 
-	// If IAM service not available, return all states (backwards compatibility)
+	// If IAM service not available, skip filtering (backwards compatibility)
 	if h.iamService == nil {
-		return summaries, nil
+		return roleScopes{}, false, nil
 	}
 
-	// Get role scopes from the user's roles
-	// We need to extract the role names from the Casbin role identifiers (e.g., "role:platform-engineer")
-	roleScopes := make([]string, 0, len(principal.Roles))
-	for _, casbinRole := range principal.Roles {
-		roleName, err := auth.ExtractRoleID(casbinRole)
+	// Principal roles are role names; "role:" identifiers are accepted as well
+	for _, principalRole := range principal.Roles {
+		roleName, err := auth.ParseRoleName(principalRole)
 		if err != nil {
-			// try with casbinRole as is
-			roleName = casbinRole
-			// TODO: Review Mode 1 vs  Mode 2 role prefix handling?
-			log.Printf("warning: could not extract role name from casbin role %s: %v", casbinRole, err)
+			if h.cfg != nil && h.cfg.StrictRoleParsing {
+				return roleScopes{}, true, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("cannot determine role scopes: %w", err))
+			}
+			log.Printf("warning: %v; looking it up as a role name (GRID_STRICT_ROLE_PARSING denies instead)", err)
+			roleName = principalRole
 		}
 
-		role, err := h.iamService.GetRoleByName(ctx, roleName)
+		policies, err := h.iamService.GetRolePermissions(ctx, roleName)
 		if err != nil {
-			continue // Skip roles that don't exist
+			return roleScopes{}, true, connect.NewError(connect.CodeInternal, fmt.Errorf("load role %s policies: %w", roleName, err))
 		}
 
-		roleScopes = append(roleScopes, role.ScopeExpr)
+		var allow []string
+		for _, policy := range policies {
+			if len(policy) < 5 || !appliesToStateRead(policy[1], policy[2]) {
+				continue
+			}
+			switch policy[4] {
+			case auth.EffectAllow:
+				allow = append(allow, policy[3])
+			case auth.EffectDeny:
+				scopes.deny = append(scopes.deny, policy[3])
+			}
+		}
+		if len(allow) > 0 {
+			scopes.allow = append(scopes.allow, allow)
+		}
 	}
 
-	// If user has no roles or couldn't fetch any, return empty list
-	// Be restrictive: if we can't determine permissions, deny access
-	if len(roleScopes) == 0 {
-		return []statepkg.StateSummary{}, nil
+	return scopes, true, nil
+}
+
+// appliesToStateRead reports whether a policy's object and action match a
+// state read check.
+func appliesToStateRead(obj, act string) bool {
+	return (obj == auth.ObjectTypeState || obj == "*") && (act == auth.StateRead || act == "*")
+}
+
+// authorizeStateScope returns a permission-denied error unless the caller's role
+// scopes match the labels of the given state. Used by handlers that return data
+// for a single state, mirroring the filtering applied to list responses.
+func (h *StateServiceHandler) authorizeStateScope(ctx context.Context, guid string) error {
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil {
+		return err
+	}
+	if !scoped {
+		return nil
 	}
 
-	// Filter states based on role scopes
-	filtered := make([]statepkg.StateSummary, 0, len(summaries))
-	for _, summary := range summaries {
-		// Check if state matches ANY of the user's role scopes
-		matchesAnyScope := false
-		for _, scopeExpr := range roleScopes {
-			// Empty scope expression means no constraint (matches all states)
-			if strings.TrimSpace(scopeExpr) == "" {
-				matchesAnyScope = true
-				break
-			}
+	state, err := h.service.GetStateByGUID(ctx, guid)
+	if err != nil {
+		return mapServiceError(err)
+	}
 
-			// Evaluate bexpr against state labels
-			if auth.EvaluateBexpr(scopeExpr, summary.Labels) {
-				matchesAnyScope = true
-				break
-			}
+	if !h.matchesRoleScopes(roleScopes, state.Labels) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("role scopes do not permit access to state %s", guid))
+	}
+	return nil
+}
+
+// authorizeStateAction reports whether the caller may perform action on the
+// state, evaluated against the state's labels with owner override, as the
+// authorization interceptor does for single-state RPCs. Without
+// authentication (no-auth mode) every action is allowed.
+func (h *StateServiceHandler) authorizeStateAction(ctx context.Context, state *models.State, action string) (bool, error) {
+	principal, ok := auth.GetUserFromContext(ctx)
+	if !ok || h.iamService == nil {
+		return true, nil
+	}
+
+	labels := make(map[string]interface{}, len(state.Labels))
+	maps.Copy(labels, state.Labels)
+	allowed, err := gridmiddleware.AuthorizeWithOwner(ctx, h.iamService, principal, auth.ObjectTypeState, action, labels, state.CreatedBy)
+	if err != nil {
+		return false, connect.NewError(connect.CodeInternal, fmt.Errorf("authorization error: %w", err))
+	}
+	return allowed, nil
+}
+
+// canReadSensitiveOutputs reports whether the caller holds
+// state-output:read-sensitive on the state. Without authentication (no-auth
+// mode) the raw tfstate is readable anyway, so sensitive values are not redacted.
+func (h *StateServiceHandler) canReadSensitiveOutputs(ctx context.Context, state *models.State) (bool, error) {
+	principal, ok := auth.GetUserFromContext(ctx)
+	if !ok || h.iamService == nil {
+		return true, nil
+	}
+
+	labels := make(map[string]interface{}, len(state.Labels))
+	maps.Copy(labels, state.Labels)
+	allowed, err := h.iamService.Authorize(ctx, &iam.Principal{Roles: principal.Roles, TokenScopes: principal.TokenScopes}, auth.ObjectTypeState, auth.StateOutputReadSensitive, labels)
+	if err != nil {
+		return false, connect.NewError(connect.CodeInternal, fmt.Errorf("authorization error: %w", err))
+	}
+	return allowed, nil
+}
+
+// stateScopeFilter compiles the caller's allow scopes into a SQL pre-filter
+// for state listings. It returns nil when the caller is unscoped or a scope
+// is outside what repository.CompileScopeFilter translates. Deny scopes are
+// not compiled (a deny rule that fails to evaluate must not hide the state
+// in SQL only), and under intersection only roles with a single allow scope
+// are; either way filterStatesByRoleScopes still has the final say.
+func (h *StateServiceHandler) stateScopeFilter(ctx context.Context) (*repository.ScopeFilter, error) {
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil || !scoped {
+		return nil, err
+	}
+	intersect := h.intersectsStateScopes()
+	var exprs []string
+	for _, allow := range roleScopes.allow {
+		if intersect && len(allow) > 1 {
+			continue // Any of several scopes may match: not an intersection term
 		}
+		exprs = append(exprs, allow...)
+	}
+	if intersect && len(exprs) == 0 && !roleScopes.empty() {
+		return nil, nil
+	}
+	return repository.CompileScopeFilter(exprs, intersect), nil
+}
 
-		if matchesAnyScope {
-			filtered = append(filtered, summary)
+// matchesRoleScopes reports whether the caller may read a state with labels:
+// no deny scope matches, and the allow scopes match, combined across roles as
+// configured for states (see intersectsStateScopes).
+func (h *StateServiceHandler) matchesRoleScopes(roleScopes roleScopes, labels map[string]any) bool {
+	if roleScopes.empty() || matchesAnyScope(roleScopes.deny, labels) {
+		return false
+	}
+	intersect := h.intersectsStateScopes()
+	for _, allow := range roleScopes.allow {
+		matched := matchesAnyScope(allow, labels)
+		if intersect && !matched {
+			return false
+		}
+		if !intersect && matched {
+			return true
 		}
 	}
+	return intersect
+}
 
-	return filtered, nil
+// intersectsStateScopes reports whether a state must match the scopes of every
+// role (intersection, when "state" is in ScopeIntersectionObjectTypes) rather
+// than of any of them (union, the default).
+func (h *StateServiceHandler) intersectsStateScopes() bool {
+	return h.cfg != nil && iam.ScopeCombinationPolicy(h.cfg.ScopeIntersectionObjectTypes).For(auth.ObjectTypeState) == iam.ScopeIntersection
+}
+
+// matchesAnyScope reports whether labels satisfy at least one scope expression.
+// auth.ScopeAll matches every state; an empty expression matches none, so a role
+// only sees everything when it was deliberately left unscoped.
+func matchesAnyScope(scopeExprs []string, labels map[string]any) bool {
+	for _, scopeExpr := range scopeExprs {
+		if auth.EvaluateBexpr(scopeExpr, labels) {
+			return true
+		}
+	}
+	return false
 }
 
 // Helper functions for label value conversion
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -10,10 +11,29 @@ import (
 	"connectrpc.com/connect"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
 	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// mapIAMError translates IAM service errors to Connect codes. Errors without
+// an IAM sentinel fall back to mapServiceError.
+func mapIAMError(err error) error {
+	switch {
+	case errors.Is(err, iam.ErrRoleNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, iam.ErrRoleExists), errors.Is(err, iam.ErrDuplicateAssignment):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, iam.ErrRoleInUse), errors.Is(err, iam.ErrVersionConflict),
+		errors.Is(err, iam.ErrAssignmentCapExceeded), errors.Is(err, iam.ErrGroupSizeUnknown):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, iam.ErrPrincipalAmbiguous):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return mapServiceError(err)
+	}
+}
+
 // CreateServiceAccount creates a new service account.
 func (h *StateServiceHandler) CreateServiceAccount(
 	ctx context.Context,
@@ -25,16 +45,26 @@ func (h *StateServiceHandler) CreateServiceAccount(
 
 	// NOTE: Authz is handled by interceptors middleware
 	// cmd/gridapi/internal/middleware/authz_interceptor.go
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	if h.iamService == nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
 	}
 
-	// Create service account via IAM service
-	// TODO: Extract createdBy from Principal in context
-	sa, clientSecret, err := h.iamService.CreateServiceAccount(ctx, req.Msg.Name, "")
+	// created_by references users(id): accounts created by a service account,
+	// or with auth disabled, are attributed to the system user
+	createdBy := auth.SystemUserID
+	if principal.Type == auth.PrincipalTypeUser && principal.InternalID != "" {
+		createdBy = principal.InternalID
+	}
+
+	// Create service account and assign its roles via IAM service
+	sa, clientSecret, roles, err := h.iamService.CreateServiceAccount(ctx, req.Msg.Name, createdBy, req.Msg.RoleNames)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	resp := &statev1.CreateServiceAccountResponse{
@@ -43,12 +73,14 @@ func (h *StateServiceHandler) CreateServiceAccount(
 		ClientSecret: clientSecret,
 		Name:         sa.Name,
 		CreatedAt:    timestamppb.New(sa.CreatedAt),
+		Roles:        roles,
 	}
 
 	return connect.NewResponse(resp), nil
 }
 
-// ListServiceAccounts lists all service accounts.
+// ListServiceAccounts lists service accounts matching the request's filters,
+// one page at a time when page_size is set.
 func (h *StateServiceHandler) ListServiceAccounts(
 	ctx context.Context,
 	req *connect.Request[statev1.ListServiceAccountsRequest],
@@ -64,15 +96,38 @@ func (h *StateServiceHandler) ListServiceAccounts(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
 	}
 
-	// List service accounts via IAM service
-	sas, err := h.iamService.ListServiceAccounts(ctx)
+	if req.Msg.PageSize < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page_size %d", req.Msg.PageSize))
+	}
+	after, err := decodePageToken(req.Msg.PageToken)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	filter := iam.ServiceAccountFilter{
+		NamePrefix: req.Msg.GetNamePrefix(),
+		Disabled:   req.Msg.Disabled,
+		CreatedBy:  req.Msg.GetCreatedBy(),
+		AfterID:    after,
+		Limit:      min(int(req.Msg.PageSize), MaxListPageSize),
+	}
+	if req.Msg.LastUsedBefore != nil {
+		lastUsedBefore := req.Msg.LastUsedBefore.AsTime()
+		filter.LastUsedBefore = &lastUsedBefore
+	}
+
+	// List service accounts via IAM service (filters applied in SQL)
+	sas, err := h.iamService.ListServiceAccounts(ctx, filter)
+	if err != nil {
+		return nil, mapIAMError(err)
 	}
 
 	resp := &statev1.ListServiceAccountsResponse{
 		ServiceAccounts: make([]*statev1.ServiceAccountInfo, len(sas)),
 	}
+	if filter.Limit > 0 && len(sas) == filter.Limit {
+		resp.NextPageToken = encodePageToken(sas[len(sas)-1].ID)
+	}
 
 	for i, sa := range sas {
 		resp.ServiceAccounts[i] = &statev1.ServiceAccountInfo{
@@ -111,7 +166,7 @@ func (h *StateServiceHandler) RevokeServiceAccount(
 	// - Revoking all active sessions
 	// - Removing Casbin role assignments (out-of-band mutation)
 	if err := h.iamService.RevokeServiceAccount(ctx, req.Msg.ClientId); err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	return connect.NewResponse(&statev1.RevokeServiceAccountResponse{Success: true}), nil
@@ -138,17 +193,25 @@ func (h *StateServiceHandler) RotateServiceAccount(
 	// This handles:
 	// - Generating new secret
 	// - Hashing with bcrypt
-	// - Updating database
+	// - Updating database (keeping the old hash during an overlap)
 	// - Returning unhashed secret and rotation timestamp
-	clientSecret, rotatedAt, err := h.iamService.RotateServiceAccountSecret(ctx, req.Msg.ClientId)
+	overlapSeconds := req.Msg.GetOverlapSeconds()
+	if overlapSeconds < 0 || overlapSeconds > int64(iam.MaxSecretRotationOverlap/time.Second) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("overlap_seconds must be between 0 and %d", int64(iam.MaxSecretRotationOverlap/time.Second)))
+	}
+	overlap := time.Duration(overlapSeconds) * time.Second
+	rotation, err := h.iamService.RotateServiceAccountSecret(ctx, req.Msg.ClientId, overlap)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	resp := &statev1.RotateServiceAccountResponse{
 		ClientId:     req.Msg.ClientId,
-		ClientSecret: clientSecret,
-		RotatedAt:    timestamppb.New(rotatedAt),
+		ClientSecret: rotation.ClientSecret,
+		RotatedAt:    timestamppb.New(rotation.RotatedAt),
+	}
+	if rotation.PreviousSecretExpiresAt != nil {
+		resp.PreviousSecretExpiresAt = timestamppb.New(*rotation.PreviousSecretExpiresAt)
 	}
 
 	return connect.NewResponse(resp), nil
@@ -171,7 +234,7 @@ func (h *StateServiceHandler) AssignRole(
 	// Get role by name to find its ID
 	role, err := h.iamService.GetRoleByName(ctx, req.Msg.RoleName)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	// Determine principal type and get principal ID
@@ -180,13 +243,13 @@ func (h *StateServiceHandler) AssignRole(
 	case "user":
 		user, err := h.iamService.GetUserBySubject(ctx, req.Msg.PrincipalId)
 		if err != nil {
-			return nil, mapServiceError(err)
+			return nil, mapIAMError(err)
 		}
 		userID = user.ID
 	case "service_account":
 		sa, err := h.iamService.GetServiceAccountByClientID(ctx, req.Msg.PrincipalId)
 		if err != nil {
-			return nil, mapServiceError(err)
+			return nil, mapIAMError(err)
 		}
 		serviceAccountID = sa.ID
 	default:
@@ -195,11 +258,7 @@ func (h *StateServiceHandler) AssignRole(
 
 	// Delegate to IAM service (handles DB write, Casbin sync, rollback)
 	if err := h.iamService.AssignUserRole(ctx, userID, serviceAccountID, role.ID); err != nil {
-		// Map known errors to appropriate gRPC codes
-		if strings.Contains(err.Error(), "already assigned") {
-			return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("role '%s' is already assigned to principal", req.Msg.RoleName))
-		}
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	return connect.NewResponse(&statev1.AssignRoleResponse{
@@ -224,7 +283,7 @@ func (h *StateServiceHandler) RemoveRole(
 	// Get role by name to find its ID
 	role, err := h.iamService.GetRoleByName(ctx, req.Msg.RoleName)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	// Determine principal type and validate principal exists
@@ -234,14 +293,14 @@ func (h *StateServiceHandler) RemoveRole(
 		// PrincipalId contains internal user ID (UUID)
 		user, err := h.iamService.GetUserByID(ctx, req.Msg.PrincipalId)
 		if err != nil {
-			return nil, mapServiceError(err)
+			return nil, mapIAMError(err)
 		}
 		userID = user.ID
 	case "service_account":
 		// PrincipalId contains internal SA ID (UUID)
 		sa, err := h.iamService.GetServiceAccountByID(ctx, req.Msg.PrincipalId)
 		if err != nil {
-			return nil, mapServiceError(err)
+			return nil, mapIAMError(err)
 		}
 		serviceAccountID = sa.ID
 	default:
@@ -250,7 +309,7 @@ func (h *StateServiceHandler) RemoveRole(
 
 	// Delegate to IAM service (handles DB delete, Casbin removal)
 	if err := h.iamService.RemoveUserRole(ctx, userID, serviceAccountID, role.ID); err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	return connect.NewResponse(&statev1.RemoveRoleResponse{Success: true}), nil
@@ -272,17 +331,13 @@ func (h *StateServiceHandler) AssignGroupRole(
 	// Get role by name to find its ID
 	role, err := h.iamService.GetRoleByName(ctx, req.Msg.RoleName)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	// Delegate to IAM service (handles DB write, Casbin sync, cache refresh, rollback)
 	// Note: IAM service currently doesn't track AssignedBy - enhancement for later
-	if err := h.iamService.AssignGroupRole(ctx, req.Msg.GroupName, role.ID); err != nil {
-		// Map known errors to appropriate gRPC codes
-		if strings.Contains(err.Error(), "already assigned") {
-			return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("role '%s' is already assigned to group '%s'", req.Msg.RoleName, req.Msg.GroupName))
-		}
-		return nil, mapServiceError(err)
+	if err := h.iamService.AssignGroupRole(ctx, req.Msg.GroupName, role.ID, req.Msg.Condition); err != nil {
+		return nil, mapIAMError(err)
 	}
 
 	return connect.NewResponse(&statev1.AssignGroupRoleResponse{
@@ -307,12 +362,12 @@ func (h *StateServiceHandler) RemoveGroupRole(
 	// Get role by name to find its ID
 	role, err := h.iamService.GetRoleByName(ctx, req.Msg.RoleName)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	// Delegate to IAM service (handles DB delete, Casbin removal, cache refresh)
 	if err := h.iamService.RemoveGroupRole(ctx, req.Msg.GroupName, role.ID); err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	return connect.NewResponse(&statev1.RemoveGroupRoleResponse{Success: true}), nil
@@ -333,7 +388,7 @@ func (h *StateServiceHandler) ListGroupRoles(
 
 	groupRoles, err := h.iamService.ListGroupRoles(ctx, req.Msg.GroupName)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	assignments := make([]*statev1.GroupRoleAssignmentInfo, 0, len(groupRoles))
@@ -347,12 +402,16 @@ func (h *StateServiceHandler) ListGroupRoles(
 		// The AssignedBy field in the DB stores a user ID. We need to fetch the user to get a displayable name/email.
 		// For simplicity in this example, we'll return the ID directly.
 		// In a real app, you might want to fetch the user details.
-		assignments = append(assignments, &statev1.GroupRoleAssignmentInfo{
+		info := &statev1.GroupRoleAssignmentInfo{
 			GroupName:        gr.GroupName,
 			RoleName:         role.Name,
 			AssignedAt:       timestamppb.New(gr.AssignedAt),
 			AssignedByUserId: gr.AssignedBy, // Returning ID directly
-		})
+		}
+		if gr.Condition != nil {
+			info.Condition = *gr.Condition
+		}
+		assignments = append(assignments, info)
 	}
 
 	return connect.NewResponse(&statev1.ListGroupRolesResponse{Assignments: assignments}), nil
@@ -371,19 +430,26 @@ func (h *StateServiceHandler) GetEffectivePermissions(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
 	}
 
+	// Read before resolving permissions so a concurrent change yields a stale
+	// version rather than a current version paired with stale permissions.
+	modelVersion, err := h.iamService.AuthzModelVersion(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get authorization model version: %w", err))
+	}
+
 	var principalID string
 
 	switch req.Msg.PrincipalType {
 	case "user":
 		user, err := h.iamService.GetUserBySubject(ctx, req.Msg.PrincipalId)
 		if err != nil {
-			return nil, mapServiceError(err)
+			return nil, mapIAMError(err)
 		}
 		principalID = user.ID
 	case "service_account":
 		sa, err := h.iamService.GetServiceAccountByClientID(ctx, req.Msg.PrincipalId)
 		if err != nil {
-			return nil, mapServiceError(err)
+			return nil, mapIAMError(err)
 		}
 		principalID = sa.ID
 	default:
@@ -425,7 +491,8 @@ func (h *StateServiceHandler) GetEffectivePermissions(
 			continue // TODO: Don't ignore error (use multierr?)
 		}
 		for _, p := range permissions {
-			if len(p) > 2 { // p = [role, objType, action, ...]
+			// p = [role, objType, action, scopeExpr, effect]; deny rules grant nothing
+			if len(p) > 2 && (len(p) < 5 || p[4] != auth.EffectDeny) {
 				actions[p[2]] = struct{}{}
 			}
 		}
@@ -458,6 +525,7 @@ func (h *StateServiceHandler) GetEffectivePermissions(
 			EffectiveImmutableKeys: finalImmutableKeys,
 			// TODO: Aggregate CreateConstraints
 		},
+		AuthzModelVersion: modelVersion,
 	}
 
 	return connect.NewResponse(resp), nil
@@ -497,17 +565,13 @@ func (h *StateServiceHandler) CreateRole(
 		req.Msg.GetLabelScopeExpr(),
 		constraintsMap,
 		req.Msg.ImmutableKeys,
+		maxAssignmentsFromProto(req.Msg.MaxAssignments),
+		req.Msg.OwnerActions,
 		req.Msg.Actions,
+		req.Msg.DefaultLabels,
 	)
 	if err != nil {
-		// Map known errors to appropriate gRPC codes
-		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "already exists") {
-			return nil, connect.NewError(connect.CodeAlreadyExists, err)
-		}
-		if strings.Contains(err.Error(), "invalid label_scope_expr") {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
-		}
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	// Convert role to proto
@@ -534,7 +598,7 @@ func (h *StateServiceHandler) ListRoles(
 
 	roles, err := h.iamService.ListAllRoles(ctx)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	roleInfos := make([]*statev1.RoleInfo, 0, len(roles))
@@ -586,20 +650,13 @@ func (h *StateServiceHandler) UpdateRole(
 		req.Msg.GetLabelScopeExpr(),
 		constraintsMap,
 		req.Msg.ImmutableKeys,
+		maxAssignmentsFromProto(req.Msg.MaxAssignments),
+		req.Msg.OwnerActions,
 		req.Msg.Actions,
+		req.Msg.DefaultLabels,
 	)
 	if err != nil {
-		// Map known errors to appropriate gRPC codes
-		if strings.Contains(err.Error(), "version mismatch") || strings.Contains(err.Error(), "modified by another") {
-			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
-		}
-		if strings.Contains(err.Error(), "not found") {
-			return nil, connect.NewError(connect.CodeNotFound, err)
-		}
-		if strings.Contains(err.Error(), "invalid label_scope_expr") {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
-		}
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	// Convert role to proto
@@ -626,11 +683,7 @@ func (h *StateServiceHandler) DeleteRole(
 
 	// Delegate to IAM service (handles safety check, DB delete, Casbin cleanup)
 	if err := h.iamService.DeleteRole(ctx, req.Msg.Name); err != nil {
-		// Map known errors to appropriate gRPC codes
-		if strings.Contains(err.Error(), "still assigned") {
-			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
-		}
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	return connect.NewResponse(&statev1.DeleteRoleResponse{Success: true}), nil
@@ -651,25 +704,116 @@ func (h *StateServiceHandler) ListSessions(
 
 	sessions, err := h.iamService.ListUserSessions(ctx, req.Msg.UserId)
 	if err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	sessionInfos := make([]*statev1.SessionInfo, 0, len(sessions))
-	for _, s := range sessions {
-		info := &statev1.SessionInfo{
-			Id:         s.ID,
-			CreatedAt:  timestamppb.New(s.CreatedAt),
-			ExpiresAt:  timestamppb.New(s.ExpiresAt),
-			LastUsedAt: timestamppb.New(s.LastUsedAt),
-			UserAgent:  s.UserAgent,
-			IpAddress:  s.IPAddress,
-		}
-		sessionInfos = append(sessionInfos, info)
+	for i := range sessions {
+		sessionInfos = append(sessionInfos, sessionToProto(&sessions[i]))
 	}
 
 	return connect.NewResponse(&statev1.ListSessionsResponse{Sessions: sessionInfos}), nil
 }
 
+// ListAllSessions lists sessions across all users for admins.
+func (h *StateServiceHandler) ListAllSessions(
+	ctx context.Context,
+	req *connect.Request[statev1.ListAllSessionsRequest],
+) (*connect.Response[statev1.ListAllSessionsResponse], error) {
+	// NOTE: Authz is handled by interceptors middleware
+	// cmd/gridapi/internal/middleware/authz_interceptor.go
+	// authorization check (requires admin:session-list)
+
+	if h.iamService == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
+	}
+
+	filter := iam.SessionFilter{
+		UserID:           req.Msg.UserId,
+		ServiceAccountID: req.Msg.ServiceAccountId,
+		ActiveOnly:       req.Msg.ActiveOnly,
+		RevokedOnly:      req.Msg.RevokedOnly,
+		PageSize:         int(req.Msg.PageSize),
+		Offset:           int(req.Msg.Offset),
+	}
+	if req.Msg.CreatedAfter != nil {
+		createdAfter := req.Msg.CreatedAfter.AsTime()
+		filter.CreatedAfter = &createdAfter
+	}
+
+	sessions, err := h.iamService.ListAllSessions(ctx, filter)
+	if err != nil {
+		return nil, mapIAMError(err)
+	}
+
+	resp := &statev1.ListAllSessionsResponse{
+		Sessions: make([]*statev1.SessionInfo, 0, len(sessions)),
+	}
+	for i := range sessions {
+		resp.Sessions = append(resp.Sessions, sessionToProto(&sessions[i]))
+	}
+
+	// A full page may have more sessions behind it
+	pageSize := min(int(req.Msg.PageSize), iam.MaxSessionPageSize)
+	if pageSize <= 0 {
+		pageSize = iam.DefaultSessionPageSize
+	}
+	if len(sessions) == pageSize {
+		resp.NextOffset = req.Msg.Offset + int32(len(sessions))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// sessionToProto converts session metadata; tokens are never included.
+func sessionToProto(s *models.Session) *statev1.SessionInfo {
+	return &statev1.SessionInfo{
+		Id:               s.ID,
+		CreatedAt:        timestamppb.New(s.CreatedAt),
+		ExpiresAt:        timestamppb.New(s.ExpiresAt),
+		LastUsedAt:       timestamppb.New(s.LastUsedAt),
+		UserAgent:        s.UserAgent,
+		IpAddress:        s.IPAddress,
+		UserId:           s.UserID,
+		ServiceAccountId: s.ServiceAccountID,
+		Revoked:          s.Revoked,
+	}
+}
+
+// RevokeSessions revokes all sessions matching a filter for incident response.
+func (h *StateServiceHandler) RevokeSessions(
+	ctx context.Context,
+	req *connect.Request[statev1.RevokeSessionsRequest],
+) (*connect.Response[statev1.RevokeSessionsResponse], error) {
+	// NOTE: Authz is handled by interceptors middleware
+	// cmd/gridapi/internal/middleware/authz_interceptor.go
+	// authorization check (requires admin:session-revoke)
+
+	if h.iamService == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
+	}
+
+	filter := iam.SessionFilter{
+		UserID:           req.Msg.UserId,
+		ServiceAccountID: req.Msg.ServiceAccountId,
+	}
+	if req.Msg.CreatedBefore != nil {
+		createdBefore := req.Msg.CreatedBefore.AsTime()
+		filter.CreatedBefore = &createdBefore
+	}
+	if req.Msg.CreatedAfter != nil {
+		createdAfter := req.Msg.CreatedAfter.AsTime()
+		filter.CreatedAfter = &createdAfter
+	}
+
+	count, err := h.iamService.RevokeSessions(ctx, filter)
+	if err != nil {
+		return nil, mapIAMError(err)
+	}
+
+	return connect.NewResponse(&statev1.RevokeSessionsResponse{RevokedCount: int32(count)}), nil
+}
+
 // RevokeSession revokes a specific session.
 func (h *StateServiceHandler) RevokeSession(
 	ctx context.Context,
@@ -684,12 +828,121 @@ func (h *StateServiceHandler) RevokeSession(
 	}
 
 	if err := h.iamService.RevokeSession(ctx, req.Msg.SessionId); err != nil {
-		return nil, mapServiceError(err)
+		return nil, mapIAMError(err)
 	}
 
 	return connect.NewResponse(&statev1.RevokeSessionResponse{Success: true}), nil
 }
 
+// IntrospectToken reports whether a bearer token is currently active (RFC 7662 style).
+func (h *StateServiceHandler) IntrospectToken(
+	ctx context.Context,
+	req *connect.Request[statev1.IntrospectTokenRequest],
+) (*connect.Response[statev1.IntrospectTokenResponse], error) {
+	// NOTE: Authz is handled by interceptors middleware (admin only)
+	// cmd/gridapi/internal/middleware/authz_interceptor.go
+
+	if h.iamService == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
+	}
+
+	token := strings.TrimSpace(req.Msg.GetToken())
+	if token == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("token is required"))
+	}
+
+	result, err := h.iamService.IntrospectToken(ctx, token)
+	if err != nil {
+		return nil, mapIAMError(err)
+	}
+
+	resp := &statev1.IntrospectTokenResponse{
+		Active:         result.Active,
+		Scopes:         result.Scopes,
+		JtiRevoked:     result.JTIRevoked,
+		SessionRevoked: result.SessionRevoked,
+	}
+	if result.InactiveReason != "" {
+		resp.InactiveReason = &result.InactiveReason
+	}
+	if result.Subject != "" {
+		resp.Subject = &result.Subject
+	}
+	if result.ClientID != "" {
+		resp.ClientId = &result.ClientID
+	}
+	if result.JTI != "" {
+		resp.Jti = &result.JTI
+	}
+	if result.SessionID != "" {
+		resp.SessionId = &result.SessionID
+	}
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = timestamppb.New(result.ExpiresAt)
+	}
+	if !result.IssuedAt.IsZero() {
+		resp.IssuedAt = timestamppb.New(result.IssuedAt)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// PreviewAuthorization explains whether a hypothetical principal would be allowed an action.
+func (h *StateServiceHandler) PreviewAuthorization(
+	ctx context.Context,
+	req *connect.Request[statev1.PreviewAuthorizationRequest],
+) (*connect.Response[statev1.PreviewAuthorizationResponse], error) {
+	// NOTE: Authz is handled by interceptors middleware (admin only)
+	// cmd/gridapi/internal/middleware/authz_interceptor.go
+
+	if h.iamService == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("IAM service not available"))
+	}
+
+	if req.Msg.GetObject() == "" || req.Msg.GetAction() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("object and action are required"))
+	}
+	if len(req.Msg.GetRoles()) == 0 && len(req.Msg.GetGroups()) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at least one role or group is required"))
+	}
+
+	labels := make(map[string]interface{}, len(req.Msg.GetLabels()))
+	for key, labelValue := range req.Msg.GetLabels() {
+		labels[key] = protoLabelValueToGo(labelValue)
+	}
+
+	result, err := h.iamService.PreviewAuthorization(ctx, iam.AuthorizationPreview{
+		Roles:  req.Msg.GetRoles(),
+		Groups: req.Msg.GetGroups(),
+		Object: req.Msg.GetObject(),
+		Action: req.Msg.GetAction(),
+		Labels: labels,
+	})
+	if err != nil {
+		return nil, mapIAMError(err)
+	}
+
+	resp := &statev1.PreviewAuthorizationResponse{
+		Allowed:     result.Allowed,
+		Combination: string(result.Combination),
+		Roles:       make([]*statev1.RoleAuthorizationDecision, 0, len(result.Roles)),
+		Reason:      result.Reason,
+	}
+	for _, decision := range result.Roles {
+		roleDecision := &statev1.RoleAuthorizationDecision{
+			Role:    decision.Role,
+			Allowed: decision.Allowed,
+		}
+		if decision.Policy != nil {
+			roleDecision.PolicyAction = &decision.Policy.Action
+			roleDecision.PolicyScopeExpr = &decision.Policy.ScopeExpr
+		}
+		resp.Roles = append(resp.Roles, roleDecision)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
 // roleToProto is a helper to convert a database role model to a protobuf message.
 func (h *StateServiceHandler) roleToProto(ctx context.Context, role *models.Role) (*statev1.RoleInfo, error) {
 	if h.iamService == nil {
@@ -702,13 +955,18 @@ func (h *StateServiceHandler) roleToProto(ctx context.Context, role *models.Role
 	}
 
 	// Extract actions from permissions
-	// permissions are in the form [role, objType, action, ...]
-	// We want to return them as "objType:action"
+	// permissions are in the form [role, objType, action, scopeExpr, effect]
+	// We want to return them as "objType:action", or "!objType:action" for deny rules
 	actions := make([]string, 0, len(permissions))
 	for _, p := range permissions {
-		if len(p) >= 3 { // p = [role, objType, action, ...]
-			actions = append(actions, fmt.Sprintf("%s:%s", p[1], p[2]))
+		if len(p) < 3 {
+			continue
+		}
+		action := fmt.Sprintf("%s:%s", p[1], p[2])
+		if len(p) >= 5 && p[4] == auth.EffectDeny {
+			action = "!" + action
 		}
+		actions = append(actions, action)
 	}
 	sort.Strings(actions)
 
@@ -735,8 +993,29 @@ func (h *StateServiceHandler) roleToProto(ctx context.Context, role *models.Role
 		LabelScopeExpr:    &role.ScopeExpr,
 		CreateConstraints: protoConstraints,
 		ImmutableKeys:     role.ImmutableKeys,
+		MaxAssignments:    maxAssignmentsToProto(role.MaxAssignments),
+		OwnerActions:      role.OwnerActions,
+		DefaultLabels:     role.DefaultLabels,
 		CreatedAt:         timestamppb.New(role.CreatedAt),
 		UpdatedAt:         timestamppb.New(role.UpdatedAt),
 		Version:           int32(role.Version),
 	}, nil
 }
+
+// maxAssignmentsFromProto converts the optional proto cap to the model's *int.
+func maxAssignmentsFromProto(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+// maxAssignmentsToProto converts the model's optional cap to the proto's *int32.
+func maxAssignmentsToProto(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	gridmiddleware "github.com/terraconstructs/grid/cmd/gridapi/internal/middleware"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+	"github.com/terraconstructs/grid/pkg/api/state/v1/statev1connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// serviceAccountRepoIAM lists service accounts from a real repository. Other
+// methods panic via the nil embed.
+type serviceAccountRepoIAM struct {
+	iamAdminService
+	repo repository.ServiceAccountRepository
+}
+
+func (s *serviceAccountRepoIAM) ListServiceAccounts(ctx context.Context, filter iam.ServiceAccountFilter) ([]*models.ServiceAccount, error) {
+	accounts, err := s.repo.ListFiltered(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.ServiceAccount, len(accounts))
+	for i := range accounts {
+		result[i] = &accounts[i]
+	}
+	return result, nil
+}
+
+func TestListServiceAccounts_FiltersAndPages(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	repo := repository.NewBunServiceAccountRepository(db)
+	now := time.Now()
+	for _, name := range []string{"ci-1", "ci-2", "ci-3", "ci-4", "ci-5", "nightly"} {
+		sa := &models.ServiceAccount{Name: name, ClientID: name + "-client", ClientSecretHash: "hash", CreatedBy: auth.SystemUserID, LastUsedAt: now}
+		if name == "ci-2" {
+			sa.Disabled = true
+		}
+		if name == "ci-5" {
+			sa.LastUsedAt = now.Add(-60 * 24 * time.Hour)
+		}
+		require.NoError(t, repo.Create(ctx, sa))
+	}
+
+	cfg := &config.Config{OIDC: config.OIDCConfig{Issuer: "https://grid.example.com"}}
+	h := NewStateServiceHandler(nil, nil, cfg).WithIAMService(&serviceAccountRepoIAM{repo: repo})
+
+	list := func(req *statev1.ListServiceAccountsRequest) (names []string, next string) {
+		t.Helper()
+		resp, err := h.ListServiceAccounts(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		for _, sa := range resp.Msg.GetServiceAccounts() {
+			names = append(names, sa.GetName())
+		}
+		return names, resp.Msg.GetNextPageToken()
+	}
+
+	t.Run("unpaginated returns everything", func(t *testing.T) {
+		names, next := list(&statev1.ListServiceAccountsRequest{})
+		assert.Len(t, names, 6)
+		assert.Empty(t, next)
+	})
+
+	t.Run("pages through filtered results", func(t *testing.T) {
+		prefix, enabled := "ci-", false
+		var pages [][]string
+		token := ""
+		for {
+			names, next := list(&statev1.ListServiceAccountsRequest{NamePrefix: &prefix, Disabled: &enabled, PageSize: 2, PageToken: token})
+			pages = append(pages, names)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		// The last full page hands out a token; the page after it is empty.
+		assert.Equal(t, [][]string{{"ci-1", "ci-3"}, {"ci-4", "ci-5"}, nil}, pages)
+	})
+
+	t.Run("last used before", func(t *testing.T) {
+		names, _ := list(&statev1.ListServiceAccountsRequest{LastUsedBefore: timestamppb.New(now.Add(-7 * 24 * time.Hour))})
+		assert.Equal(t, []string{"ci-5"}, names)
+	})
+
+	t.Run("invalid paging arguments", func(t *testing.T) {
+		_, err := h.ListServiceAccounts(ctx, connect.NewRequest(&statev1.ListServiceAccountsRequest{PageSize: -1}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = h.ListServiceAccounts(ctx, connect.NewRequest(&statev1.ListServiceAccountsRequest{PageToken: "not base64!"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+// serviceAccountCreatorIAM records CreateServiceAccount calls. Other methods
+// panic via the nil embed.
+type serviceAccountCreatorIAM struct {
+	iamAdminService
+	created   []string
+	createdBy []string
+}
+
+func (s *serviceAccountCreatorIAM) CreateServiceAccount(ctx context.Context, name, createdBy string, roleNames []string) (*models.ServiceAccount, string, []string, error) {
+	s.created = append(s.created, name)
+	s.createdBy = append(s.createdBy, createdBy)
+	return &models.ServiceAccount{ID: "sa-" + name, ClientID: name + "-client", Name: name}, "secret", roleNames, nil
+}
+
+func TestCreateServiceAccount_RoleNamesRequireUserAssign(t *testing.T) {
+	store := &serviceAccountCreatorIAM{}
+	cfg := &config.Config{OIDC: config.OIDCConfig{Issuer: "https://grid.example.com"}}
+	h := NewStateServiceHandler(nil, nil, cfg).WithIAMService(store)
+
+	principalFromHeader := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			id, role, _ := strings.Cut(req.Header().Get("X-Test-Principal"), ";")
+			return next(auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: id, Roles: []string{role}}), req)
+		}
+	})
+	authz := gridmiddleware.NewAuthzInterceptor(gridmiddleware.AuthzDependencies{IAMService: &grantsIAM{grants: map[string][]string{
+		"role:sa-manager": {auth.AdminServiceAccountManage},
+		"role:iam-admin":  {auth.AdminServiceAccountManage, auth.AdminUserAssign},
+	}}})
+	mux := http.NewServeMux()
+	mux.Handle(statev1connect.NewStateServiceHandler(h, connect.WithInterceptors(principalFromHeader, authz)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := statev1connect.NewStateServiceClient(srv.Client(), srv.URL)
+
+	const manager, admin = "user:carol@example.com;role:sa-manager", "user:admin@example.com;role:iam-admin"
+	ctx := context.Background()
+
+	_, err := client.CreateServiceAccount(ctx, asPrincipal(manager, &statev1.CreateServiceAccountRequest{Name: "ci-plain"}))
+	require.NoError(t, err, "no roles requested")
+
+	_, err = client.CreateServiceAccount(ctx, asPrincipal(manager, &statev1.CreateServiceAccountRequest{Name: "ci-admin", RoleNames: []string{"platform-engineer"}}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "granting roles needs admin:user-assign")
+
+	resp, err := client.CreateServiceAccount(ctx, asPrincipal(admin, &statev1.CreateServiceAccountRequest{Name: "ci-deploy", RoleNames: []string{"platform-engineer"}}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform-engineer"}, resp.Msg.GetRoles())
+
+	assert.Equal(t, []string{"ci-plain", "ci-deploy"}, store.created)
+}
+
+func TestCreateServiceAccount_RequiresPrincipal(t *testing.T) {
+	t.Parallel()
+
+	store := &serviceAccountCreatorIAM{}
+	cfg := &config.Config{OIDC: config.OIDCConfig{Issuer: "https://grid.example.com"}}
+	h := NewStateServiceHandler(nil, nil, cfg).WithIAMService(store)
+
+	_, err := h.CreateServiceAccount(context.Background(), connect.NewRequest(&statev1.CreateServiceAccountRequest{Name: "ci"}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Empty(t, store.created)
+}
+
+func TestCreateServiceAccount_RecordsCreator(t *testing.T) {
+	t.Parallel()
+
+	store := &serviceAccountCreatorIAM{}
+	cfg := &config.Config{OIDC: config.OIDCConfig{Issuer: "https://grid.example.com"}}
+	h := NewStateServiceHandler(nil, nil, cfg).WithIAMService(store)
+
+	user := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "user:carol@example.com", InternalID: "user-carol", Type: auth.PrincipalTypeUser})
+	_, err := h.CreateServiceAccount(user, connect.NewRequest(&statev1.CreateServiceAccountRequest{Name: "ci-plan"}))
+	require.NoError(t, err)
+
+	sa := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "sa:ci-bootstrap", InternalID: "sa-bootstrap", Type: auth.PrincipalTypeServiceAccount})
+	_, err = h.CreateServiceAccount(sa, connect.NewRequest(&statev1.CreateServiceAccountRequest{Name: "ci-deploy"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"user-carol", auth.SystemUserID}, store.createdBy, "service accounts are attributed to the system user")
+}
+
+func TestMapIAMError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want connect.Code
+	}{
+		{fmt.Errorf("%w: ghost", iam.ErrRoleNotFound), connect.CodeNotFound},
+		{fmt.Errorf("%w: viewer", iam.ErrRoleExists), connect.CodeAlreadyExists},
+		{fmt.Errorf("role 'viewer' %w to group 'ops'", iam.ErrDuplicateAssignment), connect.CodeAlreadyExists},
+		{fmt.Errorf("cannot delete role viewer: %w to 2 principals", iam.ErrRoleInUse), connect.CodeFailedPrecondition},
+		{fmt.Errorf("%w: expected 1, got 2", iam.ErrVersionConflict), connect.CodeFailedPrecondition},
+		{fmt.Errorf("role %q %w", "admin", iam.ErrAssignmentCapExceeded), connect.CodeFailedPrecondition},
+		{fmt.Errorf("role %q has an assignment cap but %w", "admin", iam.ErrGroupSizeUnknown), connect.CodeFailedPrecondition},
+		{iam.ErrPrincipalAmbiguous, connect.CodeInvalidArgument},
+		{errors.New("invalid label_scope_expr: unexpected token"), connect.CodeInvalidArgument},
+		{errors.New("database unavailable"), connect.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			err := mapIAMError(tt.err)
+			assert.Equal(t, tt.want, connect.CodeOf(err))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
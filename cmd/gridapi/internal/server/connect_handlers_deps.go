@@ -2,14 +2,20 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"slices"
+	"strconv"
 	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/dependency"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/graph"
+	statepkg "github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
 	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -56,6 +62,9 @@ func (h *StateServiceHandler) AddDependency(
 
 	edge, alreadyExists, err := h.depService.AddDependency(ctx, svcReq)
 	if err != nil {
+		if errors.Is(err, dependency.ErrInvalidMockValue) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 		return nil, mapServiceError(err)
 	}
 
@@ -94,6 +103,7 @@ func (h *StateServiceHandler) ListDependencies(
 	if h.depService == nil {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
 	}
+	ctx = withScopeDecisions(ctx) // Edges share endpoints; decide each state once
 
 	var logicID, guid string
 	if state, ok := req.Msg.State.(*statev1.ListDependenciesRequest_LogicId); ok {
@@ -129,6 +139,7 @@ func (h *StateServiceHandler) ListDependents(
 	if h.depService == nil {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
 	}
+	ctx = withScopeDecisions(ctx)
 
 	var logicID, guid string
 	if state, ok := req.Msg.State.(*statev1.ListDependentsRequest_LogicId); ok {
@@ -165,12 +176,21 @@ func (h *StateServiceHandler) SearchByOutput(
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
 	}
 
+	ctx = withScopeDecisions(ctx)
+
 	edges, err := h.depService.SearchByOutput(ctx, req.Msg.OutputKey)
 	if err != nil {
 		return nil, mapServiceError(err)
 	}
 
-	protoEdges, err := h.edgesToProto(ctx, edges)
+	// Filter edges based on user's role scopes
+	// Users only see edges where they can view both source and destination states
+	filteredEdges, err := h.filterEdgesByRoleScopes(ctx, edges)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	protoEdges, err := h.edgesToProto(ctx, filteredEdges)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -185,6 +205,7 @@ func (h *StateServiceHandler) GetTopologicalOrder(
 	if h.depService == nil {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
 	}
+	ctx = withScopeDecisions(ctx)
 
 	var logicID, guid string
 	if state, ok := req.Msg.State.(*statev1.GetTopologicalOrderRequest_LogicId); ok {
@@ -198,21 +219,61 @@ func (h *StateServiceHandler) GetTopologicalOrder(
 		direction = *req.Msg.Direction
 	}
 
-	layers, err := h.depService.GetTopologicalOrder(ctx, logicID, guid, direction)
+	// Check the root is visible before computing anything about its neighbourhood
+	if guid == "" && logicID != "" {
+		stateGUID, _, err := h.service.GetStateConfig(ctx, logicID)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		guid = stateGUID
+	}
+	if guid != "" {
+		if err := h.authorizeStateScope(ctx, guid); err != nil {
+			return nil, err
+		}
+	}
+
+	layers, err := h.depService.GetTopologicalOrder(ctx, "", guid, direction)
 	if err != nil {
+		var cycleErr *graph.CycleError
+		if errors.As(err, &cycleErr) {
+			visible, err := h.visibleStates(ctx, slices.Concat(cycleErr.Cycles...))
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			scoped := &graph.CycleError{}
+			for _, cycle := range cycleErr.Cycles {
+				members := slices.DeleteFunc(slices.Clone(cycle), func(guid string) bool { return visible[guid] == nil })
+				if len(members) > 0 {
+					scoped.Cycles = append(scoped.Cycles, members)
+				}
+			}
+			return nil, connect.NewError(connect.CodeFailedPrecondition, scoped)
+		}
 		return nil, mapServiceError(err)
 	}
 
+	var layerGUIDs []string
+	for _, layer := range layers {
+		layerGUIDs = append(layerGUIDs, layer.States...)
+	}
+	visible, err := h.visibleStates(ctx, layerGUIDs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	protoLayers := make([]*statev1.Layer, 0, len(layers))
 	for _, layer := range layers {
 		stateRefs := make([]*statev1.StateRef, 0, len(layer.States))
 		for _, guid := range layer.States {
-			state, _ := h.service.GetStateByGUID(ctx, guid)
-			stateRef := &statev1.StateRef{Guid: guid}
-			if state != nil {
-				stateRef.LogicId = state.LogicID
+			state := visible[guid]
+			if state == nil {
+				continue
 			}
-			stateRefs = append(stateRefs, stateRef)
+			stateRefs = append(stateRefs, &statev1.StateRef{Guid: guid, LogicId: state.LogicID})
+		}
+		if len(stateRefs) == 0 {
+			continue
 		}
 		protoLayers = append(protoLayers, &statev1.Layer{
 			Level:  int32(layer.Level),
@@ -230,6 +291,7 @@ func (h *StateServiceHandler) GetStateStatus(
 	if h.depService == nil {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
 	}
+	ctx = withScopeDecisions(ctx)
 
 	var logicID, guid string
 	if state, ok := req.Msg.State.(*statev1.GetStateStatusRequest_LogicId); ok {
@@ -242,15 +304,54 @@ func (h *StateServiceHandler) GetStateStatus(
 	if err != nil {
 		return nil, mapServiceError(err)
 	}
+	if err := h.authorizeStateScope(ctx, status.StateGUID); err != nil {
+		return nil, err
+	}
+
+	// Report only incoming edges from producers the caller may view, as
+	// ListDependencies does, and count the summary over those
+	incomingEdges := make([]models.Edge, 0, len(status.Incoming))
+	for _, inc := range status.Incoming {
+		incomingEdges = append(incomingEdges, models.Edge{ID: inc.EdgeID, FromState: inc.FromGUID, ToState: status.StateGUID})
+	}
+	visibleEdges, err := h.filterEdgesByRoleScopes(ctx, incomingEdges)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	visibleEdgeIDs := make(map[int64]bool, len(visibleEdges))
+	for _, edge := range visibleEdges {
+		visibleEdgeIDs[edge.ID] = true
+	}
+
+	computedStatus, err := h.visibleStateStatus(ctx, status)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
-	protoIncoming := make([]*statev1.IncomingEdgeView, 0, len(status.Incoming))
+	var summary graph.StatusSummary
+	protoIncoming := make([]*statev1.IncomingEdgeView, 0, len(visibleEdges))
 	for _, inc := range status.Incoming {
+		if !visibleEdgeIDs[inc.EdgeID] {
+			continue
+		}
+		switch models.EdgeStatus(inc.Status) {
+		case models.EdgeStatusClean:
+			summary.IncomingClean++
+		case models.EdgeStatusDirty:
+			summary.IncomingDirty++
+		case models.EdgeStatusPending:
+			summary.IncomingPending++
+		default:
+			summary.IncomingUnknown++
+		}
+
 		view := &statev1.IncomingEdgeView{
 			EdgeId:      inc.EdgeID,
 			FromGuid:    inc.FromGUID,
 			FromLogicId: inc.FromLogicID,
 			FromOutput:  inc.FromOutput,
 			Status:      inc.Status,
+			Current:     inc.Current,
 		}
 		if inc.InDigest != "" {
 			view.InDigest = &inc.InDigest
@@ -270,19 +371,42 @@ func (h *StateServiceHandler) GetStateStatus(
 	resp := &statev1.GetStateStatusResponse{
 		Guid:     status.StateGUID,
 		LogicId:  status.LogicID,
-		Status:   status.Status,
+		Status:   computedStatus,
 		Incoming: protoIncoming,
 		Summary: &statev1.StatusSummary{
-			IncomingClean:   int32(status.Summary.IncomingClean),
-			IncomingDirty:   int32(status.Summary.IncomingDirty),
-			IncomingPending: int32(status.Summary.IncomingPending),
-			IncomingUnknown: int32(status.Summary.IncomingUnknown),
+			IncomingClean:   int32(summary.IncomingClean),
+			IncomingDirty:   int32(summary.IncomingDirty),
+			IncomingPending: int32(summary.IncomingPending),
+			IncomingUnknown: int32(summary.IncomingUnknown),
 		},
 	}
 
 	return connect.NewResponse(resp), nil
 }
 
+// visibleStateStatus returns status.Status as the caller may see it. For a
+// caller limited by role scopes the status is recomputed over the edges
+// between states they can view, so staleness that only reaches the state
+// through producers outside their scopes is not revealed.
+func (h *StateServiceHandler) visibleStateStatus(ctx context.Context, status *graph.StateStatus) (string, error) {
+	if _, scoped, err := h.callerRoleScopes(ctx); err != nil || !scoped {
+		return status.Status, err
+	}
+
+	allEdges, err := h.depService.ListAllEdges(ctx)
+	if err != nil {
+		return "", err
+	}
+	visibleEdges, err := h.filterEdgesByRoleScopes(ctx, allEdges)
+	if err != nil {
+		return "", err
+	}
+	if computed, ok := graph.ComputeStateSummaries(visibleEdges)[status.StateGUID]; ok {
+		return computed, nil
+	}
+	return "clean", nil
+}
+
 func (h *StateServiceHandler) GetDependencyGraph(
 	ctx context.Context,
 	req *connect.Request[statev1.GetDependencyGraphRequest],
@@ -290,6 +414,7 @@ func (h *StateServiceHandler) GetDependencyGraph(
 	if h.depService == nil {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
 	}
+	ctx = withScopeDecisions(ctx)
 
 	var logicID, guid string
 	if state, ok := req.Msg.State.(*statev1.GetDependencyGraphRequest_LogicId); ok {
@@ -298,28 +423,60 @@ func (h *StateServiceHandler) GetDependencyGraph(
 		guid = state.Guid
 	}
 
-	graph, err := h.depService.GetDependencyGraph(ctx, logicID, guid)
+	graph, err := h.depService.GetDependencyGraph(ctx, logicID, guid, int(req.Msg.GetMaxDepth()))
 	if err != nil {
 		return nil, mapServiceError(err)
 	}
 
+	// Apply role scopes to every producer, dropping the edges of hidden ones
+	// (the consumer itself was authorized by the interceptor)
+	producers := graph.Producers
+	edges := graph.Edges
+	cycleEdgeIDs := graph.CycleEdgeIDs
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scoped {
+		hidden := make(map[string]bool)
+		producers = make([]dependency.ProducerState, 0, len(graph.Producers))
+		for _, producer := range graph.Producers {
+			if h.stateMatchesRoleScopes(ctx, roleScopes, producer.GUID, producer.Labels) {
+				producers = append(producers, producer)
+			} else {
+				hidden[producer.GUID] = true
+			}
+		}
+		edges = make([]models.Edge, 0, len(graph.Edges))
+		kept := make(map[int64]bool, len(graph.Edges))
+		for _, edge := range graph.Edges {
+			if !hidden[edge.FromState] && !hidden[edge.ToState] {
+				edges = append(edges, edge)
+				kept[edge.ID] = true
+			}
+		}
+		cycleEdgeIDs = slices.DeleteFunc(slices.Clone(cycleEdgeIDs), func(id int64) bool { return !kept[id] })
+	}
+
 	// Convert producers - compute backend configs inline (avoids N+1 GetStateConfig calls)
-	protoProducers := make([]*statev1.ProducerState, 0, len(graph.Producers))
-	for _, producer := range graph.Producers {
+	protoProducers := make([]*statev1.ProducerState, 0, len(producers))
+	for _, producer := range producers {
 		// Compute backend URLs directly using GUID (avoids extra DB query per producer)
+		backend := statepkg.NewBackendConfig(h.cfg.BackendBaseURL(), producer.GUID)
 		protoProducers = append(protoProducers, &statev1.ProducerState{
 			Guid:    producer.GUID,
 			LogicId: producer.LogicID,
 			BackendConfig: &statev1.BackendConfig{
-				Address:       fmt.Sprintf("%s/tfstate/%s", h.cfg.ServerURL, producer.GUID),
-				LockAddress:   fmt.Sprintf("%s/tfstate/%s/lock", h.cfg.ServerURL, producer.GUID),
-				UnlockAddress: fmt.Sprintf("%s/tfstate/%s/unlock", h.cfg.ServerURL, producer.GUID),
+				Address:       backend.Address,
+				LockAddress:   backend.LockAddress,
+				UnlockAddress: backend.UnlockAddress,
 			},
+			Depth: int32(producer.Depth),
 		})
 	}
 
 	// Convert edges
-	protoEdges, err := h.edgesToProto(ctx, graph.Edges)
+	protoEdges, err := h.edgesToProto(ctx, edges)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -329,11 +486,59 @@ func (h *StateServiceHandler) GetDependencyGraph(
 		ConsumerLogicId: graph.ConsumerLogicID,
 		Producers:       protoProducers,
 		Edges:           protoEdges,
+		MaxDepth:        int32(graph.MaxDepth),
+		Truncated:       graph.Truncated,
+		CycleEdgeIds:    cycleEdgeIDs,
 	}
 
 	return connect.NewResponse(resp), nil
 }
 
+// RecomputeDependencyStatus re-derives a producer's outgoing edge statuses.
+// The interceptor authorizes the producer; edges whose consumer is outside
+// the caller's role scopes are skipped rather than failing the whole call.
+func (h *StateServiceHandler) RecomputeDependencyStatus(
+	ctx context.Context,
+	req *connect.Request[statev1.RecomputeDependencyStatusRequest],
+) (*connect.Response[statev1.RecomputeDependencyStatusResponse], error) {
+	if h.depService == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
+	}
+	ctx = withScopeDecisions(ctx)
+
+	var logicID, guid string
+	if state, ok := req.Msg.State.(*statev1.RecomputeDependencyStatusRequest_LogicId); ok {
+		logicID = state.LogicId
+	} else if state, ok := req.Msg.State.(*statev1.RecomputeDependencyStatusRequest_Guid); ok {
+		guid = state.Guid
+	}
+
+	result, err := h.depService.RecomputeDependencyStatus(ctx, logicID, guid, func(edges []models.Edge) ([]models.Edge, error) {
+		return h.filterEdgesByRoleScopes(ctx, edges)
+	})
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	protoEdges, err := h.edgesToProto(ctx, result.Edges)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	changedIDs := make([]int64, 0, len(result.Changed))
+	for _, change := range result.Changed {
+		changedIDs = append(changedIDs, change.Edge.ID)
+	}
+
+	return connect.NewResponse(&statev1.RecomputeDependencyStatusResponse{
+		ProducerGuid:    result.ProducerGUID,
+		ProducerLogicId: result.ProducerLogicID,
+		Edges:           protoEdges,
+		ChangedEdgeIds:  changedIDs,
+		SkippedEdges:    int32(result.Skipped),
+	}), nil
+}
+
 // Helper function to convert edges to proto
 func (h *StateServiceHandler) edgesToProto(ctx context.Context, edges []models.Edge) ([]*statev1.DependencyEdge, error) {
 	if len(edges) == 0 {
@@ -392,6 +597,7 @@ func (h *StateServiceHandler) edgeToProtoWithCache(ctx context.Context, edge *mo
 		FromOutput: edge.FromOutput,
 		ToGuid:     edge.ToState,
 		Status:     string(edge.Status),
+		Current:    graph.IsEdgeCurrent(edge),
 	}
 
 	if edge.ToInputName != "" {
@@ -476,6 +682,11 @@ func (h *StateServiceHandler) ListStateOutputs(
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (logic_id or guid)"))
 	}
 
+	// Role scopes must cover the target state, as they do for ListAllEdges
+	if err := h.authorizeStateScope(ctx, guid); err != nil {
+		return nil, err
+	}
+
 	// Get output keys from service (cache or parse)
 	outputs, err := h.service.GetOutputKeys(ctx, guid)
 	if err != nil {
@@ -485,42 +696,235 @@ func (h *StateServiceHandler) ListStateOutputs(
 	// Convert to proto
 	protoOutputs := make([]*statev1.OutputKey, len(outputs))
 	for i, out := range outputs {
-		protoOut := &statev1.OutputKey{
-			Key:       out.Key,
-			Sensitive: out.Sensitive,
+		protoOutputs[i] = outputKeyToProto(out)
+	}
+
+	resp := &statev1.ListStateOutputsResponse{
+		StateGuid:    guid,
+		StateLogicId: logicID,
+		Outputs:      protoOutputs,
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// maxOutputsBatchStates caps the number of state refs in one ListStateOutputsBatch call.
+const maxOutputsBatchStates = 100
+
+// ListStateOutputsBatch returns outputs for several states, omitting states the
+// caller may not list (or, with values, read) outputs of, as decided from each
+// state's labels and owner. Values of sensitive outputs are never returned.
+func (h *StateServiceHandler) ListStateOutputsBatch(
+	ctx context.Context,
+	req *connect.Request[statev1.ListStateOutputsBatchRequest],
+) (*connect.Response[statev1.ListStateOutputsBatchResponse], error) {
+	refs := req.Msg.GetStates()
+	if len(refs) > maxOutputsBatchStates {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at most %d states may be requested, got %d", maxOutputsBatchStates, len(refs)))
+	}
+
+	// Resolve refs with one query per identifier kind
+	var guids, logicIDs []string
+	for _, ref := range refs {
+		switch {
+		case ref.GetGuid() != "":
+			guids = append(guids, ref.GetGuid())
+		case ref.GetLogicId() != "":
+			logicIDs = append(logicIDs, ref.GetLogicId())
+		default:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (logic_id or guid)"))
 		}
-		// Include schema if available
-		if out.SchemaJSON != nil && *out.SchemaJSON != "" {
-			protoOut.SchemaJson = out.SchemaJSON
+	}
+	byGUID, err := h.service.GetStatesByGUIDs(ctx, guids)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+	byLogicID, err := h.service.GetStatesByLogicIDs(ctx, logicIDs)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	action := auth.StateOutputList
+	if req.Msg.GetIncludeValues() {
+		action = auth.StateOutputRead
+	}
+
+	// Keep request order, dropping unknown, duplicate and unauthorized states
+	states := make([]*models.State, 0, len(refs))
+	seen := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		state := byGUID[ref.GetGuid()]
+		if ref.GetGuid() == "" {
+			state = byLogicID[ref.GetLogicId()]
 		}
-		// Include schema source if available
-		if out.SchemaSource != nil && *out.SchemaSource != "" {
-			protoOut.SchemaSource = out.SchemaSource
+		if state == nil || seen[state.GUID] {
+			continue
 		}
-		// Include validation status if available
-		if out.ValidationStatus != nil && *out.ValidationStatus != "" {
-			protoOut.ValidationStatus = out.ValidationStatus
+		seen[state.GUID] = true
+		allowed, err := h.authorizeStateAction(ctx, state, action)
+		if err != nil {
+			return nil, err
 		}
-		// Include validation error if available
-		if out.ValidationError != nil && *out.ValidationError != "" {
-			protoOut.ValidationError = out.ValidationError
+		if !allowed {
+			continue
 		}
-		// Include validated_at if available
-		if out.ValidatedAt != nil {
-			protoOut.ValidatedAt = timestamppb.New(*out.ValidatedAt)
+		states = append(states, state)
+	}
+
+	outputsByState, err := h.service.GetOutputsBatch(ctx, states, req.Msg.GetIncludeValues())
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	var wantKeys map[string]bool
+	if len(req.Msg.GetOutputKeys()) > 0 {
+		wantKeys = make(map[string]bool, len(req.Msg.GetOutputKeys()))
+		for _, key := range req.Msg.GetOutputKeys() {
+			wantKeys[key] = true
 		}
-		protoOutputs[i] = protoOut
 	}
 
-	resp := &statev1.ListStateOutputsResponse{
-		StateGuid:    guid,
-		StateLogicId: logicID,
-		Outputs:      protoOutputs,
+	resp := &statev1.ListStateOutputsBatchResponse{
+		States: make([]*statev1.StateOutputs, 0, len(states)),
+	}
+	for _, state := range states {
+		entry := outputsByState[state.GUID]
+		protoOutputs := make([]*statev1.OutputKey, 0, len(entry.Outputs))
+		for _, out := range entry.Outputs {
+			if wantKeys != nil && !wantKeys[out.Key] {
+				continue
+			}
+			protoOut := outputKeyToProto(out)
+			if val, ok := entry.Values[out.Key]; ok {
+				valueJSON, err := json.Marshal(val)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("encode output %s of state %s: %w", out.Key, state.LogicID, err))
+				}
+				value := string(valueJSON)
+				protoOut.ValueJson = &value
+			}
+			protoOutputs = append(protoOutputs, protoOut)
+		}
+		resp.States = append(resp.States, &statev1.StateOutputs{
+			StateGuid:    state.GUID,
+			StateLogicId: state.LogicID,
+			Outputs:      protoOutputs,
+		})
 	}
 
 	return connect.NewResponse(resp), nil
 }
 
+// GetStateOutputValues returns selected output values of one state. Sensitive
+// values are redacted unless the caller holds state-output:read-sensitive.
+func (h *StateServiceHandler) GetStateOutputValues(
+	ctx context.Context,
+	req *connect.Request[statev1.GetStateOutputValuesRequest],
+) (*connect.Response[statev1.GetStateOutputValuesResponse], error) {
+	ref := req.Msg.GetState()
+	guid := ref.GetGuid()
+	if guid == "" {
+		if ref.GetLogicId() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (logic_id or guid)"))
+		}
+		stateGUID, _, err := h.service.GetStateConfig(ctx, ref.GetLogicId())
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		guid = stateGUID
+	}
+
+	state, err := h.service.GetStateByGUID(ctx, guid)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scoped && !h.matchesRoleScopes(roleScopes, state.Labels) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("role scopes do not permit access to state %s", guid))
+	}
+
+	includeSensitive, err := h.canReadSensitiveOutputs(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := h.service.GetOutputValues(state, req.Msg.GetKeys(), includeSensitive)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	resp := &statev1.GetStateOutputValuesResponse{
+		StateGuid:    state.GUID,
+		StateLogicId: state.LogicID,
+		Values:       make([]*statev1.OutputValue, 0, len(values)),
+	}
+	for _, val := range values {
+		protoVal := &statev1.OutputValue{
+			Key:       val.Key,
+			Sensitive: val.Sensitive,
+			Redacted:  val.Redacted,
+		}
+		if !val.Redacted {
+			valueJSON, err := json.Marshal(val.Value)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("encode output %s of state %s: %w", val.Key, state.LogicID, err))
+			}
+			value := string(valueJSON)
+			protoVal.ValueJson = &value
+		}
+		resp.Values = append(resp.Values, protoVal)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// outputKeyToProto converts a cached output key, including any schema and
+// validation metadata, to its proto form.
+func outputKeyToProto(out repository.OutputKey) *statev1.OutputKey {
+	protoOut := &statev1.OutputKey{
+		Key:       out.Key,
+		Sensitive: out.Sensitive,
+	}
+	// Include schema if available
+	if out.SchemaJSON != nil && *out.SchemaJSON != "" {
+		protoOut.SchemaJson = out.SchemaJSON
+	}
+	// Include schema source if available
+	if out.SchemaSource != nil && *out.SchemaSource != "" {
+		protoOut.SchemaSource = out.SchemaSource
+	}
+	// Include schema registry key if the schema was set by reference
+	if out.SchemaRef != nil && *out.SchemaRef != "" {
+		protoOut.SchemaRef = out.SchemaRef
+	}
+	// Include schema history versions if available
+	if out.SchemaVersion != nil {
+		version := int32(*out.SchemaVersion)
+		protoOut.SchemaVersion = &version
+	}
+	if out.ValidatedSchemaVersion != nil {
+		version := int32(*out.ValidatedSchemaVersion)
+		protoOut.ValidatedSchemaVersion = &version
+	}
+	// Include validation status if available
+	if out.ValidationStatus != nil && *out.ValidationStatus != "" {
+		protoOut.ValidationStatus = out.ValidationStatus
+	}
+	// Include validation error if available
+	if out.ValidationError != nil && *out.ValidationError != "" {
+		protoOut.ValidationError = out.ValidationError
+	}
+	// Include validated_at if available
+	if out.ValidatedAt != nil {
+		protoOut.ValidatedAt = timestamppb.New(*out.ValidatedAt)
+	}
+	return protoOut
+}
+
 // GetStateInfo retrieves comprehensive state information including dependencies, dependents, and outputs.
 func (h *StateServiceHandler) GetStateInfo(
 	ctx context.Context,
@@ -565,31 +969,7 @@ func (h *StateServiceHandler) GetStateInfo(
 	// Convert outputs to proto
 	protoOutputs := make([]*statev1.OutputKey, len(info.Outputs))
 	for i, out := range info.Outputs {
-		protoOut := &statev1.OutputKey{
-			Key:       out.Key,
-			Sensitive: out.Sensitive,
-		}
-		// Include schema if available
-		if out.SchemaJSON != nil && *out.SchemaJSON != "" {
-			protoOut.SchemaJson = out.SchemaJSON
-		}
-		// Include schema source if available
-		if out.SchemaSource != nil && *out.SchemaSource != "" {
-			protoOut.SchemaSource = out.SchemaSource
-		}
-		// Include validation status if available
-		if out.ValidationStatus != nil && *out.ValidationStatus != "" {
-			protoOut.ValidationStatus = out.ValidationStatus
-		}
-		// Include validation error if available
-		if out.ValidationError != nil && *out.ValidationError != "" {
-			protoOut.ValidationError = out.ValidationError
-		}
-		// Include validated_at if available
-		if out.ValidatedAt != nil {
-			protoOut.ValidatedAt = timestamppb.New(*out.ValidatedAt)
-		}
-		protoOutputs[i] = protoOut
+		protoOutputs[i] = outputKeyToProto(out)
 	}
 
 	// Convert labels to proto
@@ -621,11 +1001,12 @@ func (h *StateServiceHandler) GetStateInfo(
 		resp.UpdatedAt = timestamppb.New(info.UpdatedAt)
 	}
 
-	// Populate computed_status if dependency service is available
-	if h.depService != nil {
-		status, err := h.depService.GetStateStatus(ctx, info.LogicID, "")
-		if err == nil && status != nil {
-			resp.ComputedStatus = &status.Status
+	// Populate computed_status only when requested, reusing the incoming edges
+	// already loaded with the state
+	if req.Msg.GetIncludeComputedStatus() && h.depService != nil {
+		status, err := h.depService.GetStateStatusSummary(ctx, info.GUID, info.Dependencies)
+		if err == nil {
+			resp.ComputedStatus = &status
 		}
 	}
 
@@ -642,18 +1023,33 @@ func (h *StateServiceHandler) ListAllEdges(
 	if h.depService == nil {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("dependency service not configured"))
 	}
+	ctx = withScopeDecisions(ctx)
 
-	// Get all edges from service
-	edges, err := h.depService.ListAllEdges(ctx)
-	if err != nil {
-		return nil, mapServiceError(err)
+	if req.Msg.PageSize < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page_size %d", req.Msg.PageSize))
 	}
 
-	// Filter edges based on user's role scopes
-	// Users only see edges where they can view both source and destination states
-	filteredEdges, err := h.filterEdgesByRoleScopes(ctx, edges)
-	if err != nil {
-		return nil, mapServiceError(err)
+	var filteredEdges []models.Edge
+	var nextPageToken string
+	if req.Msg.PageSize > 0 {
+		var err error
+		filteredEdges, nextPageToken, err = h.listEdgesPage(ctx, int(req.Msg.PageSize), req.Msg.PageToken)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Get all edges from service
+		edges, err := h.depService.ListAllEdges(ctx)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+
+		// Filter edges based on user's role scopes
+		// Users only see edges where they can view both source and destination states
+		filteredEdges, err = h.filterEdgesByRoleScopes(ctx, edges)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
 	}
 
 	// Convert edges to proto
@@ -662,109 +1058,130 @@ func (h *StateServiceHandler) ListAllEdges(
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return connect.NewResponse(&statev1.ListAllEdgesResponse{Edges: protoEdges}), nil
+	return connect.NewResponse(&statev1.ListAllEdgesResponse{Edges: protoEdges, NextPageToken: nextPageToken}), nil
+}
+
+// listEdgesPage returns one page of edges visible to the caller, ordered by
+// edge ID. Role scopes are applied to each fetched batch.
+func (h *StateServiceHandler) listEdgesPage(ctx context.Context, pageSize int, pageToken string) ([]models.Edge, string, error) {
+	cursor, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	var afterID int64
+	if cursor != "" {
+		afterID, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page_token"))
+		}
+	}
+
+	page, last, err := collectPage(min(pageSize, MaxListPageSize), strconv.FormatInt(afterID, 10),
+		func(after string, limit int) ([]models.Edge, error) {
+			id, _ := strconv.ParseInt(after, 10, 64)
+			return h.depService.ListEdgesAfter(ctx, id, limit)
+		},
+		func(batch []models.Edge) ([]models.Edge, error) {
+			return h.filterEdgesByRoleScopes(ctx, batch)
+		},
+		func(edge models.Edge) string { return strconv.FormatInt(edge.ID, 10) },
+	)
+	if err != nil {
+		return nil, "", mapServiceError(err)
+	}
+
+	return page, encodePageToken(last), nil
 }
 
 // filterEdgesByRoleScopes filters edges based on user's role label scopes.
 // An edge is included only if the user has permission to view BOTH the source and destination states.
+// Only roles scoped to auth.ScopeAll see every state; a role with an empty scope sees none.
 // This follows the same pattern as filterStatesByRoleScopes in connect_handlers.go.
+// Each state is decided once per call, or once per request when the handler
+// installed withScopeDecisions.
 func (h *StateServiceHandler) filterEdgesByRoleScopes(ctx context.Context, edges []models.Edge) ([]models.Edge, error) {
-	// Get principal from context
-	principal, ok := auth.GetUserFromContext(ctx)
-	if !ok {
-		// No principal (no-auth mode) - return all edges
-		return edges, nil
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil {
+		return nil, err
 	}
-
-	// If IAM service not available, return all edges (backwards compatibility)
-	if h.iamService == nil {
+	if !scoped {
+		// No-auth mode or no IAM service - return all edges
 		return edges, nil
 	}
 
-	// Get role scopes from the user's roles
-	roleScopes := make([]string, 0, len(principal.Roles))
-	for _, casbinRole := range principal.Roles {
-		roleName, err := auth.ExtractRoleID(casbinRole)
-		if err != nil {
-			// try with casbinRole as is
-			roleName = casbinRole
-			// TODO: Review Mode 1 vs  Mode 2 role prefix handling?
-			log.Printf("warning: could not extract role name from casbin role %s: %v", casbinRole, err)
-
-		}
-
-		role, err := h.iamService.GetRoleByName(ctx, roleName)
-		if err != nil {
-			continue // Skip roles that don't exist
-		}
-
-		roleScopes = append(roleScopes, role.ScopeExpr)
-	}
-
-	// If user has no roles, return empty list
-	if len(roleScopes) == 0 {
+	// If no role allows reading states, return empty list
+	if roleScopes.empty() {
 		return []models.Edge{}, nil
 	}
 
-	// Build a map of state GUID -> labels for efficient lookup
-	// We need to fetch all unique states referenced in the edges
-	stateGUIDs := make(map[string]bool)
+	// Decide every state referenced by the edges, fetching labels only for
+	// states this request hasn't decided yet
+	visible := make(map[string]bool)
+	var undecided []string
 	for _, edge := range edges {
-		stateGUIDs[edge.FromState] = true
-		stateGUIDs[edge.ToState] = true
+		for _, guid := range []string{edge.FromState, edge.ToState} {
+			if _, seen := visible[guid]; seen {
+				continue
+			}
+			allowed, ok := cachedScopeDecision(ctx, guid)
+			visible[guid] = allowed
+			if !ok {
+				undecided = append(undecided, guid)
+			}
+		}
 	}
-
-	// Fetch all states
-	stateLabels := make(map[string]map[string]any)
-	for guid := range stateGUIDs {
-		state, err := h.service.GetStateByGUID(ctx, guid)
+	if len(undecided) > 0 {
+		states, err := h.service.GetStatesByGUIDs(ctx, undecided)
 		if err != nil {
-			// State not found - skip (edge will be filtered out)
-			continue
+			return nil, err
+		}
+		// States not found stay invisible (their edges are filtered out)
+		for guid, state := range states {
+			visible[guid] = h.stateMatchesRoleScopes(ctx, roleScopes, guid, state.Labels)
 		}
-		stateLabels[guid] = state.Labels
 	}
 
 	// Filter edges: include only if user can see BOTH from and to states
 	filtered := make([]models.Edge, 0, len(edges))
 	for _, edge := range edges {
-		fromLabels, fromExists := stateLabels[edge.FromState]
-		toLabels, toExists := stateLabels[edge.ToState]
-
-		// Both states must exist
-		if !fromExists || !toExists {
-			continue
+		if visible[edge.FromState] && visible[edge.ToState] {
+			filtered = append(filtered, edge)
 		}
+	}
 
-		// Check if user can see FROM state
-		canSeeFrom := false
-		for _, scopeExpr := range roleScopes {
-			if strings.TrimSpace(scopeExpr) == "" || auth.EvaluateBexpr(scopeExpr, fromLabels) {
-				canSeeFrom = true
-				break
-			}
-		}
+	return filtered, nil
+}
 
-		// Check if user can see TO state
-		canSeeTo := false
-		for _, scopeExpr := range roleScopes {
-			if strings.TrimSpace(scopeExpr) == "" || auth.EvaluateBexpr(scopeExpr, toLabels) {
-				canSeeTo = true
-				break
-			}
-		}
+// visibleStates loads the given states and returns those the caller's role
+// scopes permit, keyed by GUID. States that no longer exist are left out.
+func (h *StateServiceHandler) visibleStates(ctx context.Context, guids []string) (map[string]*models.State, error) {
+	if len(guids) == 0 {
+		return map[string]*models.State{}, nil
+	}
+	states, err := h.service.GetStatesByGUIDs(ctx, guids)
+	if err != nil {
+		return nil, err
+	}
 
-		// Include edge only if user can see both states
-		if canSeeFrom && canSeeTo {
-			filtered = append(filtered, edge)
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !scoped {
+		return states, nil
+	}
+	for guid, state := range states {
+		if !h.stateMatchesRoleScopes(ctx, roleScopes, guid, state.Labels) {
+			delete(states, guid)
 		}
 	}
-
-	return filtered, nil
+	return states, nil
 }
 
 // SetOutputSchema sets or updates the JSON Schema for a specific state output.
 // After setting the schema, triggers validation for that output if it has a value.
+// Validation runs in the background unless validate_now is set, in which case it
+// runs inline and the resulting status is returned in the response.
 func (h *StateServiceHandler) SetOutputSchema(
 	ctx context.Context,
 	req *connect.Request[statev1.SetOutputSchemaRequest],
@@ -791,39 +1208,276 @@ func (h *StateServiceHandler) SetOutputSchema(
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
 	}
 
+	if req.Msg.SchemaJson != "" && req.Msg.SchemaRef != "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("only one of schema_json and schema_ref may be set"))
+	}
+
 	// Set schema and check if output has a value (uses outputs table, not state content)
 	// This follows proper layering: handler delegates business logic to service
-	outputExists, err := h.service.SetOutputSchemaAndCheckExists(ctx, guid, req.Msg.OutputKey, req.Msg.SchemaJson)
+	var outputExists bool
+	var err error
+	if req.Msg.SchemaRef != "" {
+		outputExists, err = h.service.SetOutputSchemaRefAndCheckExists(ctx, guid, req.Msg.OutputKey, req.Msg.SchemaRef)
+	} else {
+		outputExists, err = h.service.SetOutputSchemaAndCheckExists(ctx, guid, req.Msg.OutputKey, req.Msg.SchemaJson)
+	}
 	if err != nil {
+		// Compile errors can mention unresolved refs ("not found"); keep them InvalidArgument
+		if strings.Contains(err.Error(), "invalid JSON Schema") {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 		return nil, mapServiceError(err)
 	}
 
+	resp := &statev1.SetOutputSchemaResponse{
+		Success:      true,
+		StateGuid:    guid,
+		StateLogicId: logicID,
+		OutputKey:    req.Msg.OutputKey,
+	}
+
 	// Trigger validation if output exists with a real value (state_serial > 0)
 	// Handler only coordinates the job invocation; business logic is in the service
-	if h.validationJob != nil && outputExists {
-		// Trigger async validation for this output
+	if h.validationJob != nil && outputExists && req.Msg.ValidateNow {
+		status, validationErr, err := h.validateOutputNow(ctx, guid, req.Msg.OutputKey)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		resp.ValidationStatus = status
+		resp.ValidationError = validationErr
+	} else if h.validationJob != nil && outputExists {
+		// Trigger async validation for this output, traced under this request
+		validationCtx, cancel := h.validationJob.DetachedContext(ctx)
 		go func() {
+			defer cancel()
+
 			// Get the output value from state via service
-			val, err := h.service.GetStateOutputValue(context.Background(), guid, req.Msg.OutputKey)
+			val, err := h.service.GetStateOutputValue(validationCtx, guid, req.Msg.OutputKey)
 			if err != nil || val == nil {
 				return // Can't get value, validation can't run
 			}
 
 			// Validate this single output against the schema we just set
-			_ = h.validationJob.ValidateOutputs(context.Background(), guid, map[string]any{req.Msg.OutputKey: val})
+			_ = h.validationJob.ValidateOutputs(validationCtx, guid, map[string]any{req.Msg.OutputKey: val})
 		}()
 	}
 
-	resp := &statev1.SetOutputSchemaResponse{
-		Success:      true,
+	return connect.NewResponse(resp), nil
+}
+
+// SetOutputSchemas sets JSON Schemas for many outputs of a state in one call.
+// Schemas are validated up front and written atomically; outputs that already
+// have values are then validated together in a single async pass.
+func (h *StateServiceHandler) SetOutputSchemas(
+	ctx context.Context,
+	req *connect.Request[statev1.SetOutputSchemasRequest],
+) (*connect.Response[statev1.SetOutputSchemasResponse], error) {
+	// Resolve state GUID from logic_id or guid
+	var guid, logicID string
+	if state, ok := req.Msg.State.(*statev1.SetOutputSchemasRequest_StateLogicId); ok {
+		logicID = state.StateLogicId
+		stateGUID, _, err := h.service.GetStateConfig(ctx, logicID)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		guid = stateGUID
+	} else if state, ok := req.Msg.State.(*statev1.SetOutputSchemasRequest_StateGuid); ok {
+		guid = state.StateGuid
+		stateRecord, err := h.service.GetStateByGUID(ctx, guid)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		logicID = stateRecord.LogicID
+	} else {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+	}
+
+	results, err := h.service.SetOutputSchemas(ctx, guid, req.Msg.Schemas)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid JSON Schema") {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, mapServiceError(err)
+	}
+
+	resp := &statev1.SetOutputSchemasResponse{
 		StateGuid:    guid,
 		StateLogicId: logicID,
-		OutputKey:    req.Msg.OutputKey,
+		Results:      make([]*statev1.OutputSchemaResult, 0, len(results)),
+	}
+	var toValidate []string
+	for _, result := range results {
+		resp.Results = append(resp.Results, &statev1.OutputSchemaResult{
+			OutputKey: result.OutputKey,
+			Created:   result.Created,
+		})
+		if result.OutputExists {
+			toValidate = append(toValidate, result.OutputKey)
+		}
+	}
+
+	if h.validationJob != nil && len(toValidate) > 0 {
+		validationCtx, cancel := h.validationJob.DetachedContext(ctx)
+		go func() {
+			defer cancel()
+
+			values := make(map[string]any, len(toValidate))
+			for _, key := range toValidate {
+				val, err := h.service.GetStateOutputValue(validationCtx, guid, key)
+				if err != nil || val == nil {
+					continue // Can't get value, skip this output
+				}
+				values[key] = val
+			}
+			if len(values) == 0 {
+				return
+			}
+
+			_ = h.validationJob.ValidateOutputs(validationCtx, guid, values)
+		}()
 	}
 
 	return connect.NewResponse(resp), nil
 }
 
+// RevalidateOutputsForSchema re-validates every output sharing the schema of
+// the given output, in the states whose output schemas the caller may write.
+// Outputs in other states keep their stored results and are not counted.
+func (h *StateServiceHandler) RevalidateOutputsForSchema(
+	ctx context.Context,
+	req *connect.Request[statev1.RevalidateOutputsForSchemaRequest],
+) (*connect.Response[statev1.RevalidateOutputsForSchemaResponse], error) {
+	// Resolve state GUID from logic_id or guid
+	var guid string
+	switch state := req.Msg.State.(type) {
+	case *statev1.RevalidateOutputsForSchemaRequest_StateLogicId:
+		stateGUID, _, err := h.service.GetStateConfig(ctx, state.StateLogicId)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		guid = stateGUID
+	case *statev1.RevalidateOutputsForSchemaRequest_StateGuid:
+		guid = state.StateGuid
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+	}
+	if req.Msg.OutputKey == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("output_key is required"))
+	}
+	if h.validationJob == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("schema validation is not configured"))
+	}
+
+	schemaJSON, err := h.service.GetOutputSchema(ctx, guid, req.Msg.OutputKey)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+	if schemaJSON == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("output %s has no schema", req.Msg.OutputKey))
+	}
+
+	summary, err := h.validationJob.RevalidateSchema(ctx, schemaJSON, h.service.GetStateOutputValue, h.schemaWritableStates)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	resp, err := h.revalidationToProto(ctx, summary)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// schemaWritableStates keeps the states whose output schemas the caller may
+// write, the permission RevalidateOutputsForSchema is checked against on the
+// requested state. It is a StateAuthorizer for RevalidateSchema.
+func (h *StateServiceHandler) schemaWritableStates(ctx context.Context, guids []string) ([]string, error) {
+	if len(guids) == 0 {
+		return guids, nil
+	}
+	states, err := h.service.GetStatesByGUIDs(ctx, guids)
+	if err != nil {
+		return nil, err
+	}
+
+	writable := make([]string, 0, len(guids))
+	for _, guid := range guids {
+		state, ok := states[guid]
+		if !ok {
+			continue // Deleted since the outputs were read
+		}
+		allowed, err := h.authorizeStateAction(ctx, state, auth.StateOutputSchemaWrite)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			writable = append(writable, guid)
+		}
+	}
+	return writable, nil
+}
+
+// revalidationToProto converts a revalidation summary, keeping only the
+// transitions in states the caller can see.
+func (h *StateServiceHandler) revalidationToProto(ctx context.Context, summary *RevalidationSummary) (*statev1.RevalidateOutputsForSchemaResponse, error) {
+	guids := make([]string, 0, len(summary.Transitions))
+	for _, transition := range summary.Transitions {
+		guids = append(guids, transition.StateGUID)
+	}
+	visible, err := h.visibleStates(ctx, guids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &statev1.RevalidateOutputsForSchemaResponse{
+		Revalidated:   int32(summary.Revalidated),
+		BecameInvalid: int32(summary.BecameInvalid),
+		BecameValid:   int32(summary.BecameValid),
+	}
+	for _, transition := range summary.Transitions {
+		state, ok := visible[transition.StateGUID]
+		if !ok {
+			continue
+		}
+		resp.Transitions = append(resp.Transitions, &statev1.OutputValidationTransition{
+			StateGuid:      transition.StateGUID,
+			StateLogicId:   state.LogicID,
+			OutputKey:      transition.OutputKey,
+			PreviousStatus: transition.PreviousStatus,
+			Status:         transition.Status,
+		})
+	}
+
+	return resp, nil
+}
+
+// validateOutputNow validates a single output inline and reads back the
+// validation status the job recorded. Status is nil if the output has no value.
+func (h *StateServiceHandler) validateOutputNow(ctx context.Context, guid, outputKey string) (*string, *string, error) {
+	val, err := h.service.GetStateOutputValue(ctx, guid, outputKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if val == nil {
+		return nil, nil, nil
+	}
+
+	if err := h.validationJob.ValidateOutputs(ctx, guid, map[string]any{outputKey: val}); err != nil {
+		return nil, nil, err
+	}
+
+	outputs, err := h.service.GetOutputKeys(ctx, guid)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, out := range outputs {
+		if out.Key == outputKey {
+			return out.ValidationStatus, out.ValidationError, nil
+		}
+	}
+	return nil, nil, nil
+}
+
 // GetOutputSchema retrieves the JSON Schema for a specific state output.
 func (h *StateServiceHandler) GetOutputSchema(
 	ctx context.Context,
@@ -863,6 +1517,157 @@ func (h *StateServiceHandler) GetOutputSchema(
 		OutputKey:    req.Msg.OutputKey,
 		SchemaJson:   schemaJSON,
 	}
+	if schemaJSON != "" {
+		outputs, err := h.service.GetOutputKeys(ctx, guid)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		for _, out := range outputs {
+			if out.Key != req.Msg.OutputKey {
+				continue
+			}
+			if out.SchemaRef != nil {
+				resp.SchemaRef = *out.SchemaRef
+			}
+			if out.SchemaVersion != nil {
+				resp.SchemaVersion = int32(*out.SchemaVersion)
+			}
+		}
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ListOutputSchemaHistory lists the schema versions of a state output, oldest
+// first, with the version its last validation ran against.
+func (h *StateServiceHandler) ListOutputSchemaHistory(
+	ctx context.Context,
+	req *connect.Request[statev1.ListOutputSchemaHistoryRequest],
+) (*connect.Response[statev1.ListOutputSchemaHistoryResponse], error) {
+	// Resolve state GUID from logic_id or guid
+	var guid, logicID string
+	switch state := req.Msg.State.(type) {
+	case *statev1.ListOutputSchemaHistoryRequest_StateLogicId:
+		logicID = state.StateLogicId
+		stateGUID, _, err := h.service.GetStateConfig(ctx, logicID)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		guid = stateGUID
+	case *statev1.ListOutputSchemaHistoryRequest_StateGuid:
+		guid = state.StateGuid
+		stateRecord, err := h.service.GetStateByGUID(ctx, guid)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		logicID = stateRecord.LogicID
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+	}
+
+	versions, err := h.service.ListOutputSchemaHistory(ctx, guid, req.Msg.OutputKey)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	resp := &statev1.ListOutputSchemaHistoryResponse{
+		StateGuid:    guid,
+		StateLogicId: logicID,
+		OutputKey:    req.Msg.OutputKey,
+	}
+
+	var active *int
+	if len(versions) > 0 {
+		outputs, err := h.service.GetOutputKeys(ctx, guid)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		for _, out := range outputs {
+			if out.Key != req.Msg.OutputKey {
+				continue
+			}
+			active = out.SchemaVersion
+			if out.ValidatedSchemaVersion != nil {
+				version := int32(*out.ValidatedSchemaVersion)
+				resp.ValidatedSchemaVersion = &version
+				resp.ValidationStatus = out.ValidationStatus
+			}
+		}
+	}
+
+	for _, v := range versions {
+		version := &statev1.OutputSchemaVersion{
+			Version:      int32(v.Version),
+			SchemaJson:   v.SchemaJSON,
+			SchemaSource: v.SchemaSource,
+			CreatedAt:    timestamppb.New(v.CreatedAt),
+			Active:       active != nil && *active == v.Version,
+		}
+		if v.SchemaRef != nil {
+			version.SchemaRef = *v.SchemaRef
+		}
+		resp.Versions = append(resp.Versions, version)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// GetStateValidationSummary aggregates the stored validation results of a state's outputs.
+func (h *StateServiceHandler) GetStateValidationSummary(
+	ctx context.Context,
+	req *connect.Request[statev1.GetStateValidationSummaryRequest],
+) (*connect.Response[statev1.GetStateValidationSummaryResponse], error) {
+	// Resolve state GUID from logic_id or guid
+	var guid, logicID string
+	if state, ok := req.Msg.State.(*statev1.GetStateValidationSummaryRequest_StateLogicId); ok {
+		logicID = state.StateLogicId
+		stateGUID, _, err := h.service.GetStateConfig(ctx, logicID)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		guid = stateGUID
+	} else if state, ok := req.Msg.State.(*statev1.GetStateValidationSummaryRequest_StateGuid); ok {
+		guid = state.StateGuid
+		stateRecord, err := h.service.GetStateByGUID(ctx, guid)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		logicID = stateRecord.LogicID
+	} else {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("state reference required (state_logic_id or state_guid)"))
+	}
+
+	summary, err := h.service.GetValidationSummary(ctx, guid)
+	if err != nil {
+		return nil, mapServiceError(err)
+	}
+
+	issues := make([]*statev1.OutputValidationIssue, 0, len(summary.Issues))
+	for _, issue := range summary.Issues {
+		protoIssue := &statev1.OutputValidationIssue{
+			OutputKey:        issue.OutputKey,
+			ValidationStatus: issue.Status,
+			ValidationError:  issue.Error,
+		}
+		if issue.ValidatedAt != nil {
+			protoIssue.ValidatedAt = timestamppb.New(*issue.ValidatedAt)
+		}
+		issues = append(issues, protoIssue)
+	}
+
+	resp := &statev1.GetStateValidationSummaryResponse{
+		StateGuid:         guid,
+		StateLogicId:      logicID,
+		TotalOutputs:      int32(summary.TotalOutputs),
+		ValidCount:        int32(summary.Valid),
+		InvalidCount:      int32(summary.Invalid),
+		ErrorCount:        int32(summary.Errored),
+		NotValidatedCount: int32(summary.NotValidated),
+		Issues:            issues,
+	}
+	if summary.LastValidatedAt != nil {
+		resp.LastValidatedAt = timestamppb.New(*summary.LastValidatedAt)
+	}
 
 	return connect.NewResponse(resp), nil
 }
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	statepkg "github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Schema Registry Handlers
+
+// CreateRegisteredSchema registers a shared output schema under a named version.
+func (h *StateServiceHandler) CreateRegisteredSchema(
+	ctx context.Context,
+	req *connect.Request[statev1.CreateRegisteredSchemaRequest],
+) (*connect.Response[statev1.CreateRegisteredSchemaResponse], error) {
+	entry, err := h.service.CreateRegisteredSchema(ctx, req.Msg.Key, req.Msg.SchemaJson, req.Msg.Description)
+	if err != nil {
+		return nil, mapSchemaRegistryError(err)
+	}
+
+	return connect.NewResponse(&statev1.CreateRegisteredSchemaResponse{
+		Schema: registeredSchemaToProto(entry),
+	}), nil
+}
+
+// GetRegisteredSchema returns a registered schema by key.
+func (h *StateServiceHandler) GetRegisteredSchema(
+	ctx context.Context,
+	req *connect.Request[statev1.GetRegisteredSchemaRequest],
+) (*connect.Response[statev1.GetRegisteredSchemaResponse], error) {
+	entry, err := h.service.GetRegisteredSchema(ctx, req.Msg.Key)
+	if err != nil {
+		return nil, mapSchemaRegistryError(err)
+	}
+
+	return connect.NewResponse(&statev1.GetRegisteredSchemaResponse{
+		Schema: registeredSchemaToProto(entry),
+	}), nil
+}
+
+// ListRegisteredSchemas returns every registered schema ordered by key.
+func (h *StateServiceHandler) ListRegisteredSchemas(
+	ctx context.Context,
+	req *connect.Request[statev1.ListRegisteredSchemasRequest],
+) (*connect.Response[statev1.ListRegisteredSchemasResponse], error) {
+	entries, err := h.service.ListRegisteredSchemas(ctx)
+	if err != nil {
+		return nil, mapSchemaRegistryError(err)
+	}
+
+	resp := &statev1.ListRegisteredSchemasResponse{
+		Schemas: make([]*statev1.RegisteredSchema, 0, len(entries)),
+	}
+	for i := range entries {
+		resp.Schemas = append(resp.Schemas, registeredSchemaToProto(&entries[i]))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateRegisteredSchema replaces a registered schema. Outputs referencing the
+// key pick up the new schema; with revalidate set those in states the caller
+// may write are validated against it before the response, otherwise their
+// stored results are left as is.
+func (h *StateServiceHandler) UpdateRegisteredSchema(
+	ctx context.Context,
+	req *connect.Request[statev1.UpdateRegisteredSchemaRequest],
+) (*connect.Response[statev1.UpdateRegisteredSchemaResponse], error) {
+	if req.Msg.Revalidate && h.validationJob == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("schema validation is not configured"))
+	}
+
+	entry, err := h.service.UpdateRegisteredSchema(ctx, req.Msg.Key, req.Msg.SchemaJson, req.Msg.Description)
+	if err != nil {
+		return nil, mapSchemaRegistryError(err)
+	}
+
+	resp := &statev1.UpdateRegisteredSchemaResponse{
+		Schema: registeredSchemaToProto(entry),
+	}
+	if req.Msg.Revalidate {
+		summary, err := h.validationJob.RevalidateSchema(ctx, entry.SchemaJSON, h.service.GetStateOutputValue, h.schemaWritableStates)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+		resp.Revalidation, err = h.revalidationToProto(ctx, summary)
+		if err != nil {
+			return nil, mapServiceError(err)
+		}
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteRegisteredSchema removes a registered schema no output references.
+func (h *StateServiceHandler) DeleteRegisteredSchema(
+	ctx context.Context,
+	req *connect.Request[statev1.DeleteRegisteredSchemaRequest],
+) (*connect.Response[statev1.DeleteRegisteredSchemaResponse], error) {
+	if err := h.service.DeleteRegisteredSchema(ctx, req.Msg.Key); err != nil {
+		return nil, mapSchemaRegistryError(err)
+	}
+
+	return connect.NewResponse(&statev1.DeleteRegisteredSchemaResponse{}), nil
+}
+
+// mapSchemaRegistryError maps registry errors that mapServiceError would
+// misclassify, and defers to it for the rest.
+func mapSchemaRegistryError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrSchemaInUse):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, statepkg.ErrSchemaRegistryNotConfigured):
+		return connect.NewError(connect.CodeUnimplemented, err)
+	case errors.Is(err, statepkg.ErrInvalidJSONSchema):
+		// Compile errors can mention unresolved refs ("not found"); keep them InvalidArgument
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return mapServiceError(err)
+	}
+}
+
+func registeredSchemaToProto(entry *models.SchemaRegistryEntry) *statev1.RegisteredSchema {
+	return &statev1.RegisteredSchema{
+		Key:         entry.Key,
+		SchemaJson:  entry.SchemaJSON,
+		Description: entry.Description,
+		CreatedAt:   timestamppb.New(entry.CreatedAt),
+		UpdatedAt:   timestamppb.New(entry.UpdatedAt),
+	}
+}
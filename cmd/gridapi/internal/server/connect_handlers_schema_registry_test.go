@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	statepkg "github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/validation"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+)
+
+func TestSetOutputSchemaByRegistryRef(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	outputRepo := repository.NewBunStateOutputRepository(db)
+	state := &models.State{
+		GUID:         uuid.Must(uuid.NewV7()).String(),
+		LogicID:      "network",
+		StateContent: []byte(`{"version":4,"serial":1,"outputs":{"vpc_id":{"value":"vpc-123","type":"string"}}}`),
+	}
+	require.NoError(t, stateRepo.Create(ctx, state))
+	require.NoError(t, outputRepo.UpsertOutputs(ctx, state.GUID, 1, []repository.OutputKey{{Key: "vpc_id"}}))
+
+	validator, err := validation.NewSchemaValidator(16)
+	require.NoError(t, err)
+	svc := statepkg.NewService(stateRepo, "http://localhost").
+		WithOutputRepository(outputRepo).
+		WithSchemaRegistry(repository.NewBunSchemaRegistryRepository(db))
+	h := NewStateServiceHandler(svc, nil, nil).
+		WithValidationJob(NewSchemaValidationJob(outputRepo, validator, 5*time.Second))
+
+	_, err = h.CreateRegisteredSchema(ctx, connect.NewRequest(&statev1.CreateRegisteredSchemaRequest{
+		Key:         "aws/vpc_id@v1",
+		SchemaJson:  `{"type":"string","pattern":"^vpc-"}`,
+		Description: "AWS VPC identifier",
+	}))
+	require.NoError(t, err)
+
+	// The output is validated against the registered schema
+	setResp, err := h.SetOutputSchema(ctx, connect.NewRequest(&statev1.SetOutputSchemaRequest{
+		State:       &statev1.SetOutputSchemaRequest_StateLogicId{StateLogicId: "network"},
+		OutputKey:   "vpc_id",
+		SchemaRef:   "aws/vpc_id@v1",
+		ValidateNow: true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "valid", setResp.Msg.GetValidationStatus())
+
+	getResp, err := h.GetOutputSchema(ctx, connect.NewRequest(&statev1.GetOutputSchemaRequest{
+		State:     &statev1.GetOutputSchemaRequest_StateGuid{StateGuid: state.GUID},
+		OutputKey: "vpc_id",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "aws/vpc_id@v1", getResp.Msg.GetSchemaRef())
+	assert.JSONEq(t, `{"type":"string","pattern":"^vpc-"}`, getResp.Msg.GetSchemaJson())
+
+	// Changing the registered schema flows to the output and re-validates it
+	updResp, err := h.UpdateRegisteredSchema(ctx, connect.NewRequest(&statev1.UpdateRegisteredSchemaRequest{
+		Key:        "aws/vpc_id@v1",
+		SchemaJson: `{"type":"string","pattern":"^vpc-9"}`,
+		Revalidate: true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "AWS VPC identifier", updResp.Msg.GetSchema().GetDescription(), "description is kept when unset")
+	assert.EqualValues(t, 1, updResp.Msg.GetRevalidation().GetBecameInvalid())
+	outputs, err := outputRepo.GetOutputsByState(ctx, state.GUID)
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	assert.Equal(t, `{"type":"string","pattern":"^vpc-9"}`, *outputs[0].SchemaJSON)
+	assert.Equal(t, "invalid", *outputs[0].ValidationStatus)
+
+	t.Run("rejects invalid references", func(t *testing.T) {
+		tests := []struct {
+			name string
+			msg  *statev1.SetOutputSchemaRequest
+			code connect.Code
+		}{
+			{
+				name: "unknown key",
+				msg:  &statev1.SetOutputSchemaRequest{OutputKey: "vpc_id", SchemaRef: "aws/vpc_id@v2"},
+				code: connect.CodeNotFound,
+			},
+			{
+				name: "malformed key",
+				msg:  &statev1.SetOutputSchemaRequest{OutputKey: "vpc_id", SchemaRef: "aws/vpc_id"},
+				code: connect.CodeInvalidArgument,
+			},
+			{
+				name: "both inline and ref",
+				msg:  &statev1.SetOutputSchemaRequest{OutputKey: "vpc_id", SchemaRef: "aws/vpc_id@v1", SchemaJson: `{"type":"string"}`},
+				code: connect.CodeInvalidArgument,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.msg.State = &statev1.SetOutputSchemaRequest_StateGuid{StateGuid: state.GUID}
+				_, err := h.SetOutputSchema(ctx, connect.NewRequest(tt.msg))
+				assert.Equal(t, tt.code, connect.CodeOf(err))
+			})
+		}
+	})
+
+	t.Run("referenced schemas cannot be deleted", func(t *testing.T) {
+		_, err := h.DeleteRegisteredSchema(ctx, connect.NewRequest(&statev1.DeleteRegisteredSchemaRequest{Key: "aws/vpc_id@v1"}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		// The foreign key holds even without the reference count
+		_, err = db.NewDelete().Model((*models.SchemaRegistryEntry)(nil)).Where("key = ?", "aws/vpc_id@v1").Exec(ctx)
+		require.Error(t, err)
+
+		// An inline schema drops the reference
+		_, err = h.SetOutputSchema(ctx, connect.NewRequest(&statev1.SetOutputSchemaRequest{
+			State:       &statev1.SetOutputSchemaRequest_StateGuid{StateGuid: state.GUID},
+			OutputKey:   "vpc_id",
+			SchemaJson:  `{"type":"string"}`,
+			ValidateNow: true,
+		}))
+		require.NoError(t, err)
+		_, err = h.DeleteRegisteredSchema(ctx, connect.NewRequest(&statev1.DeleteRegisteredSchemaRequest{Key: "aws/vpc_id@v1"}))
+		require.NoError(t, err)
+
+		list, err := h.ListRegisteredSchemas(ctx, connect.NewRequest(&statev1.ListRegisteredSchemasRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, list.Msg.GetSchemas())
+	})
+}
+
+func TestSchemaRegistryErrorCodes(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+	stateRepo := repository.NewBunStateRepository(db)
+
+	unconfigured := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, nil)
+	_, err := unconfigured.ListRegisteredSchemas(ctx, connect.NewRequest(&statev1.ListRegisteredSchemasRequest{}))
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost").
+		WithSchemaRegistry(repository.NewBunSchemaRegistryRepository(db)), nil, nil)
+	for name, schemaJSON := range map[string]string{
+		"malformed":      `{"type":`,
+		"unresolved ref": `{"$ref":"#/definitions/missing"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := h.CreateRegisteredSchema(ctx, connect.NewRequest(&statev1.CreateRegisteredSchemaRequest{
+				Key:        "aws/vpc_id@v1",
+				SchemaJson: schemaJSON,
+			}))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "%v", err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	gridmiddleware "github.com/terraconstructs/grid/cmd/gridapi/internal/middleware"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+	"github.com/terraconstructs/grid/pkg/api/state/v1/statev1connect"
+)
+
+// sessionStoreIAM keeps sessions in memory. Only the session methods used by
+// the admin session RPCs are implemented; others panic via the nil embed.
+type sessionStoreIAM struct {
+	iamAdminService
+	sessions []*models.Session
+}
+
+func (s *sessionStoreIAM) RevokeSession(_ context.Context, sessionID string) error {
+	for _, session := range s.sessions {
+		if session.ID == sessionID {
+			session.Revoked = true
+			return nil
+		}
+	}
+	return fmt.Errorf("session not found")
+}
+
+func (s *sessionStoreIAM) ListAllSessions(_ context.Context, filter iam.SessionFilter) ([]models.Session, error) {
+	var result []models.Session
+	for _, session := range s.sessions {
+		switch {
+		case filter.UserID != "" && (session.UserID == nil || *session.UserID != filter.UserID):
+		case filter.ServiceAccountID != "" && (session.ServiceAccountID == nil || *session.ServiceAccountID != filter.ServiceAccountID):
+		case filter.RevokedOnly && !session.Revoked:
+		default:
+			result = append(result, *session)
+		}
+	}
+	return result, nil
+}
+
+// grantsIAM authorizes by role → actions grants. Only Authorize and
+// AuthorizeDecision are implemented; other methods panic via the nil embed.
+type grantsIAM struct {
+	iam.Service
+	grants map[string][]string
+}
+
+func (g *grantsIAM) Authorize(_ context.Context, principal *iam.Principal, _, action string, _ map[string]any) (bool, error) {
+	for _, role := range principal.Roles {
+		if slices.Contains(g.grants[role], action) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (g *grantsIAM) AuthorizeDecision(ctx context.Context, principal *iam.Principal, obj, action string, labels map[string]any) (iam.Decision, error) {
+	allowed, err := g.Authorize(ctx, principal, obj, action, labels)
+	return iam.Decision{Allowed: allowed}, err
+}
+
+// asPrincipal builds a request authenticated as "<principal ID>;<role>".
+func asPrincipal[T any](principal string, msg *T) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	req.Header().Set("X-Test-Principal", principal)
+	return req
+}
+
+func TestAdminSessionRPCs_Authorization(t *testing.T) {
+	aliceID, bobID, saID := "user-alice", "user-bob", "sa-ci"
+	now := time.Now()
+	store := &sessionStoreIAM{sessions: []*models.Session{
+		{ID: "sess-bob", UserID: &bobID, CreatedAt: now.Add(-time.Hour), LastUsedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{ID: "sess-alice", UserID: &aliceID, CreatedAt: now.Add(-time.Hour), LastUsedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{ID: "sess-ci", ServiceAccountID: &saID, CreatedAt: now.Add(-time.Hour), LastUsedAt: now, ExpiresAt: now.Add(time.Hour)},
+	}}
+	h := NewStateServiceHandler(nil, nil, nil).WithIAMService(store)
+
+	// The test authenticates callers from a header: "<principal ID>;<role>"
+	principalFromHeader := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			id, role, _ := strings.Cut(req.Header().Get("X-Test-Principal"), ";")
+			return next(auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: id, Roles: []string{role}}), req)
+		}
+	})
+	authz := gridmiddleware.NewAuthzInterceptor(gridmiddleware.AuthzDependencies{IAMService: &grantsIAM{grants: map[string][]string{
+		"role:platform-engineer": {auth.AdminSessionList, auth.AdminSessionRevoke},
+		"role:product-engineer":  {auth.StateList},
+	}}})
+	mux := http.NewServeMux()
+	mux.Handle(statev1connect.NewStateServiceHandler(h, connect.WithInterceptors(principalFromHeader, authz)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := statev1connect.NewStateServiceClient(srv.Client(), srv.URL)
+
+	const alice, admin = "user:alice@example.com;role:product-engineer", "user:admin@example.com;role:platform-engineer"
+
+	t.Run("non-admin is denied", func(t *testing.T) {
+		_, err := client.RevokeSession(context.Background(), asPrincipal(alice, &statev1.RevokeSessionRequest{SessionId: "sess-bob"}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = client.ListAllSessions(context.Background(), asPrincipal(alice, &statev1.ListAllSessionsRequest{}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		assert.False(t, store.sessions[0].Revoked)
+	})
+
+	t.Run("admin revokes another user's session", func(t *testing.T) {
+		_, err := client.RevokeSession(context.Background(), asPrincipal(admin, &statev1.RevokeSessionRequest{SessionId: "sess-bob"}))
+		require.NoError(t, err)
+
+		resp, err := client.ListAllSessions(context.Background(), asPrincipal(admin, &statev1.ListAllSessionsRequest{RevokedOnly: true}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.GetSessions(), 1)
+		session := resp.Msg.GetSessions()[0]
+		assert.Equal(t, "sess-bob", session.GetId())
+		assert.Equal(t, bobID, session.GetUserId())
+		assert.True(t, session.GetRevoked())
+		assert.NotNil(t, session.GetLastUsedAt())
+		assert.NotNil(t, session.GetExpiresAt())
+	})
+
+	t.Run("admin lists service account sessions", func(t *testing.T) {
+		resp, err := client.ListAllSessions(context.Background(), asPrincipal(admin, &statev1.ListAllSessionsRequest{ServiceAccountId: saID}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.GetSessions(), 1)
+		assert.Equal(t, saID, resp.Msg.GetSessions()[0].GetServiceAccountId())
+		assert.Empty(t, resp.Msg.GetSessions()[0].GetUserId())
+	})
+}
@@ -0,0 +1,916 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/dependency"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+	statepkg "github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/tfstate"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/validation"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+)
+
+func TestMatchesAnyScope(t *testing.T) {
+	devLabels := map[string]any{"env": "dev"}
+	prodLabels := map[string]any{"env": "prod"}
+
+	tests := []struct {
+		name       string
+		roleScopes []string
+		labels     map[string]any
+		want       bool
+	}{
+		{name: "explicitly unscoped role sees prod", roleScopes: []string{auth.ScopeAll}, labels: prodLabels, want: true},
+		{name: "explicitly unscoped role sees unlabeled", roleScopes: []string{auth.ScopeAll}, labels: map[string]any{}, want: true},
+		{name: "empty scope sees nothing", roleScopes: []string{""}, labels: prodLabels, want: false},
+		{name: "blank scope sees nothing", roleScopes: []string{"  "}, labels: map[string]any{}, want: false},
+		{name: "deny-all scope sees nothing", roleScopes: []string{auth.ScopeNone}, labels: devLabels, want: false},
+		{name: "label scope matches", roleScopes: []string{`env == "dev"`}, labels: devLabels, want: true},
+		{name: "label scope excludes", roleScopes: []string{`env == "dev"`}, labels: prodLabels, want: false},
+		{name: "any role may match", roleScopes: []string{"", `env == "prod"`}, labels: prodLabels, want: true},
+		{name: "no roles", roleScopes: nil, labels: devLabels, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesAnyScope(tt.roleScopes, tt.labels))
+		})
+	}
+}
+
+// TestFilterStatesByRoleScopes_Combination checks that a user holding two roles
+// with differing scopes sees either role's states under union (the default) but
+// only states matching both scopes under intersection.
+func TestFilterStatesByRoleScopes_Combination(t *testing.T) {
+	summaries := []statepkg.StateSummary{
+		{LogicID: "dev-platform", Labels: models.LabelMap{"env": "dev", "team": "platform"}},
+		{LogicID: "dev-data", Labels: models.LabelMap{"env": "dev", "team": "data"}},
+		{LogicID: "prod-platform", Labels: models.LabelMap{"env": "prod", "team": "platform"}},
+		{LogicID: "prod-data", Labels: models.LabelMap{"env": "prod", "team": "data"}},
+	}
+	iamSvc := &scopedRoleIAM{roles: map[string]*models.Role{
+		"dev-reader":      {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+		"platform-reader": {Name: "platform-reader", ScopeExpr: `team == "platform"`},
+	}}
+	ctx := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{
+		PrincipalID: "user:alice@example.com",
+		Roles:       []string{"role:dev-reader", "role:platform-reader"},
+	})
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want []string
+	}{
+		{name: "union", cfg: nil, want: []string{"dev-platform", "dev-data", "prod-platform"}},
+		{name: "intersection", cfg: &config.Config{ScopeIntersectionObjectTypes: []string{auth.ObjectTypeState}}, want: []string{"dev-platform"}},
+		{name: "intersection for another object type", cfg: &config.Config{ScopeIntersectionObjectTypes: []string{auth.ObjectTypeAdmin}}, want: []string{"dev-platform", "dev-data", "prod-platform"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewStateServiceHandler(nil, nil, tt.cfg).WithIAMService(iamSvc)
+			filtered, err := h.filterStatesByRoleScopes(ctx, summaries)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(filtered))
+			for _, summary := range filtered {
+				got = append(got, summary.LogicID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestFilterStatesByRoleScopes_DenyRole checks that a role denying state read
+// hides matching states even from an unscoped role, and that a role without a
+// state read rule does not widen the caller's view.
+func TestFilterStatesByRoleScopes_DenyRole(t *testing.T) {
+	summaries := []statepkg.StateSummary{
+		{LogicID: "dev", Labels: models.LabelMap{"env": "dev"}},
+		{LogicID: "prod", Labels: models.LabelMap{"env": "prod"}},
+		{LogicID: "unlabeled", Labels: models.LabelMap{}},
+	}
+	iamSvc := &scopedRoleIAM{
+		roles: map[string]*models.Role{
+			"platform-team": {Name: "platform-team", ScopeExpr: auth.ScopeAll},
+			"dev-reader":    {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+		},
+		denies: map[string]string{"no-prod": `env == "prod"`},
+	}
+
+	tests := []struct {
+		name  string
+		cfg   *config.Config
+		roles []string
+		want  []string
+	}{
+		{name: "deny overrides an unscoped role", roles: []string{"platform-team", "no-prod"}, want: []string{"dev", "unlabeled"}},
+		{name: "deny-only role sees nothing", roles: []string{"no-prod"}, want: []string{}},
+		{name: "deny-only role does not narrow intersection", cfg: &config.Config{ScopeIntersectionObjectTypes: []string{auth.ObjectTypeState}}, roles: []string{"dev-reader", "no-prod"}, want: []string{"dev"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewStateServiceHandler(nil, nil, tt.cfg).WithIAMService(iamSvc)
+			ctx := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "user:alice@example.com", Roles: tt.roles})
+			filtered, err := h.filterStatesByRoleScopes(ctx, summaries)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(filtered))
+			for _, summary := range filtered {
+				got = append(got, summary.LogicID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Deny scopes are applied in memory only: the SQL pre-filter of an
+	// unscoped role stays empty
+	h := NewStateServiceHandler(nil, nil, nil).WithIAMService(iamSvc)
+	ctx := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "user:alice@example.com", Roles: []string{"platform-team", "no-prod"}})
+	filter, err := h.stateScopeFilter(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+}
+
+func TestEdgeToProtoCurrentFlag(t *testing.T) {
+	h := &StateServiceHandler{}
+	cache := map[string]*models.State{
+		"producer": {GUID: "producer", LogicID: "network"},
+		"consumer": {GUID: "consumer", LogicID: "app"},
+	}
+
+	tests := []struct {
+		name string
+		edge models.Edge
+		want bool
+	}{
+		{name: "observed latest output", edge: models.Edge{Status: models.EdgeStatusClean, InDigest: "sha-1", OutDigest: "sha-1"}, want: true},
+		{name: "clean but failing schema", edge: models.Edge{Status: models.EdgeStatusCleanInvalid, InDigest: "sha-1", OutDigest: "sha-1"}, want: true},
+		{name: "producer moved on", edge: models.Edge{Status: models.EdgeStatusDirty, InDigest: "sha-2", OutDigest: "sha-1"}, want: false},
+		{name: "never observed", edge: models.Edge{Status: models.EdgeStatusDirty, InDigest: "sha-1"}, want: false},
+		{name: "pending", edge: models.Edge{Status: models.EdgeStatusPending}, want: false},
+		{name: "mock", edge: models.Edge{Status: models.EdgeStatusMock, MockValue: []byte(`"vpc-mock"`)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edge := tt.edge
+			edge.FromState, edge.ToState, edge.FromOutput = "producer", "consumer", "vpc_id"
+
+			protoEdge, err := h.edgeToProto(context.Background(), &edge, cache)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, protoEdge.Current)
+			assert.Equal(t, edge.InDigest, protoEdge.GetInDigest())
+			assert.Equal(t, edge.OutDigest, protoEdge.GetOutDigest())
+		})
+	}
+}
+
+// scopedRoleIAM serves role policies for role-scope filtering and grants the
+// actions listed per casbin role; other IAM methods are unused. Each role
+// allows state read under its ScopeExpr, and denies it under denies[name].
+// AuthorizeDecision also limits grants to the role's ScopeExpr.
+type scopedRoleIAM struct {
+	iamAdminService
+	roles  map[string]*models.Role
+	grants map[string][]string
+	denies map[string]string
+}
+
+func (s *scopedRoleIAM) Authorize(_ context.Context, principal *iam.Principal, _, act string, _ map[string]interface{}) (bool, error) {
+	for _, role := range principal.Roles {
+		if slices.Contains(s.grants[role], act) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *scopedRoleIAM) AuthorizeDecision(_ context.Context, principal *iam.Principal, _, act string, labels map[string]interface{}) (iam.Decision, error) {
+	for _, principalRole := range principal.Roles {
+		if !slices.Contains(s.grants[principalRole], act) {
+			continue
+		}
+		name, _ := auth.ParseRoleName(principalRole)
+		if role, ok := s.roles[name]; !ok || auth.EvaluateBexpr(role.ScopeExpr, labels) {
+			return iam.Decision{Allowed: true}, nil
+		}
+	}
+	return iam.Decision{}, nil
+}
+
+func (s *scopedRoleIAM) AuthorizeOwner(context.Context, *iam.Principal, string) (bool, error) {
+	return false, nil
+}
+
+func (s *scopedRoleIAM) GetRolePermissions(_ context.Context, name string) ([][]string, error) {
+	var policies [][]string
+	if role, ok := s.roles[name]; ok {
+		policies = append(policies, []string{auth.RoleID(name), auth.ObjectTypeState, auth.StateRead, role.ScopeExpr, auth.EffectAllow})
+	}
+	if scope, ok := s.denies[name]; ok {
+		policies = append(policies, []string{auth.RoleID(name), auth.ObjectTypeState, auth.StateRead, scope, auth.EffectDeny})
+	}
+	return policies, nil
+}
+
+func TestListStateOutputsBatch(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	outputRepo := repository.NewBunStateOutputRepository(db)
+	content := []byte(`{"version":4,"serial":1,"outputs":{` +
+		`"vpc_id":{"value":"vpc-123","type":"string"},` +
+		`"subnets":{"value":["a","b"],"type":["list","string"]},` +
+		`"db_password":{"value":"hunter2","type":"string","sensitive":true}}}`)
+
+	newState := func(logicID, env string) *models.State {
+		state := &models.State{
+			GUID:         uuid.Must(uuid.NewV7()).String(),
+			LogicID:      logicID,
+			Labels:       models.LabelMap{"env": env},
+			StateContent: content,
+		}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		return state
+	}
+	network := newState("network-dev", "dev")
+	app := newState("app-dev", "dev")
+	prod := newState("network-prod", "prod")
+
+	// network-dev has a populated output cache; app-dev falls back to parsing its JSON
+	require.NoError(t, outputRepo.UpsertOutputs(ctx, network.GUID, 1, []repository.OutputKey{
+		{Key: "db_password", Sensitive: true},
+		{Key: "subnets"},
+		{Key: "vpc_id"},
+	}))
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost").WithOutputRepository(outputRepo), nil, nil).
+		WithIAMService(&scopedRoleIAM{
+			roles: map[string]*models.Role{
+				"dev-reader":  {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+				"prod-lister": {Name: "prod-lister", ScopeExpr: `env == "prod"`},
+			},
+			grants: map[string][]string{
+				"role:dev-reader":  {auth.StateOutputList, auth.StateOutputRead},
+				"role:prod-lister": {auth.StateOutputList},
+			},
+		})
+	ctx = auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "user:dev@example.com", Roles: []string{"role:dev-reader"}})
+
+	list := func(t *testing.T, msg *statev1.ListStateOutputsBatchRequest) []*statev1.StateOutputs {
+		t.Helper()
+		resp, err := h.ListStateOutputsBatch(ctx, connect.NewRequest(msg))
+		require.NoError(t, err)
+		return resp.Msg.GetStates()
+	}
+	outputsByKey := func(outputs []*statev1.OutputKey) map[string]*statev1.OutputKey {
+		byKey := make(map[string]*statev1.OutputKey, len(outputs))
+		for _, out := range outputs {
+			byKey[out.GetKey()] = out
+		}
+		return byKey
+	}
+
+	t.Run("omits out-of-scope, unknown and duplicate states", func(t *testing.T) {
+		states := list(t, &statev1.ListStateOutputsBatchRequest{States: []*statev1.StateRef{
+			{Guid: prod.GUID},
+			{LogicId: "app-dev"},
+			{Guid: uuid.Must(uuid.NewV7()).String()},
+			{Guid: network.GUID},
+			{LogicId: "network-dev"},
+		}})
+
+		require.Len(t, states, 2)
+		assert.Equal(t, app.GUID, states[0].GetStateGuid())
+		assert.Equal(t, "app-dev", states[0].GetStateLogicId())
+		assert.Equal(t, network.GUID, states[1].GetStateGuid())
+		for _, state := range states {
+			outputs := outputsByKey(state.GetOutputs())
+			require.Len(t, outputs, 3)
+			assert.True(t, outputs["db_password"].GetSensitive())
+			assert.Nil(t, outputs["vpc_id"].ValueJson, "values are only returned on request")
+		}
+	})
+
+	t.Run("redacts sensitive values", func(t *testing.T) {
+		states := list(t, &statev1.ListStateOutputsBatchRequest{
+			States:        []*statev1.StateRef{{LogicId: "network-dev"}, {LogicId: "app-dev"}, {LogicId: "network-prod"}},
+			IncludeValues: true,
+		})
+
+		require.Len(t, states, 2)
+		for _, state := range states {
+			outputs := outputsByKey(state.GetOutputs())
+			assert.Equal(t, `"vpc-123"`, outputs["vpc_id"].GetValueJson())
+			assert.JSONEq(t, `["a","b"]`, outputs["subnets"].GetValueJson())
+			assert.True(t, outputs["db_password"].GetSensitive())
+			assert.Nil(t, outputs["db_password"].ValueJson)
+		}
+	})
+
+	t.Run("restricts to requested output keys", func(t *testing.T) {
+		states := list(t, &statev1.ListStateOutputsBatchRequest{
+			States:        []*statev1.StateRef{{Guid: network.GUID}},
+			OutputKeys:    []string{"vpc_id"},
+			IncludeValues: true,
+		})
+
+		require.Len(t, states, 1)
+		require.Len(t, states[0].GetOutputs(), 1)
+		assert.Equal(t, "vpc_id", states[0].GetOutputs()[0].GetKey())
+		assert.Equal(t, `"vpc-123"`, states[0].GetOutputs()[0].GetValueJson())
+	})
+
+	t.Run("authorizes the output action per state", func(t *testing.T) {
+		prodCtx := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "user:ops@example.com", Roles: []string{"role:prod-lister"}})
+		refs := []*statev1.StateRef{{LogicId: "network-dev"}, {LogicId: "network-prod"}}
+
+		resp, err := h.ListStateOutputsBatch(prodCtx, connect.NewRequest(&statev1.ListStateOutputsBatchRequest{States: refs}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.GetStates(), 1)
+		assert.Equal(t, prod.GUID, resp.Msg.GetStates()[0].GetStateGuid())
+
+		resp, err = h.ListStateOutputsBatch(prodCtx, connect.NewRequest(&statev1.ListStateOutputsBatchRequest{States: refs, IncludeValues: true}))
+		require.NoError(t, err)
+		assert.Empty(t, resp.Msg.GetStates(), "listing keys does not grant reading values")
+	})
+
+	t.Run("rejects oversized batches", func(t *testing.T) {
+		refs := make([]*statev1.StateRef, maxOutputsBatchStates+1)
+		for i := range refs {
+			refs[i] = &statev1.StateRef{Guid: network.GUID}
+		}
+		_, err := h.ListStateOutputsBatch(ctx, connect.NewRequest(&statev1.ListStateOutputsBatchRequest{States: refs}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestGetStateOutputValues(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	content := []byte(`{"version":4,"serial":1,"outputs":{` +
+		`"vpc_id":{"value":"vpc-123","type":"string"},` +
+		`"subnets":{"value":["a","b"],"type":["list","string"]},` +
+		`"db_password":{"value":"hunter2","type":"string","sensitive":true}}}`)
+	for _, state := range []*models.State{
+		{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: "network-dev", Labels: models.LabelMap{"env": "dev"}, StateContent: content},
+		{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: "network-prod", Labels: models.LabelMap{"env": "prod"}, StateContent: content},
+	} {
+		require.NoError(t, stateRepo.Create(ctx, state))
+	}
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, nil).
+		WithIAMService(&scopedRoleIAM{
+			roles: map[string]*models.Role{
+				"dev-reader":  {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+				"dev-secrets": {Name: "dev-secrets", ScopeExpr: `env == "dev"`},
+			},
+			grants: map[string][]string{"role:dev-secrets": {auth.StateOutputReadSensitive}},
+		})
+	readerCtx := auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "user:dev@example.com", Roles: []string{"role:dev-reader"}})
+	secretsCtx := auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "user:ops@example.com", Roles: []string{"role:dev-secrets"}})
+
+	get := func(t *testing.T, ctx context.Context, msg *statev1.GetStateOutputValuesRequest) []*statev1.OutputValue {
+		t.Helper()
+		resp, err := h.GetStateOutputValues(ctx, connect.NewRequest(msg))
+		require.NoError(t, err)
+		assert.Equal(t, "network-dev", resp.Msg.GetStateLogicId())
+		return resp.Msg.GetValues()
+	}
+
+	t.Run("returns requested non-sensitive values", func(t *testing.T) {
+		values := get(t, readerCtx, &statev1.GetStateOutputValuesRequest{
+			State: &statev1.StateRef{LogicId: "network-dev"},
+			Keys:  []string{"subnets", "missing", "vpc_id"},
+		})
+
+		require.Len(t, values, 2)
+		assert.Equal(t, "subnets", values[0].GetKey())
+		assert.JSONEq(t, `["a","b"]`, values[0].GetValueJson())
+		assert.Equal(t, "vpc_id", values[1].GetKey())
+		assert.Equal(t, `"vpc-123"`, values[1].GetValueJson())
+		assert.False(t, values[1].GetRedacted())
+	})
+
+	t.Run("redacts sensitive values without elevated permission", func(t *testing.T) {
+		values := get(t, readerCtx, &statev1.GetStateOutputValuesRequest{State: &statev1.StateRef{LogicId: "network-dev"}})
+
+		require.Len(t, values, 3)
+		assert.Equal(t, "db_password", values[0].GetKey())
+		assert.True(t, values[0].GetSensitive())
+		assert.True(t, values[0].GetRedacted())
+		assert.Nil(t, values[0].ValueJson)
+	})
+
+	t.Run("returns sensitive values with elevated permission", func(t *testing.T) {
+		values := get(t, secretsCtx, &statev1.GetStateOutputValuesRequest{
+			State: &statev1.StateRef{LogicId: "network-dev"},
+			Keys:  []string{"db_password"},
+		})
+
+		require.Len(t, values, 1)
+		assert.False(t, values[0].GetRedacted())
+		assert.Equal(t, `"hunter2"`, values[0].GetValueJson())
+	})
+
+	t.Run("enforces role scope", func(t *testing.T) {
+		_, err := h.GetStateOutputValues(secretsCtx, connect.NewRequest(&statev1.GetStateOutputValuesRequest{
+			State: &statev1.StateRef{LogicId: "network-prod"},
+		}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestGetDependencyGraph_DepthAndCycles(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	edgeRepo := repository.NewBunEdgeRepository(db)
+	newState := func(logicID, env string) *models.State {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: logicID, Labels: models.LabelMap{"env": env}}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		return state
+	}
+	depend := func(producer, consumer *models.State) *models.Edge {
+		edge := &models.Edge{FromState: producer.GUID, FromOutput: "out", ToState: consumer.GUID, ToInputName: producer.LogicID}
+		require.NoError(t, edgeRepo.Create(ctx, edge))
+		return edge
+	}
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, &config.Config{ServerURL: "http://localhost"})
+	h.depService = dependency.NewService(edgeRepo, stateRepo)
+	graphOf := func(ctx context.Context, logicID string, maxDepth *int32) *statev1.GetDependencyGraphResponse {
+		t.Helper()
+		resp, err := h.GetDependencyGraph(ctx, connect.NewRequest(&statev1.GetDependencyGraphRequest{
+			State:    &statev1.GetDependencyGraphRequest_LogicId{LogicId: logicID},
+			MaxDepth: maxDepth,
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	producerNames := func(msg *statev1.GetDependencyGraphResponse) []string {
+		names := make([]string, 0, len(msg.GetProducers()))
+		for _, producer := range msg.GetProducers() {
+			names = append(names, producer.GetLogicId())
+		}
+		return names
+	}
+
+	// Chain: chain-0 <- chain-1 <- ... <- chain-5 (chain-0 consumes chain-1)
+	chain := make([]*models.State, 6)
+	for i := range chain {
+		chain[i] = newState("chain-"+string(rune('0'+i)), "dev")
+		if i > 0 {
+			depend(chain[i], chain[i-1])
+		}
+	}
+
+	t.Run("defaults to direct producers", func(t *testing.T) {
+		msg := graphOf(ctx, "chain-0", nil)
+		assert.Equal(t, []string{"chain-1"}, producerNames(msg))
+		assert.Equal(t, int32(1), msg.GetMaxDepth())
+		assert.True(t, msg.GetTruncated())
+	})
+
+	t.Run("truncates a deep chain at the limit", func(t *testing.T) {
+		msg := graphOf(ctx, "chain-0", ptrInt32(3))
+		assert.Equal(t, []string{"chain-1", "chain-2", "chain-3"}, producerNames(msg))
+		assert.Equal(t, int32(3), msg.GetProducers()[2].GetDepth())
+		assert.Len(t, msg.GetEdges(), 3)
+		assert.True(t, msg.GetTruncated())
+
+		msg = graphOf(ctx, "chain-0", ptrInt32(5))
+		assert.Len(t, msg.GetProducers(), 5)
+		assert.False(t, msg.GetTruncated(), "nothing lies beyond the last producer")
+
+		msg = graphOf(ctx, "chain-0", ptrInt32(1000))
+		assert.Equal(t, int32(dependency.MaxDependencyGraphDepth), msg.GetMaxDepth())
+	})
+
+	t.Run("addresses producers through the backend URL", func(t *testing.T) {
+		msg := graphOf(ctx, "chain-0", nil)
+		require.Len(t, msg.GetProducers(), 1)
+		assert.Equal(t, "http://localhost/tfstate/"+chain[1].GUID, msg.GetProducers()[0].GetBackendConfig().GetAddress())
+
+		h.cfg = &config.Config{ServerURL: "http://localhost", BackendURL: "https://grid.example.com"}
+		defer func() { h.cfg = &config.Config{ServerURL: "http://localhost"} }()
+		backend := graphOf(ctx, "chain-0", nil).GetProducers()[0].GetBackendConfig()
+		assert.Equal(t, "https://grid.example.com/tfstate/"+chain[1].GUID, backend.GetAddress())
+		assert.Equal(t, "https://grid.example.com/tfstate/"+chain[1].GUID+"/lock", backend.GetLockAddress())
+		assert.Equal(t, "https://grid.example.com/tfstate/"+chain[1].GUID+"/unlock", backend.GetUnlockAddress())
+	})
+
+	t.Run("marks cycles instead of looping", func(t *testing.T) {
+		a, b, c := newState("cycle-a", "dev"), newState("cycle-b", "dev"), newState("cycle-c", "dev")
+		depend(b, a)
+		depend(c, b)
+		closing := depend(a, c)
+
+		msg := graphOf(ctx, "cycle-a", ptrInt32(10))
+		assert.Equal(t, []string{"cycle-b", "cycle-c"}, producerNames(msg))
+		assert.Len(t, msg.GetEdges(), 3)
+		assert.Equal(t, []int64{closing.ID}, msg.GetCycleEdgeIds())
+		assert.False(t, msg.GetTruncated())
+
+		// The cut-off edge leads back to the consumer, so no producer is missing
+		msg = graphOf(ctx, "cycle-a", ptrInt32(2))
+		assert.Equal(t, []string{"cycle-b", "cycle-c"}, producerNames(msg))
+		assert.Len(t, msg.GetEdges(), 2)
+		assert.False(t, msg.GetTruncated())
+	})
+
+	t.Run("applies role scopes to every producer", func(t *testing.T) {
+		consumer, devProducer, prodProducer := newState("scoped-app", "dev"), newState("scoped-net", "dev"), newState("scoped-db", "prod")
+		depend(devProducer, consumer)
+		depend(prodProducer, devProducer)
+
+		h.WithIAMService(&scopedRoleIAM{roles: map[string]*models.Role{
+			"dev-reader": {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+		}})
+		defer func() { h.iamService = nil }()
+		scopedCtx := auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "user:dev@example.com", Roles: []string{"role:dev-reader"}})
+
+		msg := graphOf(scopedCtx, consumer.LogicID, ptrInt32(2))
+		assert.Equal(t, []string{"scoped-net"}, producerNames(msg))
+		require.Len(t, msg.GetEdges(), 1)
+		assert.Equal(t, devProducer.GUID, msg.GetEdges()[0].GetFromGuid())
+	})
+}
+
+func TestRecomputeDependencyStatus(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	edgeRepo := repository.NewBunEdgeRepository(db)
+	newState := func(logicID, env string) *models.State {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: logicID, Labels: models.LabelMap{"env": env}}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		return state
+	}
+
+	// Both consumers have observed vpc-1
+	network := newState("network", "dev")
+	require.NoError(t, stateRepo.UpdateContentAndUpsertOutputs(ctx, network.GUID,
+		[]byte(`{"version":4,"serial":1,"outputs":{"vpc_id":{"value":"vpc-1"}}}`), "", 1, nil))
+	observed := tfstate.ComputeFingerprint("vpc-1")
+	consume := func(consumer *models.State) *models.Edge {
+		edge := &models.Edge{
+			FromState: network.GUID, FromOutput: "vpc_id", ToState: consumer.GUID, ToInputName: "vpc_id",
+			InDigest: observed, OutDigest: observed, Status: models.EdgeStatusClean,
+		}
+		require.NoError(t, edgeRepo.Create(ctx, edge))
+		return edge
+	}
+	appEdge := consume(newState("app", "dev"))
+	billingEdge := consume(newState("billing", "prod"))
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, &config.Config{ServerURL: "http://localhost"})
+	h.depService = dependency.NewService(edgeRepo, stateRepo)
+	recompute := func(ctx context.Context) *statev1.RecomputeDependencyStatusResponse {
+		t.Helper()
+		resp, err := h.RecomputeDependencyStatus(ctx, connect.NewRequest(&statev1.RecomputeDependencyStatusRequest{
+			State: &statev1.RecomputeDependencyStatusRequest_LogicId{LogicId: "network"},
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	statusOf := func(edge *models.Edge) models.EdgeStatus {
+		stored, err := edgeRepo.GetByID(ctx, edge.ID)
+		require.NoError(t, err)
+		return stored.Status
+	}
+
+	msg := recompute(ctx)
+	assert.Empty(t, msg.GetChangedEdgeIds(), "outputs unchanged since the consumers observed them")
+	assert.Equal(t, models.EdgeStatusClean, statusOf(appEdge))
+
+	// The upstream output changes without the edge update job running
+	require.NoError(t, stateRepo.UpdateContentAndUpsertOutputs(ctx, network.GUID,
+		[]byte(`{"version":4,"serial":2,"outputs":{"vpc_id":{"value":"vpc-2"}}}`), "", 2, nil))
+
+	t.Run("skips edges outside the caller's role scopes", func(t *testing.T) {
+		h.WithIAMService(&scopedRoleIAM{roles: map[string]*models.Role{
+			"dev-ci": {Name: "dev-ci", ScopeExpr: `env == "dev"`},
+		}})
+		defer func() { h.iamService = nil }()
+		scopedCtx := auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "sa:dev-ci", Roles: []string{"role:dev-ci"}})
+
+		msg := recompute(scopedCtx)
+		assert.Equal(t, []int64{appEdge.ID}, msg.GetChangedEdgeIds())
+		assert.Equal(t, int32(1), msg.GetSkippedEdges())
+		require.Len(t, msg.GetEdges(), 1)
+		assert.Equal(t, string(models.EdgeStatusDirty), msg.GetEdges()[0].GetStatus())
+
+		assert.Equal(t, models.EdgeStatusDirty, statusOf(appEdge))
+		assert.Equal(t, models.EdgeStatusClean, statusOf(billingEdge), "out-of-scope consumer left alone")
+	})
+
+	t.Run("marks the remaining edges dirty", func(t *testing.T) {
+		msg := recompute(ctx)
+		assert.Equal(t, []int64{billingEdge.ID}, msg.GetChangedEdgeIds())
+		assert.Zero(t, msg.GetSkippedEdges())
+		assert.Len(t, msg.GetEdges(), 2)
+		assert.Equal(t, models.EdgeStatusDirty, statusOf(billingEdge))
+	})
+}
+
+func TestAddDependency_MockValueSchema(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	edgeRepo := repository.NewBunEdgeRepository(db)
+	outputRepo := repository.NewBunStateOutputRepository(db)
+	for _, logicID := range []string{"network", "app"} {
+		require.NoError(t, stateRepo.Create(ctx, &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: logicID}))
+	}
+	network, err := stateRepo.GetByLogicID(ctx, "network")
+	require.NoError(t, err)
+	require.NoError(t, outputRepo.SetOutputSchema(ctx, network.GUID, "vpc_id", `{"type":"string","pattern":"^vpc-"}`))
+
+	validator, err := validation.NewSchemaValidator(10)
+	require.NoError(t, err)
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, &config.Config{ServerURL: "http://localhost"})
+	h.depService = dependency.NewService(edgeRepo, stateRepo).WithOutputRepository(outputRepo).WithValidator(validator)
+
+	addMock := func(output, mockJSON string) (*connect.Response[statev1.AddDependencyResponse], error) {
+		return h.AddDependency(ctx, connect.NewRequest(&statev1.AddDependencyRequest{
+			FromState:     &statev1.AddDependencyRequest_FromLogicId{FromLogicId: "network"},
+			FromOutput:    output,
+			ToState:       &statev1.AddDependencyRequest_ToLogicId{ToLogicId: "app"},
+			MockValueJson: &mockJSON,
+		}))
+	}
+
+	t.Run("rejects a non-conforming mock value", func(t *testing.T) {
+		_, err := addMock("vpc_id", `"subnet-123"`)
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		assert.Contains(t, err.Error(), "does not match pattern")
+
+		_, err = addMock("vpc_id", `42`)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		edges, err := edgeRepo.GetOutgoingEdges(ctx, network.GUID)
+		require.NoError(t, err)
+		assert.Empty(t, edges, "no edge created")
+	})
+
+	t.Run("accepts a conforming mock value", func(t *testing.T) {
+		resp, err := addMock("vpc_id", `"vpc-123"`)
+		require.NoError(t, err)
+		assert.Equal(t, string(models.EdgeStatusMock), resp.Msg.GetEdge().GetStatus())
+	})
+
+	t.Run("skips validation for outputs without a schema", func(t *testing.T) {
+		resp, err := addMock("subnet_ids", `["anything", 1]`)
+		require.NoError(t, err)
+		assert.Equal(t, string(models.EdgeStatusMock), resp.Msg.GetEdge().GetStatus())
+	})
+}
+
+func TestListOutputSchemaHistory(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	outputRepo := repository.NewBunStateOutputRepository(db)
+	state := &models.State{
+		GUID:         uuid.Must(uuid.NewV7()).String(),
+		LogicID:      "network",
+		StateContent: []byte(`{"version":4,"serial":1,"outputs":{"vpc_id":{"value":"vpc-123","type":"string"}}}`),
+	}
+	require.NoError(t, stateRepo.Create(ctx, state))
+	require.NoError(t, outputRepo.UpsertOutputs(ctx, state.GUID, 1, []repository.OutputKey{{Key: "vpc_id"}}))
+
+	validator, err := validation.NewSchemaValidator(10)
+	require.NoError(t, err)
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost").WithOutputRepository(outputRepo), nil, nil).
+		WithValidationJob(NewSchemaValidationJob(outputRepo, validator, 0))
+
+	setSchema := func(schemaJSON string) string {
+		resp, err := h.SetOutputSchema(ctx, connect.NewRequest(&statev1.SetOutputSchemaRequest{
+			State:       &statev1.SetOutputSchemaRequest_StateLogicId{StateLogicId: "network"},
+			OutputKey:   "vpc_id",
+			SchemaJson:  schemaJSON,
+			ValidateNow: true,
+		}))
+		require.NoError(t, err)
+		return resp.Msg.GetValidationStatus()
+	}
+	v1 := `{"type":"string","pattern":"^vpc-"}`
+	v2 := `{"type":"string","pattern":"^vpc-9"}`
+	assert.Equal(t, "valid", setSchema(v1))
+	assert.Equal(t, "invalid", setSchema(v2))
+	setSchema(v2) // Re-setting the active schema adds no version
+
+	resp, err := h.ListOutputSchemaHistory(ctx, connect.NewRequest(&statev1.ListOutputSchemaHistoryRequest{
+		State:     &statev1.ListOutputSchemaHistoryRequest_StateGuid{StateGuid: state.GUID},
+		OutputKey: "vpc_id",
+	}))
+	require.NoError(t, err)
+
+	versions := resp.Msg.GetVersions()
+	require.Len(t, versions, 2)
+	for i, schemaJSON := range []string{v1, v2} {
+		assert.EqualValues(t, i+1, versions[i].GetVersion())
+		assert.Equal(t, schemaJSON, versions[i].GetSchemaJson())
+		assert.Equal(t, "manual", versions[i].GetSchemaSource())
+		assert.False(t, versions[i].GetCreatedAt().AsTime().IsZero())
+	}
+	assert.False(t, versions[1].GetCreatedAt().AsTime().Before(versions[0].GetCreatedAt().AsTime()))
+	assert.False(t, versions[0].GetActive())
+	assert.True(t, versions[1].GetActive())
+
+	// The stored validation result says which version it was produced under
+	assert.EqualValues(t, 2, resp.Msg.GetValidatedSchemaVersion())
+	assert.Equal(t, "invalid", resp.Msg.GetValidationStatus())
+}
+
+func TestSearchByOutput_AppliesRoleScopes(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	edgeRepo := repository.NewBunEdgeRepository(db)
+	newState := func(logicID, env string) *models.State {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: logicID, Labels: models.LabelMap{"env": env}}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		return state
+	}
+	depend := func(producer, consumer *models.State) *models.Edge {
+		edge := &models.Edge{FromState: producer.GUID, FromOutput: "vpc_id", ToState: consumer.GUID, ToInputName: "vpc_id"}
+		require.NoError(t, edgeRepo.Create(ctx, edge))
+		return edge
+	}
+	devEdge := depend(newState("dev-network", "dev"), newState("dev-app", "dev"))
+	depend(newState("prod-network", "prod"), newState("prod-app", "prod"))
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, &config.Config{})
+	h.depService = dependency.NewService(edgeRepo, stateRepo)
+	search := func(ctx context.Context) []*statev1.DependencyEdge {
+		t.Helper()
+		resp, err := h.SearchByOutput(ctx, connect.NewRequest(&statev1.SearchByOutputRequest{OutputKey: "vpc_id"}))
+		require.NoError(t, err)
+		return resp.Msg.GetEdges()
+	}
+
+	assert.Len(t, search(ctx), 2, "no-auth mode sees every edge")
+
+	h.WithIAMService(&scopedRoleIAM{roles: map[string]*models.Role{
+		"dev-reader": {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+	}})
+	devCtx := auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "user:dev@example.com", Roles: []string{"role:dev-reader"}})
+	edges := search(devCtx)
+	require.Len(t, edges, 1)
+	assert.Equal(t, devEdge.ID, edges[0].GetId())
+}
+
+func TestStatusAndTopology_ApplyRoleScopes(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	edgeRepo := repository.NewBunEdgeRepository(db)
+	newState := func(logicID, env string) *models.State {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: logicID, Labels: models.LabelMap{"env": env}}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		return state
+	}
+	depend := func(producer, consumer *models.State) *models.Edge {
+		edge := &models.Edge{FromState: producer.GUID, FromOutput: "vpc_id", ToState: consumer.GUID, ToInputName: "vpc_id_" + producer.LogicID}
+		require.NoError(t, edgeRepo.Create(ctx, edge))
+		return edge
+	}
+
+	// dev-network and prod-network feed dev-app, which reaches dev-tail via prod-svc
+	devNet, prodNet := newState("dev-network", "dev"), newState("prod-network", "prod")
+	devApp, prodSvc, devTail := newState("dev-app", "dev"), newState("prod-svc", "prod"), newState("dev-tail", "dev")
+	devEdge := depend(devNet, devApp)
+	depend(prodNet, devApp)
+	depend(devApp, prodSvc)
+	depend(prodSvc, devTail)
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, &config.Config{})
+	h.depService = dependency.NewService(edgeRepo, stateRepo)
+	h.WithIAMService(&scopedRoleIAM{roles: map[string]*models.Role{
+		"dev-reader": {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+	}})
+	devCtx := auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "user:dev@example.com", Roles: []string{"role:dev-reader"}})
+
+	t.Run("target outside scope is denied", func(t *testing.T) {
+		_, err := h.GetStateStatus(devCtx, connect.NewRequest(&statev1.GetStateStatusRequest{
+			State: &statev1.GetStateStatusRequest_LogicId{LogicId: prodNet.LogicID},
+		}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = h.GetTopologicalOrder(devCtx, connect.NewRequest(&statev1.GetTopologicalOrderRequest{
+			State: &statev1.GetTopologicalOrderRequest_Guid{Guid: prodNet.GUID},
+		}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("status omits producers outside scope", func(t *testing.T) {
+		resp, err := h.GetStateStatus(devCtx, connect.NewRequest(&statev1.GetStateStatusRequest{
+			State: &statev1.GetStateStatusRequest_Guid{Guid: devApp.GUID},
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.GetIncoming(), 1)
+		assert.Equal(t, devEdge.ID, resp.Msg.GetIncoming()[0].GetEdgeId())
+		summary := resp.Msg.GetSummary()
+		assert.EqualValues(t, 1, summary.GetIncomingClean()+summary.GetIncomingDirty()+summary.GetIncomingPending()+summary.GetIncomingUnknown())
+	})
+
+	t.Run("status ignores staleness from producers outside scope", func(t *testing.T) {
+		edges, err := edgeRepo.GetAllEdges(ctx)
+		require.NoError(t, err)
+		for _, edge := range edges {
+			edge.Status = models.EdgeStatusClean
+			if edge.FromState == prodNet.GUID {
+				edge.Status = models.EdgeStatusDirty
+			}
+			require.NoError(t, edgeRepo.Update(ctx, &edge))
+		}
+
+		status := func(ctx context.Context, guid string) string {
+			t.Helper()
+			resp, err := h.GetStateStatus(ctx, connect.NewRequest(&statev1.GetStateStatusRequest{
+				State: &statev1.GetStateStatusRequest_Guid{Guid: guid},
+			}))
+			require.NoError(t, err)
+			return resp.Msg.GetStatus()
+		}
+		assert.Equal(t, "stale", status(ctx, devApp.GUID), "no-auth mode sees every producer")
+		assert.Equal(t, "clean", status(devCtx, devApp.GUID))
+		// dev-tail is only reached through prod-svc
+		assert.Equal(t, "potentially-stale", status(ctx, devTail.GUID))
+		assert.Equal(t, "clean", status(devCtx, devTail.GUID))
+	})
+
+	t.Run("topology omits states outside scope", func(t *testing.T) {
+		resp, err := h.GetTopologicalOrder(devCtx, connect.NewRequest(&statev1.GetTopologicalOrderRequest{
+			State: &statev1.GetTopologicalOrderRequest_LogicId{LogicId: devNet.LogicID},
+		}))
+		require.NoError(t, err)
+
+		levels := map[int32][]string{}
+		for _, layer := range resp.Msg.GetLayers() {
+			for _, ref := range layer.GetStates() {
+				levels[layer.GetLevel()] = append(levels[layer.GetLevel()], ref.GetLogicId())
+			}
+		}
+		// prod-svc's layer is dropped entirely; dev-tail keeps its level
+		assert.Equal(t, map[int32][]string{0: {"dev-network"}, 1: {"dev-app"}, 3: {"dev-tail"}}, levels)
+
+		upstream := "upstream"
+		resp, err = h.GetTopologicalOrder(devCtx, connect.NewRequest(&statev1.GetTopologicalOrderRequest{
+			State:     &statev1.GetTopologicalOrderRequest_Guid{Guid: devApp.GUID},
+			Direction: &upstream,
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.GetLayers(), 2)
+		require.Len(t, resp.Msg.GetLayers()[1].GetStates(), 1)
+		assert.Equal(t, devNet.GUID, resp.Msg.GetLayers()[1].GetStates()[0].GetGuid())
+	})
+
+	t.Run("cycles only name states in scope", func(t *testing.T) {
+		depend(devTail, prodNet) // dev-tail → prod-network → dev-app → prod-svc → dev-tail
+
+		_, err := h.GetTopologicalOrder(devCtx, connect.NewRequest(&statev1.GetTopologicalOrderRequest{
+			State: &statev1.GetTopologicalOrderRequest_Guid{Guid: devNet.GUID},
+		}))
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		assert.Contains(t, err.Error(), devApp.GUID)
+		assert.NotContains(t, err.Error(), prodNet.GUID)
+		assert.NotContains(t, err.Error(), prodSvc.GUID)
+	})
+}
+
+func ptrInt32(v int32) *int32 { return &v }
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WithStateWatchHub enables WatchStateChanges (optional dependency).
+func (h *StateServiceHandler) WithStateWatchHub(hub *StateWatchHub) *StateServiceHandler {
+	h.watchHub = hub
+	return h
+}
+
+// WatchStateChanges streams state changes until the client disconnects.
+// Events are filtered by the caller's role scopes as resolved when the stream
+// opens, matched against each state's labels after the change, so a state
+// relabelled out of scope stops being reported.
+func (h *StateServiceHandler) WatchStateChanges(
+	ctx context.Context,
+	req *connect.Request[statev1.WatchStateChangesRequest],
+	stream *connect.ServerStream[statev1.StateChangeEvent],
+) error {
+	if h.watchHub == nil {
+		return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("state change watching is not enabled"))
+	}
+
+	kinds := make(map[string]bool, len(req.Msg.Kinds))
+	for _, kind := range req.Msg.Kinds {
+		switch kind {
+		case StateChangeSerial, StateChangeLabels, StateChangeOutputs, StateChangeStatus:
+			kinds[kind] = true
+		default:
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid change kind %q: must be one of serial, labels, outputs, status", kind))
+		}
+	}
+
+	var guid string
+	if req.Msg.LogicId != nil {
+		states, err := h.service.GetStatesByLogicIDs(ctx, []string{req.Msg.GetLogicId()})
+		if err != nil {
+			return mapServiceError(err)
+		}
+		state, ok := states[req.Msg.GetLogicId()]
+		if !ok {
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("state '%s' not found", req.Msg.GetLogicId()))
+		}
+		if err := h.authorizeStateScope(ctx, state.GUID); err != nil {
+			return err
+		}
+		guid = state.GUID
+	}
+
+	roleScopes, scoped, err := h.callerRoleScopes(ctx)
+	if err != nil {
+		return err
+	}
+	changes, unsubscribe := h.watchHub.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("client fell behind the change stream; reconnect to resume"))
+			}
+			if guid != "" && change.GUID != guid {
+				continue
+			}
+			if len(kinds) > 0 && !kinds[change.Kind] {
+				continue
+			}
+			if scoped && !h.matchesRoleScopes(roleScopes, change.Labels) {
+				continue
+			}
+			if err := stream.Send(stateChangeToProto(change)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func stateChangeToProto(change StateChange) *statev1.StateChangeEvent {
+	event := &statev1.StateChangeEvent{
+		Guid:           change.GUID,
+		LogicId:        change.LogicID,
+		Kind:           change.Kind,
+		Serial:         change.Serial,
+		Labels:         make(map[string]*statev1.LabelValue, len(change.Labels)),
+		ComputedStatus: change.Status,
+		ChangedOutputs: change.ChangedOutputs,
+		DetectedAt:     timestamppb.New(change.DetectedAt),
+	}
+	for key, value := range change.Labels {
+		event.Labels[key] = goValueToProtoLabel(value)
+	}
+	return event
+}
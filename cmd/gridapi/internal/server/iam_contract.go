@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
@@ -17,31 +18,46 @@ import (
 // type safety at compile time.
 type iamAdminService interface {
 	// Authentication and authorization (used by auth handlers)
-	ResolveRoles(ctx context.Context, principalID string, groups []string, isUser bool) ([]string, error)
+	ResolveRoles(ctx context.Context, principalID string, groups []string, claims map[string]any, isUser bool) ([]string, error)
 
 	// Session management
-	CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time) (*models.Session, string, error)
+	CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time, headers http.Header) (*models.Session, string, error)
 	GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error)
 	RevokeSession(ctx context.Context, sessionID string) error
 	ListUserSessions(ctx context.Context, userID string) ([]models.Session, error)
+	ListAllSessions(ctx context.Context, filter iam.SessionFilter) ([]models.Session, error)
+	RevokeSessions(ctx context.Context, filter iam.SessionFilter) (int, error)
+	BumpRevocationEpoch(ctx context.Context, epoch time.Time, setBy string) (time.Time, error)
+	ListAuditLog(ctx context.Context, filter iam.AuditLogFilter) ([]models.AuditLogEntry, error)
+	RecordOwnershipTransfer(ctx context.Context, stateGUID, previousOwner, newOwner string, opErr error)
+
+	// Token introspection
+	IntrospectToken(ctx context.Context, token string) (*iam.TokenIntrospection, error)
+
+	// Authorization preview
+	PreviewAuthorization(ctx context.Context, preview iam.AuthorizationPreview) (*iam.AuthorizationExplanation, error)
+
+	// Access review
+	ExportAccessReview(ctx context.Context) (*iam.AccessReview, error)
 
 	// Service account management
-	CreateServiceAccount(ctx context.Context, name, createdBy string) (*models.ServiceAccount, string, error)
-	ListServiceAccounts(ctx context.Context) ([]*models.ServiceAccount, error)
+	CreateServiceAccount(ctx context.Context, name, createdBy string, roleNames []string) (*models.ServiceAccount, string, []string, error)
+	ListServiceAccounts(ctx context.Context, filter iam.ServiceAccountFilter) ([]*models.ServiceAccount, error)
 	GetServiceAccountByClientID(ctx context.Context, clientID string) (*models.ServiceAccount, error)
 	GetServiceAccountByID(ctx context.Context, saID string) (*models.ServiceAccount, error)
 	RevokeServiceAccount(ctx context.Context, clientID string) error
-	RotateServiceAccountSecret(ctx context.Context, clientID string) (string, time.Time, error)
+	RotateServiceAccountSecret(ctx context.Context, clientID string, overlap time.Duration) (*iam.SecretRotation, error)
+	TestCredentials(ctx context.Context, clientID, clientSecret string) (*iam.CredentialTestResult, error)
 
 	// Role assignment
 	AssignUserRole(ctx context.Context, userID, serviceAccountID, roleID string) error
 	RemoveUserRole(ctx context.Context, userID, serviceAccountID, roleID string) error
-	AssignGroupRole(ctx context.Context, groupName, roleID string) error
+	AssignGroupRole(ctx context.Context, groupName, roleID, condition string) error
 	RemoveGroupRole(ctx context.Context, groupName, roleID string) error
 
 	// Role CRUD
-	CreateRole(ctx context.Context, name, description, scopeExpr string, createConstraints models.CreateConstraints, immutableKeys []string, actions []string) (*models.Role, error)
-	UpdateRole(ctx context.Context, name string, expectedVersion int, description, scopeExpr string, createConstraints models.CreateConstraints, immutableKeys []string, actions []string) (*models.Role, error)
+	CreateRole(ctx context.Context, name, description, scopeExpr string, createConstraints models.CreateConstraints, immutableKeys []string, maxAssignments *int, ownerActions []string, actions []string, defaultLabels bool) (*models.Role, error)
+	UpdateRole(ctx context.Context, name string, expectedVersion int, description, scopeExpr string, createConstraints models.CreateConstraints, immutableKeys []string, maxAssignments *int, ownerActions []string, actions []string, defaultLabels bool) (*models.Role, error)
 	DeleteRole(ctx context.Context, name string) error
 
 	// User management
@@ -49,6 +65,8 @@ type iamAdminService interface {
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserBySubject(ctx context.Context, subject string) (*models.User, error)
 	GetUserByID(ctx context.Context, userID string) (*models.User, error)
+	ChangePassword(ctx context.Context, userID, oldPassword, newPassword string, opts iam.ChangePasswordOptions) error
+	AdminResetPassword(ctx context.Context, userID string) (string, error)
 
 	// Read-only lookups
 	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
@@ -57,13 +75,17 @@ type iamAdminService interface {
 	ListGroupRoles(ctx context.Context, groupName *string) ([]models.GroupRole, error)
 	GetPrincipalRoles(ctx context.Context, principalID, principalType string) ([]string, error)
 	GetRolePermissions(ctx context.Context, roleName string) ([][]string, error)
+	GetEffectivePermissions(ctx context.Context, roles []string) ([]iam.EffectivePermission, error)
 
 	// Authorization
 	Authorize(ctx context.Context, principal *iam.Principal, obj, act string, labels map[string]interface{}) (bool, error)
+	AuthorizeDecision(ctx context.Context, principal *iam.Principal, obj, act string, labels map[string]interface{}) (iam.Decision, error)
+	AuthorizeOwner(ctx context.Context, principal *iam.Principal, act string) (bool, error)
 
 	// Cache management
 	RefreshGroupRoleCache(ctx context.Context) error
 	GetGroupRoleCacheSnapshot() iam.GroupRoleSnapshot
+	AuthzModelVersion(ctx context.Context) (uint64, error)
 }
 
 // Compile-time assertion: iam.Service must implement iamAdminService.
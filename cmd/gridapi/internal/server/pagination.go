@@ -0,0 +1,105 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// MaxListPageSize caps page_size on cursor-paginated list RPCs.
+const MaxListPageSize = 1000
+
+// pageTokenPrefix versions the cursor format so it can change without
+// misreading tokens issued by an older server.
+const pageTokenPrefix = "v1:"
+
+// encodePageToken wraps the key of the last returned row in an opaque cursor.
+func encodePageToken(key string) string {
+	if key == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + key))
+}
+
+// decodePageToken returns the key a cursor resumes after ("" for the first page).
+func decodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid page_token")
+	}
+	key, ok := strings.CutPrefix(string(raw), pageTokenPrefix)
+	if !ok || key == "" {
+		return "", fmt.Errorf("invalid page_token")
+	}
+	return key, nil
+}
+
+// stateCursorKey renders a state's (created_at, guid) keyset position as a
+// page token key.
+func stateCursorKey(createdAt time.Time, guid string) string {
+	return createdAt.UTC().Format(time.RFC3339Nano) + "/" + guid
+}
+
+// parseStateCursor is the inverse of stateCursorKey; "" is the first page.
+func parseStateCursor(key string) (*repository.StateCursor, error) {
+	if key == "" {
+		return nil, nil
+	}
+	ts, guid, ok := strings.Cut(key, "/")
+	if !ok || guid == "" {
+		return nil, fmt.Errorf("invalid page_token")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page_token")
+	}
+	return &repository.StateCursor{CreatedAt: createdAt, GUID: guid}, nil
+}
+
+// collectPage fills one page of up to pageSize items, keyed after the cursor
+// key after. fetch returns rows in key order; visible drops rows the caller
+// may not see. Rows are fetched in batches until the page is full or the rows
+// run out, so filtering never produces short pages mid-listing.
+//
+// The returned cursor is the key of the last returned item, or "" once every
+// row has been scanned.
+func collectPage[T any](
+	pageSize int,
+	after string,
+	fetch func(after string, limit int) ([]T, error),
+	visible func(batch []T) ([]T, error),
+	keyOf func(T) string,
+) ([]T, string, error) {
+	items := make([]T, 0, pageSize)
+	for {
+		batch, err := fetch(after, pageSize)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(batch) == 0 {
+			return items, "", nil
+		}
+
+		kept, err := visible(batch)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, item := range kept {
+			items = append(items, item)
+			if len(items) == pageSize {
+				return items, keyOf(item), nil
+			}
+		}
+
+		if len(batch) < pageSize {
+			return items, "", nil
+		}
+		after = keyOf(batch[len(batch)-1])
+	}
+}
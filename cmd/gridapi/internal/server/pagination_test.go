@@ -0,0 +1,105 @@
+package server
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	assert.Equal(t, "", encodePageToken(""))
+
+	key, err := decodePageToken("")
+	require.NoError(t, err)
+	assert.Equal(t, "", key)
+
+	key, err = decodePageToken(encodePageToken("0192f4a1-7c3e-7000-8000-000000000001"))
+	require.NoError(t, err)
+	assert.Equal(t, "0192f4a1-7c3e-7000-8000-000000000001", key)
+
+	for _, bad := range []string{"not base64!", encodePageToken("x")[:2], "djI6YWJj"} {
+		_, err := decodePageToken(bad)
+		assert.ErrorContains(t, err, "invalid page_token", bad)
+	}
+}
+
+func TestStateCursorRoundTrip(t *testing.T) {
+	cursor, err := parseStateCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+
+	createdAt := time.Date(2026, 3, 4, 5, 6, 7, 891234000, time.FixedZone("x", 3600))
+	cursor, err = parseStateCursor(stateCursorKey(createdAt, "0192f4a1-7c3e-7000-8000-000000000001"))
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, "0192f4a1-7c3e-7000-8000-000000000001", cursor.GUID)
+
+	for _, bad := range []string{"0192f4a1-7c3e-7000-8000-000000000001", "yesterday/abc", "2026-03-04T05:06:07Z/"} {
+		_, err := parseStateCursor(bad)
+		assert.ErrorContains(t, err, "invalid page_token", bad)
+	}
+}
+
+func TestCollectPage(t *testing.T) {
+	rows := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	fetch := func(after string, limit int) ([]int, error) {
+		start := 0
+		if after != "" {
+			n, _ := strconv.Atoi(after)
+			start = n
+		}
+		if start >= len(rows) {
+			return nil, nil
+		}
+		return rows[start:min(start+limit, len(rows))], nil
+	}
+	keyOf := func(n int) string { return strconv.Itoa(n) }
+	evens := func(batch []int) ([]int, error) {
+		kept := []int{}
+		for _, n := range batch {
+			if n%2 == 0 {
+				kept = append(kept, n)
+			}
+		}
+		return kept, nil
+	}
+	all := func(batch []int) ([]int, error) { return batch, nil }
+
+	t.Run("filtered pages are filled from later batches", func(t *testing.T) {
+		page, next, err := collectPage(3, "", fetch, evens, keyOf)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 4, 6}, page)
+		assert.Equal(t, "6", next)
+
+		page, next, err = collectPage(3, next, fetch, evens, keyOf)
+		require.NoError(t, err)
+		assert.Equal(t, []int{8, 10}, page)
+		assert.Equal(t, "", next)
+	})
+
+	t.Run("iterating pages visits every row once", func(t *testing.T) {
+		var got []int
+		next := ""
+		for {
+			page, cursor, err := collectPage(4, next, fetch, all, keyOf)
+			require.NoError(t, err)
+			got = append(got, page...)
+			if cursor == "" {
+				break
+			}
+			next = cursor
+		}
+		assert.Equal(t, rows, got)
+	})
+
+	t.Run("nothing visible returns an empty final page", func(t *testing.T) {
+		none := func(batch []int) ([]int, error) { return nil, nil }
+		page, next, err := collectPage(3, "", fetch, none, keyOf)
+		require.NoError(t, err)
+		assert.Empty(t, page)
+		assert.Equal(t, "", next)
+	})
+}
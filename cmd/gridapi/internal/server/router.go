@@ -26,8 +26,10 @@ type RouterOptions struct {
 	DependencyService   *dependency.Service
 	EdgeUpdater         *EdgeUpdateJob
 	ValidationJob       *SchemaValidationJob
+	StateWatchHub       *StateWatchHub
 	PolicyService       *statepkg.PolicyService
 	Provider            *auth.Provider
+	PasswordPolicy      *auth.PasswordPolicy
 	RelyingParty        *auth.RelyingParty
 	IAMService          iamAdminService // Compile-time verified IAM service contract
 	AuthnDeps           gridmiddleware.AuthnDependencies
@@ -110,9 +112,13 @@ func NewRouter(opts RouterOptions) chi.Router {
 		r.Mount("/", opts.OIDCRouter)
 		if opts.IAMService != nil {
 			r.Post("/auth/login", HandleInternalLogin(opts.IAMService))
+			r.Post("/auth/password", HandleChangePassword(opts.IAMService))
 		} else {
 			log.Println("WARNING: Skipping /auth/login - IAMService not available")
 		}
+		if opts.PasswordPolicy != nil {
+			r.Get("/auth/password-policy", HandlePasswordPolicy(opts.PasswordPolicy))
+		}
 	}
 
 	// External IdP mode: Mount SSO endpoints
@@ -130,9 +136,22 @@ func NewRouter(opts RouterOptions) chi.Router {
 		if opts.IAMService != nil {
 			r.Get("/api/auth/whoami", HandleWhoAmI(opts.IAMService))
 			r.Post("/auth/logout", HandleLogout(opts.IAMService))
+			r.Get("/api/auth/sessions", HandleListMySessions(opts.IAMService))
+			r.Post("/api/auth/sessions/{id}/revoke", HandleRevokeMySession(opts.IAMService))
+			r.Post("/api/auth/sessions/revoke-all-others", HandleRevokeOtherSessions(opts.IAMService))
+			if opts.Provider != nil {
+				r.Post("/auth/device/verify", HandleDeviceVerify(opts.Provider))
+			}
 
 			// Admin endpoints (requires appropriate permissions)
 			r.Post("/admin/cache/refresh", HandleCacheRefresh(opts.IAMService))
+			r.Post("/admin/credentials/test", HandleCredentialTest(opts.IAMService))
+			r.Post("/admin/revocation-epoch", HandleRevocationEpochBump(opts.IAMService))
+			r.Get("/admin/audit-log", HandleAuditLog(opts.IAMService))
+			r.Get("/admin/access-review", HandleAccessReview(opts.IAMService))
+			if opts.Provider != nil {
+				r.Post("/admin/signing-key/rotate", HandleSigningKeyRotate(opts.Provider, opts.IAMService))
+			}
 		} else {
 			log.Println("WARNING: Skipping /api/auth/whoami and /auth/logout - IAMService not available")
 		}
@@ -189,6 +208,9 @@ func MountConnectHandlers(r chi.Router, opts RouterOptions) {
 	if opts.ValidationJob != nil {
 		stateHandler.WithValidationJob(opts.ValidationJob)
 	}
+	if opts.StateWatchHub != nil {
+		stateHandler.WithStateWatchHub(opts.StateWatchHub)
+	}
 	path, handler := statev1connect.NewStateServiceHandler(
 		stateHandler,
 		connect.WithInterceptors(opts.ConnectInterceptors...),
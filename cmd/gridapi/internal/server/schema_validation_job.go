@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
@@ -30,6 +31,14 @@ func NewSchemaValidationJob(outputRepo repository.StateOutputRepository, validat
 	}
 }
 
+// DetachedContext returns a context for validation that continues after the
+// originating request returns. It keeps the request's values (trace span,
+// principal) so the work stays correlated with the RPC, but drops its
+// cancellation and deadline in favour of the job's own timeout.
+func (j *SchemaValidationJob) DetachedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), j.timeout)
+}
+
 // ValidateOutputs validates all outputs for a state
 // Runs SYNCHRONOUSLY in the request path (blocks response by ~10-50ms)
 // This guarantees validation_status is set before EdgeUpdateJob reads it
@@ -51,8 +60,12 @@ func (j *SchemaValidationJob) ValidateOutputs(ctx context.Context, stateGUID str
 		return j.markOutputsAsNotValidated(timeoutCtx, stateGUID, outputs)
 	}
 
-	// Validate outputs that have schemas
-	results, err := j.validator.ValidateOutputs(timeoutCtx, schemas, outputs)
+	// Validate outputs that have schemas, keeping sensitive values out of error messages
+	schemaJSON := make(map[string]string, len(schemas))
+	for key, schema := range schemas {
+		schemaJSON[key] = schema.JSON
+	}
+	results, err := j.validator.ValidateOutputs(timeoutCtx, schemaJSON, outputs, j.sensitiveOutputs(timeoutCtx, stateGUID, outputs))
 	if err != nil {
 		// Log error but don't fail the request
 		fmt.Printf("ValidateOutputs failed for state %s: %v\n", stateGUID, err)
@@ -67,6 +80,7 @@ func (j *SchemaValidationJob) ValidateOutputs(ctx context.Context, stateGUID str
 			result.OutputKey,
 			result.Status,
 			result.ValidationError,
+			schemas[result.OutputKey].Version,
 			result.ValidatedAt,
 		)
 		if err != nil {
@@ -79,6 +93,143 @@ func (j *SchemaValidationJob) ValidateOutputs(ctx context.Context, stateGUID str
 	return j.markUnvalidatedOutputs(timeoutCtx, stateGUID, outputs, schemas)
 }
 
+// OutputTransition records an output whose validation status changed when it
+// was re-validated.
+type OutputTransition struct {
+	StateGUID      string
+	OutputKey      string
+	PreviousStatus string // Empty when the output had not been validated
+	Status         string
+}
+
+// RevalidationSummary reports the outcome of RevalidateSchema.
+type RevalidationSummary struct {
+	Revalidated   int                // Outputs with a value that were validated again
+	BecameInvalid int                // Outputs that moved from valid to invalid
+	BecameValid   int                // Outputs that moved from invalid to valid
+	Transitions   []OutputTransition // Every status change, by state GUID then output key
+}
+
+// OutputValueLoader returns an output's current value, or nil when the output
+// has no value in the state.
+type OutputValueLoader func(ctx context.Context, stateGUID, outputKey string) (any, error)
+
+// StateAuthorizer returns the subset of stateGUIDs the caller may act on.
+type StateAuthorizer func(ctx context.Context, stateGUIDs []string) ([]string, error)
+
+// RevalidateSchema re-runs validation for every output whose schema is
+// exactly schemaJSON, in the states authorized keeps, and reports how their
+// statuses moved. Outputs in other states are neither rewritten nor counted.
+// Outputs without a value (pre-declared schemas) are skipped.
+//
+// Unlike ValidateOutputs this is an explicit operator request, so failures
+// are returned rather than logged.
+func (j *SchemaValidationJob) RevalidateSchema(ctx context.Context, schemaJSON string, loadValue OutputValueLoader, authorized StateAuthorizer) (*RevalidationSummary, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	byState, err := j.outputRepo.GetOutputsBySchema(timeoutCtx, schemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("find outputs sharing schema: %w", err)
+	}
+
+	guids := make([]string, 0, len(byState))
+	for guid := range byState {
+		guids = append(guids, guid)
+	}
+	guids, err = authorized(timeoutCtx, guids)
+	if err != nil {
+		return nil, fmt.Errorf("authorize states sharing schema: %w", err)
+	}
+	sort.Strings(guids)
+
+	summary := &RevalidationSummary{}
+	for _, guid := range guids {
+		values := make(map[string]any)
+		previous := make(map[string]string)
+		versions := make(map[string]*int)
+		for _, out := range byState[guid] {
+			if out.StateSerial == 0 {
+				continue // Pre-declared schema, nothing to validate yet
+			}
+			val, err := loadValue(timeoutCtx, guid, out.Key)
+			if err != nil {
+				return nil, fmt.Errorf("load output %s of state %s: %w", out.Key, guid, err)
+			}
+			if val == nil {
+				continue
+			}
+			values[out.Key] = val
+			versions[out.Key] = out.SchemaVersion
+			if out.ValidationStatus != nil {
+				previous[out.Key] = *out.ValidationStatus
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		schemas := make(map[string]string, len(values))
+		for key := range values {
+			schemas[key] = schemaJSON
+		}
+		results, err := j.validator.ValidateOutputs(timeoutCtx, schemas, values, j.sensitiveOutputs(timeoutCtx, guid, values))
+		if err != nil {
+			return nil, fmt.Errorf("validate outputs of state %s: %w", guid, err)
+		}
+		sort.Slice(results, func(a, b int) bool { return results[a].OutputKey < results[b].OutputKey })
+
+		for _, result := range results {
+			if err := j.outputRepo.UpdateValidationStatus(timeoutCtx, guid, result.OutputKey, result.Status, result.ValidationError, versions[result.OutputKey], result.ValidatedAt); err != nil {
+				return nil, fmt.Errorf("update validation status of output %s in state %s: %w", result.OutputKey, guid, err)
+			}
+			summary.Revalidated++
+
+			before := previous[result.OutputKey]
+			if before == result.Status {
+				continue
+			}
+			summary.Transitions = append(summary.Transitions, OutputTransition{
+				StateGUID:      guid,
+				OutputKey:      result.OutputKey,
+				PreviousStatus: before,
+				Status:         result.Status,
+			})
+			switch {
+			case before == "valid" && result.Status == "invalid":
+				summary.BecameInvalid++
+			case before == "invalid" && result.Status == "valid":
+				summary.BecameValid++
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// sensitiveOutputs returns the keys of the state's outputs flagged sensitive.
+// If the flags cannot be loaded every output is treated as sensitive, so a
+// lookup failure can only hide values, never expose them.
+func (j *SchemaValidationJob) sensitiveOutputs(ctx context.Context, stateGUID string, outputs map[string]interface{}) map[string]bool {
+	sensitive := make(map[string]bool)
+
+	cached, err := j.outputRepo.GetOutputsByState(ctx, stateGUID)
+	if err != nil {
+		fmt.Printf("GetOutputsByState failed for state %s, redacting all validation errors: %v\n", stateGUID, err)
+		for outputKey := range outputs {
+			sensitive[outputKey] = true
+		}
+		return sensitive
+	}
+
+	for _, out := range cached {
+		if out.Sensitive {
+			sensitive[out.Key] = true
+		}
+	}
+	return sensitive
+}
+
 // markOutputsAsNotValidated marks all outputs as "not_validated" when no schemas exist
 func (j *SchemaValidationJob) markOutputsAsNotValidated(ctx context.Context, stateGUID string, outputs map[string]interface{}) error {
 	now := time.Now()
@@ -90,6 +241,7 @@ func (j *SchemaValidationJob) markOutputsAsNotValidated(ctx context.Context, sta
 			outputKey,
 			"not_validated",
 			nil, // No error for not_validated
+			nil, // No schema was applied
 			now,
 		)
 		if err != nil {
@@ -102,7 +254,7 @@ func (j *SchemaValidationJob) markOutputsAsNotValidated(ctx context.Context, sta
 
 // markUnvalidatedOutputs marks outputs without schemas as "not_validated"
 // Outputs that were validated are skipped (already updated in ValidateOutputs)
-func (j *SchemaValidationJob) markUnvalidatedOutputs(ctx context.Context, stateGUID string, outputs map[string]interface{}, schemas map[string]string) error {
+func (j *SchemaValidationJob) markUnvalidatedOutputs(ctx context.Context, stateGUID string, outputs map[string]interface{}, schemas map[string]repository.VersionedSchema) error {
 	now := time.Now()
 
 	for outputKey := range outputs {
@@ -118,6 +270,7 @@ func (j *SchemaValidationJob) markUnvalidatedOutputs(ctx context.Context, stateG
 			outputKey,
 			"not_validated",
 			nil, // No error for not_validated
+			nil, // No schema was applied
 			now,
 		)
 		if err != nil {
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/validation"
+)
+
+type ctxKey struct{}
+
+func TestSchemaValidationJobDetachedContext(t *testing.T) {
+	job := NewSchemaValidationJob(nil, nil, 5*time.Second)
+
+	parent, cancelParent := context.WithTimeout(context.WithValue(context.Background(), ctxKey{}, "span"), time.Millisecond)
+	ctx, cancel := job.DetachedContext(parent)
+	defer cancel()
+
+	cancelParent()
+	<-parent.Done()
+
+	// Request cancellation does not reach the detached context
+	assert.NoError(t, ctx.Err())
+	// Request-scoped values (e.g., trace span) are preserved
+	assert.Equal(t, "span", ctx.Value(ctxKey{}))
+
+	// The job timeout bounds the work instead of the request deadline
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), deadline, time.Second)
+}
+
+func TestSchemaValidationJobRevalidateSchema(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+	stateRepo := repository.NewBunStateRepository(db)
+	outputRepo := repository.NewBunStateOutputRepository(db)
+
+	values := map[string]any{}
+	newState := func(logicID string, vpcID any) string {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: logicID}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		serial := int64(1)
+		if vpcID == nil {
+			serial = 0 // Schema declared before the output exists
+		}
+		require.NoError(t, outputRepo.UpsertOutputs(ctx, state.GUID, serial, []repository.OutputKey{{Key: "vpc_id"}}))
+		values[state.GUID] = vpcID
+		return state.GUID
+	}
+	loadValue := func(ctx context.Context, stateGUID, outputKey string) (any, error) {
+		return values[stateGUID], nil
+	}
+	status := func(guid string) string {
+		outputs, err := outputRepo.GetOutputsByState(ctx, guid)
+		require.NoError(t, err)
+		require.Len(t, outputs, 1)
+		if outputs[0].ValidationStatus == nil {
+			return ""
+		}
+		return *outputs[0].ValidationStatus
+	}
+
+	loose := `{"type":"string","pattern":"^vpc-"}`
+	tight := `{"type":"string","pattern":"^vpc-1"}`
+	network := newState("network", "vpc-123")
+	legacy := newState("legacy", "vpc-456")
+	other := newState("other", "vpc-789")
+	planned := newState("planned", nil)
+	setSchema := func(schemaJSON string, guids ...string) {
+		for _, guid := range guids {
+			require.NoError(t, outputRepo.SetOutputSchema(ctx, guid, "vpc_id", schemaJSON))
+		}
+	}
+	setSchema(loose, network, legacy, planned)
+	setSchema(`{"type":"string"}`, other)
+
+	validator, err := validation.NewSchemaValidator(16)
+	require.NoError(t, err)
+	job := NewSchemaValidationJob(outputRepo, validator, 5*time.Second)
+	allStates := func(ctx context.Context, guids []string) ([]string, error) {
+		return guids, nil
+	}
+
+	// First pass records statuses for every output sharing the schema
+	summary, err := job.RevalidateSchema(ctx, loose, loadValue, allStates)
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Revalidated)
+	assert.Len(t, summary.Transitions, 2)
+	assert.Zero(t, summary.BecameInvalid)
+	assert.Equal(t, "valid", status(legacy))
+	assert.Empty(t, status(other), "outputs with a different schema are not touched")
+	assert.Empty(t, status(planned), "outputs without a value are skipped")
+
+	// Tightening the pattern invalidates the legacy VPC only
+	setSchema(tight, network, legacy)
+	summary, err = job.RevalidateSchema(ctx, tight, loadValue, allStates)
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Revalidated)
+	assert.Equal(t, 1, summary.BecameInvalid)
+	assert.Zero(t, summary.BecameValid)
+	assert.Equal(t, []OutputTransition{{StateGUID: legacy, OutputKey: "vpc_id", PreviousStatus: "valid", Status: "invalid"}}, summary.Transitions)
+	assert.Equal(t, "valid", status(network))
+	assert.Equal(t, "invalid", status(legacy))
+
+	// Loosening it again brings the legacy VPC back
+	setSchema(loose, network, legacy)
+	summary, err = job.RevalidateSchema(ctx, loose, loadValue, allStates)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.BecameValid)
+	assert.Zero(t, summary.BecameInvalid)
+	assert.Equal(t, "valid", status(legacy))
+
+	// States the caller may not write are neither rewritten nor counted
+	setSchema(tight, network, legacy)
+	onlyNetwork := func(ctx context.Context, guids []string) ([]string, error) {
+		return []string{network}, nil
+	}
+	summary, err = job.RevalidateSchema(ctx, tight, loadValue, onlyNetwork)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Revalidated)
+	assert.Zero(t, summary.BecameInvalid)
+	assert.Empty(t, summary.Transitions)
+	assert.Equal(t, "valid", status(legacy), "unauthorized states keep their previous status")
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+	"sync"
+)
+
+// scopeDecisions memoizes whether the caller's role scopes match a state, so
+// a request touching many edges of a hub state evaluates each state's labels
+// once. It lives in the request context (see withScopeDecisions) and must not
+// outlive the request: decisions go stale once labels or role scopes change,
+// which is why long-lived streams don't use it.
+type scopeDecisions struct {
+	mu     sync.Mutex
+	byGUID map[string]bool
+}
+
+type scopeDecisionsKey struct{}
+
+// withScopeDecisions returns ctx carrying an empty decision cache. Handlers
+// that filter many edges install it once at the start of the request.
+func withScopeDecisions(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(scopeDecisionsKey{}).(*scopeDecisions); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, scopeDecisionsKey{}, &scopeDecisions{byGUID: make(map[string]bool)})
+}
+
+// cachedScopeDecision returns the decision stored for guid in this request.
+func cachedScopeDecision(ctx context.Context, guid string) (allowed, ok bool) {
+	decisions, _ := ctx.Value(scopeDecisionsKey{}).(*scopeDecisions)
+	if decisions == nil {
+		return false, false
+	}
+	decisions.mu.Lock()
+	defer decisions.mu.Unlock()
+	allowed, ok = decisions.byGUID[guid]
+	return allowed, ok
+}
+
+// stateMatchesRoleScopes is matchesRoleScopes for a known state, consulting
+// and filling the request's decision cache when one is installed.
+func (h *StateServiceHandler) stateMatchesRoleScopes(ctx context.Context, roleScopes roleScopes, guid string, labels map[string]any) bool {
+	if allowed, ok := cachedScopeDecision(ctx, guid); ok {
+		return allowed
+	}
+	allowed := h.matchesRoleScopes(roleScopes, labels)
+	if decisions, _ := ctx.Value(scopeDecisionsKey{}).(*scopeDecisions); decisions != nil {
+		decisions.mu.Lock()
+		decisions.byGUID[guid] = allowed
+		decisions.mu.Unlock()
+	}
+	return allowed
+}
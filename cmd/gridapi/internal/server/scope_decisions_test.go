@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	statepkg "github.com/terraconstructs/grid/cmd/gridapi/internal/services/state"
+)
+
+func TestFilterEdgesByRoleScopes_DecidesEachStateOncePerRequest(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+	stateRepo := repository.NewBunStateRepository(db)
+	newState := func(logicID, env string) *models.State {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: logicID, Labels: models.LabelMap{"env": env}}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		return state
+	}
+
+	// Hub and spoke: one producer feeding ten dev and ten prod consumers
+	hub := newState("hub", "dev")
+	var edges []models.Edge
+	for i := range 20 {
+		env := "dev"
+		if i%2 == 1 {
+			env = "prod"
+		}
+		spoke := newState(fmt.Sprintf("spoke-%d", i), env)
+		edges = append(edges, models.Edge{ID: int64(i + 1), FromState: hub.GUID, FromOutput: "vpc_id", ToState: spoke.GUID})
+	}
+
+	h := NewStateServiceHandler(statepkg.NewService(stateRepo, "http://localhost"), nil, &config.Config{})
+	h.WithIAMService(&scopedRoleIAM{roles: map[string]*models.Role{
+		"dev-reader": {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+	}})
+	callerCtx := auth.SetUserContext(ctx, auth.AuthenticatedPrincipal{PrincipalID: "user:dev@example.com", Roles: []string{"role:dev-reader"}})
+
+	uncached, err := h.filterEdgesByRoleScopes(callerCtx, edges)
+	require.NoError(t, err)
+	assert.Len(t, uncached, 10)
+
+	requestCtx := withScopeDecisions(callerCtx)
+	filtered, err := h.filterEdgesByRoleScopes(requestCtx, edges)
+	require.NoError(t, err)
+	assert.Equal(t, uncached, filtered, "memoizing must not change the outcome")
+
+	decisions := requestCtx.Value(scopeDecisionsKey{}).(*scopeDecisions)
+	assert.Len(t, decisions.byGUID, 21, "one decision per state")
+
+	// Later batches in the same request reuse the decisions instead of
+	// re-reading labels; a new request sees the relabelled hub
+	relabelled, err := stateRepo.GetByGUID(ctx, hub.GUID)
+	require.NoError(t, err)
+	relabelled.Labels = models.LabelMap{"env": "prod"}
+	require.NoError(t, stateRepo.Update(ctx, relabelled))
+
+	filtered, err = h.filterEdgesByRoleScopes(requestCtx, edges[:4])
+	require.NoError(t, err)
+	assert.Len(t, filtered, 2)
+
+	filtered, err = h.filterEdgesByRoleScopes(withScopeDecisions(callerCtx), edges)
+	require.NoError(t, err)
+	assert.Empty(t, filtered)
+}
+
+func TestCallerRoleScopes_RoleFormats(t *testing.T) {
+	iamSvc := &scopedRoleIAM{roles: map[string]*models.Role{
+		"dev-reader":    {Name: "dev-reader", ScopeExpr: `env == "dev"`},
+		"platform-team": {Name: "platform-team", ScopeExpr: auth.ScopeAll},
+	}}
+	lenient := NewStateServiceHandler(nil, nil, &config.Config{}).WithIAMService(iamSvc)
+	strict := NewStateServiceHandler(nil, nil, &config.Config{StrictRoleParsing: true}).WithIAMService(iamSvc)
+	callerCtx := func(roles ...string) context.Context {
+		return auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "user:dev@example.com", Roles: roles})
+	}
+
+	tests := []struct {
+		name  string
+		roles []string
+		want  [][]string
+	}{
+		{name: "role names from role resolution", roles: []string{"dev-reader", "platform-team"}, want: [][]string{{`env == "dev"`}, {auth.ScopeAll}}},
+		{name: "casbin role identifiers", roles: []string{"role:dev-reader", "role:platform-team"}, want: [][]string{{`env == "dev"`}, {auth.ScopeAll}}},
+		{name: "mixed forms", roles: []string{"dev-reader", "role:platform-team"}, want: [][]string{{`env == "dev"`}, {auth.ScopeAll}}},
+		{name: "unknown role contributes no scope", roles: []string{"dev-reader", "role:ghost"}, want: [][]string{{`env == "dev"`}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, h := range []*StateServiceHandler{lenient, strict} {
+				scopes, scoped, err := h.callerRoleScopes(callerCtx(tt.roles...))
+				require.NoError(t, err)
+				assert.True(t, scoped)
+				assert.Equal(t, tt.want, scopes.allow)
+			}
+		})
+	}
+
+	for _, bad := range []string{"user:dev@example.com", "group:platform", "sa:ci", "role:", ""} {
+		t.Run(fmt.Sprintf("unparseable %q", bad), func(t *testing.T) {
+			ctx := callerCtx("dev-reader", bad)
+
+			// Lenient: the raw value is looked up (and not found)
+			scopes, scoped, err := lenient.callerRoleScopes(ctx)
+			require.NoError(t, err)
+			assert.True(t, scoped)
+			assert.Equal(t, [][]string{{`env == "dev"`}}, scopes.allow)
+
+			// Strict: the request fails closed, even though another role would match
+			_, _, err = strict.callerRoleScopes(ctx)
+			require.Error(t, err)
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+			filtered, err := strict.filterEdgesByRoleScopes(ctx, []models.Edge{{ID: 1, FromState: "a", ToState: "b"}})
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			assert.Nil(t, filtered)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+)
+
+// The self-service session endpoints let a user review where they are
+// signed in and sign out other browsers or devices. They never take a user
+// ID: every lookup is scoped to the authenticated principal, and sessions of
+// other users are reported as not found so their IDs cannot be probed.
+
+// MySessionResponse describes one of the caller's sessions.
+type MySessionResponse struct {
+	ID         string `json:"id"`
+	CreatedAt  int64  `json:"created_at"`
+	LastUsedAt int64  `json:"last_used_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	IPAddress  string `json:"ip_address,omitempty"`
+	Current    bool   `json:"current"` // The session this request was made with
+}
+
+// MySessionsResponse represents the response from GET /api/auth/sessions
+type MySessionsResponse struct {
+	Sessions []MySessionResponse `json:"sessions"`
+}
+
+// RevokeSessionsResponse represents the response from
+// POST /api/auth/sessions/revoke-all-others
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// sessionUser returns the authenticated user behind a self-service session
+// request, writing an error response when there is none.
+func sessionUser(w http.ResponseWriter, r *http.Request) (auth.AuthenticatedPrincipal, bool) {
+	principal, ok := auth.GetUserFromContext(r.Context())
+	if !ok || principal.InternalID == "" {
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return auth.AuthenticatedPrincipal{}, false
+	}
+	if principal.Type == auth.PrincipalTypeServiceAccount {
+		http.Error(w, "Forbidden: sessions are only listed for users", http.StatusForbidden)
+		return auth.AuthenticatedPrincipal{}, false
+	}
+	return principal, true
+}
+
+// HandleListMySessions lists the caller's active sessions, newest first.
+//
+// GET /api/auth/sessions
+// Response: JSON with a sessions array; the session the request was made
+// with is marked current.
+func HandleListMySessions(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := sessionUser(w, r)
+		if !ok {
+			return
+		}
+
+		sessions, err := iamService.ListUserSessions(r.Context(), principal.InternalID)
+		if err != nil {
+			log.Printf("ERROR: Failed to list sessions (user_id=%s): %v", principal.InternalID, err)
+			http.Error(w, "Failed to list sessions", http.StatusInternalServerError)
+			return
+		}
+
+		now := time.Now()
+		resp := MySessionsResponse{Sessions: make([]MySessionResponse, 0, len(sessions))}
+		for _, session := range sessions {
+			if !ownedActiveSession(&session, principal.InternalID, now) {
+				continue
+			}
+			item := MySessionResponse{
+				ID:         session.ID,
+				CreatedAt:  session.CreatedAt.UnixMilli(),
+				LastUsedAt: session.LastUsedAt.UnixMilli(),
+				ExpiresAt:  session.ExpiresAt.UnixMilli(),
+				Current:    session.ID == principal.SessionID,
+			}
+			if session.UserAgent != nil {
+				item.UserAgent = *session.UserAgent
+			}
+			if session.IPAddress != nil {
+				item.IPAddress = *session.IPAddress
+			}
+			resp.Sessions = append(resp.Sessions, item)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleRevokeMySession revokes one of the caller's sessions. Revoking the
+// current session logs the caller out, as POST /auth/logout does.
+//
+// POST /api/auth/sessions/{id}/revoke
+// Response: 204 No Content; 404 when the session is not one of the caller's
+// active sessions.
+func HandleRevokeMySession(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := sessionUser(w, r)
+		if !ok {
+			return
+		}
+		ctx := r.Context()
+		sessionID := chi.URLParam(r, "id")
+
+		session, err := iamService.GetSessionByID(ctx, sessionID)
+		if err != nil || !ownedActiveSession(session, principal.InternalID, time.Now()) {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+
+		if err := iamService.RevokeSession(ctx, session.ID); err != nil {
+			log.Printf("ERROR: Failed to revoke session (user_id=%s, session_id=%s): %v", principal.InternalID, session.ID, err)
+			http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+			return
+		}
+		if session.ID == principal.SessionID {
+			clearSessionCookie(w, r)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleRevokeOtherSessions revokes all of the caller's sessions except the
+// one the request was made with.
+//
+// POST /api/auth/sessions/revoke-all-others
+// Response: JSON with the number of sessions revoked
+func HandleRevokeOtherSessions(iamService iamAdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := sessionUser(w, r)
+		if !ok {
+			return
+		}
+
+		count, err := iamService.RevokeSessions(r.Context(), iam.SessionFilter{
+			UserID:     principal.InternalID,
+			ActiveOnly: true,
+			ExcludeID:  principal.SessionID,
+		})
+		if err != nil {
+			log.Printf("ERROR: Failed to revoke other sessions (user_id=%s): %v", principal.InternalID, err)
+			http.Error(w, "Failed to revoke sessions", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(RevokeSessionsResponse{Revoked: count}); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// ownedActiveSession reports whether session belongs to userID and is
+// neither revoked nor expired.
+func ownedActiveSession(session *models.Session, userID string, now time.Time) bool {
+	if session == nil || session.UserID == nil || *session.UserID != userID {
+		return false
+	}
+	return !session.Revoked && session.ExpiresAt.After(now)
+}
+
+// clearSessionCookie expires the session cookie on the client.
+func clearSessionCookie(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		Secure:   r.URL.Scheme == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
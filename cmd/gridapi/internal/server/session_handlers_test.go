@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/iam"
+)
+
+// sessionsIAMService keeps sessions in memory. Only the methods the session
+// handlers call are implemented; other methods panic via the nil embed.
+type sessionsIAMService struct {
+	iamAdminService
+	sessions map[string]*models.Session
+}
+
+func (s *sessionsIAMService) ListUserSessions(ctx context.Context, userID string) ([]models.Session, error) {
+	var out []models.Session
+	for _, session := range s.sessions {
+		if session.UserID != nil && *session.UserID == userID {
+			out = append(out, *session)
+		}
+	}
+	return out, nil
+}
+
+func (s *sessionsIAMService) GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error) {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (s *sessionsIAMService) RevokeSession(ctx context.Context, sessionID string) error {
+	s.sessions[sessionID].Revoked = true
+	return nil
+}
+
+func (s *sessionsIAMService) RevokeSessions(ctx context.Context, filter iam.SessionFilter) (int, error) {
+	count := 0
+	for _, session := range s.sessions {
+		if *session.UserID == filter.UserID && session.ID != filter.ExcludeID && !session.Revoked {
+			session.Revoked = true
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newSessionsIAMService() *sessionsIAMService {
+	session := func(id, userID string, revoked bool) *models.Session {
+		now := time.Now()
+		return &models.Session{
+			ID:         id,
+			UserID:     &userID,
+			CreatedAt:  now.Add(-time.Hour),
+			LastUsedAt: now,
+			ExpiresAt:  now.Add(time.Hour),
+			Revoked:    revoked,
+		}
+	}
+	return &sessionsIAMService{sessions: map[string]*models.Session{
+		"alice-laptop": session("alice-laptop", "user-alice", false),
+		"alice-phone":  session("alice-phone", "user-alice", false),
+		"alice-old":    session("alice-old", "user-alice", true),
+		"bob-laptop":   session("bob-laptop", "user-bob", false),
+	}}
+}
+
+// sessionsRouter serves the session endpoints as alice, signed in on her laptop.
+func sessionsRouter(svc *sessionsIAMService) http.Handler {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(auth.SetUserContext(req.Context(), auth.AuthenticatedPrincipal{
+				InternalID: "user-alice",
+				SessionID:  "alice-laptop",
+				Type:       auth.PrincipalTypeUser,
+			})))
+		})
+	})
+	r.Get("/api/auth/sessions", HandleListMySessions(svc))
+	r.Post("/api/auth/sessions/{id}/revoke", HandleRevokeMySession(svc))
+	r.Post("/api/auth/sessions/revoke-all-others", HandleRevokeOtherSessions(svc))
+	return r
+}
+
+func TestHandleListMySessions(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	sessionsRouter(newSessionsIAMService()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp MySessionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	current := map[string]bool{}
+	for _, session := range resp.Sessions {
+		current[session.ID] = session.Current
+		assert.NotZero(t, session.CreatedAt)
+		assert.NotZero(t, session.LastUsedAt)
+	}
+	assert.Equal(t, map[string]bool{"alice-laptop": true, "alice-phone": false}, current,
+		"only alice's active sessions are listed")
+}
+
+func TestHandleRevokeMySession(t *testing.T) {
+	t.Parallel()
+
+	revoke := func(svc *sessionsIAMService, id string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		sessionsRouter(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/sessions/"+id+"/revoke", nil))
+		return rec
+	}
+
+	t.Run("other session", func(t *testing.T) {
+		svc := newSessionsIAMService()
+		rec := revoke(svc, "alice-phone")
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		assert.True(t, svc.sessions["alice-phone"].Revoked)
+		assert.Empty(t, rec.Result().Cookies(), "the caller stays signed in")
+	})
+
+	t.Run("another user's session", func(t *testing.T) {
+		svc := newSessionsIAMService()
+		rec := revoke(svc, "bob-laptop")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.False(t, svc.sessions["bob-laptop"].Revoked)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, revoke(newSessionsIAMService(), "nope").Code)
+	})
+
+	t.Run("current session logs out", func(t *testing.T) {
+		svc := newSessionsIAMService()
+		rec := revoke(svc, "alice-laptop")
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		assert.True(t, svc.sessions["alice-laptop"].Revoked)
+
+		cookies := rec.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, auth.SessionCookieName, cookies[0].Name)
+		assert.Empty(t, cookies[0].Value)
+		assert.True(t, cookies[0].Expires.Before(time.Now()), "the session cookie is cleared")
+	})
+}
+
+func TestHandleRevokeOtherSessions(t *testing.T) {
+	t.Parallel()
+
+	svc := newSessionsIAMService()
+	rec := httptest.NewRecorder()
+	sessionsRouter(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/sessions/revoke-all-others", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp RevokeSessionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Revoked)
+	assert.True(t, svc.sessions["alice-phone"].Revoked)
+	assert.False(t, svc.sessions["alice-laptop"].Revoked, "the current session is kept")
+	assert.False(t, svc.sessions["bob-laptop"].Revoked, "other users are untouched")
+}
+
+func TestSessionEndpoints_RequireUser(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	HandleListMySessions(newSessionsIAMService())(rec, httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/sessions/revoke-all-others", nil)
+	req = req.WithContext(auth.SetUserContext(req.Context(), auth.AuthenticatedPrincipal{
+		InternalID: "sa-ci",
+		Type:       auth.PrincipalTypeServiceAccount,
+	}))
+	rec = httptest.NewRecorder()
+	HandleRevokeOtherSessions(newSessionsIAMService())(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
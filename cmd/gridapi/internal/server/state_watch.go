@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/graph"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/tfstate"
+)
+
+// State change kinds reported by WatchStateChanges
+const (
+	StateChangeSerial  = "serial"
+	StateChangeLabels  = "labels"
+	StateChangeOutputs = "outputs"
+	StateChangeStatus  = "status"
+)
+
+// StateChange is one detected change to a state, carrying the state's values
+// after the change.
+type StateChange struct {
+	GUID           string
+	LogicID        string
+	Kind           string
+	Serial         int64
+	Labels         models.LabelMap
+	Status         string
+	ChangedOutputs []string // Set for StateChangeOutputs
+	DetectedAt     time.Time
+}
+
+// StateChangeSource detects state changes. Run blocks until ctx is cancelled
+// and passes each change to emit. PollingStateChangeSource works on every
+// database; a Postgres LISTEN/NOTIFY source can replace it behind this
+// interface.
+type StateChangeSource interface {
+	Run(ctx context.Context, emit func(StateChange)) error
+}
+
+// PollingStateChangeSource detects changes by comparing snapshots of all
+// states taken every interval. A state's content is only reloaded when its
+// updated_at moved, so idle polls cost two list queries.
+type PollingStateChangeSource struct {
+	stateRepo repository.StateRepository
+	edgeRepo  repository.EdgeRepository
+	interval  time.Duration
+}
+
+// NewPollingStateChangeSource creates a polling source.
+func NewPollingStateChangeSource(stateRepo repository.StateRepository, edgeRepo repository.EdgeRepository, interval time.Duration) *PollingStateChangeSource {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	return &PollingStateChangeSource{stateRepo: stateRepo, edgeRepo: edgeRepo, interval: interval}
+}
+
+// stateSnapshot is what the polling source remembers about a state.
+type stateSnapshot struct {
+	updatedAt time.Time
+	serial    int64
+	labels    models.LabelMap
+	outputs   map[string]string // Output key → value fingerprint
+	status    string
+}
+
+// Run takes a baseline snapshot, then reports differences on every tick.
+// Failed polls are logged and retried on the next tick.
+func (p *PollingStateChangeSource) Run(ctx context.Context, emit func(StateChange)) error {
+	snapshots, err := p.poll(ctx, nil, nil)
+	if err != nil {
+		return fmt.Errorf("take baseline snapshot: %w", err)
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			next, err := p.poll(ctx, snapshots, emit)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				log.Printf("WARNING: state watch poll failed: %v", err)
+				continue
+			}
+			snapshots = next
+		}
+	}
+}
+
+// poll snapshots every state and emits how each differs from prev. With a nil
+// emit it only builds the baseline. New states get a snapshot but no events;
+// their first upload is reported as a serial change.
+func (p *PollingStateChangeSource) poll(ctx context.Context, prev map[string]*stateSnapshot, emit func(StateChange)) (map[string]*stateSnapshot, error) {
+	states, err := p.stateRepo.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list states: %w", err)
+	}
+	edges, err := p.edgeRepo.GetAllEdges(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get all edges: %w", err)
+	}
+	statuses := graph.ComputeStateSummaries(edges)
+	now := time.Now().UTC()
+
+	next := make(map[string]*stateSnapshot, len(states))
+	for _, state := range states {
+		snap := &stateSnapshot{updatedAt: state.UpdatedAt, labels: state.Labels, status: "clean"}
+		if status, ok := statuses[state.GUID]; ok {
+			snap.status = status
+		}
+
+		old := prev[state.GUID]
+		if old != nil && old.updatedAt.Equal(state.UpdatedAt) {
+			snap.serial, snap.outputs = old.serial, old.outputs
+		} else if err := p.loadContent(ctx, state.GUID, snap); err != nil {
+			log.Printf("WARNING: state watch: %v", err)
+			if old != nil {
+				snap.serial, snap.outputs = old.serial, old.outputs
+			}
+		}
+		next[state.GUID] = snap
+
+		if old == nil || emit == nil {
+			continue
+		}
+		change := StateChange{
+			GUID:       state.GUID,
+			LogicID:    state.LogicID,
+			Serial:     snap.serial,
+			Labels:     snap.labels,
+			Status:     snap.status,
+			DetectedAt: now,
+		}
+		if snap.serial != old.serial {
+			change.Kind = StateChangeSerial
+			emit(change)
+		}
+		if changed := changedOutputs(old.outputs, snap.outputs); len(changed) > 0 {
+			outputsChange := change
+			outputsChange.Kind, outputsChange.ChangedOutputs = StateChangeOutputs, changed
+			emit(outputsChange)
+		}
+		if !maps.Equal(old.labels, snap.labels) {
+			change.Kind = StateChangeLabels
+			emit(change)
+		}
+		if snap.status != old.status {
+			change.Kind = StateChangeStatus
+			emit(change)
+		}
+	}
+	return next, nil
+}
+
+// loadContent fills in the serial and output fingerprints from the state's
+// Terraform JSON.
+func (p *PollingStateChangeSource) loadContent(ctx context.Context, guid string, snap *stateSnapshot) error {
+	state, err := p.stateRepo.GetByGUID(ctx, guid)
+	if err != nil {
+		return fmt.Errorf("load state %s: %w", guid, err)
+	}
+	parsed, err := tfstate.ParseState(state.StateContent)
+	if err != nil {
+		return fmt.Errorf("parse state %s: %w", guid, err)
+	}
+	snap.serial = parsed.Serial
+	snap.outputs = make(map[string]string, len(parsed.Values))
+	for key, value := range parsed.Values {
+		snap.outputs[key] = tfstate.ComputeFingerprint(value)
+	}
+	return nil
+}
+
+// changedOutputs returns the sorted keys whose fingerprint differs between
+// old and new, including added and removed outputs.
+func changedOutputs(old, new map[string]string) []string {
+	var changed []string
+	for key, fingerprint := range new {
+		if previous, ok := old[key]; !ok || previous != fingerprint {
+			changed = append(changed, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// stateWatchBuffer is how many changes a subscriber may fall behind before it
+// is disconnected.
+const stateWatchBuffer = 256
+
+// StateWatchHub fans the changes of one StateChangeSource out to every
+// WatchStateChanges stream. The source only runs while someone is subscribed.
+type StateWatchHub struct {
+	source StateChangeSource
+
+	mu          sync.Mutex
+	subscribers map[chan StateChange]struct{}
+	cancel      context.CancelFunc
+	run         int // Incremented per source start, to tell runs apart
+}
+
+// NewStateWatchHub creates a hub for the given source.
+func NewStateWatchHub(source StateChangeSource) *StateWatchHub {
+	return &StateWatchHub{source: source, subscribers: make(map[chan StateChange]struct{})}
+}
+
+// Subscribe returns a channel of changes and a function ending the
+// subscription. The channel is closed on unsubscribe, or early when the
+// subscriber falls too far behind.
+func (h *StateWatchHub) Subscribe() (<-chan StateChange, func()) {
+	ch := make(chan StateChange, stateWatchBuffer)
+
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	if h.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		h.run++
+		go h.runSource(ctx, h.run)
+	}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.removeLocked(ch)
+	}
+}
+
+// runSource runs the source until it is cancelled. If it fails instead, the
+// current subscribers are disconnected so their clients can retry.
+func (h *StateWatchHub) runSource(ctx context.Context, run int) {
+	err := h.source.Run(ctx, h.publish)
+	if err == nil {
+		return
+	}
+	log.Printf("WARNING: state change source stopped: %v", err)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.run != run || h.cancel == nil {
+		return
+	}
+	for ch := range h.subscribers {
+		h.removeLocked(ch)
+	}
+}
+
+func (h *StateWatchHub) publish(change StateChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- change:
+		default:
+			log.Printf("WARNING: state watch subscriber fell behind, disconnecting")
+			h.removeLocked(ch)
+		}
+	}
+}
+
+// removeLocked drops a subscriber and stops the source after the last one.
+func (h *StateWatchHub) removeLocked(ch chan StateChange) {
+	if _, ok := h.subscribers[ch]; !ok {
+		return
+	}
+	delete(h.subscribers, ch)
+	close(ch)
+	if len(h.subscribers) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+func TestPollingStateChangeSource(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	edgeRepo := repository.NewBunEdgeRepository(db)
+	newState := func(logicID string) *models.State {
+		state := &models.State{
+			GUID:         uuid.Must(uuid.NewV7()).String(),
+			LogicID:      logicID,
+			Labels:       models.LabelMap{"env": "dev"},
+			StateContent: []byte(`{"version":4,"serial":1,"outputs":{"vpc_id":{"value":"vpc-1"}}}`),
+		}
+		require.NoError(t, stateRepo.Create(ctx, state))
+		return state
+	}
+	network := newState("network")
+	app := newState("app")
+
+	source := NewPollingStateChangeSource(stateRepo, edgeRepo, time.Second)
+	var changes []StateChange
+	emit := func(change StateChange) { changes = append(changes, change) }
+
+	snapshots, err := source.poll(ctx, nil, nil)
+	require.NoError(t, err)
+	snapshots, err = source.poll(ctx, snapshots, emit)
+	require.NoError(t, err)
+	assert.Empty(t, changes, "nothing changed since the baseline")
+
+	// A new serial with a changed output, a relabel and a dirty edge
+	time.Sleep(10 * time.Millisecond) // updated_at must move
+	require.NoError(t, stateRepo.UpdateContentAndUpsertOutputs(ctx, network.GUID,
+		[]byte(`{"version":4,"serial":2,"outputs":{"vpc_id":{"value":"vpc-2"},"subnet":{"value":"a"}}}`), "", 2, nil))
+	require.NoError(t, edgeRepo.Create(ctx, &models.Edge{
+		FromState: network.GUID, FromOutput: "vpc_id", ToState: app.GUID, ToInputName: "vpc_id", Status: models.EdgeStatusDirty,
+	}))
+	relabelled, err := stateRepo.GetByGUID(ctx, app.GUID)
+	require.NoError(t, err)
+	relabelled.Labels = models.LabelMap{"env": "prod"}
+	require.NoError(t, stateRepo.Update(ctx, relabelled))
+
+	_, err = source.poll(ctx, snapshots, emit)
+	require.NoError(t, err)
+
+	kinds := make(map[string][]string)
+	for _, change := range changes {
+		kinds[change.LogicID] = append(kinds[change.LogicID], change.Kind)
+	}
+	assert.Equal(t, []string{StateChangeSerial, StateChangeOutputs}, kinds["network"])
+	assert.ElementsMatch(t, []string{StateChangeLabels, StateChangeStatus}, kinds["app"])
+
+	for _, change := range changes {
+		switch change.Kind {
+		case StateChangeSerial:
+			assert.Equal(t, int64(2), change.Serial)
+		case StateChangeOutputs:
+			assert.Equal(t, []string{"subnet", "vpc_id"}, change.ChangedOutputs)
+		case StateChangeLabels:
+			assert.Equal(t, "prod", change.Labels["env"])
+		case StateChangeStatus:
+			assert.Equal(t, "stale", change.Status)
+		}
+	}
+}
+
+// blockingSource hands its emit function to the test and runs until cancelled.
+type blockingSource struct {
+	emit    chan func(StateChange)
+	stopped chan struct{}
+}
+
+func (s *blockingSource) Run(ctx context.Context, emit func(StateChange)) error {
+	defer close(s.stopped)
+	s.emit <- emit
+	<-ctx.Done()
+	return nil
+}
+
+func TestStateWatchHub(t *testing.T) {
+	source := &blockingSource{emit: make(chan func(StateChange), 1), stopped: make(chan struct{})}
+	hub := NewStateWatchHub(source)
+
+	first, unsubscribeFirst := hub.Subscribe()
+	second, unsubscribeSecond := hub.Subscribe()
+	emit := <-source.emit
+
+	emit(StateChange{LogicID: "network", Kind: StateChangeSerial})
+	assert.Equal(t, "network", (<-first).LogicID)
+	assert.Equal(t, "network", (<-second).LogicID)
+
+	// A subscriber that stops reading is disconnected once its buffer is full
+	unsubscribeFirst()
+	for i := 0; i <= stateWatchBuffer; i++ {
+		emit(StateChange{LogicID: "app", Kind: StateChangeLabels})
+	}
+	received := 0
+	for range second {
+		received++
+	}
+	assert.Equal(t, stateWatchBuffer, received)
+	unsubscribeSecond() // No-op after the disconnect
+
+	// The source stops with the last subscriber
+	select {
+	case <-source.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("source still running without subscribers")
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -121,6 +122,8 @@ func (h *TerraformHandlers) UpdateState(w http.ResponseWriter, r *http.Request)
 			http.Error(w, fmt.Sprintf("state not found: %s", guid), http.StatusNotFound)
 		} else if isLockedError(err) {
 			http.Error(w, fmt.Sprintf("state is locked: %v", err), http.StatusLocked)
+		} else if errors.Is(err, statepkg.ErrTooManyOutputs) {
+			http.Error(w, fmt.Sprintf("state rejected: %v", err), http.StatusRequestEntityTooLarge)
 		} else {
 			http.Error(w, fmt.Sprintf("failed to update state: %v", err), http.StatusInternalServerError)
 		}
@@ -147,6 +150,9 @@ func (h *TerraformHandlers) UpdateState(w http.ResponseWriter, r *http.Request)
 	if result.Summary.SizeBytes > models.StateSizeWarningThreshold {
 		w.Header().Set("X-Grid-State-Size-Warning", fmt.Sprintf("State size (%d bytes) exceeds recommended threshold (%d bytes)", result.Summary.SizeBytes, models.StateSizeWarningThreshold))
 	}
+	if result.OutputsWarning != "" {
+		w.Header().Set("X-Grid-Outputs-Warning", result.OutputsWarning)
+	}
 
 	w.WriteHeader(http.StatusOK)
 }
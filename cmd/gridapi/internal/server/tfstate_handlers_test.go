@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -245,6 +246,39 @@ func TestUpdateState(t *testing.T) {
 			},
 			expectedStatus: http.StatusLocked,
 		},
+		{
+			name: "too many outputs",
+			guid: "huge-guid",
+			body: `{"version": 4}`,
+			mockService: &mockStateService{
+				getByGUIDFunc: func(ctx context.Context, guid string) (*models.State, error) {
+					return &models.State{GUID: guid}, nil
+				},
+				updateContentFunc: func(ctx context.Context, guid string, content []byte, lockID string) (*statepkg.StateUpdateResult, error) {
+					return nil, fmt.Errorf("%w: state %s declares 20001 outputs, the limit is 20000", statepkg.ErrTooManyOutputs, guid)
+				},
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "success with outputs warning",
+			guid: "busy-guid",
+			body: `{"version": 4}`,
+			mockService: &mockStateService{
+				getByGUIDFunc: func(ctx context.Context, guid string) (*models.State, error) {
+					return &models.State{GUID: guid}, nil
+				},
+				updateContentFunc: func(ctx context.Context, guid string, content []byte, lockID string) (*statepkg.StateUpdateResult, error) {
+					return &statepkg.StateUpdateResult{
+						Summary:        &statepkg.StateSummary{SizeBytes: 100},
+						OutputsWarning: "State declares 9500 outputs, approaching the limit of 10000",
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			checkHeader:    true,
+			headerName:     "X-Grid-Outputs-Warning",
+		},
 	}
 
 	for _, tt := range tests {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/dependency"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/tfstate"
 )
 
@@ -70,63 +71,11 @@ func (j *EdgeUpdateJob) updateOutgoingEdges(ctx context.Context, stateGUID strin
 
 	for _, edgeVal := range edgesWithValidation {
 		edge := edgeVal.Edge
-
-		// Check if output still exists in tfstate
-		outputValue, outputExists := outputs[edge.FromOutput]
-
-		if !outputExists {
-			// Output removed from tfstate - mark as missing-output (retain edge)
-			if edge.Status != models.EdgeStatusMissingOutput {
-				edge.Status = models.EdgeStatusMissingOutput
-				if err := j.edgeRepo.Update(ctx, &edge); err != nil {
-					log.Printf("EdgeUpdateJob: failed to mark edge %d as missing-output: %v", edge.ID, err)
-				}
-			}
+		if !dependency.RecomputeOutgoingEdge(&edge, edgeVal.ValidationStatus, outputs) {
 			continue
 		}
-
-		// Compute new fingerprint
-		newDigest := tfstate.ComputeFingerprint(outputValue)
-		if newDigest == "" {
-			continue // Skip if fingerprint computation failed
-		}
-
-		// Check if this is a mock edge transitioning to real output
-		if edge.Status == models.EdgeStatusMock {
-			// Transition from mock to real output
-			edge.Status = models.EdgeStatusPending
-			edge.MockValue = nil
-			edge.InDigest = newDigest
-			now := time.Now()
-			edge.LastInAt = &now
-
-			if err := j.edgeRepo.Update(ctx, &edge); err != nil {
-				log.Printf("EdgeUpdateJob: failed to transition mock edge %d: %v", edge.ID, err)
-			}
-			continue
-		}
-
-		// Compute new status using composite model (drift × validation)
-		newStatus := deriveEdgeStatusWithValidation(newDigest, edge.OutDigest, edgeVal.ValidationStatus, true)
-
-		// Check if producer output changed OR validation status changed
-		digestChanged := (edge.InDigest != newDigest)
-		statusChanged := (edge.Status != newStatus)
-
-		if digestChanged || statusChanged {
-			// Update digest if it changed
-			if digestChanged {
-				edge.InDigest = newDigest
-				now := time.Now()
-				edge.LastInAt = &now
-			}
-
-			// Always update status to new computed value
-			edge.Status = newStatus
-
-			if err := j.edgeRepo.Update(ctx, &edge); err != nil {
-				log.Printf("EdgeUpdateJob: failed to update edge %d: %v", edge.ID, err)
-			}
+		if err := j.edgeRepo.Update(ctx, &edge); err != nil {
+			log.Printf("EdgeUpdateJob: failed to update edge %d: %v", edge.ID, err)
 		}
 	}
 
@@ -164,48 +113,3 @@ func (j *EdgeUpdateJob) updateIncomingEdges(ctx context.Context, stateGUID strin
 
 	return nil
 }
-
-// deriveEdgeStatusWithValidation computes edge status using composite model.
-// Combines two orthogonal dimensions: drift (in_digest vs out_digest) and validation (schema compliance).
-// Parameters:
-//   - inDigest: producer's current output fingerprint
-//   - outDigest: consumer's observed fingerprint
-//   - validationStatus: validation_status from state_outputs (nil if no schema or not validated)
-//   - outputExists: whether the output key exists in producer's tfstate
-//
-// Returns:
-//   - missing-output: if output doesn't exist (highest priority)
-//   - clean: in_digest == out_digest AND (valid OR no schema)
-//   - clean-invalid: in_digest == out_digest AND invalid
-//   - dirty: in_digest != out_digest AND (valid OR no schema)
-//   - dirty-invalid: in_digest != out_digest AND invalid
-//   - pending: no in_digest yet
-func deriveEdgeStatusWithValidation(inDigest, outDigest string, validationStatus *string, outputExists bool) models.EdgeStatus {
-	// Priority 1: Output existence (overrides everything)
-	if !outputExists {
-		return models.EdgeStatusMissingOutput
-	}
-
-	// Priority 2: Pending state (no producer data yet)
-	if inDigest == "" {
-		return models.EdgeStatusPending
-	}
-
-	// Compute drift dimension
-	isDirty := (outDigest == "" || inDigest != outDigest)
-
-	// Compute validation dimension
-	isInvalid := (validationStatus != nil && *validationStatus == "invalid")
-
-	// Composite matrix: drift × validation
-	if isDirty && isInvalid {
-		return models.EdgeStatusDirtyInvalid
-	}
-	if isDirty {
-		return models.EdgeStatusDirty
-	}
-	if isInvalid {
-		return models.EdgeStatusCleanInvalid
-	}
-	return models.EdgeStatusClean
-}
@@ -0,0 +1,185 @@
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/tfstate"
+)
+
+// EdgeStatusChange is one edge whose status was re-derived by
+// RecomputeDependencyStatus.
+type EdgeStatusChange struct {
+	Edge           models.Edge // After the recompute
+	PreviousStatus models.EdgeStatus
+}
+
+// RecomputeResult reports what RecomputeDependencyStatus did.
+type RecomputeResult struct {
+	ProducerGUID    string
+	ProducerLogicID string
+	Edges           []models.Edge      // Every recomputed edge, after the recompute
+	Changed         []EdgeStatusChange // Edges whose status or digest was persisted
+	Skipped         int                // Edges dropped by the filter
+}
+
+// RecomputeDependencyStatus re-derives the status of the producer's outgoing
+// edges from its current output digests and persists the edges that changed,
+// the same way a tfstate upload does. A producer without state content is left
+// alone, so its edges stay pending.
+//
+// filter, when set, selects the edges that may be recomputed (e.g. those the
+// caller can see both ends of); the rest are counted in Skipped.
+func (s *Service) RecomputeDependencyStatus(ctx context.Context, logicID, guid string, filter func([]models.Edge) ([]models.Edge, error)) (*RecomputeResult, error) {
+	producer, err := s.resolveState(ctx, logicID, guid)
+	if err != nil {
+		return nil, fmt.Errorf("resolve producer state: %w", err)
+	}
+
+	edgesWithValidation, err := s.edgeRepo.GetOutgoingEdgesWithValidation(ctx, producer.GUID)
+	if err != nil {
+		return nil, fmt.Errorf("get outgoing edges with validation: %w", err)
+	}
+
+	allowed := make(map[int64]bool, len(edgesWithValidation))
+	edges := make([]models.Edge, 0, len(edgesWithValidation))
+	for _, edgeVal := range edgesWithValidation {
+		edges = append(edges, edgeVal.Edge)
+	}
+	if filter != nil {
+		if edges, err = filter(edges); err != nil {
+			return nil, err
+		}
+	}
+	for _, edge := range edges {
+		allowed[edge.ID] = true
+	}
+
+	result := &RecomputeResult{
+		ProducerGUID:    producer.GUID,
+		ProducerLogicID: producer.LogicID,
+		Edges:           []models.Edge{},
+		Changed:         []EdgeStatusChange{},
+		Skipped:         len(edgesWithValidation) - len(allowed),
+	}
+	if len(producer.StateContent) == 0 {
+		for _, edgeVal := range edgesWithValidation {
+			if allowed[edgeVal.Edge.ID] {
+				result.Edges = append(result.Edges, edgeVal.Edge)
+			}
+		}
+		return result, nil
+	}
+
+	outputs, err := tfstate.ParseOutputs(producer.StateContent)
+	if err != nil {
+		return nil, fmt.Errorf("parse producer outputs: %w", err)
+	}
+
+	for _, edgeVal := range edgesWithValidation {
+		edge := edgeVal.Edge
+		if !allowed[edge.ID] {
+			continue
+		}
+		previous := edge.Status
+		if RecomputeOutgoingEdge(&edge, edgeVal.ValidationStatus, outputs) {
+			if err := s.edgeRepo.Update(ctx, &edge); err != nil {
+				return nil, fmt.Errorf("update edge %d: %w", edge.ID, err)
+			}
+			result.Changed = append(result.Changed, EdgeStatusChange{Edge: edge, PreviousStatus: previous})
+		}
+		result.Edges = append(result.Edges, edge)
+	}
+
+	return result, nil
+}
+
+// RecomputeOutgoingEdge updates a producer's outgoing edge in place from the
+// producer's current outputs and reports whether it changed:
+//   - a removed output marks the edge missing-output (the edge is retained)
+//   - a mock edge whose output now exists becomes pending with the real digest
+//   - otherwise the digest is refreshed and the status re-derived
+func RecomputeOutgoingEdge(edge *models.Edge, validationStatus *string, outputs map[string]interface{}) bool {
+	outputValue, outputExists := outputs[edge.FromOutput]
+	if !outputExists {
+		if edge.Status == models.EdgeStatusMissingOutput {
+			return false
+		}
+		edge.Status = models.EdgeStatusMissingOutput
+		return true
+	}
+
+	newDigest := tfstate.ComputeFingerprint(outputValue)
+	if newDigest == "" {
+		return false // Fingerprint computation failed
+	}
+
+	now := time.Now()
+	if edge.Status == models.EdgeStatusMock {
+		edge.Status = models.EdgeStatusPending
+		edge.MockValue = nil
+		edge.InDigest = newDigest
+		edge.LastInAt = &now
+		return true
+	}
+
+	// Composite model: drift × validation
+	newStatus := DeriveEdgeStatus(newDigest, edge.OutDigest, validationStatus, true)
+	digestChanged := edge.InDigest != newDigest
+	if !digestChanged && edge.Status == newStatus {
+		return false
+	}
+	if digestChanged {
+		edge.InDigest = newDigest
+		edge.LastInAt = &now
+	}
+	edge.Status = newStatus
+	return true
+}
+
+// DeriveEdgeStatus computes edge status using composite model.
+// Combines two orthogonal dimensions: drift (in_digest vs out_digest) and validation (schema compliance).
+// Parameters:
+//   - inDigest: producer's current output fingerprint
+//   - outDigest: consumer's observed fingerprint
+//   - validationStatus: validation_status from state_outputs (nil if no schema or not validated)
+//   - outputExists: whether the output key exists in producer's tfstate
+//
+// Returns:
+//   - missing-output: if output doesn't exist (highest priority)
+//   - clean: in_digest == out_digest AND (valid OR no schema)
+//   - clean-invalid: in_digest == out_digest AND invalid
+//   - dirty: in_digest != out_digest AND (valid OR no schema)
+//   - dirty-invalid: in_digest != out_digest AND invalid
+//   - pending: no in_digest yet
+func DeriveEdgeStatus(inDigest, outDigest string, validationStatus *string, outputExists bool) models.EdgeStatus {
+	// Priority 1: Output existence (overrides everything)
+	if !outputExists {
+		return models.EdgeStatusMissingOutput
+	}
+
+	// Priority 2: Pending state (no producer data yet)
+	if inDigest == "" {
+		return models.EdgeStatusPending
+	}
+
+	// Compute drift dimension
+	isDirty := (outDigest == "" || inDigest != outDigest)
+
+	// Compute validation dimension
+	isInvalid := (validationStatus != nil && *validationStatus == "invalid")
+
+	// Composite matrix: drift × validation
+	if isDirty && isInvalid {
+		return models.EdgeStatusDirtyInvalid
+	}
+	if isDirty {
+		return models.EdgeStatusDirty
+	}
+	if isInvalid {
+		return models.EdgeStatusCleanInvalid
+	}
+	return models.EdgeStatusClean
+}
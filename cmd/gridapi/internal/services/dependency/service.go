@@ -2,8 +2,12 @@ package dependency
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,13 +15,19 @@ import (
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/graph"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/tfstate"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/services/validation"
 )
 
+// ErrInvalidMockValue is returned by AddDependency when the mock value is not
+// JSON or does not conform to the producer output's schema.
+var ErrInvalidMockValue = errors.New("invalid mock value")
+
 // Service handles dependency management operations
 type Service struct {
 	edgeRepo   repository.EdgeRepository
 	stateRepo  repository.StateRepository
 	outputRepo repository.StateOutputRepository
+	validator  validation.Validator
 }
 
 // NewService creates a new dependency service
@@ -34,6 +44,13 @@ func (s *Service) WithOutputRepository(outputRepo repository.StateOutputReposito
 	return s
 }
 
+// WithValidator adds the schema validator used to check mock values (optional,
+// requires the output repository)
+func (s *Service) WithValidator(validator validation.Validator) *Service {
+	s.validator = validator
+	return s
+}
+
 // AddDependencyRequest represents a request to add a dependency
 type AddDependencyRequest struct {
 	FromLogicID   string
@@ -59,6 +76,12 @@ func (s *Service) AddDependency(ctx context.Context, req *AddDependencyRequest)
 		return nil, false, fmt.Errorf("resolve to state: %w", err)
 	}
 
+	if req.MockValueJSON != "" {
+		if err := s.validateMockValue(ctx, fromState.GUID, req.FromOutput, req.MockValueJSON); err != nil {
+			return nil, false, err
+		}
+	}
+
 	// Generate default to_input_name if not provided
 	toInputName := req.ToInputName
 	if toInputName == "" {
@@ -120,6 +143,41 @@ func (s *Service) AddDependency(ctx context.Context, req *AddDependencyRequest)
 	return edge, false, nil
 }
 
+// validateMockValue checks a mock value against the schema of the producer
+// output it stands in for, so the edge can't carry a value the real output
+// would never match. Outputs without a schema accept any mock value.
+func (s *Service) validateMockValue(ctx context.Context, producerGUID, outputKey, mockValueJSON string) error {
+	if s.outputRepo == nil || s.validator == nil {
+		return nil
+	}
+	schemaJSON, err := s.outputRepo.GetOutputSchema(ctx, producerGUID, outputKey)
+	if err != nil {
+		return fmt.Errorf("get output schema: %w", err)
+	}
+	if schemaJSON == "" {
+		return nil
+	}
+
+	var mockValue any
+	if err := json.Unmarshal([]byte(mockValueJSON), &mockValue); err != nil {
+		return fmt.Errorf("%w: not valid JSON: %v", ErrInvalidMockValue, err)
+	}
+	results, err := s.validator.ValidateOutputs(ctx, map[string]string{outputKey: schemaJSON}, map[string]any{outputKey: mockValue}, nil)
+	if err != nil {
+		return fmt.Errorf("validate mock value: %w", err)
+	}
+	for _, result := range results {
+		switch result.Status {
+		case "invalid":
+			return fmt.Errorf("%w: does not conform to the schema of output %q: %s", ErrInvalidMockValue, outputKey, *result.ValidationError)
+		case "error":
+			// A broken schema is the producer's problem; don't block the edge on it
+			log.Printf("WARNING: could not validate mock value for output %q of state %s: %s", outputKey, producerGUID, *result.ValidationError)
+		}
+	}
+	return nil
+}
+
 // RemoveDependency deletes an edge by ID
 func (s *Service) RemoveDependency(ctx context.Context, edgeID int64) error {
 	return s.edgeRepo.Delete(ctx, edgeID)
@@ -155,6 +213,11 @@ func (s *Service) ListAllEdges(ctx context.Context) ([]models.Edge, error) {
 	return s.edgeRepo.GetAllEdges(ctx)
 }
 
+// ListEdgesAfter returns up to limit edges ordered by ID, starting after afterID
+func (s *Service) ListEdgesAfter(ctx context.Context, afterID int64, limit int) ([]models.Edge, error) {
+	return s.edgeRepo.ListAfter(ctx, afterID, limit)
+}
+
 // GetTopologicalOrder computes layered ordering
 func (s *Service) GetTopologicalOrder(ctx context.Context, logicID, guid, direction string) ([]graph.Layer, error) {
 	state, err := s.resolveState(ctx, logicID, guid)
@@ -180,29 +243,80 @@ func (s *Service) GetStateStatus(ctx context.Context, logicID, guid string) (*gr
 	return graph.ComputeStateStatus(ctx, s.edgeRepo, s.stateRepo, state.GUID)
 }
 
-// GetDependencyGraph returns graph data for HCL generation
-func (s *Service) GetDependencyGraph(ctx context.Context, logicID, guid string) (*DependencyGraph, error) {
+// GetStateStatusSummary computes just the status string of a state from its
+// already-fetched incoming edges (see graph.ComputeStateSummaryFromIncoming)
+func (s *Service) GetStateStatusSummary(ctx context.Context, stateGUID string, incoming []models.Edge) (string, error) {
+	return graph.ComputeStateSummaryFromIncoming(ctx, s.edgeRepo, stateGUID, incoming)
+}
+
+// Depth limits for GetDependencyGraph
+const (
+	DefaultDependencyGraphDepth = 1  // Direct producers only
+	MaxDependencyGraphDepth     = 32 // Larger requests are capped
+)
+
+// GetDependencyGraph returns graph data for HCL generation: the consumer's
+// producers up to maxDepth hops away (DefaultDependencyGraphDepth when not
+// positive, capped at MaxDependencyGraphDepth) and the edges between them.
+// Each state is visited once, so a cyclic graph cannot loop the traversal;
+// edges closing a cycle are reported in CycleEdgeIDs.
+func (s *Service) GetDependencyGraph(ctx context.Context, logicID, guid string, maxDepth int) (*DependencyGraph, error) {
 	consumerState, err := s.resolveState(ctx, logicID, guid)
 	if err != nil {
 		return nil, fmt.Errorf("resolve consumer state: %w", err)
 	}
 
-	// Get incoming edges
-	edges, err := s.edgeRepo.GetIncomingEdges(ctx, consumerState.GUID)
-	if err != nil {
-		return nil, fmt.Errorf("get incoming edges: %w", err)
+	switch {
+	case maxDepth <= 0:
+		maxDepth = DefaultDependencyGraphDepth
+	case maxDepth > MaxDependencyGraphDepth:
+		maxDepth = MaxDependencyGraphDepth
 	}
 
-	// Get unique producer GUIDs
-	producerGUIDs := make(map[string]bool)
-	for _, edge := range edges {
-		producerGUIDs[edge.FromState] = true
+	result := &DependencyGraph{
+		ConsumerGUID:    consumerState.GUID,
+		ConsumerLogicID: consumerState.LogicID,
+		MaxDepth:        maxDepth,
+	}
+
+	// Walk incoming edges breadth-first so each producer gets its shortest
+	// depth, fetching each level's edges in one query
+	depths := map[string]int{consumerState.GUID: 0}
+	frontier := []string{consumerState.GUID}
+	for depth := 1; len(frontier) > 0; depth++ {
+		edges, err := s.edgeRepo.GetIncomingEdgesForStates(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("get incoming edges: %w", err)
+		}
+		if depth > maxDepth {
+			// Only producers the walk has not reached yet are cut off
+			for _, edge := range edges {
+				if _, seen := depths[edge.FromState]; !seen {
+					result.Truncated = true
+					break
+				}
+			}
+			break
+		}
+
+		var next []string
+		for _, edge := range edges {
+			result.Edges = append(result.Edges, edge)
+			if _, seen := depths[edge.FromState]; !seen {
+				depths[edge.FromState] = depth
+				next = append(next, edge.FromState)
+			}
+		}
+		frontier = next
 	}
+	result.CycleEdgeIDs = findCycleEdges(consumerState.GUID, result.Edges)
 
 	// Batch fetch producer states (avoids N+1 queries)
-	guids := make([]string, 0, len(producerGUIDs))
-	for guid := range producerGUIDs {
-		guids = append(guids, guid)
+	guids := make([]string, 0, len(depths))
+	for guid := range depths {
+		if guid != consumerState.GUID {
+			guids = append(guids, guid)
+		}
 	}
 
 	producerStates, err := s.stateRepo.GetByGUIDs(ctx, guids)
@@ -210,21 +324,60 @@ func (s *Service) GetDependencyGraph(ctx context.Context, logicID, guid string)
 		return nil, fmt.Errorf("batch fetch producer states: %w", err)
 	}
 
-	// Build producer list
-	producers := make([]ProducerState, 0, len(producerStates))
+	// Build producer list, nearest first
+	result.Producers = make([]ProducerState, 0, len(producerStates))
 	for guid, state := range producerStates {
-		producers = append(producers, ProducerState{
+		result.Producers = append(result.Producers, ProducerState{
 			GUID:    guid,
 			LogicID: state.LogicID,
+			Labels:  state.Labels,
+			Depth:   depths[guid],
 		})
 	}
+	sort.Slice(result.Producers, func(i, j int) bool {
+		a, b := result.Producers[i], result.Producers[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.LogicID < b.LogicID
+	})
 
-	return &DependencyGraph{
-		ConsumerGUID:    consumerState.GUID,
-		ConsumerLogicID: consumerState.LogicID,
-		Producers:       producers,
-		Edges:           edges,
-	}, nil
+	return result, nil
+}
+
+// findCycleEdges returns the IDs of edges that close a cycle among the given
+// edges, found as back edges of a depth-first walk from the consumer towards
+// its producers.
+func findCycleEdges(consumerGUID string, edges []models.Edge) []int64 {
+	incoming := make(map[string][]models.Edge)
+	for _, edge := range edges {
+		incoming[edge.ToState] = append(incoming[edge.ToState], edge)
+	}
+
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int)
+	var cycleEdges []int64
+	var visit func(guid string)
+	visit = func(guid string) {
+		state[guid] = onPath
+		for _, edge := range incoming[guid] {
+			switch state[edge.FromState] {
+			case onPath:
+				cycleEdges = append(cycleEdges, edge.ID)
+			case unvisited:
+				visit(edge.FromState)
+			}
+		}
+		state[guid] = done
+	}
+	visit(consumerGUID)
+
+	sort.Slice(cycleEdges, func(i, j int) bool { return cycleEdges[i] < cycleEdges[j] })
+	return cycleEdges
 }
 
 // DependencyGraph represents the full graph for a consumer state
@@ -233,12 +386,17 @@ type DependencyGraph struct {
 	ConsumerLogicID string
 	Producers       []ProducerState
 	Edges           []models.Edge
+	MaxDepth        int     // Depth limit applied
+	Truncated       bool    // Producers exist beyond MaxDepth
+	CycleEdgeIDs    []int64 // Edges closing a dependency cycle
 }
 
 // ProducerState represents a unique producer state
 type ProducerState struct {
 	GUID    string
 	LogicID string
+	Labels  models.LabelMap
+	Depth   int // Hops from the consumer; 1 for direct producers
 }
 
 // resolveState resolves a state by logic_id or GUID
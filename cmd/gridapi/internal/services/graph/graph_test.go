@@ -274,6 +274,25 @@ func TestGetTopologicalOrder_CycleDetected(t *testing.T) {
 	assert.Contains(t, err.Error(), "cycle detected")
 }
 
+func TestGetTopologicalOrder_CycleMembersReported(t *testing.T) {
+	// A -> B -> A, plus C -> A outside the cycle
+	edges := []models.Edge{
+		{FromState: "state-a", ToState: "state-b"},
+		{FromState: "state-b", ToState: "state-a"},
+		{FromState: "state-c", ToState: "state-a"},
+	}
+
+	_, err := GetTopologicalOrder(edges, "state-c", "downstream")
+	require.Error(t, err)
+
+	var cycleErr *CycleError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, [][]string{{"state-a", "state-b"}}, cycleErr.Cycles)
+	assert.Contains(t, err.Error(), "state-a")
+	assert.Contains(t, err.Error(), "state-b")
+	assert.NotContains(t, err.Error(), "state-c")
+}
+
 func TestGetTopologicalOrder_ComplexGraph(t *testing.T) {
 	// More complex dependency graph
 	//     A
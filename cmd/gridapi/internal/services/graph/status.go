@@ -30,6 +30,7 @@ type IncomingEdgeView struct {
 	OutDigest   string     `json:"out_digest,omitempty"`
 	LastInAt    *time.Time `json:"last_in_at,omitempty"`
 	LastOutAt   *time.Time `json:"last_out_at,omitempty"`
+	Current     bool       `json:"current"`
 }
 
 // StatusSummary aggregates incoming edge counts
@@ -40,6 +41,13 @@ type StatusSummary struct {
 	IncomingUnknown int `json:"incoming_unknown"`
 }
 
+// IsEdgeCurrent reports whether the consumer has observed the producer's
+// latest output, i.e. both digests are known and equal. Pending and mock edges
+// (no producer digest yet) are never current.
+func IsEdgeCurrent(edge *models.Edge) bool {
+	return edge.InDigest != "" && edge.InDigest == edge.OutDigest
+}
+
 // ComputeStateStatus derives state status from all incoming edges + transitive propagation
 func ComputeStateStatus(ctx context.Context, edgeRepo repository.EdgeRepository, stateRepo repository.StateRepository, stateGUID string) (*StateStatus, error) {
 	// Get target state
@@ -126,6 +134,7 @@ func ComputeStateStatus(ctx context.Context, edgeRepo repository.EdgeRepository,
 			OutDigest:   edge.OutDigest,
 			LastInAt:    edge.LastInAt,
 			LastOutAt:   edge.LastOutAt,
+			Current:     IsEdgeCurrent(edge),
 		}
 
 		incoming = append(incoming, view)
@@ -174,6 +183,22 @@ func ComputeStateStatus(ctx context.Context, edgeRepo repository.EdgeRepository,
 	}, nil
 }
 
+// ComputeStateSummaryFromIncoming computes the status string for a state whose
+// incoming edges the caller already holds. A dirty or pending incoming edge
+// decides "stale" and no incoming edges decide "clean" without a query;
+// otherwise ComputeStateSummary walks the graph for upstream staleness.
+func ComputeStateSummaryFromIncoming(ctx context.Context, edgeRepo repository.EdgeRepository, stateGUID string, incoming []models.Edge) (string, error) {
+	if len(incoming) == 0 {
+		return "clean", nil
+	}
+	for _, edge := range incoming {
+		if edge.Status == models.EdgeStatusDirty || edge.Status == models.EdgeStatusPending {
+			return "stale", nil
+		}
+	}
+	return ComputeStateSummary(ctx, edgeRepo, stateGUID)
+}
+
 // ComputeStateSummary computes just the status string without full details
 func ComputeStateSummary(ctx context.Context, edgeRepo repository.EdgeRepository, stateGUID string) (string, error) {
 	// Fetch all edges
@@ -182,6 +207,15 @@ func ComputeStateSummary(ctx context.Context, edgeRepo repository.EdgeRepository
 		return "", fmt.Errorf("get all edges: %w", err)
 	}
 
+	if status, ok := ComputeStateSummaries(allEdges)[stateGUID]; ok {
+		return status, nil
+	}
+	return "clean", nil
+}
+
+// ComputeStateSummaries computes the status of every state in one pass over
+// the edges. States missing from the result are "clean".
+func ComputeStateSummaries(allEdges []models.Edge) map[string]string {
 	// Build adjacency map
 	adj := make(map[string][]string)
 	for _, edge := range allEdges {
@@ -189,43 +223,28 @@ func ComputeStateSummary(ctx context.Context, edgeRepo repository.EdgeRepository
 	}
 
 	// Identify red states
-	red := make(map[string]bool)
+	statuses := make(map[string]string)
+	queue := []string{}
 	for _, edge := range allEdges {
-		if edge.Status == models.EdgeStatusDirty || edge.Status == models.EdgeStatusPending {
-			red[edge.ToState] = true
+		if (edge.Status == models.EdgeStatusDirty || edge.Status == models.EdgeStatusPending) && statuses[edge.ToState] == "" {
+			statuses[edge.ToState] = "stale"
+			queue = append(queue, edge.ToState)
 		}
 	}
 
 	// Propagate yellow
-	yellow := make(map[string]bool)
-	queue := []string{}
-	seen := make(map[string]bool)
-
-	for stateID := range red {
-		queue = append(queue, stateID)
-		seen[stateID] = true
-	}
-
 	for len(queue) > 0 {
 		current := queue[0]
 		queue = queue[1:]
 
 		for _, next := range adj[current] {
-			if seen[next] {
+			if statuses[next] != "" {
 				continue
 			}
-			yellow[next] = true
-			seen[next] = true
+			statuses[next] = "potentially-stale"
 			queue = append(queue, next)
 		}
 	}
 
-	// Compute status
-	if red[stateGUID] {
-		return "stale", nil
-	} else if yellow[stateGUID] {
-		return "potentially-stale", nil
-	}
-
-	return "clean", nil
+	return statuses
 }
@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// countingEdgeRepository serves GetAllEdges and counts the calls.
+type countingEdgeRepository struct {
+	repository.EdgeRepository
+	edges []models.Edge
+	calls int
+}
+
+func (r *countingEdgeRepository) GetAllEdges(ctx context.Context) ([]models.Edge, error) {
+	r.calls++
+	return r.edges, nil
+}
+
+func TestComputeStateSummaryFromIncoming(t *testing.T) {
+	edges := []models.Edge{
+		{ID: 1, FromState: "a", ToState: "b", Status: models.EdgeStatusDirty},
+		{ID: 2, FromState: "b", ToState: "c", Status: models.EdgeStatusClean},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		state     string
+		incoming  []models.Edge
+		want      string
+		wantQuery bool
+	}{
+		{name: "no incoming edges", state: "a", want: "clean"},
+		{name: "dirty incoming edge", state: "b", incoming: edges[:1], want: "stale"},
+		{name: "dirty upstream edge", state: "c", incoming: edges[1:], want: "potentially-stale", wantQuery: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &countingEdgeRepository{edges: edges}
+			status, err := ComputeStateSummaryFromIncoming(ctx, repo, tt.state, tt.incoming)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+			assert.Equal(t, tt.wantQuery, repo.calls > 0)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package graph
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
@@ -15,6 +17,42 @@ type Layer struct {
 	States []string `json:"states"` // GUIDs
 }
 
+// CycleError reports dependency cycles found while ordering states.
+type CycleError struct {
+	// Cycles holds the GUIDs of each group of states that depend on each
+	// other, sorted within each group.
+	Cycles [][]string
+}
+
+func (e *CycleError) Error() string {
+	groups := make([]string, 0, len(e.Cycles))
+	for _, cycle := range e.Cycles {
+		groups = append(groups, strings.Join(cycle, ", "))
+	}
+	return fmt.Sprintf("topological sort failed (cycle detected among states: %s)", strings.Join(groups, "; "))
+}
+
+// newCycleError converts gonum's unorderable components into GUID groups.
+func newCycleError(unorderable topo.Unorderable, guidToNodeID map[string]int64) *CycleError {
+	nodeIDToGUID := make(map[int64]string, len(guidToNodeID))
+	for guid, id := range guidToNodeID {
+		nodeIDToGUID[id] = guid
+	}
+
+	cycles := make([][]string, 0, len(unorderable))
+	for _, component := range unorderable {
+		guids := make([]string, 0, len(component))
+		for _, node := range component {
+			guids = append(guids, nodeIDToGUID[node.ID()])
+		}
+		sort.Strings(guids)
+		cycles = append(cycles, guids)
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+
+	return &CycleError{Cycles: cycles}
+}
+
 // GetTopologicalOrder computes layered ordering rooted at a given state
 // Direction: "upstream" (dependencies) or "downstream" (dependents)
 func GetTopologicalOrder(edges []models.Edge, rootGUID string, direction string) ([]Layer, error) {
@@ -45,9 +83,13 @@ func GetTopologicalOrder(edges []models.Edge, rootGUID string, direction string)
 		g.AddNode(simple.Node(rootNodeID))
 	}
 
-	// Verify graph is acyclic
+	// Verify graph is acyclic, reporting the states in each cycle
 	if _, err := topo.Sort(g); err != nil {
-		return nil, fmt.Errorf("topological sort failed (cycle detected): %w", err)
+		var unorderable topo.Unorderable
+		if errors.As(err, &unorderable) {
+			return nil, newCycleError(unorderable, guidToNodeID)
+		}
+		return nil, fmt.Errorf("topological sort failed: %w", err)
 	}
 
 	// Build adjacency map for traversal
@@ -0,0 +1,88 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// staticUserGroups serves canned internal IdP group memberships.
+type staticUserGroups map[string][]string
+
+func (g staticUserGroups) Add(ctx context.Context, userID, groupName string) error    { return nil }
+func (g staticUserGroups) Remove(ctx context.Context, userID, groupName string) error { return nil }
+func (g staticUserGroups) ListGroupNames(ctx context.Context, userID string) ([]string, error) {
+	return g[userID], nil
+}
+func (g staticUserGroups) CountMembers(ctx context.Context, groupName string) (int, error) {
+	count := 0
+	for _, groups := range g {
+		if slices.Contains(groups, groupName) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestExportAccessReview(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t, testRepos{
+		users: &mockUserRepository{users: map[string]*models.User{
+			"ext|alice": {ID: "user-alice", Email: "alice@example.com", Subject: strPtr("ext|alice")},
+			"user-bob":  {ID: "user-bob", Email: "bob@example.com"},
+		}},
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-reader": {ID: "role-reader", Name: "state-reader"},
+			"role-writer": {ID: "role-writer", Name: "state-writer"},
+		}},
+		serviceAccounts: &mockServiceAccountRepository{accounts: map[string]*models.ServiceAccount{
+			"ci-client": {ID: "sa-ci", ClientID: "ci-client", Name: "ci"},
+		}},
+	})
+	svc.userGroups = staticUserGroups{"user-bob": {"writers"}}
+
+	review, err := svc.ExportAccessReview(ctx)
+	require.NoError(t, err)
+	require.Len(t, review.Users, 2)
+	assert.Empty(t, review.Users[0].DirectRoles)
+
+	require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-reader"))
+	require.NoError(t, svc.AssignUserRole(ctx, "", "sa-ci", "role-writer"))
+	require.NoError(t, svc.AssignGroupRole(ctx, "writers", "role-writer", ""))
+
+	review, err = svc.ExportAccessReview(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, review.Users, 2)
+	alice, bob := review.Users[0], review.Users[1]
+	assert.Equal(t, "ext|alice", alice.Subject)
+	assert.Equal(t, []string{"state-reader"}, alice.DirectRoles)
+	assert.Empty(t, alice.GroupRoles)
+	assert.Empty(t, bob.DirectRoles)
+	assert.Equal(t, []string{"writers"}, bob.Groups)
+	assert.Equal(t, []GroupRoleGrant{{Group: "writers", Role: "state-writer"}}, bob.GroupRoles)
+
+	require.Len(t, review.ServiceAccounts, 1)
+	assert.Equal(t, []string{"state-writer"}, review.ServiceAccounts[0].Roles)
+
+	require.Len(t, review.GroupMappings, 1)
+	assert.Equal(t, "writers", review.GroupMappings[0].Group)
+	assert.Equal(t, "state-writer", review.GroupMappings[0].Role)
+
+	var buf bytes.Buffer
+	require.NoError(t, review.WriteCSV(&buf))
+	assert.Equal(t, `principal_type,principal_id,principal_name,disabled,role,granted_via,condition
+user,user-alice,alice@example.com,false,state-reader,direct,
+user,user-bob,bob@example.com,false,state-writer,group:writers,
+service_account,ci-client,ci,false,state-writer,direct,
+group,writers,writers,false,state-writer,mapping,
+`, buf.String())
+}
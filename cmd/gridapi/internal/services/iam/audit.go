@@ -0,0 +1,202 @@
+package iam
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// Audit actions recorded for out-of-band IAM mutations.
+const (
+	AuditActionRoleCreate                 = "role.create"
+	AuditActionRoleUpdate                 = "role.update"
+	AuditActionRoleDelete                 = "role.delete"
+	AuditActionUserRoleAssign             = "user_role.assign"
+	AuditActionUserRoleRemove             = "user_role.remove"
+	AuditActionGroupRoleAssign            = "group_role.assign"
+	AuditActionGroupRoleRemove            = "group_role.remove"
+	AuditActionServiceAccountCreate       = "service_account.create"
+	AuditActionServiceAccountRevoke       = "service_account.revoke"
+	AuditActionServiceAccountRotateSecret = "service_account.rotate_secret"
+	AuditActionUserMerge                  = "user.merge"
+	AuditActionUserPasswordReset          = "user.password_reset"
+	AuditActionSessionsRevoke             = "sessions.revoke"
+	AuditActionRevocationEpochBump        = "revocation_epoch.bump"
+	AuditActionStateTransferOwnership     = "state.transfer_ownership"
+)
+
+// Audit target types.
+const (
+	AuditTargetRole            = "role"
+	AuditTargetUser            = "user"
+	AuditTargetGroup           = "group"
+	AuditTargetServiceAccount  = "service_account"
+	AuditTargetSession         = "session"
+	AuditTargetRevocationEpoch = "revocation_epoch"
+	AuditTargetState           = "state"
+)
+
+// Audit outcomes.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// auditSystemActor attributes mutations made without an authenticated
+// principal, i.e. from the gridapi CLI.
+const auditSystemActor = "system"
+
+// AuditLogFilter narrows audit log queries (actor, action, target, outcome,
+// time range, paging).
+type AuditLogFilter = repository.AuditLogFilter
+
+// AuditEvent describes one IAM mutation and its outcome.
+type AuditEvent struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     map[string]any
+	After      map[string]any
+	Timestamp  time.Time
+	Outcome    string
+	Error      string
+}
+
+// AuditLogger records IAM mutation events. Implementations must be safe for
+// concurrent use.
+type AuditLogger interface {
+	Log(ctx context.Context, event AuditEvent) error
+}
+
+// repositoryAuditLogger writes events to the audit_log table.
+type repositoryAuditLogger struct {
+	entries repository.AuditLogRepository
+}
+
+// NewAuditLogger returns an AuditLogger persisting events through entries.
+func NewAuditLogger(entries repository.AuditLogRepository) AuditLogger {
+	return &repositoryAuditLogger{entries: entries}
+}
+
+func (l *repositoryAuditLogger) Log(ctx context.Context, event AuditEvent) error {
+	entry := &models.AuditLogEntry{
+		OccurredAt: event.Timestamp,
+		Actor:      event.Actor,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		Before:     event.Before,
+		After:      event.After,
+		Outcome:    event.Outcome,
+	}
+	if event.Error != "" {
+		entry.Error = &event.Error
+	}
+	return l.entries.Create(ctx, entry)
+}
+
+// audit records event with the outcome of opErr. A failed write is logged and
+// never changes the mutation's own result.
+func (s *iamService) audit(ctx context.Context, event AuditEvent, opErr error) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	event.Actor = auditActor(ctx)
+	event.Timestamp = time.Now()
+	event.Outcome = AuditOutcomeSuccess
+	if opErr != nil {
+		event.Outcome = AuditOutcomeFailure
+		event.Error = opErr.Error()
+	}
+
+	// The mutation has already happened; a cancelled request must not drop its record
+	if err := s.auditLogger.Log(context.WithoutCancel(ctx), event); err != nil {
+		log.Printf("ERROR: audit log write failed (action=%s, target=%s:%s, actor=%s, outcome=%s): %v",
+			event.Action, event.TargetType, event.TargetID, event.Actor, event.Outcome, err)
+	}
+}
+
+// RecordOwnershipTransfer audits a state ownership transfer. Owner override
+// access follows the owner, so transfers are recorded like IAM mutations.
+func (s *iamService) RecordOwnershipTransfer(ctx context.Context, stateGUID, previousOwner, newOwner string, opErr error) {
+	s.audit(ctx, AuditEvent{
+		Action:     AuditActionStateTransferOwnership,
+		TargetType: AuditTargetState,
+		TargetID:   stateGUID,
+		Before:     map[string]any{"owner": previousOwner},
+		After:      map[string]any{"owner": newOwner},
+	}, opErr)
+}
+
+// ListAuditLog returns audit entries matching filter, newest first.
+func (s *iamService) ListAuditLog(ctx context.Context, filter AuditLogFilter) ([]models.AuditLogEntry, error) {
+	if s.auditLogs == nil {
+		return []models.AuditLogEntry{}, nil
+	}
+	return s.auditLogs.List(ctx, filter)
+}
+
+// auditActor returns the principal ID of the caller, or auditSystemActor when
+// the context carries no authenticated principal.
+func auditActor(ctx context.Context) string {
+	if principal, ok := auth.GetUserFromContext(ctx); ok && principal.PrincipalID != "" {
+		return principal.PrincipalID
+	}
+	return auditSystemActor
+}
+
+// roleAuditState captures the audited fields of a role. actions lists the
+// role's "obj:act" permissions.
+func roleAuditState(role *models.Role, actions []string) map[string]any {
+	if role == nil {
+		return nil
+	}
+	state := map[string]any{
+		"name":               role.Name,
+		"description":        role.Description,
+		"scope_expr":         role.ScopeExpr,
+		"create_constraints": role.CreateConstraints,
+		"immutable_keys":     role.ImmutableKeys,
+		"owner_actions":      role.OwnerActions,
+		"actions":            actions,
+		"default_labels":     role.DefaultLabels,
+		"version":            role.Version,
+	}
+	if role.MaxAssignments != nil {
+		state["max_assignments"] = *role.MaxAssignments
+	}
+	return state
+}
+
+// sessionFilterAuditState captures the fields of a revocation filter.
+func sessionFilterAuditState(filter SessionFilter) map[string]any {
+	state := map[string]any{}
+	if filter.UserID != "" {
+		state["user_id"] = filter.UserID
+	}
+	if filter.ServiceAccountID != "" {
+		state["service_account_id"] = filter.ServiceAccountID
+	}
+	if filter.CreatedAfter != nil {
+		state["created_after"] = filter.CreatedAfter.UTC().Format(time.RFC3339)
+	}
+	if filter.CreatedBefore != nil {
+		state["created_before"] = filter.CreatedBefore.UTC().Format(time.RFC3339)
+	}
+	return state
+}
+
+// principalAuditTarget returns the audit target for a role assignment made to
+// either a user or a service account.
+func principalAuditTarget(userID, serviceAccountID string) (string, string) {
+	if userID != "" {
+		return AuditTargetUser, userID
+	}
+	return AuditTargetServiceAccount, serviceAccountID
+}
@@ -0,0 +1,122 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// recordingAuditLogger keeps events in memory, optionally failing every write
+type recordingAuditLogger struct {
+	events []AuditEvent
+	err    error
+}
+
+func (l *recordingAuditLogger) Log(ctx context.Context, event AuditEvent) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.events = append(l.events, event)
+	return nil
+}
+
+func newAuditTestService(t *testing.T, logger AuditLogger) *iamService {
+	t.Helper()
+
+	userID := "user-alice"
+	sessions := &mockSessionRepository{sessions: map[string]*models.Session{
+		"hash-1": {ID: "sess-1", UserID: &userID, ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	svc := newTestService(t, testRepos{sessions: sessions})
+	svc.auditLogger = logger
+	return svc
+}
+
+func TestAudit_RecordsSuccessWithActor(t *testing.T) {
+	t.Parallel()
+	logger := &recordingAuditLogger{}
+	svc := newAuditTestService(t, logger)
+	ctx := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "user:admin@example.com"})
+
+	count, err := svc.RevokeSessions(ctx, SessionFilter{UserID: "user-alice"})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	require.Len(t, logger.events, 1)
+	event := logger.events[0]
+	assert.Equal(t, "user:admin@example.com", event.Actor)
+	assert.Equal(t, AuditActionSessionsRevoke, event.Action)
+	assert.Equal(t, AuditTargetUser, event.TargetType)
+	assert.Equal(t, "user-alice", event.TargetID)
+	assert.Equal(t, AuditOutcomeSuccess, event.Outcome)
+	assert.Equal(t, 1, event.After["revoked"])
+	assert.False(t, event.Timestamp.IsZero())
+}
+
+func TestAudit_RecordsFailure(t *testing.T) {
+	t.Parallel()
+	logger := &recordingAuditLogger{}
+	svc := newAuditTestService(t, logger)
+
+	_, err := svc.RevokeSessions(context.Background(), SessionFilter{})
+	require.ErrorContains(t, err, "invalid session filter")
+
+	require.Len(t, logger.events, 1)
+	assert.Equal(t, auditSystemActor, logger.events[0].Actor)
+	assert.Equal(t, AuditOutcomeFailure, logger.events[0].Outcome)
+	assert.Equal(t, err.Error(), logger.events[0].Error)
+}
+
+func TestAudit_WriteFailureKeepsResult(t *testing.T) {
+	t.Parallel()
+	svc := newAuditTestService(t, &recordingAuditLogger{err: errors.New("audit_log unavailable")})
+
+	// The mutation still succeeds and reports its own result
+	count, err := svc.RevokeSessions(context.Background(), SessionFilter{UserID: "user-alice"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.True(t, svc.sessions.(*mockSessionRepository).sessions["hash-1"].Revoked)
+
+	// And a failing mutation keeps its own error rather than the audit error
+	_, err = svc.RevokeSessions(context.Background(), SessionFilter{})
+	assert.ErrorContains(t, err, "invalid session filter")
+}
+
+func TestAudit_AdminResetPassword(t *testing.T) {
+	t.Parallel()
+	svc, alice, _ := newChangePasswordTestService(t)
+	logger := &recordingAuditLogger{}
+	svc.auditLogger = logger
+
+	_, err := svc.AdminResetPassword(context.Background(), alice.ID)
+	require.NoError(t, err)
+
+	require.Len(t, logger.events, 1)
+	assert.Equal(t, AuditActionUserPasswordReset, logger.events[0].Action)
+	assert.Equal(t, alice.ID, logger.events[0].TargetID)
+}
+
+func TestAudit_RecordOwnershipTransfer(t *testing.T) {
+	t.Parallel()
+	logger := &recordingAuditLogger{}
+	svc := newAuditTestService(t, logger)
+	ctx := auth.SetUserContext(context.Background(), auth.AuthenticatedPrincipal{PrincipalID: "user:admin@example.com"})
+
+	svc.RecordOwnershipTransfer(ctx, "state-1", "user:alice@example.com", "user:bob@example.com", nil)
+
+	require.Len(t, logger.events, 1)
+	event := logger.events[0]
+	assert.Equal(t, "user:admin@example.com", event.Actor)
+	assert.Equal(t, AuditActionStateTransferOwnership, event.Action)
+	assert.Equal(t, AuditTargetState, event.TargetType)
+	assert.Equal(t, "state-1", event.TargetID)
+	assert.Equal(t, "user:alice@example.com", event.Before["owner"])
+	assert.Equal(t, "user:bob@example.com", event.After["owner"])
+	assert.Equal(t, AuditOutcomeSuccess, event.Outcome)
+}
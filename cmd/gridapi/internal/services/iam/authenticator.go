@@ -27,6 +27,13 @@ type Authenticator interface {
 	Authenticate(ctx context.Context, req AuthRequest) (*Principal, error)
 }
 
+// tokenVerifier is implemented by authenticators that can validate a raw
+// bearer token without resolving an identity (used by IntrospectToken).
+type tokenVerifier interface {
+	// VerifyToken validates signature, issuer, audience and expiry and returns the claims.
+	VerifyToken(ctx context.Context, token string) (map[string]any, error)
+}
+
 // AuthRequest wraps HTTP request data for authenticator implementations.
 // This abstraction allows authenticators to work with both HTTP and Connect RPC requests.
 type AuthRequest struct {
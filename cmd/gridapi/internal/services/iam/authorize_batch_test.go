@@ -0,0 +1,159 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+)
+
+// newBatchTestEnforcer covers what AuthorizeBatch must match: scoped, unscoped
+// and wildcard allows, deny rules, and a role inheriting another's policies.
+func newBatchTestEnforcer(t testing.TB) casbin.IEnforcer {
+	t.Helper()
+
+	enforcer := newTestEnforcer(t,
+		[]string{auth.RoleID("dev"), auth.ObjectTypeState, auth.StateRead, "env == dev", auth.EffectAllow},
+		[]string{auth.RoleID("dev"), auth.ObjectTypeState, auth.TfstateWrite, "env == dev", auth.EffectAllow},
+		[]string{auth.RoleID("viewer"), auth.ObjectTypeState, auth.StateRead, auth.ScopeAll, auth.EffectAllow},
+		[]string{auth.RoleID("viewer"), auth.ObjectTypeState, auth.StateDelete, auth.ScopeAll, auth.EffectDeny},
+		[]string{auth.RoleID("ops"), "*", "*", "team == ops", auth.EffectAllow},
+		[]string{auth.RoleID("ops"), auth.ObjectTypeState, auth.StateDelete, "env == prod", auth.EffectDeny},
+		[]string{auth.RoleID("lead"), auth.ObjectTypePolicy, auth.PolicyRead, auth.ScopeAll, auth.EffectAllow},
+	)
+	_, err := enforcer.AddGroupingPolicy(auth.RoleID("lead"), auth.RoleID("dev"))
+	require.NoError(t, err)
+	return enforcer
+}
+
+func batchTestChecks() []AuthCheck {
+	labelSets := []map[string]interface{}{
+		nil,
+		{"env": "dev"},
+		{"env": "prod", "team": "ops"},
+		{"env": "dev", "team": "ops"},
+		{"team": "web"},
+	}
+	var checks []AuthCheck
+	for _, obj := range []string{auth.ObjectTypeState, auth.ObjectTypePolicy} {
+		for _, act := range []string{auth.StateRead, auth.TfstateWrite, auth.StateDelete, auth.PolicyRead} {
+			for _, labels := range labelSets {
+				checks = append(checks, AuthCheck{Obj: obj, Act: act, Labels: labels})
+			}
+		}
+	}
+	return checks
+}
+
+func TestAuthorizeBatchWithRoles_MatchesAuthorizeWithRoles(t *testing.T) {
+	t.Parallel()
+
+	enforcer := newBatchTestEnforcer(t)
+	checks := batchTestChecks()
+
+	roleSets := [][]string{
+		nil,
+		{"dev"},
+		{"viewer"},
+		{"ops"},
+		{"lead"},
+		{"dev", "viewer"},
+		{"dev", "ops"},
+		{"viewer", "ops", "lead"},
+		{"unknown"},
+	}
+	for _, roles := range roleSets {
+		for _, combination := range []ScopeCombinationPolicy{nil, {auth.ObjectTypeState}} {
+			for _, tokenScopes := range [][]string{nil, {auth.StateRead}} {
+				name := fmt.Sprintf("roles=%v/intersection=%v/scopes=%v", roles, combination, tokenScopes)
+				t.Run(name, func(t *testing.T) {
+					got, err := AuthorizeBatchWithRoles(enforcer, roles, checks, combination, tokenScopes)
+					require.NoError(t, err)
+					require.Len(t, got, len(checks))
+
+					for i, check := range checks {
+						want, err := AuthorizeWithRoles(enforcer, roles, check.Obj, check.Act, check.Labels, combination.For(check.Obj), tokenScopes)
+						require.NoError(t, err)
+						assert.Equal(t, want, got[i], "check %d: %s %s labels=%v", i, check.Obj, check.Act, check.Labels)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestAuthorizeBatch(t *testing.T) {
+	t.Parallel()
+
+	svc := &iamService{enforcer: newBatchTestEnforcer(t)}
+	ctx := context.Background()
+	principal := &Principal{Roles: []string{"dev"}}
+
+	results, err := svc.AuthorizeBatch(ctx, principal, []AuthCheck{
+		{Obj: auth.ObjectTypeState, Act: auth.StateRead, Labels: map[string]interface{}{"env": "prod"}},
+		{Obj: auth.ObjectTypeState, Act: auth.StateRead, Labels: map[string]interface{}{"env": "dev"}},
+		{Obj: auth.ObjectTypeState, Act: auth.StateDelete, Labels: map[string]interface{}{"env": "dev"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true, false}, results, "results follow input order")
+
+	results, err = svc.AuthorizeBatch(ctx, principal, nil)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = svc.AuthorizeBatch(ctx, nil, nil)
+	assert.Error(t, err)
+	_, err = (&iamService{}).AuthorizeBatch(ctx, principal, nil)
+	assert.Error(t, err, "no enforcer")
+}
+
+// BenchmarkAuthorize compares per-item Authorize calls with one AuthorizeBatch
+// call for a list view of states under a policy set of many roles.
+func BenchmarkAuthorize(b *testing.B) {
+	output := log.Writer()
+	log.SetOutput(io.Discard) // AuthorizeWithRoles logs every decision
+	b.Cleanup(func() { log.SetOutput(output) })
+
+	enforcer := newTestEnforcer(b)
+	for i := range 200 {
+		role := auth.RoleID(fmt.Sprintf("team-%d", i))
+		_, _ = enforcer.AddPolicy(role, auth.ObjectTypeState, auth.StateRead, fmt.Sprintf("team == team-%d", i), auth.EffectAllow)
+		_, _ = enforcer.AddPolicy(role, auth.ObjectTypeState, auth.TfstateWrite, fmt.Sprintf("team == team-%d && env != prod", i), auth.EffectAllow)
+	}
+	svc := &iamService{enforcer: enforcer}
+	principal := &Principal{Roles: []string{"team-1", "team-2", "team-3"}}
+
+	checks := make([]AuthCheck, 500)
+	for i := range checks {
+		checks[i] = AuthCheck{
+			Obj:    auth.ObjectTypeState,
+			Act:    auth.StateRead,
+			Labels: map[string]interface{}{"team": fmt.Sprintf("team-%d", i%10), "env": "dev"},
+		}
+	}
+	ctx := context.Background()
+
+	b.Run("single", func(b *testing.B) {
+		for b.Loop() {
+			for _, check := range checks {
+				if _, err := svc.Authorize(ctx, principal, check.Obj, check.Act, check.Labels); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := svc.AuthorizeBatch(ctx, principal, checks); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
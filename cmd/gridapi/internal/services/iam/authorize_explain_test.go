@@ -0,0 +1,120 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestAuthorizeExplain_MatchesAuthorizeWithRoles(t *testing.T) {
+	t.Parallel()
+
+	enforcer := newTestEnforcer(t,
+		[]string{auth.RoleID("platform-engineer"), "*", "*", auth.ScopeAll, auth.EffectAllow},
+		[]string{auth.RoleID("dev-writer"), auth.ObjectTypeState, auth.TfstateWrite, `env == "dev"`, auth.EffectAllow},
+		[]string{auth.RoleID("pci-guard"), auth.ObjectTypeState, auth.TfstateWrite, `compliance == "pci"`, auth.EffectDeny},
+	)
+	dev := map[string]interface{}{"env": "dev"}
+	pci := map[string]interface{}{"env": "dev", "compliance": "pci"}
+
+	tests := []struct {
+		name   string
+		roles  []string
+		labels map[string]interface{}
+	}{
+		{name: "no roles", roles: nil, labels: dev},
+		{name: "single allow", roles: []string{"dev-writer"}, labels: dev},
+		{name: "no matching policy", roles: []string{"pci-guard"}, labels: dev},
+		{name: "deny overrides", roles: []string{"platform-engineer", "pci-guard"}, labels: pci},
+		{name: "one of two allows", roles: []string{"dev-writer", "pci-guard"}, labels: dev},
+	}
+
+	for _, tt := range tests {
+		for _, combination := range []ScopeCombination{ScopeUnion, ScopeIntersection} {
+			t.Run(tt.name+"/"+string(combination), func(t *testing.T) {
+				want, err := AuthorizeWithRoles(enforcer, tt.roles, auth.ObjectTypeState, auth.TfstateWrite, tt.labels, combination, nil)
+				require.NoError(t, err)
+
+				got, err := AuthorizeExplain(enforcer, tt.roles, auth.ObjectTypeState, auth.TfstateWrite, tt.labels, combination)
+				require.NoError(t, err)
+				assert.Equal(t, want, got.Allowed, got.Reason)
+				assert.Len(t, got.Roles, len(tt.roles), "every role is evaluated")
+			})
+		}
+	}
+}
+
+func TestAuthorizeExplain_Trace(t *testing.T) {
+	t.Parallel()
+
+	enforcer := newTestEnforcer(t,
+		[]string{auth.RoleID("platform-engineer"), "*", "*", auth.ScopeAll, auth.EffectAllow},
+		[]string{auth.RoleID("pci-guard"), auth.ObjectTypeState, auth.TfstateWrite, `compliance == "pci"`, auth.EffectDeny},
+		[]string{auth.RoleID("viewer"), auth.ObjectTypeState, auth.StateRead, auth.ScopeAll, auth.EffectAllow},
+	)
+	labels := map[string]interface{}{"compliance": "pci"}
+
+	got, err := AuthorizeExplain(enforcer, []string{"platform-engineer", "pci-guard", "viewer"},
+		auth.ObjectTypeState, auth.TfstateWrite, labels, ScopeUnion)
+	require.NoError(t, err)
+
+	assert.False(t, got.Allowed)
+	assert.Contains(t, got.Reason, "pci-guard")
+	assert.Equal(t, []RoleDecision{
+		{Role: "platform-engineer", Allowed: true, Policy: &RoleAction{Action: "*:*", ScopeExpr: auth.ScopeAll, Effect: auth.EffectAllow}},
+		{Role: "pci-guard", Allowed: false, Policy: &RoleAction{Action: "!state:tfstate:write", ScopeExpr: `compliance == "pci"`, Effect: auth.EffectDeny}},
+		{Role: "viewer", Allowed: false},
+	}, got.Roles)
+	assert.Equal(t, Decision{ExplicitDeny: true}, got.Decision())
+}
+
+func TestPreviewAuthorization(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t, testRepos{
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-writer": {ID: "role-writer", Name: "dev-writer"},
+			"role-guard":  {ID: "role-guard", Name: "pci-guard"},
+		}},
+		groupRoles: &mockGroupRoleRepository{records: []models.GroupRole{
+			{ID: "gr-1", GroupName: "compliance", RoleID: "role-guard", AssignedAt: time.Now()},
+		}},
+	},
+		[]string{auth.RoleID("dev-writer"), auth.ObjectTypeState, auth.TfstateWrite, `env == "dev"`, auth.EffectAllow},
+		[]string{auth.RoleID("pci-guard"), auth.ObjectTypeState, auth.TfstateWrite, `compliance == "pci"`, auth.EffectDeny},
+	)
+	labels := map[string]interface{}{"env": "dev", "compliance": "pci"}
+
+	t.Run("roles only", func(t *testing.T) {
+		got, err := svc.PreviewAuthorization(ctx, AuthorizationPreview{
+			Roles: []string{"dev-writer"}, Object: auth.ObjectTypeState, Action: auth.TfstateWrite, Labels: labels,
+		})
+		require.NoError(t, err)
+		assert.True(t, got.Allowed)
+		assert.Equal(t, ScopeUnion, got.Combination)
+	})
+
+	t.Run("groups add their mapped roles", func(t *testing.T) {
+		got, err := svc.PreviewAuthorization(ctx, AuthorizationPreview{
+			Roles: []string{"dev-writer"}, Groups: []string{"/compliance"}, Object: auth.ObjectTypeState, Action: auth.TfstateWrite, Labels: labels,
+		})
+		require.NoError(t, err)
+		assert.False(t, got.Allowed)
+		require.Len(t, got.Roles, 2)
+		assert.Equal(t, "pci-guard", got.Roles[1].Role)
+	})
+
+	t.Run("unknown role is rejected", func(t *testing.T) {
+		_, err := svc.PreviewAuthorization(ctx, AuthorizationPreview{
+			Roles: []string{"dev-writr"}, Object: auth.ObjectTypeState, Action: auth.TfstateWrite,
+		})
+		require.ErrorContains(t, err, "not found")
+	})
+}
@@ -0,0 +1,166 @@
+package iam
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/telemetry"
+)
+
+// authzInstruments are created once; recording on them is lock-free.
+var authzInstruments = newAuthzInstruments()
+
+type authzMetrics struct {
+	decisions      metric.Int64Counter     // By object type and decision
+	duration       metric.Float64Histogram // Evaluation time by object type
+	enforcerErrors metric.Int64Counter     // Enforcer failures (denied) by object type
+}
+
+func newAuthzInstruments() authzMetrics {
+	meter := telemetry.Meter()
+	decisions, err := meter.Int64Counter("grid.authz.decisions",
+		metric.WithDescription("Authorization decisions by object type and outcome"))
+	if err != nil {
+		log.Printf("create authz decision counter: %v", err)
+	}
+	duration, err := meter.Float64Histogram("grid.authz.duration",
+		metric.WithDescription("Authorization evaluation time by object type"),
+		metric.WithUnit("ms"))
+	if err != nil {
+		log.Printf("create authz duration histogram: %v", err)
+	}
+	enforcerErrors, err := meter.Int64Counter("grid.authz.enforcer_errors",
+		metric.WithDescription("Casbin enforcer errors by object type; each fails the check closed"))
+	if err != nil {
+		log.Printf("create authz enforcer error counter: %v", err)
+	}
+	registerBexprCacheMetrics(meter)
+	return authzMetrics{decisions: decisions, duration: duration, enforcerErrors: enforcerErrors}
+}
+
+// registerBexprCacheMetrics reports the compiled scope expression cache's
+// counters (auth.GetBexprCacheStats) whenever metrics are collected.
+func registerBexprCacheMetrics(meter metric.Meter) {
+	lookups, err := meter.Int64ObservableCounter("grid.authz.bexpr_cache.lookups",
+		metric.WithDescription("Scope expression cache lookups by result"))
+	if err != nil {
+		log.Printf("create bexpr cache lookup counter: %v", err)
+		return
+	}
+	evictions, err := meter.Int64ObservableCounter("grid.authz.bexpr_cache.evictions",
+		metric.WithDescription("Compiled scope expressions evicted to stay within the cache bound"))
+	if err != nil {
+		log.Printf("create bexpr cache eviction counter: %v", err)
+		return
+	}
+	size, err := meter.Int64ObservableGauge("grid.authz.bexpr_cache.size",
+		metric.WithDescription("Compiled scope expressions currently cached"))
+	if err != nil {
+		log.Printf("create bexpr cache size gauge: %v", err)
+		return
+	}
+
+	hit := metric.WithAttributes(telemetry.AttrCacheResult.String("hit"))
+	miss := metric.WithAttributes(telemetry.AttrCacheResult.String("miss"))
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := auth.GetBexprCacheStats()
+		o.ObserveInt64(lookups, int64(stats.Hits), hit)
+		o.ObserveInt64(lookups, int64(stats.Misses), miss)
+		o.ObserveInt64(evictions, int64(stats.Evictions))
+		o.ObserveInt64(size, int64(stats.Size))
+		return nil
+	}, lookups, evictions, size)
+	if err != nil {
+		log.Printf("register bexpr cache metrics: %v", err)
+	}
+}
+
+// startAuthzSpan opens the span around one authorization decision.
+func startAuthzSpan(ctx context.Context, principal *Principal, obj, act string) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, "iam.Authorize", trace.WithAttributes(
+		telemetry.AttrAuthzObject.String(obj),
+		telemetry.AttrAuthzAction.String(act),
+		telemetry.AttrAuthzRoleCount.Int(len(principal.Roles)),
+	))
+}
+
+// recordAuthzDecision completes the span and records the decision metrics.
+func recordAuthzDecision(ctx context.Context, span trace.Span, obj string, allowed bool, err error, elapsed time.Duration) {
+	decision := telemetry.DecisionDeny
+	switch {
+	case err != nil:
+		decision = telemetry.DecisionError
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case allowed:
+		decision = telemetry.DecisionAllow
+	}
+	durationMs := float64(elapsed) / float64(time.Millisecond)
+	span.SetAttributes(
+		telemetry.AttrAuthzDecision.String(decision),
+		telemetry.AttrAuthzDurationMs.Float64(durationMs),
+	)
+
+	countAuthzDecision(ctx, obj, decision)
+	if authzInstruments.duration != nil {
+		authzInstruments.duration.Record(ctx, durationMs, metric.WithAttributes(telemetry.AttrAuthzObject.String(obj)))
+	}
+}
+
+// startAuthzBatchSpan opens the span around an AuthorizeBatch call.
+func startAuthzBatchSpan(ctx context.Context, principal *Principal, checks int) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, "iam.AuthorizeBatch", trace.WithAttributes(
+		telemetry.AttrAuthzRoleCount.Int(len(principal.Roles)),
+		telemetry.AttrAuthzCheckCount.Int(checks),
+	))
+}
+
+// recordAuthzBatch completes the batch span and counts each check's decision.
+// The batch's duration is not split across object types, so it is only
+// recorded on the span.
+func recordAuthzBatch(ctx context.Context, span trace.Span, checks []AuthCheck, results []bool, err error, elapsed time.Duration) {
+	span.SetAttributes(telemetry.AttrAuthzDurationMs.Float64(float64(elapsed) / float64(time.Millisecond)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	allowed := 0
+	for i, check := range checks {
+		decision := telemetry.DecisionDeny
+		if results[i] {
+			decision = telemetry.DecisionAllow
+			allowed++
+		}
+		countAuthzDecision(ctx, check.Obj, decision)
+	}
+	span.SetAttributes(telemetry.AttrAuthzAllowedCount.Int(allowed))
+}
+
+// recordEnforcerError marks span with an "authz.enforcer_error" event and
+// counts the failure, apart from ordinary denials.
+func recordEnforcerError(ctx context.Context, span trace.Span, obj string, err error) {
+	span.AddEvent("authz.enforcer_error", trace.WithAttributes(
+		telemetry.AttrAuthzObject.String(obj),
+		telemetry.AttrErrorMessage.String(err.Error()),
+	))
+	if authzInstruments.enforcerErrors != nil {
+		authzInstruments.enforcerErrors.Add(ctx, 1, metric.WithAttributes(telemetry.AttrAuthzObject.String(obj)))
+	}
+}
+
+func countAuthzDecision(ctx context.Context, obj, decision string) {
+	if authzInstruments.decisions != nil {
+		authzInstruments.decisions.Add(ctx, 1, metric.WithAttributes(
+			telemetry.AttrAuthzObject.String(obj),
+			telemetry.AttrAuthzDecision.String(decision),
+		))
+	}
+}
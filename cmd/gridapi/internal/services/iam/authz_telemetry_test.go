@@ -0,0 +1,104 @@
+package iam
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/telemetry"
+)
+
+// spanRecorder is a tracer provider keeping the attributes of ended spans.
+type spanRecorder struct {
+	noop.TracerProvider
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *spanRecorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return recordingTracer{recorder: r}
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	recorder *spanRecorder
+}
+
+func (t recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordedSpan{name: name, attrs: map[attribute.Key]attribute.Value{}, recorder: t.recorder}
+	config := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(config.Attributes()...)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordedSpan struct {
+	noop.Span
+	name     string
+	attrs    map[attribute.Key]attribute.Value
+	status   codes.Code
+	recorder *spanRecorder
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...attribute.KeyValue) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.spans = append(s.recorder.spans, s)
+}
+
+// Not parallel: installs a global tracer provider.
+func TestAuthorize_Telemetry(t *testing.T) {
+	recorder := &spanRecorder{}
+	otel.SetTracerProvider(recorder)
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	svc := &iamService{enforcer: newTestEnforcer(t,
+		[]string{auth.RoleID("reader"), auth.ObjectTypeState, auth.StateRead, auth.ScopeAll, auth.EffectAllow},
+	)}
+	ctx := context.Background()
+	principal := &Principal{Roles: []string{"reader", "auditor"}}
+
+	allowed, err := svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateRead, nil)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	allowed, err = svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateDelete, nil)
+	require.NoError(t, err)
+	require.False(t, allowed)
+	_, err = (&iamService{}).Authorize(ctx, principal, auth.ObjectTypePolicy, auth.PolicyRead, nil)
+	require.Error(t, err, "no enforcer")
+
+	require.Len(t, recorder.spans, 3)
+	for _, span := range recorder.spans {
+		assert.Equal(t, "iam.Authorize", span.name)
+		assert.Equal(t, int64(2), span.attrs[telemetry.AttrAuthzRoleCount].AsInt64())
+		assert.Contains(t, span.attrs, telemetry.AttrAuthzDurationMs)
+	}
+
+	allow, deny, failed := recorder.spans[0], recorder.spans[1], recorder.spans[2]
+	assert.Equal(t, auth.ObjectTypeState, allow.attrs[telemetry.AttrAuthzObject].AsString())
+	assert.Equal(t, auth.StateRead, allow.attrs[telemetry.AttrAuthzAction].AsString())
+	assert.Equal(t, telemetry.DecisionAllow, allow.attrs[telemetry.AttrAuthzDecision].AsString())
+
+	assert.Equal(t, auth.StateDelete, deny.attrs[telemetry.AttrAuthzAction].AsString())
+	assert.Equal(t, telemetry.DecisionDeny, deny.attrs[telemetry.AttrAuthzDecision].AsString())
+	assert.Equal(t, codes.Unset, deny.status, "a denial is not an error")
+
+	assert.Equal(t, telemetry.DecisionError, failed.attrs[telemetry.AttrAuthzDecision].AsString())
+	assert.Equal(t, codes.Error, failed.status)
+}
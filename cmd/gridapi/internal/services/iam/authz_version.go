@@ -0,0 +1,73 @@
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// AuthzModelVersion returns a hash of everything affecting authorization
+// decisions: the Casbin policies (role permissions and user/service account
+// role assignments), the group→role mappings with their conditions, and each
+// role's version (bumped by every role update).
+//
+// The value is derived from the model rather than counted, so every replica
+// enforcing the same model reports the same version, and it survives
+// restarts. It is not ordered: clients must treat any change as invalidating
+// cached decisions.
+func (s *iamService) AuthzModelVersion(ctx context.Context) (uint64, error) {
+	h := sha256.New()
+	// writeRecord writes fields NUL-separated so distinct records never collide
+	writeRecord := func(kind string, fields ...string) {
+		fmt.Fprintf(h, "%s\x00%s\x01", kind, strings.Join(fields, "\x00"))
+	}
+
+	policies, err := s.enforcer.GetPolicy()
+	if err != nil {
+		return 0, fmt.Errorf("get casbin policies: %w", err)
+	}
+	grouping, err := s.enforcer.GetGroupingPolicy()
+	if err != nil {
+		return 0, fmt.Errorf("get casbin grouping policies: %w", err)
+	}
+	for _, rules := range []struct {
+		kind  string
+		rules [][]string
+	}{{"p", policies}, {"g", grouping}} {
+		sorted := slices.Clone(rules.rules)
+		slices.SortFunc(sorted, slices.Compare)
+		for _, rule := range sorted {
+			writeRecord(rules.kind, rule...)
+		}
+	}
+
+	if snapshot := s.groupRoleCache.Get(); snapshot != nil {
+		groups := make([]string, 0, len(snapshot.Mappings))
+		for group := range snapshot.Mappings {
+			groups = append(groups, group)
+		}
+		sort.Strings(groups)
+		for _, group := range groups {
+			roles := slices.Clone(snapshot.Mappings[group])
+			sort.Strings(roles)
+			for _, role := range roles {
+				writeRecord("group", group, role, snapshot.Conditions[group][role])
+			}
+		}
+	}
+
+	roles, err := s.roles.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list roles: %w", err)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	for _, role := range roles {
+		writeRecord("role", role.ID, role.Name, fmt.Sprint(role.Version))
+	}
+
+	return binary.BigEndian.Uint64(h.Sum(nil)), nil
+}
@@ -0,0 +1,54 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthzModelVersion(t *testing.T) {
+	t.Parallel()
+
+	newSvc := func() *iamService {
+		svc := newTestService(t, roleCapRepos())
+		svc.groupSizes = fixedGroupSizes{"admins": 1}
+		return svc
+	}
+	svc := newSvc()
+	ctx := context.Background()
+
+	version := func(svc *iamService) uint64 {
+		t.Helper()
+		v, err := svc.AuthzModelVersion(ctx)
+		require.NoError(t, err)
+		return v
+	}
+
+	initial := version(svc)
+	require.Equal(t, initial, version(svc), "reads do not change the version")
+	require.Equal(t, initial, version(newSvc()),
+		"another replica with the same model agrees")
+
+	require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-admin"))
+	afterAssignment := version(svc)
+	require.NotEqual(t, initial, afterAssignment)
+
+	require.NoError(t, svc.AssignGroupRole(ctx, "admins", "role-admin", ""))
+	afterMapping := version(svc)
+	require.NotEqual(t, afterAssignment, afterMapping)
+
+	require.NoError(t, svc.RemoveGroupRole(ctx, "admins", "role-admin"))
+	require.Equal(t, afterAssignment, version(svc), "undoing a change restores the version")
+
+	// Reloading an unchanged model keeps the version
+	require.NoError(t, svc.RefreshGroupRoleCache(ctx))
+	require.Equal(t, afterAssignment, version(svc))
+
+	// BunRoleRepository.Update bumps the role version; the mock does not
+	role, err := svc.roles.GetByID(ctx, "role-admin")
+	require.NoError(t, err)
+	role.Version++
+	require.NoError(t, svc.roles.Update(ctx, role))
+	require.NotEqual(t, afterAssignment, version(svc), "role updates change the version")
+}
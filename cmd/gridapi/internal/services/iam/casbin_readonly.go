@@ -3,15 +3,52 @@ package iam
 import (
 	"fmt"
 	"log"
+	"slices"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 )
 
-// AuthorizeWithRoles checks if ANY of the principal's roles grants permission for the requested action.
+// ScopeCombination controls how the permissions of a principal's roles combine.
+type ScopeCombination string
+
+const (
+	// ScopeUnion grants access when any role permits it (the default).
+	ScopeUnion ScopeCombination = "union"
+	// ScopeIntersection grants access only when every role permits it.
+	ScopeIntersection ScopeCombination = "intersection"
+)
+
+// ScopeCombinationPolicy lists the object types whose roles combine with
+// ScopeIntersection (config.Config.ScopeIntersectionObjectTypes). All other
+// object types use ScopeUnion.
+type ScopeCombinationPolicy []string
+
+// For returns the combination used for objType.
+func (p ScopeCombinationPolicy) For(objType string) ScopeCombination {
+	if slices.Contains(p, objType) {
+		return ScopeIntersection
+	}
+	return ScopeUnion
+}
+
+// AuthorizeWithRoles checks whether the principal's roles grant permission for the requested action.
 //
 // This is a READ-ONLY authorization check that never mutates Casbin state. It queries the enforcer
-// with each role in the principal's role list and returns true if at least one role allows the action.
+// with each role in the principal's role list. With ScopeUnion it returns true if at least one role
+// allows the action; with ScopeIntersection every role must allow it.
+//
+// Deny rules override allows across roles: if any role carries a matching deny policy, access is
+// refused even when another role grants it. Union therefore checks every role rather than stopping
+// at the first allow.
+//
+// Intersection narrows access: a role that carries no policy for the action at all (for example an
+// admin-only role held alongside a state-reader role) also denies, so enable it only for object types
+// where every role a principal may hold is meant to constrain that type.
+//
+// tokenScopes down-scopes a token: when non-empty, the action must also be covered by one of the
+// permission scopes the token was issued for (see auth.TokenScopesAllow), so the result is the
+// intersection of what the roles grant and what the token asked for. Nil leaves the roles in charge.
 //
 // The enforcer is queried with role principals (e.g., "role:product-engineer") which are defined
 // in the static Casbin policy. This eliminates the need for dynamic user→group→role mappings.
@@ -22,9 +59,15 @@ import (
 //   - obj: Object type (e.g., "state", "admin", "policy") or specific resource ID
 //   - act: Action being requested (e.g., "state:create", "state:read", "admin:role:manage")
 //   - labels: Resource-specific attributes for label-based filtering (e.g., state labels)
+//   - combination: ScopeUnion or ScopeIntersection (see ScopeCombinationPolicy)
+//   - tokenScopes: Permission scopes the token is limited to (nil when not down-scoped)
+//
+// Label scopes are evaluated by auth.EvaluateBexpr: a policy scoped to auth.ScopeAll matches
+// any labels, while an empty scope matches none. Legacy empty-scope policies are rewritten at
+// startup by MigrateEmptyRoleScopes.
 //
 // Returns:
-//   - bool: true if at least one role grants permission, false otherwise
+//   - bool: true if the roles grant permission under combination, false otherwise
 //   - error: Any error during enforcement (e.g., enforcer failure, invalid policy)
 //
 // Thread Safety:
@@ -33,14 +76,14 @@ import (
 //
 // Performance:
 //   - O(n) where n = number of roles (typically 1-5 roles per user)
-//   - Each enforcer.Enforce() call is fast (cached policy evaluation)
+//   - Each enforcer.EnforceEx() call is fast (cached policy evaluation)
 //   - No database queries or blocking I/O
 //
 // Example:
 //
 //	roles := []string{"product-engineer", "viewer"}
 //	labels := map[string]interface{}{"env": "dev"}
-//	allowed, err := AuthorizeWithRoles(enforcer, roles, "state", "state:read", labels)
+//	allowed, err := AuthorizeWithRoles(enforcer, roles, "state", "state:read", labels, ScopeUnion, nil)
 //	if err != nil {
 //	    return fmt.Errorf("authorization error: %w", err)
 //	}
@@ -52,44 +95,64 @@ func AuthorizeWithRoles(
 	roles []string,
 	obj, act string,
 	labels map[string]interface{},
+	combination ScopeCombination,
+	tokenScopes []string,
 ) (bool, error) {
+	decision, err := DecideWithRoles(enforcer, roles, obj, act, labels, combination, tokenScopes)
+	return decision.Allowed, err
+}
+
+// Decision is the outcome of an authorization check.
+type Decision struct {
+	Allowed bool
+	// ExplicitDeny is set when a role's deny rule ("!obj:act") matched. Such
+	// a denial is final: owner override must not grant the action either.
+	ExplicitDeny bool
+}
+
+// DecideWithRoles is AuthorizeWithRoles, also reporting whether the denial
+// came from an explicit deny rule.
+func DecideWithRoles(
+	enforcer casbin.IEnforcer,
+	roles []string,
+	obj, act string,
+	labels map[string]interface{},
+	combination ScopeCombination,
+	tokenScopes []string,
+) (Decision, error) {
 	if enforcer == nil {
-		return false, fmt.Errorf("casbin enforcer not initialized")
+		return Decision{}, fmt.Errorf("casbin enforcer not initialized")
 	}
 
 	// Handle empty roles: deny by default (no roles = no permissions)
 	if len(roles) == 0 {
 		log.Printf("authorization denied: principal has no roles (obj=%s, act=%s)", obj, act)
-		return false, nil
+		return Decision{}, nil
 	}
 
-	// Ensure labels is never nil (Casbin expects map[string]interface{})
-	if labels == nil {
-		labels = make(map[string]any)
+	// A down-scoped token never exceeds its scopes, whatever its roles allow
+	if !auth.TokenScopesAllow(tokenScopes, act) {
+		log.Printf("authorization denied: token scopes %v do not cover %s on %s", tokenScopes, act, obj)
+		return Decision{}, nil
 	}
 
-	// Try each role until one grants permission
-	for _, roleName := range roles {
-		// Convert role name to Casbin principal ID (e.g., "product-engineer" → "role:product-engineer")
-		rolePrincipal := auth.RoleID(roleName)
-
-		log.Printf("authorization check: role=%s, obj=%s, act=%s, labels=%v", rolePrincipal, obj, act, labels)
-
-		// Query Casbin enforcer (READ-ONLY - no AddGroupingPolicy!)
-		allowed, err := enforcer.Enforce(rolePrincipal, obj, act, labels)
-		if err != nil {
-			// Log error but continue checking other roles
-			log.Printf("error checking role %s: %v", rolePrincipal, err)
-			return false, fmt.Errorf("casbin enforce error for role %s: %w", rolePrincipal, err)
-		}
+	// Evaluate every role; the explanation holds the combined decision
+	explanation, err := AuthorizeExplain(enforcer, roles, obj, act, labels, combination)
+	if err != nil {
+		log.Printf("error checking roles %v: %v", roles, err)
+		return Decision{}, err
+	}
 
-		if allowed {
-			log.Printf("authorization granted: role %s allows %s on %s", rolePrincipal, act, obj)
-			return true, nil // At least one role allows - grant permission
-		}
+	if explanation.Allowed {
+		log.Printf("authorization granted: %s", explanation.Reason)
+	} else {
+		log.Printf("authorization denied: %s (labels=%v)", explanation.Reason, labels)
 	}
+	return explanation.Decision(), nil
+}
 
-	// No role granted permission
-	log.Printf("authorization denied: no role in %v allows %s on %s (labels=%v)", roles, act, obj, labels)
-	return false, nil
+// isDenyPolicy reports whether policy ([role, obj, act, scopeExpr, eft]) is a
+// deny rule.
+func isDenyPolicy(policy []string) bool {
+	return len(policy) >= 5 && policy[4] == auth.EffectDeny
 }
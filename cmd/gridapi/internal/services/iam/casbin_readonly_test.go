@@ -0,0 +1,147 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+)
+
+// TestAuthorizeWithRoles_ScopeCombination gives one principal two roles whose
+// scopes overlap only partly: union grants what either role allows, while
+// intersection grants only what both allow.
+func TestAuthorizeWithRoles_ScopeCombination(t *testing.T) {
+	t.Parallel()
+
+	enforcer := newTestEnforcer(t,
+		[]string{auth.RoleID("dev-reader"), auth.ObjectTypeState, auth.StateRead, `env == "dev"`, "allow"},
+		[]string{auth.RoleID("platform-reader"), auth.ObjectTypeState, auth.StateRead, `team == "platform"`, "allow"},
+	)
+	roles := []string{"dev-reader", "platform-reader"}
+
+	tests := []struct {
+		name             string
+		labels           map[string]interface{}
+		wantUnion        bool
+		wantIntersection bool
+	}{
+		{name: "both scopes match", labels: map[string]interface{}{"env": "dev", "team": "platform"}, wantUnion: true, wantIntersection: true},
+		{name: "only dev scope matches", labels: map[string]interface{}{"env": "dev", "team": "data"}, wantUnion: true, wantIntersection: false},
+		{name: "only platform scope matches", labels: map[string]interface{}{"env": "prod", "team": "platform"}, wantUnion: true, wantIntersection: false},
+		{name: "neither scope matches", labels: map[string]interface{}{"env": "prod", "team": "data"}, wantUnion: false, wantIntersection: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := AuthorizeWithRoles(enforcer, roles, auth.ObjectTypeState, auth.StateRead, tt.labels, ScopeUnion, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUnion, allowed, "union")
+
+			allowed, err = AuthorizeWithRoles(enforcer, roles, auth.ObjectTypeState, auth.StateRead, tt.labels, ScopeIntersection, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIntersection, allowed, "intersection")
+		})
+	}
+}
+
+// TestAuthorizeWithRoles_DenyOverrides holds a broad allow alongside a deny
+// scoped to PCI states: the deny wins for those states under both
+// combinations, whichever role is checked first.
+func TestAuthorizeWithRoles_DenyOverrides(t *testing.T) {
+	t.Parallel()
+
+	enforcer := newTestEnforcer(t,
+		[]string{auth.RoleID("platform-engineer"), "*", "*", auth.ScopeAll, auth.EffectAllow},
+		[]string{auth.RoleID("pci-guard"), auth.ObjectTypeState, auth.TfstateWrite, `compliance == "pci"`, auth.EffectDeny},
+		[]string{auth.RoleID("pci-guard"), auth.ObjectTypeState, auth.TfstateWrite, auth.ScopeAll, auth.EffectAllow},
+	)
+	pci := map[string]interface{}{"compliance": "pci"}
+	other := map[string]interface{}{"compliance": "none"}
+
+	tests := []struct {
+		name    string
+		roles   []string
+		act     string
+		labels  map[string]interface{}
+		allowed bool
+	}{
+		{name: "deny beats broader allow", roles: []string{"platform-engineer", "pci-guard"}, act: auth.TfstateWrite, labels: pci, allowed: false},
+		{name: "deny beats allow regardless of role order", roles: []string{"pci-guard", "platform-engineer"}, act: auth.TfstateWrite, labels: pci, allowed: false},
+		{name: "deny outside its scope does not apply", roles: []string{"platform-engineer", "pci-guard"}, act: auth.TfstateWrite, labels: other, allowed: true},
+		{name: "deny covers only its action", roles: []string{"platform-engineer", "pci-guard"}, act: auth.TfstateRead, labels: pci, allowed: true},
+		{name: "deny beats allow within one role", roles: []string{"pci-guard"}, act: auth.TfstateWrite, labels: pci, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := AuthorizeWithRoles(enforcer, tt.roles, auth.ObjectTypeState, tt.act, tt.labels, ScopeUnion, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, allowed, "union")
+
+			if !tt.allowed {
+				allowed, err = AuthorizeWithRoles(enforcer, tt.roles, auth.ObjectTypeState, tt.act, tt.labels, ScopeIntersection, nil)
+				require.NoError(t, err)
+				assert.False(t, allowed, "intersection")
+			}
+		})
+	}
+}
+
+// TestAuthorizeWithRoles_TokenScopes gives a CI service account a role that
+// may read and write state, then down-scopes its token: the token is held to
+// the intersection of its roles and its permission scopes.
+func TestAuthorizeWithRoles_TokenScopes(t *testing.T) {
+	t.Parallel()
+
+	enforcer := newTestEnforcer(t,
+		[]string{auth.RoleID("ci-deployer"), auth.ObjectTypeState, auth.StateRead, auth.ScopeAll, auth.EffectAllow},
+		[]string{auth.RoleID("ci-deployer"), auth.ObjectTypeState, auth.TfstateRead, auth.ScopeAll, auth.EffectAllow},
+		[]string{auth.RoleID("ci-deployer"), auth.ObjectTypeState, auth.TfstateWrite, auth.ScopeAll, auth.EffectAllow},
+	)
+	roles := []string{"ci-deployer"}
+
+	tests := []struct {
+		name        string
+		tokenScopes []string
+		act         string
+		allowed     bool
+	}{
+		{name: "full token may write", tokenScopes: nil, act: auth.TfstateWrite, allowed: true},
+		{name: "read-only token may read", tokenScopes: []string{auth.StateRead}, act: auth.StateRead, allowed: true},
+		{name: "read-only token is denied the write its role allows", tokenScopes: []string{auth.StateRead}, act: auth.TfstateWrite, allowed: false},
+		{name: "wildcard scope covers its actions", tokenScopes: []string{auth.TfstateWildcard}, act: auth.TfstateWrite, allowed: true},
+		{name: "scopes never exceed the roles", tokenScopes: []string{auth.StateDelete}, act: auth.StateDelete, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := AuthorizeWithRoles(enforcer, roles, auth.ObjectTypeState, tt.act, nil, ScopeUnion, tt.tokenScopes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+
+	t.Run("through Authorize", func(t *testing.T) {
+		svc := &iamService{enforcer: enforcer}
+		principal := &Principal{Type: PrincipalTypeServiceAccount, Roles: roles, TokenScopes: []string{auth.StateRead, auth.TfstateRead}}
+
+		allowed, err := svc.Authorize(context.Background(), principal, auth.ObjectTypeState, auth.TfstateRead, nil)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = svc.Authorize(context.Background(), principal, auth.ObjectTypeState, auth.TfstateWrite, nil)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+}
+
+func TestScopeCombinationPolicy_For(t *testing.T) {
+	t.Parallel()
+
+	policy := ScopeCombinationPolicy{auth.ObjectTypeState}
+	assert.Equal(t, ScopeIntersection, policy.For(auth.ObjectTypeState))
+	assert.Equal(t, ScopeUnion, policy.For(auth.ObjectTypeAdmin))
+	assert.Equal(t, ScopeUnion, ScopeCombinationPolicy(nil).For(auth.ObjectTypeState))
+}
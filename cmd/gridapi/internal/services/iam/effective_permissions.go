@@ -0,0 +1,30 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+)
+
+// EffectivePermission is one action a principal holds through one of its
+// roles, with the label scope it applies under. Deny rules are included with
+// their "!" prefix so callers can show what is withheld as well as granted.
+type EffectivePermission struct {
+	Role string `json:"role"`
+	RoleAction
+}
+
+// GetEffectivePermissions lists the actions granted or denied by roles, in
+// role order. Roles without Casbin policies contribute nothing.
+func (s *iamService) GetEffectivePermissions(ctx context.Context, roles []string) ([]EffectivePermission, error) {
+	permissions := []EffectivePermission{}
+	for _, roleName := range roles {
+		actions, err := s.casbinRoleActions(roleName)
+		if err != nil {
+			return nil, fmt.Errorf("get actions for role %s: %w", roleName, err)
+		}
+		for _, action := range actions {
+			permissions = append(permissions, EffectivePermission{Role: roleName, RoleAction: action})
+		}
+	}
+	return permissions, nil
+}
@@ -0,0 +1,120 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrEnforcerCircuitOpen is returned, with a denial, while repeated enforcer
+// errors keep the authorization breaker open.
+var ErrEnforcerCircuitOpen = errors.New("authorization unavailable: casbin enforcer circuit open")
+
+// enforcerBreakerThreshold is how many consecutive enforcer errors trip the
+// breaker.
+const enforcerBreakerThreshold = 5
+
+// enforcerBreakerCooldown is how long a tripped breaker rejects checks before
+// letting one through to probe the enforcer again.
+const enforcerBreakerCooldown = 30 * time.Second
+
+// enforcerBreaker counts consecutive enforcer errors. Once
+// enforcerBreakerThreshold is reached it opens: checks are denied without
+// querying the enforcer until the cooldown passes, and Health reports the
+// enforcer unavailable. The first check after the cooldown probes the
+// enforcer while the rest keep being denied; a success closes the breaker,
+// an error re-opens it.
+//
+// The zero value is a closed breaker.
+type enforcerBreaker struct {
+	mu       sync.Mutex
+	failures int       // Consecutive enforcer errors
+	openedAt time.Time // Zero while closed
+	probing  bool      // A check admitted after the cooldown has not been recorded yet
+	lastErr  error
+}
+
+// allow returns ErrEnforcerCircuitOpen while the breaker is open, except for
+// the single probe admitted once the cooldown has passed.
+func (b *enforcerBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return nil
+	}
+	if !b.probing && now.Sub(b.openedAt) >= enforcerBreakerCooldown {
+		b.probing = true
+		return nil
+	}
+	return fmt.Errorf("%w after %d consecutive errors (last: %v)", ErrEnforcerCircuitOpen, b.failures, b.lastErr)
+}
+
+// record notes the outcome of an enforcer query and reports whether it
+// tripped the breaker.
+func (b *enforcerBreaker) record(err error, now time.Time) (tripped bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		b.lastErr = nil
+		return false
+	}
+
+	b.failures++
+	b.lastErr = err
+	if b.failures < enforcerBreakerThreshold {
+		return false
+	}
+	tripped = b.openedAt.IsZero()
+	b.openedAt = now // A failed probe restarts the cooldown
+	return tripped
+}
+
+// health reports an open breaker as an unavailable enforcer; ok is false
+// while the breaker is closed.
+func (b *enforcerBreaker) health() (health ComponentHealth, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return ComponentHealth{}, false
+	}
+	return ComponentHealth{
+		Status: HealthStatusUnavailable,
+		Error:  fmt.Sprintf("authorization circuit open since %s after %d consecutive enforcer errors (last: %v)", b.openedAt.Format(time.RFC3339), b.failures, b.lastErr),
+	}, true
+}
+
+// guardEnforcer runs query, which consults the enforcer, behind the breaker.
+// Enforcer errors and panics are returned as errors, recorded on span and
+// counted (see recordEnforcerError); callers must treat any error as a
+// denial.
+func (s *iamService) guardEnforcer(ctx context.Context, span trace.Span, obj string, query func() error) error {
+	now := time.Now()
+	if err := s.enforcerBreaker.allow(now); err != nil {
+		return err
+	}
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("casbin enforcer panicked: %v", r)
+			}
+		}()
+		return query()
+	}()
+
+	if s.enforcerBreaker.record(err, now) {
+		log.Printf("authorization circuit open: %d consecutive enforcer errors, denying checks for %s (last: %v)", enforcerBreakerThreshold, enforcerBreakerCooldown, err)
+	}
+	if err != nil {
+		recordEnforcerError(ctx, span, obj, err)
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+)
+
+// flakyEnforcer fails authorization queries while failing is set, but keeps
+// answering the health probe.
+type flakyEnforcer struct {
+	casbin.IEnforcer
+	failing atomic.Bool
+	panics  bool
+	queries atomic.Int32
+}
+
+func (e *flakyEnforcer) EnforceEx(rvals ...interface{}) (bool, []string, error) {
+	e.queries.Add(1)
+	if e.failing.Load() {
+		if e.panics {
+			panic("matcher blew up")
+		}
+		return false, nil, errors.New("policy adapter lost")
+	}
+	return e.IEnforcer.EnforceEx(rvals...)
+}
+
+func (e *flakyEnforcer) GetImplicitPermissionsForUser(user string, domain ...string) ([][]string, error) {
+	e.queries.Add(1)
+	if e.failing.Load() {
+		return nil, errors.New("policy adapter lost")
+	}
+	return e.IEnforcer.GetImplicitPermissionsForUser(user, domain...)
+}
+
+func newFlakyTestService(t *testing.T) (*iamService, *flakyEnforcer) {
+	t.Helper()
+	enforcer := &flakyEnforcer{IEnforcer: newTestEnforcer(t,
+		[]string{auth.RoleID("admin"), "*", "*", auth.ScopeAll, auth.EffectAllow},
+	)}
+	return &iamService{groupRoleCache: healthTestCache(time.Now()), enforcer: enforcer}, enforcer
+}
+
+func TestAuthorize_EnforcerErrorFailsClosed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	principal := &Principal{Roles: []string{"admin"}}
+
+	t.Run("error", func(t *testing.T) {
+		svc, enforcer := newFlakyTestService(t)
+		enforcer.failing.Store(true)
+
+		allowed, err := svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateRead, nil)
+		require.Error(t, err)
+		assert.False(t, allowed)
+		assert.ErrorContains(t, err, "policy adapter lost")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		svc, enforcer := newFlakyTestService(t)
+		enforcer.panics = true
+		enforcer.failing.Store(true)
+
+		allowed, err := svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateRead, nil)
+		require.Error(t, err)
+		assert.False(t, allowed)
+		assert.ErrorContains(t, err, "matcher blew up")
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		svc, enforcer := newFlakyTestService(t)
+		enforcer.failing.Store(true)
+
+		results, err := svc.AuthorizeBatch(ctx, principal, []AuthCheck{{Obj: auth.ObjectTypeState, Act: auth.StateRead}})
+		require.Error(t, err)
+		assert.Nil(t, results)
+	})
+}
+
+func TestAuthorize_EnforcerBreaker(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	principal := &Principal{Roles: []string{"admin"}}
+	svc, enforcer := newFlakyTestService(t)
+
+	enforcer.failing.Store(true)
+	for range enforcerBreakerThreshold - 1 {
+		_, err := svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateRead, nil)
+		require.Error(t, err)
+	}
+	assert.Equal(t, HealthStatusOK, svc.Health(ctx).Enforcer.Status, "below the threshold the breaker stays closed")
+
+	_, err := svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateRead, nil)
+	require.Error(t, err)
+
+	// Open: the probe still answers, but health reports the breaker
+	report := svc.Health(ctx)
+	assert.Equal(t, HealthStatusUnavailable, report.Status)
+	assert.False(t, report.Ready())
+	assert.Equal(t, HealthStatusUnavailable, report.Enforcer.Status)
+	assert.Contains(t, report.Enforcer.Error, "authorization circuit open")
+	assert.Contains(t, report.Enforcer.Error, "policy adapter lost")
+
+	// Open: checks are denied without querying the enforcer, even once it heals
+	enforcer.failing.Store(false)
+	queries := enforcer.queries.Load()
+	allowed, err := svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateRead, nil)
+	assert.ErrorIs(t, err, ErrEnforcerCircuitOpen)
+	assert.False(t, allowed)
+	_, err = svc.AuthorizeBatch(ctx, principal, []AuthCheck{{Obj: auth.ObjectTypeState, Act: auth.StateRead}})
+	assert.ErrorIs(t, err, ErrEnforcerCircuitOpen)
+	assert.Equal(t, queries, enforcer.queries.Load())
+
+	// After the cooldown a successful check closes the breaker
+	svc.enforcerBreaker.mu.Lock()
+	svc.enforcerBreaker.openedAt = time.Now().Add(-enforcerBreakerCooldown)
+	svc.enforcerBreaker.mu.Unlock()
+
+	allowed, err = svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.StateRead, nil)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, HealthStatusOK, svc.Health(ctx).Enforcer.Status)
+}
+
+func TestEnforcerBreaker_FailedProbeReopens(t *testing.T) {
+	t.Parallel()
+
+	var b enforcerBreaker
+	start := time.Now()
+	for range enforcerBreakerThreshold {
+		b.record(errors.New("down"), start)
+	}
+	require.ErrorIs(t, b.allow(start), ErrEnforcerCircuitOpen)
+
+	probe := start.Add(enforcerBreakerCooldown)
+	require.NoError(t, b.allow(probe), "one check probes the enforcer after the cooldown")
+	assert.ErrorIs(t, b.allow(probe), ErrEnforcerCircuitOpen, "other checks are denied while the probe runs")
+	assert.False(t, b.record(errors.New("still down"), probe), "already open")
+	assert.ErrorIs(t, b.allow(probe.Add(time.Second)), ErrEnforcerCircuitOpen, "a failed probe restarts the cooldown")
+}
+
+func TestEnforcerBreaker_SuccessfulProbeCloses(t *testing.T) {
+	t.Parallel()
+
+	var b enforcerBreaker
+	start := time.Now()
+	for range enforcerBreakerThreshold {
+		b.record(errors.New("down"), start)
+	}
+
+	probe := start.Add(enforcerBreakerCooldown)
+	require.NoError(t, b.allow(probe))
+	assert.ErrorIs(t, b.allow(probe), ErrEnforcerCircuitOpen, "only one probe is admitted")
+	b.record(nil, probe)
+	assert.NoError(t, b.allow(probe), "a successful probe closes the breaker")
+	assert.NoError(t, b.allow(probe))
+}
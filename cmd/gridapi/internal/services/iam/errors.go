@@ -0,0 +1,53 @@
+package iam
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// Role management and assignment methods wrap these errors so callers can
+// tell failures apart with errors.Is instead of matching messages.
+var (
+	// ErrRoleNotFound is returned when the named or referenced role does not
+	// exist.
+	ErrRoleNotFound = errors.New("role not found")
+
+	// ErrRoleExists is returned by CreateRole when a role with the name
+	// already exists.
+	ErrRoleExists = errors.New("role already exists")
+
+	// ErrRoleInUse is returned by DeleteRole while the role is still assigned
+	// to principals.
+	ErrRoleInUse = errors.New("role is still assigned")
+
+	// ErrDuplicateAssignment is returned when assigning a role the principal
+	// or group already holds.
+	ErrDuplicateAssignment = errors.New("already assigned")
+
+	// ErrAssignmentCapExceeded is returned when an assignment would exceed
+	// the role's MaxAssignments.
+	ErrAssignmentCapExceeded = errors.New("assignment cap exceeded")
+
+	// ErrGroupSizeUnknown is returned when mapping a group to a role with an
+	// assignment cap while no group size estimator is configured.
+	ErrGroupSizeUnknown = errors.New("group sizes cannot be estimated")
+
+	// ErrVersionConflict is returned by UpdateRole when the role was modified
+	// since the expected version was read.
+	ErrVersionConflict = errors.New("version mismatch")
+
+	// ErrPrincipalAmbiguous is returned when a role assignment names both or
+	// neither of a user and a service account.
+	ErrPrincipalAmbiguous = errors.New("exactly one of userID or serviceAccountID must be specified")
+)
+
+// roleLookupError reports a failed role lookup, as ErrRoleNotFound when the
+// role does not exist.
+func roleLookupError(err error, role string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrRoleNotFound, role)
+	}
+	return fmt.Errorf("get role: %w", err)
+}
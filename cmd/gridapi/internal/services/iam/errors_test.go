@@ -0,0 +1,75 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestRoleErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// Each case starts with user alice and a "viewer" role at version 1
+	newSvc := func(t *testing.T) *iamService {
+		return newTestService(t, testRepos{
+			users: &mockUserRepository{users: map[string]*models.User{
+				"alice": {ID: "user-alice", Subject: strPtr("alice")},
+			}},
+			roles: &mockRoleRepository{roles: map[string]*models.Role{
+				"role-viewer": {ID: "role-viewer", Name: "viewer", Version: 1},
+			}},
+		})
+	}
+	updateViewer := func(svc *iamService, name string, version int) error {
+		_, err := svc.UpdateRole(ctx, name, version, "", auth.ScopeAll, nil, nil, nil, nil, []string{auth.StateRead}, false)
+		return err
+	}
+
+	t.Run("role not found", func(t *testing.T) {
+		svc := newSvc(t)
+		assert.ErrorIs(t, updateViewer(svc, "ghost", 1), ErrRoleNotFound)
+		assert.ErrorIs(t, svc.DeleteRole(ctx, "ghost"), ErrRoleNotFound)
+		assert.ErrorIs(t, svc.AssignUserRole(ctx, "user-alice", "", "role-ghost"), ErrRoleNotFound)
+		assert.ErrorIs(t, svc.RemoveUserRole(ctx, "user-alice", "", "role-ghost"), ErrRoleNotFound)
+		_, err := svc.GetRoleByName(ctx, "ghost")
+		assert.ErrorIs(t, err, ErrRoleNotFound)
+	})
+
+	t.Run("role exists", func(t *testing.T) {
+		svc := newSvc(t)
+		_, err := svc.CreateRole(ctx, "viewer", "", auth.ScopeAll, nil, nil, nil, nil, nil, false)
+		assert.ErrorIs(t, err, ErrRoleExists)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		svc := newSvc(t)
+		assert.ErrorIs(t, updateViewer(svc, "viewer", 7), ErrVersionConflict)
+		assert.NoError(t, updateViewer(svc, "viewer", 1))
+	})
+
+	t.Run("duplicate assignment", func(t *testing.T) {
+		svc := newSvc(t)
+		require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-viewer"))
+		err := svc.AssignUserRole(ctx, "user-alice", "", "role-viewer")
+		assert.ErrorIs(t, err, ErrDuplicateAssignment)
+		assert.EqualError(t, err, "role 'viewer' already assigned to principal")
+	})
+
+	t.Run("role in use", func(t *testing.T) {
+		svc := newSvc(t)
+		require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-viewer"))
+		assert.ErrorIs(t, svc.DeleteRole(ctx, "viewer"), ErrRoleInUse)
+	})
+
+	t.Run("principal ambiguous", func(t *testing.T) {
+		svc := newSvc(t)
+		assert.ErrorIs(t, svc.AssignUserRole(ctx, "", "", "role-viewer"), ErrPrincipalAmbiguous)
+		assert.ErrorIs(t, svc.RemoveUserRole(ctx, "user-alice", "sa-ci", "role-viewer"), ErrPrincipalAmbiguous)
+	})
+}
@@ -3,10 +3,13 @@ package iam
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+	"golang.org/x/sync/singleflight"
 )
 
 // GroupRoleCache provides lock-free access to group→role mappings.
@@ -21,6 +24,8 @@ type GroupRoleCache struct {
 	snapshot      atomic.Value // Holds *GroupRoleSnapshot
 	groupRoleRepo repository.GroupRoleRepository
 	roleRepo      repository.RoleRepository
+	normalization auth.GroupNameNormalization // Applied to mapping keys and looked-up groups
+	refreshes     singleflight.Group          // Coalesces RefreshIfOlderThan calls
 }
 
 // NewGroupRoleCache creates a new cache and performs initial load from database.
@@ -28,9 +33,20 @@ type GroupRoleCache struct {
 // Returns error if initial load fails (e.g., database unavailable).
 // The cache must be successfully initialized before the server can start.
 func NewGroupRoleCache(groupRoleRepo repository.GroupRoleRepository, roleRepo repository.RoleRepository) (*GroupRoleCache, error) {
+	return NewGroupRoleCacheWithNormalization(groupRoleRepo, roleRepo, auth.DefaultGroupNameNormalization)
+}
+
+// NewGroupRoleCacheWithNormalization is NewGroupRoleCache with an explicit
+// group name normalization.
+func NewGroupRoleCacheWithNormalization(
+	groupRoleRepo repository.GroupRoleRepository,
+	roleRepo repository.RoleRepository,
+	normalization auth.GroupNameNormalization,
+) (*GroupRoleCache, error) {
 	cache := &GroupRoleCache{
 		groupRoleRepo: groupRoleRepo,
 		roleRepo:      roleRepo,
+		normalization: normalization,
 	}
 
 	// Perform initial load (must succeed for server to start)
@@ -42,6 +58,12 @@ func NewGroupRoleCache(groupRoleRepo repository.GroupRoleRepository, roleRepo re
 	return cache, nil
 }
 
+// NormalizeGroupName normalizes a group name the way the cache keys mappings,
+// so group-role assignments are stored under the name lookups will use.
+func (c *GroupRoleCache) NormalizeGroupName(name string) string {
+	return auth.NormalizeGroupName(name, c.normalization)
+}
+
 // Get returns the current snapshot for lock-free reads.
 //
 // This method never blocks and has O(1) latency. Safe for concurrent
@@ -80,6 +102,7 @@ func (c *GroupRoleCache) Refresh(ctx context.Context) error {
 
 	// Step 2: Build new mappings (on stack, not visible to readers yet)
 	newMappings := make(map[string][]string)
+	newConditions := make(map[string]map[string]string)
 	roleCache := make(map[string]string) // roleID → roleName cache
 
 	for _, assignment := range assignments {
@@ -94,8 +117,17 @@ func (c *GroupRoleCache) Refresh(ctx context.Context) error {
 			roleCache[assignment.RoleID] = roleName
 		}
 
-		// Add to mappings (group can have multiple roles)
-		newMappings[assignment.GroupName] = append(newMappings[assignment.GroupName], roleName)
+		// Add to mappings (group can have multiple roles). Keys are normalized
+		// so assignments stored before normalization still match.
+		groupName := c.NormalizeGroupName(assignment.GroupName)
+		newMappings[groupName] = append(newMappings[groupName], roleName)
+
+		if assignment.Condition != nil && strings.TrimSpace(*assignment.Condition) != "" {
+			if newConditions[groupName] == nil {
+				newConditions[groupName] = make(map[string]string)
+			}
+			newConditions[groupName][roleName] = *assignment.Condition
+		}
 	}
 
 	// Step 3: Get previous version for incrementing
@@ -106,9 +138,10 @@ func (c *GroupRoleCache) Refresh(ctx context.Context) error {
 
 	// Step 4: Create immutable snapshot
 	newSnapshot := &GroupRoleSnapshot{
-		Mappings:  newMappings,
-		CreatedAt: time.Now(),
-		Version:   prevVersion + 1,
+		Mappings:   newMappings,
+		Conditions: newConditions,
+		CreatedAt:  time.Now(),
+		Version:    prevVersion + 1,
 	}
 
 	// Step 5: Atomic swap - all readers see new snapshot immediately
@@ -117,15 +150,60 @@ func (c *GroupRoleCache) Refresh(ctx context.Context) error {
 	return nil
 }
 
-// GetRolesForGroups computes the union of roles for the given groups.
+// RefreshIfOlderThan refreshes the cache unless the current snapshot is
+// younger than maxAge, and reports whether a newer snapshot was loaded.
 //
-// This is a PURE FUNCTION with no side effects:
-//   - No database queries
-//   - No state mutation
-//   - Uses lock-free cache read (Get())
+// Concurrent callers share a single refresh, so a burst of logins that all
+// find the cache stale costs one database load. The refresh is not tied to
+// the first caller's cancellation, since the others are waiting on it.
+func (c *GroupRoleCache) RefreshIfOlderThan(ctx context.Context, maxAge time.Duration) (bool, error) {
+	fresh := func() bool {
+		snapshot := c.Get()
+		return snapshot != nil && time.Since(snapshot.CreatedAt) < maxAge
+	}
+	if fresh() {
+		return false, nil
+	}
+
+	before := c.version()
+	_, err, _ := c.refreshes.Do("refresh", func() (any, error) {
+		if fresh() {
+			return nil, nil // Refreshed while this caller waited for the group
+		}
+		return nil, c.Refresh(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return false, err
+	}
+	return c.version() != before, nil
+}
+
+// HasUnmappedGroups reports whether any of groups has no mapping in the
+// current snapshot.
+func (c *GroupRoleCache) HasUnmappedGroups(groups []string) bool {
+	snapshot := c.Get()
+	if snapshot == nil {
+		return len(groups) > 0
+	}
+	for _, groupName := range groups {
+		if _, ok := snapshot.Mappings[c.NormalizeGroupName(groupName)]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *GroupRoleCache) version() int {
+	if snapshot := c.Get(); snapshot != nil {
+		return snapshot.Version
+	}
+	return 0
+}
+
+// GetRolesForGroups computes the union of roles for the given groups.
 //
-// Returns deduplicated list of role names. If a role is granted by multiple
-// groups, it appears once in the result.
+// Conditional mappings are never granted here because there are no claims to
+// evaluate them against; use GetRolesForGroupsWithClaims on the auth path.
 //
 // Examples:
 //   - GetRolesForGroups(["platform-engineers"]) → ["platform-engineer"]
@@ -133,6 +211,22 @@ func (c *GroupRoleCache) Refresh(ctx context.Context) error {
 //   - GetRolesForGroups([]) → []
 //   - GetRolesForGroups(["unknown-group"]) → []
 func (c *GroupRoleCache) GetRolesForGroups(groups []string) []string {
+	return c.GetRolesForGroupsWithClaims(groups, nil)
+}
+
+// GetRolesForGroupsWithClaims computes the union of roles for the given
+// groups, granting a conditional mapping only when its condition evaluates
+// true against claims.
+//
+// This is a PURE FUNCTION with no side effects:
+//   - No database queries
+//   - No state mutation
+//   - Uses lock-free cache read (Get())
+//
+// Returns deduplicated list of role names. If a role is granted by multiple
+// groups, it appears once in the result. A condition that fails to evaluate
+// (e.g., the claim is missing) does not grant the role.
+func (c *GroupRoleCache) GetRolesForGroupsWithClaims(groups []string, claims map[string]any) []string {
 	snapshot := c.Get()
 	if snapshot == nil {
 		return []string{}
@@ -142,10 +236,19 @@ func (c *GroupRoleCache) GetRolesForGroups(groups []string) []string {
 	roleSet := make(map[string]struct{})
 
 	for _, groupName := range groups {
-		if roles, ok := snapshot.Mappings[groupName]; ok {
-			for _, role := range roles {
-				roleSet[role] = struct{}{}
+		groupName = c.NormalizeGroupName(groupName)
+		roles, ok := snapshot.Mappings[groupName]
+		if !ok {
+			continue
+		}
+		conditions := snapshot.Conditions[groupName]
+		for _, role := range roles {
+			if condition, conditional := conditions[role]; conditional {
+				if claims == nil || !auth.EvaluateBexpr(condition, claims) {
+					continue
+				}
 			}
+			roleSet[role] = struct{}{}
 		}
 	}
 
@@ -2,6 +2,7 @@ package iam
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -9,143 +10,6 @@ import (
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 )
 
-// Mock repositories for testing
-
-type mockGroupRoleRepository struct {
-	mu      sync.RWMutex
-	records []models.GroupRole
-}
-
-func (m *mockGroupRoleRepository) Create(ctx context.Context, gr *models.GroupRole) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.records = append(m.records, *gr)
-	return nil
-}
-
-func (m *mockGroupRoleRepository) GetByID(ctx context.Context, id string) (*models.GroupRole, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for _, gr := range m.records {
-		if gr.ID == id {
-			return &gr, nil
-		}
-	}
-	return nil, nil
-}
-
-func (m *mockGroupRoleRepository) GetByGroupName(ctx context.Context, groupName string) ([]models.GroupRole, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	var result []models.GroupRole
-	for _, gr := range m.records {
-		if gr.GroupName == groupName {
-			result = append(result, gr)
-		}
-	}
-	return result, nil
-}
-
-func (m *mockGroupRoleRepository) GetByRoleID(ctx context.Context, roleID string) ([]models.GroupRole, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	var result []models.GroupRole
-	for _, gr := range m.records {
-		if gr.RoleID == roleID {
-			result = append(result, gr)
-		}
-	}
-	return result, nil
-}
-
-func (m *mockGroupRoleRepository) Delete(ctx context.Context, id string) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	for i, gr := range m.records {
-		if gr.ID == id {
-			m.records = append(m.records[:i], m.records[i+1:]...)
-			return nil
-		}
-	}
-	return nil
-}
-
-func (m *mockGroupRoleRepository) DeleteByGroupAndRole(ctx context.Context, groupName string, roleID string) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	for i := len(m.records) - 1; i >= 0; i-- {
-		if m.records[i].GroupName == groupName && m.records[i].RoleID == roleID {
-			m.records = append(m.records[:i], m.records[i+1:]...)
-		}
-	}
-	return nil
-}
-
-func (m *mockGroupRoleRepository) List(ctx context.Context) ([]models.GroupRole, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	// Return copy to prevent data races
-	result := make([]models.GroupRole, len(m.records))
-	copy(result, m.records)
-	return result, nil
-}
-
-type mockRoleRepository struct {
-	mu    sync.RWMutex
-	roles map[string]*models.Role // roleID → role
-}
-
-func (m *mockRoleRepository) Create(ctx context.Context, role *models.Role) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.roles[role.ID] = role
-	return nil
-}
-
-func (m *mockRoleRepository) GetByID(ctx context.Context, id string) (*models.Role, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	if role, ok := m.roles[id]; ok {
-		return role, nil
-	}
-	return nil, nil
-}
-
-func (m *mockRoleRepository) GetByName(ctx context.Context, name string) (*models.Role, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for _, role := range m.roles {
-		if role.Name == name {
-			return role, nil
-		}
-	}
-	return nil, nil
-}
-
-func (m *mockRoleRepository) Update(ctx context.Context, role *models.Role) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.roles[role.ID] = role
-	return nil
-}
-
-func (m *mockRoleRepository) Delete(ctx context.Context, id string) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	delete(m.roles, id)
-	return nil
-}
-
-func (m *mockRoleRepository) List(ctx context.Context) ([]models.Role, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	result := make([]models.Role, 0, len(m.roles))
-	for _, role := range m.roles {
-		result = append(result, *role)
-	}
-	return result, nil
-}
-
 // Test helper to create test data
 func setupTestCache(t *testing.T) (*GroupRoleCache, *mockGroupRoleRepository, *mockRoleRepository) {
 	t.Helper()
@@ -461,3 +325,35 @@ func TestGroupRoleCache_Deduplication(t *testing.T) {
 		t.Errorf("Expected admin role, got %s", roles[0])
 	}
 }
+
+// Keycloak sends group paths ("/platform-engineers") while other IdPs send bare
+// names; both must resolve to the same roles whichever form was assigned.
+func TestGroupRoleCache_LeadingSlashNormalization(t *testing.T) {
+	roleRepo := &mockRoleRepository{
+		roles: map[string]*models.Role{
+			"role-1": {ID: "role-1", Name: "platform-engineer"},
+			"role-2": {ID: "role-2", Name: "product-engineer"},
+		},
+	}
+	groupRoleRepo := &mockGroupRoleRepository{
+		records: []models.GroupRole{
+			{ID: "gr-1", GroupName: "platform-engineers", RoleID: "role-1", AssignedAt: time.Now()},
+			{ID: "gr-2", GroupName: "/product-engineers", RoleID: "role-2", AssignedAt: time.Now()},
+		},
+	}
+	cache, err := NewGroupRoleCache(groupRoleRepo, roleRepo)
+	if err != nil {
+		t.Fatalf("NewGroupRoleCache failed: %v", err)
+	}
+
+	for _, groups := range [][]string{
+		{"platform-engineers", "product-engineers"},
+		{"/platform-engineers", "/product-engineers"},
+	} {
+		roles := cache.GetRolesForGroups(groups)
+		sort.Strings(roles)
+		if len(roles) != 2 || roles[0] != "platform-engineer" || roles[1] != "product-engineer" {
+			t.Errorf("GetRolesForGroups(%v) = %v, want [platform-engineer product-engineer]", groups, roles)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr"
+)
+
+// normalizeGroupRoleCondition validates a group-role claim condition.
+// A blank condition returns nil (the mapping is unconditional); anything else
+// must compile as go-bexpr so a typo cannot silently stop granting the role.
+func normalizeGroupRoleCondition(condition string) (*string, error) {
+	trimmed := strings.TrimSpace(condition)
+	if trimmed == "" {
+		return nil, nil
+	}
+	if _, err := bexpr.CreateEvaluator(trimmed); err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+	return &trimmed, nil
+}
@@ -0,0 +1,100 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// brokenEnforcer fails every query, as an enforcer whose model failed to load would.
+type brokenEnforcer struct {
+	casbin.IEnforcer
+}
+
+func (brokenEnforcer) Enforce(...interface{}) (bool, error) {
+	return false, errors.New("model not loaded")
+}
+
+func healthTestCache(createdAt time.Time) *GroupRoleCache {
+	cache := &GroupRoleCache{}
+	cache.snapshot.Store(&GroupRoleSnapshot{Mappings: map[string][]string{}, CreatedAt: createdAt, Version: 4})
+	return cache
+}
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("healthy", func(t *testing.T) {
+		svc := &iamService{
+			groupRoleCache:       healthTestCache(time.Now().Add(-time.Minute)),
+			cacheRefreshInterval: 5 * time.Minute,
+			enforcer:             newTestEnforcer(t),
+		}
+
+		report := svc.Health(ctx)
+		assert.Equal(t, HealthStatusOK, report.Status)
+		assert.True(t, report.Ready())
+		assert.Equal(t, HealthStatusOK, report.GroupRoleCache.Status)
+		assert.Equal(t, 4, report.GroupRoleCache.Version)
+		require.NotNil(t, report.GroupRoleCache.LoadedAt)
+		assert.InDelta(t, 60, report.GroupRoleCache.AgeSeconds, 5)
+		assert.Equal(t, HealthStatusOK, report.Enforcer.Status)
+	})
+
+	t.Run("stale cache degrades", func(t *testing.T) {
+		svc := &iamService{
+			groupRoleCache:       healthTestCache(time.Now().Add(-time.Hour)),
+			cacheRefreshInterval: 5 * time.Minute,
+			enforcer:             newTestEnforcer(t),
+		}
+
+		report := svc.Health(ctx)
+		assert.Equal(t, HealthStatusDegraded, report.Status)
+		assert.True(t, report.Ready(), "the last mappings still apply")
+		assert.Equal(t, HealthStatusDegraded, report.GroupRoleCache.Status)
+		assert.Contains(t, report.GroupRoleCache.Error, "not refreshed")
+	})
+
+	t.Run("unloaded cache is unavailable", func(t *testing.T) {
+		svc := &iamService{
+			groupRoleCache: &GroupRoleCache{},
+			enforcer:       newTestEnforcer(t),
+		}
+
+		report := svc.Health(ctx)
+		assert.Equal(t, HealthStatusUnavailable, report.Status)
+		assert.False(t, report.Ready())
+		assert.Equal(t, HealthStatusUnavailable, report.GroupRoleCache.Status)
+		assert.Nil(t, report.GroupRoleCache.LoadedAt)
+		assert.Equal(t, HealthStatusOK, report.Enforcer.Status)
+	})
+
+	t.Run("failing enforcer is unavailable", func(t *testing.T) {
+		svc := &iamService{
+			groupRoleCache: healthTestCache(time.Now()),
+			enforcer:       brokenEnforcer{},
+		}
+
+		report := svc.Health(ctx)
+		assert.Equal(t, HealthStatusUnavailable, report.Status)
+		assert.False(t, report.Ready())
+		assert.Equal(t, HealthStatusOK, report.GroupRoleCache.Status)
+		assert.Equal(t, HealthStatusUnavailable, report.Enforcer.Status)
+		assert.Contains(t, report.Enforcer.Error, "model not loaded")
+	})
+
+	t.Run("missing enforcer is unavailable", func(t *testing.T) {
+		svc := &iamService{groupRoleCache: healthTestCache(time.Now())}
+
+		report := svc.Health(ctx)
+		assert.Equal(t, HealthStatusUnavailable, report.Status)
+		assert.Equal(t, HealthStatusUnavailable, report.Enforcer.Status)
+	})
+}
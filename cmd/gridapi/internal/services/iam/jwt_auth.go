@@ -2,7 +2,11 @@ package iam
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/xenitab/go-oidc-middleware/oidctoken"
@@ -21,7 +25,7 @@ import (
 //  2. Return (nil, nil) if not present
 //  3. Verify JWT signature using existing auth.Verifier logic
 //  4. Extract claims: sub, email, groups, jti
-//  5. Check JTI revocation
+//  5. Check JTI revocation and the revocation epoch
 //  6. Resolve user/service account (JIT provision if needed)
 //  7. Call ResolveRoles() using immutable cache
 //  8. Construct Principal with all fields populated
@@ -30,23 +34,45 @@ import (
 // This authenticator is stateless and thread-safe.
 type JWTAuthenticator struct {
 	cfg             *config.Config
-	tokenHandler    *oidctoken.TokenHandler[map[string]any]
+	issuers         map[string]*jwtIssuer // Keyed by iss claim
 	users           repository.UserRepository
 	serviceAccounts repository.ServiceAccountRepository
 	revokedJTIs     repository.RevokedJTIRepository
 	iamService      Service // Reference to parent IAM service for ResolveRoles
 }
 
+// jwtIssuer validates tokens from one trusted issuer.
+type jwtIssuer struct {
+	tokenHandler     *oidctoken.TokenHandler[map[string]any]
+	audiences        []string // Accepted aud values (any one must be present)
+	groupsClaimField string
+	groupsClaimPath  string
+
+	// Set for additional trusted issuers only, so identities and groups from
+	// the primary issuer keep their stored form
+	identityPrefix string   // Prepended to subjects and client IDs
+	allowedGroups  []string // When non-empty, groups outside it are dropped
+	groupPrefix    string   // Prepended to every kept group
+}
+
 // NewJWTAuthenticator creates a new JWT authenticator.
 //
 // This constructor initializes the OIDC token handler using the same logic
 // as auth.NewVerifier, but adapted for the Authenticator interface pattern.
+// httpClient, when set, is used for external IdP discovery and JWKS fetches.
+// jwks, keyed by issuer, serves an external issuer's keys from memory instead.
+//
+// In Mode 1 tokens from cfg.OIDC.ExternalIdP.TrustedIssuers are accepted too;
+// each token is routed to its issuer's keys, audiences and group mapping by
+// the iss claim.
 func NewJWTAuthenticator(
 	cfg *config.Config,
 	users repository.UserRepository,
 	serviceAccounts repository.ServiceAccountRepository,
 	revokedJTIs repository.RevokedJTIRepository,
 	iamService Service,
+	httpClient *http.Client,
+	jwks map[string]*auth.JWKSCache,
 ) (*JWTAuthenticator, error) {
 	var issuer, clientID string
 	var isInternalProvider bool
@@ -76,12 +102,15 @@ func NewJWTAuthenticator(
 
 	oidcOpts := []options.Option{
 		options.WithIssuer(issuer),
-		options.WithRequiredAudience(clientID),
 	}
 
 	// CRITICAL: For internal provider, use lazy load to avoid race condition
 	if isInternalProvider {
 		oidcOpts = append(oidcOpts, options.WithLazyLoadJwks(true))
+	} else if cache := jwks[issuer]; cache != nil {
+		oidcOpts = append(oidcOpts, options.WithJwksUri(cache.URI()), options.WithHttpClient(cache.Client()))
+	} else if httpClient != nil {
+		oidcOpts = append(oidcOpts, options.WithHttpClient(httpClient))
 	}
 
 	tokenHandler, err := oidctoken.New[map[string]any](nil, oidcOpts...)
@@ -89,9 +118,30 @@ func NewJWTAuthenticator(
 		return nil, fmt.Errorf("initialize oidc token handler: %w", err)
 	}
 
+	// Audience is validated by validateAudience rather than the token handler,
+	// which only supports a single required audience.
+	issuers := map[string]*jwtIssuer{
+		issuer: {
+			tokenHandler:     tokenHandler,
+			audiences:        append([]string{clientID}, cfg.OIDC.Audiences...),
+			groupsClaimField: cfg.OIDC.GroupsClaimField,
+			groupsClaimPath:  cfg.OIDC.GroupsClaimPath,
+		},
+	}
+
+	if !isInternalProvider {
+		for _, trusted := range cfg.OIDC.ExternalIdP.TrustedIssuers {
+			trustedIssuer, err := newTrustedJWTIssuer(cfg, trusted, httpClient, jwks[trusted.Issuer])
+			if err != nil {
+				return nil, err
+			}
+			issuers[trusted.Issuer] = trustedIssuer
+		}
+	}
+
 	return &JWTAuthenticator{
 		cfg:             cfg,
-		tokenHandler:    tokenHandler,
+		issuers:         issuers,
 		users:           users,
 		serviceAccounts: serviceAccounts,
 		revokedJTIs:     revokedJTIs,
@@ -99,6 +149,57 @@ func NewJWTAuthenticator(
 	}, nil
 }
 
+// newTrustedJWTIssuer builds the validator for an additional Mode 1 issuer.
+// Keys are loaded lazily so an unreachable issuer does not block startup.
+func newTrustedJWTIssuer(
+	cfg *config.Config,
+	trusted config.TrustedIssuerConfig,
+	httpClient *http.Client,
+	jwks *auth.JWKSCache,
+) (*jwtIssuer, error) {
+	oidcOpts := []options.Option{
+		options.WithIssuer(trusted.Issuer),
+		options.WithJwksUri(trusted.JWKSURI),
+		options.WithLazyLoadJwks(true),
+	}
+	if jwks != nil {
+		oidcOpts = append(oidcOpts, options.WithHttpClient(jwks.Client()))
+	} else if httpClient != nil {
+		oidcOpts = append(oidcOpts, options.WithHttpClient(httpClient))
+	}
+
+	tokenHandler, err := oidctoken.New[map[string]any](nil, oidcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("initialize oidc token handler for %s: %w", trusted.Issuer, err)
+	}
+
+	groupsClaimField := trusted.GroupsClaimField
+	if groupsClaimField == "" {
+		groupsClaimField = cfg.OIDC.GroupsClaimField
+	}
+	groupsClaimPath := trusted.GroupsClaimPath
+	if groupsClaimPath == "" {
+		groupsClaimPath = cfg.OIDC.GroupsClaimPath
+	}
+
+	return &jwtIssuer{
+		tokenHandler:     tokenHandler,
+		audiences:        trusted.Audiences,
+		groupsClaimField: groupsClaimField,
+		groupsClaimPath:  groupsClaimPath,
+		identityPrefix:   trusted.Issuer + "|",
+		allowedGroups:    trusted.AllowedGroups,
+		groupPrefix:      trusted.GroupPrefix,
+	}, nil
+}
+
+// identityKey scopes a subject or client ID to the issuer, so the same sub
+// from two issuers resolves to two identities. Identities of the primary
+// issuer are stored unprefixed.
+func (i *jwtIssuer) identityKey(id string) string {
+	return i.identityPrefix + id
+}
+
 // Authenticate extracts and validates JWT bearer tokens.
 //
 // Returns:
@@ -126,7 +227,7 @@ func (a *JWTAuthenticator) Authenticate(ctx context.Context, req AuthRequest) (*
 	trimmedToken := strings.TrimSpace(token)
 
 	// Step 3: Verify JWT signature
-	claims, err := a.tokenHandler.ParseToken(ctx, trimmedToken)
+	claims, issuer, err := a.parseToken(ctx, trimmedToken)
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
@@ -149,7 +250,7 @@ func (a *JWTAuthenticator) Authenticate(ctx context.Context, req AuthRequest) (*
 	name, _ := claims["name"].(string)
 
 	// Extract groups (optional, may be empty for users with no groups)
-	groups := a.extractGroups(claims)
+	groups := issuer.extractGroups(claims)
 
 	// Step 5: Check JTI revocation
 	isRevoked, err := a.revokedJTIs.IsRevoked(ctx, jti)
@@ -160,8 +261,18 @@ func (a *JWTAuthenticator) Authenticate(ctx context.Context, req AuthRequest) (*
 		return nil, fmt.Errorf("token has been revoked")
 	}
 
+	// Step 5b: Check the revocation epoch. The jti denylist revokes single
+	// tokens; the epoch rejects every token issued before it in one step.
+	epoch, err := a.iamService.RevocationEpoch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get revocation epoch: %w", err)
+	}
+	if auth.IssuedBeforeEpoch(claims, epoch) {
+		return nil, ErrRevokedByEpoch
+	}
+
 	// Step 6: Resolve user/service account (JIT provision if needed)
-	user, serviceAccount, err := a.resolveIdentity(ctx, sub, email, name, groups)
+	user, serviceAccount, err := a.resolveIdentity(ctx, issuer, sub, email, name, groups)
 	if err != nil {
 		return nil, fmt.Errorf("resolve identity: %w", err)
 	}
@@ -169,21 +280,34 @@ func (a *JWTAuthenticator) Authenticate(ctx context.Context, req AuthRequest) (*
 	var internalID string
 	var principalID string
 	var principalType PrincipalType
+	var displayName string
 
 	if user != nil {
+		if revokedByUserEpoch(user, auth.ClaimTime(claims, "iat")) {
+			return nil, ErrRevokedByEpoch
+		}
 		internalID = user.ID
-		principalID = fmt.Sprintf("user:%s", user.PrincipalSubject())
+		principalID = auth.UserID(user.PrincipalSubject())
 		principalType = PrincipalTypeUser
+		// Tokens may omit profile claims; fall back to the stored user
+		if email == "" {
+			email = user.Email
+		}
+		if name == "" {
+			name = user.Name
+		}
+		displayName = userDisplayName(name, email, user.PrincipalSubject())
 	} else if serviceAccount != nil {
 		internalID = serviceAccount.ID
-		principalID = fmt.Sprintf("service_account:%s", serviceAccount.Name)
+		principalID = auth.ServiceAccountID(serviceAccount.ClientID)
 		principalType = PrincipalTypeServiceAccount
+		displayName = serviceAccount.Name
 	} else {
 		return nil, fmt.Errorf("identity resolution failed")
 	}
 
 	// Step 7: Resolve roles using immutable cache
-	roles, err := a.iamService.ResolveRoles(ctx, internalID, groups, principalType == PrincipalTypeUser)
+	roles, err := a.iamService.ResolveRoles(ctx, internalID, groups, claims, principalType == PrincipalTypeUser)
 	if err != nil {
 		return nil, fmt.Errorf("resolve roles: %w", err)
 	}
@@ -195,24 +319,112 @@ func (a *JWTAuthenticator) Authenticate(ctx context.Context, req AuthRequest) (*
 		InternalID:  internalID,
 		Email:       email,
 		Name:        name,
+		DisplayName: displayName,
 		SessionID:   "", // No session for JWT auth
 		Groups:      groups,
 		Roles:       roles,
+		TokenScopes: auth.PermissionScopes(scopeClaim(claims)),
 		Type:        principalType,
 	}
 
 	return principal, nil
 }
 
-// extractGroups extracts groups from JWT claims using configured claim field.
-func (a *JWTAuthenticator) extractGroups(claims map[string]any) []string {
-	// Use configured claim field from config
-	claimField := a.cfg.OIDC.GroupsClaimField
+// VerifyToken validates a raw JWT and returns its claims.
+//
+// Unlike Authenticate, this does not check JTI revocation or resolve (or JIT
+// provision) an identity, so it is safe to use for introspection.
+func (a *JWTAuthenticator) VerifyToken(ctx context.Context, token string) (map[string]any, error) {
+	claims, _, err := a.parseToken(ctx, strings.TrimSpace(token))
+	return claims, err
+}
+
+// parseToken picks the validator for the token's issuer, verifies signature,
+// issuer and expiry, then checks the audience.
+func (a *JWTAuthenticator) parseToken(ctx context.Context, token string) (map[string]any, *jwtIssuer, error) {
+	iss, err := unverifiedIssuer(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	issuer, ok := a.issuers[iss]
+	if !ok {
+		return nil, nil, fmt.Errorf("untrusted token issuer %q", iss)
+	}
+
+	claims, err := issuer.tokenHandler.ParseToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateAudience(claims["aud"], issuer.audiences); err != nil {
+		return nil, nil, err
+	}
+	return claims, issuer, nil
+}
+
+// unverifiedIssuer reads the iss claim without checking the signature. It is
+// only used to choose which issuer's keys verify the token.
+func unverifiedIssuer(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("decode token payload: %w", err)
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("decode token payload: %w", err)
+	}
+	if claims.Issuer == "" {
+		return "", fmt.Errorf("token missing iss claim")
+	}
+	return claims.Issuer, nil
+}
+
+// validateAudience checks that the aud claim contains at least one accepted value.
+// Per RFC 7519 §4.1.3 the claim may be a single string or an array of strings.
+func validateAudience(aud any, accepted []string) error {
+	var received []string
+	switch v := aud.(type) {
+	case string:
+		if v != "" {
+			received = []string{v}
+		}
+	case []string:
+		received = v
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				received = append(received, s)
+			}
+		}
+	}
+
+	if len(received) == 0 {
+		return fmt.Errorf("token missing aud claim")
+	}
+
+	for _, got := range received {
+		for _, want := range accepted {
+			if got == want {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("none of the accepted audiences %v were found, received: %v", accepted, received)
+}
+
+// extractGroups extracts groups from JWT claims using the issuer's claim field.
+func (i *jwtIssuer) extractGroups(claims map[string]any) []string {
+	claimField := i.groupsClaimField
 	if claimField == "" {
 		claimField = "groups" // Default
 	}
 
-	claimPath := a.cfg.OIDC.GroupsClaimPath
+	claimPath := i.groupsClaimPath
 
 	// Extract groups using existing helper
 	groups, err := auth.ExtractGroups(claims, claimField, claimPath)
@@ -221,10 +433,40 @@ func (a *JWTAuthenticator) extractGroups(claims map[string]any) []string {
 		return []string{}
 	}
 
-	return groups
+	if len(i.allowedGroups) == 0 && i.groupPrefix == "" {
+		return groups
+	}
+	mapped := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if len(i.allowedGroups) > 0 && !slices.Contains(i.allowedGroups, group) {
+			continue
+		}
+		mapped = append(mapped, i.groupPrefix+group)
+	}
+	return mapped
+}
+
+// scopeClaim returns the scopes granted to the token: the space-delimited
+// "scope" claim (RFC 9068), or a list for issuers that send one.
+func scopeClaim(claims map[string]any) []string {
+	switch scope := claims["scope"].(type) {
+	case string:
+		return strings.Fields(scope)
+	case []any:
+		scopes := make([]string, 0, len(scope))
+		for _, s := range scope {
+			if str, ok := s.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+		return scopes
+	}
+	return nil
 }
 
 // resolveIdentity resolves the user or service account from the JWT subject.
+// Subjects and client IDs are keyed by (issuer, sub) through
+// jwtIssuer.identityKey.
 //
 // Implementation:
 //   - Look up user by subject
@@ -235,11 +477,14 @@ func (a *JWTAuthenticator) extractGroups(claims map[string]any) []string {
 // This matches the existing behavior in authn middleware.
 func (a *JWTAuthenticator) resolveIdentity(
 	ctx context.Context,
+	issuer *jwtIssuer,
 	sub, email, name string,
 	groups []string,
 ) (*models.User, *models.ServiceAccount, error) {
+	subject := issuer.identityKey(sub)
+
 	// Try to find existing user by subject
-	user, err := a.users.GetBySubject(ctx, sub)
+	user, err := a.users.GetBySubject(ctx, subject)
 	if err == nil && user != nil {
 		// User found, update last login
 		_ = a.users.UpdateLastLogin(ctx, user.ID)
@@ -249,7 +494,7 @@ func (a *JWTAuthenticator) resolveIdentity(
 	// User not found - check if this is a user (has email) or service account
 	if email != "" {
 		// JIT provision user
-		subjectPtr := &sub
+		subjectPtr := &subject
 		user = &models.User{
 			Subject:     subjectPtr,
 			Email:       email,
@@ -269,6 +514,7 @@ func (a *JWTAuthenticator) resolveIdentity(
 	if extractedID, err := auth.ExtractServiceAccountID(sub); err == nil {
 		clientID = extractedID
 	}
+	clientID = issuer.identityKey(clientID)
 
 	// Try to find existing service account by client_id
 	serviceAccount, err := a.serviceAccounts.GetByClientID(ctx, clientID)
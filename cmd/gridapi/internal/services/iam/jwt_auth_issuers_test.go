@@ -0,0 +1,298 @@
+package iam
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// testIssuer is an OIDC issuer serving discovery and JWKS documents and
+// signing tokens with its own key.
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer := &testIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key: &key.PublicKey, KeyID: "key", Algorithm: string(jose.RS256), Use: "sig",
+		}}})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (i *testIssuer) token(t *testing.T, audience string, extra map[string]any) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: i.key, KeyID: "key"}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	require.NoError(t, err)
+	now := time.Now()
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   i.server.URL,
+		Subject:  "alice",
+		Audience: jwt.Audience{audience},
+		ID:       "jti-" + audience,
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}).Claims(extra).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func bearerRequest(token string) AuthRequest {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+token)
+	return AuthRequest{Headers: headers}
+}
+
+func TestJWTAuthenticator_TrustedIssuers(t *testing.T) {
+	primary := newTestIssuer(t)
+	teamB := newTestIssuer(t)
+	unlisted := newTestIssuer(t)
+
+	cfg := &config.Config{
+		OIDC: config.OIDCConfig{
+			GroupsClaimField: "groups",
+			ExternalIdP: &config.ExternalIdPConfig{
+				Issuer:   primary.server.URL,
+				ClientID: "grid-api",
+				TrustedIssuers: []config.TrustedIssuerConfig{{
+					Issuer:           teamB.server.URL,
+					JWKSURI:          teamB.server.URL + "/keys",
+					Audiences:        []string{"team-b-api"},
+					GroupsClaimField: "roles",
+					AllowedGroups:    []string{"team-b", "platform"},
+					GroupPrefix:      "realm-b/",
+				}},
+			},
+		},
+	}
+
+	users := &mockUserRepository{users: make(map[string]*models.User)}
+	accounts := &mockServiceAccountRepository{accounts: make(map[string]*models.ServiceAccount)}
+	authenticator, err := NewJWTAuthenticator(
+		cfg,
+		users,
+		accounts,
+		&mockRevokedJTIRepository{revokedJTIs: make(map[string]bool)},
+		&mockIAMService{},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("primary issuer", func(t *testing.T) {
+		token := primary.token(t, "grid-api", map[string]any{
+			"email": "alice@example.com", "groups": []string{"platform"},
+		})
+		principal, err := authenticator.Authenticate(ctx, bearerRequest(token))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"platform"}, principal.Groups)
+	})
+
+	t.Run("allowed issuer uses its own audience and group mapping", func(t *testing.T) {
+		token := teamB.token(t, "team-b-api", map[string]any{
+			"email": "alice@example.com", "roles": []string{"team-b", "admins"}, "groups": []string{"ignored"},
+		})
+		principal, err := authenticator.Authenticate(ctx, bearerRequest(token))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"realm-b/team-b"}, principal.Groups, "groups outside the allow-list are dropped")
+
+		claims, err := authenticator.VerifyToken(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, teamB.server.URL, claims["iss"])
+	})
+
+	t.Run("same subject from two issuers is two identities", func(t *testing.T) {
+		primaryPrincipal, err := authenticator.Authenticate(ctx, bearerRequest(primary.token(t, "grid-api", map[string]any{"email": "alice@example.com"})))
+		require.NoError(t, err)
+		teamBPrincipal, err := authenticator.Authenticate(ctx, bearerRequest(teamB.token(t, "team-b-api", map[string]any{"email": "alice@example.com"})))
+		require.NoError(t, err)
+
+		assert.Equal(t, "user:alice", primaryPrincipal.PrincipalID)
+		assert.Equal(t, "user:"+teamB.server.URL+"|alice", teamBPrincipal.PrincipalID)
+		assert.Contains(t, users.users, "alice")
+		assert.Contains(t, users.users, teamB.server.URL+"|alice")
+	})
+
+	t.Run("service accounts are keyed by issuer", func(t *testing.T) {
+		accounts.accounts["ci"] = &models.ServiceAccount{ID: "sa-primary", ClientID: "ci", Name: "ci"}
+
+		principal, err := authenticator.Authenticate(ctx, bearerRequest(teamB.token(t, "team-b-api", map[string]any{"sub": "ci"})))
+		require.NoError(t, err)
+		assert.NotEqual(t, "sa-primary", principal.InternalID, "another issuer's client must not resolve to the primary issuer's account")
+		assert.Contains(t, accounts.accounts, teamB.server.URL+"|ci")
+	})
+
+	t.Run("disallowed issuer", func(t *testing.T) {
+		token := unlisted.token(t, "grid-api", map[string]any{"email": "alice@example.com"})
+		_, err := authenticator.Authenticate(ctx, bearerRequest(token))
+		require.ErrorContains(t, err, "untrusted token issuer")
+	})
+
+	t.Run("allowed issuer with mismatched audience", func(t *testing.T) {
+		// The primary issuer's audience is not accepted from another issuer
+		token := teamB.token(t, "grid-api", map[string]any{"email": "alice@example.com"})
+		_, err := authenticator.Authenticate(ctx, bearerRequest(token))
+		require.ErrorContains(t, err, "accepted audiences")
+	})
+
+	t.Run("issuer claim must match the verifying keys", func(t *testing.T) {
+		// Signed by the unlisted issuer but claiming to be team B
+		signer, err := jose.NewSigner(
+			jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: unlisted.key, KeyID: "key"}},
+			(&jose.SignerOptions{}).WithType("JWT"),
+		)
+		require.NoError(t, err)
+		token, err := jwt.Signed(signer).Claims(jwt.Claims{
+			Issuer:   teamB.server.URL,
+			Subject:  "alice",
+			Audience: jwt.Audience{"team-b-api"},
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).Serialize()
+		require.NoError(t, err)
+
+		_, err = authenticator.Authenticate(ctx, bearerRequest(token))
+		require.Error(t, err)
+	})
+}
+
+func TestJWTAuthenticator_TokenScopes(t *testing.T) {
+	issuer := newTestIssuer(t)
+	cfg := &config.Config{
+		OIDC: config.OIDCConfig{
+			ExternalIdP: &config.ExternalIdPConfig{Issuer: issuer.server.URL, ClientID: "grid-api"},
+		},
+	}
+	authenticator, err := NewJWTAuthenticator(
+		cfg,
+		&mockUserRepository{users: make(map[string]*models.User)},
+		&mockServiceAccountRepository{accounts: make(map[string]*models.ServiceAccount)},
+		&mockRevokedJTIRepository{revokedJTIs: make(map[string]bool)},
+		&mockIAMService{},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name  string
+		scope any
+		want  []string
+	}{
+		{name: "no scope claim", scope: nil, want: nil},
+		{name: "only OIDC scopes", scope: "openid profile email", want: nil},
+		{name: "permission scopes", scope: "openid state:read tfstate:*", want: []string{"state:read", "tfstate:*"}},
+		{name: "scope list", scope: []string{"openid", "state:read"}, want: []string{"state:read"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			extra := map[string]any{"email": "alice@example.com"}
+			if tc.scope != nil {
+				extra["scope"] = tc.scope
+			}
+			principal, err := authenticator.Authenticate(context.Background(), bearerRequest(issuer.token(t, "grid-api", extra)))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, principal.TokenScopes)
+		})
+	}
+}
+
+func TestJWTAuthenticator_PrincipalProfile(t *testing.T) {
+	issuer := newTestIssuer(t)
+	cfg := &config.Config{
+		OIDC: config.OIDCConfig{
+			ExternalIdP: &config.ExternalIdPConfig{Issuer: issuer.server.URL, ClientID: "grid-api"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		user            *models.User
+		account         *models.ServiceAccount
+		claims          map[string]any
+		wantEmail       string
+		wantName        string
+		wantDisplayName string
+	}{
+		{
+			name:            "profile claims",
+			claims:          map[string]any{"email": "alice@example.com", "name": "Alice Liddell"},
+			wantEmail:       "alice@example.com",
+			wantName:        "Alice Liddell",
+			wantDisplayName: "Alice Liddell",
+		},
+		{
+			name:            "email claim only",
+			claims:          map[string]any{"email": "alice@example.com"},
+			wantEmail:       "alice@example.com",
+			wantDisplayName: "alice@example.com",
+		},
+		{
+			name:            "user record fills missing claims",
+			user:            &models.User{ID: "user-alice", Email: "alice@example.com", Name: "Alice Liddell"},
+			wantEmail:       "alice@example.com",
+			wantName:        "Alice Liddell",
+			wantDisplayName: "Alice Liddell",
+		},
+		{
+			name:            "service account",
+			account:         &models.ServiceAccount{ID: "sa-1", ClientID: "alice", Name: "ci-deployer"},
+			wantDisplayName: "ci-deployer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &mockUserRepository{users: make(map[string]*models.User)}
+			if tt.user != nil {
+				subject := "alice"
+				tt.user.Subject = &subject
+				users.users[subject] = tt.user
+			}
+			accounts := &mockServiceAccountRepository{accounts: make(map[string]*models.ServiceAccount)}
+			if tt.account != nil {
+				accounts.accounts[tt.account.ClientID] = tt.account
+			}
+			authenticator, err := NewJWTAuthenticator(cfg, users, accounts,
+				&mockRevokedJTIRepository{revokedJTIs: make(map[string]bool)}, &mockIAMService{}, nil, nil)
+			require.NoError(t, err)
+
+			principal, err := authenticator.Authenticate(context.Background(), bearerRequest(issuer.token(t, "grid-api", tt.claims)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEmail, principal.Email)
+			assert.Equal(t, tt.wantName, principal.Name)
+			assert.Equal(t, tt.wantDisplayName, principal.DisplayName)
+		})
+	}
+}
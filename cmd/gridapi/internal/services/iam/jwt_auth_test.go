@@ -11,233 +11,189 @@ import (
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 )
 
-// mockUserRepository for testing
-type mockUserRepository struct {
-	users map[string]*models.User // subject → user
+// mockIAMService for testing (simplified, only implements ResolveRoles)
+type mockIAMService struct {
+	roles []string
+	epoch time.Time // Returned by RevocationEpoch
 }
 
-func (m *mockUserRepository) Create(ctx context.Context, user *models.User) error {
-	if user.Subject != nil {
-		m.users[*user.Subject] = user
-	} else {
-		m.users[user.Email] = user
-	}
-	return nil
+func (m *mockIAMService) AuthenticateRequest(ctx context.Context, req AuthRequest) (*Principal, error) {
+	return nil, nil
 }
 
-func (m *mockUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
-	for _, u := range m.users {
-		if u.ID == id {
-			return u, nil
-		}
-	}
-	return nil, fmt.Errorf("user not found")
+func (m *mockIAMService) ResolveRoles(ctx context.Context, principalID string, groups []string, claims map[string]any, isUser bool) ([]string, error) {
+	// Return mock roles for testing
+	return m.roles, nil
 }
 
-func (m *mockUserRepository) GetBySubject(ctx context.Context, subject string) (*models.User, error) {
-	if u, ok := m.users[subject]; ok {
-		return u, nil
-	}
-	return nil, fmt.Errorf("user not found")
+func (m *mockIAMService) Authorize(ctx context.Context, principal *Principal, obj, act string, labels map[string]interface{}) (bool, error) {
+	return false, nil
 }
 
-func (m *mockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
-	if u, ok := m.users[email]; ok {
-		return u, nil
-	}
-	return nil, fmt.Errorf("user not found")
+func (m *mockIAMService) AuthorizeDecision(ctx context.Context, principal *Principal, obj, act string, labels map[string]interface{}) (Decision, error) {
+	return Decision{}, nil
 }
 
-func (m *mockUserRepository) Update(ctx context.Context, user *models.User) error {
-	if user.Subject != nil {
-		m.users[*user.Subject] = user
-	}
+func (m *mockIAMService) RefreshGroupRoleCache(ctx context.Context) error {
 	return nil
 }
 
-func (m *mockUserRepository) UpdateLastLogin(ctx context.Context, id string) error {
-	for _, u := range m.users {
-		if u.ID == id {
-			now := time.Now()
-			u.LastLoginAt = &now
-			return nil
-		}
-	}
-	return fmt.Errorf("user not found")
+func (m *mockIAMService) GetGroupRoleCacheSnapshot() GroupRoleSnapshot {
+	return GroupRoleSnapshot{}
 }
 
-func (m *mockUserRepository) SetPasswordHash(ctx context.Context, id string, passwordHash string) error {
-	return nil
+func (m *mockIAMService) AuthzModelVersion(ctx context.Context) (uint64, error) {
+	return 0, nil
 }
 
-func (m *mockUserRepository) List(ctx context.Context) ([]models.User, error) {
-	result := make([]models.User, 0, len(m.users))
-	for _, u := range m.users {
-		result = append(result, *u)
-	}
-	return result, nil
+func (m *mockIAMService) AuthorizeBatch(ctx context.Context, principal *Principal, checks []AuthCheck) ([]bool, error) {
+	return make([]bool, len(checks)), nil
 }
 
-// mockServiceAccountRepository for testing
-type mockServiceAccountRepository struct {
-	accounts map[string]*models.ServiceAccount // clientID → account
+func (m *mockIAMService) Health(ctx context.Context) HealthReport {
+	return HealthReport{Status: HealthStatusOK}
 }
 
-func (m *mockServiceAccountRepository) Create(ctx context.Context, sa *models.ServiceAccount) error {
-	m.accounts[sa.ClientID] = sa
+func (m *mockIAMService) CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time, headers http.Header) (*models.Session, string, error) {
+	return nil, "", nil
+}
+
+func (m *mockIAMService) RevokeSession(ctx context.Context, sessionID string) error {
 	return nil
 }
 
-func (m *mockServiceAccountRepository) GetByID(ctx context.Context, id string) (*models.ServiceAccount, error) {
-	for _, sa := range m.accounts {
-		if sa.ID == id {
-			return sa, nil
-		}
-	}
-	return nil, fmt.Errorf("service account not found")
+func (m *mockIAMService) GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error) {
+	return nil, nil
 }
 
-func (m *mockServiceAccountRepository) GetByName(ctx context.Context, name string) (*models.ServiceAccount, error) {
-	for _, sa := range m.accounts {
-		if sa.Name == name {
-			return sa, nil
-		}
-	}
-	return nil, fmt.Errorf("service account not found")
+func (m *mockIAMService) GetSessionByToken(ctx context.Context, rawToken string) (*models.Session, error) {
+	return nil, nil
 }
 
-func (m *mockServiceAccountRepository) GetByClientID(ctx context.Context, clientID string) (*models.ServiceAccount, error) {
-	if sa, ok := m.accounts[clientID]; ok {
-		return sa, nil
-	}
-	return nil, fmt.Errorf("service account not found")
+func (m *mockIAMService) ListUserSessions(ctx context.Context, userID string) ([]models.Session, error) {
+	return nil, nil
 }
 
-func (m *mockServiceAccountRepository) Update(ctx context.Context, sa *models.ServiceAccount) error {
-	m.accounts[sa.ClientID] = sa
-	return nil
+func (m *mockIAMService) ListAllSessions(ctx context.Context, filter SessionFilter) ([]models.Session, error) {
+	return nil, nil
 }
 
-func (m *mockServiceAccountRepository) UpdateLastUsed(ctx context.Context, id string) error {
-	return nil
+func (m *mockIAMService) RevokeSessions(ctx context.Context, filter SessionFilter) (int, error) {
+	return 0, nil
 }
 
-func (m *mockServiceAccountRepository) UpdateSecretHash(ctx context.Context, id string, secretHash string) error {
+func (m *mockIAMService) RevokeJTI(ctx context.Context, jti string, expiresAt time.Time) error {
 	return nil
 }
 
-func (m *mockServiceAccountRepository) SetDisabled(ctx context.Context, id string, disabled bool) error {
+func (m *mockIAMService) CheckCreateConstraints(ctx context.Context, principal *Principal, labels map[string]interface{}) error {
 	return nil
 }
 
-func (m *mockServiceAccountRepository) List(ctx context.Context) ([]models.ServiceAccount, error) {
-	result := make([]models.ServiceAccount, 0, len(m.accounts))
-	for _, sa := range m.accounts {
-		result = append(result, *sa)
-	}
-	return result, nil
+func (m *mockIAMService) DefaultCreateLabels(ctx context.Context, principal *Principal, labels map[string]interface{}) (map[string]string, error) {
+	return nil, nil
 }
 
-func (m *mockServiceAccountRepository) ListByCreator(ctx context.Context, createdBy string) ([]models.ServiceAccount, error) {
-	return nil, nil
+func (m *mockIAMService) CheckImmutableKeys(ctx context.Context, principal *Principal, current, adds map[string]interface{}, removals []string) error {
+	return nil
 }
 
-// mockRevokedJTIRepository for testing
-type mockRevokedJTIRepository struct {
-	revokedJTIs map[string]bool
+func (m *mockIAMService) AuthorizeOwner(ctx context.Context, principal *Principal, act string) (bool, error) {
+	return false, nil
 }
 
-func (m *mockRevokedJTIRepository) Create(ctx context.Context, revokedJTI *models.RevokedJTI) error {
-	m.revokedJTIs[revokedJTI.JTI] = true
-	return nil
+func (m *mockIAMService) ListPrincipalsForRole(ctx context.Context, roleName string) ([]PrincipalRef, error) {
+	return nil, nil
 }
 
-func (m *mockRevokedJTIRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
-	return m.revokedJTIs[jti], nil
+func (m *mockIAMService) ListGroupsForRole(ctx context.Context, roleName string) ([]models.GroupRole, error) {
+	return nil, nil
 }
 
-func (m *mockRevokedJTIRepository) DeleteExpired(ctx context.Context, gracePeriod time.Duration) error {
-	return nil
+func (m *mockIAMService) ReconcileRoleAssignments(ctx context.Context) (*RoleAssignmentReconciliation, error) {
+	return &RoleAssignmentReconciliation{}, nil
 }
 
-func (m *mockRevokedJTIRepository) GetByJTI(ctx context.Context, jti string) (*models.RevokedJTI, error) {
-	if m.revokedJTIs[jti] {
-		return &models.RevokedJTI{JTI: jti}, nil
-	}
-	return nil, fmt.Errorf("not found")
+func (m *mockIAMService) ReconcileRolePolicies(ctx context.Context, repair bool) (*RolePolicyReconciliation, error) {
+	return &RolePolicyReconciliation{}, nil
 }
 
-// mockIAMService for testing (simplified, only implements ResolveRoles)
-type mockIAMService struct {
-	roles []string
+func (m *mockIAMService) ExportAccessReview(ctx context.Context) (*AccessReview, error) {
+	return nil, nil
 }
 
-func (m *mockIAMService) AuthenticateRequest(ctx context.Context, req AuthRequest) (*Principal, error) {
+func (m *mockIAMService) PreviewAuthorization(ctx context.Context, preview AuthorizationPreview) (*AuthorizationExplanation, error) {
 	return nil, nil
 }
 
-func (m *mockIAMService) ResolveRoles(ctx context.Context, principalID string, groups []string, isUser bool) ([]string, error) {
-	// Return mock roles for testing
-	return m.roles, nil
+func (m *mockIAMService) IntrospectToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	return nil, nil
 }
 
-func (m *mockIAMService) Authorize(ctx context.Context, principal *Principal, obj, act string, labels map[string]interface{}) (bool, error) {
-	return false, nil
+func (m *mockIAMService) CreateUser(ctx context.Context, email, username, passwordHash, subject string) (*models.User, error) {
+	return nil, nil
 }
 
-func (m *mockIAMService) RefreshGroupRoleCache(ctx context.Context) error {
-	return nil
+func (m *mockIAMService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return nil, nil
 }
 
-func (m *mockIAMService) GetGroupRoleCacheSnapshot() GroupRoleSnapshot {
-	return GroupRoleSnapshot{}
+func (m *mockIAMService) GetUserBySubject(ctx context.Context, subject string) (*models.User, error) {
+	return nil, nil
 }
 
-func (m *mockIAMService) CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time) (*models.Session, string, error) {
-	return nil, "", nil
+func (m *mockIAMService) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	return nil, nil
 }
 
-func (m *mockIAMService) RevokeSession(ctx context.Context, sessionID string) error {
+func (m *mockIAMService) DisableUser(ctx context.Context, userID string) error {
 	return nil
 }
 
-func (m *mockIAMService) GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error) {
-	return nil, nil
+func (m *mockIAMService) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string, opts ChangePasswordOptions) error {
+	return nil
 }
 
-func (m *mockIAMService) ListUserSessions(ctx context.Context, userID string) ([]models.Session, error) {
-	return nil, nil
+func (m *mockIAMService) AdminResetPassword(ctx context.Context, userID string) (string, error) {
+	return "", nil
 }
 
-func (m *mockIAMService) RevokeJTI(ctx context.Context, jti string, expiresAt time.Time) error {
-	return nil
+func (m *mockIAMService) RevokeAllSessions(ctx context.Context, principalID, principalType string) (int, error) {
+	return 0, nil
 }
 
-func (m *mockIAMService) CreateUser(ctx context.Context, email, username, passwordHash, subject string) (*models.User, error) {
+func (m *mockIAMService) GetRoleActions(ctx context.Context, roleName string) ([]RoleAction, error) {
 	return nil, nil
 }
 
-func (m *mockIAMService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+func (m *mockIAMService) GetEffectivePermissions(ctx context.Context, roles []string) ([]EffectivePermission, error) {
 	return nil, nil
 }
 
-func (m *mockIAMService) GetUserBySubject(ctx context.Context, subject string) (*models.User, error) {
+func (m *mockIAMService) ListAuditLog(ctx context.Context, filter AuditLogFilter) ([]models.AuditLogEntry, error) {
 	return nil, nil
 }
 
-func (m *mockIAMService) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
-	return nil, nil
+func (m *mockIAMService) RecordOwnershipTransfer(ctx context.Context, stateGUID, previousOwner, newOwner string, opErr error) {
 }
 
-func (m *mockIAMService) DisableUser(ctx context.Context, userID string) error {
+func (m *mockIAMService) RevocationEpoch(ctx context.Context) (time.Time, error) {
+	return m.epoch, nil
+}
+
+func (m *mockIAMService) BumpRevocationEpoch(ctx context.Context, epoch time.Time, setBy string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (m *mockIAMService) MergeUsers(ctx context.Context, primaryID, duplicateID string) error {
 	return nil
 }
 
-func (m *mockIAMService) CreateServiceAccount(ctx context.Context, name, createdBy string) (*models.ServiceAccount, string, error) {
-	return nil, "", nil
+func (m *mockIAMService) CreateServiceAccount(ctx context.Context, name, createdBy string, roleNames []string) (*models.ServiceAccount, string, []string, error) {
+	return nil, "", nil, nil
 }
 
-func (m *mockIAMService) ListServiceAccounts(ctx context.Context) ([]*models.ServiceAccount, error) {
+func (m *mockIAMService) ListServiceAccounts(ctx context.Context, filter ServiceAccountFilter) ([]*models.ServiceAccount, error) {
 	return nil, nil
 }
 
@@ -253,8 +209,12 @@ func (m *mockIAMService) RevokeServiceAccount(ctx context.Context, clientID stri
 	return nil
 }
 
-func (m *mockIAMService) RotateServiceAccountSecret(ctx context.Context, clientID string) (string, time.Time, error) {
-	return "", time.Time{}, nil
+func (m *mockIAMService) RotateServiceAccountSecret(ctx context.Context, clientID string, overlap time.Duration) (*SecretRotation, error) {
+	return &SecretRotation{}, nil
+}
+
+func (m *mockIAMService) TestCredentials(ctx context.Context, clientID, clientSecret string) (*CredentialTestResult, error) {
+	return nil, nil
 }
 
 func (m *mockIAMService) AssignRolesToServiceAccount(ctx context.Context, serviceAccountID string, roleIDs []string) error {
@@ -273,7 +233,7 @@ func (m *mockIAMService) RemoveUserRole(ctx context.Context, userID, serviceAcco
 	return nil
 }
 
-func (m *mockIAMService) AssignGroupRole(ctx context.Context, groupName, roleID string) error {
+func (m *mockIAMService) AssignGroupRole(ctx context.Context, groupName, roleID, condition string) error {
 	return nil
 }
 
@@ -286,7 +246,10 @@ func (m *mockIAMService) CreateRole(
 	name, description, scopeExpr string,
 	createConstraints models.CreateConstraints,
 	immutableKeys []string,
+	maxAssignments *int,
+	ownerActions []string,
 	actions []string,
+	defaultLabels bool,
 ) (*models.Role, error) {
 	return nil, nil
 }
@@ -298,7 +261,10 @@ func (m *mockIAMService) UpdateRole(
 	description, scopeExpr string,
 	createConstraints models.CreateConstraints,
 	immutableKeys []string,
+	maxAssignments *int,
+	ownerActions []string,
 	actions []string,
+	defaultLabels bool,
 ) (*models.Role, error) {
 	return nil, nil
 }
@@ -307,6 +273,18 @@ func (m *mockIAMService) DeleteRole(ctx context.Context, name string) error {
 	return nil
 }
 
+func (m *mockIAMService) CloneRole(ctx context.Context, sourceName, newName string, overrideScopeExpr *string) (*models.Role, error) {
+	return nil, nil
+}
+
+func (m *mockIAMService) MigrateEmptyRoleScopes(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *mockIAMService) CountStatesForRole(ctx context.Context, roleName string) (int, error) {
+	return 0, nil
+}
+
 func (m *mockIAMService) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
 	return nil, nil
 }
@@ -486,3 +464,36 @@ func TestJWTAuthenticator_RevokedJTI(t *testing.T) {
 		t.Error("Expected JTI to not be revoked")
 	}
 }
+
+// TestValidateAudience tests aud claim handling for string and array forms
+func TestValidateAudience(t *testing.T) {
+	accepted := []string{"gridapi", "grid-admin-api"}
+
+	tests := []struct {
+		name    string
+		aud     any
+		wantErr bool
+	}{
+		{name: "string aud accepted", aud: "gridapi"},
+		{name: "string aud secondary audience", aud: "grid-admin-api"},
+		{name: "string aud not accepted", aud: "other-api", wantErr: true},
+		{name: "array aud contains accepted", aud: []any{"other-api", "grid-admin-api"}},
+		{name: "typed array aud contains accepted", aud: []string{"gridapi"}},
+		{name: "array aud none accepted", aud: []any{"other-api", "another-api"}, wantErr: true},
+		{name: "empty string aud", aud: "", wantErr: true},
+		{name: "empty array aud", aud: []any{}, wantErr: true},
+		{name: "missing aud", aud: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAudience(tt.aud, accepted)
+			if tt.wantErr && err == nil {
+				t.Errorf("Expected error for aud %v, got nil", tt.aud)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected aud %v to be accepted, got: %v", tt.aud, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// Label constraint kinds reported in LabelConstraintError.Constraint.
+const (
+	ConstraintRequired      = "required"
+	ConstraintAllowedValues = "allowed_values"
+	ConstraintImmutable     = "immutable"
+)
+
+// LabelConstraintError identifies the label key and role constraint that
+// rejected a state create or label update.
+type LabelConstraintError struct {
+	Role       string
+	Key        string
+	Constraint string
+	Message    string
+}
+
+func (e *LabelConstraintError) Error() string {
+	return fmt.Sprintf("label %q violates %s constraint of role %q: %s", e.Key, e.Constraint, e.Role, e.Message)
+}
+
+// CheckCreateConstraints validates labels for a new state against the
+// CreateConstraints of each principal role that grants state:create.
+//
+// The create is allowed if ANY granting role's constraints are satisfied,
+// mirroring how Authorize treats roles. When every granting role rejects the
+// labels, the violation from the first role (by name) is returned. Roles that
+// do not grant state:create are ignored; Authorize reports those denials.
+func (s *iamService) CheckCreateConstraints(ctx context.Context, principal *Principal, labels map[string]interface{}) error {
+	if principal == nil {
+		return fmt.Errorf("nil principal")
+	}
+
+	ctx, span := startAuthzSpan(ctx, principal, auth.ObjectTypeState, auth.StateCreate)
+	defer span.End()
+
+	roles := slices.Clone(principal.Roles)
+	sort.Strings(roles)
+
+	var firstViolation *LabelConstraintError
+	for _, roleName := range roles {
+		allowed, err := s.authorizeCreate(ctx, span, principal, []string{roleName}, labels)
+		if err != nil {
+			return fmt.Errorf("authorize role %s: %w", roleName, err)
+		}
+		if !allowed {
+			continue
+		}
+
+		role, err := s.roles.GetByName(ctx, roleName)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue // Role deleted since the principal authenticated
+		}
+		if err != nil {
+			return fmt.Errorf("get role %s: %w", roleName, err)
+		}
+
+		violation := checkCreateConstraints(role, labels)
+		if violation == nil {
+			return nil
+		}
+		if firstViolation == nil {
+			firstViolation = violation
+		}
+	}
+
+	if firstViolation != nil {
+		return firstViolation
+	}
+	return nil
+}
+
+// DefaultCreateLabels returns labels to add to a new state so that a role
+// with DefaultLabels set accepts it, sparing the caller from repeating labels
+// the role's CreateConstraints pin to a single allowed value.
+//
+// Nothing is added when the labels already pass Authorize and
+// CheckCreateConstraints as submitted. Otherwise roles are tried by name: for
+// each DefaultLabels role, the missing constrained keys with exactly one
+// allowed value are filled in, and the first role that then grants
+// state:create with its constraints satisfied supplies the defaults. A nil
+// result means the create proceeds, and is rejected, with the labels as given.
+func (s *iamService) DefaultCreateLabels(ctx context.Context, principal *Principal, labels map[string]interface{}) (map[string]string, error) {
+	if principal == nil {
+		return nil, fmt.Errorf("nil principal")
+	}
+
+	ctx, span := startAuthzSpan(ctx, principal, auth.ObjectTypeState, auth.StateCreate)
+	defer span.End()
+
+	// Step 1: Leave labels that are already acceptable untouched
+	allowed, err := s.authorizeCreate(ctx, span, principal, principal.Roles, labels)
+	if err != nil {
+		return nil, fmt.Errorf("authorize create: %w", err)
+	}
+	if allowed {
+		err := s.CheckCreateConstraints(ctx, principal, labels)
+		if err == nil {
+			return nil, nil
+		}
+		var violation *LabelConstraintError
+		if !errors.As(err, &violation) {
+			return nil, err
+		}
+	}
+
+	roles := slices.Clone(principal.Roles)
+	sort.Strings(roles)
+
+	// Step 2: Find the first opted-in role whose constant labels make the create pass
+	for _, roleName := range roles {
+		role, err := s.roles.GetByName(ctx, roleName)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue // Role deleted since the principal authenticated
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get role %s: %w", roleName, err)
+		}
+		if !role.DefaultLabels {
+			continue
+		}
+
+		defaults := constantCreateLabels(role, labels)
+		if len(defaults) == 0 {
+			continue
+		}
+		candidate := maps.Clone(labels)
+		if candidate == nil {
+			candidate = make(map[string]interface{}, len(defaults))
+		}
+		for key, value := range defaults {
+			candidate[key] = value
+		}
+
+		allowed, err := s.authorizeCreate(ctx, span, principal, []string{roleName}, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("authorize role %s: %w", roleName, err)
+		}
+		if allowed && checkCreateConstraints(role, candidate) == nil {
+			return defaults, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// authorizeCreate reports whether roles grant state:create on a state with
+// labels, consulting the enforcer behind the breaker with the principal's
+// token scopes, as Authorize does.
+func (s *iamService) authorizeCreate(ctx context.Context, span trace.Span, principal *Principal, roles []string, labels map[string]interface{}) (bool, error) {
+	var allowed bool
+	err := s.guardEnforcer(ctx, span, auth.ObjectTypeState, func() (err error) {
+		allowed, err = AuthorizeWithRoles(s.enforcer, roles, auth.ObjectTypeState, auth.StateCreate, labels, s.scopeCombination.For(auth.ObjectTypeState), principal.TokenScopes)
+		return err
+	})
+	return allowed, err
+}
+
+// CheckImmutableKeys rejects label updates that change or remove a key listed
+// in the ImmutableKeys of any of the principal's roles (the union across roles,
+// matching EffectiveImmutableKeys). Re-setting a key to its current value is
+// not a change.
+func (s *iamService) CheckImmutableKeys(ctx context.Context, principal *Principal, current, adds map[string]interface{}, removals []string) error {
+	if principal == nil {
+		return fmt.Errorf("nil principal")
+	}
+
+	roles := slices.Clone(principal.Roles)
+	sort.Strings(roles)
+
+	// Step 1: Union immutable keys, remembering the first role declaring each
+	immutableKeys := make(map[string]string)
+	for _, roleName := range roles {
+		role, err := s.roles.GetByName(ctx, roleName)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue // Role deleted since the principal authenticated
+		}
+		if err != nil {
+			return fmt.Errorf("get role %s: %w", roleName, err)
+		}
+		for _, key := range role.ImmutableKeys {
+			if _, seen := immutableKeys[key]; !seen {
+				immutableKeys[key] = role.Name
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(immutableKeys))
+	for key := range immutableKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Step 2: Reject any add or removal that would change an immutable key
+	for _, key := range keys {
+		currentValue, exists := current[key]
+		if newValue, ok := adds[key]; ok && (!exists || fmt.Sprint(newValue) != fmt.Sprint(currentValue)) {
+			return &LabelConstraintError{
+				Role:       immutableKeys[key],
+				Key:        key,
+				Constraint: ConstraintImmutable,
+				Message:    fmt.Sprintf("label %q cannot be changed once set", key),
+			}
+		}
+		if exists && slices.Contains(removals, key) {
+			return &LabelConstraintError{
+				Role:       immutableKeys[key],
+				Key:        key,
+				Constraint: ConstraintImmutable,
+				Message:    fmt.Sprintf("label %q cannot be removed", key),
+			}
+		}
+	}
+
+	return nil
+}
+
+// constantCreateLabels returns the role's create-constrained keys missing from
+// labels whose constraint allows exactly one value, mapped to that value.
+func constantCreateLabels(role *models.Role, labels map[string]interface{}) map[string]string {
+	var defaults map[string]string
+	for key, constraint := range role.CreateConstraints {
+		if _, present := labels[key]; present || len(constraint.AllowedValues) != 1 {
+			continue
+		}
+		if defaults == nil {
+			defaults = make(map[string]string)
+		}
+		defaults[key] = constraint.AllowedValues[0]
+	}
+	return defaults
+}
+
+// checkCreateConstraints returns the first constraint (by key) the labels breach.
+func checkCreateConstraints(role *models.Role, labels map[string]interface{}) *LabelConstraintError {
+	keys := make([]string, 0, len(role.CreateConstraints))
+	for key := range role.CreateConstraints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		constraint := role.CreateConstraints[key]
+		value, present := labels[key]
+
+		if !present {
+			if constraint.Required {
+				return &LabelConstraintError{
+					Role:       role.Name,
+					Key:        key,
+					Constraint: ConstraintRequired,
+					Message:    fmt.Sprintf("label %q is required", key),
+				}
+			}
+			continue
+		}
+
+		if len(constraint.AllowedValues) > 0 && !slices.Contains(constraint.AllowedValues, fmt.Sprint(value)) {
+			return &LabelConstraintError{
+				Role:       role.Name,
+				Key:        key,
+				Constraint: ConstraintAllowedValues,
+				Message:    fmt.Sprintf("value %q is not one of %v", fmt.Sprint(value), constraint.AllowedValues),
+			}
+		}
+	}
+
+	return nil
+}
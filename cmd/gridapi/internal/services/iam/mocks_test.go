@@ -0,0 +1,907 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// testCasbinModel mirrors auth/model.conf so policies can be evaluated in memory.
+const testCasbinModel = `
+[request_definition]
+r = sub, obj, act, labels
+
+[policy_definition]
+p = role, obj, act, scopeExpr, eft
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = (r.act == "read-self" && r.sub == r.obj) || g(r.sub, p.role) && (r.obj == p.obj || p.obj == "*") && (r.act == p.act || p.act == "*") && (bexprMatch(p.scopeExpr, r.labels))
+`
+
+func newTestEnforcer(t testing.TB, policies ...[]string) casbin.IEnforcer {
+	t.Helper()
+
+	m, err := model.NewModelFromString(testCasbinModel)
+	require.NoError(t, err)
+
+	enforcer, err := casbin.NewEnforcer(m)
+	require.NoError(t, err)
+	enforcer.AddFunction("bexprMatch", auth.BexprMatchFunction())
+
+	for _, p := range policies {
+		_, err := enforcer.AddPolicy(p[0], p[1], p[2], p[3], p[4])
+		require.NoError(t, err)
+	}
+	return enforcer
+}
+
+// testRepos are the in-memory repositories a test iamService is built over
+// (see newTestService). Nil repositories are created empty.
+type testRepos struct {
+	users           *mockUserRepository
+	roles           *mockRoleRepository
+	userRoles       *mockUserRoleRepository
+	groupRoles      *mockGroupRoleRepository
+	serviceAccounts *mockServiceAccountRepository
+	sessions        *mockSessionRepository
+}
+
+// newTestService builds an iamService over repos, wired together the way the
+// Bun repositories share one database: assignment checks see every role
+// assignment, service accounts are created with their roles and merging users
+// moves their assignments. The group-role cache is loaded from the
+// repositories and the enforcer holds policies. Tests set any other
+// dependency on the returned service.
+func newTestService(t testing.TB, repos testRepos, policies ...[]string) *iamService {
+	t.Helper()
+
+	if repos.users == nil {
+		repos.users = &mockUserRepository{users: map[string]*models.User{}}
+	}
+	if repos.roles == nil {
+		repos.roles = &mockRoleRepository{roles: map[string]*models.Role{}}
+	}
+	if repos.userRoles == nil {
+		repos.userRoles = &mockUserRoleRepository{}
+	}
+	if repos.groupRoles == nil {
+		repos.groupRoles = &mockGroupRoleRepository{}
+	}
+	if repos.serviceAccounts == nil {
+		repos.serviceAccounts = &mockServiceAccountRepository{accounts: map[string]*models.ServiceAccount{}}
+	}
+	if repos.sessions == nil {
+		repos.sessions = &mockSessionRepository{sessions: map[string]*models.Session{}}
+	}
+
+	assignments := &testAssignments{roles: repos.roles, userRoles: repos.userRoles, groupRoles: repos.groupRoles}
+	repos.userRoles.assignments = assignments
+	repos.groupRoles.assignments = assignments
+	repos.serviceAccounts.userRoles, repos.serviceAccounts.assignments = repos.userRoles, assignments
+	repos.users.userRoles = repos.userRoles
+
+	cache, err := NewGroupRoleCache(repos.groupRoles, repos.roles)
+	require.NoError(t, err)
+
+	return &iamService{
+		users:           repos.users,
+		roles:           repos.roles,
+		userRoles:       repos.userRoles,
+		groupRoles:      repos.groupRoles,
+		serviceAccounts: repos.serviceAccounts,
+		sessions:        repos.sessions,
+		groupRoleCache:  cache,
+		enforcer:        newTestEnforcer(t, policies...),
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+// mockUserRepository for testing
+type mockUserRepository struct {
+	users     map[string]*models.User // subject → user
+	userRoles *mockUserRoleRepository // Optional: Merge moves its assignments
+}
+
+func (m *mockUserRepository) Create(ctx context.Context, user *models.User) error {
+	if user.Subject != nil {
+		m.users[*user.Subject] = user
+	} else {
+		m.users[user.Email] = user
+	}
+	return nil
+}
+
+func (m *mockUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	for _, u := range m.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func (m *mockUserRepository) GetBySubject(ctx context.Context, subject string) (*models.User, error) {
+	if u, ok := m.users[subject]; ok {
+		return u, nil
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func (m *mockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	if u, ok := m.users[email]; ok {
+		return u, nil
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func (m *mockUserRepository) Update(ctx context.Context, user *models.User) error {
+	if user.Subject != nil {
+		m.users[*user.Subject] = user
+	}
+	return nil
+}
+
+func (m *mockUserRepository) UpdateLastLogin(ctx context.Context, id string) error {
+	for _, u := range m.users {
+		if u.ID == id {
+			now := time.Now()
+			u.LastLoginAt = &now
+			return nil
+		}
+	}
+	return fmt.Errorf("user not found")
+}
+
+func (m *mockUserRepository) SetTokensValidAfter(ctx context.Context, id string, epoch time.Time) error {
+	for _, u := range m.users {
+		if u.ID == id {
+			u.TokensValidAfter = &epoch
+			return nil
+		}
+	}
+	return fmt.Errorf("user not found")
+}
+
+func (m *mockUserRepository) SetPasswordHash(ctx context.Context, id string, passwordHash string, mustChange bool) error {
+	for _, u := range m.users {
+		if u.ID == id {
+			u.PasswordHash = &passwordHash
+			u.MustChangePassword = mustChange
+			return nil
+		}
+	}
+	return fmt.Errorf("user not found")
+}
+
+func (m *mockUserRepository) List(ctx context.Context) ([]models.User, error) {
+	result := make([]models.User, 0, len(m.users))
+	for _, u := range m.users {
+		result = append(result, *u)
+	}
+	return result, nil
+}
+
+// Merge drops the duplicate user, moving its role assignments to the primary
+// user the way BunUserRepository does inside its transaction.
+func (m *mockUserRepository) Merge(ctx context.Context, primaryID, duplicateID, primaryPrincipal, duplicatePrincipal string) error {
+	if m.userRoles != nil {
+		held := make(map[string]bool)
+		for _, ur := range m.userRoles.records {
+			if ur.UserID != nil && *ur.UserID == primaryID {
+				held[ur.RoleID] = true
+			}
+		}
+
+		kept := m.userRoles.records[:0]
+		for _, ur := range m.userRoles.records {
+			if ur.UserID != nil && *ur.UserID == duplicateID {
+				if held[ur.RoleID] {
+					continue
+				}
+				ur.UserID = strPtr(primaryID)
+			}
+			kept = append(kept, ur)
+		}
+		m.userRoles.records = kept
+	}
+
+	for key, u := range m.users {
+		if u.ID == duplicateID {
+			delete(m.users, key)
+			return nil
+		}
+	}
+	return fmt.Errorf("user not found")
+}
+
+// mockServiceAccountRepository for testing
+type mockServiceAccountRepository struct {
+	accounts    map[string]*models.ServiceAccount // clientID → account
+	userRoles   repository.UserRoleRepository     // Optional: receives CreateWithRoles assignments
+	assignments *testAssignments                  // Optional: runs CreateWithRoles checks
+}
+
+func (m *mockServiceAccountRepository) Create(ctx context.Context, sa *models.ServiceAccount) error {
+	if sa.ID == "" {
+		sa.ID = "sa-" + sa.ClientID
+	}
+	m.accounts[sa.ClientID] = sa
+	return nil
+}
+
+func (m *mockServiceAccountRepository) CreateWithRoles(ctx context.Context, sa *models.ServiceAccount, roles []models.UserRole, check repository.AssignmentCheck) error {
+	// Check every assignment first, as a rolled-back transaction leaves nothing
+	for _, ur := range roles {
+		if err := m.assignments.check(ctx, ur.RoleID, check); err != nil {
+			return err
+		}
+	}
+	if err := m.Create(ctx, sa); err != nil {
+		return err
+	}
+	for i := range roles {
+		roles[i].ServiceAccountID = &sa.ID
+		if err := m.userRoles.Create(ctx, &roles[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockServiceAccountRepository) GetByID(ctx context.Context, id string) (*models.ServiceAccount, error) {
+	for _, sa := range m.accounts {
+		if sa.ID == id {
+			return sa, nil
+		}
+	}
+	return nil, fmt.Errorf("service account not found")
+}
+
+func (m *mockServiceAccountRepository) GetByName(ctx context.Context, name string) (*models.ServiceAccount, error) {
+	for _, sa := range m.accounts {
+		if sa.Name == name {
+			return sa, nil
+		}
+	}
+	return nil, fmt.Errorf("service account not found")
+}
+
+func (m *mockServiceAccountRepository) GetByClientID(ctx context.Context, clientID string) (*models.ServiceAccount, error) {
+	if sa, ok := m.accounts[clientID]; ok {
+		return sa, nil
+	}
+	return nil, fmt.Errorf("service account not found")
+}
+
+func (m *mockServiceAccountRepository) Update(ctx context.Context, sa *models.ServiceAccount) error {
+	m.accounts[sa.ClientID] = sa
+	return nil
+}
+
+func (m *mockServiceAccountRepository) UpdateLastUsed(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockServiceAccountRepository) UpdateSecretHash(ctx context.Context, id string, secretHash string) error {
+	for _, sa := range m.accounts {
+		if sa.ID == id {
+			sa.ClientSecretHash = secretHash
+			sa.SecretRotatedAt = time.Now()
+			sa.PreviousSecretHash, sa.PreviousSecretExpiresAt = nil, nil
+		}
+	}
+	return nil
+}
+
+func (m *mockServiceAccountRepository) UpdateSecretHashWithOverlap(ctx context.Context, id string, secretHash string, previousExpiresAt time.Time) error {
+	for _, sa := range m.accounts {
+		if sa.ID == id {
+			previous := sa.ClientSecretHash
+			sa.PreviousSecretHash, sa.PreviousSecretExpiresAt = &previous, &previousExpiresAt
+			sa.ClientSecretHash = secretHash
+			sa.SecretRotatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (m *mockServiceAccountRepository) ClearPreviousSecret(ctx context.Context, id string, now time.Time) error {
+	for _, sa := range m.accounts {
+		if sa.ID == id && sa.PreviousSecretExpiresAt != nil && !sa.PreviousSecretExpiresAt.After(now) {
+			sa.PreviousSecretHash, sa.PreviousSecretExpiresAt = nil, nil
+		}
+	}
+	return nil
+}
+
+func (m *mockServiceAccountRepository) SetDisabled(ctx context.Context, id string, disabled bool) error {
+	return nil
+}
+
+func (m *mockServiceAccountRepository) Delete(ctx context.Context, id string) error {
+	for clientID, sa := range m.accounts {
+		if sa.ID == id {
+			delete(m.accounts, clientID)
+			return nil
+		}
+	}
+	return fmt.Errorf("service account not found")
+}
+
+func (m *mockServiceAccountRepository) List(ctx context.Context) ([]models.ServiceAccount, error) {
+	result := make([]models.ServiceAccount, 0, len(m.accounts))
+	for _, sa := range m.accounts {
+		result = append(result, *sa)
+	}
+	return result, nil
+}
+
+func (m *mockServiceAccountRepository) ListByCreator(ctx context.Context, createdBy string) ([]models.ServiceAccount, error) {
+	return nil, nil
+}
+
+func (m *mockServiceAccountRepository) ListFiltered(ctx context.Context, filter ServiceAccountFilter) ([]models.ServiceAccount, error) {
+	return m.List(ctx)
+}
+
+// mockRevokedJTIRepository for testing
+type mockRevokedJTIRepository struct {
+	revokedJTIs map[string]bool
+}
+
+func (m *mockRevokedJTIRepository) Create(ctx context.Context, revokedJTI *models.RevokedJTI) error {
+	m.revokedJTIs[revokedJTI.JTI] = true
+	return nil
+}
+
+func (m *mockRevokedJTIRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return m.revokedJTIs[jti], nil
+}
+
+func (m *mockRevokedJTIRepository) DeleteExpired(ctx context.Context, gracePeriod time.Duration) error {
+	return nil
+}
+
+func (m *mockRevokedJTIRepository) GetByJTI(ctx context.Context, jti string) (*models.RevokedJTI, error) {
+	if m.revokedJTIs[jti] {
+		return &models.RevokedJTI{JTI: jti}, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+// mockSessionRepository for testing. It is locked because Authenticate
+// updates last-used timestamps from a background goroutine.
+type mockSessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]*models.Session // tokenHash → session
+}
+
+func (m *mockSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (m *mockSessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sessions {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("session not found")
+}
+
+func (m *mockSessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.sessions[tokenHash]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("session %w", repository.ErrNotFound)
+}
+
+func (m *mockSessionRepository) GetByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := []models.Session{}
+	for _, s := range m.sessions {
+		if s.UserID != nil && *s.UserID == userID {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) GetByServiceAccountID(ctx context.Context, serviceAccountID string) ([]models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := []models.Session{}
+	for _, s := range m.sessions {
+		if s.ServiceAccountID != nil && *s.ServiceAccountID == serviceAccountID {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) UpdateLastUsed(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sessions {
+		if s.ID == id {
+			s.LastUsedAt = time.Now()
+			return nil
+		}
+	}
+	return fmt.Errorf("session not found")
+}
+
+func (m *mockSessionRepository) Revoke(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sessions {
+		if s.ID == id {
+			s.Revoked = true
+			return nil
+		}
+	}
+	return fmt.Errorf("session not found")
+}
+
+func (m *mockSessionRepository) RevokeByUserID(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sessions {
+		if s.UserID != nil && *s.UserID == userID {
+			s.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (m *mockSessionRepository) RevokeByServiceAccountID(ctx context.Context, serviceAccountID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sessions {
+		if s.ServiceAccountID != nil && *s.ServiceAccountID == serviceAccountID {
+			s.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (m *mockSessionRepository) DeleteExpired(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for hash, s := range m.sessions {
+		if s.ExpiresAt.Before(now) {
+			delete(m.sessions, hash)
+		}
+	}
+	return nil
+}
+
+func (m *mockSessionRepository) List(ctx context.Context) ([]models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]models.Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		result = append(result, *s)
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) ListFiltered(ctx context.Context, filter repository.SessionFilter) ([]models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := []models.Session{}
+	for _, s := range m.sessions {
+		if sessionMatches(s, filter) {
+			result = append(result, *s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	if filter.Offset >= len(result) {
+		return []models.Session{}, nil
+	}
+	result = result[filter.Offset:]
+	if filter.PageSize > 0 && len(result) > filter.PageSize {
+		result = result[:filter.PageSize]
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) RevokeFiltered(ctx context.Context, filter repository.SessionFilter, batchSize int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, s := range m.sessions {
+		if !s.Revoked && sessionMatches(s, filter) {
+			s.Revoked = true
+			count++
+		}
+	}
+	return count, nil
+}
+
+// sessionMatches mirrors the repository's SessionFilter conditions.
+func sessionMatches(s *models.Session, filter repository.SessionFilter) bool {
+	if filter.UserID != "" && (s.UserID == nil || *s.UserID != filter.UserID) {
+		return false
+	}
+	if filter.ServiceAccountID != "" && (s.ServiceAccountID == nil || *s.ServiceAccountID != filter.ServiceAccountID) {
+		return false
+	}
+	if filter.ActiveOnly && (s.Revoked || !s.ExpiresAt.After(time.Now())) {
+		return false
+	}
+	if filter.RevokedOnly && !s.Revoked {
+		return false
+	}
+	if filter.CreatedAfter != nil && !s.CreatedAt.After(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !s.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	if filter.ExcludeID != "" && s.ID == filter.ExcludeID {
+		return false
+	}
+	return true
+}
+
+// mockRoleRepository reports missing and duplicate roles the way
+// BunRoleRepository does.
+type mockRoleRepository struct {
+	mu      sync.RWMutex
+	roles   map[string]*models.Role // roleID → role
+	listErr error                   // Optional: returned by List
+}
+
+func (m *mockRoleRepository) Create(ctx context.Context, role *models.Role) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.roles {
+		if existing.Name == role.Name {
+			return fmt.Errorf("create role: %w", repository.ErrAlreadyExists)
+		}
+	}
+	if role.ID == "" {
+		role.ID = "role-" + role.Name // the database would generate one
+	}
+	m.roles[role.ID] = role
+	return nil
+}
+
+func (m *mockRoleRepository) GetByID(ctx context.Context, id string) (*models.Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if role, ok := m.roles[id]; ok {
+		return role, nil
+	}
+	return nil, fmt.Errorf("role %w: %s", repository.ErrNotFound, id)
+}
+
+func (m *mockRoleRepository) GetByName(ctx context.Context, name string) (*models.Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, role := range m.roles {
+		if role.Name == name {
+			return role, nil
+		}
+	}
+	return nil, fmt.Errorf("role %w: %s", repository.ErrNotFound, name)
+}
+
+func (m *mockRoleRepository) Update(ctx context.Context, role *models.Role) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.roles[role.ID]; !ok {
+		return fmt.Errorf("role %w: %s", repository.ErrNotFound, role.ID)
+	}
+	m.roles[role.ID] = role
+	return nil
+}
+
+func (m *mockRoleRepository) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.roles[id]; !ok {
+		return fmt.Errorf("role %w: %s", repository.ErrNotFound, id)
+	}
+	delete(m.roles, id)
+	return nil
+}
+
+func (m *mockRoleRepository) List(ctx context.Context) ([]models.Role, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]models.Role, 0, len(m.roles))
+	for _, role := range m.roles {
+		result = append(result, *role)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// mockUserRoleRepository stores role assignments of users and service
+// accounts, rejecting a second assignment of the same role as the user_roles
+// unique constraints do.
+type mockUserRoleRepository struct {
+	records     []models.UserRole
+	assignments *testAssignments // Optional: runs CreateChecked checks
+}
+
+func (m *mockUserRoleRepository) Create(ctx context.Context, ur *models.UserRole) error {
+	for _, existing := range m.records {
+		if existing.RoleID == ur.RoleID && samePrincipal(existing, *ur) {
+			return fmt.Errorf("create user role: %w", repository.ErrAlreadyExists)
+		}
+	}
+	if ur.ID == "" {
+		ur.ID = fmt.Sprintf("ur-%d", len(m.records)+1)
+	}
+	m.records = append(m.records, *ur)
+	return nil
+}
+
+func (m *mockUserRoleRepository) CreateChecked(ctx context.Context, ur *models.UserRole, check repository.AssignmentCheck) error {
+	if err := m.assignments.check(ctx, ur.RoleID, check); err != nil {
+		return err
+	}
+	return m.Create(ctx, ur)
+}
+
+func (m *mockUserRoleRepository) GetByID(ctx context.Context, id string) (*models.UserRole, error) {
+	for _, ur := range m.records {
+		if ur.ID == id {
+			return &ur, nil
+		}
+	}
+	return nil, fmt.Errorf("user role not found: %s", id)
+}
+
+func (m *mockUserRoleRepository) GetByUserID(ctx context.Context, userID string) ([]models.UserRole, error) {
+	return m.filter(func(ur models.UserRole) bool { return ur.UserID != nil && *ur.UserID == userID }), nil
+}
+
+func (m *mockUserRoleRepository) GetByUserAndRoleID(ctx context.Context, userID string, roleID string) (*models.UserRole, error) {
+	for _, ur := range m.records {
+		if ur.UserID != nil && *ur.UserID == userID && ur.RoleID == roleID {
+			return &ur, nil
+		}
+	}
+	return nil, fmt.Errorf("user role assignment not found: user_id=%s, role_id=%s", userID, roleID)
+}
+
+func (m *mockUserRoleRepository) GetByServiceAccountID(ctx context.Context, serviceAccountID string) ([]models.UserRole, error) {
+	return m.filter(func(ur models.UserRole) bool {
+		return ur.ServiceAccountID != nil && *ur.ServiceAccountID == serviceAccountID
+	}), nil
+}
+
+func (m *mockUserRoleRepository) GetByServiceAccountAndRoleID(ctx context.Context, serviceAccountID string, roleID string) (*models.UserRole, error) {
+	for _, ur := range m.records {
+		if ur.ServiceAccountID != nil && *ur.ServiceAccountID == serviceAccountID && ur.RoleID == roleID {
+			return &ur, nil
+		}
+	}
+	return nil, fmt.Errorf("service account role assignment not found: service_account_id=%s, role_id=%s", serviceAccountID, roleID)
+}
+
+func (m *mockUserRoleRepository) GetByRoleID(ctx context.Context, roleID string) ([]models.UserRole, error) {
+	return m.filter(func(ur models.UserRole) bool { return ur.RoleID == roleID }), nil
+}
+
+func (m *mockUserRoleRepository) Delete(ctx context.Context, id string) error {
+	for i, ur := range m.records {
+		if ur.ID == id {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("user role not found: %s", id)
+}
+
+func (m *mockUserRoleRepository) DeleteByUserAndRole(ctx context.Context, userID string, roleID string) error {
+	m.records = m.filter(func(ur models.UserRole) bool {
+		return ur.UserID == nil || *ur.UserID != userID || ur.RoleID != roleID
+	})
+	return nil
+}
+
+func (m *mockUserRoleRepository) DeleteByServiceAccountAndRole(ctx context.Context, serviceAccountID string, roleID string) error {
+	m.records = m.filter(func(ur models.UserRole) bool {
+		return ur.ServiceAccountID == nil || *ur.ServiceAccountID != serviceAccountID || ur.RoleID != roleID
+	})
+	return nil
+}
+
+func (m *mockUserRoleRepository) List(ctx context.Context) ([]models.UserRole, error) {
+	return append([]models.UserRole(nil), m.records...), nil
+}
+
+// filter returns the records keep accepts, in order.
+func (m *mockUserRoleRepository) filter(keep func(models.UserRole) bool) []models.UserRole {
+	var result []models.UserRole
+	for _, ur := range m.records {
+		if keep(ur) {
+			result = append(result, ur)
+		}
+	}
+	return result
+}
+
+// samePrincipal reports whether two assignments are held by the same user or
+// service account.
+func samePrincipal(a, b models.UserRole) bool {
+	switch {
+	case a.UserID != nil && b.UserID != nil:
+		return *a.UserID == *b.UserID
+	case a.ServiceAccountID != nil && b.ServiceAccountID != nil:
+		return *a.ServiceAccountID == *b.ServiceAccountID
+	}
+	return false
+}
+
+// Mock repositories for testing
+
+type mockGroupRoleRepository struct {
+	mu          sync.RWMutex
+	records     []models.GroupRole
+	assignments *testAssignments // Optional: runs CreateChecked checks
+}
+
+func (m *mockGroupRoleRepository) Create(ctx context.Context, gr *models.GroupRole) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, *gr)
+	return nil
+}
+
+func (m *mockGroupRoleRepository) CreateChecked(ctx context.Context, gr *models.GroupRole, check repository.AssignmentCheck) error {
+	if err := m.assignments.check(ctx, gr.RoleID, check); err != nil {
+		return err
+	}
+	return m.Create(ctx, gr)
+}
+
+func (m *mockGroupRoleRepository) GetByID(ctx context.Context, id string) (*models.GroupRole, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, gr := range m.records {
+		if gr.ID == id {
+			return &gr, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockGroupRoleRepository) GetByGroupName(ctx context.Context, groupName string) ([]models.GroupRole, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []models.GroupRole
+	for _, gr := range m.records {
+		if gr.GroupName == groupName {
+			result = append(result, gr)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockGroupRoleRepository) GetByRoleID(ctx context.Context, roleID string) ([]models.GroupRole, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []models.GroupRole
+	for _, gr := range m.records {
+		if gr.RoleID == roleID {
+			result = append(result, gr)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockGroupRoleRepository) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, gr := range m.records {
+		if gr.ID == id {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockGroupRoleRepository) DeleteByGroupAndRole(ctx context.Context, groupName string, roleID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.records) - 1; i >= 0; i-- {
+		if m.records[i].GroupName == groupName && m.records[i].RoleID == roleID {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+		}
+	}
+	return nil
+}
+
+func (m *mockGroupRoleRepository) List(ctx context.Context) ([]models.GroupRole, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// Return copy to prevent data races
+	result := make([]models.GroupRole, len(m.records))
+	copy(result, m.records)
+	return result, nil
+}
+
+// testAssignments runs AssignmentChecks against the records of the test
+// repositories, as the Bun repositories do inside their transaction. A nil
+// *testAssignments runs no checks.
+type testAssignments struct {
+	roles      repository.RoleRepository
+	userRoles  repository.UserRoleRepository
+	groupRoles repository.GroupRoleRepository
+}
+
+func (a *testAssignments) check(ctx context.Context, roleID string, check repository.AssignmentCheck) error {
+	if a == nil || check == nil {
+		return nil
+	}
+	role, err := a.roles.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	userRoles, err := a.userRoles.GetByRoleID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	groupRoles, err := a.groupRoles.GetByRoleID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	return check(ctx, role, userRoles, groupRoles)
+}
+
+// mockRevocationEpochRepository stores the epoch in memory
+type mockRevocationEpochRepository struct {
+	epoch *models.RevocationEpoch
+}
+
+func (m *mockRevocationEpochRepository) Get(ctx context.Context) (*models.RevocationEpoch, error) {
+	return m.epoch, nil
+}
+
+func (m *mockRevocationEpochRepository) Set(ctx context.Context, epoch time.Time, setBy string) error {
+	m.epoch = &models.RevocationEpoch{ID: 1, Epoch: epoch, SetBy: setBy, UpdatedAt: time.Now()}
+	return nil
+}
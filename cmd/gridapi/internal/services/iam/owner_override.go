@@ -0,0 +1,71 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// validateOwnerActions checks a role's owner override actions. Each must be a
+// known action (wildcards allowed) whose concrete actions the role already
+// grants on states, so owner override only lifts the label scope and never
+// adds permissions the role lacks.
+// Role actions are in "obj:act" format (e.g. "state:tfstate:read").
+func validateOwnerActions(ownerActions, actions []string) error {
+	granted := make(map[string]struct{})
+	for _, action := range actions {
+		objType, act, ok := strings.Cut(action, ":")
+		if !ok || (objType != auth.ObjectTypeState && objType != auth.ObjectTypeAll) {
+			continue
+		}
+		for _, concrete := range auth.ExpandWildcard(act) {
+			granted[concrete] = struct{}{}
+		}
+	}
+
+	for _, ownerAction := range ownerActions {
+		if !auth.ValidateAction(ownerAction) {
+			return fmt.Errorf("invalid owner_actions: unknown action %q", ownerAction)
+		}
+		for _, concrete := range auth.ExpandWildcard(ownerAction) {
+			if _, ok := granted[concrete]; !ok {
+				return fmt.Errorf("invalid owner_actions: %q is not granted on states by the role's actions", concrete)
+			}
+		}
+	}
+	return nil
+}
+
+// AuthorizeOwner reports whether any of the principal's roles lists act in its
+// OwnerActions. Callers must already have established that the principal
+// created the state; the role's label scope is deliberately not consulted,
+// but a down-scoped token is still held to its token scopes.
+func (s *iamService) AuthorizeOwner(ctx context.Context, principal *Principal, act string) (bool, error) {
+	if principal == nil {
+		return false, fmt.Errorf("nil principal")
+	}
+	if !auth.TokenScopesAllow(principal.TokenScopes, act) {
+		return false, nil
+	}
+
+	for _, roleName := range principal.Roles {
+		role, err := s.roles.GetByName(ctx, roleName)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue // Role deleted since the principal authenticated
+		}
+		if err != nil {
+			return false, fmt.Errorf("get role %s: %w", roleName, err)
+		}
+		for _, ownerAction := range role.OwnerActions {
+			if slices.Contains(auth.ExpandWildcard(ownerAction), act) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
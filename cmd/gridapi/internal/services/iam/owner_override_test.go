@@ -0,0 +1,105 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestAuthorizeOwner(t *testing.T) {
+	t.Parallel()
+
+	devScope := `env == "dev"`
+	svc := &iamService{
+		roles: &mockRoleRepository{
+			roles: map[string]*models.Role{
+				"role-owner": {ID: "role-owner", Name: "dev-owner", ScopeExpr: devScope, OwnerActions: []string{auth.TfstateWildcard}},
+				"role-dev":   {ID: "role-dev", Name: "dev", ScopeExpr: devScope},
+			},
+		},
+		enforcer: newTestEnforcer(t,
+			[]string{auth.RoleID("dev-owner"), auth.ObjectTypeState, auth.TfstateWrite, devScope, "allow"},
+			[]string{auth.RoleID("dev"), auth.ObjectTypeState, auth.TfstateWrite, devScope, "allow"},
+			[]string{auth.RoleID("no-prod"), auth.ObjectTypeState, auth.TfstateWrite, `env == "prod"`, auth.EffectDeny},
+		),
+	}
+	ctx := context.Background()
+	prodLabels := map[string]any{"env": "prod"}
+
+	for _, tc := range []struct {
+		role    string
+		allowed bool
+	}{
+		{role: "dev-owner", allowed: true},
+		{role: "dev", allowed: false},
+	} {
+		t.Run(tc.role, func(t *testing.T) {
+			principal := &Principal{Roles: []string{tc.role}}
+
+			// The state is outside the role's label scope...
+			allowed, err := svc.Authorize(ctx, principal, auth.ObjectTypeState, auth.TfstateWrite, prodLabels)
+			require.NoError(t, err)
+			require.False(t, allowed)
+
+			// ...so only an owner override lets its creator write to it.
+			allowed, err = svc.AuthorizeOwner(ctx, principal, auth.TfstateWrite)
+			require.NoError(t, err)
+			require.Equal(t, tc.allowed, allowed)
+
+			// A read-only token gets no write through ownership either
+			readOnly := &Principal{Roles: principal.Roles, TokenScopes: []string{auth.TfstateRead}}
+			allowed, err = svc.AuthorizeOwner(ctx, readOnly, auth.TfstateWrite)
+			require.NoError(t, err)
+			require.False(t, allowed)
+		})
+	}
+
+	t.Run("explicit deny is reported", func(t *testing.T) {
+		// An owner override role alongside a deny role: the denial is final
+		principal := &Principal{Roles: []string{"dev-owner", "no-prod"}}
+		decision, err := svc.AuthorizeDecision(ctx, principal, auth.ObjectTypeState, auth.TfstateWrite, prodLabels)
+		require.NoError(t, err)
+		require.Equal(t, Decision{ExplicitDeny: true}, decision)
+
+		decision, err = svc.AuthorizeDecision(ctx, &Principal{Roles: []string{"dev-owner"}}, auth.ObjectTypeState, auth.TfstateWrite, prodLabels)
+		require.NoError(t, err)
+		require.Equal(t, Decision{}, decision, "out of scope is not an explicit deny")
+	})
+
+	t.Run("actions not opted in are denied", func(t *testing.T) {
+		allowed, err := svc.AuthorizeOwner(ctx, &Principal{Roles: []string{"dev-owner"}}, auth.StateDelete)
+		require.NoError(t, err)
+		require.False(t, allowed)
+	})
+
+	t.Run("role deleted since authentication is skipped", func(t *testing.T) {
+		// Runs last: the other subtests share svc and need the dev role
+		require.NoError(t, svc.roles.Delete(ctx, "role-dev"))
+
+		allowed, err := svc.AuthorizeOwner(ctx, &Principal{Roles: []string{"dev", "dev-owner"}}, auth.TfstateWrite)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	})
+}
+
+func TestValidateOwnerActions(t *testing.T) {
+	t.Parallel()
+
+	actions := []string{"state:tfstate:*", "state:state:read", "policy:policy:read"}
+
+	require.NoError(t, validateOwnerActions(nil, actions))
+	require.NoError(t, validateOwnerActions([]string{auth.TfstateWrite, auth.StateRead}, actions))
+	require.NoError(t, validateOwnerActions([]string{auth.TfstateWildcard}, actions))
+	require.NoError(t, validateOwnerActions([]string{auth.StateDelete}, []string{"*:*"}))
+
+	err := validateOwnerActions([]string{auth.StateDelete}, actions)
+	require.ErrorContains(t, err, "invalid owner_actions")
+	require.ErrorContains(t, err, auth.StateDelete)
+
+	// Only grants on states count
+	require.Error(t, validateOwnerActions([]string{auth.PolicyRead}, actions))
+	require.ErrorContains(t, validateOwnerActions([]string{"state:nope"}, actions), "unknown action")
+}
@@ -0,0 +1,132 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordHashCost matches the cost `gridapi users create` hashes passwords with.
+const passwordHashCost = 12
+
+var (
+	// ErrIncorrectPassword is returned by ChangePassword when the old password
+	// does not match the stored hash.
+	ErrIncorrectPassword = errors.New("incorrect password")
+
+	// ErrNoLocalPassword is returned by ChangePassword for users authenticated
+	// by an external IdP, who have no Grid password to change.
+	ErrNoLocalPassword = errors.New("user has no local password")
+)
+
+// ChangePasswordOptions controls what happens to a user's sessions after a
+// password change.
+type ChangePasswordOptions struct {
+	// RevokeOtherSessions revokes every session of the user except KeepSessionID.
+	RevokeOtherSessions bool
+	// KeepSessionID is the caller's own session, left active. Optional.
+	KeepSessionID string
+}
+
+// ChangePassword replaces an internal IdP user's password after checking the
+// old one, clearing any must_change_password flag. A new password failing the policy returns the *auth.PasswordPolicyError.
+func (s *iamService) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string, opts ChangePasswordOptions) error {
+	// Step 1: Verify the old password
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if user.PasswordHash == nil || *user.PasswordHash == "" {
+		return ErrNoLocalPassword
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(oldPassword)); err != nil {
+		return ErrIncorrectPassword
+	}
+
+	// Step 2: Enforce the password policy on the new password
+	if newPassword == oldPassword {
+		return &auth.PasswordPolicyError{
+			Requirement: auth.PasswordRequirementChanged,
+			Message:     "must differ from the current password",
+		}
+	}
+	if s.passwordPolicy != nil {
+		if err := s.passwordPolicy.Validate(newPassword); err != nil {
+			return err
+		}
+	}
+
+	// Step 3: Store the new hash
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), passwordHashCost)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	if err := s.users.SetPasswordHash(ctx, userID, string(hash), false); err != nil {
+		return err
+	}
+
+	// Step 4: Sign out other devices if requested
+	if opts.RevokeOtherSessions {
+		filter := SessionFilter{UserID: userID, ExcludeID: opts.KeepSessionID}
+		if _, err := s.sessions.RevokeFiltered(ctx, filter, revokeSessionsBatchSize); err != nil {
+			return fmt.Errorf("revoke other sessions: %w", err)
+		}
+	}
+	return nil
+}
+
+// AdminResetPassword gives an internal IdP user a generated temporary password
+// and flags the account so the next login must replace it. Every credential
+// issued to the user before the reset stops working.
+func (s *iamService) AdminResetPassword(ctx context.Context, userID string) (_ string, err error) {
+	event := AuditEvent{
+		Action:     AuditActionUserPasswordReset,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+		After:      map[string]any{"must_change_password": true},
+	}
+	defer func() { s.audit(ctx, event, err) }()
+
+	// Step 1: Only users with a local password can be reset
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("get user: %w", err)
+	}
+	if user.PasswordHash == nil || *user.PasswordHash == "" {
+		return "", ErrNoLocalPassword
+	}
+
+	// Step 2: Generate a temporary password that satisfies the policy
+	policy := s.passwordPolicy
+	if policy == nil {
+		policy = &auth.PasswordPolicy{}
+	}
+	tempPassword, err := policy.Generate()
+	if err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(tempPassword), passwordHashCost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+
+	// Step 3: Store it as a temporary password
+	if err := s.users.SetPasswordHash(ctx, userID, string(hash), true); err != nil {
+		return "", err
+	}
+
+	// Step 4: Sign the user out everywhere: every session with its refresh and
+	// access tokens, and, through the user's revocation epoch, any token whose
+	// session was not recorded
+	if err := s.users.SetTokensValidAfter(ctx, userID, roundUpToSecond(time.Now())); err != nil {
+		return "", err
+	}
+	if _, err := s.revokeFilteredSessions(ctx, SessionFilter{UserID: userID}); err != nil {
+		return "", err
+	}
+	return tempPassword, nil
+}
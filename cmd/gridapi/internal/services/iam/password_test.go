@@ -0,0 +1,155 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newChangePasswordTestService seeds alice with password "old-password" and
+// two active sessions, sess-current and sess-other.
+func newChangePasswordTestService(t *testing.T) (*iamService, *models.User, *mockSessionRepository) {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	passwordHash := string(hash)
+	alice := &models.User{ID: "user-alice", Email: "alice@example.com", PasswordHash: &passwordHash}
+	users := &mockUserRepository{users: map[string]*models.User{alice.Email: alice}}
+
+	sessions := &mockSessionRepository{sessions: map[string]*models.Session{}}
+	for _, id := range []string{"sess-current", "sess-other"} {
+		sessions.sessions["hash-"+id] = &models.Session{ID: id, UserID: &alice.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	policy, err := auth.NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 12, RequireDigit: true})
+	require.NoError(t, err)
+
+	svc := newTestService(t, testRepos{users: users, sessions: sessions})
+	svc.passwordPolicy = policy
+	return svc, alice, sessions
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("success updates hash and keeps sessions by default", func(t *testing.T) {
+		svc, alice, sessions := newChangePasswordTestService(t)
+
+		require.NoError(t, svc.ChangePassword(ctx, alice.ID, "old-password", "new-password-42", ChangePasswordOptions{}))
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(*alice.PasswordHash), []byte("new-password-42")))
+		for _, s := range sessions.sessions {
+			assert.False(t, s.Revoked, "session %s should stay active", s.ID)
+		}
+	})
+
+	t.Run("revokes other sessions but keeps the caller's", func(t *testing.T) {
+		svc, alice, sessions := newChangePasswordTestService(t)
+
+		require.NoError(t, svc.ChangePassword(ctx, alice.ID, "old-password", "new-password-42", ChangePasswordOptions{
+			RevokeOtherSessions: true,
+			KeepSessionID:       "sess-current",
+		}))
+		assert.False(t, sessions.sessions["hash-sess-current"].Revoked)
+		assert.True(t, sessions.sessions["hash-sess-other"].Revoked)
+	})
+
+	t.Run("wrong old password", func(t *testing.T) {
+		svc, alice, _ := newChangePasswordTestService(t)
+		before := *alice.PasswordHash
+
+		err := svc.ChangePassword(ctx, alice.ID, "not-my-password", "new-password-42", ChangePasswordOptions{})
+		assert.ErrorIs(t, err, ErrIncorrectPassword)
+		assert.Equal(t, before, *alice.PasswordHash)
+	})
+
+	t.Run("weak new password", func(t *testing.T) {
+		svc, alice, _ := newChangePasswordTestService(t)
+		before := *alice.PasswordHash
+
+		err := svc.ChangePassword(ctx, alice.ID, "old-password", "no-digits-here", ChangePasswordOptions{})
+		var policyErr *auth.PasswordPolicyError
+		require.True(t, errors.As(err, &policyErr), "expected PasswordPolicyError, got %v", err)
+		assert.Equal(t, auth.PasswordRequirementDigit, policyErr.Requirement)
+		assert.NotErrorIs(t, err, ErrIncorrectPassword)
+		assert.Equal(t, before, *alice.PasswordHash)
+	})
+
+	t.Run("new password must differ", func(t *testing.T) {
+		svc, alice, _ := newChangePasswordTestService(t)
+
+		err := svc.ChangePassword(ctx, alice.ID, "old-password", "old-password", ChangePasswordOptions{})
+		var policyErr *auth.PasswordPolicyError
+		require.True(t, errors.As(err, &policyErr), "expected PasswordPolicyError, got %v", err)
+		assert.Equal(t, auth.PasswordRequirementChanged, policyErr.Requirement)
+	})
+
+	t.Run("external IdP user has no local password", func(t *testing.T) {
+		svc, alice, _ := newChangePasswordTestService(t)
+		alice.PasswordHash = nil
+
+		err := svc.ChangePassword(ctx, alice.ID, "old-password", "new-password-42", ChangePasswordOptions{})
+		assert.ErrorIs(t, err, ErrNoLocalPassword)
+	})
+}
+
+func TestAdminResetPassword_ForcesChange(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc, alice, sessions := newChangePasswordTestService(t)
+	for _, s := range sessions.sessions {
+		s.JTI, s.RefreshToken = "jti-"+s.ID, "rt-"+s.ID
+	}
+	revoker := &recordingRefreshTokenRevoker{}
+	revokedJTIs := &mockRevokedJTIRepository{revokedJTIs: map[string]bool{}}
+	svc.refreshTokens, svc.revokedJTIs = revoker, revokedJTIs
+
+	resetAt := time.Now()
+	tempPassword, err := svc.AdminResetPassword(ctx, alice.ID)
+	require.NoError(t, err)
+
+	// The temporary password satisfies the policy, replaces the old one and
+	// must be rotated; every session is signed out with its tokens, and
+	// anything issued before the reset predates the user's epoch.
+	assert.NoError(t, svc.passwordPolicy.Validate(tempPassword))
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(*alice.PasswordHash), []byte(tempPassword)))
+	assert.True(t, alice.MustChangePassword)
+	for _, s := range sessions.sessions {
+		assert.True(t, s.Revoked, "session %s should be revoked", s.ID)
+	}
+	assert.ElementsMatch(t, []string{"rt-sess-current", "rt-sess-other"}, revoker.revoked)
+	assert.Equal(t, map[string]bool{"jti-sess-current": true, "jti-sess-other": true}, revokedJTIs.revokedJTIs)
+	require.NotNil(t, alice.TokensValidAfter)
+	assert.True(t, revokedByUserEpoch(alice, resetAt))
+	assert.True(t, revokedByUserEpoch(alice, resetAt.Truncate(time.Second)), "a token issued in the reset's second is rejected")
+
+	// The old password no longer works for the forced change
+	err = svc.ChangePassword(ctx, alice.ID, "old-password", "new-password-42", ChangePasswordOptions{})
+	assert.ErrorIs(t, err, ErrIncorrectPassword)
+	assert.True(t, alice.MustChangePassword)
+
+	// Choosing a new password clears the flag
+	require.NoError(t, svc.ChangePassword(ctx, alice.ID, tempPassword, "new-password-42", ChangePasswordOptions{}))
+	assert.False(t, alice.MustChangePassword)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(*alice.PasswordHash), []byte("new-password-42")))
+}
+
+func TestAdminResetPassword_ExternalUser(t *testing.T) {
+	t.Parallel()
+	svc, alice, sessions := newChangePasswordTestService(t)
+	alice.PasswordHash = nil
+
+	_, err := svc.AdminResetPassword(context.Background(), alice.ID)
+	assert.ErrorIs(t, err, ErrNoLocalPassword)
+	assert.False(t, alice.MustChangePassword)
+	assert.False(t, sessions.sessions["hash-sess-current"].Revoked)
+}
@@ -24,11 +24,17 @@ type Principal struct {
 	InternalID string
 
 	// Email is the user's email address (optional, only for human users).
+	// Taken from the token claims, or the user record when the token has none.
 	Email string
 
-	// Name is the display name (optional, only for human users).
+	// Name is the user's full name (optional, only for human users).
 	Name string
 
+	// DisplayName is a human-friendly label for logs and responses, so they do
+	// not need to look the principal up again. Always set: the user's name,
+	// else email, else subject; for service accounts, the account name.
+	DisplayName string
+
 	// SessionID references the active session (optional, only for cookie auth).
 	// References sessions.id (UUID).
 	SessionID string
@@ -47,10 +53,28 @@ type Principal struct {
 	// policies without mutating any state.
 	Roles []string
 
+	// TokenScopes lists the permission scopes (e.g. "state:read") the token
+	// was issued for. When set, authorization also requires the action to be
+	// covered by one of them, so a token can carry less than its roles allow.
+	// Empty means the token is not down-scoped.
+	TokenScopes []string
+
 	// Type differentiates users and service accounts.
 	Type PrincipalType
 }
 
+// userDisplayName returns the first of name, email and subject that is set.
+func userDisplayName(name, email, subject string) string {
+	switch {
+	case name != "":
+		return name
+	case email != "":
+		return email
+	default:
+		return subject
+	}
+}
+
 // PrincipalType identifies whether this is a user or service account.
 type PrincipalType string
 
@@ -0,0 +1,311 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+)
+
+// RoleAssignmentCorrection is one Casbin grouping rule added or removed by
+// ReconcileRoleAssignments.
+type RoleAssignmentCorrection struct {
+	Principal string `json:"principal"` // Casbin ID, e.g. "user:<subject>" or "group:<name>"
+	Role      string `json:"role"`      // Casbin role ID ("role:<name>")
+	Added     bool   `json:"added"`     // false: removed
+}
+
+// RoleAssignmentReconciliation reports what ReconcileRoleAssignments changed.
+type RoleAssignmentReconciliation struct {
+	Corrections []RoleAssignmentCorrection `json:"corrections"`
+	// Skipped counts user_roles rows whose user or service account no longer
+	// exists, so no Casbin ID can be built for them.
+	Skipped int `json:"skipped"`
+}
+
+// ReconcileRoleAssignments repairs divergence between the user_roles and
+// group_roles tables and Casbin's grouping rules, e.g. after a crash between
+// the database write and the Casbin sync of an assignment or removal.
+//
+// The database is authoritative: rows without a grouping rule get one, and
+// grouping rules of users, service accounts and groups without a row are
+// removed. Rules that don't bind a prefixed principal to a "role:" ID are left
+// alone. Every correction is logged. Changes reach casbin_rule only while the
+// enforcer's AutoSave is on.
+func (s *iamService) ReconcileRoleAssignments(ctx context.Context) (*RoleAssignmentReconciliation, error) {
+	// Step 1: Build the grouping rules the database implies
+	roles, err := s.roles.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	roleIDs := make(map[string]string, len(roles))
+	for _, role := range roles {
+		roleIDs[role.ID] = auth.RoleID(role.Name)
+	}
+
+	users, err := s.users.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	userIDs := make(map[string]string, len(users))
+	for i := range users {
+		userIDs[users[i].ID] = auth.UserID(users[i].PrincipalSubject())
+	}
+
+	accounts, err := s.serviceAccounts.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list service accounts: %w", err)
+	}
+	saIDs := make(map[string]string, len(accounts))
+	for _, sa := range accounts {
+		saIDs[sa.ID] = auth.ServiceAccountID(sa.ClientID)
+	}
+
+	result := &RoleAssignmentReconciliation{Corrections: []RoleAssignmentCorrection{}}
+	want := make(map[[2]string]bool)
+
+	assignments, err := s.userRoles.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list role assignments: %w", err)
+	}
+	for _, ur := range assignments {
+		roleID, ok := roleIDs[ur.RoleID]
+		if !ok {
+			continue
+		}
+		var principal string
+		switch {
+		case ur.UserID != nil:
+			principal = userIDs[*ur.UserID]
+		case ur.ServiceAccountID != nil:
+			principal = saIDs[*ur.ServiceAccountID]
+		}
+		if principal == "" {
+			result.Skipped++
+			log.Printf("WARNING: reconcile: role assignment %s references a missing principal, skipping", ur.ID)
+			continue
+		}
+		want[[2]string{principal, roleID}] = true
+	}
+
+	groupRoles, err := s.groupRoles.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list group roles: %w", err)
+	}
+	for _, gr := range groupRoles {
+		if roleID, ok := roleIDs[gr.RoleID]; ok {
+			want[[2]string{auth.GroupID(gr.GroupName), roleID}] = true
+		}
+	}
+
+	// Step 2: Compare with Casbin's grouping rules
+	rules, err := s.enforcer.GetGroupingPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("get Casbin grouping policy: %w", err)
+	}
+	have := make(map[[2]string]bool, len(rules))
+	for _, rule := range rules {
+		if len(rule) < 2 || !isReconciledGrouping(rule[0], rule[1]) {
+			continue
+		}
+		have[[2]string{rule[0], rule[1]}] = true
+	}
+
+	var missing, orphaned [][2]string
+	for key := range want {
+		if !have[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range have {
+		if !want[key] {
+			orphaned = append(orphaned, key)
+		}
+	}
+	sortGroupings(missing)
+	sortGroupings(orphaned)
+
+	// Step 3: Repair both directions
+	for _, key := range missing {
+		if _, err := s.enforcer.AddRoleForUser(key[0], key[1]); err != nil {
+			return result, fmt.Errorf("add Casbin role assignment %s → %s: %w", key[0], key[1], err)
+		}
+		log.Printf("INFO: reconcile: added missing Casbin grouping %s → %s", key[0], key[1])
+		result.Corrections = append(result.Corrections, RoleAssignmentCorrection{Principal: key[0], Role: key[1], Added: true})
+	}
+	for _, key := range orphaned {
+		if _, err := s.enforcer.DeleteRoleForUser(key[0], key[1]); err != nil {
+			return result, fmt.Errorf("remove Casbin role assignment %s → %s: %w", key[0], key[1], err)
+		}
+		log.Printf("INFO: reconcile: removed Casbin grouping %s → %s without a database assignment", key[0], key[1])
+		result.Corrections = append(result.Corrections, RoleAssignmentCorrection{Principal: key[0], Role: key[1]})
+	}
+
+	return result, nil
+}
+
+// isReconciledGrouping reports whether a grouping rule is a role assignment
+// managed through user_roles or group_roles.
+func isReconciledGrouping(principal, role string) bool {
+	roleID, err := auth.ParseCasbinID(role)
+	if err != nil || roleID.Kind != auth.CasbinRole {
+		return false
+	}
+	principalID, err := auth.ParseCasbinID(principal)
+	return err == nil && principalID.Kind != auth.CasbinRole
+}
+
+func sortGroupings(keys [][2]string) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+}
+
+// RolePolicyDrift is a role whose Casbin policies differ from the actions and
+// scope stored on it.
+type RolePolicyDrift struct {
+	Role string `json:"role"`
+	// Deleted is set for policies left behind by a role that no longer exists
+	Deleted    bool         `json:"deleted,omitempty"`
+	Missing    []RoleAction `json:"missing"`    // Expected but absent from Casbin
+	Unexpected []RoleAction `json:"unexpected"` // In Casbin but not expected
+}
+
+// RolePolicyReconciliation reports the drift ReconcileRolePolicies found.
+type RolePolicyReconciliation struct {
+	Drift []RolePolicyDrift `json:"drift"`
+	// Unverified lists roles stored before their actions were recorded; their
+	// policies can't be rebuilt and are left alone. Saving such a role with
+	// UpdateRole records its actions.
+	Unverified []string `json:"unverified"`
+	Repaired   bool     `json:"repaired"`
+}
+
+// ReconcileRolePolicies compares every role's Casbin policies with the
+// policies its stored actions and scope expression imply, as UpdateRole would
+// write them, e.g. after UpdateRole failed halfway through its Casbin sync.
+// Policies of deleted roles are reported too. With repair, each drifted role's
+// policies are rewritten (and a deleted role's removed); otherwise the drift
+// is only reported so an operator can review it first.
+func (s *iamService) ReconcileRolePolicies(ctx context.Context, repair bool) (*RolePolicyReconciliation, error) {
+	// Step 1: Group the enforcer's policies by role
+	policies, err := s.enforcer.GetPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("get Casbin policies: %w", err)
+	}
+	actual := make(map[string][][]string)
+	for _, policy := range policies {
+		if len(policy) < 5 {
+			continue
+		}
+		if id, err := auth.ParseCasbinID(policy[0]); err != nil || id.Kind != auth.CasbinRole {
+			continue
+		}
+		actual[policy[0]] = append(actual[policy[0]], policy)
+	}
+
+	roles, err := s.roles.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+	result := &RolePolicyReconciliation{Drift: []RolePolicyDrift{}, Unverified: []string{}}
+	expected := make(map[string][][]string)
+
+	// Step 2: Diff each role with recorded actions against its expected policies
+	for _, role := range roles {
+		casbinRoleID := auth.RoleID(role.Name)
+		have := actual[casbinRoleID]
+		delete(actual, casbinRoleID)
+		if role.Actions == nil {
+			result.Unverified = append(result.Unverified, role.Name)
+			continue
+		}
+
+		want := make([][]string, 0, len(role.Actions))
+		for _, action := range role.Actions {
+			objType, act, effect, ok := parseRoleAction(action)
+			if !ok {
+				return nil, fmt.Errorf("role %s has invalid stored action %q", role.Name, action)
+			}
+			want = append(want, []string{casbinRoleID, objType, act, role.ScopeExpr, effect})
+		}
+		if drift, ok := diffRolePolicies(role.Name, want, have); ok {
+			result.Drift = append(result.Drift, drift)
+			expected[casbinRoleID] = want
+		}
+	}
+
+	// Step 3: Whatever remains belongs to deleted roles
+	var deleted []string
+	for casbinRoleID := range actual {
+		deleted = append(deleted, casbinRoleID)
+	}
+	sort.Strings(deleted)
+	for _, casbinRoleID := range deleted {
+		roleName, _ := auth.ExtractRoleID(casbinRoleID)
+		drift, _ := diffRolePolicies(roleName, nil, actual[casbinRoleID])
+		drift.Deleted = true
+		result.Drift = append(result.Drift, drift)
+		expected[casbinRoleID] = nil
+	}
+
+	for _, drift := range result.Drift {
+		log.Printf("WARNING: reconcile: Casbin policies of role %s drifted (deleted=%t, missing=%v, unexpected=%v)",
+			drift.Role, drift.Deleted, drift.Missing, drift.Unexpected)
+	}
+	if !repair || len(result.Drift) == 0 {
+		return result, nil
+	}
+
+	// Step 4: Rewrite the drifted roles' policies
+	for _, drift := range result.Drift {
+		casbinRoleID := auth.RoleID(drift.Role)
+		if _, err := s.enforcer.RemoveFilteredPolicy(0, casbinRoleID); err != nil {
+			return result, fmt.Errorf("remove Casbin policies of role %s: %w", drift.Role, err)
+		}
+		if want := expected[casbinRoleID]; len(want) > 0 {
+			if _, err := s.enforcer.AddPolicies(want); err != nil {
+				return result, fmt.Errorf("add Casbin policies of role %s: %w", drift.Role, err)
+			}
+		}
+		log.Printf("INFO: reconcile: rewrote Casbin policies of role %s", drift.Role)
+	}
+	result.Repaired = true
+	return result, nil
+}
+
+// diffRolePolicies compares a role's expected and actual policies
+// ([role, obj, act, scope, effect]) and reports whether they differ.
+func diffRolePolicies(roleName string, want, have [][]string) (RolePolicyDrift, bool) {
+	key := func(policy []string) string { return strings.Join(policy[:5], "\x00") }
+	toAction := func(policy []string) RoleAction {
+		return RoleAction{Action: formatRoleAction(policy[1], policy[2], policy[4]), ScopeExpr: policy[3], Effect: policy[4]}
+	}
+
+	drift := RolePolicyDrift{Role: roleName, Missing: []RoleAction{}, Unexpected: []RoleAction{}}
+	haveSet := make(map[string]bool, len(have))
+	for _, policy := range have {
+		haveSet[key(policy)] = true
+	}
+	wantSet := make(map[string]bool, len(want))
+	for _, policy := range want {
+		wantSet[key(policy)] = true
+		if !haveSet[key(policy)] {
+			drift.Missing = append(drift.Missing, toAction(policy))
+		}
+	}
+	for _, policy := range have {
+		if !wantSet[key(policy)] {
+			drift.Unexpected = append(drift.Unexpected, toAction(policy))
+		}
+	}
+	return drift, len(drift.Missing) > 0 || len(drift.Unexpected) > 0
+}
@@ -0,0 +1,134 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestReconcileRoleAssignments(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userRoles := &mockUserRoleRepository{}
+	svc := newTestService(t, testRepos{
+		users: &mockUserRepository{users: map[string]*models.User{
+			"ext|alice": {ID: "user-alice", Email: "alice@example.com", Subject: strPtr("ext|alice")},
+		}},
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-reader": {ID: "role-reader", Name: "state-reader"},
+		}},
+		userRoles: userRoles,
+		serviceAccounts: &mockServiceAccountRepository{accounts: map[string]*models.ServiceAccount{
+			"ci-client": {ID: "sa-ci", ClientID: "ci-client", Name: "ci"},
+		}},
+	})
+	require.NoError(t, svc.AssignUserRole(ctx, "", "sa-ci", "role-reader"))
+	require.NoError(t, svc.AssignGroupRole(ctx, "auditors", "role-reader", ""))
+
+	result, err := svc.ReconcileRoleAssignments(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Corrections, "consistent state needs no corrections")
+
+	// Crash after the DB write of an assignment: the row has no grouping
+	userID := "user-alice"
+	userRoles.records = append(userRoles.records, models.UserRole{ID: "ur-crashed", UserID: &userID, RoleID: "role-reader"})
+	// Crash after the DB delete of a removal: the grouping has no row
+	_, err = svc.enforcer.DeleteRoleForUser(auth.GroupID("auditors"), auth.RoleID("state-reader"))
+	require.NoError(t, err)
+	_, err = svc.enforcer.AddRoleForUser(auth.UserID("ext|removed"), auth.RoleID("state-reader"))
+	require.NoError(t, err)
+	// Not a role assignment; left alone
+	_, err = svc.enforcer.AddGroupingPolicy("legacy-subject", "state-reader")
+	require.NoError(t, err)
+
+	result, err = svc.ReconcileRoleAssignments(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []RoleAssignmentCorrection{
+		{Principal: "group:auditors", Role: "role:state-reader", Added: true},
+		{Principal: "user:ext|alice", Role: "role:state-reader", Added: true},
+		{Principal: "user:ext|removed", Role: "role:state-reader"},
+	}, result.Corrections)
+
+	users, err := svc.enforcer.GetUsersForRole(auth.RoleID("state-reader"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"sa:ci-client", "group:auditors", "user:ext|alice"}, users)
+	legacy, err := svc.enforcer.HasGroupingPolicy("legacy-subject", "state-reader")
+	require.NoError(t, err)
+	assert.True(t, legacy)
+
+	result, err = svc.ReconcileRoleAssignments(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Corrections, "reconciliation is idempotent")
+}
+
+func TestReconcileRolePolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	roleRepo := &mockRoleRepository{
+		roles: map[string]*models.Role{
+			// UpdateRole recorded the new actions but crashed before the Casbin sync
+			"role-reader": {ID: "role-reader", Name: "state-reader", ScopeExpr: `env == "dev"`, Actions: []string{"state:state:read", "!state:state:delete"}},
+			"role-ok":     {ID: "role-ok", Name: "in-sync", ScopeExpr: "*", Actions: []string{"state:state:list"}},
+			"role-legacy": {ID: "role-legacy", Name: "legacy", ScopeExpr: "*"},
+		},
+	}
+	svc := &iamService{
+		roles: roleRepo,
+		enforcer: newTestEnforcer(t,
+			[]string{"role:state-reader", "state", "state:read", `env == "prod"`, "allow"},
+			[]string{"role:state-reader", "state", "state:write", `env == "prod"`, "allow"},
+			[]string{"role:in-sync", "state", "state:list", "*", "allow"},
+			[]string{"role:legacy", "state", "state:write", "*", "allow"},
+			[]string{"role:removed", "state", "state:read", "*", "allow"},
+		),
+	}
+
+	// Report only: nothing changes
+	result, err := svc.ReconcileRolePolicies(ctx, false)
+	require.NoError(t, err)
+	assert.False(t, result.Repaired)
+	assert.Equal(t, []string{"legacy"}, result.Unverified)
+	require.Len(t, result.Drift, 2)
+	assert.Equal(t, RolePolicyDrift{
+		Role: "state-reader",
+		Missing: []RoleAction{
+			{Action: "state:state:read", ScopeExpr: `env == "dev"`, Effect: auth.EffectAllow},
+			{Action: "!state:state:delete", ScopeExpr: `env == "dev"`, Effect: auth.EffectDeny},
+		},
+		Unexpected: []RoleAction{
+			{Action: "state:state:read", ScopeExpr: `env == "prod"`, Effect: auth.EffectAllow},
+			{Action: "state:state:write", ScopeExpr: `env == "prod"`, Effect: auth.EffectAllow},
+		},
+	}, result.Drift[0])
+	assert.Equal(t, "removed", result.Drift[1].Role)
+	assert.True(t, result.Drift[1].Deleted)
+
+	policies, err := svc.enforcer.GetPolicy()
+	require.NoError(t, err)
+	assert.Len(t, policies, 5)
+
+	// Repair rewrites the drifted roles and leaves the others alone
+	result, err = svc.ReconcileRolePolicies(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, result.Repaired)
+
+	policies, err = svc.enforcer.GetPolicy()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]string{
+		{"role:state-reader", "state", "state:read", `env == "dev"`, "allow"},
+		{"role:state-reader", "state", "state:delete", `env == "dev"`, "deny"},
+		{"role:in-sync", "state", "state:list", "*", "allow"},
+		{"role:legacy", "state", "state:write", "*", "allow"},
+	}, policies)
+
+	result, err = svc.ReconcileRolePolicies(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Drift)
+}
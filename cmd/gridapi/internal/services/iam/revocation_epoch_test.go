@@ -0,0 +1,124 @@
+package iam
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestBumpRevocationEpoch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	floor := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	svc := &iamService{revocationEpoch: auth.NewRevocationEpochCache(&mockRevocationEpochRepository{}, floor)}
+
+	// The configured epoch applies until an admin bumps it
+	epoch, err := svc.RevocationEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, floor, epoch)
+
+	issued := time.Now()
+	bumped, err := svc.BumpRevocationEpoch(ctx, time.Time{}, "user:admin")
+	require.NoError(t, err)
+	assert.Zero(t, bumped.Nanosecond(), "epoch is rounded to whole seconds")
+	epoch, err = svc.RevocationEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, bumped, epoch)
+
+	// A token issued just before the bump, possibly within the same second,
+	// carries an iat no later than the bump's second and is rejected
+	assert.True(t, auth.IssuedBeforeEpoch(map[string]any{"iat": float64(issued.Unix())}, epoch))
+
+	_, err = svc.BumpRevocationEpoch(ctx, floor, "user:admin")
+	assert.ErrorIs(t, err, ErrInvalidRevocationEpoch)
+
+	_, err = svc.BumpRevocationEpoch(ctx, time.Now().Add(time.Hour), "user:admin")
+	assert.ErrorIs(t, err, ErrInvalidRevocationEpoch)
+}
+
+func TestIntrospectTokenIssuedBeforeEpoch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := newIntrospectTestService(t, map[string]bool{}, map[string]*models.Session{
+		auth.HashBearerToken("jti-1"): {ID: "sess-1", ExpiresAt: time.Now().Add(time.Hour)},
+	})
+	svc.revocationEpoch = auth.NewRevocationEpochCache(&mockRevocationEpochRepository{}, time.Time{})
+
+	// The test token was issued a minute ago, before the bump
+	_, err := svc.BumpRevocationEpoch(ctx, time.Time{}, "user:admin")
+	require.NoError(t, err)
+
+	result, err := svc.IntrospectToken(ctx, "good-token")
+	require.NoError(t, err)
+	assert.False(t, result.Active)
+	assert.Equal(t, "token was issued before the revocation epoch", result.InactiveReason)
+}
+
+func TestSessionAuthenticator_UserRevocationEpoch(t *testing.T) {
+	t.Parallel()
+	epoch := time.Now().Add(-time.Minute)
+
+	userID := "user-123"
+	sub := "alice@example.com"
+	users := &mockUserRepository{users: map[string]*models.User{
+		sub: {ID: userID, Subject: &sub, Email: sub, TokensValidAfter: &epoch},
+	}}
+	hash := auth.HashToken("old-session")
+	sessions := &mockSessionRepository{sessions: map[string]*models.Session{
+		hash: {ID: "old-session", UserID: &userID, TokenHash: hash, CreatedAt: epoch.Add(-time.Hour), ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+
+	authenticator := NewSessionAuthenticator(users, sessions, &mockIAMService{}, 0)
+	_, err := authenticator.Authenticate(context.Background(), AuthRequest{
+		Headers: http.Header{},
+		Cookies: []*http.Cookie{{Name: auth.SessionCookieName, Value: "old-session"}},
+	})
+	assert.ErrorIs(t, err, ErrRevokedByEpoch)
+}
+
+func TestSessionAuthenticator_RevocationEpoch(t *testing.T) {
+	t.Parallel()
+	epoch := time.Now().Add(-time.Minute)
+
+	userID := "user-123"
+	sub := "alice@example.com"
+	users := &mockUserRepository{users: map[string]*models.User{
+		sub: {ID: userID, Subject: &sub, Email: sub},
+	}}
+	sessions := &mockSessionRepository{sessions: map[string]*models.Session{}}
+	for token, createdAt := range map[string]time.Time{
+		"old-session": epoch.Add(-time.Hour),
+		"new-session": epoch.Add(time.Second),
+	} {
+		hash := auth.HashToken(token)
+		sessions.sessions[hash] = &models.Session{
+			ID:        token,
+			UserID:    &userID,
+			TokenHash: hash,
+			CreatedAt: createdAt,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	authenticator := NewSessionAuthenticator(users, sessions, &mockIAMService{epoch: epoch}, 0)
+	request := func(token string) AuthRequest {
+		return AuthRequest{
+			Headers: http.Header{},
+			Cookies: []*http.Cookie{{Name: auth.SessionCookieName, Value: token}},
+		}
+	}
+
+	_, err := authenticator.Authenticate(context.Background(), request("old-session"))
+	assert.ErrorIs(t, err, ErrRevokedByEpoch)
+
+	principal, err := authenticator.Authenticate(context.Background(), request("new-session"))
+	require.NoError(t, err)
+	assert.Equal(t, "new-session", principal.SessionID)
+}
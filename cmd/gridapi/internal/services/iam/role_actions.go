@@ -0,0 +1,117 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+)
+
+// denyActionPrefix marks a role action as a deny rule, e.g. "!state:tfstate:read".
+const denyActionPrefix = "!"
+
+// RoleAction is one permission of a role, in the "obj:act" form CreateRole and
+// UpdateRole accept, with the label scope expression it applies under and its
+// effect (auth.EffectAllow or auth.EffectDeny).
+type RoleAction struct {
+	Action    string `json:"action"`
+	ScopeExpr string `json:"scope_expr"`
+	Effect    string `json:"effect"`
+}
+
+// parseRoleAction splits a role action in "obj:act" format, e.g. "state:read"
+// or "state:tfstate:read" (the action part may itself contain colons). A
+// leading "!" makes it a deny rule.
+func parseRoleAction(action string) (objType, act, effect string, ok bool) {
+	effect = auth.EffectAllow
+	if rest, denied := strings.CutPrefix(action, denyActionPrefix); denied {
+		action, effect = rest, auth.EffectDeny
+	}
+	objType, act, ok = strings.Cut(action, ":")
+	if !ok || objType == "" || act == "" || strings.ContainsAny(action, " \t\n") {
+		return "", "", "", false
+	}
+	return objType, act, effect, true
+}
+
+// formatRoleAction is the inverse of parseRoleAction.
+func formatRoleAction(objType, act, effect string) string {
+	action := objType + ":" + act
+	if effect == auth.EffectDeny {
+		return denyActionPrefix + action
+	}
+	return action
+}
+
+// validateRoleActions rejects an action list containing any malformed entry,
+// naming every offender so a single request can fix them all.
+func validateRoleActions(actions []string) error {
+	var malformed []string
+	for _, action := range actions {
+		if _, _, _, ok := parseRoleAction(action); !ok {
+			malformed = append(malformed, strconv.Quote(action))
+		}
+	}
+	if len(malformed) > 0 {
+		return fmt.Errorf("invalid actions: %s not in obj:act format", strings.Join(malformed, ", "))
+	}
+	return nil
+}
+
+// GetRoleActions returns the role's actions as CreateRole received them,
+// rebuilt from the role's Casbin policies ([role, obj, act, scope, effect]).
+// Deny rules carry the "!" prefix.
+func (s *iamService) GetRoleActions(ctx context.Context, roleName string) ([]RoleAction, error) {
+	role, err := s.roles.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("get role: %w", err)
+	}
+	if role == nil {
+		return nil, fmt.Errorf("role %s not found", roleName)
+	}
+	return s.casbinRoleActions(role.Name)
+}
+
+// casbinRoleActions reads a role's actions back from the enforcer.
+func (s *iamService) casbinRoleActions(roleName string) ([]RoleAction, error) {
+	policies, err := s.enforcer.GetPermissionsForUser(auth.RoleID(roleName))
+	if err != nil {
+		return nil, fmt.Errorf("get permissions from casbin: %w", err)
+	}
+	actions := make([]RoleAction, 0, len(policies))
+	for _, policy := range policies {
+		if action, ok := policyRoleAction(policy); ok {
+			actions = append(actions, action)
+		}
+	}
+	return actions, nil
+}
+
+// policyRoleAction converts a Casbin policy ([role, obj, act, scope, effect])
+// to the role action it was created from.
+func policyRoleAction(policy []string) (RoleAction, bool) {
+	if len(policy) < 5 {
+		return RoleAction{}, false
+	}
+	return RoleAction{
+		Action:    formatRoleAction(policy[1], policy[2], policy[4]),
+		ScopeExpr: policy[3],
+		Effect:    policy[4],
+	}, true
+}
+
+// roleActions returns the role's "obj:act" actions for audit snapshots, where
+// a lookup failure yields nil rather than failing the mutation.
+func (s *iamService) roleActions(roleName string) []string {
+	roleActions, err := s.casbinRoleActions(roleName)
+	if err != nil {
+		return nil
+	}
+	actions := make([]string, 0, len(roleActions))
+	for _, ra := range roleActions {
+		actions = append(actions, ra.Action)
+	}
+	return actions
+}
@@ -0,0 +1,96 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// GroupSizeEstimator reports the approximate member count of an IdP group.
+//
+// Group membership lives in the identity provider, so Grid cannot count group
+// members itself. An estimator lets role assignment caps account for group
+// mappings; without one, capped roles cannot be mapped to groups.
+type GroupSizeEstimator interface {
+	EstimateGroupSize(ctx context.Context, groupName string) (int, error)
+}
+
+// userGroupSizes counts the group memberships Grid records for its internal
+// IdP, where Grid itself is the source of truth for groups.
+type userGroupSizes struct {
+	userGroups repository.UserGroupRepository
+}
+
+// NewUserGroupSizeEstimator returns a GroupSizeEstimator that reports the
+// exact member count of internal IdP groups. It must not be used with an
+// external IdP, whose group memberships Grid never sees.
+func NewUserGroupSizeEstimator(userGroups repository.UserGroupRepository) GroupSizeEstimator {
+	return &userGroupSizes{userGroups: userGroups}
+}
+
+// EstimateGroupSize returns the group's recorded member count.
+func (e *userGroupSizes) EstimateGroupSize(ctx context.Context, groupName string) (int, error) {
+	return e.userGroups.CountMembers(ctx, groupName)
+}
+
+// validateMaxAssignments rejects non-positive caps. nil means unlimited.
+func validateMaxAssignments(maxAssignments *int) error {
+	if maxAssignments != nil && *maxAssignments < 1 {
+		return fmt.Errorf("invalid max_assignments %d: must be at least 1", *maxAssignments)
+	}
+	return nil
+}
+
+// assignmentCapCheck returns the check that keeps a role within its
+// MaxAssignments when it is granted to one more principal, or to groupName's
+// estimated members when groupName is set. The repository runs it inside the
+// assigning transaction, against the role's current direct assignments plus
+// the estimated size of every group mapped to it. Roles without a cap always
+// pass.
+func (s *iamService) assignmentCapCheck(groupName string) repository.AssignmentCheck {
+	return func(ctx context.Context, role *models.Role, userRoles []models.UserRole, groupRoles []models.GroupRole) error {
+		if role.MaxAssignments == nil {
+			return nil
+		}
+
+		additional := 1
+		if groupName != "" {
+			size, err := s.estimateGroupSize(ctx, role, groupName)
+			if err != nil {
+				return err
+			}
+			additional = size
+		}
+
+		current := len(userRoles)
+		for _, gr := range groupRoles {
+			size, err := s.estimateGroupSize(ctx, role, gr.GroupName)
+			if err != nil {
+				return err
+			}
+			current += size
+		}
+
+		if current+additional > *role.MaxAssignments {
+			return fmt.Errorf("role %q %w: %d principal(s) assigned, %d more would exceed the maximum of %d",
+				role.Name, ErrAssignmentCapExceeded, current, additional, *role.MaxAssignments)
+		}
+		return nil
+	}
+}
+
+// estimateGroupSize asks the configured estimator for a group's size. Capped
+// roles cannot be bounded without one, so the mapping is rejected instead.
+func (s *iamService) estimateGroupSize(ctx context.Context, role *models.Role, groupName string) (int, error) {
+	if s.groupSizes == nil {
+		return 0, fmt.Errorf("role %q has an assignment cap but %w for group %q", role.Name, ErrGroupSizeUnknown, groupName)
+	}
+
+	size, err := s.groupSizes.EstimateGroupSize(ctx, groupName)
+	if err != nil {
+		return 0, fmt.Errorf("estimate size of group %s: %w", groupName, err)
+	}
+	return size, nil
+}
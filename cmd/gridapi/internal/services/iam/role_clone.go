@@ -0,0 +1,52 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// CloneRole creates newName as a copy of sourceName: description, actions
+// (deny rules included), create constraints, immutable keys, owner actions
+// and assignment cap. overrideScopeExpr, when non-nil, replaces the source's
+// label scope. The copy goes through CreateRole, so it is validated, synced to
+// Casbin and audited exactly like a role created from scratch.
+func (s *iamService) CloneRole(ctx context.Context, sourceName, newName string, overrideScopeExpr *string) (*models.Role, error) {
+	// Step 1: Load the source role and its actions
+	source, err := s.roles.GetByName(ctx, sourceName)
+	if err != nil {
+		return nil, fmt.Errorf("get source role: %w", err)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("role %s not found", sourceName)
+	}
+
+	roleActions, err := s.casbinRoleActions(source.Name)
+	if err != nil {
+		return nil, err
+	}
+	actions := make([]string, 0, len(roleActions))
+	for _, ra := range roleActions {
+		actions = append(actions, ra.Action)
+	}
+
+	// Step 2: Create the copy with the requested scope
+	scopeExpr := source.ScopeExpr
+	if overrideScopeExpr != nil {
+		scopeExpr = *overrideScopeExpr
+	}
+
+	return s.CreateRole(
+		ctx,
+		newName,
+		source.Description,
+		scopeExpr,
+		source.CreateConstraints,
+		source.ImmutableKeys,
+		source.MaxAssignments,
+		source.OwnerActions,
+		actions,
+		source.DefaultLabels,
+	)
+}
@@ -0,0 +1,123 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// PrincipalRef is a user or service account assigned a role directly.
+type PrincipalRef struct {
+	Type     PrincipalType `json:"type"`
+	CasbinID string        `json:"casbin_id"` // e.g. "user:<subject>" or "sa:<client_id>"
+
+	// ID is users.id or service_accounts.id; empty when the Casbin assignment
+	// no longer resolves to a record (Resolved is false).
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"` // Email for users, name for service accounts
+	Resolved bool   `json:"resolved"`
+
+	// From the user_roles row; zero when the row is missing
+	AssignedBy string    `json:"assigned_by,omitempty"`
+	AssignedAt time.Time `json:"assigned_at,omitempty"`
+}
+
+// ListPrincipalsForRole returns the users and service accounts the role is
+// assigned to, sorted by CasbinID. Assignments are read from Casbin (as
+// DeleteRole checks them) and resolved back to their database records and
+// user_roles rows. Groups are not principals; see ListGroupsForRole.
+func (s *iamService) ListPrincipalsForRole(ctx context.Context, roleName string) ([]PrincipalRef, error) {
+	// Step 1: Load the role and its assignment rows
+	role, err := s.roles.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("get role: %w", err)
+	}
+	if role == nil {
+		return nil, fmt.Errorf("role %s not found", roleName)
+	}
+	assignments, err := s.userRoles.GetByRoleID(ctx, role.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list role assignments: %w", err)
+	}
+
+	// Step 2: Read the assigned principals from Casbin
+	casbinIDs, err := s.enforcer.GetUsersForRole(auth.RoleID(role.Name))
+	if err != nil {
+		return nil, fmt.Errorf("get role assignments from casbin: %w", err)
+	}
+
+	// Step 3: Resolve each Casbin ID by its prefix
+	principals := []PrincipalRef{}
+	for _, casbinID := range casbinIDs {
+		id, err := auth.ParseCasbinID(casbinID)
+		if err != nil {
+			continue
+		}
+		var ref PrincipalRef
+		switch id.Kind {
+		case auth.CasbinUser:
+			ref = s.resolveUserRef(ctx, id)
+		case auth.CasbinServiceAccount:
+			ref = s.resolveServiceAccountRef(ctx, id)
+		default:
+			continue // Groups (and anything else) are not principals
+		}
+		if ref.Resolved {
+			for _, ur := range assignments {
+				if (ur.UserID != nil && *ur.UserID == ref.ID) || (ur.ServiceAccountID != nil && *ur.ServiceAccountID == ref.ID) {
+					ref.AssignedBy, ref.AssignedAt = ur.AssignedBy, ur.AssignedAt
+					break
+				}
+			}
+		}
+		principals = append(principals, ref)
+	}
+
+	sort.Slice(principals, func(i, j int) bool { return principals[i].CasbinID < principals[j].CasbinID })
+	return principals, nil
+}
+
+// resolveUserRef looks up a "user:<subject>" Casbin ID. Internal users are
+// keyed by ID, which GetBySubject also matches.
+func (s *iamService) resolveUserRef(ctx context.Context, id auth.CasbinID) PrincipalRef {
+	ref := PrincipalRef{Type: PrincipalTypeUser, CasbinID: id.String()}
+	user, err := s.users.GetBySubject(ctx, id.Name)
+	if err != nil || user == nil {
+		return ref
+	}
+	ref.ID, ref.Name, ref.Resolved = user.ID, user.Email, true
+	return ref
+}
+
+// resolveServiceAccountRef looks up a "sa:<client_id>" Casbin ID.
+func (s *iamService) resolveServiceAccountRef(ctx context.Context, id auth.CasbinID) PrincipalRef {
+	ref := PrincipalRef{Type: PrincipalTypeServiceAccount, CasbinID: id.String()}
+	sa, err := s.serviceAccounts.GetByClientID(ctx, id.Name)
+	if err != nil || sa == nil {
+		return ref
+	}
+	ref.ID, ref.Name, ref.Resolved = sa.ID, sa.Name, true
+	return ref
+}
+
+// ListGroupsForRole returns the group mappings granting the role, sorted by
+// group name.
+func (s *iamService) ListGroupsForRole(ctx context.Context, roleName string) ([]models.GroupRole, error) {
+	role, err := s.roles.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("get role: %w", err)
+	}
+	if role == nil {
+		return nil, fmt.Errorf("role %s not found", roleName)
+	}
+	groupRoles, err := s.groupRoles.GetByRoleID(ctx, role.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list group assignments: %w", err)
+	}
+	sort.Slice(groupRoles, func(i, j int) bool { return groupRoles[i].GroupName < groupRoles[j].GroupName })
+	return groupRoles, nil
+}
@@ -0,0 +1,62 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+func TestListPrincipalsForRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t, testRepos{
+		users: &mockUserRepository{users: map[string]*models.User{
+			"ext|alice": {ID: "user-alice", Email: "alice@example.com", Subject: strPtr("ext|alice")},
+		}},
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-reader": {ID: "role-reader", Name: "state-reader"},
+		}},
+		serviceAccounts: &mockServiceAccountRepository{accounts: map[string]*models.ServiceAccount{
+			"ci-client": {ID: "sa-ci", ClientID: "ci-client", Name: "ci"},
+		}},
+	})
+
+	require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-reader"))
+	require.NoError(t, svc.AssignUserRole(ctx, "", "sa-ci", "role-reader"))
+	require.NoError(t, svc.AssignGroupRole(ctx, "auditors", "role-reader", ""))
+	// An assignment left behind in Casbin after its user was deleted
+	_, err := svc.enforcer.AddRoleForUser(auth.UserID("ext|gone"), auth.RoleID("state-reader"))
+	require.NoError(t, err)
+
+	principals, err := svc.ListPrincipalsForRole(ctx, "state-reader")
+	require.NoError(t, err)
+	require.Len(t, principals, 3, "groups are not principals")
+
+	assert.Equal(t, PrincipalRef{
+		Type: PrincipalTypeServiceAccount, CasbinID: "sa:ci-client", ID: "sa-ci", Name: "ci", Resolved: true,
+		AssignedBy: auth.SystemUserID,
+	}, principals[0])
+
+	alice := principals[1]
+	assert.Equal(t, PrincipalTypeUser, alice.Type)
+	assert.Equal(t, "user:ext|alice", alice.CasbinID)
+	assert.Equal(t, "user-alice", alice.ID)
+	assert.Equal(t, "alice@example.com", alice.Name)
+	assert.Equal(t, auth.SystemUserID, alice.AssignedBy)
+
+	assert.Equal(t, PrincipalRef{Type: PrincipalTypeUser, CasbinID: "user:ext|gone"}, principals[2])
+
+	groups, err := svc.ListGroupsForRole(ctx, "state-reader")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "auditors", groups[0].GroupName)
+
+	_, err = svc.ListPrincipalsForRole(ctx, "missing")
+	assert.ErrorContains(t, err, "not found")
+}
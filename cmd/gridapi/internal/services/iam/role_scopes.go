@@ -0,0 +1,149 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+)
+
+// StateCounter counts states whose labels match a bexpr filter; an empty
+// filter counts every state. repository.StateRepository satisfies it.
+type StateCounter interface {
+	CountMatching(ctx context.Context, filter string) (int, error)
+}
+
+// resolveScopeExpr validates a role's label scope expression and applies the
+// configured empty-scope policy, returning the expression to store.
+//
+// An empty scope matches no state, so it is never stored. Under the default
+// policy it is taken to mean auth.ScopeAll; the policy can instead reject empty
+// scopes (auth.ScopeAll then has to be given explicitly) or store them as
+// auth.ScopeNone.
+func (s *iamService) resolveScopeExpr(scopeExpr string) (string, error) {
+	trimmed := strings.TrimSpace(scopeExpr)
+
+	switch trimmed {
+	case "":
+		switch s.emptyRoleScope {
+		case config.EmptyRoleScopeReject:
+			return "", fmt.Errorf("invalid label_scope_expr: a scope expression is required (use %q to match all states)", auth.ScopeAll)
+		case config.EmptyRoleScopeDeny:
+			return auth.ScopeNone, nil
+		default:
+			return auth.ScopeAll, nil
+		}
+	case auth.ScopeAll, auth.ScopeNone:
+		return trimmed, nil
+	}
+
+	if _, err := bexpr.CreateEvaluator(scopeExpr); err != nil {
+		return "", fmt.Errorf("invalid label_scope_expr: %w", err)
+	}
+	return scopeExpr, nil
+}
+
+// MigrateEmptyRoleScopes rewrites roles and Casbin policies stored while an
+// empty scope still meant "every state". Under the allow policy they become
+// auth.ScopeAll, which keeps their access unchanged. Under the reject and deny
+// policies an empty-scope role becomes auth.ScopeNone and is logged so an
+// admin can grant auth.ScopeAll deliberately.
+//
+// Unscoped policies of a role that does have a scope (e.g. a global state:list
+// grant on a label-scoped role) always become auth.ScopeAll. Returns the number
+// of roles rewritten.
+func (s *iamService) MigrateEmptyRoleScopes(ctx context.Context) (int, error) {
+	target := auth.ScopeAll
+	if s.emptyRoleScope == config.EmptyRoleScopeReject || s.emptyRoleScope == config.EmptyRoleScopeDeny {
+		target = auth.ScopeNone
+	}
+
+	// Step 1: Rewrite empty-scope role records
+	roles, err := s.roles.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list roles: %w", err)
+	}
+
+	scopedRoles := make(map[string]bool, len(roles))
+	migrated := 0
+	for i := range roles {
+		role := &roles[i]
+		casbinRoleID := auth.RoleID(role.Name)
+		if strings.TrimSpace(role.ScopeExpr) != "" {
+			scopedRoles[casbinRoleID] = true
+			continue
+		}
+
+		role.ScopeExpr = target
+		if err := s.roles.Update(ctx, role); err != nil {
+			return migrated, fmt.Errorf("update scope of role %s: %w", role.Name, err)
+		}
+		migrated++
+		if target == auth.ScopeNone {
+			log.Printf("WARNING: role %q had an empty label scope and now matches no states (empty_role_scope=%s); set its scope to %q to grant access to all states",
+				role.Name, s.emptyRoleScope, auth.ScopeAll)
+		}
+	}
+
+	// Step 2: Rewrite empty-scope Casbin policies to match
+	policies, err := s.enforcer.GetPolicy()
+	if err != nil {
+		return migrated, fmt.Errorf("get Casbin policies: %w", err)
+	}
+
+	var oldRules, newRules [][]string
+	for _, policy := range policies {
+		if len(policy) < 4 || strings.TrimSpace(policy[3]) != "" {
+			continue
+		}
+		updated := slices.Clone(policy)
+		if scopedRoles[policy[0]] {
+			updated[3] = auth.ScopeAll
+		} else {
+			updated[3] = target
+		}
+		oldRules = append(oldRules, policy)
+		newRules = append(newRules, updated)
+	}
+
+	if len(oldRules) > 0 {
+		if _, err := s.enforcer.UpdatePolicies(oldRules, newRules); err != nil {
+			return migrated, fmt.Errorf("update Casbin policies: %w", err)
+		}
+	}
+
+	return migrated, nil
+}
+
+// CountStatesForRole returns how many states the role's label scope grants
+// access to. auth.ScopeAll is counted in SQL; label expressions are evaluated
+// against every state's labels. Empty and auth.ScopeNone scopes match nothing.
+func (s *iamService) CountStatesForRole(ctx context.Context, roleName string) (int, error) {
+	if s.states == nil {
+		return 0, fmt.Errorf("state counter not configured")
+	}
+
+	role, err := s.roles.GetByName(ctx, roleName)
+	if err != nil {
+		return 0, fmt.Errorf("get role: %w", err)
+	}
+
+	filter := role.ScopeExpr
+	switch strings.TrimSpace(role.ScopeExpr) {
+	case "", auth.ScopeNone:
+		return 0, nil
+	case auth.ScopeAll:
+		filter = ""
+	}
+
+	count, err := s.states.CountMatching(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("count states for role %s: %w", roleName, err)
+	}
+	return count, nil
+}
@@ -2,11 +2,20 @@ package iam
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
 )
 
+// SessionFilter narrows admin session queries (user, service account,
+// active-only, creation time) and pages listing results.
+type SessionFilter = repository.SessionFilter
+
+// ServiceAccountFilter narrows ListServiceAccounts (see repository.ServiceAccountFilter).
+type ServiceAccountFilter = repository.ServiceAccountFilter
+
 // Service provides all identity and access management operations.
 //
 // This service centralizes:
@@ -44,10 +53,11 @@ type Service interface {
 	// Parameters:
 	//   - principalID: users.id or service_accounts.id (UUID)
 	//   - groups: Group names from JWT/session
+	//   - claims: Token claims for conditional group mappings (nil grants only unconditional ones)
 	//   - isUser: true for users, false for service accounts
 	//
 	// Returns: user_roles ∪ group_roles (union, deduplicated)
-	ResolveRoles(ctx context.Context, principalID string, groups []string, isUser bool) ([]string, error)
+	ResolveRoles(ctx context.Context, principalID string, groups []string, claims map[string]any, isUser bool) ([]string, error)
 
 	// =========================================================================
 	// Authorization (Request Path - Read-Only)
@@ -65,6 +75,43 @@ type Service interface {
 	// This is READ-ONLY - no AddGroupingPolicy or similar calls.
 	Authorize(ctx context.Context, principal *Principal, obj, act string, labels map[string]interface{}) (bool, error)
 
+	// AuthorizeDecision is Authorize, also reporting in Decision.ExplicitDeny
+	// whether a role's deny rule refused the action. Callers that can grant an
+	// action by other means (owner override) must not do so after an explicit
+	// deny.
+	AuthorizeDecision(ctx context.Context, principal *Principal, obj, act string, labels map[string]interface{}) (Decision, error)
+
+	// AuthorizeBatch answers many Authorize checks for one principal in one
+	// pass, loading the principal's role policies once instead of per check.
+	// Results are positionally aligned with checks. For list views.
+	AuthorizeBatch(ctx context.Context, principal *Principal, checks []AuthCheck) ([]bool, error)
+
+	// CheckCreateConstraints validates new-state labels against the
+	// CreateConstraints of the principal's roles that grant state:create.
+	// Returns *LabelConstraintError naming the offending key on violation.
+	CheckCreateConstraints(ctx context.Context, principal *Principal, labels map[string]interface{}) error
+
+	// DefaultCreateLabels returns the labels to add to a CreateState that
+	// would otherwise be rejected, taken from the CreateConstraints of a role
+	// with DefaultLabels set. Returns nil when the labels are left as submitted.
+	DefaultCreateLabels(ctx context.Context, principal *Principal, labels map[string]interface{}) (map[string]string, error)
+
+	// CheckImmutableKeys rejects label updates that change or remove a key
+	// listed in ImmutableKeys of any of the principal's roles.
+	// Returns *LabelConstraintError naming the offending key on violation.
+	CheckImmutableKeys(ctx context.Context, principal *Principal, current, adds map[string]interface{}, removals []string) error
+
+	// AuthorizeOwner reports whether any of the principal's roles opts act into
+	// owner override (Role.OwnerActions). Only call it for a state the principal
+	// created; label scope is not evaluated.
+	AuthorizeOwner(ctx context.Context, principal *Principal, act string) (bool, error)
+
+	// PreviewAuthorization is a dry run of Authorize for a hypothetical
+	// principal described by roles and/or groups. It returns the decision and
+	// each role's verdict (see AuthorizeExplain) without needing the user to
+	// exist or be logged in. For admins debugging role configuration.
+	PreviewAuthorization(ctx context.Context, preview AuthorizationPreview) (*AuthorizationExplanation, error)
+
 	// =========================================================================
 	// Cache Management (Out-of-Band, Not in Request Path)
 	// =========================================================================
@@ -87,6 +134,17 @@ type Service interface {
 	// Contains: map[groupName][]roleName, version, timestamp
 	GetGroupRoleCacheSnapshot() GroupRoleSnapshot
 
+	// AuthzModelVersion returns a hash of the authorization model that changes
+	// whenever roles, role assignments or group→role mappings change, for
+	// invalidating cached authorization decisions. Replicas enforcing the same
+	// model return the same value.
+	AuthzModelVersion(ctx context.Context) (uint64, error)
+
+	// Health reports whether the group→role cache is loaded and fresh and the
+	// Casbin enforcer answers queries. Unavailable means requests cannot be
+	// authorized and the node should be taken out of rotation.
+	Health(ctx context.Context) HealthReport
+
 	// =========================================================================
 	// Session Management (Login/Logout - Control Plane)
 	// =========================================================================
@@ -97,6 +155,8 @@ type Service interface {
 	//   - userID: users.id (UUID)
 	//   - idToken: JWT from external IdP (stored for group extraction)
 	//   - expiresAt: Session expiry time
+	//   - headers: Login request headers; with session binding enabled the
+	//     session is bound to the binding header's value
 	//
 	// Returns:
 	//   - session: Created session record
@@ -104,24 +164,83 @@ type Service interface {
 	//   - error: If creation fails
 	//
 	// The token is hashed (SHA256) before storage for security.
-	CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time) (*models.Session, string, error)
+	CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time, headers http.Header) (*models.Session, string, error)
 
 	// RevokeSession invalidates a session by ID.
-	// Sets session.revoked = true and session.revoked_at = now().
+	// Sets session.revoked = true and session.revoked_at = now(), drops the
+	// session's refresh token and denylists its access token's JTI.
 	RevokeSession(ctx context.Context, sessionID string) error
 
 	// GetSessionByID retrieves a session by its ID.
 	// Returns repository.ErrNotFound if session doesn't exist.
 	GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error)
 
+	// GetSessionByToken retrieves a session from a raw session cookie value
+	// (admin tooling, e.g. inspecting a cookie). Returns an error wrapping
+	// repository.ErrNotFound if no session matches. A revoked or expired
+	// session is returned together with ErrSessionRevoked or ErrSessionExpired
+	// so callers can still show it.
+	GetSessionByToken(ctx context.Context, rawToken string) (*models.Session, error)
+
 	// ListUserSessions retrieves all sessions for a specific user.
 	// Returns empty slice if user has no active sessions.
 	ListUserSessions(ctx context.Context, userID string) ([]models.Session, error)
 
+	// ListAllSessions retrieves sessions across all users (admin operation).
+	// Results are newest first and paged by filter.PageSize/Offset; a zero
+	// PageSize uses the default page size. Token fields are cleared so only
+	// session metadata leaves the service.
+	ListAllSessions(ctx context.Context, filter SessionFilter) ([]models.Session, error)
+
+	// RevokeSessions revokes every session matching filter (admin operation,
+	// incident response) and returns how many were revoked. The sessions'
+	// refresh tokens and access-token JTIs are revoked as well. Paging fields are
+	// ignored. The filter must name a user, service account or creation time
+	// bound so an empty filter cannot revoke every session.
+	RevokeSessions(ctx context.Context, filter SessionFilter) (int, error)
+
+	// RevokeAllSessions force-logs-out a principal: every session of the user
+	// or service account (principalType "user" or "service_account",
+	// principalID its database ID) is revoked, along with the internal IdP
+	// refresh tokens issued with them, and their unexpired access tokens are
+	// added to the jti denylist. Returns how many sessions were revoked.
+	RevokeAllSessions(ctx context.Context, principalID, principalType string) (int, error)
+
+	// ListAuditLog returns audit log entries matching filter, newest first.
+	// Every out-of-band mutation (roles, role assignments, service accounts,
+	// password resets, session revocation, state ownership transfers) is
+	// recorded with its actor, target, before/after state and outcome.
+	ListAuditLog(ctx context.Context, filter AuditLogFilter) ([]models.AuditLogEntry, error)
+
+	// RecordOwnershipTransfer writes the audit entry of a state ownership
+	// transfer from previousOwner to newOwner; opErr is the transfer's result.
+	RecordOwnershipTransfer(ctx context.Context, stateGUID, previousOwner, newOwner string, opErr error)
+
+	// RevocationEpoch returns the global revocation epoch. Authenticators
+	// reject JWTs whose iat, and sessions whose created_at, is before it.
+	// The zero time means no epoch is in force.
+	RevocationEpoch(ctx context.Context) (time.Time, error)
+
+	// BumpRevocationEpoch advances the revocation epoch to epoch (zero means
+	// now), instantly invalidating every older credential. This is the coarse
+	// kill switch; RevokeJTI and RevokeSession remain the surgical tools.
+	// Returns the stored epoch, truncated to whole seconds.
+	BumpRevocationEpoch(ctx context.Context, epoch time.Time, setBy string) (time.Time, error)
+
 	// RevokeJTI adds a JWT ID to the revocation list.
 	// Used for logout and emergency token revocation.
 	RevokeJTI(ctx context.Context, jti string, expiresAt time.Time) error
 
+	// IntrospectToken reports the status of an arbitrary bearer token (RFC 7662 style).
+	//
+	// The token is verified with the configured JWT authenticator, then its
+	// jti is checked against the revocation denylist and the backing session
+	// (looked up by auth.HashBearerToken(jti)) is checked for revocation and expiry.
+	//
+	// Invalid, expired, or revoked tokens are reported as inactive, not as
+	// errors. An error is returned only when a repository lookup fails.
+	IntrospectToken(ctx context.Context, token string) (*TokenIntrospection, error)
+
 	// =========================================================================
 	// User Management (Admin Operations)
 	// =========================================================================
@@ -155,21 +274,58 @@ type Service interface {
 	// Disabled users cannot authenticate.
 	DisableUser(ctx context.Context, userID string) error
 
+	// ChangePassword replaces an internal IdP user's password.
+	//
+	// The old password must match the stored bcrypt hash (ErrIncorrectPassword
+	// otherwise) and the new one must satisfy the password policy (an
+	// *auth.PasswordPolicyError otherwise). Users without a local password
+	// (external IdP) get ErrNoLocalPassword. With opts.RevokeOtherSessions,
+	// every other session of the user is revoked.
+	ChangePassword(ctx context.Context, userID, oldPassword, newPassword string, opts ChangePasswordOptions) error
+
+	// AdminResetPassword replaces an internal IdP user's password with a
+	// generated temporary one that satisfies the password policy, sets
+	// must_change_password and revokes all of the user's sessions, their
+	// refresh and access tokens, and (through the user's tokens_valid_after
+	// epoch) any other credential issued before the reset. The
+	// temporary password is returned for the admin to hand over; login with it
+	// requires choosing a new password. Returns ErrNoLocalPassword for
+	// external IdP users.
+	AdminResetPassword(ctx context.Context, userID string) (string, error)
+
+	// MergeUsers folds a duplicate account (e.g., created by JIT provisioning
+	// alongside an internal user) into the primary account.
+	//
+	// The duplicate's role assignments, group memberships, sessions, and
+	// ownership references (including the states it owns) move to the primary
+	// in a single database transaction, Casbin grouping policies are synced to
+	// match, and the duplicate is deleted. The primary ends with the union of
+	// both accounts' roles and groups.
+	//
+	// Returns error if primaryID == duplicateID or either user doesn't exist.
+	MergeUsers(ctx context.Context, primaryID, duplicateID string) error
+
 	// =========================================================================
 	// Service Account Management (Admin Operations)
 	// =========================================================================
 
-	// CreateServiceAccount creates a new service account for machine-to-machine auth.
+	// CreateServiceAccount creates a new service account for machine-to-machine auth
+	// and assigns it the named roles in the same operation.
 	//
 	// Returns:
 	//   - serviceAccount: Created record
 	//   - clientSecret: Unhashed secret (return to caller, not stored)
+	//   - appliedRoles: Names of the roles assigned
 	//
-	// The secret is hashed (bcrypt) before storage.
-	CreateServiceAccount(ctx context.Context, name, createdBy string) (*models.ServiceAccount, string, error)
+	// The secret is hashed (bcrypt) before storage. Unknown role names fail
+	// before anything is created; a failed assignment removes the account and
+	// any roles already assigned, so no account is left without its roles.
+	CreateServiceAccount(ctx context.Context, name, createdBy string, roleNames []string) (*models.ServiceAccount, string, []string, error)
 
-	// ListServiceAccounts returns all service accounts.
-	ListServiceAccounts(ctx context.Context) ([]*models.ServiceAccount, error)
+	// ListServiceAccounts returns the service accounts matching filter,
+	// ordered by ID. A zero filter returns every account. Filters are applied
+	// in the database; page with filter.AfterID and filter.Limit.
+	ListServiceAccounts(ctx context.Context, filter ServiceAccountFilter) ([]*models.ServiceAccount, error)
 
 	// GetServiceAccountByName retrieves a service account by its human-readable name.
 	// Returns repository.ErrNotFound if the service account doesn't exist.
@@ -192,7 +348,24 @@ type Service interface {
 	// RotateServiceAccountSecret generates a new secret for a service account.
 	// Returns the unhashed secret (caller must save it) and the timestamp of rotation.
 	// The secret is hashed with bcrypt before storage.
-	RotateServiceAccountSecret(ctx context.Context, clientID string) (string, time.Time, error)
+	//
+	// With a zero overlap the old secret stops working at once. Otherwise it
+	// stays valid for overlap (at most MaxSecretRotationOverlap) so automation
+	// can switch over, and the result carries that deadline.
+	RotateServiceAccountSecret(ctx context.Context, clientID string, overlap time.Duration) (*SecretRotation, error)
+
+	// TestCredentials runs the client-credentials flow for a service account
+	// without issuing a token, then reports the resolved roles and the outcome
+	// of a fixed set of representative authorization checks.
+	//
+	// Intended as an admin diagnostic (e.g., CI setup scripts verifying that a
+	// service account can authenticate and read states). Callers must gate it
+	// behind an admin permission.
+	//
+	// Authentication failures (unknown client, wrong secret, disabled account)
+	// are reported in the result, not returned as errors. An error is returned
+	// only when role resolution or policy evaluation fails.
+	TestCredentials(ctx context.Context, clientID, clientSecret string) (*CredentialTestResult, error)
 
 	// AssignRolesToServiceAccount assigns one or more roles to a service account.
 	//
@@ -209,8 +382,10 @@ type Service interface {
 
 	// AssignUserRole assigns a role directly to a user or service account.
 	//
-	// Exactly one of userID or serviceAccountID must be provided (non-empty string).
-	// This creates a UserRole record and syncs to Casbin.
+	// Exactly one of userID or serviceAccountID must be provided (non-empty string),
+	// else ErrPrincipalAmbiguous. This creates a UserRole record and syncs to Casbin.
+	// Returns ErrRoleNotFound, ErrDuplicateAssignment or ErrAssignmentCapExceeded
+	// when the assignment is rejected.
 	//
 	// This is an out-of-band mutation (admin operation), not part of the auth request path.
 	AssignUserRole(ctx context.Context, userID, serviceAccountID, roleID string) error
@@ -230,7 +405,13 @@ type Service interface {
 	//
 	// This is a control-plane operation (not in request path), so the cache
 	// refresh latency is acceptable.
-	AssignGroupRole(ctx context.Context, groupName, roleID string) error
+	//
+	// condition is an optional go-bexpr expression over token claims; when
+	// non-empty the role is granted only to group members whose claims match.
+	//
+	// Returns ErrRoleNotFound, ErrDuplicateAssignment, ErrAssignmentCapExceeded or
+	// ErrGroupSizeUnknown when the mapping is rejected.
+	AssignGroupRole(ctx context.Context, groupName, roleID, condition string) error
 
 	// RemoveGroupRole removes a role from a group.
 	//
@@ -255,15 +436,28 @@ type Service interface {
 	//   - scopeExpr: Label scope expression (go-bexpr syntax, e.g., "env == 'prod'")
 	//   - createConstraints: Map of label key → constraint (allowed values, required)
 	//   - immutableKeys: List of label keys that cannot be changed
-	//   - actions: List of actions in "obj:act" format (e.g., ["state:read", "state:write"])
-	//
-	// Returns the created role with generated ID, or error if validation/creation fails.
+	//   - maxAssignments: Optional cap on principals holding the role (nil = unlimited)
+	//   - ownerActions: Actions (e.g., "tfstate:write") allowed on states the principal
+	//     created regardless of scopeExpr; each must be granted on states by actions
+	//   - actions: List of actions in "obj:act" format (e.g., ["state:read", "state:write"]).
+	//     Any malformed entry fails the whole call before anything is written,
+	//     with an "invalid actions" error naming every malformed entry.
+	//     A leading "!" (e.g. "!state:tfstate:write") makes the action a deny rule,
+	//     which overrides allows from this or any other role the principal holds.
+	//   - defaultLabels: When true, CreateState fills in missing labels that
+	//     createConstraints pin to a single allowed value (see DefaultCreateLabels)
+	//
+	// Returns the created role with generated ID, or error if validation/creation fails
+	// (ErrRoleExists if the name is taken).
 	CreateRole(
 		ctx context.Context,
 		name, description, scopeExpr string,
 		createConstraints models.CreateConstraints,
 		immutableKeys []string,
+		maxAssignments *int,
+		ownerActions []string,
 		actions []string,
+		defaultLabels bool,
 	) (*models.Role, error)
 
 	// UpdateRole updates an existing role's permissions and metadata.
@@ -278,10 +472,11 @@ type Service interface {
 	// Parameters:
 	//   - name: Role name (immutable, used for lookup)
 	//   - expectedVersion: For optimistic locking (must match current version)
-	//   - description, scopeExpr, createConstraints, immutableKeys, actions: Same as CreateRole
+	//   - description, scopeExpr, createConstraints, immutableKeys, maxAssignments, ownerActions, actions, defaultLabels: Same as CreateRole
 	//
 	// Returns the updated role with incremented version, or error if validation/update fails.
-	// Returns error if version mismatch (concurrent modification detected).
+	// Returns ErrRoleNotFound for an unknown role, and ErrVersionConflict if
+	// expectedVersion does not match (concurrent modification detected).
 	UpdateRole(
 		ctx context.Context,
 		name string,
@@ -289,9 +484,37 @@ type Service interface {
 		description, scopeExpr string,
 		createConstraints models.CreateConstraints,
 		immutableKeys []string,
+		maxAssignments *int,
+		ownerActions []string,
 		actions []string,
+		defaultLabels bool,
 	) (*models.Role, error)
 
+	// MigrateEmptyRoleScopes rewrites roles and Casbin policies whose scope
+	// expression is empty, which no longer matches any state.
+	//
+	// Empty-scope roles become auth.ScopeAll under the "allow" empty_role_scope
+	// policy and auth.ScopeNone under "reject" or "deny". Called once at startup,
+	// before requests are served. Returns the number of roles rewritten.
+	MigrateEmptyRoleScopes(ctx context.Context) (int, error)
+
+	// ReconcileRoleAssignments brings Casbin's grouping rules back in line
+	// with the user_roles and group_roles tables, adding missing rules and
+	// removing rules without a row. Run at startup and by
+	// "gridapi iam reconcile"; each correction is logged and returned.
+	ReconcileRoleAssignments(ctx context.Context) (*RoleAssignmentReconciliation, error)
+
+	// ReconcileRolePolicies diffs each role's Casbin policies against the
+	// actions and scope stored on the role and, with repair, rewrites the
+	// drifted ones. Roles stored before their actions were recorded are
+	// reported as unverified and left alone.
+	ReconcileRolePolicies(ctx context.Context, repair bool) (*RolePolicyReconciliation, error)
+
+	// CountStatesForRole returns how many states the role's label scope
+	// expression grants access to, to help spot overly broad roles.
+	// Requires the optional States dependency.
+	CountStatesForRole(ctx context.Context, roleName string) (int, error)
+
 	// DeleteRole deletes a role and removes all associated Casbin policies.
 	//
 	// This is an out-of-band mutation operation that:
@@ -301,15 +524,36 @@ type Service interface {
 	//   4. Removes all Casbin policies for the role
 	//
 	// Safety: Rejects deletion if role is assigned to any principals.
-	// Returns error if role not found, still assigned, or deletion fails.
+	// Returns ErrRoleNotFound, ErrRoleInUse while still assigned, or an error if
+	// deletion fails.
 	DeleteRole(ctx context.Context, name string) error
 
+	// CloneRole creates newName with the source role's description, actions,
+	// create constraints, immutable keys, owner actions and assignment cap.
+	// A non-nil overrideScopeExpr replaces the source's label scope. The new
+	// role is created through CreateRole, with the same validation and Casbin sync.
+	// Returns a "not found" error if the source role does not exist.
+	CloneRole(ctx context.Context, sourceName, newName string, overrideScopeExpr *string) (*models.Role, error)
+
+	// ListPrincipalsForRole returns the users and service accounts assigned
+	// the role, resolved from their Casbin IDs ("user:"/"sa:") to database
+	// records, with the assigning actor and time from user_roles. Casbin
+	// assignments whose record is gone are returned with Resolved false.
+	ListPrincipalsForRole(ctx context.Context, roleName string) ([]PrincipalRef, error)
+
+	// ListGroupsForRole returns the group mappings that grant the role.
+	ListGroupsForRole(ctx context.Context, roleName string) ([]models.GroupRole, error)
+
+	// ExportAccessReview snapshots every user, service account and group
+	// mapping with the roles they grant, for compliance review. Read-only.
+	ExportAccessReview(ctx context.Context) (*AccessReview, error)
+
 	// =========================================================================
 	// Read-Only Lookup Methods (For Handlers - No Mutations)
 	// =========================================================================
 
 	// GetRoleByName retrieves a role by its name.
-	// Returns repository.ErrNotFound if role doesn't exist.
+	// Returns ErrRoleNotFound if role doesn't exist.
 	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
 
 	// GetRolesByName returns the roles matching the provided names along with metadata
@@ -321,7 +565,7 @@ type Service interface {
 	GetRolesByName(ctx context.Context, roleNames []string) ([]models.Role, []string, []string, error)
 
 	// GetRoleByID retrieves a role by its internal ID.
-	// Returns repository.ErrNotFound if role doesn't exist.
+	// Returns ErrRoleNotFound if role doesn't exist.
 	GetRoleByID(ctx context.Context, roleID string) (*models.Role, error)
 
 	// ListAllRoles returns all roles in the system.
@@ -351,6 +595,18 @@ type Service interface {
 	//
 	// Returns: Array of permission tuples from Casbin (e.g., [["role::platform-engineer", "state", "read", ...]]).
 	GetRolePermissions(ctx context.Context, roleName string) ([][]string, error)
+
+	// GetRoleActions returns a role's actions in the "obj:act" format accepted
+	// by CreateRole and UpdateRole, each with its label scope expression and
+	// effect (deny rules keep their "!" prefix), so callers can round-trip a
+	// role without parsing Casbin tuples.
+	// Returns a "not found" error if the role does not exist.
+	GetRoleActions(ctx context.Context, roleName string) ([]RoleAction, error)
+
+	// GetEffectivePermissions returns every action held through roles (role
+	// names as returned by ResolveRoles), each with its role, label scope
+	// expression and effect. Used by whoami to show users their own grants.
+	GetEffectivePermissions(ctx context.Context, roles []string) ([]EffectivePermission, error)
 }
 
 // GroupRoleSnapshot is an immutable snapshot of group→role mappings.
@@ -362,6 +618,12 @@ type GroupRoleSnapshot struct {
 	// Example: {"platform-engineers": ["platform-engineer"], "dev-team": ["product-engineer"]}
 	Mappings map[string][]string
 
+	// Conditions: groupName → roleName → claim condition (go-bexpr).
+	// Only conditional mappings appear here; a role listed in Mappings with no
+	// entry is granted to every member of the group.
+	// Example: {"finance-team": {"ledger-admin": `department == "finance"`}}
+	Conditions map[string]map[string]string
+
 	// CreatedAt is when this snapshot was built.
 	CreatedAt time.Time
 
@@ -369,3 +631,62 @@ type GroupRoleSnapshot struct {
 	// Helps identify which snapshot is active.
 	Version int
 }
+
+// TokenIntrospection is the result of IntrospectToken.
+type TokenIntrospection struct {
+	// Active reports whether the token would currently be accepted.
+	Active bool
+
+	// InactiveReason explains why the token is inactive (empty when active).
+	InactiveReason string
+
+	// Claims extracted from the verified token. Empty if verification failed.
+	Subject   string
+	ClientID  string
+	Scopes    []string
+	JTI       string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+
+	// JTIRevoked reports whether the jti is on the revocation denylist.
+	JTIRevoked bool
+
+	// SessionID identifies the backing session, if any. Only tokens issued by
+	// the internal IdP have sessions.
+	SessionID      string
+	SessionRevoked bool
+}
+
+// CredentialTestResult is the structured report produced by TestCredentials.
+type CredentialTestResult struct {
+	// ClientID is the client ID that was tested.
+	ClientID string
+
+	// ServiceAccountID and ServiceAccountName identify the matched service
+	// account. Empty when the client ID is unknown.
+	ServiceAccountID   string
+	ServiceAccountName string
+
+	// Authenticated reports whether the client-credentials check succeeded.
+	Authenticated bool
+
+	// AuthenticationError explains why authentication failed (empty on success).
+	AuthenticationError string
+
+	// Roles lists the resolved role names (sorted). Empty if not authenticated.
+	Roles []string
+
+	// Checks lists the representative authorization checks that were run.
+	// Empty if not authenticated.
+	Checks []CredentialCheck
+}
+
+// CredentialCheck is a single authorization probe run by TestCredentials.
+//
+// Checks are evaluated without resource labels, so roles with a label scope
+// expression only pass checks when their scope matches an unlabeled resource.
+type CredentialCheck struct {
+	Object  string
+	Action  string
+	Allowed bool
+}
@@ -0,0 +1,90 @@
+package iam
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// ageSnapshot replaces the cache's snapshot with a copy created age ago.
+func ageSnapshot(cache *GroupRoleCache, age time.Duration) {
+	aged := *cache.Get()
+	aged.CreatedAt = time.Now().Add(-age)
+	cache.snapshot.Store(&aged)
+}
+
+func TestResolveRoles_RefreshesStaleCacheOnUnmappedGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newMapping := models.GroupRole{ID: "gr-late", GroupName: "late-team", RoleID: "role-product", AssignedAt: time.Now()}
+
+	tests := []struct {
+		name         string
+		refreshAfter time.Duration
+		snapshotAge  time.Duration
+		want         []string
+	}{
+		{name: "disabled", refreshAfter: 0, snapshotAge: time.Hour, want: []string{}},
+		{name: "snapshot too fresh", refreshAfter: time.Minute, snapshotAge: time.Second, want: []string{}},
+		{name: "stale snapshot is refreshed", refreshAfter: time.Minute, snapshotAge: time.Hour, want: []string{"product-engineer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newTestService(t, productEngineerRepos())
+			svc.cacheMissRefreshAfter = tt.refreshAfter
+
+			// Mapping added (e.g. on another replica) after the snapshot was taken
+			require.NoError(t, svc.groupRoles.Create(ctx, &newMapping))
+			ageSnapshot(svc.groupRoleCache, tt.snapshotAge)
+
+			roles, err := svc.ResolveRoles(ctx, "user-1", []string{"late-team"}, nil, true)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.want, roles)
+		})
+	}
+}
+
+func TestGroupRoleCache_RefreshIfOlderThan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache, _, _ := setupTestCache(t)
+
+	refreshed, err := cache.RefreshIfOlderThan(ctx, time.Minute)
+	require.NoError(t, err)
+	require.False(t, refreshed, "fresh snapshot should not be refreshed")
+	require.Equal(t, 1, cache.Get().Version)
+
+	// Concurrent callers that find the snapshot stale share one refresh
+	ageSnapshot(cache, time.Hour)
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cache.RefreshIfOlderThan(ctx, time.Minute)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 2, cache.Get().Version)
+}
+
+func TestGroupRoleCache_HasUnmappedGroups(t *testing.T) {
+	t.Parallel()
+
+	cache, _, _ := setupTestCache(t)
+
+	require.False(t, cache.HasUnmappedGroups(nil))
+	require.False(t, cache.HasUnmappedGroups([]string{"dev-team", "/everyone"}))
+	require.True(t, cache.HasUnmappedGroups([]string{"dev-team", "unknown"}))
+}
@@ -0,0 +1,94 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// createServiceAccountRepos seeds a "ci" role and an "org-admin" role capped
+// at one principal, already held by a user.
+func createServiceAccountRepos() testRepos {
+	maxAssignments := 1
+	return testRepos{
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-ci":    {ID: "role-ci", Name: "ci"},
+			"role-admin": {ID: "role-admin", Name: "org-admin", MaxAssignments: &maxAssignments},
+		}},
+		userRoles: &mockUserRoleRepository{records: []models.UserRole{
+			{ID: "ur-0", UserID: strPtr("user-alice"), RoleID: "role-admin"},
+		}},
+		serviceAccounts: &mockServiceAccountRepository{accounts: map[string]*models.ServiceAccount{}},
+	}
+}
+
+func TestCreateServiceAccountWithRoles(t *testing.T) {
+	t.Parallel()
+
+	repos := createServiceAccountRepos()
+	svc, serviceAccounts, userRoles := newTestService(t, repos), repos.serviceAccounts, repos.userRoles
+	ctx := context.Background()
+
+	sa, secret, roles, err := svc.CreateServiceAccount(ctx, "ci-deploy", auth.SystemUserID, []string{"ci", "ci"})
+	require.NoError(t, err)
+	require.NotNil(t, sa)
+	assert.NotEmpty(t, secret)
+	assert.Equal(t, []string{"ci"}, roles, "duplicate names are assigned once")
+	assert.Contains(t, serviceAccounts.accounts, sa.ClientID)
+
+	assigned, err := userRoles.GetByRoleID(ctx, "role-ci")
+	require.NoError(t, err)
+	require.Len(t, assigned, 1)
+	assert.Equal(t, sa.ID, *assigned[0].ServiceAccountID)
+
+	hasRole, err := svc.enforcer.HasRoleForUser(auth.ServiceAccountID(sa.ClientID), auth.RoleID("ci"))
+	require.NoError(t, err)
+	assert.True(t, hasRole)
+}
+
+func TestCreateServiceAccountRollsBack(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("invalid role name creates nothing", func(t *testing.T) {
+		repos := createServiceAccountRepos()
+		svc, serviceAccounts, userRoles := newTestService(t, repos), repos.serviceAccounts, repos.userRoles
+
+		sa, secret, roles, err := svc.CreateServiceAccount(ctx, "ci-deploy", auth.SystemUserID, []string{"ci", "no-such-role"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid role(s): no-such-role")
+		assert.Nil(t, sa)
+		assert.Empty(t, secret)
+		assert.Nil(t, roles)
+
+		assert.Empty(t, serviceAccounts.accounts)
+		assert.Len(t, userRoles.records, 1, "only the pre-existing assignment remains")
+	})
+
+	t.Run("capped role creates nothing", func(t *testing.T) {
+		repos := createServiceAccountRepos()
+		svc, serviceAccounts, userRoles := newTestService(t, repos), repos.serviceAccounts, repos.userRoles
+
+		// "org-admin" is already at its cap, so not even "ci" is assigned
+		sa, _, roles, err := svc.CreateServiceAccount(ctx, "ci-deploy", auth.SystemUserID, []string{"ci", "org-admin"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrAssignmentCapExceeded)
+		assert.Contains(t, err.Error(), `role "org-admin" assignment cap exceeded`)
+		assert.Nil(t, sa)
+		assert.Nil(t, roles)
+
+		assert.Empty(t, serviceAccounts.accounts)
+		assigned, err := userRoles.GetByRoleID(ctx, "role-ci")
+		require.NoError(t, err)
+		assert.Empty(t, assigned)
+
+		subjects, err := svc.enforcer.GetUsersForRole(auth.RoleID("ci"))
+		require.NoError(t, err)
+		assert.Empty(t, subjects, "no Casbin grant")
+	})
+}
@@ -0,0 +1,143 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// credentialRepos seeds one service account ("ci-client", secret "s3cret")
+// holding the "ci-reader" role; see ciReaderPolicies.
+func credentialRepos(t *testing.T, disabled bool) testRepos {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return testRepos{
+		serviceAccounts: &mockServiceAccountRepository{accounts: map[string]*models.ServiceAccount{
+			"ci-client": {ID: "sa-1", Name: "ci", ClientID: "ci-client", ClientSecretHash: string(hash), Disabled: disabled},
+		}},
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-ci": {ID: "role-ci", Name: "ci-reader"},
+		}},
+		userRoles: &mockUserRoleRepository{records: []models.UserRole{
+			{ID: "ur-1", ServiceAccountID: strPtr("sa-1"), RoleID: "role-ci"},
+		}},
+	}
+}
+
+// ciReaderPolicies let "ci-reader" list and read states.
+var ciReaderPolicies = [][]string{
+	{auth.RoleID("ci-reader"), auth.ObjectTypeState, auth.StateList, auth.ScopeAll, "allow"},
+	{auth.RoleID("ci-reader"), auth.ObjectTypeState, auth.StateRead, auth.ScopeAll, "allow"},
+}
+
+func checkOutcomes(checks []CredentialCheck) map[string]bool {
+	out := make(map[string]bool, len(checks))
+	for _, c := range checks {
+		out[c.Action] = c.Allowed
+	}
+	return out
+}
+
+func TestTestCredentialsValidSecretReportsAuthorization(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, credentialRepos(t, false), ciReaderPolicies...)
+
+	result, err := svc.TestCredentials(context.Background(), "ci-client", "s3cret")
+	require.NoError(t, err)
+	require.True(t, result.Authenticated)
+	require.Empty(t, result.AuthenticationError)
+	require.Equal(t, "sa-1", result.ServiceAccountID)
+	require.Equal(t, []string{"ci-reader"}, result.Roles)
+	require.Equal(t, map[string]bool{
+		auth.StateList:    true,
+		auth.StateRead:    true,
+		auth.StateCreate:  false,
+		auth.TfstateRead:  false,
+		auth.TfstateWrite: false,
+	}, checkOutcomes(result.Checks))
+}
+
+func TestTestCredentialsInvalidSecret(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, credentialRepos(t, false), ciReaderPolicies...)
+
+	result, err := svc.TestCredentials(context.Background(), "ci-client", "wrong")
+	require.NoError(t, err)
+	require.False(t, result.Authenticated)
+	require.Equal(t, "invalid client secret", result.AuthenticationError)
+	require.Empty(t, result.Roles)
+	require.Empty(t, result.Checks)
+}
+
+func TestTestCredentialsUnknownClient(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, credentialRepos(t, false), ciReaderPolicies...)
+
+	result, err := svc.TestCredentials(context.Background(), "nope", "s3cret")
+	require.NoError(t, err)
+	require.False(t, result.Authenticated)
+	require.Equal(t, "service account not found", result.AuthenticationError)
+	require.Empty(t, result.ServiceAccountID)
+}
+
+func TestTestCredentialsDisabledAccount(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, credentialRepos(t, true), ciReaderPolicies...)
+
+	result, err := svc.TestCredentials(context.Background(), "ci-client", "s3cret")
+	require.NoError(t, err)
+	require.False(t, result.Authenticated)
+	require.Equal(t, "service account is disabled", result.AuthenticationError)
+	require.Empty(t, result.Checks)
+}
+
+func TestRotateServiceAccountSecretWithOverlap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t, credentialRepos(t, false), ciReaderPolicies...)
+	authenticates := func(secret string) bool {
+		t.Helper()
+		result, err := svc.TestCredentials(ctx, "ci-client", secret)
+		require.NoError(t, err)
+		return result.Authenticated
+	}
+
+	rotation, err := svc.RotateServiceAccountSecret(ctx, "ci-client", time.Hour)
+	require.NoError(t, err)
+	require.NotEmpty(t, rotation.ClientSecret)
+	require.NotNil(t, rotation.PreviousSecretExpiresAt)
+	require.WithinDuration(t, time.Now().Add(time.Hour), *rotation.PreviousSecretExpiresAt, time.Minute)
+
+	require.True(t, authenticates(rotation.ClientSecret), "new secret")
+	require.True(t, authenticates("s3cret"), "old secret during overlap")
+	require.False(t, authenticates("other"))
+
+	// Once the overlap has ended only the new secret works
+	expired := time.Now().Add(-time.Second)
+	svc.serviceAccounts.(*mockServiceAccountRepository).accounts["ci-client"].PreviousSecretExpiresAt = &expired
+	require.False(t, authenticates("s3cret"), "old secret after overlap")
+	require.True(t, authenticates(rotation.ClientSecret))
+
+	// A rotation without overlap invalidates the old secret at once
+	next, err := svc.RotateServiceAccountSecret(ctx, "ci-client", 0)
+	require.NoError(t, err)
+	require.Nil(t, next.PreviousSecretExpiresAt)
+	require.False(t, authenticates(rotation.ClientSecret))
+	require.True(t, authenticates(next.ClientSecret))
+
+	_, err = svc.RotateServiceAccountSecret(ctx, "ci-client", MaxSecretRotationOverlap+time.Hour)
+	require.ErrorContains(t, err, "invalid overlap")
+}
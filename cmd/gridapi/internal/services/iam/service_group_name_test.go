@@ -0,0 +1,129 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// productEngineerRepos holds a "product-engineer" role and the given group
+// mappings.
+func productEngineerRepos(records ...models.GroupRole) testRepos {
+	return testRepos{
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-product": {ID: "role-product", Name: "product-engineer"},
+		}},
+		groupRoles: &mockGroupRoleRepository{records: records},
+	}
+}
+
+func TestGroupNameNormalization(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	stripAndLower := auth.GroupNameNormalization{StripLeadingSlash: true, Lowercase: true}
+
+	tests := []struct {
+		name     string
+		norm     auth.GroupNameNormalization
+		assigned string
+		claimed  []string
+		want     []string
+	}{
+		{
+			name:     "mapping without slash matches claim with slash",
+			norm:     auth.DefaultGroupNameNormalization,
+			assigned: "product-engineers",
+			claimed:  []string{"/product-engineers"},
+			want:     []string{"product-engineer"},
+		},
+		{
+			name:     "mapping with slash matches claim without slash",
+			norm:     auth.DefaultGroupNameNormalization,
+			assigned: "/product-engineers",
+			claimed:  []string{"product-engineers"},
+			want:     []string{"product-engineer"},
+		},
+		{
+			name:     "case differs by default",
+			norm:     auth.DefaultGroupNameNormalization,
+			assigned: "Product-Engineers",
+			claimed:  []string{"/product-engineers"},
+			want:     []string{},
+		},
+		{
+			name:     "lowercase ignores case",
+			norm:     stripAndLower,
+			assigned: "Product-Engineers",
+			claimed:  []string{"/product-engineers"},
+			want:     []string{"product-engineer"},
+		},
+		{
+			name:     "no normalization keeps the slash significant",
+			norm:     auth.GroupNameNormalization{},
+			assigned: "product-engineers",
+			claimed:  []string{"/product-engineers"},
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t, productEngineerRepos())
+			cache, err := NewGroupRoleCacheWithNormalization(svc.groupRoles, svc.roles, tt.norm)
+			require.NoError(t, err)
+			svc.groupRoleCache = cache
+			require.NoError(t, svc.AssignGroupRole(ctx, tt.assigned, "role-product", ""))
+
+			roles, err := svc.ResolveRoles(ctx, "user-alice", tt.claimed, nil, true)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.want, roles)
+		})
+	}
+}
+
+func TestAssignGroupRoleStoresNormalizedName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t, productEngineerRepos())
+	require.NoError(t, svc.AssignGroupRole(ctx, "/product-engineers", "role-product", ""))
+
+	mapped, err := svc.groupRoles.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, mapped, 1)
+	require.Equal(t, "product-engineers", mapped[0].GroupName)
+
+	// Either spelling finds and removes the mapping
+	listed, err := svc.ListGroupRoles(ctx, strPtr("/product-engineers"))
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	require.NoError(t, svc.RemoveGroupRole(ctx, "/product-engineers", "role-product"))
+	mapped, err = svc.groupRoles.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, mapped)
+}
+
+func TestRemoveGroupRoleUnnormalizedLegacyMapping(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t, productEngineerRepos(models.GroupRole{
+		ID: "gr-legacy", GroupName: "/product-engineers", RoleID: "role-product", AssignedAt: time.Now(),
+	}))
+
+	listed, err := svc.ListGroupRoles(ctx, strPtr("/product-engineers"))
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	require.NoError(t, svc.RemoveGroupRole(ctx, "/product-engineers", "role-product"))
+	mapped, err := svc.groupRoles.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, mapped)
+}
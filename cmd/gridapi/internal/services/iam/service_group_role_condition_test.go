@@ -0,0 +1,109 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// groupConditionRepos holds an uncapped "ledger-admin" role, a "viewer" role
+// and an empty group mapping table.
+func groupConditionRepos() testRepos {
+	return testRepos{roles: &mockRoleRepository{roles: map[string]*models.Role{
+		"role-ledger": {ID: "role-ledger", Name: "ledger-admin"},
+		"role-viewer": {ID: "role-viewer", Name: "viewer"},
+	}}}
+}
+
+func TestResolveRolesConditionalGroupMapping(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t, groupConditionRepos())
+	require.NoError(t, svc.AssignGroupRole(ctx, "finance", "role-ledger", `department == "finance"`))
+	require.NoError(t, svc.AssignGroupRole(ctx, "finance", "role-viewer", ""))
+
+	tests := []struct {
+		name   string
+		groups []string
+		claims map[string]any
+		want   []string
+	}{
+		{
+			name:   "matching claim grants conditional role",
+			groups: []string{"finance"},
+			claims: map[string]any{"department": "finance"},
+			want:   []string{"ledger-admin", "viewer"},
+		},
+		{
+			name:   "non-matching claim withholds conditional role",
+			groups: []string{"finance"},
+			claims: map[string]any{"department": "engineering"},
+			want:   []string{"viewer"},
+		},
+		{
+			name:   "missing claim withholds conditional role",
+			groups: []string{"finance"},
+			claims: map[string]any{"email": "alice@example.com"},
+			want:   []string{"viewer"},
+		},
+		{
+			name:   "no claims withholds conditional role",
+			groups: []string{"finance"},
+			want:   []string{"viewer"},
+		},
+		{
+			name:   "matching claim without group grants nothing",
+			groups: []string{"engineering"},
+			claims: map[string]any{"department": "finance"},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles, err := svc.ResolveRoles(ctx, "user-alice", tt.groups, tt.claims, true)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.want, roles)
+		})
+	}
+}
+
+func TestAssignGroupRoleCondition(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("condition is stored on the mapping", func(t *testing.T) {
+		svc := newTestService(t, groupConditionRepos())
+		require.NoError(t, svc.AssignGroupRole(ctx, "finance", "role-ledger", `  department == "finance"  `))
+
+		mapped, err := svc.groupRoles.GetByGroupName(ctx, "finance")
+		require.NoError(t, err)
+		require.Len(t, mapped, 1)
+		require.NotNil(t, mapped[0].Condition)
+		require.Equal(t, `department == "finance"`, *mapped[0].Condition)
+	})
+
+	t.Run("blank condition is unconditional", func(t *testing.T) {
+		svc := newTestService(t, groupConditionRepos())
+		require.NoError(t, svc.AssignGroupRole(ctx, "finance", "role-ledger", "  "))
+
+		mapped, err := svc.groupRoles.GetByGroupName(ctx, "finance")
+		require.NoError(t, err)
+		require.Len(t, mapped, 1)
+		require.Nil(t, mapped[0].Condition)
+	})
+
+	t.Run("invalid condition is rejected", func(t *testing.T) {
+		svc := newTestService(t, groupConditionRepos())
+		err := svc.AssignGroupRole(ctx, "finance", "role-ledger", `department ==`)
+		require.ErrorContains(t, err, "invalid condition")
+
+		mapped, err := svc.groupRoles.GetByGroupName(ctx, "finance")
+		require.NoError(t, err)
+		require.Empty(t, mapped)
+	})
+}
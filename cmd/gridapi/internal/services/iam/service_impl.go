@@ -5,12 +5,16 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/casbin/casbin/v2"
-	"github.com/hashicorp/go-bexpr"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/bunx"
@@ -34,12 +38,48 @@ type iamService struct {
 	roles           repository.RoleRepository
 	revokedJTIs     repository.RevokedJTIRepository
 
+	// Optional: configured and runtime revocation epoch
+	revocationEpoch *auth.RevocationEpochCache
+
+	// Optional: audit trail of out-of-band mutations (written and queried)
+	auditLogger AuditLogger
+	auditLogs   repository.AuditLogRepository
+
+	// Optional: internal IdP group memberships, read by ExportAccessReview
+	userGroups repository.UserGroupRepository
+
+	// Optional: sizes IdP groups for role assignment caps
+	groupSizes    GroupSizeEstimator
+	states        StateCounter
+	refreshTokens RefreshTokenRevoker
+
+	// Policy for roles saved without a label scope (config.EmptyRoleScope*)
+	emptyRoleScope string
+
+	// How the roles of a principal combine, per object type
+	scopeCombination ScopeCombinationPolicy
+
+	// Rules new internal IdP passwords must satisfy
+	passwordPolicy *auth.PasswordPolicy
+
 	// Immutable cache (lock-free reads)
 	groupRoleCache *GroupRoleCache
 
+	// Expected time between cache refreshes, for reporting a stale cache
+	cacheRefreshInterval time.Duration
+
+	// Minimum cache age before a login with unmapped groups refreshes it (0 disables)
+	cacheMissRefreshAfter time.Duration
+
+	// Header new sessions are bound to (see session_binding.go); empty disables binding
+	sessionBindingHeader string
+
 	// Casbin enforcer (read-only for authorization)
 	enforcer casbin.IEnforcer
 
+	// Denies checks after repeated enforcer errors (see enforcer_breaker.go)
+	enforcerBreaker enforcerBreaker
+
 	// Authenticators (injected, populated in Phase 3)
 	authenticators []Authenticator
 }
@@ -59,12 +99,36 @@ type IAMServiceDependencies struct {
 	Roles           repository.RoleRepository
 	RevokedJTIs     repository.RevokedJTIRepository
 	Enforcer        casbin.IEnforcer
+	GroupSizes      GroupSizeEstimator  // Optional; required to map capped roles to groups
+	States          StateCounter        // Optional; required by CountStatesForRole
+	RefreshTokens   RefreshTokenRevoker // Optional; internal IdP only
+
+	// Optional; without it only GRID_REVOCATION_EPOCH applies and the epoch
+	// cannot be bumped. Share it with the OIDC provider so a bump applies to
+	// both at once.
+	RevocationEpoch *auth.RevocationEpochCache
+
+	// Optional; AuditLogger records mutations, AuditLogs serves ListAuditLog
+	AuditLogger AuditLogger
+	AuditLogs   repository.AuditLogRepository
+
+	// Optional; internal IdP only. Without it ExportAccessReview reports no group-derived roles
+	UserGroups repository.UserGroupRepository
 }
 
 // IAMServiceConfig contains configuration for IAM service construction.
 // Separated from dependencies to clearly distinguish config from runtime dependencies.
 type IAMServiceConfig struct {
 	Config *config.Config
+	// IdPHTTPClient is used to fetch external IdP discovery and JWKS documents.
+	// Optional; nil uses http.DefaultClient.
+	IdPHTTPClient *http.Client
+	// JWKSCaches serve external issuers' signing keys from memory, keyed by
+	// issuer. Optional; issuers without a cache fetch keys through IdPHTTPClient.
+	JWKSCaches map[string]*auth.JWKSCache
+	// PasswordPolicy is enforced by ChangePassword. Optional; nil builds the
+	// policy from Config.PasswordPolicy.
+	PasswordPolicy *auth.PasswordPolicy
 }
 
 // NewIAMService creates a new IAM service with all dependencies.
@@ -77,8 +141,17 @@ type IAMServiceConfig struct {
 // The cache initialization is critical - the server must not start if
 // the cache cannot be loaded, as role resolution would fail for all requests.
 func NewIAMService(deps IAMServiceDependencies, cfg IAMServiceConfig) (Service, error) {
+	groupNameNormalization := auth.DefaultGroupNameNormalization
+	if cfg.Config != nil {
+		var err error
+		groupNameNormalization, err = auth.ParseGroupNameNormalization(cfg.Config.OIDC.GroupNameNormalization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize cache with initial load
-	cache, err := NewGroupRoleCache(deps.GroupRoles, deps.Roles)
+	cache, err := NewGroupRoleCacheWithNormalization(deps.GroupRoles, deps.Roles, groupNameNormalization)
 	if err != nil {
 		return nil, fmt.Errorf("initialize group role cache: %w", err)
 	}
@@ -92,13 +165,37 @@ func NewIAMService(deps IAMServiceDependencies, cfg IAMServiceConfig) (Service,
 		groupRoles:      deps.GroupRoles,
 		roles:           deps.Roles,
 		revokedJTIs:     deps.RevokedJTIs,
+		groupSizes:      deps.GroupSizes,
+		states:          deps.States,
+		refreshTokens:   deps.RefreshTokens,
+		revocationEpoch: deps.RevocationEpoch,
+		auditLogger:     deps.AuditLogger,
+		auditLogs:       deps.AuditLogs,
+		userGroups:      deps.UserGroups,
 		groupRoleCache:  cache,
 		enforcer:        deps.Enforcer,
 		authenticators:  []Authenticator{}, // Initialized below
 	}
+	if cfg.Config != nil {
+		svc.emptyRoleScope = cfg.Config.EmptyRoleScope
+		svc.scopeCombination = cfg.Config.ScopeIntersectionObjectTypes
+		if svc.revocationEpoch == nil {
+			svc.revocationEpoch = auth.NewRevocationEpochCache(nil, cfg.Config.RevocationEpochTime())
+		}
+		svc.cacheRefreshInterval = cfg.Config.CacheRefreshInterval
+		svc.cacheMissRefreshAfter = cfg.Config.CacheMissRefreshAfter
+		svc.sessionBindingHeader = cfg.Config.SessionBindingHeader
+	}
+	svc.passwordPolicy = cfg.PasswordPolicy
+	if svc.passwordPolicy == nil && cfg.Config != nil {
+		svc.passwordPolicy, err = auth.NewPasswordPolicy(cfg.Config.PasswordPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("initialize password policy: %w", err)
+		}
+	}
 
 	// Phase 3: Initialize authenticators
-	authenticators, err := initializeAuthenticators(cfg.Config, deps, svc)
+	authenticators, err := initializeAuthenticators(cfg, deps, svc)
 	if err != nil {
 		return nil, fmt.Errorf("initialize authenticators: %w", err)
 	}
@@ -115,10 +212,11 @@ func NewIAMService(deps IAMServiceDependencies, cfg IAMServiceConfig) (Service,
 //
 // Returns empty slice if auth is disabled (cfg.OIDC not configured).
 func initializeAuthenticators(
-	cfg *config.Config,
+	serviceCfg IAMServiceConfig,
 	deps IAMServiceDependencies,
 	svc *iamService,
 ) ([]Authenticator, error) {
+	cfg := serviceCfg.Config
 	var authenticators []Authenticator
 
 	// SessionAuthenticator always available (even if OIDC disabled)
@@ -126,7 +224,9 @@ func initializeAuthenticators(
 		deps.Users,
 		deps.Sessions,
 		svc,
+		cfg.SessionExpiryGrace,
 	)
+	sessionAuth.bindingHeader = cfg.SessionBindingHeader
 	authenticators = append(authenticators, sessionAuth)
 
 	// JWTAuthenticator only if OIDC configured
@@ -136,6 +236,8 @@ func initializeAuthenticators(
 		deps.ServiceAccounts,
 		deps.RevokedJTIs,
 		svc,
+		serviceCfg.IdPHTTPClient,
+		serviceCfg.JWKSCaches,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create JWT authenticator: %w", err)
@@ -185,14 +287,15 @@ func (s *iamService) AuthenticateRequest(ctx context.Context, req AuthRequest) (
 //
 // This is a PURE FUNCTION with no side effects. It:
 //  1. Fetches principal's directly-assigned roles from database (1-2 DB queries)
-//  2. Fetches group roles from IMMUTABLE CACHE (zero DB queries, lock-free)
+//  2. Fetches group roles from IMMUTABLE CACHE (zero DB queries, lock-free),
+//     evaluating conditional mappings against claims
 //  3. Unions the two sets and deduplicates
 //
 // Performance characteristics:
 //   - Before: 9 DB queries + mutex contention + Casbin mutation
 //   - After: 2 DB queries + zero contention + zero mutation
 //   - Expected latency: <10ms (down from 50-100ms)
-func (s *iamService) ResolveRoles(ctx context.Context, principalID string, groups []string, isUser bool) ([]string, error) {
+func (s *iamService) ResolveRoles(ctx context.Context, principalID string, groups []string, claims map[string]any, isUser bool) ([]string, error) {
 	roleSet := make(map[string]struct{})
 
 	// Step 1: Get principal's directly-assigned roles (DB read)
@@ -219,7 +322,19 @@ func (s *iamService) ResolveRoles(ctx context.Context, principalID string, group
 	}
 
 	// Step 2: Get roles from groups (LOCK-FREE cache read)
-	groupRoles := s.groupRoleCache.GetRolesForGroups(groups)
+	groupRoles := s.groupRoleCache.GetRolesForGroupsWithClaims(groups, claims)
+
+	// Step 2b: A group without a mapping may have been mapped on another
+	// replica since the last refresh; if the snapshot is old enough, refresh
+	// once and look again
+	if s.cacheMissRefreshAfter > 0 && s.groupRoleCache.HasUnmappedGroups(groups) {
+		refreshed, err := s.groupRoleCache.RefreshIfOlderThan(ctx, s.cacheMissRefreshAfter)
+		if err != nil {
+			log.Printf("warning: refresh group role cache for unmapped groups: %v", err)
+		} else if refreshed {
+			groupRoles = s.groupRoleCache.GetRolesForGroupsWithClaims(groups, claims)
+		}
+	}
 	for _, role := range groupRoles {
 		roleSet[role] = struct{}{}
 	}
@@ -248,13 +363,40 @@ func (s *iamService) ResolveRoles(ctx context.Context, principalID string, group
 //   - Zero Casbin mutation (no AddGroupingPolicy)
 //   - Zero database writes
 //   - Thread-safe concurrent calls
+//
+// Each decision is traced as an "iam.Authorize" span and counted by object
+// type and outcome (see authz_telemetry.go).
+//
+// Authorization fails closed: an enforcer error or panic denies the check and
+// is returned alongside false, and repeated errors trip the enforcer breaker
+// (see enforcer_breaker.go).
 func (s *iamService) Authorize(ctx context.Context, principal *Principal, obj, act string, labels map[string]interface{}) (bool, error) {
+	decision, err := s.AuthorizeDecision(ctx, principal, obj, act, labels)
+	return decision.Allowed, err
+}
+
+// AuthorizeDecision is Authorize, also reporting whether an explicit deny
+// rule refused the action.
+func (s *iamService) AuthorizeDecision(ctx context.Context, principal *Principal, obj, act string, labels map[string]interface{}) (Decision, error) {
 	if principal == nil {
-		return false, fmt.Errorf("nil principal")
+		return Decision{}, fmt.Errorf("nil principal")
 	}
 
-	// Use AuthorizeWithRoles from casbin_readonly.go
-	return AuthorizeWithRoles(s.enforcer, principal.Roles, obj, act, labels)
+	ctx, span := startAuthzSpan(ctx, principal, obj, act)
+	defer span.End()
+	start := time.Now()
+
+	// Use DecideWithRoles from casbin_readonly.go
+	var decision Decision
+	err := s.guardEnforcer(ctx, span, obj, func() (err error) {
+		decision, err = DecideWithRoles(s.enforcer, principal.Roles, obj, act, labels, s.scopeCombination.For(obj), principal.TokenScopes)
+		return err
+	})
+	if err != nil {
+		decision = Decision{} // Fail closed
+	}
+	recordAuthzDecision(ctx, span, obj, decision.Allowed, err, time.Since(start))
+	return decision, err
 }
 
 // =========================================================================
@@ -298,8 +440,10 @@ func (s *iamService) GetGroupRoleCacheSnapshot() GroupRoleSnapshot {
 //
 // Generates a cryptographically secure session token, hashes it with SHA256,
 // and stores the session record in the database. Returns the unhashed token
-// (to be set as cookie) and the session record.
-func (s *iamService) CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time) (*models.Session, string, error) {
+// (to be set as cookie) and the session record. With session binding enabled
+// the session records the fingerprint of the login's binding header, and a
+// login without the header fails with ErrSessionBindingHeaderMissing.
+func (s *iamService) CreateSession(ctx context.Context, userID, idToken string, expiresAt time.Time, headers http.Header) (*models.Session, string, error) {
 	// Generate cryptographically secure session token (32 bytes = 64 hex chars)
 	token, err := generateSessionToken()
 	if err != nil {
@@ -316,6 +460,13 @@ func (s *iamService) CreateSession(ctx context.Context, userID, idToken string,
 		IDToken:   idToken,
 		ExpiresAt: expiresAt,
 	}
+	if s.sessionBindingHeader != "" {
+		fingerprint, ok := sessionFingerprint(s.sessionBindingHeader, headers)
+		if !ok {
+			return nil, "", ErrSessionBindingHeaderMissing
+		}
+		session.Fingerprint = &fingerprint
+	}
 
 	// Persist to database
 	if err := s.sessions.Create(ctx, session); err != nil {
@@ -329,11 +480,51 @@ func (s *iamService) CreateSession(ctx context.Context, userID, idToken string,
 // RevokeSession invalidates a session by ID.
 //
 // Sets session.revoked = true and session.revoked_at = now() in the database.
-// Subsequent authentication attempts with this session will fail.
+// Subsequent authentication attempts with this session will fail, and the
+// session's refresh token and access token (internal IdP) are revoked too.
 func (s *iamService) RevokeSession(ctx context.Context, sessionID string) error {
+	session, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
 	if err := s.sessions.Revoke(ctx, sessionID); err != nil {
 		return fmt.Errorf("revoke session: %w", err)
 	}
+	return s.revokeSessionTokens(ctx, []models.Session{*session})
+}
+
+// revokeSessionTokens drops the internal IdP refresh tokens of sessions and
+// denylists their access tokens' JTIs, so a revoked session can neither be
+// renewed nor used through a bearer token it already handed out. Web sessions
+// carry neither and are skipped.
+func (s *iamService) revokeSessionTokens(ctx context.Context, sessions []models.Session) error {
+	var refreshTokens []string
+	now := time.Now()
+	for _, session := range sessions {
+		if session.RefreshToken != "" {
+			refreshTokens = append(refreshTokens, session.RefreshToken)
+		}
+		if session.JTI == "" || !session.ExpiresAt.After(now) || s.revokedJTIs == nil {
+			continue // Expired access tokens are rejected anyway
+		}
+		subject := ""
+		if session.UserID != nil {
+			subject = *session.UserID
+		} else if session.ServiceAccountID != nil {
+			subject = *session.ServiceAccountID
+		}
+		if err := s.revokedJTIs.Create(ctx, &models.RevokedJTI{
+			JTI:       session.JTI,
+			Subject:   subject,
+			Exp:       session.ExpiresAt,
+			RevokedAt: now,
+		}); err != nil {
+			return fmt.Errorf("revoke access token of session %s: %w", session.ID, err)
+		}
+	}
+	if s.refreshTokens != nil && len(refreshTokens) > 0 {
+		s.refreshTokens.RevokeRefreshTokens(ctx, refreshTokens)
+	}
 	return nil
 }
 
@@ -348,6 +539,24 @@ func (s *iamService) GetSessionByID(ctx context.Context, sessionID string) (*mod
 	return session, nil
 }
 
+// GetSessionByToken looks up a session from its raw cookie value.
+//
+// The token is hashed the same way SessionAuthenticator hashes cookies, so
+// callers never handle token hashes themselves.
+func (s *iamService) GetSessionByToken(ctx context.Context, rawToken string) (*models.Session, error) {
+	session, err := s.sessions.GetByTokenHash(ctx, auth.HashToken(rawToken))
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+	if session.Revoked {
+		return session, ErrSessionRevoked
+	}
+	if !session.ExpiresAt.After(time.Now()) {
+		return session, ErrSessionExpired
+	}
+	return session, nil
+}
+
 // ListUserSessions retrieves all sessions for a specific user.
 //
 // Returns empty slice if user has no active sessions.
@@ -359,6 +568,121 @@ func (s *iamService) ListUserSessions(ctx context.Context, userID string) ([]mod
 	return sessions, nil
 }
 
+// Page size bounds for ListAllSessions.
+const (
+	DefaultSessionPageSize = 50
+	MaxSessionPageSize     = 500
+)
+
+// ListAllSessions retrieves sessions across all users for admin review.
+//
+// Page size defaults to 50 and is capped at 500. Token hashes, ID tokens and
+// refresh tokens are stripped from every result.
+func (s *iamService) ListAllSessions(ctx context.Context, filter SessionFilter) ([]models.Session, error) {
+	if filter.PageSize < 0 || filter.Offset < 0 {
+		return nil, fmt.Errorf("invalid paging: page size and offset must not be negative")
+	}
+	if filter.ActiveOnly && filter.RevokedOnly {
+		return nil, fmt.Errorf("invalid filter: active_only and revoked_only are mutually exclusive")
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = DefaultSessionPageSize
+	}
+	if filter.PageSize > MaxSessionPageSize {
+		filter.PageSize = MaxSessionPageSize
+	}
+
+	sessions, err := s.sessions.ListFiltered(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list all sessions: %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].TokenHash = ""
+		sessions[i].IDToken = ""
+		sessions[i].RefreshToken = ""
+	}
+	return sessions, nil
+}
+
+// revokeSessionsBatchSize bounds the rows touched by each RevokeSessions UPDATE.
+const revokeSessionsBatchSize = 500
+
+// RevokeSessions revokes all sessions matching filter in bounded batches.
+//
+// Used during incidents, e.g. revoking every session created before a breach
+// or every session of a compromised service account.
+func (s *iamService) RevokeSessions(ctx context.Context, filter SessionFilter) (count int, err error) {
+	event := AuditEvent{Action: AuditActionSessionsRevoke, TargetType: AuditTargetSession}
+	if filter.UserID != "" || filter.ServiceAccountID != "" {
+		event.TargetType, event.TargetID = principalAuditTarget(filter.UserID, filter.ServiceAccountID)
+	}
+	defer func() {
+		event.After = map[string]any{"filter": sessionFilterAuditState(filter), "revoked": count}
+		s.audit(ctx, event, err)
+	}()
+
+	// Step 1: Refuse filters that would match every session
+	if filter.UserID == "" && filter.ServiceAccountID == "" && filter.CreatedBefore == nil && filter.CreatedAfter == nil {
+		return 0, fmt.Errorf("invalid session filter: a user, service account or creation time bound is required")
+	}
+
+	// Step 2: Revoke in batches, then the tokens the sessions were issued
+	return s.revokeFilteredSessions(ctx, filter)
+}
+
+// revokeFilteredSessions revokes the sessions matching filter (paging does
+// not apply) with their refresh and access tokens. The tokens are collected
+// before revoking so that tokens of sessions revoked earlier (e.g. by logout)
+// are dropped too. Returns how many sessions were newly revoked.
+func (s *iamService) revokeFilteredSessions(ctx context.Context, filter SessionFilter) (int, error) {
+	filter.PageSize, filter.Offset = 0, 0
+	sessions, err := s.sessions.ListFiltered(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("list sessions: %w", err)
+	}
+
+	count, err := s.sessions.RevokeFiltered(ctx, filter, revokeSessionsBatchSize)
+	if err != nil {
+		return count, fmt.Errorf("revoke sessions: %w", err)
+	}
+	if err := s.revokeSessionTokens(ctx, sessions); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// RevokeAllSessions revokes every session of a user or service account,
+// drops the refresh tokens those sessions were issued with and denylists
+// their unexpired access tokens.
+func (s *iamService) RevokeAllSessions(ctx context.Context, principalID, principalType string) (count int, err error) {
+	event := AuditEvent{Action: AuditActionSessionsRevoke, TargetType: principalType, TargetID: principalID}
+	defer func() {
+		event.After = map[string]any{"revoked": count}
+		s.audit(ctx, event, err)
+	}()
+
+	// Step 1: Resolve the principal
+	var filter SessionFilter
+	switch principalType {
+	case "user":
+		if _, err := s.users.GetByID(ctx, principalID); err != nil {
+			return 0, fmt.Errorf("get user: %w", err)
+		}
+		filter.UserID = principalID
+	case "service_account":
+		if _, err := s.serviceAccounts.GetByID(ctx, principalID); err != nil {
+			return 0, fmt.Errorf("get service account: %w", err)
+		}
+		filter.ServiceAccountID = principalID
+	default:
+		return 0, fmt.Errorf("invalid principal type: %s", principalType)
+	}
+
+	// Step 2: Revoke sessions, then the refresh and access tokens
+	return s.revokeFilteredSessions(ctx, filter)
+}
+
 // RevokeJTI adds a JWT ID to the revocation list.
 //
 // Implementation note: This is a stub. Will be implemented when JWT
@@ -368,6 +692,112 @@ func (s *iamService) RevokeJTI(ctx context.Context, jti string, expiresAt time.T
 	return fmt.Errorf("not implemented")
 }
 
+// IntrospectToken reports the status of an arbitrary bearer token.
+//
+// Steps mirror the request path so the answer matches what the JWT
+// authenticator would decide:
+//  1. Verify signature, issuer, audience, and expiry
+//  2. Check the jti against the revocation denylist
+//  3. Check the backing session (internal IdP tokens only)
+//
+// Identity resolution is intentionally skipped: introspection must never
+// JIT-provision users or service accounts.
+func (s *iamService) IntrospectToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	result := &TokenIntrospection{}
+
+	// Step 1: Verify token with the first authenticator able to do so
+	var verifier tokenVerifier
+	for _, authenticator := range s.authenticators {
+		if v, ok := authenticator.(tokenVerifier); ok {
+			verifier = v
+			break
+		}
+	}
+	if verifier == nil {
+		result.InactiveReason = "bearer token authentication is not configured"
+		return result, nil
+	}
+
+	claims, err := verifier.VerifyToken(ctx, token)
+	if err != nil {
+		result.InactiveReason = fmt.Sprintf("invalid token: %v", err)
+		return result, nil
+	}
+
+	result.Subject, _ = claims["sub"].(string)
+	result.JTI, _ = claims["jti"].(string)
+	result.ClientID, _ = claims["client_id"].(string)
+	if result.ClientID == "" {
+		result.ClientID, _ = claims["azp"].(string)
+	}
+	result.Scopes = claimScopes(claims)
+	result.ExpiresAt = auth.ClaimTime(claims, "exp")
+	result.IssuedAt = auth.ClaimTime(claims, "iat")
+
+	if result.JTI == "" {
+		result.InactiveReason = "token missing jti claim"
+		return result, nil
+	}
+
+	// Step 2: Check JTI revocation
+	revoked, err := s.revokedJTIs.IsRevoked(ctx, result.JTI)
+	if err != nil {
+		return nil, fmt.Errorf("check revocation status: %w", err)
+	}
+	result.JTIRevoked = revoked
+
+	// Step 2b: Check the revocation epoch
+	epoch, err := s.RevocationEpoch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get revocation epoch: %w", err)
+	}
+	if auth.IssuedBeforeEpoch(claims, epoch) {
+		result.InactiveReason = "token was issued before the revocation epoch"
+	}
+
+	// Step 3: Look up backing session (sessions are keyed by hashed jti)
+	session, err := s.sessions.GetByTokenHash(ctx, auth.HashBearerToken(result.JTI))
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return nil, fmt.Errorf("get session by token: %w", err)
+	}
+	if err == nil && session != nil {
+		result.SessionID = session.ID
+		result.SessionRevoked = session.Revoked
+		if session.ExpiresAt.Before(time.Now()) {
+			result.InactiveReason = "session has expired"
+		}
+	}
+
+	switch {
+	case result.JTIRevoked:
+		result.InactiveReason = "token has been revoked"
+	case result.SessionRevoked:
+		result.InactiveReason = "session has been revoked"
+	}
+	result.Active = result.InactiveReason == ""
+
+	return result, nil
+}
+
+// claimScopes extracts scopes from either a space-delimited "scope" claim
+// (RFC 8693) or an array-valued "scp" claim (used by some IdPs).
+func claimScopes(claims map[string]any) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	raw, ok := claims["scp"].([]any)
+	if !ok {
+		return nil
+	}
+	scopes := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
 // =========================================================================
 // User Management (Admin Operations)
 // =========================================================================
@@ -443,53 +873,194 @@ func (s *iamService) DisableUser(ctx context.Context, userID string) error {
 	return fmt.Errorf("not implemented")
 }
 
+// MergeUsers folds duplicateID into primaryID.
+//
+// Casbin is synced before the database transaction so that a Casbin failure
+// leaves the database untouched; if the transaction fails, the Casbin changes
+// are reverted.
+func (s *iamService) MergeUsers(ctx context.Context, primaryID, duplicateID string) (err error) {
+	event := AuditEvent{
+		Action:     AuditActionUserMerge,
+		TargetType: AuditTargetUser,
+		TargetID:   primaryID,
+		Before:     map[string]any{"duplicate_id": duplicateID},
+	}
+	defer func() { s.audit(ctx, event, err) }()
+
+	// Step 1: Guard against self-merge
+	if primaryID == "" || duplicateID == "" {
+		return fmt.Errorf("primaryID and duplicateID are required")
+	}
+	if primaryID == duplicateID {
+		return fmt.Errorf("invalid merge: cannot merge user %s into itself", primaryID)
+	}
+
+	// Step 2: Load both users to resolve their Casbin subjects
+	primary, err := s.users.GetByID(ctx, primaryID)
+	if err != nil {
+		return fmt.Errorf("get primary user: %w", err)
+	}
+	duplicate, err := s.users.GetByID(ctx, duplicateID)
+	if err != nil {
+		return fmt.Errorf("get duplicate user: %w", err)
+	}
+	primarySubject := auth.UserID(primary.PrincipalSubject())
+	duplicateSubject := auth.UserID(duplicate.PrincipalSubject())
+
+	// Step 3: Collect the duplicate's role names
+	duplicateRoles, err := s.userRoles.GetByUserID(ctx, duplicateID)
+	if err != nil {
+		return fmt.Errorf("get duplicate user roles: %w", err)
+	}
+	casbinRoles := make([]string, 0, len(duplicateRoles))
+	for _, ur := range duplicateRoles {
+		role, err := s.roles.GetByID(ctx, ur.RoleID)
+		if err != nil {
+			return fmt.Errorf("get role %s: %w", ur.RoleID, err)
+		}
+		casbinRoles = append(casbinRoles, auth.RoleID(role.Name))
+	}
+
+	// Step 4: Sync Casbin, remembering what changed so it can be reverted
+	var added, removed []string
+	revertCasbin := func() {
+		for _, roleID := range added {
+			_, _ = s.enforcer.DeleteRoleForUser(primarySubject, roleID)
+		}
+		for _, roleID := range removed {
+			_, _ = s.enforcer.AddRoleForUser(duplicateSubject, roleID)
+		}
+	}
+	for _, roleID := range casbinRoles {
+		ok, err := s.enforcer.AddRoleForUser(primarySubject, roleID)
+		if err != nil {
+			revertCasbin()
+			return fmt.Errorf("add Casbin role assignment: %w", err)
+		}
+		if ok {
+			added = append(added, roleID)
+		}
+		ok, err = s.enforcer.DeleteRoleForUser(duplicateSubject, roleID)
+		if err != nil {
+			revertCasbin()
+			return fmt.Errorf("remove Casbin role assignment: %w", err)
+		}
+		if ok {
+			removed = append(removed, roleID)
+		}
+	}
+
+	// Step 5: Move assignments, memberships, sessions, and ownership (including
+	// state ownership, recorded by principal ID), then delete the duplicate
+	if err := s.users.Merge(ctx, primaryID, duplicateID, primarySubject, duplicateSubject); err != nil {
+		revertCasbin()
+		return fmt.Errorf("merge users: %w", err)
+	}
+
+	return nil
+}
+
 // =========================================================================
 // Service Account Management (Admin Operations)
 // =========================================================================
 
-// CreateServiceAccount creates a new service account.
+// CreateServiceAccount creates a new service account and assigns it roleNames.
 //
 // Generates client_id (UUIDv7), client_secret (32 random bytes), hashes the secret
-// with bcrypt, and persists to database. Returns the service account record and
-// the unhashed secret (caller must save it - it won't be shown again).
-func (s *iamService) CreateServiceAccount(ctx context.Context, name, createdBy string) (*models.ServiceAccount, string, error) {
+// with bcrypt, and persists to database. Returns the service account record,
+// the unhashed secret (caller must save it - it won't be shown again), and the
+// names of the roles assigned.
+//
+// Role names are resolved before anything is written, so an unknown name
+// creates nothing. The account and its assignments are inserted in one
+// transaction that also checks each role's assignment cap, so a reached cap
+// creates nothing either.
+func (s *iamService) CreateServiceAccount(ctx context.Context, name, createdBy string, roleNames []string) (sa *models.ServiceAccount, clientSecret string, appliedRoles []string, err error) {
+	event := AuditEvent{Action: AuditActionServiceAccountCreate, TargetType: AuditTargetServiceAccount, TargetID: name}
+	defer func() {
+		if sa != nil {
+			event.TargetID = sa.ID
+			event.After = map[string]any{"name": sa.Name, "client_id": sa.ClientID, "created_by": sa.CreatedBy, "roles": appliedRoles}
+		}
+		s.audit(ctx, event, err)
+	}()
+
+	// Resolve roles before anything is written; their caps are checked as
+	// the account and its assignments are created
+	var roles []models.Role
+	if len(roleNames) > 0 {
+		var unique, invalid, valid []string
+		for _, roleName := range roleNames {
+			if !slices.Contains(unique, roleName) {
+				unique = append(unique, roleName)
+			}
+		}
+		roles, invalid, valid, err = s.GetRolesByName(ctx, unique)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("resolve roles: %w", err)
+		}
+		if len(invalid) > 0 {
+			return nil, "", nil, fmt.Errorf("invalid role(s): %s (valid roles are: %s)", strings.Join(invalid, ", "), strings.Join(valid, ", "))
+		}
+	}
+
 	// Generate client_id (UUIDv7 for time-sortable IDs)
 	clientID := bunx.NewUUIDv7()
 
 	// Generate client_secret (32 random bytes = 64 hex characters)
-	clientSecret, err := generateSessionToken()
+	clientSecret, err = generateSessionToken()
 	if err != nil {
-		return nil, "", fmt.Errorf("generate client secret: %w", err)
+		return nil, "", nil, fmt.Errorf("generate client secret: %w", err)
 	}
 
 	// Hash secret with bcrypt (cost 10 is bcrypt.DefaultCost)
 	hashedSecret, err := bcrypt.GenerateFromPassword([]byte(clientSecret), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, "", fmt.Errorf("hash client secret: %w", err)
+		return nil, "", nil, fmt.Errorf("hash client secret: %w", err)
 	}
 
 	// Create service account record
-	sa := &models.ServiceAccount{
+	created := &models.ServiceAccount{
 		Name:             name,
 		ClientID:         clientID,
 		ClientSecretHash: string(hashedSecret),
 		CreatedBy:        createdBy,
 	}
 
-	// Persist to database
-	if err := s.serviceAccounts.Create(ctx, sa); err != nil {
-		return nil, "", fmt.Errorf("create service account in database: %w", err)
+	// Persist the account and its role assignments in one transaction
+	assignments := make([]models.UserRole, len(roles))
+	casbinRules := make([][]string, len(roles))
+	for i, role := range roles {
+		assignments[i] = models.UserRole{RoleID: role.ID, AssignedBy: auth.SystemUserID}
+		casbinRules[i] = []string{auth.ServiceAccountID(clientID), auth.RoleID(role.Name)}
+	}
+	if err := s.serviceAccounts.CreateWithRoles(ctx, created, assignments, s.assignmentCapCheck("")); err != nil {
+		return nil, "", nil, fmt.Errorf("create service account in database: %w", err)
+	}
+
+	// Sync the assignments to Casbin in one batch; on failure the account
+	// (and, by cascade, its assignments) is deleted again
+	if len(casbinRules) > 0 {
+		if _, err := s.enforcer.AddGroupingPolicies(casbinRules); err != nil {
+			if delErr := s.serviceAccounts.Delete(ctx, created.ID); delErr != nil {
+				return nil, "", nil, fmt.Errorf("add Casbin role assignments: %w (rollback failed, service account %s remains: %v)", err, created.ID, delErr)
+			}
+			return nil, "", nil, fmt.Errorf("add Casbin role assignments: %w", err)
+		}
+	}
+	for _, role := range roles {
+		appliedRoles = append(appliedRoles, role.Name)
 	}
 
-	return sa, clientSecret, nil
+	return created, clientSecret, appliedRoles, nil
 }
 
-// ListServiceAccounts returns all service accounts.
+// ListServiceAccounts returns the service accounts matching filter.
 //
 // Implementation note: Simple delegation to repository.
-func (s *iamService) ListServiceAccounts(ctx context.Context) ([]*models.ServiceAccount, error) {
+func (s *iamService) ListServiceAccounts(ctx context.Context, filter ServiceAccountFilter) ([]*models.ServiceAccount, error) {
 	// Delegate to repository
-	accounts, err := s.serviceAccounts.List(ctx)
+	accounts, err := s.serviceAccounts.ListFiltered(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -532,12 +1103,18 @@ func (s *iamService) GetServiceAccountByName(ctx context.Context, name string) (
 //
 // This follows the Phase 4 pattern: Casbin mutations happen in IAM service methods
 // (out-of-band admin operations), NOT in the authentication/authorization request path.
-func (s *iamService) RevokeServiceAccount(ctx context.Context, clientID string) error {
+func (s *iamService) RevokeServiceAccount(ctx context.Context, clientID string) (err error) {
+	event := AuditEvent{Action: AuditActionServiceAccountRevoke, TargetType: AuditTargetServiceAccount, TargetID: clientID}
+	defer func() { s.audit(ctx, event, err) }()
+
 	// Step 1: Get service account by client ID
 	sa, err := s.serviceAccounts.GetByClientID(ctx, clientID)
 	if err != nil {
 		return fmt.Errorf("get service account: %w", err)
 	}
+	event.TargetID = sa.ID
+	event.Before = map[string]any{"client_id": sa.ClientID, "disabled": sa.Disabled}
+	event.After = map[string]any{"client_id": sa.ClientID, "disabled": true}
 
 	// Step 2: Disable the service account
 	if err := s.serviceAccounts.SetDisabled(ctx, sa.ID, true); err != nil {
@@ -551,7 +1128,7 @@ func (s *iamService) RevokeServiceAccount(ctx context.Context, clientID string)
 
 	// Step 4: Remove all Casbin role assignments
 	// This is an out-of-band Casbin mutation (allowed in admin operations)
-	casbinID := fmt.Sprintf("sa:%s", sa.ClientID)
+	casbinID := auth.ServiceAccountID(sa.ClientID)
 	if _, err := s.enforcer.DeleteRolesForUser(casbinID); err != nil {
 		return fmt.Errorf("delete Casbin roles for service account: %w", err)
 	}
@@ -559,41 +1136,142 @@ func (s *iamService) RevokeServiceAccount(ctx context.Context, clientID string)
 	return nil
 }
 
+// MaxSecretRotationOverlap bounds how long a rotated-out service account
+// secret may stay valid.
+const MaxSecretRotationOverlap = 7 * 24 * time.Hour
+
+// SecretRotation is the result of RotateServiceAccountSecret.
+type SecretRotation struct {
+	ClientSecret string // Unhashed new secret, only returned once
+	RotatedAt    time.Time
+	// When the replaced secret stops working; nil when it was invalidated at once
+	PreviousSecretExpiresAt *time.Time
+}
+
 // RotateServiceAccountSecret generates a new secret for a service account.
 //
 // Returns the unhashed secret (caller must save it) and the timestamp of rotation.
 // The secret is hashed with bcrypt before storage.
-func (s *iamService) RotateServiceAccountSecret(ctx context.Context, clientID string) (string, time.Time, error) {
+//
+// With overlap > 0 the replaced secret is kept as the previous secret and
+// accepted by the token endpoint until the overlap ends; a previous secret
+// from an earlier overlapping rotation is dropped. A zero overlap invalidates
+// the old secret immediately.
+func (s *iamService) RotateServiceAccountSecret(ctx context.Context, clientID string, overlap time.Duration) (_ *SecretRotation, err error) {
+	event := AuditEvent{Action: AuditActionServiceAccountRotateSecret, TargetType: AuditTargetServiceAccount, TargetID: clientID}
+	defer func() { s.audit(ctx, event, err) }()
+
+	if overlap < 0 || overlap > MaxSecretRotationOverlap {
+		return nil, fmt.Errorf("invalid overlap %s: must be between 0 and %s", overlap, MaxSecretRotationOverlap)
+	}
+
 	// Step 1: Get service account by client ID
 	sa, err := s.serviceAccounts.GetByClientID(ctx, clientID)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("get service account: %w", err)
+		return nil, fmt.Errorf("get service account: %w", err)
 	}
+	event.TargetID = sa.ID
 
 	// Step 2: Generate new secret
 	newSecret, err := generateSessionToken()
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("generate new secret: %w", err)
+		return nil, fmt.Errorf("generate new secret: %w", err)
 	}
 
 	// Step 3: Hash new secret with bcrypt
 	hashedSecret, err := bcrypt.GenerateFromPassword([]byte(newSecret), bcrypt.DefaultCost)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("hash new secret: %w", err)
+		return nil, fmt.Errorf("hash new secret: %w", err)
 	}
 
-	// Step 4: Update secret hash in database
-	if err := s.serviceAccounts.UpdateSecretHash(ctx, sa.ID, string(hashedSecret)); err != nil {
-		return "", time.Time{}, fmt.Errorf("update secret hash: %w", err)
+	// Step 4: Update secret hash in database, keeping the old one during overlap
+	var previousExpiresAt *time.Time
+	if overlap > 0 {
+		expiresAt := time.Now().Add(overlap)
+		previousExpiresAt = &expiresAt
+		if err := s.serviceAccounts.UpdateSecretHashWithOverlap(ctx, sa.ID, string(hashedSecret), expiresAt); err != nil {
+			return nil, fmt.Errorf("update secret hash: %w", err)
+		}
+		event.After = map[string]any{"previous_secret_expires_at": expiresAt}
+	} else if err := s.serviceAccounts.UpdateSecretHash(ctx, sa.ID, string(hashedSecret)); err != nil {
+		return nil, fmt.Errorf("update secret hash: %w", err)
 	}
 
 	// Step 5: Get updated service account to retrieve rotation timestamp
 	updatedSA, err := s.serviceAccounts.GetByID(ctx, sa.ID)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("get updated service account: %w", err)
+		return nil, fmt.Errorf("get updated service account: %w", err)
 	}
 
-	return newSecret, updatedSA.SecretRotatedAt, nil
+	return &SecretRotation{
+		ClientSecret:            newSecret,
+		RotatedAt:               updatedSA.SecretRotatedAt,
+		PreviousSecretExpiresAt: previousExpiresAt,
+	}, nil
+}
+
+// credentialTestChecks are the representative authorization checks run by
+// TestCredentials, covering the control plane and the Terraform data plane.
+var credentialTestChecks = []CredentialCheck{
+	{Object: auth.ObjectTypeState, Action: auth.StateList},
+	{Object: auth.ObjectTypeState, Action: auth.StateRead},
+	{Object: auth.ObjectTypeState, Action: auth.StateCreate},
+	{Object: auth.ObjectTypeState, Action: auth.TfstateRead},
+	{Object: auth.ObjectTypeState, Action: auth.TfstateWrite},
+}
+
+// TestCredentials runs the client-credentials flow internally and reports
+// authentication and authorization outcomes.
+//
+// Mirrors the checks performed by the OIDC provider's token endpoint
+// (client lookup, bcrypt secret comparison, disabled flag) but never issues a
+// token, persists a session, or updates last-used timestamps.
+func (s *iamService) TestCredentials(ctx context.Context, clientID, clientSecret string) (*CredentialTestResult, error) {
+	result := &CredentialTestResult{ClientID: clientID}
+
+	// Step 1: Look up service account by client ID
+	sa, err := s.serviceAccounts.GetByClientID(ctx, clientID)
+	if err != nil || sa == nil {
+		result.AuthenticationError = "service account not found"
+		return result, nil
+	}
+	result.ServiceAccountID = sa.ID
+	result.ServiceAccountName = sa.Name
+
+	// Step 2: Verify client secret against stored bcrypt hash (or the
+	// previous secret during a rotation overlap)
+	if _, err := auth.VerifyClientSecret(sa, clientSecret, time.Now()); err != nil {
+		result.AuthenticationError = "invalid client secret"
+		return result, nil
+	}
+
+	// Step 3: Reject disabled service accounts (same as token issuance)
+	if sa.Disabled {
+		result.AuthenticationError = "service account is disabled"
+		return result, nil
+	}
+	result.Authenticated = true
+
+	// Step 4: Resolve roles (service accounts carry no IdP groups)
+	roles, err := s.ResolveRoles(ctx, sa.ID, nil, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("resolve roles: %w", err)
+	}
+	sort.Strings(roles)
+	result.Roles = roles
+
+	// Step 5: Run representative authorization checks
+	result.Checks = make([]CredentialCheck, 0, len(credentialTestChecks))
+	for _, check := range credentialTestChecks {
+		allowed, err := AuthorizeWithRoles(s.enforcer, roles, check.Object, check.Action, nil, s.scopeCombination.For(check.Object), nil)
+		if err != nil {
+			return nil, fmt.Errorf("authorize %s on %s: %w", check.Action, check.Object, err)
+		}
+		check.Allowed = allowed
+		result.Checks = append(result.Checks, check)
+	}
+
+	return result, nil
 }
 
 // =========================================================================
@@ -633,10 +1311,14 @@ func (s *iamService) RemoveRolesFromServiceAccount(ctx context.Context, serviceA
 // AssignUserRole assigns a role directly to a user or service account.
 //
 // This is an out-of-band mutation operation that:
-//  1. Creates a UserRole record in the database
+//  1. Creates a UserRole record in the database, unless it would exceed the
+//     role's MaxAssignments (checked in the same transaction)
 //  2. Syncs the assignment to Casbin for enforcement
 //  3. Rolls back the database change if Casbin sync fails
 //
+// A crash between steps 1 and 2 leaves the row without its Casbin grouping;
+// ReconcileRoleAssignments repairs that on the next startup.
+//
 // Parameters:
 //   - userID: Internal UUID of user (set this for user principals, empty for SA)
 //   - serviceAccountID: Internal UUID of service account (set this for SA principals, empty for user)
@@ -644,17 +1326,22 @@ func (s *iamService) RemoveRolesFromServiceAccount(ctx context.Context, serviceA
 //
 // This follows the Phase 4 pattern: Casbin mutations happen in IAM service methods
 // (out-of-band admin operations), NOT in the authentication/authorization request path.
-func (s *iamService) AssignUserRole(ctx context.Context, userID, serviceAccountID, roleID string) error {
+func (s *iamService) AssignUserRole(ctx context.Context, userID, serviceAccountID, roleID string) (err error) {
+	event := AuditEvent{Action: AuditActionUserRoleAssign, After: map[string]any{"role_id": roleID}}
+	event.TargetType, event.TargetID = principalAuditTarget(userID, serviceAccountID)
+	defer func() { s.audit(ctx, event, err) }()
+
 	// Step 1: Validate that exactly one principal is specified
 	if (userID == "" && serviceAccountID == "") || (userID != "" && serviceAccountID != "") {
-		return fmt.Errorf("exactly one of userID or serviceAccountID must be specified")
+		return ErrPrincipalAmbiguous
 	}
 
 	// Step 2: Get role to verify it exists and get its name for Casbin
 	role, err := s.roles.GetByID(ctx, roleID)
 	if err != nil {
-		return fmt.Errorf("get role: %w", err)
+		return roleLookupError(err, roleID)
 	}
+	event.After["role"] = role.Name
 
 	// Step 3: Create UserRole record
 	var casbinPrincipalID string
@@ -684,11 +1371,11 @@ func (s *iamService) AssignUserRole(ctx context.Context, userID, serviceAccountI
 		casbinPrincipalID = auth.ServiceAccountID(sa.ClientID)
 	}
 
-	// Step 4: Persist the assignment to database
-	if err := s.userRoles.Create(ctx, userRole); err != nil {
+	// Step 4: Persist the assignment to database, within the role's cap
+	if err := s.userRoles.CreateChecked(ctx, userRole, s.assignmentCapCheck("")); err != nil {
 		// Handle duplicate assignment gracefully
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return fmt.Errorf("role already assigned to principal")
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return fmt.Errorf("role '%s' %w to principal", role.Name, ErrDuplicateAssignment)
 		}
 		return fmt.Errorf("create user role assignment: %w", err)
 	}
@@ -717,17 +1404,22 @@ func (s *iamService) AssignUserRole(ctx context.Context, userID, serviceAccountI
 //
 // This follows the Phase 4 pattern: Casbin mutations happen in IAM service methods
 // (out-of-band admin operations), NOT in the authentication/authorization request path.
-func (s *iamService) RemoveUserRole(ctx context.Context, userID, serviceAccountID, roleID string) error {
+func (s *iamService) RemoveUserRole(ctx context.Context, userID, serviceAccountID, roleID string) (err error) {
+	event := AuditEvent{Action: AuditActionUserRoleRemove, Before: map[string]any{"role_id": roleID}}
+	event.TargetType, event.TargetID = principalAuditTarget(userID, serviceAccountID)
+	defer func() { s.audit(ctx, event, err) }()
+
 	// Step 1: Validate that exactly one principal is specified
 	if (userID == "" && serviceAccountID == "") || (userID != "" && serviceAccountID != "") {
-		return fmt.Errorf("exactly one of userID or serviceAccountID must be specified")
+		return ErrPrincipalAmbiguous
 	}
 
 	// Step 2: Get role to verify it exists and get its name for Casbin
 	role, err := s.roles.GetByID(ctx, roleID)
 	if err != nil {
-		return fmt.Errorf("get role: %w", err)
+		return roleLookupError(err, roleID)
 	}
+	event.Before["role"] = role.Name
 
 	// Step 3: Determine principal type and construct Casbin ID
 	var casbinPrincipalID string
@@ -773,40 +1465,62 @@ func (s *iamService) RemoveUserRole(ctx context.Context, userID, serviceAccountI
 // AssignGroupRole assigns a role to an IdP group.
 //
 // This is an out-of-band mutation operation that:
-//  1. Creates a GroupRole record in the database
-//  2. Syncs the assignment to Casbin for enforcement
-//  3. Automatically refreshes the group→role cache
-//  4. Rolls back the database change if Casbin sync fails
+//  1. Rejects the mapping if its claim condition is not valid go-bexpr
+//  2. Creates a GroupRole record in the database, unless the group's estimated
+//     size would exceed the role's MaxAssignments (checked in the same transaction)
+//  3. Syncs the assignment to Casbin for enforcement
+//  4. Automatically refreshes the group→role cache
+//  5. Rolls back the database change if Casbin sync fails
 //
 // The automatic cache refresh ensures new mappings are visible to the
 // authentication flow immediately (Phase 7 Task 7.3).
 //
+// groupName is stored normalized (GRID_OIDC_GROUP_NAME_NORMALIZATION), the
+// same way token groups are normalized before lookup.
+//
 // This follows the Phase 4 pattern: Casbin mutations happen in IAM service methods
 // (out-of-band admin operations), NOT in the authentication/authorization request path.
-func (s *iamService) AssignGroupRole(ctx context.Context, groupName, roleID string) error {
+func (s *iamService) AssignGroupRole(ctx context.Context, groupName, roleID, condition string) (err error) {
+	groupName = s.groupRoleCache.NormalizeGroupName(groupName)
+	event := AuditEvent{
+		Action:     AuditActionGroupRoleAssign,
+		TargetType: AuditTargetGroup,
+		TargetID:   groupName,
+		After:      map[string]any{"role_id": roleID, "condition": condition},
+	}
+	defer func() { s.audit(ctx, event, err) }()
+
 	// Step 1: Get role to verify it exists and get its name for Casbin
 	role, err := s.roles.GetByID(ctx, roleID)
 	if err != nil {
-		return fmt.Errorf("get role: %w", err)
+		return roleLookupError(err, roleID)
+	}
+	event.After["role"] = role.Name
+
+	// Step 2: Validate the claim condition
+	conditionPtr, err := normalizeGroupRoleCondition(condition)
+	if err != nil {
+		return err
 	}
 
-	// Step 2: Create GroupRole record
+	// Step 3: Create GroupRole record
 	// Use SystemUserID for CLI/system operations (until we add assignedBy parameter)
 	groupRole := &models.GroupRole{
 		GroupName:  groupName,
 		RoleID:     roleID,
 		AssignedBy: auth.SystemUserID,
+		Condition:  conditionPtr,
 	}
 
-	if err := s.groupRoles.Create(ctx, groupRole); err != nil {
+	if err := s.groupRoles.CreateChecked(ctx, groupRole, s.assignmentCapCheck(groupName)); err != nil {
 		// Handle duplicate assignment gracefully
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return fmt.Errorf("role already assigned to group")
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return fmt.Errorf("role '%s' %w to group '%s'", role.Name, ErrDuplicateAssignment, groupName)
 		}
 		return fmt.Errorf("create group role assignment: %w", err)
 	}
 
-	// Step 3: Sync to Casbin (out-of-band mutation)
+	// Step 4: Sync to Casbin (out-of-band mutation)
 	casbinPrincipalID := auth.GroupID(groupName)
 	casbinRoleID := auth.RoleID(role.Name)
 
@@ -816,7 +1530,7 @@ func (s *iamService) AssignGroupRole(ctx context.Context, groupName, roleID stri
 		return fmt.Errorf("add Casbin group-role assignment: %w", err)
 	}
 
-	// Step 4: Automatically refresh cache (Phase 7 Task 7.3)
+	// Step 5: Automatically refresh cache (Phase 7 Task 7.3)
 	// This ensures new group→role mappings are visible immediately
 	if err := s.RefreshGroupRoleCache(ctx); err != nil {
 		// Log error but don't fail - cache will refresh on background ticker
@@ -839,24 +1553,32 @@ func (s *iamService) AssignGroupRole(ctx context.Context, groupName, roleID stri
 //
 // This follows the Phase 4 pattern: Casbin mutations happen in IAM service methods
 // (out-of-band admin operations), NOT in the authentication/authorization request path.
-func (s *iamService) RemoveGroupRole(ctx context.Context, groupName, roleID string) error {
+func (s *iamService) RemoveGroupRole(ctx context.Context, groupName, roleID string) (err error) {
+	event := AuditEvent{
+		Action:     AuditActionGroupRoleRemove,
+		TargetType: AuditTargetGroup,
+		TargetID:   groupName,
+		Before:     map[string]any{"role_id": roleID},
+	}
+	defer func() { s.audit(ctx, event, err) }()
+
 	// Step 1: Get role to verify it exists and get its name for Casbin
 	role, err := s.roles.GetByID(ctx, roleID)
 	if err != nil {
-		return fmt.Errorf("get role: %w", err)
-	}
-
-	// Step 2: Delete from database
-	if err := s.groupRoles.DeleteByGroupAndRole(ctx, groupName, roleID); err != nil {
-		return fmt.Errorf("delete group role assignment: %w", err)
+		return roleLookupError(err, roleID)
 	}
+	event.Before["role"] = role.Name
 
-	// Step 3: Remove from Casbin (out-of-band mutation)
-	casbinPrincipalID := auth.GroupID(groupName)
+	// Step 2-3: Delete from database and Casbin, under the normalized name
+	// and any un-normalized name stored before normalization applied
 	casbinRoleID := auth.RoleID(role.Name)
-
-	if _, err := s.enforcer.DeleteRoleForUser(casbinPrincipalID, casbinRoleID); err != nil {
-		return fmt.Errorf("remove Casbin group-role assignment: %w", err)
+	for _, name := range s.groupNameVariants(groupName) {
+		if err := s.groupRoles.DeleteByGroupAndRole(ctx, name, roleID); err != nil {
+			return fmt.Errorf("delete group role assignment: %w", err)
+		}
+		if _, err := s.enforcer.DeleteRoleForUser(auth.GroupID(name), casbinRoleID); err != nil {
+			return fmt.Errorf("remove Casbin group-role assignment: %w", err)
+		}
 	}
 
 	// Step 4: Automatically refresh cache (Phase 7 Task 7.3)
@@ -876,9 +1598,9 @@ func (s *iamService) RemoveGroupRole(ctx context.Context, groupName, roleID stri
 // CreateRole creates a new role with permissions synced to Casbin.
 //
 // This is an out-of-band mutation operation that:
-//  1. Validates label_scope_expr as valid go-bexpr syntax
+//  1. Validates label_scope_expr as valid go-bexpr syntax and every action as "obj:act"
 //  2. Creates a Role record in the database
-//  3. Parses actions (format "obj:act") and adds Casbin policies
+//  3. Parses actions (format "obj:act", or "!obj:act" for a deny rule) and adds Casbin policies
 //  4. Rolls back the database change if Casbin sync fails
 //
 // This follows the Phase 4 pattern: Casbin mutations happen in IAM service methods
@@ -888,26 +1610,51 @@ func (s *iamService) CreateRole(
 	name, description, scopeExpr string,
 	createConstraints models.CreateConstraints,
 	immutableKeys []string,
+	maxAssignments *int,
+	ownerActions []string,
 	actions []string,
-) (*models.Role, error) {
-	// Step 1: Validate scope expression as valid go-bexpr syntax
-	if scopeExpr != "" {
-		if _, err := bexpr.CreateEvaluator(scopeExpr); err != nil {
-			return nil, fmt.Errorf("invalid label_scope_expr: %w", err)
-		}
+	defaultLabels bool,
+) (role *models.Role, err error) {
+	event := AuditEvent{Action: AuditActionRoleCreate, TargetType: AuditTargetRole, TargetID: name}
+	defer func() {
+		event.After = roleAuditState(role, actions)
+		s.audit(ctx, event, err)
+	}()
+
+	// Step 1: Validate scope expression and apply the empty-scope policy
+	scopeExpr, err = s.resolveScopeExpr(scopeExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxAssignments(maxAssignments); err != nil {
+		return nil, err
+	}
+	if err := validateRoleActions(actions); err != nil {
+		return nil, err
+	}
+	if err := validateOwnerActions(ownerActions, actions); err != nil {
+		return nil, err
 	}
 
 	// Step 2: Create role record
-	role := &models.Role{
+	role = &models.Role{
 		Name:              name,
 		Description:       description,
 		ScopeExpr:         scopeExpr,
 		CreateConstraints: createConstraints,
 		ImmutableKeys:     immutableKeys,
-		Version:           1, // Initial version
+		MaxAssignments:    maxAssignments,
+		OwnerActions:      ownerActions,
+		DefaultLabels:     defaultLabels,
+		Actions:           append([]string{}, actions...), // Non-nil: recorded, even when empty
+		Version:           1,                              // Initial version
 	}
 
 	if err := s.roles.Create(ctx, role); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, fmt.Errorf("%w: %s", ErrRoleExists, name)
+		}
 		return nil, fmt.Errorf("create role: %w", err)
 	}
 
@@ -916,21 +1663,15 @@ func (s *iamService) CreateRole(
 	casbinRoleID := auth.RoleID(role.Name)
 
 	for _, action := range actions {
-		// Parse action format "obj:act" (e.g., "state:read")
-		parts := strings.SplitN(action, ":", 2)
-		if len(parts) != 2 {
-			// Skip invalid actions with warning (don't fail entire request)
-			// TODO: Add proper logging
-			continue
-		}
-		objType := parts[0]
-		act := parts[1]
+		// Actions were validated above as "obj:act" (e.g., "state:read") or "!obj:act"
+		objType, act, effect, _ := parseRoleAction(action)
 
-		// Construct Casbin policy: [role, obj, action, scopeExpr, "allow"]
-		policy := []string{casbinRoleID, objType, act, scopeExpr, "allow"}
+		// Construct Casbin policy: [role, obj, action, scopeExpr, effect]
+		policy := []string{casbinRoleID, objType, act, scopeExpr, effect}
 
 		if _, err := s.enforcer.AddPolicy(policy); err != nil {
-			// Rollback database change if Casbin sync fails
+			// Rollback database change and any policies already added if Casbin sync fails
+			_, _ = s.enforcer.RemoveFilteredPolicy(0, casbinRoleID)
 			_ = s.roles.Delete(ctx, role.ID)
 			return nil, fmt.Errorf("add Casbin policy for action '%s': %w", action, err)
 		}
@@ -942,7 +1683,7 @@ func (s *iamService) CreateRole(
 // UpdateRole updates an existing role's permissions and metadata.
 //
 // This is an out-of-band mutation operation that:
-//  1. Validates label_scope_expr as valid go-bexpr syntax
+//  1. Validates label_scope_expr as valid go-bexpr syntax and every action as "obj:act"
 //  2. Checks optimistic locking (version must match)
 //  3. Updates the Role record in the database
 //  4. Removes all old Casbin policies for the role
@@ -950,7 +1691,7 @@ func (s *iamService) CreateRole(
 //
 // Note: Unlike CreateRole, there is NO automatic rollback if Casbin sync fails after
 // the database update. This is because the update has already been committed.
-// In production, consider using a transactional Casbin adapter or manual reconciliation.
+// ReconcileRolePolicies rebuilds the policies from the actions stored on the role.
 func (s *iamService) UpdateRole(
 	ctx context.Context,
 	name string,
@@ -958,24 +1699,42 @@ func (s *iamService) UpdateRole(
 	description, scopeExpr string,
 	createConstraints models.CreateConstraints,
 	immutableKeys []string,
+	maxAssignments *int,
+	ownerActions []string,
 	actions []string,
-) (*models.Role, error) {
-	// Step 1: Validate scope expression
-	if scopeExpr != "" {
-		if _, err := bexpr.CreateEvaluator(scopeExpr); err != nil {
-			return nil, fmt.Errorf("invalid label_scope_expr: %w", err)
-		}
+	defaultLabels bool,
+) (updatedRole *models.Role, err error) {
+	event := AuditEvent{Action: AuditActionRoleUpdate, TargetType: AuditTargetRole, TargetID: name}
+	defer func() {
+		event.After = roleAuditState(updatedRole, actions)
+		s.audit(ctx, event, err)
+	}()
+
+	// Step 1: Validate scope expression and apply the empty-scope policy
+	scopeExpr, err = s.resolveScopeExpr(scopeExpr)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMaxAssignments(maxAssignments); err != nil {
+		return nil, err
+	}
+	if err := validateRoleActions(actions); err != nil {
+		return nil, err
+	}
+	if err := validateOwnerActions(ownerActions, actions); err != nil {
+		return nil, err
 	}
 
 	// Step 2: Get existing role by name
 	role, err := s.roles.GetByName(ctx, name)
 	if err != nil {
-		return nil, fmt.Errorf("get role: %w", err)
+		return nil, roleLookupError(err, name)
 	}
+	event.Before = roleAuditState(role, s.roleActions(role.Name))
 
 	// Step 3: Check optimistic locking
 	if role.Version != expectedVersion {
-		return nil, fmt.Errorf("version mismatch: expected %d, got %d (concurrent modification detected)", expectedVersion, role.Version)
+		return nil, fmt.Errorf("%w: expected %d, got %d (concurrent modification detected)", ErrVersionConflict, expectedVersion, role.Version)
 	}
 
 	// Step 4: Update role fields
@@ -983,9 +1742,16 @@ func (s *iamService) UpdateRole(
 	role.ScopeExpr = scopeExpr
 	role.CreateConstraints = createConstraints
 	role.ImmutableKeys = immutableKeys
+	role.MaxAssignments = maxAssignments
+	role.OwnerActions = ownerActions
+	role.Actions = append([]string{}, actions...)
+	role.DefaultLabels = defaultLabels
 	// Version is incremented by repository
 
 	if err := s.roles.Update(ctx, role); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, roleLookupError(err, name)
+		}
 		return nil, fmt.Errorf("update role: %w", err)
 	}
 
@@ -993,30 +1759,25 @@ func (s *iamService) UpdateRole(
 	// Remove all old policies for this role
 	casbinRoleID := auth.RoleID(role.Name)
 	if _, err := s.enforcer.RemoveFilteredPolicy(0, casbinRoleID); err != nil {
-		// Log error but don't fail - update is already committed
-		// TODO: Add proper logging
+		// The update is already committed; the failed audit entry flags the role for reconciliation
+		log.Printf("ERROR: role %s updated but its old Casbin policies were not removed: %v", name, err)
 		return nil, fmt.Errorf("remove old Casbin policies: %w", err)
 	}
 
 	// Add new policies
 	for _, action := range actions {
-		parts := strings.SplitN(action, ":", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		objType := parts[0]
-		act := parts[1]
+		objType, act, effect, _ := parseRoleAction(action)
 
-		policy := []string{casbinRoleID, objType, act, scopeExpr, "allow"}
+		policy := []string{casbinRoleID, objType, act, scopeExpr, effect}
 		if _, err := s.enforcer.AddPolicy(policy); err != nil {
-			// Log error but don't fail - can't rollback database update
-			// TODO: Add proper logging for manual reconciliation
+			// Can't rollback the database update; ReconcileRolePolicies repairs it
+			log.Printf("ERROR: role %s updated but Casbin policy for action %q was not added: %v", name, action, err)
 			return nil, fmt.Errorf("add Casbin policy for action '%s': %w", action, err)
 		}
 	}
 
 	// Step 6: Fetch updated role (with incremented version)
-	updatedRole, err := s.roles.GetByID(ctx, role.ID)
+	updatedRole, err = s.roles.GetByID(ctx, role.ID)
 	if err != nil {
 		return nil, fmt.Errorf("get updated role: %w", err)
 	}
@@ -1033,12 +1794,16 @@ func (s *iamService) UpdateRole(
 //  4. Removes all Casbin policies for the role
 //
 // Safety: Rejects deletion if role is assigned to any principals.
-func (s *iamService) DeleteRole(ctx context.Context, name string) error {
+func (s *iamService) DeleteRole(ctx context.Context, name string) (err error) {
+	event := AuditEvent{Action: AuditActionRoleDelete, TargetType: AuditTargetRole, TargetID: name}
+	defer func() { s.audit(ctx, event, err) }()
+
 	// Step 1: Get role by name
 	role, err := s.roles.GetByName(ctx, name)
 	if err != nil {
-		return fmt.Errorf("get role: %w", err)
+		return roleLookupError(err, name)
 	}
+	event.Before = roleAuditState(role, s.roleActions(role.Name))
 
 	// Step 2: Check if role is assigned to any principals (safety check)
 	casbinRoleID := auth.RoleID(role.Name)
@@ -1048,7 +1813,7 @@ func (s *iamService) DeleteRole(ctx context.Context, name string) error {
 	}
 
 	if len(users) > 0 {
-		return fmt.Errorf("cannot delete role: still assigned to %d principals", len(users))
+		return fmt.Errorf("cannot delete role %s: %w to %d principals", name, ErrRoleInUse, len(users))
 	}
 
 	// Step 3: Delete role from database
@@ -1059,7 +1824,7 @@ func (s *iamService) DeleteRole(ctx context.Context, name string) error {
 	// Step 4: Remove all Casbin policies for this role
 	if _, err := s.enforcer.RemoveFilteredPolicy(0, casbinRoleID); err != nil {
 		// Log error prominently - role is already deleted from DB
-		// TODO: Add proper logging for manual reconciliation
+		log.Printf("ERROR: role %s deleted but its Casbin policies were not removed: %v", name, err)
 		return fmt.Errorf("remove Casbin policies: %w", err)
 	}
 
@@ -1072,7 +1837,11 @@ func (s *iamService) DeleteRole(ctx context.Context, name string) error {
 
 // GetRoleByName retrieves a role by its name.
 func (s *iamService) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
-	return s.roles.GetByName(ctx, name)
+	role, err := s.roles.GetByName(ctx, name)
+	if err != nil {
+		return nil, roleLookupError(err, name)
+	}
+	return role, nil
 }
 
 // GetRolesByName returns the roles matching the provided names.
@@ -1107,7 +1876,11 @@ func (s *iamService) GetRolesByName(ctx context.Context, roleNames []string) ([]
 
 // GetRoleByID retrieves a role by its internal ID.
 func (s *iamService) GetRoleByID(ctx context.Context, roleID string) (*models.Role, error) {
-	return s.roles.GetByID(ctx, roleID)
+	role, err := s.roles.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, roleLookupError(err, roleID)
+	}
+	return role, nil
 }
 
 // ListAllRoles returns all roles in the system.
@@ -1122,10 +1895,28 @@ func (s *iamService) GetServiceAccountByID(ctx context.Context, saID string) (*m
 
 // ListGroupRoles returns all group→role assignments, optionally filtered by group name.
 func (s *iamService) ListGroupRoles(ctx context.Context, groupName *string) ([]models.GroupRole, error) {
-	if groupName != nil {
-		return s.groupRoles.GetByGroupName(ctx, *groupName)
+	if groupName == nil {
+		return s.groupRoles.List(ctx)
+	}
+	var groupRoles []models.GroupRole
+	for _, name := range s.groupNameVariants(*groupName) {
+		found, err := s.groupRoles.GetByGroupName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		groupRoles = append(groupRoles, found...)
+	}
+	return groupRoles, nil
+}
+
+// groupNameVariants returns the normalized group name, followed by name itself
+// when it differs (assignments stored before normalization keep their name).
+func (s *iamService) groupNameVariants(name string) []string {
+	normalized := s.groupRoleCache.NormalizeGroupName(name)
+	if normalized == name {
+		return []string{normalized}
 	}
-	return s.groupRoles.List(ctx)
+	return []string{normalized, name}
 }
 
 // GetPrincipalRoles returns the Casbin role IDs for a principal.
@@ -0,0 +1,109 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// fakeTokenVerifier returns canned claims for a single known token.
+type fakeTokenVerifier struct {
+	token  string
+	claims map[string]any
+}
+
+func (f *fakeTokenVerifier) Authenticate(ctx context.Context, req AuthRequest) (*Principal, error) {
+	return nil, nil
+}
+
+func (f *fakeTokenVerifier) VerifyToken(ctx context.Context, token string) (map[string]any, error) {
+	if token != f.token {
+		return nil, errors.New("signature verification failed")
+	}
+	return f.claims, nil
+}
+
+func newIntrospectTestService(t *testing.T, revoked map[string]bool, sessions map[string]*models.Session) *iamService {
+	t.Helper()
+
+	now := time.Now()
+	svc := newTestService(t, testRepos{sessions: &mockSessionRepository{sessions: sessions}})
+	svc.revokedJTIs = &mockRevokedJTIRepository{revokedJTIs: revoked}
+	svc.authenticators = []Authenticator{
+		&fakeTokenVerifier{
+			token: "good-token",
+			claims: map[string]any{
+				"sub":       "user:alice",
+				"jti":       "jti-1",
+				"client_id": "gridctl",
+				"scope":     "openid profile",
+				"iat":       float64(now.Add(-time.Minute).Unix()),
+				"exp":       float64(now.Add(time.Hour).Unix()),
+			},
+		},
+	}
+	return svc
+}
+
+func TestIntrospectTokenActive(t *testing.T) {
+	t.Parallel()
+
+	svc := newIntrospectTestService(t, map[string]bool{}, map[string]*models.Session{
+		auth.HashBearerToken("jti-1"): {ID: "sess-1", ExpiresAt: time.Now().Add(time.Hour)},
+	})
+
+	result, err := svc.IntrospectToken(context.Background(), "good-token")
+	require.NoError(t, err)
+	require.True(t, result.Active)
+	require.Empty(t, result.InactiveReason)
+	require.Equal(t, "user:alice", result.Subject)
+	require.Equal(t, "gridctl", result.ClientID)
+	require.Equal(t, []string{"openid", "profile"}, result.Scopes)
+	require.Equal(t, "jti-1", result.JTI)
+	require.Equal(t, "sess-1", result.SessionID)
+	require.False(t, result.ExpiresAt.IsZero())
+}
+
+func TestIntrospectTokenRevokedJTI(t *testing.T) {
+	t.Parallel()
+
+	svc := newIntrospectTestService(t, map[string]bool{"jti-1": true}, map[string]*models.Session{})
+
+	result, err := svc.IntrospectToken(context.Background(), "good-token")
+	require.NoError(t, err)
+	require.False(t, result.Active)
+	require.True(t, result.JTIRevoked)
+	require.Equal(t, "token has been revoked", result.InactiveReason)
+	require.Empty(t, result.SessionID)
+}
+
+func TestIntrospectTokenRevokedSession(t *testing.T) {
+	t.Parallel()
+
+	svc := newIntrospectTestService(t, map[string]bool{}, map[string]*models.Session{
+		auth.HashBearerToken("jti-1"): {ID: "sess-1", Revoked: true, ExpiresAt: time.Now().Add(time.Hour)},
+	})
+
+	result, err := svc.IntrospectToken(context.Background(), "good-token")
+	require.NoError(t, err)
+	require.False(t, result.Active)
+	require.True(t, result.SessionRevoked)
+	require.Equal(t, "session has been revoked", result.InactiveReason)
+}
+
+func TestIntrospectTokenInvalid(t *testing.T) {
+	t.Parallel()
+
+	svc := newIntrospectTestService(t, map[string]bool{}, map[string]*models.Session{})
+
+	result, err := svc.IntrospectToken(context.Background(), "forged-token")
+	require.NoError(t, err)
+	require.False(t, result.Active)
+	require.Contains(t, result.InactiveReason, "invalid token")
+	require.Empty(t, result.Subject)
+}
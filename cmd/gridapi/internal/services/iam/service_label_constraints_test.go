@@ -0,0 +1,220 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// labelConstraintRepos holds three roles:
+//   - "dev-creator" may create states, constrained to env=dev with team required
+//   - "ops" may create any state and has "env" as an immutable key
+//   - "finance" has "cost-center" as an immutable key
+func labelConstraintRepos() testRepos {
+	return testRepos{roles: &mockRoleRepository{roles: map[string]*models.Role{
+		"role-dev": {
+			ID:   "role-dev",
+			Name: "dev-creator",
+			CreateConstraints: models.CreateConstraints{
+				"env":  {AllowedValues: []string{"dev"}},
+				"team": {Required: true},
+			},
+		},
+		"role-ops": {
+			ID:            "role-ops",
+			Name:          "ops",
+			ImmutableKeys: []string{"env"},
+		},
+		"role-fin": {
+			ID:            "role-fin",
+			Name:          "finance",
+			ImmutableKeys: []string{"cost-center"},
+		},
+	}}}
+}
+
+var labelConstraintPolicies = [][]string{
+	{auth.RoleID("dev-creator"), auth.ObjectTypeState, auth.StateCreate, auth.ScopeAll, "allow"},
+	{auth.RoleID("ops"), auth.ObjectTypeState, auth.StateCreate, auth.ScopeAll, "allow"},
+}
+
+func requireConstraintError(t *testing.T, err error, key, constraint string) {
+	t.Helper()
+
+	var violation *LabelConstraintError
+	require.True(t, errors.As(err, &violation), "expected LabelConstraintError, got %v", err)
+	require.Equal(t, key, violation.Key)
+	require.Equal(t, constraint, violation.Constraint)
+}
+
+func TestCheckCreateConstraints(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, labelConstraintRepos(), labelConstraintPolicies...)
+	ctx := context.Background()
+	dev := &Principal{Roles: []string{"dev-creator"}}
+
+	t.Run("allowed labels pass", func(t *testing.T) {
+		err := svc.CheckCreateConstraints(ctx, dev, map[string]any{"env": "dev", "team": "core"})
+		require.NoError(t, err)
+	})
+
+	t.Run("disallowed value names key", func(t *testing.T) {
+		err := svc.CheckCreateConstraints(ctx, dev, map[string]any{"env": "prod", "team": "core"})
+		requireConstraintError(t, err, "env", ConstraintAllowedValues)
+		require.Contains(t, err.Error(), "dev-creator")
+	})
+
+	t.Run("missing required key", func(t *testing.T) {
+		err := svc.CheckCreateConstraints(ctx, dev, map[string]any{"env": "dev"})
+		requireConstraintError(t, err, "team", ConstraintRequired)
+	})
+
+	t.Run("unconstrained role allows create", func(t *testing.T) {
+		both := &Principal{Roles: []string{"dev-creator", "ops"}}
+		err := svc.CheckCreateConstraints(ctx, both, map[string]any{"env": "prod"})
+		require.NoError(t, err)
+	})
+
+	t.Run("open enforcer breaker fails the check", func(t *testing.T) {
+		svc := newTestService(t, labelConstraintRepos(), labelConstraintPolicies...)
+		for range enforcerBreakerThreshold {
+			svc.enforcerBreaker.record(errors.New("enforcer down"), time.Now())
+		}
+		err := svc.CheckCreateConstraints(ctx, dev, map[string]any{"env": "dev", "team": "core"})
+		require.ErrorIs(t, err, ErrEnforcerCircuitOpen)
+	})
+
+	t.Run("role deleted since authentication is skipped", func(t *testing.T) {
+		svc := newTestService(t, labelConstraintRepos(), labelConstraintPolicies...)
+		require.NoError(t, svc.roles.Delete(ctx, "role-dev"))
+
+		err := svc.CheckCreateConstraints(ctx, dev, map[string]any{"env": "prod"})
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckImmutableKeys(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, labelConstraintRepos(), labelConstraintPolicies...)
+	ctx := context.Background()
+	ops := &Principal{Roles: []string{"ops"}}
+	current := map[string]any{"env": "dev", "team": "core"}
+
+	t.Run("changing other keys passes", func(t *testing.T) {
+		err := svc.CheckImmutableKeys(ctx, ops, current, map[string]any{"team": "platform", "env": "dev"}, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("changing immutable key is rejected", func(t *testing.T) {
+		err := svc.CheckImmutableKeys(ctx, ops, current, map[string]any{"env": "prod"}, nil)
+		requireConstraintError(t, err, "env", ConstraintImmutable)
+	})
+
+	t.Run("removing immutable key is rejected", func(t *testing.T) {
+		err := svc.CheckImmutableKeys(ctx, ops, current, nil, []string{"env"})
+		requireConstraintError(t, err, "env", ConstraintImmutable)
+	})
+
+	t.Run("role deleted since authentication is skipped", func(t *testing.T) {
+		svc := newTestService(t, labelConstraintRepos(), labelConstraintPolicies...)
+		require.NoError(t, svc.roles.Delete(ctx, "role-ops"))
+
+		err := svc.CheckImmutableKeys(ctx, ops, current, map[string]any{"env": "prod"}, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckImmutableKeysUnionAcrossRoles(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, labelConstraintRepos(), labelConstraintPolicies...)
+	ctx := context.Background()
+	both := &Principal{Roles: []string{"ops", "finance"}}
+	current := map[string]any{"env": "dev", "team": "core", "cost-center": "cc-1"}
+
+	err := svc.CheckImmutableKeys(ctx, both, current, map[string]any{"team": "platform"}, nil)
+	require.NoError(t, err)
+
+	err = svc.CheckImmutableKeys(ctx, both, current, map[string]any{"env": "prod"}, nil)
+	requireConstraintError(t, err, "env", ConstraintImmutable)
+
+	err = svc.CheckImmutableKeys(ctx, both, current, map[string]any{"cost-center": "cc-2"}, nil)
+	requireConstraintError(t, err, "cost-center", ConstraintImmutable)
+	require.Contains(t, err.Error(), "finance")
+}
+
+func TestDefaultCreateLabels(t *testing.T) {
+	t.Parallel()
+
+	productEngineer := func(defaultLabels bool) *iamService {
+		return newTestService(t, testRepos{roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-product": {
+				ID:                "role-product",
+				Name:              "product-engineer",
+				ScopeExpr:         `env == "dev"`,
+				CreateConstraints: models.CreateConstraints{"env": {AllowedValues: []string{"dev"}, Required: true}},
+				DefaultLabels:     defaultLabels,
+			},
+			"role-ops": {ID: "role-ops", Name: "ops"},
+		}}},
+			[]string{auth.RoleID("product-engineer"), auth.ObjectTypeState, auth.StateCreate, `env == "dev"`, "allow"},
+			[]string{auth.RoleID("ops"), auth.ObjectTypeState, auth.StateCreate, auth.ScopeAll, "allow"},
+		)
+	}
+	ctx := context.Background()
+	pe := &Principal{Roles: []string{"product-engineer"}}
+
+	t.Run("product-engineer omitting env gets the allowed value", func(t *testing.T) {
+		svc := productEngineer(true)
+		labels := map[string]any{"team": "core"}
+
+		defaults, err := svc.DefaultCreateLabels(ctx, pe, labels)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"env": "dev"}, defaults)
+
+		labels["env"] = defaults["env"]
+		require.NoError(t, svc.CheckCreateConstraints(ctx, pe, labels))
+	})
+
+	t.Run("role without default_labels leaves labels alone", func(t *testing.T) {
+		defaults, err := productEngineer(false).DefaultCreateLabels(ctx, pe, map[string]any{"team": "core"})
+		require.NoError(t, err)
+		require.Nil(t, defaults)
+	})
+
+	t.Run("explicit disallowed value is not overridden", func(t *testing.T) {
+		defaults, err := productEngineer(true).DefaultCreateLabels(ctx, pe, map[string]any{"env": "prod"})
+		require.NoError(t, err)
+		require.Nil(t, defaults)
+	})
+
+	t.Run("labels another role already accepts are left alone", func(t *testing.T) {
+		both := &Principal{Roles: []string{"ops", "product-engineer"}}
+		defaults, err := productEngineer(true).DefaultCreateLabels(ctx, both, map[string]any{"team": "core"})
+		require.NoError(t, err)
+		require.Nil(t, defaults)
+	})
+
+	t.Run("token scoped away from state:create gets no defaults", func(t *testing.T) {
+		readOnly := &Principal{Roles: []string{"product-engineer"}, TokenScopes: []string{auth.StateRead}}
+		defaults, err := productEngineer(true).DefaultCreateLabels(ctx, readOnly, map[string]any{"team": "core"})
+		require.NoError(t, err)
+		require.Nil(t, defaults)
+	})
+
+	t.Run("role deleted since authentication is skipped", func(t *testing.T) {
+		svc := productEngineer(true)
+		require.NoError(t, svc.roles.Delete(ctx, "role-product"))
+
+		defaults, err := svc.DefaultCreateLabels(ctx, pe, map[string]any{"team": "core"})
+		require.NoError(t, err)
+		require.Nil(t, defaults)
+	})
+}
@@ -0,0 +1,160 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// newListSessionsTestService seeds three sessions each for alice and bob,
+// created one minute apart, with bob's oldest session revoked.
+func newListSessionsTestService(t *testing.T, base time.Time) *iamService {
+	t.Helper()
+
+	sessions := &mockSessionRepository{sessions: map[string]*models.Session{}}
+	for i, userID := range []string{"user-alice", "user-bob", "user-alice", "user-bob", "user-alice", "user-bob"} {
+		uid := userID
+		s := &models.Session{
+			ID:           fmt.Sprintf("sess-%d", i),
+			UserID:       &uid,
+			TokenHash:    fmt.Sprintf("hash-%d", i),
+			IDToken:      "id-token",
+			RefreshToken: "refresh-token",
+			CreatedAt:    base.Add(time.Duration(i) * time.Minute),
+			ExpiresAt:    base.Add(24 * time.Hour),
+			Revoked:      i == 1,
+		}
+		sessions.sessions[s.TokenHash] = s
+	}
+
+	return newTestService(t, testRepos{sessions: sessions})
+}
+
+func sessionIDs(sessions []models.Session) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func TestListAllSessions(t *testing.T) {
+	t.Parallel()
+
+	base := time.Now().Add(-time.Hour)
+	svc := newListSessionsTestService(t, base)
+	ctx := context.Background()
+
+	t.Run("pages across users newest first", func(t *testing.T) {
+		first, err := svc.ListAllSessions(ctx, SessionFilter{PageSize: 4})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-5", "sess-4", "sess-3", "sess-2"}, sessionIDs(first))
+
+		second, err := svc.ListAllSessions(ctx, SessionFilter{PageSize: 4, Offset: 4})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-1", "sess-0"}, sessionIDs(second))
+	})
+
+	t.Run("filters by user", func(t *testing.T) {
+		got, err := svc.ListAllSessions(ctx, SessionFilter{UserID: "user-bob"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-5", "sess-3", "sess-1"}, sessionIDs(got))
+	})
+
+	t.Run("active only excludes revoked sessions", func(t *testing.T) {
+		got, err := svc.ListAllSessions(ctx, SessionFilter{UserID: "user-bob", ActiveOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-5", "sess-3"}, sessionIDs(got))
+	})
+
+	t.Run("revoked only returns revoked sessions", func(t *testing.T) {
+		got, err := svc.ListAllSessions(ctx, SessionFilter{RevokedOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-1"}, sessionIDs(got))
+
+		_, err = svc.ListAllSessions(ctx, SessionFilter{ActiveOnly: true, RevokedOnly: true})
+		require.ErrorContains(t, err, "mutually exclusive")
+	})
+
+	t.Run("filters by service account", func(t *testing.T) {
+		saID := "sa-ci"
+		repo := svc.sessions.(*mockSessionRepository)
+		repo.sessions["hash-sa"] = &models.Session{ID: "sess-sa", ServiceAccountID: &saID, TokenHash: "hash-sa", CreatedAt: base, ExpiresAt: base.Add(time.Hour)}
+		defer delete(repo.sessions, "hash-sa")
+
+		got, err := svc.ListAllSessions(ctx, SessionFilter{ServiceAccountID: saID})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-sa"}, sessionIDs(got))
+	})
+
+	t.Run("filters by creation time", func(t *testing.T) {
+		cutoff := base.Add(3 * time.Minute)
+		got, err := svc.ListAllSessions(ctx, SessionFilter{CreatedAfter: &cutoff})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-5", "sess-4"}, sessionIDs(got))
+	})
+
+	t.Run("strips tokens", func(t *testing.T) {
+		got, err := svc.ListAllSessions(ctx, SessionFilter{})
+		require.NoError(t, err)
+		require.Len(t, got, 6)
+		for _, s := range got {
+			require.Empty(t, s.TokenHash)
+			require.Empty(t, s.IDToken)
+			require.Empty(t, s.RefreshToken)
+		}
+	})
+
+	t.Run("rejects negative paging", func(t *testing.T) {
+		_, err := svc.ListAllSessions(ctx, SessionFilter{Offset: -1})
+		require.ErrorContains(t, err, "invalid paging")
+	})
+}
+
+func TestRevokeSessions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("revokes sessions created before cutoff", func(t *testing.T) {
+		base := time.Now().Add(-time.Hour)
+		svc := newListSessionsTestService(t, base)
+
+		cutoff := base.Add(3 * time.Minute)
+		count, err := svc.RevokeSessions(ctx, SessionFilter{CreatedBefore: &cutoff})
+		require.NoError(t, err)
+		// sess-1 was already revoked, so only sess-0 and sess-2 are counted
+		require.Equal(t, 2, count)
+
+		active, err := svc.ListAllSessions(ctx, SessionFilter{ActiveOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-5", "sess-4", "sess-3"}, sessionIDs(active))
+	})
+
+	t.Run("scopes to one principal", func(t *testing.T) {
+		svc := newListSessionsTestService(t, time.Now().Add(-time.Hour))
+
+		count, err := svc.RevokeSessions(ctx, SessionFilter{UserID: "user-alice"})
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		active, err := svc.ListAllSessions(ctx, SessionFilter{ActiveOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"sess-5", "sess-3"}, sessionIDs(active))
+	})
+
+	t.Run("rejects empty filter", func(t *testing.T) {
+		svc := newListSessionsTestService(t, time.Now().Add(-time.Hour))
+
+		_, err := svc.RevokeSessions(ctx, SessionFilter{})
+		require.ErrorContains(t, err, "invalid session filter")
+
+		active, err := svc.ListAllSessions(ctx, SessionFilter{ActiveOnly: true})
+		require.NoError(t, err)
+		require.Len(t, active, 5)
+	})
+}
@@ -0,0 +1,81 @@
+package iam
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// mergeRepos seeds an internal and an external account for alice and three
+// unassigned roles.
+func mergeRepos() testRepos {
+	return testRepos{
+		users: &mockUserRepository{users: map[string]*models.User{
+			"internal": {ID: "user-internal", Email: "alice@example.com"},
+			"ext|123":  {ID: "user-external", Email: "alice@idp.example.com", Subject: strPtr("ext|123")},
+		}},
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-reader":   {ID: "role-reader", Name: "reader"},
+			"role-writer":   {ID: "role-writer", Name: "writer"},
+			"role-approver": {ID: "role-approver", Name: "approver"},
+		}},
+	}
+}
+
+func TestMergeUsersUnionsRoles(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, mergeRepos())
+	ctx := context.Background()
+
+	// Primary: reader, writer. Duplicate: writer (overlapping), approver (distinct).
+	require.NoError(t, svc.AssignUserRole(ctx, "user-internal", "", "role-reader"))
+	require.NoError(t, svc.AssignUserRole(ctx, "user-internal", "", "role-writer"))
+	require.NoError(t, svc.AssignUserRole(ctx, "user-external", "", "role-writer"))
+	require.NoError(t, svc.AssignUserRole(ctx, "user-external", "", "role-approver"))
+
+	require.NoError(t, svc.MergeUsers(ctx, "user-internal", "user-external"))
+
+	// Database: primary holds the union once each, duplicate is gone
+	primaryRoles, err := svc.userRoles.GetByUserID(ctx, "user-internal")
+	require.NoError(t, err)
+	roleIDs := make([]string, 0, len(primaryRoles))
+	for _, ur := range primaryRoles {
+		roleIDs = append(roleIDs, ur.RoleID)
+	}
+	sort.Strings(roleIDs)
+	require.Equal(t, []string{"role-approver", "role-reader", "role-writer"}, roleIDs)
+
+	duplicateRoles, err := svc.userRoles.GetByUserID(ctx, "user-external")
+	require.NoError(t, err)
+	require.Empty(t, duplicateRoles)
+
+	_, err = svc.users.GetByID(ctx, "user-external")
+	require.Error(t, err)
+
+	// Casbin: groupings follow the database
+	casbinRoles, err := svc.enforcer.GetRolesForUser(auth.UserID("user-internal"))
+	require.NoError(t, err)
+	sort.Strings(casbinRoles)
+	require.Equal(t, []string{auth.RoleID("approver"), auth.RoleID("reader"), auth.RoleID("writer")}, casbinRoles)
+
+	duplicateCasbinRoles, err := svc.enforcer.GetRolesForUser(auth.UserID("ext|123"))
+	require.NoError(t, err)
+	require.Empty(t, duplicateCasbinRoles)
+}
+
+func TestMergeUsersRejectsSelfMerge(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, mergeRepos())
+
+	err := svc.MergeUsers(context.Background(), "user-internal", "user-internal")
+	require.ErrorContains(t, err, "into itself")
+
+	_, err = svc.users.GetByID(context.Background(), "user-internal")
+	require.NoError(t, err)
+}
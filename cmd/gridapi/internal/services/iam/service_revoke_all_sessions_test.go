@@ -0,0 +1,158 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// recordingRefreshTokenRevoker records the refresh tokens it was asked to drop.
+type recordingRefreshTokenRevoker struct {
+	revoked []string
+}
+
+func (r *recordingRefreshTokenRevoker) RevokeRefreshTokens(ctx context.Context, tokens []string) int {
+	r.revoked = append(r.revoked, tokens...)
+	return len(tokens)
+}
+
+// newRevokeAllSessionsTestService seeds two sessions each for alice and the
+// ci service account plus one for bob. alice's first session is already
+// revoked (logged out) but still holds a refresh token.
+func newRevokeAllSessionsTestService(t *testing.T) (*iamService, *mockSessionRepository, *recordingRefreshTokenRevoker) {
+	t.Helper()
+
+	alice, bob, ci := "user-alice", "user-bob", "sa-ci"
+	users := &mockUserRepository{users: map[string]*models.User{
+		"alice@example.com": {ID: alice, Email: "alice@example.com"},
+		"bob@example.com":   {ID: bob, Email: "bob@example.com"},
+	}}
+	serviceAccounts := &mockServiceAccountRepository{accounts: map[string]*models.ServiceAccount{
+		"ci-client": {ID: ci, ClientID: "ci-client", Name: "ci"},
+	}}
+
+	expires := time.Now().Add(time.Hour)
+	sessions := &mockSessionRepository{sessions: map[string]*models.Session{}}
+	for _, s := range []*models.Session{
+		{ID: "alice-1", UserID: &alice, RefreshToken: "rt-alice-1", Revoked: true},
+		{ID: "alice-2", UserID: &alice, RefreshToken: "rt-alice-2"},
+		{ID: "bob-1", UserID: &bob, RefreshToken: "rt-bob-1"},
+		{ID: "ci-1", ServiceAccountID: &ci},
+		{ID: "ci-2", ServiceAccountID: &ci},
+	} {
+		s.TokenHash = "hash-" + s.ID
+		s.ExpiresAt = expires
+		sessions.sessions[s.TokenHash] = s
+	}
+
+	revoker := &recordingRefreshTokenRevoker{}
+	svc := newTestService(t, testRepos{users: users, serviceAccounts: serviceAccounts, sessions: sessions})
+	svc.refreshTokens = revoker
+	return svc, sessions, revoker
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("user", func(t *testing.T) {
+		svc, sessions, revoker := newRevokeAllSessionsTestService(t)
+
+		count, err := svc.RevokeAllSessions(ctx, "user-alice", "user")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count, "only the still-active session counts")
+		assert.True(t, sessions.sessions["hash-alice-2"].Revoked)
+		assert.False(t, sessions.sessions["hash-bob-1"].Revoked)
+		assert.False(t, sessions.sessions["hash-ci-1"].Revoked)
+		assert.ElementsMatch(t, []string{"rt-alice-1", "rt-alice-2"}, revoker.revoked)
+	})
+
+	t.Run("service account", func(t *testing.T) {
+		svc, sessions, revoker := newRevokeAllSessionsTestService(t)
+
+		count, err := svc.RevokeAllSessions(ctx, "sa-ci", "service_account")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.True(t, sessions.sessions["hash-ci-1"].Revoked)
+		assert.True(t, sessions.sessions["hash-ci-2"].Revoked)
+		assert.False(t, sessions.sessions["hash-alice-2"].Revoked)
+		assert.Empty(t, revoker.revoked, "client credential sessions hold no refresh tokens")
+	})
+
+	t.Run("access tokens are denylisted", func(t *testing.T) {
+		svc, sessions, _ := newRevokeAllSessionsTestService(t)
+		for _, session := range sessions.sessions {
+			session.JTI = "jti-" + session.ID
+		}
+		revokedJTIs := &mockRevokedJTIRepository{revokedJTIs: map[string]bool{}}
+		svc.revokedJTIs = revokedJTIs
+
+		_, err := svc.RevokeAllSessions(ctx, "sa-ci", "service_account")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"jti-ci-1": true, "jti-ci-2": true}, revokedJTIs.revokedJTIs)
+	})
+
+	t.Run("unknown principal", func(t *testing.T) {
+		svc, sessions, _ := newRevokeAllSessionsTestService(t)
+
+		_, err := svc.RevokeAllSessions(ctx, "user-carol", "user")
+		assert.ErrorContains(t, err, "not found")
+		_, err = svc.RevokeAllSessions(ctx, "user-alice", "group")
+		assert.ErrorContains(t, err, "invalid principal type")
+		assert.False(t, sessions.sessions["hash-alice-2"].Revoked)
+	})
+}
+
+// TestRevokeSession_CLISession revokes an internal IdP session, as the
+// self-service session endpoints do, and checks that its refresh token and
+// access token die with it.
+func TestRevokeSession_CLISession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	newService := func() (*iamService, *mockSessionRepository, *recordingRefreshTokenRevoker, *mockRevokedJTIRepository) {
+		svc, sessions, revoker := newRevokeAllSessionsTestService(t)
+		for _, session := range sessions.sessions {
+			session.JTI = "jti-" + session.ID
+		}
+		revokedJTIs := &mockRevokedJTIRepository{revokedJTIs: map[string]bool{}}
+		svc.revokedJTIs = revokedJTIs
+		return svc, sessions, revoker, revokedJTIs
+	}
+
+	t.Run("one session", func(t *testing.T) {
+		svc, sessions, revoker, revokedJTIs := newService()
+
+		require.NoError(t, svc.RevokeSession(ctx, "alice-2"))
+		assert.True(t, sessions.sessions["hash-alice-2"].Revoked)
+		assert.Equal(t, []string{"rt-alice-2"}, revoker.revoked)
+		assert.Equal(t, map[string]bool{"jti-alice-2": true}, revokedJTIs.revokedJTIs)
+	})
+
+	t.Run("all other sessions", func(t *testing.T) {
+		svc, sessions, revoker, revokedJTIs := newService()
+		sessions.sessions["hash-alice-3"] = &models.Session{
+			ID: "alice-3", UserID: sessions.sessions["hash-alice-2"].UserID, TokenHash: "hash-alice-3",
+			JTI: "jti-alice-3", RefreshToken: "rt-alice-3", ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		count, err := svc.RevokeSessions(ctx, SessionFilter{UserID: "user-alice", ActiveOnly: true, ExcludeID: "alice-3"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.False(t, sessions.sessions["hash-alice-3"].Revoked, "the current session is kept")
+		assert.Equal(t, []string{"rt-alice-2"}, revoker.revoked)
+		assert.Equal(t, map[string]bool{"jti-alice-2": true}, revokedJTIs.revokedJTIs)
+	})
+
+	t.Run("expired access token is not denylisted", func(t *testing.T) {
+		svc, sessions, _, revokedJTIs := newService()
+		sessions.sessions["hash-bob-1"].ExpiresAt = time.Now().Add(-time.Minute)
+
+		require.NoError(t, svc.RevokeSession(ctx, "bob-1"))
+		assert.Empty(t, revokedJTIs.revokedJTIs)
+	})
+}
@@ -0,0 +1,136 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// fixedGroupSizes reports canned IdP group sizes.
+type fixedGroupSizes map[string]int
+
+func (f fixedGroupSizes) EstimateGroupSize(ctx context.Context, groupName string) (int, error) {
+	size, ok := f[groupName]
+	if !ok {
+		return 0, fmt.Errorf("unknown group %s", groupName)
+	}
+	return size, nil
+}
+
+// roleCapRepos seeds three users and an "org-admin" role capped at two
+// principals.
+func roleCapRepos() testRepos {
+	maxAssignments := 2
+	users := &mockUserRepository{users: map[string]*models.User{}}
+	for _, name := range []string{"alice", "bob", "carol"} {
+		users.users[name] = &models.User{ID: "user-" + name, Subject: strPtr(name)}
+	}
+	return testRepos{
+		users: users,
+		roles: &mockRoleRepository{roles: map[string]*models.Role{
+			"role-admin": {ID: "role-admin", Name: "org-admin", MaxAssignments: &maxAssignments},
+		}},
+	}
+}
+
+func TestAssignUserRoleWithinCap(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, roleCapRepos())
+	ctx := context.Background()
+
+	require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-admin"))
+	require.NoError(t, svc.AssignUserRole(ctx, "user-bob", "", "role-admin"))
+}
+
+func TestAssignUserRoleExceedingCap(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(t, roleCapRepos())
+	ctx := context.Background()
+
+	require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-admin"))
+	require.NoError(t, svc.AssignUserRole(ctx, "user-bob", "", "role-admin"))
+
+	err := svc.AssignUserRole(ctx, "user-carol", "", "role-admin")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `role "org-admin" assignment cap exceeded`)
+	require.ErrorIs(t, err, ErrAssignmentCapExceeded)
+
+	assigned, err := svc.userRoles.GetByRoleID(ctx, "role-admin")
+	require.NoError(t, err)
+	require.Len(t, assigned, 2)
+}
+
+func TestAssignGroupRoleCap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("group within cap succeeds", func(t *testing.T) {
+		svc := newTestService(t, roleCapRepos())
+		svc.groupSizes = fixedGroupSizes{"admins": 1}
+		require.NoError(t, svc.AssignUserRole(ctx, "user-alice", "", "role-admin"))
+		require.NoError(t, svc.AssignGroupRole(ctx, "admins", "role-admin", ""))
+	})
+
+	t.Run("group estimated size exceeding cap is rejected", func(t *testing.T) {
+		svc := newTestService(t, roleCapRepos())
+		svc.groupSizes = fixedGroupSizes{"platform": 5}
+		err := svc.AssignGroupRole(ctx, "platform", "role-admin", "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "assignment cap exceeded")
+
+		mapped, err := svc.groupRoles.GetByRoleID(ctx, "role-admin")
+		require.NoError(t, err)
+		require.Empty(t, mapped)
+	})
+
+	t.Run("mapped group counts toward direct assignments", func(t *testing.T) {
+		svc := newTestService(t, roleCapRepos())
+		svc.groupSizes = fixedGroupSizes{"admins": 2}
+		require.NoError(t, svc.AssignGroupRole(ctx, "admins", "role-admin", ""))
+
+		err := svc.AssignUserRole(ctx, "user-alice", "", "role-admin")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "assignment cap exceeded")
+	})
+
+	t.Run("group without estimator is rejected", func(t *testing.T) {
+		svc := newTestService(t, roleCapRepos())
+		err := svc.AssignGroupRole(ctx, "admins", "role-admin", "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "assignment cap")
+		require.ErrorIs(t, err, ErrGroupSizeUnknown)
+	})
+}
+
+func TestUserGroupSizeEstimator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	members := staticUserGroups{
+		"user-alice": {"admins", "platform"},
+		"user-bob":   {"platform"},
+		"user-carol": {"platform"},
+	}
+	svc := newTestService(t, roleCapRepos())
+	svc.groupSizes = NewUserGroupSizeEstimator(members)
+
+	require.NoError(t, svc.AssignGroupRole(ctx, "admins", "role-admin", ""))
+	err := svc.AssignGroupRole(ctx, "platform", "role-admin", "")
+	require.ErrorIs(t, err, ErrAssignmentCapExceeded)
+	require.Contains(t, err.Error(), "1 principal(s) assigned, 3 more")
+}
+
+func TestValidateMaxAssignments(t *testing.T) {
+	t.Parallel()
+
+	zero, one := 0, 1
+	require.NoError(t, validateMaxAssignments(nil))
+	require.NoError(t, validateMaxAssignments(&one))
+	require.ErrorContains(t, validateMaxAssignments(&zero), "invalid max_assignments")
+}
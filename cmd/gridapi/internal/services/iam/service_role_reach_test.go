@@ -0,0 +1,92 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// fakeStateCounter evaluates filters against an in-memory set of state labels.
+type fakeStateCounter struct {
+	labels  []map[string]any
+	filters []string
+}
+
+func (f *fakeStateCounter) CountMatching(ctx context.Context, filter string) (int, error) {
+	f.filters = append(f.filters, filter)
+	if filter == "" {
+		return len(f.labels), nil
+	}
+	count := 0
+	for _, labels := range f.labels {
+		if auth.EvaluateBexpr(filter, labels) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestCountStatesForRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newService := func(scopes map[string]string) (*iamService, *fakeStateCounter) {
+		roles := make(map[string]*models.Role, len(scopes))
+		for name, scope := range scopes {
+			roles["role-"+name] = &models.Role{ID: "role-" + name, Name: name, ScopeExpr: scope}
+		}
+		counter := &fakeStateCounter{labels: []map[string]any{
+			{"env": "dev", "team": "core"},
+			{"env": "dev", "team": "platform"},
+			{"env": "prod", "team": "core"},
+			{"env": "staging"},
+			{},
+		}}
+		return &iamService{roles: &mockRoleRepository{roles: roles}, states: counter}, counter
+	}
+
+	t.Run("counts states matching the scope expression", func(t *testing.T) {
+		svc, counter := newService(map[string]string{
+			"dev":      `env == "dev"`,
+			"core":     `team == "core"`,
+			"dev-core": `env == "dev" and team == "core"`,
+		})
+
+		for name, want := range map[string]int{"dev": 2, "core": 2, "dev-core": 1} {
+			count, err := svc.CountStatesForRole(ctx, name)
+			require.NoError(t, err)
+			require.Equal(t, want, count, name)
+		}
+		require.NotContains(t, counter.filters, "")
+	})
+
+	t.Run("match-all scope counts every state in SQL", func(t *testing.T) {
+		svc, counter := newService(map[string]string{"admin": auth.ScopeAll})
+
+		count, err := svc.CountStatesForRole(ctx, "admin")
+		require.NoError(t, err)
+		require.Equal(t, 5, count)
+		require.Equal(t, []string{""}, counter.filters)
+	})
+
+	t.Run("empty and deny-all scopes reach nothing", func(t *testing.T) {
+		svc, counter := newService(map[string]string{"empty": "", "none": auth.ScopeNone})
+
+		for _, name := range []string{"empty", "none"} {
+			count, err := svc.CountStatesForRole(ctx, name)
+			require.NoError(t, err)
+			require.Zero(t, count, name)
+		}
+		require.Empty(t, counter.filters)
+	})
+
+	t.Run("requires a state counter", func(t *testing.T) {
+		svc := &iamService{roles: &mockRoleRepository{roles: map[string]*models.Role{}}}
+
+		_, err := svc.CountStatesForRole(ctx, "dev")
+		require.ErrorContains(t, err, "state counter not configured")
+	})
+}
@@ -0,0 +1,381 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	casbinbunadapter "github.com/terraconstructs/grid/cmd/gridapi/internal/auth/bunadapter"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/config"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+// newRoleScopeTestService builds an iamService whose alice user holds the
+// "ops" role once it is created.
+func newRoleScopeTestService(t *testing.T, emptyRoleScope string) *iamService {
+	t.Helper()
+
+	svc := newTestService(t, testRepos{})
+	svc.emptyRoleScope = emptyRoleScope
+	_, err := svc.enforcer.AddRoleForUser(auth.UserID("alice"), auth.RoleID("ops"))
+	require.NoError(t, err)
+	return svc
+}
+
+// canReadState reports whether alice may read a state with the given labels.
+func canReadState(t *testing.T, svc *iamService, labels map[string]any) bool {
+	t.Helper()
+
+	allowed, err := svc.enforcer.Enforce(auth.UserID("alice"), auth.ObjectTypeState, "read", labels)
+	require.NoError(t, err)
+	return allowed
+}
+
+func TestCreateRoleEmptyScopePolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	prodLabels := map[string]any{"env": "prod"}
+
+	t.Run("allow stores an explicit match-all scope", func(t *testing.T) {
+		svc := newRoleScopeTestService(t, config.EmptyRoleScopeAllow)
+
+		role, err := svc.CreateRole(ctx, "ops", "", "", nil, nil, nil, nil, []string{"state:read"}, false)
+		require.NoError(t, err)
+		require.Equal(t, auth.ScopeAll, role.ScopeExpr)
+		require.True(t, canReadState(t, svc, prodLabels))
+	})
+
+	t.Run("unset policy behaves like allow", func(t *testing.T) {
+		svc := newRoleScopeTestService(t, "")
+
+		_, err := svc.CreateRole(ctx, "ops", "", "", nil, nil, nil, nil, []string{"state:read"}, false)
+		require.NoError(t, err)
+		require.True(t, canReadState(t, svc, prodLabels))
+	})
+
+	t.Run("reject refuses empty scope", func(t *testing.T) {
+		svc := newRoleScopeTestService(t, config.EmptyRoleScopeReject)
+
+		_, err := svc.CreateRole(ctx, "ops", "", "  ", nil, nil, nil, nil, []string{"state:read"}, false)
+		require.ErrorContains(t, err, "invalid label_scope_expr")
+
+		roles, err := svc.roles.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, roles)
+		require.False(t, canReadState(t, svc, prodLabels))
+	})
+
+	t.Run("reject accepts explicit match-all", func(t *testing.T) {
+		svc := newRoleScopeTestService(t, config.EmptyRoleScopeReject)
+
+		role, err := svc.CreateRole(ctx, "ops", "", auth.ScopeAll, nil, nil, nil, nil, []string{"state:read"}, false)
+		require.NoError(t, err)
+		require.Equal(t, auth.ScopeAll, role.ScopeExpr)
+		require.True(t, canReadState(t, svc, prodLabels))
+	})
+
+	t.Run("deny stores a deny-all scope", func(t *testing.T) {
+		svc := newRoleScopeTestService(t, config.EmptyRoleScopeDeny)
+
+		role, err := svc.CreateRole(ctx, "ops", "", "", nil, nil, nil, nil, []string{"state:read"}, false)
+		require.NoError(t, err)
+		require.Equal(t, auth.ScopeNone, role.ScopeExpr)
+		require.False(t, canReadState(t, svc, prodLabels))
+		require.False(t, canReadState(t, svc, map[string]any{}))
+	})
+
+	t.Run("explicit scopes are unaffected by policy", func(t *testing.T) {
+		svc := newRoleScopeTestService(t, config.EmptyRoleScopeDeny)
+
+		_, err := svc.CreateRole(ctx, "ops", "", `env == "dev"`, nil, nil, nil, nil, []string{"state:read"}, false)
+		require.NoError(t, err)
+		require.True(t, canReadState(t, svc, map[string]any{"env": "dev"}))
+		require.False(t, canReadState(t, svc, prodLabels))
+	})
+}
+
+// newLegacyScopeTestService builds an iamService holding roles saved before
+// empty scopes stopped matching every state:
+//   - legacy-admin (alice): empty scope, state read
+//   - unscoped (bob): explicit auth.ScopeAll, state read
+//   - dev (carol): env == "dev" read, plus an unscoped state list grant
+func newLegacyScopeTestService(t *testing.T, emptyRoleScope string) *iamService {
+	t.Helper()
+
+	svc := newTestService(t, testRepos{roles: &mockRoleRepository{roles: map[string]*models.Role{
+		"role-legacy":   {ID: "role-legacy", Name: "legacy-admin"},
+		"role-unscoped": {ID: "role-unscoped", Name: "unscoped", ScopeExpr: auth.ScopeAll},
+		"role-dev":      {ID: "role-dev", Name: "dev", ScopeExpr: `env == "dev"`},
+	}}},
+		[]string{auth.RoleID("legacy-admin"), auth.ObjectTypeState, "read", "", "allow"},
+		[]string{auth.RoleID("unscoped"), auth.ObjectTypeState, "read", auth.ScopeAll, "allow"},
+		[]string{auth.RoleID("dev"), auth.ObjectTypeState, "read", `env == "dev"`, "allow"},
+		[]string{auth.RoleID("dev"), auth.ObjectTypeState, "list", "", "allow"},
+	)
+	svc.emptyRoleScope = emptyRoleScope
+	for user, role := range map[string]string{"alice": "legacy-admin", "bob": "unscoped", "carol": "dev"} {
+		_, err := svc.enforcer.AddRoleForUser(auth.UserID(user), auth.RoleID(role))
+		require.NoError(t, err)
+	}
+	return svc
+}
+
+func enforceAs(t *testing.T, svc *iamService, user, act string, labels map[string]any) bool {
+	t.Helper()
+
+	allowed, err := svc.enforcer.Enforce(auth.UserID(user), auth.ObjectTypeState, act, labels)
+	require.NoError(t, err)
+	return allowed
+}
+
+func TestMigrateEmptyRoleScopes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	prodLabels := map[string]any{"env": "prod"}
+
+	t.Run("empty scope no longer matches before migration", func(t *testing.T) {
+		svc := newLegacyScopeTestService(t, config.EmptyRoleScopeAllow)
+
+		require.False(t, enforceAs(t, svc, "alice", "read", prodLabels))
+		require.True(t, enforceAs(t, svc, "bob", "read", prodLabels))
+	})
+
+	t.Run("allow rewrites empty scopes to match-all", func(t *testing.T) {
+		svc := newLegacyScopeTestService(t, config.EmptyRoleScopeAllow)
+
+		migrated, err := svc.MigrateEmptyRoleScopes(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, migrated)
+
+		role, err := svc.roles.GetByName(ctx, "legacy-admin")
+		require.NoError(t, err)
+		require.Equal(t, auth.ScopeAll, role.ScopeExpr)
+		require.True(t, enforceAs(t, svc, "alice", "read", prodLabels))
+		require.True(t, enforceAs(t, svc, "bob", "read", prodLabels))
+	})
+
+	t.Run("deny rewrites empty scopes to match-none", func(t *testing.T) {
+		svc := newLegacyScopeTestService(t, config.EmptyRoleScopeDeny)
+
+		migrated, err := svc.MigrateEmptyRoleScopes(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, migrated)
+
+		role, err := svc.roles.GetByName(ctx, "legacy-admin")
+		require.NoError(t, err)
+		require.Equal(t, auth.ScopeNone, role.ScopeExpr)
+		require.False(t, enforceAs(t, svc, "alice", "read", prodLabels))
+		require.False(t, enforceAs(t, svc, "alice", "read", map[string]any{}))
+
+		// The explicitly unscoped role still sees everything
+		require.True(t, enforceAs(t, svc, "bob", "read", prodLabels))
+	})
+
+	t.Run("unscoped grants on scoped roles become match-all", func(t *testing.T) {
+		svc := newLegacyScopeTestService(t, config.EmptyRoleScopeDeny)
+
+		_, err := svc.MigrateEmptyRoleScopes(ctx)
+		require.NoError(t, err)
+
+		require.True(t, enforceAs(t, svc, "carol", "list", prodLabels))
+		require.True(t, enforceAs(t, svc, "carol", "read", map[string]any{"env": "dev"}))
+		require.False(t, enforceAs(t, svc, "carol", "read", prodLabels))
+	})
+
+	t.Run("stored scoped policies are left alone", func(t *testing.T) {
+		db := dbtest.NewSQLite(t)
+
+		enforcer, err := auth.InitEnforcer(db)
+		require.NoError(t, err)
+		_, err = enforcer.AddPolicies([][]string{
+			{auth.RoleID("dev"), auth.ObjectTypeState, "list", "", "allow"},
+			{auth.RoleID("dev"), auth.ObjectTypeState, "list", `env == "dev"`, "allow"},
+		})
+		require.NoError(t, err)
+		svc := &iamService{
+			roles: &mockRoleRepository{roles: map[string]*models.Role{
+				"role-dev": {ID: "role-dev", Name: "dev", ScopeExpr: `env == "dev"`},
+			}},
+			enforcer:       enforcer,
+			emptyRoleScope: config.EmptyRoleScopeDeny,
+		}
+
+		_, err = svc.MigrateEmptyRoleScopes(ctx)
+		require.NoError(t, err)
+
+		var scopes []string
+		require.NoError(t, db.NewSelect().Model((*casbinbunadapter.CasbinRule)(nil)).
+			Column("v3").Where("v0 = ?", auth.RoleID("dev")).Order("v3").Scan(ctx, &scopes))
+		require.Equal(t, []string{auth.ScopeAll, `env == "dev"`}, scopes)
+	})
+
+	t.Run("second run is a no-op", func(t *testing.T) {
+		svc := newLegacyScopeTestService(t, config.EmptyRoleScopeAllow)
+
+		_, err := svc.MigrateEmptyRoleScopes(ctx)
+		require.NoError(t, err)
+		migrated, err := svc.MigrateEmptyRoleScopes(ctx)
+		require.NoError(t, err)
+		require.Zero(t, migrated)
+	})
+}
+
+func TestCreateRoleRejectsMalformedActions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := newRoleScopeTestService(t, config.EmptyRoleScopeAllow)
+
+	_, err := svc.CreateRole(ctx, "ops", "", "", nil, nil, nil, nil, []string{"state:read", "bogus", "state:write", ":read"}, false)
+	require.ErrorContains(t, err, "invalid actions")
+	require.ErrorContains(t, err, `"bogus"`)
+	require.ErrorContains(t, err, `":read"`)
+	require.NotContains(t, err.Error(), `"state:read"`)
+
+	// Nothing was written to the database or Casbin
+	roles, err := svc.roles.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+	require.False(t, canReadState(t, svc, map[string]any{"env": "prod"}))
+}
+
+func TestUpdateRoleRejectsMalformedActions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := newRoleScopeTestService(t, config.EmptyRoleScopeAllow)
+
+	role, err := svc.CreateRole(ctx, "ops", "", "", nil, nil, nil, nil, []string{"state:read"}, false)
+	require.NoError(t, err)
+
+	_, err = svc.UpdateRole(ctx, "ops", role.Version, "", "", nil, nil, nil, nil, []string{"bogus"}, false)
+	require.ErrorContains(t, err, `invalid actions: "bogus"`)
+
+	// The original permissions are untouched
+	require.True(t, canReadState(t, svc, map[string]any{"env": "prod"}))
+}
+
+func TestGetRoleActionsRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := newRoleScopeTestService(t, config.EmptyRoleScopeAllow)
+
+	actions := []string{"state:read", "state:tfstate:read", "admin:audit-read"}
+	_, err := svc.CreateRole(ctx, "ops", "", "env == \"prod\"", nil, nil, nil, nil, actions, false)
+	require.NoError(t, err)
+
+	got, err := svc.GetRoleActions(ctx, "ops")
+	require.NoError(t, err)
+	require.Equal(t, []RoleAction{
+		{Action: "state:read", ScopeExpr: `env == "prod"`, Effect: auth.EffectAllow},
+		{Action: "state:tfstate:read", ScopeExpr: `env == "prod"`, Effect: auth.EffectAllow},
+		{Action: "admin:audit-read", ScopeExpr: `env == "prod"`, Effect: auth.EffectAllow},
+	}, got)
+
+	_, err = svc.GetRoleActions(ctx, "missing")
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestCreateRoleDenyActions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := newRoleScopeTestService(t, config.EmptyRoleScopeAllow)
+
+	_, err := svc.CreateRole(ctx, "platform-engineer", "", auth.ScopeAll, nil, nil, nil, nil, []string{"state:*"}, false)
+	require.NoError(t, err)
+	_, err = svc.CreateRole(ctx, "pci-guard", "", `compliance == "pci"`, nil, nil, nil, nil, []string{"!state:tfstate:write"}, false)
+	require.NoError(t, err)
+
+	got, err := svc.GetRoleActions(ctx, "pci-guard")
+	require.NoError(t, err)
+	require.Equal(t, []RoleAction{
+		{Action: "!state:tfstate:write", ScopeExpr: `compliance == "pci"`, Effect: auth.EffectDeny},
+	}, got)
+
+	roles := []string{"platform-engineer", "pci-guard"}
+	authorize := func(act string, labels map[string]any) bool {
+		allowed, err := AuthorizeWithRoles(svc.enforcer, roles, auth.ObjectTypeState, act, labels, ScopeUnion, nil)
+		require.NoError(t, err)
+		return allowed
+	}
+	require.False(t, authorize(auth.TfstateWrite, map[string]any{"compliance": "pci"}))
+	require.True(t, authorize(auth.TfstateWrite, map[string]any{"compliance": "sox"}))
+	require.True(t, authorize(auth.TfstateRead, map[string]any{"compliance": "pci"}))
+
+	// A deny grants nothing, so it cannot back an owner action
+	_, err = svc.CreateRole(ctx, "owner-deny", "", auth.ScopeAll, nil, nil, nil, []string{auth.TfstateWrite}, []string{"!state:tfstate:write"}, false)
+	require.ErrorContains(t, err, "invalid owner_actions")
+}
+
+func TestCloneRoleOverridesScope(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := newRoleScopeTestService(t, config.EmptyRoleScopeAllow)
+
+	maxAssignments := 5
+	constraints := models.CreateConstraints{"team": {AllowedValues: []string{"payments"}, Required: true}}
+	source, err := svc.CreateRole(ctx, "team-payments", "Payments team", `team == "payments"`,
+		constraints, []string{"team"}, &maxAssignments, []string{auth.TfstateRead},
+		[]string{"state:read", "state:tfstate:read", "!state:tfstate:write"}, true)
+	require.NoError(t, err)
+
+	scope := `team == "search"`
+	clone, err := svc.CloneRole(ctx, "team-payments", "team-search", &scope)
+	require.NoError(t, err)
+	require.Equal(t, "team-search", clone.Name)
+	require.Equal(t, scope, clone.ScopeExpr)
+	require.Equal(t, source.Description, clone.Description)
+	require.Equal(t, source.CreateConstraints, clone.CreateConstraints)
+	require.Equal(t, source.ImmutableKeys, clone.ImmutableKeys)
+	require.Equal(t, source.OwnerActions, clone.OwnerActions)
+	require.Equal(t, source.MaxAssignments, clone.MaxAssignments)
+	require.True(t, clone.DefaultLabels)
+
+	got, err := svc.GetRoleActions(ctx, "team-search")
+	require.NoError(t, err)
+	require.Equal(t, []RoleAction{
+		{Action: "state:read", ScopeExpr: scope, Effect: auth.EffectAllow},
+		{Action: "state:tfstate:read", ScopeExpr: scope, Effect: auth.EffectAllow},
+		{Action: "!state:tfstate:write", ScopeExpr: scope, Effect: auth.EffectDeny},
+	}, got)
+
+	// The source role keeps its own scope
+	sourceActions, err := svc.GetRoleActions(ctx, "team-payments")
+	require.NoError(t, err)
+	for _, ra := range sourceActions {
+		require.Equal(t, `team == "payments"`, ra.ScopeExpr)
+	}
+
+	// Without an override the scope is copied; invalid scopes fail like CreateRole
+	plain, err := svc.CloneRole(ctx, "team-payments", "team-payments-copy", nil)
+	require.NoError(t, err)
+	require.Equal(t, source.ScopeExpr, plain.ScopeExpr)
+
+	bad := "team =="
+	_, err = svc.CloneRole(ctx, "team-payments", "team-broken", &bad)
+	require.Error(t, err)
+
+	_, err = svc.CloneRole(ctx, "missing", "team-other", nil)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestGetEffectivePermissions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	svc := newRoleScopeTestService(t, config.EmptyRoleScopeAllow)
+
+	_, err := svc.CreateRole(ctx, "ops", "", `env == "prod"`, nil, nil, nil, nil, []string{"state:read", "!state:tfstate:write"}, false)
+	require.NoError(t, err)
+	_, err = svc.CreateRole(ctx, "viewer", "", auth.ScopeAll, nil, nil, nil, nil, []string{"state:list"}, false)
+	require.NoError(t, err)
+
+	got, err := svc.GetEffectivePermissions(ctx, []string{"ops", "viewer", "deleted-role"})
+	require.NoError(t, err)
+	require.Equal(t, []EffectivePermission{
+		{Role: "ops", RoleAction: RoleAction{Action: "state:read", ScopeExpr: `env == "prod"`, Effect: auth.EffectAllow}},
+		{Role: "ops", RoleAction: RoleAction{Action: "!state:tfstate:write", ScopeExpr: `env == "prod"`, Effect: auth.EffectDeny}},
+		{Role: "viewer", RoleAction: RoleAction{Action: "state:list", ScopeExpr: auth.ScopeAll, Effect: auth.EffectAllow}},
+	}, got)
+}
@@ -9,47 +9,13 @@ import (
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 )
 
-type stubRoleRepository struct {
-	roles   []models.Role
-	listErr error
-}
-
-func (s *stubRoleRepository) Create(ctx context.Context, role *models.Role) error {
-	return errors.New("not implemented")
-}
-
-func (s *stubRoleRepository) GetByID(ctx context.Context, id string) (*models.Role, error) {
-	return nil, errors.New("not implemented")
-}
-
-func (s *stubRoleRepository) GetByName(ctx context.Context, name string) (*models.Role, error) {
-	return nil, errors.New("not implemented")
-}
-
-func (s *stubRoleRepository) Update(ctx context.Context, role *models.Role) error {
-	return errors.New("not implemented")
-}
-
-func (s *stubRoleRepository) Delete(ctx context.Context, id string) error {
-	return errors.New("not implemented")
-}
-
-func (s *stubRoleRepository) List(ctx context.Context) ([]models.Role, error) {
-	if s.listErr != nil {
-		return nil, s.listErr
-	}
-	result := make([]models.Role, len(s.roles))
-	copy(result, s.roles)
-	return result, nil
-}
-
 func TestGetRolesByNameReturnsMatchesAndInvalid(t *testing.T) {
 	t.Parallel()
 
-	repo := &stubRoleRepository{
-		roles: []models.Role{
-			{ID: "1", Name: "admin"},
-			{ID: "2", Name: "viewer"},
+	repo := &mockRoleRepository{
+		roles: map[string]*models.Role{
+			"1": {ID: "1", Name: "admin"},
+			"2": {ID: "2", Name: "viewer"},
 		},
 	}
 
@@ -73,7 +39,7 @@ func TestGetRolesByNameReturnsMatchesAndInvalid(t *testing.T) {
 func TestGetRolesByNamePropagatesListError(t *testing.T) {
 	t.Parallel()
 
-	repo := &stubRoleRepository{
+	repo := &mockRoleRepository{
 		listErr: errors.New("boom"),
 	}
 
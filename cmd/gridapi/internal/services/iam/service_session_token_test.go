@@ -0,0 +1,49 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+func TestGetSessionByToken(t *testing.T) {
+	t.Parallel()
+
+	userID := "user-alice"
+	sessions := &mockSessionRepository{sessions: map[string]*models.Session{}}
+	for token, s := range map[string]*models.Session{
+		"active-token":  {ID: "sess-active", ExpiresAt: time.Now().Add(time.Hour)},
+		"revoked-token": {ID: "sess-revoked", ExpiresAt: time.Now().Add(time.Hour), Revoked: true},
+		"expired-token": {ID: "sess-expired", ExpiresAt: time.Now().Add(-time.Minute)},
+	} {
+		s.UserID = &userID
+		s.TokenHash = auth.HashToken(token)
+		sessions.sessions[s.TokenHash] = s
+	}
+	svc := &iamService{sessions: sessions}
+	ctx := context.Background()
+
+	session, err := svc.GetSessionByToken(ctx, "active-token")
+	require.NoError(t, err)
+	assert.Equal(t, "sess-active", session.ID)
+
+	session, err = svc.GetSessionByToken(ctx, "revoked-token")
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+	require.NotNil(t, session)
+	assert.Equal(t, "sess-revoked", session.ID)
+
+	session, err = svc.GetSessionByToken(ctx, "expired-token")
+	assert.ErrorIs(t, err, ErrSessionExpired)
+	require.NotNil(t, session)
+	assert.Equal(t, "sess-expired", session.ID)
+
+	// The raw token is hashed by the service; passing a hash finds nothing.
+	_, err = svc.GetSessionByToken(ctx, auth.HashToken("active-token"))
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
@@ -2,6 +2,7 @@ package iam
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,24 @@ import (
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
 )
 
+// ErrSessionRecentlyExpired is returned for a session that expired within the
+// configured grace window. It still fails authentication, but lets callers
+// tell a user whose session just lapsed apart from one who never logged in.
+var ErrSessionRecentlyExpired = errors.New("session recently expired")
+
+// ErrSessionRevoked and ErrSessionExpired report a session that exists but can
+// no longer authenticate.
+var (
+	ErrSessionRevoked = errors.New("session has been revoked")
+	ErrSessionExpired = errors.New("session has expired")
+)
+
+// RefreshTokenRevoker drops outstanding refresh tokens so revoked sessions
+// cannot be renewed. *auth.Provider satisfies it in internal IdP mode.
+type RefreshTokenRevoker interface {
+	RevokeRefreshTokens(ctx context.Context, tokens []string) int
+}
+
 // SessionAuthenticator authenticates requests using session cookies.
 //
 // Implementation follows Phase 3 specification:
@@ -17,7 +36,8 @@ import (
 //  2. Return (nil, nil) if not present
 //  3. Hash cookie value
 //  4. Lookup session in DB
-//  5. Validate: not revoked, not expired
+//  5. Validate: not revoked, not before the revocation epoch, not expired,
+//     presented by the client it is bound to (when binding is enabled)
 //  6. Lookup user
 //  7. Validate: not disabled
 //  8. Extract groups from session.id_token (stored JWT)
@@ -30,18 +50,29 @@ type SessionAuthenticator struct {
 	users      repository.UserRepository
 	sessions   repository.SessionRepository
 	iamService Service // Reference to parent IAM service for ResolveRoles
+
+	// expiryGrace is how long after expiry a session is reported as
+	// ErrSessionRecentlyExpired instead of a generic expiry error.
+	expiryGrace time.Duration
+
+	// bindingHeader is the header bound sessions must present the same value
+	// of (see session_binding.go); empty disables the check.
+	bindingHeader string
 }
 
 // NewSessionAuthenticator creates a new session authenticator.
+// A zero expiryGrace disables the recently-expired signal.
 func NewSessionAuthenticator(
 	users repository.UserRepository,
 	sessions repository.SessionRepository,
 	iamService Service,
+	expiryGrace time.Duration,
 ) *SessionAuthenticator {
 	return &SessionAuthenticator{
-		users:      users,
-		sessions:   sessions,
-		iamService: iamService,
+		users:       users,
+		sessions:    sessions,
+		iamService:  iamService,
+		expiryGrace: expiryGrace,
 	}
 }
 
@@ -80,12 +111,26 @@ func (a *SessionAuthenticator) Authenticate(ctx context.Context, req AuthRequest
 
 	// Step 5: Validate session
 	if session.Revoked {
-		return nil, fmt.Errorf("session has been revoked")
+		return nil, ErrSessionRevoked
+	}
+	epoch, err := a.iamService.RevocationEpoch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get revocation epoch: %w", err)
+	}
+	if session.CreatedAt.Before(epoch) {
+		return nil, ErrRevokedByEpoch
 	}
 
 	now := time.Now()
 	if session.ExpiresAt.Before(now) {
-		return nil, fmt.Errorf("session has expired")
+		// Access is never granted during grace; only the error differs
+		if now.Sub(session.ExpiresAt) <= a.expiryGrace {
+			return nil, ErrSessionRecentlyExpired
+		}
+		return nil, ErrSessionExpired
+	}
+	if err := a.checkBinding(session, req.Headers); err != nil {
+		return nil, err
 	}
 
 	// Step 6: Lookup user (sessions are only for users, not service accounts)
@@ -106,6 +151,9 @@ func (a *SessionAuthenticator) Authenticate(ctx context.Context, req AuthRequest
 	if user.DisabledAt != nil {
 		return nil, fmt.Errorf("user is disabled")
 	}
+	if revokedByUserEpoch(user, session.CreatedAt) {
+		return nil, ErrRevokedByEpoch
+	}
 
 	// Step 8: Extract groups from session.id_token (stored JWT)
 	groups, err := auth.ExtractGroupsFromIDToken(session.IDToken)
@@ -114,9 +162,11 @@ func (a *SessionAuthenticator) Authenticate(ctx context.Context, req AuthRequest
 		// This is not a fatal error - user may have no groups
 		groups = []string{}
 	}
+	// Claims feed conditional group mappings; on failure only unconditional ones apply
+	claims, _ := auth.ExtractClaimsFromIDToken(session.IDToken)
 
 	// Step 9: Resolve roles using immutable cache
-	roles, err := a.iamService.ResolveRoles(ctx, user.ID, groups, true)
+	roles, err := a.iamService.ResolveRoles(ctx, user.ID, groups, claims, true)
 	if err != nil {
 		return nil, fmt.Errorf("resolve roles: %w", err)
 	}
@@ -125,13 +175,15 @@ func (a *SessionAuthenticator) Authenticate(ctx context.Context, req AuthRequest
 	subject := user.PrincipalSubject() // Use helper method to handle nil Subject
 	principal := &Principal{
 		Subject:     subject,
-		PrincipalID: fmt.Sprintf("user:%s", subject),
+		PrincipalID: auth.UserID(subject),
 		InternalID:  user.ID,
 		Email:       user.Email,
 		Name:        user.Name,
+		DisplayName: userDisplayName(user.Name, user.Email, subject),
 		SessionID:   session.ID,
 		Groups:      groups,
 		Roles:       roles,
+		TokenScopes: session.Scopes,
 		Type:        PrincipalTypeUser,
 	}
 
@@ -2,7 +2,7 @@ package iam
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -11,115 +11,13 @@ import (
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 )
 
-// mockSessionRepository for testing
-type mockSessionRepository struct {
-	sessions map[string]*models.Session // tokenHash → session
-}
-
-func (m *mockSessionRepository) Create(ctx context.Context, session *models.Session) error {
-	m.sessions[session.TokenHash] = session
-	return nil
-}
-
-func (m *mockSessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
-	for _, s := range m.sessions {
-		if s.ID == id {
-			return s, nil
-		}
-	}
-	return nil, fmt.Errorf("session not found")
-}
-
-func (m *mockSessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error) {
-	if s, ok := m.sessions[tokenHash]; ok {
-		return s, nil
-	}
-	return nil, fmt.Errorf("session not found")
-}
-
-func (m *mockSessionRepository) GetByUserID(ctx context.Context, userID string) ([]models.Session, error) {
-	result := []models.Session{}
-	for _, s := range m.sessions {
-		if s.UserID != nil && *s.UserID == userID {
-			result = append(result, *s)
-		}
-	}
-	return result, nil
-}
-
-func (m *mockSessionRepository) GetByServiceAccountID(ctx context.Context, serviceAccountID string) ([]models.Session, error) {
-	result := []models.Session{}
-	for _, s := range m.sessions {
-		if s.ServiceAccountID != nil && *s.ServiceAccountID == serviceAccountID {
-			result = append(result, *s)
-		}
-	}
-	return result, nil
-}
-
-func (m *mockSessionRepository) UpdateLastUsed(ctx context.Context, id string) error {
-	for _, s := range m.sessions {
-		if s.ID == id {
-			s.LastUsedAt = time.Now()
-			return nil
-		}
-	}
-	return fmt.Errorf("session not found")
-}
-
-func (m *mockSessionRepository) Revoke(ctx context.Context, id string) error {
-	for _, s := range m.sessions {
-		if s.ID == id {
-			s.Revoked = true
-			return nil
-		}
-	}
-	return fmt.Errorf("session not found")
-}
-
-func (m *mockSessionRepository) RevokeByUserID(ctx context.Context, userID string) error {
-	for _, s := range m.sessions {
-		if s.UserID != nil && *s.UserID == userID {
-			s.Revoked = true
-		}
-	}
-	return nil
-}
-
-func (m *mockSessionRepository) RevokeByServiceAccountID(ctx context.Context, serviceAccountID string) error {
-	for _, s := range m.sessions {
-		if s.ServiceAccountID != nil && *s.ServiceAccountID == serviceAccountID {
-			s.Revoked = true
-		}
-	}
-	return nil
-}
-
-func (m *mockSessionRepository) DeleteExpired(ctx context.Context) error {
-	now := time.Now()
-	for hash, s := range m.sessions {
-		if s.ExpiresAt.Before(now) {
-			delete(m.sessions, hash)
-		}
-	}
-	return nil
-}
-
-func (m *mockSessionRepository) List(ctx context.Context) ([]models.Session, error) {
-	result := make([]models.Session, 0, len(m.sessions))
-	for _, s := range m.sessions {
-		result = append(result, *s)
-	}
-	return result, nil
-}
-
 // TestSessionAuthenticator_NoCookie tests behavior when no session cookie present
 func TestSessionAuthenticator_NoCookie(t *testing.T) {
 	users := &mockUserRepository{users: make(map[string]*models.User)}
 	sessions := &mockSessionRepository{sessions: make(map[string]*models.Session)}
 	iamService := &mockIAMService{roles: []string{"platform-engineer"}}
 
-	auth := NewSessionAuthenticator(users, sessions, iamService)
+	auth := NewSessionAuthenticator(users, sessions, iamService, 0)
 
 	ctx := context.Background()
 	req := AuthRequest{
@@ -169,7 +67,7 @@ func TestSessionAuthenticator_ValidSession(t *testing.T) {
 	}
 	sessions.sessions[tokenHash] = session
 
-	authHandler := NewSessionAuthenticator(users, sessions, iamService)
+	authHandler := NewSessionAuthenticator(users, sessions, iamService, 0)
 
 	ctx := context.Background()
 	req := AuthRequest{
@@ -196,6 +94,10 @@ func TestSessionAuthenticator_ValidSession(t *testing.T) {
 		t.Errorf("Expected email alice@example.com, got %s", principal.Email)
 	}
 
+	if principal.Name != "Alice" || principal.DisplayName != "Alice" {
+		t.Errorf("Expected name and display name Alice, got %q and %q", principal.Name, principal.DisplayName)
+	}
+
 	if principal.Type != PrincipalTypeUser {
 		t.Errorf("Expected type %s, got %s", PrincipalTypeUser, principal.Type)
 	}
@@ -238,7 +140,7 @@ func TestSessionAuthenticator_ExpiredSession(t *testing.T) {
 	}
 	sessions.sessions[tokenHash] = session
 
-	authHandler := NewSessionAuthenticator(users, sessions, iamService)
+	authHandler := NewSessionAuthenticator(users, sessions, iamService, 0)
 
 	ctx := context.Background()
 	req := AuthRequest{
@@ -291,7 +193,7 @@ func TestSessionAuthenticator_RevokedSession(t *testing.T) {
 	}
 	sessions.sessions[tokenHash] = session
 
-	authHandler := NewSessionAuthenticator(users, sessions, iamService)
+	authHandler := NewSessionAuthenticator(users, sessions, iamService, 0)
 
 	ctx := context.Background()
 	req := AuthRequest{
@@ -346,7 +248,7 @@ func TestSessionAuthenticator_DisabledUser(t *testing.T) {
 	}
 	sessions.sessions[tokenHash] = session
 
-	authHandler := NewSessionAuthenticator(users, sessions, iamService)
+	authHandler := NewSessionAuthenticator(users, sessions, iamService, 0)
 
 	ctx := context.Background()
 	req := AuthRequest{
@@ -376,7 +278,7 @@ func TestSessionAuthenticator_InvalidTokenHash(t *testing.T) {
 	sessions := &mockSessionRepository{sessions: make(map[string]*models.Session)}
 	iamService := &mockIAMService{roles: []string{}}
 
-	authHandler := NewSessionAuthenticator(users, sessions, iamService)
+	authHandler := NewSessionAuthenticator(users, sessions, iamService, 0)
 
 	ctx := context.Background()
 	req := AuthRequest{
@@ -395,3 +297,54 @@ func TestSessionAuthenticator_InvalidTokenHash(t *testing.T) {
 		t.Error("Expected nil principal for invalid session")
 	}
 }
+
+// TestSessionAuthenticator_ExpiredSessionGrace tests the recently-expired signal
+func TestSessionAuthenticator_ExpiredSessionGrace(t *testing.T) {
+	users := &mockUserRepository{users: make(map[string]*models.User)}
+	sessions := &mockSessionRepository{sessions: make(map[string]*models.Session)}
+	iamService := &mockIAMService{roles: []string{}}
+
+	userID := "user-123"
+	sub := "alice@example.com"
+	users.users[sub] = &models.User{ID: userID, Subject: &sub, Email: sub}
+
+	recentToken := "recently-expired-token"
+	sessions.sessions[auth.HashToken(recentToken)] = &models.Session{
+		ID:        "session-recent",
+		UserID:    &userID,
+		TokenHash: auth.HashToken(recentToken),
+		ExpiresAt: time.Now().Add(-5 * time.Minute),
+	}
+	staleToken := "long-expired-token"
+	sessions.sessions[auth.HashToken(staleToken)] = &models.Session{
+		ID:        "session-stale",
+		UserID:    &userID,
+		TokenHash: auth.HashToken(staleToken),
+		ExpiresAt: time.Now().Add(-1 * time.Hour),
+	}
+
+	authHandler := NewSessionAuthenticator(users, sessions, iamService, 15*time.Minute)
+	ctx := context.Background()
+	reqFor := func(token string) AuthRequest {
+		return AuthRequest{
+			Headers: http.Header{},
+			Cookies: []*http.Cookie{{Name: auth.SessionCookieName, Value: token}},
+		}
+	}
+
+	principal, err := authHandler.Authenticate(ctx, reqFor(recentToken))
+	if principal != nil {
+		t.Error("Expected nil principal for session within grace")
+	}
+	if !errors.Is(err, ErrSessionRecentlyExpired) {
+		t.Errorf("Expected ErrSessionRecentlyExpired within grace, got: %v", err)
+	}
+
+	principal, err = authHandler.Authenticate(ctx, reqFor(staleToken))
+	if principal != nil {
+		t.Error("Expected nil principal for session past grace")
+	}
+	if err == nil || errors.Is(err, ErrSessionRecentlyExpired) {
+		t.Errorf("Expected generic expiry error past grace, got: %v", err)
+	}
+}
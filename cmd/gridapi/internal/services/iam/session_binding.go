@@ -0,0 +1,55 @@
+package iam
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+var (
+	// ErrSessionBindingMismatch is returned for a bound session presented by
+	// a client whose binding header differs from the one it logged in with,
+	// or that omits the header.
+	ErrSessionBindingMismatch = errors.New("session is bound to a different client")
+
+	// ErrSessionBindingHeaderMissing is returned by CreateSession when binding
+	// is enabled and the login request lacks the binding header.
+	ErrSessionBindingHeaderMissing = errors.New("session binding header is missing")
+)
+
+// Session binding (config.Config.SessionBindingHeader) ties a session cookie
+// to the client it was issued to. CreateSession records the SHA256 of the
+// binding header's value at login, and SessionAuthenticator rejects the
+// session when a request's value hashes differently. A login without the
+// header is refused rather than bound to nothing, and a bound session
+// presented without it is rejected: an empty value is what a replaying client
+// that cannot forge the header would send.
+//
+// Binding is opt-in: sessions created while it was disabled are not bound and
+// keep working once it is enabled, and disabling it stops the check.
+
+// sessionFingerprint returns the fingerprint of the client that sent headers,
+// or false when the binding header is missing or empty.
+func sessionFingerprint(bindingHeader string, headers http.Header) (string, bool) {
+	value := headers.Get(bindingHeader)
+	if value == "" {
+		return "", false
+	}
+	return auth.HashToken(value), true
+}
+
+// checkBinding rejects a bound session presented with a different
+// fingerprint.
+func (a *SessionAuthenticator) checkBinding(session *models.Session, headers http.Header) error {
+	if a.bindingHeader == "" || session.Fingerprint == nil {
+		return nil
+	}
+	presented, ok := sessionFingerprint(a.bindingHeader, headers)
+	if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(*session.Fingerprint)) != 1 {
+		return ErrSessionBindingMismatch
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package iam
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/auth"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+)
+
+const testBindingHeader = "X-Client-Cert-Fingerprint"
+
+func TestSessionBinding(t *testing.T) {
+	ctx := context.Background()
+	userID := "user-123"
+	sub := "alice@example.com"
+	users := &mockUserRepository{users: map[string]*models.User{
+		sub: {ID: userID, Subject: &sub, Email: sub, Name: "Alice"},
+	}}
+	sessions := &mockSessionRepository{sessions: make(map[string]*models.Session)}
+	svc := &iamService{sessions: sessions, sessionBindingHeader: testBindingHeader}
+
+	authenticator := NewSessionAuthenticator(users, sessions, &mockIAMService{roles: []string{"viewer"}}, 0)
+	authenticator.bindingHeader = testBindingHeader
+
+	login := func(headers http.Header) string {
+		t.Helper()
+		_, token, err := svc.CreateSession(ctx, userID, "", time.Now().Add(time.Hour), headers)
+		require.NoError(t, err)
+		return token
+	}
+	present := func(token, fingerprint string) (*Principal, error) {
+		headers := http.Header{}
+		if fingerprint != "" {
+			headers.Set(testBindingHeader, fingerprint)
+		}
+		return authenticator.Authenticate(ctx, AuthRequest{
+			Headers: headers,
+			Cookies: []*http.Cookie{{Name: auth.SessionCookieName, Value: token}},
+		})
+	}
+
+	token := login(http.Header{testBindingHeader: {"laptop"}})
+	stored := sessions.sessions[auth.HashToken(token)]
+	require.NotNil(t, stored.Fingerprint)
+	assert.NotEqual(t, "laptop", *stored.Fingerprint, "only a hash of the header is stored")
+	fingerprint := *stored.Fingerprint
+
+	t.Run("matching fingerprint", func(t *testing.T) {
+		principal, err := present(token, "laptop")
+		require.NoError(t, err)
+		assert.Equal(t, sub, principal.Subject)
+	})
+
+	t.Run("mismatching fingerprint", func(t *testing.T) {
+		principal, err := present(token, "attacker")
+		assert.ErrorIs(t, err, ErrSessionBindingMismatch)
+		assert.Nil(t, principal)
+
+		_, err = present(token, "")
+		assert.ErrorIs(t, err, ErrSessionBindingMismatch, "a missing header does not match")
+	})
+
+	t.Run("login without the header is refused", func(t *testing.T) {
+		before := len(sessions.sessions)
+		for _, headers := range []http.Header{nil, {testBindingHeader: {""}}} {
+			session, token, err := svc.CreateSession(ctx, userID, "", time.Now().Add(time.Hour), headers)
+			assert.ErrorIs(t, err, ErrSessionBindingHeaderMissing)
+			assert.Nil(t, session)
+			assert.Empty(t, token)
+		}
+		assert.Len(t, sessions.sessions, before, "no session is stored")
+	})
+
+	t.Run("replay without the header", func(t *testing.T) {
+		// A session bound before logins without the header were refused
+		// carries the fingerprint of the empty value.
+		legacy := auth.HashToken("")
+		stored.Fingerprint = &legacy
+		t.Cleanup(func() { stored.Fingerprint = &fingerprint })
+
+		_, err := present(token, "")
+		assert.ErrorIs(t, err, ErrSessionBindingMismatch)
+	})
+
+	t.Run("unbound sessions keep working", func(t *testing.T) {
+		unbound := &iamService{sessions: sessions}
+		session, token, err := unbound.CreateSession(ctx, userID, "", time.Now().Add(time.Hour), http.Header{testBindingHeader: {"laptop"}})
+		require.NoError(t, err)
+		assert.Nil(t, session.Fingerprint, "binding disabled at login")
+
+		_, err = present(token, "anywhere")
+		assert.NoError(t, err)
+	})
+
+	t.Run("disabling binding stops the check", func(t *testing.T) {
+		relaxed := NewSessionAuthenticator(users, sessions, &mockIAMService{roles: []string{"viewer"}}, 0)
+		_, err := relaxed.Authenticate(ctx, AuthRequest{
+			Headers: http.Header{testBindingHeader: {"attacker"}},
+			Cookies: []*http.Cookie{{Name: auth.SessionCookieName, Value: token}},
+		})
+		assert.NoError(t, err)
+	})
+}
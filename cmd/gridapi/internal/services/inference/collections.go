@@ -0,0 +1,289 @@
+package inference
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JLugagne/jsonschema-infer"
+)
+
+// The inference library models every JSON object as a record (properties +
+// required) and every array as a list with one merged item schema. Terraform
+// outputs also carry maps and tuples, so for outputs whose state records no
+// type (see types.go) the generated schema is reshaped against the values it
+// was inferred from:
+//
+//   - an object whose members all share one shape is a map and gets
+//     "additionalProperties" with the members' schema instead of properties
+//   - a fixed-length array mixing element kinds is a tuple and gets one item
+//     schema per index
+//   - an empty array accepts any items (the library emits an invalid type)
+//
+// Lists of objects keep the library's single merged item schema.
+
+// refineSchema reshapes the generated schema for value and turns detected
+// ARN/CIDR formats into pattern constraints.
+func refineSchema(schemaJSON string, value any) (string, error) {
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
+		return "", fmt.Errorf("parse generated schema: %w", err)
+	}
+
+	if err := refineCollections(schema, []any{value}); err != nil {
+		return "", err
+	}
+	rewriteFormats(schema)
+
+	out, err := json.Marshal(schema)
+	if err != nil {
+		return "", fmt.Errorf("marshal schema: %w", err)
+	}
+	return string(out), nil
+}
+
+// generateSchema infers a schema node (without "$schema") from samples.
+func generateSchema(samples []any) (map[string]any, error) {
+	generator := jsonschema.New(generatorOptions()...)
+	for _, sample := range samples {
+		sampleJSON, err := json.Marshal(sample)
+		if err != nil {
+			return nil, fmt.Errorf("marshal sample: %w", err)
+		}
+		if err := generator.AddSample(string(sampleJSON)); err != nil {
+			return nil, fmt.Errorf("add sample: %w", err)
+		}
+	}
+
+	schemaJSON, err := generator.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("generate schema: %w", err)
+	}
+	var node map[string]any
+	if err := json.Unmarshal([]byte(schemaJSON), &node); err != nil {
+		return nil, fmt.Errorf("parse generated schema: %w", err)
+	}
+	delete(node, "$schema")
+
+	if err := refineCollections(node, samples); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// refineCollections reshapes node, generated from values, where values are
+// maps or tuples, and recurses into record properties and list items.
+func refineCollections(node map[string]any, values []any) error {
+	if objects, ok := allOf[map[string]any](values); ok {
+		return refineObject(node, objects)
+	}
+	if arrays, ok := allOf[[]any](values); ok {
+		return refineArray(node, arrays)
+	}
+	return nil
+}
+
+func refineObject(node map[string]any, objects []map[string]any) error {
+	var members []any
+	for _, object := range objects {
+		for _, member := range object {
+			members = append(members, member)
+		}
+	}
+
+	if sameShape(members) {
+		valueSchema, err := generateSchema(members)
+		if err != nil {
+			return err
+		}
+		replaceNode(node, map[string]any{
+			"type":                 "object",
+			"additionalProperties": valueSchema,
+		})
+		return nil
+	}
+
+	props, _ := node["properties"].(map[string]any)
+	for key, prop := range props {
+		propNode, ok := prop.(map[string]any)
+		if !ok {
+			continue
+		}
+		var propValues []any
+		for _, object := range objects {
+			if v, present := object[key]; present {
+				propValues = append(propValues, v)
+			}
+		}
+		if err := refineCollections(propNode, propValues); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func refineArray(node map[string]any, arrays [][]any) error {
+	if isTuple(arrays) {
+		length := len(arrays[0])
+		items := make([]any, length)
+		for i := range length {
+			column := make([]any, len(arrays))
+			for j, array := range arrays {
+				column[j] = array[i]
+			}
+			itemSchema, err := generateSchema(column)
+			if err != nil {
+				return err
+			}
+			items[i] = itemSchema
+		}
+		replaceNode(node, map[string]any{
+			"type":            "array",
+			"items":           items,
+			"minItems":        length,
+			"additionalItems": false,
+		})
+		return nil
+	}
+
+	var elements []any
+	for _, array := range arrays {
+		elements = append(elements, array...)
+	}
+	if len(elements) == 0 {
+		node["items"] = map[string]any{}
+		return nil
+	}
+	if items, ok := node["items"].(map[string]any); ok {
+		return refineCollections(items, elements)
+	}
+	return nil
+}
+
+// isTuple reports whether arrays are one fixed-length tuple type: the same
+// length (at least two), the same kind at each index across arrays, and more
+// than one kind across indexes.
+func isTuple(arrays [][]any) bool {
+	length := len(arrays[0])
+	if length < 2 {
+		return false
+	}
+
+	kinds := make([]string, length)
+	for i, element := range arrays[0] {
+		kinds[i] = valueKind(element)
+	}
+	for _, array := range arrays[1:] {
+		if len(array) != length {
+			return false
+		}
+		for i, element := range array {
+			if valueKind(element) != kinds[i] {
+				return false
+			}
+		}
+	}
+
+	for _, kind := range kinds[1:] {
+		if kind != kinds[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// sameShape reports whether there are at least two values and all have the
+// same shape (see valueShape).
+func sameShape(values []any) bool {
+	if len(values) < 2 {
+		return false
+	}
+	shape := valueShape(values[0])
+	for _, v := range values[1:] {
+		if valueShape(v) != shape {
+			return false
+		}
+	}
+	return true
+}
+
+// valueShape describes v's kind, an object's attribute names and shapes, and
+// the set of a list's element shapes, so values with the same Terraform
+// type share a shape regardless of list lengths.
+func valueShape(v any) string {
+	switch t := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for key := range t {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = key + ":" + valueShape(t[key])
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	case []any:
+		seen := make(map[string]struct{})
+		var parts []string
+		for _, element := range t {
+			shape := valueShape(element)
+			if _, ok := seen[shape]; !ok {
+				seen[shape] = struct{}{}
+				parts = append(parts, shape)
+			}
+		}
+		sort.Strings(parts)
+		return "[" + strings.Join(parts, "|") + "]"
+	default:
+		return valueKind(v)
+	}
+}
+
+// valueKind returns the JSON kind of a decoded value.
+func valueKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return "number"
+	}
+}
+
+// allOf returns values as []T when every value is a T and there is at least one.
+func allOf[T any](values []any) ([]T, bool) {
+	if len(values) == 0 {
+		return nil, false
+	}
+	out := make([]T, len(values))
+	for i, v := range values {
+		typed, ok := v.(T)
+		if !ok {
+			return nil, false
+		}
+		out[i] = typed
+	}
+	return out, true
+}
+
+// replaceNode swaps node's contents for with, keeping the map identity its
+// parent holds.
+func replaceNode(node, with map[string]any) {
+	for key := range node {
+		if key != "$schema" {
+			delete(node, key)
+		}
+	}
+	for key, value := range with {
+		node[key] = value
+	}
+}
@@ -0,0 +1,159 @@
+package inference
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+// decode turns a JSON literal into the values the tfstate parser produces.
+func decode(t *testing.T, literal string) any {
+	t.Helper()
+
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(literal), &v))
+	return v
+}
+
+// requireValidates compiles schema the way the validation job does and
+// checks that value conforms to it.
+func requireValidates(t *testing.T, schema map[string]any, value any) {
+	t.Helper()
+
+	schemaJSON, err := json.Marshal(schema)
+	require.NoError(t, err)
+	parsed, err := jsonschema.UnmarshalJSON(strings.NewReader(string(schemaJSON)))
+	require.NoError(t, err)
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft7)
+	require.NoError(t, compiler.AddResource("schema.json", parsed))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err, "inferred schema does not compile: %s", schemaJSON)
+
+	require.NoError(t, compiled.Validate(value), "inferred schema %s rejects its source value", schemaJSON)
+}
+
+func TestInferSchemasCollectionTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		check func(t *testing.T, schema map[string]any)
+	}{
+		{
+			name:  "map of strings",
+			value: `{"Environment": "prod", "Team": "platform", "CostCenter": "cc-42"}`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, "object", schema["type"])
+				require.NotContains(t, schema, "properties")
+				require.Equal(t, map[string]any{"type": "string"}, schema["additionalProperties"])
+			},
+		},
+		{
+			name: "nested map of maps",
+			value: `{
+				"us-east-1": {"public": "subnet-0a1", "private": "subnet-0b1"},
+				"us-west-2": {"public": "subnet-0a2", "private": "subnet-0b2"}
+			}`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, "object", schema["type"])
+				inner, ok := schema["additionalProperties"].(map[string]any)
+				require.True(t, ok, "outer map should use additionalProperties")
+				require.Equal(t, "object", inner["type"])
+				require.Equal(t, map[string]any{"type": "string"}, inner["additionalProperties"])
+			},
+		},
+		{
+			name:  "map of ARNs keeps the pattern",
+			value: `{"deploy": "arn:aws:iam::123456789012:role/deploy", "audit": "arn:aws:iam::123456789012:role/audit"}`,
+			check: func(t *testing.T, schema map[string]any) {
+				values, ok := schema["additionalProperties"].(map[string]any)
+				require.True(t, ok)
+				require.Equal(t, patternFormats[0].pattern.String(), values["pattern"])
+			},
+		},
+		{
+			name:  "heterogeneous tuple",
+			value: `["vpc-0abc123", 3, true, {"cidr": "10.0.0.0/16", "ipv6": false}]`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, "array", schema["type"])
+				items, ok := schema["items"].([]any)
+				require.True(t, ok, "tuple should have per-index items")
+				require.Len(t, items, 4)
+				require.Equal(t, "string", items[0].(map[string]any)["type"])
+				require.Equal(t, "integer", items[1].(map[string]any)["type"])
+				require.Equal(t, "boolean", items[2].(map[string]any)["type"])
+				object := items[3].(map[string]any)
+				require.Equal(t, "object", object["type"])
+				require.Contains(t, object["properties"], "cidr")
+				require.Equal(t, false, schema["additionalItems"])
+				require.EqualValues(t, 4, schema["minItems"])
+			},
+		},
+		{
+			name: "list of objects",
+			value: `[
+				{"name": "web", "port": 443, "public": true},
+				{"name": "db", "port": 5432, "public": false}
+			]`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, "array", schema["type"])
+				items, ok := schema["items"].(map[string]any)
+				require.True(t, ok, "list should have a single item schema")
+				require.Equal(t, "object", items["type"])
+				require.ElementsMatch(t, []any{"name", "port", "public"}, items["required"])
+			},
+		},
+		{
+			name:  "list of tuples",
+			value: `[["web", 443], ["db", 5432]]`,
+			check: func(t *testing.T, schema map[string]any) {
+				items, ok := schema["items"].(map[string]any)
+				require.True(t, ok)
+				tuple, ok := items["items"].([]any)
+				require.True(t, ok)
+				require.Len(t, tuple, 2)
+			},
+		},
+		{
+			name:  "homogeneous list stays a list",
+			value: `["subnet-abc", "subnet-def"]`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, map[string]any{"type": "string"}, schema["items"])
+			},
+		},
+		{
+			name:  "empty list",
+			value: `[]`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, map[string]any{}, schema["items"])
+			},
+		},
+		{
+			name:  "object with mixed attributes stays a record",
+			value: `{"name": "production", "replicas": 3, "tags": {"Team": "platform", "Tier": "gold"}}`,
+			check: func(t *testing.T, schema map[string]any) {
+				props, ok := schema["properties"].(map[string]any)
+				require.True(t, ok)
+				tags := props["tags"].(map[string]any)
+				require.Equal(t, map[string]any{"type": "string"}, tags["additionalProperties"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			value := decode(t, tt.value)
+			schema := inferOne(t, value)
+			tt.check(t, schema)
+			requireValidates(t, schema, value)
+		})
+	}
+}
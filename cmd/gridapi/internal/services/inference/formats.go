@@ -0,0 +1,74 @@
+package inference
+
+import (
+	"regexp"
+
+	"github.com/JLugagne/jsonschema-infer"
+)
+
+// patternFormat is a well-known string shape with no standard JSON Schema
+// "format" keyword. It is detected as a custom format during inference and
+// then rewritten into a "pattern" constraint.
+//
+// Standard formats (date-time, email, uuid, ipv4, ipv6, uri) are detected by
+// the inference library itself and are emitted as "format".
+type patternFormat struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var patternFormats = []patternFormat{
+	{
+		name:    "aws-arn",
+		pattern: regexp.MustCompile(`^arn:aws[a-z-]*:[a-z0-9-]+:[a-z0-9-]*:[0-9]{0,12}:.+$`),
+	},
+	{
+		name:    "ipv4-cidr",
+		pattern: regexp.MustCompile(`^((25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])/(3[0-2]|[12]?[0-9])$`),
+	},
+}
+
+// generatorOptions registers the pattern formats as custom format detectors.
+func generatorOptions() []jsonschema.Option {
+	opts := make([]jsonschema.Option, 0, len(patternFormats))
+	for _, pf := range patternFormats {
+		opts = append(opts, jsonschema.WithCustomFormat(pf.name, pf.pattern.MatchString))
+	}
+	return opts
+}
+
+// rewriteFormats replaces custom format names in a schema node and its
+// properties/additionalProperties/items with the matching "pattern" so
+// validators enforce them.
+func rewriteFormats(node map[string]any) {
+	if format, ok := node["format"].(string); ok {
+		for _, pf := range patternFormats {
+			if pf.name == format {
+				delete(node, "format")
+				node["pattern"] = pf.pattern.String()
+				break
+			}
+		}
+	}
+
+	if props, ok := node["properties"].(map[string]any); ok {
+		for _, child := range props {
+			if childNode, ok := child.(map[string]any); ok {
+				rewriteFormats(childNode)
+			}
+		}
+	}
+	if values, ok := node["additionalProperties"].(map[string]any); ok {
+		rewriteFormats(values)
+	}
+	switch items := node["items"].(type) {
+	case map[string]any:
+		rewriteFormats(items)
+	case []any:
+		for _, item := range items {
+			if itemNode, ok := item.(map[string]any); ok {
+				rewriteFormats(itemNode)
+			}
+		}
+	}
+}
@@ -17,8 +17,9 @@ func NewInferrer() state.SchemaInferrer {
 	return &inferrer{}
 }
 
-// InferSchemas infers JSON Schema from output values for outputs that need schemas
-func (i *inferrer) InferSchemas(ctx context.Context, stateGUID string, outputs map[string]interface{}, needsSchema []string) ([]state.InferredSchema, error) {
+// InferSchemas infers JSON Schema for outputs that need schemas, from the
+// output's Terraform type when the state records it and from its value otherwise
+func (i *inferrer) InferSchemas(ctx context.Context, stateGUID string, outputs map[string]interface{}, types map[string]json.RawMessage, needsSchema []string) ([]state.InferredSchema, error) {
 	var inferred []state.InferredSchema
 
 	// Create a set of outputs that need schema inference
@@ -34,14 +35,27 @@ func (i *inferrer) InferSchemas(ctx context.Context, stateGUID string, outputs m
 			continue
 		}
 
+		// Derive the schema from the declared type when there is one
+		schemaJSON, typed, err := typedSchema(types[outputKey], outputValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive schema for %s from its type: %w", outputKey, err)
+		}
+		if typed {
+			inferred = append(inferred, state.InferredSchema{
+				OutputKey:  outputKey,
+				SchemaJSON: schemaJSON,
+			})
+			continue
+		}
+
 		// Marshal output value to JSON for inference library
 		valueJSON, err := json.Marshal(outputValue)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal output value for %s: %w", outputKey, err)
 		}
 
-		// Create generator and add sample
-		generator := jsonschema.New()
+		// Create generator (with ARN/CIDR detection) and add sample
+		generator := jsonschema.New(generatorOptions()...)
 		if err := generator.AddSample(string(valueJSON)); err != nil {
 			return nil, fmt.Errorf("failed to add sample for %s: %w", outputKey, err)
 		}
@@ -52,10 +66,15 @@ func (i *inferrer) InferSchemas(ctx context.Context, stateGUID string, outputs m
 			return nil, fmt.Errorf("failed to generate schema for %s: %w", outputKey, err)
 		}
 
-		// schema is already a JSON string, use it directly (no marshaling needed)
+		// Reshape maps and tuples, and turn detected ARN/CIDR formats into pattern constraints
+		schemaJSON, err = refineSchema(string(schema), outputValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refine schema for %s: %w", outputKey, err)
+		}
+
 		inferred = append(inferred, state.InferredSchema{
 			OutputKey:  outputKey,
-			SchemaJSON: string(schema),
+			SchemaJSON: schemaJSON,
 		})
 	}
 
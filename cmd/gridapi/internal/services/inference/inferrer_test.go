@@ -0,0 +1,73 @@
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func inferOne(t *testing.T, value any) map[string]any {
+	t.Helper()
+
+	schemas, err := NewInferrer().InferSchemas(context.Background(), "state-1", map[string]any{"out": value}, nil, []string{"out"})
+	require.NoError(t, err)
+	require.Len(t, schemas, 1)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(schemas[0].SchemaJSON), &schema))
+	return schema
+}
+
+func TestInferSchemasStringFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       any
+		wantFormat  string
+		wantPattern string
+	}{
+		{name: "aws arn", value: "arn:aws:iam::123456789012:role/deploy", wantPattern: patternFormats[0].pattern.String()},
+		{name: "ipv4 cidr", value: "10.0.0.0/16", wantPattern: patternFormats[1].pattern.String()},
+		{name: "ipv4", value: "10.0.1.25", wantFormat: "ipv4"},
+		{name: "uuid", value: "3f1c2a9e-4b7d-4e2a-9c1f-8d6b5a4e3c2b", wantFormat: "uuid"},
+		{name: "url", value: "https://example.com/path", wantFormat: "uri"},
+		{name: "plain string", value: "vpc-0abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schema := inferOne(t, tt.value)
+			require.Equal(t, "string", schema["type"])
+
+			if tt.wantFormat == "" {
+				require.NotContains(t, schema, "format")
+			} else {
+				require.Equal(t, tt.wantFormat, schema["format"])
+			}
+			if tt.wantPattern == "" {
+				require.NotContains(t, schema, "pattern")
+			} else {
+				require.Equal(t, tt.wantPattern, schema["pattern"])
+			}
+		})
+	}
+}
+
+func TestInferSchemasNestedARNPattern(t *testing.T) {
+	t.Parallel()
+
+	schema := inferOne(t, []any{
+		"arn:aws:s3:::logs-bucket",
+		"arn:aws:s3:::assets-bucket",
+	})
+
+	items, ok := schema["items"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, patternFormats[0].pattern.String(), items["pattern"])
+	require.NotContains(t, items, "format")
+}
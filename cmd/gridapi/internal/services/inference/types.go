@@ -0,0 +1,226 @@
+package inference
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Terraform records each output's type constraint next to its value in the
+// state file, in cty's JSON type encoding:
+//
+//	"string" | "number" | "bool" | "dynamic"
+//	["list", T] | ["set", T] | ["map", T]
+//	["object", {"attr": T, ...}] | ["object", {...}, ["optional_attr", ...]]
+//	["tuple", [T, ...]]
+//
+// When present the type, not the value's shape, decides whether a collection
+// is a list, set, map, object or tuple. The values are still consulted for
+// string formats and for "dynamic" parts, which carry no static type.
+
+// jsonSchemaDraft is the "$schema" the inference library stamps on schemas.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// typeSchema derives a schema node from a Terraform type constraint and the
+// values of that type. It reports false for an encoding it does not know,
+// in which case the caller falls back to inferring from the values alone.
+// Any Terraform value may be null, so a null among values makes the schema
+// accept null.
+func typeSchema(typ json.RawMessage, values []any) (map[string]any, bool, error) {
+	node, ok, err := typeSchemaOf(typ, values)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	if t, typed := node["type"].(string); typed && slices.Contains(values, nil) {
+		node["type"] = []any{t, "null"}
+	}
+	return node, true, nil
+}
+
+func typeSchemaOf(typ json.RawMessage, values []any) (map[string]any, bool, error) {
+	var primitive string
+	if err := json.Unmarshal(typ, &primitive); err == nil {
+		return primitiveSchema(primitive, values)
+	}
+
+	var parts []json.RawMessage
+	if err := json.Unmarshal(typ, &parts); err != nil || len(parts) < 2 {
+		return nil, false, nil
+	}
+	var kind string
+	if err := json.Unmarshal(parts[0], &kind); err != nil {
+		return nil, false, nil
+	}
+
+	switch kind {
+	case "list", "set":
+		var elements []any
+		for _, array := range nonNull[[]any](values) {
+			elements = append(elements, array...)
+		}
+		items, ok, err := typeSchema(parts[1], elements)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		node := map[string]any{"type": "array", "items": items}
+		if kind == "set" {
+			node["uniqueItems"] = true
+		}
+		return node, true, nil
+
+	case "map":
+		var members []any
+		for _, object := range nonNull[map[string]any](values) {
+			for _, member := range object {
+				members = append(members, member)
+			}
+		}
+		valueSchema, ok, err := typeSchema(parts[1], members)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": valueSchema}, true, nil
+
+	case "object":
+		return objectSchema(parts, nonNull[map[string]any](values))
+
+	case "tuple":
+		var elementTypes []json.RawMessage
+		if err := json.Unmarshal(parts[1], &elementTypes); err != nil {
+			return nil, false, nil
+		}
+		arrays := nonNull[[]any](values)
+		items := make([]any, len(elementTypes))
+		for i, elementType := range elementTypes {
+			var column []any
+			for _, array := range arrays {
+				if i < len(array) {
+					column = append(column, array[i])
+				}
+			}
+			itemSchema, ok, err := typeSchema(elementType, column)
+			if !ok || err != nil {
+				return nil, ok, err
+			}
+			items[i] = itemSchema
+		}
+		return map[string]any{
+			"type":            "array",
+			"items":           items,
+			"minItems":        len(items),
+			"maxItems":        len(items),
+			"additionalItems": false,
+		}, true, nil
+	}
+	return nil, false, nil
+}
+
+// primitiveSchema derives the schema of a primitive or "dynamic" type.
+func primitiveSchema(primitive string, values []any) (map[string]any, bool, error) {
+	switch primitive {
+	case "string":
+		texts := nonNull[string](values)
+		if len(texts) == 0 {
+			return map[string]any{"type": "string"}, true, nil
+		}
+		// Inferring from the strings detects their format
+		node, err := generateSchema(toAny(texts))
+		return node, err == nil, err
+	case "number":
+		return map[string]any{"type": "number"}, true, nil
+	case "bool":
+		return map[string]any{"type": "boolean"}, true, nil
+	case "dynamic":
+		if len(values) == 0 {
+			return map[string]any{}, true, nil
+		}
+		node, err := generateSchema(values)
+		return node, err == nil, err
+	}
+	return nil, false, nil
+}
+
+// objectSchema derives the schema of an ["object", attrs, optional] type.
+// Every attribute that is not optional is required.
+func objectSchema(parts []json.RawMessage, objects []map[string]any) (map[string]any, bool, error) {
+	var attrTypes map[string]json.RawMessage
+	if err := json.Unmarshal(parts[1], &attrTypes); err != nil {
+		return nil, false, nil
+	}
+	optional := make(map[string]bool)
+	if len(parts) > 2 {
+		var names []string
+		if err := json.Unmarshal(parts[2], &names); err != nil {
+			return nil, false, nil
+		}
+		for _, name := range names {
+			optional[name] = true
+		}
+	}
+
+	props := make(map[string]any, len(attrTypes))
+	required := make([]string, 0, len(attrTypes))
+	for attr, attrType := range attrTypes {
+		var attrValues []any
+		for _, object := range objects {
+			if v, present := object[attr]; present {
+				attrValues = append(attrValues, v)
+			}
+		}
+		propSchema, ok, err := typeSchema(attrType, attrValues)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		props[attr] = propSchema
+		if !optional[attr] {
+			required = append(required, attr)
+		}
+	}
+	sort.Strings(required)
+
+	node := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		node["required"] = required
+	}
+	return node, true, nil
+}
+
+// typedSchema derives the schema of an output from its Terraform type, for
+// values whose type is recorded.
+func typedSchema(typ json.RawMessage, value any) (string, bool, error) {
+	if len(typ) == 0 || value == nil {
+		return "", false, nil
+	}
+	node, ok, err := typeSchema(typ, []any{value})
+	if !ok || err != nil {
+		return "", false, err
+	}
+	node["$schema"] = jsonSchemaDraft
+	rewriteFormats(node)
+
+	out, err := json.Marshal(node)
+	if err != nil {
+		return "", false, fmt.Errorf("marshal schema: %w", err)
+	}
+	return string(out), true, nil
+}
+
+// nonNull returns the values that are a T, skipping nulls.
+func nonNull[T any](values []any) []T {
+	out := make([]T, 0, len(values))
+	for _, v := range values {
+		if typed, ok := v.(T); ok {
+			out = append(out, typed)
+		}
+	}
+	return out
+}
+
+func toAny[T any](values []T) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func inferTyped(t *testing.T, typ string, value any) map[string]any {
+	t.Helper()
+
+	types := map[string]json.RawMessage{"out": json.RawMessage(typ)}
+	schemas, err := NewInferrer().InferSchemas(context.Background(), "state-1", map[string]any{"out": value}, types, []string{"out"})
+	require.NoError(t, err)
+	require.Len(t, schemas, 1)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(schemas[0].SchemaJSON), &schema))
+	return schema
+}
+
+func TestInferSchemasFromTerraformType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		typ   string
+		value string
+		check func(t *testing.T, schema map[string]any)
+	}{
+		{
+			name:  "single-entry map is still a map",
+			typ:   `["map", "string"]`,
+			value: `{"Environment": "prod"}`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.NotContains(t, schema, "properties")
+				require.Equal(t, map[string]any{"type": "string"}, schema["additionalProperties"])
+			},
+		},
+		{
+			name:  "numbers accept fractions",
+			typ:   `["list", "number"]`,
+			value: `[1, 2, 3]`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, map[string]any{"type": "number"}, schema["items"])
+			},
+		},
+		{
+			name:  "homogeneous tuple is still a tuple",
+			typ:   `["tuple", ["string", "string"]]`,
+			value: `["web", "db"]`,
+			check: func(t *testing.T, schema map[string]any) {
+				items, ok := schema["items"].([]any)
+				require.True(t, ok, "tuple should have per-index items")
+				require.Len(t, items, 2)
+				require.EqualValues(t, 2, schema["minItems"])
+				require.EqualValues(t, 2, schema["maxItems"])
+			},
+		},
+		{
+			name:  "set has unique items",
+			typ:   `["set", "string"]`,
+			value: `["a", "b"]`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, true, schema["uniqueItems"])
+			},
+		},
+		{
+			name:  "object with optional and null attributes",
+			typ:   `["object", {"name": "string", "port": "number", "note": "string"}, ["note"]]`,
+			value: `{"name": "web", "port": null, "note": "x"}`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.ElementsMatch(t, []any{"name", "port"}, schema["required"])
+				props := schema["properties"].(map[string]any)
+				require.Equal(t, []any{"number", "null"}, props["port"].(map[string]any)["type"])
+			},
+		},
+		{
+			name:  "empty list keeps its element type",
+			typ:   `["list", "string"]`,
+			value: `[]`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, map[string]any{"type": "string"}, schema["items"])
+			},
+		},
+		{
+			name:  "string formats are still detected",
+			typ:   `["map", "string"]`,
+			value: `{"deploy": "arn:aws:iam::123456789012:role/deploy"}`,
+			check: func(t *testing.T, schema map[string]any) {
+				values := schema["additionalProperties"].(map[string]any)
+				require.Equal(t, patternFormats[0].pattern.String(), values["pattern"])
+			},
+		},
+		{
+			name:  "dynamic parts are inferred from the value",
+			typ:   `["object", {"extra": "dynamic"}]`,
+			value: `{"extra": {"a": 1, "b": "two"}}`,
+			check: func(t *testing.T, schema map[string]any) {
+				extra := schema["properties"].(map[string]any)["extra"].(map[string]any)
+				require.Equal(t, "object", extra["type"])
+				require.Contains(t, extra, "properties")
+			},
+		},
+		{
+			name:  "unknown type encoding falls back to the value",
+			typ:   `["capsule", "x"]`,
+			value: `{"Environment": "prod", "Team": "platform"}`,
+			check: func(t *testing.T, schema map[string]any) {
+				require.Equal(t, map[string]any{"type": "string"}, schema["additionalProperties"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			value := decode(t, tt.value)
+			schema := inferTyped(t, tt.typ, value)
+			require.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"])
+			tt.check(t, schema)
+			requireValidates(t, schema, value)
+		})
+	}
+}
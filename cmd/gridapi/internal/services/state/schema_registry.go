@@ -0,0 +1,148 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// schemaKeyPattern matches registry keys of the form <name>@<version>, where
+// name may be namespaced with slashes: "aws/vpc_id@v1", "network/cidr@2024-01".
+var schemaKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)*@[A-Za-z0-9_.-]+$`)
+
+// maxSchemaKeyLength bounds registry keys.
+const maxSchemaKeyLength = 256
+
+var (
+	// ErrSchemaRegistryNotConfigured is returned by registry operations when
+	// the service was built without WithSchemaRegistry.
+	ErrSchemaRegistryNotConfigured = errors.New("schema registry not configured")
+
+	// ErrInvalidJSONSchema is returned when a schema to register does not
+	// compile.
+	ErrInvalidJSONSchema = errors.New("invalid JSON Schema")
+)
+
+// WithSchemaRegistry enables shared output schemas referenced by key
+// (optional dependency).
+func (s *Service) WithSchemaRegistry(registry repository.SchemaRegistryRepository) *Service {
+	s.schemaRegistry = registry
+	return s
+}
+
+// validateSchemaKey checks that key is a well-formed registry key.
+func validateSchemaKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("schema key is required")
+	}
+	if len(key) > maxSchemaKeyLength || !schemaKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid schema key %q: expected <name>@<version>, e.g. aws/vpc_id@v1", key)
+	}
+	return nil
+}
+
+func (s *Service) registry() (repository.SchemaRegistryRepository, error) {
+	if s.schemaRegistry == nil {
+		return nil, ErrSchemaRegistryNotConfigured
+	}
+	return s.schemaRegistry, nil
+}
+
+// CreateRegisteredSchema registers schemaJSON under key.
+func (s *Service) CreateRegisteredSchema(ctx context.Context, key, schemaJSON, description string) (*models.SchemaRegistryEntry, error) {
+	registry, err := s.registry()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSchemaKey(key); err != nil {
+		return nil, err
+	}
+	if schemaJSON == "" {
+		return nil, fmt.Errorf("schema JSON is required")
+	}
+	if err := validateJSONSchema(schemaJSON); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJSONSchema, err)
+	}
+
+	entry := &models.SchemaRegistryEntry{
+		Key:         key,
+		SchemaJSON:  schemaJSON,
+		Description: description,
+	}
+	if err := registry.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetRegisteredSchema returns the registry entry for key.
+func (s *Service) GetRegisteredSchema(ctx context.Context, key string) (*models.SchemaRegistryEntry, error) {
+	registry, err := s.registry()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Get(ctx, key)
+}
+
+// ListRegisteredSchemas returns all registry entries ordered by key.
+func (s *Service) ListRegisteredSchemas(ctx context.Context) ([]models.SchemaRegistryEntry, error) {
+	registry, err := s.registry()
+	if err != nil {
+		return nil, err
+	}
+	return registry.List(ctx)
+}
+
+// UpdateRegisteredSchema replaces the schema registered under key. Every
+// output referencing key picks up the new schema; stored validation results
+// are left as they are until the outputs are re-validated.
+func (s *Service) UpdateRegisteredSchema(ctx context.Context, key, schemaJSON string, description *string) (*models.SchemaRegistryEntry, error) {
+	registry, err := s.registry()
+	if err != nil {
+		return nil, err
+	}
+	if schemaJSON == "" {
+		return nil, fmt.Errorf("schema JSON is required")
+	}
+	if err := validateJSONSchema(schemaJSON); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJSONSchema, err)
+	}
+	return registry.Update(ctx, key, schemaJSON, description)
+}
+
+// DeleteRegisteredSchema removes the entry for key. It fails with
+// repository.ErrSchemaInUse while outputs still reference it.
+func (s *Service) DeleteRegisteredSchema(ctx context.Context, key string) error {
+	registry, err := s.registry()
+	if err != nil {
+		return err
+	}
+	return registry.Delete(ctx, key)
+}
+
+// SetOutputSchemaRefAndCheckExists sets an output's schema to the registry
+// entry key and reports whether the output has a value in Terraform state,
+// like SetOutputSchemaAndCheckExists.
+func (s *Service) SetOutputSchemaRefAndCheckExists(ctx context.Context, guid, outputKey, schemaRef string) (bool, error) {
+	if _, err := s.registry(); err != nil {
+		return false, err
+	}
+	if s.outputRepo == nil {
+		return false, fmt.Errorf("output repository not configured")
+	}
+	if _, err := s.repo.GetByGUID(ctx, guid); err != nil {
+		return false, fmt.Errorf("state not found: %w", err)
+	}
+	if err := validateSchemaKey(schemaRef); err != nil {
+		return false, err
+	}
+
+	if err := s.outputRepo.SetOutputSchemaRef(ctx, guid, outputKey, schemaRef); err != nil {
+		return false, err
+	}
+	return s.outputInState(ctx, guid, outputKey)
+}
@@ -2,10 +2,18 @@ package state
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/santhosh-tekuri/jsonschema/v6"
@@ -32,6 +40,7 @@ type StateSummary struct {
 	UpdatedAt time.Time
 	LockInfo  *models.LockInfo
 	Labels    models.LabelMap
+	CreatedBy string // Principal that created the state; empty when unknown
 
 	// Relationship counts (populated from repository COUNT subqueries)
 	DependenciesCount int
@@ -61,12 +70,56 @@ type Service struct {
 	policyRepo repository.LabelPolicyRepository
 	inferrer   SchemaInferrer
 	serverURL  string
+
+	// logic_id naming rules enforced at CreateState
+	logicIDPattern   *regexp.Regexp
+	logicIDMaxLength int
+
+	// maxOutputsPerState caps outputs per uploaded state (0 = unlimited)
+	maxOutputsPerState int
+
+	// CreateState idempotency keys (disabled when idempotencyRepo is nil)
+	idempotencyRepo repository.StateIdempotencyKeyRepository
+	idempotencyTTL  time.Duration
+
+	// Shared output schemas (disabled when schemaRegistry is nil)
+	schemaRegistry repository.SchemaRegistryRepository
 }
 
+const (
+	// defaultLogicIDMaxLength matches the logic_id column width.
+	defaultLogicIDMaxLength = 128
+
+	// defaultLogicIDPattern allows names like "prod-us-east.network_v2".
+	defaultLogicIDPattern = `^[A-Za-z0-9][A-Za-z0-9._-]*$`
+
+	// defaultIdempotencyKeyTTL is how long a CreateState idempotency key is honoured.
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+
+	// idempotencyKeyPurgeInterval is how often expired idempotency keys are
+	// deleted.
+	idempotencyKeyPurgeInterval = time.Hour
+
+	// maxIdempotencyKeyLength bounds client-supplied idempotency keys.
+	maxIdempotencyKeyLength = 255
+)
+
+var defaultLogicIDRegexp = regexp.MustCompile(defaultLogicIDPattern)
+
+// ErrTooManyOutputs is returned by UpdateStateContent when an uploaded state
+// declares more outputs than the configured per-state cap.
+var ErrTooManyOutputs = errors.New("too many outputs")
+
+// outputsWarningPercent is how full the output cap must be before uploads
+// are flagged as approaching it.
+const outputsWarningPercent = 90
+
 // SchemaInferrer defines the interface for schema inference.
 // Defined here to avoid circular dependencies with inference package.
+// types holds the Terraform type constraint of each output whose state
+// records one.
 type SchemaInferrer interface {
-	InferSchemas(ctx context.Context, stateGUID string, outputs map[string]interface{}, needsSchema []string) ([]InferredSchema, error)
+	InferSchemas(ctx context.Context, stateGUID string, outputs map[string]interface{}, types map[string]json.RawMessage, needsSchema []string) ([]InferredSchema, error)
 }
 
 // InferredSchema represents a schema generated from output data.
@@ -77,7 +130,12 @@ type InferredSchema struct {
 
 // NewService constructs a new Service instance.
 func NewService(repo repository.StateRepository, serverURL string) *Service {
-	return &Service{repo: repo, serverURL: serverURL}
+	return &Service{
+		repo:             repo,
+		serverURL:        serverURL,
+		logicIDPattern:   defaultLogicIDRegexp,
+		logicIDMaxLength: defaultLogicIDMaxLength,
+	}
 }
 
 // WithOutputRepository adds the output repository to the service (optional dependency).
@@ -104,14 +162,47 @@ func (s *Service) WithInferrer(inferrer SchemaInferrer) *Service {
 	return s
 }
 
+// WithLogicIDRules overrides the logic_id naming rules applied at CreateState.
+// A nil pattern or non-positive maxLength keeps the corresponding default.
+// Existing states are not re-validated, so tightening the rules never breaks lookups.
+func (s *Service) WithLogicIDRules(pattern *regexp.Regexp, maxLength int) *Service {
+	if pattern != nil {
+		s.logicIDPattern = pattern
+	}
+	if maxLength > 0 {
+		s.logicIDMaxLength = maxLength
+	}
+	return s
+}
+
+// WithMaxOutputsPerState caps the number of outputs an uploaded state may
+// declare. Uploads over the cap fail with ErrTooManyOutputs and leave the
+// stored state untouched. Zero or negative disables the cap.
+func (s *Service) WithMaxOutputsPerState(maxOutputs int) *Service {
+	s.maxOutputsPerState = max(maxOutputs, 0)
+	return s
+}
+
+// WithIdempotencyKeys enables CreateState idempotency keys, remembering each
+// key for ttl (non-positive uses the 24h default).
+func (s *Service) WithIdempotencyKeys(repo repository.StateIdempotencyKeyRepository, ttl time.Duration) *Service {
+	s.idempotencyRepo = repo
+	s.idempotencyTTL = defaultIdempotencyKeyTTL
+	if ttl > 0 {
+		s.idempotencyTTL = ttl
+	}
+	return s
+}
+
 // CreateState validates inputs, persists the state, and returns summary + backend config.
 // T033: Updated to accept and validate labels via LabelValidator.
-func (s *Service) CreateState(ctx context.Context, guid, logicID string, labels models.LabelMap) (*StateSummary, *BackendConfig, error) {
+// createdBy is the creating principal's ID, or empty when auth is disabled.
+func (s *Service) CreateState(ctx context.Context, guid, logicID string, labels models.LabelMap, createdBy string) (*StateSummary, *BackendConfig, error) {
 	if _, err := uuid.Parse(guid); err != nil {
 		return nil, nil, fmt.Errorf("invalid GUID format: %w", err)
 	}
 
-	if err := validateLogicID(logicID); err != nil {
+	if err := s.validateLogicID(logicID); err != nil {
 		return nil, nil, err
 	}
 
@@ -129,9 +220,10 @@ func (s *Service) CreateState(ctx context.Context, guid, logicID string, labels
 	}
 
 	record := &models.State{
-		GUID:    guid,
-		LogicID: logicID,
-		Labels:  labels,
+		GUID:      guid,
+		LogicID:   logicID,
+		Labels:    labels,
+		CreatedBy: createdBy,
 	}
 
 	if err := s.repo.Create(ctx, record); err != nil {
@@ -143,9 +235,119 @@ func (s *Service) CreateState(ctx context.Context, guid, logicID string, labels
 	return &summary, config, nil
 }
 
-// ListStates returns summaries for all states ordered newest first.
-func (s *Service) ListStates(ctx context.Context) ([]StateSummary, error) {
-	records, err := s.repo.List(ctx)
+// PurgeExpiredIdempotencyKeys deletes idempotency keys whose TTL has passed,
+// now and then every idempotencyKeyPurgeInterval, until ctx is done. It
+// returns at once when idempotency keys are disabled.
+func (s *Service) PurgeExpiredIdempotencyKeys(ctx context.Context) {
+	if s.idempotencyRepo == nil {
+		return
+	}
+
+	ticker := time.NewTicker(idempotencyKeyPurgeInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.idempotencyRepo.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("ERROR: purging expired idempotency keys failed: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// CreateStateIdempotent creates a state like CreateState, deduplicating retries
+// by idempotencyKey. If the key was used within its TTL with the same logic_id
+// and labels, the state created by that call is returned (the GUID of the
+// retry is ignored). Reusing a key with a different payload is rejected.
+// An empty key, or a service without idempotency keys enabled, creates normally.
+func (s *Service) CreateStateIdempotent(ctx context.Context, idempotencyKey, guid, logicID string, labels models.LabelMap, createdBy string) (*StateSummary, *BackendConfig, error) {
+	if idempotencyKey == "" || s.idempotencyRepo == nil {
+		return s.CreateState(ctx, guid, logicID, labels, createdBy)
+	}
+	if len(idempotencyKey) > maxIdempotencyKeyLength || strings.TrimSpace(idempotencyKey) != idempotencyKey {
+		return nil, nil, fmt.Errorf("invalid idempotency_key: must be at most %d characters without surrounding whitespace", maxIdempotencyKeyLength)
+	}
+
+	requestHash, err := createStateRequestHash(logicID, labels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if prior, err := s.idempotencyRepo.Get(ctx, idempotencyKey); err == nil {
+		if prior.RequestHash != requestHash {
+			return nil, nil, fmt.Errorf("invalid idempotency_key: already used for a different CreateState request")
+		}
+		record, err := s.repo.GetByGUID(ctx, prior.StateGUID)
+		if err == nil {
+			summary := toSummary(record)
+			return &summary, s.backendConfig(record.GUID), nil
+		}
+		// The state was deleted since; fall through and create it afresh
+	}
+
+	summary, config, err := s.CreateState(ctx, guid, logicID, labels, createdBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	record := &models.StateIdempotencyKey{
+		Key:         idempotencyKey,
+		RequestHash: requestHash,
+		StateGUID:   summary.GUID,
+		ExpiresAt:   time.Now().Add(s.idempotencyTTL),
+	}
+	if err := s.idempotencyRepo.Put(ctx, record); err != nil {
+		// The state exists; a retry will find it by logic_id and fail with
+		// AlreadyExists rather than create a duplicate, so don't fail the call
+		fmt.Printf("storing idempotency key for state %s failed: %v\n", summary.GUID, err)
+	}
+
+	return summary, config, nil
+}
+
+// createStateRequestHash fingerprints the parts of a CreateState request that
+// must match for an idempotency key to be reused. encoding/json sorts map keys,
+// so equal label sets always hash the same.
+func createStateRequestHash(logicID string, labels models.LabelMap) (string, error) {
+	if labels == nil {
+		labels = models.LabelMap{}
+	}
+	payload, err := json.Marshal(struct {
+		LogicID string          `json:"logic_id"`
+		Labels  models.LabelMap `json:"labels"`
+	}{logicID, labels})
+	if err != nil {
+		return "", fmt.Errorf("hash create request: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ListStates returns summaries for all states ordered newest first. A non-nil
+// scope excludes states outside the caller's role scopes before they are loaded.
+func (s *Service) ListStates(ctx context.Context, scope *repository.ScopeFilter) ([]StateSummary, error) {
+	records, err := s.repo.List(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("list states: %w", err)
+	}
+
+	summaries := make([]StateSummary, 0, len(records))
+	for _, rec := range records {
+		recordCopy := rec
+		summaries = append(summaries, toSummary(&recordCopy))
+	}
+
+	return summaries, nil
+}
+
+// ListStatesAfter returns up to limit states ordered by (created_at, guid),
+// starting after the after cursor. Callers page through every state by passing
+// the position of the last state seen. Only states matching selector (see
+// repository.ParseLabelSelector) and scope, when non-nil, are returned.
+func (s *Service) ListStatesAfter(ctx context.Context, selector repository.LabelSelector, scope *repository.ScopeFilter, after *repository.StateCursor, limit int) ([]StateSummary, error) {
+	records, err := s.repo.ListAfter(ctx, selector, scope, after, limit)
 	if err != nil {
 		return nil, fmt.Errorf("list states: %w", err)
 	}
@@ -160,8 +362,9 @@ func (s *Service) ListStates(ctx context.Context) ([]StateSummary, error) {
 }
 
 // ListStatesWithFilter returns states matching bexpr filter with pagination.
-func (s *Service) ListStatesWithFilter(ctx context.Context, filter string, pageSize int, offset int) ([]StateSummary, error) {
-	states, err := s.repo.ListWithFilter(ctx, filter, pageSize, offset)
+// A non-nil scope is applied in SQL ahead of the filter.
+func (s *Service) ListStatesWithFilter(ctx context.Context, filter string, scope *repository.ScopeFilter, pageSize int, offset int) ([]StateSummary, error) {
+	states, err := s.repo.ListWithFilter(ctx, filter, scope, pageSize, offset)
 	if err != nil {
 		return nil, fmt.Errorf("list states with filter: %w", err)
 	}
@@ -177,8 +380,10 @@ func (s *Service) ListStatesWithFilter(ctx context.Context, filter string, pageS
 
 // GetStateConfig resolves backend configuration for a state by logic ID.
 func (s *Service) GetStateConfig(ctx context.Context, logicID string) (string, *BackendConfig, error) {
-	if err := validateLogicID(logicID); err != nil {
-		return "", nil, err
+	// Naming rules are only enforced at create time so states created
+	// under older (or looser) rules remain addressable.
+	if logicID == "" {
+		return "", nil, fmt.Errorf("logic_id is required")
 	}
 
 	record, err := s.repo.GetByLogicID(ctx, logicID)
@@ -209,6 +414,16 @@ func (s *Service) GetStatesByGUIDs(ctx context.Context, guids []string) (map[str
 	return stateMap, nil
 }
 
+// GetStatesByLogicIDs retrieves multiple states by logic ID in a single query.
+// Returns a map of logic ID -> State. Missing logic IDs are omitted from result.
+func (s *Service) GetStatesByLogicIDs(ctx context.Context, logicIDs []string) (map[string]*models.State, error) {
+	stateMap, err := s.repo.GetByLogicIDs(ctx, logicIDs)
+	if err != nil {
+		return nil, fmt.Errorf("batch get states by logic IDs: %w", err)
+	}
+	return stateMap, nil
+}
+
 // GetEdgeByID retrieves an edge by ID for authorization checks
 func (s *Service) GetEdgeByID(ctx context.Context, edgeID int64) (*models.Edge, error) {
 	edge, err := s.edgeRepo.GetByID(ctx, edgeID)
@@ -245,6 +460,8 @@ func (s *Service) UnlockState(ctx context.Context, guid, lockID string) error {
 type StateUpdateResult struct {
 	Summary      *StateSummary
 	OutputValues map[string]interface{} // Parsed output values for edge job
+	// OutputsWarning is set when the state is close to the per-state output cap
+	OutputsWarning string
 }
 
 // UpdateStateContent replaces the stored Terraform state payload.
@@ -262,6 +479,11 @@ func (s *Service) UpdateStateContent(ctx context.Context, guid string, content [
 		return nil, fmt.Errorf("parse state: %w", err)
 	}
 
+	outputsWarning, err := s.checkOutputCount(ctx, guid, len(parsed.Keys))
+	if err != nil {
+		return nil, err
+	}
+
 	// Use atomic update method to ensure state and outputs are consistent (FR-027)
 	// Both operations happen in ONE transaction via repository.UpdateContentAndUpsertOutputs
 	err = s.repo.UpdateContentAndUpsertOutputs(ctx, guid, content, lockID, parsed.Serial, parsed.Keys)
@@ -298,7 +520,7 @@ func (s *Service) UpdateStateContent(ctx context.Context, guid string, content [
 			}
 
 			// Infer schemas for outputs that need them
-			inferred, err := s.inferrer.InferSchemas(inferCtx, guid, parsed.Values, needsSchema)
+			inferred, err := s.inferrer.InferSchemas(inferCtx, guid, parsed.Values, parsed.Types, needsSchema)
 			if err != nil {
 				// Log error but don't fail state upload
 				fmt.Printf("InferSchemas failed for state %s: %v\n", guid, err)
@@ -319,11 +541,32 @@ func (s *Service) UpdateStateContent(ctx context.Context, guid string, content [
 
 	summary := toSummary(record)
 	return &StateUpdateResult{
-		Summary:      &summary,
-		OutputValues: parsed.Values,
+		Summary:        &summary,
+		OutputValues:   parsed.Values,
+		OutputsWarning: outputsWarning,
 	}, nil
 }
 
+// checkOutputCount enforces the per-state output cap. It returns a warning
+// (and logs and counts it) when count is within outputsWarningPercent of the
+// cap.
+func (s *Service) checkOutputCount(ctx context.Context, guid string, count int) (string, error) {
+	if s.maxOutputsPerState == 0 {
+		return "", nil
+	}
+	if count > s.maxOutputsPerState {
+		return "", fmt.Errorf("%w: state %s declares %d outputs, the limit is %d", ErrTooManyOutputs, guid, count, s.maxOutputsPerState)
+	}
+	if count*100 < s.maxOutputsPerState*outputsWarningPercent {
+		return "", nil
+	}
+
+	warning := fmt.Sprintf("State declares %d outputs, approaching the limit of %d", count, s.maxOutputsPerState)
+	log.Printf("WARNING: state %s declares %d outputs, approaching the limit of %d", guid, count, s.maxOutputsPerState)
+	outputsNearLimit.Add(ctx, 1)
+	return warning, nil
+}
+
 // LockState acquires a lock for the given state.
 func (s *Service) LockState(ctx context.Context, guid string, lockInfo *models.LockInfo) error {
 	if lockInfo == nil {
@@ -341,10 +584,18 @@ func (s *Service) LockState(ctx context.Context, guid string, lockInfo *models.L
 }
 
 func (s *Service) backendConfig(guid string) *BackendConfig {
+	return NewBackendConfig(s.serverURL, guid)
+}
+
+// NewBackendConfig builds the Terraform HTTP backend endpoints for a state.
+// The GUID is escaped as a single path segment so the addresses are always
+// well-formed, and a trailing slash on serverURL is tolerated.
+func NewBackendConfig(serverURL, guid string) *BackendConfig {
+	address := strings.TrimRight(serverURL, "/") + "/tfstate/" + url.PathEscape(guid)
 	return &BackendConfig{
-		Address:       fmt.Sprintf("%s/tfstate/%s", s.serverURL, guid),
-		LockAddress:   fmt.Sprintf("%s/tfstate/%s/lock", s.serverURL, guid),
-		UnlockAddress: fmt.Sprintf("%s/tfstate/%s/unlock", s.serverURL, guid),
+		Address:       address,
+		LockAddress:   address + "/lock",
+		UnlockAddress: address + "/unlock",
 	}
 }
 
@@ -371,22 +622,41 @@ func toSummary(record *models.State) StateSummary {
 		CreatedAt:         record.CreatedAt,
 		UpdatedAt:         record.UpdatedAt,
 		Labels:            labels,
+		CreatedBy:         record.CreatedBy,
 		DependenciesCount: record.DependenciesCount,
 		DependentsCount:   record.DependentsCount,
 		OutputsCount:      record.OutputsCount,
 	}
 }
 
-func validateLogicID(logicID string) error {
+// validateLogicID enforces the configured naming rules. Characters that would
+// corrupt a URL path segment are rejected even if a custom pattern allows them.
+func (s *Service) validateLogicID(logicID string) error {
 	if logicID == "" {
 		return fmt.Errorf("logic_id is required")
 	}
-	if len(logicID) > 128 {
-		return fmt.Errorf("logic_id exceeds maximum length of 128 characters")
+	if len(logicID) > s.logicIDMaxLength {
+		return fmt.Errorf("invalid logic_id: exceeds maximum length of %d characters", s.logicIDMaxLength)
+	}
+	for _, r := range logicID {
+		if isUnsafeLogicIDRune(r) {
+			return fmt.Errorf("invalid logic_id %q: character %q is not allowed", logicID, r)
+		}
+	}
+	if !s.logicIDPattern.MatchString(logicID) {
+		return fmt.Errorf("invalid logic_id %q: must match pattern %s", logicID, s.logicIDPattern.String())
 	}
 	return nil
 }
 
+func isUnsafeLogicIDRune(r rune) bool {
+	switch r {
+	case '/', '\\', '?', '#', '%':
+		return true
+	}
+	return unicode.IsSpace(r) || unicode.IsControl(r)
+}
+
 // GetOutputKeys retrieves output keys with sensitive flags from cached state outputs.
 // Falls back to parsing state JSON if cache is unavailable or empty.
 func (s *Service) GetOutputKeys(ctx context.Context, guid string) ([]repository.OutputKey, error) {
@@ -420,6 +690,119 @@ func (s *Service) GetOutputKeys(ctx context.Context, guid string) ([]repository.
 	return tfOutputs, nil
 }
 
+// StateOutputs holds the outputs of one state returned by GetOutputsBatch.
+type StateOutputs struct {
+	Outputs []repository.OutputKey
+	// Values maps output keys to their values. Only populated when requested,
+	// and never contains sensitive outputs.
+	Values map[string]interface{}
+}
+
+// GetOutputsBatch returns outputs for several already-loaded states, keyed by
+// state GUID. Cached outputs are read in a single query; states without cached
+// outputs fall back to parsing their state JSON, as GetOutputKeys does.
+// When includeValues is set, non-sensitive output values are parsed from each
+// state's JSON.
+func (s *Service) GetOutputsBatch(ctx context.Context, states []*models.State, includeValues bool) (map[string]StateOutputs, error) {
+	cached := make(map[string][]repository.OutputKey)
+	if s.outputRepo != nil && len(states) > 0 {
+		guids := make([]string, len(states))
+		for i, state := range states {
+			guids[i] = state.GUID
+		}
+		var err error
+		cached, err = s.outputRepo.GetOutputsByStates(ctx, guids)
+		if err != nil {
+			return nil, fmt.Errorf("batch get outputs: %w", err)
+		}
+	}
+
+	result := make(map[string]StateOutputs, len(states))
+	for _, state := range states {
+		outputs, ok := cached[state.GUID]
+		if !ok {
+			parsed, err := tfstate.ParseOutputKeys(state.StateContent)
+			if err != nil {
+				return nil, fmt.Errorf("parse output keys for state %s: %w", state.GUID, err)
+			}
+			outputs = parsed
+		}
+
+		entry := StateOutputs{Outputs: outputs}
+		if includeValues {
+			values, err := tfstate.ParseOutputs(state.StateContent)
+			if err != nil {
+				return nil, fmt.Errorf("parse outputs for state %s: %w", state.GUID, err)
+			}
+			entry.Values = make(map[string]interface{}, len(outputs))
+			for _, out := range outputs {
+				if val, ok := values[out.Key]; ok && !out.Sensitive {
+					entry.Values[out.Key] = val
+				}
+			}
+		}
+		result[state.GUID] = entry
+	}
+
+	return result, nil
+}
+
+// OutputValue is a single output returned by GetOutputValues.
+type OutputValue struct {
+	Key       string
+	Sensitive bool
+	Value     interface{}
+	// Redacted is set instead of Value for sensitive outputs the caller may not read.
+	Redacted bool
+}
+
+// GetOutputValues returns the values of the given outputs of an already-loaded
+// state, in the order requested. Unknown keys are skipped, and an empty keys list
+// returns every output sorted by key. Sensitive values are redacted unless
+// includeSensitive is set.
+func (s *Service) GetOutputValues(state *models.State, keys []string, includeSensitive bool) ([]OutputValue, error) {
+	outputKeys, err := tfstate.ParseOutputKeys(state.StateContent)
+	if err != nil {
+		return nil, fmt.Errorf("parse output keys for state %s: %w", state.GUID, err)
+	}
+	values, err := tfstate.ParseOutputs(state.StateContent)
+	if err != nil {
+		return nil, fmt.Errorf("parse outputs for state %s: %w", state.GUID, err)
+	}
+
+	sensitive := make(map[string]bool, len(outputKeys))
+	for _, out := range outputKeys {
+		sensitive[out.Key] = out.Sensitive
+	}
+	if len(keys) == 0 {
+		keys = make([]string, 0, len(outputKeys))
+		for _, out := range outputKeys {
+			keys = append(keys, out.Key)
+		}
+		sort.Strings(keys)
+	}
+
+	result := make([]OutputValue, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		isSensitive, ok := sensitive[key]
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		out := OutputValue{Key: key, Sensitive: isSensitive}
+		if isSensitive && !includeSensitive {
+			out.Redacted = true
+		} else {
+			out.Value = values[key]
+		}
+		result = append(result, out)
+	}
+
+	return result, nil
+}
+
 // UpdateLabels modifies labels on a state with atomic updates and policy validation.
 // T032: Implements add/remove operations with validation and updated_at bump.
 // Accepts adds (key-value pairs to add/update) and removals (keys to remove).
@@ -464,6 +847,27 @@ func (s *Service) UpdateLabels(ctx context.Context, guid string, adds models.Lab
 	return nil
 }
 
+// TransferOwnership records newOwner (a principal ID such as "user:alice") as
+// the state's owner and returns the previous owner, empty if none was recorded.
+// Owner override access follows created_by, so it moves to the new owner.
+// A concurrent transfer of the same state fails with a conflict error.
+func (s *Service) TransferOwnership(ctx context.Context, guid, newOwner string) (string, error) {
+	if newOwner == "" {
+		return "", fmt.Errorf("invalid new owner: principal ID is required")
+	}
+
+	state, err := s.repo.GetByGUID(ctx, guid)
+	if err != nil {
+		return "", fmt.Errorf("get state: %w", err)
+	}
+
+	// Only created_by is written, and only if no one transferred it meanwhile
+	if err := s.repo.TransferOwner(ctx, guid, state.CreatedBy, newOwner); err != nil {
+		return "", err
+	}
+	return state.CreatedBy, nil
+}
+
 // GetStateInfo retrieves comprehensive state information including dependencies, dependents, and outputs.
 // This consolidates multiple data sources into a single response using eager loading to avoid N+1 queries.
 func (s *Service) GetStateInfo(ctx context.Context, logicID, guid string) (*StateInfo, error) {
@@ -501,13 +905,16 @@ func (s *Service) GetStateInfo(ctx context.Context, logicID, guid string) (*Stat
 		outputs := make([]repository.OutputKey, len(state.Outputs))
 		for i, out := range state.Outputs {
 			outputs[i] = repository.OutputKey{
-				Key:              out.OutputKey,
-				Sensitive:        out.Sensitive,
-				SchemaJSON:       out.SchemaJSON,
-				SchemaSource:     out.SchemaSource,
-				ValidationStatus: out.ValidationStatus,
-				ValidationError:  out.ValidationError,
-				ValidatedAt:      out.ValidatedAt,
+				Key:                    out.OutputKey,
+				Sensitive:              out.Sensitive,
+				SchemaJSON:             out.SchemaJSON,
+				SchemaSource:           out.SchemaSource,
+				SchemaRef:              out.SchemaRef,
+				SchemaVersion:          out.SchemaVersion,
+				ValidationStatus:       out.ValidationStatus,
+				ValidationError:        out.ValidationError,
+				ValidatedAt:            out.ValidatedAt,
+				ValidatedSchemaVersion: out.ValidatedSchemaVersion,
 			}
 		}
 		info.Outputs = outputs
@@ -548,7 +955,7 @@ func (s *Service) SetOutputSchema(ctx context.Context, guid string, outputKey st
 
 	// Validate that schemaJSON is not empty
 	if schemaJSON == "" {
-		return fmt.Errorf("schema JSON cannot be empty")
+		return fmt.Errorf("schema JSON is required")
 	}
 
 	// Validate that schemaJSON is a valid JSON Schema
@@ -569,6 +976,12 @@ func (s *Service) SetOutputSchemaAndCheckExists(ctx context.Context, guid string
 		return false, err
 	}
 
+	return s.outputInState(ctx, guid, outputKey)
+}
+
+// outputInState reports whether outputKey has a value in the state's current
+// Terraform state, as opposed to only a pre-declared schema.
+func (s *Service) outputInState(ctx context.Context, guid string, outputKey string) (bool, error) {
 	if s.outputRepo == nil {
 		return false, fmt.Errorf("output repository not configured")
 	}
@@ -592,9 +1005,80 @@ func (s *Service) SetOutputSchemaAndCheckExists(ctx context.Context, guid string
 	return false, nil
 }
 
+// OutputSchemaResult reports how one schema in a bulk write was stored.
+type OutputSchemaResult struct {
+	OutputKey string
+	// Created is true if the output had no schema before the write.
+	Created bool
+	// OutputExists is true if the output has a value in Terraform state
+	// (state_serial > 0), so validation can run against it.
+	OutputExists bool
+}
+
+// SetOutputSchemas sets JSON Schemas for several outputs of a state at once.
+// Every schema is validated before anything is written, and the writes happen
+// in a single transaction so a failure leaves existing schemas untouched.
+// Results are sorted by output key.
+func (s *Service) SetOutputSchemas(ctx context.Context, guid string, schemas map[string]string) ([]OutputSchemaResult, error) {
+	if s.outputRepo == nil {
+		return nil, fmt.Errorf("output repository not configured")
+	}
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("at least one schema is required")
+	}
+
+	// Validate that state exists
+	if _, err := s.repo.GetByGUID(ctx, guid); err != nil {
+		return nil, fmt.Errorf("state not found: %w", err)
+	}
+
+	keys := make([]string, 0, len(schemas))
+	for key, schemaJSON := range schemas {
+		if key == "" {
+			return nil, fmt.Errorf("output key is required")
+		}
+		if schemaJSON == "" {
+			return nil, fmt.Errorf("schema JSON is required for output %s", key)
+		}
+		if err := validateJSONSchema(schemaJSON); err != nil {
+			return nil, fmt.Errorf("invalid JSON Schema for output %s: %w", key, err)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	created, err := s.outputRepo.SetOutputSchemas(ctx, guid, schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := s.outputRepo.GetOutputsByState(ctx, guid)
+	if err != nil {
+		return nil, fmt.Errorf("get outputs: %w", err)
+	}
+	inState := make(map[string]bool, len(outputs))
+	for i := range outputs {
+		if outputs[i].StateSerial > 0 {
+			inState[outputs[i].Key] = true
+		}
+	}
+
+	results := make([]OutputSchemaResult, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, OutputSchemaResult{
+			OutputKey:    key,
+			Created:      created[key],
+			OutputExists: inState[key],
+		})
+	}
+	return results, nil
+}
+
 // GetStateOutputValue returns the value of a specific output from the state JSON.
 // Returns nil if the output is not found in the state.
 // This is a helper for validation logic that needs the actual output value.
+// The value is returned unredacted even for sensitive outputs; the schema
+// validation job masks sensitive values in the errors it records.
 func (s *Service) GetStateOutputValue(ctx context.Context, guid string, outputKey string) (interface{}, error) {
 	// Fetch state content
 	state, err := s.repo.GetByGUID(ctx, guid)
@@ -620,9 +1104,17 @@ func (s *Service) GetStateOutputValue(ctx context.Context, guid string, outputKe
 	return val, nil
 }
 
+// maxOutputSchemaBytes bounds the size of a stored output schema. Output
+// contracts are small; anything larger is almost certainly not a schema.
+const maxOutputSchemaBytes = 64 * 1024
+
 // validateJSONSchema validates that a JSON string is a valid JSON Schema.
 // Uses the same jsonschema library as the validation job (github.com/santhosh-tekuri/jsonschema/v6).
 func validateJSONSchema(schemaJSON string) error {
+	if len(schemaJSON) > maxOutputSchemaBytes {
+		return fmt.Errorf("schema is %d bytes, exceeds the maximum of %d bytes", len(schemaJSON), maxOutputSchemaBytes)
+	}
+
 	// Parse schema JSON
 	parsed, err := jsonschema.UnmarshalJSON(strings.NewReader(schemaJSON))
 	if err != nil {
@@ -678,3 +1170,108 @@ func (s *Service) GetOutputSchema(ctx context.Context, guid string, outputKey st
 
 	return s.outputRepo.GetOutputSchema(ctx, guid, outputKey)
 }
+
+// ListOutputSchemaHistory returns every schema the output has had, oldest first.
+func (s *Service) ListOutputSchemaHistory(ctx context.Context, guid string, outputKey string) ([]models.OutputSchemaVersion, error) {
+	if s.outputRepo == nil {
+		return nil, fmt.Errorf("output repository not configured")
+	}
+	if outputKey == "" {
+		return nil, fmt.Errorf("output key is required")
+	}
+
+	// Validate that state exists
+	if _, err := s.repo.GetByGUID(ctx, guid); err != nil {
+		return nil, fmt.Errorf("state not found: %w", err)
+	}
+
+	return s.outputRepo.ListSchemaVersions(ctx, guid, outputKey)
+}
+
+// Validation statuses recorded on state outputs by the schema validation job.
+const (
+	ValidationStatusValid        = "valid"
+	ValidationStatusInvalid      = "invalid"
+	ValidationStatusError        = "error"
+	ValidationStatusNotValidated = "not_validated"
+)
+
+// OutputValidationIssue describes an output whose last validation did not pass.
+type OutputValidationIssue struct {
+	OutputKey   string
+	Status      string // "invalid" or "error"
+	Error       string
+	ValidatedAt *time.Time
+}
+
+// ValidationSummary aggregates the stored validation results of a state's outputs.
+type ValidationSummary struct {
+	TotalOutputs int
+	Valid        int
+	Invalid      int
+	Errored      int
+	NotValidated int
+	// Issues lists every invalid or errored output, sorted by output key.
+	Issues []OutputValidationIssue
+	// LastValidatedAt is the newest ValidatedAt across all outputs, nil if
+	// no output has been validated yet.
+	LastValidatedAt *time.Time
+}
+
+// GetValidationSummary aggregates the validation results already stored for a
+// state's outputs. It does not trigger validation.
+func (s *Service) GetValidationSummary(ctx context.Context, guid string) (*ValidationSummary, error) {
+	outputs, err := s.GetOutputKeys(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeValidation(outputs), nil
+}
+
+// summarizeValidation counts outputs by validation status. Outputs without a
+// recorded status are counted as not validated.
+func summarizeValidation(outputs []repository.OutputKey) *ValidationSummary {
+	summary := &ValidationSummary{
+		TotalOutputs: len(outputs),
+		Issues:       []OutputValidationIssue{},
+	}
+
+	for _, out := range outputs {
+		status := ValidationStatusNotValidated
+		if out.ValidationStatus != nil && *out.ValidationStatus != "" {
+			status = *out.ValidationStatus
+		}
+
+		switch status {
+		case ValidationStatusValid:
+			summary.Valid++
+		case ValidationStatusInvalid, ValidationStatusError:
+			if status == ValidationStatusInvalid {
+				summary.Invalid++
+			} else {
+				summary.Errored++
+			}
+			issue := OutputValidationIssue{
+				OutputKey:   out.Key,
+				Status:      status,
+				ValidatedAt: out.ValidatedAt,
+			}
+			if out.ValidationError != nil {
+				issue.Error = *out.ValidationError
+			}
+			summary.Issues = append(summary.Issues, issue)
+		default:
+			summary.NotValidated++
+		}
+
+		if out.ValidatedAt != nil && (summary.LastValidatedAt == nil || out.ValidatedAt.After(*summary.LastValidatedAt)) {
+			validatedAt := *out.ValidatedAt
+			summary.LastValidatedAt = &validatedAt
+		}
+	}
+
+	sort.Slice(summary.Issues, func(i, j int) bool {
+		return summary.Issues[i].OutputKey < summary.Issues[j].OutputKey
+	})
+	return summary
+}
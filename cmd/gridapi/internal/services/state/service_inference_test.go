@@ -0,0 +1,123 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/dbtest"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
+)
+
+// stringInferrer infers {"type":"string"} for every output that needs a schema.
+type stringInferrer struct{}
+
+func (stringInferrer) InferSchemas(_ context.Context, _ string, outputs map[string]interface{}, _ map[string]json.RawMessage, needsSchema []string) ([]InferredSchema, error) {
+	var inferred []InferredSchema
+	for _, key := range needsSchema {
+		if _, ok := outputs[key]; ok {
+			inferred = append(inferred, InferredSchema{OutputKey: key, SchemaJSON: `{"type":"string"}`})
+		}
+	}
+	return inferred, nil
+}
+
+func TestUpdateStateContent_InferredOutputsKeepSensitiveFlag(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	outputRepo := repository.NewBunStateOutputRepository(db)
+	svc := NewService(stateRepo, "http://localhost").
+		WithOutputRepository(outputRepo).
+		WithInferrer(stringInferrer{})
+
+	state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: "prod-db-passwords"}
+	require.NoError(t, stateRepo.Create(ctx, state))
+
+	content := []byte(`{"version":4,"serial":1,"outputs":{` +
+		`"db_password":{"value":"hunter2","type":"string","sensitive":true},` +
+		`"db_host":{"value":"db.internal","type":"string"}}}`)
+	_, err := svc.UpdateStateContent(ctx, state.GUID, content, "")
+	require.NoError(t, err)
+
+	var outputs []repository.OutputKey
+	require.Eventually(t, func() bool {
+		var err error
+		outputs, err = outputRepo.GetOutputsByState(ctx, state.GUID)
+		if err != nil {
+			return false
+		}
+		for _, out := range outputs {
+			if out.SchemaSource == nil || *out.SchemaSource != "inferred" {
+				return false
+			}
+		}
+		return len(outputs) == 2
+	}, 5*time.Second, 10*time.Millisecond, "inference should write a schema for every output")
+
+	sensitive := map[string]bool{}
+	for _, out := range outputs {
+		sensitive[out.Key] = out.Sensitive
+	}
+	assert.Equal(t, map[string]bool{"db_host": false, "db_password": true}, sensitive)
+}
+
+// TestUpsertOutputs_MixedSensitiveFlags checks that every row of a
+// multi-row upsert keeps its own sensitive flag, whichever row comes first and
+// without an inferrer rewriting the rows afterwards.
+func TestUpsertOutputs_MixedSensitiveFlags(t *testing.T) {
+	db := dbtest.NewSQLite(t)
+
+	ctx := context.Background()
+
+	stateRepo := repository.NewBunStateRepository(db)
+	outputRepo := repository.NewBunStateOutputRepository(db)
+	svc := NewService(stateRepo, "http://localhost").WithOutputRepository(outputRepo)
+
+	sensitiveByKey := func(guid string) map[string]bool {
+		outputs, err := outputRepo.GetOutputsByState(ctx, guid)
+		require.NoError(t, err)
+		sensitive := map[string]bool{}
+		for _, out := range outputs {
+			sensitive[out.Key] = out.Sensitive
+		}
+		return sensitive
+	}
+
+	t.Run("UpsertOutputs", func(t *testing.T) {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: "upsert-outputs"}
+		require.NoError(t, stateRepo.Create(ctx, state))
+
+		// A non-sensitive first row must not drop the column for the others
+		require.NoError(t, outputRepo.UpsertOutputs(ctx, state.GUID, 1, []repository.OutputKey{
+			{Key: "db_host"}, {Key: "db_password", Sensitive: true}, {Key: "api_key", Sensitive: true},
+		}))
+		assert.Equal(t, map[string]bool{"db_host": false, "db_password": true, "api_key": true}, sensitiveByKey(state.GUID))
+
+		// Flags follow the latest serial in both directions
+		require.NoError(t, outputRepo.UpsertOutputs(ctx, state.GUID, 2, []repository.OutputKey{
+			{Key: "db_host", Sensitive: true}, {Key: "db_password"}, {Key: "api_key", Sensitive: true},
+		}))
+		assert.Equal(t, map[string]bool{"db_host": true, "db_password": false, "api_key": true}, sensitiveByKey(state.GUID))
+	})
+
+	t.Run("UpdateStateContent", func(t *testing.T) {
+		state := &models.State{GUID: uuid.Must(uuid.NewV7()).String(), LogicID: "update-content"}
+		require.NoError(t, stateRepo.Create(ctx, state))
+
+		content := []byte(`{"version":4,"serial":1,"outputs":{` +
+			`"a_host":{"value":"db.internal","type":"string"},` +
+			`"b_password":{"value":"hunter2","type":"string","sensitive":true},` +
+			`"c_token":{"value":"t0ken","type":"string","sensitive":true}}}`)
+		_, err := svc.UpdateStateContent(ctx, state.GUID, content, "")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"a_host": false, "b_password": true, "c_token": true}, sensitiveByKey(state.GUID))
+	})
+}
@@ -2,12 +2,16 @@ package state
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/db/models"
 	"github.com/terraconstructs/grid/cmd/gridapi/internal/repository"
 )
@@ -43,8 +47,8 @@ func (m *MockStateRepository) Update(ctx context.Context, state *models.State) e
 	return args.Error(0)
 }
 
-func (m *MockStateRepository) List(ctx context.Context) ([]models.State, error) {
-	args := m.Called(ctx)
+func (m *MockStateRepository) List(ctx context.Context, scope *repository.ScopeFilter) ([]models.State, error) {
+	args := m.Called(ctx, scope)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -61,13 +65,18 @@ func (m *MockStateRepository) Unlock(ctx context.Context, guid string, lockID st
 	return args.Error(0)
 }
 
+func (m *MockStateRepository) TransferOwner(ctx context.Context, guid, expectedOwner, newOwner string) error {
+	args := m.Called(ctx, guid, expectedOwner, newOwner)
+	return args.Error(0)
+}
+
 func (m *MockStateRepository) UpdateContentAndUpsertOutputs(ctx context.Context, guid string, stateContent []byte, lockID string, serial int64, outputs []repository.OutputKey) error {
 	args := m.Called(ctx, guid, stateContent, lockID, serial, outputs)
 	return args.Error(0)
 }
 
-func (m *MockStateRepository) ListWithFilter(ctx context.Context, filter string, pageSize int, offset int) ([]models.State, error) {
-	args := m.Called(ctx, filter, pageSize, offset)
+func (m *MockStateRepository) ListWithFilter(ctx context.Context, filter string, scope *repository.ScopeFilter, pageSize int, offset int) ([]models.State, error) {
+	args := m.Called(ctx, filter, scope, pageSize, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -82,6 +91,14 @@ func (m *MockStateRepository) GetByGUIDs(ctx context.Context, guids []string) (m
 	return args.Get(0).(map[string]*models.State), args.Error(1)
 }
 
+func (m *MockStateRepository) GetByLogicIDs(ctx context.Context, logicIDs []string) (map[string]*models.State, error) {
+	args := m.Called(ctx, logicIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*models.State), args.Error(1)
+}
+
 func (m *MockStateRepository) GetByGUIDWithRelations(ctx context.Context, guid string, relations ...string) (*models.State, error) {
 	args := m.Called(ctx, guid, relations)
 	if args.Get(0) == nil {
@@ -98,6 +115,16 @@ func (m *MockStateRepository) ListStatesWithOutputs(ctx context.Context) ([]*mod
 	return args.Get(0).([]*models.State), args.Error(1)
 }
 
+func (m *MockStateRepository) CountMatching(ctx context.Context, filter string) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStateRepository) ListAfter(ctx context.Context, selector repository.LabelSelector, scope *repository.ScopeFilter, after *repository.StateCursor, limit int) ([]models.State, error) {
+	args := m.Called(ctx, selector, scope, after, limit)
+	return args.Get(0).([]models.State), args.Error(1)
+}
+
 // T015: Test StateService.CreateState with labels
 func TestStateService_CreateStateWithLabels(t *testing.T) {
 	t.Run("creates state with valid labels", func(t *testing.T) {
@@ -138,7 +165,7 @@ func TestStateService_CreateStateWithLabels(t *testing.T) {
 				s.Labels["team"] == "platform"
 		})).Return(nil)
 
-		_, _, err := service.CreateState(ctx, guid, logicID, labels)
+		_, _, err := service.CreateState(ctx, guid, logicID, labels, "")
 		assert.NoError(t, err)
 
 		mockRepo.AssertExpectations(t)
@@ -171,7 +198,7 @@ func TestStateService_CreateStateWithLabels(t *testing.T) {
 		mockPolicyRepo.On("GetPolicy", ctx).Return(policy, nil)
 
 		// CreateState should fail validation
-		_, _, err := service.CreateState(ctx, guid, logicID, labels)
+		_, _, err := service.CreateState(ctx, guid, logicID, labels, "")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
@@ -180,6 +207,233 @@ func TestStateService_CreateStateWithLabels(t *testing.T) {
 	})
 }
 
+func TestStateService_CreateStateLogicIDValidation(t *testing.T) {
+	t.Run("accepts valid logic_id", func(t *testing.T) {
+		mockRepo := new(MockStateRepository)
+		service := NewService(mockRepo, "http://localhost:8080")
+		ctx := context.Background()
+
+		guid := uuid.NewString()
+		logicID := "test-output-happy-1712345678901234567"
+
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(s *models.State) bool {
+			return s.LogicID == logicID
+		})).Return(nil)
+
+		_, config, err := service.CreateState(ctx, guid, logicID, nil, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080/tfstate/"+guid, config.Address)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	for _, logicID := range []string{"team/network", "prod network", "prod\tnetwork", "-leading-dash"} {
+		t.Run("rejects "+logicID, func(t *testing.T) {
+			mockRepo := new(MockStateRepository)
+			service := NewService(mockRepo, "http://localhost:8080")
+
+			_, _, err := service.CreateState(context.Background(), uuid.NewString(), logicID, nil, "")
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid logic_id")
+
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+
+	t.Run("custom rules cannot allow slashes", func(t *testing.T) {
+		mockRepo := new(MockStateRepository)
+		service := NewService(mockRepo, "http://localhost:8080").
+			WithLogicIDRules(regexp.MustCompile(`^.+$`), 16)
+
+		_, _, err := service.CreateState(context.Background(), uuid.NewString(), "team/network", nil, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not allowed")
+
+		_, _, err = service.CreateState(context.Background(), uuid.NewString(), "a-very-long-logic-id", nil, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "maximum length of 16")
+
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestStateService_UpdateStateContentOutputCap(t *testing.T) {
+	stateWithOutputs := func(n int) []byte {
+		outputs := make([]string, n)
+		for i := range outputs {
+			outputs[i] = fmt.Sprintf(`"out_%d":{"value":%d,"type":"number"}`, i, i)
+		}
+		return []byte(`{"version":4,"serial":3,"outputs":{` + strings.Join(outputs, ",") + `}}`)
+	}
+
+	t.Run("rejects states over the cap", func(t *testing.T) {
+		mockRepo := new(MockStateRepository)
+		service := NewService(mockRepo, "http://localhost:8080").WithMaxOutputsPerState(10)
+		guid := uuid.NewString()
+
+		_, err := service.UpdateStateContent(context.Background(), guid, stateWithOutputs(11), "")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrTooManyOutputs)
+		assert.Contains(t, err.Error(), "declares 11 outputs, the limit is 10")
+
+		mockRepo.AssertNotCalled(t, "UpdateContentAndUpsertOutputs")
+	})
+
+	t.Run("warns when approaching the cap", func(t *testing.T) {
+		mockRepo := new(MockStateRepository)
+		service := NewService(mockRepo, "http://localhost:8080").WithMaxOutputsPerState(10)
+		ctx := context.Background()
+		guid := uuid.NewString()
+
+		mockRepo.On("UpdateContentAndUpsertOutputs", ctx, guid, mock.Anything, "", int64(3), mock.Anything).Return(nil)
+		mockRepo.On("GetByGUID", ctx, guid).Return(&models.State{GUID: guid}, nil)
+
+		result, err := service.UpdateStateContent(ctx, guid, stateWithOutputs(9), "")
+		require.NoError(t, err)
+		assert.Contains(t, result.OutputsWarning, "approaching the limit of 10")
+
+		result, err = service.UpdateStateContent(ctx, guid, stateWithOutputs(8), "")
+		require.NoError(t, err)
+		assert.Empty(t, result.OutputsWarning)
+	})
+
+	t.Run("zero disables the cap", func(t *testing.T) {
+		mockRepo := new(MockStateRepository)
+		service := NewService(mockRepo, "http://localhost:8080").WithMaxOutputsPerState(0)
+		ctx := context.Background()
+		guid := uuid.NewString()
+
+		mockRepo.On("UpdateContentAndUpsertOutputs", ctx, guid, mock.Anything, "", int64(3), mock.Anything).Return(nil)
+		mockRepo.On("GetByGUID", ctx, guid).Return(&models.State{GUID: guid}, nil)
+
+		result, err := service.UpdateStateContent(ctx, guid, stateWithOutputs(50), "")
+		require.NoError(t, err)
+		assert.Empty(t, result.OutputsWarning)
+	})
+}
+
+// memoryIdempotencyRepo is an in-memory StateIdempotencyKeyRepository.
+type memoryIdempotencyRepo struct {
+	records map[string]*models.StateIdempotencyKey
+	purges  int
+	onPurge func()
+}
+
+func (m *memoryIdempotencyRepo) Get(_ context.Context, key string) (*models.StateIdempotencyKey, error) {
+	record, ok := m.records[key]
+	if !ok || !record.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("idempotency key not found")
+	}
+	return record, nil
+}
+
+func (m *memoryIdempotencyRepo) Put(_ context.Context, record *models.StateIdempotencyKey) error {
+	m.records[record.Key] = record
+	return nil
+}
+
+func (m *memoryIdempotencyRepo) DeleteExpired(context.Context) error {
+	m.purges++
+	if m.onPurge != nil {
+		m.onPurge()
+	}
+	return nil
+}
+
+func TestStateService_PurgeExpiredIdempotencyKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	keys := &memoryIdempotencyRepo{records: map[string]*models.StateIdempotencyKey{}, onPurge: cancel}
+	svc := NewService(new(MockStateRepository), "http://localhost:8080").WithIdempotencyKeys(keys, time.Hour)
+
+	// Purges at once, then stops when the context is done
+	svc.PurgeExpiredIdempotencyKeys(ctx)
+	assert.Equal(t, 1, keys.purges)
+
+	// Disabled idempotency keys leave nothing to purge
+	NewService(new(MockStateRepository), "http://localhost:8080").PurgeExpiredIdempotencyKeys(context.Background())
+}
+
+func TestStateService_CreateStateIdempotent(t *testing.T) {
+	ctx := context.Background()
+	labels := models.LabelMap{"env": "dev", "team": "platform"}
+
+	newService := func() (*Service, *MockStateRepository, *memoryIdempotencyRepo) {
+		mockRepo := new(MockStateRepository)
+		keys := &memoryIdempotencyRepo{records: map[string]*models.StateIdempotencyKey{}}
+		return NewService(mockRepo, "http://localhost:8080").WithIdempotencyKeys(keys, time.Hour), mockRepo, keys
+	}
+
+	t.Run("retry with the same key returns the original state", func(t *testing.T) {
+		service, mockRepo, keys := newService()
+		firstGUID := uuid.NewString()
+
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(s *models.State) bool { return s.GUID == firstGUID })).Return(nil).Once()
+		mockRepo.On("GetByGUID", ctx, firstGUID).Return(&models.State{GUID: firstGUID, LogicID: "network-dev", Labels: labels}, nil)
+
+		first, _, err := service.CreateStateIdempotent(ctx, "retry-1", firstGUID, "network-dev", labels, "")
+		require.NoError(t, err)
+		assert.Equal(t, firstGUID, first.GUID)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), keys.records["retry-1"].ExpiresAt, time.Minute)
+
+		// The retry carries a fresh GUID and labels in a different map order
+		retry, config, err := service.CreateStateIdempotent(ctx, "retry-1", uuid.NewString(), "network-dev",
+			models.LabelMap{"team": "platform", "env": "dev"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, firstGUID, retry.GUID)
+		assert.Equal(t, "http://localhost:8080/tfstate/"+firstGUID, config.Address)
+
+		mockRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("reusing a key with a different payload is rejected", func(t *testing.T) {
+		service, mockRepo, _ := newService()
+		mockRepo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+		_, _, err := service.CreateStateIdempotent(ctx, "retry-2", uuid.NewString(), "network-dev", labels, "")
+		require.NoError(t, err)
+
+		_, _, err = service.CreateStateIdempotent(ctx, "retry-2", uuid.NewString(), "network-prod", labels, "")
+		assert.ErrorContains(t, err, "invalid idempotency_key")
+
+		_, _, err = service.CreateStateIdempotent(ctx, "retry-2", uuid.NewString(), "network-dev", models.LabelMap{"env": "prod"}, "")
+		assert.ErrorContains(t, err, "invalid idempotency_key")
+
+		mockRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("expired key creates a new state", func(t *testing.T) {
+		service, mockRepo, keys := newService()
+		keys.records["retry-3"] = &models.StateIdempotencyKey{Key: "retry-3", StateGUID: uuid.NewString(), ExpiresAt: time.Now().Add(-time.Minute)}
+		mockRepo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+		guid := uuid.NewString()
+		summary, _, err := service.CreateStateIdempotent(ctx, "retry-3", guid, "network-dev", labels, "")
+		require.NoError(t, err)
+		assert.Equal(t, guid, summary.GUID)
+		assert.Equal(t, guid, keys.records["retry-3"].StateGUID)
+	})
+
+	t.Run("no key creates every time", func(t *testing.T) {
+		service, mockRepo, keys := newService()
+		mockRepo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+		_, _, err := service.CreateStateIdempotent(ctx, "", uuid.NewString(), "network-dev", labels, "")
+		require.NoError(t, err)
+		assert.Empty(t, keys.records)
+	})
+
+	t.Run("rejects malformed keys", func(t *testing.T) {
+		service, mockRepo, _ := newService()
+
+		_, _, err := service.CreateStateIdempotent(ctx, strings.Repeat("k", 256), uuid.NewString(), "network-dev", labels, "")
+		assert.ErrorContains(t, err, "invalid idempotency_key")
+		_, _, err = service.CreateStateIdempotent(ctx, " padded ", uuid.NewString(), "network-dev", labels, "")
+		assert.ErrorContains(t, err, "invalid idempotency_key")
+
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+}
+
 // T016: Test StateService.UpdateLabels
 func TestStateService_UpdateLabels(t *testing.T) {
 	t.Run("add and remove labels atomically", func(t *testing.T) {
@@ -327,3 +581,92 @@ func TestStateService_UpdateLabelsUpdatesTimestamp(t *testing.T) {
 		mockPolicyRepo.AssertExpectations(t)
 	})
 }
+
+func TestNewBackendConfig(t *testing.T) {
+	t.Run("normal GUID is unaffected", func(t *testing.T) {
+		guid := uuid.NewString()
+		config := NewBackendConfig("http://localhost:8080", guid)
+
+		assert.Equal(t, "http://localhost:8080/tfstate/"+guid, config.Address)
+		assert.Equal(t, "http://localhost:8080/tfstate/"+guid+"/lock", config.LockAddress)
+		assert.Equal(t, "http://localhost:8080/tfstate/"+guid+"/unlock", config.UnlockAddress)
+	})
+
+	t.Run("escapes path segment characters", func(t *testing.T) {
+		config := NewBackendConfig("https://grid.example.com/", "a/b c?d#e")
+
+		assert.Equal(t, "https://grid.example.com/tfstate/a%2Fb%20c%3Fd%23e", config.Address)
+		assert.Equal(t, "https://grid.example.com/tfstate/a%2Fb%20c%3Fd%23e/lock", config.LockAddress)
+		assert.Equal(t, "https://grid.example.com/tfstate/a%2Fb%20c%3Fd%23e/unlock", config.UnlockAddress)
+	})
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{name: "valid schema", schema: `{"type":"string","pattern":"^vpc-"}`},
+		{name: "malformed JSON", schema: `{"type":`, wantErr: "parse schema JSON"},
+		{name: "invalid keyword value", schema: `{"type":"not-a-type"}`, wantErr: "compile schema"},
+		{name: "bad pattern", schema: `{"type":"string","pattern":"("}`, wantErr: "compile schema"},
+		{
+			name:    "oversized schema",
+			schema:  `{"description":"` + strings.Repeat("x", maxOutputSchemaBytes) + `"}`,
+			wantErr: "exceeds the maximum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateJSONSchema(tt.schema)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.NotContains(t, err.Error(), "file://")
+		})
+	}
+}
+
+func TestSummarizeValidation(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	older := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	outputs := []repository.OutputKey{
+		{Key: "vpc_id", ValidationStatus: strPtr("valid"), ValidatedAt: &older},
+		{Key: "subnet_ids", ValidationStatus: strPtr("invalid"), ValidationError: strPtr("at '': got string, want array"), ValidatedAt: &newer},
+		{Key: "db_url", ValidationStatus: strPtr("error"), ValidationError: strPtr("schema compile failed"), ValidatedAt: &older},
+		{Key: "region", ValidationStatus: strPtr("not_validated"), ValidatedAt: &older},
+		{Key: "tags"},
+	}
+
+	summary := summarizeValidation(outputs)
+
+	assert.Equal(t, 5, summary.TotalOutputs)
+	assert.Equal(t, 1, summary.Valid)
+	assert.Equal(t, 1, summary.Invalid)
+	assert.Equal(t, 1, summary.Errored)
+	assert.Equal(t, 2, summary.NotValidated)
+	if assert.Len(t, summary.Issues, 2) {
+		assert.Equal(t, "db_url", summary.Issues[0].OutputKey)
+		assert.Equal(t, "error", summary.Issues[0].Status)
+		assert.Equal(t, "schema compile failed", summary.Issues[0].Error)
+		assert.Equal(t, "subnet_ids", summary.Issues[1].OutputKey)
+		assert.Equal(t, "invalid", summary.Issues[1].Status)
+		assert.Equal(t, "at '': got string, want array", summary.Issues[1].Error)
+	}
+	if assert.NotNil(t, summary.LastValidatedAt) {
+		assert.True(t, summary.LastValidatedAt.Equal(newer))
+	}
+
+	t.Run("no outputs", func(t *testing.T) {
+		empty := summarizeValidation(nil)
+		assert.Zero(t, empty.TotalOutputs)
+		assert.Empty(t, empty.Issues)
+		assert.Nil(t, empty.LastValidatedAt)
+	})
+}
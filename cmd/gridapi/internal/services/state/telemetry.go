@@ -0,0 +1,22 @@
+package state
+
+import (
+	"log"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/terraconstructs/grid/cmd/gridapi/internal/telemetry"
+)
+
+// outputsNearLimit counts state writes whose output count is within
+// outputsWarningPercent of the per-state cap.
+var outputsNearLimit = newOutputsNearLimitCounter()
+
+func newOutputsNearLimitCounter() metric.Int64Counter {
+	counter, err := telemetry.Meter().Int64Counter("grid.state.outputs_near_limit",
+		metric.WithDescription("State writes whose output count is approaching the per-state limit"))
+	if err != nil {
+		log.Printf("create outputs near limit counter: %v", err)
+	}
+	return counter
+}
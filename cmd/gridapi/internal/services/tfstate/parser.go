@@ -22,8 +22,9 @@ type TFOutputs struct {
 
 // OutputValue represents a single Terraform output value
 type OutputValue struct {
-	Value     interface{} `json:"value"`
-	Sensitive bool        `json:"sensitive,omitempty"`
+	Value     interface{}     `json:"value"`
+	Type      json.RawMessage `json:"type,omitempty"` // Terraform type constraint, e.g. ["map","string"]
+	Sensitive bool            `json:"sensitive,omitempty"`
 }
 
 // ParseOutputs extracts output values from Terraform state JSON
@@ -81,11 +82,13 @@ func ParseSerial(tfstateJSON []byte) (int64, error) {
 	return state.Serial, nil
 }
 
-// ParsedState represents the parsed Terraform state with serial, keys, and values
+// ParsedState represents the parsed Terraform state with serial, keys, values,
+// and the type constraints of outputs that record one
 type ParsedState struct {
 	Serial int64
 	Keys   []repository.OutputKey
 	Values map[string]interface{}
+	Types  map[string]json.RawMessage
 }
 
 // ParseState parses Terraform state JSON once and returns serial, output keys, and output values
@@ -96,6 +99,7 @@ func ParseState(tfstateJSON []byte) (*ParsedState, error) {
 			Serial: 0,
 			Keys:   []repository.OutputKey{},
 			Values: make(map[string]interface{}),
+			Types:  make(map[string]json.RawMessage),
 		}, nil
 	}
 
@@ -107,17 +111,22 @@ func ParseState(tfstateJSON []byte) (*ParsedState, error) {
 	// Extract keys with sensitive flags
 	keys := make([]repository.OutputKey, 0, len(state.Outputs))
 	values := make(map[string]interface{}, len(state.Outputs))
+	types := make(map[string]json.RawMessage, len(state.Outputs))
 	for k, v := range state.Outputs {
 		keys = append(keys, repository.OutputKey{
 			Key:       k,
 			Sensitive: v.Sensitive,
 		})
 		values[k] = v.Value
+		if len(v.Type) > 0 {
+			types[k] = v.Type
+		}
 	}
 
 	return &ParsedState{
 		Serial: state.Serial,
 		Keys:   keys,
 		Values: values,
+		Types:  types,
 	}, nil
 }
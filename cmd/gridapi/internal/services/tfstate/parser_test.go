@@ -209,3 +209,23 @@ func TestParseOutputs_RealWorldExample(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "52.1.2.3", natIPs["us-east-1a"])
 }
+
+func TestParseState_Types(t *testing.T) {
+	tfstate := `{
+		"serial": 3,
+		"outputs": {
+			"nat_gateway_ips": {
+				"value": {"us-east-1a": "52.1.2.3"},
+				"type": ["map", "string"]
+			},
+			"legacy": {
+				"value": "no type recorded"
+			}
+		}
+	}`
+
+	parsed, err := ParseState([]byte(tfstate))
+	require.NoError(t, err)
+	assert.JSONEq(t, `["map", "string"]`, string(parsed.Types["nat_gateway_ips"]))
+	assert.NotContains(t, parsed.Types, "legacy")
+}
@@ -22,7 +22,8 @@ type ValidationResult struct {
 type Validator interface {
 	// ValidateOutputs validates multiple outputs against their schemas
 	// Returns results only for outputs that have schemas (skips outputs without schemas per FR-033)
-	ValidateOutputs(ctx context.Context, schemas map[string]string, outputs map[string]interface{}) ([]ValidationResult, error)
+	// Error messages for outputs in sensitive never include the output's value.
+	ValidateOutputs(ctx context.Context, schemas map[string]string, outputs map[string]interface{}, sensitive map[string]bool) ([]ValidationResult, error)
 }
 
 // SchemaValidator implements Validator using santhosh-tekuri/jsonschema/v6
@@ -50,7 +51,8 @@ func NewSchemaValidator(cacheSize int) (*SchemaValidator, error) {
 
 // ValidateOutputs validates all outputs that have schemas
 // Outputs without schemas are skipped (caller should set validation_status="not_validated")
-func (v *SchemaValidator) ValidateOutputs(ctx context.Context, schemas map[string]string, outputs map[string]interface{}) ([]ValidationResult, error) {
+// Outputs flagged in sensitive get redacted error messages (see formatRedactedValidationError)
+func (v *SchemaValidator) ValidateOutputs(ctx context.Context, schemas map[string]string, outputs map[string]interface{}, sensitive map[string]bool) ([]ValidationResult, error) {
 	var results []ValidationResult
 	now := time.Now()
 
@@ -64,7 +66,7 @@ func (v *SchemaValidator) ValidateOutputs(ctx context.Context, schemas map[strin
 		}
 
 		// Validate the output
-		result := v.validateOutput(ctx, outputKey, schemaJSON, outputValue, now)
+		result := v.validateOutput(ctx, outputKey, schemaJSON, outputValue, sensitive[outputKey], now)
 		results = append(results, result)
 	}
 
@@ -72,7 +74,7 @@ func (v *SchemaValidator) ValidateOutputs(ctx context.Context, schemas map[strin
 }
 
 // validateOutput validates a single output against its schema
-func (v *SchemaValidator) validateOutput(ctx context.Context, outputKey, schemaJSON string, outputValue interface{}, now time.Time) ValidationResult {
+func (v *SchemaValidator) validateOutput(ctx context.Context, outputKey, schemaJSON string, outputValue interface{}, sensitive bool, now time.Time) ValidationResult {
 	// Try to get compiled schema from cache
 	cacheKey := schemaJSON // Use schema JSON as cache key (schemas are unique)
 	cachedSchema, found := v.schemaCache.Get(cacheKey)
@@ -105,6 +107,9 @@ func (v *SchemaValidator) validateOutput(ctx context.Context, outputKey, schemaJ
 	if err != nil {
 		// Data error: output violates schema
 		errMsg := v.formatValidationError(err)
+		if sensitive {
+			errMsg = v.formatRedactedValidationError(err)
+		}
 		return ValidationResult{
 			OutputKey:       outputKey,
 			Status:          "invalid",
@@ -196,6 +201,35 @@ func (v *SchemaValidator) formatValidationError(err error) string {
 	return finalMsg
 }
 
+// formatRedactedValidationError formats a validation error for a sensitive output.
+// Library messages often quote the offending value (e.g. pattern and enum
+// failures), so each failure is reported only by its path and the violated
+// keyword.
+func (v *SchemaValidator) formatRedactedValidationError(err error) string {
+	ve, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return "validation failed (value redacted)"
+	}
+
+	var messages []string
+	for _, leaf := range getLeafErrors(ve) {
+		path := jsonPointerToDot(leaf.InstanceLocation)
+		keyword := "schema"
+		if leaf.ErrorKind != nil {
+			if kp := leaf.ErrorKind.KeywordPath(); len(kp) > 0 {
+				keyword = strings.Join(kp, "/")
+			}
+		}
+		messages = append(messages, fmt.Sprintf("at '%s': violates %s constraint (value redacted)", path, keyword))
+	}
+
+	finalMsg := strings.Join(messages, "; ")
+	if len(finalMsg) > 200 {
+		return finalMsg[:200] + "... (truncated)"
+	}
+	return finalMsg
+}
+
 // getLeafErrors recursively finds the errors that resulted in the validation failure.
 // jsonschema returns a tree; "Causes" hold the detailed errors.
 // A leaf error is one with no causes (the actual validation failure).
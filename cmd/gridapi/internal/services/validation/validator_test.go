@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validateOne(t *testing.T, schemaJSON string, value any, sensitive bool) ValidationResult {
+	t.Helper()
+
+	v, err := NewSchemaValidator(10)
+	require.NoError(t, err)
+
+	results, err := v.ValidateOutputs(context.Background(),
+		map[string]string{"out": schemaJSON},
+		map[string]any{"out": value},
+		map[string]bool{"out": sensitive},
+	)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	return results[0]
+}
+
+func TestValidateOutputsRedactsSensitiveValues(t *testing.T) {
+	const secret = "hunter2-super-secret-token"
+
+	t.Run("sensitive maxLength failure masks the value", func(t *testing.T) {
+		result := validateOne(t, `{"type":"string","maxLength":8}`, secret, true)
+
+		require.Equal(t, "invalid", result.Status)
+		require.NotNil(t, result.ValidationError)
+		assert.Contains(t, *result.ValidationError, "maxLength")
+		assert.Contains(t, *result.ValidationError, "at '$'")
+		assert.NotContains(t, *result.ValidationError, secret)
+		assert.NotContains(t, *result.ValidationError, "hunter2")
+	})
+
+	t.Run("sensitive pattern failure masks the value", func(t *testing.T) {
+		result := validateOne(t, `{"type":"string","pattern":"^vpc-"}`, secret, true)
+
+		require.Equal(t, "invalid", result.Status)
+		assert.Contains(t, *result.ValidationError, "pattern")
+		assert.NotContains(t, *result.ValidationError, "hunter2")
+	})
+
+	t.Run("sensitive nested value keeps its path", func(t *testing.T) {
+		schema := `{"type":"object","properties":{"db":{"type":"object","properties":{"password":{"type":"string","enum":["a","b"]}}}}}`
+		value := map[string]any{"db": map[string]any{"password": secret}}
+		result := validateOne(t, schema, value, true)
+
+		require.Equal(t, "invalid", result.Status)
+		assert.Contains(t, *result.ValidationError, "at '$.db.password'")
+		assert.Contains(t, *result.ValidationError, "enum")
+		assert.NotContains(t, *result.ValidationError, "hunter2")
+	})
+
+	t.Run("non-sensitive errors keep library detail", func(t *testing.T) {
+		result := validateOne(t, `{"type":"string","pattern":"^vpc-"}`, "subnet-123", false)
+
+		require.Equal(t, "invalid", result.Status)
+		assert.Contains(t, *result.ValidationError, "subnet-123")
+	})
+
+	t.Run("sensitive valid value passes", func(t *testing.T) {
+		result := validateOne(t, `{"type":"string","minLength":8}`, secret, true)
+
+		assert.Equal(t, "valid", result.Status)
+		assert.Nil(t, result.ValidationError)
+	})
+}
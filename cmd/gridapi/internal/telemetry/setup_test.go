@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("none installs nothing", func(t *testing.T) {
+		before := otel.GetTracerProvider()
+		shutdown, err := Setup(ctx, Options{Exporter: ExporterNone})
+		require.NoError(t, err)
+		require.Same(t, before, otel.GetTracerProvider())
+		require.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		_, err := Setup(ctx, Options{Exporter: "jaeger"})
+		require.ErrorContains(t, err, "unknown telemetry exporter")
+	})
+
+	t.Run("otlp installs the SDK providers", func(t *testing.T) {
+		// Nothing listens here; spans are dropped when the exporter flushes
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:1")
+		tracerProvider, meterProvider := otel.GetTracerProvider(), otel.GetMeterProvider()
+		t.Cleanup(func() {
+			otel.SetTracerProvider(tracerProvider)
+			otel.SetMeterProvider(meterProvider)
+		})
+
+		shutdown, err := Setup(ctx, Options{Exporter: ExporterOTLP, ServiceName: "gridapi", MetricInterval: time.Minute})
+		require.NoError(t, err)
+		require.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_ = shutdown(shutdownCtx)
+	})
+}
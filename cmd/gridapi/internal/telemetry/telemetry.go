@@ -0,0 +1,55 @@
+// Package telemetry holds the OpenTelemetry tracer, meter and attribute keys
+// shared by Grid API instrumentation.
+//
+// Instruments use the global OpenTelemetry providers, which are no-ops until
+// Setup installs an SDK with an exporter, so instrumented code pays next to
+// nothing when telemetry is not exported.
+package telemetry
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies Grid API spans and metrics.
+const InstrumentationName = "github.com/terraconstructs/grid/cmd/gridapi"
+
+// Attribute keys for authorization spans and metrics.
+const (
+	AttrAuthzObject     = attribute.Key("grid.authz.object")
+	AttrAuthzAction     = attribute.Key("grid.authz.action")
+	AttrAuthzRoleCount  = attribute.Key("grid.authz.role_count")
+	AttrAuthzDecision   = attribute.Key("grid.authz.decision")
+	AttrAuthzDurationMs = attribute.Key("grid.authz.duration_ms")
+
+	// Batch authorization
+	AttrAuthzCheckCount   = attribute.Key("grid.authz.check_count")
+	AttrAuthzAllowedCount = attribute.Key("grid.authz.allowed_count")
+
+	// Error detail on span events
+	AttrErrorMessage = attribute.Key("grid.error.message")
+
+	// Cache metrics: "hit" or "miss"
+	AttrCacheResult = attribute.Key("grid.cache.result")
+)
+
+// Values of AttrAuthzDecision.
+const (
+	DecisionAllow = "allow"
+	DecisionDeny  = "deny"
+	DecisionError = "error"
+)
+
+// Tracer returns the Grid API tracer from the global provider. It is looked
+// up per call so a provider installed after startup takes effect.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Meter returns the Grid API meter from the global provider. Instruments
+// created from it before an SDK is installed are forwarded once it is.
+func Meter() metric.Meter {
+	return otel.Meter(InstrumentationName)
+}
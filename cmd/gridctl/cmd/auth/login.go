@@ -12,6 +12,7 @@ import (
 var (
 	clientID     string
 	clientSecret string
+	scopes       []string
 )
 
 var loginCmd = &cobra.Command{
@@ -25,7 +26,9 @@ and initiates the appropriate OIDC device flow (external IdP or Grid's internal
 Two methods are supported:
 1. Interactive Login (default): Initiates a device authorization flow for human users.
 2. Service Account Login: Uses a client ID and secret for non-interactive authentication.
-   Use the --client-id and --client-secret flags.`,
+   Use the --client-id and --client-secret flags. Add --scope (repeatable) to limit
+   the token to specific actions, e.g. --scope state:read, regardless of what the
+   service account's roles allow.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.MustFromContext(cmd.Context())
 
@@ -37,7 +40,7 @@ Two methods are supported:
 		// Service account flow (uses explicit client_id/secret, no discovery needed)
 		if clientID != "" && clientSecret != "" {
 			fmt.Println("Authenticating as service account...")
-			creds, err := sdk.LoginWithServiceAccount(cmd.Context(), cfg.ServerURL, clientID, clientSecret)
+			creds, err := sdk.LoginWithServiceAccount(cmd.Context(), cfg.ServerURL, clientID, clientSecret, scopes...)
 			if err != nil {
 				return err
 			}
@@ -66,6 +69,7 @@ Two methods are supported:
 func init() {
 	loginCmd.Flags().StringVar(&clientID, "client-id", "", "Client ID for service account authentication")
 	loginCmd.Flags().StringVar(&clientSecret, "client-secret", "", "Client secret for service account authentication")
+	loginCmd.Flags().StringSliceVar(&scopes, "scope", nil, "Limit the service account token to these actions (e.g. state:read, tfstate:*)")
 	if clientID == "" && clientSecret == "" {
 		if ok, env := sdk.CheckEnvCreds(); ok {
 			fmt.Println("Using service account credentials from environment variables.")
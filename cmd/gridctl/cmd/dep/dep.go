@@ -24,6 +24,7 @@ func init() {
 	DepCmd.AddCommand(statusCmd)
 	DepCmd.AddCommand(topoCmd)
 	DepCmd.AddCommand(syncCmd)
+	DepCmd.AddCommand(recomputeCmd)
 }
 
 func sdkClient(ctx context.Context) (*sdk.Client, error) {
@@ -0,0 +1,79 @@
+package dep
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/terraconstructs/grid/cmd/gridctl/internal/dirctx"
+	"github.com/terraconstructs/grid/pkg/sdk"
+)
+
+var recomputeLogicID string
+
+var recomputeCmd = &cobra.Command{
+	Use:   "recompute",
+	Short: "Recompute the status of a producer's dependency edges",
+	Long: `Re-derives the status of every edge consuming the producer's outputs from
+its current outputs and stores the result. Run it from CI after an apply to
+mark downstream states dirty right away.
+
+Edges whose consumer is outside your role scopes are skipped.`,
+	Args: cobra.NoArgs,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		logicID := recomputeLogicID
+		if strings.TrimSpace(logicID) == "" {
+			gridCtx, err := dirctx.ReadGridContext()
+			if err != nil {
+				fmt.Printf("Warning: .grid file corrupted or invalid, ignoring: %v\n", err)
+				return fmt.Errorf("flag --state is required (no .grid context found)")
+			} else if gridCtx != nil {
+				logicID = gridCtx.StateLogicID
+				fmt.Printf("Using --state from .grid context: %s\n", logicID)
+			} else {
+				return fmt.Errorf("flag --state is required (no .grid context found)")
+			}
+		}
+
+		gridClient, err := sdkClient(cobraCmd.Context())
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(cobraCmd.Context(), 30*time.Second)
+		defer cancel()
+
+		result, err := gridClient.RecomputeDependencyStatus(ctx, sdk.StateReference{LogicID: logicID})
+		if err != nil {
+			return fmt.Errorf("failed to recompute dependency status: %w", err)
+		}
+
+		fmt.Printf("Recomputed %d edge(s) of %s, %d changed", len(result.Edges), result.Producer.LogicID, len(result.ChangedEdgeIDs))
+		if result.SkippedEdges > 0 {
+			fmt.Printf(", %d skipped (outside your role scopes)", result.SkippedEdges)
+		}
+		fmt.Println()
+		if len(result.Edges) == 0 {
+			return nil
+		}
+
+		changed := make(map[int64]bool, len(result.ChangedEdgeIDs))
+		for _, id := range result.ChangedEdgeIDs {
+			changed[id] = true
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "\nEDGE_ID\tTO_STATE\tOUTPUT\tSTATUS\tCHANGED")
+		for _, edge := range result.Edges {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%t\n", edge.ID, edge.To.LogicID, edge.FromOutput, edge.Status, changed[edge.ID])
+		}
+		_ = w.Flush()
+		return nil
+	},
+}
+
+func init() {
+	recomputeCmd.Flags().StringVar(&recomputeLogicID, "state", "", "Logic ID of the producer state")
+}
@@ -20,15 +20,26 @@ var assignGroupCmd = &cobra.Command{
 			return err
 		}
 
+		condition, _ := cmd.Flags().GetString("condition")
+
 		_, err = gridClient.AssignGroupRole(cmd.Context(), sdk.AssignGroupRoleInput{
 			GroupName: group,
 			RoleName:  role,
+			Condition: condition,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to assign group role: %w", err)
 		}
 
+		if condition != "" {
+			fmt.Printf("Assigned group '%s' to role '%s' when %s\n", group, role, condition)
+			return nil
+		}
 		fmt.Printf("Assigned group '%s' to role '%s'\n", group, role)
 		return nil
 	},
 }
+
+func init() {
+	assignGroupCmd.Flags().String("condition", "", `Only grant the role when token claims match this go-bexpr expression (e.g. 'department == "finance"')`)
+}
@@ -47,7 +47,11 @@ var listCmd = &cobra.Command{
 		}
 
 		include := true
-		states, err := gridClient.ListStatesWithOptions(ctx, sdk.ListStatesOptions{Filter: finalFilter, IncludeLabels: &include})
+		states, err := gridClient.ListStatesWithOptions(ctx, sdk.ListStatesOptions{
+			Filter:        finalFilter,
+			LabelSelector: strings.TrimSpace(listSelector),
+			IncludeLabels: &include,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to list states: %w", err)
 		}
@@ -80,9 +84,11 @@ var listCmd = &cobra.Command{
 var (
 	listFilter          string
 	listLabelFilterArgs []string
+	listSelector        string
 )
 
 func init() {
 	listCmd.Flags().StringVar(&listFilter, "filter", "", "bexpr filter expression (e.g. env == \"prod\")")
 	listCmd.Flags().StringArrayVarP(&listLabelFilterArgs, "label", "l", nil, "Filter by label equality (key=value). Converted to bexpr AND expression")
+	listCmd.Flags().StringVar(&listSelector, "selector", "", "Label selector evaluated server-side (e.g. env=dev,team in (platform,data))")
 }
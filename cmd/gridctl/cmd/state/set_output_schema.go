@@ -16,6 +16,7 @@ var (
 	setSchemaGUID      string
 	setSchemaOutputKey string
 	setSchemaFile      string
+	setSchemaValidate  bool
 )
 
 var setOutputSchemaCmd = &cobra.Command{
@@ -23,7 +24,10 @@ var setOutputSchemaCmd = &cobra.Command{
 	Short: "Set JSON Schema for a state output",
 	Long: `Sets or updates the JSON Schema definition for a specific state output.
 This allows declaring expected output types before the output exists in Terraform state.
-Uses .grid context if no state identifier is provided.`,
+Uses .grid context if no state identifier is provided.
+
+With --validate, the output's current value is validated against the new schema
+before the command returns and the result is printed.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		if setSchemaOutputKey == "" {
@@ -71,15 +75,33 @@ Uses .grid context if no state identifier is provided.`,
 		ctx, cancel := context.WithTimeout(cobraCmd.Context(), 10*time.Second)
 		defer cancel()
 
-		err = gridClient.SetOutputSchema(ctx, sdk.StateReference{
+		ref := sdk.StateReference{
 			LogicID: stateRef.LogicID,
 			GUID:    stateRef.GUID,
-		}, setSchemaOutputKey, string(schemaBytes))
+		}
+
+		if !setSchemaValidate {
+			if err := gridClient.SetOutputSchema(ctx, ref, setSchemaOutputKey, string(schemaBytes)); err != nil {
+				return fmt.Errorf("failed to set output schema: %w", err)
+			}
+			fmt.Printf("✓ Set schema for output '%s' on state '%s'\n", setSchemaOutputKey, stateRef.LogicID)
+			return nil
+		}
+
+		result, err := gridClient.SetOutputSchemaAndValidate(ctx, ref, setSchemaOutputKey, string(schemaBytes))
 		if err != nil {
 			return fmt.Errorf("failed to set output schema: %w", err)
 		}
-
 		fmt.Printf("✓ Set schema for output '%s' on state '%s'\n", setSchemaOutputKey, stateRef.LogicID)
+
+		switch {
+		case result.Status == nil:
+			fmt.Println("  Validation: skipped (output has no value yet)")
+		case result.Error != nil:
+			fmt.Printf("  Validation: %s: %s\n", *result.Status, *result.Error)
+		default:
+			fmt.Printf("  Validation: %s\n", *result.Status)
+		}
 		return nil
 	},
 }
@@ -89,6 +111,7 @@ func init() {
 	setOutputSchemaCmd.Flags().StringVar(&setSchemaGUID, "guid", "", "State GUID")
 	setOutputSchemaCmd.Flags().StringVarP(&setSchemaOutputKey, "key", "k", "", "Output key name (required)")
 	setOutputSchemaCmd.Flags().StringVarP(&setSchemaFile, "file", "f", "", "Path to JSON Schema file (required)")
+	setOutputSchemaCmd.Flags().BoolVar(&setSchemaValidate, "validate", false, "Validate the current output value before returning and print the result")
 	setOutputSchemaCmd.MarkFlagRequired("key")
 	setOutputSchemaCmd.MarkFlagRequired("file")
 }
@@ -3,6 +3,7 @@ package dirctx
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"time"
 
@@ -172,17 +173,28 @@ func (dc *DirectoryContext) GetGUID() string {
 // GetBackendURL returns the full HTTP backend address for Terraform
 // Format: {server_url}/tfstate/{guid}
 func (dc *DirectoryContext) GetBackendURL() string {
-	return fmt.Sprintf("%s/tfstate/%s", dc.ServerURL, dc.StateGUID)
+	return dc.tfstateURL()
 }
 
 // GetLockURL returns the lock endpoint URL for Terraform
 // Format: {server_url}/tfstate/{guid}/lock
 func (dc *DirectoryContext) GetLockURL() string {
-	return fmt.Sprintf("%s/tfstate/%s/lock", dc.ServerURL, dc.StateGUID)
+	return dc.tfstateURL("lock")
 }
 
 // GetUnlockURL returns the unlock endpoint URL for Terraform
 // Format: {server_url}/tfstate/{guid}/unlock
 func (dc *DirectoryContext) GetUnlockURL() string {
-	return fmt.Sprintf("%s/tfstate/%s/unlock", dc.ServerURL, dc.StateGUID)
+	return dc.tfstateURL("unlock")
+}
+
+// tfstateURL joins the state's tfstate path and elem onto the server URL,
+// escaping the GUID as a single segment. It is empty when the server URL
+// cannot be parsed.
+func (dc *DirectoryContext) tfstateURL(elem ...string) string {
+	address, err := url.JoinPath(dc.ServerURL, append([]string{"tfstate", url.PathEscape(dc.StateGUID)}, elem...)...)
+	if err != nil {
+		return ""
+	}
+	return address
 }
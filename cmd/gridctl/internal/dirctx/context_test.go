@@ -204,6 +204,17 @@ func TestDirectoryContext_GetUnlockURL(t *testing.T) {
 	}
 }
 
+func TestDirectoryContext_BackendURLs_TrailingSlashAndPathPrefix(t *testing.T) {
+	dc := &DirectoryContext{
+		StateGUID: "0199039d-8b5e-7a2f-b7c4-1a2b3c4d5e6f",
+		ServerURL: "https://grid.example.com/api/",
+	}
+	want := "https://grid.example.com/api/tfstate/0199039d-8b5e-7a2f-b7c4-1a2b3c4d5e6f/lock"
+	if got := dc.GetLockURL(); got != want {
+		t.Fatalf("GetLockURL() = %q, want %q", got, want)
+	}
+}
+
 func TestDirectoryContext_BackendURLs_WithHTTPS(t *testing.T) {
 	dc := &DirectoryContext{
 		StateGUID: "0199c24c-b330-79ee-9b25-4bb80926868f",
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file state/v1/state.proto.
  */
 export const file_state_v1_state: GenFile = /*@__PURE__*/
-  fileDesc("ChRzdGF0ZS92MS9zdGF0ZS5wcm90bxIIc3RhdGUudjEinQEKEkNyZWF0ZVN0YXRlUmVxdWVzdBIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJEjgKBmxhYmVscxgDIAMoCzIoLnN0YXRlLnYxLkNyZWF0ZVN0YXRlUmVxdWVzdC5MYWJlbHNFbnRyeRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBImYKE0NyZWF0ZVN0YXRlUmVzcG9uc2USDAoEZ3VpZBgBIAEoCRIQCghsb2dpY19pZBgCIAEoCRIvCg5iYWNrZW5kX2NvbmZpZxgDIAEoCzIXLnN0YXRlLnYxLkJhY2tlbmRDb25maWcikwEKEUxpc3RTdGF0ZXNSZXF1ZXN0EhMKBmZpbHRlchgBIAEoCUgAiAEBEhsKDmluY2x1ZGVfbGFiZWxzGAIgASgISAGIAQESGwoOaW5jbHVkZV9zdGF0dXMYAyABKAhIAogBAUIJCgdfZmlsdGVyQhEKD19pbmNsdWRlX2xhYmVsc0IRCg9faW5jbHVkZV9zdGF0dXMiOQoSTGlzdFN0YXRlc1Jlc3BvbnNlEiMKBnN0YXRlcxgBIAMoCzITLnN0YXRlLnYxLlN0YXRlSW5mbyKPBAoJU3RhdGVJbmZvEgwKBGd1aWQYASABKAkSEAoIbG9naWNfaWQYAiABKAkSDgoGbG9ja2VkGAMgASgIEi4KCmNyZWF0ZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhIKCnNpemVfYnl0ZXMYBiABKAMSHAoPY29tcHV0ZWRfc3RhdHVzGAcgASgJSACIAQESHAoUZGVwZW5kZW5jeV9sb2dpY19pZHMYCCADKAkSLwoGbGFiZWxzGAkgAygLMh8uc3RhdGUudjEuU3RhdGVJbmZvLkxhYmVsc0VudHJ5Eh8KEmRlcGVuZGVuY2llc19jb3VudBgKIAEoBUgBiAEBEh0KEGRlcGVuZGVudHNfY291bnQYCyABKAVIAogBARIaCg1vdXRwdXRzX2NvdW50GAwgASgFSAOIAQEaQwoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEiMKBXZhbHVlGAIgASgLMhQuc3RhdGUudjEuTGFiZWxWYWx1ZToCOAFCEgoQX2NvbXB1dGVkX3N0YXR1c0IVChNfZGVwZW5kZW5jaWVzX2NvdW50QhMKEV9kZXBlbmRlbnRzX2NvdW50QhAKDl9vdXRwdXRzX2NvdW50Ik4KDUJhY2tlbmRDb25maWcSDwoHYWRkcmVzcxgBIAEoCRIUCgxsb2NrX2FkZHJlc3MYAiABKAkSFgoOdW5sb2NrX2FkZHJlc3MYAyABKAkiKQoVR2V0U3RhdGVDb25maWdSZXF1ZXN0EhAKCGxvZ2ljX2lkGAEgASgJIlcKFkdldFN0YXRlQ29uZmlnUmVzcG9uc2USDAoEZ3VpZBgBIAEoCRIvCg5iYWNrZW5kX2NvbmZpZxgCIAEoCzIXLnN0YXRlLnYxLkJhY2tlbmRDb25maWciIwoTR2V0U3RhdGVMb2NrUmVxdWVzdBIMCgRndWlkGAEgASgJIpABCghMb2NrSW5mbxIKCgJpZBgBIAEoCRIRCglvcGVyYXRpb24YAiABKAkSDAoEaW5mbxgDIAEoCRILCgN3aG8YBCABKAkSDwoHdmVyc2lvbhgFIAEoCRIrCgdjcmVhdGVkGAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIMCgRwYXRoGAcgASgJIj0KCVN0YXRlTG9jaxIOCgZsb2NrZWQYASABKAgSIAoEaW5mbxgCIAEoCzISLnN0YXRlLnYxLkxvY2tJbmZvIjkKFEdldFN0YXRlTG9ja1Jlc3BvbnNlEiEKBGxvY2sYASABKAsyEy5zdGF0ZS52MS5TdGF0ZUxvY2siMwoSVW5sb2NrU3RhdGVSZXF1ZXN0EgwKBGd1aWQYASABKAkSDwoHbG9ja19pZBgCIAEoCSI4ChNVbmxvY2tTdGF0ZVJlc3BvbnNlEiEKBGxvY2sYASABKAsyEy5zdGF0ZS52MS5TdGF0ZUxvY2si/QEKFEFkZERlcGVuZGVuY3lSZXF1ZXN0EhcKDWZyb21fbG9naWNfaWQYASABKAlIABITCglmcm9tX2d1aWQYAiABKAlIABITCgtmcm9tX291dHB1dBgDIAEoCRIVCgt0b19sb2dpY19pZBgEIAEoCUgBEhEKB3RvX2d1aWQYBSABKAlIARIaCg10b19pbnB1dF9uYW1lGAYgASgJSAKIAQESHAoPbW9ja192YWx1ZV9qc29uGAcgASgJSAOIAQFCDAoKZnJvbV9zdGF0ZUIKCgh0b19zdGF0ZUIQCg5fdG9faW5wdXRfbmFtZUISChBfbW9ja192YWx1ZV9qc29uIlcKFUFkZERlcGVuZGVuY3lSZXNwb25zZRImCgRlZGdlGAEgASgLMhguc3RhdGUudjEuRGVwZW5kZW5jeUVkZ2USFgoOYWxyZWFkeV9leGlzdHMYAiABKAgiKgoXUmVtb3ZlRGVwZW5kZW5jeVJlcXVlc3QSDwoHZWRnZV9pZBgBIAEoAyIrChhSZW1vdmVEZXBlbmRlbmN5UmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCJGChdMaXN0RGVwZW5kZW5jaWVzUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIAEIHCgVzdGF0ZSJDChhMaXN0RGVwZW5kZW5jaWVzUmVzcG9uc2USJwoFZWRnZXMYASADKAsyGC5zdGF0ZS52MS5EZXBlbmRlbmN5RWRnZSJEChVMaXN0RGVwZW5kZW50c1JlcXVlc3QSEgoIbG9naWNfaWQYASABKAlIABIOCgRndWlkGAIgASgJSABCBwoFc3RhdGUiQQoWTGlzdERlcGVuZGVudHNSZXNwb25zZRInCgVlZGdlcxgBIAMoCzIYLnN0YXRlLnYxLkRlcGVuZGVuY3lFZGdlIisKFVNlYXJjaEJ5T3V0cHV0UmVxdWVzdBISCgpvdXRwdXRfa2V5GAEgASgJIkEKFlNlYXJjaEJ5T3V0cHV0UmVzcG9uc2USJwoFZWRnZXMYASADKAsyGC5zdGF0ZS52MS5EZXBlbmRlbmN5RWRnZSJvChpHZXRUb3BvbG9naWNhbE9yZGVyUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIABIWCglkaXJlY3Rpb24YAyABKAlIAYgBAUIHCgVzdGF0ZUIMCgpfZGlyZWN0aW9uIj4KG0dldFRvcG9sb2dpY2FsT3JkZXJSZXNwb25zZRIfCgZsYXllcnMYASADKAsyDy5zdGF0ZS52MS5MYXllciI6CgVMYXllchINCgVsZXZlbBgBIAEoBRIiCgZzdGF0ZXMYAiADKAsyEi5zdGF0ZS52MS5TdGF0ZVJlZiIqCghTdGF0ZVJlZhIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJIkQKFUdldFN0YXRlU3RhdHVzUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIAEIHCgVzdGF0ZSKgAQoWR2V0U3RhdGVTdGF0dXNSZXNwb25zZRIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJEg4KBnN0YXR1cxgDIAEoCRIsCghpbmNvbWluZxgEIAMoCzIaLnN0YXRlLnYxLkluY29taW5nRWRnZVZpZXcSKAoHc3VtbWFyeRgFIAEoCzIXLnN0YXRlLnYxLlN0YXR1c1N1bW1hcnkiygIKEEluY29taW5nRWRnZVZpZXcSDwoHZWRnZV9pZBgBIAEoAxIRCglmcm9tX2d1aWQYAiABKAkSFQoNZnJvbV9sb2dpY19pZBgDIAEoCRITCgtmcm9tX291dHB1dBgEIAEoCRIOCgZzdGF0dXMYBSABKAkSFgoJaW5fZGlnZXN0GAYgASgJSACIAQESFwoKb3V0X2RpZ2VzdBgHIAEoCUgBiAEBEjMKCmxhc3RfaW5fYXQYCCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAKIAQESNAoLbGFzdF9vdXRfYXQYCSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAOIAQFCDAoKX2luX2RpZ2VzdEINCgtfb3V0X2RpZ2VzdEINCgtfbGFzdF9pbl9hdEIOCgxfbGFzdF9vdXRfYXQicwoNU3RhdHVzU3VtbWFyeRIWCg5pbmNvbWluZ19jbGVhbhgBIAEoBRIWCg5pbmNvbWluZ19kaXJ0eRgCIAEoBRIYChBpbmNvbWluZ19wZW5kaW5nGAMgASgFEhgKEGluY29taW5nX3Vua25vd24YBCABKAUiSAoZR2V0RGVwZW5kZW5jeUdyYXBoUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIAEIHCgVzdGF0ZSKjAQoaR2V0RGVwZW5kZW5jeUdyYXBoUmVzcG9uc2USFQoNY29uc3VtZXJfZ3VpZBgBIAEoCRIZChFjb25zdW1lcl9sb2dpY19pZBgCIAEoCRIqCglwcm9kdWNlcnMYAyADKAsyFy5zdGF0ZS52MS5Qcm9kdWNlclN0YXRlEicKBWVkZ2VzGAQgAygLMhguc3RhdGUudjEuRGVwZW5kZW5jeUVkZ2UiYAoNUHJvZHVjZXJTdGF0ZRIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJEi8KDmJhY2tlbmRfY29uZmlnGAMgASgLMhcuc3RhdGUudjEuQmFja2VuZENvbmZpZyKpBAoORGVwZW5kZW5jeUVkZ2USCgoCaWQYASABKAMSEQoJZnJvbV9ndWlkGAIgASgJEhUKDWZyb21fbG9naWNfaWQYAyABKAkSEwoLZnJvbV9vdXRwdXQYBCABKAkSDwoHdG9fZ3VpZBgFIAEoCRITCgt0b19sb2dpY19pZBgGIAEoCRIaCg10b19pbnB1dF9uYW1lGAcgASgJSACIAQESDgoGc3RhdHVzGAggASgJEhYKCWluX2RpZ2VzdBgJIAEoCUgBiAEBEhcKCm91dF9kaWdlc3QYCiABKAlIAogBARIcCg9tb2NrX3ZhbHVlX2pzb24YCyABKAlIA4gBARIzCgpsYXN0X2luX2F0GAwgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgEiAEBEjQKC2xhc3Rfb3V0X2F0GA0gASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgFiAEBEi4KCmNyZWF0ZWRfYXQYDiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYDyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQhAKDl90b19pbnB1dF9uYW1lQgwKCl9pbl9kaWdlc3RCDQoLX291dF9kaWdlc3RCEgoQX21vY2tfdmFsdWVfanNvbkINCgtfbGFzdF9pbl9hdEIOCgxfbGFzdF9vdXRfYXQitQIKCU91dHB1dEtleRILCgNrZXkYASABKAkSEQoJc2Vuc2l0aXZlGAIgASgIEhgKC3NjaGVtYV9qc29uGAMgASgJSACIAQESGgoNc2NoZW1hX3NvdXJjZRgEIAEoCUgBiAEBEh4KEXZhbGlkYXRpb25fc3RhdHVzGAUgASgJSAKIAQESHQoQdmFsaWRhdGlvbl9lcnJvchgGIAEoCUgDiAEBEjUKDHZhbGlkYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIBIgBAUIOCgxfc2NoZW1hX2pzb25CEAoOX3NjaGVtYV9zb3VyY2VCFAoSX3ZhbGlkYXRpb25fc3RhdHVzQhMKEV92YWxpZGF0aW9uX2Vycm9yQg8KDV92YWxpZGF0ZWRfYXQiRgoXTGlzdFN0YXRlT3V0cHV0c1JlcXVlc3QSEgoIbG9naWNfaWQYASABKAlIABIOCgRndWlkGAIgASgJSABCBwoFc3RhdGUibAoYTGlzdFN0YXRlT3V0cHV0c1Jlc3BvbnNlEhIKCnN0YXRlX2d1aWQYASABKAkSFgoOc3RhdGVfbG9naWNfaWQYAiABKAkSJAoHb3V0cHV0cxgDIAMoCzITLnN0YXRlLnYxLk91dHB1dEtleSJCChNHZXRTdGF0ZUluZm9SZXF1ZXN0EhIKCGxvZ2ljX2lkGAEgASgJSAASDgoEZ3VpZBgCIAEoCUgAQgcKBXN0YXRlIpIEChRHZXRTdGF0ZUluZm9SZXNwb25zZRIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJEi8KDmJhY2tlbmRfY29uZmlnGAMgASgLMhcuc3RhdGUudjEuQmFja2VuZENvbmZpZxIuCgxkZXBlbmRlbmNpZXMYBCADKAsyGC5zdGF0ZS52MS5EZXBlbmRlbmN5RWRnZRIsCgpkZXBlbmRlbnRzGAUgAygLMhguc3RhdGUudjEuRGVwZW5kZW5jeUVkZ2USJAoHb3V0cHV0cxgGIAMoCzITLnN0YXRlLnYxLk91dHB1dEtleRIuCgpjcmVhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIcCg9jb21wdXRlZF9zdGF0dXMYCSABKAlIAIgBARISCgpzaXplX2J5dGVzGAogASgDEjoKBmxhYmVscxgLIAMoCzIqLnN0YXRlLnYxLkdldFN0YXRlSW5mb1Jlc3BvbnNlLkxhYmVsc0VudHJ5GkMKC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRIjCgV2YWx1ZRgCIAEoCzIULnN0YXRlLnYxLkxhYmVsVmFsdWU6AjgBQhIKEF9jb21wdXRlZF9zdGF0dXMiFQoTTGlzdEFsbEVkZ2VzUmVxdWVzdCI/ChRMaXN0QWxsRWRnZXNSZXNwb25zZRInCgVlZGdlcxgBIAMoCzIYLnN0YXRlLnYxLkRlcGVuZGVuY3lFZGdlIlsKCkxhYmVsVmFsdWUSFgoMc3RyaW5nX3ZhbHVlGAEgASgJSAASFgoMbnVtYmVyX3ZhbHVlGAIgASgBSAASFAoKYm9vbF92YWx1ZRgDIAEoCEgAQgcKBXZhbHVlIvMBChhVcGRhdGVTdGF0ZUxhYmVsc1JlcXVlc3QSEAoIc3RhdGVfaWQYASABKAkSOgoEYWRkcxgCIAMoCzIsLnN0YXRlLnYxLlVwZGF0ZVN0YXRlTGFiZWxzUmVxdWVzdC5BZGRzRW50cnkSEAoIcmVtb3ZhbHMYAyADKAkSHgoRY2xpZW50X3JlcXVlc3RfaWQYBCABKAlIAIgBARpBCglBZGRzRW50cnkSCwoDa2V5GAEgASgJEiMKBXZhbHVlGAIgASgLMhQuc3RhdGUudjEuTGFiZWxWYWx1ZToCOAFCFAoSX2NsaWVudF9yZXF1ZXN0X2lkIpYCChlVcGRhdGVTdGF0ZUxhYmVsc1Jlc3BvbnNlEhAKCHN0YXRlX2lkGAEgASgJEj8KBmxhYmVscxgCIAMoCzIvLnN0YXRlLnYxLlVwZGF0ZVN0YXRlTGFiZWxzUmVzcG9uc2UuTGFiZWxzRW50cnkSFgoOcG9saWN5X3ZlcnNpb24YAyABKAUSGQoRY29tcGxpYW5jZV9zdGF0dXMYBCABKAkSLgoKdXBkYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAaQwoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEiMKBXZhbHVlGAIgASgLMhQuc3RhdGUudjEuTGFiZWxWYWx1ZToCOAEiFwoVR2V0TGFiZWxQb2xpY3lSZXF1ZXN0Ip4BChZHZXRMYWJlbFBvbGljeVJlc3BvbnNlEg8KB3ZlcnNpb24YASABKAUSEwoLcG9saWN5X2pzb24YAiABKAkSLgoKY3JlYXRlZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiLAoVU2V0TGFiZWxQb2xpY3lSZXF1ZXN0EhMKC3BvbGljeV9qc29uGAEgASgJIlkKFlNldExhYmVsUG9saWN5UmVzcG9uc2USDwoHdmVyc2lvbhgBIAEoBRIuCgp1cGRhdGVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJVChtDcmVhdGVTZXJ2aWNlQWNjb3VudFJlcXVlc3QSDAoEbmFtZRgBIAEoCRIYCgtkZXNjcmlwdGlvbhgCIAEoCUgAiAEBQg4KDF9kZXNjcmlwdGlvbiKSAQocQ3JlYXRlU2VydmljZUFjY291bnRSZXNwb25zZRIKCgJpZBgBIAEoCRIRCgljbGllbnRfaWQYAiABKAkSFQoNY2xpZW50X3NlY3JldBgDIAEoCRIMCgRuYW1lGAQgASgJEi4KCmNyZWF0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIhwKGkxpc3RTZXJ2aWNlQWNjb3VudHNSZXF1ZXN0It8BChJTZXJ2aWNlQWNjb3VudEluZm8SCgoCaWQYASABKAkSEQoJY2xpZW50X2lkGAIgASgJEgwKBG5hbWUYAyABKAkSGAoLZGVzY3JpcHRpb24YBCABKAlIAIgBARIuCgpjcmVhdGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIwCgxsYXN0X3VzZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhAKCGRpc2FibGVkGAcgASgIQg4KDF9kZXNjcmlwdGlvbiJVChtMaXN0U2VydmljZUFjY291bnRzUmVzcG9uc2USNgoQc2VydmljZV9hY2NvdW50cxgBIAMoCzIcLnN0YXRlLnYxLlNlcnZpY2VBY2NvdW50SW5mbyIwChtSZXZva2VTZXJ2aWNlQWNjb3VudFJlcXVlc3QSEQoJY2xpZW50X2lkGAEgASgJIi8KHFJldm9rZVNlcnZpY2VBY2NvdW50UmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCIwChtSb3RhdGVTZXJ2aWNlQWNjb3VudFJlcXVlc3QSEQoJY2xpZW50X2lkGAEgASgJIngKHFJvdGF0ZVNlcnZpY2VBY2NvdW50UmVzcG9uc2USEQoJY2xpZW50X2lkGAEgASgJEhUKDWNsaWVudF9zZWNyZXQYAiABKAkSLgoKcm90YXRlZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAi/QEKEUNyZWF0ZVJvbGVSZXF1ZXN0EgwKBG5hbWUYASABKAkSGAoLZGVzY3JpcHRpb24YAiABKAlIAIgBARIPCgdhY3Rpb25zGAMgAygJEh0KEGxhYmVsX3Njb3BlX2V4cHIYBCABKAlIAYgBARI8ChJjcmVhdGVfY29uc3RyYWludHMYBSABKAsyGy5zdGF0ZS52MS5DcmVhdGVDb25zdHJhaW50c0gCiAEBEhYKDmltbXV0YWJsZV9rZXlzGAYgAygJQg4KDF9kZXNjcmlwdGlvbkITChFfbGFiZWxfc2NvcGVfZXhwckIVChNfY3JlYXRlX2NvbnN0cmFpbnRzIqYBChFDcmVhdGVDb25zdHJhaW50cxJBCgtjb25zdHJhaW50cxgBIAMoCzIsLnN0YXRlLnYxLkNyZWF0ZUNvbnN0cmFpbnRzLkNvbnN0cmFpbnRzRW50cnkaTgoQQ29uc3RyYWludHNFbnRyeRILCgNrZXkYASABKAkSKQoFdmFsdWUYAiABKAsyGi5zdGF0ZS52MS5DcmVhdGVDb25zdHJhaW50OgI4ASI8ChBDcmVhdGVDb25zdHJhaW50EhYKDmFsbG93ZWRfdmFsdWVzGAEgAygJEhAKCHJlcXVpcmVkGAIgASgIIvECCghSb2xlSW5mbxIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEhgKC2Rlc2NyaXB0aW9uGAMgASgJSACIAQESDwoHYWN0aW9ucxgEIAMoCRIdChBsYWJlbF9zY29wZV9leHByGAUgASgJSAGIAQESPAoSY3JlYXRlX2NvbnN0cmFpbnRzGAYgASgLMhsuc3RhdGUudjEuQ3JlYXRlQ29uc3RyYWludHNIAogBARIWCg5pbW11dGFibGVfa2V5cxgHIAMoCRIuCgpjcmVhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIPCgd2ZXJzaW9uGAogASgFQg4KDF9kZXNjcmlwdGlvbkITChFfbGFiZWxfc2NvcGVfZXhwckIVChNfY3JlYXRlX2NvbnN0cmFpbnRzIjYKEkNyZWF0ZVJvbGVSZXNwb25zZRIgCgRyb2xlGAEgASgLMhIuc3RhdGUudjEuUm9sZUluZm8iEgoQTGlzdFJvbGVzUmVxdWVzdCI2ChFMaXN0Um9sZXNSZXNwb25zZRIhCgVyb2xlcxgBIAMoCzISLnN0YXRlLnYxLlJvbGVJbmZvIpcCChFVcGRhdGVSb2xlUmVxdWVzdBIMCgRuYW1lGAEgASgJEhgKC2Rlc2NyaXB0aW9uGAIgASgJSACIAQESDwoHYWN0aW9ucxgDIAMoCRIdChBsYWJlbF9zY29wZV9leHByGAQgASgJSAGIAQESPAoSY3JlYXRlX2NvbnN0cmFpbnRzGAUgASgLMhsuc3RhdGUudjEuQ3JlYXRlQ29uc3RyYWludHNIAogBARIWCg5pbW11dGFibGVfa2V5cxgGIAMoCRIYChBleHBlY3RlZF92ZXJzaW9uGAcgASgFQg4KDF9kZXNjcmlwdGlvbkITChFfbGFiZWxfc2NvcGVfZXhwckIVChNfY3JlYXRlX2NvbnN0cmFpbnRzIjYKElVwZGF0ZVJvbGVSZXNwb25zZRIgCgRyb2xlGAEgASgLMhIuc3RhdGUudjEuUm9sZUluZm8iIQoRRGVsZXRlUm9sZVJlcXVlc3QSDAoEbmFtZRgBIAEoCSIlChJEZWxldGVSb2xlUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCJUChFBc3NpZ25Sb2xlUmVxdWVzdBIWCg5wcmluY2lwYWxfdHlwZRgBIAEoCRIUCgxwcmluY2lwYWxfaWQYAiABKAkSEQoJcm9sZV9uYW1lGAMgASgJIlYKEkFzc2lnblJvbGVSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEi8KC2Fzc2lnbmVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJUChFSZW1vdmVSb2xlUmVxdWVzdBIWCg5wcmluY2lwYWxfdHlwZRgBIAEoCRIUCgxwcmluY2lwYWxfaWQYAiABKAkSEQoJcm9sZV9uYW1lGAMgASgJIiUKElJlbW92ZVJvbGVSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIkQKFExpc3RVc2VyUm9sZXNSZXF1ZXN0EhYKDnByaW5jaXBhbF90eXBlGAEgASgJEhQKDHByaW5jaXBhbF9pZBgCIAEoCSJ1ChJSb2xlQXNzaWdubWVudEluZm8SEQoJcm9sZV9uYW1lGAEgASgJEi8KC2Fzc2lnbmVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIbChNhc3NpZ25lZF9ieV91c2VyX2lkGAMgASgJIkQKFUxpc3RVc2VyUm9sZXNSZXNwb25zZRIrCgVyb2xlcxgBIAMoCzIcLnN0YXRlLnYxLlJvbGVBc3NpZ25tZW50SW5mbyI/ChZBc3NpZ25Hcm91cFJvbGVSZXF1ZXN0EhIKCmdyb3VwX25hbWUYASABKAkSEQoJcm9sZV9uYW1lGAIgASgJIlsKF0Fzc2lnbkdyb3VwUm9sZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSLwoLYXNzaWduZWRfYXQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIj8KFlJlbW92ZUdyb3VwUm9sZVJlcXVlc3QSEgoKZ3JvdXBfbmFtZRgBIAEoCRIRCglyb2xlX25hbWUYAiABKAkiKgoXUmVtb3ZlR3JvdXBSb2xlUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCI/ChVMaXN0R3JvdXBSb2xlc1JlcXVlc3QSFwoKZ3JvdXBfbmFtZRgBIAEoCUgAiAEBQg0KC19ncm91cF9uYW1lIo4BChdHcm91cFJvbGVBc3NpZ25tZW50SW5mbxISCgpncm91cF9uYW1lGAEgASgJEhEKCXJvbGVfbmFtZRgCIAEoCRIvCgthc3NpZ25lZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASGwoTYXNzaWduZWRfYnlfdXNlcl9pZBgEIAEoCSJQChZMaXN0R3JvdXBSb2xlc1Jlc3BvbnNlEjYKC2Fzc2lnbm1lbnRzGAEgAygLMiEuc3RhdGUudjEuR3JvdXBSb2xlQXNzaWdubWVudEluZm8iTgoeR2V0RWZmZWN0aXZlUGVybWlzc2lvbnNSZXF1ZXN0EhYKDnByaW5jaXBhbF90eXBlGAEgASgJEhQKDHByaW5jaXBhbF9pZBgCIAEoCSLcAQoURWZmZWN0aXZlUGVybWlzc2lvbnMSDQoFcm9sZXMYASADKAkSDwoHYWN0aW9ucxgCIAMoCRIZChFsYWJlbF9zY29wZV9leHBycxgDIAMoCRJGChxlZmZlY3RpdmVfY3JlYXRlX2NvbnN0cmFpbnRzGAQgASgLMhsuc3RhdGUudjEuQ3JlYXRlQ29uc3RyYWludHNIAIgBARIgChhlZmZlY3RpdmVfaW1tdXRhYmxlX2tleXMYBSADKAlCHwodX2VmZmVjdGl2ZV9jcmVhdGVfY29uc3RyYWludHMiVgofR2V0RWZmZWN0aXZlUGVybWlzc2lvbnNSZXNwb25zZRIzCgtwZXJtaXNzaW9ucxgBIAEoCzIeLnN0YXRlLnYxLkVmZmVjdGl2ZVBlcm1pc3Npb25zIiYKE0xpc3RTZXNzaW9uc1JlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCSL7AQoLU2Vzc2lvbkluZm8SCgoCaWQYASABKAkSLgoKY3JlYXRlZF9hdBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMbGFzdF91c2VkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgpleHBpcmVzX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIXCgp1c2VyX2FnZW50GAUgASgJSACIAQESFwoKaXBfYWRkcmVzcxgGIAEoCUgBiAEBQg0KC191c2VyX2FnZW50Qg0KC19pcF9hZGRyZXNzIj8KFExpc3RTZXNzaW9uc1Jlc3BvbnNlEicKCHNlc3Npb25zGAEgAygLMhUuc3RhdGUudjEuU2Vzc2lvbkluZm8iKgoUUmV2b2tlU2Vzc2lvblJlcXVlc3QSEgoKc2Vzc2lvbl9pZBgBIAEoCSIoChVSZXZva2VTZXNzaW9uUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCJ6ChZTZXRPdXRwdXRTY2hlbWFSZXF1ZXN0EhgKDnN0YXRlX2xvZ2ljX2lkGAEgASgJSAASFAoKc3RhdGVfZ3VpZBgCIAEoCUgAEhIKCm91dHB1dF9rZXkYAyABKAkSEwoLc2NoZW1hX2pzb24YBCABKAlCBwoFc3RhdGUiagoXU2V0T3V0cHV0U2NoZW1hUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCBISCgpzdGF0ZV9ndWlkGAIgASgJEhYKDnN0YXRlX2xvZ2ljX2lkGAMgASgJEhIKCm91dHB1dF9rZXkYBCABKAkiZQoWR2V0T3V0cHV0U2NoZW1hUmVxdWVzdBIYCg5zdGF0ZV9sb2dpY19pZBgBIAEoCUgAEhQKCnN0YXRlX2d1aWQYAiABKAlIABISCgpvdXRwdXRfa2V5GAMgASgJQgcKBXN0YXRlIm4KF0dldE91dHB1dFNjaGVtYVJlc3BvbnNlEhIKCnN0YXRlX2d1aWQYASABKAkSFgoOc3RhdGVfbG9naWNfaWQYAiABKAkSEgoKb3V0cHV0X2tleRgDIAEoCRITCgtzY2hlbWFfanNvbhgEIAEoCTLEGQoMU3RhdGVTZXJ2aWNlEkoKC0NyZWF0ZVN0YXRlEhwuc3RhdGUudjEuQ3JlYXRlU3RhdGVSZXF1ZXN0Gh0uc3RhdGUudjEuQ3JlYXRlU3RhdGVSZXNwb25zZRJHCgpMaXN0U3RhdGVzEhsuc3RhdGUudjEuTGlzdFN0YXRlc1JlcXVlc3QaHC5zdGF0ZS52MS5MaXN0U3RhdGVzUmVzcG9uc2USUwoOR2V0U3RhdGVDb25maWcSHy5zdGF0ZS52MS5HZXRTdGF0ZUNvbmZpZ1JlcXVlc3QaIC5zdGF0ZS52MS5HZXRTdGF0ZUNvbmZpZ1Jlc3BvbnNlEk0KDEdldFN0YXRlTG9jaxIdLnN0YXRlLnYxLkdldFN0YXRlTG9ja1JlcXVlc3QaHi5zdGF0ZS52MS5HZXRTdGF0ZUxvY2tSZXNwb25zZRJKCgtVbmxvY2tTdGF0ZRIcLnN0YXRlLnYxLlVubG9ja1N0YXRlUmVxdWVzdBodLnN0YXRlLnYxLlVubG9ja1N0YXRlUmVzcG9uc2USUAoNQWRkRGVwZW5kZW5jeRIeLnN0YXRlLnYxLkFkZERlcGVuZGVuY3lSZXF1ZXN0Gh8uc3RhdGUudjEuQWRkRGVwZW5kZW5jeVJlc3BvbnNlElkKEFJlbW92ZURlcGVuZGVuY3kSIS5zdGF0ZS52MS5SZW1vdmVEZXBlbmRlbmN5UmVxdWVzdBoiLnN0YXRlLnYxLlJlbW92ZURlcGVuZGVuY3lSZXNwb25zZRJZChBMaXN0RGVwZW5kZW5jaWVzEiEuc3RhdGUudjEuTGlzdERlcGVuZGVuY2llc1JlcXVlc3QaIi5zdGF0ZS52MS5MaXN0RGVwZW5kZW5jaWVzUmVzcG9uc2USUwoOTGlzdERlcGVuZGVudHMSHy5zdGF0ZS52MS5MaXN0RGVwZW5kZW50c1JlcXVlc3QaIC5zdGF0ZS52MS5MaXN0RGVwZW5kZW50c1Jlc3BvbnNlElMKDlNlYXJjaEJ5T3V0cHV0Eh8uc3RhdGUudjEuU2VhcmNoQnlPdXRwdXRSZXF1ZXN0GiAuc3RhdGUudjEuU2VhcmNoQnlPdXRwdXRSZXNwb25zZRJiChNHZXRUb3BvbG9naWNhbE9yZGVyEiQuc3RhdGUudjEuR2V0VG9wb2xvZ2ljYWxPcmRlclJlcXVlc3QaJS5zdGF0ZS52MS5HZXRUb3BvbG9naWNhbE9yZGVyUmVzcG9uc2USUwoOR2V0U3RhdGVTdGF0dXMSHy5zdGF0ZS52MS5HZXRTdGF0ZVN0YXR1c1JlcXVlc3QaIC5zdGF0ZS52MS5HZXRTdGF0ZVN0YXR1c1Jlc3BvbnNlEl8KEkdldERlcGVuZGVuY3lHcmFwaBIjLnN0YXRlLnYxLkdldERlcGVuZGVuY3lHcmFwaFJlcXVlc3QaJC5zdGF0ZS52MS5HZXREZXBlbmRlbmN5R3JhcGhSZXNwb25zZRJZChBMaXN0U3RhdGVPdXRwdXRzEiEuc3RhdGUudjEuTGlzdFN0YXRlT3V0cHV0c1JlcXVlc3QaIi5zdGF0ZS52MS5MaXN0U3RhdGVPdXRwdXRzUmVzcG9uc2USTQoMR2V0U3RhdGVJbmZvEh0uc3RhdGUudjEuR2V0U3RhdGVJbmZvUmVxdWVzdBoeLnN0YXRlLnYxLkdldFN0YXRlSW5mb1Jlc3BvbnNlEk0KDExpc3RBbGxFZGdlcxIdLnN0YXRlLnYxLkxpc3RBbGxFZGdlc1JlcXVlc3QaHi5zdGF0ZS52MS5MaXN0QWxsRWRnZXNSZXNwb25zZRJcChFVcGRhdGVTdGF0ZUxhYmVscxIiLnN0YXRlLnYxLlVwZGF0ZVN0YXRlTGFiZWxzUmVxdWVzdBojLnN0YXRlLnYxLlVwZGF0ZVN0YXRlTGFiZWxzUmVzcG9uc2USUwoOR2V0TGFiZWxQb2xpY3kSHy5zdGF0ZS52MS5HZXRMYWJlbFBvbGljeVJlcXVlc3QaIC5zdGF0ZS52MS5HZXRMYWJlbFBvbGljeVJlc3BvbnNlElMKDlNldExhYmVsUG9saWN5Eh8uc3RhdGUudjEuU2V0TGFiZWxQb2xpY3lSZXF1ZXN0GiAuc3RhdGUudjEuU2V0TGFiZWxQb2xpY3lSZXNwb25zZRJlChRDcmVhdGVTZXJ2aWNlQWNjb3VudBIlLnN0YXRlLnYxLkNyZWF0ZVNlcnZpY2VBY2NvdW50UmVxdWVzdBomLnN0YXRlLnYxLkNyZWF0ZVNlcnZpY2VBY2NvdW50UmVzcG9uc2USYgoTTGlzdFNlcnZpY2VBY2NvdW50cxIkLnN0YXRlLnYxLkxpc3RTZXJ2aWNlQWNjb3VudHNSZXF1ZXN0GiUuc3RhdGUudjEuTGlzdFNlcnZpY2VBY2NvdW50c1Jlc3BvbnNlEmUKFFJldm9rZVNlcnZpY2VBY2NvdW50EiUuc3RhdGUudjEuUmV2b2tlU2VydmljZUFjY291bnRSZXF1ZXN0GiYuc3RhdGUudjEuUmV2b2tlU2VydmljZUFjY291bnRSZXNwb25zZRJlChRSb3RhdGVTZXJ2aWNlQWNjb3VudBIlLnN0YXRlLnYxLlJvdGF0ZVNlcnZpY2VBY2NvdW50UmVxdWVzdBomLnN0YXRlLnYxLlJvdGF0ZVNlcnZpY2VBY2NvdW50UmVzcG9uc2USRwoKQ3JlYXRlUm9sZRIbLnN0YXRlLnYxLkNyZWF0ZVJvbGVSZXF1ZXN0Ghwuc3RhdGUudjEuQ3JlYXRlUm9sZVJlc3BvbnNlEkQKCUxpc3RSb2xlcxIaLnN0YXRlLnYxLkxpc3RSb2xlc1JlcXVlc3QaGy5zdGF0ZS52MS5MaXN0Um9sZXNSZXNwb25zZRJHCgpVcGRhdGVSb2xlEhsuc3RhdGUudjEuVXBkYXRlUm9sZVJlcXVlc3QaHC5zdGF0ZS52MS5VcGRhdGVSb2xlUmVzcG9uc2USRwoKRGVsZXRlUm9sZRIbLnN0YXRlLnYxLkRlbGV0ZVJvbGVSZXF1ZXN0Ghwuc3RhdGUudjEuRGVsZXRlUm9sZVJlc3BvbnNlEkcKCkFzc2lnblJvbGUSGy5zdGF0ZS52MS5Bc3NpZ25Sb2xlUmVxdWVzdBocLnN0YXRlLnYxLkFzc2lnblJvbGVSZXNwb25zZRJHCgpSZW1vdmVSb2xlEhsuc3RhdGUudjEuUmVtb3ZlUm9sZVJlcXVlc3QaHC5zdGF0ZS52MS5SZW1vdmVSb2xlUmVzcG9uc2USUAoNTGlzdFVzZXJSb2xlcxIeLnN0YXRlLnYxLkxpc3RVc2VyUm9sZXNSZXF1ZXN0Gh8uc3RhdGUudjEuTGlzdFVzZXJSb2xlc1Jlc3BvbnNlElYKD0Fzc2lnbkdyb3VwUm9sZRIgLnN0YXRlLnYxLkFzc2lnbkdyb3VwUm9sZVJlcXVlc3QaIS5zdGF0ZS52MS5Bc3NpZ25Hcm91cFJvbGVSZXNwb25zZRJWCg9SZW1vdmVHcm91cFJvbGUSIC5zdGF0ZS52MS5SZW1vdmVHcm91cFJvbGVSZXF1ZXN0GiEuc3RhdGUudjEuUmVtb3ZlR3JvdXBSb2xlUmVzcG9uc2USUwoOTGlzdEdyb3VwUm9sZXMSHy5zdGF0ZS52MS5MaXN0R3JvdXBSb2xlc1JlcXVlc3QaIC5zdGF0ZS52MS5MaXN0R3JvdXBSb2xlc1Jlc3BvbnNlEm4KF0dldEVmZmVjdGl2ZVBlcm1pc3Npb25zEiguc3RhdGUudjEuR2V0RWZmZWN0aXZlUGVybWlzc2lvbnNSZXF1ZXN0Gikuc3RhdGUudjEuR2V0RWZmZWN0aXZlUGVybWlzc2lvbnNSZXNwb25zZRJNCgxMaXN0U2Vzc2lvbnMSHS5zdGF0ZS52MS5MaXN0U2Vzc2lvbnNSZXF1ZXN0Gh4uc3RhdGUudjEuTGlzdFNlc3Npb25zUmVzcG9uc2USUAoNUmV2b2tlU2Vzc2lvbhIeLnN0YXRlLnYxLlJldm9rZVNlc3Npb25SZXF1ZXN0Gh8uc3RhdGUudjEuUmV2b2tlU2Vzc2lvblJlc3BvbnNlElYKD1NldE91dHB1dFNjaGVtYRIgLnN0YXRlLnYxLlNldE91dHB1dFNjaGVtYVJlcXVlc3QaIS5zdGF0ZS52MS5TZXRPdXRwdXRTY2hlbWFSZXNwb25zZRJWCg9HZXRPdXRwdXRTY2hlbWESIC5zdGF0ZS52MS5HZXRPdXRwdXRTY2hlbWFSZXF1ZXN0GiEuc3RhdGUudjEuR2V0T3V0cHV0U2NoZW1hUmVzcG9uc2VCOlo4Z2l0aHViLmNvbS90ZXJyYWNvbnN0cnVjdHMvZ3JpZC9wa2cvYXBpL3N0YXRlL3YxO3N0YXRldjFiBnByb3RvMw", [file_google_protobuf_timestamp]);
+  fileDesc("ChRzdGF0ZS92MS9zdGF0ZS5wcm90bxIIc3RhdGUudjEitgEKEkNyZWF0ZVN0YXRlUmVxdWVzdBIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJEjgKBmxhYmVscxgDIAMoCzIoLnN0YXRlLnYxLkNyZWF0ZVN0YXRlUmVxdWVzdC5MYWJlbHNFbnRyeRIXCg9pZGVtcG90ZW5jeV9rZXkYBCABKAkaLQoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJmChNDcmVhdGVTdGF0ZVJlc3BvbnNlEgwKBGd1aWQYASABKAkSEAoIbG9naWNfaWQYAiABKAkSLwoOYmFja2VuZF9jb25maWcYAyABKAsyFy5zdGF0ZS52MS5CYWNrZW5kQ29uZmlnItIBChFMaXN0U3RhdGVzUmVxdWVzdBITCgZmaWx0ZXIYASABKAlIAIgBARIbCg5pbmNsdWRlX2xhYmVscxgCIAEoCEgBiAEBEhsKDmluY2x1ZGVfc3RhdHVzGAMgASgISAKIAQESEQoJcGFnZV9zaXplGAQgASgFEhIKCnBhZ2VfdG9rZW4YBSABKAkSFgoObGFiZWxfc2VsZWN0b3IYBiABKAlCCQoHX2ZpbHRlckIRCg9faW5jbHVkZV9sYWJlbHNCEQoPX2luY2x1ZGVfc3RhdHVzIlIKEkxpc3RTdGF0ZXNSZXNwb25zZRIjCgZzdGF0ZXMYASADKAsyEy5zdGF0ZS52MS5TdGF0ZUluZm8SFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJIrcECglTdGF0ZUluZm8SDAoEZ3VpZBgBIAEoCRIQCghsb2dpY19pZBgCIAEoCRIOCgZsb2NrZWQYAyABKAgSLgoKY3JlYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEgoKc2l6ZV9ieXRlcxgGIAEoAxIcCg9jb21wdXRlZF9zdGF0dXMYByABKAlIAIgBARIcChRkZXBlbmRlbmN5X2xvZ2ljX2lkcxgIIAMoCRIvCgZsYWJlbHMYCSADKAsyHy5zdGF0ZS52MS5TdGF0ZUluZm8uTGFiZWxzRW50cnkSHwoSZGVwZW5kZW5jaWVzX2NvdW50GAogASgFSAGIAQESHQoQZGVwZW5kZW50c19jb3VudBgLIAEoBUgCiAEBEhoKDW91dHB1dHNfY291bnQYDCABKAVIA4gBARIXCgpjcmVhdGVkX2J5GA0gASgJSASIAQEaQwoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEiMKBXZhbHVlGAIgASgLMhQuc3RhdGUudjEuTGFiZWxWYWx1ZToCOAFCEgoQX2NvbXB1dGVkX3N0YXR1c0IVChNfZGVwZW5kZW5jaWVzX2NvdW50QhMKEV9kZXBlbmRlbnRzX2NvdW50QhAKDl9vdXRwdXRzX2NvdW50Qg0KC19jcmVhdGVkX2J5Ik4KDUJhY2tlbmRDb25maWcSDwoHYWRkcmVzcxgBIAEoCRIUCgxsb2NrX2FkZHJlc3MYAiABKAkSFgoOdW5sb2NrX2FkZHJlc3MYAyABKAkiKQoVR2V0U3RhdGVDb25maWdSZXF1ZXN0EhAKCGxvZ2ljX2lkGAEgASgJIlcKFkdldFN0YXRlQ29uZmlnUmVzcG9uc2USDAoEZ3VpZBgBIAEoCRIvCg5iYWNrZW5kX2NvbmZpZxgCIAEoCzIXLnN0YXRlLnYxLkJhY2tlbmRDb25maWciIwoTR2V0U3RhdGVMb2NrUmVxdWVzdBIMCgRndWlkGAEgASgJIpABCghMb2NrSW5mbxIKCgJpZBgBIAEoCRIRCglvcGVyYXRpb24YAiABKAkSDAoEaW5mbxgDIAEoCRILCgN3aG8YBCABKAkSDwoHdmVyc2lvbhgFIAEoCRIrCgdjcmVhdGVkGAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIMCgRwYXRoGAcgASgJIj0KCVN0YXRlTG9jaxIOCgZsb2NrZWQYASABKAgSIAoEaW5mbxgCIAEoCzISLnN0YXRlLnYxLkxvY2tJbmZvIjkKFEdldFN0YXRlTG9ja1Jlc3BvbnNlEiEKBGxvY2sYASABKAsyEy5zdGF0ZS52MS5TdGF0ZUxvY2siMwoSVW5sb2NrU3RhdGVSZXF1ZXN0EgwKBGd1aWQYASABKAkSDwoHbG9ja19pZBgCIAEoCSI4ChNVbmxvY2tTdGF0ZVJlc3BvbnNlEiEKBGxvY2sYASABKAsyEy5zdGF0ZS52MS5TdGF0ZUxvY2si/QEKFEFkZERlcGVuZGVuY3lSZXF1ZXN0EhcKDWZyb21fbG9naWNfaWQYASABKAlIABITCglmcm9tX2d1aWQYAiABKAlIABITCgtmcm9tX291dHB1dBgDIAEoCRIVCgt0b19sb2dpY19pZBgEIAEoCUgBEhEKB3RvX2d1aWQYBSABKAlIARIaCg10b19pbnB1dF9uYW1lGAYgASgJSAKIAQESHAoPbW9ja192YWx1ZV9qc29uGAcgASgJSAOIAQFCDAoKZnJvbV9zdGF0ZUIKCgh0b19zdGF0ZUIQCg5fdG9faW5wdXRfbmFtZUISChBfbW9ja192YWx1ZV9qc29uIlcKFUFkZERlcGVuZGVuY3lSZXNwb25zZRImCgRlZGdlGAEgASgLMhguc3RhdGUudjEuRGVwZW5kZW5jeUVkZ2USFgoOYWxyZWFkeV9leGlzdHMYAiABKAgiKgoXUmVtb3ZlRGVwZW5kZW5jeVJlcXVlc3QSDwoHZWRnZV9pZBgBIAEoAyIrChhSZW1vdmVEZXBlbmRlbmN5UmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCJGChdMaXN0RGVwZW5kZW5jaWVzUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIAEIHCgVzdGF0ZSJDChhMaXN0RGVwZW5kZW5jaWVzUmVzcG9uc2USJwoFZWRnZXMYASADKAsyGC5zdGF0ZS52MS5EZXBlbmRlbmN5RWRnZSJEChVMaXN0RGVwZW5kZW50c1JlcXVlc3QSEgoIbG9naWNfaWQYASABKAlIABIOCgRndWlkGAIgASgJSABCBwoFc3RhdGUiQQoWTGlzdERlcGVuZGVudHNSZXNwb25zZRInCgVlZGdlcxgBIAMoCzIYLnN0YXRlLnYxLkRlcGVuZGVuY3lFZGdlIisKFVNlYXJjaEJ5T3V0cHV0UmVxdWVzdBISCgpvdXRwdXRfa2V5GAEgASgJIkEKFlNlYXJjaEJ5T3V0cHV0UmVzcG9uc2USJwoFZWRnZXMYASADKAsyGC5zdGF0ZS52MS5EZXBlbmRlbmN5RWRnZSJvChpHZXRUb3BvbG9naWNhbE9yZGVyUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIABIWCglkaXJlY3Rpb24YAyABKAlIAYgBAUIHCgVzdGF0ZUIMCgpfZGlyZWN0aW9uIj4KG0dldFRvcG9sb2dpY2FsT3JkZXJSZXNwb25zZRIfCgZsYXllcnMYASADKAsyDy5zdGF0ZS52MS5MYXllciI6CgVMYXllchINCgVsZXZlbBgBIAEoBRIiCgZzdGF0ZXMYAiADKAsyEi5zdGF0ZS52MS5TdGF0ZVJlZiIqCghTdGF0ZVJlZhIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJIkQKFUdldFN0YXRlU3RhdHVzUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIAEIHCgVzdGF0ZSKgAQoWR2V0U3RhdGVTdGF0dXNSZXNwb25zZRIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJEg4KBnN0YXR1cxgDIAEoCRIsCghpbmNvbWluZxgEIAMoCzIaLnN0YXRlLnYxLkluY29taW5nRWRnZVZpZXcSKAoHc3VtbWFyeRgFIAEoCzIXLnN0YXRlLnYxLlN0YXR1c1N1bW1hcnki2wIKEEluY29taW5nRWRnZVZpZXcSDwoHZWRnZV9pZBgBIAEoAxIRCglmcm9tX2d1aWQYAiABKAkSFQoNZnJvbV9sb2dpY19pZBgDIAEoCRITCgtmcm9tX291dHB1dBgEIAEoCRIOCgZzdGF0dXMYBSABKAkSFgoJaW5fZGlnZXN0GAYgASgJSACIAQESFwoKb3V0X2RpZ2VzdBgHIAEoCUgBiAEBEjMKCmxhc3RfaW5fYXQYCCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAKIAQESNAoLbGFzdF9vdXRfYXQYCSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAOIAQESDwoHY3VycmVudBgKIAEoCEIMCgpfaW5fZGlnZXN0Qg0KC19vdXRfZGlnZXN0Qg0KC19sYXN0X2luX2F0Qg4KDF9sYXN0X291dF9hdCJzCg1TdGF0dXNTdW1tYXJ5EhYKDmluY29taW5nX2NsZWFuGAEgASgFEhYKDmluY29taW5nX2RpcnR5GAIgASgFEhgKEGluY29taW5nX3BlbmRpbmcYAyABKAUSGAoQaW5jb21pbmdfdW5rbm93bhgEIAEoBSJuChlHZXREZXBlbmRlbmN5R3JhcGhSZXF1ZXN0EhIKCGxvZ2ljX2lkGAEgASgJSAASDgoEZ3VpZBgCIAEoCUgAEhYKCW1heF9kZXB0aBgDIAEoBUgBiAEBQgcKBXN0YXRlQgwKCl9tYXhfZGVwdGgi4QEKGkdldERlcGVuZGVuY3lHcmFwaFJlc3BvbnNlEhUKDWNvbnN1bWVyX2d1aWQYASABKAkSGQoRY29uc3VtZXJfbG9naWNfaWQYAiABKAkSKgoJcHJvZHVjZXJzGAMgAygLMhcuc3RhdGUudjEuUHJvZHVjZXJTdGF0ZRInCgVlZGdlcxgEIAMoCzIYLnN0YXRlLnYxLkRlcGVuZGVuY3lFZGdlEhEKCW1heF9kZXB0aBgFIAEoBRIRCgl0cnVuY2F0ZWQYBiABKAgSFgoOY3ljbGVfZWRnZV9pZHMYByADKAMiTwogUmVjb21wdXRlRGVwZW5kZW5jeVN0YXR1c1JlcXVlc3QSEgoIbG9naWNfaWQYASABKAlIABIOCgRndWlkGAIgASgJSABCBwoFc3RhdGUirwEKIVJlY29tcHV0ZURlcGVuZGVuY3lTdGF0dXNSZXNwb25zZRIVCg1wcm9kdWNlcl9ndWlkGAEgASgJEhkKEXByb2R1Y2VyX2xvZ2ljX2lkGAIgASgJEicKBWVkZ2VzGAMgAygLMhguc3RhdGUudjEuRGVwZW5kZW5jeUVkZ2USGAoQY2hhbmdlZF9lZGdlX2lkcxgEIAMoAxIVCg1za2lwcGVkX2VkZ2VzGAUgASgFIm8KDVByb2R1Y2VyU3RhdGUSDAoEZ3VpZBgBIAEoCRIQCghsb2dpY19pZBgCIAEoCRIvCg5iYWNrZW5kX2NvbmZpZxgDIAEoCzIXLnN0YXRlLnYxLkJhY2tlbmRDb25maWcSDQoFZGVwdGgYBCABKAUiugQKDkRlcGVuZGVuY3lFZGdlEgoKAmlkGAEgASgDEhEKCWZyb21fZ3VpZBgCIAEoCRIVCg1mcm9tX2xvZ2ljX2lkGAMgASgJEhMKC2Zyb21fb3V0cHV0GAQgASgJEg8KB3RvX2d1aWQYBSABKAkSEwoLdG9fbG9naWNfaWQYBiABKAkSGgoNdG9faW5wdXRfbmFtZRgHIAEoCUgAiAEBEg4KBnN0YXR1cxgIIAEoCRIWCglpbl9kaWdlc3QYCSABKAlIAYgBARIXCgpvdXRfZGlnZXN0GAogASgJSAKIAQESHAoPbW9ja192YWx1ZV9qc29uGAsgASgJSAOIAQESMwoKbGFzdF9pbl9hdBgMIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIBIgBARI0CgtsYXN0X291dF9hdBgNIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIBYgBARIuCgpjcmVhdGVkX2F0GA4gASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GA8gASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIPCgdjdXJyZW50GBAgASgIQhAKDl90b19pbnB1dF9uYW1lQgwKCl9pbl9kaWdlc3RCDQoLX291dF9kaWdlc3RCEgoQX21vY2tfdmFsdWVfanNvbkINCgtfbGFzdF9pbl9hdEIOCgxfbGFzdF9vdXRfYXQi+QMKCU91dHB1dEtleRILCgNrZXkYASABKAkSEQoJc2Vuc2l0aXZlGAIgASgIEhgKC3NjaGVtYV9qc29uGAMgASgJSACIAQESGgoNc2NoZW1hX3NvdXJjZRgEIAEoCUgBiAEBEh4KEXZhbGlkYXRpb25fc3RhdHVzGAUgASgJSAKIAQESHQoQdmFsaWRhdGlvbl9lcnJvchgGIAEoCUgDiAEBEjUKDHZhbGlkYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIBIgBARIXCgp2YWx1ZV9qc29uGAggASgJSAWIAQESFwoKc2NoZW1hX3JlZhgJIAEoCUgGiAEBEhsKDnNjaGVtYV92ZXJzaW9uGAogASgFSAeIAQESJQoYdmFsaWRhdGVkX3NjaGVtYV92ZXJzaW9uGAsgASgFSAiIAQFCDgoMX3NjaGVtYV9qc29uQhAKDl9zY2hlbWFfc291cmNlQhQKEl92YWxpZGF0aW9uX3N0YXR1c0ITChFfdmFsaWRhdGlvbl9lcnJvckIPCg1fdmFsaWRhdGVkX2F0Qg0KC192YWx1ZV9qc29uQg0KC19zY2hlbWFfcmVmQhEKD19zY2hlbWFfdmVyc2lvbkIbChlfdmFsaWRhdGVkX3NjaGVtYV92ZXJzaW9uIkYKF0xpc3RTdGF0ZU91dHB1dHNSZXF1ZXN0EhIKCGxvZ2ljX2lkGAEgASgJSAASDgoEZ3VpZBgCIAEoCUgAQgcKBXN0YXRlImwKGExpc3RTdGF0ZU91dHB1dHNSZXNwb25zZRISCgpzdGF0ZV9ndWlkGAEgASgJEhYKDnN0YXRlX2xvZ2ljX2lkGAIgASgJEiQKB291dHB1dHMYAyADKAsyEy5zdGF0ZS52MS5PdXRwdXRLZXkibwocTGlzdFN0YXRlT3V0cHV0c0JhdGNoUmVxdWVzdBIiCgZzdGF0ZXMYASADKAsyEi5zdGF0ZS52MS5TdGF0ZVJlZhITCgtvdXRwdXRfa2V5cxgCIAMoCRIWCg5pbmNsdWRlX3ZhbHVlcxgDIAEoCCJHCh1MaXN0U3RhdGVPdXRwdXRzQmF0Y2hSZXNwb25zZRImCgZzdGF0ZXMYASADKAsyFi5zdGF0ZS52MS5TdGF0ZU91dHB1dHMiYAoMU3RhdGVPdXRwdXRzEhIKCnN0YXRlX2d1aWQYASABKAkSFgoOc3RhdGVfbG9naWNfaWQYAiABKAkSJAoHb3V0cHV0cxgDIAMoCzITLnN0YXRlLnYxLk91dHB1dEtleSJOChtHZXRTdGF0ZU91dHB1dFZhbHVlc1JlcXVlc3QSIQoFc3RhdGUYASABKAsyEi5zdGF0ZS52MS5TdGF0ZVJlZhIMCgRrZXlzGAIgAygJInEKHEdldFN0YXRlT3V0cHV0VmFsdWVzUmVzcG9uc2USEgoKc3RhdGVfZ3VpZBgBIAEoCRIWCg5zdGF0ZV9sb2dpY19pZBgCIAEoCRIlCgZ2YWx1ZXMYAyADKAsyFS5zdGF0ZS52MS5PdXRwdXRWYWx1ZSJnCgtPdXRwdXRWYWx1ZRILCgNrZXkYASABKAkSEQoJc2Vuc2l0aXZlGAIgASgIEhcKCnZhbHVlX2pzb24YAyABKAlIAIgBARIQCghyZWRhY3RlZBgEIAEoCEINCgtfdmFsdWVfanNvbiKEAQoTR2V0U3RhdGVJbmZvUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIABIkChdpbmNsdWRlX2NvbXB1dGVkX3N0YXR1cxgDIAEoCEgBiAEBQgcKBXN0YXRlQhoKGF9pbmNsdWRlX2NvbXB1dGVkX3N0YXR1cyKSBAoUR2V0U3RhdGVJbmZvUmVzcG9uc2USDAoEZ3VpZBgBIAEoCRIQCghsb2dpY19pZBgCIAEoCRIvCg5iYWNrZW5kX2NvbmZpZxgDIAEoCzIXLnN0YXRlLnYxLkJhY2tlbmRDb25maWcSLgoMZGVwZW5kZW5jaWVzGAQgAygLMhguc3RhdGUudjEuRGVwZW5kZW5jeUVkZ2USLAoKZGVwZW5kZW50cxgFIAMoCzIYLnN0YXRlLnYxLkRlcGVuZGVuY3lFZGdlEiQKB291dHB1dHMYBiADKAsyEy5zdGF0ZS52MS5PdXRwdXRLZXkSLgoKY3JlYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASHAoPY29tcHV0ZWRfc3RhdHVzGAkgASgJSACIAQESEgoKc2l6ZV9ieXRlcxgKIAEoAxI6CgZsYWJlbHMYCyADKAsyKi5zdGF0ZS52MS5HZXRTdGF0ZUluZm9SZXNwb25zZS5MYWJlbHNFbnRyeRpDCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSIwoFdmFsdWUYAiABKAsyFC5zdGF0ZS52MS5MYWJlbFZhbHVlOgI4AUISChBfY29tcHV0ZWRfc3RhdHVzIjwKE0xpc3RBbGxFZGdlc1JlcXVlc3QSEQoJcGFnZV9zaXplGAIgASgFEhIKCnBhZ2VfdG9rZW4YAyABKAkiWAoUTGlzdEFsbEVkZ2VzUmVzcG9uc2USJwoFZWRnZXMYASADKAsyGC5zdGF0ZS52MS5EZXBlbmRlbmN5RWRnZRIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiWwoKTGFiZWxWYWx1ZRIWCgxzdHJpbmdfdmFsdWUYASABKAlIABIWCgxudW1iZXJfdmFsdWUYAiABKAFIABIUCgpib29sX3ZhbHVlGAMgASgISABCBwoFdmFsdWUi8wEKGFVwZGF0ZVN0YXRlTGFiZWxzUmVxdWVzdBIQCghzdGF0ZV9pZBgBIAEoCRI6CgRhZGRzGAIgAygLMiwuc3RhdGUudjEuVXBkYXRlU3RhdGVMYWJlbHNSZXF1ZXN0LkFkZHNFbnRyeRIQCghyZW1vdmFscxgDIAMoCRIeChFjbGllbnRfcmVxdWVzdF9pZBgEIAEoCUgAiAEBGkEKCUFkZHNFbnRyeRILCgNrZXkYASABKAkSIwoFdmFsdWUYAiABKAsyFC5zdGF0ZS52MS5MYWJlbFZhbHVlOgI4AUIUChJfY2xpZW50X3JlcXVlc3RfaWQilgIKGVVwZGF0ZVN0YXRlTGFiZWxzUmVzcG9uc2USEAoIc3RhdGVfaWQYASABKAkSPwoGbGFiZWxzGAIgAygLMi8uc3RhdGUudjEuVXBkYXRlU3RhdGVMYWJlbHNSZXNwb25zZS5MYWJlbHNFbnRyeRIWCg5wb2xpY3lfdmVyc2lvbhgDIAEoBRIZChFjb21wbGlhbmNlX3N0YXR1cxgEIAEoCRIuCgp1cGRhdGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBpDCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSIwoFdmFsdWUYAiABKAsyFC5zdGF0ZS52MS5MYWJlbFZhbHVlOgI4ASJ6Ch1UcmFuc2ZlclN0YXRlT3duZXJzaGlwUmVxdWVzdBISCghsb2dpY19pZBgBIAEoCUgAEg4KBGd1aWQYAiABKAlIABIWCg5uZXdfb3duZXJfdHlwZRgDIAEoCRIUCgxuZXdfb3duZXJfaWQYBCABKAlCBwoFc3RhdGUiWQoeVHJhbnNmZXJTdGF0ZU93bmVyc2hpcFJlc3BvbnNlEgwKBGd1aWQYASABKAkSFgoOcHJldmlvdXNfb3duZXIYAiABKAkSEQoJbmV3X293bmVyGAMgASgJImAKGExhYmVsQ29uc3RyYWludFZpb2xhdGlvbhIMCgRyb2xlGAEgASgJEhEKCWxhYmVsX2tleRgCIAEoCRISCgpjb25zdHJhaW50GAMgASgJEg8KB21lc3NhZ2UYBCABKAkiFwoVR2V0TGFiZWxQb2xpY3lSZXF1ZXN0Ip4BChZHZXRMYWJlbFBvbGljeVJlc3BvbnNlEg8KB3ZlcnNpb24YASABKAUSEwoLcG9saWN5X2pzb24YAiABKAkSLgoKY3JlYXRlZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiLAoVU2V0TGFiZWxQb2xpY3lSZXF1ZXN0EhMKC3BvbGljeV9qc29uGAEgASgJIlkKFlNldExhYmVsUG9saWN5UmVzcG9uc2USDwoHdmVyc2lvbhgBIAEoBRIuCgp1cGRhdGVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJpChtDcmVhdGVTZXJ2aWNlQWNjb3VudFJlcXVlc3QSDAoEbmFtZRgBIAEoCRIYCgtkZXNjcmlwdGlvbhgCIAEoCUgAiAEBEhIKCnJvbGVfbmFtZXMYAyADKAlCDgoMX2Rlc2NyaXB0aW9uIqEBChxDcmVhdGVTZXJ2aWNlQWNjb3VudFJlc3BvbnNlEgoKAmlkGAEgASgJEhEKCWNsaWVudF9pZBgCIAEoCRIVCg1jbGllbnRfc2VjcmV0GAMgASgJEgwKBG5hbWUYBCABKAkSLgoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDQoFcm9sZXMYBiADKAkiiQIKGkxpc3RTZXJ2aWNlQWNjb3VudHNSZXF1ZXN0EhgKC25hbWVfcHJlZml4GAEgASgJSACIAQESFQoIZGlzYWJsZWQYAiABKAhIAYgBARIXCgpjcmVhdGVkX2J5GAMgASgJSAKIAQESOQoQbGFzdF91c2VkX2JlZm9yZRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIA4gBARIRCglwYWdlX3NpemUYBSABKAUSEgoKcGFnZV90b2tlbhgGIAEoCUIOCgxfbmFtZV9wcmVmaXhCCwoJX2Rpc2FibGVkQg0KC19jcmVhdGVkX2J5QhMKEV9sYXN0X3VzZWRfYmVmb3JlIt8BChJTZXJ2aWNlQWNjb3VudEluZm8SCgoCaWQYASABKAkSEQoJY2xpZW50X2lkGAIgASgJEgwKBG5hbWUYAyABKAkSGAoLZGVzY3JpcHRpb24YBCABKAlIAIgBARIuCgpjcmVhdGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIwCgxsYXN0X3VzZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhAKCGRpc2FibGVkGAcgASgIQg4KDF9kZXNjcmlwdGlvbiJuChtMaXN0U2VydmljZUFjY291bnRzUmVzcG9uc2USNgoQc2VydmljZV9hY2NvdW50cxgBIAMoCzIcLnN0YXRlLnYxLlNlcnZpY2VBY2NvdW50SW5mbxIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiMAobUmV2b2tlU2VydmljZUFjY291bnRSZXF1ZXN0EhEKCWNsaWVudF9pZBgBIAEoCSIvChxSZXZva2VTZXJ2aWNlQWNjb3VudFJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiYgobUm90YXRlU2VydmljZUFjY291bnRSZXF1ZXN0EhEKCWNsaWVudF9pZBgBIAEoCRIcCg9vdmVybGFwX3NlY29uZHMYAiABKANIAIgBAUISChBfb3ZlcmxhcF9zZWNvbmRzItwBChxSb3RhdGVTZXJ2aWNlQWNjb3VudFJlc3BvbnNlEhEKCWNsaWVudF9pZBgBIAEoCRIVCg1jbGllbnRfc2VjcmV0GAIgASgJEi4KCnJvdGF0ZWRfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEkMKGnByZXZpb3VzX3NlY3JldF9leHBpcmVzX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgAiAEBQh0KG19wcmV2aW91c19zZWNyZXRfZXhwaXJlc19hdCLeAgoRQ3JlYXRlUm9sZVJlcXVlc3QSDAoEbmFtZRgBIAEoCRIYCgtkZXNjcmlwdGlvbhgCIAEoCUgAiAEBEg8KB2FjdGlvbnMYAyADKAkSHQoQbGFiZWxfc2NvcGVfZXhwchgEIAEoCUgBiAEBEjwKEmNyZWF0ZV9jb25zdHJhaW50cxgFIAEoCzIbLnN0YXRlLnYxLkNyZWF0ZUNvbnN0cmFpbnRzSAKIAQESFgoOaW1tdXRhYmxlX2tleXMYBiADKAkSHAoPbWF4X2Fzc2lnbm1lbnRzGAcgASgFSAOIAQESFQoNb3duZXJfYWN0aW9ucxgIIAMoCRIWCg5kZWZhdWx0X2xhYmVscxgJIAEoCEIOCgxfZGVzY3JpcHRpb25CEwoRX2xhYmVsX3Njb3BlX2V4cHJCFQoTX2NyZWF0ZV9jb25zdHJhaW50c0ISChBfbWF4X2Fzc2lnbm1lbnRzIqYBChFDcmVhdGVDb25zdHJhaW50cxJBCgtjb25zdHJhaW50cxgBIAMoCzIsLnN0YXRlLnYxLkNyZWF0ZUNvbnN0cmFpbnRzLkNvbnN0cmFpbnRzRW50cnkaTgoQQ29uc3RyYWludHNFbnRyeRILCgNrZXkYASABKAkSKQoFdmFsdWUYAiABKAsyGi5zdGF0ZS52MS5DcmVhdGVDb25zdHJhaW50OgI4ASI8ChBDcmVhdGVDb25zdHJhaW50EhYKDmFsbG93ZWRfdmFsdWVzGAEgAygJEhAKCHJlcXVpcmVkGAIgASgIItIDCghSb2xlSW5mbxIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEhgKC2Rlc2NyaXB0aW9uGAMgASgJSACIAQESDwoHYWN0aW9ucxgEIAMoCRIdChBsYWJlbF9zY29wZV9leHByGAUgASgJSAGIAQESPAoSY3JlYXRlX2NvbnN0cmFpbnRzGAYgASgLMhsuc3RhdGUudjEuQ3JlYXRlQ29uc3RyYWludHNIAogBARIWCg5pbW11dGFibGVfa2V5cxgHIAMoCRIuCgpjcmVhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIPCgd2ZXJzaW9uGAogASgFEhwKD21heF9hc3NpZ25tZW50cxgLIAEoBUgDiAEBEhUKDW93bmVyX2FjdGlvbnMYDCADKAkSFgoOZGVmYXVsdF9sYWJlbHMYDSABKAhCDgoMX2Rlc2NyaXB0aW9uQhMKEV9sYWJlbF9zY29wZV9leHByQhUKE19jcmVhdGVfY29uc3RyYWludHNCEgoQX21heF9hc3NpZ25tZW50cyI2ChJDcmVhdGVSb2xlUmVzcG9uc2USIAoEcm9sZRgBIAEoCzISLnN0YXRlLnYxLlJvbGVJbmZvIhIKEExpc3RSb2xlc1JlcXVlc3QiNgoRTGlzdFJvbGVzUmVzcG9uc2USIQoFcm9sZXMYASADKAsyEi5zdGF0ZS52MS5Sb2xlSW5mbyL4AgoRVXBkYXRlUm9sZVJlcXVlc3QSDAoEbmFtZRgBIAEoCRIYCgtkZXNjcmlwdGlvbhgCIAEoCUgAiAEBEg8KB2FjdGlvbnMYAyADKAkSHQoQbGFiZWxfc2NvcGVfZXhwchgEIAEoCUgBiAEBEjwKEmNyZWF0ZV9jb25zdHJhaW50cxgFIAEoCzIbLnN0YXRlLnYxLkNyZWF0ZUNvbnN0cmFpbnRzSAKIAQESFgoOaW1tdXRhYmxlX2tleXMYBiADKAkSGAoQZXhwZWN0ZWRfdmVyc2lvbhgHIAEoBRIcCg9tYXhfYXNzaWdubWVudHMYCCABKAVIA4gBARIVCg1vd25lcl9hY3Rpb25zGAkgAygJEhYKDmRlZmF1bHRfbGFiZWxzGAogASgIQg4KDF9kZXNjcmlwdGlvbkITChFfbGFiZWxfc2NvcGVfZXhwckIVChNfY3JlYXRlX2NvbnN0cmFpbnRzQhIKEF9tYXhfYXNzaWdubWVudHMiNgoSVXBkYXRlUm9sZVJlc3BvbnNlEiAKBHJvbGUYASABKAsyEi5zdGF0ZS52MS5Sb2xlSW5mbyIhChFEZWxldGVSb2xlUmVxdWVzdBIMCgRuYW1lGAEgASgJIiUKEkRlbGV0ZVJvbGVSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIlQKEUFzc2lnblJvbGVSZXF1ZXN0EhYKDnByaW5jaXBhbF90eXBlGAEgASgJEhQKDHByaW5jaXBhbF9pZBgCIAEoCRIRCglyb2xlX25hbWUYAyABKAkiVgoSQXNzaWduUm9sZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSLwoLYXNzaWduZWRfYXQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIlQKEVJlbW92ZVJvbGVSZXF1ZXN0EhYKDnByaW5jaXBhbF90eXBlGAEgASgJEhQKDHByaW5jaXBhbF9pZBgCIAEoCRIRCglyb2xlX25hbWUYAyABKAkiJQoSUmVtb3ZlUm9sZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiRAoUTGlzdFVzZXJSb2xlc1JlcXVlc3QSFgoOcHJpbmNpcGFsX3R5cGUYASABKAkSFAoMcHJpbmNpcGFsX2lkGAIgASgJInUKElJvbGVBc3NpZ25tZW50SW5mbxIRCglyb2xlX25hbWUYASABKAkSLwoLYXNzaWduZWRfYXQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhsKE2Fzc2lnbmVkX2J5X3VzZXJfaWQYAyABKAkiRAoVTGlzdFVzZXJSb2xlc1Jlc3BvbnNlEisKBXJvbGVzGAEgAygLMhwuc3RhdGUudjEuUm9sZUFzc2lnbm1lbnRJbmZvIlIKFkFzc2lnbkdyb3VwUm9sZVJlcXVlc3QSEgoKZ3JvdXBfbmFtZRgBIAEoCRIRCglyb2xlX25hbWUYAiABKAkSEQoJY29uZGl0aW9uGAMgASgJIlsKF0Fzc2lnbkdyb3VwUm9sZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSLwoLYXNzaWduZWRfYXQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIj8KFlJlbW92ZUdyb3VwUm9sZVJlcXVlc3QSEgoKZ3JvdXBfbmFtZRgBIAEoCRIRCglyb2xlX25hbWUYAiABKAkiKgoXUmVtb3ZlR3JvdXBSb2xlUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCI/ChVMaXN0R3JvdXBSb2xlc1JlcXVlc3QSFwoKZ3JvdXBfbmFtZRgBIAEoCUgAiAEBQg0KC19ncm91cF9uYW1lIqEBChdHcm91cFJvbGVBc3NpZ25tZW50SW5mbxISCgpncm91cF9uYW1lGAEgASgJEhEKCXJvbGVfbmFtZRgCIAEoCRIvCgthc3NpZ25lZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASGwoTYXNzaWduZWRfYnlfdXNlcl9pZBgEIAEoCRIRCgljb25kaXRpb24YBSABKAkiUAoWTGlzdEdyb3VwUm9sZXNSZXNwb25zZRI2Cgthc3NpZ25tZW50cxgBIAMoCzIhLnN0YXRlLnYxLkdyb3VwUm9sZUFzc2lnbm1lbnRJbmZvIk4KHkdldEVmZmVjdGl2ZVBlcm1pc3Npb25zUmVxdWVzdBIWCg5wcmluY2lwYWxfdHlwZRgBIAEoCRIUCgxwcmluY2lwYWxfaWQYAiABKAki3AEKFEVmZmVjdGl2ZVBlcm1pc3Npb25zEg0KBXJvbGVzGAEgAygJEg8KB2FjdGlvbnMYAiADKAkSGQoRbGFiZWxfc2NvcGVfZXhwcnMYAyADKAkSRgocZWZmZWN0aXZlX2NyZWF0ZV9jb25zdHJhaW50cxgEIAEoCzIbLnN0YXRlLnYxLkNyZWF0ZUNvbnN0cmFpbnRzSACIAQESIAoYZWZmZWN0aXZlX2ltbXV0YWJsZV9rZXlzGAUgAygJQh8KHV9lZmZlY3RpdmVfY3JlYXRlX2NvbnN0cmFpbnRzInMKH0dldEVmZmVjdGl2ZVBlcm1pc3Npb25zUmVzcG9uc2USMwoLcGVybWlzc2lvbnMYASABKAsyHi5zdGF0ZS52MS5FZmZlY3RpdmVQZXJtaXNzaW9ucxIbChNhdXRoel9tb2RlbF92ZXJzaW9uGAIgASgEIiYKE0xpc3RTZXNzaW9uc1JlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCSLmAgoLU2Vzc2lvbkluZm8SCgoCaWQYASABKAkSLgoKY3JlYXRlZF9hdBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMbGFzdF91c2VkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgpleHBpcmVzX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIXCgp1c2VyX2FnZW50GAUgASgJSACIAQESFwoKaXBfYWRkcmVzcxgGIAEoCUgBiAEBEhQKB3VzZXJfaWQYByABKAlIAogBARIPCgdyZXZva2VkGAggASgIEh8KEnNlcnZpY2VfYWNjb3VudF9pZBgJIAEoCUgDiAEBQg0KC191c2VyX2FnZW50Qg0KC19pcF9hZGRyZXNzQgoKCF91c2VyX2lkQhUKE19zZXJ2aWNlX2FjY291bnRfaWQiPwoUTGlzdFNlc3Npb25zUmVzcG9uc2USJwoIc2Vzc2lvbnMYASADKAsyFS5zdGF0ZS52MS5TZXNzaW9uSW5mbyLGAQoWTGlzdEFsbFNlc3Npb25zUmVxdWVzdBIPCgd1c2VyX2lkGAEgASgJEhMKC2FjdGl2ZV9vbmx5GAIgASgIEjEKDWNyZWF0ZWRfYWZ0ZXIYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhEKCXBhZ2Vfc2l6ZRgEIAEoBRIOCgZvZmZzZXQYBSABKAUSGgoSc2VydmljZV9hY2NvdW50X2lkGAYgASgJEhQKDHJldm9rZWRfb25seRgHIAEoCCJXChdMaXN0QWxsU2Vzc2lvbnNSZXNwb25zZRInCghzZXNzaW9ucxgBIAMoCzIVLnN0YXRlLnYxLlNlc3Npb25JbmZvEhMKC25leHRfb2Zmc2V0GAIgASgFIqsBChVSZXZva2VTZXNzaW9uc1JlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCRIaChJzZXJ2aWNlX2FjY291bnRfaWQYAiABKAkSMgoOY3JlYXRlZF9iZWZvcmUYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjEKDWNyZWF0ZWRfYWZ0ZXIYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIi8KFlJldm9rZVNlc3Npb25zUmVzcG9uc2USFQoNcmV2b2tlZF9jb3VudBgBIAEoBSIqChRSZXZva2VTZXNzaW9uUmVxdWVzdBISCgpzZXNzaW9uX2lkGAEgASgJIigKFVJldm9rZVNlc3Npb25SZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIicKFkludHJvc3BlY3RUb2tlblJlcXVlc3QSDQoFdG9rZW4YASABKAkiqQMKF0ludHJvc3BlY3RUb2tlblJlc3BvbnNlEg4KBmFjdGl2ZRgBIAEoCBIcCg9pbmFjdGl2ZV9yZWFzb24YAiABKAlIAIgBARIUCgdzdWJqZWN0GAMgASgJSAGIAQESFgoJY2xpZW50X2lkGAQgASgJSAKIAQESDgoGc2NvcGVzGAUgAygJEjMKCmV4cGlyZXNfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAOIAQESMgoJaXNzdWVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgEiAEBEhAKA2p0aRgIIAEoCUgFiAEBEhMKC2p0aV9yZXZva2VkGAkgASgIEhcKCnNlc3Npb25faWQYCiABKAlIBogBARIXCg9zZXNzaW9uX3Jldm9rZWQYCyABKAhCEgoQX2luYWN0aXZlX3JlYXNvbkIKCghfc3ViamVjdEIMCgpfY2xpZW50X2lkQg0KC19leHBpcmVzX2F0QgwKCl9pc3N1ZWRfYXRCBgoEX2p0aUINCgtfc2Vzc2lvbl9pZCLkAQobUHJldmlld0F1dGhvcml6YXRpb25SZXF1ZXN0Eg0KBXJvbGVzGAEgAygJEg4KBmdyb3VwcxgCIAMoCRIOCgZvYmplY3QYAyABKAkSDgoGYWN0aW9uGAQgASgJEkEKBmxhYmVscxgFIAMoCzIxLnN0YXRlLnYxLlByZXZpZXdBdXRob3JpemF0aW9uUmVxdWVzdC5MYWJlbHNFbnRyeRpDCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSIwoFdmFsdWUYAiABKAsyFC5zdGF0ZS52MS5MYWJlbFZhbHVlOgI4ASKeAQoZUm9sZUF1dGhvcml6YXRpb25EZWNpc2lvbhIMCgRyb2xlGAEgASgJEg8KB2FsbG93ZWQYAiABKAgSGgoNcG9saWN5X2FjdGlvbhgDIAEoCUgAiAEBEh4KEXBvbGljeV9zY29wZV9leHByGAQgASgJSAGIAQFCEAoOX3BvbGljeV9hY3Rpb25CFAoSX3BvbGljeV9zY29wZV9leHByIogBChxQcmV2aWV3QXV0aG9yaXphdGlvblJlc3BvbnNlEg8KB2FsbG93ZWQYASABKAgSEwoLY29tYmluYXRpb24YAiABKAkSMgoFcm9sZXMYAyADKAsyIy5zdGF0ZS52MS5Sb2xlQXV0aG9yaXphdGlvbkRlY2lzaW9uEg4KBnJlYXNvbhgEIAEoCSKkAQoWU2V0T3V0cHV0U2NoZW1hUmVxdWVzdBIYCg5zdGF0ZV9sb2dpY19pZBgBIAEoCUgAEhQKCnN0YXRlX2d1aWQYAiABKAlIABISCgpvdXRwdXRfa2V5GAMgASgJEhMKC3NjaGVtYV9qc29uGAQgASgJEhQKDHZhbGlkYXRlX25vdxgFIAEoCBISCgpzY2hlbWFfcmVmGAYgASgJQgcKBXN0YXRlItQBChdTZXRPdXRwdXRTY2hlbWFSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEhIKCnN0YXRlX2d1aWQYAiABKAkSFgoOc3RhdGVfbG9naWNfaWQYAyABKAkSEgoKb3V0cHV0X2tleRgEIAEoCRIeChF2YWxpZGF0aW9uX3N0YXR1cxgFIAEoCUgAiAEBEh0KEHZhbGlkYXRpb25fZXJyb3IYBiABKAlIAYgBAUIUChJfdmFsaWRhdGlvbl9zdGF0dXNCEwoRX3ZhbGlkYXRpb25fZXJyb3IiwwEKF1NldE91dHB1dFNjaGVtYXNSZXF1ZXN0EhgKDnN0YXRlX2xvZ2ljX2lkGAEgASgJSAASFAoKc3RhdGVfZ3VpZBgCIAEoCUgAEj8KB3NjaGVtYXMYAyADKAsyLi5zdGF0ZS52MS5TZXRPdXRwdXRTY2hlbWFzUmVxdWVzdC5TY2hlbWFzRW50cnkaLgoMU2NoZW1hc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAFCBwoFc3RhdGUiOQoST3V0cHV0U2NoZW1hUmVzdWx0EhIKCm91dHB1dF9rZXkYASABKAkSDwoHY3JlYXRlZBgCIAEoCCJ1ChhTZXRPdXRwdXRTY2hlbWFzUmVzcG9uc2USEgoKc3RhdGVfZ3VpZBgBIAEoCRIWCg5zdGF0ZV9sb2dpY19pZBgCIAEoCRItCgdyZXN1bHRzGAMgAygLMhwuc3RhdGUudjEuT3V0cHV0U2NoZW1hUmVzdWx0InAKIVJldmFsaWRhdGVPdXRwdXRzRm9yU2NoZW1hUmVxdWVzdBIYCg5zdGF0ZV9sb2dpY19pZBgBIAEoCUgAEhQKCnN0YXRlX2d1aWQYAiABKAlIABISCgpvdXRwdXRfa2V5GAMgASgJQgcKBXN0YXRlIoUBChpPdXRwdXRWYWxpZGF0aW9uVHJhbnNpdGlvbhISCgpzdGF0ZV9ndWlkGAEgASgJEhYKDnN0YXRlX2xvZ2ljX2lkGAIgASgJEhIKCm91dHB1dF9rZXkYAyABKAkSFwoPcHJldmlvdXNfc3RhdHVzGAQgASgJEg4KBnN0YXR1cxgFIAEoCSKiAQoiUmV2YWxpZGF0ZU91dHB1dHNGb3JTY2hlbWFSZXNwb25zZRITCgtyZXZhbGlkYXRlZBgBIAEoBRIWCg5iZWNhbWVfaW52YWxpZBgCIAEoBRIUCgxiZWNhbWVfdmFsaWQYAyABKAUSOQoLdHJhbnNpdGlvbnMYBCADKAsyJC5zdGF0ZS52MS5PdXRwdXRWYWxpZGF0aW9uVHJhbnNpdGlvbiJlChZHZXRPdXRwdXRTY2hlbWFSZXF1ZXN0EhgKDnN0YXRlX2xvZ2ljX2lkGAEgASgJSAASFAoKc3RhdGVfZ3VpZBgCIAEoCUgAEhIKCm91dHB1dF9rZXkYAyABKAlCBwoFc3RhdGUimgEKF0dldE91dHB1dFNjaGVtYVJlc3BvbnNlEhIKCnN0YXRlX2d1aWQYASABKAkSFgoOc3RhdGVfbG9naWNfaWQYAiABKAkSEgoKb3V0cHV0X2tleRgDIAEoCRITCgtzY2hlbWFfanNvbhgEIAEoCRISCgpzY2hlbWFfcmVmGAUgASgJEhYKDnNjaGVtYV92ZXJzaW9uGAYgASgFIm0KHkxpc3RPdXRwdXRTY2hlbWFIaXN0b3J5UmVxdWVzdBIYCg5zdGF0ZV9sb2dpY19pZBgBIAEoCUgAEhQKCnN0YXRlX2d1aWQYAiABKAlIABISCgpvdXRwdXRfa2V5GAMgASgJQgcKBXN0YXRlIqYBChNPdXRwdXRTY2hlbWFWZXJzaW9uEg8KB3ZlcnNpb24YASABKAUSEwoLc2NoZW1hX2pzb24YAiABKAkSFQoNc2NoZW1hX3NvdXJjZRgDIAEoCRISCgpzY2hlbWFfcmVmGAQgASgJEi4KCmNyZWF0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEg4KBmFjdGl2ZRgGIAEoCCKMAgofTGlzdE91dHB1dFNjaGVtYUhpc3RvcnlSZXNwb25zZRISCgpzdGF0ZV9ndWlkGAEgASgJEhYKDnN0YXRlX2xvZ2ljX2lkGAIgASgJEhIKCm91dHB1dF9rZXkYAyABKAkSLwoIdmVyc2lvbnMYBCADKAsyHS5zdGF0ZS52MS5PdXRwdXRTY2hlbWFWZXJzaW9uEiUKGHZhbGlkYXRlZF9zY2hlbWFfdmVyc2lvbhgFIAEoBUgAiAEBEh4KEXZhbGlkYXRpb25fc3RhdHVzGAYgASgJSAGIAQFCGwoZX3ZhbGlkYXRlZF9zY2hlbWFfdmVyc2lvbkIUChJfdmFsaWRhdGlvbl9zdGF0dXMiqQEKEFJlZ2lzdGVyZWRTY2hlbWESCwoDa2V5GAEgASgJEhMKC3NjaGVtYV9qc29uGAIgASgJEhMKC2Rlc2NyaXB0aW9uGAMgASgJEi4KCmNyZWF0ZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIlYKHUNyZWF0ZVJlZ2lzdGVyZWRTY2hlbWFSZXF1ZXN0EgsKA2tleRgBIAEoCRITCgtzY2hlbWFfanNvbhgCIAEoCRITCgtkZXNjcmlwdGlvbhgDIAEoCSJMCh5DcmVhdGVSZWdpc3RlcmVkU2NoZW1hUmVzcG9uc2USKgoGc2NoZW1hGAEgASgLMhouc3RhdGUudjEuUmVnaXN0ZXJlZFNjaGVtYSIpChpHZXRSZWdpc3RlcmVkU2NoZW1hUmVxdWVzdBILCgNrZXkYASABKAkiSQobR2V0UmVnaXN0ZXJlZFNjaGVtYVJlc3BvbnNlEioKBnNjaGVtYRgBIAEoCzIaLnN0YXRlLnYxLlJlZ2lzdGVyZWRTY2hlbWEiHgocTGlzdFJlZ2lzdGVyZWRTY2hlbWFzUmVxdWVzdCJMCh1MaXN0UmVnaXN0ZXJlZFNjaGVtYXNSZXNwb25zZRIrCgdzY2hlbWFzGAEgAygLMhouc3RhdGUudjEuUmVnaXN0ZXJlZFNjaGVtYSJ/Ch1VcGRhdGVSZWdpc3RlcmVkU2NoZW1hUmVxdWVzdBILCgNrZXkYASABKAkSEwoLc2NoZW1hX2pzb24YAiABKAkSGAoLZGVzY3JpcHRpb24YAyABKAlIAIgBARISCgpyZXZhbGlkYXRlGAQgASgIQg4KDF9kZXNjcmlwdGlvbiKQAQoeVXBkYXRlUmVnaXN0ZXJlZFNjaGVtYVJlc3BvbnNlEioKBnNjaGVtYRgBIAEoCzIaLnN0YXRlLnYxLlJlZ2lzdGVyZWRTY2hlbWESQgoMcmV2YWxpZGF0aW9uGAIgASgLMiwuc3RhdGUudjEuUmV2YWxpZGF0ZU91dHB1dHNGb3JTY2hlbWFSZXNwb25zZSIsCh1EZWxldGVSZWdpc3RlcmVkU2NoZW1hUmVxdWVzdBILCgNrZXkYASABKAkiIAoeRGVsZXRlUmVnaXN0ZXJlZFNjaGVtYVJlc3BvbnNlIlsKIEdldFN0YXRlVmFsaWRhdGlvblN1bW1hcnlSZXF1ZXN0EhgKDnN0YXRlX2xvZ2ljX2lkGAEgASgJSAASFAoKc3RhdGVfZ3VpZBgCIAEoCUgAQgcKBXN0YXRlIqgBChVPdXRwdXRWYWxpZGF0aW9uSXNzdWUSEgoKb3V0cHV0X2tleRgBIAEoCRIZChF2YWxpZGF0aW9uX3N0YXR1cxgCIAEoCRIYChB2YWxpZGF0aW9uX2Vycm9yGAMgASgJEjUKDHZhbGlkYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAIgBAUIPCg1fdmFsaWRhdGVkX2F0IscCCiFHZXRTdGF0ZVZhbGlkYXRpb25TdW1tYXJ5UmVzcG9uc2USEgoKc3RhdGVfZ3VpZBgBIAEoCRIWCg5zdGF0ZV9sb2dpY19pZBgCIAEoCRIVCg10b3RhbF9vdXRwdXRzGAMgASgFEhMKC3ZhbGlkX2NvdW50GAQgASgFEhUKDWludmFsaWRfY291bnQYBSABKAUSEwoLZXJyb3JfY291bnQYBiABKAUSGwoTbm90X3ZhbGlkYXRlZF9jb3VudBgHIAEoBRIvCgZpc3N1ZXMYCCADKAsyHy5zdGF0ZS52MS5PdXRwdXRWYWxpZGF0aW9uSXNzdWUSOgoRbGFzdF92YWxpZGF0ZWRfYXQYCSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSACIAQFCFAoSX2xhc3RfdmFsaWRhdGVkX2F0Ik0KGFdhdGNoU3RhdGVDaGFuZ2VzUmVxdWVzdBIVCghsb2dpY19pZBgBIAEoCUgAiAEBEg0KBWtpbmRzGAIgAygJQgsKCV9sb2dpY19pZCKwAgoQU3RhdGVDaGFuZ2VFdmVudBIMCgRndWlkGAEgASgJEhAKCGxvZ2ljX2lkGAIgASgJEgwKBGtpbmQYAyABKAkSDgoGc2VyaWFsGAQgASgDEjYKBmxhYmVscxgFIAMoCzImLnN0YXRlLnYxLlN0YXRlQ2hhbmdlRXZlbnQuTGFiZWxzRW50cnkSFwoPY29tcHV0ZWRfc3RhdHVzGAYgASgJEhcKD2NoYW5nZWRfb3V0cHV0cxgHIAMoCRIvCgtkZXRlY3RlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAaQwoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEiMKBXZhbHVlGAIgASgLMhQuc3RhdGUudjEuTGFiZWxWYWx1ZToCOAEyiigKDFN0YXRlU2VydmljZRJKCgtDcmVhdGVTdGF0ZRIcLnN0YXRlLnYxLkNyZWF0ZVN0YXRlUmVxdWVzdBodLnN0YXRlLnYxLkNyZWF0ZVN0YXRlUmVzcG9uc2USRwoKTGlzdFN0YXRlcxIbLnN0YXRlLnYxLkxpc3RTdGF0ZXNSZXF1ZXN0Ghwuc3RhdGUudjEuTGlzdFN0YXRlc1Jlc3BvbnNlElMKDkdldFN0YXRlQ29uZmlnEh8uc3RhdGUudjEuR2V0U3RhdGVDb25maWdSZXF1ZXN0GiAuc3RhdGUudjEuR2V0U3RhdGVDb25maWdSZXNwb25zZRJNCgxHZXRTdGF0ZUxvY2sSHS5zdGF0ZS52MS5HZXRTdGF0ZUxvY2tSZXF1ZXN0Gh4uc3RhdGUudjEuR2V0U3RhdGVMb2NrUmVzcG9uc2USSgoLVW5sb2NrU3RhdGUSHC5zdGF0ZS52MS5VbmxvY2tTdGF0ZVJlcXVlc3QaHS5zdGF0ZS52MS5VbmxvY2tTdGF0ZVJlc3BvbnNlElAKDUFkZERlcGVuZGVuY3kSHi5zdGF0ZS52MS5BZGREZXBlbmRlbmN5UmVxdWVzdBofLnN0YXRlLnYxLkFkZERlcGVuZGVuY3lSZXNwb25zZRJZChBSZW1vdmVEZXBlbmRlbmN5EiEuc3RhdGUudjEuUmVtb3ZlRGVwZW5kZW5jeVJlcXVlc3QaIi5zdGF0ZS52MS5SZW1vdmVEZXBlbmRlbmN5UmVzcG9uc2USWQoQTGlzdERlcGVuZGVuY2llcxIhLnN0YXRlLnYxLkxpc3REZXBlbmRlbmNpZXNSZXF1ZXN0GiIuc3RhdGUudjEuTGlzdERlcGVuZGVuY2llc1Jlc3BvbnNlElMKDkxpc3REZXBlbmRlbnRzEh8uc3RhdGUudjEuTGlzdERlcGVuZGVudHNSZXF1ZXN0GiAuc3RhdGUudjEuTGlzdERlcGVuZGVudHNSZXNwb25zZRJTCg5TZWFyY2hCeU91dHB1dBIfLnN0YXRlLnYxLlNlYXJjaEJ5T3V0cHV0UmVxdWVzdBogLnN0YXRlLnYxLlNlYXJjaEJ5T3V0cHV0UmVzcG9uc2USYgoTR2V0VG9wb2xvZ2ljYWxPcmRlchIkLnN0YXRlLnYxLkdldFRvcG9sb2dpY2FsT3JkZXJSZXF1ZXN0GiUuc3RhdGUudjEuR2V0VG9wb2xvZ2ljYWxPcmRlclJlc3BvbnNlElMKDkdldFN0YXRlU3RhdHVzEh8uc3RhdGUudjEuR2V0U3RhdGVTdGF0dXNSZXF1ZXN0GiAuc3RhdGUudjEuR2V0U3RhdGVTdGF0dXNSZXNwb25zZRJfChJHZXREZXBlbmRlbmN5R3JhcGgSIy5zdGF0ZS52MS5HZXREZXBlbmRlbmN5R3JhcGhSZXF1ZXN0GiQuc3RhdGUudjEuR2V0RGVwZW5kZW5jeUdyYXBoUmVzcG9uc2USdAoZUmVjb21wdXRlRGVwZW5kZW5jeVN0YXR1cxIqLnN0YXRlLnYxLlJlY29tcHV0ZURlcGVuZGVuY3lTdGF0dXNSZXF1ZXN0Gisuc3RhdGUudjEuUmVjb21wdXRlRGVwZW5kZW5jeVN0YXR1c1Jlc3BvbnNlElkKEExpc3RTdGF0ZU91dHB1dHMSIS5zdGF0ZS52MS5MaXN0U3RhdGVPdXRwdXRzUmVxdWVzdBoiLnN0YXRlLnYxLkxpc3RTdGF0ZU91dHB1dHNSZXNwb25zZRJoChVMaXN0U3RhdGVPdXRwdXRzQmF0Y2gSJi5zdGF0ZS52MS5MaXN0U3RhdGVPdXRwdXRzQmF0Y2hSZXF1ZXN0Gicuc3RhdGUudjEuTGlzdFN0YXRlT3V0cHV0c0JhdGNoUmVzcG9uc2USZQoUR2V0U3RhdGVPdXRwdXRWYWx1ZXMSJS5zdGF0ZS52MS5HZXRTdGF0ZU91dHB1dFZhbHVlc1JlcXVlc3QaJi5zdGF0ZS52MS5HZXRTdGF0ZU91dHB1dFZhbHVlc1Jlc3BvbnNlEk0KDEdldFN0YXRlSW5mbxIdLnN0YXRlLnYxLkdldFN0YXRlSW5mb1JlcXVlc3QaHi5zdGF0ZS52MS5HZXRTdGF0ZUluZm9SZXNwb25zZRJNCgxMaXN0QWxsRWRnZXMSHS5zdGF0ZS52MS5MaXN0QWxsRWRnZXNSZXF1ZXN0Gh4uc3RhdGUudjEuTGlzdEFsbEVkZ2VzUmVzcG9uc2USVQoRV2F0Y2hTdGF0ZUNoYW5nZXMSIi5zdGF0ZS52MS5XYXRjaFN0YXRlQ2hhbmdlc1JlcXVlc3QaGi5zdGF0ZS52MS5TdGF0ZUNoYW5nZUV2ZW50MAESXAoRVXBkYXRlU3RhdGVMYWJlbHMSIi5zdGF0ZS52MS5VcGRhdGVTdGF0ZUxhYmVsc1JlcXVlc3QaIy5zdGF0ZS52MS5VcGRhdGVTdGF0ZUxhYmVsc1Jlc3BvbnNlEmsKFlRyYW5zZmVyU3RhdGVPd25lcnNoaXASJy5zdGF0ZS52MS5UcmFuc2ZlclN0YXRlT3duZXJzaGlwUmVxdWVzdBooLnN0YXRlLnYxLlRyYW5zZmVyU3RhdGVPd25lcnNoaXBSZXNwb25zZRJTCg5HZXRMYWJlbFBvbGljeRIfLnN0YXRlLnYxLkdldExhYmVsUG9saWN5UmVxdWVzdBogLnN0YXRlLnYxLkdldExhYmVsUG9saWN5UmVzcG9uc2USUwoOU2V0TGFiZWxQb2xpY3kSHy5zdGF0ZS52MS5TZXRMYWJlbFBvbGljeVJlcXVlc3QaIC5zdGF0ZS52MS5TZXRMYWJlbFBvbGljeVJlc3BvbnNlEmUKFENyZWF0ZVNlcnZpY2VBY2NvdW50EiUuc3RhdGUudjEuQ3JlYXRlU2VydmljZUFjY291bnRSZXF1ZXN0GiYuc3RhdGUudjEuQ3JlYXRlU2VydmljZUFjY291bnRSZXNwb25zZRJiChNMaXN0U2VydmljZUFjY291bnRzEiQuc3RhdGUudjEuTGlzdFNlcnZpY2VBY2NvdW50c1JlcXVlc3QaJS5zdGF0ZS52MS5MaXN0U2VydmljZUFjY291bnRzUmVzcG9uc2USZQoUUmV2b2tlU2VydmljZUFjY291bnQSJS5zdGF0ZS52MS5SZXZva2VTZXJ2aWNlQWNjb3VudFJlcXVlc3QaJi5zdGF0ZS52MS5SZXZva2VTZXJ2aWNlQWNjb3VudFJlc3BvbnNlEmUKFFJvdGF0ZVNlcnZpY2VBY2NvdW50EiUuc3RhdGUudjEuUm90YXRlU2VydmljZUFjY291bnRSZXF1ZXN0GiYuc3RhdGUudjEuUm90YXRlU2VydmljZUFjY291bnRSZXNwb25zZRJHCgpDcmVhdGVSb2xlEhsuc3RhdGUudjEuQ3JlYXRlUm9sZVJlcXVlc3QaHC5zdGF0ZS52MS5DcmVhdGVSb2xlUmVzcG9uc2USRAoJTGlzdFJvbGVzEhouc3RhdGUudjEuTGlzdFJvbGVzUmVxdWVzdBobLnN0YXRlLnYxLkxpc3RSb2xlc1Jlc3BvbnNlEkcKClVwZGF0ZVJvbGUSGy5zdGF0ZS52MS5VcGRhdGVSb2xlUmVxdWVzdBocLnN0YXRlLnYxLlVwZGF0ZVJvbGVSZXNwb25zZRJHCgpEZWxldGVSb2xlEhsuc3RhdGUudjEuRGVsZXRlUm9sZVJlcXVlc3QaHC5zdGF0ZS52MS5EZWxldGVSb2xlUmVzcG9uc2USRwoKQXNzaWduUm9sZRIbLnN0YXRlLnYxLkFzc2lnblJvbGVSZXF1ZXN0Ghwuc3RhdGUudjEuQXNzaWduUm9sZVJlc3BvbnNlEkcKClJlbW92ZVJvbGUSGy5zdGF0ZS52MS5SZW1vdmVSb2xlUmVxdWVzdBocLnN0YXRlLnYxLlJlbW92ZVJvbGVSZXNwb25zZRJQCg1MaXN0VXNlclJvbGVzEh4uc3RhdGUudjEuTGlzdFVzZXJSb2xlc1JlcXVlc3QaHy5zdGF0ZS52MS5MaXN0VXNlclJvbGVzUmVzcG9uc2USVgoPQXNzaWduR3JvdXBSb2xlEiAuc3RhdGUudjEuQXNzaWduR3JvdXBSb2xlUmVxdWVzdBohLnN0YXRlLnYxLkFzc2lnbkdyb3VwUm9sZVJlc3BvbnNlElYKD1JlbW92ZUdyb3VwUm9sZRIgLnN0YXRlLnYxLlJlbW92ZUdyb3VwUm9sZVJlcXVlc3QaIS5zdGF0ZS52MS5SZW1vdmVHcm91cFJvbGVSZXNwb25zZRJTCg5MaXN0R3JvdXBSb2xlcxIfLnN0YXRlLnYxLkxpc3RHcm91cFJvbGVzUmVxdWVzdBogLnN0YXRlLnYxLkxpc3RHcm91cFJvbGVzUmVzcG9uc2USbgoXR2V0RWZmZWN0aXZlUGVybWlzc2lvbnMSKC5zdGF0ZS52MS5HZXRFZmZlY3RpdmVQZXJtaXNzaW9uc1JlcXVlc3QaKS5zdGF0ZS52MS5HZXRFZmZlY3RpdmVQZXJtaXNzaW9uc1Jlc3BvbnNlEk0KDExpc3RTZXNzaW9ucxIdLnN0YXRlLnYxLkxpc3RTZXNzaW9uc1JlcXVlc3QaHi5zdGF0ZS52MS5MaXN0U2Vzc2lvbnNSZXNwb25zZRJQCg1SZXZva2VTZXNzaW9uEh4uc3RhdGUudjEuUmV2b2tlU2Vzc2lvblJlcXVlc3QaHy5zdGF0ZS52MS5SZXZva2VTZXNzaW9uUmVzcG9uc2USVgoPTGlzdEFsbFNlc3Npb25zEiAuc3RhdGUudjEuTGlzdEFsbFNlc3Npb25zUmVxdWVzdBohLnN0YXRlLnYxLkxpc3RBbGxTZXNzaW9uc1Jlc3BvbnNlElMKDlJldm9rZVNlc3Npb25zEh8uc3RhdGUudjEuUmV2b2tlU2Vzc2lvbnNSZXF1ZXN0GiAuc3RhdGUudjEuUmV2b2tlU2Vzc2lvbnNSZXNwb25zZRJWCg9JbnRyb3NwZWN0VG9rZW4SIC5zdGF0ZS52MS5JbnRyb3NwZWN0VG9rZW5SZXF1ZXN0GiEuc3RhdGUudjEuSW50cm9zcGVjdFRva2VuUmVzcG9uc2USZQoUUHJldmlld0F1dGhvcml6YXRpb24SJS5zdGF0ZS52MS5QcmV2aWV3QXV0aG9yaXphdGlvblJlcXVlc3QaJi5zdGF0ZS52MS5QcmV2aWV3QXV0aG9yaXphdGlvblJlc3BvbnNlElYKD1NldE91dHB1dFNjaGVtYRIgLnN0YXRlLnYxLlNldE91dHB1dFNjaGVtYVJlcXVlc3QaIS5zdGF0ZS52MS5TZXRPdXRwdXRTY2hlbWFSZXNwb25zZRJZChBTZXRPdXRwdXRTY2hlbWFzEiEuc3RhdGUudjEuU2V0T3V0cHV0U2NoZW1hc1JlcXVlc3QaIi5zdGF0ZS52MS5TZXRPdXRwdXRTY2hlbWFzUmVzcG9uc2USdwoaUmV2YWxpZGF0ZU91dHB1dHNGb3JTY2hlbWESKy5zdGF0ZS52MS5SZXZhbGlkYXRlT3V0cHV0c0ZvclNjaGVtYVJlcXVlc3QaLC5zdGF0ZS52MS5SZXZhbGlkYXRlT3V0cHV0c0ZvclNjaGVtYVJlc3BvbnNlEmsKFkNyZWF0ZVJlZ2lzdGVyZWRTY2hlbWESJy5zdGF0ZS52MS5DcmVhdGVSZWdpc3RlcmVkU2NoZW1hUmVxdWVzdBooLnN0YXRlLnYxLkNyZWF0ZVJlZ2lzdGVyZWRTY2hlbWFSZXNwb25zZRJiChNHZXRSZWdpc3RlcmVkU2NoZW1hEiQuc3RhdGUudjEuR2V0UmVnaXN0ZXJlZFNjaGVtYVJlcXVlc3QaJS5zdGF0ZS52MS5HZXRSZWdpc3RlcmVkU2NoZW1hUmVzcG9uc2USaAoVTGlzdFJlZ2lzdGVyZWRTY2hlbWFzEiYuc3RhdGUudjEuTGlzdFJlZ2lzdGVyZWRTY2hlbWFzUmVxdWVzdBonLnN0YXRlLnYxLkxpc3RSZWdpc3RlcmVkU2NoZW1hc1Jlc3BvbnNlEmsKFlVwZGF0ZVJlZ2lzdGVyZWRTY2hlbWESJy5zdGF0ZS52MS5VcGRhdGVSZWdpc3RlcmVkU2NoZW1hUmVxdWVzdBooLnN0YXRlLnYxLlVwZGF0ZVJlZ2lzdGVyZWRTY2hlbWFSZXNwb25zZRJrChZEZWxldGVSZWdpc3RlcmVkU2NoZW1hEicuc3RhdGUudjEuRGVsZXRlUmVnaXN0ZXJlZFNjaGVtYVJlcXVlc3QaKC5zdGF0ZS52MS5EZWxldGVSZWdpc3RlcmVkU2NoZW1hUmVzcG9uc2USVgoPR2V0T3V0cHV0U2NoZW1hEiAuc3RhdGUudjEuR2V0T3V0cHV0U2NoZW1hUmVxdWVzdBohLnN0YXRlLnYxLkdldE91dHB1dFNjaGVtYVJlc3BvbnNlEm4KF0xpc3RPdXRwdXRTY2hlbWFIaXN0b3J5Eiguc3RhdGUudjEuTGlzdE91dHB1dFNjaGVtYUhpc3RvcnlSZXF1ZXN0Gikuc3RhdGUudjEuTGlzdE91dHB1dFNjaGVtYUhpc3RvcnlSZXNwb25zZRJ0ChlHZXRTdGF0ZVZhbGlkYXRpb25TdW1tYXJ5Eiouc3RhdGUudjEuR2V0U3RhdGVWYWxpZGF0aW9uU3VtbWFyeVJlcXVlc3QaKy5zdGF0ZS52MS5HZXRTdGF0ZVZhbGlkYXRpb25TdW1tYXJ5UmVzcG9uc2VCOlo4Z2l0aHViLmNvbS90ZXJyYWNvbnN0cnVjdHMvZ3JpZC9wa2cvYXBpL3N0YXRlL3YxO3N0YXRldjFiBnByb3RvMw", [file_google_protobuf_timestamp]);
 
 /**
  * CreateStateRequest creates a new state using a client-generated GUID.
@@ -34,6 +34,16 @@ export type CreateStateRequest = Message<"state.v1.CreateStateRequest"> & {
    * @generated from field: map<string, string> labels = 3;
    */
   labels: { [key: string]: string };
+
+  /**
+   * Optional key making retries safe. A repeated call with the same key,
+   * logic_id and labels returns the state created by the first call (its guid
+   * may differ from this request's). Reusing a key with a different logic_id
+   * or labels fails with INVALID_ARGUMENT. Keys expire after 24h by default.
+   *
+   * @generated from field: string idempotency_key = 4;
+   */
+  idempotencyKey: string;
 };
 
 /**
@@ -100,6 +110,30 @@ export type ListStatesRequest = Message<"state.v1.ListStatesRequest"> & {
    * @generated from field: optional bool include_status = 3;
    */
   includeStatus?: boolean;
+
+  /**
+   * Max states per page (max 1000). Unset or 0 returns every state in one
+   * response, as before pagination existed.
+   *
+   * @generated from field: int32 page_size = 4;
+   */
+  pageSize: number;
+
+  /**
+   * Opaque cursor from a previous response's next_page_token
+   *
+   * @generated from field: string page_token = 5;
+   */
+  pageToken: string;
+
+  /**
+   * Optional Kubernetes-style label selector evaluated in the database query,
+   * e.g. "env=dev,team in (platform,data),!deprecated". Selector queries are
+   * always paginated: without page_size, at most 1000 states are returned.
+   *
+   * @generated from field: string label_selector = 6;
+   */
+  labelSelector: string;
 };
 
 /**
@@ -119,6 +153,14 @@ export type ListStatesResponse = Message<"state.v1.ListStatesResponse"> & {
    * @generated from field: repeated state.v1.StateInfo states = 1;
    */
   states: StateInfo[];
+
+  /**
+   * Cursor for the next page; empty when there are no more states.
+   * Pages are ordered by creation time, so states created mid-iteration are not skipped.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
 };
 
 /**
@@ -211,6 +253,13 @@ export type StateInfo = Message<"state.v1.StateInfo"> & {
    * @generated from field: optional int32 outputs_count = 12;
    */
   outputsCount?: number;
+
+  /**
+   * Principal that created the state (e.g. "user:alice@example.com")
+   *
+   * @generated from field: optional string created_by = 13;
+   */
+  createdBy?: string;
 };
 
 /**
@@ -506,7 +555,9 @@ export type AddDependencyRequest = Message<"state.v1.AddDependencyRequest"> & {
 
   /**
    * Optional mock value for ahead-of-time dependency declaration
-   * (JSON-encoded value, used when producer output doesn't exist yet)
+   * (JSON-encoded value, used when producer output doesn't exist yet).
+   * Rejected with invalid_argument if the output has a schema the value
+   * does not conform to.
    *
    * @generated from field: optional string mock_value_json = 7;
    */
@@ -959,6 +1010,13 @@ export type IncomingEdgeView = Message<"state.v1.IncomingEdgeView"> & {
    * @generated from field: optional google.protobuf.Timestamp last_out_at = 9;
    */
   lastOutAt?: Timestamp;
+
+  /**
+   * True when the consumer has observed the producer's latest output (in_digest == out_digest)
+   *
+   * @generated from field: bool current = 10;
+   */
+  current: boolean;
 };
 
 /**
@@ -1026,6 +1084,14 @@ export type GetDependencyGraphRequest = Message<"state.v1.GetDependencyGraphRequ
     value: string;
     case: "guid";
   } | { case: undefined; value?: undefined };
+
+  /**
+   * How many producer hops to follow from the consumer. Unset or 0 returns
+   * direct producers only; values above 32 are capped at 32.
+   *
+   * @generated from field: optional int32 max_depth = 3;
+   */
+  maxDepth?: number;
 };
 
 /**
@@ -1037,6 +1103,7 @@ export const GetDependencyGraphRequestSchema: GenMessage<GetDependencyGraphReque
 
 /**
  * GetDependencyGraphResponse returns data needed for grid_dependencies.tf generation.
+ * Producers outside the caller's role scopes are omitted, with their edges.
  *
  * @generated from message state.v1.GetDependencyGraphResponse
  */
@@ -1052,6 +1119,8 @@ export type GetDependencyGraphResponse = Message<"state.v1.GetDependencyGraphRes
   consumerLogicId: string;
 
   /**
+   * Nearest first
+   *
    * @generated from field: repeated state.v1.ProducerState producers = 3;
    */
   producers: ProducerState[];
@@ -1060,6 +1129,28 @@ export type GetDependencyGraphResponse = Message<"state.v1.GetDependencyGraphRes
    * @generated from field: repeated state.v1.DependencyEdge edges = 4;
    */
   edges: DependencyEdge[];
+
+  /**
+   * Depth limit that was applied
+   *
+   * @generated from field: int32 max_depth = 5;
+   */
+  maxDepth: number;
+
+  /**
+   * Set when producers exist beyond max_depth and were not returned
+   *
+   * @generated from field: bool truncated = 6;
+   */
+  truncated: boolean;
+
+  /**
+   * IDs of edges closing a dependency cycle. Each state is visited once, so
+   * cycles never loop the traversal.
+   *
+   * @generated from field: repeated int64 cycle_edge_ids = 7;
+   */
+  cycleEdgeIds: bigint[];
 };
 
 /**
@@ -1069,6 +1160,85 @@ export type GetDependencyGraphResponse = Message<"state.v1.GetDependencyGraphRes
 export const GetDependencyGraphResponseSchema: GenMessage<GetDependencyGraphResponse> = /*@__PURE__*/
   messageDesc(file_state_v1_state, 33);
 
+/**
+ * RecomputeDependencyStatusRequest identifies the producer whose outgoing
+ * edges are recomputed.
+ *
+ * @generated from message state.v1.RecomputeDependencyStatusRequest
+ */
+export type RecomputeDependencyStatusRequest = Message<"state.v1.RecomputeDependencyStatusRequest"> & {
+  /**
+   * Producer state reference (logic_id or GUID)
+   *
+   * @generated from oneof state.v1.RecomputeDependencyStatusRequest.state
+   */
+  state: {
+    /**
+     * @generated from field: string logic_id = 1;
+     */
+    value: string;
+    case: "logicId";
+  } | {
+    /**
+     * @generated from field: string guid = 2;
+     */
+    value: string;
+    case: "guid";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message state.v1.RecomputeDependencyStatusRequest.
+ * Use `create(RecomputeDependencyStatusRequestSchema)` to create a new message.
+ */
+export const RecomputeDependencyStatusRequestSchema: GenMessage<RecomputeDependencyStatusRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 34);
+
+/**
+ * RecomputeDependencyStatusResponse reports the recomputed edges. Only edges
+ * whose producer and consumer are both within the caller's role scopes are
+ * recomputed; the others are left as they were and counted in skipped_edges.
+ *
+ * @generated from message state.v1.RecomputeDependencyStatusResponse
+ */
+export type RecomputeDependencyStatusResponse = Message<"state.v1.RecomputeDependencyStatusResponse"> & {
+  /**
+   * @generated from field: string producer_guid = 1;
+   */
+  producerGuid: string;
+
+  /**
+   * @generated from field: string producer_logic_id = 2;
+   */
+  producerLogicId: string;
+
+  /**
+   * After the recompute
+   *
+   * @generated from field: repeated state.v1.DependencyEdge edges = 3;
+   */
+  edges: DependencyEdge[];
+
+  /**
+   * Edges whose status or digest changed
+   *
+   * @generated from field: repeated int64 changed_edge_ids = 4;
+   */
+  changedEdgeIds: bigint[];
+
+  /**
+   * @generated from field: int32 skipped_edges = 5;
+   */
+  skippedEdges: number;
+};
+
+/**
+ * Describes the message state.v1.RecomputeDependencyStatusResponse.
+ * Use `create(RecomputeDependencyStatusResponseSchema)` to create a new message.
+ */
+export const RecomputeDependencyStatusResponseSchema: GenMessage<RecomputeDependencyStatusResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 35);
+
 /**
  * ProducerState represents a unique producer state in the graph.
  *
@@ -1089,6 +1259,13 @@ export type ProducerState = Message<"state.v1.ProducerState"> & {
    * @generated from field: state.v1.BackendConfig backend_config = 3;
    */
   backendConfig?: BackendConfig;
+
+  /**
+   * Hops from the consumer; 1 for direct producers
+   *
+   * @generated from field: int32 depth = 4;
+   */
+  depth: number;
 };
 
 /**
@@ -1096,7 +1273,7 @@ export type ProducerState = Message<"state.v1.ProducerState"> & {
  * Use `create(ProducerStateSchema)` to create a new message.
  */
 export const ProducerStateSchema: GenMessage<ProducerState> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 34);
+  messageDesc(file_state_v1_state, 36);
 
 /**
  * DependencyEdge represents a directed dependency edge.
@@ -1192,6 +1369,13 @@ export type DependencyEdge = Message<"state.v1.DependencyEdge"> & {
    * @generated from field: google.protobuf.Timestamp updated_at = 15;
    */
   updatedAt?: Timestamp;
+
+  /**
+   * True when the consumer has observed the producer's latest output (in_digest == out_digest)
+   *
+   * @generated from field: bool current = 16;
+   */
+  current: boolean;
 };
 
 /**
@@ -1199,7 +1383,7 @@ export type DependencyEdge = Message<"state.v1.DependencyEdge"> & {
  * Use `create(DependencyEdgeSchema)` to create a new message.
  */
 export const DependencyEdgeSchema: GenMessage<DependencyEdge> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 35);
+  messageDesc(file_state_v1_state, 37);
 
 /**
  * OutputKey represents a single Terraform/OpenTofu output name and metadata.
@@ -1264,6 +1448,39 @@ export type OutputKey = Message<"state.v1.OutputKey"> & {
    * @generated from field: optional google.protobuf.Timestamp validated_at = 7;
    */
   validatedAt?: Timestamp;
+
+  /**
+   * Value is the output value encoded as JSON.
+   * Only populated by ListStateOutputsBatch with include_values set; always
+   * omitted for sensitive outputs.
+   *
+   * @generated from field: optional string value_json = 8;
+   */
+  valueJson?: string;
+
+  /**
+   * Schema registry key the schema was set from. Only populated for schemas
+   * set via SetOutputSchema with schema_ref.
+   *
+   * @generated from field: optional string schema_ref = 9;
+   */
+  schemaRef?: string;
+
+  /**
+   * Version of the current schema in the output's schema history
+   * (see ListOutputSchemaHistory). Only populated when schema_json is set.
+   *
+   * @generated from field: optional int32 schema_version = 10;
+   */
+  schemaVersion?: number;
+
+  /**
+   * Schema version the last validation ran against. Only populated when
+   * validation has run.
+   *
+   * @generated from field: optional int32 validated_schema_version = 11;
+   */
+  validatedSchemaVersion?: number;
 };
 
 /**
@@ -1271,7 +1488,7 @@ export type OutputKey = Message<"state.v1.OutputKey"> & {
  * Use `create(OutputKeySchema)` to create a new message.
  */
 export const OutputKeySchema: GenMessage<OutputKey> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 36);
+  messageDesc(file_state_v1_state, 38);
 
 /**
  * ListStateOutputsRequest fetches output keys for a state.
@@ -1308,7 +1525,7 @@ export type ListStateOutputsRequest = Message<"state.v1.ListStateOutputsRequest"
  * Use `create(ListStateOutputsRequestSchema)` to create a new message.
  */
 export const ListStateOutputsRequestSchema: GenMessage<ListStateOutputsRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 37);
+  messageDesc(file_state_v1_state, 39);
 
 /**
  * ListStateOutputsResponse returns output keys parsed from Terraform state JSON.
@@ -1342,7 +1559,181 @@ export type ListStateOutputsResponse = Message<"state.v1.ListStateOutputsRespons
  * Use `create(ListStateOutputsResponseSchema)` to create a new message.
  */
 export const ListStateOutputsResponseSchema: GenMessage<ListStateOutputsResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 38);
+  messageDesc(file_state_v1_state, 40);
+
+/**
+ * ListStateOutputsBatchRequest fetches outputs for several states.
+ *
+ * @generated from message state.v1.ListStateOutputsBatchRequest
+ */
+export type ListStateOutputsBatchRequest = Message<"state.v1.ListStateOutputsBatchRequest"> & {
+  /**
+   * States to fetch (at most 100). Each ref is resolved by guid, or by logic_id when guid is empty.
+   *
+   * @generated from field: repeated state.v1.StateRef states = 1;
+   */
+  states: StateRef[];
+
+  /**
+   * Restrict results to these output keys. Empty returns every output.
+   *
+   * @generated from field: repeated string output_keys = 2;
+   */
+  outputKeys: string[];
+
+  /**
+   * Include non-sensitive output values (requires state-output:read).
+   *
+   * @generated from field: bool include_values = 3;
+   */
+  includeValues: boolean;
+};
+
+/**
+ * Describes the message state.v1.ListStateOutputsBatchRequest.
+ * Use `create(ListStateOutputsBatchRequestSchema)` to create a new message.
+ */
+export const ListStateOutputsBatchRequestSchema: GenMessage<ListStateOutputsBatchRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 41);
+
+/**
+ * ListStateOutputsBatchResponse returns outputs grouped by state, in request order.
+ *
+ * @generated from message state.v1.ListStateOutputsBatchResponse
+ */
+export type ListStateOutputsBatchResponse = Message<"state.v1.ListStateOutputsBatchResponse"> & {
+  /**
+   * @generated from field: repeated state.v1.StateOutputs states = 1;
+   */
+  states: StateOutputs[];
+};
+
+/**
+ * Describes the message state.v1.ListStateOutputsBatchResponse.
+ * Use `create(ListStateOutputsBatchResponseSchema)` to create a new message.
+ */
+export const ListStateOutputsBatchResponseSchema: GenMessage<ListStateOutputsBatchResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 42);
+
+/**
+ * StateOutputs lists the outputs of one state.
+ *
+ * @generated from message state.v1.StateOutputs
+ */
+export type StateOutputs = Message<"state.v1.StateOutputs"> & {
+  /**
+   * @generated from field: string state_guid = 1;
+   */
+  stateGuid: string;
+
+  /**
+   * @generated from field: string state_logic_id = 2;
+   */
+  stateLogicId: string;
+
+  /**
+   * @generated from field: repeated state.v1.OutputKey outputs = 3;
+   */
+  outputs: OutputKey[];
+};
+
+/**
+ * Describes the message state.v1.StateOutputs.
+ * Use `create(StateOutputsSchema)` to create a new message.
+ */
+export const StateOutputsSchema: GenMessage<StateOutputs> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 43);
+
+/**
+ * GetStateOutputValuesRequest selects outputs of one state by key.
+ *
+ * @generated from message state.v1.GetStateOutputValuesRequest
+ */
+export type GetStateOutputValuesRequest = Message<"state.v1.GetStateOutputValuesRequest"> & {
+  /**
+   * @generated from field: state.v1.StateRef state = 1;
+   */
+  state?: StateRef;
+
+  /**
+   * Output keys to return. Empty returns every output. Unknown keys are omitted.
+   *
+   * @generated from field: repeated string keys = 2;
+   */
+  keys: string[];
+};
+
+/**
+ * Describes the message state.v1.GetStateOutputValuesRequest.
+ * Use `create(GetStateOutputValuesRequestSchema)` to create a new message.
+ */
+export const GetStateOutputValuesRequestSchema: GenMessage<GetStateOutputValuesRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 44);
+
+/**
+ * @generated from message state.v1.GetStateOutputValuesResponse
+ */
+export type GetStateOutputValuesResponse = Message<"state.v1.GetStateOutputValuesResponse"> & {
+  /**
+   * @generated from field: string state_guid = 1;
+   */
+  stateGuid: string;
+
+  /**
+   * @generated from field: string state_logic_id = 2;
+   */
+  stateLogicId: string;
+
+  /**
+   * @generated from field: repeated state.v1.OutputValue values = 3;
+   */
+  values: OutputValue[];
+};
+
+/**
+ * Describes the message state.v1.GetStateOutputValuesResponse.
+ * Use `create(GetStateOutputValuesResponseSchema)` to create a new message.
+ */
+export const GetStateOutputValuesResponseSchema: GenMessage<GetStateOutputValuesResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 45);
+
+/**
+ * OutputValue is a single Terraform output value.
+ *
+ * @generated from message state.v1.OutputValue
+ */
+export type OutputValue = Message<"state.v1.OutputValue"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * @generated from field: bool sensitive = 2;
+   */
+  sensitive: boolean;
+
+  /**
+   * JSON-encoded value; unset when redacted.
+   *
+   * @generated from field: optional string value_json = 3;
+   */
+  valueJson?: string;
+
+  /**
+   * True when the output is sensitive and the caller may not read it.
+   *
+   * @generated from field: bool redacted = 4;
+   */
+  redacted: boolean;
+};
+
+/**
+ * Describes the message state.v1.OutputValue.
+ * Use `create(OutputValueSchema)` to create a new message.
+ */
+export const OutputValueSchema: GenMessage<OutputValue> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 46);
 
 /**
  * GetStateInfoRequest fetches full state information.
@@ -1372,6 +1763,15 @@ export type GetStateInfoRequest = Message<"state.v1.GetStateInfoRequest"> & {
     value: string;
     case: "guid";
   } | { case: undefined; value?: undefined };
+
+  /**
+   * Whether to compute computed_status (default: false). Status is derived
+   * from the whole dependency graph, so skip it when only outputs and labels
+   * are needed.
+   *
+   * @generated from field: optional bool include_computed_status = 3;
+   */
+  includeComputedStatus?: boolean;
 };
 
 /**
@@ -1379,7 +1779,7 @@ export type GetStateInfoRequest = Message<"state.v1.GetStateInfoRequest"> & {
  * Use `create(GetStateInfoRequestSchema)` to create a new message.
  */
 export const GetStateInfoRequestSchema: GenMessage<GetStateInfoRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 39);
+  messageDesc(file_state_v1_state, 47);
 
 /**
  * GetStateInfoResponse returns comprehensive state view.
@@ -1443,7 +1843,8 @@ export type GetStateInfoResponse = Message<"state.v1.GetStateInfoResponse"> & {
   updatedAt?: Timestamp;
 
   /**
-   * Computed status based on incoming dependency edges
+   * Computed status based on incoming dependency edges; only set when
+   * include_computed_status was requested
    *
    * "clean", "stale", "potentially-stale"
    *
@@ -1471,25 +1872,40 @@ export type GetStateInfoResponse = Message<"state.v1.GetStateInfoResponse"> & {
  * Use `create(GetStateInfoResponseSchema)` to create a new message.
  */
 export const GetStateInfoResponseSchema: GenMessage<GetStateInfoResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 40);
+  messageDesc(file_state_v1_state, 48);
 
 /**
  * ListAllEdgesRequest currently has no parameters.
  * Future: Add filtering, pagination, sorting options.
  *
- * No fields for PoC (returns all edges)
+ * Future enhancements (not in PoC):
+ * optional string status_filter = 1;  // e.g., "dirty", "clean"
  *
  * @generated from message state.v1.ListAllEdgesRequest
  */
 export type ListAllEdgesRequest = Message<"state.v1.ListAllEdgesRequest"> & {
-};
+  /**
+   * Max edges per page (max 1000). Unset or 0 returns every edge in one
+   * response, as before pagination existed.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize: number;
+
+  /**
+   * Opaque cursor from a previous response's next_page_token
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken: string;
+};
 
 /**
  * Describes the message state.v1.ListAllEdgesRequest.
  * Use `create(ListAllEdgesRequestSchema)` to create a new message.
  */
 export const ListAllEdgesRequestSchema: GenMessage<ListAllEdgesRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 41);
+  messageDesc(file_state_v1_state, 49);
 
 /**
  * ListAllEdgesResponse contains all dependency edges.
@@ -1501,6 +1917,14 @@ export type ListAllEdgesResponse = Message<"state.v1.ListAllEdgesResponse"> & {
    * @generated from field: repeated state.v1.DependencyEdge edges = 1;
    */
   edges: DependencyEdge[];
+
+  /**
+   * Cursor for the next page; empty when there are no more edges.
+   * Pages are ordered by edge ID, so edges created mid-iteration are not skipped.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
 };
 
 /**
@@ -1508,7 +1932,7 @@ export type ListAllEdgesResponse = Message<"state.v1.ListAllEdgesResponse"> & {
  * Use `create(ListAllEdgesResponseSchema)` to create a new message.
  */
 export const ListAllEdgesResponseSchema: GenMessage<ListAllEdgesResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 42);
+  messageDesc(file_state_v1_state, 50);
 
 /**
  * LabelValue represents a typed label value (string, number, or boolean).
@@ -1545,7 +1969,7 @@ export type LabelValue = Message<"state.v1.LabelValue"> & {
  * Use `create(LabelValueSchema)` to create a new message.
  */
 export const LabelValueSchema: GenMessage<LabelValue> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 43);
+  messageDesc(file_state_v1_state, 51);
 
 /**
  * UpdateStateLabelsRequest mutates labels for an existing state.
@@ -1587,7 +2011,7 @@ export type UpdateStateLabelsRequest = Message<"state.v1.UpdateStateLabelsReques
  * Use `create(UpdateStateLabelsRequestSchema)` to create a new message.
  */
 export const UpdateStateLabelsRequestSchema: GenMessage<UpdateStateLabelsRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 44);
+  messageDesc(file_state_v1_state, 52);
 
 /**
  * UpdateStateLabelsResponse returns updated label set.
@@ -1628,7 +2052,129 @@ export type UpdateStateLabelsResponse = Message<"state.v1.UpdateStateLabelsRespo
  * Use `create(UpdateStateLabelsResponseSchema)` to create a new message.
  */
 export const UpdateStateLabelsResponseSchema: GenMessage<UpdateStateLabelsResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 45);
+  messageDesc(file_state_v1_state, 53);
+
+/**
+ * TransferStateOwnershipRequest names a state and its new owner.
+ *
+ * @generated from message state.v1.TransferStateOwnershipRequest
+ */
+export type TransferStateOwnershipRequest = Message<"state.v1.TransferStateOwnershipRequest"> & {
+  /**
+   * @generated from oneof state.v1.TransferStateOwnershipRequest.state
+   */
+  state: {
+    /**
+     * @generated from field: string logic_id = 1;
+     */
+    value: string;
+    case: "logicId";
+  } | {
+    /**
+     * @generated from field: string guid = 2;
+     */
+    value: string;
+    case: "guid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * "user" or "service_account"
+   *
+   * @generated from field: string new_owner_type = 3;
+   */
+  newOwnerType: string;
+
+  /**
+   * User subject or service account client ID
+   *
+   * @generated from field: string new_owner_id = 4;
+   */
+  newOwnerId: string;
+};
+
+/**
+ * Describes the message state.v1.TransferStateOwnershipRequest.
+ * Use `create(TransferStateOwnershipRequestSchema)` to create a new message.
+ */
+export const TransferStateOwnershipRequestSchema: GenMessage<TransferStateOwnershipRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 54);
+
+/**
+ * TransferStateOwnershipResponse returns the owner principal IDs before and after.
+ *
+ * @generated from message state.v1.TransferStateOwnershipResponse
+ */
+export type TransferStateOwnershipResponse = Message<"state.v1.TransferStateOwnershipResponse"> & {
+  /**
+   * @generated from field: string guid = 1;
+   */
+  guid: string;
+
+  /**
+   * Empty if the state had no recorded owner
+   *
+   * @generated from field: string previous_owner = 2;
+   */
+  previousOwner: string;
+
+  /**
+   * Principal ID, e.g. "user:alice"
+   *
+   * @generated from field: string new_owner = 3;
+   */
+  newOwner: string;
+};
+
+/**
+ * Describes the message state.v1.TransferStateOwnershipResponse.
+ * Use `create(TransferStateOwnershipResponseSchema)` to create a new message.
+ */
+export const TransferStateOwnershipResponseSchema: GenMessage<TransferStateOwnershipResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 55);
+
+/**
+ * LabelConstraintViolation is attached as a Connect error detail when a
+ * CreateState or UpdateStateLabels call is rejected by a role's
+ * create_constraints or immutable_keys.
+ *
+ * @generated from message state.v1.LabelConstraintViolation
+ */
+export type LabelConstraintViolation = Message<"state.v1.LabelConstraintViolation"> & {
+  /**
+   * Role whose constraint was breached
+   *
+   * @generated from field: string role = 1;
+   */
+  role: string;
+
+  /**
+   * Offending label key
+   *
+   * @generated from field: string label_key = 2;
+   */
+  labelKey: string;
+
+  /**
+   * "required", "allowed_values", or "immutable"
+   *
+   * @generated from field: string constraint = 3;
+   */
+  constraint: string;
+
+  /**
+   * Human-readable explanation
+   *
+   * @generated from field: string message = 4;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message state.v1.LabelConstraintViolation.
+ * Use `create(LabelConstraintViolationSchema)` to create a new message.
+ */
+export const LabelConstraintViolationSchema: GenMessage<LabelConstraintViolation> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 56);
 
 /**
  * GetLabelPolicyRequest retrieves the current policy.
@@ -1645,7 +2191,7 @@ export type GetLabelPolicyRequest = Message<"state.v1.GetLabelPolicyRequest"> &
  * Use `create(GetLabelPolicyRequestSchema)` to create a new message.
  */
 export const GetLabelPolicyRequestSchema: GenMessage<GetLabelPolicyRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 46);
+  messageDesc(file_state_v1_state, 57);
 
 /**
  * GetLabelPolicyResponse returns the label validation policy.
@@ -1681,7 +2227,7 @@ export type GetLabelPolicyResponse = Message<"state.v1.GetLabelPolicyResponse">
  * Use `create(GetLabelPolicyResponseSchema)` to create a new message.
  */
 export const GetLabelPolicyResponseSchema: GenMessage<GetLabelPolicyResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 47);
+  messageDesc(file_state_v1_state, 58);
 
 /**
  * SetLabelPolicyRequest updates the policy.
@@ -1702,7 +2248,7 @@ export type SetLabelPolicyRequest = Message<"state.v1.SetLabelPolicyRequest"> &
  * Use `create(SetLabelPolicyRequestSchema)` to create a new message.
  */
 export const SetLabelPolicyRequestSchema: GenMessage<SetLabelPolicyRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 48);
+  messageDesc(file_state_v1_state, 59);
 
 /**
  * SetLabelPolicyResponse confirms policy update.
@@ -1728,7 +2274,7 @@ export type SetLabelPolicyResponse = Message<"state.v1.SetLabelPolicyResponse">
  * Use `create(SetLabelPolicyResponseSchema)` to create a new message.
  */
 export const SetLabelPolicyResponseSchema: GenMessage<SetLabelPolicyResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 49);
+  messageDesc(file_state_v1_state, 60);
 
 /**
  * @generated from message state.v1.CreateServiceAccountRequest
@@ -1743,6 +2289,14 @@ export type CreateServiceAccountRequest = Message<"state.v1.CreateServiceAccount
    * @generated from field: optional string description = 2;
    */
   description?: string;
+
+  /**
+   * Roles assigned in the same operation. If any assignment fails, the
+   * account is not created.
+   *
+   * @generated from field: repeated string role_names = 3;
+   */
+  roleNames: string[];
 };
 
 /**
@@ -1750,7 +2304,7 @@ export type CreateServiceAccountRequest = Message<"state.v1.CreateServiceAccount
  * Use `create(CreateServiceAccountRequestSchema)` to create a new message.
  */
 export const CreateServiceAccountRequestSchema: GenMessage<CreateServiceAccountRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 50);
+  messageDesc(file_state_v1_state, 61);
 
 /**
  * @generated from message state.v1.CreateServiceAccountResponse
@@ -1782,6 +2336,13 @@ export type CreateServiceAccountResponse = Message<"state.v1.CreateServiceAccoun
    * @generated from field: google.protobuf.Timestamp created_at = 5;
    */
   createdAt?: Timestamp;
+
+  /**
+   * Roles assigned from role_names
+   *
+   * @generated from field: repeated string roles = 6;
+   */
+  roles: string[];
 };
 
 /**
@@ -1789,14 +2350,55 @@ export type CreateServiceAccountResponse = Message<"state.v1.CreateServiceAccoun
  * Use `create(CreateServiceAccountResponseSchema)` to create a new message.
  */
 export const CreateServiceAccountResponseSchema: GenMessage<CreateServiceAccountResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 51);
+  messageDesc(file_state_v1_state, 62);
 
 /**
- * Future: Add pagination
- *
  * @generated from message state.v1.ListServiceAccountsRequest
  */
 export type ListServiceAccountsRequest = Message<"state.v1.ListServiceAccountsRequest"> & {
+  /**
+   * Only accounts whose name starts with this prefix (case-sensitive)
+   *
+   * @generated from field: optional string name_prefix = 1;
+   */
+  namePrefix?: string;
+
+  /**
+   * Only disabled (true) or enabled (false) accounts
+   *
+   * @generated from field: optional bool disabled = 2;
+   */
+  disabled?: boolean;
+
+  /**
+   * Only accounts created by this user ID
+   *
+   * @generated from field: optional string created_by = 3;
+   */
+  createdBy?: string;
+
+  /**
+   * Only accounts not used since this time, including never-used ones
+   * (for stale-account cleanup)
+   *
+   * @generated from field: optional google.protobuf.Timestamp last_used_before = 4;
+   */
+  lastUsedBefore?: Timestamp;
+
+  /**
+   * Max accounts per page (max 1000). Unset or 0 returns every matching
+   * account in one response, as before pagination existed.
+   *
+   * @generated from field: int32 page_size = 5;
+   */
+  pageSize: number;
+
+  /**
+   * Opaque cursor from a previous response's next_page_token
+   *
+   * @generated from field: string page_token = 6;
+   */
+  pageToken: string;
 };
 
 /**
@@ -1804,7 +2406,7 @@ export type ListServiceAccountsRequest = Message<"state.v1.ListServiceAccountsRe
  * Use `create(ListServiceAccountsRequestSchema)` to create a new message.
  */
 export const ListServiceAccountsRequestSchema: GenMessage<ListServiceAccountsRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 52);
+  messageDesc(file_state_v1_state, 63);
 
 /**
  * @generated from message state.v1.ServiceAccountInfo
@@ -1851,7 +2453,7 @@ export type ServiceAccountInfo = Message<"state.v1.ServiceAccountInfo"> & {
  * Use `create(ServiceAccountInfoSchema)` to create a new message.
  */
 export const ServiceAccountInfoSchema: GenMessage<ServiceAccountInfo> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 53);
+  messageDesc(file_state_v1_state, 64);
 
 /**
  * @generated from message state.v1.ListServiceAccountsResponse
@@ -1861,6 +2463,14 @@ export type ListServiceAccountsResponse = Message<"state.v1.ListServiceAccountsR
    * @generated from field: repeated state.v1.ServiceAccountInfo service_accounts = 1;
    */
   serviceAccounts: ServiceAccountInfo[];
+
+  /**
+   * Cursor for the next page; empty when there are no more accounts.
+   * Pages are ordered by account ID, so accounts created mid-iteration are not skipped.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
 };
 
 /**
@@ -1868,7 +2478,7 @@ export type ListServiceAccountsResponse = Message<"state.v1.ListServiceAccountsR
  * Use `create(ListServiceAccountsResponseSchema)` to create a new message.
  */
 export const ListServiceAccountsResponseSchema: GenMessage<ListServiceAccountsResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 54);
+  messageDesc(file_state_v1_state, 65);
 
 /**
  * @generated from message state.v1.RevokeServiceAccountRequest
@@ -1885,7 +2495,7 @@ export type RevokeServiceAccountRequest = Message<"state.v1.RevokeServiceAccount
  * Use `create(RevokeServiceAccountRequestSchema)` to create a new message.
  */
 export const RevokeServiceAccountRequestSchema: GenMessage<RevokeServiceAccountRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 55);
+  messageDesc(file_state_v1_state, 66);
 
 /**
  * @generated from message state.v1.RevokeServiceAccountResponse
@@ -1902,7 +2512,7 @@ export type RevokeServiceAccountResponse = Message<"state.v1.RevokeServiceAccoun
  * Use `create(RevokeServiceAccountResponseSchema)` to create a new message.
  */
 export const RevokeServiceAccountResponseSchema: GenMessage<RevokeServiceAccountResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 56);
+  messageDesc(file_state_v1_state, 67);
 
 /**
  * @generated from message state.v1.RotateServiceAccountRequest
@@ -1914,6 +2524,14 @@ export type RotateServiceAccountRequest = Message<"state.v1.RotateServiceAccount
    * @generated from field: string client_id = 1;
    */
   clientId: string;
+
+  /**
+   * Seconds the old secret stays valid alongside the new one (max 7 days).
+   * Unset or 0 invalidates it immediately.
+   *
+   * @generated from field: optional int64 overlap_seconds = 2;
+   */
+  overlapSeconds?: bigint;
 };
 
 /**
@@ -1921,7 +2539,7 @@ export type RotateServiceAccountRequest = Message<"state.v1.RotateServiceAccount
  * Use `create(RotateServiceAccountRequestSchema)` to create a new message.
  */
 export const RotateServiceAccountRequestSchema: GenMessage<RotateServiceAccountRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 57);
+  messageDesc(file_state_v1_state, 68);
 
 /**
  * @generated from message state.v1.RotateServiceAccountResponse
@@ -1945,6 +2563,13 @@ export type RotateServiceAccountResponse = Message<"state.v1.RotateServiceAccoun
    * @generated from field: google.protobuf.Timestamp rotated_at = 3;
    */
   rotatedAt?: Timestamp;
+
+  /**
+   * When the old secret stops working; unset when it was invalidated at once
+   *
+   * @generated from field: optional google.protobuf.Timestamp previous_secret_expires_at = 4;
+   */
+  previousSecretExpiresAt?: Timestamp;
 };
 
 /**
@@ -1952,7 +2577,7 @@ export type RotateServiceAccountResponse = Message<"state.v1.RotateServiceAccoun
  * Use `create(RotateServiceAccountResponseSchema)` to create a new message.
  */
 export const RotateServiceAccountResponseSchema: GenMessage<RotateServiceAccountResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 58);
+  messageDesc(file_state_v1_state, 69);
 
 /**
  * @generated from message state.v1.CreateRoleRequest
@@ -1991,6 +2616,27 @@ export type CreateRoleRequest = Message<"state.v1.CreateRoleRequest"> & {
    * @generated from field: repeated string immutable_keys = 6;
    */
   immutableKeys: string[];
+
+  /**
+   * Cap on principals holding the role (unset = unlimited)
+   *
+   * @generated from field: optional int32 max_assignments = 7;
+   */
+  maxAssignments?: number;
+
+  /**
+   * Actions allowed on states the principal created, regardless of label_scope_expr; must be granted by actions
+   *
+   * @generated from field: repeated string owner_actions = 8;
+   */
+  ownerActions: string[];
+
+  /**
+   * CreateState fills in missing labels that create_constraints pin to a single allowed value
+   *
+   * @generated from field: bool default_labels = 9;
+   */
+  defaultLabels: boolean;
 };
 
 /**
@@ -1998,7 +2644,7 @@ export type CreateRoleRequest = Message<"state.v1.CreateRoleRequest"> & {
  * Use `create(CreateRoleRequestSchema)` to create a new message.
  */
 export const CreateRoleRequestSchema: GenMessage<CreateRoleRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 59);
+  messageDesc(file_state_v1_state, 70);
 
 /**
  * @generated from message state.v1.CreateConstraints
@@ -2017,7 +2663,7 @@ export type CreateConstraints = Message<"state.v1.CreateConstraints"> & {
  * Use `create(CreateConstraintsSchema)` to create a new message.
  */
 export const CreateConstraintsSchema: GenMessage<CreateConstraints> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 60);
+  messageDesc(file_state_v1_state, 71);
 
 /**
  * @generated from message state.v1.CreateConstraint
@@ -2039,7 +2685,7 @@ export type CreateConstraint = Message<"state.v1.CreateConstraint"> & {
  * Use `create(CreateConstraintSchema)` to create a new message.
  */
 export const CreateConstraintSchema: GenMessage<CreateConstraint> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 61);
+  messageDesc(file_state_v1_state, 72);
 
 /**
  * @generated from message state.v1.RoleInfo
@@ -2096,6 +2742,21 @@ export type RoleInfo = Message<"state.v1.RoleInfo"> & {
    * @generated from field: int32 version = 10;
    */
   version: number;
+
+  /**
+   * @generated from field: optional int32 max_assignments = 11;
+   */
+  maxAssignments?: number;
+
+  /**
+   * @generated from field: repeated string owner_actions = 12;
+   */
+  ownerActions: string[];
+
+  /**
+   * @generated from field: bool default_labels = 13;
+   */
+  defaultLabels: boolean;
 };
 
 /**
@@ -2103,7 +2764,7 @@ export type RoleInfo = Message<"state.v1.RoleInfo"> & {
  * Use `create(RoleInfoSchema)` to create a new message.
  */
 export const RoleInfoSchema: GenMessage<RoleInfo> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 62);
+  messageDesc(file_state_v1_state, 73);
 
 /**
  * @generated from message state.v1.CreateRoleResponse
@@ -2120,7 +2781,7 @@ export type CreateRoleResponse = Message<"state.v1.CreateRoleResponse"> & {
  * Use `create(CreateRoleResponseSchema)` to create a new message.
  */
 export const CreateRoleResponseSchema: GenMessage<CreateRoleResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 63);
+  messageDesc(file_state_v1_state, 74);
 
 /**
  * Future: Add filtering
@@ -2135,7 +2796,7 @@ export type ListRolesRequest = Message<"state.v1.ListRolesRequest"> & {
  * Use `create(ListRolesRequestSchema)` to create a new message.
  */
 export const ListRolesRequestSchema: GenMessage<ListRolesRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 64);
+  messageDesc(file_state_v1_state, 75);
 
 /**
  * @generated from message state.v1.ListRolesResponse
@@ -2152,7 +2813,7 @@ export type ListRolesResponse = Message<"state.v1.ListRolesResponse"> & {
  * Use `create(ListRolesResponseSchema)` to create a new message.
  */
 export const ListRolesResponseSchema: GenMessage<ListRolesResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 65);
+  messageDesc(file_state_v1_state, 76);
 
 /**
  * @generated from message state.v1.UpdateRoleRequest
@@ -2198,6 +2859,27 @@ export type UpdateRoleRequest = Message<"state.v1.UpdateRoleRequest"> & {
    * @generated from field: int32 expected_version = 7;
    */
   expectedVersion: number;
+
+  /**
+   * Cap on principals holding the role (unset = unlimited)
+   *
+   * @generated from field: optional int32 max_assignments = 8;
+   */
+  maxAssignments?: number;
+
+  /**
+   * Actions allowed on states the principal created, regardless of label_scope_expr
+   *
+   * @generated from field: repeated string owner_actions = 9;
+   */
+  ownerActions: string[];
+
+  /**
+   * CreateState fills in missing labels that create_constraints pin to a single allowed value
+   *
+   * @generated from field: bool default_labels = 10;
+   */
+  defaultLabels: boolean;
 };
 
 /**
@@ -2205,7 +2887,7 @@ export type UpdateRoleRequest = Message<"state.v1.UpdateRoleRequest"> & {
  * Use `create(UpdateRoleRequestSchema)` to create a new message.
  */
 export const UpdateRoleRequestSchema: GenMessage<UpdateRoleRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 66);
+  messageDesc(file_state_v1_state, 77);
 
 /**
  * @generated from message state.v1.UpdateRoleResponse
@@ -2222,7 +2904,7 @@ export type UpdateRoleResponse = Message<"state.v1.UpdateRoleResponse"> & {
  * Use `create(UpdateRoleResponseSchema)` to create a new message.
  */
 export const UpdateRoleResponseSchema: GenMessage<UpdateRoleResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 67);
+  messageDesc(file_state_v1_state, 78);
 
 /**
  * @generated from message state.v1.DeleteRoleRequest
@@ -2239,7 +2921,7 @@ export type DeleteRoleRequest = Message<"state.v1.DeleteRoleRequest"> & {
  * Use `create(DeleteRoleRequestSchema)` to create a new message.
  */
 export const DeleteRoleRequestSchema: GenMessage<DeleteRoleRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 68);
+  messageDesc(file_state_v1_state, 79);
 
 /**
  * @generated from message state.v1.DeleteRoleResponse
@@ -2256,7 +2938,7 @@ export type DeleteRoleResponse = Message<"state.v1.DeleteRoleResponse"> & {
  * Use `create(DeleteRoleResponseSchema)` to create a new message.
  */
 export const DeleteRoleResponseSchema: GenMessage<DeleteRoleResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 69);
+  messageDesc(file_state_v1_state, 80);
 
 /**
  * @generated from message state.v1.AssignRoleRequest
@@ -2287,7 +2969,7 @@ export type AssignRoleRequest = Message<"state.v1.AssignRoleRequest"> & {
  * Use `create(AssignRoleRequestSchema)` to create a new message.
  */
 export const AssignRoleRequestSchema: GenMessage<AssignRoleRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 70);
+  messageDesc(file_state_v1_state, 81);
 
 /**
  * @generated from message state.v1.AssignRoleResponse
@@ -2309,7 +2991,7 @@ export type AssignRoleResponse = Message<"state.v1.AssignRoleResponse"> & {
  * Use `create(AssignRoleResponseSchema)` to create a new message.
  */
 export const AssignRoleResponseSchema: GenMessage<AssignRoleResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 71);
+  messageDesc(file_state_v1_state, 82);
 
 /**
  * @generated from message state.v1.RemoveRoleRequest
@@ -2336,7 +3018,7 @@ export type RemoveRoleRequest = Message<"state.v1.RemoveRoleRequest"> & {
  * Use `create(RemoveRoleRequestSchema)` to create a new message.
  */
 export const RemoveRoleRequestSchema: GenMessage<RemoveRoleRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 72);
+  messageDesc(file_state_v1_state, 83);
 
 /**
  * @generated from message state.v1.RemoveRoleResponse
@@ -2353,7 +3035,7 @@ export type RemoveRoleResponse = Message<"state.v1.RemoveRoleResponse"> & {
  * Use `create(RemoveRoleResponseSchema)` to create a new message.
  */
 export const RemoveRoleResponseSchema: GenMessage<RemoveRoleResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 73);
+  messageDesc(file_state_v1_state, 84);
 
 /**
  * @generated from message state.v1.ListUserRolesRequest
@@ -2375,7 +3057,7 @@ export type ListUserRolesRequest = Message<"state.v1.ListUserRolesRequest"> & {
  * Use `create(ListUserRolesRequestSchema)` to create a new message.
  */
 export const ListUserRolesRequestSchema: GenMessage<ListUserRolesRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 74);
+  messageDesc(file_state_v1_state, 85);
 
 /**
  * @generated from message state.v1.RoleAssignmentInfo
@@ -2402,7 +3084,7 @@ export type RoleAssignmentInfo = Message<"state.v1.RoleAssignmentInfo"> & {
  * Use `create(RoleAssignmentInfoSchema)` to create a new message.
  */
 export const RoleAssignmentInfoSchema: GenMessage<RoleAssignmentInfo> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 75);
+  messageDesc(file_state_v1_state, 86);
 
 /**
  * @generated from message state.v1.ListUserRolesResponse
@@ -2419,7 +3101,7 @@ export type ListUserRolesResponse = Message<"state.v1.ListUserRolesResponse"> &
  * Use `create(ListUserRolesResponseSchema)` to create a new message.
  */
 export const ListUserRolesResponseSchema: GenMessage<ListUserRolesResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 76);
+  messageDesc(file_state_v1_state, 87);
 
 /**
  * @generated from message state.v1.AssignGroupRoleRequest
@@ -2436,6 +3118,15 @@ export type AssignGroupRoleRequest = Message<"state.v1.AssignGroupRoleRequest">
    * @generated from field: string role_name = 2;
    */
   roleName: string;
+
+  /**
+   * Optional go-bexpr expression over the caller's token claims
+   * (e.g., 'department == "finance"'). When set, the role is granted only if
+   * the group is present AND the expression evaluates true. Empty = unconditional.
+   *
+   * @generated from field: string condition = 3;
+   */
+  condition: string;
 };
 
 /**
@@ -2443,7 +3134,7 @@ export type AssignGroupRoleRequest = Message<"state.v1.AssignGroupRoleRequest">
  * Use `create(AssignGroupRoleRequestSchema)` to create a new message.
  */
 export const AssignGroupRoleRequestSchema: GenMessage<AssignGroupRoleRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 77);
+  messageDesc(file_state_v1_state, 88);
 
 /**
  * @generated from message state.v1.AssignGroupRoleResponse
@@ -2465,7 +3156,7 @@ export type AssignGroupRoleResponse = Message<"state.v1.AssignGroupRoleResponse"
  * Use `create(AssignGroupRoleResponseSchema)` to create a new message.
  */
 export const AssignGroupRoleResponseSchema: GenMessage<AssignGroupRoleResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 78);
+  messageDesc(file_state_v1_state, 89);
 
 /**
  * @generated from message state.v1.RemoveGroupRoleRequest
@@ -2487,7 +3178,7 @@ export type RemoveGroupRoleRequest = Message<"state.v1.RemoveGroupRoleRequest">
  * Use `create(RemoveGroupRoleRequestSchema)` to create a new message.
  */
 export const RemoveGroupRoleRequestSchema: GenMessage<RemoveGroupRoleRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 79);
+  messageDesc(file_state_v1_state, 90);
 
 /**
  * @generated from message state.v1.RemoveGroupRoleResponse
@@ -2504,7 +3195,7 @@ export type RemoveGroupRoleResponse = Message<"state.v1.RemoveGroupRoleResponse"
  * Use `create(RemoveGroupRoleResponseSchema)` to create a new message.
  */
 export const RemoveGroupRoleResponseSchema: GenMessage<RemoveGroupRoleResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 80);
+  messageDesc(file_state_v1_state, 91);
 
 /**
  * @generated from message state.v1.ListGroupRolesRequest
@@ -2523,7 +3214,7 @@ export type ListGroupRolesRequest = Message<"state.v1.ListGroupRolesRequest"> &
  * Use `create(ListGroupRolesRequestSchema)` to create a new message.
  */
 export const ListGroupRolesRequestSchema: GenMessage<ListGroupRolesRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 81);
+  messageDesc(file_state_v1_state, 92);
 
 /**
  * @generated from message state.v1.GroupRoleAssignmentInfo
@@ -2548,14 +3239,21 @@ export type GroupRoleAssignmentInfo = Message<"state.v1.GroupRoleAssignmentInfo"
    * @generated from field: string assigned_by_user_id = 4;
    */
   assignedByUserId: string;
-};
 
-/**
+  /**
+   * Empty when the mapping is unconditional
+   *
+   * @generated from field: string condition = 5;
+   */
+  condition: string;
+};
+
+/**
  * Describes the message state.v1.GroupRoleAssignmentInfo.
  * Use `create(GroupRoleAssignmentInfoSchema)` to create a new message.
  */
 export const GroupRoleAssignmentInfoSchema: GenMessage<GroupRoleAssignmentInfo> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 82);
+  messageDesc(file_state_v1_state, 93);
 
 /**
  * @generated from message state.v1.ListGroupRolesResponse
@@ -2572,7 +3270,7 @@ export type ListGroupRolesResponse = Message<"state.v1.ListGroupRolesResponse">
  * Use `create(ListGroupRolesResponseSchema)` to create a new message.
  */
 export const ListGroupRolesResponseSchema: GenMessage<ListGroupRolesResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 83);
+  messageDesc(file_state_v1_state, 94);
 
 /**
  * @generated from message state.v1.GetEffectivePermissionsRequest
@@ -2594,7 +3292,7 @@ export type GetEffectivePermissionsRequest = Message<"state.v1.GetEffectivePermi
  * Use `create(GetEffectivePermissionsRequestSchema)` to create a new message.
  */
 export const GetEffectivePermissionsRequestSchema: GenMessage<GetEffectivePermissionsRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 84);
+  messageDesc(file_state_v1_state, 95);
 
 /**
  * @generated from message state.v1.EffectivePermissions
@@ -2639,7 +3337,7 @@ export type EffectivePermissions = Message<"state.v1.EffectivePermissions"> & {
  * Use `create(EffectivePermissionsSchema)` to create a new message.
  */
 export const EffectivePermissionsSchema: GenMessage<EffectivePermissions> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 85);
+  messageDesc(file_state_v1_state, 96);
 
 /**
  * @generated from message state.v1.GetEffectivePermissionsResponse
@@ -2649,6 +3347,15 @@ export type GetEffectivePermissionsResponse = Message<"state.v1.GetEffectivePerm
    * @generated from field: state.v1.EffectivePermissions permissions = 1;
    */
   permissions?: EffectivePermissions;
+
+  /**
+   * Changes whenever roles, role assignments or group mappings change; cached
+   * permissions are stale once it differs. Derived from the model, so it is
+   * the same on every replica and across restarts.
+   *
+   * @generated from field: uint64 authz_model_version = 2;
+   */
+  authzModelVersion: bigint;
 };
 
 /**
@@ -2656,7 +3363,7 @@ export type GetEffectivePermissionsResponse = Message<"state.v1.GetEffectivePerm
  * Use `create(GetEffectivePermissionsResponseSchema)` to create a new message.
  */
 export const GetEffectivePermissionsResponseSchema: GenMessage<GetEffectivePermissionsResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 86);
+  messageDesc(file_state_v1_state, 97);
 
 /**
  * @generated from message state.v1.ListSessionsRequest
@@ -2673,7 +3380,7 @@ export type ListSessionsRequest = Message<"state.v1.ListSessionsRequest"> & {
  * Use `create(ListSessionsRequestSchema)` to create a new message.
  */
 export const ListSessionsRequestSchema: GenMessage<ListSessionsRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 87);
+  messageDesc(file_state_v1_state, 98);
 
 /**
  * @generated from message state.v1.SessionInfo
@@ -2708,6 +3415,23 @@ export type SessionInfo = Message<"state.v1.SessionInfo"> & {
    * @generated from field: optional string ip_address = 6;
    */
   ipAddress?: string;
+
+  /**
+   * @generated from field: optional string user_id = 7;
+   */
+  userId?: string;
+
+  /**
+   * @generated from field: bool revoked = 8;
+   */
+  revoked: boolean;
+
+  /**
+   * Set instead of user_id for service account sessions
+   *
+   * @generated from field: optional string service_account_id = 9;
+   */
+  serviceAccountId?: string;
 };
 
 /**
@@ -2715,70 +3439,1251 @@ export type SessionInfo = Message<"state.v1.SessionInfo"> & {
  * Use `create(SessionInfoSchema)` to create a new message.
  */
 export const SessionInfoSchema: GenMessage<SessionInfo> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 88);
+  messageDesc(file_state_v1_state, 99);
+
+/**
+ * @generated from message state.v1.ListSessionsResponse
+ */
+export type ListSessionsResponse = Message<"state.v1.ListSessionsResponse"> & {
+  /**
+   * @generated from field: repeated state.v1.SessionInfo sessions = 1;
+   */
+  sessions: SessionInfo[];
+};
+
+/**
+ * Describes the message state.v1.ListSessionsResponse.
+ * Use `create(ListSessionsResponseSchema)` to create a new message.
+ */
+export const ListSessionsResponseSchema: GenMessage<ListSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 100);
+
+/**
+ * ListAllSessionsRequest filters and pages sessions across all users.
+ *
+ * @generated from message state.v1.ListAllSessionsRequest
+ */
+export type ListAllSessionsRequest = Message<"state.v1.ListAllSessionsRequest"> & {
+  /**
+   * Only sessions belonging to this user (empty = all users)
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * Exclude revoked and expired sessions
+   *
+   * @generated from field: bool active_only = 2;
+   */
+  activeOnly: boolean;
+
+  /**
+   * Only sessions created after this time
+   *
+   * @generated from field: google.protobuf.Timestamp created_after = 3;
+   */
+  createdAfter?: Timestamp;
+
+  /**
+   * Max sessions per page (default 50, max 500)
+   *
+   * @generated from field: int32 page_size = 4;
+   */
+  pageSize: number;
+
+  /**
+   * Sessions to skip, for paging
+   *
+   * @generated from field: int32 offset = 5;
+   */
+  offset: number;
+
+  /**
+   * Only sessions belonging to this service account
+   *
+   * @generated from field: string service_account_id = 6;
+   */
+  serviceAccountId: string;
+
+  /**
+   * Only revoked sessions (mutually exclusive with active_only)
+   *
+   * @generated from field: bool revoked_only = 7;
+   */
+  revokedOnly: boolean;
+};
+
+/**
+ * Describes the message state.v1.ListAllSessionsRequest.
+ * Use `create(ListAllSessionsRequestSchema)` to create a new message.
+ */
+export const ListAllSessionsRequestSchema: GenMessage<ListAllSessionsRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 101);
+
+/**
+ * ListAllSessionsResponse returns one page of session metadata (never tokens).
+ *
+ * @generated from message state.v1.ListAllSessionsResponse
+ */
+export type ListAllSessionsResponse = Message<"state.v1.ListAllSessionsResponse"> & {
+  /**
+   * @generated from field: repeated state.v1.SessionInfo sessions = 1;
+   */
+  sessions: SessionInfo[];
+
+  /**
+   * Offset of the next page; 0 when there are no more sessions
+   *
+   * @generated from field: int32 next_offset = 2;
+   */
+  nextOffset: number;
+};
+
+/**
+ * Describes the message state.v1.ListAllSessionsResponse.
+ * Use `create(ListAllSessionsResponseSchema)` to create a new message.
+ */
+export const ListAllSessionsResponseSchema: GenMessage<ListAllSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 102);
+
+/**
+ * RevokeSessionsRequest selects sessions to revoke. At least one of user_id,
+ * service_account_id, created_before or created_after must be set.
+ *
+ * @generated from message state.v1.RevokeSessionsRequest
+ */
+export type RevokeSessionsRequest = Message<"state.v1.RevokeSessionsRequest"> & {
+  /**
+   * Only sessions belonging to this user
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * Only sessions belonging to this service account
+   *
+   * @generated from field: string service_account_id = 2;
+   */
+  serviceAccountId: string;
+
+  /**
+   * Only sessions created before this time (e.g. a breach time)
+   *
+   * @generated from field: google.protobuf.Timestamp created_before = 3;
+   */
+  createdBefore?: Timestamp;
+
+  /**
+   * Only sessions created after this time
+   *
+   * @generated from field: google.protobuf.Timestamp created_after = 4;
+   */
+  createdAfter?: Timestamp;
+};
+
+/**
+ * Describes the message state.v1.RevokeSessionsRequest.
+ * Use `create(RevokeSessionsRequestSchema)` to create a new message.
+ */
+export const RevokeSessionsRequestSchema: GenMessage<RevokeSessionsRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 103);
+
+/**
+ * RevokeSessionsResponse reports how many sessions were revoked.
+ *
+ * @generated from message state.v1.RevokeSessionsResponse
+ */
+export type RevokeSessionsResponse = Message<"state.v1.RevokeSessionsResponse"> & {
+  /**
+   * @generated from field: int32 revoked_count = 1;
+   */
+  revokedCount: number;
+};
+
+/**
+ * Describes the message state.v1.RevokeSessionsResponse.
+ * Use `create(RevokeSessionsResponseSchema)` to create a new message.
+ */
+export const RevokeSessionsResponseSchema: GenMessage<RevokeSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 104);
+
+/**
+ * @generated from message state.v1.RevokeSessionRequest
+ */
+export type RevokeSessionRequest = Message<"state.v1.RevokeSessionRequest"> & {
+  /**
+   * @generated from field: string session_id = 1;
+   */
+  sessionId: string;
+};
+
+/**
+ * Describes the message state.v1.RevokeSessionRequest.
+ * Use `create(RevokeSessionRequestSchema)` to create a new message.
+ */
+export const RevokeSessionRequestSchema: GenMessage<RevokeSessionRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 105);
+
+/**
+ * @generated from message state.v1.RevokeSessionResponse
+ */
+export type RevokeSessionResponse = Message<"state.v1.RevokeSessionResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message state.v1.RevokeSessionResponse.
+ * Use `create(RevokeSessionResponseSchema)` to create a new message.
+ */
+export const RevokeSessionResponseSchema: GenMessage<RevokeSessionResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 106);
+
+/**
+ * IntrospectTokenRequest asks for the status of an arbitrary bearer token.
+ *
+ * @generated from message state.v1.IntrospectTokenRequest
+ */
+export type IntrospectTokenRequest = Message<"state.v1.IntrospectTokenRequest"> & {
+  /**
+   * @generated from field: string token = 1;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message state.v1.IntrospectTokenRequest.
+ * Use `create(IntrospectTokenRequestSchema)` to create a new message.
+ */
+export const IntrospectTokenRequestSchema: GenMessage<IntrospectTokenRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 107);
+
+/**
+ * IntrospectTokenResponse reports whether the token would currently be accepted
+ * and the claims and session state that decision is based on.
+ *
+ * @generated from message state.v1.IntrospectTokenResponse
+ */
+export type IntrospectTokenResponse = Message<"state.v1.IntrospectTokenResponse"> & {
+  /**
+   * @generated from field: bool active = 1;
+   */
+  active: boolean;
+
+  /**
+   * Why the token is inactive (unset when active)
+   *
+   * @generated from field: optional string inactive_reason = 2;
+   */
+  inactiveReason?: string;
+
+  /**
+   * @generated from field: optional string subject = 3;
+   */
+  subject?: string;
+
+  /**
+   * @generated from field: optional string client_id = 4;
+   */
+  clientId?: string;
+
+  /**
+   * @generated from field: repeated string scopes = 5;
+   */
+  scopes: string[];
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp expires_at = 6;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp issued_at = 7;
+   */
+  issuedAt?: Timestamp;
+
+  /**
+   * @generated from field: optional string jti = 8;
+   */
+  jti?: string;
+
+  /**
+   * True if the jti is on the revocation denylist
+   *
+   * @generated from field: bool jti_revoked = 9;
+   */
+  jtiRevoked: boolean;
+
+  /**
+   * Backing session (internal IdP tokens only)
+   *
+   * @generated from field: optional string session_id = 10;
+   */
+  sessionId?: string;
+
+  /**
+   * @generated from field: bool session_revoked = 11;
+   */
+  sessionRevoked: boolean;
+};
+
+/**
+ * Describes the message state.v1.IntrospectTokenResponse.
+ * Use `create(IntrospectTokenResponseSchema)` to create a new message.
+ */
+export const IntrospectTokenResponseSchema: GenMessage<IntrospectTokenResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 108);
+
+/**
+ * PreviewAuthorizationRequest describes a hypothetical principal and request.
+ *
+ * @generated from message state.v1.PreviewAuthorizationRequest
+ */
+export type PreviewAuthorizationRequest = Message<"state.v1.PreviewAuthorizationRequest"> & {
+  /**
+   * Role names; each must exist
+   *
+   * @generated from field: repeated string roles = 1;
+   */
+  roles: string[];
+
+  /**
+   * IdP groups, resolved through group-role mappings (conditional mappings are not granted)
+   *
+   * @generated from field: repeated string groups = 2;
+   */
+  groups: string[];
+
+  /**
+   * Object type, e.g. "state", "policy", "admin"
+   *
+   * @generated from field: string object = 3;
+   */
+  object: string;
+
+  /**
+   * e.g. "state:read", "tfstate:write"
+   *
+   * @generated from field: string action = 4;
+   */
+  action: string;
+
+  /**
+   * Labels of the target state
+   *
+   * @generated from field: map<string, state.v1.LabelValue> labels = 5;
+   */
+  labels: { [key: string]: LabelValue };
+};
+
+/**
+ * Describes the message state.v1.PreviewAuthorizationRequest.
+ * Use `create(PreviewAuthorizationRequestSchema)` to create a new message.
+ */
+export const PreviewAuthorizationRequestSchema: GenMessage<PreviewAuthorizationRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 109);
+
+/**
+ * RoleAuthorizationDecision is one role's verdict and the policy behind it.
+ *
+ * @generated from message state.v1.RoleAuthorizationDecision
+ */
+export type RoleAuthorizationDecision = Message<"state.v1.RoleAuthorizationDecision"> & {
+  /**
+   * @generated from field: string role = 1;
+   */
+  role: string;
+
+  /**
+   * @generated from field: bool allowed = 2;
+   */
+  allowed: boolean;
+
+  /**
+   * Decisive policy in CreateRole form ("obj:act", "!" prefix for deny); unset when none matched
+   *
+   * @generated from field: optional string policy_action = 3;
+   */
+  policyAction?: string;
+
+  /**
+   * @generated from field: optional string policy_scope_expr = 4;
+   */
+  policyScopeExpr?: string;
+};
+
+/**
+ * Describes the message state.v1.RoleAuthorizationDecision.
+ * Use `create(RoleAuthorizationDecisionSchema)` to create a new message.
+ */
+export const RoleAuthorizationDecisionSchema: GenMessage<RoleAuthorizationDecision> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 110);
+
+/**
+ * PreviewAuthorizationResponse is the decision with its explain trace.
+ *
+ * @generated from message state.v1.PreviewAuthorizationResponse
+ */
+export type PreviewAuthorizationResponse = Message<"state.v1.PreviewAuthorizationResponse"> & {
+  /**
+   * @generated from field: bool allowed = 1;
+   */
+  allowed: boolean;
+
+  /**
+   * "union" or "intersection"
+   *
+   * @generated from field: string combination = 2;
+   */
+  combination: string;
+
+  /**
+   * Explicit roles first, then group-mapped roles
+   *
+   * @generated from field: repeated state.v1.RoleAuthorizationDecision roles = 3;
+   */
+  roles: RoleAuthorizationDecision[];
+
+  /**
+   * @generated from field: string reason = 4;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message state.v1.PreviewAuthorizationResponse.
+ * Use `create(PreviewAuthorizationResponseSchema)` to create a new message.
+ */
+export const PreviewAuthorizationResponseSchema: GenMessage<PreviewAuthorizationResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 111);
+
+/**
+ * SetOutputSchemaRequest publishes or updates a JSON Schema for a specific state output.
+ * Allows clients to declare expected output types before the output actually exists.
+ *
+ * @generated from message state.v1.SetOutputSchemaRequest
+ */
+export type SetOutputSchemaRequest = Message<"state.v1.SetOutputSchemaRequest"> & {
+  /**
+   * State identifier (logic_id or GUID)
+   *
+   * @generated from oneof state.v1.SetOutputSchemaRequest.state
+   */
+  state: {
+    /**
+     * @generated from field: string state_logic_id = 1;
+     */
+    value: string;
+    case: "stateLogicId";
+  } | {
+    /**
+     * @generated from field: string state_guid = 2;
+     */
+    value: string;
+    case: "stateGuid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Name of the output (e.g., "vpc_id", "subnet_ids")
+   *
+   * @generated from field: string output_key = 3;
+   */
+  outputKey: string;
+
+  /**
+   * JSON Schema definition for this output (must be valid JSON Schema)
+   * Example: {"type": "string", "pattern": "^vpc-[a-z0-9]+$"}
+   * Exactly one of schema_json and schema_ref must be set.
+   *
+   * @generated from field: string schema_json = 4;
+   */
+  schemaJson: string;
+
+  /**
+   * Validate the output's current value inline and report the result in the
+   * response. By default validation runs asynchronously after the response.
+   *
+   * @generated from field: bool validate_now = 5;
+   */
+  validateNow: boolean;
+
+  /**
+   * Schema registry key to use instead of inline schema_json (e.g. "aws/vpc_id@v1").
+   * The output follows later updates to the registered schema.
+   *
+   * @generated from field: string schema_ref = 6;
+   */
+  schemaRef: string;
+};
+
+/**
+ * Describes the message state.v1.SetOutputSchemaRequest.
+ * Use `create(SetOutputSchemaRequestSchema)` to create a new message.
+ */
+export const SetOutputSchemaRequestSchema: GenMessage<SetOutputSchemaRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 112);
+
+/**
+ * SetOutputSchemaResponse confirms schema publication.
+ *
+ * @generated from message state.v1.SetOutputSchemaResponse
+ */
+export type SetOutputSchemaResponse = Message<"state.v1.SetOutputSchemaResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+
+  /**
+   * State identifiers for confirmation
+   *
+   * @generated from field: string state_guid = 2;
+   */
+  stateGuid: string;
+
+  /**
+   * @generated from field: string state_logic_id = 3;
+   */
+  stateLogicId: string;
+
+  /**
+   * @generated from field: string output_key = 4;
+   */
+  outputKey: string;
+
+  /**
+   * Validation result when validate_now was set and the output has a value:
+   * "valid", "invalid", or "error". Unset otherwise.
+   *
+   * @generated from field: optional string validation_status = 5;
+   */
+  validationStatus?: string;
+
+  /**
+   * @generated from field: optional string validation_error = 6;
+   */
+  validationError?: string;
+};
+
+/**
+ * Describes the message state.v1.SetOutputSchemaResponse.
+ * Use `create(SetOutputSchemaResponseSchema)` to create a new message.
+ */
+export const SetOutputSchemaResponseSchema: GenMessage<SetOutputSchemaResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 113);
+
+/**
+ * SetOutputSchemasRequest publishes or updates JSON Schemas for many outputs.
+ * Either every schema is stored or none are.
+ *
+ * @generated from message state.v1.SetOutputSchemasRequest
+ */
+export type SetOutputSchemasRequest = Message<"state.v1.SetOutputSchemasRequest"> & {
+  /**
+   * State identifier (logic_id or GUID)
+   *
+   * @generated from oneof state.v1.SetOutputSchemasRequest.state
+   */
+  state: {
+    /**
+     * @generated from field: string state_logic_id = 1;
+     */
+    value: string;
+    case: "stateLogicId";
+  } | {
+    /**
+     * @generated from field: string state_guid = 2;
+     */
+    value: string;
+    case: "stateGuid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Output key -> JSON Schema definition (each must be valid JSON Schema)
+   *
+   * @generated from field: map<string, string> schemas = 3;
+   */
+  schemas: { [key: string]: string };
+};
+
+/**
+ * Describes the message state.v1.SetOutputSchemasRequest.
+ * Use `create(SetOutputSchemasRequestSchema)` to create a new message.
+ */
+export const SetOutputSchemasRequestSchema: GenMessage<SetOutputSchemasRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 114);
+
+/**
+ * OutputSchemaResult reports how a single schema in a bulk write was stored.
+ *
+ * @generated from message state.v1.OutputSchemaResult
+ */
+export type OutputSchemaResult = Message<"state.v1.OutputSchemaResult"> & {
+  /**
+   * @generated from field: string output_key = 1;
+   */
+  outputKey: string;
+
+  /**
+   * True if the output had no schema before this call, false if one was replaced
+   *
+   * @generated from field: bool created = 2;
+   */
+  created: boolean;
+};
+
+/**
+ * Describes the message state.v1.OutputSchemaResult.
+ * Use `create(OutputSchemaResultSchema)` to create a new message.
+ */
+export const OutputSchemaResultSchema: GenMessage<OutputSchemaResult> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 115);
+
+/**
+ * SetOutputSchemasResponse confirms bulk schema publication.
+ *
+ * @generated from message state.v1.SetOutputSchemasResponse
+ */
+export type SetOutputSchemasResponse = Message<"state.v1.SetOutputSchemasResponse"> & {
+  /**
+   * State identifiers for confirmation
+   *
+   * @generated from field: string state_guid = 1;
+   */
+  stateGuid: string;
+
+  /**
+   * @generated from field: string state_logic_id = 2;
+   */
+  stateLogicId: string;
+
+  /**
+   * One result per output key, sorted by output_key
+   *
+   * @generated from field: repeated state.v1.OutputSchemaResult results = 3;
+   */
+  results: OutputSchemaResult[];
+};
+
+/**
+ * Describes the message state.v1.SetOutputSchemasResponse.
+ * Use `create(SetOutputSchemasResponseSchema)` to create a new message.
+ */
+export const SetOutputSchemasResponseSchema: GenMessage<SetOutputSchemasResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 116);
+
+/**
+ * RevalidateOutputsForSchemaRequest names the output whose schema selects the
+ * outputs to re-validate. A schema registry key may be added as another selector.
+ *
+ * @generated from message state.v1.RevalidateOutputsForSchemaRequest
+ */
+export type RevalidateOutputsForSchemaRequest = Message<"state.v1.RevalidateOutputsForSchemaRequest"> & {
+  /**
+   * State identifier (logic_id or GUID)
+   *
+   * @generated from oneof state.v1.RevalidateOutputsForSchemaRequest.state
+   */
+  state: {
+    /**
+     * @generated from field: string state_logic_id = 1;
+     */
+    value: string;
+    case: "stateLogicId";
+  } | {
+    /**
+     * @generated from field: string state_guid = 2;
+     */
+    value: string;
+    case: "stateGuid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Output whose current schema is matched (exact schema text)
+   *
+   * @generated from field: string output_key = 3;
+   */
+  outputKey: string;
+};
+
+/**
+ * Describes the message state.v1.RevalidateOutputsForSchemaRequest.
+ * Use `create(RevalidateOutputsForSchemaRequestSchema)` to create a new message.
+ */
+export const RevalidateOutputsForSchemaRequestSchema: GenMessage<RevalidateOutputsForSchemaRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 117);
+
+/**
+ * OutputValidationTransition is an output whose validation status changed.
+ *
+ * @generated from message state.v1.OutputValidationTransition
+ */
+export type OutputValidationTransition = Message<"state.v1.OutputValidationTransition"> & {
+  /**
+   * @generated from field: string state_guid = 1;
+   */
+  stateGuid: string;
+
+  /**
+   * @generated from field: string state_logic_id = 2;
+   */
+  stateLogicId: string;
+
+  /**
+   * @generated from field: string output_key = 3;
+   */
+  outputKey: string;
+
+  /**
+   * Empty if the output had not been validated
+   *
+   * @generated from field: string previous_status = 4;
+   */
+  previousStatus: string;
+
+  /**
+   * @generated from field: string status = 5;
+   */
+  status: string;
+};
+
+/**
+ * Describes the message state.v1.OutputValidationTransition.
+ * Use `create(OutputValidationTransitionSchema)` to create a new message.
+ */
+export const OutputValidationTransitionSchema: GenMessage<OutputValidationTransition> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 118);
+
+/**
+ * @generated from message state.v1.RevalidateOutputsForSchemaResponse
+ */
+export type RevalidateOutputsForSchemaResponse = Message<"state.v1.RevalidateOutputsForSchemaResponse"> & {
+  /**
+   * Outputs with a value that were validated again
+   *
+   * @generated from field: int32 revalidated = 1;
+   */
+  revalidated: number;
+
+  /**
+   * Outputs that moved from valid to invalid, and from invalid to valid
+   *
+   * @generated from field: int32 became_invalid = 2;
+   */
+  becameInvalid: number;
+
+  /**
+   * @generated from field: int32 became_valid = 3;
+   */
+  becameValid: number;
+
+  /**
+   * Status changes in states visible to the caller, by state then output key
+   *
+   * @generated from field: repeated state.v1.OutputValidationTransition transitions = 4;
+   */
+  transitions: OutputValidationTransition[];
+};
+
+/**
+ * Describes the message state.v1.RevalidateOutputsForSchemaResponse.
+ * Use `create(RevalidateOutputsForSchemaResponseSchema)` to create a new message.
+ */
+export const RevalidateOutputsForSchemaResponseSchema: GenMessage<RevalidateOutputsForSchemaResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 119);
+
+/**
+ * GetOutputSchemaRequest retrieves the JSON Schema for a specific state output.
+ *
+ * @generated from message state.v1.GetOutputSchemaRequest
+ */
+export type GetOutputSchemaRequest = Message<"state.v1.GetOutputSchemaRequest"> & {
+  /**
+   * State identifier (logic_id or GUID)
+   *
+   * @generated from oneof state.v1.GetOutputSchemaRequest.state
+   */
+  state: {
+    /**
+     * @generated from field: string state_logic_id = 1;
+     */
+    value: string;
+    case: "stateLogicId";
+  } | {
+    /**
+     * @generated from field: string state_guid = 2;
+     */
+    value: string;
+    case: "stateGuid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Name of the output to get schema for
+   *
+   * @generated from field: string output_key = 3;
+   */
+  outputKey: string;
+};
+
+/**
+ * Describes the message state.v1.GetOutputSchemaRequest.
+ * Use `create(GetOutputSchemaRequestSchema)` to create a new message.
+ */
+export const GetOutputSchemaRequestSchema: GenMessage<GetOutputSchemaRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 120);
+
+/**
+ * GetOutputSchemaResponse returns the JSON Schema for an output.
+ *
+ * @generated from message state.v1.GetOutputSchemaResponse
+ */
+export type GetOutputSchemaResponse = Message<"state.v1.GetOutputSchemaResponse"> & {
+  /**
+   * State identifiers
+   *
+   * @generated from field: string state_guid = 1;
+   */
+  stateGuid: string;
+
+  /**
+   * @generated from field: string state_logic_id = 2;
+   */
+  stateLogicId: string;
+
+  /**
+   * Output name
+   *
+   * @generated from field: string output_key = 3;
+   */
+  outputKey: string;
+
+  /**
+   * JSON Schema definition (empty string if no schema has been set)
+   *
+   * @generated from field: string schema_json = 4;
+   */
+  schemaJson: string;
+
+  /**
+   * Schema registry key the schema was set from (empty for inline schemas)
+   *
+   * @generated from field: string schema_ref = 5;
+   */
+  schemaRef: string;
+
+  /**
+   * Version of this schema in the output's schema history (0 if no schema)
+   *
+   * @generated from field: int32 schema_version = 6;
+   */
+  schemaVersion: number;
+};
+
+/**
+ * Describes the message state.v1.GetOutputSchemaResponse.
+ * Use `create(GetOutputSchemaResponseSchema)` to create a new message.
+ */
+export const GetOutputSchemaResponseSchema: GenMessage<GetOutputSchemaResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 121);
+
+/**
+ * ListOutputSchemaHistoryRequest lists the schema versions of a state output.
+ *
+ * @generated from message state.v1.ListOutputSchemaHistoryRequest
+ */
+export type ListOutputSchemaHistoryRequest = Message<"state.v1.ListOutputSchemaHistoryRequest"> & {
+  /**
+   * State identifier (logic_id or GUID)
+   *
+   * @generated from oneof state.v1.ListOutputSchemaHistoryRequest.state
+   */
+  state: {
+    /**
+     * @generated from field: string state_logic_id = 1;
+     */
+    value: string;
+    case: "stateLogicId";
+  } | {
+    /**
+     * @generated from field: string state_guid = 2;
+     */
+    value: string;
+    case: "stateGuid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * @generated from field: string output_key = 3;
+   */
+  outputKey: string;
+};
+
+/**
+ * Describes the message state.v1.ListOutputSchemaHistoryRequest.
+ * Use `create(ListOutputSchemaHistoryRequestSchema)` to create a new message.
+ */
+export const ListOutputSchemaHistoryRequestSchema: GenMessage<ListOutputSchemaHistoryRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 122);
+
+/**
+ * OutputSchemaVersion is one schema a state output has had. Every schema
+ * change (manual, inferred or via the schema registry) adds a version.
+ *
+ * @generated from message state.v1.OutputSchemaVersion
+ */
+export type OutputSchemaVersion = Message<"state.v1.OutputSchemaVersion"> & {
+  /**
+   * @generated from field: int32 version = 1;
+   */
+  version: number;
+
+  /**
+   * @generated from field: string schema_json = 2;
+   */
+  schemaJson: string;
+
+  /**
+   * "manual" or "inferred"
+   *
+   * @generated from field: string schema_source = 3;
+   */
+  schemaSource: string;
+
+  /**
+   * Schema registry key, empty for inline schemas
+   *
+   * @generated from field: string schema_ref = 4;
+   */
+  schemaRef: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 5;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * True for the version currently set on the output
+   *
+   * @generated from field: bool active = 6;
+   */
+  active: boolean;
+};
+
+/**
+ * Describes the message state.v1.OutputSchemaVersion.
+ * Use `create(OutputSchemaVersionSchema)` to create a new message.
+ */
+export const OutputSchemaVersionSchema: GenMessage<OutputSchemaVersion> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 123);
+
+/**
+ * @generated from message state.v1.ListOutputSchemaHistoryResponse
+ */
+export type ListOutputSchemaHistoryResponse = Message<"state.v1.ListOutputSchemaHistoryResponse"> & {
+  /**
+   * @generated from field: string state_guid = 1;
+   */
+  stateGuid: string;
+
+  /**
+   * @generated from field: string state_logic_id = 2;
+   */
+  stateLogicId: string;
+
+  /**
+   * @generated from field: string output_key = 3;
+   */
+  outputKey: string;
+
+  /**
+   * Versions oldest first
+   *
+   * @generated from field: repeated state.v1.OutputSchemaVersion versions = 4;
+   */
+  versions: OutputSchemaVersion[];
+
+  /**
+   * Schema version the output's last validation ran against, with its result
+   *
+   * @generated from field: optional int32 validated_schema_version = 5;
+   */
+  validatedSchemaVersion?: number;
+
+  /**
+   * @generated from field: optional string validation_status = 6;
+   */
+  validationStatus?: string;
+};
+
+/**
+ * Describes the message state.v1.ListOutputSchemaHistoryResponse.
+ * Use `create(ListOutputSchemaHistoryResponseSchema)` to create a new message.
+ */
+export const ListOutputSchemaHistoryResponseSchema: GenMessage<ListOutputSchemaHistoryResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 124);
+
+/**
+ * RegisteredSchema is a JSON Schema shared across outputs under a named version.
+ *
+ * @generated from message state.v1.RegisteredSchema
+ */
+export type RegisteredSchema = Message<"state.v1.RegisteredSchema"> & {
+  /**
+   * Registry key: <name>@<version>, name optionally namespaced with slashes
+   *
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * @generated from field: string schema_json = 2;
+   */
+  schemaJson: string;
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 5;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message state.v1.RegisteredSchema.
+ * Use `create(RegisteredSchemaSchema)` to create a new message.
+ */
+export const RegisteredSchemaSchema: GenMessage<RegisteredSchema> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 125);
+
+/**
+ * CreateRegisteredSchemaRequest registers a new schema.
+ *
+ * @generated from message state.v1.CreateRegisteredSchemaRequest
+ */
+export type CreateRegisteredSchemaRequest = Message<"state.v1.CreateRegisteredSchemaRequest"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * must be valid JSON Schema
+   *
+   * @generated from field: string schema_json = 2;
+   */
+  schemaJson: string;
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description: string;
+};
+
+/**
+ * Describes the message state.v1.CreateRegisteredSchemaRequest.
+ * Use `create(CreateRegisteredSchemaRequestSchema)` to create a new message.
+ */
+export const CreateRegisteredSchemaRequestSchema: GenMessage<CreateRegisteredSchemaRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 126);
+
+/**
+ * @generated from message state.v1.CreateRegisteredSchemaResponse
+ */
+export type CreateRegisteredSchemaResponse = Message<"state.v1.CreateRegisteredSchemaResponse"> & {
+  /**
+   * @generated from field: state.v1.RegisteredSchema schema = 1;
+   */
+  schema?: RegisteredSchema;
+};
+
+/**
+ * Describes the message state.v1.CreateRegisteredSchemaResponse.
+ * Use `create(CreateRegisteredSchemaResponseSchema)` to create a new message.
+ */
+export const CreateRegisteredSchemaResponseSchema: GenMessage<CreateRegisteredSchemaResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 127);
+
+/**
+ * @generated from message state.v1.GetRegisteredSchemaRequest
+ */
+export type GetRegisteredSchemaRequest = Message<"state.v1.GetRegisteredSchemaRequest"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+};
+
+/**
+ * Describes the message state.v1.GetRegisteredSchemaRequest.
+ * Use `create(GetRegisteredSchemaRequestSchema)` to create a new message.
+ */
+export const GetRegisteredSchemaRequestSchema: GenMessage<GetRegisteredSchemaRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 128);
+
+/**
+ * @generated from message state.v1.GetRegisteredSchemaResponse
+ */
+export type GetRegisteredSchemaResponse = Message<"state.v1.GetRegisteredSchemaResponse"> & {
+  /**
+   * @generated from field: state.v1.RegisteredSchema schema = 1;
+   */
+  schema?: RegisteredSchema;
+};
+
+/**
+ * Describes the message state.v1.GetRegisteredSchemaResponse.
+ * Use `create(GetRegisteredSchemaResponseSchema)` to create a new message.
+ */
+export const GetRegisteredSchemaResponseSchema: GenMessage<GetRegisteredSchemaResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 129);
+
+/**
+ * @generated from message state.v1.ListRegisteredSchemasRequest
+ */
+export type ListRegisteredSchemasRequest = Message<"state.v1.ListRegisteredSchemasRequest"> & {
+};
+
+/**
+ * Describes the message state.v1.ListRegisteredSchemasRequest.
+ * Use `create(ListRegisteredSchemasRequestSchema)` to create a new message.
+ */
+export const ListRegisteredSchemasRequestSchema: GenMessage<ListRegisteredSchemasRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 130);
+
+/**
+ * @generated from message state.v1.ListRegisteredSchemasResponse
+ */
+export type ListRegisteredSchemasResponse = Message<"state.v1.ListRegisteredSchemasResponse"> & {
+  /**
+   * ordered by key
+   *
+   * @generated from field: repeated state.v1.RegisteredSchema schemas = 1;
+   */
+  schemas: RegisteredSchema[];
+};
+
+/**
+ * Describes the message state.v1.ListRegisteredSchemasResponse.
+ * Use `create(ListRegisteredSchemasResponseSchema)` to create a new message.
+ */
+export const ListRegisteredSchemasResponseSchema: GenMessage<ListRegisteredSchemasResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 131);
+
+/**
+ * UpdateRegisteredSchemaRequest replaces the schema registered under key.
+ *
+ * @generated from message state.v1.UpdateRegisteredSchemaRequest
+ */
+export type UpdateRegisteredSchemaRequest = Message<"state.v1.UpdateRegisteredSchemaRequest"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * must be valid JSON Schema
+   *
+   * @generated from field: string schema_json = 2;
+   */
+  schemaJson: string;
+
+  /**
+   * New description; the existing one is kept when unset
+   *
+   * @generated from field: optional string description = 3;
+   */
+  description?: string;
+
+  /**
+   * Re-validate every output using the new schema before responding
+   *
+   * @generated from field: bool revalidate = 4;
+   */
+  revalidate: boolean;
+};
+
+/**
+ * Describes the message state.v1.UpdateRegisteredSchemaRequest.
+ * Use `create(UpdateRegisteredSchemaRequestSchema)` to create a new message.
+ */
+export const UpdateRegisteredSchemaRequestSchema: GenMessage<UpdateRegisteredSchemaRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 132);
 
 /**
- * @generated from message state.v1.ListSessionsResponse
+ * @generated from message state.v1.UpdateRegisteredSchemaResponse
  */
-export type ListSessionsResponse = Message<"state.v1.ListSessionsResponse"> & {
+export type UpdateRegisteredSchemaResponse = Message<"state.v1.UpdateRegisteredSchemaResponse"> & {
   /**
-   * @generated from field: repeated state.v1.SessionInfo sessions = 1;
+   * @generated from field: state.v1.RegisteredSchema schema = 1;
    */
-  sessions: SessionInfo[];
+  schema?: RegisteredSchema;
+
+  /**
+   * Re-validation results, set only when revalidate was requested
+   *
+   * @generated from field: state.v1.RevalidateOutputsForSchemaResponse revalidation = 2;
+   */
+  revalidation?: RevalidateOutputsForSchemaResponse;
 };
 
 /**
- * Describes the message state.v1.ListSessionsResponse.
- * Use `create(ListSessionsResponseSchema)` to create a new message.
+ * Describes the message state.v1.UpdateRegisteredSchemaResponse.
+ * Use `create(UpdateRegisteredSchemaResponseSchema)` to create a new message.
  */
-export const ListSessionsResponseSchema: GenMessage<ListSessionsResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 89);
+export const UpdateRegisteredSchemaResponseSchema: GenMessage<UpdateRegisteredSchemaResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 133);
 
 /**
- * @generated from message state.v1.RevokeSessionRequest
+ * @generated from message state.v1.DeleteRegisteredSchemaRequest
  */
-export type RevokeSessionRequest = Message<"state.v1.RevokeSessionRequest"> & {
+export type DeleteRegisteredSchemaRequest = Message<"state.v1.DeleteRegisteredSchemaRequest"> & {
   /**
-   * @generated from field: string session_id = 1;
+   * @generated from field: string key = 1;
    */
-  sessionId: string;
+  key: string;
 };
 
 /**
- * Describes the message state.v1.RevokeSessionRequest.
- * Use `create(RevokeSessionRequestSchema)` to create a new message.
+ * Describes the message state.v1.DeleteRegisteredSchemaRequest.
+ * Use `create(DeleteRegisteredSchemaRequestSchema)` to create a new message.
  */
-export const RevokeSessionRequestSchema: GenMessage<RevokeSessionRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 90);
+export const DeleteRegisteredSchemaRequestSchema: GenMessage<DeleteRegisteredSchemaRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 134);
 
 /**
- * @generated from message state.v1.RevokeSessionResponse
+ * @generated from message state.v1.DeleteRegisteredSchemaResponse
  */
-export type RevokeSessionResponse = Message<"state.v1.RevokeSessionResponse"> & {
-  /**
-   * @generated from field: bool success = 1;
-   */
-  success: boolean;
+export type DeleteRegisteredSchemaResponse = Message<"state.v1.DeleteRegisteredSchemaResponse"> & {
 };
 
 /**
- * Describes the message state.v1.RevokeSessionResponse.
- * Use `create(RevokeSessionResponseSchema)` to create a new message.
+ * Describes the message state.v1.DeleteRegisteredSchemaResponse.
+ * Use `create(DeleteRegisteredSchemaResponseSchema)` to create a new message.
  */
-export const RevokeSessionResponseSchema: GenMessage<RevokeSessionResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 91);
+export const DeleteRegisteredSchemaResponseSchema: GenMessage<DeleteRegisteredSchemaResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 135);
 
 /**
- * SetOutputSchemaRequest publishes or updates a JSON Schema for a specific state output.
- * Allows clients to declare expected output types before the output actually exists.
+ * GetStateValidationSummaryRequest asks for aggregate validation status of a state's outputs.
  *
- * @generated from message state.v1.SetOutputSchemaRequest
+ * @generated from message state.v1.GetStateValidationSummaryRequest
  */
-export type SetOutputSchemaRequest = Message<"state.v1.SetOutputSchemaRequest"> & {
+export type GetStateValidationSummaryRequest = Message<"state.v1.GetStateValidationSummaryRequest"> & {
   /**
    * State identifier (logic_id or GUID)
    *
-   * @generated from oneof state.v1.SetOutputSchemaRequest.state
+   * @generated from oneof state.v1.GetStateValidationSummaryRequest.state
    */
   state: {
     /**
@@ -2793,145 +4698,217 @@ export type SetOutputSchemaRequest = Message<"state.v1.SetOutputSchemaRequest">
     value: string;
     case: "stateGuid";
   } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message state.v1.GetStateValidationSummaryRequest.
+ * Use `create(GetStateValidationSummaryRequestSchema)` to create a new message.
+ */
+export const GetStateValidationSummaryRequestSchema: GenMessage<GetStateValidationSummaryRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 136);
 
+/**
+ * OutputValidationIssue describes an output whose last validation did not pass.
+ *
+ * @generated from message state.v1.OutputValidationIssue
+ */
+export type OutputValidationIssue = Message<"state.v1.OutputValidationIssue"> & {
   /**
-   * Name of the output (e.g., "vpc_id", "subnet_ids")
-   *
-   * @generated from field: string output_key = 3;
+   * @generated from field: string output_key = 1;
    */
   outputKey: string;
 
   /**
-   * JSON Schema definition for this output (must be valid JSON Schema)
-   * Example: {"type": "string", "pattern": "^vpc-[a-z0-9]+$"}
+   * Validation status: "invalid" (failed validation) or "error" (validation system error)
    *
-   * @generated from field: string schema_json = 4;
+   * @generated from field: string validation_status = 2;
    */
-  schemaJson: string;
+  validationStatus: string;
+
+  /**
+   * Validation error message with JSON path information
+   *
+   * @generated from field: string validation_error = 3;
+   */
+  validationError: string;
+
+  /**
+   * Timestamp of the validation run that produced this result
+   *
+   * @generated from field: optional google.protobuf.Timestamp validated_at = 4;
+   */
+  validatedAt?: Timestamp;
 };
 
 /**
- * Describes the message state.v1.SetOutputSchemaRequest.
- * Use `create(SetOutputSchemaRequestSchema)` to create a new message.
+ * Describes the message state.v1.OutputValidationIssue.
+ * Use `create(OutputValidationIssueSchema)` to create a new message.
  */
-export const SetOutputSchemaRequestSchema: GenMessage<SetOutputSchemaRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 92);
+export const OutputValidationIssueSchema: GenMessage<OutputValidationIssue> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 137);
 
 /**
- * SetOutputSchemaResponse confirms schema publication.
+ * GetStateValidationSummaryResponse reports output validation counts for a state.
  *
- * @generated from message state.v1.SetOutputSchemaResponse
+ * @generated from message state.v1.GetStateValidationSummaryResponse
  */
-export type SetOutputSchemaResponse = Message<"state.v1.SetOutputSchemaResponse"> & {
+export type GetStateValidationSummaryResponse = Message<"state.v1.GetStateValidationSummaryResponse"> & {
   /**
-   * @generated from field: bool success = 1;
+   * State identifiers
+   *
+   * @generated from field: string state_guid = 1;
    */
-  success: boolean;
+  stateGuid: string;
 
   /**
-   * State identifiers for confirmation
+   * @generated from field: string state_logic_id = 2;
+   */
+  stateLogicId: string;
+
+  /**
+   * Output counts by validation status. Outputs without a recorded status
+   * count as not_validated.
    *
-   * @generated from field: string state_guid = 2;
+   * @generated from field: int32 total_outputs = 3;
    */
-  stateGuid: string;
+  totalOutputs: number;
 
   /**
-   * @generated from field: string state_logic_id = 3;
+   * @generated from field: int32 valid_count = 4;
    */
-  stateLogicId: string;
+  validCount: number;
 
   /**
-   * @generated from field: string output_key = 4;
+   * @generated from field: int32 invalid_count = 5;
    */
-  outputKey: string;
+  invalidCount: number;
+
+  /**
+   * @generated from field: int32 error_count = 6;
+   */
+  errorCount: number;
+
+  /**
+   * @generated from field: int32 not_validated_count = 7;
+   */
+  notValidatedCount: number;
+
+  /**
+   * Every output with status "invalid" or "error", sorted by output_key
+   *
+   * @generated from field: repeated state.v1.OutputValidationIssue issues = 8;
+   */
+  issues: OutputValidationIssue[];
+
+  /**
+   * Newest validated_at across all outputs; unset if nothing has been validated.
+   * Compare against the state's last update to detect stale results.
+   *
+   * @generated from field: optional google.protobuf.Timestamp last_validated_at = 9;
+   */
+  lastValidatedAt?: Timestamp;
 };
 
 /**
- * Describes the message state.v1.SetOutputSchemaResponse.
- * Use `create(SetOutputSchemaResponseSchema)` to create a new message.
+ * Describes the message state.v1.GetStateValidationSummaryResponse.
+ * Use `create(GetStateValidationSummaryResponseSchema)` to create a new message.
  */
-export const SetOutputSchemaResponseSchema: GenMessage<SetOutputSchemaResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 93);
+export const GetStateValidationSummaryResponseSchema: GenMessage<GetStateValidationSummaryResponse> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 138);
 
 /**
- * GetOutputSchemaRequest retrieves the JSON Schema for a specific state output.
+ * WatchStateChangesRequest selects the states and changes to watch.
  *
- * @generated from message state.v1.GetOutputSchemaRequest
+ * @generated from message state.v1.WatchStateChangesRequest
  */
-export type GetOutputSchemaRequest = Message<"state.v1.GetOutputSchemaRequest"> & {
+export type WatchStateChangesRequest = Message<"state.v1.WatchStateChangesRequest"> & {
   /**
-   * State identifier (logic_id or GUID)
+   * Restrict events to one state; unset watches every visible state
    *
-   * @generated from oneof state.v1.GetOutputSchemaRequest.state
+   * @generated from field: optional string logic_id = 1;
    */
-  state: {
-    /**
-     * @generated from field: string state_logic_id = 1;
-     */
-    value: string;
-    case: "stateLogicId";
-  } | {
-    /**
-     * @generated from field: string state_guid = 2;
-     */
-    value: string;
-    case: "stateGuid";
-  } | { case: undefined; value?: undefined };
+  logicId?: string;
 
   /**
-   * Name of the output to get schema for
+   * Change kinds to report ("serial", "labels", "outputs", "status");
+   * empty reports all of them
    *
-   * @generated from field: string output_key = 3;
+   * @generated from field: repeated string kinds = 2;
    */
-  outputKey: string;
+  kinds: string[];
 };
 
 /**
- * Describes the message state.v1.GetOutputSchemaRequest.
- * Use `create(GetOutputSchemaRequestSchema)` to create a new message.
+ * Describes the message state.v1.WatchStateChangesRequest.
+ * Use `create(WatchStateChangesRequestSchema)` to create a new message.
  */
-export const GetOutputSchemaRequestSchema: GenMessage<GetOutputSchemaRequest> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 94);
+export const WatchStateChangesRequestSchema: GenMessage<WatchStateChangesRequest> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 139);
 
 /**
- * GetOutputSchemaResponse returns the JSON Schema for an output.
+ * StateChangeEvent reports one change to a state. A single update can produce
+ * several events, e.g. a new serial together with changed outputs.
  *
- * @generated from message state.v1.GetOutputSchemaResponse
+ * @generated from message state.v1.StateChangeEvent
  */
-export type GetOutputSchemaResponse = Message<"state.v1.GetOutputSchemaResponse"> & {
+export type StateChangeEvent = Message<"state.v1.StateChangeEvent"> & {
   /**
-   * State identifiers
+   * @generated from field: string guid = 1;
+   */
+  guid: string;
+
+  /**
+   * @generated from field: string logic_id = 2;
+   */
+  logicId: string;
+
+  /**
+   * What changed: "serial", "labels", "outputs" or "status"
    *
-   * @generated from field: string state_guid = 1;
+   * @generated from field: string kind = 3;
    */
-  stateGuid: string;
+  kind: string;
 
   /**
-   * @generated from field: string state_logic_id = 2;
+   * The state's values after the change
+   *
+   * @generated from field: int64 serial = 4;
    */
-  stateLogicId: string;
+  serial: bigint;
 
   /**
-   * Output name
+   * @generated from field: map<string, state.v1.LabelValue> labels = 5;
+   */
+  labels: { [key: string]: LabelValue };
+
+  /**
+   * "clean", "stale", "potentially-stale"
    *
-   * @generated from field: string output_key = 3;
+   * @generated from field: string computed_status = 6;
    */
-  outputKey: string;
+  computedStatus: string;
 
   /**
-   * JSON Schema definition (empty string if no schema has been set)
+   * Output keys whose value changed, appeared or disappeared (kind "outputs")
    *
-   * @generated from field: string schema_json = 4;
+   * @generated from field: repeated string changed_outputs = 7;
    */
-  schemaJson: string;
+  changedOutputs: string[];
+
+  /**
+   * When the change was detected
+   *
+   * @generated from field: google.protobuf.Timestamp detected_at = 8;
+   */
+  detectedAt?: Timestamp;
 };
 
 /**
- * Describes the message state.v1.GetOutputSchemaResponse.
- * Use `create(GetOutputSchemaResponseSchema)` to create a new message.
+ * Describes the message state.v1.StateChangeEvent.
+ * Use `create(StateChangeEventSchema)` to create a new message.
  */
-export const GetOutputSchemaResponseSchema: GenMessage<GetOutputSchemaResponse> = /*@__PURE__*/
-  messageDesc(file_state_v1_state, 95);
+export const StateChangeEventSchema: GenMessage<StateChangeEvent> = /*@__PURE__*/
+  messageDesc(file_state_v1_state, 140);
 
 /**
  * StateService provides remote state management for Terraform/OpenTofu clients.
@@ -3032,6 +5009,8 @@ export const StateService: GenService<{
   },
   /**
    * SearchByOutput finds all edges that reference a specific output key (by name).
+   * Only edges whose producer and consumer are both within the caller's role
+   * scopes are returned.
    *
    * @generated from rpc state.v1.StateService.SearchByOutput
    */
@@ -3042,6 +5021,8 @@ export const StateService: GenService<{
   },
   /**
    * GetTopologicalOrder computes layered ordering of states rooted at given state.
+   * The root must be within the caller's role scopes; states outside them are
+   * omitted from the layers.
    *
    * @generated from rpc state.v1.StateService.GetTopologicalOrder
    */
@@ -3052,6 +5033,8 @@ export const StateService: GenService<{
   },
   /**
    * GetStateStatus computes on-demand status for a state based on its incoming edges.
+   * The state must be within the caller's role scopes; incoming edges and summary
+   * counts only cover producers within them.
    *
    * @generated from rpc state.v1.StateService.GetStateStatus
    */
@@ -3070,6 +5053,17 @@ export const StateService: GenService<{
     input: typeof GetDependencyGraphRequestSchema;
     output: typeof GetDependencyGraphResponseSchema;
   },
+  /**
+   * RecomputeDependencyStatus re-derives the statuses of a producer's outgoing
+   * edges from its current outputs and persists them, e.g. from CI after an apply.
+   *
+   * @generated from rpc state.v1.StateService.RecomputeDependencyStatus
+   */
+  recomputeDependencyStatus: {
+    methodKind: "unary";
+    input: typeof RecomputeDependencyStatusRequestSchema;
+    output: typeof RecomputeDependencyStatusResponseSchema;
+  },
   /**
    * ListStateOutputs returns available output keys from a state's Terraform/OpenTofu JSON.
    * Output values are NOT returned (security/size concerns); only keys and sensitive flags.
@@ -3082,6 +5076,31 @@ export const StateService: GenService<{
     input: typeof ListStateOutputsRequestSchema;
     output: typeof ListStateOutputsResponseSchema;
   },
+  /**
+   * ListStateOutputsBatch returns outputs for several states in one call, e.g. to
+   * compare vpc_id across every env=dev state. States outside the caller's role
+   * scopes, and states that do not exist, are omitted rather than failing the call.
+   * Values are returned only when include_values is set, and never for sensitive outputs.
+   *
+   * @generated from rpc state.v1.StateService.ListStateOutputsBatch
+   */
+  listStateOutputsBatch: {
+    methodKind: "unary";
+    input: typeof ListStateOutputsBatchRequestSchema;
+    output: typeof ListStateOutputsBatchResponseSchema;
+  },
+  /**
+   * GetStateOutputValues returns the values of selected outputs of one state without
+   * exposing the raw tfstate. Sensitive values are redacted unless the caller holds
+   * state-output:read-sensitive on the state.
+   *
+   * @generated from rpc state.v1.StateService.GetStateOutputValues
+   */
+  getStateOutputValues: {
+    methodKind: "unary";
+    input: typeof GetStateOutputValuesRequestSchema;
+    output: typeof GetStateOutputValuesResponseSchema;
+  },
   /**
    * GetStateInfo retrieves comprehensive state information including:
    * - Basic metadata (GUID, logic-id, timestamps)
@@ -3111,6 +5130,20 @@ export const StateService: GenService<{
     input: typeof ListAllEdgesRequestSchema;
     output: typeof ListAllEdgesResponseSchema;
   },
+  /**
+   * WatchStateChanges streams an event whenever a state's serial, labels,
+   * outputs or computed dependency status changes. Only states whose current
+   * labels match the caller's role scopes are reported. Changes are detected
+   * by polling, so events arrive up to one poll interval late; a client that
+   * falls behind is disconnected and should reconnect.
+   *
+   * @generated from rpc state.v1.StateService.WatchStateChanges
+   */
+  watchStateChanges: {
+    methodKind: "server_streaming";
+    input: typeof WatchStateChangesRequestSchema;
+    output: typeof StateChangeEventSchema;
+  },
   /**
    * UpdateStateLabels mutates labels for an existing state (add/replace/remove).
    *
@@ -3121,6 +5154,18 @@ export const StateService: GenService<{
     input: typeof UpdateStateLabelsRequestSchema;
     output: typeof UpdateStateLabelsResponseSchema;
   },
+  /**
+   * TransferStateOwnership records a new owner (created_by) for a state, moving
+   * role owner-override access to them. Allowed for the current owner or with
+   * state:transfer-ownership.
+   *
+   * @generated from rpc state.v1.StateService.TransferStateOwnership
+   */
+  transferStateOwnership: {
+    methodKind: "unary";
+    input: typeof TransferStateOwnershipRequestSchema;
+    output: typeof TransferStateOwnershipResponseSchema;
+  },
   /**
    * GetLabelPolicy retrieves the current label validation policy.
    *
@@ -3289,6 +5334,47 @@ export const StateService: GenService<{
     input: typeof RevokeSessionRequestSchema;
     output: typeof RevokeSessionResponseSchema;
   },
+  /**
+   * ListAllSessions lists sessions across all users (admin only)
+   *
+   * @generated from rpc state.v1.StateService.ListAllSessions
+   */
+  listAllSessions: {
+    methodKind: "unary";
+    input: typeof ListAllSessionsRequestSchema;
+    output: typeof ListAllSessionsResponseSchema;
+  },
+  /**
+   * RevokeSessions revokes every session matching a filter (admin only, incident response)
+   *
+   * @generated from rpc state.v1.StateService.RevokeSessions
+   */
+  revokeSessions: {
+    methodKind: "unary";
+    input: typeof RevokeSessionsRequestSchema;
+    output: typeof RevokeSessionsResponseSchema;
+  },
+  /**
+   * Token Introspection (admin/debug, RFC 7662 style)
+   *
+   * @generated from rpc state.v1.StateService.IntrospectToken
+   */
+  introspectToken: {
+    methodKind: "unary";
+    input: typeof IntrospectTokenRequestSchema;
+    output: typeof IntrospectTokenResponseSchema;
+  },
+  /**
+   * Authorization dry run (admin/debug): would a principal with these roles or
+   * groups be allowed the action? No user needs to exist or be logged in.
+   *
+   * @generated from rpc state.v1.StateService.PreviewAuthorization
+   */
+  previewAuthorization: {
+    methodKind: "unary";
+    input: typeof PreviewAuthorizationRequestSchema;
+    output: typeof PreviewAuthorizationResponseSchema;
+  },
   /**
    * SetOutputSchema publishes or updates a JSON Schema for a specific state output.
    * This allows clients to declare expected output types before the output exists.
@@ -3300,6 +5386,80 @@ export const StateService: GenService<{
     input: typeof SetOutputSchemaRequestSchema;
     output: typeof SetOutputSchemaResponseSchema;
   },
+  /**
+   * SetOutputSchemas publishes or updates JSON Schemas for several outputs of a
+   * state at once. All schemas are validated up front and written atomically.
+   *
+   * @generated from rpc state.v1.StateService.SetOutputSchemas
+   */
+  setOutputSchemas: {
+    methodKind: "unary";
+    input: typeof SetOutputSchemasRequestSchema;
+    output: typeof SetOutputSchemasResponseSchema;
+  },
+  /**
+   * RevalidateOutputsForSchema re-validates every output, across all states,
+   * whose schema matches the given output's schema, and reports which outputs
+   * moved between valid and invalid. Use after changing a shared schema.
+   *
+   * @generated from rpc state.v1.StateService.RevalidateOutputsForSchema
+   */
+  revalidateOutputsForSchema: {
+    methodKind: "unary";
+    input: typeof RevalidateOutputsForSchemaRequestSchema;
+    output: typeof RevalidateOutputsForSchemaResponseSchema;
+  },
+  /**
+   * CreateRegisteredSchema registers a new schema under a key.
+   *
+   * @generated from rpc state.v1.StateService.CreateRegisteredSchema
+   */
+  createRegisteredSchema: {
+    methodKind: "unary";
+    input: typeof CreateRegisteredSchemaRequestSchema;
+    output: typeof CreateRegisteredSchemaResponseSchema;
+  },
+  /**
+   * GetRegisteredSchema retrieves a registered schema by key.
+   *
+   * @generated from rpc state.v1.StateService.GetRegisteredSchema
+   */
+  getRegisteredSchema: {
+    methodKind: "unary";
+    input: typeof GetRegisteredSchemaRequestSchema;
+    output: typeof GetRegisteredSchemaResponseSchema;
+  },
+  /**
+   * ListRegisteredSchemas lists all registered schemas.
+   *
+   * @generated from rpc state.v1.StateService.ListRegisteredSchemas
+   */
+  listRegisteredSchemas: {
+    methodKind: "unary";
+    input: typeof ListRegisteredSchemasRequestSchema;
+    output: typeof ListRegisteredSchemasResponseSchema;
+  },
+  /**
+   * UpdateRegisteredSchema replaces a registered schema. Every output that
+   * references the key picks up the new schema, and can be re-validated.
+   *
+   * @generated from rpc state.v1.StateService.UpdateRegisteredSchema
+   */
+  updateRegisteredSchema: {
+    methodKind: "unary";
+    input: typeof UpdateRegisteredSchemaRequestSchema;
+    output: typeof UpdateRegisteredSchemaResponseSchema;
+  },
+  /**
+   * DeleteRegisteredSchema removes a registered schema that no output references.
+   *
+   * @generated from rpc state.v1.StateService.DeleteRegisteredSchema
+   */
+  deleteRegisteredSchema: {
+    methodKind: "unary";
+    input: typeof DeleteRegisteredSchemaRequestSchema;
+    output: typeof DeleteRegisteredSchemaResponseSchema;
+  },
   /**
    * GetOutputSchema retrieves the JSON Schema for a specific state output.
    *
@@ -3310,6 +5470,28 @@ export const StateService: GenService<{
     input: typeof GetOutputSchemaRequestSchema;
     output: typeof GetOutputSchemaResponseSchema;
   },
+  /**
+   * ListOutputSchemaHistory lists every schema a state output has had, with the
+   * version each validation result refers to.
+   *
+   * @generated from rpc state.v1.StateService.ListOutputSchemaHistory
+   */
+  listOutputSchemaHistory: {
+    methodKind: "unary";
+    input: typeof ListOutputSchemaHistoryRequestSchema;
+    output: typeof ListOutputSchemaHistoryResponseSchema;
+  },
+  /**
+   * GetStateValidationSummary aggregates the stored validation results of a
+   * state's outputs. It reports existing results and never re-runs validation.
+   *
+   * @generated from rpc state.v1.StateService.GetStateValidationSummary
+   */
+  getStateValidationSummary: {
+    methodKind: "unary";
+    input: typeof GetStateValidationSummaryRequestSchema;
+    output: typeof GetStateValidationSummaryResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_state_v1_state, 0);
 
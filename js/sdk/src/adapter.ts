@@ -265,12 +265,18 @@ export class GridApiAdapter {
    * Get comprehensive information about a specific state.
    *
    * @param logicId - The state's logic ID
+   * @param options - includeComputedStatus (default: true) computes computed_status
    * @returns StateInfo or null if not found
    */
-  async getStateInfo(logicId: string): Promise<StateInfo | null> {
+  async getStateInfo(
+    logicId: string,
+    options?: { includeComputedStatus?: boolean },
+  ): Promise<StateInfo | null> {
+    const { includeComputedStatus = true } = options ?? {};
     try {
       const response = await this.client.getStateInfo({
         state: { case: 'logicId', value: logicId },
+        includeComputedStatus,
       });
       return convertProtoStateInfo(response);
     } catch (error) {
@@ -11,6 +11,7 @@
 
 import type {
   AuthConfig,
+  ChangePasswordRequest,
   LoginCredentials,
   LoginResponse,
   WhoamiResponse,
@@ -113,6 +114,9 @@ export async function fetchAuthConfig(): Promise<AuthConfig> {
  *
  * @param credentials User credentials (username and password)
  * @returns Login response with authenticated user and session expiration time
+ * @throws PasswordChangeRequiredError if an admin reset the password; retry
+ *   with `newPassword` set
+ * @throws PasswordPolicyError if `newPassword` violates the password policy
  * @throws Error if authentication fails
  *
  * @example
@@ -134,11 +138,19 @@ export async function loginInternal(
     body: JSON.stringify({
       username: credentials.username,
       password: credentials.password,
+      new_password: credentials.newPassword,
     }),
   });
 
   if (!response.ok) {
     const errorText = await response.text();
+    if (response.status === 403 && parseErrorCode(errorText) === 'password_change_required') {
+      throw new PasswordChangeRequiredError();
+    }
+    if (response.status === 400 && parseErrorCode(errorText) !== undefined) {
+      const data = JSON.parse(errorText);
+      throw new PasswordPolicyError(data.error, data.requirement);
+    }
     throw new Error(`Login failed: ${response.status} ${errorText}`);
   }
 
@@ -196,6 +208,44 @@ export async function loginExternal(): Promise<void> {
   window.location.href = `${API_BASE_URL}/auth/sso/login?redirect_uri=${redirectUri}`;
 }
 
+/**
+ * Thrown by fetchWhoami when the server reports that the session expired
+ * recently, as opposed to the user never having logged in.
+ */
+export class SessionExpiredError extends Error {
+  constructor() {
+    super('Your session expired, please log in again');
+    this.name = 'SessionExpiredError';
+  }
+}
+
+/**
+ * Thrown by loginInternal when the account's password was reset by an admin.
+ * Retry the login with `newPassword` set to complete it.
+ */
+export class PasswordChangeRequiredError extends Error {
+  constructor() {
+    super('Your password was reset, choose a new password to continue');
+    this.name = 'PasswordChangeRequiredError';
+  }
+}
+
+function parseErrorCode(text: string): string | undefined {
+  try {
+    return JSON.parse(text)?.error;
+  } catch {
+    return undefined;
+  }
+}
+
+function isSessionExpiredBody(text: string): boolean {
+  try {
+    return JSON.parse(text)?.error === 'session_expired';
+  } catch {
+    return false;
+  }
+}
+
 /**
  * Restore user session from httpOnly cookie
  *
@@ -206,6 +256,7 @@ export async function loginExternal(): Promise<void> {
  * This is called on app load to restore the user session if one exists.
  *
  * @returns Session information with authenticated user and expiration time
+ * @throws SessionExpiredError if the session expired recently (prompt to log in again)
  * @throws Error if the request fails or user is not authenticated
  *
  * @example
@@ -230,6 +281,9 @@ export async function fetchWhoami(): Promise<WhoamiResponse> {
 
   if (!response.ok) {
     const errorText = await response.text();
+    if (response.status === 401 && isSessionExpiredBody(errorText)) {
+      throw new SessionExpiredError();
+    }
     throw new Error(`Fetch whoami failed: ${response.status} ${errorText}`);
   }
 
@@ -239,6 +293,7 @@ export async function fetchWhoami(): Promise<WhoamiResponse> {
       id: data.user.id,
       username: data.user.username,
       email: data.user.email,
+      displayName: data.user.display_name,
       authType: data.user.auth_type, // Map snake_case to camelCase
       roles: data.user.roles || [],
       groups: data.user.groups,
@@ -283,3 +338,47 @@ export async function logout(): Promise<void> {
     throw new Error(`Logout failed: ${response.status} ${errorText}`);
   }
 }
+
+/**
+ * Thrown by changePassword when the new password does not satisfy the
+ * server's password policy. `requirement` names the unmet rule
+ * (e.g. "min_length", "digit", "not_breached").
+ */
+export class PasswordPolicyError extends Error {
+  constructor(message: string, public readonly requirement: string) {
+    super(message);
+    this.name = 'PasswordPolicyError';
+  }
+}
+
+/**
+ * Change the signed-in user's password (internal IdP mode only)
+ *
+ * Makes a POST request to /auth/password. The current session stays active
+ * even when `revokeOtherSessions` is set.
+ *
+ * @param request - Current and new password
+ * @throws PasswordPolicyError if the new password violates the policy
+ * @throws Error if the current password is wrong or the request fails
+ */
+export async function changePassword(request: ChangePasswordRequest): Promise<void> {
+  const response = await fetch(`${API_BASE_URL}/auth/password`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    credentials: 'include', // Include httpOnly cookies
+    body: JSON.stringify({
+      old_password: request.oldPassword,
+      new_password: request.newPassword,
+      revoke_other_sessions: request.revokeOtherSessions ?? false,
+    }),
+  });
+
+  if (!response.ok) {
+    const errorText = await response.text();
+    if (response.status === 400 && response.headers.get('Content-Type')?.includes('application/json')) {
+      const data = JSON.parse(errorText);
+      throw new PasswordPolicyError(data.error, data.requirement);
+    }
+    throw new Error(`Change password failed: ${response.status} ${errorText}`);
+  }
+}
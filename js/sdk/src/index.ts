@@ -71,7 +71,11 @@ export {
   loginExternal,
   fetchWhoami,
   logout,
+  changePassword,
   setApiBaseUrl,
+  SessionExpiredError,
+  PasswordPolicyError,
+  PasswordChangeRequiredError,
 } from './auth.js';
 export type {
   User,
@@ -79,6 +83,7 @@ export type {
   LoginResponse,
   WhoamiResponse,
   AuthConfig,
+  ChangePasswordRequest,
 } from './types/auth.js';
 
 // ===== Low-level Connect RPC API =====
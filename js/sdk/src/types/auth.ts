@@ -20,6 +20,9 @@ export interface User {
   /** User email */
   email: string;
 
+  /** Name, email or subject, whichever is set first (whoami only) */
+  displayName?: string;
+
   /** Authentication mode */
   authType: 'internal' | 'external';
 
@@ -39,6 +42,23 @@ export interface LoginCredentials {
 
   /** User password */
   password: string;
+
+  /** Replacement password, required after an admin password reset */
+  newPassword?: string;
+}
+
+/**
+ * Request body for changing the signed-in user's password (internal IdP only)
+ */
+export interface ChangePasswordRequest {
+  /** Current password */
+  oldPassword: string;
+
+  /** Replacement password, checked against the server's password policy */
+  newPassword: string;
+
+  /** Sign out every other session of the user */
+  revokeOtherSessions?: boolean;
 }
 
 /**
@@ -24,12 +24,17 @@ const (
 
 // CreateStateRequest creates a new state using a client-generated GUID.
 type CreateStateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Guid          string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
-	LogicId       string                 `protobuf:"bytes,2,opt,name=logic_id,json=logicId,proto3" json:"logic_id,omitempty"`
-	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Guid    string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	LogicId string                 `protobuf:"bytes,2,opt,name=logic_id,json=logicId,proto3" json:"logic_id,omitempty"`
+	Labels  map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional key making retries safe. A repeated call with the same key,
+	// logic_id and labels returns the state created by the first call (its guid
+	// may differ from this request's). Reusing a key with a different logic_id
+	// or labels fails with INVALID_ARGUMENT. Keys expire after 24h by default.
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateStateRequest) Reset() {
@@ -83,6 +88,13 @@ func (x *CreateStateRequest) GetLabels() map[string]string {
 	return nil
 }
 
+func (x *CreateStateRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 // CreateStateResponse confirms creation and returns backend config.
 type CreateStateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -155,6 +167,15 @@ type ListStatesRequest struct {
 	// WARNING: This requires computing status for EVERY state which can be expensive (N+1 pattern).
 	// Set to false if you don't need real-time status to improve performance significantly.
 	IncludeStatus *bool `protobuf:"varint,3,opt,name=include_status,json=includeStatus,proto3,oneof" json:"include_status,omitempty"`
+	// Max states per page (max 1000). Unset or 0 returns every state in one
+	// response, as before pagination existed.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor from a previous response's next_page_token
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Optional Kubernetes-style label selector evaluated in the database query,
+	// e.g. "env=dev,team in (platform,data),!deprecated". Selector queries are
+	// always paginated: without page_size, at most 1000 states are returned.
+	LabelSelector string `protobuf:"bytes,6,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -210,10 +231,34 @@ func (x *ListStatesRequest) GetIncludeStatus() bool {
 	return false
 }
 
+func (x *ListStatesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStatesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListStatesRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
 // ListStatesResponse returns all states with basic info.
 type ListStatesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	States        []*StateInfo           `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	States []*StateInfo           `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	// Cursor for the next page; empty when there are no more states.
+	// Pages are ordered by creation time, so states created mid-iteration are not skipped.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -255,6 +300,13 @@ func (x *ListStatesResponse) GetStates() []*StateInfo {
 	return nil
 }
 
+func (x *ListStatesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // StateInfo is summary information for a state.
 type StateInfo struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
@@ -272,9 +324,10 @@ type StateInfo struct {
 	// Relationship counts for efficient list rendering (eliminates N+1 pattern in frontend)
 	// These counts are populated from database relationships without fetching full edge/output data
 	// Using optional to ensure zero values are always serialized in JSON
-	DependenciesCount *int32 `protobuf:"varint,10,opt,name=dependencies_count,json=dependenciesCount,proto3,oneof" json:"dependencies_count,omitempty"` // Number of incoming dependency edges
-	DependentsCount   *int32 `protobuf:"varint,11,opt,name=dependents_count,json=dependentsCount,proto3,oneof" json:"dependents_count,omitempty"`       // Number of outgoing dependency edges
-	OutputsCount      *int32 `protobuf:"varint,12,opt,name=outputs_count,json=outputsCount,proto3,oneof" json:"outputs_count,omitempty"`                // Number of outputs available from this state
+	DependenciesCount *int32  `protobuf:"varint,10,opt,name=dependencies_count,json=dependenciesCount,proto3,oneof" json:"dependencies_count,omitempty"` // Number of incoming dependency edges
+	DependentsCount   *int32  `protobuf:"varint,11,opt,name=dependents_count,json=dependentsCount,proto3,oneof" json:"dependents_count,omitempty"`       // Number of outgoing dependency edges
+	OutputsCount      *int32  `protobuf:"varint,12,opt,name=outputs_count,json=outputsCount,proto3,oneof" json:"outputs_count,omitempty"`                // Number of outputs available from this state
+	CreatedBy         *string `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3,oneof" json:"created_by,omitempty"`                          // Principal that created the state (e.g. "user:alice@example.com")
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -393,6 +446,13 @@ func (x *StateInfo) GetOutputsCount() int32 {
 	return 0
 }
 
+func (x *StateInfo) GetCreatedBy() string {
+	if x != nil && x.CreatedBy != nil {
+		return *x.CreatedBy
+	}
+	return ""
+}
+
 // BackendConfig contains Terraform backend configuration URLs.
 type BackendConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -908,7 +968,9 @@ type AddDependencyRequest struct {
 	// Optional override for generated HCL local variable name
 	ToInputName *string `protobuf:"bytes,6,opt,name=to_input_name,json=toInputName,proto3,oneof" json:"to_input_name,omitempty"`
 	// Optional mock value for ahead-of-time dependency declaration
-	// (JSON-encoded value, used when producer output doesn't exist yet)
+	// (JSON-encoded value, used when producer output doesn't exist yet).
+	// Rejected with invalid_argument if the output has a schema the value
+	// does not conform to.
 	MockValueJson *string `protobuf:"bytes,7,opt,name=mock_value_json,json=mockValueJson,proto3,oneof" json:"mock_value_json,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1959,6 +2021,7 @@ type IncomingEdgeView struct {
 	OutDigest     *string                `protobuf:"bytes,7,opt,name=out_digest,json=outDigest,proto3,oneof" json:"out_digest,omitempty"`
 	LastInAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_in_at,json=lastInAt,proto3,oneof" json:"last_in_at,omitempty"`
 	LastOutAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_out_at,json=lastOutAt,proto3,oneof" json:"last_out_at,omitempty"`
+	Current       bool                   `protobuf:"varint,10,opt,name=current,proto3" json:"current,omitempty"` // True when the consumer has observed the producer's latest output (in_digest == out_digest)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2056,6 +2119,13 @@ func (x *IncomingEdgeView) GetLastOutAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *IncomingEdgeView) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
 // StatusSummary aggregates incoming edge counts.
 type StatusSummary struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -2134,7 +2204,10 @@ type GetDependencyGraphRequest struct {
 	//
 	//	*GetDependencyGraphRequest_LogicId
 	//	*GetDependencyGraphRequest_Guid
-	State         isGetDependencyGraphRequest_State `protobuf_oneof:"state"`
+	State isGetDependencyGraphRequest_State `protobuf_oneof:"state"`
+	// How many producer hops to follow from the consumer. Unset or 0 returns
+	// direct producers only; values above 32 are capped at 32.
+	MaxDepth      *int32 `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3,oneof" json:"max_depth,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2194,6 +2267,13 @@ func (x *GetDependencyGraphRequest) GetGuid() string {
 	return ""
 }
 
+func (x *GetDependencyGraphRequest) GetMaxDepth() int32 {
+	if x != nil && x.MaxDepth != nil {
+		return *x.MaxDepth
+	}
+	return 0
+}
+
 type isGetDependencyGraphRequest_State interface {
 	isGetDependencyGraphRequest_State()
 }
@@ -2211,14 +2291,22 @@ func (*GetDependencyGraphRequest_LogicId) isGetDependencyGraphRequest_State() {}
 func (*GetDependencyGraphRequest_Guid) isGetDependencyGraphRequest_State() {}
 
 // GetDependencyGraphResponse returns data needed for grid_dependencies.tf generation.
+// Producers outside the caller's role scopes are omitted, with their edges.
 type GetDependencyGraphResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ConsumerGuid    string                 `protobuf:"bytes,1,opt,name=consumer_guid,json=consumerGuid,proto3" json:"consumer_guid,omitempty"`
 	ConsumerLogicId string                 `protobuf:"bytes,2,opt,name=consumer_logic_id,json=consumerLogicId,proto3" json:"consumer_logic_id,omitempty"`
-	Producers       []*ProducerState       `protobuf:"bytes,3,rep,name=producers,proto3" json:"producers,omitempty"`
+	Producers       []*ProducerState       `protobuf:"bytes,3,rep,name=producers,proto3" json:"producers,omitempty"` // Nearest first
 	Edges           []*DependencyEdge      `protobuf:"bytes,4,rep,name=edges,proto3" json:"edges,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Depth limit that was applied
+	MaxDepth int32 `protobuf:"varint,5,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	// Set when producers exist beyond max_depth and were not returned
+	Truncated bool `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// IDs of edges closing a dependency cycle. Each state is visited once, so
+	// cycles never loop the traversal.
+	CycleEdgeIds  []int64 `protobuf:"varint,7,rep,packed,name=cycle_edge_ids,json=cycleEdgeIds,proto3" json:"cycle_edge_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetDependencyGraphResponse) Reset() {
@@ -2279,19 +2367,206 @@ func (x *GetDependencyGraphResponse) GetEdges() []*DependencyEdge {
 	return nil
 }
 
+func (x *GetDependencyGraphResponse) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *GetDependencyGraphResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *GetDependencyGraphResponse) GetCycleEdgeIds() []int64 {
+	if x != nil {
+		return x.CycleEdgeIds
+	}
+	return nil
+}
+
+// RecomputeDependencyStatusRequest identifies the producer whose outgoing
+// edges are recomputed.
+type RecomputeDependencyStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Producer state reference (logic_id or GUID)
+	//
+	// Types that are valid to be assigned to State:
+	//
+	//	*RecomputeDependencyStatusRequest_LogicId
+	//	*RecomputeDependencyStatusRequest_Guid
+	State         isRecomputeDependencyStatusRequest_State `protobuf_oneof:"state"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecomputeDependencyStatusRequest) Reset() {
+	*x = RecomputeDependencyStatusRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecomputeDependencyStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecomputeDependencyStatusRequest) ProtoMessage() {}
+
+func (x *RecomputeDependencyStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecomputeDependencyStatusRequest.ProtoReflect.Descriptor instead.
+func (*RecomputeDependencyStatusRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RecomputeDependencyStatusRequest) GetState() isRecomputeDependencyStatusRequest_State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *RecomputeDependencyStatusRequest) GetLogicId() string {
+	if x != nil {
+		if x, ok := x.State.(*RecomputeDependencyStatusRequest_LogicId); ok {
+			return x.LogicId
+		}
+	}
+	return ""
+}
+
+func (x *RecomputeDependencyStatusRequest) GetGuid() string {
+	if x != nil {
+		if x, ok := x.State.(*RecomputeDependencyStatusRequest_Guid); ok {
+			return x.Guid
+		}
+	}
+	return ""
+}
+
+type isRecomputeDependencyStatusRequest_State interface {
+	isRecomputeDependencyStatusRequest_State()
+}
+
+type RecomputeDependencyStatusRequest_LogicId struct {
+	LogicId string `protobuf:"bytes,1,opt,name=logic_id,json=logicId,proto3,oneof"`
+}
+
+type RecomputeDependencyStatusRequest_Guid struct {
+	Guid string `protobuf:"bytes,2,opt,name=guid,proto3,oneof"`
+}
+
+func (*RecomputeDependencyStatusRequest_LogicId) isRecomputeDependencyStatusRequest_State() {}
+
+func (*RecomputeDependencyStatusRequest_Guid) isRecomputeDependencyStatusRequest_State() {}
+
+// RecomputeDependencyStatusResponse reports the recomputed edges. Only edges
+// whose producer and consumer are both within the caller's role scopes are
+// recomputed; the others are left as they were and counted in skipped_edges.
+type RecomputeDependencyStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProducerGuid    string                 `protobuf:"bytes,1,opt,name=producer_guid,json=producerGuid,proto3" json:"producer_guid,omitempty"`
+	ProducerLogicId string                 `protobuf:"bytes,2,opt,name=producer_logic_id,json=producerLogicId,proto3" json:"producer_logic_id,omitempty"`
+	Edges           []*DependencyEdge      `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`                                                   // After the recompute
+	ChangedEdgeIds  []int64                `protobuf:"varint,4,rep,packed,name=changed_edge_ids,json=changedEdgeIds,proto3" json:"changed_edge_ids,omitempty"` // Edges whose status or digest changed
+	SkippedEdges    int32                  `protobuf:"varint,5,opt,name=skipped_edges,json=skippedEdges,proto3" json:"skipped_edges,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecomputeDependencyStatusResponse) Reset() {
+	*x = RecomputeDependencyStatusResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecomputeDependencyStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecomputeDependencyStatusResponse) ProtoMessage() {}
+
+func (x *RecomputeDependencyStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecomputeDependencyStatusResponse.ProtoReflect.Descriptor instead.
+func (*RecomputeDependencyStatusResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RecomputeDependencyStatusResponse) GetProducerGuid() string {
+	if x != nil {
+		return x.ProducerGuid
+	}
+	return ""
+}
+
+func (x *RecomputeDependencyStatusResponse) GetProducerLogicId() string {
+	if x != nil {
+		return x.ProducerLogicId
+	}
+	return ""
+}
+
+func (x *RecomputeDependencyStatusResponse) GetEdges() []*DependencyEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *RecomputeDependencyStatusResponse) GetChangedEdgeIds() []int64 {
+	if x != nil {
+		return x.ChangedEdgeIds
+	}
+	return nil
+}
+
+func (x *RecomputeDependencyStatusResponse) GetSkippedEdges() int32 {
+	if x != nil {
+		return x.SkippedEdges
+	}
+	return 0
+}
+
 // ProducerState represents a unique producer state in the graph.
 type ProducerState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Guid          string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
 	LogicId       string                 `protobuf:"bytes,2,opt,name=logic_id,json=logicId,proto3" json:"logic_id,omitempty"`
 	BackendConfig *BackendConfig         `protobuf:"bytes,3,opt,name=backend_config,json=backendConfig,proto3" json:"backend_config,omitempty"`
+	Depth         int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"` // Hops from the consumer; 1 for direct producers
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProducerState) Reset() {
 	*x = ProducerState{}
-	mi := &file_state_v1_state_proto_msgTypes[34]
+	mi := &file_state_v1_state_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2303,7 +2578,7 @@ func (x *ProducerState) String() string {
 func (*ProducerState) ProtoMessage() {}
 
 func (x *ProducerState) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[34]
+	mi := &file_state_v1_state_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2316,7 +2591,7 @@ func (x *ProducerState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProducerState.ProtoReflect.Descriptor instead.
 func (*ProducerState) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{34}
+	return file_state_v1_state_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ProducerState) GetGuid() string {
@@ -2340,6 +2615,13 @@ func (x *ProducerState) GetBackendConfig() *BackendConfig {
 	return nil
 }
 
+func (x *ProducerState) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
 // DependencyEdge represents a directed dependency edge.
 type DependencyEdge struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
@@ -2371,13 +2653,14 @@ type DependencyEdge struct {
 	LastOutAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=last_out_at,json=lastOutAt,proto3,oneof" json:"last_out_at,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Current       bool                   `protobuf:"varint,16,opt,name=current,proto3" json:"current,omitempty"` // True when the consumer has observed the producer's latest output (in_digest == out_digest)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DependencyEdge) Reset() {
 	*x = DependencyEdge{}
-	mi := &file_state_v1_state_proto_msgTypes[35]
+	mi := &file_state_v1_state_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2389,7 +2672,7 @@ func (x *DependencyEdge) String() string {
 func (*DependencyEdge) ProtoMessage() {}
 
 func (x *DependencyEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[35]
+	mi := &file_state_v1_state_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2402,7 +2685,7 @@ func (x *DependencyEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DependencyEdge.ProtoReflect.Descriptor instead.
 func (*DependencyEdge) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{35}
+	return file_state_v1_state_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *DependencyEdge) GetId() int64 {
@@ -2510,6 +2793,13 @@ func (x *DependencyEdge) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *DependencyEdge) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
 // OutputKey represents a single Terraform/OpenTofu output name and metadata.
 type OutputKey struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2535,14 +2825,27 @@ type OutputKey struct {
 	ValidationError *string `protobuf:"bytes,6,opt,name=validation_error,json=validationError,proto3,oneof" json:"validation_error,omitempty"`
 	// Validated at is the timestamp of the last validation run.
 	// This field is optional and only populated when validation has run.
-	ValidatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=validated_at,json=validatedAt,proto3,oneof" json:"validated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ValidatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=validated_at,json=validatedAt,proto3,oneof" json:"validated_at,omitempty"`
+	// Value is the output value encoded as JSON.
+	// Only populated by ListStateOutputsBatch with include_values set; always
+	// omitted for sensitive outputs.
+	ValueJson *string `protobuf:"bytes,8,opt,name=value_json,json=valueJson,proto3,oneof" json:"value_json,omitempty"`
+	// Schema registry key the schema was set from. Only populated for schemas
+	// set via SetOutputSchema with schema_ref.
+	SchemaRef *string `protobuf:"bytes,9,opt,name=schema_ref,json=schemaRef,proto3,oneof" json:"schema_ref,omitempty"`
+	// Version of the current schema in the output's schema history
+	// (see ListOutputSchemaHistory). Only populated when schema_json is set.
+	SchemaVersion *int32 `protobuf:"varint,10,opt,name=schema_version,json=schemaVersion,proto3,oneof" json:"schema_version,omitempty"`
+	// Schema version the last validation ran against. Only populated when
+	// validation has run.
+	ValidatedSchemaVersion *int32 `protobuf:"varint,11,opt,name=validated_schema_version,json=validatedSchemaVersion,proto3,oneof" json:"validated_schema_version,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *OutputKey) Reset() {
 	*x = OutputKey{}
-	mi := &file_state_v1_state_proto_msgTypes[36]
+	mi := &file_state_v1_state_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2554,7 +2857,7 @@ func (x *OutputKey) String() string {
 func (*OutputKey) ProtoMessage() {}
 
 func (x *OutputKey) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[36]
+	mi := &file_state_v1_state_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2567,7 +2870,7 @@ func (x *OutputKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OutputKey.ProtoReflect.Descriptor instead.
 func (*OutputKey) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{36}
+	return file_state_v1_state_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *OutputKey) GetKey() string {
@@ -2619,6 +2922,34 @@ func (x *OutputKey) GetValidatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *OutputKey) GetValueJson() string {
+	if x != nil && x.ValueJson != nil {
+		return *x.ValueJson
+	}
+	return ""
+}
+
+func (x *OutputKey) GetSchemaRef() string {
+	if x != nil && x.SchemaRef != nil {
+		return *x.SchemaRef
+	}
+	return ""
+}
+
+func (x *OutputKey) GetSchemaVersion() int32 {
+	if x != nil && x.SchemaVersion != nil {
+		return *x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *OutputKey) GetValidatedSchemaVersion() int32 {
+	if x != nil && x.ValidatedSchemaVersion != nil {
+		return *x.ValidatedSchemaVersion
+	}
+	return 0
+}
+
 // ListStateOutputsRequest fetches output keys for a state.
 type ListStateOutputsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2635,7 +2966,7 @@ type ListStateOutputsRequest struct {
 
 func (x *ListStateOutputsRequest) Reset() {
 	*x = ListStateOutputsRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[37]
+	mi := &file_state_v1_state_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2647,7 +2978,7 @@ func (x *ListStateOutputsRequest) String() string {
 func (*ListStateOutputsRequest) ProtoMessage() {}
 
 func (x *ListStateOutputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[37]
+	mi := &file_state_v1_state_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2660,7 +2991,7 @@ func (x *ListStateOutputsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListStateOutputsRequest.ProtoReflect.Descriptor instead.
 func (*ListStateOutputsRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{37}
+	return file_state_v1_state_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ListStateOutputsRequest) GetState() isListStateOutputsRequest_State {
@@ -2719,7 +3050,7 @@ type ListStateOutputsResponse struct {
 
 func (x *ListStateOutputsResponse) Reset() {
 	*x = ListStateOutputsResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[38]
+	mi := &file_state_v1_state_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2731,7 +3062,7 @@ func (x *ListStateOutputsResponse) String() string {
 func (*ListStateOutputsResponse) ProtoMessage() {}
 
 func (x *ListStateOutputsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[38]
+	mi := &file_state_v1_state_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2744,7 +3075,7 @@ func (x *ListStateOutputsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListStateOutputsResponse.ProtoReflect.Descriptor instead.
 func (*ListStateOutputsResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{38}
+	return file_state_v1_state_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ListStateOutputsResponse) GetStateGuid() string {
@@ -2768,35 +3099,34 @@ func (x *ListStateOutputsResponse) GetOutputs() []*OutputKey {
 	return nil
 }
 
-// GetStateInfoRequest fetches full state information.
-type GetStateInfoRequest struct {
+// ListStateOutputsBatchRequest fetches outputs for several states.
+type ListStateOutputsBatchRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// State identifier (prefer logic_id for UX, guid for precision)
-	//
-	// Types that are valid to be assigned to State:
-	//
-	//	*GetStateInfoRequest_LogicId
-	//	*GetStateInfoRequest_Guid
-	State         isGetStateInfoRequest_State `protobuf_oneof:"state"`
+	// States to fetch (at most 100). Each ref is resolved by guid, or by logic_id when guid is empty.
+	States []*StateRef `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	// Restrict results to these output keys. Empty returns every output.
+	OutputKeys []string `protobuf:"bytes,2,rep,name=output_keys,json=outputKeys,proto3" json:"output_keys,omitempty"`
+	// Include non-sensitive output values (requires state-output:read).
+	IncludeValues bool `protobuf:"varint,3,opt,name=include_values,json=includeValues,proto3" json:"include_values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStateInfoRequest) Reset() {
-	*x = GetStateInfoRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[39]
+func (x *ListStateOutputsBatchRequest) Reset() {
+	*x = ListStateOutputsBatchRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStateInfoRequest) String() string {
+func (x *ListStateOutputsBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStateInfoRequest) ProtoMessage() {}
+func (*ListStateOutputsBatchRequest) ProtoMessage() {}
 
-func (x *GetStateInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[39]
+func (x *ListStateOutputsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2807,97 +3137,55 @@ func (x *GetStateInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStateInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetStateInfoRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use ListStateOutputsBatchRequest.ProtoReflect.Descriptor instead.
+func (*ListStateOutputsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *GetStateInfoRequest) GetState() isGetStateInfoRequest_State {
+func (x *ListStateOutputsBatchRequest) GetStates() []*StateRef {
 	if x != nil {
-		return x.State
+		return x.States
 	}
 	return nil
 }
 
-func (x *GetStateInfoRequest) GetLogicId() string {
+func (x *ListStateOutputsBatchRequest) GetOutputKeys() []string {
 	if x != nil {
-		if x, ok := x.State.(*GetStateInfoRequest_LogicId); ok {
-			return x.LogicId
-		}
+		return x.OutputKeys
 	}
-	return ""
+	return nil
 }
 
-func (x *GetStateInfoRequest) GetGuid() string {
+func (x *ListStateOutputsBatchRequest) GetIncludeValues() bool {
 	if x != nil {
-		if x, ok := x.State.(*GetStateInfoRequest_Guid); ok {
-			return x.Guid
-		}
+		return x.IncludeValues
 	}
-	return ""
-}
-
-type isGetStateInfoRequest_State interface {
-	isGetStateInfoRequest_State()
-}
-
-type GetStateInfoRequest_LogicId struct {
-	LogicId string `protobuf:"bytes,1,opt,name=logic_id,json=logicId,proto3,oneof"` // User-friendly state identifier
-}
-
-type GetStateInfoRequest_Guid struct {
-	Guid string `protobuf:"bytes,2,opt,name=guid,proto3,oneof"` // Immutable UUIDv7 identifier
+	return false
 }
 
-func (*GetStateInfoRequest_LogicId) isGetStateInfoRequest_State() {}
-
-func (*GetStateInfoRequest_Guid) isGetStateInfoRequest_State() {}
-
-// GetStateInfoResponse returns comprehensive state view.
-type GetStateInfoResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// State identifiers
-	Guid    string `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
-	LogicId string `protobuf:"bytes,2,opt,name=logic_id,json=logicId,proto3" json:"logic_id,omitempty"`
-	// Terraform HTTP backend configuration
-	BackendConfig *BackendConfig `protobuf:"bytes,3,opt,name=backend_config,json=backendConfig,proto3" json:"backend_config,omitempty"`
-	// Incoming dependency edges (this state consumes outputs from these states)
-	// Equivalent to: SELECT * FROM edges WHERE to_guid = this.guid
-	Dependencies []*DependencyEdge `protobuf:"bytes,4,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
-	// Outgoing dependency edges (other states consume this state's outputs)
-	// Equivalent to: SELECT * FROM edges WHERE from_guid = this.guid
-	Dependents []*DependencyEdge `protobuf:"bytes,5,rep,name=dependents,proto3" json:"dependents,omitempty"`
-	// Available outputs from this state's Terraform JSON (keys only, no values)
-	// Empty array if state has no Terraform state JSON uploaded yet
-	Outputs []*OutputKey `protobuf:"bytes,6,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	// State lifecycle timestamps
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	// Computed status based on incoming dependency edges
-	ComputedStatus *string `protobuf:"bytes,9,opt,name=computed_status,json=computedStatus,proto3,oneof" json:"computed_status,omitempty"` // "clean", "stale", "potentially-stale"
-	// State JSON size in bytes (calculated without including state JSON in response)
-	SizeBytes int64 `protobuf:"varint,10,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	// State labels (key-value pairs with typed values)
-	Labels        map[string]*LabelValue `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+// ListStateOutputsBatchResponse returns outputs grouped by state, in request order.
+type ListStateOutputsBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []*StateOutputs        `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStateInfoResponse) Reset() {
-	*x = GetStateInfoResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[40]
+func (x *ListStateOutputsBatchResponse) Reset() {
+	*x = ListStateOutputsBatchResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStateInfoResponse) String() string {
+func (x *ListStateOutputsBatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStateInfoResponse) ProtoMessage() {}
+func (*ListStateOutputsBatchResponse) ProtoMessage() {}
 
-func (x *GetStateInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[40]
+func (x *ListStateOutputsBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2908,111 +3196,104 @@ func (x *GetStateInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStateInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetStateInfoResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use ListStateOutputsBatchResponse.ProtoReflect.Descriptor instead.
+func (*ListStateOutputsBatchResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *GetStateInfoResponse) GetGuid() string {
+func (x *ListStateOutputsBatchResponse) GetStates() []*StateOutputs {
 	if x != nil {
-		return x.Guid
+		return x.States
 	}
-	return ""
+	return nil
 }
 
-func (x *GetStateInfoResponse) GetLogicId() string {
-	if x != nil {
-		return x.LogicId
-	}
-	return ""
+// StateOutputs lists the outputs of one state.
+type StateOutputs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StateGuid     string                 `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId  string                 `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	Outputs       []*OutputKey           `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStateInfoResponse) GetBackendConfig() *BackendConfig {
-	if x != nil {
-		return x.BackendConfig
-	}
-	return nil
+func (x *StateOutputs) Reset() {
+	*x = StateOutputs{}
+	mi := &file_state_v1_state_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStateInfoResponse) GetDependencies() []*DependencyEdge {
-	if x != nil {
-		return x.Dependencies
-	}
-	return nil
+func (x *StateOutputs) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GetStateInfoResponse) GetDependents() []*DependencyEdge {
-	if x != nil {
-		return x.Dependents
-	}
-	return nil
-}
+func (*StateOutputs) ProtoMessage() {}
 
-func (x *GetStateInfoResponse) GetOutputs() []*OutputKey {
+func (x *StateOutputs) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[43]
 	if x != nil {
-		return x.Outputs
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetStateInfoResponse) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+// Deprecated: Use StateOutputs.ProtoReflect.Descriptor instead.
+func (*StateOutputs) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *GetStateInfoResponse) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *StateOutputs) GetStateGuid() string {
 	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-func (x *GetStateInfoResponse) GetComputedStatus() string {
-	if x != nil && x.ComputedStatus != nil {
-		return *x.ComputedStatus
+		return x.StateGuid
 	}
 	return ""
 }
 
-func (x *GetStateInfoResponse) GetSizeBytes() int64 {
+func (x *StateOutputs) GetStateLogicId() string {
 	if x != nil {
-		return x.SizeBytes
+		return x.StateLogicId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetStateInfoResponse) GetLabels() map[string]*LabelValue {
+func (x *StateOutputs) GetOutputs() []*OutputKey {
 	if x != nil {
-		return x.Labels
+		return x.Outputs
 	}
 	return nil
 }
 
-// ListAllEdgesRequest currently has no parameters.
-// Future: Add filtering, pagination, sorting options.
-type ListAllEdgesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// GetStateOutputValuesRequest selects outputs of one state by key.
+type GetStateOutputValuesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State *StateRef              `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// Output keys to return. Empty returns every output. Unknown keys are omitted.
+	Keys          []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAllEdgesRequest) Reset() {
-	*x = ListAllEdgesRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[41]
+func (x *GetStateOutputValuesRequest) Reset() {
+	*x = GetStateOutputValuesRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAllEdgesRequest) String() string {
+func (x *GetStateOutputValuesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAllEdgesRequest) ProtoMessage() {}
+func (*GetStateOutputValuesRequest) ProtoMessage() {}
 
-func (x *ListAllEdgesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[41]
+func (x *GetStateOutputValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3023,34 +3304,49 @@ func (x *ListAllEdgesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAllEdgesRequest.ProtoReflect.Descriptor instead.
-func (*ListAllEdgesRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use GetStateOutputValuesRequest.ProtoReflect.Descriptor instead.
+func (*GetStateOutputValuesRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{44}
 }
 
-// ListAllEdgesResponse contains all dependency edges.
-type ListAllEdgesResponse struct {
+func (x *GetStateOutputValuesRequest) GetState() *StateRef {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *GetStateOutputValuesRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type GetStateOutputValuesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Edges         []*DependencyEdge      `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	StateGuid     string                 `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId  string                 `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	Values        []*OutputValue         `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAllEdgesResponse) Reset() {
-	*x = ListAllEdgesResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[42]
+func (x *GetStateOutputValuesResponse) Reset() {
+	*x = GetStateOutputValuesResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAllEdgesResponse) String() string {
+func (x *GetStateOutputValuesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAllEdgesResponse) ProtoMessage() {}
+func (*GetStateOutputValuesResponse) ProtoMessage() {}
 
-func (x *ListAllEdgesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[42]
+func (x *GetStateOutputValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3061,46 +3357,60 @@ func (x *ListAllEdgesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAllEdgesResponse.ProtoReflect.Descriptor instead.
-func (*ListAllEdgesResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use GetStateOutputValuesResponse.ProtoReflect.Descriptor instead.
+func (*GetStateOutputValuesResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *ListAllEdgesResponse) GetEdges() []*DependencyEdge {
+func (x *GetStateOutputValuesResponse) GetStateGuid() string {
 	if x != nil {
-		return x.Edges
+		return x.StateGuid
+	}
+	return ""
+}
+
+func (x *GetStateOutputValuesResponse) GetStateLogicId() string {
+	if x != nil {
+		return x.StateLogicId
+	}
+	return ""
+}
+
+func (x *GetStateOutputValuesResponse) GetValues() []*OutputValue {
+	if x != nil {
+		return x.Values
 	}
 	return nil
 }
 
-// LabelValue represents a typed label value (string, number, or boolean).
-type LabelValue struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Value:
-	//
-	//	*LabelValue_StringValue
-	//	*LabelValue_NumberValue
-	//	*LabelValue_BoolValue
-	Value         isLabelValue_Value `protobuf_oneof:"value"`
+// OutputValue is a single Terraform output value.
+type OutputValue struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Key       string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Sensitive bool                   `protobuf:"varint,2,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	// JSON-encoded value; unset when redacted.
+	ValueJson *string `protobuf:"bytes,3,opt,name=value_json,json=valueJson,proto3,oneof" json:"value_json,omitempty"`
+	// True when the output is sensitive and the caller may not read it.
+	Redacted      bool `protobuf:"varint,4,opt,name=redacted,proto3" json:"redacted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LabelValue) Reset() {
-	*x = LabelValue{}
-	mi := &file_state_v1_state_proto_msgTypes[43]
+func (x *OutputValue) Reset() {
+	*x = OutputValue{}
+	mi := &file_state_v1_state_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LabelValue) String() string {
+func (x *OutputValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LabelValue) ProtoMessage() {}
+func (*OutputValue) ProtoMessage() {}
 
-func (x *LabelValue) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[43]
+func (x *OutputValue) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3111,97 +3421,181 @@ func (x *LabelValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LabelValue.ProtoReflect.Descriptor instead.
-func (*LabelValue) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use OutputValue.ProtoReflect.Descriptor instead.
+func (*OutputValue) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *LabelValue) GetValue() isLabelValue_Value {
+func (x *OutputValue) GetKey() string {
 	if x != nil {
-		return x.Value
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-func (x *LabelValue) GetStringValue() string {
+func (x *OutputValue) GetSensitive() bool {
 	if x != nil {
-		if x, ok := x.Value.(*LabelValue_StringValue); ok {
-			return x.StringValue
-		}
+		return x.Sensitive
 	}
-	return ""
+	return false
 }
 
-func (x *LabelValue) GetNumberValue() float64 {
-	if x != nil {
-		if x, ok := x.Value.(*LabelValue_NumberValue); ok {
-			return x.NumberValue
-		}
+func (x *OutputValue) GetValueJson() string {
+	if x != nil && x.ValueJson != nil {
+		return *x.ValueJson
 	}
-	return 0
+	return ""
 }
 
-func (x *LabelValue) GetBoolValue() bool {
+func (x *OutputValue) GetRedacted() bool {
 	if x != nil {
-		if x, ok := x.Value.(*LabelValue_BoolValue); ok {
-			return x.BoolValue
-		}
+		return x.Redacted
 	}
 	return false
 }
 
-type isLabelValue_Value interface {
-	isLabelValue_Value()
+// GetStateInfoRequest fetches full state information.
+type GetStateInfoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifier (prefer logic_id for UX, guid for precision)
+	//
+	// Types that are valid to be assigned to State:
+	//
+	//	*GetStateInfoRequest_LogicId
+	//	*GetStateInfoRequest_Guid
+	State isGetStateInfoRequest_State `protobuf_oneof:"state"`
+	// Whether to compute computed_status (default: false). Status is derived
+	// from the whole dependency graph, so skip it when only outputs and labels
+	// are needed.
+	IncludeComputedStatus *bool `protobuf:"varint,3,opt,name=include_computed_status,json=includeComputedStatus,proto3,oneof" json:"include_computed_status,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
-type LabelValue_StringValue struct {
-	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+func (x *GetStateInfoRequest) Reset() {
+	*x = GetStateInfoRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type LabelValue_NumberValue struct {
-	NumberValue float64 `protobuf:"fixed64,2,opt,name=number_value,json=numberValue,proto3,oneof"`
+func (x *GetStateInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type LabelValue_BoolValue struct {
-	BoolValue bool `protobuf:"varint,3,opt,name=bool_value,json=boolValue,proto3,oneof"`
+func (*GetStateInfoRequest) ProtoMessage() {}
+
+func (x *GetStateInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (*LabelValue_StringValue) isLabelValue_Value() {}
+// Deprecated: Use GetStateInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetStateInfoRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{47}
+}
 
-func (*LabelValue_NumberValue) isLabelValue_Value() {}
+func (x *GetStateInfoRequest) GetState() isGetStateInfoRequest_State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
 
-func (*LabelValue_BoolValue) isLabelValue_Value() {}
+func (x *GetStateInfoRequest) GetLogicId() string {
+	if x != nil {
+		if x, ok := x.State.(*GetStateInfoRequest_LogicId); ok {
+			return x.LogicId
+		}
+	}
+	return ""
+}
 
-// UpdateStateLabelsRequest mutates labels for an existing state.
-type UpdateStateLabelsRequest struct {
+func (x *GetStateInfoRequest) GetGuid() string {
+	if x != nil {
+		if x, ok := x.State.(*GetStateInfoRequest_Guid); ok {
+			return x.Guid
+		}
+	}
+	return ""
+}
+
+func (x *GetStateInfoRequest) GetIncludeComputedStatus() bool {
+	if x != nil && x.IncludeComputedStatus != nil {
+		return *x.IncludeComputedStatus
+	}
+	return false
+}
+
+type isGetStateInfoRequest_State interface {
+	isGetStateInfoRequest_State()
+}
+
+type GetStateInfoRequest_LogicId struct {
+	LogicId string `protobuf:"bytes,1,opt,name=logic_id,json=logicId,proto3,oneof"` // User-friendly state identifier
+}
+
+type GetStateInfoRequest_Guid struct {
+	Guid string `protobuf:"bytes,2,opt,name=guid,proto3,oneof"` // Immutable UUIDv7 identifier
+}
+
+func (*GetStateInfoRequest_LogicId) isGetStateInfoRequest_State() {}
+
+func (*GetStateInfoRequest_Guid) isGetStateInfoRequest_State() {}
+
+// GetStateInfoResponse returns comprehensive state view.
+type GetStateInfoResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// State identifier (GUID)
-	StateId string `protobuf:"bytes,1,opt,name=state_id,json=stateId,proto3" json:"state_id,omitempty"`
-	// Labels to add or update (key-value pairs)
-	Adds map[string]*LabelValue `protobuf:"bytes,2,rep,name=adds,proto3" json:"adds,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// Label keys to remove
-	Removals []string `protobuf:"bytes,3,rep,name=removals,proto3" json:"removals,omitempty"`
-	// Optional idempotency token
-	ClientRequestId *string `protobuf:"bytes,4,opt,name=client_request_id,json=clientRequestId,proto3,oneof" json:"client_request_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// State identifiers
+	Guid    string `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	LogicId string `protobuf:"bytes,2,opt,name=logic_id,json=logicId,proto3" json:"logic_id,omitempty"`
+	// Terraform HTTP backend configuration
+	BackendConfig *BackendConfig `protobuf:"bytes,3,opt,name=backend_config,json=backendConfig,proto3" json:"backend_config,omitempty"`
+	// Incoming dependency edges (this state consumes outputs from these states)
+	// Equivalent to: SELECT * FROM edges WHERE to_guid = this.guid
+	Dependencies []*DependencyEdge `protobuf:"bytes,4,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	// Outgoing dependency edges (other states consume this state's outputs)
+	// Equivalent to: SELECT * FROM edges WHERE from_guid = this.guid
+	Dependents []*DependencyEdge `protobuf:"bytes,5,rep,name=dependents,proto3" json:"dependents,omitempty"`
+	// Available outputs from this state's Terraform JSON (keys only, no values)
+	// Empty array if state has no Terraform state JSON uploaded yet
+	Outputs []*OutputKey `protobuf:"bytes,6,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// State lifecycle timestamps
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Computed status based on incoming dependency edges; only set when
+	// include_computed_status was requested
+	ComputedStatus *string `protobuf:"bytes,9,opt,name=computed_status,json=computedStatus,proto3,oneof" json:"computed_status,omitempty"` // "clean", "stale", "potentially-stale"
+	// State JSON size in bytes (calculated without including state JSON in response)
+	SizeBytes int64 `protobuf:"varint,10,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// State labels (key-value pairs with typed values)
+	Labels        map[string]*LabelValue `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateStateLabelsRequest) Reset() {
-	*x = UpdateStateLabelsRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[44]
+func (x *GetStateInfoResponse) Reset() {
+	*x = GetStateInfoResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStateLabelsRequest) String() string {
+func (x *GetStateInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStateLabelsRequest) ProtoMessage() {}
+func (*GetStateInfoResponse) ProtoMessage() {}
 
-func (x *UpdateStateLabelsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[44]
+func (x *GetStateInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3212,66 +3606,2707 @@ func (x *UpdateStateLabelsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStateLabelsRequest.ProtoReflect.Descriptor instead.
-func (*UpdateStateLabelsRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use GetStateInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetStateInfoResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *UpdateStateLabelsRequest) GetStateId() string {
+func (x *GetStateInfoResponse) GetGuid() string {
 	if x != nil {
-		return x.StateId
+		return x.Guid
 	}
 	return ""
 }
 
-func (x *UpdateStateLabelsRequest) GetAdds() map[string]*LabelValue {
+func (x *GetStateInfoResponse) GetLogicId() string {
 	if x != nil {
-		return x.Adds
+		return x.LogicId
+	}
+	return ""
+}
+
+func (x *GetStateInfoResponse) GetBackendConfig() *BackendConfig {
+	if x != nil {
+		return x.BackendConfig
 	}
 	return nil
 }
 
-func (x *UpdateStateLabelsRequest) GetRemovals() []string {
+func (x *GetStateInfoResponse) GetDependencies() []*DependencyEdge {
 	if x != nil {
-		return x.Removals
+		return x.Dependencies
 	}
 	return nil
 }
 
-func (x *UpdateStateLabelsRequest) GetClientRequestId() string {
-	if x != nil && x.ClientRequestId != nil {
-		return *x.ClientRequestId
+func (x *GetStateInfoResponse) GetDependents() []*DependencyEdge {
+	if x != nil {
+		return x.Dependents
+	}
+	return nil
+}
+
+func (x *GetStateInfoResponse) GetOutputs() []*OutputKey {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *GetStateInfoResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetStateInfoResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GetStateInfoResponse) GetComputedStatus() string {
+	if x != nil && x.ComputedStatus != nil {
+		return *x.ComputedStatus
 	}
 	return ""
 }
 
-// UpdateStateLabelsResponse returns updated label set.
-type UpdateStateLabelsResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	StateId          string                 `protobuf:"bytes,1,opt,name=state_id,json=stateId,proto3" json:"state_id,omitempty"`
-	Labels           map[string]*LabelValue `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	PolicyVersion    int32                  `protobuf:"varint,3,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
-	ComplianceStatus string                 `protobuf:"bytes,4,opt,name=compliance_status,json=complianceStatus,proto3" json:"compliance_status,omitempty"` // "COMPLIANT" or "NON_COMPLIANT"
-	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *GetStateInfoResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *GetStateInfoResponse) GetLabels() map[string]*LabelValue {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// ListAllEdgesRequest currently has no parameters.
+// Future: Add filtering, pagination, sorting options.
+type ListAllEdgesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Max edges per page (max 1000). Unset or 0 returns every edge in one
+	// response, as before pagination existed.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor from a previous response's next_page_token
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAllEdgesRequest) Reset() {
+	*x = ListAllEdgesRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAllEdgesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllEdgesRequest) ProtoMessage() {}
+
+func (x *ListAllEdgesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllEdgesRequest.ProtoReflect.Descriptor instead.
+func (*ListAllEdgesRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListAllEdgesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAllEdgesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListAllEdgesResponse contains all dependency edges.
+type ListAllEdgesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Edges []*DependencyEdge      `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	// Cursor for the next page; empty when there are no more edges.
+	// Pages are ordered by edge ID, so edges created mid-iteration are not skipped.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAllEdgesResponse) Reset() {
+	*x = ListAllEdgesResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAllEdgesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllEdgesResponse) ProtoMessage() {}
+
+func (x *ListAllEdgesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllEdgesResponse.ProtoReflect.Descriptor instead.
+func (*ListAllEdgesResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListAllEdgesResponse) GetEdges() []*DependencyEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *ListAllEdgesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// LabelValue represents a typed label value (string, number, or boolean).
+type LabelValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*LabelValue_StringValue
+	//	*LabelValue_NumberValue
+	//	*LabelValue_BoolValue
+	Value         isLabelValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelValue) Reset() {
+	*x = LabelValue{}
+	mi := &file_state_v1_state_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelValue) ProtoMessage() {}
+
+func (x *LabelValue) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelValue.ProtoReflect.Descriptor instead.
+func (*LabelValue) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *LabelValue) GetValue() isLabelValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *LabelValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*LabelValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *LabelValue) GetNumberValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*LabelValue_NumberValue); ok {
+			return x.NumberValue
+		}
+	}
+	return 0
+}
+
+func (x *LabelValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*LabelValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+type isLabelValue_Value interface {
+	isLabelValue_Value()
+}
+
+type LabelValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type LabelValue_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,2,opt,name=number_value,json=numberValue,proto3,oneof"`
+}
+
+type LabelValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,3,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+func (*LabelValue_StringValue) isLabelValue_Value() {}
+
+func (*LabelValue_NumberValue) isLabelValue_Value() {}
+
+func (*LabelValue_BoolValue) isLabelValue_Value() {}
+
+// UpdateStateLabelsRequest mutates labels for an existing state.
+type UpdateStateLabelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifier (GUID)
+	StateId string `protobuf:"bytes,1,opt,name=state_id,json=stateId,proto3" json:"state_id,omitempty"`
+	// Labels to add or update (key-value pairs)
+	Adds map[string]*LabelValue `protobuf:"bytes,2,rep,name=adds,proto3" json:"adds,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Label keys to remove
+	Removals []string `protobuf:"bytes,3,rep,name=removals,proto3" json:"removals,omitempty"`
+	// Optional idempotency token
+	ClientRequestId *string `protobuf:"bytes,4,opt,name=client_request_id,json=clientRequestId,proto3,oneof" json:"client_request_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateStateLabelsRequest) Reset() {
+	*x = UpdateStateLabelsRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStateLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStateLabelsRequest) ProtoMessage() {}
+
+func (x *UpdateStateLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStateLabelsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStateLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *UpdateStateLabelsRequest) GetStateId() string {
+	if x != nil {
+		return x.StateId
+	}
+	return ""
+}
+
+func (x *UpdateStateLabelsRequest) GetAdds() map[string]*LabelValue {
+	if x != nil {
+		return x.Adds
+	}
+	return nil
+}
+
+func (x *UpdateStateLabelsRequest) GetRemovals() []string {
+	if x != nil {
+		return x.Removals
+	}
+	return nil
+}
+
+func (x *UpdateStateLabelsRequest) GetClientRequestId() string {
+	if x != nil && x.ClientRequestId != nil {
+		return *x.ClientRequestId
+	}
+	return ""
+}
+
+// UpdateStateLabelsResponse returns updated label set.
+type UpdateStateLabelsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	StateId          string                 `protobuf:"bytes,1,opt,name=state_id,json=stateId,proto3" json:"state_id,omitempty"`
+	Labels           map[string]*LabelValue `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PolicyVersion    int32                  `protobuf:"varint,3,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	ComplianceStatus string                 `protobuf:"bytes,4,opt,name=compliance_status,json=complianceStatus,proto3" json:"compliance_status,omitempty"` // "COMPLIANT" or "NON_COMPLIANT"
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateStateLabelsResponse) Reset() {
+	*x = UpdateStateLabelsResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStateLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStateLabelsResponse) ProtoMessage() {}
+
+func (x *UpdateStateLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStateLabelsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStateLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *UpdateStateLabelsResponse) GetStateId() string {
+	if x != nil {
+		return x.StateId
+	}
+	return ""
+}
+
+func (x *UpdateStateLabelsResponse) GetLabels() map[string]*LabelValue {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateStateLabelsResponse) GetPolicyVersion() int32 {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return 0
+}
+
+func (x *UpdateStateLabelsResponse) GetComplianceStatus() string {
+	if x != nil {
+		return x.ComplianceStatus
+	}
+	return ""
+}
+
+func (x *UpdateStateLabelsResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// TransferStateOwnershipRequest names a state and its new owner.
+type TransferStateOwnershipRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to State:
+	//
+	//	*TransferStateOwnershipRequest_LogicId
+	//	*TransferStateOwnershipRequest_Guid
+	State         isTransferStateOwnershipRequest_State `protobuf_oneof:"state"`
+	NewOwnerType  string                                `protobuf:"bytes,3,opt,name=new_owner_type,json=newOwnerType,proto3" json:"new_owner_type,omitempty"` // "user" or "service_account"
+	NewOwnerId    string                                `protobuf:"bytes,4,opt,name=new_owner_id,json=newOwnerId,proto3" json:"new_owner_id,omitempty"`       // User subject or service account client ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferStateOwnershipRequest) Reset() {
+	*x = TransferStateOwnershipRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStateOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStateOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferStateOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStateOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferStateOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TransferStateOwnershipRequest) GetState() isTransferStateOwnershipRequest_State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *TransferStateOwnershipRequest) GetLogicId() string {
+	if x != nil {
+		if x, ok := x.State.(*TransferStateOwnershipRequest_LogicId); ok {
+			return x.LogicId
+		}
+	}
+	return ""
+}
+
+func (x *TransferStateOwnershipRequest) GetGuid() string {
+	if x != nil {
+		if x, ok := x.State.(*TransferStateOwnershipRequest_Guid); ok {
+			return x.Guid
+		}
+	}
+	return ""
+}
+
+func (x *TransferStateOwnershipRequest) GetNewOwnerType() string {
+	if x != nil {
+		return x.NewOwnerType
+	}
+	return ""
+}
+
+func (x *TransferStateOwnershipRequest) GetNewOwnerId() string {
+	if x != nil {
+		return x.NewOwnerId
+	}
+	return ""
+}
+
+type isTransferStateOwnershipRequest_State interface {
+	isTransferStateOwnershipRequest_State()
+}
+
+type TransferStateOwnershipRequest_LogicId struct {
+	LogicId string `protobuf:"bytes,1,opt,name=logic_id,json=logicId,proto3,oneof"`
+}
+
+type TransferStateOwnershipRequest_Guid struct {
+	Guid string `protobuf:"bytes,2,opt,name=guid,proto3,oneof"`
+}
+
+func (*TransferStateOwnershipRequest_LogicId) isTransferStateOwnershipRequest_State() {}
+
+func (*TransferStateOwnershipRequest_Guid) isTransferStateOwnershipRequest_State() {}
+
+// TransferStateOwnershipResponse returns the owner principal IDs before and after.
+type TransferStateOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Guid          string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	PreviousOwner string                 `protobuf:"bytes,2,opt,name=previous_owner,json=previousOwner,proto3" json:"previous_owner,omitempty"` // Empty if the state had no recorded owner
+	NewOwner      string                 `protobuf:"bytes,3,opt,name=new_owner,json=newOwner,proto3" json:"new_owner,omitempty"`                // Principal ID, e.g. "user:alice"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferStateOwnershipResponse) Reset() {
+	*x = TransferStateOwnershipResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStateOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStateOwnershipResponse) ProtoMessage() {}
+
+func (x *TransferStateOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStateOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*TransferStateOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *TransferStateOwnershipResponse) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *TransferStateOwnershipResponse) GetPreviousOwner() string {
+	if x != nil {
+		return x.PreviousOwner
+	}
+	return ""
+}
+
+func (x *TransferStateOwnershipResponse) GetNewOwner() string {
+	if x != nil {
+		return x.NewOwner
+	}
+	return ""
+}
+
+// LabelConstraintViolation is attached as a Connect error detail when a
+// CreateState or UpdateStateLabels call is rejected by a role's
+// create_constraints or immutable_keys.
+type LabelConstraintViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`                         // Role whose constraint was breached
+	LabelKey      string                 `protobuf:"bytes,2,opt,name=label_key,json=labelKey,proto3" json:"label_key,omitempty"` // Offending label key
+	Constraint    string                 `protobuf:"bytes,3,opt,name=constraint,proto3" json:"constraint,omitempty"`             // "required", "allowed_values", or "immutable"
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                   // Human-readable explanation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelConstraintViolation) Reset() {
+	*x = LabelConstraintViolation{}
+	mi := &file_state_v1_state_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelConstraintViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelConstraintViolation) ProtoMessage() {}
+
+func (x *LabelConstraintViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelConstraintViolation.ProtoReflect.Descriptor instead.
+func (*LabelConstraintViolation) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *LabelConstraintViolation) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *LabelConstraintViolation) GetLabelKey() string {
+	if x != nil {
+		return x.LabelKey
+	}
+	return ""
+}
+
+func (x *LabelConstraintViolation) GetConstraint() string {
+	if x != nil {
+		return x.Constraint
+	}
+	return ""
+}
+
+func (x *LabelConstraintViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetLabelPolicyRequest retrieves the current policy.
+type GetLabelPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLabelPolicyRequest) Reset() {
+	*x = GetLabelPolicyRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelPolicyRequest) ProtoMessage() {}
+
+func (x *GetLabelPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetLabelPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{57}
+}
+
+// GetLabelPolicyResponse returns the label validation policy.
+type GetLabelPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	PolicyJson    string                 `protobuf:"bytes,2,opt,name=policy_json,json=policyJson,proto3" json:"policy_json,omitempty"` // JSON-encoded PolicyDefinition
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLabelPolicyResponse) Reset() {
+	*x = GetLabelPolicyResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelPolicyResponse) ProtoMessage() {}
+
+func (x *GetLabelPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetLabelPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetLabelPolicyResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetLabelPolicyResponse) GetPolicyJson() string {
+	if x != nil {
+		return x.PolicyJson
+	}
+	return ""
+}
+
+func (x *GetLabelPolicyResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetLabelPolicyResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// SetLabelPolicyRequest updates the policy.
+type SetLabelPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON-encoded PolicyDefinition (see data-model.md)
+	PolicyJson    string `protobuf:"bytes,1,opt,name=policy_json,json=policyJson,proto3" json:"policy_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLabelPolicyRequest) Reset() {
+	*x = SetLabelPolicyRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLabelPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLabelPolicyRequest) ProtoMessage() {}
+
+func (x *SetLabelPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLabelPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetLabelPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SetLabelPolicyRequest) GetPolicyJson() string {
+	if x != nil {
+		return x.PolicyJson
+	}
+	return ""
+}
+
+// SetLabelPolicyResponse confirms policy update.
+type SetLabelPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // Incremented version number
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLabelPolicyResponse) Reset() {
+	*x = SetLabelPolicyResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLabelPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLabelPolicyResponse) ProtoMessage() {}
+
+func (x *SetLabelPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLabelPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetLabelPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SetLabelPolicyResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SetLabelPolicyResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateServiceAccountRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Roles assigned in the same operation. If any assignment fails, the
+	// account is not created.
+	RoleNames     []string `protobuf:"bytes,3,rep,name=role_names,json=roleNames,proto3" json:"role_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceAccountRequest) Reset() {
+	*x = CreateServiceAccountRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceAccountRequest) ProtoMessage() {}
+
+func (x *CreateServiceAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateServiceAccountRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateServiceAccountRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateServiceAccountRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateServiceAccountRequest) GetRoleNames() []string {
+	if x != nil {
+		return x.RoleNames
+	}
+	return nil
+}
+
+type CreateServiceAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // Only returned once on creation
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Roles         []string               `protobuf:"bytes,6,rep,name=roles,proto3" json:"roles,omitempty"` // Roles assigned from role_names
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceAccountResponse) Reset() {
+	*x = CreateServiceAccountResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceAccountResponse) ProtoMessage() {}
+
+func (x *CreateServiceAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceAccountResponse.ProtoReflect.Descriptor instead.
+func (*CreateServiceAccountResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateServiceAccountResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateServiceAccountResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *CreateServiceAccountResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *CreateServiceAccountResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateServiceAccountResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CreateServiceAccountResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type ListServiceAccountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only accounts whose name starts with this prefix (case-sensitive)
+	NamePrefix *string `protobuf:"bytes,1,opt,name=name_prefix,json=namePrefix,proto3,oneof" json:"name_prefix,omitempty"`
+	// Only disabled (true) or enabled (false) accounts
+	Disabled *bool `protobuf:"varint,2,opt,name=disabled,proto3,oneof" json:"disabled,omitempty"`
+	// Only accounts created by this user ID
+	CreatedBy *string `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3,oneof" json:"created_by,omitempty"`
+	// Only accounts not used since this time, including never-used ones
+	// (for stale-account cleanup)
+	LastUsedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_used_before,json=lastUsedBefore,proto3,oneof" json:"last_used_before,omitempty"`
+	// Max accounts per page (max 1000). Unset or 0 returns every matching
+	// account in one response, as before pagination existed.
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor from a previous response's next_page_token
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceAccountsRequest) Reset() {
+	*x = ListServiceAccountsRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceAccountsRequest) ProtoMessage() {}
+
+func (x *ListServiceAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListServiceAccountsRequest) GetNamePrefix() string {
+	if x != nil && x.NamePrefix != nil {
+		return *x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListServiceAccountsRequest) GetDisabled() bool {
+	if x != nil && x.Disabled != nil {
+		return *x.Disabled
+	}
+	return false
+}
+
+func (x *ListServiceAccountsRequest) GetCreatedBy() string {
+	if x != nil && x.CreatedBy != nil {
+		return *x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ListServiceAccountsRequest) GetLastUsedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedBefore
+	}
+	return nil
+}
+
+func (x *ListServiceAccountsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListServiceAccountsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ServiceAccountInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	Disabled      bool                   `protobuf:"varint,7,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceAccountInfo) Reset() {
+	*x = ServiceAccountInfo{}
+	mi := &file_state_v1_state_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceAccountInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceAccountInfo) ProtoMessage() {}
+
+func (x *ServiceAccountInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceAccountInfo.ProtoReflect.Descriptor instead.
+func (*ServiceAccountInfo) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ServiceAccountInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ServiceAccountInfo) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ServiceAccountInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceAccountInfo) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ServiceAccountInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ServiceAccountInfo) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *ServiceAccountInfo) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type ListServiceAccountsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAccounts []*ServiceAccountInfo  `protobuf:"bytes,1,rep,name=service_accounts,json=serviceAccounts,proto3" json:"service_accounts,omitempty"`
+	// Cursor for the next page; empty when there are no more accounts.
+	// Pages are ordered by account ID, so accounts created mid-iteration are not skipped.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceAccountsResponse) Reset() {
+	*x = ListServiceAccountsResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceAccountsResponse) ProtoMessage() {}
+
+func (x *ListServiceAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListServiceAccountsResponse) GetServiceAccounts() []*ServiceAccountInfo {
+	if x != nil {
+		return x.ServiceAccounts
+	}
+	return nil
+}
+
+func (x *ListServiceAccountsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RevokeServiceAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeServiceAccountRequest) Reset() {
+	*x = RevokeServiceAccountRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeServiceAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeServiceAccountRequest) ProtoMessage() {}
+
+func (x *RevokeServiceAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeServiceAccountRequest.ProtoReflect.Descriptor instead.
+func (*RevokeServiceAccountRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RevokeServiceAccountRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RevokeServiceAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeServiceAccountResponse) Reset() {
+	*x = RevokeServiceAccountResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeServiceAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeServiceAccountResponse) ProtoMessage() {}
+
+func (x *RevokeServiceAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeServiceAccountResponse.ProtoReflect.Descriptor instead.
+func (*RevokeServiceAccountResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RevokeServiceAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RotateServiceAccountRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ClientId string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // Service account to rotate credentials for
+	// Seconds the old secret stays valid alongside the new one (max 7 days).
+	// Unset or 0 invalidates it immediately.
+	OverlapSeconds *int64 `protobuf:"varint,2,opt,name=overlap_seconds,json=overlapSeconds,proto3,oneof" json:"overlap_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RotateServiceAccountRequest) Reset() {
+	*x = RotateServiceAccountRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateServiceAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateServiceAccountRequest) ProtoMessage() {}
+
+func (x *RotateServiceAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateServiceAccountRequest.ProtoReflect.Descriptor instead.
+func (*RotateServiceAccountRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *RotateServiceAccountRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RotateServiceAccountRequest) GetOverlapSeconds() int64 {
+	if x != nil && x.OverlapSeconds != nil {
+		return *x.OverlapSeconds
+	}
+	return 0
+}
+
+type RotateServiceAccountResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ClientId     string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`             // Same client_id (identity preserved)
+	ClientSecret string                 `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // New secret (only returned once)
+	RotatedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=rotated_at,json=rotatedAt,proto3" json:"rotated_at,omitempty"`
+	// When the old secret stops working; unset when it was invalidated at once
+	PreviousSecretExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=previous_secret_expires_at,json=previousSecretExpiresAt,proto3,oneof" json:"previous_secret_expires_at,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RotateServiceAccountResponse) Reset() {
+	*x = RotateServiceAccountResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateServiceAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateServiceAccountResponse) ProtoMessage() {}
+
+func (x *RotateServiceAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateServiceAccountResponse.ProtoReflect.Descriptor instead.
+func (*RotateServiceAccountResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *RotateServiceAccountResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RotateServiceAccountResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *RotateServiceAccountResponse) GetRotatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RotatedAt
+	}
+	return nil
+}
+
+func (x *RotateServiceAccountResponse) GetPreviousSecretExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousSecretExpiresAt
+	}
+	return nil
+}
+
+type CreateRoleRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description       *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Actions           []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`                                             // e.g., ["state:create", "tfstate:*"]
+	LabelScopeExpr    *string                `protobuf:"bytes,4,opt,name=label_scope_expr,json=labelScopeExpr,proto3,oneof" json:"label_scope_expr,omitempty"` // go-bexpr expression (e.g., "env == \"dev\"" or "env == \"dev\" and team == \"platform\" or team == \"sre\"")
+	CreateConstraints *CreateConstraints     `protobuf:"bytes,5,opt,name=create_constraints,json=createConstraints,proto3,oneof" json:"create_constraints,omitempty"`
+	ImmutableKeys     []string               `protobuf:"bytes,6,rep,name=immutable_keys,json=immutableKeys,proto3" json:"immutable_keys,omitempty"`
+	MaxAssignments    *int32                 `protobuf:"varint,7,opt,name=max_assignments,json=maxAssignments,proto3,oneof" json:"max_assignments,omitempty"` // Cap on principals holding the role (unset = unlimited)
+	OwnerActions      []string               `protobuf:"bytes,8,rep,name=owner_actions,json=ownerActions,proto3" json:"owner_actions,omitempty"`              // Actions allowed on states the principal created, regardless of label_scope_expr; must be granted by actions
+	DefaultLabels     bool                   `protobuf:"varint,9,opt,name=default_labels,json=defaultLabels,proto3" json:"default_labels,omitempty"`          // CreateState fills in missing labels that create_constraints pin to a single allowed value
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateRoleRequest) Reset() {
+	*x = CreateRoleRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleRequest) ProtoMessage() {}
+
+func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CreateRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRoleRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateRoleRequest) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *CreateRoleRequest) GetLabelScopeExpr() string {
+	if x != nil && x.LabelScopeExpr != nil {
+		return *x.LabelScopeExpr
+	}
+	return ""
+}
+
+func (x *CreateRoleRequest) GetCreateConstraints() *CreateConstraints {
+	if x != nil {
+		return x.CreateConstraints
+	}
+	return nil
+}
+
+func (x *CreateRoleRequest) GetImmutableKeys() []string {
+	if x != nil {
+		return x.ImmutableKeys
+	}
+	return nil
+}
+
+func (x *CreateRoleRequest) GetMaxAssignments() int32 {
+	if x != nil && x.MaxAssignments != nil {
+		return *x.MaxAssignments
+	}
+	return 0
+}
+
+func (x *CreateRoleRequest) GetOwnerActions() []string {
+	if x != nil {
+		return x.OwnerActions
+	}
+	return nil
+}
+
+func (x *CreateRoleRequest) GetDefaultLabels() bool {
+	if x != nil {
+		return x.DefaultLabels
+	}
+	return false
+}
+
+type CreateConstraints struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Map of label key to constraint definition
+	Constraints   map[string]*CreateConstraint `protobuf:"bytes,1,rep,name=constraints,proto3" json:"constraints,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConstraints) Reset() {
+	*x = CreateConstraints{}
+	mi := &file_state_v1_state_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConstraints) ProtoMessage() {}
+
+func (x *CreateConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConstraints.ProtoReflect.Descriptor instead.
+func (*CreateConstraints) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateConstraints) GetConstraints() map[string]*CreateConstraint {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+type CreateConstraint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AllowedValues []string               `protobuf:"bytes,1,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
+	Required      bool                   `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConstraint) Reset() {
+	*x = CreateConstraint{}
+	mi := &file_state_v1_state_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConstraint) ProtoMessage() {}
+
+func (x *CreateConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConstraint.ProtoReflect.Descriptor instead.
+func (*CreateConstraint) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateConstraint) GetAllowedValues() []string {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
+func (x *CreateConstraint) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type RoleInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Actions           []string               `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`
+	LabelScopeExpr    *string                `protobuf:"bytes,5,opt,name=label_scope_expr,json=labelScopeExpr,proto3,oneof" json:"label_scope_expr,omitempty"` // go-bexpr expression evaluated at enforcement time
+	CreateConstraints *CreateConstraints     `protobuf:"bytes,6,opt,name=create_constraints,json=createConstraints,proto3,oneof" json:"create_constraints,omitempty"`
+	ImmutableKeys     []string               `protobuf:"bytes,7,rep,name=immutable_keys,json=immutableKeys,proto3" json:"immutable_keys,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version           int32                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	MaxAssignments    *int32                 `protobuf:"varint,11,opt,name=max_assignments,json=maxAssignments,proto3,oneof" json:"max_assignments,omitempty"`
+	OwnerActions      []string               `protobuf:"bytes,12,rep,name=owner_actions,json=ownerActions,proto3" json:"owner_actions,omitempty"`
+	DefaultLabels     bool                   `protobuf:"varint,13,opt,name=default_labels,json=defaultLabels,proto3" json:"default_labels,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RoleInfo) Reset() {
+	*x = RoleInfo{}
+	mi := &file_state_v1_state_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleInfo) ProtoMessage() {}
+
+func (x *RoleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleInfo.ProtoReflect.Descriptor instead.
+func (*RoleInfo) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RoleInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoleInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoleInfo) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *RoleInfo) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *RoleInfo) GetLabelScopeExpr() string {
+	if x != nil && x.LabelScopeExpr != nil {
+		return *x.LabelScopeExpr
+	}
+	return ""
+}
+
+func (x *RoleInfo) GetCreateConstraints() *CreateConstraints {
+	if x != nil {
+		return x.CreateConstraints
+	}
+	return nil
+}
+
+func (x *RoleInfo) GetImmutableKeys() []string {
+	if x != nil {
+		return x.ImmutableKeys
+	}
+	return nil
+}
+
+func (x *RoleInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RoleInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *RoleInfo) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RoleInfo) GetMaxAssignments() int32 {
+	if x != nil && x.MaxAssignments != nil {
+		return *x.MaxAssignments
+	}
+	return 0
+}
+
+func (x *RoleInfo) GetOwnerActions() []string {
+	if x != nil {
+		return x.OwnerActions
+	}
+	return nil
+}
+
+func (x *RoleInfo) GetDefaultLabels() bool {
+	if x != nil {
+		return x.DefaultLabels
+	}
+	return false
+}
+
+type CreateRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          *RoleInfo              `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleResponse) Reset() {
+	*x = CreateRoleResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleResponse) ProtoMessage() {}
+
+func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoleResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CreateRoleResponse) GetRole() *RoleInfo {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
+type ListRolesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesRequest) Reset() {
+	*x = ListRolesRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesRequest) ProtoMessage() {}
+
+func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesRequest.ProtoReflect.Descriptor instead.
+func (*ListRolesRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{75}
+}
+
+type ListRolesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []*RoleInfo            `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesResponse) Reset() {
+	*x = ListRolesResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesResponse) ProtoMessage() {}
+
+func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesResponse.ProtoReflect.Descriptor instead.
+func (*ListRolesResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListRolesResponse) GetRoles() []*RoleInfo {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type UpdateRoleRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Role to update
+	Description       *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Actions           []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	LabelScopeExpr    *string                `protobuf:"bytes,4,opt,name=label_scope_expr,json=labelScopeExpr,proto3,oneof" json:"label_scope_expr,omitempty"` // go-bexpr expression (e.g., "env == \"dev\" and team == \"platform\"")
+	CreateConstraints *CreateConstraints     `protobuf:"bytes,5,opt,name=create_constraints,json=createConstraints,proto3,oneof" json:"create_constraints,omitempty"`
+	ImmutableKeys     []string               `protobuf:"bytes,6,rep,name=immutable_keys,json=immutableKeys,proto3" json:"immutable_keys,omitempty"`
+	ExpectedVersion   int32                  `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`    // Optimistic locking
+	MaxAssignments    *int32                 `protobuf:"varint,8,opt,name=max_assignments,json=maxAssignments,proto3,oneof" json:"max_assignments,omitempty"` // Cap on principals holding the role (unset = unlimited)
+	OwnerActions      []string               `protobuf:"bytes,9,rep,name=owner_actions,json=ownerActions,proto3" json:"owner_actions,omitempty"`              // Actions allowed on states the principal created, regardless of label_scope_expr
+	DefaultLabels     bool                   `protobuf:"varint,10,opt,name=default_labels,json=defaultLabels,proto3" json:"default_labels,omitempty"`         // CreateState fills in missing labels that create_constraints pin to a single allowed value
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateRoleRequest) Reset() {
+	*x = UpdateRoleRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoleRequest) ProtoMessage() {}
+
+func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *UpdateRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *UpdateRoleRequest) GetLabelScopeExpr() string {
+	if x != nil && x.LabelScopeExpr != nil {
+		return *x.LabelScopeExpr
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetCreateConstraints() *CreateConstraints {
+	if x != nil {
+		return x.CreateConstraints
+	}
+	return nil
+}
+
+func (x *UpdateRoleRequest) GetImmutableKeys() []string {
+	if x != nil {
+		return x.ImmutableKeys
+	}
+	return nil
+}
+
+func (x *UpdateRoleRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateRoleRequest) GetMaxAssignments() int32 {
+	if x != nil && x.MaxAssignments != nil {
+		return *x.MaxAssignments
+	}
+	return 0
+}
+
+func (x *UpdateRoleRequest) GetOwnerActions() []string {
+	if x != nil {
+		return x.OwnerActions
+	}
+	return nil
+}
+
+func (x *UpdateRoleRequest) GetDefaultLabels() bool {
+	if x != nil {
+		return x.DefaultLabels
+	}
+	return false
+}
+
+type UpdateRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          *RoleInfo              `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoleResponse) Reset() {
+	*x = UpdateRoleResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoleResponse) ProtoMessage() {}
+
+func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRoleResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *UpdateRoleResponse) GetRole() *RoleInfo {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
+type DeleteRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoleRequest) Reset() {
+	*x = DeleteRoleRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoleRequest) ProtoMessage() {}
+
+func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoleRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DeleteRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoleResponse) Reset() {
+	*x = DeleteRoleResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoleResponse) ProtoMessage() {}
+
+func (x *DeleteRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRoleResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DeleteRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AssignRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"` // "user" or "service_account"
+	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`       // UUID
+	RoleName      string                 `protobuf:"bytes,3,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRoleRequest) Reset() {
+	*x = AssignRoleRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRoleRequest) ProtoMessage() {}
+
+func (x *AssignRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRoleRequest.ProtoReflect.Descriptor instead.
+func (*AssignRoleRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *AssignRoleRequest) GetPrincipalType() string {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return ""
+}
+
+func (x *AssignRoleRequest) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
+	}
+	return ""
+}
+
+func (x *AssignRoleRequest) GetRoleName() string {
+	if x != nil {
+		return x.RoleName
+	}
+	return ""
+}
+
+type AssignRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRoleResponse) Reset() {
+	*x = AssignRoleResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRoleResponse) ProtoMessage() {}
+
+func (x *AssignRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRoleResponse.ProtoReflect.Descriptor instead.
+func (*AssignRoleResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *AssignRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AssignRoleResponse) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+type RemoveRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"`
+	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+	RoleName      string                 `protobuf:"bytes,3,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRoleRequest) Reset() {
+	*x = RemoveRoleRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRoleRequest) ProtoMessage() {}
+
+func (x *RemoveRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRoleRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRoleRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *RemoveRoleRequest) GetPrincipalType() string {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return ""
+}
+
+func (x *RemoveRoleRequest) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
+	}
+	return ""
+}
+
+func (x *RemoveRoleRequest) GetRoleName() string {
+	if x != nil {
+		return x.RoleName
+	}
+	return ""
+}
+
+type RemoveRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRoleResponse) Reset() {
+	*x = RemoveRoleResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRoleResponse) ProtoMessage() {}
+
+func (x *RemoveRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRoleResponse.ProtoReflect.Descriptor instead.
+func (*RemoveRoleResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *RemoveRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListUserRolesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"`
+	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserRolesRequest) Reset() {
+	*x = ListUserRolesRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserRolesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserRolesRequest) ProtoMessage() {}
+
+func (x *ListUserRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserRolesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserRolesRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ListUserRolesRequest) GetPrincipalType() string {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return ""
+}
+
+func (x *ListUserRolesRequest) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
+	}
+	return ""
+}
+
+type RoleAssignmentInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RoleName         string                 `protobuf:"bytes,1,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	AssignedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	AssignedByUserId string                 `protobuf:"bytes,3,opt,name=assigned_by_user_id,json=assignedByUserId,proto3" json:"assigned_by_user_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RoleAssignmentInfo) Reset() {
+	*x = RoleAssignmentInfo{}
+	mi := &file_state_v1_state_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleAssignmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleAssignmentInfo) ProtoMessage() {}
+
+func (x *RoleAssignmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleAssignmentInfo.ProtoReflect.Descriptor instead.
+func (*RoleAssignmentInfo) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *RoleAssignmentInfo) GetRoleName() string {
+	if x != nil {
+		return x.RoleName
+	}
+	return ""
+}
+
+func (x *RoleAssignmentInfo) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *RoleAssignmentInfo) GetAssignedByUserId() string {
+	if x != nil {
+		return x.AssignedByUserId
+	}
+	return ""
+}
+
+type ListUserRolesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []*RoleAssignmentInfo  `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserRolesResponse) Reset() {
+	*x = ListUserRolesResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserRolesResponse) ProtoMessage() {}
+
+func (x *ListUserRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserRolesResponse.ProtoReflect.Descriptor instead.
+func (*ListUserRolesResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListUserRolesResponse) GetRoles() []*RoleAssignmentInfo {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type AssignGroupRoleRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	GroupName string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Group name as it appears in JWT claims (e.g., "dev-team", "platform-engineers")
+	RoleName  string                 `protobuf:"bytes,2,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	// Optional go-bexpr expression over the caller's token claims
+	// (e.g., 'department == "finance"'). When set, the role is granted only if
+	// the group is present AND the expression evaluates true. Empty = unconditional.
+	Condition     string `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignGroupRoleRequest) Reset() {
+	*x = AssignGroupRoleRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignGroupRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignGroupRoleRequest) ProtoMessage() {}
+
+func (x *AssignGroupRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignGroupRoleRequest.ProtoReflect.Descriptor instead.
+func (*AssignGroupRoleRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *AssignGroupRoleRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *AssignGroupRoleRequest) GetRoleName() string {
+	if x != nil {
+		return x.RoleName
+	}
+	return ""
+}
+
+func (x *AssignGroupRoleRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+type AssignGroupRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignGroupRoleResponse) Reset() {
+	*x = AssignGroupRoleResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignGroupRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignGroupRoleResponse) ProtoMessage() {}
+
+func (x *AssignGroupRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignGroupRoleResponse.ProtoReflect.Descriptor instead.
+func (*AssignGroupRoleResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *AssignGroupRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AssignGroupRoleResponse) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+type RemoveGroupRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	RoleName      string                 `protobuf:"bytes,2,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateStateLabelsResponse) Reset() {
-	*x = UpdateStateLabelsResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[45]
+func (x *RemoveGroupRoleRequest) Reset() {
+	*x = RemoveGroupRoleRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStateLabelsResponse) String() string {
+func (x *RemoveGroupRoleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStateLabelsResponse) ProtoMessage() {}
+func (*RemoveGroupRoleRequest) ProtoMessage() {}
 
-func (x *UpdateStateLabelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[45]
+func (x *RemoveGroupRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3282,68 +6317,47 @@ func (x *UpdateStateLabelsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStateLabelsResponse.ProtoReflect.Descriptor instead.
-func (*UpdateStateLabelsResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use RemoveGroupRoleRequest.ProtoReflect.Descriptor instead.
+func (*RemoveGroupRoleRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *UpdateStateLabelsResponse) GetStateId() string {
+func (x *RemoveGroupRoleRequest) GetGroupName() string {
 	if x != nil {
-		return x.StateId
+		return x.GroupName
 	}
 	return ""
 }
 
-func (x *UpdateStateLabelsResponse) GetLabels() map[string]*LabelValue {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
-}
-
-func (x *UpdateStateLabelsResponse) GetPolicyVersion() int32 {
-	if x != nil {
-		return x.PolicyVersion
-	}
-	return 0
-}
-
-func (x *UpdateStateLabelsResponse) GetComplianceStatus() string {
+func (x *RemoveGroupRoleRequest) GetRoleName() string {
 	if x != nil {
-		return x.ComplianceStatus
+		return x.RoleName
 	}
 	return ""
 }
 
-func (x *UpdateStateLabelsResponse) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-// GetLabelPolicyRequest retrieves the current policy.
-type GetLabelPolicyRequest struct {
+type RemoveGroupRoleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLabelPolicyRequest) Reset() {
-	*x = GetLabelPolicyRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[46]
+func (x *RemoveGroupRoleResponse) Reset() {
+	*x = RemoveGroupRoleResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLabelPolicyRequest) String() string {
+func (x *RemoveGroupRoleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLabelPolicyRequest) ProtoMessage() {}
+func (*RemoveGroupRoleResponse) ProtoMessage() {}
 
-func (x *GetLabelPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[46]
+func (x *RemoveGroupRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3354,37 +6368,40 @@ func (x *GetLabelPolicyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLabelPolicyRequest.ProtoReflect.Descriptor instead.
-func (*GetLabelPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use RemoveGroupRoleResponse.ProtoReflect.Descriptor instead.
+func (*RemoveGroupRoleResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{91}
 }
 
-// GetLabelPolicyResponse returns the label validation policy.
-type GetLabelPolicyResponse struct {
+func (x *RemoveGroupRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListGroupRolesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
-	PolicyJson    string                 `protobuf:"bytes,2,opt,name=policy_json,json=policyJson,proto3" json:"policy_json,omitempty"` // JSON-encoded PolicyDefinition
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	GroupName     *string                `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3,oneof" json:"group_name,omitempty"` // If provided, list roles for specific group; if omitted, list all group-role mappings
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLabelPolicyResponse) Reset() {
-	*x = GetLabelPolicyResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[47]
+func (x *ListGroupRolesRequest) Reset() {
+	*x = ListGroupRolesRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLabelPolicyResponse) String() string {
+func (x *ListGroupRolesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLabelPolicyResponse) ProtoMessage() {}
+func (*ListGroupRolesRequest) ProtoMessage() {}
 
-func (x *GetLabelPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[47]
+func (x *ListGroupRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3395,63 +6412,44 @@ func (x *GetLabelPolicyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLabelPolicyResponse.ProtoReflect.Descriptor instead.
-func (*GetLabelPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{47}
-}
-
-func (x *GetLabelPolicyResponse) GetVersion() int32 {
-	if x != nil {
-		return x.Version
-	}
-	return 0
+// Deprecated: Use ListGroupRolesRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupRolesRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *GetLabelPolicyResponse) GetPolicyJson() string {
-	if x != nil {
-		return x.PolicyJson
+func (x *ListGroupRolesRequest) GetGroupName() string {
+	if x != nil && x.GroupName != nil {
+		return *x.GroupName
 	}
 	return ""
 }
 
-func (x *GetLabelPolicyResponse) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *GetLabelPolicyResponse) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-// SetLabelPolicyRequest updates the policy.
-type SetLabelPolicyRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// JSON-encoded PolicyDefinition (see data-model.md)
-	PolicyJson    string `protobuf:"bytes,1,opt,name=policy_json,json=policyJson,proto3" json:"policy_json,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GroupRoleAssignmentInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	GroupName        string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	RoleName         string                 `protobuf:"bytes,2,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	AssignedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	AssignedByUserId string                 `protobuf:"bytes,4,opt,name=assigned_by_user_id,json=assignedByUserId,proto3" json:"assigned_by_user_id,omitempty"`
+	Condition        string                 `protobuf:"bytes,5,opt,name=condition,proto3" json:"condition,omitempty"` // Empty when the mapping is unconditional
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *SetLabelPolicyRequest) Reset() {
-	*x = SetLabelPolicyRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[48]
+func (x *GroupRoleAssignmentInfo) Reset() {
+	*x = GroupRoleAssignmentInfo{}
+	mi := &file_state_v1_state_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetLabelPolicyRequest) String() string {
+func (x *GroupRoleAssignmentInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetLabelPolicyRequest) ProtoMessage() {}
+func (*GroupRoleAssignmentInfo) ProtoMessage() {}
 
-func (x *SetLabelPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[48]
+func (x *GroupRoleAssignmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3462,42 +6460,68 @@ func (x *SetLabelPolicyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetLabelPolicyRequest.ProtoReflect.Descriptor instead.
-func (*SetLabelPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use GroupRoleAssignmentInfo.ProtoReflect.Descriptor instead.
+func (*GroupRoleAssignmentInfo) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *SetLabelPolicyRequest) GetPolicyJson() string {
+func (x *GroupRoleAssignmentInfo) GetGroupName() string {
 	if x != nil {
-		return x.PolicyJson
+		return x.GroupName
 	}
 	return ""
 }
 
-// SetLabelPolicyResponse confirms policy update.
-type SetLabelPolicyResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // Incremented version number
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+func (x *GroupRoleAssignmentInfo) GetRoleName() string {
+	if x != nil {
+		return x.RoleName
+	}
+	return ""
+}
+
+func (x *GroupRoleAssignmentInfo) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *GroupRoleAssignmentInfo) GetAssignedByUserId() string {
+	if x != nil {
+		return x.AssignedByUserId
+	}
+	return ""
+}
+
+func (x *GroupRoleAssignmentInfo) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+type ListGroupRolesResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Assignments   []*GroupRoleAssignmentInfo `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetLabelPolicyResponse) Reset() {
-	*x = SetLabelPolicyResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[49]
+func (x *ListGroupRolesResponse) Reset() {
+	*x = ListGroupRolesResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetLabelPolicyResponse) String() string {
+func (x *ListGroupRolesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetLabelPolicyResponse) ProtoMessage() {}
+func (*ListGroupRolesResponse) ProtoMessage() {}
 
-func (x *SetLabelPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[49]
+func (x *ListGroupRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3508,48 +6532,41 @@ func (x *SetLabelPolicyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetLabelPolicyResponse.ProtoReflect.Descriptor instead.
-func (*SetLabelPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{49}
-}
-
-func (x *SetLabelPolicyResponse) GetVersion() int32 {
-	if x != nil {
-		return x.Version
-	}
-	return 0
+// Deprecated: Use ListGroupRolesResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupRolesResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *SetLabelPolicyResponse) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *ListGroupRolesResponse) GetAssignments() []*GroupRoleAssignmentInfo {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Assignments
 	}
 	return nil
 }
 
-type CreateServiceAccountRequest struct {
+type GetEffectivePermissionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"`
+	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateServiceAccountRequest) Reset() {
-	*x = CreateServiceAccountRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[50]
+func (x *GetEffectivePermissionsRequest) Reset() {
+	*x = GetEffectivePermissionsRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateServiceAccountRequest) String() string {
+func (x *GetEffectivePermissionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateServiceAccountRequest) ProtoMessage() {}
+func (*GetEffectivePermissionsRequest) ProtoMessage() {}
 
-func (x *CreateServiceAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[50]
+func (x *GetEffectivePermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3560,51 +6577,51 @@ func (x *CreateServiceAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateServiceAccountRequest.ProtoReflect.Descriptor instead.
-func (*CreateServiceAccountRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use GetEffectivePermissionsRequest.ProtoReflect.Descriptor instead.
+func (*GetEffectivePermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *CreateServiceAccountRequest) GetName() string {
+func (x *GetEffectivePermissionsRequest) GetPrincipalType() string {
 	if x != nil {
-		return x.Name
+		return x.PrincipalType
 	}
 	return ""
 }
 
-func (x *CreateServiceAccountRequest) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+func (x *GetEffectivePermissionsRequest) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
 	}
 	return ""
 }
 
-type CreateServiceAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ClientSecret  string                 `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // Only returned once on creation
-	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type EffectivePermissions struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Roles                      []string               `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`                                              // Role names
+	Actions                    []string               `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`                                          // Aggregated actions (e.g., ["state:*", "tfstate:read"])
+	LabelScopeExprs            []string               `protobuf:"bytes,3,rep,name=label_scope_exprs,json=labelScopeExprs,proto3" json:"label_scope_exprs,omitempty"` // List of go-bexpr expressions from all roles (union/OR semantics - access granted if ANY expression matches)
+	EffectiveCreateConstraints *CreateConstraints     `protobuf:"bytes,4,opt,name=effective_create_constraints,json=effectiveCreateConstraints,proto3,oneof" json:"effective_create_constraints,omitempty"`
+	EffectiveImmutableKeys     []string               `protobuf:"bytes,5,rep,name=effective_immutable_keys,json=effectiveImmutableKeys,proto3" json:"effective_immutable_keys,omitempty"` // Union of all immutable keys
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
-func (x *CreateServiceAccountResponse) Reset() {
-	*x = CreateServiceAccountResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[51]
+func (x *EffectivePermissions) Reset() {
+	*x = EffectivePermissions{}
+	mi := &file_state_v1_state_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateServiceAccountResponse) String() string {
+func (x *EffectivePermissions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateServiceAccountResponse) ProtoMessage() {}
+func (*EffectivePermissions) ProtoMessage() {}
 
-func (x *CreateServiceAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[51]
+func (x *EffectivePermissions) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3615,67 +6632,72 @@ func (x *CreateServiceAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateServiceAccountResponse.ProtoReflect.Descriptor instead.
-func (*CreateServiceAccountResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use EffectivePermissions.ProtoReflect.Descriptor instead.
+func (*EffectivePermissions) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *CreateServiceAccountResponse) GetId() string {
+func (x *EffectivePermissions) GetRoles() []string {
 	if x != nil {
-		return x.Id
+		return x.Roles
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateServiceAccountResponse) GetClientId() string {
+func (x *EffectivePermissions) GetActions() []string {
 	if x != nil {
-		return x.ClientId
+		return x.Actions
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateServiceAccountResponse) GetClientSecret() string {
+func (x *EffectivePermissions) GetLabelScopeExprs() []string {
 	if x != nil {
-		return x.ClientSecret
+		return x.LabelScopeExprs
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateServiceAccountResponse) GetName() string {
+func (x *EffectivePermissions) GetEffectiveCreateConstraints() *CreateConstraints {
 	if x != nil {
-		return x.Name
+		return x.EffectiveCreateConstraints
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateServiceAccountResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (x *EffectivePermissions) GetEffectiveImmutableKeys() []string {
 	if x != nil {
-		return x.CreatedAt
+		return x.EffectiveImmutableKeys
 	}
 	return nil
 }
 
-type ListServiceAccountsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetEffectivePermissionsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Permissions *EffectivePermissions  `protobuf:"bytes,1,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	// Changes whenever roles, role assignments or group mappings change; cached
+	// permissions are stale once it differs. Derived from the model, so it is
+	// the same on every replica and across restarts.
+	AuthzModelVersion uint64 `protobuf:"varint,2,opt,name=authz_model_version,json=authzModelVersion,proto3" json:"authz_model_version,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ListServiceAccountsRequest) Reset() {
-	*x = ListServiceAccountsRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[52]
+func (x *GetEffectivePermissionsResponse) Reset() {
+	*x = GetEffectivePermissionsResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListServiceAccountsRequest) String() string {
+func (x *GetEffectivePermissionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListServiceAccountsRequest) ProtoMessage() {}
+func (*GetEffectivePermissionsResponse) ProtoMessage() {}
 
-func (x *ListServiceAccountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[52]
+func (x *GetEffectivePermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3686,39 +6708,47 @@ func (x *ListServiceAccountsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListServiceAccountsRequest.ProtoReflect.Descriptor instead.
-func (*ListServiceAccountsRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{52}
+// Deprecated: Use GetEffectivePermissionsResponse.ProtoReflect.Descriptor instead.
+func (*GetEffectivePermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{97}
 }
 
-type ServiceAccountInfo struct {
+func (x *GetEffectivePermissionsResponse) GetPermissions() *EffectivePermissions {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *GetEffectivePermissionsResponse) GetAuthzModelVersion() uint64 {
+	if x != nil {
+		return x.AuthzModelVersion
+	}
+	return 0
+}
+
+type ListSessionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
-	Disabled      bool                   `protobuf:"varint,7,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServiceAccountInfo) Reset() {
-	*x = ServiceAccountInfo{}
-	mi := &file_state_v1_state_proto_msgTypes[53]
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServiceAccountInfo) String() string {
+func (x *ListSessionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServiceAccountInfo) ProtoMessage() {}
+func (*ListSessionsRequest) ProtoMessage() {}
 
-func (x *ServiceAccountInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[53]
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3729,126 +6759,148 @@ func (x *ServiceAccountInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServiceAccountInfo.ProtoReflect.Descriptor instead.
-func (*ServiceAccountInfo) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{53}
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *ServiceAccountInfo) GetId() string {
+func (x *ListSessionsRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *ServiceAccountInfo) GetClientId() string {
-	if x != nil {
-		return x.ClientId
-	}
-	return ""
+type SessionInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UserAgent        *string                `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3,oneof" json:"user_agent,omitempty"`
+	IpAddress        *string                `protobuf:"bytes,6,opt,name=ip_address,json=ipAddress,proto3,oneof" json:"ip_address,omitempty"`
+	UserId           *string                `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Revoked          bool                   `protobuf:"varint,8,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	ServiceAccountId *string                `protobuf:"bytes,9,opt,name=service_account_id,json=serviceAccountId,proto3,oneof" json:"service_account_id,omitempty"` // Set instead of user_id for service account sessions
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ServiceAccountInfo) GetName() string {
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_state_v1_state_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[99]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ServiceAccountInfo) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *SessionInfo) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ServiceAccountInfo) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SessionInfo) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *ServiceAccountInfo) GetLastUsedAt() *timestamppb.Timestamp {
+func (x *SessionInfo) GetLastUsedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.LastUsedAt
 	}
 	return nil
 }
 
-func (x *ServiceAccountInfo) GetDisabled() bool {
+func (x *SessionInfo) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Disabled
+		return x.ExpiresAt
 	}
-	return false
+	return nil
 }
 
-type ListServiceAccountsResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ServiceAccounts []*ServiceAccountInfo  `protobuf:"bytes,1,rep,name=service_accounts,json=serviceAccounts,proto3" json:"service_accounts,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *SessionInfo) GetUserAgent() string {
+	if x != nil && x.UserAgent != nil {
+		return *x.UserAgent
+	}
+	return ""
 }
 
-func (x *ListServiceAccountsResponse) Reset() {
-	*x = ListServiceAccountsResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[54]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SessionInfo) GetIpAddress() string {
+	if x != nil && x.IpAddress != nil {
+		return *x.IpAddress
+	}
+	return ""
 }
 
-func (x *ListServiceAccountsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SessionInfo) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
 }
 
-func (*ListServiceAccountsResponse) ProtoMessage() {}
-
-func (x *ListServiceAccountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[54]
+func (x *SessionInfo) GetRevoked() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Revoked
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListServiceAccountsResponse.ProtoReflect.Descriptor instead.
-func (*ListServiceAccountsResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{54}
+	return false
 }
 
-func (x *ListServiceAccountsResponse) GetServiceAccounts() []*ServiceAccountInfo {
-	if x != nil {
-		return x.ServiceAccounts
+func (x *SessionInfo) GetServiceAccountId() string {
+	if x != nil && x.ServiceAccountId != nil {
+		return *x.ServiceAccountId
 	}
-	return nil
+	return ""
 }
 
-type RevokeServiceAccountRequest struct {
+type ListSessionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sessions      []*SessionInfo         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeServiceAccountRequest) Reset() {
-	*x = RevokeServiceAccountRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[55]
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeServiceAccountRequest) String() string {
+func (x *ListSessionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeServiceAccountRequest) ProtoMessage() {}
+func (*ListSessionsResponse) ProtoMessage() {}
 
-func (x *RevokeServiceAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[55]
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3859,40 +6911,54 @@ func (x *RevokeServiceAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeServiceAccountRequest.ProtoReflect.Descriptor instead.
-func (*RevokeServiceAccountRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *RevokeServiceAccountRequest) GetClientId() string {
+func (x *ListSessionsResponse) GetSessions() []*SessionInfo {
 	if x != nil {
-		return x.ClientId
+		return x.Sessions
 	}
-	return ""
+	return nil
 }
 
-type RevokeServiceAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+// ListAllSessionsRequest filters and pages sessions across all users.
+type ListAllSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only sessions belonging to this user (empty = all users)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Exclude revoked and expired sessions
+	ActiveOnly bool `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	// Only sessions created after this time
+	CreatedAfter *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Max sessions per page (default 50, max 500)
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Sessions to skip, for paging
+	Offset int32 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Only sessions belonging to this service account
+	ServiceAccountId string `protobuf:"bytes,6,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	// Only revoked sessions (mutually exclusive with active_only)
+	RevokedOnly   bool `protobuf:"varint,7,opt,name=revoked_only,json=revokedOnly,proto3" json:"revoked_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeServiceAccountResponse) Reset() {
-	*x = RevokeServiceAccountResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[56]
+func (x *ListAllSessionsRequest) Reset() {
+	*x = ListAllSessionsRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeServiceAccountResponse) String() string {
+func (x *ListAllSessionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeServiceAccountResponse) ProtoMessage() {}
+func (*ListAllSessionsRequest) ProtoMessage() {}
 
-func (x *RevokeServiceAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[56]
+func (x *ListAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3903,86 +6969,85 @@ func (x *RevokeServiceAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeServiceAccountResponse.ProtoReflect.Descriptor instead.
-func (*RevokeServiceAccountResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use ListAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *RevokeServiceAccountResponse) GetSuccess() bool {
+func (x *ListAllSessionsRequest) GetUserId() string {
 	if x != nil {
-		return x.Success
+		return x.UserId
 	}
-	return false
+	return ""
 }
 
-type RotateServiceAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // Service account to rotate credentials for
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListAllSessionsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
 }
 
-func (x *RotateServiceAccountRequest) Reset() {
-	*x = RotateServiceAccountRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[57]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ListAllSessionsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
 }
 
-func (x *RotateServiceAccountRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ListAllSessionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
 }
 
-func (*RotateServiceAccountRequest) ProtoMessage() {}
-
-func (x *RotateServiceAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[57]
+func (x *ListAllSessionsRequest) GetOffset() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Offset
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use RotateServiceAccountRequest.ProtoReflect.Descriptor instead.
-func (*RotateServiceAccountRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{57}
+func (x *ListAllSessionsRequest) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
+	}
+	return ""
 }
 
-func (x *RotateServiceAccountRequest) GetClientId() string {
+func (x *ListAllSessionsRequest) GetRevokedOnly() bool {
 	if x != nil {
-		return x.ClientId
+		return x.RevokedOnly
 	}
-	return ""
+	return false
 }
 
-type RotateServiceAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`             // Same client_id (identity preserved)
-	ClientSecret  string                 `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // New secret (only returned once)
-	RotatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=rotated_at,json=rotatedAt,proto3" json:"rotated_at,omitempty"`
+// ListAllSessionsResponse returns one page of session metadata (never tokens).
+type ListAllSessionsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sessions []*SessionInfo         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	// Offset of the next page; 0 when there are no more sessions
+	NextOffset    int32 `protobuf:"varint,2,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RotateServiceAccountResponse) Reset() {
-	*x = RotateServiceAccountResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[58]
+func (x *ListAllSessionsResponse) Reset() {
+	*x = ListAllSessionsResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RotateServiceAccountResponse) String() string {
+func (x *ListAllSessionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RotateServiceAccountResponse) ProtoMessage() {}
+func (*ListAllSessionsResponse) ProtoMessage() {}
 
-func (x *RotateServiceAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[58]
+func (x *ListAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3993,59 +7058,56 @@ func (x *RotateServiceAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RotateServiceAccountResponse.ProtoReflect.Descriptor instead.
-func (*RotateServiceAccountResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{58}
-}
-
-func (x *RotateServiceAccountResponse) GetClientId() string {
-	if x != nil {
-		return x.ClientId
-	}
-	return ""
+// Deprecated: Use ListAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *RotateServiceAccountResponse) GetClientSecret() string {
+func (x *ListAllSessionsResponse) GetSessions() []*SessionInfo {
 	if x != nil {
-		return x.ClientSecret
+		return x.Sessions
 	}
-	return ""
+	return nil
 }
 
-func (x *RotateServiceAccountResponse) GetRotatedAt() *timestamppb.Timestamp {
+func (x *ListAllSessionsResponse) GetNextOffset() int32 {
 	if x != nil {
-		return x.RotatedAt
+		return x.NextOffset
 	}
-	return nil
+	return 0
 }
 
-type CreateRoleRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description       *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	Actions           []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`                                             // e.g., ["state:create", "tfstate:*"]
-	LabelScopeExpr    *string                `protobuf:"bytes,4,opt,name=label_scope_expr,json=labelScopeExpr,proto3,oneof" json:"label_scope_expr,omitempty"` // go-bexpr expression (e.g., "env == \"dev\"" or "env == \"dev\" and team == \"platform\" or team == \"sre\"")
-	CreateConstraints *CreateConstraints     `protobuf:"bytes,5,opt,name=create_constraints,json=createConstraints,proto3,oneof" json:"create_constraints,omitempty"`
-	ImmutableKeys     []string               `protobuf:"bytes,6,rep,name=immutable_keys,json=immutableKeys,proto3" json:"immutable_keys,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// RevokeSessionsRequest selects sessions to revoke. At least one of user_id,
+// service_account_id, created_before or created_after must be set.
+type RevokeSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only sessions belonging to this user
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Only sessions belonging to this service account
+	ServiceAccountId string `protobuf:"bytes,2,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	// Only sessions created before this time (e.g. a breach time)
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Only sessions created after this time
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoleRequest) Reset() {
-	*x = CreateRoleRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[59]
+func (x *RevokeSessionsRequest) Reset() {
+	*x = RevokeSessionsRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoleRequest) String() string {
+func (x *RevokeSessionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoleRequest) ProtoMessage() {}
+func (*RevokeSessionsRequest) ProtoMessage() {}
 
-func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[59]
+func (x *RevokeSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4056,76 +7118,106 @@ func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
-func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use RevokeSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *CreateRoleRequest) GetName() string {
+func (x *RevokeSessionsRequest) GetUserId() string {
 	if x != nil {
-		return x.Name
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *CreateRoleRequest) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+func (x *RevokeSessionsRequest) GetServiceAccountId() string {
+	if x != nil {
+		return x.ServiceAccountId
 	}
 	return ""
 }
 
-func (x *CreateRoleRequest) GetActions() []string {
+func (x *RevokeSessionsRequest) GetCreatedBefore() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Actions
+		return x.CreatedBefore
 	}
 	return nil
 }
 
-func (x *CreateRoleRequest) GetLabelScopeExpr() string {
-	if x != nil && x.LabelScopeExpr != nil {
-		return *x.LabelScopeExpr
+func (x *RevokeSessionsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateRoleRequest) GetCreateConstraints() *CreateConstraints {
+// RevokeSessionsResponse reports how many sessions were revoked.
+type RevokeSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RevokedCount  int32                  `protobuf:"varint,1,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionsResponse) Reset() {
+	*x = RevokeSessionsResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[104]
 	if x != nil {
-		return x.CreateConstraints
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CreateRoleRequest) GetImmutableKeys() []string {
+// Deprecated: Use RevokeSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *RevokeSessionsResponse) GetRevokedCount() int32 {
 	if x != nil {
-		return x.ImmutableKeys
+		return x.RevokedCount
 	}
-	return nil
+	return 0
 }
 
-type CreateConstraints struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Map of label key to constraint definition
-	Constraints   map[string]*CreateConstraint `protobuf:"bytes,1,rep,name=constraints,proto3" json:"constraints,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateConstraints) Reset() {
-	*x = CreateConstraints{}
-	mi := &file_state_v1_state_proto_msgTypes[60]
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateConstraints) String() string {
+func (x *RevokeSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateConstraints) ProtoMessage() {}
+func (*RevokeSessionRequest) ProtoMessage() {}
 
-func (x *CreateConstraints) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[60]
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4136,41 +7228,40 @@ func (x *CreateConstraints) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateConstraints.ProtoReflect.Descriptor instead.
-func (*CreateConstraints) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{60}
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *CreateConstraints) GetConstraints() map[string]*CreateConstraint {
+func (x *RevokeSessionRequest) GetSessionId() string {
 	if x != nil {
-		return x.Constraints
+		return x.SessionId
 	}
-	return nil
+	return ""
 }
 
-type CreateConstraint struct {
+type RevokeSessionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AllowedValues []string               `protobuf:"bytes,1,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
-	Required      bool                   `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateConstraint) Reset() {
-	*x = CreateConstraint{}
-	mi := &file_state_v1_state_proto_msgTypes[61]
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateConstraint) String() string {
+func (x *RevokeSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateConstraint) ProtoMessage() {}
+func (*RevokeSessionResponse) ProtoMessage() {}
 
-func (x *CreateConstraint) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[61]
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4181,56 +7272,41 @@ func (x *CreateConstraint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateConstraint.ProtoReflect.Descriptor instead.
-func (*CreateConstraint) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{61}
-}
-
-func (x *CreateConstraint) GetAllowedValues() []string {
-	if x != nil {
-		return x.AllowedValues
-	}
-	return nil
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *CreateConstraint) GetRequired() bool {
+func (x *RevokeSessionResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Required
+		return x.Success
 	}
 	return false
 }
 
-type RoleInfo struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description       *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	Actions           []string               `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`
-	LabelScopeExpr    *string                `protobuf:"bytes,5,opt,name=label_scope_expr,json=labelScopeExpr,proto3,oneof" json:"label_scope_expr,omitempty"` // go-bexpr expression evaluated at enforcement time
-	CreateConstraints *CreateConstraints     `protobuf:"bytes,6,opt,name=create_constraints,json=createConstraints,proto3,oneof" json:"create_constraints,omitempty"`
-	ImmutableKeys     []string               `protobuf:"bytes,7,rep,name=immutable_keys,json=immutableKeys,proto3" json:"immutable_keys,omitempty"`
-	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Version           int32                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// IntrospectTokenRequest asks for the status of an arbitrary bearer token.
+type IntrospectTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
-
-func (x *RoleInfo) Reset() {
-	*x = RoleInfo{}
-	mi := &file_state_v1_state_proto_msgTypes[62]
+
+func (x *IntrospectTokenRequest) Reset() {
+	*x = IntrospectTokenRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoleInfo) String() string {
+func (x *IntrospectTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoleInfo) ProtoMessage() {}
+func (*IntrospectTokenRequest) ProtoMessage() {}
 
-func (x *RoleInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[62]
+func (x *IntrospectTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4241,146 +7317,171 @@ func (x *RoleInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoleInfo.ProtoReflect.Descriptor instead.
-func (*RoleInfo) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{62}
+// Deprecated: Use IntrospectTokenRequest.ProtoReflect.Descriptor instead.
+func (*IntrospectTokenRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *RoleInfo) GetId() string {
+func (x *IntrospectTokenRequest) GetToken() string {
 	if x != nil {
-		return x.Id
+		return x.Token
 	}
 	return ""
 }
 
-func (x *RoleInfo) GetName() string {
+// IntrospectTokenResponse reports whether the token would currently be accepted
+// and the claims and session state that decision is based on.
+type IntrospectTokenResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Active         bool                   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	InactiveReason *string                `protobuf:"bytes,2,opt,name=inactive_reason,json=inactiveReason,proto3,oneof" json:"inactive_reason,omitempty"` // Why the token is inactive (unset when active)
+	Subject        *string                `protobuf:"bytes,3,opt,name=subject,proto3,oneof" json:"subject,omitempty"`
+	ClientId       *string                `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3,oneof" json:"client_id,omitempty"`
+	Scopes         []string               `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	IssuedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=issued_at,json=issuedAt,proto3,oneof" json:"issued_at,omitempty"`
+	Jti            *string                `protobuf:"bytes,8,opt,name=jti,proto3,oneof" json:"jti,omitempty"`
+	JtiRevoked     bool                   `protobuf:"varint,9,opt,name=jti_revoked,json=jtiRevoked,proto3" json:"jti_revoked,omitempty"`    // True if the jti is on the revocation denylist
+	SessionId      *string                `protobuf:"bytes,10,opt,name=session_id,json=sessionId,proto3,oneof" json:"session_id,omitempty"` // Backing session (internal IdP tokens only)
+	SessionRevoked bool                   `protobuf:"varint,11,opt,name=session_revoked,json=sessionRevoked,proto3" json:"session_revoked,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IntrospectTokenResponse) Reset() {
+	*x = IntrospectTokenResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntrospectTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntrospectTokenResponse) ProtoMessage() {}
+
+func (x *IntrospectTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[108]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *RoleInfo) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
-	}
-	return ""
+// Deprecated: Use IntrospectTokenResponse.ProtoReflect.Descriptor instead.
+func (*IntrospectTokenResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *RoleInfo) GetActions() []string {
+func (x *IntrospectTokenResponse) GetActive() bool {
 	if x != nil {
-		return x.Actions
+		return x.Active
 	}
-	return nil
+	return false
 }
 
-func (x *RoleInfo) GetLabelScopeExpr() string {
-	if x != nil && x.LabelScopeExpr != nil {
-		return *x.LabelScopeExpr
+func (x *IntrospectTokenResponse) GetInactiveReason() string {
+	if x != nil && x.InactiveReason != nil {
+		return *x.InactiveReason
 	}
 	return ""
 }
 
-func (x *RoleInfo) GetCreateConstraints() *CreateConstraints {
-	if x != nil {
-		return x.CreateConstraints
+func (x *IntrospectTokenResponse) GetSubject() string {
+	if x != nil && x.Subject != nil {
+		return *x.Subject
 	}
-	return nil
+	return ""
 }
 
-func (x *RoleInfo) GetImmutableKeys() []string {
-	if x != nil {
-		return x.ImmutableKeys
+func (x *IntrospectTokenResponse) GetClientId() string {
+	if x != nil && x.ClientId != nil {
+		return *x.ClientId
 	}
-	return nil
+	return ""
 }
 
-func (x *RoleInfo) GetCreatedAt() *timestamppb.Timestamp {
+func (x *IntrospectTokenResponse) GetScopes() []string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Scopes
 	}
 	return nil
 }
 
-func (x *RoleInfo) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *IntrospectTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdatedAt
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-func (x *RoleInfo) GetVersion() int32 {
+func (x *IntrospectTokenResponse) GetIssuedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Version
+		return x.IssuedAt
 	}
-	return 0
-}
-
-type CreateRoleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          *RoleInfo              `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateRoleResponse) Reset() {
-	*x = CreateRoleResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[63]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return nil
 }
 
-func (x *CreateRoleResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *IntrospectTokenResponse) GetJti() string {
+	if x != nil && x.Jti != nil {
+		return *x.Jti
+	}
+	return ""
 }
 
-func (*CreateRoleResponse) ProtoMessage() {}
-
-func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[63]
+func (x *IntrospectTokenResponse) GetJtiRevoked() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.JtiRevoked
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use CreateRoleResponse.ProtoReflect.Descriptor instead.
-func (*CreateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{63}
+func (x *IntrospectTokenResponse) GetSessionId() string {
+	if x != nil && x.SessionId != nil {
+		return *x.SessionId
+	}
+	return ""
 }
 
-func (x *CreateRoleResponse) GetRole() *RoleInfo {
+func (x *IntrospectTokenResponse) GetSessionRevoked() bool {
 	if x != nil {
-		return x.Role
+		return x.SessionRevoked
 	}
-	return nil
+	return false
 }
 
-type ListRolesRequest struct {
+// PreviewAuthorizationRequest describes a hypothetical principal and request.
+type PreviewAuthorizationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []string               `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`                                                                             // Role names; each must exist
+	Groups        []string               `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`                                                                           // IdP groups, resolved through group-role mappings (conditional mappings are not granted)
+	Object        string                 `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`                                                                           // Object type, e.g. "state", "policy", "admin"
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                                                                           // e.g. "state:read", "tfstate:write"
+	Labels        map[string]*LabelValue `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Labels of the target state
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRolesRequest) Reset() {
-	*x = ListRolesRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[64]
+func (x *PreviewAuthorizationRequest) Reset() {
+	*x = PreviewAuthorizationRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRolesRequest) String() string {
+func (x *PreviewAuthorizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRolesRequest) ProtoMessage() {}
+func (*PreviewAuthorizationRequest) ProtoMessage() {}
 
-func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[64]
+func (x *PreviewAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4391,83 +7492,73 @@ func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRolesRequest.ProtoReflect.Descriptor instead.
-func (*ListRolesRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{64}
-}
-
-type ListRolesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Roles         []*RoleInfo            `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Deprecated: Use PreviewAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*PreviewAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{109}
 }
 
-func (x *ListRolesResponse) Reset() {
-	*x = ListRolesResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[65]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PreviewAuthorizationRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
 }
 
-func (x *ListRolesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PreviewAuthorizationRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
 }
 
-func (*ListRolesResponse) ProtoMessage() {}
-
-func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[65]
+func (x *PreviewAuthorizationRequest) GetObject() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Object
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ListRolesResponse.ProtoReflect.Descriptor instead.
-func (*ListRolesResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{65}
+func (x *PreviewAuthorizationRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
 }
 
-func (x *ListRolesResponse) GetRoles() []*RoleInfo {
+func (x *PreviewAuthorizationRequest) GetLabels() map[string]*LabelValue {
 	if x != nil {
-		return x.Roles
+		return x.Labels
 	}
 	return nil
 }
 
-type UpdateRoleRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Role to update
-	Description       *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	Actions           []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
-	LabelScopeExpr    *string                `protobuf:"bytes,4,opt,name=label_scope_expr,json=labelScopeExpr,proto3,oneof" json:"label_scope_expr,omitempty"` // go-bexpr expression (e.g., "env == \"dev\" and team == \"platform\"")
-	CreateConstraints *CreateConstraints     `protobuf:"bytes,5,opt,name=create_constraints,json=createConstraints,proto3,oneof" json:"create_constraints,omitempty"`
-	ImmutableKeys     []string               `protobuf:"bytes,6,rep,name=immutable_keys,json=immutableKeys,proto3" json:"immutable_keys,omitempty"`
-	ExpectedVersion   int32                  `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Optimistic locking
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// RoleAuthorizationDecision is one role's verdict and the policy behind it.
+type RoleAuthorizationDecision struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Role    string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Allowed bool                   `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// Decisive policy in CreateRole form ("obj:act", "!" prefix for deny); unset when none matched
+	PolicyAction    *string `protobuf:"bytes,3,opt,name=policy_action,json=policyAction,proto3,oneof" json:"policy_action,omitempty"`
+	PolicyScopeExpr *string `protobuf:"bytes,4,opt,name=policy_scope_expr,json=policyScopeExpr,proto3,oneof" json:"policy_scope_expr,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *UpdateRoleRequest) Reset() {
-	*x = UpdateRoleRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[66]
+func (x *RoleAuthorizationDecision) Reset() {
+	*x = RoleAuthorizationDecision{}
+	mi := &file_state_v1_state_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRoleRequest) String() string {
+func (x *RoleAuthorizationDecision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRoleRequest) ProtoMessage() {}
+func (*RoleAuthorizationDecision) ProtoMessage() {}
 
-func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[66]
+func (x *RoleAuthorizationDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4478,82 +7569,65 @@ func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRoleRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{66}
+// Deprecated: Use RoleAuthorizationDecision.ProtoReflect.Descriptor instead.
+func (*RoleAuthorizationDecision) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *UpdateRoleRequest) GetName() string {
+func (x *RoleAuthorizationDecision) GetRole() string {
 	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *UpdateRoleRequest) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+		return x.Role
 	}
 	return ""
 }
 
-func (x *UpdateRoleRequest) GetActions() []string {
+func (x *RoleAuthorizationDecision) GetAllowed() bool {
 	if x != nil {
-		return x.Actions
+		return x.Allowed
 	}
-	return nil
+	return false
 }
 
-func (x *UpdateRoleRequest) GetLabelScopeExpr() string {
-	if x != nil && x.LabelScopeExpr != nil {
-		return *x.LabelScopeExpr
+func (x *RoleAuthorizationDecision) GetPolicyAction() string {
+	if x != nil && x.PolicyAction != nil {
+		return *x.PolicyAction
 	}
 	return ""
 }
 
-func (x *UpdateRoleRequest) GetCreateConstraints() *CreateConstraints {
-	if x != nil {
-		return x.CreateConstraints
-	}
-	return nil
-}
-
-func (x *UpdateRoleRequest) GetImmutableKeys() []string {
-	if x != nil {
-		return x.ImmutableKeys
-	}
-	return nil
-}
-
-func (x *UpdateRoleRequest) GetExpectedVersion() int32 {
-	if x != nil {
-		return x.ExpectedVersion
+func (x *RoleAuthorizationDecision) GetPolicyScopeExpr() string {
+	if x != nil && x.PolicyScopeExpr != nil {
+		return *x.PolicyScopeExpr
 	}
-	return 0
+	return ""
 }
 
-type UpdateRoleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          *RoleInfo              `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+// PreviewAuthorizationResponse is the decision with its explain trace.
+type PreviewAuthorizationResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Allowed       bool                         `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Combination   string                       `protobuf:"bytes,2,opt,name=combination,proto3" json:"combination,omitempty"` // "union" or "intersection"
+	Roles         []*RoleAuthorizationDecision `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`             // Explicit roles first, then group-mapped roles
+	Reason        string                       `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateRoleResponse) Reset() {
-	*x = UpdateRoleResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[67]
+func (x *PreviewAuthorizationResponse) Reset() {
+	*x = PreviewAuthorizationResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRoleResponse) String() string {
+func (x *PreviewAuthorizationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRoleResponse) ProtoMessage() {}
+func (*PreviewAuthorizationResponse) ProtoMessage() {}
 
-func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[67]
+func (x *PreviewAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4564,40 +7638,81 @@ func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRoleResponse.ProtoReflect.Descriptor instead.
-func (*UpdateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{67}
+// Deprecated: Use PreviewAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*PreviewAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *UpdateRoleResponse) GetRole() *RoleInfo {
+func (x *PreviewAuthorizationResponse) GetAllowed() bool {
 	if x != nil {
-		return x.Role
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *PreviewAuthorizationResponse) GetCombination() string {
+	if x != nil {
+		return x.Combination
+	}
+	return ""
+}
+
+func (x *PreviewAuthorizationResponse) GetRoles() []*RoleAuthorizationDecision {
+	if x != nil {
+		return x.Roles
 	}
 	return nil
 }
 
-type DeleteRoleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+func (x *PreviewAuthorizationResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// SetOutputSchemaRequest publishes or updates a JSON Schema for a specific state output.
+// Allows clients to declare expected output types before the output actually exists.
+type SetOutputSchemaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifier (logic_id or GUID)
+	//
+	// Types that are valid to be assigned to State:
+	//
+	//	*SetOutputSchemaRequest_StateLogicId
+	//	*SetOutputSchemaRequest_StateGuid
+	State isSetOutputSchemaRequest_State `protobuf_oneof:"state"`
+	// Name of the output (e.g., "vpc_id", "subnet_ids")
+	OutputKey string `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+	// JSON Schema definition for this output (must be valid JSON Schema)
+	// Example: {"type": "string", "pattern": "^vpc-[a-z0-9]+$"}
+	// Exactly one of schema_json and schema_ref must be set.
+	SchemaJson string `protobuf:"bytes,4,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"`
+	// Validate the output's current value inline and report the result in the
+	// response. By default validation runs asynchronously after the response.
+	ValidateNow bool `protobuf:"varint,5,opt,name=validate_now,json=validateNow,proto3" json:"validate_now,omitempty"`
+	// Schema registry key to use instead of inline schema_json (e.g. "aws/vpc_id@v1").
+	// The output follows later updates to the registered schema.
+	SchemaRef     string `protobuf:"bytes,6,opt,name=schema_ref,json=schemaRef,proto3" json:"schema_ref,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteRoleRequest) Reset() {
-	*x = DeleteRoleRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[68]
+func (x *SetOutputSchemaRequest) Reset() {
+	*x = SetOutputSchemaRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRoleRequest) String() string {
+func (x *SetOutputSchemaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRoleRequest) ProtoMessage() {}
+func (*SetOutputSchemaRequest) ProtoMessage() {}
 
-func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[68]
+func (x *SetOutputSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4608,86 +7723,111 @@ func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRoleRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRoleRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{68}
+// Deprecated: Use SetOutputSchemaRequest.ProtoReflect.Descriptor instead.
+func (*SetOutputSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *DeleteRoleRequest) GetName() string {
+func (x *SetOutputSchemaRequest) GetState() isSetOutputSchemaRequest_State {
 	if x != nil {
-		return x.Name
+		return x.State
 	}
-	return ""
+	return nil
 }
 
-type DeleteRoleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SetOutputSchemaRequest) GetStateLogicId() string {
+	if x != nil {
+		if x, ok := x.State.(*SetOutputSchemaRequest_StateLogicId); ok {
+			return x.StateLogicId
+		}
+	}
+	return ""
 }
 
-func (x *DeleteRoleResponse) Reset() {
-	*x = DeleteRoleResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[69]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SetOutputSchemaRequest) GetStateGuid() string {
+	if x != nil {
+		if x, ok := x.State.(*SetOutputSchemaRequest_StateGuid); ok {
+			return x.StateGuid
+		}
+	}
+	return ""
 }
 
-func (x *DeleteRoleResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SetOutputSchemaRequest) GetOutputKey() string {
+	if x != nil {
+		return x.OutputKey
+	}
+	return ""
 }
 
-func (*DeleteRoleResponse) ProtoMessage() {}
-
-func (x *DeleteRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[69]
+func (x *SetOutputSchemaRequest) GetSchemaJson() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SchemaJson
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use DeleteRoleResponse.ProtoReflect.Descriptor instead.
-func (*DeleteRoleResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{69}
+func (x *SetOutputSchemaRequest) GetValidateNow() bool {
+	if x != nil {
+		return x.ValidateNow
+	}
+	return false
 }
 
-func (x *DeleteRoleResponse) GetSuccess() bool {
+func (x *SetOutputSchemaRequest) GetSchemaRef() string {
 	if x != nil {
-		return x.Success
+		return x.SchemaRef
 	}
-	return false
+	return ""
 }
 
-type AssignRoleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"` // "user" or "service_account"
-	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`       // UUID
-	RoleName      string                 `protobuf:"bytes,3,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type isSetOutputSchemaRequest_State interface {
+	isSetOutputSchemaRequest_State()
 }
 
-func (x *AssignRoleRequest) Reset() {
-	*x = AssignRoleRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[70]
+type SetOutputSchemaRequest_StateLogicId struct {
+	StateLogicId string `protobuf:"bytes,1,opt,name=state_logic_id,json=stateLogicId,proto3,oneof"`
+}
+
+type SetOutputSchemaRequest_StateGuid struct {
+	StateGuid string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3,oneof"`
+}
+
+func (*SetOutputSchemaRequest_StateLogicId) isSetOutputSchemaRequest_State() {}
+
+func (*SetOutputSchemaRequest_StateGuid) isSetOutputSchemaRequest_State() {}
+
+// SetOutputSchemaResponse confirms schema publication.
+type SetOutputSchemaResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// State identifiers for confirmation
+	StateGuid    string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId string `protobuf:"bytes,3,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	OutputKey    string `protobuf:"bytes,4,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+	// Validation result when validate_now was set and the output has a value:
+	// "valid", "invalid", or "error". Unset otherwise.
+	ValidationStatus *string `protobuf:"bytes,5,opt,name=validation_status,json=validationStatus,proto3,oneof" json:"validation_status,omitempty"`
+	ValidationError  *string `protobuf:"bytes,6,opt,name=validation_error,json=validationError,proto3,oneof" json:"validation_error,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetOutputSchemaResponse) Reset() {
+	*x = SetOutputSchemaResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignRoleRequest) String() string {
+func (x *SetOutputSchemaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignRoleRequest) ProtoMessage() {}
+func (*SetOutputSchemaResponse) ProtoMessage() {}
 
-func (x *AssignRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[70]
+func (x *SetOutputSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4698,55 +7838,85 @@ func (x *AssignRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignRoleRequest.ProtoReflect.Descriptor instead.
-func (*AssignRoleRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{70}
+// Deprecated: Use SetOutputSchemaResponse.ProtoReflect.Descriptor instead.
+func (*SetOutputSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{113}
 }
 
-func (x *AssignRoleRequest) GetPrincipalType() string {
+func (x *SetOutputSchemaResponse) GetSuccess() bool {
 	if x != nil {
-		return x.PrincipalType
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetOutputSchemaResponse) GetStateGuid() string {
+	if x != nil {
+		return x.StateGuid
 	}
 	return ""
 }
 
-func (x *AssignRoleRequest) GetPrincipalId() string {
+func (x *SetOutputSchemaResponse) GetStateLogicId() string {
 	if x != nil {
-		return x.PrincipalId
+		return x.StateLogicId
 	}
 	return ""
 }
 
-func (x *AssignRoleRequest) GetRoleName() string {
+func (x *SetOutputSchemaResponse) GetOutputKey() string {
 	if x != nil {
-		return x.RoleName
+		return x.OutputKey
 	}
 	return ""
 }
 
-type AssignRoleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+func (x *SetOutputSchemaResponse) GetValidationStatus() string {
+	if x != nil && x.ValidationStatus != nil {
+		return *x.ValidationStatus
+	}
+	return ""
+}
+
+func (x *SetOutputSchemaResponse) GetValidationError() string {
+	if x != nil && x.ValidationError != nil {
+		return *x.ValidationError
+	}
+	return ""
+}
+
+// SetOutputSchemasRequest publishes or updates JSON Schemas for many outputs.
+// Either every schema is stored or none are.
+type SetOutputSchemasRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifier (logic_id or GUID)
+	//
+	// Types that are valid to be assigned to State:
+	//
+	//	*SetOutputSchemasRequest_StateLogicId
+	//	*SetOutputSchemasRequest_StateGuid
+	State isSetOutputSchemasRequest_State `protobuf_oneof:"state"`
+	// Output key -> JSON Schema definition (each must be valid JSON Schema)
+	Schemas       map[string]string `protobuf:"bytes,3,rep,name=schemas,proto3" json:"schemas,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignRoleResponse) Reset() {
-	*x = AssignRoleResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[71]
+func (x *SetOutputSchemasRequest) Reset() {
+	*x = SetOutputSchemasRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignRoleResponse) String() string {
+func (x *SetOutputSchemasRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignRoleResponse) ProtoMessage() {}
+func (*SetOutputSchemasRequest) ProtoMessage() {}
 
-func (x *AssignRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[71]
+func (x *SetOutputSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4757,49 +7927,84 @@ func (x *AssignRoleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignRoleResponse.ProtoReflect.Descriptor instead.
-func (*AssignRoleResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{71}
+// Deprecated: Use SetOutputSchemasRequest.ProtoReflect.Descriptor instead.
+func (*SetOutputSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *AssignRoleResponse) GetSuccess() bool {
+func (x *SetOutputSchemasRequest) GetState() isSetOutputSchemasRequest_State {
 	if x != nil {
-		return x.Success
+		return x.State
 	}
-	return false
+	return nil
 }
 
-func (x *AssignRoleResponse) GetAssignedAt() *timestamppb.Timestamp {
+func (x *SetOutputSchemasRequest) GetStateLogicId() string {
 	if x != nil {
-		return x.AssignedAt
+		if x, ok := x.State.(*SetOutputSchemasRequest_StateLogicId); ok {
+			return x.StateLogicId
+		}
+	}
+	return ""
+}
+
+func (x *SetOutputSchemasRequest) GetStateGuid() string {
+	if x != nil {
+		if x, ok := x.State.(*SetOutputSchemasRequest_StateGuid); ok {
+			return x.StateGuid
+		}
+	}
+	return ""
+}
+
+func (x *SetOutputSchemasRequest) GetSchemas() map[string]string {
+	if x != nil {
+		return x.Schemas
 	}
 	return nil
 }
 
-type RemoveRoleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"`
-	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
-	RoleName      string                 `protobuf:"bytes,3,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+type isSetOutputSchemasRequest_State interface {
+	isSetOutputSchemasRequest_State()
+}
+
+type SetOutputSchemasRequest_StateLogicId struct {
+	StateLogicId string `protobuf:"bytes,1,opt,name=state_logic_id,json=stateLogicId,proto3,oneof"`
+}
+
+type SetOutputSchemasRequest_StateGuid struct {
+	StateGuid string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3,oneof"`
+}
+
+func (*SetOutputSchemasRequest_StateLogicId) isSetOutputSchemasRequest_State() {}
+
+func (*SetOutputSchemasRequest_StateGuid) isSetOutputSchemasRequest_State() {}
+
+// OutputSchemaResult reports how a single schema in a bulk write was stored.
+type OutputSchemaResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OutputKey string                 `protobuf:"bytes,1,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+	// True if the output had no schema before this call, false if one was replaced
+	Created       bool `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveRoleRequest) Reset() {
-	*x = RemoveRoleRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[72]
+func (x *OutputSchemaResult) Reset() {
+	*x = OutputSchemaResult{}
+	mi := &file_state_v1_state_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveRoleRequest) String() string {
+func (x *OutputSchemaResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveRoleRequest) ProtoMessage() {}
+func (*OutputSchemaResult) ProtoMessage() {}
 
-func (x *RemoveRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[72]
+func (x *OutputSchemaResult) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4810,54 +8015,52 @@ func (x *RemoveRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveRoleRequest.ProtoReflect.Descriptor instead.
-func (*RemoveRoleRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{72}
-}
-
-func (x *RemoveRoleRequest) GetPrincipalType() string {
-	if x != nil {
-		return x.PrincipalType
-	}
-	return ""
+// Deprecated: Use OutputSchemaResult.ProtoReflect.Descriptor instead.
+func (*OutputSchemaResult) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{115}
 }
 
-func (x *RemoveRoleRequest) GetPrincipalId() string {
+func (x *OutputSchemaResult) GetOutputKey() string {
 	if x != nil {
-		return x.PrincipalId
+		return x.OutputKey
 	}
 	return ""
 }
 
-func (x *RemoveRoleRequest) GetRoleName() string {
+func (x *OutputSchemaResult) GetCreated() bool {
 	if x != nil {
-		return x.RoleName
+		return x.Created
 	}
-	return ""
+	return false
 }
 
-type RemoveRoleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+// SetOutputSchemasResponse confirms bulk schema publication.
+type SetOutputSchemasResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifiers for confirmation
+	StateGuid    string `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId string `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	// One result per output key, sorted by output_key
+	Results       []*OutputSchemaResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveRoleResponse) Reset() {
-	*x = RemoveRoleResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[73]
+func (x *SetOutputSchemasResponse) Reset() {
+	*x = SetOutputSchemasResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveRoleResponse) String() string {
+func (x *SetOutputSchemasResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveRoleResponse) ProtoMessage() {}
+func (*SetOutputSchemasResponse) ProtoMessage() {}
 
-func (x *RemoveRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[73]
+func (x *SetOutputSchemasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4865,44 +8068,67 @@ func (x *RemoveRoleResponse) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOutputSchemasResponse.ProtoReflect.Descriptor instead.
+func (*SetOutputSchemasResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *SetOutputSchemasResponse) GetStateGuid() string {
+	if x != nil {
+		return x.StateGuid
+	}
+	return ""
 }
 
-// Deprecated: Use RemoveRoleResponse.ProtoReflect.Descriptor instead.
-func (*RemoveRoleResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{73}
+func (x *SetOutputSchemasResponse) GetStateLogicId() string {
+	if x != nil {
+		return x.StateLogicId
+	}
+	return ""
 }
 
-func (x *RemoveRoleResponse) GetSuccess() bool {
+func (x *SetOutputSchemasResponse) GetResults() []*OutputSchemaResult {
 	if x != nil {
-		return x.Success
+		return x.Results
 	}
-	return false
+	return nil
 }
 
-type ListUserRolesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"`
-	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+// RevalidateOutputsForSchemaRequest names the output whose schema selects the
+// outputs to re-validate. A schema registry key may be added as another selector.
+type RevalidateOutputsForSchemaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifier (logic_id or GUID)
+	//
+	// Types that are valid to be assigned to State:
+	//
+	//	*RevalidateOutputsForSchemaRequest_StateLogicId
+	//	*RevalidateOutputsForSchemaRequest_StateGuid
+	State isRevalidateOutputsForSchemaRequest_State `protobuf_oneof:"state"`
+	// Output whose current schema is matched (exact schema text)
+	OutputKey     string `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUserRolesRequest) Reset() {
-	*x = ListUserRolesRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[74]
+func (x *RevalidateOutputsForSchemaRequest) Reset() {
+	*x = RevalidateOutputsForSchemaRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUserRolesRequest) String() string {
+func (x *RevalidateOutputsForSchemaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUserRolesRequest) ProtoMessage() {}
+func (*RevalidateOutputsForSchemaRequest) ProtoMessage() {}
 
-func (x *ListUserRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[74]
+func (x *RevalidateOutputsForSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4913,49 +8139,86 @@ func (x *ListUserRolesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUserRolesRequest.ProtoReflect.Descriptor instead.
-func (*ListUserRolesRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{74}
+// Deprecated: Use RevalidateOutputsForSchemaRequest.ProtoReflect.Descriptor instead.
+func (*RevalidateOutputsForSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{117}
 }
 
-func (x *ListUserRolesRequest) GetPrincipalType() string {
+func (x *RevalidateOutputsForSchemaRequest) GetState() isRevalidateOutputsForSchemaRequest_State {
 	if x != nil {
-		return x.PrincipalType
+		return x.State
+	}
+	return nil
+}
+
+func (x *RevalidateOutputsForSchemaRequest) GetStateLogicId() string {
+	if x != nil {
+		if x, ok := x.State.(*RevalidateOutputsForSchemaRequest_StateLogicId); ok {
+			return x.StateLogicId
+		}
 	}
 	return ""
 }
 
-func (x *ListUserRolesRequest) GetPrincipalId() string {
+func (x *RevalidateOutputsForSchemaRequest) GetStateGuid() string {
 	if x != nil {
-		return x.PrincipalId
+		if x, ok := x.State.(*RevalidateOutputsForSchemaRequest_StateGuid); ok {
+			return x.StateGuid
+		}
 	}
 	return ""
 }
 
-type RoleAssignmentInfo struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	RoleName         string                 `protobuf:"bytes,1,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
-	AssignedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
-	AssignedByUserId string                 `protobuf:"bytes,3,opt,name=assigned_by_user_id,json=assignedByUserId,proto3" json:"assigned_by_user_id,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *RevalidateOutputsForSchemaRequest) GetOutputKey() string {
+	if x != nil {
+		return x.OutputKey
+	}
+	return ""
 }
 
-func (x *RoleAssignmentInfo) Reset() {
-	*x = RoleAssignmentInfo{}
-	mi := &file_state_v1_state_proto_msgTypes[75]
+type isRevalidateOutputsForSchemaRequest_State interface {
+	isRevalidateOutputsForSchemaRequest_State()
+}
+
+type RevalidateOutputsForSchemaRequest_StateLogicId struct {
+	StateLogicId string `protobuf:"bytes,1,opt,name=state_logic_id,json=stateLogicId,proto3,oneof"`
+}
+
+type RevalidateOutputsForSchemaRequest_StateGuid struct {
+	StateGuid string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3,oneof"`
+}
+
+func (*RevalidateOutputsForSchemaRequest_StateLogicId) isRevalidateOutputsForSchemaRequest_State() {}
+
+func (*RevalidateOutputsForSchemaRequest_StateGuid) isRevalidateOutputsForSchemaRequest_State() {}
+
+// OutputValidationTransition is an output whose validation status changed.
+type OutputValidationTransition struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StateGuid      string                 `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId   string                 `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	OutputKey      string                 `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,4,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"` // Empty if the output had not been validated
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OutputValidationTransition) Reset() {
+	*x = OutputValidationTransition{}
+	mi := &file_state_v1_state_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoleAssignmentInfo) String() string {
+func (x *OutputValidationTransition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoleAssignmentInfo) ProtoMessage() {}
+func (*OutputValidationTransition) ProtoMessage() {}
 
-func (x *RoleAssignmentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[75]
+func (x *OutputValidationTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4966,54 +8229,74 @@ func (x *RoleAssignmentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoleAssignmentInfo.ProtoReflect.Descriptor instead.
-func (*RoleAssignmentInfo) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{75}
+// Deprecated: Use OutputValidationTransition.ProtoReflect.Descriptor instead.
+func (*OutputValidationTransition) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{118}
 }
 
-func (x *RoleAssignmentInfo) GetRoleName() string {
+func (x *OutputValidationTransition) GetStateGuid() string {
 	if x != nil {
-		return x.RoleName
+		return x.StateGuid
 	}
 	return ""
 }
 
-func (x *RoleAssignmentInfo) GetAssignedAt() *timestamppb.Timestamp {
+func (x *OutputValidationTransition) GetStateLogicId() string {
 	if x != nil {
-		return x.AssignedAt
+		return x.StateLogicId
 	}
-	return nil
+	return ""
 }
 
-func (x *RoleAssignmentInfo) GetAssignedByUserId() string {
+func (x *OutputValidationTransition) GetOutputKey() string {
 	if x != nil {
-		return x.AssignedByUserId
+		return x.OutputKey
 	}
 	return ""
 }
 
-type ListUserRolesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Roles         []*RoleAssignmentInfo  `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+func (x *OutputValidationTransition) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *OutputValidationTransition) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type RevalidateOutputsForSchemaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Outputs with a value that were validated again
+	Revalidated int32 `protobuf:"varint,1,opt,name=revalidated,proto3" json:"revalidated,omitempty"`
+	// Outputs that moved from valid to invalid, and from invalid to valid
+	BecameInvalid int32 `protobuf:"varint,2,opt,name=became_invalid,json=becameInvalid,proto3" json:"became_invalid,omitempty"`
+	BecameValid   int32 `protobuf:"varint,3,opt,name=became_valid,json=becameValid,proto3" json:"became_valid,omitempty"`
+	// Status changes in states visible to the caller, by state then output key
+	Transitions   []*OutputValidationTransition `protobuf:"bytes,4,rep,name=transitions,proto3" json:"transitions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUserRolesResponse) Reset() {
-	*x = ListUserRolesResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[76]
+func (x *RevalidateOutputsForSchemaResponse) Reset() {
+	*x = RevalidateOutputsForSchemaResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUserRolesResponse) String() string {
+func (x *RevalidateOutputsForSchemaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUserRolesResponse) ProtoMessage() {}
+func (*RevalidateOutputsForSchemaResponse) ProtoMessage() {}
 
-func (x *ListUserRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[76]
+func (x *RevalidateOutputsForSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5024,41 +8307,70 @@ func (x *ListUserRolesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUserRolesResponse.ProtoReflect.Descriptor instead.
-func (*ListUserRolesResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{76}
+// Deprecated: Use RevalidateOutputsForSchemaResponse.ProtoReflect.Descriptor instead.
+func (*RevalidateOutputsForSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{119}
 }
 
-func (x *ListUserRolesResponse) GetRoles() []*RoleAssignmentInfo {
+func (x *RevalidateOutputsForSchemaResponse) GetRevalidated() int32 {
 	if x != nil {
-		return x.Roles
+		return x.Revalidated
+	}
+	return 0
+}
+
+func (x *RevalidateOutputsForSchemaResponse) GetBecameInvalid() int32 {
+	if x != nil {
+		return x.BecameInvalid
+	}
+	return 0
+}
+
+func (x *RevalidateOutputsForSchemaResponse) GetBecameValid() int32 {
+	if x != nil {
+		return x.BecameValid
+	}
+	return 0
+}
+
+func (x *RevalidateOutputsForSchemaResponse) GetTransitions() []*OutputValidationTransition {
+	if x != nil {
+		return x.Transitions
 	}
 	return nil
 }
 
-type AssignGroupRoleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Group name as it appears in JWT claims (e.g., "dev-team", "platform-engineers")
-	RoleName      string                 `protobuf:"bytes,2,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+// GetOutputSchemaRequest retrieves the JSON Schema for a specific state output.
+type GetOutputSchemaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifier (logic_id or GUID)
+	//
+	// Types that are valid to be assigned to State:
+	//
+	//	*GetOutputSchemaRequest_StateLogicId
+	//	*GetOutputSchemaRequest_StateGuid
+	State isGetOutputSchemaRequest_State `protobuf_oneof:"state"`
+	// Name of the output to get schema for
+	OutputKey     string `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignGroupRoleRequest) Reset() {
-	*x = AssignGroupRoleRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[77]
+func (x *GetOutputSchemaRequest) Reset() {
+	*x = GetOutputSchemaRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignGroupRoleRequest) String() string {
+func (x *GetOutputSchemaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignGroupRoleRequest) ProtoMessage() {}
+func (*GetOutputSchemaRequest) ProtoMessage() {}
 
-func (x *AssignGroupRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[77]
+func (x *GetOutputSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5069,100 +8381,179 @@ func (x *AssignGroupRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignGroupRoleRequest.ProtoReflect.Descriptor instead.
-func (*AssignGroupRoleRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{77}
+// Deprecated: Use GetOutputSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetOutputSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *AssignGroupRoleRequest) GetGroupName() string {
+func (x *GetOutputSchemaRequest) GetState() isGetOutputSchemaRequest_State {
 	if x != nil {
-		return x.GroupName
+		return x.State
+	}
+	return nil
+}
+
+func (x *GetOutputSchemaRequest) GetStateLogicId() string {
+	if x != nil {
+		if x, ok := x.State.(*GetOutputSchemaRequest_StateLogicId); ok {
+			return x.StateLogicId
+		}
 	}
 	return ""
 }
 
-func (x *AssignGroupRoleRequest) GetRoleName() string {
+func (x *GetOutputSchemaRequest) GetStateGuid() string {
 	if x != nil {
-		return x.RoleName
+		if x, ok := x.State.(*GetOutputSchemaRequest_StateGuid); ok {
+			return x.StateGuid
+		}
 	}
 	return ""
 }
 
-type AssignGroupRoleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+func (x *GetOutputSchemaRequest) GetOutputKey() string {
+	if x != nil {
+		return x.OutputKey
+	}
+	return ""
+}
+
+type isGetOutputSchemaRequest_State interface {
+	isGetOutputSchemaRequest_State()
+}
+
+type GetOutputSchemaRequest_StateLogicId struct {
+	StateLogicId string `protobuf:"bytes,1,opt,name=state_logic_id,json=stateLogicId,proto3,oneof"`
+}
+
+type GetOutputSchemaRequest_StateGuid struct {
+	StateGuid string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3,oneof"`
+}
+
+func (*GetOutputSchemaRequest_StateLogicId) isGetOutputSchemaRequest_State() {}
+
+func (*GetOutputSchemaRequest_StateGuid) isGetOutputSchemaRequest_State() {}
+
+// GetOutputSchemaResponse returns the JSON Schema for an output.
+type GetOutputSchemaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifiers
+	StateGuid    string `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId string `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	// Output name
+	OutputKey string `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+	// JSON Schema definition (empty string if no schema has been set)
+	SchemaJson string `protobuf:"bytes,4,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"`
+	// Schema registry key the schema was set from (empty for inline schemas)
+	SchemaRef string `protobuf:"bytes,5,opt,name=schema_ref,json=schemaRef,proto3" json:"schema_ref,omitempty"`
+	// Version of this schema in the output's schema history (0 if no schema)
+	SchemaVersion int32 `protobuf:"varint,6,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignGroupRoleResponse) Reset() {
-	*x = AssignGroupRoleResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[78]
+func (x *GetOutputSchemaResponse) Reset() {
+	*x = GetOutputSchemaResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignGroupRoleResponse) String() string {
+func (x *GetOutputSchemaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignGroupRoleResponse) ProtoMessage() {}
+func (*GetOutputSchemaResponse) ProtoMessage() {}
+
+func (x *GetOutputSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputSchemaResponse.ProtoReflect.Descriptor instead.
+func (*GetOutputSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *GetOutputSchemaResponse) GetStateGuid() string {
+	if x != nil {
+		return x.StateGuid
+	}
+	return ""
+}
+
+func (x *GetOutputSchemaResponse) GetStateLogicId() string {
+	if x != nil {
+		return x.StateLogicId
+	}
+	return ""
+}
 
-func (x *AssignGroupRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[78]
+func (x *GetOutputSchemaResponse) GetOutputKey() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.OutputKey
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use AssignGroupRoleResponse.ProtoReflect.Descriptor instead.
-func (*AssignGroupRoleResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{78}
+func (x *GetOutputSchemaResponse) GetSchemaJson() string {
+	if x != nil {
+		return x.SchemaJson
+	}
+	return ""
 }
 
-func (x *AssignGroupRoleResponse) GetSuccess() bool {
+func (x *GetOutputSchemaResponse) GetSchemaRef() string {
 	if x != nil {
-		return x.Success
+		return x.SchemaRef
 	}
-	return false
+	return ""
 }
 
-func (x *AssignGroupRoleResponse) GetAssignedAt() *timestamppb.Timestamp {
+func (x *GetOutputSchemaResponse) GetSchemaVersion() int32 {
 	if x != nil {
-		return x.AssignedAt
+		return x.SchemaVersion
 	}
-	return nil
+	return 0
 }
 
-type RemoveGroupRoleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
-	RoleName      string                 `protobuf:"bytes,2,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+// ListOutputSchemaHistoryRequest lists the schema versions of a state output.
+type ListOutputSchemaHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State identifier (logic_id or GUID)
+	//
+	// Types that are valid to be assigned to State:
+	//
+	//	*ListOutputSchemaHistoryRequest_StateLogicId
+	//	*ListOutputSchemaHistoryRequest_StateGuid
+	State         isListOutputSchemaHistoryRequest_State `protobuf_oneof:"state"`
+	OutputKey     string                                 `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveGroupRoleRequest) Reset() {
-	*x = RemoveGroupRoleRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[79]
+func (x *ListOutputSchemaHistoryRequest) Reset() {
+	*x = ListOutputSchemaHistoryRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveGroupRoleRequest) String() string {
+func (x *ListOutputSchemaHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveGroupRoleRequest) ProtoMessage() {}
+func (*ListOutputSchemaHistoryRequest) ProtoMessage() {}
 
-func (x *RemoveGroupRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[79]
+func (x *ListOutputSchemaHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5173,47 +8564,89 @@ func (x *RemoveGroupRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveGroupRoleRequest.ProtoReflect.Descriptor instead.
-func (*RemoveGroupRoleRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{79}
+// Deprecated: Use ListOutputSchemaHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListOutputSchemaHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{122}
 }
 
-func (x *RemoveGroupRoleRequest) GetGroupName() string {
+func (x *ListOutputSchemaHistoryRequest) GetState() isListOutputSchemaHistoryRequest_State {
 	if x != nil {
-		return x.GroupName
+		return x.State
+	}
+	return nil
+}
+
+func (x *ListOutputSchemaHistoryRequest) GetStateLogicId() string {
+	if x != nil {
+		if x, ok := x.State.(*ListOutputSchemaHistoryRequest_StateLogicId); ok {
+			return x.StateLogicId
+		}
 	}
 	return ""
 }
 
-func (x *RemoveGroupRoleRequest) GetRoleName() string {
+func (x *ListOutputSchemaHistoryRequest) GetStateGuid() string {
 	if x != nil {
-		return x.RoleName
+		if x, ok := x.State.(*ListOutputSchemaHistoryRequest_StateGuid); ok {
+			return x.StateGuid
+		}
 	}
 	return ""
 }
 
-type RemoveGroupRoleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+func (x *ListOutputSchemaHistoryRequest) GetOutputKey() string {
+	if x != nil {
+		return x.OutputKey
+	}
+	return ""
+}
+
+type isListOutputSchemaHistoryRequest_State interface {
+	isListOutputSchemaHistoryRequest_State()
+}
+
+type ListOutputSchemaHistoryRequest_StateLogicId struct {
+	StateLogicId string `protobuf:"bytes,1,opt,name=state_logic_id,json=stateLogicId,proto3,oneof"`
+}
+
+type ListOutputSchemaHistoryRequest_StateGuid struct {
+	StateGuid string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3,oneof"`
+}
+
+func (*ListOutputSchemaHistoryRequest_StateLogicId) isListOutputSchemaHistoryRequest_State() {}
+
+func (*ListOutputSchemaHistoryRequest_StateGuid) isListOutputSchemaHistoryRequest_State() {}
+
+// OutputSchemaVersion is one schema a state output has had. Every schema
+// change (manual, inferred or via the schema registry) adds a version.
+type OutputSchemaVersion struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Version      int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	SchemaJson   string                 `protobuf:"bytes,2,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"`
+	SchemaSource string                 `protobuf:"bytes,3,opt,name=schema_source,json=schemaSource,proto3" json:"schema_source,omitempty"` // "manual" or "inferred"
+	SchemaRef    string                 `protobuf:"bytes,4,opt,name=schema_ref,json=schemaRef,proto3" json:"schema_ref,omitempty"`          // Schema registry key, empty for inline schemas
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// True for the version currently set on the output
+	Active        bool `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveGroupRoleResponse) Reset() {
-	*x = RemoveGroupRoleResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[80]
+func (x *OutputSchemaVersion) Reset() {
+	*x = OutputSchemaVersion{}
+	mi := &file_state_v1_state_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveGroupRoleResponse) String() string {
+func (x *OutputSchemaVersion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveGroupRoleResponse) ProtoMessage() {}
+func (*OutputSchemaVersion) ProtoMessage() {}
 
-func (x *RemoveGroupRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[80]
+func (x *OutputSchemaVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5224,87 +8657,82 @@ func (x *RemoveGroupRoleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveGroupRoleResponse.ProtoReflect.Descriptor instead.
-func (*RemoveGroupRoleResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{80}
+// Deprecated: Use OutputSchemaVersion.ProtoReflect.Descriptor instead.
+func (*OutputSchemaVersion) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *RemoveGroupRoleResponse) GetSuccess() bool {
+func (x *OutputSchemaVersion) GetVersion() int32 {
 	if x != nil {
-		return x.Success
+		return x.Version
 	}
-	return false
-}
-
-type ListGroupRolesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GroupName     *string                `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3,oneof" json:"group_name,omitempty"` // If provided, list roles for specific group; if omitted, list all group-role mappings
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return 0
 }
 
-func (x *ListGroupRolesRequest) Reset() {
-	*x = ListGroupRolesRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[81]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *OutputSchemaVersion) GetSchemaJson() string {
+	if x != nil {
+		return x.SchemaJson
+	}
+	return ""
 }
 
-func (x *ListGroupRolesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *OutputSchemaVersion) GetSchemaSource() string {
+	if x != nil {
+		return x.SchemaSource
+	}
+	return ""
 }
 
-func (*ListGroupRolesRequest) ProtoMessage() {}
-
-func (x *ListGroupRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[81]
+func (x *OutputSchemaVersion) GetSchemaRef() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SchemaRef
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ListGroupRolesRequest.ProtoReflect.Descriptor instead.
-func (*ListGroupRolesRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{81}
+func (x *OutputSchemaVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *ListGroupRolesRequest) GetGroupName() string {
-	if x != nil && x.GroupName != nil {
-		return *x.GroupName
+func (x *OutputSchemaVersion) GetActive() bool {
+	if x != nil {
+		return x.Active
 	}
-	return ""
+	return false
 }
 
-type GroupRoleAssignmentInfo struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	GroupName        string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
-	RoleName         string                 `protobuf:"bytes,2,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
-	AssignedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
-	AssignedByUserId string                 `protobuf:"bytes,4,opt,name=assigned_by_user_id,json=assignedByUserId,proto3" json:"assigned_by_user_id,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type ListOutputSchemaHistoryResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	StateGuid    string                 `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId string                 `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	OutputKey    string                 `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+	// Versions oldest first
+	Versions []*OutputSchemaVersion `protobuf:"bytes,4,rep,name=versions,proto3" json:"versions,omitempty"`
+	// Schema version the output's last validation ran against, with its result
+	ValidatedSchemaVersion *int32  `protobuf:"varint,5,opt,name=validated_schema_version,json=validatedSchemaVersion,proto3,oneof" json:"validated_schema_version,omitempty"`
+	ValidationStatus       *string `protobuf:"bytes,6,opt,name=validation_status,json=validationStatus,proto3,oneof" json:"validation_status,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *GroupRoleAssignmentInfo) Reset() {
-	*x = GroupRoleAssignmentInfo{}
-	mi := &file_state_v1_state_proto_msgTypes[82]
+func (x *ListOutputSchemaHistoryResponse) Reset() {
+	*x = ListOutputSchemaHistoryResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GroupRoleAssignmentInfo) String() string {
+func (x *ListOutputSchemaHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GroupRoleAssignmentInfo) ProtoMessage() {}
+func (*ListOutputSchemaHistoryResponse) ProtoMessage() {}
 
-func (x *GroupRoleAssignmentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[82]
+func (x *ListOutputSchemaHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5315,61 +8743,81 @@ func (x *GroupRoleAssignmentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GroupRoleAssignmentInfo.ProtoReflect.Descriptor instead.
-func (*GroupRoleAssignmentInfo) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{82}
+// Deprecated: Use ListOutputSchemaHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListOutputSchemaHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *GroupRoleAssignmentInfo) GetGroupName() string {
+func (x *ListOutputSchemaHistoryResponse) GetStateGuid() string {
 	if x != nil {
-		return x.GroupName
+		return x.StateGuid
 	}
 	return ""
 }
 
-func (x *GroupRoleAssignmentInfo) GetRoleName() string {
+func (x *ListOutputSchemaHistoryResponse) GetStateLogicId() string {
 	if x != nil {
-		return x.RoleName
+		return x.StateLogicId
 	}
 	return ""
 }
 
-func (x *GroupRoleAssignmentInfo) GetAssignedAt() *timestamppb.Timestamp {
+func (x *ListOutputSchemaHistoryResponse) GetOutputKey() string {
 	if x != nil {
-		return x.AssignedAt
+		return x.OutputKey
 	}
-	return nil
+	return ""
 }
 
-func (x *GroupRoleAssignmentInfo) GetAssignedByUserId() string {
+func (x *ListOutputSchemaHistoryResponse) GetVersions() []*OutputSchemaVersion {
 	if x != nil {
-		return x.AssignedByUserId
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ListOutputSchemaHistoryResponse) GetValidatedSchemaVersion() int32 {
+	if x != nil && x.ValidatedSchemaVersion != nil {
+		return *x.ValidatedSchemaVersion
+	}
+	return 0
+}
+
+func (x *ListOutputSchemaHistoryResponse) GetValidationStatus() string {
+	if x != nil && x.ValidationStatus != nil {
+		return *x.ValidationStatus
 	}
 	return ""
 }
 
-type ListGroupRolesResponse struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	Assignments   []*GroupRoleAssignmentInfo `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+// RegisteredSchema is a JSON Schema shared across outputs under a named version.
+type RegisteredSchema struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Registry key: <name>@<version>, name optionally namespaced with slashes
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	SchemaJson    string                 `protobuf:"bytes,2,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGroupRolesResponse) Reset() {
-	*x = ListGroupRolesResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[83]
+func (x *RegisteredSchema) Reset() {
+	*x = RegisteredSchema{}
+	mi := &file_state_v1_state_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGroupRolesResponse) String() string {
+func (x *RegisteredSchema) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGroupRolesResponse) ProtoMessage() {}
+func (*RegisteredSchema) ProtoMessage() {}
 
-func (x *ListGroupRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[83]
+func (x *RegisteredSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5380,41 +8828,71 @@ func (x *ListGroupRolesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGroupRolesResponse.ProtoReflect.Descriptor instead.
-func (*ListGroupRolesResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{83}
+// Deprecated: Use RegisteredSchema.ProtoReflect.Descriptor instead.
+func (*RegisteredSchema) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{125}
 }
 
-func (x *ListGroupRolesResponse) GetAssignments() []*GroupRoleAssignmentInfo {
+func (x *RegisteredSchema) GetKey() string {
 	if x != nil {
-		return x.Assignments
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RegisteredSchema) GetSchemaJson() string {
+	if x != nil {
+		return x.SchemaJson
+	}
+	return ""
+}
+
+func (x *RegisteredSchema) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RegisteredSchema) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type GetEffectivePermissionsRequest struct {
+func (x *RegisteredSchema) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CreateRegisteredSchemaRequest registers a new schema.
+type CreateRegisteredSchemaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"`
-	PrincipalId   string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	SchemaJson    string                 `protobuf:"bytes,2,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"` // must be valid JSON Schema
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEffectivePermissionsRequest) Reset() {
-	*x = GetEffectivePermissionsRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[84]
+func (x *CreateRegisteredSchemaRequest) Reset() {
+	*x = CreateRegisteredSchemaRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEffectivePermissionsRequest) String() string {
+func (x *CreateRegisteredSchemaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEffectivePermissionsRequest) ProtoMessage() {}
+func (*CreateRegisteredSchemaRequest) ProtoMessage() {}
 
-func (x *GetEffectivePermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[84]
+func (x *CreateRegisteredSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5425,51 +8903,54 @@ func (x *GetEffectivePermissionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEffectivePermissionsRequest.ProtoReflect.Descriptor instead.
-func (*GetEffectivePermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{84}
+// Deprecated: Use CreateRegisteredSchemaRequest.ProtoReflect.Descriptor instead.
+func (*CreateRegisteredSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{126}
 }
 
-func (x *GetEffectivePermissionsRequest) GetPrincipalType() string {
+func (x *CreateRegisteredSchemaRequest) GetKey() string {
 	if x != nil {
-		return x.PrincipalType
+		return x.Key
 	}
 	return ""
 }
 
-func (x *GetEffectivePermissionsRequest) GetPrincipalId() string {
+func (x *CreateRegisteredSchemaRequest) GetSchemaJson() string {
 	if x != nil {
-		return x.PrincipalId
+		return x.SchemaJson
 	}
 	return ""
 }
 
-type EffectivePermissions struct {
-	state                      protoimpl.MessageState `protogen:"open.v1"`
-	Roles                      []string               `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`                                              // Role names
-	Actions                    []string               `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`                                          // Aggregated actions (e.g., ["state:*", "tfstate:read"])
-	LabelScopeExprs            []string               `protobuf:"bytes,3,rep,name=label_scope_exprs,json=labelScopeExprs,proto3" json:"label_scope_exprs,omitempty"` // List of go-bexpr expressions from all roles (union/OR semantics - access granted if ANY expression matches)
-	EffectiveCreateConstraints *CreateConstraints     `protobuf:"bytes,4,opt,name=effective_create_constraints,json=effectiveCreateConstraints,proto3,oneof" json:"effective_create_constraints,omitempty"`
-	EffectiveImmutableKeys     []string               `protobuf:"bytes,5,rep,name=effective_immutable_keys,json=effectiveImmutableKeys,proto3" json:"effective_immutable_keys,omitempty"` // Union of all immutable keys
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+func (x *CreateRegisteredSchemaRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (x *EffectivePermissions) Reset() {
-	*x = EffectivePermissions{}
-	mi := &file_state_v1_state_proto_msgTypes[85]
+type CreateRegisteredSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *RegisteredSchema      `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRegisteredSchemaResponse) Reset() {
+	*x = CreateRegisteredSchemaResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EffectivePermissions) String() string {
+func (x *CreateRegisteredSchemaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EffectivePermissions) ProtoMessage() {}
+func (*CreateRegisteredSchemaResponse) ProtoMessage() {}
 
-func (x *EffectivePermissions) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[85]
+func (x *CreateRegisteredSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5480,68 +8961,84 @@ func (x *EffectivePermissions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EffectivePermissions.ProtoReflect.Descriptor instead.
-func (*EffectivePermissions) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{85}
+// Deprecated: Use CreateRegisteredSchemaResponse.ProtoReflect.Descriptor instead.
+func (*CreateRegisteredSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{127}
 }
 
-func (x *EffectivePermissions) GetRoles() []string {
+func (x *CreateRegisteredSchemaResponse) GetSchema() *RegisteredSchema {
 	if x != nil {
-		return x.Roles
+		return x.Schema
 	}
 	return nil
 }
 
-func (x *EffectivePermissions) GetActions() []string {
-	if x != nil {
-		return x.Actions
-	}
-	return nil
+type GetRegisteredSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EffectivePermissions) GetLabelScopeExprs() []string {
-	if x != nil {
-		return x.LabelScopeExprs
-	}
-	return nil
+func (x *GetRegisteredSchemaRequest) Reset() {
+	*x = GetRegisteredSchemaRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *EffectivePermissions) GetEffectiveCreateConstraints() *CreateConstraints {
+func (x *GetRegisteredSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegisteredSchemaRequest) ProtoMessage() {}
+
+func (x *GetRegisteredSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[128]
 	if x != nil {
-		return x.EffectiveCreateConstraints
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *EffectivePermissions) GetEffectiveImmutableKeys() []string {
+// Deprecated: Use GetRegisteredSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetRegisteredSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *GetRegisteredSchemaRequest) GetKey() string {
 	if x != nil {
-		return x.EffectiveImmutableKeys
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-type GetEffectivePermissionsResponse struct {
+type GetRegisteredSchemaResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Permissions   *EffectivePermissions  `protobuf:"bytes,1,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Schema        *RegisteredSchema      `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEffectivePermissionsResponse) Reset() {
-	*x = GetEffectivePermissionsResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[86]
+func (x *GetRegisteredSchemaResponse) Reset() {
+	*x = GetRegisteredSchemaResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEffectivePermissionsResponse) String() string {
+func (x *GetRegisteredSchemaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEffectivePermissionsResponse) ProtoMessage() {}
+func (*GetRegisteredSchemaResponse) ProtoMessage() {}
 
-func (x *GetEffectivePermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[86]
+func (x *GetRegisteredSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5552,40 +9049,39 @@ func (x *GetEffectivePermissionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEffectivePermissionsResponse.ProtoReflect.Descriptor instead.
-func (*GetEffectivePermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{86}
+// Deprecated: Use GetRegisteredSchemaResponse.ProtoReflect.Descriptor instead.
+func (*GetRegisteredSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{129}
 }
 
-func (x *GetEffectivePermissionsResponse) GetPermissions() *EffectivePermissions {
+func (x *GetRegisteredSchemaResponse) GetSchema() *RegisteredSchema {
 	if x != nil {
-		return x.Permissions
+		return x.Schema
 	}
 	return nil
 }
 
-type ListSessionsRequest struct {
+type ListRegisteredSchemasRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSessionsRequest) Reset() {
-	*x = ListSessionsRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[87]
+func (x *ListRegisteredSchemasRequest) Reset() {
+	*x = ListRegisteredSchemasRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSessionsRequest) String() string {
+func (x *ListRegisteredSchemasRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSessionsRequest) ProtoMessage() {}
+func (*ListRegisteredSchemasRequest) ProtoMessage() {}
 
-func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[87]
+func (x *ListRegisteredSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5596,45 +9092,33 @@ func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
-func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{87}
-}
-
-func (x *ListSessionsRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
+// Deprecated: Use ListRegisteredSchemasRequest.ProtoReflect.Descriptor instead.
+func (*ListRegisteredSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{130}
 }
 
-type SessionInfo struct {
+type ListRegisteredSchemasResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	UserAgent     *string                `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3,oneof" json:"user_agent,omitempty"`
-	IpAddress     *string                `protobuf:"bytes,6,opt,name=ip_address,json=ipAddress,proto3,oneof" json:"ip_address,omitempty"`
+	Schemas       []*RegisteredSchema    `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"` // ordered by key
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SessionInfo) Reset() {
-	*x = SessionInfo{}
-	mi := &file_state_v1_state_proto_msgTypes[88]
+func (x *ListRegisteredSchemasResponse) Reset() {
+	*x = ListRegisteredSchemasResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SessionInfo) String() string {
+func (x *ListRegisteredSchemasResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SessionInfo) ProtoMessage() {}
+func (*ListRegisteredSchemasResponse) ProtoMessage() {}
 
-func (x *SessionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[88]
+func (x *ListRegisteredSchemasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5645,75 +9129,113 @@ func (x *SessionInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
-func (*SessionInfo) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{88}
+// Deprecated: Use ListRegisteredSchemasResponse.ProtoReflect.Descriptor instead.
+func (*ListRegisteredSchemasResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{131}
 }
 
-func (x *SessionInfo) GetId() string {
+func (x *ListRegisteredSchemasResponse) GetSchemas() []*RegisteredSchema {
 	if x != nil {
-		return x.Id
+		return x.Schemas
 	}
-	return ""
+	return nil
 }
 
-func (x *SessionInfo) GetCreatedAt() *timestamppb.Timestamp {
+// UpdateRegisteredSchemaRequest replaces the schema registered under key.
+type UpdateRegisteredSchemaRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Key        string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	SchemaJson string                 `protobuf:"bytes,2,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"` // must be valid JSON Schema
+	// New description; the existing one is kept when unset
+	Description *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Re-validate every output using the new schema before responding
+	Revalidate    bool `protobuf:"varint,4,opt,name=revalidate,proto3" json:"revalidate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRegisteredSchemaRequest) Reset() {
+	*x = UpdateRegisteredSchemaRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRegisteredSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRegisteredSchemaRequest) ProtoMessage() {}
+
+func (x *UpdateRegisteredSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[132]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *SessionInfo) GetLastUsedAt() *timestamppb.Timestamp {
+// Deprecated: Use UpdateRegisteredSchemaRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRegisteredSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *UpdateRegisteredSchemaRequest) GetKey() string {
 	if x != nil {
-		return x.LastUsedAt
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-func (x *SessionInfo) GetExpiresAt() *timestamppb.Timestamp {
+func (x *UpdateRegisteredSchemaRequest) GetSchemaJson() string {
 	if x != nil {
-		return x.ExpiresAt
+		return x.SchemaJson
 	}
-	return nil
+	return ""
 }
 
-func (x *SessionInfo) GetUserAgent() string {
-	if x != nil && x.UserAgent != nil {
-		return *x.UserAgent
+func (x *UpdateRegisteredSchemaRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
 
-func (x *SessionInfo) GetIpAddress() string {
-	if x != nil && x.IpAddress != nil {
-		return *x.IpAddress
+func (x *UpdateRegisteredSchemaRequest) GetRevalidate() bool {
+	if x != nil {
+		return x.Revalidate
 	}
-	return ""
+	return false
 }
 
-type ListSessionsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sessions      []*SessionInfo         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+type UpdateRegisteredSchemaResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Schema *RegisteredSchema      `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	// Re-validation results, set only when revalidate was requested
+	Revalidation  *RevalidateOutputsForSchemaResponse `protobuf:"bytes,2,opt,name=revalidation,proto3" json:"revalidation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSessionsResponse) Reset() {
-	*x = ListSessionsResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[89]
+func (x *UpdateRegisteredSchemaResponse) Reset() {
+	*x = UpdateRegisteredSchemaResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSessionsResponse) String() string {
+func (x *UpdateRegisteredSchemaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSessionsResponse) ProtoMessage() {}
+func (*UpdateRegisteredSchemaResponse) ProtoMessage() {}
 
-func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[89]
+func (x *UpdateRegisteredSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5724,40 +9246,47 @@ func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
-func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{89}
+// Deprecated: Use UpdateRegisteredSchemaResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRegisteredSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{133}
 }
 
-func (x *ListSessionsResponse) GetSessions() []*SessionInfo {
+func (x *UpdateRegisteredSchemaResponse) GetSchema() *RegisteredSchema {
 	if x != nil {
-		return x.Sessions
+		return x.Schema
 	}
 	return nil
 }
 
-type RevokeSessionRequest struct {
+func (x *UpdateRegisteredSchemaResponse) GetRevalidation() *RevalidateOutputsForSchemaResponse {
+	if x != nil {
+		return x.Revalidation
+	}
+	return nil
+}
+
+type DeleteRegisteredSchemaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeSessionRequest) Reset() {
-	*x = RevokeSessionRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[90]
+func (x *DeleteRegisteredSchemaRequest) Reset() {
+	*x = DeleteRegisteredSchemaRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeSessionRequest) String() string {
+func (x *DeleteRegisteredSchemaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeSessionRequest) ProtoMessage() {}
+func (*DeleteRegisteredSchemaRequest) ProtoMessage() {}
 
-func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[90]
+func (x *DeleteRegisteredSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5768,40 +9297,39 @@ func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
-func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{90}
+// Deprecated: Use DeleteRegisteredSchemaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRegisteredSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{134}
 }
 
-func (x *RevokeSessionRequest) GetSessionId() string {
+func (x *DeleteRegisteredSchemaRequest) GetKey() string {
 	if x != nil {
-		return x.SessionId
+		return x.Key
 	}
 	return ""
 }
 
-type RevokeSessionResponse struct {
+type DeleteRegisteredSchemaResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeSessionResponse) Reset() {
-	*x = RevokeSessionResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[91]
+func (x *DeleteRegisteredSchemaResponse) Reset() {
+	*x = DeleteRegisteredSchemaResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeSessionResponse) String() string {
+func (x *DeleteRegisteredSchemaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeSessionResponse) ProtoMessage() {}
+func (*DeleteRegisteredSchemaResponse) ProtoMessage() {}
 
-func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[91]
+func (x *DeleteRegisteredSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5812,53 +9340,40 @@ func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
-func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{91}
-}
-
-func (x *RevokeSessionResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use DeleteRegisteredSchemaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRegisteredSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{135}
 }
 
-// SetOutputSchemaRequest publishes or updates a JSON Schema for a specific state output.
-// Allows clients to declare expected output types before the output actually exists.
-type SetOutputSchemaRequest struct {
+// GetStateValidationSummaryRequest asks for aggregate validation status of a state's outputs.
+type GetStateValidationSummaryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// State identifier (logic_id or GUID)
 	//
 	// Types that are valid to be assigned to State:
 	//
-	//	*SetOutputSchemaRequest_StateLogicId
-	//	*SetOutputSchemaRequest_StateGuid
-	State isSetOutputSchemaRequest_State `protobuf_oneof:"state"`
-	// Name of the output (e.g., "vpc_id", "subnet_ids")
-	OutputKey string `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
-	// JSON Schema definition for this output (must be valid JSON Schema)
-	// Example: {"type": "string", "pattern": "^vpc-[a-z0-9]+$"}
-	SchemaJson    string `protobuf:"bytes,4,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"`
+	//	*GetStateValidationSummaryRequest_StateLogicId
+	//	*GetStateValidationSummaryRequest_StateGuid
+	State         isGetStateValidationSummaryRequest_State `protobuf_oneof:"state"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetOutputSchemaRequest) Reset() {
-	*x = SetOutputSchemaRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[92]
+func (x *GetStateValidationSummaryRequest) Reset() {
+	*x = GetStateValidationSummaryRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetOutputSchemaRequest) String() string {
+func (x *GetStateValidationSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetOutputSchemaRequest) ProtoMessage() {}
+func (*GetStateValidationSummaryRequest) ProtoMessage() {}
 
-func (x *SetOutputSchemaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[92]
+func (x *GetStateValidationSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5869,93 +9384,81 @@ func (x *SetOutputSchemaRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetOutputSchemaRequest.ProtoReflect.Descriptor instead.
-func (*SetOutputSchemaRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{92}
+// Deprecated: Use GetStateValidationSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetStateValidationSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{136}
 }
 
-func (x *SetOutputSchemaRequest) GetState() isSetOutputSchemaRequest_State {
+func (x *GetStateValidationSummaryRequest) GetState() isGetStateValidationSummaryRequest_State {
 	if x != nil {
 		return x.State
 	}
 	return nil
 }
 
-func (x *SetOutputSchemaRequest) GetStateLogicId() string {
+func (x *GetStateValidationSummaryRequest) GetStateLogicId() string {
 	if x != nil {
-		if x, ok := x.State.(*SetOutputSchemaRequest_StateLogicId); ok {
+		if x, ok := x.State.(*GetStateValidationSummaryRequest_StateLogicId); ok {
 			return x.StateLogicId
 		}
 	}
 	return ""
 }
 
-func (x *SetOutputSchemaRequest) GetStateGuid() string {
+func (x *GetStateValidationSummaryRequest) GetStateGuid() string {
 	if x != nil {
-		if x, ok := x.State.(*SetOutputSchemaRequest_StateGuid); ok {
+		if x, ok := x.State.(*GetStateValidationSummaryRequest_StateGuid); ok {
 			return x.StateGuid
 		}
 	}
 	return ""
 }
 
-func (x *SetOutputSchemaRequest) GetOutputKey() string {
-	if x != nil {
-		return x.OutputKey
-	}
-	return ""
-}
-
-func (x *SetOutputSchemaRequest) GetSchemaJson() string {
-	if x != nil {
-		return x.SchemaJson
-	}
-	return ""
-}
-
-type isSetOutputSchemaRequest_State interface {
-	isSetOutputSchemaRequest_State()
+type isGetStateValidationSummaryRequest_State interface {
+	isGetStateValidationSummaryRequest_State()
 }
 
-type SetOutputSchemaRequest_StateLogicId struct {
+type GetStateValidationSummaryRequest_StateLogicId struct {
 	StateLogicId string `protobuf:"bytes,1,opt,name=state_logic_id,json=stateLogicId,proto3,oneof"`
 }
 
-type SetOutputSchemaRequest_StateGuid struct {
+type GetStateValidationSummaryRequest_StateGuid struct {
 	StateGuid string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3,oneof"`
 }
 
-func (*SetOutputSchemaRequest_StateLogicId) isSetOutputSchemaRequest_State() {}
+func (*GetStateValidationSummaryRequest_StateLogicId) isGetStateValidationSummaryRequest_State() {}
 
-func (*SetOutputSchemaRequest_StateGuid) isSetOutputSchemaRequest_State() {}
+func (*GetStateValidationSummaryRequest_StateGuid) isGetStateValidationSummaryRequest_State() {}
 
-// SetOutputSchemaResponse confirms schema publication.
-type SetOutputSchemaResponse struct {
-	state   protoimpl.MessageState `protogen:"open.v1"`
-	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	// State identifiers for confirmation
-	StateGuid     string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
-	StateLogicId  string `protobuf:"bytes,3,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
-	OutputKey     string `protobuf:"bytes,4,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+// OutputValidationIssue describes an output whose last validation did not pass.
+type OutputValidationIssue struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OutputKey string                 `protobuf:"bytes,1,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
+	// Validation status: "invalid" (failed validation) or "error" (validation system error)
+	ValidationStatus string `protobuf:"bytes,2,opt,name=validation_status,json=validationStatus,proto3" json:"validation_status,omitempty"`
+	// Validation error message with JSON path information
+	ValidationError string `protobuf:"bytes,3,opt,name=validation_error,json=validationError,proto3" json:"validation_error,omitempty"`
+	// Timestamp of the validation run that produced this result
+	ValidatedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=validated_at,json=validatedAt,proto3,oneof" json:"validated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetOutputSchemaResponse) Reset() {
-	*x = SetOutputSchemaResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[93]
+func (x *OutputValidationIssue) Reset() {
+	*x = OutputValidationIssue{}
+	mi := &file_state_v1_state_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetOutputSchemaResponse) String() string {
+func (x *OutputValidationIssue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetOutputSchemaResponse) ProtoMessage() {}
+func (*OutputValidationIssue) ProtoMessage() {}
 
-func (x *SetOutputSchemaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[93]
+func (x *OutputValidationIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5966,70 +9469,76 @@ func (x *SetOutputSchemaResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetOutputSchemaResponse.ProtoReflect.Descriptor instead.
-func (*SetOutputSchemaResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{93}
+// Deprecated: Use OutputValidationIssue.ProtoReflect.Descriptor instead.
+func (*OutputValidationIssue) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{137}
 }
 
-func (x *SetOutputSchemaResponse) GetSuccess() bool {
+func (x *OutputValidationIssue) GetOutputKey() string {
 	if x != nil {
-		return x.Success
+		return x.OutputKey
 	}
-	return false
+	return ""
 }
 
-func (x *SetOutputSchemaResponse) GetStateGuid() string {
+func (x *OutputValidationIssue) GetValidationStatus() string {
 	if x != nil {
-		return x.StateGuid
+		return x.ValidationStatus
 	}
 	return ""
 }
 
-func (x *SetOutputSchemaResponse) GetStateLogicId() string {
+func (x *OutputValidationIssue) GetValidationError() string {
 	if x != nil {
-		return x.StateLogicId
+		return x.ValidationError
 	}
 	return ""
 }
 
-func (x *SetOutputSchemaResponse) GetOutputKey() string {
+func (x *OutputValidationIssue) GetValidatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.OutputKey
+		return x.ValidatedAt
 	}
-	return ""
+	return nil
 }
 
-// GetOutputSchemaRequest retrieves the JSON Schema for a specific state output.
-type GetOutputSchemaRequest struct {
+// GetStateValidationSummaryResponse reports output validation counts for a state.
+type GetStateValidationSummaryResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// State identifier (logic_id or GUID)
-	//
-	// Types that are valid to be assigned to State:
-	//
-	//	*GetOutputSchemaRequest_StateLogicId
-	//	*GetOutputSchemaRequest_StateGuid
-	State isGetOutputSchemaRequest_State `protobuf_oneof:"state"`
-	// Name of the output to get schema for
-	OutputKey     string `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// State identifiers
+	StateGuid    string `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
+	StateLogicId string `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
+	// Output counts by validation status. Outputs without a recorded status
+	// count as not_validated.
+	TotalOutputs      int32 `protobuf:"varint,3,opt,name=total_outputs,json=totalOutputs,proto3" json:"total_outputs,omitempty"`
+	ValidCount        int32 `protobuf:"varint,4,opt,name=valid_count,json=validCount,proto3" json:"valid_count,omitempty"`
+	InvalidCount      int32 `protobuf:"varint,5,opt,name=invalid_count,json=invalidCount,proto3" json:"invalid_count,omitempty"`
+	ErrorCount        int32 `protobuf:"varint,6,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	NotValidatedCount int32 `protobuf:"varint,7,opt,name=not_validated_count,json=notValidatedCount,proto3" json:"not_validated_count,omitempty"`
+	// Every output with status "invalid" or "error", sorted by output_key
+	Issues []*OutputValidationIssue `protobuf:"bytes,8,rep,name=issues,proto3" json:"issues,omitempty"`
+	// Newest validated_at across all outputs; unset if nothing has been validated.
+	// Compare against the state's last update to detect stale results.
+	LastValidatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_validated_at,json=lastValidatedAt,proto3,oneof" json:"last_validated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetOutputSchemaRequest) Reset() {
-	*x = GetOutputSchemaRequest{}
-	mi := &file_state_v1_state_proto_msgTypes[94]
+func (x *GetStateValidationSummaryResponse) Reset() {
+	*x = GetStateValidationSummaryResponse{}
+	mi := &file_state_v1_state_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOutputSchemaRequest) String() string {
+func (x *GetStateValidationSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputSchemaRequest) ProtoMessage() {}
+func (*GetStateValidationSummaryResponse) ProtoMessage() {}
 
-func (x *GetOutputSchemaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[94]
+func (x *GetStateValidationSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6040,88 +9549,101 @@ func (x *GetOutputSchemaRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputSchemaRequest.ProtoReflect.Descriptor instead.
-func (*GetOutputSchemaRequest) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{94}
+// Deprecated: Use GetStateValidationSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetStateValidationSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{138}
 }
 
-func (x *GetOutputSchemaRequest) GetState() isGetOutputSchemaRequest_State {
+func (x *GetStateValidationSummaryResponse) GetStateGuid() string {
 	if x != nil {
-		return x.State
+		return x.StateGuid
 	}
-	return nil
+	return ""
 }
 
-func (x *GetOutputSchemaRequest) GetStateLogicId() string {
+func (x *GetStateValidationSummaryResponse) GetStateLogicId() string {
 	if x != nil {
-		if x, ok := x.State.(*GetOutputSchemaRequest_StateLogicId); ok {
-			return x.StateLogicId
-		}
+		return x.StateLogicId
 	}
 	return ""
 }
 
-func (x *GetOutputSchemaRequest) GetStateGuid() string {
+func (x *GetStateValidationSummaryResponse) GetTotalOutputs() int32 {
 	if x != nil {
-		if x, ok := x.State.(*GetOutputSchemaRequest_StateGuid); ok {
-			return x.StateGuid
-		}
+		return x.TotalOutputs
 	}
-	return ""
+	return 0
 }
 
-func (x *GetOutputSchemaRequest) GetOutputKey() string {
+func (x *GetStateValidationSummaryResponse) GetValidCount() int32 {
 	if x != nil {
-		return x.OutputKey
+		return x.ValidCount
 	}
-	return ""
+	return 0
 }
 
-type isGetOutputSchemaRequest_State interface {
-	isGetOutputSchemaRequest_State()
+func (x *GetStateValidationSummaryResponse) GetInvalidCount() int32 {
+	if x != nil {
+		return x.InvalidCount
+	}
+	return 0
 }
 
-type GetOutputSchemaRequest_StateLogicId struct {
-	StateLogicId string `protobuf:"bytes,1,opt,name=state_logic_id,json=stateLogicId,proto3,oneof"`
+func (x *GetStateValidationSummaryResponse) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
 }
 
-type GetOutputSchemaRequest_StateGuid struct {
-	StateGuid string `protobuf:"bytes,2,opt,name=state_guid,json=stateGuid,proto3,oneof"`
+func (x *GetStateValidationSummaryResponse) GetNotValidatedCount() int32 {
+	if x != nil {
+		return x.NotValidatedCount
+	}
+	return 0
 }
 
-func (*GetOutputSchemaRequest_StateLogicId) isGetOutputSchemaRequest_State() {}
+func (x *GetStateValidationSummaryResponse) GetIssues() []*OutputValidationIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
 
-func (*GetOutputSchemaRequest_StateGuid) isGetOutputSchemaRequest_State() {}
+func (x *GetStateValidationSummaryResponse) GetLastValidatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastValidatedAt
+	}
+	return nil
+}
 
-// GetOutputSchemaResponse returns the JSON Schema for an output.
-type GetOutputSchemaResponse struct {
+// WatchStateChangesRequest selects the states and changes to watch.
+type WatchStateChangesRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// State identifiers
-	StateGuid    string `protobuf:"bytes,1,opt,name=state_guid,json=stateGuid,proto3" json:"state_guid,omitempty"`
-	StateLogicId string `protobuf:"bytes,2,opt,name=state_logic_id,json=stateLogicId,proto3" json:"state_logic_id,omitempty"`
-	// Output name
-	OutputKey string `protobuf:"bytes,3,opt,name=output_key,json=outputKey,proto3" json:"output_key,omitempty"`
-	// JSON Schema definition (empty string if no schema has been set)
-	SchemaJson    string `protobuf:"bytes,4,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"`
+	// Restrict events to one state; unset watches every visible state
+	LogicId *string `protobuf:"bytes,1,opt,name=logic_id,json=logicId,proto3,oneof" json:"logic_id,omitempty"`
+	// Change kinds to report ("serial", "labels", "outputs", "status");
+	// empty reports all of them
+	Kinds         []string `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOutputSchemaResponse) Reset() {
-	*x = GetOutputSchemaResponse{}
-	mi := &file_state_v1_state_proto_msgTypes[95]
+func (x *WatchStateChangesRequest) Reset() {
+	*x = WatchStateChangesRequest{}
+	mi := &file_state_v1_state_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOutputSchemaResponse) String() string {
+func (x *WatchStateChangesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputSchemaResponse) ProtoMessage() {}
+func (*WatchStateChangesRequest) ProtoMessage() {}
 
-func (x *GetOutputSchemaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_state_v1_state_proto_msgTypes[95]
+func (x *WatchStateChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6132,64 +9654,162 @@ func (x *GetOutputSchemaResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputSchemaResponse.ProtoReflect.Descriptor instead.
-func (*GetOutputSchemaResponse) Descriptor() ([]byte, []int) {
-	return file_state_v1_state_proto_rawDescGZIP(), []int{95}
+// Deprecated: Use WatchStateChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchStateChangesRequest) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{139}
 }
 
-func (x *GetOutputSchemaResponse) GetStateGuid() string {
+func (x *WatchStateChangesRequest) GetLogicId() string {
+	if x != nil && x.LogicId != nil {
+		return *x.LogicId
+	}
+	return ""
+}
+
+func (x *WatchStateChangesRequest) GetKinds() []string {
 	if x != nil {
-		return x.StateGuid
+		return x.Kinds
+	}
+	return nil
+}
+
+// StateChangeEvent reports one change to a state. A single update can produce
+// several events, e.g. a new serial together with changed outputs.
+type StateChangeEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Guid    string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	LogicId string                 `protobuf:"bytes,2,opt,name=logic_id,json=logicId,proto3" json:"logic_id,omitempty"`
+	// What changed: "serial", "labels", "outputs" or "status"
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The state's values after the change
+	Serial         int64                  `protobuf:"varint,4,opt,name=serial,proto3" json:"serial,omitempty"`
+	Labels         map[string]*LabelValue `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ComputedStatus string                 `protobuf:"bytes,6,opt,name=computed_status,json=computedStatus,proto3" json:"computed_status,omitempty"` // "clean", "stale", "potentially-stale"
+	// Output keys whose value changed, appeared or disappeared (kind "outputs")
+	ChangedOutputs []string `protobuf:"bytes,7,rep,name=changed_outputs,json=changedOutputs,proto3" json:"changed_outputs,omitempty"`
+	// When the change was detected
+	DetectedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateChangeEvent) Reset() {
+	*x = StateChangeEvent{}
+	mi := &file_state_v1_state_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateChangeEvent) ProtoMessage() {}
+
+func (x *StateChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_state_v1_state_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateChangeEvent.ProtoReflect.Descriptor instead.
+func (*StateChangeEvent) Descriptor() ([]byte, []int) {
+	return file_state_v1_state_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *StateChangeEvent) GetGuid() string {
+	if x != nil {
+		return x.Guid
 	}
 	return ""
 }
 
-func (x *GetOutputSchemaResponse) GetStateLogicId() string {
+func (x *StateChangeEvent) GetLogicId() string {
 	if x != nil {
-		return x.StateLogicId
+		return x.LogicId
 	}
 	return ""
 }
 
-func (x *GetOutputSchemaResponse) GetOutputKey() string {
+func (x *StateChangeEvent) GetKind() string {
 	if x != nil {
-		return x.OutputKey
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *GetOutputSchemaResponse) GetSchemaJson() string {
+func (x *StateChangeEvent) GetSerial() int64 {
 	if x != nil {
-		return x.SchemaJson
+		return x.Serial
+	}
+	return 0
+}
+
+func (x *StateChangeEvent) GetLabels() map[string]*LabelValue {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *StateChangeEvent) GetComputedStatus() string {
+	if x != nil {
+		return x.ComputedStatus
 	}
 	return ""
 }
 
+func (x *StateChangeEvent) GetChangedOutputs() []string {
+	if x != nil {
+		return x.ChangedOutputs
+	}
+	return nil
+}
+
+func (x *StateChangeEvent) GetDetectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return nil
+}
+
 var File_state_v1_state_proto protoreflect.FileDescriptor
 
 const file_state_v1_state_proto_rawDesc = "" +
 	"\n" +
-	"\x14state/v1/state.proto\x12\bstate.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc0\x01\n" +
+	"\x14state/v1/state.proto\x12\bstate.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe9\x01\n" +
 	"\x12CreateStateRequest\x12\x12\n" +
 	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x19\n" +
 	"\blogic_id\x18\x02 \x01(\tR\alogicId\x12@\n" +
-	"\x06labels\x18\x03 \x03(\v2(.state.v1.CreateStateRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\x06labels\x18\x03 \x03(\v2(.state.v1.CreateStateRequest.LabelsEntryR\x06labels\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x84\x01\n" +
 	"\x13CreateStateResponse\x12\x12\n" +
 	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x19\n" +
 	"\blogic_id\x18\x02 \x01(\tR\alogicId\x12>\n" +
-	"\x0ebackend_config\x18\x03 \x01(\v2\x17.state.v1.BackendConfigR\rbackendConfig\"\xb9\x01\n" +
+	"\x0ebackend_config\x18\x03 \x01(\v2\x17.state.v1.BackendConfigR\rbackendConfig\"\x9c\x02\n" +
 	"\x11ListStatesRequest\x12\x1b\n" +
 	"\x06filter\x18\x01 \x01(\tH\x00R\x06filter\x88\x01\x01\x12*\n" +
 	"\x0einclude_labels\x18\x02 \x01(\bH\x01R\rincludeLabels\x88\x01\x01\x12*\n" +
-	"\x0einclude_status\x18\x03 \x01(\bH\x02R\rincludeStatus\x88\x01\x01B\t\n" +
+	"\x0einclude_status\x18\x03 \x01(\bH\x02R\rincludeStatus\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12%\n" +
+	"\x0elabel_selector\x18\x06 \x01(\tR\rlabelSelectorB\t\n" +
 	"\a_filterB\x11\n" +
 	"\x0f_include_labelsB\x11\n" +
-	"\x0f_include_status\"A\n" +
+	"\x0f_include_status\"i\n" +
 	"\x12ListStatesResponse\x12+\n" +
-	"\x06states\x18\x01 \x03(\v2\x13.state.v1.StateInfoR\x06states\"\xb1\x05\n" +
+	"\x06states\x18\x01 \x03(\v2\x13.state.v1.StateInfoR\x06states\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe4\x05\n" +
 	"\tStateInfo\x12\x12\n" +
 	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x19\n" +
 	"\blogic_id\x18\x02 \x01(\tR\alogicId\x12\x16\n" +
@@ -6206,14 +9826,17 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x12dependencies_count\x18\n" +
 	" \x01(\x05H\x01R\x11dependenciesCount\x88\x01\x01\x12.\n" +
 	"\x10dependents_count\x18\v \x01(\x05H\x02R\x0fdependentsCount\x88\x01\x01\x12(\n" +
-	"\routputs_count\x18\f \x01(\x05H\x03R\foutputsCount\x88\x01\x01\x1aO\n" +
+	"\routputs_count\x18\f \x01(\x05H\x03R\foutputsCount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"created_by\x18\r \x01(\tH\x04R\tcreatedBy\x88\x01\x01\x1aO\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
 	"\x05value\x18\x02 \x01(\v2\x14.state.v1.LabelValueR\x05value:\x028\x01B\x12\n" +
 	"\x10_computed_statusB\x15\n" +
 	"\x13_dependencies_countB\x13\n" +
 	"\x11_dependents_countB\x10\n" +
-	"\x0e_outputs_count\"s\n" +
+	"\x0e_outputs_countB\r\n" +
+	"\v_created_by\"s\n" +
 	"\rBackendConfig\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12!\n" +
 	"\flock_address\x18\x02 \x01(\tR\vlockAddress\x12%\n" +
@@ -6306,7 +9929,7 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\blogic_id\x18\x02 \x01(\tR\alogicId\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x126\n" +
 	"\bincoming\x18\x04 \x03(\v2\x1a.state.v1.IncomingEdgeViewR\bincoming\x121\n" +
-	"\asummary\x18\x05 \x01(\v2\x17.state.v1.StatusSummaryR\asummary\"\xa7\x03\n" +
+	"\asummary\x18\x05 \x01(\v2\x17.state.v1.StatusSummaryR\asummary\"\xc1\x03\n" +
 	"\x10IncomingEdgeView\x12\x17\n" +
 	"\aedge_id\x18\x01 \x01(\x03R\x06edgeId\x12\x1b\n" +
 	"\tfrom_guid\x18\x02 \x01(\tR\bfromGuid\x12\"\n" +
@@ -6319,7 +9942,9 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"out_digest\x18\a \x01(\tH\x01R\toutDigest\x88\x01\x01\x12=\n" +
 	"\n" +
 	"last_in_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x02R\blastInAt\x88\x01\x01\x12?\n" +
-	"\vlast_out_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x03R\tlastOutAt\x88\x01\x01B\f\n" +
+	"\vlast_out_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x03R\tlastOutAt\x88\x01\x01\x12\x18\n" +
+	"\acurrent\x18\n" +
+	" \x01(\bR\acurrentB\f\n" +
 	"\n" +
 	"_in_digestB\r\n" +
 	"\v_out_digestB\r\n" +
@@ -6329,20 +9954,37 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x0eincoming_clean\x18\x01 \x01(\x05R\rincomingClean\x12%\n" +
 	"\x0eincoming_dirty\x18\x02 \x01(\x05R\rincomingDirty\x12)\n" +
 	"\x10incoming_pending\x18\x03 \x01(\x05R\x0fincomingPending\x12)\n" +
-	"\x10incoming_unknown\x18\x04 \x01(\x05R\x0fincomingUnknown\"W\n" +
+	"\x10incoming_unknown\x18\x04 \x01(\x05R\x0fincomingUnknown\"\x87\x01\n" +
 	"\x19GetDependencyGraphRequest\x12\x1b\n" +
 	"\blogic_id\x18\x01 \x01(\tH\x00R\alogicId\x12\x14\n" +
-	"\x04guid\x18\x02 \x01(\tH\x00R\x04guidB\a\n" +
-	"\x05state\"\xd4\x01\n" +
+	"\x04guid\x18\x02 \x01(\tH\x00R\x04guid\x12 \n" +
+	"\tmax_depth\x18\x03 \x01(\x05H\x01R\bmaxDepth\x88\x01\x01B\a\n" +
+	"\x05stateB\f\n" +
+	"\n" +
+	"_max_depth\"\xb5\x02\n" +
 	"\x1aGetDependencyGraphResponse\x12#\n" +
 	"\rconsumer_guid\x18\x01 \x01(\tR\fconsumerGuid\x12*\n" +
 	"\x11consumer_logic_id\x18\x02 \x01(\tR\x0fconsumerLogicId\x125\n" +
 	"\tproducers\x18\x03 \x03(\v2\x17.state.v1.ProducerStateR\tproducers\x12.\n" +
-	"\x05edges\x18\x04 \x03(\v2\x18.state.v1.DependencyEdgeR\x05edges\"~\n" +
+	"\x05edges\x18\x04 \x03(\v2\x18.state.v1.DependencyEdgeR\x05edges\x12\x1b\n" +
+	"\tmax_depth\x18\x05 \x01(\x05R\bmaxDepth\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\x12$\n" +
+	"\x0ecycle_edge_ids\x18\a \x03(\x03R\fcycleEdgeIds\"^\n" +
+	" RecomputeDependencyStatusRequest\x12\x1b\n" +
+	"\blogic_id\x18\x01 \x01(\tH\x00R\alogicId\x12\x14\n" +
+	"\x04guid\x18\x02 \x01(\tH\x00R\x04guidB\a\n" +
+	"\x05state\"\xf3\x01\n" +
+	"!RecomputeDependencyStatusResponse\x12#\n" +
+	"\rproducer_guid\x18\x01 \x01(\tR\fproducerGuid\x12*\n" +
+	"\x11producer_logic_id\x18\x02 \x01(\tR\x0fproducerLogicId\x12.\n" +
+	"\x05edges\x18\x03 \x03(\v2\x18.state.v1.DependencyEdgeR\x05edges\x12(\n" +
+	"\x10changed_edge_ids\x18\x04 \x03(\x03R\x0echangedEdgeIds\x12#\n" +
+	"\rskipped_edges\x18\x05 \x01(\x05R\fskippedEdges\"\x94\x01\n" +
 	"\rProducerState\x12\x12\n" +
 	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x19\n" +
 	"\blogic_id\x18\x02 \x01(\tR\alogicId\x12>\n" +
-	"\x0ebackend_config\x18\x03 \x01(\v2\x17.state.v1.BackendConfigR\rbackendConfig\"\xc7\x05\n" +
+	"\x0ebackend_config\x18\x03 \x01(\v2\x17.state.v1.BackendConfigR\rbackendConfig\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\"\xe1\x05\n" +
 	"\x0eDependencyEdge\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
 	"\tfrom_guid\x18\x02 \x01(\tR\bfromGuid\x12\"\n" +
@@ -6364,14 +10006,15 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x10\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\acurrent\x18\x10 \x01(\bR\acurrentB\x10\n" +
 	"\x0e_to_input_nameB\f\n" +
 	"\n" +
 	"_in_digestB\r\n" +
 	"\v_out_digestB\x12\n" +
 	"\x10_mock_value_jsonB\r\n" +
 	"\v_last_in_atB\x0e\n" +
-	"\f_last_out_at\"\x8f\x03\n" +
+	"\f_last_out_at\"\x90\x05\n" +
 	"\tOutputKey\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1c\n" +
 	"\tsensitive\x18\x02 \x01(\bR\tsensitive\x12$\n" +
@@ -6380,12 +10023,23 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\rschema_source\x18\x04 \x01(\tH\x01R\fschemaSource\x88\x01\x01\x120\n" +
 	"\x11validation_status\x18\x05 \x01(\tH\x02R\x10validationStatus\x88\x01\x01\x12.\n" +
 	"\x10validation_error\x18\x06 \x01(\tH\x03R\x0fvalidationError\x88\x01\x01\x12B\n" +
-	"\fvalidated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x04R\vvalidatedAt\x88\x01\x01B\x0e\n" +
+	"\fvalidated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x04R\vvalidatedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"value_json\x18\b \x01(\tH\x05R\tvalueJson\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"schema_ref\x18\t \x01(\tH\x06R\tschemaRef\x88\x01\x01\x12*\n" +
+	"\x0eschema_version\x18\n" +
+	" \x01(\x05H\aR\rschemaVersion\x88\x01\x01\x12=\n" +
+	"\x18validated_schema_version\x18\v \x01(\x05H\bR\x16validatedSchemaVersion\x88\x01\x01B\x0e\n" +
 	"\f_schema_jsonB\x10\n" +
 	"\x0e_schema_sourceB\x14\n" +
 	"\x12_validation_statusB\x13\n" +
 	"\x11_validation_errorB\x0f\n" +
-	"\r_validated_at\"U\n" +
+	"\r_validated_atB\r\n" +
+	"\v_value_jsonB\r\n" +
+	"\v_schema_refB\x11\n" +
+	"\x0f_schema_versionB\x1b\n" +
+	"\x19_validated_schema_version\"U\n" +
 	"\x17ListStateOutputsRequest\x12\x1b\n" +
 	"\blogic_id\x18\x01 \x01(\tH\x00R\alogicId\x12\x14\n" +
 	"\x04guid\x18\x02 \x01(\tH\x00R\x04guidB\a\n" +
@@ -6394,11 +10048,40 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\n" +
 	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
 	"\x0estate_logic_id\x18\x02 \x01(\tR\fstateLogicId\x12-\n" +
-	"\aoutputs\x18\x03 \x03(\v2\x13.state.v1.OutputKeyR\aoutputs\"Q\n" +
+	"\aoutputs\x18\x03 \x03(\v2\x13.state.v1.OutputKeyR\aoutputs\"\x92\x01\n" +
+	"\x1cListStateOutputsBatchRequest\x12*\n" +
+	"\x06states\x18\x01 \x03(\v2\x12.state.v1.StateRefR\x06states\x12\x1f\n" +
+	"\voutput_keys\x18\x02 \x03(\tR\n" +
+	"outputKeys\x12%\n" +
+	"\x0einclude_values\x18\x03 \x01(\bR\rincludeValues\"O\n" +
+	"\x1dListStateOutputsBatchResponse\x12.\n" +
+	"\x06states\x18\x01 \x03(\v2\x16.state.v1.StateOutputsR\x06states\"\x82\x01\n" +
+	"\fStateOutputs\x12\x1d\n" +
+	"\n" +
+	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
+	"\x0estate_logic_id\x18\x02 \x01(\tR\fstateLogicId\x12-\n" +
+	"\aoutputs\x18\x03 \x03(\v2\x13.state.v1.OutputKeyR\aoutputs\"[\n" +
+	"\x1bGetStateOutputValuesRequest\x12(\n" +
+	"\x05state\x18\x01 \x01(\v2\x12.state.v1.StateRefR\x05state\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\"\x92\x01\n" +
+	"\x1cGetStateOutputValuesResponse\x12\x1d\n" +
+	"\n" +
+	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
+	"\x0estate_logic_id\x18\x02 \x01(\tR\fstateLogicId\x12-\n" +
+	"\x06values\x18\x03 \x03(\v2\x15.state.v1.OutputValueR\x06values\"\x8c\x01\n" +
+	"\vOutputValue\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1c\n" +
+	"\tsensitive\x18\x02 \x01(\bR\tsensitive\x12\"\n" +
+	"\n" +
+	"value_json\x18\x03 \x01(\tH\x00R\tvalueJson\x88\x01\x01\x12\x1a\n" +
+	"\bredacted\x18\x04 \x01(\bR\bredactedB\r\n" +
+	"\v_value_json\"\xaa\x01\n" +
 	"\x13GetStateInfoRequest\x12\x1b\n" +
 	"\blogic_id\x18\x01 \x01(\tH\x00R\alogicId\x12\x14\n" +
-	"\x04guid\x18\x02 \x01(\tH\x00R\x04guidB\a\n" +
-	"\x05state\"\x98\x05\n" +
+	"\x04guid\x18\x02 \x01(\tH\x00R\x04guid\x12;\n" +
+	"\x17include_computed_status\x18\x03 \x01(\bH\x01R\x15includeComputedStatus\x88\x01\x01B\a\n" +
+	"\x05stateB\x1a\n" +
+	"\x18_include_computed_status\"\x98\x05\n" +
 	"\x14GetStateInfoResponse\x12\x12\n" +
 	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x19\n" +
 	"\blogic_id\x18\x02 \x01(\tR\alogicId\x12>\n" +
@@ -6420,10 +10103,14 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
 	"\x05value\x18\x02 \x01(\v2\x14.state.v1.LabelValueR\x05value:\x028\x01B\x12\n" +
-	"\x10_computed_status\"\x15\n" +
-	"\x13ListAllEdgesRequest\"F\n" +
+	"\x10_computed_status\"Q\n" +
+	"\x13ListAllEdgesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"n\n" +
 	"\x14ListAllEdgesResponse\x12.\n" +
-	"\x05edges\x18\x01 \x03(\v2\x18.state.v1.DependencyEdgeR\x05edges\"\x80\x01\n" +
+	"\x05edges\x18\x01 \x03(\v2\x18.state.v1.DependencyEdgeR\x05edges\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x80\x01\n" +
 	"\n" +
 	"LabelValue\x12#\n" +
 	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12#\n" +
@@ -6449,7 +10136,25 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1aO\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
-	"\x05value\x18\x02 \x01(\v2\x14.state.v1.LabelValueR\x05value:\x028\x01\"\x17\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.state.v1.LabelValueR\x05value:\x028\x01\"\xa3\x01\n" +
+	"\x1dTransferStateOwnershipRequest\x12\x1b\n" +
+	"\blogic_id\x18\x01 \x01(\tH\x00R\alogicId\x12\x14\n" +
+	"\x04guid\x18\x02 \x01(\tH\x00R\x04guid\x12$\n" +
+	"\x0enew_owner_type\x18\x03 \x01(\tR\fnewOwnerType\x12 \n" +
+	"\fnew_owner_id\x18\x04 \x01(\tR\n" +
+	"newOwnerIdB\a\n" +
+	"\x05state\"x\n" +
+	"\x1eTransferStateOwnershipResponse\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12%\n" +
+	"\x0eprevious_owner\x18\x02 \x01(\tR\rpreviousOwner\x12\x1b\n" +
+	"\tnew_owner\x18\x03 \x01(\tR\bnewOwner\"\x85\x01\n" +
+	"\x18LabelConstraintViolation\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x1b\n" +
+	"\tlabel_key\x18\x02 \x01(\tR\blabelKey\x12\x1e\n" +
+	"\n" +
+	"constraint\x18\x03 \x01(\tR\n" +
+	"constraint\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x17\n" +
 	"\x15GetLabelPolicyRequest\"\xc9\x01\n" +
 	"\x16GetLabelPolicyResponse\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1f\n" +
@@ -6465,19 +10170,35 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x16SetLabelPolicyResponse\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x05R\aversion\x129\n" +
 	"\n" +
-	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"h\n" +
+	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x87\x01\n" +
 	"\x1bCreateServiceAccountRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
-	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
-	"\f_description\"\xbf\x01\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"role_names\x18\x03 \x03(\tR\troleNamesB\x0e\n" +
+	"\f_description\"\xd5\x01\n" +
 	"\x1cCreateServiceAccountResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12#\n" +
 	"\rclient_secret\x18\x03 \x01(\tR\fclientSecret\x12\x12\n" +
 	"\x04name\x18\x04 \x01(\tR\x04name\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x1c\n" +
-	"\x1aListServiceAccountsRequest\"\xa1\x02\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05roles\x18\x06 \x03(\tR\x05roles\"\xcf\x02\n" +
+	"\x1aListServiceAccountsRequest\x12$\n" +
+	"\vname_prefix\x18\x01 \x01(\tH\x00R\n" +
+	"namePrefix\x88\x01\x01\x12\x1f\n" +
+	"\bdisabled\x18\x02 \x01(\bH\x01R\bdisabled\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tH\x02R\tcreatedBy\x88\x01\x01\x12I\n" +
+	"\x10last_used_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x0elastUsedBefore\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageTokenB\x0e\n" +
+	"\f_name_prefixB\v\n" +
+	"\t_disabledB\r\n" +
+	"\v_created_byB\x13\n" +
+	"\x11_last_used_before\"\xa1\x02\n" +
 	"\x12ServiceAccountInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x12\n" +
@@ -6488,30 +10209,39 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"lastUsedAt\x12\x1a\n" +
 	"\bdisabled\x18\a \x01(\bR\bdisabledB\x0e\n" +
-	"\f_description\"f\n" +
+	"\f_description\"\x8e\x01\n" +
 	"\x1bListServiceAccountsResponse\x12G\n" +
-	"\x10service_accounts\x18\x01 \x03(\v2\x1c.state.v1.ServiceAccountInfoR\x0fserviceAccounts\":\n" +
+	"\x10service_accounts\x18\x01 \x03(\v2\x1c.state.v1.ServiceAccountInfoR\x0fserviceAccounts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\":\n" +
 	"\x1bRevokeServiceAccountRequest\x12\x1b\n" +
 	"\tclient_id\x18\x01 \x01(\tR\bclientId\"8\n" +
 	"\x1cRevokeServiceAccountResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\":\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"|\n" +
 	"\x1bRotateServiceAccountRequest\x12\x1b\n" +
-	"\tclient_id\x18\x01 \x01(\tR\bclientId\"\x9b\x01\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12,\n" +
+	"\x0foverlap_seconds\x18\x02 \x01(\x03H\x00R\x0eoverlapSeconds\x88\x01\x01B\x12\n" +
+	"\x10_overlap_seconds\"\x98\x02\n" +
 	"\x1cRotateServiceAccountResponse\x12\x1b\n" +
 	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
 	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\x129\n" +
 	"\n" +
-	"rotated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\trotatedAt\"\xcb\x02\n" +
+	"rotated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\trotatedAt\x12\\\n" +
+	"\x1aprevious_secret_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x17previousSecretExpiresAt\x88\x01\x01B\x1d\n" +
+	"\x1b_previous_secret_expires_at\"\xd9\x03\n" +
 	"\x11CreateRoleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
 	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x18\n" +
 	"\aactions\x18\x03 \x03(\tR\aactions\x12-\n" +
 	"\x10label_scope_expr\x18\x04 \x01(\tH\x01R\x0elabelScopeExpr\x88\x01\x01\x12O\n" +
 	"\x12create_constraints\x18\x05 \x01(\v2\x1b.state.v1.CreateConstraintsH\x02R\x11createConstraints\x88\x01\x01\x12%\n" +
-	"\x0eimmutable_keys\x18\x06 \x03(\tR\rimmutableKeysB\x0e\n" +
+	"\x0eimmutable_keys\x18\x06 \x03(\tR\rimmutableKeys\x12,\n" +
+	"\x0fmax_assignments\x18\a \x01(\x05H\x03R\x0emaxAssignments\x88\x01\x01\x12#\n" +
+	"\rowner_actions\x18\b \x03(\tR\fownerActions\x12%\n" +
+	"\x0edefault_labels\x18\t \x01(\bR\rdefaultLabelsB\x0e\n" +
 	"\f_descriptionB\x13\n" +
 	"\x11_label_scope_exprB\x15\n" +
-	"\x13_create_constraints\"\xbf\x01\n" +
+	"\x13_create_constraintsB\x12\n" +
+	"\x10_max_assignments\"\xbf\x01\n" +
 	"\x11CreateConstraints\x12N\n" +
 	"\vconstraints\x18\x01 \x03(\v2,.state.v1.CreateConstraints.ConstraintsEntryR\vconstraints\x1aZ\n" +
 	"\x10ConstraintsEntry\x12\x10\n" +
@@ -6519,7 +10249,7 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\v2\x1a.state.v1.CreateConstraintR\x05value:\x028\x01\"U\n" +
 	"\x10CreateConstraint\x12%\n" +
 	"\x0eallowed_values\x18\x01 \x03(\tR\rallowedValues\x12\x1a\n" +
-	"\brequired\x18\x02 \x01(\bR\brequired\"\xe2\x03\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired\"\xf0\x04\n" +
 	"\bRoleInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
@@ -6533,15 +10263,19 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
 	"\aversion\x18\n" +
-	" \x01(\x05R\aversionB\x0e\n" +
+	" \x01(\x05R\aversion\x12,\n" +
+	"\x0fmax_assignments\x18\v \x01(\x05H\x03R\x0emaxAssignments\x88\x01\x01\x12#\n" +
+	"\rowner_actions\x18\f \x03(\tR\fownerActions\x12%\n" +
+	"\x0edefault_labels\x18\r \x01(\bR\rdefaultLabelsB\x0e\n" +
 	"\f_descriptionB\x13\n" +
 	"\x11_label_scope_exprB\x15\n" +
-	"\x13_create_constraints\"<\n" +
+	"\x13_create_constraintsB\x12\n" +
+	"\x10_max_assignments\"<\n" +
 	"\x12CreateRoleResponse\x12&\n" +
 	"\x04role\x18\x01 \x01(\v2\x12.state.v1.RoleInfoR\x04role\"\x12\n" +
 	"\x10ListRolesRequest\"=\n" +
 	"\x11ListRolesResponse\x12(\n" +
-	"\x05roles\x18\x01 \x03(\v2\x12.state.v1.RoleInfoR\x05roles\"\xf6\x02\n" +
+	"\x05roles\x18\x01 \x03(\v2\x12.state.v1.RoleInfoR\x05roles\"\x84\x04\n" +
 	"\x11UpdateRoleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
 	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x18\n" +
@@ -6549,10 +10283,15 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x10label_scope_expr\x18\x04 \x01(\tH\x01R\x0elabelScopeExpr\x88\x01\x01\x12O\n" +
 	"\x12create_constraints\x18\x05 \x01(\v2\x1b.state.v1.CreateConstraintsH\x02R\x11createConstraints\x88\x01\x01\x12%\n" +
 	"\x0eimmutable_keys\x18\x06 \x03(\tR\rimmutableKeys\x12)\n" +
-	"\x10expected_version\x18\a \x01(\x05R\x0fexpectedVersionB\x0e\n" +
+	"\x10expected_version\x18\a \x01(\x05R\x0fexpectedVersion\x12,\n" +
+	"\x0fmax_assignments\x18\b \x01(\x05H\x03R\x0emaxAssignments\x88\x01\x01\x12#\n" +
+	"\rowner_actions\x18\t \x03(\tR\fownerActions\x12%\n" +
+	"\x0edefault_labels\x18\n" +
+	" \x01(\bR\rdefaultLabelsB\x0e\n" +
 	"\f_descriptionB\x13\n" +
 	"\x11_label_scope_exprB\x15\n" +
-	"\x13_create_constraints\"<\n" +
+	"\x13_create_constraintsB\x12\n" +
+	"\x10_max_assignments\"<\n" +
 	"\x12UpdateRoleResponse\x12&\n" +
 	"\x04role\x18\x01 \x01(\v2\x12.state.v1.RoleInfoR\x04role\"'\n" +
 	"\x11DeleteRoleRequest\x12\x12\n" +
@@ -6582,11 +10321,12 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"assignedAt\x12-\n" +
 	"\x13assigned_by_user_id\x18\x03 \x01(\tR\x10assignedByUserId\"K\n" +
 	"\x15ListUserRolesResponse\x122\n" +
-	"\x05roles\x18\x01 \x03(\v2\x1c.state.v1.RoleAssignmentInfoR\x05roles\"T\n" +
+	"\x05roles\x18\x01 \x03(\v2\x1c.state.v1.RoleAssignmentInfoR\x05roles\"r\n" +
 	"\x16AssignGroupRoleRequest\x12\x1d\n" +
 	"\n" +
 	"group_name\x18\x01 \x01(\tR\tgroupName\x12\x1b\n" +
-	"\trole_name\x18\x02 \x01(\tR\broleName\"p\n" +
+	"\trole_name\x18\x02 \x01(\tR\broleName\x12\x1c\n" +
+	"\tcondition\x18\x03 \x01(\tR\tcondition\"p\n" +
 	"\x17AssignGroupRoleResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12;\n" +
 	"\vassigned_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
@@ -6600,14 +10340,15 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x15ListGroupRolesRequest\x12\"\n" +
 	"\n" +
 	"group_name\x18\x01 \x01(\tH\x00R\tgroupName\x88\x01\x01B\r\n" +
-	"\v_group_name\"\xc1\x01\n" +
+	"\v_group_name\"\xdf\x01\n" +
 	"\x17GroupRoleAssignmentInfo\x12\x1d\n" +
 	"\n" +
 	"group_name\x18\x01 \x01(\tR\tgroupName\x12\x1b\n" +
 	"\trole_name\x18\x02 \x01(\tR\broleName\x12;\n" +
 	"\vassigned_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"assignedAt\x12-\n" +
-	"\x13assigned_by_user_id\x18\x04 \x01(\tR\x10assignedByUserId\"]\n" +
+	"\x13assigned_by_user_id\x18\x04 \x01(\tR\x10assignedByUserId\x12\x1c\n" +
+	"\tcondition\x18\x05 \x01(\tR\tcondition\"]\n" +
 	"\x16ListGroupRolesResponse\x12C\n" +
 	"\vassignments\x18\x01 \x03(\v2!.state.v1.GroupRoleAssignmentInfoR\vassignments\"j\n" +
 	"\x1eGetEffectivePermissionsRequest\x12%\n" +
@@ -6619,11 +10360,12 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x11label_scope_exprs\x18\x03 \x03(\tR\x0flabelScopeExprs\x12b\n" +
 	"\x1ceffective_create_constraints\x18\x04 \x01(\v2\x1b.state.v1.CreateConstraintsH\x00R\x1aeffectiveCreateConstraints\x88\x01\x01\x128\n" +
 	"\x18effective_immutable_keys\x18\x05 \x03(\tR\x16effectiveImmutableKeysB\x1f\n" +
-	"\x1d_effective_create_constraints\"c\n" +
+	"\x1d_effective_create_constraints\"\x93\x01\n" +
 	"\x1fGetEffectivePermissionsResponse\x12@\n" +
-	"\vpermissions\x18\x01 \x01(\v2\x1e.state.v1.EffectivePermissionsR\vpermissions\".\n" +
+	"\vpermissions\x18\x01 \x01(\v2\x1e.state.v1.EffectivePermissionsR\vpermissions\x12.\n" +
+	"\x13authz_model_version\x18\x02 \x01(\x04R\x11authzModelVersion\".\n" +
 	"\x13ListSessionsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xb7\x02\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc5\x03\n" +
 	"\vSessionInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
 	"\n" +
@@ -6635,16 +10377,91 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\n" +
 	"user_agent\x18\x05 \x01(\tH\x00R\tuserAgent\x88\x01\x01\x12\"\n" +
 	"\n" +
-	"ip_address\x18\x06 \x01(\tH\x01R\tipAddress\x88\x01\x01B\r\n" +
+	"ip_address\x18\x06 \x01(\tH\x01R\tipAddress\x88\x01\x01\x12\x1c\n" +
+	"\auser_id\x18\a \x01(\tH\x02R\x06userId\x88\x01\x01\x12\x18\n" +
+	"\arevoked\x18\b \x01(\bR\arevoked\x121\n" +
+	"\x12service_account_id\x18\t \x01(\tH\x03R\x10serviceAccountId\x88\x01\x01B\r\n" +
 	"\v_user_agentB\r\n" +
-	"\v_ip_address\"I\n" +
+	"\v_ip_addressB\n" +
+	"\n" +
+	"\b_user_idB\x15\n" +
+	"\x13_service_account_id\"I\n" +
 	"\x14ListSessionsResponse\x121\n" +
-	"\bsessions\x18\x01 \x03(\v2\x15.state.v1.SessionInfoR\bsessions\"5\n" +
+	"\bsessions\x18\x01 \x03(\v2\x15.state.v1.SessionInfoR\bsessions\"\x99\x02\n" +
+	"\x16ListAllSessionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vactive_only\x18\x02 \x01(\bR\n" +
+	"activeOnly\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12,\n" +
+	"\x12service_account_id\x18\x06 \x01(\tR\x10serviceAccountId\x12!\n" +
+	"\frevoked_only\x18\a \x01(\bR\vrevokedOnly\"m\n" +
+	"\x17ListAllSessionsResponse\x121\n" +
+	"\bsessions\x18\x01 \x03(\v2\x15.state.v1.SessionInfoR\bsessions\x12\x1f\n" +
+	"\vnext_offset\x18\x02 \x01(\x05R\n" +
+	"nextOffset\"\xe2\x01\n" +
+	"\x15RevokeSessionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12,\n" +
+	"\x12service_account_id\x18\x02 \x01(\tR\x10serviceAccountId\x12A\n" +
+	"\x0ecreated_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\"=\n" +
+	"\x16RevokeSessionsResponse\x12#\n" +
+	"\rrevoked_count\x18\x01 \x01(\x05R\frevokedCount\"5\n" +
 	"\x14RevokeSessionRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\"1\n" +
 	"\x15RevokeSessionResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xaa\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\".\n" +
+	"\x16IntrospectTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9d\x04\n" +
+	"\x17IntrospectTokenResponse\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x12,\n" +
+	"\x0finactive_reason\x18\x02 \x01(\tH\x00R\x0einactiveReason\x88\x01\x01\x12\x1d\n" +
+	"\asubject\x18\x03 \x01(\tH\x01R\asubject\x88\x01\x01\x12 \n" +
+	"\tclient_id\x18\x04 \x01(\tH\x02R\bclientId\x88\x01\x01\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x12>\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\texpiresAt\x88\x01\x01\x12<\n" +
+	"\tissued_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x04R\bissuedAt\x88\x01\x01\x12\x15\n" +
+	"\x03jti\x18\b \x01(\tH\x05R\x03jti\x88\x01\x01\x12\x1f\n" +
+	"\vjti_revoked\x18\t \x01(\bR\n" +
+	"jtiRevoked\x12\"\n" +
+	"\n" +
+	"session_id\x18\n" +
+	" \x01(\tH\x06R\tsessionId\x88\x01\x01\x12'\n" +
+	"\x0fsession_revoked\x18\v \x01(\bR\x0esessionRevokedB\x12\n" +
+	"\x10_inactive_reasonB\n" +
+	"\n" +
+	"\b_subjectB\f\n" +
+	"\n" +
+	"_client_idB\r\n" +
+	"\v_expires_atB\f\n" +
+	"\n" +
+	"_issued_atB\x06\n" +
+	"\x04_jtiB\r\n" +
+	"\v_session_id\"\x97\x02\n" +
+	"\x1bPreviewAuthorizationRequest\x12\x14\n" +
+	"\x05roles\x18\x01 \x03(\tR\x05roles\x12\x16\n" +
+	"\x06groups\x18\x02 \x03(\tR\x06groups\x12\x16\n" +
+	"\x06object\x18\x03 \x01(\tR\x06object\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12I\n" +
+	"\x06labels\x18\x05 \x03(\v21.state.v1.PreviewAuthorizationRequest.LabelsEntryR\x06labels\x1aO\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.state.v1.LabelValueR\x05value:\x028\x01\"\xcc\x01\n" +
+	"\x19RoleAuthorizationDecision\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\aallowed\x18\x02 \x01(\bR\aallowed\x12(\n" +
+	"\rpolicy_action\x18\x03 \x01(\tH\x00R\fpolicyAction\x88\x01\x01\x12/\n" +
+	"\x11policy_scope_expr\x18\x04 \x01(\tH\x01R\x0fpolicyScopeExpr\x88\x01\x01B\x10\n" +
+	"\x0e_policy_actionB\x14\n" +
+	"\x12_policy_scope_expr\"\xad\x01\n" +
+	"\x1cPreviewAuthorizationResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12 \n" +
+	"\vcombination\x18\x02 \x01(\tR\vcombination\x129\n" +
+	"\x05roles\x18\x03 \x03(\v2#.state.v1.RoleAuthorizationDecisionR\x05roles\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xec\x01\n" +
 	"\x16SetOutputSchemaRequest\x12&\n" +
 	"\x0estate_logic_id\x18\x01 \x01(\tH\x00R\fstateLogicId\x12\x1f\n" +
 	"\n" +
@@ -6652,22 +10469,67 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\n" +
 	"output_key\x18\x03 \x01(\tR\toutputKey\x12\x1f\n" +
 	"\vschema_json\x18\x04 \x01(\tR\n" +
-	"schemaJsonB\a\n" +
-	"\x05state\"\x97\x01\n" +
+	"schemaJson\x12!\n" +
+	"\fvalidate_now\x18\x05 \x01(\bR\vvalidateNow\x12\x1d\n" +
+	"\n" +
+	"schema_ref\x18\x06 \x01(\tR\tschemaRefB\a\n" +
+	"\x05state\"\xa4\x02\n" +
 	"\x17SetOutputSchemaResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
 	"\n" +
 	"state_guid\x18\x02 \x01(\tR\tstateGuid\x12$\n" +
 	"\x0estate_logic_id\x18\x03 \x01(\tR\fstateLogicId\x12\x1d\n" +
 	"\n" +
-	"output_key\x18\x04 \x01(\tR\toutputKey\"\x89\x01\n" +
+	"output_key\x18\x04 \x01(\tR\toutputKey\x120\n" +
+	"\x11validation_status\x18\x05 \x01(\tH\x00R\x10validationStatus\x88\x01\x01\x12.\n" +
+	"\x10validation_error\x18\x06 \x01(\tH\x01R\x0fvalidationError\x88\x01\x01B\x14\n" +
+	"\x12_validation_statusB\x13\n" +
+	"\x11_validation_error\"\xf1\x01\n" +
+	"\x17SetOutputSchemasRequest\x12&\n" +
+	"\x0estate_logic_id\x18\x01 \x01(\tH\x00R\fstateLogicId\x12\x1f\n" +
+	"\n" +
+	"state_guid\x18\x02 \x01(\tH\x00R\tstateGuid\x12H\n" +
+	"\aschemas\x18\x03 \x03(\v2..state.v1.SetOutputSchemasRequest.SchemasEntryR\aschemas\x1a:\n" +
+	"\fSchemasEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05state\"M\n" +
+	"\x12OutputSchemaResult\x12\x1d\n" +
+	"\n" +
+	"output_key\x18\x01 \x01(\tR\toutputKey\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"\x97\x01\n" +
+	"\x18SetOutputSchemasResponse\x12\x1d\n" +
+	"\n" +
+	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
+	"\x0estate_logic_id\x18\x02 \x01(\tR\fstateLogicId\x126\n" +
+	"\aresults\x18\x03 \x03(\v2\x1c.state.v1.OutputSchemaResultR\aresults\"\x94\x01\n" +
+	"!RevalidateOutputsForSchemaRequest\x12&\n" +
+	"\x0estate_logic_id\x18\x01 \x01(\tH\x00R\fstateLogicId\x12\x1f\n" +
+	"\n" +
+	"state_guid\x18\x02 \x01(\tH\x00R\tstateGuid\x12\x1d\n" +
+	"\n" +
+	"output_key\x18\x03 \x01(\tR\toutputKeyB\a\n" +
+	"\x05state\"\xc1\x01\n" +
+	"\x1aOutputValidationTransition\x12\x1d\n" +
+	"\n" +
+	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
+	"\x0estate_logic_id\x18\x02 \x01(\tR\fstateLogicId\x12\x1d\n" +
+	"\n" +
+	"output_key\x18\x03 \x01(\tR\toutputKey\x12'\n" +
+	"\x0fprevious_status\x18\x04 \x01(\tR\x0epreviousStatus\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"\xd8\x01\n" +
+	"\"RevalidateOutputsForSchemaResponse\x12 \n" +
+	"\vrevalidated\x18\x01 \x01(\x05R\vrevalidated\x12%\n" +
+	"\x0ebecame_invalid\x18\x02 \x01(\x05R\rbecameInvalid\x12!\n" +
+	"\fbecame_valid\x18\x03 \x01(\x05R\vbecameValid\x12F\n" +
+	"\vtransitions\x18\x04 \x03(\v2$.state.v1.OutputValidationTransitionR\vtransitions\"\x89\x01\n" +
 	"\x16GetOutputSchemaRequest\x12&\n" +
 	"\x0estate_logic_id\x18\x01 \x01(\tH\x00R\fstateLogicId\x12\x1f\n" +
 	"\n" +
 	"state_guid\x18\x02 \x01(\tH\x00R\tstateGuid\x12\x1d\n" +
 	"\n" +
 	"output_key\x18\x03 \x01(\tR\toutputKeyB\a\n" +
-	"\x05state\"\x9e\x01\n" +
+	"\x05state\"\xe4\x01\n" +
 	"\x17GetOutputSchemaResponse\x12\x1d\n" +
 	"\n" +
 	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
@@ -6675,7 +10537,119 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\n" +
 	"output_key\x18\x03 \x01(\tR\toutputKey\x12\x1f\n" +
 	"\vschema_json\x18\x04 \x01(\tR\n" +
-	"schemaJson2\xc4\x19\n" +
+	"schemaJson\x12\x1d\n" +
+	"\n" +
+	"schema_ref\x18\x05 \x01(\tR\tschemaRef\x12%\n" +
+	"\x0eschema_version\x18\x06 \x01(\x05R\rschemaVersion\"\x91\x01\n" +
+	"\x1eListOutputSchemaHistoryRequest\x12&\n" +
+	"\x0estate_logic_id\x18\x01 \x01(\tH\x00R\fstateLogicId\x12\x1f\n" +
+	"\n" +
+	"state_guid\x18\x02 \x01(\tH\x00R\tstateGuid\x12\x1d\n" +
+	"\n" +
+	"output_key\x18\x03 \x01(\tR\toutputKeyB\a\n" +
+	"\x05state\"\xe7\x01\n" +
+	"\x13OutputSchemaVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1f\n" +
+	"\vschema_json\x18\x02 \x01(\tR\n" +
+	"schemaJson\x12#\n" +
+	"\rschema_source\x18\x03 \x01(\tR\fschemaSource\x12\x1d\n" +
+	"\n" +
+	"schema_ref\x18\x04 \x01(\tR\tschemaRef\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06active\x18\x06 \x01(\bR\x06active\"\xe4\x02\n" +
+	"\x1fListOutputSchemaHistoryResponse\x12\x1d\n" +
+	"\n" +
+	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
+	"\x0estate_logic_id\x18\x02 \x01(\tR\fstateLogicId\x12\x1d\n" +
+	"\n" +
+	"output_key\x18\x03 \x01(\tR\toutputKey\x129\n" +
+	"\bversions\x18\x04 \x03(\v2\x1d.state.v1.OutputSchemaVersionR\bversions\x12=\n" +
+	"\x18validated_schema_version\x18\x05 \x01(\x05H\x00R\x16validatedSchemaVersion\x88\x01\x01\x120\n" +
+	"\x11validation_status\x18\x06 \x01(\tH\x01R\x10validationStatus\x88\x01\x01B\x1b\n" +
+	"\x19_validated_schema_versionB\x14\n" +
+	"\x12_validation_status\"\xdd\x01\n" +
+	"\x10RegisteredSchema\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1f\n" +
+	"\vschema_json\x18\x02 \x01(\tR\n" +
+	"schemaJson\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"t\n" +
+	"\x1dCreateRegisteredSchemaRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1f\n" +
+	"\vschema_json\x18\x02 \x01(\tR\n" +
+	"schemaJson\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"T\n" +
+	"\x1eCreateRegisteredSchemaResponse\x122\n" +
+	"\x06schema\x18\x01 \x01(\v2\x1a.state.v1.RegisteredSchemaR\x06schema\".\n" +
+	"\x1aGetRegisteredSchemaRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"Q\n" +
+	"\x1bGetRegisteredSchemaResponse\x122\n" +
+	"\x06schema\x18\x01 \x01(\v2\x1a.state.v1.RegisteredSchemaR\x06schema\"\x1e\n" +
+	"\x1cListRegisteredSchemasRequest\"U\n" +
+	"\x1dListRegisteredSchemasResponse\x124\n" +
+	"\aschemas\x18\x01 \x03(\v2\x1a.state.v1.RegisteredSchemaR\aschemas\"\xa9\x01\n" +
+	"\x1dUpdateRegisteredSchemaRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1f\n" +
+	"\vschema_json\x18\x02 \x01(\tR\n" +
+	"schemaJson\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"revalidate\x18\x04 \x01(\bR\n" +
+	"revalidateB\x0e\n" +
+	"\f_description\"\xa6\x01\n" +
+	"\x1eUpdateRegisteredSchemaResponse\x122\n" +
+	"\x06schema\x18\x01 \x01(\v2\x1a.state.v1.RegisteredSchemaR\x06schema\x12P\n" +
+	"\frevalidation\x18\x02 \x01(\v2,.state.v1.RevalidateOutputsForSchemaResponseR\frevalidation\"1\n" +
+	"\x1dDeleteRegisteredSchemaRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\" \n" +
+	"\x1eDeleteRegisteredSchemaResponse\"t\n" +
+	" GetStateValidationSummaryRequest\x12&\n" +
+	"\x0estate_logic_id\x18\x01 \x01(\tH\x00R\fstateLogicId\x12\x1f\n" +
+	"\n" +
+	"state_guid\x18\x02 \x01(\tH\x00R\tstateGuidB\a\n" +
+	"\x05state\"\xe3\x01\n" +
+	"\x15OutputValidationIssue\x12\x1d\n" +
+	"\n" +
+	"output_key\x18\x01 \x01(\tR\toutputKey\x12+\n" +
+	"\x11validation_status\x18\x02 \x01(\tR\x10validationStatus\x12)\n" +
+	"\x10validation_error\x18\x03 \x01(\tR\x0fvalidationError\x12B\n" +
+	"\fvalidated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\vvalidatedAt\x88\x01\x01B\x0f\n" +
+	"\r_validated_at\"\xc0\x03\n" +
+	"!GetStateValidationSummaryResponse\x12\x1d\n" +
+	"\n" +
+	"state_guid\x18\x01 \x01(\tR\tstateGuid\x12$\n" +
+	"\x0estate_logic_id\x18\x02 \x01(\tR\fstateLogicId\x12#\n" +
+	"\rtotal_outputs\x18\x03 \x01(\x05R\ftotalOutputs\x12\x1f\n" +
+	"\vvalid_count\x18\x04 \x01(\x05R\n" +
+	"validCount\x12#\n" +
+	"\rinvalid_count\x18\x05 \x01(\x05R\finvalidCount\x12\x1f\n" +
+	"\verror_count\x18\x06 \x01(\x05R\n" +
+	"errorCount\x12.\n" +
+	"\x13not_validated_count\x18\a \x01(\x05R\x11notValidatedCount\x127\n" +
+	"\x06issues\x18\b \x03(\v2\x1f.state.v1.OutputValidationIssueR\x06issues\x12K\n" +
+	"\x11last_validated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0flastValidatedAt\x88\x01\x01B\x14\n" +
+	"\x12_last_validated_at\"]\n" +
+	"\x18WatchStateChangesRequest\x12\x1e\n" +
+	"\blogic_id\x18\x01 \x01(\tH\x00R\alogicId\x88\x01\x01\x12\x14\n" +
+	"\x05kinds\x18\x02 \x03(\tR\x05kindsB\v\n" +
+	"\t_logic_id\"\x8d\x03\n" +
+	"\x10StateChangeEvent\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x19\n" +
+	"\blogic_id\x18\x02 \x01(\tR\alogicId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06serial\x18\x04 \x01(\x03R\x06serial\x12>\n" +
+	"\x06labels\x18\x05 \x03(\v2&.state.v1.StateChangeEvent.LabelsEntryR\x06labels\x12'\n" +
+	"\x0fcomputed_status\x18\x06 \x01(\tR\x0ecomputedStatus\x12'\n" +
+	"\x0fchanged_outputs\x18\a \x03(\tR\x0echangedOutputs\x12;\n" +
+	"\vdetected_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"detectedAt\x1aO\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.state.v1.LabelValueR\x05value:\x028\x012\x8a(\n" +
 	"\fStateService\x12J\n" +
 	"\vCreateState\x12\x1c.state.v1.CreateStateRequest\x1a\x1d.state.v1.CreateStateResponse\x12G\n" +
 	"\n" +
@@ -6690,11 +10664,16 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x0eSearchByOutput\x12\x1f.state.v1.SearchByOutputRequest\x1a .state.v1.SearchByOutputResponse\x12b\n" +
 	"\x13GetTopologicalOrder\x12$.state.v1.GetTopologicalOrderRequest\x1a%.state.v1.GetTopologicalOrderResponse\x12S\n" +
 	"\x0eGetStateStatus\x12\x1f.state.v1.GetStateStatusRequest\x1a .state.v1.GetStateStatusResponse\x12_\n" +
-	"\x12GetDependencyGraph\x12#.state.v1.GetDependencyGraphRequest\x1a$.state.v1.GetDependencyGraphResponse\x12Y\n" +
-	"\x10ListStateOutputs\x12!.state.v1.ListStateOutputsRequest\x1a\".state.v1.ListStateOutputsResponse\x12M\n" +
+	"\x12GetDependencyGraph\x12#.state.v1.GetDependencyGraphRequest\x1a$.state.v1.GetDependencyGraphResponse\x12t\n" +
+	"\x19RecomputeDependencyStatus\x12*.state.v1.RecomputeDependencyStatusRequest\x1a+.state.v1.RecomputeDependencyStatusResponse\x12Y\n" +
+	"\x10ListStateOutputs\x12!.state.v1.ListStateOutputsRequest\x1a\".state.v1.ListStateOutputsResponse\x12h\n" +
+	"\x15ListStateOutputsBatch\x12&.state.v1.ListStateOutputsBatchRequest\x1a'.state.v1.ListStateOutputsBatchResponse\x12e\n" +
+	"\x14GetStateOutputValues\x12%.state.v1.GetStateOutputValuesRequest\x1a&.state.v1.GetStateOutputValuesResponse\x12M\n" +
 	"\fGetStateInfo\x12\x1d.state.v1.GetStateInfoRequest\x1a\x1e.state.v1.GetStateInfoResponse\x12M\n" +
-	"\fListAllEdges\x12\x1d.state.v1.ListAllEdgesRequest\x1a\x1e.state.v1.ListAllEdgesResponse\x12\\\n" +
-	"\x11UpdateStateLabels\x12\".state.v1.UpdateStateLabelsRequest\x1a#.state.v1.UpdateStateLabelsResponse\x12S\n" +
+	"\fListAllEdges\x12\x1d.state.v1.ListAllEdgesRequest\x1a\x1e.state.v1.ListAllEdgesResponse\x12U\n" +
+	"\x11WatchStateChanges\x12\".state.v1.WatchStateChangesRequest\x1a\x1a.state.v1.StateChangeEvent0\x01\x12\\\n" +
+	"\x11UpdateStateLabels\x12\".state.v1.UpdateStateLabelsRequest\x1a#.state.v1.UpdateStateLabelsResponse\x12k\n" +
+	"\x16TransferStateOwnership\x12'.state.v1.TransferStateOwnershipRequest\x1a(.state.v1.TransferStateOwnershipResponse\x12S\n" +
 	"\x0eGetLabelPolicy\x12\x1f.state.v1.GetLabelPolicyRequest\x1a .state.v1.GetLabelPolicyResponse\x12S\n" +
 	"\x0eSetLabelPolicy\x12\x1f.state.v1.SetLabelPolicyRequest\x1a .state.v1.SetLabelPolicyResponse\x12e\n" +
 	"\x14CreateServiceAccount\x12%.state.v1.CreateServiceAccountRequest\x1a&.state.v1.CreateServiceAccountResponse\x12b\n" +
@@ -6719,8 +10698,21 @@ const file_state_v1_state_proto_rawDesc = "" +
 	"\x17GetEffectivePermissions\x12(.state.v1.GetEffectivePermissionsRequest\x1a).state.v1.GetEffectivePermissionsResponse\x12M\n" +
 	"\fListSessions\x12\x1d.state.v1.ListSessionsRequest\x1a\x1e.state.v1.ListSessionsResponse\x12P\n" +
 	"\rRevokeSession\x12\x1e.state.v1.RevokeSessionRequest\x1a\x1f.state.v1.RevokeSessionResponse\x12V\n" +
-	"\x0fSetOutputSchema\x12 .state.v1.SetOutputSchemaRequest\x1a!.state.v1.SetOutputSchemaResponse\x12V\n" +
-	"\x0fGetOutputSchema\x12 .state.v1.GetOutputSchemaRequest\x1a!.state.v1.GetOutputSchemaResponseB:Z8github.com/terraconstructs/grid/pkg/api/state/v1;statev1b\x06proto3"
+	"\x0fListAllSessions\x12 .state.v1.ListAllSessionsRequest\x1a!.state.v1.ListAllSessionsResponse\x12S\n" +
+	"\x0eRevokeSessions\x12\x1f.state.v1.RevokeSessionsRequest\x1a .state.v1.RevokeSessionsResponse\x12V\n" +
+	"\x0fIntrospectToken\x12 .state.v1.IntrospectTokenRequest\x1a!.state.v1.IntrospectTokenResponse\x12e\n" +
+	"\x14PreviewAuthorization\x12%.state.v1.PreviewAuthorizationRequest\x1a&.state.v1.PreviewAuthorizationResponse\x12V\n" +
+	"\x0fSetOutputSchema\x12 .state.v1.SetOutputSchemaRequest\x1a!.state.v1.SetOutputSchemaResponse\x12Y\n" +
+	"\x10SetOutputSchemas\x12!.state.v1.SetOutputSchemasRequest\x1a\".state.v1.SetOutputSchemasResponse\x12w\n" +
+	"\x1aRevalidateOutputsForSchema\x12+.state.v1.RevalidateOutputsForSchemaRequest\x1a,.state.v1.RevalidateOutputsForSchemaResponse\x12k\n" +
+	"\x16CreateRegisteredSchema\x12'.state.v1.CreateRegisteredSchemaRequest\x1a(.state.v1.CreateRegisteredSchemaResponse\x12b\n" +
+	"\x13GetRegisteredSchema\x12$.state.v1.GetRegisteredSchemaRequest\x1a%.state.v1.GetRegisteredSchemaResponse\x12h\n" +
+	"\x15ListRegisteredSchemas\x12&.state.v1.ListRegisteredSchemasRequest\x1a'.state.v1.ListRegisteredSchemasResponse\x12k\n" +
+	"\x16UpdateRegisteredSchema\x12'.state.v1.UpdateRegisteredSchemaRequest\x1a(.state.v1.UpdateRegisteredSchemaResponse\x12k\n" +
+	"\x16DeleteRegisteredSchema\x12'.state.v1.DeleteRegisteredSchemaRequest\x1a(.state.v1.DeleteRegisteredSchemaResponse\x12V\n" +
+	"\x0fGetOutputSchema\x12 .state.v1.GetOutputSchemaRequest\x1a!.state.v1.GetOutputSchemaResponse\x12n\n" +
+	"\x17ListOutputSchemaHistory\x12(.state.v1.ListOutputSchemaHistoryRequest\x1a).state.v1.ListOutputSchemaHistoryResponse\x12t\n" +
+	"\x19GetStateValidationSummary\x12*.state.v1.GetStateValidationSummaryRequest\x1a+.state.v1.GetStateValidationSummaryResponseB:Z8github.com/terraconstructs/grid/pkg/api/state/v1;statev1b\x06proto3"
 
 var (
 	file_state_v1_state_proto_rawDescOnce sync.Once
@@ -6734,269 +10726,388 @@ func file_state_v1_state_proto_rawDescGZIP() []byte {
 	return file_state_v1_state_proto_rawDescData
 }
 
-var file_state_v1_state_proto_msgTypes = make([]protoimpl.MessageInfo, 102)
+var file_state_v1_state_proto_msgTypes = make([]protoimpl.MessageInfo, 150)
 var file_state_v1_state_proto_goTypes = []any{
-	(*CreateStateRequest)(nil),              // 0: state.v1.CreateStateRequest
-	(*CreateStateResponse)(nil),             // 1: state.v1.CreateStateResponse
-	(*ListStatesRequest)(nil),               // 2: state.v1.ListStatesRequest
-	(*ListStatesResponse)(nil),              // 3: state.v1.ListStatesResponse
-	(*StateInfo)(nil),                       // 4: state.v1.StateInfo
-	(*BackendConfig)(nil),                   // 5: state.v1.BackendConfig
-	(*GetStateConfigRequest)(nil),           // 6: state.v1.GetStateConfigRequest
-	(*GetStateConfigResponse)(nil),          // 7: state.v1.GetStateConfigResponse
-	(*GetStateLockRequest)(nil),             // 8: state.v1.GetStateLockRequest
-	(*LockInfo)(nil),                        // 9: state.v1.LockInfo
-	(*StateLock)(nil),                       // 10: state.v1.StateLock
-	(*GetStateLockResponse)(nil),            // 11: state.v1.GetStateLockResponse
-	(*UnlockStateRequest)(nil),              // 12: state.v1.UnlockStateRequest
-	(*UnlockStateResponse)(nil),             // 13: state.v1.UnlockStateResponse
-	(*AddDependencyRequest)(nil),            // 14: state.v1.AddDependencyRequest
-	(*AddDependencyResponse)(nil),           // 15: state.v1.AddDependencyResponse
-	(*RemoveDependencyRequest)(nil),         // 16: state.v1.RemoveDependencyRequest
-	(*RemoveDependencyResponse)(nil),        // 17: state.v1.RemoveDependencyResponse
-	(*ListDependenciesRequest)(nil),         // 18: state.v1.ListDependenciesRequest
-	(*ListDependenciesResponse)(nil),        // 19: state.v1.ListDependenciesResponse
-	(*ListDependentsRequest)(nil),           // 20: state.v1.ListDependentsRequest
-	(*ListDependentsResponse)(nil),          // 21: state.v1.ListDependentsResponse
-	(*SearchByOutputRequest)(nil),           // 22: state.v1.SearchByOutputRequest
-	(*SearchByOutputResponse)(nil),          // 23: state.v1.SearchByOutputResponse
-	(*GetTopologicalOrderRequest)(nil),      // 24: state.v1.GetTopologicalOrderRequest
-	(*GetTopologicalOrderResponse)(nil),     // 25: state.v1.GetTopologicalOrderResponse
-	(*Layer)(nil),                           // 26: state.v1.Layer
-	(*StateRef)(nil),                        // 27: state.v1.StateRef
-	(*GetStateStatusRequest)(nil),           // 28: state.v1.GetStateStatusRequest
-	(*GetStateStatusResponse)(nil),          // 29: state.v1.GetStateStatusResponse
-	(*IncomingEdgeView)(nil),                // 30: state.v1.IncomingEdgeView
-	(*StatusSummary)(nil),                   // 31: state.v1.StatusSummary
-	(*GetDependencyGraphRequest)(nil),       // 32: state.v1.GetDependencyGraphRequest
-	(*GetDependencyGraphResponse)(nil),      // 33: state.v1.GetDependencyGraphResponse
-	(*ProducerState)(nil),                   // 34: state.v1.ProducerState
-	(*DependencyEdge)(nil),                  // 35: state.v1.DependencyEdge
-	(*OutputKey)(nil),                       // 36: state.v1.OutputKey
-	(*ListStateOutputsRequest)(nil),         // 37: state.v1.ListStateOutputsRequest
-	(*ListStateOutputsResponse)(nil),        // 38: state.v1.ListStateOutputsResponse
-	(*GetStateInfoRequest)(nil),             // 39: state.v1.GetStateInfoRequest
-	(*GetStateInfoResponse)(nil),            // 40: state.v1.GetStateInfoResponse
-	(*ListAllEdgesRequest)(nil),             // 41: state.v1.ListAllEdgesRequest
-	(*ListAllEdgesResponse)(nil),            // 42: state.v1.ListAllEdgesResponse
-	(*LabelValue)(nil),                      // 43: state.v1.LabelValue
-	(*UpdateStateLabelsRequest)(nil),        // 44: state.v1.UpdateStateLabelsRequest
-	(*UpdateStateLabelsResponse)(nil),       // 45: state.v1.UpdateStateLabelsResponse
-	(*GetLabelPolicyRequest)(nil),           // 46: state.v1.GetLabelPolicyRequest
-	(*GetLabelPolicyResponse)(nil),          // 47: state.v1.GetLabelPolicyResponse
-	(*SetLabelPolicyRequest)(nil),           // 48: state.v1.SetLabelPolicyRequest
-	(*SetLabelPolicyResponse)(nil),          // 49: state.v1.SetLabelPolicyResponse
-	(*CreateServiceAccountRequest)(nil),     // 50: state.v1.CreateServiceAccountRequest
-	(*CreateServiceAccountResponse)(nil),    // 51: state.v1.CreateServiceAccountResponse
-	(*ListServiceAccountsRequest)(nil),      // 52: state.v1.ListServiceAccountsRequest
-	(*ServiceAccountInfo)(nil),              // 53: state.v1.ServiceAccountInfo
-	(*ListServiceAccountsResponse)(nil),     // 54: state.v1.ListServiceAccountsResponse
-	(*RevokeServiceAccountRequest)(nil),     // 55: state.v1.RevokeServiceAccountRequest
-	(*RevokeServiceAccountResponse)(nil),    // 56: state.v1.RevokeServiceAccountResponse
-	(*RotateServiceAccountRequest)(nil),     // 57: state.v1.RotateServiceAccountRequest
-	(*RotateServiceAccountResponse)(nil),    // 58: state.v1.RotateServiceAccountResponse
-	(*CreateRoleRequest)(nil),               // 59: state.v1.CreateRoleRequest
-	(*CreateConstraints)(nil),               // 60: state.v1.CreateConstraints
-	(*CreateConstraint)(nil),                // 61: state.v1.CreateConstraint
-	(*RoleInfo)(nil),                        // 62: state.v1.RoleInfo
-	(*CreateRoleResponse)(nil),              // 63: state.v1.CreateRoleResponse
-	(*ListRolesRequest)(nil),                // 64: state.v1.ListRolesRequest
-	(*ListRolesResponse)(nil),               // 65: state.v1.ListRolesResponse
-	(*UpdateRoleRequest)(nil),               // 66: state.v1.UpdateRoleRequest
-	(*UpdateRoleResponse)(nil),              // 67: state.v1.UpdateRoleResponse
-	(*DeleteRoleRequest)(nil),               // 68: state.v1.DeleteRoleRequest
-	(*DeleteRoleResponse)(nil),              // 69: state.v1.DeleteRoleResponse
-	(*AssignRoleRequest)(nil),               // 70: state.v1.AssignRoleRequest
-	(*AssignRoleResponse)(nil),              // 71: state.v1.AssignRoleResponse
-	(*RemoveRoleRequest)(nil),               // 72: state.v1.RemoveRoleRequest
-	(*RemoveRoleResponse)(nil),              // 73: state.v1.RemoveRoleResponse
-	(*ListUserRolesRequest)(nil),            // 74: state.v1.ListUserRolesRequest
-	(*RoleAssignmentInfo)(nil),              // 75: state.v1.RoleAssignmentInfo
-	(*ListUserRolesResponse)(nil),           // 76: state.v1.ListUserRolesResponse
-	(*AssignGroupRoleRequest)(nil),          // 77: state.v1.AssignGroupRoleRequest
-	(*AssignGroupRoleResponse)(nil),         // 78: state.v1.AssignGroupRoleResponse
-	(*RemoveGroupRoleRequest)(nil),          // 79: state.v1.RemoveGroupRoleRequest
-	(*RemoveGroupRoleResponse)(nil),         // 80: state.v1.RemoveGroupRoleResponse
-	(*ListGroupRolesRequest)(nil),           // 81: state.v1.ListGroupRolesRequest
-	(*GroupRoleAssignmentInfo)(nil),         // 82: state.v1.GroupRoleAssignmentInfo
-	(*ListGroupRolesResponse)(nil),          // 83: state.v1.ListGroupRolesResponse
-	(*GetEffectivePermissionsRequest)(nil),  // 84: state.v1.GetEffectivePermissionsRequest
-	(*EffectivePermissions)(nil),            // 85: state.v1.EffectivePermissions
-	(*GetEffectivePermissionsResponse)(nil), // 86: state.v1.GetEffectivePermissionsResponse
-	(*ListSessionsRequest)(nil),             // 87: state.v1.ListSessionsRequest
-	(*SessionInfo)(nil),                     // 88: state.v1.SessionInfo
-	(*ListSessionsResponse)(nil),            // 89: state.v1.ListSessionsResponse
-	(*RevokeSessionRequest)(nil),            // 90: state.v1.RevokeSessionRequest
-	(*RevokeSessionResponse)(nil),           // 91: state.v1.RevokeSessionResponse
-	(*SetOutputSchemaRequest)(nil),          // 92: state.v1.SetOutputSchemaRequest
-	(*SetOutputSchemaResponse)(nil),         // 93: state.v1.SetOutputSchemaResponse
-	(*GetOutputSchemaRequest)(nil),          // 94: state.v1.GetOutputSchemaRequest
-	(*GetOutputSchemaResponse)(nil),         // 95: state.v1.GetOutputSchemaResponse
-	nil,                                     // 96: state.v1.CreateStateRequest.LabelsEntry
-	nil,                                     // 97: state.v1.StateInfo.LabelsEntry
-	nil,                                     // 98: state.v1.GetStateInfoResponse.LabelsEntry
-	nil,                                     // 99: state.v1.UpdateStateLabelsRequest.AddsEntry
-	nil,                                     // 100: state.v1.UpdateStateLabelsResponse.LabelsEntry
-	nil,                                     // 101: state.v1.CreateConstraints.ConstraintsEntry
-	(*timestamppb.Timestamp)(nil),           // 102: google.protobuf.Timestamp
+	(*CreateStateRequest)(nil),                 // 0: state.v1.CreateStateRequest
+	(*CreateStateResponse)(nil),                // 1: state.v1.CreateStateResponse
+	(*ListStatesRequest)(nil),                  // 2: state.v1.ListStatesRequest
+	(*ListStatesResponse)(nil),                 // 3: state.v1.ListStatesResponse
+	(*StateInfo)(nil),                          // 4: state.v1.StateInfo
+	(*BackendConfig)(nil),                      // 5: state.v1.BackendConfig
+	(*GetStateConfigRequest)(nil),              // 6: state.v1.GetStateConfigRequest
+	(*GetStateConfigResponse)(nil),             // 7: state.v1.GetStateConfigResponse
+	(*GetStateLockRequest)(nil),                // 8: state.v1.GetStateLockRequest
+	(*LockInfo)(nil),                           // 9: state.v1.LockInfo
+	(*StateLock)(nil),                          // 10: state.v1.StateLock
+	(*GetStateLockResponse)(nil),               // 11: state.v1.GetStateLockResponse
+	(*UnlockStateRequest)(nil),                 // 12: state.v1.UnlockStateRequest
+	(*UnlockStateResponse)(nil),                // 13: state.v1.UnlockStateResponse
+	(*AddDependencyRequest)(nil),               // 14: state.v1.AddDependencyRequest
+	(*AddDependencyResponse)(nil),              // 15: state.v1.AddDependencyResponse
+	(*RemoveDependencyRequest)(nil),            // 16: state.v1.RemoveDependencyRequest
+	(*RemoveDependencyResponse)(nil),           // 17: state.v1.RemoveDependencyResponse
+	(*ListDependenciesRequest)(nil),            // 18: state.v1.ListDependenciesRequest
+	(*ListDependenciesResponse)(nil),           // 19: state.v1.ListDependenciesResponse
+	(*ListDependentsRequest)(nil),              // 20: state.v1.ListDependentsRequest
+	(*ListDependentsResponse)(nil),             // 21: state.v1.ListDependentsResponse
+	(*SearchByOutputRequest)(nil),              // 22: state.v1.SearchByOutputRequest
+	(*SearchByOutputResponse)(nil),             // 23: state.v1.SearchByOutputResponse
+	(*GetTopologicalOrderRequest)(nil),         // 24: state.v1.GetTopologicalOrderRequest
+	(*GetTopologicalOrderResponse)(nil),        // 25: state.v1.GetTopologicalOrderResponse
+	(*Layer)(nil),                              // 26: state.v1.Layer
+	(*StateRef)(nil),                           // 27: state.v1.StateRef
+	(*GetStateStatusRequest)(nil),              // 28: state.v1.GetStateStatusRequest
+	(*GetStateStatusResponse)(nil),             // 29: state.v1.GetStateStatusResponse
+	(*IncomingEdgeView)(nil),                   // 30: state.v1.IncomingEdgeView
+	(*StatusSummary)(nil),                      // 31: state.v1.StatusSummary
+	(*GetDependencyGraphRequest)(nil),          // 32: state.v1.GetDependencyGraphRequest
+	(*GetDependencyGraphResponse)(nil),         // 33: state.v1.GetDependencyGraphResponse
+	(*RecomputeDependencyStatusRequest)(nil),   // 34: state.v1.RecomputeDependencyStatusRequest
+	(*RecomputeDependencyStatusResponse)(nil),  // 35: state.v1.RecomputeDependencyStatusResponse
+	(*ProducerState)(nil),                      // 36: state.v1.ProducerState
+	(*DependencyEdge)(nil),                     // 37: state.v1.DependencyEdge
+	(*OutputKey)(nil),                          // 38: state.v1.OutputKey
+	(*ListStateOutputsRequest)(nil),            // 39: state.v1.ListStateOutputsRequest
+	(*ListStateOutputsResponse)(nil),           // 40: state.v1.ListStateOutputsResponse
+	(*ListStateOutputsBatchRequest)(nil),       // 41: state.v1.ListStateOutputsBatchRequest
+	(*ListStateOutputsBatchResponse)(nil),      // 42: state.v1.ListStateOutputsBatchResponse
+	(*StateOutputs)(nil),                       // 43: state.v1.StateOutputs
+	(*GetStateOutputValuesRequest)(nil),        // 44: state.v1.GetStateOutputValuesRequest
+	(*GetStateOutputValuesResponse)(nil),       // 45: state.v1.GetStateOutputValuesResponse
+	(*OutputValue)(nil),                        // 46: state.v1.OutputValue
+	(*GetStateInfoRequest)(nil),                // 47: state.v1.GetStateInfoRequest
+	(*GetStateInfoResponse)(nil),               // 48: state.v1.GetStateInfoResponse
+	(*ListAllEdgesRequest)(nil),                // 49: state.v1.ListAllEdgesRequest
+	(*ListAllEdgesResponse)(nil),               // 50: state.v1.ListAllEdgesResponse
+	(*LabelValue)(nil),                         // 51: state.v1.LabelValue
+	(*UpdateStateLabelsRequest)(nil),           // 52: state.v1.UpdateStateLabelsRequest
+	(*UpdateStateLabelsResponse)(nil),          // 53: state.v1.UpdateStateLabelsResponse
+	(*TransferStateOwnershipRequest)(nil),      // 54: state.v1.TransferStateOwnershipRequest
+	(*TransferStateOwnershipResponse)(nil),     // 55: state.v1.TransferStateOwnershipResponse
+	(*LabelConstraintViolation)(nil),           // 56: state.v1.LabelConstraintViolation
+	(*GetLabelPolicyRequest)(nil),              // 57: state.v1.GetLabelPolicyRequest
+	(*GetLabelPolicyResponse)(nil),             // 58: state.v1.GetLabelPolicyResponse
+	(*SetLabelPolicyRequest)(nil),              // 59: state.v1.SetLabelPolicyRequest
+	(*SetLabelPolicyResponse)(nil),             // 60: state.v1.SetLabelPolicyResponse
+	(*CreateServiceAccountRequest)(nil),        // 61: state.v1.CreateServiceAccountRequest
+	(*CreateServiceAccountResponse)(nil),       // 62: state.v1.CreateServiceAccountResponse
+	(*ListServiceAccountsRequest)(nil),         // 63: state.v1.ListServiceAccountsRequest
+	(*ServiceAccountInfo)(nil),                 // 64: state.v1.ServiceAccountInfo
+	(*ListServiceAccountsResponse)(nil),        // 65: state.v1.ListServiceAccountsResponse
+	(*RevokeServiceAccountRequest)(nil),        // 66: state.v1.RevokeServiceAccountRequest
+	(*RevokeServiceAccountResponse)(nil),       // 67: state.v1.RevokeServiceAccountResponse
+	(*RotateServiceAccountRequest)(nil),        // 68: state.v1.RotateServiceAccountRequest
+	(*RotateServiceAccountResponse)(nil),       // 69: state.v1.RotateServiceAccountResponse
+	(*CreateRoleRequest)(nil),                  // 70: state.v1.CreateRoleRequest
+	(*CreateConstraints)(nil),                  // 71: state.v1.CreateConstraints
+	(*CreateConstraint)(nil),                   // 72: state.v1.CreateConstraint
+	(*RoleInfo)(nil),                           // 73: state.v1.RoleInfo
+	(*CreateRoleResponse)(nil),                 // 74: state.v1.CreateRoleResponse
+	(*ListRolesRequest)(nil),                   // 75: state.v1.ListRolesRequest
+	(*ListRolesResponse)(nil),                  // 76: state.v1.ListRolesResponse
+	(*UpdateRoleRequest)(nil),                  // 77: state.v1.UpdateRoleRequest
+	(*UpdateRoleResponse)(nil),                 // 78: state.v1.UpdateRoleResponse
+	(*DeleteRoleRequest)(nil),                  // 79: state.v1.DeleteRoleRequest
+	(*DeleteRoleResponse)(nil),                 // 80: state.v1.DeleteRoleResponse
+	(*AssignRoleRequest)(nil),                  // 81: state.v1.AssignRoleRequest
+	(*AssignRoleResponse)(nil),                 // 82: state.v1.AssignRoleResponse
+	(*RemoveRoleRequest)(nil),                  // 83: state.v1.RemoveRoleRequest
+	(*RemoveRoleResponse)(nil),                 // 84: state.v1.RemoveRoleResponse
+	(*ListUserRolesRequest)(nil),               // 85: state.v1.ListUserRolesRequest
+	(*RoleAssignmentInfo)(nil),                 // 86: state.v1.RoleAssignmentInfo
+	(*ListUserRolesResponse)(nil),              // 87: state.v1.ListUserRolesResponse
+	(*AssignGroupRoleRequest)(nil),             // 88: state.v1.AssignGroupRoleRequest
+	(*AssignGroupRoleResponse)(nil),            // 89: state.v1.AssignGroupRoleResponse
+	(*RemoveGroupRoleRequest)(nil),             // 90: state.v1.RemoveGroupRoleRequest
+	(*RemoveGroupRoleResponse)(nil),            // 91: state.v1.RemoveGroupRoleResponse
+	(*ListGroupRolesRequest)(nil),              // 92: state.v1.ListGroupRolesRequest
+	(*GroupRoleAssignmentInfo)(nil),            // 93: state.v1.GroupRoleAssignmentInfo
+	(*ListGroupRolesResponse)(nil),             // 94: state.v1.ListGroupRolesResponse
+	(*GetEffectivePermissionsRequest)(nil),     // 95: state.v1.GetEffectivePermissionsRequest
+	(*EffectivePermissions)(nil),               // 96: state.v1.EffectivePermissions
+	(*GetEffectivePermissionsResponse)(nil),    // 97: state.v1.GetEffectivePermissionsResponse
+	(*ListSessionsRequest)(nil),                // 98: state.v1.ListSessionsRequest
+	(*SessionInfo)(nil),                        // 99: state.v1.SessionInfo
+	(*ListSessionsResponse)(nil),               // 100: state.v1.ListSessionsResponse
+	(*ListAllSessionsRequest)(nil),             // 101: state.v1.ListAllSessionsRequest
+	(*ListAllSessionsResponse)(nil),            // 102: state.v1.ListAllSessionsResponse
+	(*RevokeSessionsRequest)(nil),              // 103: state.v1.RevokeSessionsRequest
+	(*RevokeSessionsResponse)(nil),             // 104: state.v1.RevokeSessionsResponse
+	(*RevokeSessionRequest)(nil),               // 105: state.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),              // 106: state.v1.RevokeSessionResponse
+	(*IntrospectTokenRequest)(nil),             // 107: state.v1.IntrospectTokenRequest
+	(*IntrospectTokenResponse)(nil),            // 108: state.v1.IntrospectTokenResponse
+	(*PreviewAuthorizationRequest)(nil),        // 109: state.v1.PreviewAuthorizationRequest
+	(*RoleAuthorizationDecision)(nil),          // 110: state.v1.RoleAuthorizationDecision
+	(*PreviewAuthorizationResponse)(nil),       // 111: state.v1.PreviewAuthorizationResponse
+	(*SetOutputSchemaRequest)(nil),             // 112: state.v1.SetOutputSchemaRequest
+	(*SetOutputSchemaResponse)(nil),            // 113: state.v1.SetOutputSchemaResponse
+	(*SetOutputSchemasRequest)(nil),            // 114: state.v1.SetOutputSchemasRequest
+	(*OutputSchemaResult)(nil),                 // 115: state.v1.OutputSchemaResult
+	(*SetOutputSchemasResponse)(nil),           // 116: state.v1.SetOutputSchemasResponse
+	(*RevalidateOutputsForSchemaRequest)(nil),  // 117: state.v1.RevalidateOutputsForSchemaRequest
+	(*OutputValidationTransition)(nil),         // 118: state.v1.OutputValidationTransition
+	(*RevalidateOutputsForSchemaResponse)(nil), // 119: state.v1.RevalidateOutputsForSchemaResponse
+	(*GetOutputSchemaRequest)(nil),             // 120: state.v1.GetOutputSchemaRequest
+	(*GetOutputSchemaResponse)(nil),            // 121: state.v1.GetOutputSchemaResponse
+	(*ListOutputSchemaHistoryRequest)(nil),     // 122: state.v1.ListOutputSchemaHistoryRequest
+	(*OutputSchemaVersion)(nil),                // 123: state.v1.OutputSchemaVersion
+	(*ListOutputSchemaHistoryResponse)(nil),    // 124: state.v1.ListOutputSchemaHistoryResponse
+	(*RegisteredSchema)(nil),                   // 125: state.v1.RegisteredSchema
+	(*CreateRegisteredSchemaRequest)(nil),      // 126: state.v1.CreateRegisteredSchemaRequest
+	(*CreateRegisteredSchemaResponse)(nil),     // 127: state.v1.CreateRegisteredSchemaResponse
+	(*GetRegisteredSchemaRequest)(nil),         // 128: state.v1.GetRegisteredSchemaRequest
+	(*GetRegisteredSchemaResponse)(nil),        // 129: state.v1.GetRegisteredSchemaResponse
+	(*ListRegisteredSchemasRequest)(nil),       // 130: state.v1.ListRegisteredSchemasRequest
+	(*ListRegisteredSchemasResponse)(nil),      // 131: state.v1.ListRegisteredSchemasResponse
+	(*UpdateRegisteredSchemaRequest)(nil),      // 132: state.v1.UpdateRegisteredSchemaRequest
+	(*UpdateRegisteredSchemaResponse)(nil),     // 133: state.v1.UpdateRegisteredSchemaResponse
+	(*DeleteRegisteredSchemaRequest)(nil),      // 134: state.v1.DeleteRegisteredSchemaRequest
+	(*DeleteRegisteredSchemaResponse)(nil),     // 135: state.v1.DeleteRegisteredSchemaResponse
+	(*GetStateValidationSummaryRequest)(nil),   // 136: state.v1.GetStateValidationSummaryRequest
+	(*OutputValidationIssue)(nil),              // 137: state.v1.OutputValidationIssue
+	(*GetStateValidationSummaryResponse)(nil),  // 138: state.v1.GetStateValidationSummaryResponse
+	(*WatchStateChangesRequest)(nil),           // 139: state.v1.WatchStateChangesRequest
+	(*StateChangeEvent)(nil),                   // 140: state.v1.StateChangeEvent
+	nil,                                        // 141: state.v1.CreateStateRequest.LabelsEntry
+	nil,                                        // 142: state.v1.StateInfo.LabelsEntry
+	nil,                                        // 143: state.v1.GetStateInfoResponse.LabelsEntry
+	nil,                                        // 144: state.v1.UpdateStateLabelsRequest.AddsEntry
+	nil,                                        // 145: state.v1.UpdateStateLabelsResponse.LabelsEntry
+	nil,                                        // 146: state.v1.CreateConstraints.ConstraintsEntry
+	nil,                                        // 147: state.v1.PreviewAuthorizationRequest.LabelsEntry
+	nil,                                        // 148: state.v1.SetOutputSchemasRequest.SchemasEntry
+	nil,                                        // 149: state.v1.StateChangeEvent.LabelsEntry
+	(*timestamppb.Timestamp)(nil),              // 150: google.protobuf.Timestamp
 }
 var file_state_v1_state_proto_depIdxs = []int32{
-	96,  // 0: state.v1.CreateStateRequest.labels:type_name -> state.v1.CreateStateRequest.LabelsEntry
+	141, // 0: state.v1.CreateStateRequest.labels:type_name -> state.v1.CreateStateRequest.LabelsEntry
 	5,   // 1: state.v1.CreateStateResponse.backend_config:type_name -> state.v1.BackendConfig
 	4,   // 2: state.v1.ListStatesResponse.states:type_name -> state.v1.StateInfo
-	102, // 3: state.v1.StateInfo.created_at:type_name -> google.protobuf.Timestamp
-	102, // 4: state.v1.StateInfo.updated_at:type_name -> google.protobuf.Timestamp
-	97,  // 5: state.v1.StateInfo.labels:type_name -> state.v1.StateInfo.LabelsEntry
+	150, // 3: state.v1.StateInfo.created_at:type_name -> google.protobuf.Timestamp
+	150, // 4: state.v1.StateInfo.updated_at:type_name -> google.protobuf.Timestamp
+	142, // 5: state.v1.StateInfo.labels:type_name -> state.v1.StateInfo.LabelsEntry
 	5,   // 6: state.v1.GetStateConfigResponse.backend_config:type_name -> state.v1.BackendConfig
-	102, // 7: state.v1.LockInfo.created:type_name -> google.protobuf.Timestamp
+	150, // 7: state.v1.LockInfo.created:type_name -> google.protobuf.Timestamp
 	9,   // 8: state.v1.StateLock.info:type_name -> state.v1.LockInfo
 	10,  // 9: state.v1.GetStateLockResponse.lock:type_name -> state.v1.StateLock
 	10,  // 10: state.v1.UnlockStateResponse.lock:type_name -> state.v1.StateLock
-	35,  // 11: state.v1.AddDependencyResponse.edge:type_name -> state.v1.DependencyEdge
-	35,  // 12: state.v1.ListDependenciesResponse.edges:type_name -> state.v1.DependencyEdge
-	35,  // 13: state.v1.ListDependentsResponse.edges:type_name -> state.v1.DependencyEdge
-	35,  // 14: state.v1.SearchByOutputResponse.edges:type_name -> state.v1.DependencyEdge
+	37,  // 11: state.v1.AddDependencyResponse.edge:type_name -> state.v1.DependencyEdge
+	37,  // 12: state.v1.ListDependenciesResponse.edges:type_name -> state.v1.DependencyEdge
+	37,  // 13: state.v1.ListDependentsResponse.edges:type_name -> state.v1.DependencyEdge
+	37,  // 14: state.v1.SearchByOutputResponse.edges:type_name -> state.v1.DependencyEdge
 	26,  // 15: state.v1.GetTopologicalOrderResponse.layers:type_name -> state.v1.Layer
 	27,  // 16: state.v1.Layer.states:type_name -> state.v1.StateRef
 	30,  // 17: state.v1.GetStateStatusResponse.incoming:type_name -> state.v1.IncomingEdgeView
 	31,  // 18: state.v1.GetStateStatusResponse.summary:type_name -> state.v1.StatusSummary
-	102, // 19: state.v1.IncomingEdgeView.last_in_at:type_name -> google.protobuf.Timestamp
-	102, // 20: state.v1.IncomingEdgeView.last_out_at:type_name -> google.protobuf.Timestamp
-	34,  // 21: state.v1.GetDependencyGraphResponse.producers:type_name -> state.v1.ProducerState
-	35,  // 22: state.v1.GetDependencyGraphResponse.edges:type_name -> state.v1.DependencyEdge
-	5,   // 23: state.v1.ProducerState.backend_config:type_name -> state.v1.BackendConfig
-	102, // 24: state.v1.DependencyEdge.last_in_at:type_name -> google.protobuf.Timestamp
-	102, // 25: state.v1.DependencyEdge.last_out_at:type_name -> google.protobuf.Timestamp
-	102, // 26: state.v1.DependencyEdge.created_at:type_name -> google.protobuf.Timestamp
-	102, // 27: state.v1.DependencyEdge.updated_at:type_name -> google.protobuf.Timestamp
-	102, // 28: state.v1.OutputKey.validated_at:type_name -> google.protobuf.Timestamp
-	36,  // 29: state.v1.ListStateOutputsResponse.outputs:type_name -> state.v1.OutputKey
-	5,   // 30: state.v1.GetStateInfoResponse.backend_config:type_name -> state.v1.BackendConfig
-	35,  // 31: state.v1.GetStateInfoResponse.dependencies:type_name -> state.v1.DependencyEdge
-	35,  // 32: state.v1.GetStateInfoResponse.dependents:type_name -> state.v1.DependencyEdge
-	36,  // 33: state.v1.GetStateInfoResponse.outputs:type_name -> state.v1.OutputKey
-	102, // 34: state.v1.GetStateInfoResponse.created_at:type_name -> google.protobuf.Timestamp
-	102, // 35: state.v1.GetStateInfoResponse.updated_at:type_name -> google.protobuf.Timestamp
-	98,  // 36: state.v1.GetStateInfoResponse.labels:type_name -> state.v1.GetStateInfoResponse.LabelsEntry
-	35,  // 37: state.v1.ListAllEdgesResponse.edges:type_name -> state.v1.DependencyEdge
-	99,  // 38: state.v1.UpdateStateLabelsRequest.adds:type_name -> state.v1.UpdateStateLabelsRequest.AddsEntry
-	100, // 39: state.v1.UpdateStateLabelsResponse.labels:type_name -> state.v1.UpdateStateLabelsResponse.LabelsEntry
-	102, // 40: state.v1.UpdateStateLabelsResponse.updated_at:type_name -> google.protobuf.Timestamp
-	102, // 41: state.v1.GetLabelPolicyResponse.created_at:type_name -> google.protobuf.Timestamp
-	102, // 42: state.v1.GetLabelPolicyResponse.updated_at:type_name -> google.protobuf.Timestamp
-	102, // 43: state.v1.SetLabelPolicyResponse.updated_at:type_name -> google.protobuf.Timestamp
-	102, // 44: state.v1.CreateServiceAccountResponse.created_at:type_name -> google.protobuf.Timestamp
-	102, // 45: state.v1.ServiceAccountInfo.created_at:type_name -> google.protobuf.Timestamp
-	102, // 46: state.v1.ServiceAccountInfo.last_used_at:type_name -> google.protobuf.Timestamp
-	53,  // 47: state.v1.ListServiceAccountsResponse.service_accounts:type_name -> state.v1.ServiceAccountInfo
-	102, // 48: state.v1.RotateServiceAccountResponse.rotated_at:type_name -> google.protobuf.Timestamp
-	60,  // 49: state.v1.CreateRoleRequest.create_constraints:type_name -> state.v1.CreateConstraints
-	101, // 50: state.v1.CreateConstraints.constraints:type_name -> state.v1.CreateConstraints.ConstraintsEntry
-	60,  // 51: state.v1.RoleInfo.create_constraints:type_name -> state.v1.CreateConstraints
-	102, // 52: state.v1.RoleInfo.created_at:type_name -> google.protobuf.Timestamp
-	102, // 53: state.v1.RoleInfo.updated_at:type_name -> google.protobuf.Timestamp
-	62,  // 54: state.v1.CreateRoleResponse.role:type_name -> state.v1.RoleInfo
-	62,  // 55: state.v1.ListRolesResponse.roles:type_name -> state.v1.RoleInfo
-	60,  // 56: state.v1.UpdateRoleRequest.create_constraints:type_name -> state.v1.CreateConstraints
-	62,  // 57: state.v1.UpdateRoleResponse.role:type_name -> state.v1.RoleInfo
-	102, // 58: state.v1.AssignRoleResponse.assigned_at:type_name -> google.protobuf.Timestamp
-	102, // 59: state.v1.RoleAssignmentInfo.assigned_at:type_name -> google.protobuf.Timestamp
-	75,  // 60: state.v1.ListUserRolesResponse.roles:type_name -> state.v1.RoleAssignmentInfo
-	102, // 61: state.v1.AssignGroupRoleResponse.assigned_at:type_name -> google.protobuf.Timestamp
-	102, // 62: state.v1.GroupRoleAssignmentInfo.assigned_at:type_name -> google.protobuf.Timestamp
-	82,  // 63: state.v1.ListGroupRolesResponse.assignments:type_name -> state.v1.GroupRoleAssignmentInfo
-	60,  // 64: state.v1.EffectivePermissions.effective_create_constraints:type_name -> state.v1.CreateConstraints
-	85,  // 65: state.v1.GetEffectivePermissionsResponse.permissions:type_name -> state.v1.EffectivePermissions
-	102, // 66: state.v1.SessionInfo.created_at:type_name -> google.protobuf.Timestamp
-	102, // 67: state.v1.SessionInfo.last_used_at:type_name -> google.protobuf.Timestamp
-	102, // 68: state.v1.SessionInfo.expires_at:type_name -> google.protobuf.Timestamp
-	88,  // 69: state.v1.ListSessionsResponse.sessions:type_name -> state.v1.SessionInfo
-	43,  // 70: state.v1.StateInfo.LabelsEntry.value:type_name -> state.v1.LabelValue
-	43,  // 71: state.v1.GetStateInfoResponse.LabelsEntry.value:type_name -> state.v1.LabelValue
-	43,  // 72: state.v1.UpdateStateLabelsRequest.AddsEntry.value:type_name -> state.v1.LabelValue
-	43,  // 73: state.v1.UpdateStateLabelsResponse.LabelsEntry.value:type_name -> state.v1.LabelValue
-	61,  // 74: state.v1.CreateConstraints.ConstraintsEntry.value:type_name -> state.v1.CreateConstraint
-	0,   // 75: state.v1.StateService.CreateState:input_type -> state.v1.CreateStateRequest
-	2,   // 76: state.v1.StateService.ListStates:input_type -> state.v1.ListStatesRequest
-	6,   // 77: state.v1.StateService.GetStateConfig:input_type -> state.v1.GetStateConfigRequest
-	8,   // 78: state.v1.StateService.GetStateLock:input_type -> state.v1.GetStateLockRequest
-	12,  // 79: state.v1.StateService.UnlockState:input_type -> state.v1.UnlockStateRequest
-	14,  // 80: state.v1.StateService.AddDependency:input_type -> state.v1.AddDependencyRequest
-	16,  // 81: state.v1.StateService.RemoveDependency:input_type -> state.v1.RemoveDependencyRequest
-	18,  // 82: state.v1.StateService.ListDependencies:input_type -> state.v1.ListDependenciesRequest
-	20,  // 83: state.v1.StateService.ListDependents:input_type -> state.v1.ListDependentsRequest
-	22,  // 84: state.v1.StateService.SearchByOutput:input_type -> state.v1.SearchByOutputRequest
-	24,  // 85: state.v1.StateService.GetTopologicalOrder:input_type -> state.v1.GetTopologicalOrderRequest
-	28,  // 86: state.v1.StateService.GetStateStatus:input_type -> state.v1.GetStateStatusRequest
-	32,  // 87: state.v1.StateService.GetDependencyGraph:input_type -> state.v1.GetDependencyGraphRequest
-	37,  // 88: state.v1.StateService.ListStateOutputs:input_type -> state.v1.ListStateOutputsRequest
-	39,  // 89: state.v1.StateService.GetStateInfo:input_type -> state.v1.GetStateInfoRequest
-	41,  // 90: state.v1.StateService.ListAllEdges:input_type -> state.v1.ListAllEdgesRequest
-	44,  // 91: state.v1.StateService.UpdateStateLabels:input_type -> state.v1.UpdateStateLabelsRequest
-	46,  // 92: state.v1.StateService.GetLabelPolicy:input_type -> state.v1.GetLabelPolicyRequest
-	48,  // 93: state.v1.StateService.SetLabelPolicy:input_type -> state.v1.SetLabelPolicyRequest
-	50,  // 94: state.v1.StateService.CreateServiceAccount:input_type -> state.v1.CreateServiceAccountRequest
-	52,  // 95: state.v1.StateService.ListServiceAccounts:input_type -> state.v1.ListServiceAccountsRequest
-	55,  // 96: state.v1.StateService.RevokeServiceAccount:input_type -> state.v1.RevokeServiceAccountRequest
-	57,  // 97: state.v1.StateService.RotateServiceAccount:input_type -> state.v1.RotateServiceAccountRequest
-	59,  // 98: state.v1.StateService.CreateRole:input_type -> state.v1.CreateRoleRequest
-	64,  // 99: state.v1.StateService.ListRoles:input_type -> state.v1.ListRolesRequest
-	66,  // 100: state.v1.StateService.UpdateRole:input_type -> state.v1.UpdateRoleRequest
-	68,  // 101: state.v1.StateService.DeleteRole:input_type -> state.v1.DeleteRoleRequest
-	70,  // 102: state.v1.StateService.AssignRole:input_type -> state.v1.AssignRoleRequest
-	72,  // 103: state.v1.StateService.RemoveRole:input_type -> state.v1.RemoveRoleRequest
-	74,  // 104: state.v1.StateService.ListUserRoles:input_type -> state.v1.ListUserRolesRequest
-	77,  // 105: state.v1.StateService.AssignGroupRole:input_type -> state.v1.AssignGroupRoleRequest
-	79,  // 106: state.v1.StateService.RemoveGroupRole:input_type -> state.v1.RemoveGroupRoleRequest
-	81,  // 107: state.v1.StateService.ListGroupRoles:input_type -> state.v1.ListGroupRolesRequest
-	84,  // 108: state.v1.StateService.GetEffectivePermissions:input_type -> state.v1.GetEffectivePermissionsRequest
-	87,  // 109: state.v1.StateService.ListSessions:input_type -> state.v1.ListSessionsRequest
-	90,  // 110: state.v1.StateService.RevokeSession:input_type -> state.v1.RevokeSessionRequest
-	92,  // 111: state.v1.StateService.SetOutputSchema:input_type -> state.v1.SetOutputSchemaRequest
-	94,  // 112: state.v1.StateService.GetOutputSchema:input_type -> state.v1.GetOutputSchemaRequest
-	1,   // 113: state.v1.StateService.CreateState:output_type -> state.v1.CreateStateResponse
-	3,   // 114: state.v1.StateService.ListStates:output_type -> state.v1.ListStatesResponse
-	7,   // 115: state.v1.StateService.GetStateConfig:output_type -> state.v1.GetStateConfigResponse
-	11,  // 116: state.v1.StateService.GetStateLock:output_type -> state.v1.GetStateLockResponse
-	13,  // 117: state.v1.StateService.UnlockState:output_type -> state.v1.UnlockStateResponse
-	15,  // 118: state.v1.StateService.AddDependency:output_type -> state.v1.AddDependencyResponse
-	17,  // 119: state.v1.StateService.RemoveDependency:output_type -> state.v1.RemoveDependencyResponse
-	19,  // 120: state.v1.StateService.ListDependencies:output_type -> state.v1.ListDependenciesResponse
-	21,  // 121: state.v1.StateService.ListDependents:output_type -> state.v1.ListDependentsResponse
-	23,  // 122: state.v1.StateService.SearchByOutput:output_type -> state.v1.SearchByOutputResponse
-	25,  // 123: state.v1.StateService.GetTopologicalOrder:output_type -> state.v1.GetTopologicalOrderResponse
-	29,  // 124: state.v1.StateService.GetStateStatus:output_type -> state.v1.GetStateStatusResponse
-	33,  // 125: state.v1.StateService.GetDependencyGraph:output_type -> state.v1.GetDependencyGraphResponse
-	38,  // 126: state.v1.StateService.ListStateOutputs:output_type -> state.v1.ListStateOutputsResponse
-	40,  // 127: state.v1.StateService.GetStateInfo:output_type -> state.v1.GetStateInfoResponse
-	42,  // 128: state.v1.StateService.ListAllEdges:output_type -> state.v1.ListAllEdgesResponse
-	45,  // 129: state.v1.StateService.UpdateStateLabels:output_type -> state.v1.UpdateStateLabelsResponse
-	47,  // 130: state.v1.StateService.GetLabelPolicy:output_type -> state.v1.GetLabelPolicyResponse
-	49,  // 131: state.v1.StateService.SetLabelPolicy:output_type -> state.v1.SetLabelPolicyResponse
-	51,  // 132: state.v1.StateService.CreateServiceAccount:output_type -> state.v1.CreateServiceAccountResponse
-	54,  // 133: state.v1.StateService.ListServiceAccounts:output_type -> state.v1.ListServiceAccountsResponse
-	56,  // 134: state.v1.StateService.RevokeServiceAccount:output_type -> state.v1.RevokeServiceAccountResponse
-	58,  // 135: state.v1.StateService.RotateServiceAccount:output_type -> state.v1.RotateServiceAccountResponse
-	63,  // 136: state.v1.StateService.CreateRole:output_type -> state.v1.CreateRoleResponse
-	65,  // 137: state.v1.StateService.ListRoles:output_type -> state.v1.ListRolesResponse
-	67,  // 138: state.v1.StateService.UpdateRole:output_type -> state.v1.UpdateRoleResponse
-	69,  // 139: state.v1.StateService.DeleteRole:output_type -> state.v1.DeleteRoleResponse
-	71,  // 140: state.v1.StateService.AssignRole:output_type -> state.v1.AssignRoleResponse
-	73,  // 141: state.v1.StateService.RemoveRole:output_type -> state.v1.RemoveRoleResponse
-	76,  // 142: state.v1.StateService.ListUserRoles:output_type -> state.v1.ListUserRolesResponse
-	78,  // 143: state.v1.StateService.AssignGroupRole:output_type -> state.v1.AssignGroupRoleResponse
-	80,  // 144: state.v1.StateService.RemoveGroupRole:output_type -> state.v1.RemoveGroupRoleResponse
-	83,  // 145: state.v1.StateService.ListGroupRoles:output_type -> state.v1.ListGroupRolesResponse
-	86,  // 146: state.v1.StateService.GetEffectivePermissions:output_type -> state.v1.GetEffectivePermissionsResponse
-	89,  // 147: state.v1.StateService.ListSessions:output_type -> state.v1.ListSessionsResponse
-	91,  // 148: state.v1.StateService.RevokeSession:output_type -> state.v1.RevokeSessionResponse
-	93,  // 149: state.v1.StateService.SetOutputSchema:output_type -> state.v1.SetOutputSchemaResponse
-	95,  // 150: state.v1.StateService.GetOutputSchema:output_type -> state.v1.GetOutputSchemaResponse
-	113, // [113:151] is the sub-list for method output_type
-	75,  // [75:113] is the sub-list for method input_type
-	75,  // [75:75] is the sub-list for extension type_name
-	75,  // [75:75] is the sub-list for extension extendee
-	0,   // [0:75] is the sub-list for field type_name
+	150, // 19: state.v1.IncomingEdgeView.last_in_at:type_name -> google.protobuf.Timestamp
+	150, // 20: state.v1.IncomingEdgeView.last_out_at:type_name -> google.protobuf.Timestamp
+	36,  // 21: state.v1.GetDependencyGraphResponse.producers:type_name -> state.v1.ProducerState
+	37,  // 22: state.v1.GetDependencyGraphResponse.edges:type_name -> state.v1.DependencyEdge
+	37,  // 23: state.v1.RecomputeDependencyStatusResponse.edges:type_name -> state.v1.DependencyEdge
+	5,   // 24: state.v1.ProducerState.backend_config:type_name -> state.v1.BackendConfig
+	150, // 25: state.v1.DependencyEdge.last_in_at:type_name -> google.protobuf.Timestamp
+	150, // 26: state.v1.DependencyEdge.last_out_at:type_name -> google.protobuf.Timestamp
+	150, // 27: state.v1.DependencyEdge.created_at:type_name -> google.protobuf.Timestamp
+	150, // 28: state.v1.DependencyEdge.updated_at:type_name -> google.protobuf.Timestamp
+	150, // 29: state.v1.OutputKey.validated_at:type_name -> google.protobuf.Timestamp
+	38,  // 30: state.v1.ListStateOutputsResponse.outputs:type_name -> state.v1.OutputKey
+	27,  // 31: state.v1.ListStateOutputsBatchRequest.states:type_name -> state.v1.StateRef
+	43,  // 32: state.v1.ListStateOutputsBatchResponse.states:type_name -> state.v1.StateOutputs
+	38,  // 33: state.v1.StateOutputs.outputs:type_name -> state.v1.OutputKey
+	27,  // 34: state.v1.GetStateOutputValuesRequest.state:type_name -> state.v1.StateRef
+	46,  // 35: state.v1.GetStateOutputValuesResponse.values:type_name -> state.v1.OutputValue
+	5,   // 36: state.v1.GetStateInfoResponse.backend_config:type_name -> state.v1.BackendConfig
+	37,  // 37: state.v1.GetStateInfoResponse.dependencies:type_name -> state.v1.DependencyEdge
+	37,  // 38: state.v1.GetStateInfoResponse.dependents:type_name -> state.v1.DependencyEdge
+	38,  // 39: state.v1.GetStateInfoResponse.outputs:type_name -> state.v1.OutputKey
+	150, // 40: state.v1.GetStateInfoResponse.created_at:type_name -> google.protobuf.Timestamp
+	150, // 41: state.v1.GetStateInfoResponse.updated_at:type_name -> google.protobuf.Timestamp
+	143, // 42: state.v1.GetStateInfoResponse.labels:type_name -> state.v1.GetStateInfoResponse.LabelsEntry
+	37,  // 43: state.v1.ListAllEdgesResponse.edges:type_name -> state.v1.DependencyEdge
+	144, // 44: state.v1.UpdateStateLabelsRequest.adds:type_name -> state.v1.UpdateStateLabelsRequest.AddsEntry
+	145, // 45: state.v1.UpdateStateLabelsResponse.labels:type_name -> state.v1.UpdateStateLabelsResponse.LabelsEntry
+	150, // 46: state.v1.UpdateStateLabelsResponse.updated_at:type_name -> google.protobuf.Timestamp
+	150, // 47: state.v1.GetLabelPolicyResponse.created_at:type_name -> google.protobuf.Timestamp
+	150, // 48: state.v1.GetLabelPolicyResponse.updated_at:type_name -> google.protobuf.Timestamp
+	150, // 49: state.v1.SetLabelPolicyResponse.updated_at:type_name -> google.protobuf.Timestamp
+	150, // 50: state.v1.CreateServiceAccountResponse.created_at:type_name -> google.protobuf.Timestamp
+	150, // 51: state.v1.ListServiceAccountsRequest.last_used_before:type_name -> google.protobuf.Timestamp
+	150, // 52: state.v1.ServiceAccountInfo.created_at:type_name -> google.protobuf.Timestamp
+	150, // 53: state.v1.ServiceAccountInfo.last_used_at:type_name -> google.protobuf.Timestamp
+	64,  // 54: state.v1.ListServiceAccountsResponse.service_accounts:type_name -> state.v1.ServiceAccountInfo
+	150, // 55: state.v1.RotateServiceAccountResponse.rotated_at:type_name -> google.protobuf.Timestamp
+	150, // 56: state.v1.RotateServiceAccountResponse.previous_secret_expires_at:type_name -> google.protobuf.Timestamp
+	71,  // 57: state.v1.CreateRoleRequest.create_constraints:type_name -> state.v1.CreateConstraints
+	146, // 58: state.v1.CreateConstraints.constraints:type_name -> state.v1.CreateConstraints.ConstraintsEntry
+	71,  // 59: state.v1.RoleInfo.create_constraints:type_name -> state.v1.CreateConstraints
+	150, // 60: state.v1.RoleInfo.created_at:type_name -> google.protobuf.Timestamp
+	150, // 61: state.v1.RoleInfo.updated_at:type_name -> google.protobuf.Timestamp
+	73,  // 62: state.v1.CreateRoleResponse.role:type_name -> state.v1.RoleInfo
+	73,  // 63: state.v1.ListRolesResponse.roles:type_name -> state.v1.RoleInfo
+	71,  // 64: state.v1.UpdateRoleRequest.create_constraints:type_name -> state.v1.CreateConstraints
+	73,  // 65: state.v1.UpdateRoleResponse.role:type_name -> state.v1.RoleInfo
+	150, // 66: state.v1.AssignRoleResponse.assigned_at:type_name -> google.protobuf.Timestamp
+	150, // 67: state.v1.RoleAssignmentInfo.assigned_at:type_name -> google.protobuf.Timestamp
+	86,  // 68: state.v1.ListUserRolesResponse.roles:type_name -> state.v1.RoleAssignmentInfo
+	150, // 69: state.v1.AssignGroupRoleResponse.assigned_at:type_name -> google.protobuf.Timestamp
+	150, // 70: state.v1.GroupRoleAssignmentInfo.assigned_at:type_name -> google.protobuf.Timestamp
+	93,  // 71: state.v1.ListGroupRolesResponse.assignments:type_name -> state.v1.GroupRoleAssignmentInfo
+	71,  // 72: state.v1.EffectivePermissions.effective_create_constraints:type_name -> state.v1.CreateConstraints
+	96,  // 73: state.v1.GetEffectivePermissionsResponse.permissions:type_name -> state.v1.EffectivePermissions
+	150, // 74: state.v1.SessionInfo.created_at:type_name -> google.protobuf.Timestamp
+	150, // 75: state.v1.SessionInfo.last_used_at:type_name -> google.protobuf.Timestamp
+	150, // 76: state.v1.SessionInfo.expires_at:type_name -> google.protobuf.Timestamp
+	99,  // 77: state.v1.ListSessionsResponse.sessions:type_name -> state.v1.SessionInfo
+	150, // 78: state.v1.ListAllSessionsRequest.created_after:type_name -> google.protobuf.Timestamp
+	99,  // 79: state.v1.ListAllSessionsResponse.sessions:type_name -> state.v1.SessionInfo
+	150, // 80: state.v1.RevokeSessionsRequest.created_before:type_name -> google.protobuf.Timestamp
+	150, // 81: state.v1.RevokeSessionsRequest.created_after:type_name -> google.protobuf.Timestamp
+	150, // 82: state.v1.IntrospectTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	150, // 83: state.v1.IntrospectTokenResponse.issued_at:type_name -> google.protobuf.Timestamp
+	147, // 84: state.v1.PreviewAuthorizationRequest.labels:type_name -> state.v1.PreviewAuthorizationRequest.LabelsEntry
+	110, // 85: state.v1.PreviewAuthorizationResponse.roles:type_name -> state.v1.RoleAuthorizationDecision
+	148, // 86: state.v1.SetOutputSchemasRequest.schemas:type_name -> state.v1.SetOutputSchemasRequest.SchemasEntry
+	115, // 87: state.v1.SetOutputSchemasResponse.results:type_name -> state.v1.OutputSchemaResult
+	118, // 88: state.v1.RevalidateOutputsForSchemaResponse.transitions:type_name -> state.v1.OutputValidationTransition
+	150, // 89: state.v1.OutputSchemaVersion.created_at:type_name -> google.protobuf.Timestamp
+	123, // 90: state.v1.ListOutputSchemaHistoryResponse.versions:type_name -> state.v1.OutputSchemaVersion
+	150, // 91: state.v1.RegisteredSchema.created_at:type_name -> google.protobuf.Timestamp
+	150, // 92: state.v1.RegisteredSchema.updated_at:type_name -> google.protobuf.Timestamp
+	125, // 93: state.v1.CreateRegisteredSchemaResponse.schema:type_name -> state.v1.RegisteredSchema
+	125, // 94: state.v1.GetRegisteredSchemaResponse.schema:type_name -> state.v1.RegisteredSchema
+	125, // 95: state.v1.ListRegisteredSchemasResponse.schemas:type_name -> state.v1.RegisteredSchema
+	125, // 96: state.v1.UpdateRegisteredSchemaResponse.schema:type_name -> state.v1.RegisteredSchema
+	119, // 97: state.v1.UpdateRegisteredSchemaResponse.revalidation:type_name -> state.v1.RevalidateOutputsForSchemaResponse
+	150, // 98: state.v1.OutputValidationIssue.validated_at:type_name -> google.protobuf.Timestamp
+	137, // 99: state.v1.GetStateValidationSummaryResponse.issues:type_name -> state.v1.OutputValidationIssue
+	150, // 100: state.v1.GetStateValidationSummaryResponse.last_validated_at:type_name -> google.protobuf.Timestamp
+	149, // 101: state.v1.StateChangeEvent.labels:type_name -> state.v1.StateChangeEvent.LabelsEntry
+	150, // 102: state.v1.StateChangeEvent.detected_at:type_name -> google.protobuf.Timestamp
+	51,  // 103: state.v1.StateInfo.LabelsEntry.value:type_name -> state.v1.LabelValue
+	51,  // 104: state.v1.GetStateInfoResponse.LabelsEntry.value:type_name -> state.v1.LabelValue
+	51,  // 105: state.v1.UpdateStateLabelsRequest.AddsEntry.value:type_name -> state.v1.LabelValue
+	51,  // 106: state.v1.UpdateStateLabelsResponse.LabelsEntry.value:type_name -> state.v1.LabelValue
+	72,  // 107: state.v1.CreateConstraints.ConstraintsEntry.value:type_name -> state.v1.CreateConstraint
+	51,  // 108: state.v1.PreviewAuthorizationRequest.LabelsEntry.value:type_name -> state.v1.LabelValue
+	51,  // 109: state.v1.StateChangeEvent.LabelsEntry.value:type_name -> state.v1.LabelValue
+	0,   // 110: state.v1.StateService.CreateState:input_type -> state.v1.CreateStateRequest
+	2,   // 111: state.v1.StateService.ListStates:input_type -> state.v1.ListStatesRequest
+	6,   // 112: state.v1.StateService.GetStateConfig:input_type -> state.v1.GetStateConfigRequest
+	8,   // 113: state.v1.StateService.GetStateLock:input_type -> state.v1.GetStateLockRequest
+	12,  // 114: state.v1.StateService.UnlockState:input_type -> state.v1.UnlockStateRequest
+	14,  // 115: state.v1.StateService.AddDependency:input_type -> state.v1.AddDependencyRequest
+	16,  // 116: state.v1.StateService.RemoveDependency:input_type -> state.v1.RemoveDependencyRequest
+	18,  // 117: state.v1.StateService.ListDependencies:input_type -> state.v1.ListDependenciesRequest
+	20,  // 118: state.v1.StateService.ListDependents:input_type -> state.v1.ListDependentsRequest
+	22,  // 119: state.v1.StateService.SearchByOutput:input_type -> state.v1.SearchByOutputRequest
+	24,  // 120: state.v1.StateService.GetTopologicalOrder:input_type -> state.v1.GetTopologicalOrderRequest
+	28,  // 121: state.v1.StateService.GetStateStatus:input_type -> state.v1.GetStateStatusRequest
+	32,  // 122: state.v1.StateService.GetDependencyGraph:input_type -> state.v1.GetDependencyGraphRequest
+	34,  // 123: state.v1.StateService.RecomputeDependencyStatus:input_type -> state.v1.RecomputeDependencyStatusRequest
+	39,  // 124: state.v1.StateService.ListStateOutputs:input_type -> state.v1.ListStateOutputsRequest
+	41,  // 125: state.v1.StateService.ListStateOutputsBatch:input_type -> state.v1.ListStateOutputsBatchRequest
+	44,  // 126: state.v1.StateService.GetStateOutputValues:input_type -> state.v1.GetStateOutputValuesRequest
+	47,  // 127: state.v1.StateService.GetStateInfo:input_type -> state.v1.GetStateInfoRequest
+	49,  // 128: state.v1.StateService.ListAllEdges:input_type -> state.v1.ListAllEdgesRequest
+	139, // 129: state.v1.StateService.WatchStateChanges:input_type -> state.v1.WatchStateChangesRequest
+	52,  // 130: state.v1.StateService.UpdateStateLabels:input_type -> state.v1.UpdateStateLabelsRequest
+	54,  // 131: state.v1.StateService.TransferStateOwnership:input_type -> state.v1.TransferStateOwnershipRequest
+	57,  // 132: state.v1.StateService.GetLabelPolicy:input_type -> state.v1.GetLabelPolicyRequest
+	59,  // 133: state.v1.StateService.SetLabelPolicy:input_type -> state.v1.SetLabelPolicyRequest
+	61,  // 134: state.v1.StateService.CreateServiceAccount:input_type -> state.v1.CreateServiceAccountRequest
+	63,  // 135: state.v1.StateService.ListServiceAccounts:input_type -> state.v1.ListServiceAccountsRequest
+	66,  // 136: state.v1.StateService.RevokeServiceAccount:input_type -> state.v1.RevokeServiceAccountRequest
+	68,  // 137: state.v1.StateService.RotateServiceAccount:input_type -> state.v1.RotateServiceAccountRequest
+	70,  // 138: state.v1.StateService.CreateRole:input_type -> state.v1.CreateRoleRequest
+	75,  // 139: state.v1.StateService.ListRoles:input_type -> state.v1.ListRolesRequest
+	77,  // 140: state.v1.StateService.UpdateRole:input_type -> state.v1.UpdateRoleRequest
+	79,  // 141: state.v1.StateService.DeleteRole:input_type -> state.v1.DeleteRoleRequest
+	81,  // 142: state.v1.StateService.AssignRole:input_type -> state.v1.AssignRoleRequest
+	83,  // 143: state.v1.StateService.RemoveRole:input_type -> state.v1.RemoveRoleRequest
+	85,  // 144: state.v1.StateService.ListUserRoles:input_type -> state.v1.ListUserRolesRequest
+	88,  // 145: state.v1.StateService.AssignGroupRole:input_type -> state.v1.AssignGroupRoleRequest
+	90,  // 146: state.v1.StateService.RemoveGroupRole:input_type -> state.v1.RemoveGroupRoleRequest
+	92,  // 147: state.v1.StateService.ListGroupRoles:input_type -> state.v1.ListGroupRolesRequest
+	95,  // 148: state.v1.StateService.GetEffectivePermissions:input_type -> state.v1.GetEffectivePermissionsRequest
+	98,  // 149: state.v1.StateService.ListSessions:input_type -> state.v1.ListSessionsRequest
+	105, // 150: state.v1.StateService.RevokeSession:input_type -> state.v1.RevokeSessionRequest
+	101, // 151: state.v1.StateService.ListAllSessions:input_type -> state.v1.ListAllSessionsRequest
+	103, // 152: state.v1.StateService.RevokeSessions:input_type -> state.v1.RevokeSessionsRequest
+	107, // 153: state.v1.StateService.IntrospectToken:input_type -> state.v1.IntrospectTokenRequest
+	109, // 154: state.v1.StateService.PreviewAuthorization:input_type -> state.v1.PreviewAuthorizationRequest
+	112, // 155: state.v1.StateService.SetOutputSchema:input_type -> state.v1.SetOutputSchemaRequest
+	114, // 156: state.v1.StateService.SetOutputSchemas:input_type -> state.v1.SetOutputSchemasRequest
+	117, // 157: state.v1.StateService.RevalidateOutputsForSchema:input_type -> state.v1.RevalidateOutputsForSchemaRequest
+	126, // 158: state.v1.StateService.CreateRegisteredSchema:input_type -> state.v1.CreateRegisteredSchemaRequest
+	128, // 159: state.v1.StateService.GetRegisteredSchema:input_type -> state.v1.GetRegisteredSchemaRequest
+	130, // 160: state.v1.StateService.ListRegisteredSchemas:input_type -> state.v1.ListRegisteredSchemasRequest
+	132, // 161: state.v1.StateService.UpdateRegisteredSchema:input_type -> state.v1.UpdateRegisteredSchemaRequest
+	134, // 162: state.v1.StateService.DeleteRegisteredSchema:input_type -> state.v1.DeleteRegisteredSchemaRequest
+	120, // 163: state.v1.StateService.GetOutputSchema:input_type -> state.v1.GetOutputSchemaRequest
+	122, // 164: state.v1.StateService.ListOutputSchemaHistory:input_type -> state.v1.ListOutputSchemaHistoryRequest
+	136, // 165: state.v1.StateService.GetStateValidationSummary:input_type -> state.v1.GetStateValidationSummaryRequest
+	1,   // 166: state.v1.StateService.CreateState:output_type -> state.v1.CreateStateResponse
+	3,   // 167: state.v1.StateService.ListStates:output_type -> state.v1.ListStatesResponse
+	7,   // 168: state.v1.StateService.GetStateConfig:output_type -> state.v1.GetStateConfigResponse
+	11,  // 169: state.v1.StateService.GetStateLock:output_type -> state.v1.GetStateLockResponse
+	13,  // 170: state.v1.StateService.UnlockState:output_type -> state.v1.UnlockStateResponse
+	15,  // 171: state.v1.StateService.AddDependency:output_type -> state.v1.AddDependencyResponse
+	17,  // 172: state.v1.StateService.RemoveDependency:output_type -> state.v1.RemoveDependencyResponse
+	19,  // 173: state.v1.StateService.ListDependencies:output_type -> state.v1.ListDependenciesResponse
+	21,  // 174: state.v1.StateService.ListDependents:output_type -> state.v1.ListDependentsResponse
+	23,  // 175: state.v1.StateService.SearchByOutput:output_type -> state.v1.SearchByOutputResponse
+	25,  // 176: state.v1.StateService.GetTopologicalOrder:output_type -> state.v1.GetTopologicalOrderResponse
+	29,  // 177: state.v1.StateService.GetStateStatus:output_type -> state.v1.GetStateStatusResponse
+	33,  // 178: state.v1.StateService.GetDependencyGraph:output_type -> state.v1.GetDependencyGraphResponse
+	35,  // 179: state.v1.StateService.RecomputeDependencyStatus:output_type -> state.v1.RecomputeDependencyStatusResponse
+	40,  // 180: state.v1.StateService.ListStateOutputs:output_type -> state.v1.ListStateOutputsResponse
+	42,  // 181: state.v1.StateService.ListStateOutputsBatch:output_type -> state.v1.ListStateOutputsBatchResponse
+	45,  // 182: state.v1.StateService.GetStateOutputValues:output_type -> state.v1.GetStateOutputValuesResponse
+	48,  // 183: state.v1.StateService.GetStateInfo:output_type -> state.v1.GetStateInfoResponse
+	50,  // 184: state.v1.StateService.ListAllEdges:output_type -> state.v1.ListAllEdgesResponse
+	140, // 185: state.v1.StateService.WatchStateChanges:output_type -> state.v1.StateChangeEvent
+	53,  // 186: state.v1.StateService.UpdateStateLabels:output_type -> state.v1.UpdateStateLabelsResponse
+	55,  // 187: state.v1.StateService.TransferStateOwnership:output_type -> state.v1.TransferStateOwnershipResponse
+	58,  // 188: state.v1.StateService.GetLabelPolicy:output_type -> state.v1.GetLabelPolicyResponse
+	60,  // 189: state.v1.StateService.SetLabelPolicy:output_type -> state.v1.SetLabelPolicyResponse
+	62,  // 190: state.v1.StateService.CreateServiceAccount:output_type -> state.v1.CreateServiceAccountResponse
+	65,  // 191: state.v1.StateService.ListServiceAccounts:output_type -> state.v1.ListServiceAccountsResponse
+	67,  // 192: state.v1.StateService.RevokeServiceAccount:output_type -> state.v1.RevokeServiceAccountResponse
+	69,  // 193: state.v1.StateService.RotateServiceAccount:output_type -> state.v1.RotateServiceAccountResponse
+	74,  // 194: state.v1.StateService.CreateRole:output_type -> state.v1.CreateRoleResponse
+	76,  // 195: state.v1.StateService.ListRoles:output_type -> state.v1.ListRolesResponse
+	78,  // 196: state.v1.StateService.UpdateRole:output_type -> state.v1.UpdateRoleResponse
+	80,  // 197: state.v1.StateService.DeleteRole:output_type -> state.v1.DeleteRoleResponse
+	82,  // 198: state.v1.StateService.AssignRole:output_type -> state.v1.AssignRoleResponse
+	84,  // 199: state.v1.StateService.RemoveRole:output_type -> state.v1.RemoveRoleResponse
+	87,  // 200: state.v1.StateService.ListUserRoles:output_type -> state.v1.ListUserRolesResponse
+	89,  // 201: state.v1.StateService.AssignGroupRole:output_type -> state.v1.AssignGroupRoleResponse
+	91,  // 202: state.v1.StateService.RemoveGroupRole:output_type -> state.v1.RemoveGroupRoleResponse
+	94,  // 203: state.v1.StateService.ListGroupRoles:output_type -> state.v1.ListGroupRolesResponse
+	97,  // 204: state.v1.StateService.GetEffectivePermissions:output_type -> state.v1.GetEffectivePermissionsResponse
+	100, // 205: state.v1.StateService.ListSessions:output_type -> state.v1.ListSessionsResponse
+	106, // 206: state.v1.StateService.RevokeSession:output_type -> state.v1.RevokeSessionResponse
+	102, // 207: state.v1.StateService.ListAllSessions:output_type -> state.v1.ListAllSessionsResponse
+	104, // 208: state.v1.StateService.RevokeSessions:output_type -> state.v1.RevokeSessionsResponse
+	108, // 209: state.v1.StateService.IntrospectToken:output_type -> state.v1.IntrospectTokenResponse
+	111, // 210: state.v1.StateService.PreviewAuthorization:output_type -> state.v1.PreviewAuthorizationResponse
+	113, // 211: state.v1.StateService.SetOutputSchema:output_type -> state.v1.SetOutputSchemaResponse
+	116, // 212: state.v1.StateService.SetOutputSchemas:output_type -> state.v1.SetOutputSchemasResponse
+	119, // 213: state.v1.StateService.RevalidateOutputsForSchema:output_type -> state.v1.RevalidateOutputsForSchemaResponse
+	127, // 214: state.v1.StateService.CreateRegisteredSchema:output_type -> state.v1.CreateRegisteredSchemaResponse
+	129, // 215: state.v1.StateService.GetRegisteredSchema:output_type -> state.v1.GetRegisteredSchemaResponse
+	131, // 216: state.v1.StateService.ListRegisteredSchemas:output_type -> state.v1.ListRegisteredSchemasResponse
+	133, // 217: state.v1.StateService.UpdateRegisteredSchema:output_type -> state.v1.UpdateRegisteredSchemaResponse
+	135, // 218: state.v1.StateService.DeleteRegisteredSchema:output_type -> state.v1.DeleteRegisteredSchemaResponse
+	121, // 219: state.v1.StateService.GetOutputSchema:output_type -> state.v1.GetOutputSchemaResponse
+	124, // 220: state.v1.StateService.ListOutputSchemaHistory:output_type -> state.v1.ListOutputSchemaHistoryResponse
+	138, // 221: state.v1.StateService.GetStateValidationSummary:output_type -> state.v1.GetStateValidationSummaryResponse
+	166, // [166:222] is the sub-list for method output_type
+	110, // [110:166] is the sub-list for method input_type
+	110, // [110:110] is the sub-list for extension type_name
+	110, // [110:110] is the sub-list for extension extendee
+	0,   // [0:110] is the sub-list for field type_name
 }
 
 func init() { file_state_v1_state_proto_init() }
@@ -7033,46 +11144,82 @@ func file_state_v1_state_proto_init() {
 		(*GetDependencyGraphRequest_LogicId)(nil),
 		(*GetDependencyGraphRequest_Guid)(nil),
 	}
-	file_state_v1_state_proto_msgTypes[35].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[36].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[37].OneofWrappers = []any{
+	file_state_v1_state_proto_msgTypes[34].OneofWrappers = []any{
+		(*RecomputeDependencyStatusRequest_LogicId)(nil),
+		(*RecomputeDependencyStatusRequest_Guid)(nil),
+	}
+	file_state_v1_state_proto_msgTypes[37].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[38].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[39].OneofWrappers = []any{
 		(*ListStateOutputsRequest_LogicId)(nil),
 		(*ListStateOutputsRequest_Guid)(nil),
 	}
-	file_state_v1_state_proto_msgTypes[39].OneofWrappers = []any{
+	file_state_v1_state_proto_msgTypes[46].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[47].OneofWrappers = []any{
 		(*GetStateInfoRequest_LogicId)(nil),
 		(*GetStateInfoRequest_Guid)(nil),
 	}
-	file_state_v1_state_proto_msgTypes[40].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[43].OneofWrappers = []any{
+	file_state_v1_state_proto_msgTypes[48].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[51].OneofWrappers = []any{
 		(*LabelValue_StringValue)(nil),
 		(*LabelValue_NumberValue)(nil),
 		(*LabelValue_BoolValue)(nil),
 	}
-	file_state_v1_state_proto_msgTypes[44].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[50].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[53].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[59].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[62].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[66].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[81].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[85].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[88].OneofWrappers = []any{}
-	file_state_v1_state_proto_msgTypes[92].OneofWrappers = []any{
+	file_state_v1_state_proto_msgTypes[52].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[54].OneofWrappers = []any{
+		(*TransferStateOwnershipRequest_LogicId)(nil),
+		(*TransferStateOwnershipRequest_Guid)(nil),
+	}
+	file_state_v1_state_proto_msgTypes[61].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[63].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[64].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[68].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[69].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[70].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[73].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[77].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[92].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[96].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[99].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[108].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[110].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[112].OneofWrappers = []any{
 		(*SetOutputSchemaRequest_StateLogicId)(nil),
 		(*SetOutputSchemaRequest_StateGuid)(nil),
 	}
-	file_state_v1_state_proto_msgTypes[94].OneofWrappers = []any{
+	file_state_v1_state_proto_msgTypes[113].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[114].OneofWrappers = []any{
+		(*SetOutputSchemasRequest_StateLogicId)(nil),
+		(*SetOutputSchemasRequest_StateGuid)(nil),
+	}
+	file_state_v1_state_proto_msgTypes[117].OneofWrappers = []any{
+		(*RevalidateOutputsForSchemaRequest_StateLogicId)(nil),
+		(*RevalidateOutputsForSchemaRequest_StateGuid)(nil),
+	}
+	file_state_v1_state_proto_msgTypes[120].OneofWrappers = []any{
 		(*GetOutputSchemaRequest_StateLogicId)(nil),
 		(*GetOutputSchemaRequest_StateGuid)(nil),
 	}
+	file_state_v1_state_proto_msgTypes[122].OneofWrappers = []any{
+		(*ListOutputSchemaHistoryRequest_StateLogicId)(nil),
+		(*ListOutputSchemaHistoryRequest_StateGuid)(nil),
+	}
+	file_state_v1_state_proto_msgTypes[124].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[132].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[136].OneofWrappers = []any{
+		(*GetStateValidationSummaryRequest_StateLogicId)(nil),
+		(*GetStateValidationSummaryRequest_StateGuid)(nil),
+	}
+	file_state_v1_state_proto_msgTypes[137].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[138].OneofWrappers = []any{}
+	file_state_v1_state_proto_msgTypes[139].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_state_v1_state_proto_rawDesc), len(file_state_v1_state_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   102,
+			NumMessages:   150,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
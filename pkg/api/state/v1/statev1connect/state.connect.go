@@ -71,18 +71,33 @@ const (
 	// StateServiceGetDependencyGraphProcedure is the fully-qualified name of the StateService's
 	// GetDependencyGraph RPC.
 	StateServiceGetDependencyGraphProcedure = "/state.v1.StateService/GetDependencyGraph"
+	// StateServiceRecomputeDependencyStatusProcedure is the fully-qualified name of the StateService's
+	// RecomputeDependencyStatus RPC.
+	StateServiceRecomputeDependencyStatusProcedure = "/state.v1.StateService/RecomputeDependencyStatus"
 	// StateServiceListStateOutputsProcedure is the fully-qualified name of the StateService's
 	// ListStateOutputs RPC.
 	StateServiceListStateOutputsProcedure = "/state.v1.StateService/ListStateOutputs"
+	// StateServiceListStateOutputsBatchProcedure is the fully-qualified name of the StateService's
+	// ListStateOutputsBatch RPC.
+	StateServiceListStateOutputsBatchProcedure = "/state.v1.StateService/ListStateOutputsBatch"
+	// StateServiceGetStateOutputValuesProcedure is the fully-qualified name of the StateService's
+	// GetStateOutputValues RPC.
+	StateServiceGetStateOutputValuesProcedure = "/state.v1.StateService/GetStateOutputValues"
 	// StateServiceGetStateInfoProcedure is the fully-qualified name of the StateService's GetStateInfo
 	// RPC.
 	StateServiceGetStateInfoProcedure = "/state.v1.StateService/GetStateInfo"
 	// StateServiceListAllEdgesProcedure is the fully-qualified name of the StateService's ListAllEdges
 	// RPC.
 	StateServiceListAllEdgesProcedure = "/state.v1.StateService/ListAllEdges"
+	// StateServiceWatchStateChangesProcedure is the fully-qualified name of the StateService's
+	// WatchStateChanges RPC.
+	StateServiceWatchStateChangesProcedure = "/state.v1.StateService/WatchStateChanges"
 	// StateServiceUpdateStateLabelsProcedure is the fully-qualified name of the StateService's
 	// UpdateStateLabels RPC.
 	StateServiceUpdateStateLabelsProcedure = "/state.v1.StateService/UpdateStateLabels"
+	// StateServiceTransferStateOwnershipProcedure is the fully-qualified name of the StateService's
+	// TransferStateOwnership RPC.
+	StateServiceTransferStateOwnershipProcedure = "/state.v1.StateService/TransferStateOwnership"
 	// StateServiceGetLabelPolicyProcedure is the fully-qualified name of the StateService's
 	// GetLabelPolicy RPC.
 	StateServiceGetLabelPolicyProcedure = "/state.v1.StateService/GetLabelPolicy"
@@ -134,12 +149,51 @@ const (
 	// StateServiceRevokeSessionProcedure is the fully-qualified name of the StateService's
 	// RevokeSession RPC.
 	StateServiceRevokeSessionProcedure = "/state.v1.StateService/RevokeSession"
+	// StateServiceListAllSessionsProcedure is the fully-qualified name of the StateService's
+	// ListAllSessions RPC.
+	StateServiceListAllSessionsProcedure = "/state.v1.StateService/ListAllSessions"
+	// StateServiceRevokeSessionsProcedure is the fully-qualified name of the StateService's
+	// RevokeSessions RPC.
+	StateServiceRevokeSessionsProcedure = "/state.v1.StateService/RevokeSessions"
+	// StateServiceIntrospectTokenProcedure is the fully-qualified name of the StateService's
+	// IntrospectToken RPC.
+	StateServiceIntrospectTokenProcedure = "/state.v1.StateService/IntrospectToken"
+	// StateServicePreviewAuthorizationProcedure is the fully-qualified name of the StateService's
+	// PreviewAuthorization RPC.
+	StateServicePreviewAuthorizationProcedure = "/state.v1.StateService/PreviewAuthorization"
 	// StateServiceSetOutputSchemaProcedure is the fully-qualified name of the StateService's
 	// SetOutputSchema RPC.
 	StateServiceSetOutputSchemaProcedure = "/state.v1.StateService/SetOutputSchema"
+	// StateServiceSetOutputSchemasProcedure is the fully-qualified name of the StateService's
+	// SetOutputSchemas RPC.
+	StateServiceSetOutputSchemasProcedure = "/state.v1.StateService/SetOutputSchemas"
+	// StateServiceRevalidateOutputsForSchemaProcedure is the fully-qualified name of the StateService's
+	// RevalidateOutputsForSchema RPC.
+	StateServiceRevalidateOutputsForSchemaProcedure = "/state.v1.StateService/RevalidateOutputsForSchema"
+	// StateServiceCreateRegisteredSchemaProcedure is the fully-qualified name of the StateService's
+	// CreateRegisteredSchema RPC.
+	StateServiceCreateRegisteredSchemaProcedure = "/state.v1.StateService/CreateRegisteredSchema"
+	// StateServiceGetRegisteredSchemaProcedure is the fully-qualified name of the StateService's
+	// GetRegisteredSchema RPC.
+	StateServiceGetRegisteredSchemaProcedure = "/state.v1.StateService/GetRegisteredSchema"
+	// StateServiceListRegisteredSchemasProcedure is the fully-qualified name of the StateService's
+	// ListRegisteredSchemas RPC.
+	StateServiceListRegisteredSchemasProcedure = "/state.v1.StateService/ListRegisteredSchemas"
+	// StateServiceUpdateRegisteredSchemaProcedure is the fully-qualified name of the StateService's
+	// UpdateRegisteredSchema RPC.
+	StateServiceUpdateRegisteredSchemaProcedure = "/state.v1.StateService/UpdateRegisteredSchema"
+	// StateServiceDeleteRegisteredSchemaProcedure is the fully-qualified name of the StateService's
+	// DeleteRegisteredSchema RPC.
+	StateServiceDeleteRegisteredSchemaProcedure = "/state.v1.StateService/DeleteRegisteredSchema"
 	// StateServiceGetOutputSchemaProcedure is the fully-qualified name of the StateService's
 	// GetOutputSchema RPC.
 	StateServiceGetOutputSchemaProcedure = "/state.v1.StateService/GetOutputSchema"
+	// StateServiceListOutputSchemaHistoryProcedure is the fully-qualified name of the StateService's
+	// ListOutputSchemaHistory RPC.
+	StateServiceListOutputSchemaHistoryProcedure = "/state.v1.StateService/ListOutputSchemaHistory"
+	// StateServiceGetStateValidationSummaryProcedure is the fully-qualified name of the StateService's
+	// GetStateValidationSummary RPC.
+	StateServiceGetStateValidationSummaryProcedure = "/state.v1.StateService/GetStateValidationSummary"
 )
 
 // StateServiceClient is a client for the state.v1.StateService service.
@@ -164,17 +218,35 @@ type StateServiceClient interface {
 	// ListDependents returns all edges where the given state is the producer (outgoing deps).
 	ListDependents(context.Context, *connect.Request[v1.ListDependentsRequest]) (*connect.Response[v1.ListDependentsResponse], error)
 	// SearchByOutput finds all edges that reference a specific output key (by name).
+	// Only edges whose producer and consumer are both within the caller's role
+	// scopes are returned.
 	SearchByOutput(context.Context, *connect.Request[v1.SearchByOutputRequest]) (*connect.Response[v1.SearchByOutputResponse], error)
 	// GetTopologicalOrder computes layered ordering of states rooted at given state.
+	// The root must be within the caller's role scopes; states outside them are
+	// omitted from the layers.
 	GetTopologicalOrder(context.Context, *connect.Request[v1.GetTopologicalOrderRequest]) (*connect.Response[v1.GetTopologicalOrderResponse], error)
 	// GetStateStatus computes on-demand status for a state based on its incoming edges.
+	// The state must be within the caller's role scopes; incoming edges and summary
+	// counts only cover producers within them.
 	GetStateStatus(context.Context, *connect.Request[v1.GetStateStatusRequest]) (*connect.Response[v1.GetStateStatusResponse], error)
 	// GetDependencyGraph returns full dependency graph data for client-side HCL generation.
 	GetDependencyGraph(context.Context, *connect.Request[v1.GetDependencyGraphRequest]) (*connect.Response[v1.GetDependencyGraphResponse], error)
+	// RecomputeDependencyStatus re-derives the statuses of a producer's outgoing
+	// edges from its current outputs and persists them, e.g. from CI after an apply.
+	RecomputeDependencyStatus(context.Context, *connect.Request[v1.RecomputeDependencyStatusRequest]) (*connect.Response[v1.RecomputeDependencyStatusResponse], error)
 	// ListStateOutputs returns available output keys from a state's Terraform/OpenTofu JSON.
 	// Output values are NOT returned (security/size concerns); only keys and sensitive flags.
 	// Used by CLI for interactive output selection when creating dependencies.
 	ListStateOutputs(context.Context, *connect.Request[v1.ListStateOutputsRequest]) (*connect.Response[v1.ListStateOutputsResponse], error)
+	// ListStateOutputsBatch returns outputs for several states in one call, e.g. to
+	// compare vpc_id across every env=dev state. States outside the caller's role
+	// scopes, and states that do not exist, are omitted rather than failing the call.
+	// Values are returned only when include_values is set, and never for sensitive outputs.
+	ListStateOutputsBatch(context.Context, *connect.Request[v1.ListStateOutputsBatchRequest]) (*connect.Response[v1.ListStateOutputsBatchResponse], error)
+	// GetStateOutputValues returns the values of selected outputs of one state without
+	// exposing the raw tfstate. Sensitive values are redacted unless the caller holds
+	// state-output:read-sensitive on the state.
+	GetStateOutputValues(context.Context, *connect.Request[v1.GetStateOutputValuesRequest]) (*connect.Response[v1.GetStateOutputValuesResponse], error)
 	// GetStateInfo retrieves comprehensive state information including:
 	// - Basic metadata (GUID, logic-id, timestamps)
 	// - Backend configuration (Terraform HTTP backend URLs)
@@ -188,8 +260,18 @@ type StateServiceClient interface {
 	// Used by dashboards and monitoring tools to visualize complete topology.
 	// Returns edges in ascending order by ID (database insertion order).
 	ListAllEdges(context.Context, *connect.Request[v1.ListAllEdgesRequest]) (*connect.Response[v1.ListAllEdgesResponse], error)
+	// WatchStateChanges streams an event whenever a state's serial, labels,
+	// outputs or computed dependency status changes. Only states whose current
+	// labels match the caller's role scopes are reported. Changes are detected
+	// by polling, so events arrive up to one poll interval late; a client that
+	// falls behind is disconnected and should reconnect.
+	WatchStateChanges(context.Context, *connect.Request[v1.WatchStateChangesRequest]) (*connect.ServerStreamForClient[v1.StateChangeEvent], error)
 	// UpdateStateLabels mutates labels for an existing state (add/replace/remove).
 	UpdateStateLabels(context.Context, *connect.Request[v1.UpdateStateLabelsRequest]) (*connect.Response[v1.UpdateStateLabelsResponse], error)
+	// TransferStateOwnership records a new owner (created_by) for a state, moving
+	// role owner-override access to them. Allowed for the current owner or with
+	// state:transfer-ownership.
+	TransferStateOwnership(context.Context, *connect.Request[v1.TransferStateOwnershipRequest]) (*connect.Response[v1.TransferStateOwnershipResponse], error)
 	// GetLabelPolicy retrieves the current label validation policy.
 	GetLabelPolicy(context.Context, *connect.Request[v1.GetLabelPolicyRequest]) (*connect.Response[v1.GetLabelPolicyResponse], error)
 	// SetLabelPolicy updates the label validation policy with version increment.
@@ -217,11 +299,44 @@ type StateServiceClient interface {
 	// Session Management
 	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
 	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	// ListAllSessions lists sessions across all users (admin only)
+	ListAllSessions(context.Context, *connect.Request[v1.ListAllSessionsRequest]) (*connect.Response[v1.ListAllSessionsResponse], error)
+	// RevokeSessions revokes every session matching a filter (admin only, incident response)
+	RevokeSessions(context.Context, *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error)
+	// Token Introspection (admin/debug, RFC 7662 style)
+	IntrospectToken(context.Context, *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error)
+	// Authorization dry run (admin/debug): would a principal with these roles or
+	// groups be allowed the action? No user needs to exist or be logged in.
+	PreviewAuthorization(context.Context, *connect.Request[v1.PreviewAuthorizationRequest]) (*connect.Response[v1.PreviewAuthorizationResponse], error)
 	// SetOutputSchema publishes or updates a JSON Schema for a specific state output.
 	// This allows clients to declare expected output types before the output exists.
 	SetOutputSchema(context.Context, *connect.Request[v1.SetOutputSchemaRequest]) (*connect.Response[v1.SetOutputSchemaResponse], error)
+	// SetOutputSchemas publishes or updates JSON Schemas for several outputs of a
+	// state at once. All schemas are validated up front and written atomically.
+	SetOutputSchemas(context.Context, *connect.Request[v1.SetOutputSchemasRequest]) (*connect.Response[v1.SetOutputSchemasResponse], error)
+	// RevalidateOutputsForSchema re-validates every output, across all states,
+	// whose schema matches the given output's schema, and reports which outputs
+	// moved between valid and invalid. Use after changing a shared schema.
+	RevalidateOutputsForSchema(context.Context, *connect.Request[v1.RevalidateOutputsForSchemaRequest]) (*connect.Response[v1.RevalidateOutputsForSchemaResponse], error)
+	// CreateRegisteredSchema registers a new schema under a key.
+	CreateRegisteredSchema(context.Context, *connect.Request[v1.CreateRegisteredSchemaRequest]) (*connect.Response[v1.CreateRegisteredSchemaResponse], error)
+	// GetRegisteredSchema retrieves a registered schema by key.
+	GetRegisteredSchema(context.Context, *connect.Request[v1.GetRegisteredSchemaRequest]) (*connect.Response[v1.GetRegisteredSchemaResponse], error)
+	// ListRegisteredSchemas lists all registered schemas.
+	ListRegisteredSchemas(context.Context, *connect.Request[v1.ListRegisteredSchemasRequest]) (*connect.Response[v1.ListRegisteredSchemasResponse], error)
+	// UpdateRegisteredSchema replaces a registered schema. Every output that
+	// references the key picks up the new schema, and can be re-validated.
+	UpdateRegisteredSchema(context.Context, *connect.Request[v1.UpdateRegisteredSchemaRequest]) (*connect.Response[v1.UpdateRegisteredSchemaResponse], error)
+	// DeleteRegisteredSchema removes a registered schema that no output references.
+	DeleteRegisteredSchema(context.Context, *connect.Request[v1.DeleteRegisteredSchemaRequest]) (*connect.Response[v1.DeleteRegisteredSchemaResponse], error)
 	// GetOutputSchema retrieves the JSON Schema for a specific state output.
 	GetOutputSchema(context.Context, *connect.Request[v1.GetOutputSchemaRequest]) (*connect.Response[v1.GetOutputSchemaResponse], error)
+	// ListOutputSchemaHistory lists every schema a state output has had, with the
+	// version each validation result refers to.
+	ListOutputSchemaHistory(context.Context, *connect.Request[v1.ListOutputSchemaHistoryRequest]) (*connect.Response[v1.ListOutputSchemaHistoryResponse], error)
+	// GetStateValidationSummary aggregates the stored validation results of a
+	// state's outputs. It reports existing results and never re-runs validation.
+	GetStateValidationSummary(context.Context, *connect.Request[v1.GetStateValidationSummaryRequest]) (*connect.Response[v1.GetStateValidationSummaryResponse], error)
 }
 
 // NewStateServiceClient constructs a client for the state.v1.StateService service. By default, it
@@ -313,12 +428,30 @@ func NewStateServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(stateServiceMethods.ByName("GetDependencyGraph")),
 			connect.WithClientOptions(opts...),
 		),
+		recomputeDependencyStatus: connect.NewClient[v1.RecomputeDependencyStatusRequest, v1.RecomputeDependencyStatusResponse](
+			httpClient,
+			baseURL+StateServiceRecomputeDependencyStatusProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("RecomputeDependencyStatus")),
+			connect.WithClientOptions(opts...),
+		),
 		listStateOutputs: connect.NewClient[v1.ListStateOutputsRequest, v1.ListStateOutputsResponse](
 			httpClient,
 			baseURL+StateServiceListStateOutputsProcedure,
 			connect.WithSchema(stateServiceMethods.ByName("ListStateOutputs")),
 			connect.WithClientOptions(opts...),
 		),
+		listStateOutputsBatch: connect.NewClient[v1.ListStateOutputsBatchRequest, v1.ListStateOutputsBatchResponse](
+			httpClient,
+			baseURL+StateServiceListStateOutputsBatchProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("ListStateOutputsBatch")),
+			connect.WithClientOptions(opts...),
+		),
+		getStateOutputValues: connect.NewClient[v1.GetStateOutputValuesRequest, v1.GetStateOutputValuesResponse](
+			httpClient,
+			baseURL+StateServiceGetStateOutputValuesProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("GetStateOutputValues")),
+			connect.WithClientOptions(opts...),
+		),
 		getStateInfo: connect.NewClient[v1.GetStateInfoRequest, v1.GetStateInfoResponse](
 			httpClient,
 			baseURL+StateServiceGetStateInfoProcedure,
@@ -331,12 +464,24 @@ func NewStateServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(stateServiceMethods.ByName("ListAllEdges")),
 			connect.WithClientOptions(opts...),
 		),
+		watchStateChanges: connect.NewClient[v1.WatchStateChangesRequest, v1.StateChangeEvent](
+			httpClient,
+			baseURL+StateServiceWatchStateChangesProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("WatchStateChanges")),
+			connect.WithClientOptions(opts...),
+		),
 		updateStateLabels: connect.NewClient[v1.UpdateStateLabelsRequest, v1.UpdateStateLabelsResponse](
 			httpClient,
 			baseURL+StateServiceUpdateStateLabelsProcedure,
 			connect.WithSchema(stateServiceMethods.ByName("UpdateStateLabels")),
 			connect.WithClientOptions(opts...),
 		),
+		transferStateOwnership: connect.NewClient[v1.TransferStateOwnershipRequest, v1.TransferStateOwnershipResponse](
+			httpClient,
+			baseURL+StateServiceTransferStateOwnershipProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("TransferStateOwnership")),
+			connect.WithClientOptions(opts...),
+		),
 		getLabelPolicy: connect.NewClient[v1.GetLabelPolicyRequest, v1.GetLabelPolicyResponse](
 			httpClient,
 			baseURL+StateServiceGetLabelPolicyProcedure,
@@ -451,61 +596,157 @@ func NewStateServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(stateServiceMethods.ByName("RevokeSession")),
 			connect.WithClientOptions(opts...),
 		),
+		listAllSessions: connect.NewClient[v1.ListAllSessionsRequest, v1.ListAllSessionsResponse](
+			httpClient,
+			baseURL+StateServiceListAllSessionsProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("ListAllSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeSessions: connect.NewClient[v1.RevokeSessionsRequest, v1.RevokeSessionsResponse](
+			httpClient,
+			baseURL+StateServiceRevokeSessionsProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("RevokeSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		introspectToken: connect.NewClient[v1.IntrospectTokenRequest, v1.IntrospectTokenResponse](
+			httpClient,
+			baseURL+StateServiceIntrospectTokenProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("IntrospectToken")),
+			connect.WithClientOptions(opts...),
+		),
+		previewAuthorization: connect.NewClient[v1.PreviewAuthorizationRequest, v1.PreviewAuthorizationResponse](
+			httpClient,
+			baseURL+StateServicePreviewAuthorizationProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("PreviewAuthorization")),
+			connect.WithClientOptions(opts...),
+		),
 		setOutputSchema: connect.NewClient[v1.SetOutputSchemaRequest, v1.SetOutputSchemaResponse](
 			httpClient,
 			baseURL+StateServiceSetOutputSchemaProcedure,
 			connect.WithSchema(stateServiceMethods.ByName("SetOutputSchema")),
 			connect.WithClientOptions(opts...),
 		),
+		setOutputSchemas: connect.NewClient[v1.SetOutputSchemasRequest, v1.SetOutputSchemasResponse](
+			httpClient,
+			baseURL+StateServiceSetOutputSchemasProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("SetOutputSchemas")),
+			connect.WithClientOptions(opts...),
+		),
+		revalidateOutputsForSchema: connect.NewClient[v1.RevalidateOutputsForSchemaRequest, v1.RevalidateOutputsForSchemaResponse](
+			httpClient,
+			baseURL+StateServiceRevalidateOutputsForSchemaProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("RevalidateOutputsForSchema")),
+			connect.WithClientOptions(opts...),
+		),
+		createRegisteredSchema: connect.NewClient[v1.CreateRegisteredSchemaRequest, v1.CreateRegisteredSchemaResponse](
+			httpClient,
+			baseURL+StateServiceCreateRegisteredSchemaProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("CreateRegisteredSchema")),
+			connect.WithClientOptions(opts...),
+		),
+		getRegisteredSchema: connect.NewClient[v1.GetRegisteredSchemaRequest, v1.GetRegisteredSchemaResponse](
+			httpClient,
+			baseURL+StateServiceGetRegisteredSchemaProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("GetRegisteredSchema")),
+			connect.WithClientOptions(opts...),
+		),
+		listRegisteredSchemas: connect.NewClient[v1.ListRegisteredSchemasRequest, v1.ListRegisteredSchemasResponse](
+			httpClient,
+			baseURL+StateServiceListRegisteredSchemasProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("ListRegisteredSchemas")),
+			connect.WithClientOptions(opts...),
+		),
+		updateRegisteredSchema: connect.NewClient[v1.UpdateRegisteredSchemaRequest, v1.UpdateRegisteredSchemaResponse](
+			httpClient,
+			baseURL+StateServiceUpdateRegisteredSchemaProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("UpdateRegisteredSchema")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteRegisteredSchema: connect.NewClient[v1.DeleteRegisteredSchemaRequest, v1.DeleteRegisteredSchemaResponse](
+			httpClient,
+			baseURL+StateServiceDeleteRegisteredSchemaProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("DeleteRegisteredSchema")),
+			connect.WithClientOptions(opts...),
+		),
 		getOutputSchema: connect.NewClient[v1.GetOutputSchemaRequest, v1.GetOutputSchemaResponse](
 			httpClient,
 			baseURL+StateServiceGetOutputSchemaProcedure,
 			connect.WithSchema(stateServiceMethods.ByName("GetOutputSchema")),
 			connect.WithClientOptions(opts...),
 		),
+		listOutputSchemaHistory: connect.NewClient[v1.ListOutputSchemaHistoryRequest, v1.ListOutputSchemaHistoryResponse](
+			httpClient,
+			baseURL+StateServiceListOutputSchemaHistoryProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("ListOutputSchemaHistory")),
+			connect.WithClientOptions(opts...),
+		),
+		getStateValidationSummary: connect.NewClient[v1.GetStateValidationSummaryRequest, v1.GetStateValidationSummaryResponse](
+			httpClient,
+			baseURL+StateServiceGetStateValidationSummaryProcedure,
+			connect.WithSchema(stateServiceMethods.ByName("GetStateValidationSummary")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // stateServiceClient implements StateServiceClient.
 type stateServiceClient struct {
-	createState             *connect.Client[v1.CreateStateRequest, v1.CreateStateResponse]
-	listStates              *connect.Client[v1.ListStatesRequest, v1.ListStatesResponse]
-	getStateConfig          *connect.Client[v1.GetStateConfigRequest, v1.GetStateConfigResponse]
-	getStateLock            *connect.Client[v1.GetStateLockRequest, v1.GetStateLockResponse]
-	unlockState             *connect.Client[v1.UnlockStateRequest, v1.UnlockStateResponse]
-	addDependency           *connect.Client[v1.AddDependencyRequest, v1.AddDependencyResponse]
-	removeDependency        *connect.Client[v1.RemoveDependencyRequest, v1.RemoveDependencyResponse]
-	listDependencies        *connect.Client[v1.ListDependenciesRequest, v1.ListDependenciesResponse]
-	listDependents          *connect.Client[v1.ListDependentsRequest, v1.ListDependentsResponse]
-	searchByOutput          *connect.Client[v1.SearchByOutputRequest, v1.SearchByOutputResponse]
-	getTopologicalOrder     *connect.Client[v1.GetTopologicalOrderRequest, v1.GetTopologicalOrderResponse]
-	getStateStatus          *connect.Client[v1.GetStateStatusRequest, v1.GetStateStatusResponse]
-	getDependencyGraph      *connect.Client[v1.GetDependencyGraphRequest, v1.GetDependencyGraphResponse]
-	listStateOutputs        *connect.Client[v1.ListStateOutputsRequest, v1.ListStateOutputsResponse]
-	getStateInfo            *connect.Client[v1.GetStateInfoRequest, v1.GetStateInfoResponse]
-	listAllEdges            *connect.Client[v1.ListAllEdgesRequest, v1.ListAllEdgesResponse]
-	updateStateLabels       *connect.Client[v1.UpdateStateLabelsRequest, v1.UpdateStateLabelsResponse]
-	getLabelPolicy          *connect.Client[v1.GetLabelPolicyRequest, v1.GetLabelPolicyResponse]
-	setLabelPolicy          *connect.Client[v1.SetLabelPolicyRequest, v1.SetLabelPolicyResponse]
-	createServiceAccount    *connect.Client[v1.CreateServiceAccountRequest, v1.CreateServiceAccountResponse]
-	listServiceAccounts     *connect.Client[v1.ListServiceAccountsRequest, v1.ListServiceAccountsResponse]
-	revokeServiceAccount    *connect.Client[v1.RevokeServiceAccountRequest, v1.RevokeServiceAccountResponse]
-	rotateServiceAccount    *connect.Client[v1.RotateServiceAccountRequest, v1.RotateServiceAccountResponse]
-	createRole              *connect.Client[v1.CreateRoleRequest, v1.CreateRoleResponse]
-	listRoles               *connect.Client[v1.ListRolesRequest, v1.ListRolesResponse]
-	updateRole              *connect.Client[v1.UpdateRoleRequest, v1.UpdateRoleResponse]
-	deleteRole              *connect.Client[v1.DeleteRoleRequest, v1.DeleteRoleResponse]
-	assignRole              *connect.Client[v1.AssignRoleRequest, v1.AssignRoleResponse]
-	removeRole              *connect.Client[v1.RemoveRoleRequest, v1.RemoveRoleResponse]
-	listUserRoles           *connect.Client[v1.ListUserRolesRequest, v1.ListUserRolesResponse]
-	assignGroupRole         *connect.Client[v1.AssignGroupRoleRequest, v1.AssignGroupRoleResponse]
-	removeGroupRole         *connect.Client[v1.RemoveGroupRoleRequest, v1.RemoveGroupRoleResponse]
-	listGroupRoles          *connect.Client[v1.ListGroupRolesRequest, v1.ListGroupRolesResponse]
-	getEffectivePermissions *connect.Client[v1.GetEffectivePermissionsRequest, v1.GetEffectivePermissionsResponse]
-	listSessions            *connect.Client[v1.ListSessionsRequest, v1.ListSessionsResponse]
-	revokeSession           *connect.Client[v1.RevokeSessionRequest, v1.RevokeSessionResponse]
-	setOutputSchema         *connect.Client[v1.SetOutputSchemaRequest, v1.SetOutputSchemaResponse]
-	getOutputSchema         *connect.Client[v1.GetOutputSchemaRequest, v1.GetOutputSchemaResponse]
+	createState                *connect.Client[v1.CreateStateRequest, v1.CreateStateResponse]
+	listStates                 *connect.Client[v1.ListStatesRequest, v1.ListStatesResponse]
+	getStateConfig             *connect.Client[v1.GetStateConfigRequest, v1.GetStateConfigResponse]
+	getStateLock               *connect.Client[v1.GetStateLockRequest, v1.GetStateLockResponse]
+	unlockState                *connect.Client[v1.UnlockStateRequest, v1.UnlockStateResponse]
+	addDependency              *connect.Client[v1.AddDependencyRequest, v1.AddDependencyResponse]
+	removeDependency           *connect.Client[v1.RemoveDependencyRequest, v1.RemoveDependencyResponse]
+	listDependencies           *connect.Client[v1.ListDependenciesRequest, v1.ListDependenciesResponse]
+	listDependents             *connect.Client[v1.ListDependentsRequest, v1.ListDependentsResponse]
+	searchByOutput             *connect.Client[v1.SearchByOutputRequest, v1.SearchByOutputResponse]
+	getTopologicalOrder        *connect.Client[v1.GetTopologicalOrderRequest, v1.GetTopologicalOrderResponse]
+	getStateStatus             *connect.Client[v1.GetStateStatusRequest, v1.GetStateStatusResponse]
+	getDependencyGraph         *connect.Client[v1.GetDependencyGraphRequest, v1.GetDependencyGraphResponse]
+	recomputeDependencyStatus  *connect.Client[v1.RecomputeDependencyStatusRequest, v1.RecomputeDependencyStatusResponse]
+	listStateOutputs           *connect.Client[v1.ListStateOutputsRequest, v1.ListStateOutputsResponse]
+	listStateOutputsBatch      *connect.Client[v1.ListStateOutputsBatchRequest, v1.ListStateOutputsBatchResponse]
+	getStateOutputValues       *connect.Client[v1.GetStateOutputValuesRequest, v1.GetStateOutputValuesResponse]
+	getStateInfo               *connect.Client[v1.GetStateInfoRequest, v1.GetStateInfoResponse]
+	listAllEdges               *connect.Client[v1.ListAllEdgesRequest, v1.ListAllEdgesResponse]
+	watchStateChanges          *connect.Client[v1.WatchStateChangesRequest, v1.StateChangeEvent]
+	updateStateLabels          *connect.Client[v1.UpdateStateLabelsRequest, v1.UpdateStateLabelsResponse]
+	transferStateOwnership     *connect.Client[v1.TransferStateOwnershipRequest, v1.TransferStateOwnershipResponse]
+	getLabelPolicy             *connect.Client[v1.GetLabelPolicyRequest, v1.GetLabelPolicyResponse]
+	setLabelPolicy             *connect.Client[v1.SetLabelPolicyRequest, v1.SetLabelPolicyResponse]
+	createServiceAccount       *connect.Client[v1.CreateServiceAccountRequest, v1.CreateServiceAccountResponse]
+	listServiceAccounts        *connect.Client[v1.ListServiceAccountsRequest, v1.ListServiceAccountsResponse]
+	revokeServiceAccount       *connect.Client[v1.RevokeServiceAccountRequest, v1.RevokeServiceAccountResponse]
+	rotateServiceAccount       *connect.Client[v1.RotateServiceAccountRequest, v1.RotateServiceAccountResponse]
+	createRole                 *connect.Client[v1.CreateRoleRequest, v1.CreateRoleResponse]
+	listRoles                  *connect.Client[v1.ListRolesRequest, v1.ListRolesResponse]
+	updateRole                 *connect.Client[v1.UpdateRoleRequest, v1.UpdateRoleResponse]
+	deleteRole                 *connect.Client[v1.DeleteRoleRequest, v1.DeleteRoleResponse]
+	assignRole                 *connect.Client[v1.AssignRoleRequest, v1.AssignRoleResponse]
+	removeRole                 *connect.Client[v1.RemoveRoleRequest, v1.RemoveRoleResponse]
+	listUserRoles              *connect.Client[v1.ListUserRolesRequest, v1.ListUserRolesResponse]
+	assignGroupRole            *connect.Client[v1.AssignGroupRoleRequest, v1.AssignGroupRoleResponse]
+	removeGroupRole            *connect.Client[v1.RemoveGroupRoleRequest, v1.RemoveGroupRoleResponse]
+	listGroupRoles             *connect.Client[v1.ListGroupRolesRequest, v1.ListGroupRolesResponse]
+	getEffectivePermissions    *connect.Client[v1.GetEffectivePermissionsRequest, v1.GetEffectivePermissionsResponse]
+	listSessions               *connect.Client[v1.ListSessionsRequest, v1.ListSessionsResponse]
+	revokeSession              *connect.Client[v1.RevokeSessionRequest, v1.RevokeSessionResponse]
+	listAllSessions            *connect.Client[v1.ListAllSessionsRequest, v1.ListAllSessionsResponse]
+	revokeSessions             *connect.Client[v1.RevokeSessionsRequest, v1.RevokeSessionsResponse]
+	introspectToken            *connect.Client[v1.IntrospectTokenRequest, v1.IntrospectTokenResponse]
+	previewAuthorization       *connect.Client[v1.PreviewAuthorizationRequest, v1.PreviewAuthorizationResponse]
+	setOutputSchema            *connect.Client[v1.SetOutputSchemaRequest, v1.SetOutputSchemaResponse]
+	setOutputSchemas           *connect.Client[v1.SetOutputSchemasRequest, v1.SetOutputSchemasResponse]
+	revalidateOutputsForSchema *connect.Client[v1.RevalidateOutputsForSchemaRequest, v1.RevalidateOutputsForSchemaResponse]
+	createRegisteredSchema     *connect.Client[v1.CreateRegisteredSchemaRequest, v1.CreateRegisteredSchemaResponse]
+	getRegisteredSchema        *connect.Client[v1.GetRegisteredSchemaRequest, v1.GetRegisteredSchemaResponse]
+	listRegisteredSchemas      *connect.Client[v1.ListRegisteredSchemasRequest, v1.ListRegisteredSchemasResponse]
+	updateRegisteredSchema     *connect.Client[v1.UpdateRegisteredSchemaRequest, v1.UpdateRegisteredSchemaResponse]
+	deleteRegisteredSchema     *connect.Client[v1.DeleteRegisteredSchemaRequest, v1.DeleteRegisteredSchemaResponse]
+	getOutputSchema            *connect.Client[v1.GetOutputSchemaRequest, v1.GetOutputSchemaResponse]
+	listOutputSchemaHistory    *connect.Client[v1.ListOutputSchemaHistoryRequest, v1.ListOutputSchemaHistoryResponse]
+	getStateValidationSummary  *connect.Client[v1.GetStateValidationSummaryRequest, v1.GetStateValidationSummaryResponse]
 }
 
 // CreateState calls state.v1.StateService.CreateState.
@@ -573,11 +814,26 @@ func (c *stateServiceClient) GetDependencyGraph(ctx context.Context, req *connec
 	return c.getDependencyGraph.CallUnary(ctx, req)
 }
 
+// RecomputeDependencyStatus calls state.v1.StateService.RecomputeDependencyStatus.
+func (c *stateServiceClient) RecomputeDependencyStatus(ctx context.Context, req *connect.Request[v1.RecomputeDependencyStatusRequest]) (*connect.Response[v1.RecomputeDependencyStatusResponse], error) {
+	return c.recomputeDependencyStatus.CallUnary(ctx, req)
+}
+
 // ListStateOutputs calls state.v1.StateService.ListStateOutputs.
 func (c *stateServiceClient) ListStateOutputs(ctx context.Context, req *connect.Request[v1.ListStateOutputsRequest]) (*connect.Response[v1.ListStateOutputsResponse], error) {
 	return c.listStateOutputs.CallUnary(ctx, req)
 }
 
+// ListStateOutputsBatch calls state.v1.StateService.ListStateOutputsBatch.
+func (c *stateServiceClient) ListStateOutputsBatch(ctx context.Context, req *connect.Request[v1.ListStateOutputsBatchRequest]) (*connect.Response[v1.ListStateOutputsBatchResponse], error) {
+	return c.listStateOutputsBatch.CallUnary(ctx, req)
+}
+
+// GetStateOutputValues calls state.v1.StateService.GetStateOutputValues.
+func (c *stateServiceClient) GetStateOutputValues(ctx context.Context, req *connect.Request[v1.GetStateOutputValuesRequest]) (*connect.Response[v1.GetStateOutputValuesResponse], error) {
+	return c.getStateOutputValues.CallUnary(ctx, req)
+}
+
 // GetStateInfo calls state.v1.StateService.GetStateInfo.
 func (c *stateServiceClient) GetStateInfo(ctx context.Context, req *connect.Request[v1.GetStateInfoRequest]) (*connect.Response[v1.GetStateInfoResponse], error) {
 	return c.getStateInfo.CallUnary(ctx, req)
@@ -588,11 +844,21 @@ func (c *stateServiceClient) ListAllEdges(ctx context.Context, req *connect.Requ
 	return c.listAllEdges.CallUnary(ctx, req)
 }
 
+// WatchStateChanges calls state.v1.StateService.WatchStateChanges.
+func (c *stateServiceClient) WatchStateChanges(ctx context.Context, req *connect.Request[v1.WatchStateChangesRequest]) (*connect.ServerStreamForClient[v1.StateChangeEvent], error) {
+	return c.watchStateChanges.CallServerStream(ctx, req)
+}
+
 // UpdateStateLabels calls state.v1.StateService.UpdateStateLabels.
 func (c *stateServiceClient) UpdateStateLabels(ctx context.Context, req *connect.Request[v1.UpdateStateLabelsRequest]) (*connect.Response[v1.UpdateStateLabelsResponse], error) {
 	return c.updateStateLabels.CallUnary(ctx, req)
 }
 
+// TransferStateOwnership calls state.v1.StateService.TransferStateOwnership.
+func (c *stateServiceClient) TransferStateOwnership(ctx context.Context, req *connect.Request[v1.TransferStateOwnershipRequest]) (*connect.Response[v1.TransferStateOwnershipResponse], error) {
+	return c.transferStateOwnership.CallUnary(ctx, req)
+}
+
 // GetLabelPolicy calls state.v1.StateService.GetLabelPolicy.
 func (c *stateServiceClient) GetLabelPolicy(ctx context.Context, req *connect.Request[v1.GetLabelPolicyRequest]) (*connect.Response[v1.GetLabelPolicyResponse], error) {
 	return c.getLabelPolicy.CallUnary(ctx, req)
@@ -688,16 +954,81 @@ func (c *stateServiceClient) RevokeSession(ctx context.Context, req *connect.Req
 	return c.revokeSession.CallUnary(ctx, req)
 }
 
+// ListAllSessions calls state.v1.StateService.ListAllSessions.
+func (c *stateServiceClient) ListAllSessions(ctx context.Context, req *connect.Request[v1.ListAllSessionsRequest]) (*connect.Response[v1.ListAllSessionsResponse], error) {
+	return c.listAllSessions.CallUnary(ctx, req)
+}
+
+// RevokeSessions calls state.v1.StateService.RevokeSessions.
+func (c *stateServiceClient) RevokeSessions(ctx context.Context, req *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error) {
+	return c.revokeSessions.CallUnary(ctx, req)
+}
+
+// IntrospectToken calls state.v1.StateService.IntrospectToken.
+func (c *stateServiceClient) IntrospectToken(ctx context.Context, req *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error) {
+	return c.introspectToken.CallUnary(ctx, req)
+}
+
+// PreviewAuthorization calls state.v1.StateService.PreviewAuthorization.
+func (c *stateServiceClient) PreviewAuthorization(ctx context.Context, req *connect.Request[v1.PreviewAuthorizationRequest]) (*connect.Response[v1.PreviewAuthorizationResponse], error) {
+	return c.previewAuthorization.CallUnary(ctx, req)
+}
+
 // SetOutputSchema calls state.v1.StateService.SetOutputSchema.
 func (c *stateServiceClient) SetOutputSchema(ctx context.Context, req *connect.Request[v1.SetOutputSchemaRequest]) (*connect.Response[v1.SetOutputSchemaResponse], error) {
 	return c.setOutputSchema.CallUnary(ctx, req)
 }
 
+// SetOutputSchemas calls state.v1.StateService.SetOutputSchemas.
+func (c *stateServiceClient) SetOutputSchemas(ctx context.Context, req *connect.Request[v1.SetOutputSchemasRequest]) (*connect.Response[v1.SetOutputSchemasResponse], error) {
+	return c.setOutputSchemas.CallUnary(ctx, req)
+}
+
+// RevalidateOutputsForSchema calls state.v1.StateService.RevalidateOutputsForSchema.
+func (c *stateServiceClient) RevalidateOutputsForSchema(ctx context.Context, req *connect.Request[v1.RevalidateOutputsForSchemaRequest]) (*connect.Response[v1.RevalidateOutputsForSchemaResponse], error) {
+	return c.revalidateOutputsForSchema.CallUnary(ctx, req)
+}
+
+// CreateRegisteredSchema calls state.v1.StateService.CreateRegisteredSchema.
+func (c *stateServiceClient) CreateRegisteredSchema(ctx context.Context, req *connect.Request[v1.CreateRegisteredSchemaRequest]) (*connect.Response[v1.CreateRegisteredSchemaResponse], error) {
+	return c.createRegisteredSchema.CallUnary(ctx, req)
+}
+
+// GetRegisteredSchema calls state.v1.StateService.GetRegisteredSchema.
+func (c *stateServiceClient) GetRegisteredSchema(ctx context.Context, req *connect.Request[v1.GetRegisteredSchemaRequest]) (*connect.Response[v1.GetRegisteredSchemaResponse], error) {
+	return c.getRegisteredSchema.CallUnary(ctx, req)
+}
+
+// ListRegisteredSchemas calls state.v1.StateService.ListRegisteredSchemas.
+func (c *stateServiceClient) ListRegisteredSchemas(ctx context.Context, req *connect.Request[v1.ListRegisteredSchemasRequest]) (*connect.Response[v1.ListRegisteredSchemasResponse], error) {
+	return c.listRegisteredSchemas.CallUnary(ctx, req)
+}
+
+// UpdateRegisteredSchema calls state.v1.StateService.UpdateRegisteredSchema.
+func (c *stateServiceClient) UpdateRegisteredSchema(ctx context.Context, req *connect.Request[v1.UpdateRegisteredSchemaRequest]) (*connect.Response[v1.UpdateRegisteredSchemaResponse], error) {
+	return c.updateRegisteredSchema.CallUnary(ctx, req)
+}
+
+// DeleteRegisteredSchema calls state.v1.StateService.DeleteRegisteredSchema.
+func (c *stateServiceClient) DeleteRegisteredSchema(ctx context.Context, req *connect.Request[v1.DeleteRegisteredSchemaRequest]) (*connect.Response[v1.DeleteRegisteredSchemaResponse], error) {
+	return c.deleteRegisteredSchema.CallUnary(ctx, req)
+}
+
 // GetOutputSchema calls state.v1.StateService.GetOutputSchema.
 func (c *stateServiceClient) GetOutputSchema(ctx context.Context, req *connect.Request[v1.GetOutputSchemaRequest]) (*connect.Response[v1.GetOutputSchemaResponse], error) {
 	return c.getOutputSchema.CallUnary(ctx, req)
 }
 
+// ListOutputSchemaHistory calls state.v1.StateService.ListOutputSchemaHistory.
+func (c *stateServiceClient) ListOutputSchemaHistory(ctx context.Context, req *connect.Request[v1.ListOutputSchemaHistoryRequest]) (*connect.Response[v1.ListOutputSchemaHistoryResponse], error) {
+	return c.listOutputSchemaHistory.CallUnary(ctx, req)
+}
+
+// GetStateValidationSummary calls state.v1.StateService.GetStateValidationSummary.
+func (c *stateServiceClient) GetStateValidationSummary(ctx context.Context, req *connect.Request[v1.GetStateValidationSummaryRequest]) (*connect.Response[v1.GetStateValidationSummaryResponse], error) {
+	return c.getStateValidationSummary.CallUnary(ctx, req)
+}
+
 // StateServiceHandler is an implementation of the state.v1.StateService service.
 type StateServiceHandler interface {
 	// CreateState creates a new state with client-generated GUID and logic ID.
@@ -720,17 +1051,35 @@ type StateServiceHandler interface {
 	// ListDependents returns all edges where the given state is the producer (outgoing deps).
 	ListDependents(context.Context, *connect.Request[v1.ListDependentsRequest]) (*connect.Response[v1.ListDependentsResponse], error)
 	// SearchByOutput finds all edges that reference a specific output key (by name).
+	// Only edges whose producer and consumer are both within the caller's role
+	// scopes are returned.
 	SearchByOutput(context.Context, *connect.Request[v1.SearchByOutputRequest]) (*connect.Response[v1.SearchByOutputResponse], error)
 	// GetTopologicalOrder computes layered ordering of states rooted at given state.
+	// The root must be within the caller's role scopes; states outside them are
+	// omitted from the layers.
 	GetTopologicalOrder(context.Context, *connect.Request[v1.GetTopologicalOrderRequest]) (*connect.Response[v1.GetTopologicalOrderResponse], error)
 	// GetStateStatus computes on-demand status for a state based on its incoming edges.
+	// The state must be within the caller's role scopes; incoming edges and summary
+	// counts only cover producers within them.
 	GetStateStatus(context.Context, *connect.Request[v1.GetStateStatusRequest]) (*connect.Response[v1.GetStateStatusResponse], error)
 	// GetDependencyGraph returns full dependency graph data for client-side HCL generation.
 	GetDependencyGraph(context.Context, *connect.Request[v1.GetDependencyGraphRequest]) (*connect.Response[v1.GetDependencyGraphResponse], error)
+	// RecomputeDependencyStatus re-derives the statuses of a producer's outgoing
+	// edges from its current outputs and persists them, e.g. from CI after an apply.
+	RecomputeDependencyStatus(context.Context, *connect.Request[v1.RecomputeDependencyStatusRequest]) (*connect.Response[v1.RecomputeDependencyStatusResponse], error)
 	// ListStateOutputs returns available output keys from a state's Terraform/OpenTofu JSON.
 	// Output values are NOT returned (security/size concerns); only keys and sensitive flags.
 	// Used by CLI for interactive output selection when creating dependencies.
 	ListStateOutputs(context.Context, *connect.Request[v1.ListStateOutputsRequest]) (*connect.Response[v1.ListStateOutputsResponse], error)
+	// ListStateOutputsBatch returns outputs for several states in one call, e.g. to
+	// compare vpc_id across every env=dev state. States outside the caller's role
+	// scopes, and states that do not exist, are omitted rather than failing the call.
+	// Values are returned only when include_values is set, and never for sensitive outputs.
+	ListStateOutputsBatch(context.Context, *connect.Request[v1.ListStateOutputsBatchRequest]) (*connect.Response[v1.ListStateOutputsBatchResponse], error)
+	// GetStateOutputValues returns the values of selected outputs of one state without
+	// exposing the raw tfstate. Sensitive values are redacted unless the caller holds
+	// state-output:read-sensitive on the state.
+	GetStateOutputValues(context.Context, *connect.Request[v1.GetStateOutputValuesRequest]) (*connect.Response[v1.GetStateOutputValuesResponse], error)
 	// GetStateInfo retrieves comprehensive state information including:
 	// - Basic metadata (GUID, logic-id, timestamps)
 	// - Backend configuration (Terraform HTTP backend URLs)
@@ -744,8 +1093,18 @@ type StateServiceHandler interface {
 	// Used by dashboards and monitoring tools to visualize complete topology.
 	// Returns edges in ascending order by ID (database insertion order).
 	ListAllEdges(context.Context, *connect.Request[v1.ListAllEdgesRequest]) (*connect.Response[v1.ListAllEdgesResponse], error)
+	// WatchStateChanges streams an event whenever a state's serial, labels,
+	// outputs or computed dependency status changes. Only states whose current
+	// labels match the caller's role scopes are reported. Changes are detected
+	// by polling, so events arrive up to one poll interval late; a client that
+	// falls behind is disconnected and should reconnect.
+	WatchStateChanges(context.Context, *connect.Request[v1.WatchStateChangesRequest], *connect.ServerStream[v1.StateChangeEvent]) error
 	// UpdateStateLabels mutates labels for an existing state (add/replace/remove).
 	UpdateStateLabels(context.Context, *connect.Request[v1.UpdateStateLabelsRequest]) (*connect.Response[v1.UpdateStateLabelsResponse], error)
+	// TransferStateOwnership records a new owner (created_by) for a state, moving
+	// role owner-override access to them. Allowed for the current owner or with
+	// state:transfer-ownership.
+	TransferStateOwnership(context.Context, *connect.Request[v1.TransferStateOwnershipRequest]) (*connect.Response[v1.TransferStateOwnershipResponse], error)
 	// GetLabelPolicy retrieves the current label validation policy.
 	GetLabelPolicy(context.Context, *connect.Request[v1.GetLabelPolicyRequest]) (*connect.Response[v1.GetLabelPolicyResponse], error)
 	// SetLabelPolicy updates the label validation policy with version increment.
@@ -773,11 +1132,44 @@ type StateServiceHandler interface {
 	// Session Management
 	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
 	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	// ListAllSessions lists sessions across all users (admin only)
+	ListAllSessions(context.Context, *connect.Request[v1.ListAllSessionsRequest]) (*connect.Response[v1.ListAllSessionsResponse], error)
+	// RevokeSessions revokes every session matching a filter (admin only, incident response)
+	RevokeSessions(context.Context, *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error)
+	// Token Introspection (admin/debug, RFC 7662 style)
+	IntrospectToken(context.Context, *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error)
+	// Authorization dry run (admin/debug): would a principal with these roles or
+	// groups be allowed the action? No user needs to exist or be logged in.
+	PreviewAuthorization(context.Context, *connect.Request[v1.PreviewAuthorizationRequest]) (*connect.Response[v1.PreviewAuthorizationResponse], error)
 	// SetOutputSchema publishes or updates a JSON Schema for a specific state output.
 	// This allows clients to declare expected output types before the output exists.
 	SetOutputSchema(context.Context, *connect.Request[v1.SetOutputSchemaRequest]) (*connect.Response[v1.SetOutputSchemaResponse], error)
+	// SetOutputSchemas publishes or updates JSON Schemas for several outputs of a
+	// state at once. All schemas are validated up front and written atomically.
+	SetOutputSchemas(context.Context, *connect.Request[v1.SetOutputSchemasRequest]) (*connect.Response[v1.SetOutputSchemasResponse], error)
+	// RevalidateOutputsForSchema re-validates every output, across all states,
+	// whose schema matches the given output's schema, and reports which outputs
+	// moved between valid and invalid. Use after changing a shared schema.
+	RevalidateOutputsForSchema(context.Context, *connect.Request[v1.RevalidateOutputsForSchemaRequest]) (*connect.Response[v1.RevalidateOutputsForSchemaResponse], error)
+	// CreateRegisteredSchema registers a new schema under a key.
+	CreateRegisteredSchema(context.Context, *connect.Request[v1.CreateRegisteredSchemaRequest]) (*connect.Response[v1.CreateRegisteredSchemaResponse], error)
+	// GetRegisteredSchema retrieves a registered schema by key.
+	GetRegisteredSchema(context.Context, *connect.Request[v1.GetRegisteredSchemaRequest]) (*connect.Response[v1.GetRegisteredSchemaResponse], error)
+	// ListRegisteredSchemas lists all registered schemas.
+	ListRegisteredSchemas(context.Context, *connect.Request[v1.ListRegisteredSchemasRequest]) (*connect.Response[v1.ListRegisteredSchemasResponse], error)
+	// UpdateRegisteredSchema replaces a registered schema. Every output that
+	// references the key picks up the new schema, and can be re-validated.
+	UpdateRegisteredSchema(context.Context, *connect.Request[v1.UpdateRegisteredSchemaRequest]) (*connect.Response[v1.UpdateRegisteredSchemaResponse], error)
+	// DeleteRegisteredSchema removes a registered schema that no output references.
+	DeleteRegisteredSchema(context.Context, *connect.Request[v1.DeleteRegisteredSchemaRequest]) (*connect.Response[v1.DeleteRegisteredSchemaResponse], error)
 	// GetOutputSchema retrieves the JSON Schema for a specific state output.
 	GetOutputSchema(context.Context, *connect.Request[v1.GetOutputSchemaRequest]) (*connect.Response[v1.GetOutputSchemaResponse], error)
+	// ListOutputSchemaHistory lists every schema a state output has had, with the
+	// version each validation result refers to.
+	ListOutputSchemaHistory(context.Context, *connect.Request[v1.ListOutputSchemaHistoryRequest]) (*connect.Response[v1.ListOutputSchemaHistoryResponse], error)
+	// GetStateValidationSummary aggregates the stored validation results of a
+	// state's outputs. It reports existing results and never re-runs validation.
+	GetStateValidationSummary(context.Context, *connect.Request[v1.GetStateValidationSummaryRequest]) (*connect.Response[v1.GetStateValidationSummaryResponse], error)
 }
 
 // NewStateServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -865,12 +1257,30 @@ func NewStateServiceHandler(svc StateServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(stateServiceMethods.ByName("GetDependencyGraph")),
 		connect.WithHandlerOptions(opts...),
 	)
+	stateServiceRecomputeDependencyStatusHandler := connect.NewUnaryHandler(
+		StateServiceRecomputeDependencyStatusProcedure,
+		svc.RecomputeDependencyStatus,
+		connect.WithSchema(stateServiceMethods.ByName("RecomputeDependencyStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
 	stateServiceListStateOutputsHandler := connect.NewUnaryHandler(
 		StateServiceListStateOutputsProcedure,
 		svc.ListStateOutputs,
 		connect.WithSchema(stateServiceMethods.ByName("ListStateOutputs")),
 		connect.WithHandlerOptions(opts...),
 	)
+	stateServiceListStateOutputsBatchHandler := connect.NewUnaryHandler(
+		StateServiceListStateOutputsBatchProcedure,
+		svc.ListStateOutputsBatch,
+		connect.WithSchema(stateServiceMethods.ByName("ListStateOutputsBatch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceGetStateOutputValuesHandler := connect.NewUnaryHandler(
+		StateServiceGetStateOutputValuesProcedure,
+		svc.GetStateOutputValues,
+		connect.WithSchema(stateServiceMethods.ByName("GetStateOutputValues")),
+		connect.WithHandlerOptions(opts...),
+	)
 	stateServiceGetStateInfoHandler := connect.NewUnaryHandler(
 		StateServiceGetStateInfoProcedure,
 		svc.GetStateInfo,
@@ -883,12 +1293,24 @@ func NewStateServiceHandler(svc StateServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(stateServiceMethods.ByName("ListAllEdges")),
 		connect.WithHandlerOptions(opts...),
 	)
+	stateServiceWatchStateChangesHandler := connect.NewServerStreamHandler(
+		StateServiceWatchStateChangesProcedure,
+		svc.WatchStateChanges,
+		connect.WithSchema(stateServiceMethods.ByName("WatchStateChanges")),
+		connect.WithHandlerOptions(opts...),
+	)
 	stateServiceUpdateStateLabelsHandler := connect.NewUnaryHandler(
 		StateServiceUpdateStateLabelsProcedure,
 		svc.UpdateStateLabels,
 		connect.WithSchema(stateServiceMethods.ByName("UpdateStateLabels")),
 		connect.WithHandlerOptions(opts...),
 	)
+	stateServiceTransferStateOwnershipHandler := connect.NewUnaryHandler(
+		StateServiceTransferStateOwnershipProcedure,
+		svc.TransferStateOwnership,
+		connect.WithSchema(stateServiceMethods.ByName("TransferStateOwnership")),
+		connect.WithHandlerOptions(opts...),
+	)
 	stateServiceGetLabelPolicyHandler := connect.NewUnaryHandler(
 		StateServiceGetLabelPolicyProcedure,
 		svc.GetLabelPolicy,
@@ -1003,18 +1425,96 @@ func NewStateServiceHandler(svc StateServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(stateServiceMethods.ByName("RevokeSession")),
 		connect.WithHandlerOptions(opts...),
 	)
+	stateServiceListAllSessionsHandler := connect.NewUnaryHandler(
+		StateServiceListAllSessionsProcedure,
+		svc.ListAllSessions,
+		connect.WithSchema(stateServiceMethods.ByName("ListAllSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceRevokeSessionsHandler := connect.NewUnaryHandler(
+		StateServiceRevokeSessionsProcedure,
+		svc.RevokeSessions,
+		connect.WithSchema(stateServiceMethods.ByName("RevokeSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceIntrospectTokenHandler := connect.NewUnaryHandler(
+		StateServiceIntrospectTokenProcedure,
+		svc.IntrospectToken,
+		connect.WithSchema(stateServiceMethods.ByName("IntrospectToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServicePreviewAuthorizationHandler := connect.NewUnaryHandler(
+		StateServicePreviewAuthorizationProcedure,
+		svc.PreviewAuthorization,
+		connect.WithSchema(stateServiceMethods.ByName("PreviewAuthorization")),
+		connect.WithHandlerOptions(opts...),
+	)
 	stateServiceSetOutputSchemaHandler := connect.NewUnaryHandler(
 		StateServiceSetOutputSchemaProcedure,
 		svc.SetOutputSchema,
 		connect.WithSchema(stateServiceMethods.ByName("SetOutputSchema")),
 		connect.WithHandlerOptions(opts...),
 	)
+	stateServiceSetOutputSchemasHandler := connect.NewUnaryHandler(
+		StateServiceSetOutputSchemasProcedure,
+		svc.SetOutputSchemas,
+		connect.WithSchema(stateServiceMethods.ByName("SetOutputSchemas")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceRevalidateOutputsForSchemaHandler := connect.NewUnaryHandler(
+		StateServiceRevalidateOutputsForSchemaProcedure,
+		svc.RevalidateOutputsForSchema,
+		connect.WithSchema(stateServiceMethods.ByName("RevalidateOutputsForSchema")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceCreateRegisteredSchemaHandler := connect.NewUnaryHandler(
+		StateServiceCreateRegisteredSchemaProcedure,
+		svc.CreateRegisteredSchema,
+		connect.WithSchema(stateServiceMethods.ByName("CreateRegisteredSchema")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceGetRegisteredSchemaHandler := connect.NewUnaryHandler(
+		StateServiceGetRegisteredSchemaProcedure,
+		svc.GetRegisteredSchema,
+		connect.WithSchema(stateServiceMethods.ByName("GetRegisteredSchema")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceListRegisteredSchemasHandler := connect.NewUnaryHandler(
+		StateServiceListRegisteredSchemasProcedure,
+		svc.ListRegisteredSchemas,
+		connect.WithSchema(stateServiceMethods.ByName("ListRegisteredSchemas")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceUpdateRegisteredSchemaHandler := connect.NewUnaryHandler(
+		StateServiceUpdateRegisteredSchemaProcedure,
+		svc.UpdateRegisteredSchema,
+		connect.WithSchema(stateServiceMethods.ByName("UpdateRegisteredSchema")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceDeleteRegisteredSchemaHandler := connect.NewUnaryHandler(
+		StateServiceDeleteRegisteredSchemaProcedure,
+		svc.DeleteRegisteredSchema,
+		connect.WithSchema(stateServiceMethods.ByName("DeleteRegisteredSchema")),
+		connect.WithHandlerOptions(opts...),
+	)
 	stateServiceGetOutputSchemaHandler := connect.NewUnaryHandler(
 		StateServiceGetOutputSchemaProcedure,
 		svc.GetOutputSchema,
 		connect.WithSchema(stateServiceMethods.ByName("GetOutputSchema")),
 		connect.WithHandlerOptions(opts...),
 	)
+	stateServiceListOutputSchemaHistoryHandler := connect.NewUnaryHandler(
+		StateServiceListOutputSchemaHistoryProcedure,
+		svc.ListOutputSchemaHistory,
+		connect.WithSchema(stateServiceMethods.ByName("ListOutputSchemaHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stateServiceGetStateValidationSummaryHandler := connect.NewUnaryHandler(
+		StateServiceGetStateValidationSummaryProcedure,
+		svc.GetStateValidationSummary,
+		connect.WithSchema(stateServiceMethods.ByName("GetStateValidationSummary")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/state.v1.StateService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case StateServiceCreateStateProcedure:
@@ -1043,14 +1543,24 @@ func NewStateServiceHandler(svc StateServiceHandler, opts ...connect.HandlerOpti
 			stateServiceGetStateStatusHandler.ServeHTTP(w, r)
 		case StateServiceGetDependencyGraphProcedure:
 			stateServiceGetDependencyGraphHandler.ServeHTTP(w, r)
+		case StateServiceRecomputeDependencyStatusProcedure:
+			stateServiceRecomputeDependencyStatusHandler.ServeHTTP(w, r)
 		case StateServiceListStateOutputsProcedure:
 			stateServiceListStateOutputsHandler.ServeHTTP(w, r)
+		case StateServiceListStateOutputsBatchProcedure:
+			stateServiceListStateOutputsBatchHandler.ServeHTTP(w, r)
+		case StateServiceGetStateOutputValuesProcedure:
+			stateServiceGetStateOutputValuesHandler.ServeHTTP(w, r)
 		case StateServiceGetStateInfoProcedure:
 			stateServiceGetStateInfoHandler.ServeHTTP(w, r)
 		case StateServiceListAllEdgesProcedure:
 			stateServiceListAllEdgesHandler.ServeHTTP(w, r)
+		case StateServiceWatchStateChangesProcedure:
+			stateServiceWatchStateChangesHandler.ServeHTTP(w, r)
 		case StateServiceUpdateStateLabelsProcedure:
 			stateServiceUpdateStateLabelsHandler.ServeHTTP(w, r)
+		case StateServiceTransferStateOwnershipProcedure:
+			stateServiceTransferStateOwnershipHandler.ServeHTTP(w, r)
 		case StateServiceGetLabelPolicyProcedure:
 			stateServiceGetLabelPolicyHandler.ServeHTTP(w, r)
 		case StateServiceSetLabelPolicyProcedure:
@@ -1089,10 +1599,36 @@ func NewStateServiceHandler(svc StateServiceHandler, opts ...connect.HandlerOpti
 			stateServiceListSessionsHandler.ServeHTTP(w, r)
 		case StateServiceRevokeSessionProcedure:
 			stateServiceRevokeSessionHandler.ServeHTTP(w, r)
+		case StateServiceListAllSessionsProcedure:
+			stateServiceListAllSessionsHandler.ServeHTTP(w, r)
+		case StateServiceRevokeSessionsProcedure:
+			stateServiceRevokeSessionsHandler.ServeHTTP(w, r)
+		case StateServiceIntrospectTokenProcedure:
+			stateServiceIntrospectTokenHandler.ServeHTTP(w, r)
+		case StateServicePreviewAuthorizationProcedure:
+			stateServicePreviewAuthorizationHandler.ServeHTTP(w, r)
 		case StateServiceSetOutputSchemaProcedure:
 			stateServiceSetOutputSchemaHandler.ServeHTTP(w, r)
+		case StateServiceSetOutputSchemasProcedure:
+			stateServiceSetOutputSchemasHandler.ServeHTTP(w, r)
+		case StateServiceRevalidateOutputsForSchemaProcedure:
+			stateServiceRevalidateOutputsForSchemaHandler.ServeHTTP(w, r)
+		case StateServiceCreateRegisteredSchemaProcedure:
+			stateServiceCreateRegisteredSchemaHandler.ServeHTTP(w, r)
+		case StateServiceGetRegisteredSchemaProcedure:
+			stateServiceGetRegisteredSchemaHandler.ServeHTTP(w, r)
+		case StateServiceListRegisteredSchemasProcedure:
+			stateServiceListRegisteredSchemasHandler.ServeHTTP(w, r)
+		case StateServiceUpdateRegisteredSchemaProcedure:
+			stateServiceUpdateRegisteredSchemaHandler.ServeHTTP(w, r)
+		case StateServiceDeleteRegisteredSchemaProcedure:
+			stateServiceDeleteRegisteredSchemaHandler.ServeHTTP(w, r)
 		case StateServiceGetOutputSchemaProcedure:
 			stateServiceGetOutputSchemaHandler.ServeHTTP(w, r)
+		case StateServiceListOutputSchemaHistoryProcedure:
+			stateServiceListOutputSchemaHistoryHandler.ServeHTTP(w, r)
+		case StateServiceGetStateValidationSummaryProcedure:
+			stateServiceGetStateValidationSummaryHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -1154,10 +1690,22 @@ func (UnimplementedStateServiceHandler) GetDependencyGraph(context.Context, *con
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.GetDependencyGraph is not implemented"))
 }
 
+func (UnimplementedStateServiceHandler) RecomputeDependencyStatus(context.Context, *connect.Request[v1.RecomputeDependencyStatusRequest]) (*connect.Response[v1.RecomputeDependencyStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.RecomputeDependencyStatus is not implemented"))
+}
+
 func (UnimplementedStateServiceHandler) ListStateOutputs(context.Context, *connect.Request[v1.ListStateOutputsRequest]) (*connect.Response[v1.ListStateOutputsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.ListStateOutputs is not implemented"))
 }
 
+func (UnimplementedStateServiceHandler) ListStateOutputsBatch(context.Context, *connect.Request[v1.ListStateOutputsBatchRequest]) (*connect.Response[v1.ListStateOutputsBatchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.ListStateOutputsBatch is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) GetStateOutputValues(context.Context, *connect.Request[v1.GetStateOutputValuesRequest]) (*connect.Response[v1.GetStateOutputValuesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.GetStateOutputValues is not implemented"))
+}
+
 func (UnimplementedStateServiceHandler) GetStateInfo(context.Context, *connect.Request[v1.GetStateInfoRequest]) (*connect.Response[v1.GetStateInfoResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.GetStateInfo is not implemented"))
 }
@@ -1166,10 +1714,18 @@ func (UnimplementedStateServiceHandler) ListAllEdges(context.Context, *connect.R
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.ListAllEdges is not implemented"))
 }
 
+func (UnimplementedStateServiceHandler) WatchStateChanges(context.Context, *connect.Request[v1.WatchStateChangesRequest], *connect.ServerStream[v1.StateChangeEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.WatchStateChanges is not implemented"))
+}
+
 func (UnimplementedStateServiceHandler) UpdateStateLabels(context.Context, *connect.Request[v1.UpdateStateLabelsRequest]) (*connect.Response[v1.UpdateStateLabelsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.UpdateStateLabels is not implemented"))
 }
 
+func (UnimplementedStateServiceHandler) TransferStateOwnership(context.Context, *connect.Request[v1.TransferStateOwnershipRequest]) (*connect.Response[v1.TransferStateOwnershipResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.TransferStateOwnership is not implemented"))
+}
+
 func (UnimplementedStateServiceHandler) GetLabelPolicy(context.Context, *connect.Request[v1.GetLabelPolicyRequest]) (*connect.Response[v1.GetLabelPolicyResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.GetLabelPolicy is not implemented"))
 }
@@ -1246,10 +1802,62 @@ func (UnimplementedStateServiceHandler) RevokeSession(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.RevokeSession is not implemented"))
 }
 
+func (UnimplementedStateServiceHandler) ListAllSessions(context.Context, *connect.Request[v1.ListAllSessionsRequest]) (*connect.Response[v1.ListAllSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.ListAllSessions is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) RevokeSessions(context.Context, *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.RevokeSessions is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) IntrospectToken(context.Context, *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.IntrospectToken is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) PreviewAuthorization(context.Context, *connect.Request[v1.PreviewAuthorizationRequest]) (*connect.Response[v1.PreviewAuthorizationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.PreviewAuthorization is not implemented"))
+}
+
 func (UnimplementedStateServiceHandler) SetOutputSchema(context.Context, *connect.Request[v1.SetOutputSchemaRequest]) (*connect.Response[v1.SetOutputSchemaResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.SetOutputSchema is not implemented"))
 }
 
+func (UnimplementedStateServiceHandler) SetOutputSchemas(context.Context, *connect.Request[v1.SetOutputSchemasRequest]) (*connect.Response[v1.SetOutputSchemasResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.SetOutputSchemas is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) RevalidateOutputsForSchema(context.Context, *connect.Request[v1.RevalidateOutputsForSchemaRequest]) (*connect.Response[v1.RevalidateOutputsForSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.RevalidateOutputsForSchema is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) CreateRegisteredSchema(context.Context, *connect.Request[v1.CreateRegisteredSchemaRequest]) (*connect.Response[v1.CreateRegisteredSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.CreateRegisteredSchema is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) GetRegisteredSchema(context.Context, *connect.Request[v1.GetRegisteredSchemaRequest]) (*connect.Response[v1.GetRegisteredSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.GetRegisteredSchema is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) ListRegisteredSchemas(context.Context, *connect.Request[v1.ListRegisteredSchemasRequest]) (*connect.Response[v1.ListRegisteredSchemasResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.ListRegisteredSchemas is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) UpdateRegisteredSchema(context.Context, *connect.Request[v1.UpdateRegisteredSchemaRequest]) (*connect.Response[v1.UpdateRegisteredSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.UpdateRegisteredSchema is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) DeleteRegisteredSchema(context.Context, *connect.Request[v1.DeleteRegisteredSchemaRequest]) (*connect.Response[v1.DeleteRegisteredSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.DeleteRegisteredSchema is not implemented"))
+}
+
 func (UnimplementedStateServiceHandler) GetOutputSchema(context.Context, *connect.Request[v1.GetOutputSchemaRequest]) (*connect.Response[v1.GetOutputSchemaResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.GetOutputSchema is not implemented"))
 }
+
+func (UnimplementedStateServiceHandler) ListOutputSchemaHistory(context.Context, *connect.Request[v1.ListOutputSchemaHistoryRequest]) (*connect.Response[v1.ListOutputSchemaHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.ListOutputSchemaHistory is not implemented"))
+}
+
+func (UnimplementedStateServiceHandler) GetStateValidationSummary(context.Context, *connect.Request[v1.GetStateValidationSummaryRequest]) (*connect.Response[v1.GetStateValidationSummaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("state.v1.StateService.GetStateValidationSummary is not implemented"))
+}
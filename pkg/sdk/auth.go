@@ -9,6 +9,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
@@ -18,6 +19,10 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+// scopeGroups requests the user's group memberships, which Grid maps to roles.
+// Refreshes request it too so renewed tokens keep the claim.
+const scopeGroups = "groups"
+
 // LoginSuccessMetadata contains information about the successful login,
 // useful for displaying a confirmation message to the user.
 type LoginSuccessMetadata struct {
@@ -144,7 +149,7 @@ func performDeviceFlow(
 	clientID string,
 ) (*LoginSuccessMetadata, *Credentials, error) {
 
-	scopes := []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess}
+	scopes := []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess, scopeGroups}
 
 	// 1. Discover Provider Configuration
 	// The relying party client performs OIDC discovery (/.well-known/openid-configuration)
@@ -242,11 +247,16 @@ func performDeviceFlow(
 //
 // The function performs OIDC discovery to find the token endpoint, then exchanges
 // client credentials for an access token.
+//
+// permissionScopes optionally down-scope the token to the given Grid actions
+// (e.g. "state:read", "tfstate:*"): Grid's internal IdP then denies any action
+// outside them, even one the service account's roles allow.
 func LoginWithServiceAccount(
 	ctx context.Context,
 	issuer string,
 	clientID string,
 	clientSecret string,
+	permissionScopes ...string,
 ) (*Credentials, error) {
 	// 1. Discover Provider Configuration
 	// We only need discovery to get the token endpoint URL
@@ -269,7 +279,7 @@ func LoginWithServiceAccount(
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		TokenURL:     discoverer.OAuthConfig().Endpoint.TokenURL,
-		Scopes:       scopes,
+		Scopes:       append(slices.Clone(scopes), permissionScopes...),
 	}
 
 	// 3. Exchange Credentials for Token
@@ -300,7 +310,7 @@ func RefreshToken(
 	refreshToken string,
 ) (*Credentials, error) {
 	// Discover provider to get token endpoint
-	scopes := []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess}
+	scopes := []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess, scopeGroups}
 	relyingParty, err := rp.NewRelyingPartyOIDC(
 		ctx,
 		issuer,
@@ -106,6 +106,45 @@ func (c *Client) ListAllEdges(ctx context.Context) ([]DependencyEdge, error) {
 	return edgesFromProto(resp.Msg.GetEdges()), nil
 }
 
+// ListAllEdgesPaged returns every dependency edge, fetching pageSize edges per request.
+func (c *Client) ListAllEdgesPaged(ctx context.Context, pageSize int32) ([]DependencyEdge, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive")
+	}
+
+	edges := []DependencyEdge{}
+	pageToken := ""
+	for {
+		page, err := c.ListEdgesPage(ctx, pageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, page.Edges...)
+		if page.NextPageToken == "" {
+			return edges, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// ListEdgesPage returns a single page of dependency edges, resuming after
+// pageToken (empty for the first page).
+func (c *Client) ListEdgesPage(ctx context.Context, pageSize int32, pageToken string) (*EdgesPage, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive")
+	}
+
+	resp, err := c.rpc.ListAllEdges(ctx, connect.NewRequest(&statev1.ListAllEdgesRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &EdgesPage{Edges: edgesFromProto(resp.Msg.GetEdges()), NextPageToken: resp.Msg.GetNextPageToken()}, nil
+}
+
 // SearchByOutput finds every dependency edge that consumes a specific producer output key.
 func (c *Client) SearchByOutput(ctx context.Context, outputKey string) ([]DependencyEdge, error) {
 	if outputKey == "" {
@@ -186,12 +225,21 @@ func (c *Client) GetStateStatus(ctx context.Context, ref StateReference) (*State
 	return status, nil
 }
 
-// GetDependencyGraph retrieves the dependency graph for a consumer state reference.
+// GetDependencyGraph retrieves the direct producers of a consumer state reference.
 func (c *Client) GetDependencyGraph(ctx context.Context, ref StateReference) (*DependencyGraph, error) {
+	return c.GetDependencyGraphWithOptions(ctx, ref, DependencyGraphOptions{})
+}
+
+// GetDependencyGraphWithOptions retrieves the dependency graph for a consumer
+// state reference, following producers up to opts.MaxDepth hops.
+func (c *Client) GetDependencyGraphWithOptions(ctx context.Context, ref StateReference, opts DependencyGraphOptions) (*DependencyGraph, error) {
 	req, err := newDependencyGraphRequest(ref)
 	if err != nil {
 		return nil, err
 	}
+	if opts.MaxDepth > 0 {
+		req.MaxDepth = &opts.MaxDepth
+	}
 
 	resp, err := c.rpc.GetDependencyGraph(ctx, connect.NewRequest(req))
 	if err != nil {
@@ -203,7 +251,10 @@ func (c *Client) GetDependencyGraph(ctx context.Context, ref StateReference) (*D
 			GUID:    resp.Msg.GetConsumerGuid(),
 			LogicID: resp.Msg.GetConsumerLogicId(),
 		},
-		Edges: edgesFromProto(resp.Msg.GetEdges()),
+		Edges:        edgesFromProto(resp.Msg.GetEdges()),
+		MaxDepth:     int(resp.Msg.GetMaxDepth()),
+		Truncated:    resp.Msg.GetTruncated(),
+		CycleEdgeIDs: resp.Msg.GetCycleEdgeIds(),
 	}
 
 	producers := make([]ProducerState, 0, len(resp.Msg.GetProducers()))
@@ -214,6 +265,7 @@ func (c *Client) GetDependencyGraph(ctx context.Context, ref StateReference) (*D
 				LogicID: producer.GetLogicId(),
 			},
 			BackendConfig: backendConfigFromProto(producer.BackendConfig),
+			Depth:         int(producer.GetDepth()),
 		})
 	}
 	graph.Producers = producers
@@ -221,6 +273,35 @@ func (c *Client) GetDependencyGraph(ctx context.Context, ref StateReference) (*D
 	return graph, nil
 }
 
+// RecomputeDependencyStatus re-derives the status of the producer's outgoing
+// edges from its current outputs, e.g. from CI right after an apply.
+func (c *Client) RecomputeDependencyStatus(ctx context.Context, producer StateReference) (*DependencyRecompute, error) {
+	req := &statev1.RecomputeDependencyStatusRequest{}
+	switch {
+	case producer.GUID != "":
+		req.State = &statev1.RecomputeDependencyStatusRequest_Guid{Guid: producer.GUID}
+	case producer.LogicID != "":
+		req.State = &statev1.RecomputeDependencyStatusRequest_LogicId{LogicId: producer.LogicID}
+	default:
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
+	}
+
+	resp, err := c.rpc.RecomputeDependencyStatus(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &DependencyRecompute{
+		Producer: StateReference{
+			GUID:    resp.Msg.GetProducerGuid(),
+			LogicID: resp.Msg.GetProducerLogicId(),
+		},
+		Edges:          edgesFromProto(resp.Msg.GetEdges()),
+		ChangedEdgeIDs: resp.Msg.GetChangedEdgeIds(),
+		SkippedEdges:   int(resp.Msg.GetSkippedEdges()),
+	}, nil
+}
+
 // --- internal helpers ---------------------------------------------------------------------
 
 func newListDependenciesRequest(ref StateReference) (*statev1.ListDependenciesRequest, error) {
@@ -362,6 +443,7 @@ func dependencyEdgeFromProto(edge *statev1.DependencyEdge) DependencyEdge {
 		Status:         edge.GetStatus(),
 		InDigest:       edge.GetInDigest(),
 		OutDigest:      edge.GetOutDigest(),
+		Current:        edge.GetCurrent(),
 		MockValueJSON:  mock,
 		LastProducedAt: lastIn,
 		LastConsumedAt: lastOut,
@@ -397,6 +479,7 @@ func incomingEdgeFromProto(edge *statev1.IncomingEdgeView) IncomingEdge {
 		Status:         edge.GetStatus(),
 		InDigest:       edge.GetInDigest(),
 		OutDigest:      edge.GetOutDigest(),
+		Current:        edge.GetCurrent(),
 		LastProducedAt: lastIn,
 		LastConsumedAt: lastOut,
 	}
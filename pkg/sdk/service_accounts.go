@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ServiceAccount describes a service account returned by ListServiceAccounts.
+type ServiceAccount struct {
+	ID          string
+	ClientID    string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	LastUsedAt  time.Time
+	Disabled    bool
+}
+
+// ListServiceAccountsOptions filters ListServiceAccounts. Zero values do not filter.
+type ListServiceAccountsOptions struct {
+	NamePrefix string
+	Disabled   *bool
+	CreatedBy  string // Creator's user ID
+	// LastUsedBefore matches accounts last used before this time, including
+	// accounts never used.
+	LastUsedBefore *time.Time
+	// PageSize fetches accounts in pages of this size (max 1000). Zero
+	// fetches every account in a single response.
+	PageSize int32
+}
+
+// ServiceAccountsPage is one page of ListServiceAccountsPage results.
+type ServiceAccountsPage struct {
+	ServiceAccounts []ServiceAccount
+	// NextPageToken resumes the listing; empty when there are no more accounts.
+	NextPageToken string
+}
+
+// ListServiceAccounts returns the service accounts matching opts. When
+// opts.PageSize is set, every page is fetched and the results concatenated.
+func (c *Client) ListServiceAccounts(ctx context.Context, opts ListServiceAccountsOptions) ([]ServiceAccount, error) {
+	var accounts []ServiceAccount
+	pageToken := ""
+	for {
+		page, err := c.ListServiceAccountsPage(ctx, opts, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, page.ServiceAccounts...)
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	if accounts == nil {
+		accounts = []ServiceAccount{}
+	}
+	return accounts, nil
+}
+
+// ListServiceAccountsPage returns a single page of service accounts of
+// opts.PageSize, resuming after pageToken (empty for the first page).
+func (c *Client) ListServiceAccountsPage(ctx context.Context, opts ListServiceAccountsOptions, pageToken string) (*ServiceAccountsPage, error) {
+	req := connect.NewRequest(&statev1.ListServiceAccountsRequest{
+		Disabled:  opts.Disabled,
+		PageSize:  opts.PageSize,
+		PageToken: pageToken,
+	})
+	if opts.NamePrefix != "" {
+		req.Msg.NamePrefix = &opts.NamePrefix
+	}
+	if opts.CreatedBy != "" {
+		req.Msg.CreatedBy = &opts.CreatedBy
+	}
+	if opts.LastUsedBefore != nil {
+		req.Msg.LastUsedBefore = timestamppb.New(*opts.LastUsedBefore)
+	}
+
+	resp, err := c.rpc.ListServiceAccounts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]ServiceAccount, 0, len(resp.Msg.GetServiceAccounts()))
+	for _, info := range resp.Msg.GetServiceAccounts() {
+		accounts = append(accounts, ServiceAccount{
+			ID:          info.GetId(),
+			ClientID:    info.GetClientId(),
+			Name:        info.GetName(),
+			Description: info.GetDescription(),
+			CreatedAt:   info.GetCreatedAt().AsTime(),
+			LastUsedAt:  info.GetLastUsedAt().AsTime(),
+			Disabled:    info.GetDisabled(),
+		})
+	}
+	return &ServiceAccountsPage{ServiceAccounts: accounts, NextPageToken: resp.Msg.GetNextPageToken()}, nil
+}
@@ -0,0 +1,84 @@
+package sdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	statev1 "github.com/terraconstructs/grid/pkg/api/state/v1"
+	"github.com/terraconstructs/grid/pkg/api/state/v1/statev1connect"
+	"github.com/terraconstructs/grid/pkg/sdk"
+)
+
+type serviceAccountsHandler struct {
+	statev1connect.UnimplementedStateServiceHandler
+	listFunc func(*statev1.ListServiceAccountsRequest) (*statev1.ListServiceAccountsResponse, error)
+}
+
+func (h *serviceAccountsHandler) ListServiceAccounts(_ context.Context, req *connect.Request[statev1.ListServiceAccountsRequest]) (*connect.Response[statev1.ListServiceAccountsResponse], error) {
+	resp, err := h.listFunc(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func TestClient_ListServiceAccountsPaged(t *testing.T) {
+	pages := map[string]*statev1.ListServiceAccountsResponse{
+		"": {
+			ServiceAccounts: []*statev1.ServiceAccountInfo{{Id: "sa-1", Name: "ci-deploy"}, {Id: "sa-2", Name: "ci-plan"}},
+			NextPageToken:   "page-2",
+		},
+		"page-2": {
+			ServiceAccounts: []*statev1.ServiceAccountInfo{{Id: "sa-3", Name: "ci-apply", Disabled: true}},
+		},
+	}
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	disabled := true
+	var requests int
+
+	handler := &serviceAccountsHandler{listFunc: func(req *statev1.ListServiceAccountsRequest) (*statev1.ListServiceAccountsResponse, error) {
+		requests++
+		if req.GetNamePrefix() != "ci-" || !req.GetDisabled() || req.GetCreatedBy() != "user-1" {
+			t.Fatalf("filters not forwarded: %+v", req)
+		}
+		if !req.GetLastUsedBefore().AsTime().Equal(cutoff) {
+			t.Fatalf("expected last_used_before %s, got %s", cutoff, req.GetLastUsedBefore().AsTime())
+		}
+		if req.GetPageSize() != 2 {
+			t.Fatalf("expected page_size 2, got %d", req.GetPageSize())
+		}
+		page, ok := pages[req.GetPageToken()]
+		if !ok {
+			return nil, connect.NewError(connect.CodeInvalidArgument, nil)
+		}
+		return page, nil
+	}}
+
+	mux := http.NewServeMux()
+	path, handlerFunc := statev1connect.NewStateServiceHandler(handler)
+	mux.Handle(path, handlerFunc)
+	client := newSDKClient(mux, "http://example.com")
+
+	opts := sdk.ListServiceAccountsOptions{NamePrefix: "ci-", Disabled: &disabled, CreatedBy: "user-1", LastUsedBefore: &cutoff, PageSize: 2}
+	accounts, err := client.ListServiceAccounts(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListServiceAccounts returned error: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 page requests, got %d", requests)
+	}
+	if len(accounts) != 3 || accounts[0].ID != "sa-1" || !accounts[2].Disabled {
+		t.Fatalf("unexpected accounts across pages: %+v", accounts)
+	}
+
+	page, err := client.ListServiceAccountsPage(context.Background(), opts, "")
+	if err != nil {
+		t.Fatalf("ListServiceAccountsPage returned error: %v", err)
+	}
+	if len(page.ServiceAccounts) != 2 || page.NextPageToken != "page-2" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+}
@@ -24,6 +24,19 @@ type ListStatesOptions struct {
 	Filter        string
 	IncludeLabels *bool
 	IncludeStatus *bool // Whether to compute status for each state (default: true, expensive N+1 operation)
+	// PageSize fetches states in pages of this size (max 1000). Zero fetches
+	// every state in a single response.
+	PageSize int32
+	// LabelSelector filters by labels in Kubernetes selector syntax, e.g.
+	// "env=dev,team in (platform,data)". Selector listings are always paged.
+	LabelSelector string
+}
+
+// StatesPage is one page of ListStatesPage results.
+type StatesPage struct {
+	States []StateSummary
+	// NextPageToken resumes the listing; empty when there are no more states.
+	NextPageToken string
 }
 
 // UpdateStateLabelsInput describes label mutations for UpdateStateLabels.
@@ -87,9 +100,10 @@ func (c *Client) CreateState(ctx context.Context, input CreateStateInput) (*Stat
 	}
 
 	req := connect.NewRequest(&statev1.CreateStateRequest{
-		Guid:    guid,
-		LogicId: input.LogicID,
-		Labels:  protoLabels,
+		Guid:           guid,
+		LogicId:        input.LogicID,
+		Labels:         protoLabels,
+		IdempotencyKey: input.IdempotencyKey,
 	})
 
 	resp, err := c.rpc.CreateState(ctx, req)
@@ -110,8 +124,35 @@ func (c *Client) ListStates(ctx context.Context) ([]StateSummary, error) {
 }
 
 // ListStatesWithOptions returns summaries with optional filter/label projection controls.
+// When opts.PageSize is set, every page is fetched and the results concatenated.
 func (c *Client) ListStatesWithOptions(ctx context.Context, opts ListStatesOptions) ([]StateSummary, error) {
-	req := connect.NewRequest(&statev1.ListStatesRequest{})
+	var summaries []StateSummary
+	pageToken := ""
+	for {
+		page, err := c.ListStatesPage(ctx, opts, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, page.States...)
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	if summaries == nil {
+		summaries = []StateSummary{}
+	}
+	return summaries, nil
+}
+
+// ListStatesPage returns a single page of states of opts.PageSize, resuming
+// after pageToken (empty for the first page).
+func (c *Client) ListStatesPage(ctx context.Context, opts ListStatesOptions, pageToken string) (*StatesPage, error) {
+	req := connect.NewRequest(&statev1.ListStatesRequest{
+		PageSize:      opts.PageSize,
+		PageToken:     pageToken,
+		LabelSelector: opts.LabelSelector,
+	})
 	if opts.Filter != "" {
 		req.Msg.Filter = &opts.Filter
 	}
@@ -131,7 +172,7 @@ func (c *Client) ListStatesWithOptions(ctx context.Context, opts ListStatesOptio
 	for _, info := range resp.Msg.GetStates() {
 		summaries = append(summaries, stateSummaryFromProto(info))
 	}
-	return summaries, nil
+	return &StatesPage{States: summaries, NextPageToken: resp.Msg.GetNextPageToken()}, nil
 }
 
 // GetState retrieves state metadata and backend configuration using either GUID or logic ID.
@@ -224,23 +265,113 @@ func (c *Client) ListStateOutputs(ctx context.Context, ref StateReference) ([]Ou
 	return outputKeysFromProto(resp.Msg.GetOutputs()), nil
 }
 
+// ListStateOutputsBatch returns outputs for several states in one call, in
+// request order. States the caller cannot access, and unknown states, are
+// omitted from the result rather than failing the call. At most 100 states
+// may be requested at once.
+func (c *Client) ListStateOutputsBatch(ctx context.Context, refs []StateReference, opts ListStateOutputsBatchOptions) ([]StateOutputs, error) {
+	req := connect.NewRequest(&statev1.ListStateOutputsBatchRequest{
+		States:        make([]*statev1.StateRef, 0, len(refs)),
+		OutputKeys:    opts.OutputKeys,
+		IncludeValues: opts.IncludeValues,
+	})
+	for _, ref := range refs {
+		if ref.LogicID == "" && ref.GUID == "" {
+			return nil, fmt.Errorf("state reference requires guid or logic ID")
+		}
+		req.Msg.States = append(req.Msg.States, &statev1.StateRef{Guid: ref.GUID, LogicId: ref.LogicID})
+	}
+
+	resp, err := c.rpc.ListStateOutputsBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]StateOutputs, 0, len(resp.Msg.GetStates()))
+	for _, state := range resp.Msg.GetStates() {
+		results = append(results, StateOutputs{
+			State:   StateReference{GUID: state.GetStateGuid(), LogicID: state.GetStateLogicId()},
+			Outputs: outputKeysFromProto(state.GetOutputs()),
+		})
+	}
+	return results, nil
+}
+
+// GetStateOutputValues returns the values of the given outputs of a state, in
+// the order requested; unknown keys are omitted and an empty keys list returns
+// every output. Sensitive values are redacted unless the caller holds
+// state-output:read-sensitive on the state.
+func (c *Client) GetStateOutputValues(ctx context.Context, ref StateReference, keys []string) ([]OutputValue, error) {
+	if ref.LogicID == "" && ref.GUID == "" {
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
+	}
+
+	resp, err := c.rpc.GetStateOutputValues(ctx, connect.NewRequest(&statev1.GetStateOutputValuesRequest{
+		State: &statev1.StateRef{Guid: ref.GUID, LogicId: ref.LogicID},
+		Keys:  keys,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]OutputValue, 0, len(resp.Msg.GetValues()))
+	for _, val := range resp.Msg.GetValues() {
+		values = append(values, OutputValue{
+			Key:       val.GetKey(),
+			Sensitive: val.GetSensitive(),
+			ValueJSON: val.ValueJson,
+			Redacted:  val.GetRedacted(),
+		})
+	}
+	return values, nil
+}
+
 // SetOutputSchema sets or updates the JSON Schema for a specific state output.
 // This allows declaring expected output types before the output exists in state.
+// Validation of an existing output value runs asynchronously on the server.
 func (c *Client) SetOutputSchema(ctx context.Context, ref StateReference, outputKey string, schemaJSON string) error {
+	_, err := c.setOutputSchema(ctx, ref, outputKey, &statev1.SetOutputSchemaRequest{SchemaJson: schemaJSON})
+	return err
+}
+
+// SetOutputSchemaRef sets a state output's schema to the registered schema
+// schemaRef (e.g. "aws/vpc_id@v1"). The output follows later updates to the
+// registered schema.
+func (c *Client) SetOutputSchemaRef(ctx context.Context, ref StateReference, outputKey string, schemaRef string) error {
+	_, err := c.setOutputSchema(ctx, ref, outputKey, &statev1.SetOutputSchemaRequest{SchemaRef: schemaRef})
+	return err
+}
+
+// SetOutputSchemaAndValidate sets the JSON Schema for a state output and
+// validates the output's current value before returning, so callers see the
+// validation result immediately instead of polling ListStateOutputs.
+func (c *Client) SetOutputSchemaAndValidate(ctx context.Context, ref StateReference, outputKey string, schemaJSON string) (*OutputValidation, error) {
+	resp, err := c.setOutputSchema(ctx, ref, outputKey, &statev1.SetOutputSchemaRequest{SchemaJson: schemaJSON, ValidateNow: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutputValidation{
+		Status: resp.ValidationStatus,
+		Error:  resp.ValidationError,
+	}, nil
+}
+
+// setOutputSchema sends msg, which carries the schema (inline or by registry
+// key) and options, for the referenced state output.
+func (c *Client) setOutputSchema(ctx context.Context, ref StateReference, outputKey string, msg *statev1.SetOutputSchemaRequest) (*statev1.SetOutputSchemaResponse, error) {
 	if ref.LogicID == "" && ref.GUID == "" {
-		return fmt.Errorf("state reference requires guid or logic ID")
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
 	}
 	if outputKey == "" {
-		return fmt.Errorf("output key is required")
+		return nil, fmt.Errorf("output key is required")
 	}
-	if schemaJSON == "" {
-		return fmt.Errorf("schema JSON is required")
+	if msg.SchemaJson == "" && msg.SchemaRef == "" {
+		return nil, fmt.Errorf("schema JSON or schema ref is required")
 	}
 
-	req := connect.NewRequest(&statev1.SetOutputSchemaRequest{
-		OutputKey:  outputKey,
-		SchemaJson: schemaJSON,
-	})
+	msg.OutputKey = outputKey
+	req := connect.NewRequest(msg)
 
 	if ref.LogicID != "" {
 		req.Msg.State = &statev1.SetOutputSchemaRequest_StateLogicId{StateLogicId: ref.LogicID}
@@ -248,12 +379,160 @@ func (c *Client) SetOutputSchema(ctx context.Context, ref StateReference, output
 		req.Msg.State = &statev1.SetOutputSchemaRequest_StateGuid{StateGuid: ref.GUID}
 	}
 
-	_, err := c.rpc.SetOutputSchema(ctx, req)
+	resp, err := c.rpc.SetOutputSchema(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// SetOutputSchemas sets JSON Schemas for several outputs of a state in one call.
+// The server validates every schema first and writes them atomically, so either
+// all schemas are stored or none are. Results are sorted by output key.
+func (c *Client) SetOutputSchemas(ctx context.Context, ref StateReference, schemas map[string]string) ([]OutputSchemaResult, error) {
+	if ref.LogicID == "" && ref.GUID == "" {
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
+	}
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("at least one schema is required")
+	}
+
+	req := connect.NewRequest(&statev1.SetOutputSchemasRequest{
+		Schemas: schemas,
+	})
+
+	if ref.LogicID != "" {
+		req.Msg.State = &statev1.SetOutputSchemasRequest_StateLogicId{StateLogicId: ref.LogicID}
+	} else {
+		req.Msg.State = &statev1.SetOutputSchemasRequest_StateGuid{StateGuid: ref.GUID}
+	}
+
+	resp, err := c.rpc.SetOutputSchemas(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]OutputSchemaResult, 0, len(resp.Msg.GetResults()))
+	for _, r := range resp.Msg.GetResults() {
+		results = append(results, OutputSchemaResult{
+			OutputKey: r.GetOutputKey(),
+			Created:   r.GetCreated(),
+		})
+	}
+	return results, nil
+}
+
+// RevalidateOutputsForSchema re-validates every output, across all states,
+// whose schema matches the schema of the given output, for example after
+// tightening a schema that several states reuse. Transitions only list
+// states the caller can see; the counts cover every affected output.
+func (c *Client) RevalidateOutputsForSchema(ctx context.Context, ref StateReference, outputKey string) (*SchemaRevalidation, error) {
+	if ref.LogicID == "" && ref.GUID == "" {
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
+	}
+	if outputKey == "" {
+		return nil, fmt.Errorf("output key is required")
+	}
+
+	req := connect.NewRequest(&statev1.RevalidateOutputsForSchemaRequest{
+		OutputKey: outputKey,
+	})
+
+	if ref.LogicID != "" {
+		req.Msg.State = &statev1.RevalidateOutputsForSchemaRequest_StateLogicId{StateLogicId: ref.LogicID}
+	} else {
+		req.Msg.State = &statev1.RevalidateOutputsForSchemaRequest_StateGuid{StateGuid: ref.GUID}
+	}
+
+	resp, err := c.rpc.RevalidateOutputsForSchema(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaRevalidationFromProto(resp.Msg), nil
+}
+
+// CreateRegisteredSchema registers schemaJSON in the shared schema registry
+// under key, a named version such as "aws/vpc_id@v1".
+func (c *Client) CreateRegisteredSchema(ctx context.Context, key, schemaJSON, description string) (*RegisteredSchema, error) {
+	if key == "" {
+		return nil, fmt.Errorf("schema key is required")
+	}
+	if schemaJSON == "" {
+		return nil, fmt.Errorf("schema JSON is required")
 	}
 
-	return nil
+	resp, err := c.rpc.CreateRegisteredSchema(ctx, connect.NewRequest(&statev1.CreateRegisteredSchemaRequest{
+		Key:         key,
+		SchemaJson:  schemaJSON,
+		Description: description,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return registeredSchemaFromProto(resp.Msg.GetSchema()), nil
+}
+
+// GetRegisteredSchema retrieves a registered schema by key.
+func (c *Client) GetRegisteredSchema(ctx context.Context, key string) (*RegisteredSchema, error) {
+	if key == "" {
+		return nil, fmt.Errorf("schema key is required")
+	}
+
+	resp, err := c.rpc.GetRegisteredSchema(ctx, connect.NewRequest(&statev1.GetRegisteredSchemaRequest{Key: key}))
+	if err != nil {
+		return nil, err
+	}
+	return registeredSchemaFromProto(resp.Msg.GetSchema()), nil
+}
+
+// ListRegisteredSchemas returns every registered schema ordered by key.
+func (c *Client) ListRegisteredSchemas(ctx context.Context) ([]RegisteredSchema, error) {
+	resp, err := c.rpc.ListRegisteredSchemas(ctx, connect.NewRequest(&statev1.ListRegisteredSchemasRequest{}))
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]RegisteredSchema, 0, len(resp.Msg.GetSchemas()))
+	for _, pb := range resp.Msg.GetSchemas() {
+		schemas = append(schemas, *registeredSchemaFromProto(pb))
+	}
+	return schemas, nil
+}
+
+// UpdateRegisteredSchema replaces the schema registered under key. Outputs
+// that reference the key pick up the new schema. The revalidation result is
+// nil unless opts.Revalidate is set.
+func (c *Client) UpdateRegisteredSchema(ctx context.Context, key, schemaJSON string, opts UpdateRegisteredSchemaOptions) (*RegisteredSchema, *SchemaRevalidation, error) {
+	if key == "" {
+		return nil, nil, fmt.Errorf("schema key is required")
+	}
+	if schemaJSON == "" {
+		return nil, nil, fmt.Errorf("schema JSON is required")
+	}
+
+	resp, err := c.rpc.UpdateRegisteredSchema(ctx, connect.NewRequest(&statev1.UpdateRegisteredSchemaRequest{
+		Key:         key,
+		SchemaJson:  schemaJSON,
+		Description: opts.Description,
+		Revalidate:  opts.Revalidate,
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+	return registeredSchemaFromProto(resp.Msg.GetSchema()), schemaRevalidationFromProto(resp.Msg.GetRevalidation()), nil
+}
+
+// DeleteRegisteredSchema removes a registered schema. The server refuses while
+// any output still references it.
+func (c *Client) DeleteRegisteredSchema(ctx context.Context, key string) error {
+	if key == "" {
+		return fmt.Errorf("schema key is required")
+	}
+
+	_, err := c.rpc.DeleteRegisteredSchema(ctx, connect.NewRequest(&statev1.DeleteRegisteredSchemaRequest{Key: key}))
+	return err
 }
 
 // GetOutputSchema retrieves the JSON Schema for a specific state output.
@@ -284,6 +563,106 @@ func (c *Client) GetOutputSchema(ctx context.Context, ref StateReference, output
 	return resp.Msg.GetSchemaJson(), nil
 }
 
+// ListOutputSchemaHistory returns every schema a state output has had, oldest
+// first, and which version the output's last validation ran against.
+func (c *Client) ListOutputSchemaHistory(ctx context.Context, ref StateReference, outputKey string) (*OutputSchemaHistory, error) {
+	if ref.LogicID == "" && ref.GUID == "" {
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
+	}
+	if outputKey == "" {
+		return nil, fmt.Errorf("output key is required")
+	}
+
+	req := connect.NewRequest(&statev1.ListOutputSchemaHistoryRequest{
+		OutputKey: outputKey,
+	})
+
+	if ref.LogicID != "" {
+		req.Msg.State = &statev1.ListOutputSchemaHistoryRequest_StateLogicId{StateLogicId: ref.LogicID}
+	} else {
+		req.Msg.State = &statev1.ListOutputSchemaHistoryRequest_StateGuid{StateGuid: ref.GUID}
+	}
+
+	resp, err := c.rpc.ListOutputSchemaHistory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &OutputSchemaHistory{
+		State:            StateReference{GUID: resp.Msg.GetStateGuid(), LogicID: resp.Msg.GetStateLogicId()},
+		OutputKey:        resp.Msg.GetOutputKey(),
+		ValidationStatus: resp.Msg.GetValidationStatus(),
+	}
+	if resp.Msg.ValidatedSchemaVersion != nil {
+		version := int(resp.Msg.GetValidatedSchemaVersion())
+		history.ValidatedVersion = &version
+	}
+	for _, v := range resp.Msg.GetVersions() {
+		version := OutputSchemaVersion{
+			Version:      int(v.GetVersion()),
+			SchemaJSON:   v.GetSchemaJson(),
+			SchemaSource: v.GetSchemaSource(),
+			SchemaRef:    v.GetSchemaRef(),
+			Active:       v.GetActive(),
+		}
+		if v.CreatedAt != nil {
+			version.CreatedAt = v.CreatedAt.AsTime()
+		}
+		history.Versions = append(history.Versions, version)
+	}
+	return history, nil
+}
+
+// GetStateValidationSummary returns aggregate validation status for a state's outputs.
+// It reports the results of the server's last validation runs and does not
+// trigger validation, so callers can gate on Passed() without listing outputs.
+func (c *Client) GetStateValidationSummary(ctx context.Context, ref StateReference) (*StateValidationSummary, error) {
+	if ref.LogicID == "" && ref.GUID == "" {
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
+	}
+
+	req := connect.NewRequest(&statev1.GetStateValidationSummaryRequest{})
+
+	if ref.LogicID != "" {
+		req.Msg.State = &statev1.GetStateValidationSummaryRequest_StateLogicId{StateLogicId: ref.LogicID}
+	} else {
+		req.Msg.State = &statev1.GetStateValidationSummaryRequest_StateGuid{StateGuid: ref.GUID}
+	}
+
+	resp, err := c.rpc.GetStateValidationSummary(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := resp.Msg
+	summary := &StateValidationSummary{
+		State:        StateReference{GUID: msg.GetStateGuid(), LogicID: msg.GetStateLogicId()},
+		TotalOutputs: int(msg.GetTotalOutputs()),
+		Valid:        int(msg.GetValidCount()),
+		Invalid:      int(msg.GetInvalidCount()),
+		Errored:      int(msg.GetErrorCount()),
+		NotValidated: int(msg.GetNotValidatedCount()),
+		Issues:       make([]OutputValidationIssue, 0, len(msg.GetIssues())),
+	}
+	for _, issue := range msg.GetIssues() {
+		out := OutputValidationIssue{
+			OutputKey: issue.GetOutputKey(),
+			Status:    issue.GetValidationStatus(),
+			Error:     issue.GetValidationError(),
+		}
+		if issue.ValidatedAt != nil {
+			t := issue.ValidatedAt.AsTime()
+			out.ValidatedAt = &t
+		}
+		summary.Issues = append(summary.Issues, out)
+	}
+	if msg.LastValidatedAt != nil {
+		t := msg.LastValidatedAt.AsTime()
+		summary.LastValidatedAt = &t
+	}
+	return summary, nil
+}
+
 // UpdateStateLabels mutates labels on an existing state and returns the updated set.
 func (c *Client) UpdateStateLabels(ctx context.Context, input UpdateStateLabelsInput) (*UpdateStateLabelsResult, error) {
 	if input.StateID == "" {
@@ -312,6 +691,37 @@ func (c *Client) UpdateStateLabels(ctx context.Context, input UpdateStateLabelsI
 	return result, nil
 }
 
+// TransferStateOwnership makes newOwner the owner of the referenced state.
+func (c *Client) TransferStateOwnership(ctx context.Context, ref StateReference, newOwner PrincipalReference) (*TransferStateOwnershipResult, error) {
+	if ref.LogicID == "" && ref.GUID == "" {
+		return nil, fmt.Errorf("state reference requires guid or logic ID")
+	}
+	if newOwner.Type == "" || newOwner.ID == "" {
+		return nil, fmt.Errorf("new owner type and ID are required")
+	}
+
+	req := connect.NewRequest(&statev1.TransferStateOwnershipRequest{
+		NewOwnerType: newOwner.Type,
+		NewOwnerId:   newOwner.ID,
+	})
+	if ref.LogicID != "" {
+		req.Msg.State = &statev1.TransferStateOwnershipRequest_LogicId{LogicId: ref.LogicID}
+	} else {
+		req.Msg.State = &statev1.TransferStateOwnershipRequest_Guid{Guid: ref.GUID}
+	}
+
+	resp, err := c.rpc.TransferStateOwnership(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferStateOwnershipResult{
+		GUID:          resp.Msg.GetGuid(),
+		PreviousOwner: resp.Msg.GetPreviousOwner(),
+		NewOwner:      resp.Msg.GetNewOwner(),
+	}, nil
+}
+
 // GetLabelPolicy retrieves the current label validation policy.
 func (c *Client) GetLabelPolicy(ctx context.Context) (*LabelPolicy, error) {
 	resp, err := c.rpc.GetLabelPolicy(ctx, connect.NewRequest(&statev1.GetLabelPolicyRequest{}))
@@ -395,6 +805,45 @@ func (c *Client) GetStateInfo(ctx context.Context, ref StateReference) (*StateIn
 	return stateInfo, nil
 }
 
+// WatchStateChanges streams state changes until ctx is cancelled. Events
+// arrive on the first channel, which is closed when the stream ends; if it
+// ended for any reason other than ctx being cancelled, the cause is then
+// delivered on the error channel. Callers wanting an uninterrupted feed
+// should reconnect when that happens.
+func (c *Client) WatchStateChanges(ctx context.Context, opts WatchStateChangesOptions) (<-chan StateChangeEvent, <-chan error, error) {
+	req := connect.NewRequest(&statev1.WatchStateChangesRequest{})
+	if opts.LogicID != "" {
+		req.Msg.LogicId = &opts.LogicID
+	}
+	for _, kind := range opts.Kinds {
+		req.Msg.Kinds = append(req.Msg.Kinds, string(kind))
+	}
+
+	stream, err := c.rpc.WatchStateChanges(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan StateChangeEvent)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(events)
+		defer stream.Close()
+		for stream.Receive() {
+			select {
+			case events <- stateChangeEventFromProto(stream.Msg()):
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := stream.Err(); err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+	return events, errs, nil
+}
+
 // GetEffectivePermissions retrieves the effective permissions for a principal.
 // The principal can be identified by prefixing the ID ("user:alice", "sa:deployer")
 // or by explicitly setting PrincipalType. Valid types: "user", "service_account".
@@ -441,6 +890,7 @@ func (c *Client) GetEffectivePermissions(ctx context.Context, input GetEffective
 			EffectiveCreateConstraints: createConstraintsFromProto(resp.Msg.Permissions.EffectiveCreateConstraints),
 			EffectiveImmutableKeys:     resp.Msg.Permissions.EffectiveImmutableKeys,
 		},
+		AuthzModelVersion: resp.Msg.GetAuthzModelVersion(),
 	}, nil
 }
 
@@ -449,6 +899,7 @@ func (c *Client) AssignGroupRole(ctx context.Context, input AssignGroupRoleInput
 	req := connect.NewRequest(&statev1.AssignGroupRoleRequest{
 		GroupName: input.GroupName,
 		RoleName:  input.RoleName,
+		Condition: input.Condition,
 	})
 
 	resp, err := c.rpc.AssignGroupRole(ctx, req)
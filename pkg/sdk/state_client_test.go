@@ -25,6 +25,7 @@ type mockStateServiceHandler struct {
 	updateStateLabelsFunc func(context.Context, *connect.Request[statev1.UpdateStateLabelsRequest]) (*connect.Response[statev1.UpdateStateLabelsResponse], error)
 	getLabelPolicyFunc    func(context.Context, *connect.Request[statev1.GetLabelPolicyRequest]) (*connect.Response[statev1.GetLabelPolicyResponse], error)
 	setLabelPolicyFunc    func(context.Context, *connect.Request[statev1.SetLabelPolicyRequest]) (*connect.Response[statev1.SetLabelPolicyResponse], error)
+	watchStateChangesFunc func(context.Context, *connect.Request[statev1.WatchStateChangesRequest], *connect.ServerStream[statev1.StateChangeEvent]) error
 }
 
 func (m *mockStateServiceHandler) CreateState(ctx context.Context, req *connect.Request[statev1.CreateStateRequest]) (*connect.Response[statev1.CreateStateResponse], error) {
@@ -90,6 +91,13 @@ func (m *mockStateServiceHandler) SetLabelPolicy(ctx context.Context, req *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, nil)
 }
 
+func (m *mockStateServiceHandler) WatchStateChanges(ctx context.Context, req *connect.Request[statev1.WatchStateChangesRequest], stream *connect.ServerStream[statev1.StateChangeEvent]) error {
+	if m.watchStateChangesFunc != nil {
+		return m.watchStateChangesFunc(ctx, req, stream)
+	}
+	return connect.NewError(connect.CodeUnimplemented, nil)
+}
+
 func TestClient_CreateState(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -179,6 +187,40 @@ func TestClient_CreateState(t *testing.T) {
 	}
 }
 
+func TestClient_CreateStateIdempotencyKey(t *testing.T) {
+	const originalGUID = "018e8c5e-7890-7000-8000-123456789abc"
+
+	handler := &mockStateServiceHandler{
+		createStateFunc: func(_ context.Context, req *connect.Request[statev1.CreateStateRequest]) (*connect.Response[statev1.CreateStateResponse], error) {
+			if req.Msg.IdempotencyKey != "deploy-42" {
+				t.Fatalf("expected idempotency_key deploy-42, got %q", req.Msg.IdempotencyKey)
+			}
+			// The server answers a retry with the state from the first call
+			return connect.NewResponse(&statev1.CreateStateResponse{
+				Guid:          originalGUID,
+				LogicId:       req.Msg.LogicId,
+				BackendConfig: &statev1.BackendConfig{Address: "http://localhost:8080/tfstate/" + originalGUID},
+			}), nil
+		},
+	}
+
+	mux := http.NewServeMux()
+	path, handlerFunc := statev1connect.NewStateServiceHandler(handler)
+	mux.Handle(path, handlerFunc)
+
+	client := newSDKClient(mux, "http://example.com")
+	state, err := client.CreateState(context.Background(), sdk.CreateStateInput{
+		LogicID:        "production-us-east",
+		IdempotencyKey: "deploy-42",
+	})
+	if err != nil {
+		t.Fatalf("CreateState() unexpected error: %v", err)
+	}
+	if state.GUID != originalGUID {
+		t.Errorf("CreateState() GUID = %v, want %v", state.GUID, originalGUID)
+	}
+}
+
 func TestClient_ListStates(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -294,6 +336,60 @@ func TestClient_ListStatesWithOptions(t *testing.T) {
 	}
 }
 
+func TestClient_ListStatesWithOptionsPaged(t *testing.T) {
+	pages := map[string]*statev1.ListStatesResponse{
+		"": {
+			States:        []*statev1.StateInfo{{Guid: "guid-1", LogicId: "a"}, {Guid: "guid-2", LogicId: "b"}},
+			NextPageToken: "page-2",
+		},
+		"page-2": {
+			States: []*statev1.StateInfo{{Guid: "guid-3", LogicId: "c"}},
+		},
+	}
+	var requests int
+
+	handler := &mockStateServiceHandler{
+		listStatesFunc: func(_ context.Context, req *connect.Request[statev1.ListStatesRequest]) (*connect.Response[statev1.ListStatesResponse], error) {
+			requests++
+			if req.Msg.PageSize != 2 {
+				t.Fatalf("expected page_size 2, got %d", req.Msg.PageSize)
+			}
+			if req.Msg.LabelSelector != "env=dev" {
+				t.Fatalf("expected label_selector env=dev, got %q", req.Msg.LabelSelector)
+			}
+			page, ok := pages[req.Msg.PageToken]
+			if !ok {
+				return nil, connect.NewError(connect.CodeInvalidArgument, nil)
+			}
+			return connect.NewResponse(page), nil
+		},
+	}
+
+	mux := http.NewServeMux()
+	path, handlerFunc := statev1connect.NewStateServiceHandler(handler)
+	mux.Handle(path, handlerFunc)
+
+	client := newSDKClient(mux, "http://example.com")
+	states, err := client.ListStatesWithOptions(context.Background(), sdk.ListStatesOptions{PageSize: 2, LabelSelector: "env=dev"})
+	if err != nil {
+		t.Fatalf("ListStatesWithOptions returned error: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 page requests, got %d", requests)
+	}
+	if len(states) != 3 || states[0].GUID != "guid-1" || states[2].GUID != "guid-3" {
+		t.Fatalf("unexpected states across pages: %+v", states)
+	}
+
+	page, err := client.ListStatesPage(context.Background(), sdk.ListStatesOptions{PageSize: 2, LabelSelector: "env=dev"}, "")
+	if err != nil {
+		t.Fatalf("ListStatesPage returned error: %v", err)
+	}
+	if len(page.States) != 2 || page.NextPageToken != "page-2" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+}
+
 func TestClient_GetState(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -658,3 +754,65 @@ func TestClient_SetLabelPolicy(t *testing.T) {
 		t.Fatal("expected policy JSON to echo request")
 	}
 }
+
+func TestClient_WatchStateChanges(t *testing.T) {
+	detected := time.Unix(1700000000, 0).UTC()
+
+	handler := &mockStateServiceHandler{
+		watchStateChangesFunc: func(_ context.Context, req *connect.Request[statev1.WatchStateChangesRequest], stream *connect.ServerStream[statev1.StateChangeEvent]) error {
+			if req.Msg.GetLogicId() != "prod-vpc" || len(req.Msg.GetKinds()) != 1 || req.Msg.GetKinds()[0] != "outputs" {
+				return connect.NewError(connect.CodeInvalidArgument, nil)
+			}
+			if err := stream.Send(&statev1.StateChangeEvent{
+				Guid:           "018e8c5e-7890-7000-8000-123456789abc",
+				LogicId:        "prod-vpc",
+				Kind:           "outputs",
+				Serial:         7,
+				ComputedStatus: "clean",
+				ChangedOutputs: []string{"vpc_id"},
+				Labels: map[string]*statev1.LabelValue{
+					"env": {Value: &statev1.LabelValue_StringValue{StringValue: "prod"}},
+				},
+				DetectedAt: timestamppb.New(detected),
+			}); err != nil {
+				return err
+			}
+			return connect.NewError(connect.CodeResourceExhausted, nil)
+		},
+	}
+
+	mux := http.NewServeMux()
+	path, handlerFunc := statev1connect.NewStateServiceHandler(handler)
+	mux.Handle(path, handlerFunc)
+
+	client := newSDKClient(mux, "http://example.com")
+	events, errs, err := client.WatchStateChanges(context.Background(), sdk.WatchStateChangesOptions{
+		LogicID: "prod-vpc",
+		Kinds:   []sdk.StateChangeKind{sdk.StateChangeOutputs},
+	})
+	if err != nil {
+		t.Fatalf("WatchStateChanges() unexpected error: %v", err)
+	}
+
+	var received []sdk.StateChangeEvent
+	for event := range events {
+		received = append(received, event)
+	}
+	if len(received) != 1 {
+		t.Fatalf("WatchStateChanges() received %d events, want 1", len(received))
+	}
+	event := received[0]
+	if event.State.LogicID != "prod-vpc" || event.Kind != sdk.StateChangeOutputs || event.Serial != 7 {
+		t.Errorf("WatchStateChanges() event = %+v", event)
+	}
+	if len(event.ChangedOutputs) != 1 || event.ChangedOutputs[0] != "vpc_id" || event.Labels["env"] != "prod" {
+		t.Errorf("WatchStateChanges() event payload = %+v", event)
+	}
+	if !event.DetectedAt.Equal(detected) {
+		t.Errorf("WatchStateChanges() DetectedAt = %v, want %v", event.DetectedAt, detected)
+	}
+
+	if err := <-errs; connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("WatchStateChanges() stream error = %v, want resource_exhausted", err)
+	}
+}
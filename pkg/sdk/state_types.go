@@ -38,6 +38,7 @@ type StateSummary struct {
 	ComputedStatus     string
 	DependencyLogicIDs []string
 	Labels             LabelMap
+	CreatedBy          string // Principal that created the state, when recorded
 
 	// Count fields populated from backend (efficient for list rendering)
 	DependenciesCount int32
@@ -64,14 +65,16 @@ type StateLock struct {
 
 // DependencyEdge represents a dependency relationship between producer and consumer states.
 type DependencyEdge struct {
-	ID             int64
-	From           StateReference
-	FromOutput     string
-	To             StateReference
-	ToInputName    string
-	Status         string
-	InDigest       string
-	OutDigest      string
+	ID          int64
+	From        StateReference
+	FromOutput  string
+	To          StateReference
+	ToInputName string
+	Status      string
+	InDigest    string
+	OutDigest   string
+	// Current is true when the consumer has observed the producer's latest output.
+	Current        bool
 	MockValueJSON  string
 	LastProducedAt *time.Time
 	LastConsumedAt *time.Time
@@ -79,10 +82,18 @@ type DependencyEdge struct {
 	UpdatedAt      time.Time
 }
 
+// EdgesPage is one page of ListEdgesPage results.
+type EdgesPage struct {
+	Edges []DependencyEdge
+	// NextPageToken resumes the listing; empty when there are no more edges.
+	NextPageToken string
+}
+
 // ProducerState describes a unique producer within a dependency graph.
 type ProducerState struct {
 	State         StateReference
 	BackendConfig BackendConfig
+	Depth         int // Hops from the consumer; 1 for direct producers
 }
 
 // DependencyGraph captures the full dependency topology for a consumer state.
@@ -90,6 +101,27 @@ type DependencyGraph struct {
 	Consumer  StateReference
 	Producers []ProducerState
 	Edges     []DependencyEdge
+	MaxDepth  int  // Depth limit the server applied
+	Truncated bool // Producers exist beyond MaxDepth
+	// CycleEdgeIDs lists edges closing a dependency cycle
+	CycleEdgeIDs []int64
+}
+
+// DependencyRecompute reports the result of RecomputeDependencyStatus.
+type DependencyRecompute struct {
+	Producer       StateReference
+	Edges          []DependencyEdge // Recomputed edges, with their new status
+	ChangedEdgeIDs []int64          // Edges whose status or digest changed
+	// SkippedEdges counts edges left alone because their consumer is outside
+	// the caller's role scopes
+	SkippedEdges int
+}
+
+// DependencyGraphOptions configures GetDependencyGraphWithOptions.
+type DependencyGraphOptions struct {
+	// MaxDepth is how many producer hops to follow; zero returns direct
+	// producers only. The server caps it at 32.
+	MaxDepth int32
 }
 
 // TopologyLayer represents a level within a topological ordering of states.
@@ -108,12 +140,14 @@ type StatusSummary struct {
 
 // IncomingEdge conveys details about an incoming producer edge when computing status.
 type IncomingEdge struct {
-	ID             int64
-	From           StateReference
-	FromOutput     string
-	Status         string
-	InDigest       string
-	OutDigest      string
+	ID         int64
+	From       StateReference
+	FromOutput string
+	Status     string
+	InDigest   string
+	OutDigest  string
+	// Current is true when the consumer has observed the producer's latest output.
+	Current        bool
 	LastProducedAt *time.Time
 	LastConsumedAt *time.Time
 }
@@ -146,6 +180,9 @@ type CreateStateInput struct {
 	GUID    string
 	LogicID string
 	Labels  LabelMap
+	// IdempotencyKey makes retries safe: repeating a create with the same key,
+	// logic ID and labels returns the originally created state.
+	IdempotencyKey string
 }
 
 // TopologyDirection indicates the traversal direction for topological ordering.
@@ -166,13 +203,135 @@ type TopologyInput struct {
 
 // OutputKey represents a Terraform output name and metadata.
 type OutputKey struct {
-	Key              string
-	Sensitive        bool
-	SchemaJSON       *string    // Optional JSON Schema definition for this output
-	SchemaSource     *string    // Schema source: "manual" or "inferred"
-	ValidationStatus *string    // Validation status: "valid", "invalid", or "error"
-	ValidationError  *string    // Validation error message (if validation failed)
-	ValidatedAt      *time.Time // Last validation timestamp
+	Key                    string
+	Sensitive              bool
+	SchemaJSON             *string    // Optional JSON Schema definition for this output
+	SchemaSource           *string    // Schema source: "manual" or "inferred"
+	SchemaRef              *string    // Schema registry key the schema was set from, if any
+	SchemaVersion          *int       // Version of the current schema in the output's schema history
+	ValidationStatus       *string    // Validation status: "valid", "invalid", or "error"
+	ValidationError        *string    // Validation error message (if validation failed)
+	ValidatedAt            *time.Time // Last validation timestamp
+	ValidatedSchemaVersion *int       // Schema version the last validation ran against
+	ValueJSON              *string    // JSON-encoded value; set only by ListStateOutputsBatch with IncludeValues, never for sensitive outputs
+}
+
+// ListStateOutputsBatchOptions configures ListStateOutputsBatch.
+type ListStateOutputsBatchOptions struct {
+	// OutputKeys restricts results to these outputs. Empty returns every output.
+	OutputKeys []string
+	// IncludeValues returns non-sensitive output values (requires state-output:read).
+	IncludeValues bool
+}
+
+// StateOutputs lists the outputs of one state returned by ListStateOutputsBatch.
+type StateOutputs struct {
+	State   StateReference
+	Outputs []OutputKey
+}
+
+// OutputValue is a Terraform output value returned by GetStateOutputValues.
+type OutputValue struct {
+	Key       string
+	Sensitive bool
+	ValueJSON *string // JSON-encoded value; nil when Redacted
+	Redacted  bool    // Sensitive output the caller lacks state-output:read-sensitive for
+}
+
+// OutputValidation reports the result of validating an output against its schema.
+type OutputValidation struct {
+	Status *string // "valid", "invalid", or "error"; nil if the output has no value yet
+	Error  *string // Validation error message (if validation failed)
+}
+
+// OutputSchemaResult reports how one schema in a bulk SetOutputSchemas call was stored.
+type OutputSchemaResult struct {
+	OutputKey string
+	Created   bool // true if the output had no schema before, false if one was replaced
+}
+
+// OutputValidationTransition is an output whose validation status changed on re-validation.
+type OutputValidationTransition struct {
+	State          StateReference
+	OutputKey      string
+	PreviousStatus string // Empty if the output had not been validated
+	Status         string
+}
+
+// SchemaRevalidation reports the outcome of RevalidateOutputsForSchema.
+type SchemaRevalidation struct {
+	Revalidated   int // Outputs with a value that were validated again
+	BecameInvalid int // Outputs that moved from valid to invalid
+	BecameValid   int // Outputs that moved from invalid to valid
+	Transitions   []OutputValidationTransition
+}
+
+// OutputSchemaVersion is one schema a state output has had.
+type OutputSchemaVersion struct {
+	Version      int
+	SchemaJSON   string
+	SchemaSource string // "manual" or "inferred"
+	SchemaRef    string // Schema registry key, empty for inline schemas
+	CreatedAt    time.Time
+	Active       bool // True for the version currently set on the output
+}
+
+// OutputSchemaHistory reports the schema versions of a state output.
+type OutputSchemaHistory struct {
+	State     StateReference
+	OutputKey string
+	Versions  []OutputSchemaVersion // Oldest first
+
+	// ValidatedVersion is the version the last validation ran against (nil if
+	// validation has not run) and ValidationStatus its result.
+	ValidatedVersion *int
+	ValidationStatus string
+}
+
+// RegisteredSchema is a JSON Schema shared across outputs under a named
+// version such as "aws/vpc_id@v1".
+type RegisteredSchema struct {
+	Key         string
+	SchemaJSON  string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// UpdateRegisteredSchemaOptions configures UpdateRegisteredSchema.
+type UpdateRegisteredSchemaOptions struct {
+	// Description replaces the entry's description. Nil keeps the current one.
+	Description *string
+	// Revalidate validates every output using the schema against the new
+	// version before returning.
+	Revalidate bool
+}
+
+// OutputValidationIssue describes an output whose last validation did not pass.
+type OutputValidationIssue struct {
+	OutputKey   string
+	Status      string // "invalid" or "error"
+	Error       string
+	ValidatedAt *time.Time
+}
+
+// StateValidationSummary aggregates the stored validation results of a state's outputs.
+type StateValidationSummary struct {
+	State        StateReference
+	TotalOutputs int
+	Valid        int
+	Invalid      int
+	Errored      int
+	NotValidated int // includes outputs with no recorded status
+	// Issues lists every invalid or errored output, sorted by output key.
+	Issues []OutputValidationIssue
+	// LastValidatedAt is the newest validation timestamp across outputs, nil if none ran.
+	LastValidatedAt *time.Time
+}
+
+// Passed reports whether no output is invalid or failed to validate.
+func (s *StateValidationSummary) Passed() bool {
+	return s.Invalid == 0 && s.Errored == 0
 }
 
 // StateInfo provides comprehensive information about a state including dependencies, dependents, and outputs.
@@ -204,6 +363,19 @@ type UpdateStateLabelsResult struct {
 	UpdatedAt     time.Time
 }
 
+// PrincipalReference identifies a user (by subject) or service account (by client ID).
+type PrincipalReference struct {
+	Type string // "user" or "service_account"
+	ID   string
+}
+
+// TransferStateOwnershipResult reports a state's owner before and after a transfer.
+type TransferStateOwnershipResult struct {
+	GUID          string
+	PreviousOwner string // Empty if the state had no recorded owner
+	NewOwner      string // Principal ID, e.g. "user:alice"
+}
+
 // CreateConstraint defines a constraint for a label key.
 type CreateConstraint struct {
 	AllowedValues []string
@@ -233,12 +405,18 @@ type EffectivePermissions struct {
 // GetEffectivePermissionsResult is the result of GetEffectivePermissions.
 type GetEffectivePermissionsResult struct {
 	Permissions *EffectivePermissions
+	// AuthzModelVersion changes whenever the server's roles, role assignments or
+	// group mappings change. Cached permissions are stale once it differs.
+	AuthzModelVersion uint64
 }
 
 // AssignGroupRoleInput describes the parameters for AssignGroupRole.
 type AssignGroupRoleInput struct {
 	GroupName string
 	RoleName  string
+	// Condition optionally restricts the mapping to group members whose token
+	// claims match this go-bexpr expression (e.g., `department == "finance"`).
+	Condition string
 }
 
 // AssignGroupRoleResult is the result of AssignGroupRole.
@@ -299,6 +477,36 @@ type ImportRolesResult struct {
 	Errors        []string
 }
 
+// StateChangeKind names what changed in a StateChangeEvent.
+type StateChangeKind string
+
+// Change kinds reported by WatchStateChanges.
+const (
+	StateChangeSerial  StateChangeKind = "serial"
+	StateChangeLabels  StateChangeKind = "labels"
+	StateChangeOutputs StateChangeKind = "outputs"
+	StateChangeStatus  StateChangeKind = "status"
+)
+
+// WatchStateChangesOptions narrows WatchStateChanges. The zero value watches
+// every change of every visible state.
+type WatchStateChangesOptions struct {
+	LogicID string            // Watch a single state
+	Kinds   []StateChangeKind // Empty reports all kinds
+}
+
+// StateChangeEvent is one change reported by WatchStateChanges, carrying the
+// state's values after the change.
+type StateChangeEvent struct {
+	State          StateReference
+	Kind           StateChangeKind
+	Serial         int64
+	Labels         LabelMap
+	ComputedStatus string
+	ChangedOutputs []string // Set for StateChangeOutputs
+	DetectedAt     time.Time
+}
+
 // helper conversions from proto messages ----------------------------------------------------
 
 func backendConfigFromProto(pb *statev1.BackendConfig) BackendConfig {
@@ -323,6 +531,7 @@ func stateSummaryFromProto(info *statev1.StateInfo) StateSummary {
 		SizeBytes:          info.GetSizeBytes(),
 		DependencyLogicIDs: append([]string(nil), info.DependencyLogicIds...),
 		Labels:             ConvertProtoLabels(info.GetLabels()),
+		CreatedBy:          info.GetCreatedBy(),
 		DependenciesCount:  info.GetDependenciesCount(),
 		DependentsCount:    info.GetDependentsCount(),
 		OutputsCount:       info.GetOutputsCount(),
@@ -371,6 +580,44 @@ func stateLockFromProto(lock *statev1.StateLock) StateLock {
 	}
 }
 
+func registeredSchemaFromProto(pb *statev1.RegisteredSchema) *RegisteredSchema {
+	if pb == nil {
+		return nil
+	}
+	schema := &RegisteredSchema{
+		Key:         pb.GetKey(),
+		SchemaJSON:  pb.GetSchemaJson(),
+		Description: pb.GetDescription(),
+	}
+	if pb.CreatedAt != nil {
+		schema.CreatedAt = pb.CreatedAt.AsTime()
+	}
+	if pb.UpdatedAt != nil {
+		schema.UpdatedAt = pb.UpdatedAt.AsTime()
+	}
+	return schema
+}
+
+func schemaRevalidationFromProto(pb *statev1.RevalidateOutputsForSchemaResponse) *SchemaRevalidation {
+	if pb == nil {
+		return nil
+	}
+	result := &SchemaRevalidation{
+		Revalidated:   int(pb.GetRevalidated()),
+		BecameInvalid: int(pb.GetBecameInvalid()),
+		BecameValid:   int(pb.GetBecameValid()),
+	}
+	for _, t := range pb.GetTransitions() {
+		result.Transitions = append(result.Transitions, OutputValidationTransition{
+			State:          StateReference{GUID: t.GetStateGuid(), LogicID: t.GetStateLogicId()},
+			OutputKey:      t.GetOutputKey(),
+			PreviousStatus: t.GetPreviousStatus(),
+			Status:         t.GetStatus(),
+		})
+	}
+	return result
+}
+
 func labelPolicyFromProto(pb *statev1.GetLabelPolicyResponse) *LabelPolicy {
 	if pb == nil {
 		return nil
@@ -404,6 +651,19 @@ func outputKeyFromProto(pb *statev1.OutputKey) OutputKey {
 	if pb.SchemaSource != nil && *pb.SchemaSource != "" {
 		out.SchemaSource = pb.SchemaSource
 	}
+	// Include schema registry key if available
+	if pb.SchemaRef != nil && *pb.SchemaRef != "" {
+		out.SchemaRef = pb.SchemaRef
+	}
+	// Include schema history versions if available
+	if pb.SchemaVersion != nil {
+		version := int(pb.GetSchemaVersion())
+		out.SchemaVersion = &version
+	}
+	if pb.ValidatedSchemaVersion != nil {
+		version := int(pb.GetValidatedSchemaVersion())
+		out.ValidatedSchemaVersion = &version
+	}
 	// Include validation status if available
 	if pb.ValidationStatus != nil && *pb.ValidationStatus != "" {
 		out.ValidationStatus = pb.ValidationStatus
@@ -417,6 +677,9 @@ func outputKeyFromProto(pb *statev1.OutputKey) OutputKey {
 		t := pb.ValidatedAt.AsTime()
 		out.ValidatedAt = &t
 	}
+	if pb.ValueJson != nil {
+		out.ValueJSON = pb.ValueJson
+	}
 	return out
 }
 
@@ -444,3 +707,18 @@ func createConstraintsFromProto(pb *statev1.CreateConstraints) *CreateConstraint
 	}
 	return &CreateConstraints{Constraints: constraints}
 }
+
+func stateChangeEventFromProto(pb *statev1.StateChangeEvent) StateChangeEvent {
+	event := StateChangeEvent{
+		State:          StateReference{GUID: pb.GetGuid(), LogicID: pb.GetLogicId()},
+		Kind:           StateChangeKind(pb.GetKind()),
+		Serial:         pb.GetSerial(),
+		Labels:         ConvertProtoLabels(pb.GetLabels()),
+		ComputedStatus: pb.GetComputedStatus(),
+		ChangedOutputs: append([]string(nil), pb.GetChangedOutputs()...),
+	}
+	if pb.DetectedAt != nil {
+		event.DetectedAt = pb.DetectedAt.AsTime()
+	}
+	return event
+}
@@ -615,3 +615,63 @@ func TestSchemaInferenceRunsOnce(t *testing.T) {
 	require.NotNil(t, vpcOutput2.SchemaSource)
 	assert.Equal(t, "inferred", *vpcOutput2.SchemaSource)
 }
+
+// TestSchemaInferenceNeverReplacesManualValidation tests that inference after a
+// state upload leaves a manual schema and its validation result untouched.
+func TestSchemaInferenceNeverReplacesManualValidation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	client := newSDKClient()
+
+	state, err := client.CreateState(ctx, sdk.CreateStateInput{LogicID: uniqueLogicID("test-inference-manual-validation")})
+	require.NoError(t, err)
+	ref := sdk.StateReference{GUID: state.GUID}
+
+	// Strict manual schema that the uploaded vpc_id violates
+	schemaBytes, err := os.ReadFile(filepath.Join("testdata", "schema_pattern_strict.json"))
+	require.NoError(t, err)
+	manualSchema := string(schemaBytes)
+
+	err = client.SetOutputSchema(ctx, ref, "vpc_id", manualSchema)
+	require.NoError(t, err)
+
+	stateBytes, err := os.ReadFile(filepath.Join("testdata", "tfstate_invalid_pattern.json"))
+	require.NoError(t, err)
+	err = uploadTerraformState(state.GUID, stateBytes)
+	require.NoError(t, err)
+
+	findVPC := func() sdk.OutputKey {
+		outputs, err := client.ListStateOutputs(ctx, ref)
+		require.NoError(t, err)
+		for _, out := range outputs {
+			if out.Key == "vpc_id" {
+				return out
+			}
+		}
+		t.Fatal("vpc_id output should exist")
+		return sdk.OutputKey{}
+	}
+
+	// Wait for async inference to have had its chance to run
+	time.Sleep(500 * time.Millisecond)
+	before := findVPC()
+	require.NotNil(t, before.SchemaSource)
+	assert.Equal(t, "manual", *before.SchemaSource)
+	require.NotNil(t, before.SchemaJSON)
+	assert.JSONEq(t, manualSchema, *before.SchemaJSON)
+	require.NotNil(t, before.ValidationStatus)
+	assert.Equal(t, "invalid", *before.ValidationStatus)
+	require.NotNil(t, before.ValidationError)
+
+	// Nothing changes once inference settles
+	time.Sleep(500 * time.Millisecond)
+	after := findVPC()
+	require.NotNil(t, after.SchemaSource)
+	assert.Equal(t, "manual", *after.SchemaSource)
+	assert.JSONEq(t, manualSchema, *after.SchemaJSON)
+	assert.Equal(t, *before.ValidationStatus, *after.ValidationStatus)
+	assert.Equal(t, *before.ValidationError, *after.ValidationError)
+}
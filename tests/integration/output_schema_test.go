@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -460,6 +461,15 @@ func TestInvalidSchemaRejection(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid JSON Schema", "Error should mention invalid schema")
 	})
 
+	t.Run("OversizedSchema", func(t *testing.T) {
+		// Schemas above the server's size limit are rejected before compiling
+		oversizedSchema := `{"type": "string", "description": "` + strings.Repeat("x", 128*1024) + `"}`
+
+		err := client.SetOutputSchema(ctx, sdk.StateReference{GUID: state.GUID}, "output5", oversizedSchema)
+		require.Error(t, err, "Should reject oversized schema")
+		assert.Contains(t, err.Error(), "exceeds the maximum", "Error should mention the size limit")
+	})
+
 	t.Run("ValidSchemaStillWorks", func(t *testing.T) {
 		// Verify that valid schemas still work after rejecting invalid ones
 		validSchema := `{"type": "string", "pattern": "^vpc-[a-z0-9]+$"}`
@@ -493,3 +503,57 @@ func TestInvalidSchemaRejection(t *testing.T) {
 		}
 	})
 }
+
+// TestBulkSchemaOperations tests setting many output schemas in one call,
+// including per-key created/updated results and rollback on invalid input.
+func TestBulkSchemaOperations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	client := newSDKClient()
+
+	state, err := client.CreateState(ctx, sdk.CreateStateInput{LogicID: uniqueLogicID("test-bulk-schemas")})
+	require.NoError(t, err)
+	ref := sdk.StateReference{GUID: state.GUID}
+
+	vpcSchema := `{"type": "string", "pattern": "^vpc-[a-z0-9]+$"}`
+	err = client.SetOutputSchema(ctx, ref, "vpc_id", vpcSchema)
+	require.NoError(t, err)
+
+	subnetSchema := `{"type": "array", "items": {"type": "string"}}`
+	vpcSchemaV2 := `{"type": "string", "pattern": "^vpc-[a-f0-9]{8,17}$"}`
+	results, err := client.SetOutputSchemas(ctx, ref, map[string]string{
+		"vpc_id":     vpcSchemaV2,
+		"subnet_ids": subnetSchema,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []sdk.OutputSchemaResult{
+		{OutputKey: "subnet_ids", Created: true},
+		{OutputKey: "vpc_id", Created: false},
+	}, results)
+
+	retrieved, err := client.GetOutputSchema(ctx, ref, "vpc_id")
+	require.NoError(t, err)
+	assert.JSONEq(t, vpcSchemaV2, retrieved)
+
+	t.Run("InvalidSchemaRollsBack", func(t *testing.T) {
+		_, err := client.SetOutputSchemas(ctx, ref, map[string]string{
+			"vpc_id":    vpcSchema,
+			"region":    `{"type": "string"}`,
+			"bad_field": `{"type": "invalid_type"}`,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid JSON Schema for output bad_field")
+
+		// Nothing from the failed batch was written
+		retrieved, err := client.GetOutputSchema(ctx, ref, "vpc_id")
+		require.NoError(t, err)
+		assert.JSONEq(t, vpcSchemaV2, retrieved)
+
+		region, err := client.GetOutputSchema(ctx, ref, "region")
+		require.NoError(t, err)
+		assert.Empty(t, region)
+	})
+}
@@ -641,6 +641,42 @@ func TestValidationWithInferredSchema(t *testing.T) {
 	assert.Nil(t, inferredOutput.ValidationError)
 }
 
+// TestValidationWithInferredCollectionSchemas tests that schemas inferred for
+// maps, tuples, lists of objects and empty lists validate the state they were
+// inferred from.
+func TestValidationWithInferredCollectionSchemas(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	client := newSDKClient()
+
+	state, err := client.CreateState(ctx, sdk.CreateStateInput{LogicID: uniqueLogicID("test-validation-inferred-collections")})
+	require.NoError(t, err)
+
+	stateBytes, err := os.ReadFile(filepath.Join("testdata", "tfstate_collection_types.json"))
+	require.NoError(t, err)
+
+	// First upload infers the schemas, the second validates against them
+	require.NoError(t, uploadTerraformState(state.GUID, stateBytes))
+	time.Sleep(500 * time.Millisecond)
+	require.NoError(t, uploadTerraformState(state.GUID, stateBytes))
+	time.Sleep(100 * time.Millisecond)
+
+	outputs, err := client.ListStateOutputs(ctx, sdk.StateReference{GUID: state.GUID})
+	require.NoError(t, err)
+	require.Len(t, outputs, 4)
+
+	for _, output := range outputs {
+		require.NotNil(t, output.SchemaSource, "output %s should have an inferred schema", output.Key)
+		assert.Equal(t, "inferred", *output.SchemaSource)
+		require.NotNil(t, output.ValidationStatus, "output %s should be validated", output.Key)
+		assert.Equal(t, "valid", *output.ValidationStatus,
+			"output %s should validate against its inferred schema", output.Key)
+	}
+}
+
 // TestValidationErrorWithArrayItemViolation tests that validation errors include array index in path.
 // Validates FR-035 for array validation errors.
 func TestValidationErrorWithArrayItemViolation(t *testing.T) {
@@ -963,3 +999,114 @@ func TestSetOutputSchemaTriggersValidation(t *testing.T) {
 		assert.Greater(t, len(*vpcOutput2.ValidationError), 0, "ValidationError message should not be empty")
 	})
 }
+
+// TestSetOutputSchemaValidateNow tests that SetOutputSchemaAndValidate returns the
+// validation result for an existing output without waiting for background validation.
+func TestSetOutputSchemaValidateNow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	client := newSDKClient()
+
+	schemaBytes, err := os.ReadFile(filepath.Join("testdata", "schema_pattern_strict.json"))
+	require.NoError(t, err)
+
+	t.Run("invalid value reported inline", func(t *testing.T) {
+		state, err := client.CreateState(ctx, sdk.CreateStateInput{LogicID: uniqueLogicID("test-validate-now-invalid")})
+		require.NoError(t, err)
+
+		invalidStateBytes, err := os.ReadFile(filepath.Join("testdata", "tfstate_invalid_pattern.json"))
+		require.NoError(t, err)
+		require.NoError(t, uploadTerraformState(state.GUID, invalidStateBytes))
+
+		result, err := client.SetOutputSchemaAndValidate(ctx, sdk.StateReference{GUID: state.GUID}, "vpc_id", string(schemaBytes))
+		require.NoError(t, err)
+		require.NotNil(t, result.Status, "Status should be set for an output with a value")
+		assert.Equal(t, "invalid", *result.Status)
+		require.NotNil(t, result.Error)
+		assert.NotEmpty(t, *result.Error)
+
+		// The stored status matches the response with no wait
+		outputs, err := client.ListStateOutputs(ctx, sdk.StateReference{GUID: state.GUID})
+		require.NoError(t, err)
+		for _, out := range outputs {
+			if out.Key == "vpc_id" {
+				require.NotNil(t, out.ValidationStatus)
+				assert.Equal(t, "invalid", *out.ValidationStatus)
+			}
+		}
+	})
+
+	t.Run("valid value reported inline", func(t *testing.T) {
+		state, err := client.CreateState(ctx, sdk.CreateStateInput{LogicID: uniqueLogicID("test-validate-now-valid")})
+		require.NoError(t, err)
+
+		validStateBytes, err := os.ReadFile(filepath.Join("testdata", "tfstate_valid_pattern.json"))
+		require.NoError(t, err)
+		require.NoError(t, uploadTerraformState(state.GUID, validStateBytes))
+
+		result, err := client.SetOutputSchemaAndValidate(ctx, sdk.StateReference{GUID: state.GUID}, "vpc_id", string(schemaBytes))
+		require.NoError(t, err)
+		require.NotNil(t, result.Status)
+		assert.Equal(t, "valid", *result.Status)
+		assert.Nil(t, result.Error)
+	})
+
+	t.Run("pre-declared schema has no status", func(t *testing.T) {
+		state, err := client.CreateState(ctx, sdk.CreateStateInput{LogicID: uniqueLogicID("test-validate-now-predeclared")})
+		require.NoError(t, err)
+
+		result, err := client.SetOutputSchemaAndValidate(ctx, sdk.StateReference{GUID: state.GUID}, "vpc_id", string(schemaBytes))
+		require.NoError(t, err)
+		assert.Nil(t, result.Status, "Status should be unset when the output has no value")
+	})
+}
+
+// TestValidationSummary tests that GetStateValidationSummary aggregates stored
+// validation results without the client scanning outputs.
+func TestValidationSummary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	client := newSDKClient()
+
+	state, err := client.CreateState(ctx, sdk.CreateStateInput{LogicID: uniqueLogicID("test-validation-summary")})
+	require.NoError(t, err)
+	ref := sdk.StateReference{GUID: state.GUID}
+
+	// Nothing uploaded yet: summary is empty and passes
+	summary, err := client.GetStateValidationSummary(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, 0, summary.TotalOutputs)
+	assert.True(t, summary.Passed())
+	assert.Nil(t, summary.LastValidatedAt)
+
+	schemaBytes, err := os.ReadFile(filepath.Join("testdata", "schema_pattern_strict.json"))
+	require.NoError(t, err)
+	err = client.SetOutputSchema(ctx, ref, "vpc_id", string(schemaBytes))
+	require.NoError(t, err)
+
+	invalidStateBytes, err := os.ReadFile(filepath.Join("testdata", "tfstate_invalid_pattern.json"))
+	require.NoError(t, err)
+	err = uploadTerraformState(state.GUID, invalidStateBytes)
+	require.NoError(t, err)
+
+	// Wait for validation
+	time.Sleep(100 * time.Millisecond)
+
+	summary, err = client.GetStateValidationSummary(ctx, sdk.StateReference{LogicID: state.LogicID})
+	require.NoError(t, err)
+	assert.Equal(t, state.GUID, summary.State.GUID)
+	assert.False(t, summary.Passed(), "invalid vpc_id should fail the summary")
+	assert.Equal(t, 1, summary.Invalid)
+	require.Len(t, summary.Issues, 1)
+	assert.Equal(t, "vpc_id", summary.Issues[0].OutputKey)
+	assert.Equal(t, "invalid", summary.Issues[0].Status)
+	assert.Contains(t, summary.Issues[0].Error, "pattern")
+	require.NotNil(t, summary.LastValidatedAt)
+	assert.Equal(t, summary.TotalOutputs, summary.Valid+summary.Invalid+summary.Errored+summary.NotValidated)
+}
@@ -109,6 +109,23 @@ export interface LoginResponse {
   expiresAt: number;
 }
 
+/**
+ * One action held through a role, from /api/auth/whoami?include=permissions
+ */
+export interface EffectivePermission {
+  /** Role granting (or denying) the action */
+  role: string;
+
+  /** Action in "obj:act" format; deny rules are prefixed with "!" */
+  action: string;
+
+  /** Label scope expression the action applies under */
+  scope_expr: string;
+
+  /** "allow" or "deny" */
+  effect: 'allow' | 'deny';
+}
+
 /**
  * Response from /api/auth/whoami endpoint
  */
@@ -118,4 +135,7 @@ export interface WhoamiResponse {
 
   /** Session expiration timestamp (Unix milliseconds) */
   expiresAt: number;
+
+  /** Effective permissions, only present with ?include=permissions */
+  permissions?: EffectivePermission[];
 }